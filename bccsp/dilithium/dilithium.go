@@ -0,0 +1,374 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package dilithium implements the CRYSTALS-Dilithium post-quantum signature
+// scheme, as submitted to the third round of the NIST standardization, with
+// the parameters of Dilithium2. Signing is deterministic.
+//
+// The implementation is experimental: it passes the known answer tests of the
+// submission, but is not constant time.
+package dilithium
+
+import (
+	"crypto/subtle"
+	"io"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/sha3"
+)
+
+// PublicKey is a Dilithium public key
+type PublicKey struct {
+	rho [seedBytes]byte
+	t1  [k]poly
+
+	// packed is the encoding of the key
+	packed []byte
+	// a is the matrix expanded from rho, in the NTT domain
+	a [k][l]poly
+}
+
+// PrivateKey is a Dilithium private key
+type PrivateKey struct {
+	PublicKey
+
+	key [seedBytes]byte
+	tr  [seedBytes]byte
+	s1  [l]poly
+	s2  [k]poly
+	t0  [k]poly
+}
+
+// Public returns the public key of the private key
+func (sk *PrivateKey) Public() *PublicKey {
+	return &sk.PublicKey
+}
+
+func expandA(a *[k][l]poly, rho []byte) {
+	for i := range a {
+		for j := range a[i] {
+			a[i][j].uniform(rho, uint16(i<<8+j))
+		}
+	}
+}
+
+// mulA multiplies the matrix by the vector, both in the NTT domain
+func mulA(a *[k][l]poly, v *[l]poly) [k]poly {
+	var r [k]poly
+	var t poly
+	for i := range a {
+		for j := range a[i] {
+			t.pointwise(&a[i][j], &v[j])
+			r[i].add(&r[i], &t)
+		}
+	}
+	return r
+}
+
+func shake256(out []byte, in ...[]byte) {
+	h := sha3.NewShake256()
+	for _, b := range in {
+		h.Write(b)
+	}
+	h.Read(out)
+}
+
+// GenerateKey generates a key pair with the randomness of rand
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	var seed [seedBytes]byte
+	if _, err := io.ReadFull(rand, seed[:]); err != nil {
+		return nil, errors.Wrap(err, "failed reading randomness")
+	}
+	return NewKeyFromSeed(seed[:]), nil
+}
+
+// NewKeyFromSeed derives a key pair from a seed of 32 bytes
+func NewKeyFromSeed(seed []byte) *PrivateKey {
+	var buf [2*seedBytes + crhBytes]byte
+	shake256(buf[:], seed)
+	sk := &PrivateKey{}
+	copy(sk.rho[:], buf[:seedBytes])
+	rhoPrime := buf[seedBytes : seedBytes+crhBytes]
+	copy(sk.key[:], buf[seedBytes+crhBytes:])
+
+	expandA(&sk.a, sk.rho[:])
+	for i := range sk.s1 {
+		sk.s1[i].uniformEta(rhoPrime, uint16(i))
+	}
+	for i := range sk.s2 {
+		sk.s2[i].uniformEta(rhoPrime, uint16(l+i))
+	}
+
+	s1Hat := sk.s1
+	for i := range s1Hat {
+		s1Hat[i].ntt()
+	}
+	t := mulA(&sk.a, &s1Hat)
+	for i := range t {
+		t[i].invNTT()
+		t[i].add(&t[i], &sk.s2[i])
+		t[i].power2Round(&sk.t1[i], &sk.t0[i])
+	}
+
+	sk.packed = sk.PublicKey.pack()
+	shake256(sk.tr[:], sk.packed)
+	return sk
+}
+
+func (pk *PublicKey) pack() []byte {
+	out := make([]byte, PublicKeySize)
+	copy(out, pk.rho[:])
+	for i := range pk.t1 {
+		pk.t1[i].packT1(out[seedBytes+i*polyT1PackedBytes:])
+	}
+	return out
+}
+
+// Bytes returns the encoding of the public key
+func (pk *PublicKey) Bytes() []byte {
+	return append([]byte(nil), pk.packed...)
+}
+
+// Equal returns true if the public keys are the same
+func (pk *PublicKey) Equal(other *PublicKey) bool {
+	return subtle.ConstantTimeCompare(pk.packed, other.packed) == 1
+}
+
+// NewPublicKey decodes a public key
+func NewPublicKey(b []byte) (*PublicKey, error) {
+	if len(b) != PublicKeySize {
+		return nil, errors.Errorf("invalid public key size %d, expected %d", len(b), PublicKeySize)
+	}
+	pk := &PublicKey{packed: append([]byte(nil), b...)}
+	copy(pk.rho[:], b)
+	for i := range pk.t1 {
+		pk.t1[i].unpackT1(b[seedBytes+i*polyT1PackedBytes:])
+	}
+	expandA(&pk.a, pk.rho[:])
+	return pk, nil
+}
+
+// Bytes returns the encoding of the private key
+func (sk *PrivateKey) Bytes() []byte {
+	out := make([]byte, PrivateKeySize)
+	copy(out, sk.rho[:])
+	copy(out[seedBytes:], sk.key[:])
+	copy(out[2*seedBytes:], sk.tr[:])
+	off := 3 * seedBytes
+	for i := range sk.s1 {
+		sk.s1[i].packEta(out[off:])
+		off += polyEtaPackedBytes
+	}
+	for i := range sk.s2 {
+		sk.s2[i].packEta(out[off:])
+		off += polyEtaPackedBytes
+	}
+	for i := range sk.t0 {
+		sk.t0[i].packT0(out[off:])
+		off += polyT0PackedBytes
+	}
+	return out
+}
+
+// NewPrivateKey decodes a private key
+func NewPrivateKey(b []byte) (*PrivateKey, error) {
+	if len(b) != PrivateKeySize {
+		return nil, errors.Errorf("invalid private key size %d, expected %d", len(b), PrivateKeySize)
+	}
+	sk := &PrivateKey{}
+	copy(sk.rho[:], b)
+	copy(sk.key[:], b[seedBytes:])
+	copy(sk.tr[:], b[2*seedBytes:])
+	off := 3 * seedBytes
+	for i := range sk.s1 {
+		if !sk.s1[i].unpackEta(b[off:]) {
+			return nil, errors.New("invalid private key coefficients")
+		}
+		off += polyEtaPackedBytes
+	}
+	for i := range sk.s2 {
+		if !sk.s2[i].unpackEta(b[off:]) {
+			return nil, errors.New("invalid private key coefficients")
+		}
+		off += polyEtaPackedBytes
+	}
+	for i := range sk.t0 {
+		sk.t0[i].unpackT0(b[off:])
+		off += polyT0PackedBytes
+	}
+
+	// the public key is recomputed, as it is not part of the encoding
+	expandA(&sk.a, sk.rho[:])
+	s1Hat := sk.s1
+	for i := range s1Hat {
+		s1Hat[i].ntt()
+	}
+	t := mulA(&sk.a, &s1Hat)
+	var t0 poly
+	for i := range t {
+		t[i].invNTT()
+		t[i].add(&t[i], &sk.s2[i])
+		t[i].power2Round(&sk.t1[i], &t0)
+		if t0 != sk.t0[i] {
+			return nil, errors.New("inconsistent private key")
+		}
+	}
+	sk.packed = sk.PublicKey.pack()
+	var tr [seedBytes]byte
+	shake256(tr[:], sk.packed)
+	if subtle.ConstantTimeCompare(tr[:], sk.tr[:]) != 1 {
+		return nil, errors.New("inconsistent private key")
+	}
+	return sk, nil
+}
+
+func packW1(w1 *[k]poly) []byte {
+	out := make([]byte, k*polyW1PackedBytes)
+	for i := range w1 {
+		w1[i].packW1(out[i*polyW1PackedBytes:])
+	}
+	return out
+}
+
+// Sign signs the message with the private key
+func Sign(sk *PrivateKey, msg []byte) []byte {
+	var mu, rhoPrime [crhBytes]byte
+	shake256(mu[:], sk.tr[:], msg)
+	shake256(rhoPrime[:], sk.key[:], mu[:])
+
+	s1Hat, s2Hat, t0Hat := sk.s1, sk.s2, sk.t0
+	for i := range s1Hat {
+		s1Hat[i].ntt()
+	}
+	for i := range s2Hat {
+		s2Hat[i].ntt()
+		t0Hat[i].ntt()
+	}
+
+	sig := make([]byte, SignatureSize)
+	for nonce := 0; ; nonce++ {
+		var y, z [l]poly
+		for i := range y {
+			y[i].uniformGamma1(rhoPrime[:], uint16(l*nonce+i))
+			z[i] = y[i]
+			z[i].ntt()
+		}
+		w := mulA(&sk.a, &z)
+		var w1 [k]poly
+		var w0 [k][n]int32
+		for i := range w {
+			w[i].invNTT()
+			for j, a := range w[i] {
+				w1[i][j], w0[i][j] = decompose(a)
+			}
+		}
+
+		cTilde := sig[:seedBytes]
+		shake256(cTilde, mu[:], packW1(&w1))
+		var c poly
+		c.challenge(cTilde)
+		c.ntt()
+
+		// z = y + c*s1
+		rejected := false
+		for i := range z {
+			z[i].pointwise(&c, &s1Hat[i])
+			z[i].invNTT()
+			z[i].add(&z[i], &y[i])
+			if z[i].exceeds(gamma1 - beta) {
+				rejected = true
+				break
+			}
+		}
+		if rejected {
+			continue
+		}
+
+		// the low bits of w - c*s2 must not leak s2, and with the hint
+		// of c*t0 they must give the high bits of w - c*s2 + c*t0
+		var h [k]poly
+		hints := 0
+		for i := 0; i < k && !rejected; i++ {
+			var cs2, ct0 poly
+			cs2.pointwise(&c, &s2Hat[i])
+			cs2.invNTT()
+			ct0.pointwise(&c, &t0Hat[i])
+			ct0.invNTT()
+			if ct0.exceeds(gamma2) {
+				rejected = true
+				break
+			}
+			for j, a0 := range w0[i] {
+				r0 := a0 - centered(cs2[j])
+				if r0 >= gamma2-beta || -r0 >= gamma2-beta {
+					rejected = true
+					break
+				}
+				h[i][j] = makeHint(r0+centered(ct0[j]), w1[i][j])
+				hints += int(h[i][j])
+			}
+		}
+		if rejected || hints > omega {
+			continue
+		}
+
+		for i := range z {
+			z[i].packZ(sig[seedBytes+i*polyZPackedBytes:])
+		}
+		packHint(sig[seedBytes+l*polyZPackedBytes:], &h)
+		return sig
+	}
+}
+
+// Verify returns true if sig is a valid signature of the message with the public key
+func Verify(pk *PublicKey, msg []byte, sig []byte) bool {
+	if len(sig) != SignatureSize {
+		return false
+	}
+	cTilde := sig[:seedBytes]
+	var z [l]poly
+	for i := range z {
+		z[i].unpackZ(sig[seedBytes+i*polyZPackedBytes:])
+		if z[i].exceeds(gamma1 - beta) {
+			return false
+		}
+		z[i].ntt()
+	}
+	var h [k]poly
+	if !unpackHint(&h, sig[seedBytes+l*polyZPackedBytes:]) {
+		return false
+	}
+
+	var tr [seedBytes]byte
+	var mu [crhBytes]byte
+	shake256(tr[:], pk.packed)
+	shake256(mu[:], tr[:], msg)
+
+	var c poly
+	c.challenge(cTilde)
+	c.ntt()
+
+	// w1 = UseHint(h, A*z - c*t1*2^d)
+	w := mulA(&pk.a, &z)
+	var w1 [k]poly
+	for i := range w {
+		var ct1 poly
+		for j, a := range pk.t1[i] {
+			ct1[j] = a << d
+		}
+		ct1.ntt()
+		ct1.pointwise(&c, &ct1)
+		w[i].sub(&w[i], &ct1)
+		w[i].invNTT()
+		for j, a := range w[i] {
+			w1[i][j] = useHint(a, h[i][j])
+		}
+	}
+
+	var cTilde2 [seedBytes]byte
+	shake256(cTilde2[:], mu[:], packW1(&w1))
+	return subtle.ConstantTimeCompare(cTilde, cTilde2[:]) == 1
+}
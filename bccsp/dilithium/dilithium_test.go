@@ -0,0 +1,152 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dilithium
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func randomPoly(t *testing.T) poly {
+	var p poly
+	for i := range p {
+		v, err := rand.Int(rand.Reader, big.NewInt(q))
+		assert.NoError(t, err)
+		p[i] = uint32(v.Int64())
+	}
+	return p
+}
+
+func TestNTT(t *testing.T) {
+	a, b := randomPoly(t), randomPoly(t)
+
+	// schoolbook multiplication modulo X^256+1
+	var expected poly
+	for i := range a {
+		for j := range b {
+			m := mulMod(a[i], b[j])
+			if i+j < n {
+				expected[i+j] = addMod(expected[i+j], m)
+			} else {
+				expected[i+j-n] = subMod(expected[i+j-n], m)
+			}
+		}
+	}
+
+	aHat, bHat := a, b
+	aHat.ntt()
+	bHat.ntt()
+	var product poly
+	product.pointwise(&aHat, &bHat)
+	product.invNTT()
+	assert.Equal(t, expected, product)
+
+	aHat.invNTT()
+	assert.Equal(t, a, aHat)
+}
+
+func TestDecompose(t *testing.T) {
+	for _, a := range []uint32{0, 1, gamma2, gamma2 + 1, 2 * gamma2, q - gamma2, q - 1, 4190208, 8000000} {
+		a1, a0 := decompose(a)
+		assert.Equal(t, a, fromInt(int32(a1)*2*gamma2+a0), "a = %d", a)
+		assert.True(t, a1 < (q-1)/(2*gamma2))
+
+		// as for the signatures, whose low bits are bounded, the hints
+		// recover the high bits of a from a+z
+		if a0 >= gamma2-beta || -a0 >= gamma2-beta {
+			continue
+		}
+		for _, z := range []int32{-gamma2 + 1, -1, 0, 1, gamma2 - 1} {
+			sum := fromInt(int32(a) + z)
+			assert.Equal(t, a1, useHint(sum, makeHint(a0+z, a1)), "a = %d, z = %d", a, z)
+		}
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	sk, err := GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	pk := sk.Public()
+	msg := []byte("hello world")
+
+	sig := Sign(sk, msg)
+	assert.Len(t, sig, SignatureSize)
+	assert.True(t, Verify(pk, msg, sig))
+	// signatures are deterministic
+	assert.Equal(t, sig, Sign(sk, msg))
+
+	assert.False(t, Verify(pk, []byte("hello world!"), sig))
+	assert.False(t, Verify(pk, msg, sig[1:]))
+	for _, i := range []int{0, seedBytes, SignatureSize - k - 1, SignatureSize - 1} {
+		tampered := append([]byte(nil), sig...)
+		tampered[i] ^= 1
+		assert.False(t, Verify(pk, msg, tampered), "byte %d", i)
+	}
+
+	other, err := GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	assert.False(t, Verify(other.Public(), msg, sig))
+	assert.False(t, pk.Equal(other.Public()))
+}
+
+func TestEncoding(t *testing.T) {
+	sk := NewKeyFromSeed(make([]byte, seedBytes))
+	assert.Equal(t, sk.Bytes(), NewKeyFromSeed(make([]byte, seedBytes)).Bytes())
+
+	pk, err := NewPublicKey(sk.Public().Bytes())
+	assert.NoError(t, err)
+	assert.True(t, pk.Equal(sk.Public()))
+	assert.Len(t, pk.Bytes(), PublicKeySize)
+
+	sk2, err := NewPrivateKey(sk.Bytes())
+	assert.NoError(t, err)
+	assert.Len(t, sk2.Bytes(), PrivateKeySize)
+	assert.True(t, sk2.Public().Equal(pk))
+	assert.True(t, Verify(pk, []byte("msg"), Sign(sk2, []byte("msg"))))
+
+	_, err = NewPublicKey(pk.Bytes()[1:])
+	assert.EqualError(t, err, "invalid public key size 1311, expected 1312")
+	_, err = NewPrivateKey(sk.Bytes()[1:])
+	assert.EqualError(t, err, "invalid private key size 2527, expected 2528")
+
+	tampered := sk.Bytes()
+	tampered[0] ^= 1
+	_, err = NewPrivateKey(tampered)
+	assert.EqualError(t, err, "inconsistent private key")
+
+	tampered = sk.Bytes()
+	tampered[3*seedBytes] = 0xFF
+	_, err = NewPrivateKey(tampered)
+	assert.EqualError(t, err, "invalid private key coefficients")
+}
+
+func TestPKIX(t *testing.T) {
+	sk, err := GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	der, err := MarshalPKIXPublicKey(sk.Public())
+	assert.NoError(t, err)
+	pk, err := ParsePKIXPublicKey(der)
+	assert.NoError(t, err)
+	assert.True(t, pk.Equal(sk.Public()))
+
+	der, err = MarshalPKCS8PrivateKey(sk)
+	assert.NoError(t, err)
+	sk2, err := ParsePKCS8PrivateKey(der)
+	assert.NoError(t, err)
+	assert.Equal(t, sk.Bytes(), sk2.Bytes())
+
+	_, err = ParsePKIXPublicKey(der)
+	assert.Error(t, err)
+	_, err = ParsePKCS8PrivateKey([]byte("garbage"))
+	assert.Error(t, err)
+	_, err = ParsePKIXPublicKey(append(der, 0))
+	assert.Error(t, err)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dilithium
+
+import (
+	"crypto/aes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// drbg is the AES-256 CTR DRBG of the NIST PQCgenKAT tools, which derives the
+// seeds and the messages of the known answer tests.
+type drbg struct {
+	key [32]byte
+	v   [16]byte
+}
+
+func newDRBG(seed *[48]byte) *drbg {
+	g := &drbg{}
+	g.update(seed)
+	return g
+}
+
+func (g *drbg) incV() {
+	for j := len(g.v) - 1; j >= 0; j-- {
+		g.v[j]++
+		if g.v[j] != 0 {
+			break
+		}
+	}
+}
+
+func (g *drbg) update(data *[48]byte) {
+	var buf [48]byte
+	b, _ := aes.NewCipher(g.key[:])
+	for i := 0; i < 3; i++ {
+		g.incV()
+		b.Encrypt(buf[i*16:(i+1)*16], g.v[:])
+	}
+	if data != nil {
+		for i := range buf {
+			buf[i] ^= data[i]
+		}
+	}
+	copy(g.key[:], buf[:32])
+	copy(g.v[:], buf[32:])
+}
+
+func (g *drbg) fill(out []byte) {
+	var block [16]byte
+	b, _ := aes.NewCipher(g.key[:])
+	for len(out) > 0 {
+		g.incV()
+		b.Encrypt(block[:], g.v[:])
+		out = out[copy(out, block[:]):]
+	}
+	g.update(nil)
+}
+
+// TestKnownAnswers generates the PQCsignKAT_2420.rsp file of the Dilithium2
+// known answer tests of the third round submission, as PQCgenKAT_sign does,
+// and checks it against the SHA256 digest of the file produced by the
+// reference implementation at commit 61b51a71701b8ae9f546a1e5 of
+// https://github.com/pq-crystals/dilithium.
+func TestKnownAnswers(t *testing.T) {
+	const expected = "38ed991c5ca11e39ab23945ca37af89e059d16c5474bf8ba96b15cb4e948af2a"
+
+	var seed [48]byte
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	g := newDRBG(&seed)
+
+	rsp := sha256.New()
+	fmt.Fprintf(rsp, "# Dilithium2\n\n")
+	for i := 0; i < 100; i++ {
+		mlen := 33 * (i + 1)
+		g.fill(seed[:])
+		msg := make([]byte, mlen)
+		g.fill(msg)
+
+		fmt.Fprintf(rsp, "count = %d\n", i)
+		fmt.Fprintf(rsp, "seed = %X\n", seed)
+		fmt.Fprintf(rsp, "mlen = %d\n", mlen)
+		fmt.Fprintf(rsp, "msg = %X\n", msg)
+
+		keySeed := make([]byte, seedBytes)
+		newDRBG(&seed).fill(keySeed)
+		sk := NewKeyFromSeed(keySeed)
+		sig := Sign(sk, msg)
+		require.True(t, Verify(sk.Public(), msg, sig), "signature %d does not verify", i)
+
+		fmt.Fprintf(rsp, "pk = %X\n", sk.Public().Bytes())
+		fmt.Fprintf(rsp, "sk = %X\n", sk.Bytes())
+		fmt.Fprintf(rsp, "smlen = %d\n", mlen+SignatureSize)
+		fmt.Fprintf(rsp, "sm = %X%X\n\n", sig, msg)
+	}
+	assert.Equal(t, expected, hex.EncodeToString(rsp.Sum(nil)))
+}
@@ -0,0 +1,161 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dilithium
+
+const (
+	polyT1PackedBytes  = n * 10 / 8
+	polyT0PackedBytes  = n * d / 8
+	polyEtaPackedBytes = n * 3 / 8
+	polyZPackedBytes   = n * 18 / 8
+	polyW1PackedBytes  = n * 6 / 8
+
+	// PublicKeySize is the size in bytes of the public keys
+	PublicKeySize = seedBytes + k*polyT1PackedBytes
+	// PrivateKeySize is the size in bytes of the private keys
+	PrivateKeySize = 3*seedBytes + (k+l)*polyEtaPackedBytes + k*polyT0PackedBytes
+	// SignatureSize is the size in bytes of the signatures
+	SignatureSize = seedBytes + l*polyZPackedBytes + omega + k
+)
+
+// packBits packs the values, of the given number of bits each, least
+// significant bit first
+func packBits(out []byte, values []uint32, bits uint) {
+	var acc uint64
+	var accBits uint
+	pos := 0
+	for _, v := range values {
+		acc |= uint64(v) << accBits
+		accBits += bits
+		for accBits >= 8 {
+			out[pos] = byte(acc)
+			pos++
+			acc >>= 8
+			accBits -= 8
+		}
+	}
+}
+
+// unpackBits is the inverse of packBits
+func unpackBits(values []uint32, in []byte, bits uint) {
+	var acc uint64
+	var accBits uint
+	pos := 0
+	mask := uint64(1)<<bits - 1
+	for i := range values {
+		for accBits < bits {
+			acc |= uint64(in[pos]) << accBits
+			pos++
+			accBits += 8
+		}
+		values[i] = uint32(acc & mask)
+		acc >>= bits
+		accBits -= bits
+	}
+}
+
+func (p *poly) packT1(out []byte) {
+	packBits(out, p[:], 10)
+}
+
+func (p *poly) unpackT1(in []byte) {
+	unpackBits(p[:], in, 10)
+}
+
+func (p *poly) packT0(out []byte) {
+	var values [n]uint32
+	for i, a := range p {
+		values[i] = uint32((1 << (d - 1)) - centered(a))
+	}
+	packBits(out, values[:], d)
+}
+
+func (p *poly) unpackT0(in []byte) {
+	unpackBits(p[:], in, d)
+	for i, v := range p {
+		p[i] = fromInt((1 << (d - 1)) - int32(v))
+	}
+}
+
+func (p *poly) packEta(out []byte) {
+	var values [n]uint32
+	for i, a := range p {
+		values[i] = uint32(eta - centered(a))
+	}
+	packBits(out, values[:], 3)
+}
+
+// unpackEta returns false if a coefficient is out of [-eta, eta]
+func (p *poly) unpackEta(in []byte) bool {
+	unpackBits(p[:], in, 3)
+	for i, v := range p {
+		if v > 2*eta {
+			return false
+		}
+		p[i] = fromInt(eta - int32(v))
+	}
+	return true
+}
+
+func (p *poly) packZ(out []byte) {
+	var values [n]uint32
+	for i, a := range p {
+		values[i] = uint32(gamma1 - centered(a))
+	}
+	packBits(out, values[:], 18)
+}
+
+func (p *poly) unpackZ(in []byte) {
+	unpackBits(p[:], in, 18)
+	for i, v := range p {
+		p[i] = fromInt(gamma1 - int32(v))
+	}
+}
+
+func (p *poly) packW1(out []byte) {
+	packBits(out, p[:], 6)
+}
+
+// packHint packs the hint vector, which must have at most omega ones, as the
+// positions of its ones followed by the number of ones up to each polynomial
+func packHint(out []byte, h *[k]poly) {
+	pos := 0
+	for i := range h {
+		for j, c := range h[i] {
+			if c != 0 {
+				out[pos] = byte(j)
+				pos++
+			}
+		}
+		out[omega+i] = byte(pos)
+	}
+}
+
+// unpackHint returns false if the hint is malformed
+func unpackHint(h *[k]poly, in []byte) bool {
+	pos := 0
+	for i := range h {
+		h[i] = poly{}
+		end := int(in[omega+i])
+		if end < pos || end > omega {
+			return false
+		}
+		for j := pos; j < end; j++ {
+			// the positions are strictly increasing, so that the encoding is unique
+			if j > pos && in[j] <= in[j-1] {
+				return false
+			}
+			h[i][in[j]] = 1
+		}
+		pos = end
+	}
+	for j := pos; j < omega; j++ {
+		if in[j] != 0 {
+			return false
+		}
+	}
+	return true
+}
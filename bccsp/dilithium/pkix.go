@@ -0,0 +1,76 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dilithium
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+
+	"github.com/pkg/errors"
+)
+
+// OID is the object identifier of Dilithium2 (round 3) keys
+var OID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 2, 267, 7, 4, 4}
+
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+type pkcs8 struct {
+	Version    int
+	Algorithm  pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+// MarshalPKIXPublicKey encodes the public key as a DER SubjectPublicKeyInfo
+func MarshalPKIXPublicKey(pk *PublicKey) ([]byte, error) {
+	return asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{Algorithm: OID},
+		PublicKey: asn1.BitString{Bytes: pk.Bytes(), BitLength: 8 * PublicKeySize},
+	})
+}
+
+// ParsePKIXPublicKey decodes a public key from a DER SubjectPublicKeyInfo
+func ParsePKIXPublicKey(der []byte) (*PublicKey, error) {
+	var info subjectPublicKeyInfo
+	rest, err := asn1.Unmarshal(der, &info)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshalling public key")
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("trailing data after public key")
+	}
+	if !info.Algorithm.Algorithm.Equal(OID) {
+		return nil, errors.Errorf("unexpected public key algorithm %s", info.Algorithm.Algorithm)
+	}
+	return NewPublicKey(info.PublicKey.RightAlign())
+}
+
+// MarshalPKCS8PrivateKey encodes the private key as a DER PKCS#8 private key
+func MarshalPKCS8PrivateKey(sk *PrivateKey) ([]byte, error) {
+	return asn1.Marshal(pkcs8{
+		Algorithm:  pkix.AlgorithmIdentifier{Algorithm: OID},
+		PrivateKey: sk.Bytes(),
+	})
+}
+
+// ParsePKCS8PrivateKey decodes a private key from a DER PKCS#8 private key
+func ParsePKCS8PrivateKey(der []byte) (*PrivateKey, error) {
+	var key pkcs8
+	rest, err := asn1.Unmarshal(der, &key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshalling private key")
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("trailing data after private key")
+	}
+	if !key.Algorithm.Algorithm.Equal(OID) {
+		return nil, errors.Errorf("unexpected private key algorithm %s", key.Algorithm.Algorithm)
+	}
+	return NewPrivateKey(key.PrivateKey)
+}
@@ -0,0 +1,299 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dilithium
+
+import (
+	"encoding/binary"
+
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	n = 256
+	q = 8380417
+	d = 13
+
+	k      = 4
+	l      = 4
+	eta    = 2
+	tau    = 39
+	beta   = tau * eta
+	gamma1 = 1 << 17
+	gamma2 = (q - 1) / 88
+	omega  = 80
+
+	seedBytes = 32
+	crhBytes  = 64
+
+	// rootOfUnity is a primitive 512-th root of unity modulo q
+	rootOfUnity = 1753
+)
+
+// A poly is a polynomial of Z_q[X]/(X^256+1), with its coefficients in [0, q)
+type poly [n]uint32
+
+var (
+	// zetas are the powers of the root of unity, in bit-reversed order
+	zetas [n]uint32
+	// nInv is the inverse of n modulo q
+	nInv uint32
+)
+
+func init() {
+	for i := 0; i < n; i++ {
+		zetas[i] = powMod(rootOfUnity, uint32(bitReverse8(uint8(i))))
+	}
+	nInv = powMod(n, q-2)
+}
+
+func bitReverse8(x uint8) uint8 {
+	var r uint8
+	for i := 0; i < 8; i++ {
+		r = (r << 1) | (x & 1)
+		x >>= 1
+	}
+	return r
+}
+
+func mulMod(a, b uint32) uint32 {
+	return uint32(uint64(a) * uint64(b) % q)
+}
+
+func addMod(a, b uint32) uint32 {
+	r := a + b
+	if r >= q {
+		r -= q
+	}
+	return r
+}
+
+func subMod(a, b uint32) uint32 {
+	if a >= b {
+		return a - b
+	}
+	return a + q - b
+}
+
+func powMod(a, e uint32) uint32 {
+	r := uint32(1)
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			r = mulMod(r, a)
+		}
+		a = mulMod(a, a)
+	}
+	return r
+}
+
+// fromInt returns the representative in [0, q) of a
+func fromInt(a int32) uint32 {
+	r := a % q
+	if r < 0 {
+		r += q
+	}
+	return uint32(r)
+}
+
+// centered returns the representative in (-(q-1)/2, (q-1)/2] of a
+func centered(a uint32) int32 {
+	if a > (q-1)/2 {
+		return int32(a) - q
+	}
+	return int32(a)
+}
+
+func (p *poly) add(a, b *poly) {
+	for i := range p {
+		p[i] = addMod(a[i], b[i])
+	}
+}
+
+func (p *poly) sub(a, b *poly) {
+	for i := range p {
+		p[i] = subMod(a[i], b[i])
+	}
+}
+
+// pointwise multiplies two polynomials in the NTT domain
+func (p *poly) pointwise(a, b *poly) {
+	for i := range p {
+		p[i] = mulMod(a[i], b[i])
+	}
+}
+
+// ntt transforms the polynomial to the NTT domain, in place
+func (p *poly) ntt() {
+	m := 0
+	for length := n / 2; length > 0; length >>= 1 {
+		for start := 0; start < n; start += 2 * length {
+			m++
+			zeta := zetas[m]
+			for j := start; j < start+length; j++ {
+				t := mulMod(zeta, p[j+length])
+				p[j+length] = subMod(p[j], t)
+				p[j] = addMod(p[j], t)
+			}
+		}
+	}
+}
+
+// invNTT transforms the polynomial back from the NTT domain, in place
+func (p *poly) invNTT() {
+	m := n
+	for length := 1; length < n; length <<= 1 {
+		for start := 0; start < n; start += 2 * length {
+			m--
+			zeta := q - zetas[m]
+			for j := start; j < start+length; j++ {
+				t := p[j]
+				p[j] = addMod(t, p[j+length])
+				p[j+length] = mulMod(zeta, subMod(t, p[j+length]))
+			}
+		}
+	}
+	for i := range p {
+		p[i] = mulMod(p[i], nInv)
+	}
+}
+
+// exceeds returns true if the infinity norm of the polynomial is at least bound
+func (p *poly) exceeds(bound int32) bool {
+	for _, a := range p {
+		c := centered(a)
+		if c >= bound || -c >= bound {
+			return true
+		}
+	}
+	return false
+}
+
+// power2Round splits the coefficients a into a1*2^d + a0, with a0 in (-2^(d-1), 2^(d-1)]
+func (p *poly) power2Round(p1, p0 *poly) {
+	for i, a := range p {
+		a1 := (a + (1 << (d - 1)) - 1) >> d
+		p1[i] = a1
+		p0[i] = fromInt(int32(a) - int32(a1<<d))
+	}
+}
+
+// decompose splits a into a1*2*gamma2 + a0, with a0 in (-gamma2, gamma2], except
+// for the coefficients close to q, for which a1 is 0 and a0 is in [-gamma2, 0)
+func decompose(a uint32) (uint32, int32) {
+	a0 := int32(a % (2 * gamma2))
+	if a0 > gamma2 {
+		a0 -= 2 * gamma2
+	}
+	if int32(a)-a0 == q-1 {
+		return 0, a0 - 1
+	}
+	return uint32((int32(a) - a0) / (2 * gamma2)), a0
+}
+
+// makeHint returns 1 if the high bits of the coefficient are changed by adding
+// a low part a0 (centered) to a coefficient with high bits a1
+func makeHint(a0 int32, a1 uint32) uint32 {
+	if a0 > gamma2 || a0 < -gamma2 || (a0 == -gamma2 && a1 != 0) {
+		return 1
+	}
+	return 0
+}
+
+// useHint returns the high bits of a, corrected by the hint
+func useHint(a uint32, hint uint32) uint32 {
+	a1, a0 := decompose(a)
+	if hint == 0 {
+		return a1
+	}
+	if a0 > 0 {
+		if a1 == (q-1)/(2*gamma2)-1 {
+			return 0
+		}
+		return a1 + 1
+	}
+	if a1 == 0 {
+		return (q-1)/(2*gamma2) - 1
+	}
+	return a1 - 1
+}
+
+func nonceBytes(nonce uint16) []byte {
+	var b [2]byte
+	binary.LittleEndian.PutUint16(b[:], nonce)
+	return b[:]
+}
+
+// uniform samples a polynomial in the NTT domain, uniformly, from the seed and the nonce
+func (p *poly) uniform(seed []byte, nonce uint16) {
+	h := sha3.NewShake128()
+	h.Write(seed)
+	h.Write(nonceBytes(nonce))
+	var buf [3]byte
+	for i := 0; i < n; {
+		h.Read(buf[:])
+		t := (uint32(buf[0]) | uint32(buf[1])<<8 | uint32(buf[2])<<16) & 0x7FFFFF
+		if t < q {
+			p[i] = t
+			i++
+		}
+	}
+}
+
+// uniformEta samples a polynomial with coefficients in [-eta, eta] from the seed and the nonce
+func (p *poly) uniformEta(seed []byte, nonce uint16) {
+	h := sha3.NewShake256()
+	h.Write(seed)
+	h.Write(nonceBytes(nonce))
+	var buf [1]byte
+	for i := 0; i < n; {
+		h.Read(buf[:])
+		for _, t := range []uint32{uint32(buf[0] & 0x0F), uint32(buf[0] >> 4)} {
+			if t < 15 && i < n {
+				t = t - (205*t>>10)*5
+				p[i] = subMod(eta, t)
+				i++
+			}
+		}
+	}
+}
+
+// uniformGamma1 samples a polynomial with coefficients in (-gamma1, gamma1] from the seed and the nonce
+func (p *poly) uniformGamma1(seed []byte, nonce uint16) {
+	h := sha3.NewShake256()
+	h.Write(seed)
+	h.Write(nonceBytes(nonce))
+	buf := make([]byte, polyZPackedBytes)
+	h.Read(buf)
+	p.unpackZ(buf)
+}
+
+// challenge samples the polynomial with tau coefficients in {-1, 1} and the
+// others zero from the seed
+func (p *poly) challenge(seed []byte) {
+	h := sha3.NewShake256()
+	h.Write(seed)
+	var buf [8]byte
+	h.Read(buf[:])
+	signs := binary.LittleEndian.Uint64(buf[:])
+
+	*p = poly{}
+	var b [1]byte
+	for i := n - tau; i < n; i++ {
+		for {
+			h.Read(b[:])
+			if int(b[0]) <= i {
+				break
+			}
+		}
+		p[i] = p[b[0]]
+		if signs&1 == 1 {
+			p[b[0]] = q - 1
+		} else {
+			p[b[0]] = 1
+		}
+		signs >>= 1
+	}
+}
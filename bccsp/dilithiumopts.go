@@ -0,0 +1,55 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bccsp
+
+// DilithiumKeyGenOpts contains options for Dilithium key generation.
+type DilithiumKeyGenOpts struct {
+	Temporary bool
+}
+
+// Algorithm returns the key generation algorithm identifier (to be used).
+func (opts *DilithiumKeyGenOpts) Algorithm() string {
+	return DILITHIUM
+}
+
+// Ephemeral returns true if the key to generate has to be ephemeral,
+// false otherwise.
+func (opts *DilithiumKeyGenOpts) Ephemeral() bool {
+	return opts.Temporary
+}
+
+// DilithiumPKIXPublicKeyImportOpts contains options for Dilithium public key importation in PKIX format
+type DilithiumPKIXPublicKeyImportOpts struct {
+	Temporary bool
+}
+
+// Algorithm returns the key importation algorithm identifier (to be used).
+func (opts *DilithiumPKIXPublicKeyImportOpts) Algorithm() string {
+	return DILITHIUM
+}
+
+// Ephemeral returns true if the key to generate has to be ephemeral,
+// false otherwise.
+func (opts *DilithiumPKIXPublicKeyImportOpts) Ephemeral() bool {
+	return opts.Temporary
+}
+
+// DilithiumPrivateKeyImportOpts contains options for Dilithium secret key importation in PKCS#8 format.
+type DilithiumPrivateKeyImportOpts struct {
+	Temporary bool
+}
+
+// Algorithm returns the key importation algorithm identifier (to be used).
+func (opts *DilithiumPrivateKeyImportOpts) Algorithm() string {
+	return DILITHIUM
+}
+
+// Ephemeral returns true if the key to generate has to be ephemeral,
+// false otherwise.
+func (opts *DilithiumPrivateKeyImportOpts) Ephemeral() bool {
+	return opts.Temporary
+}
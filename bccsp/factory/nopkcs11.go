@@ -60,6 +60,15 @@ func InitFactories(config *FactoryOpts) error {
 			}
 		}
 
+		// Post-quantum experimental BCCSP, only initialized when it is the default
+		if config.ProviderName == PostQuantumFactoryName {
+			f := &PQFactory{}
+			err := initBCCSP(f, config)
+			if err != nil {
+				factoriesInitError = errors.Wrapf(err, "Failed initializing PQ.BCCSP %s", factoriesInitError)
+			}
+		}
+
 		// BCCSP Plugin
 		if config.PluginOpts != nil {
 			f := &PluginFactory{}
@@ -85,6 +94,8 @@ func GetBCCSPFromOpts(config *FactoryOpts) (bccsp.BCCSP, error) {
 	switch config.ProviderName {
 	case "SW":
 		f = &SWFactory{}
+	case "PQ":
+		f = &PQFactory{}
 	case "PLUGIN":
 		f = &PluginFactory{}
 	default:
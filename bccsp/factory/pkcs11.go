@@ -69,6 +69,15 @@ func setFactories(config *FactoryOpts) error {
 		}
 	}
 
+	// Post-quantum experimental BCCSP, only initialized when it is the default
+	if config.ProviderName == PostQuantumFactoryName {
+		f := &PQFactory{}
+		err := initBCCSP(f, config)
+		if err != nil {
+			factoriesInitError = errors.Wrapf(err, "Failed initializing PQ.BCCSP %s", factoriesInitError)
+		}
+	}
+
 	// PKCS11-Based BCCSP
 	if config.Pkcs11Opts != nil {
 		f := &PKCS11Factory{}
@@ -102,6 +111,8 @@ func GetBCCSPFromOpts(config *FactoryOpts) (bccsp.BCCSP, error) {
 	switch config.ProviderName {
 	case "SW":
 		f = &SWFactory{}
+	case "PQ":
+		f = &PQFactory{}
 	case "PKCS11":
 		f = &PKCS11Factory{}
 	case "PLUGIN":
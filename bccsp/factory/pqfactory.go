@@ -0,0 +1,44 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/pkg/errors"
+)
+
+const (
+	// PostQuantumFactoryName is the name of the factory of the software-based BCCSP
+	// implementation supporting the experimental post-quantum signatures
+	PostQuantumFactoryName = "PQ"
+)
+
+// PQFactory is the factory of the software-based BCCSP supporting, in addition
+// to the algorithms of the SWFactory, the experimental Dilithium signatures.
+// It is configured with the SwOpts.
+type PQFactory struct{}
+
+// Name returns the name of this factory
+func (f *PQFactory) Name() string {
+	return PostQuantumFactoryName
+}
+
+// Get returns an instance of BCCSP using Opts.
+func (f *PQFactory) Get(config *FactoryOpts) (bccsp.BCCSP, error) {
+	// Validate arguments
+	if config == nil || config.SwOpts == nil {
+		return nil, errors.New("Invalid config. It must not be nil.")
+	}
+
+	ks, err := swKeyStore(config.SwOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return sw.NewPostQuantumWithParams(config.SwOpts.SecLevel, config.SwOpts.HashFamily, ks)
+}
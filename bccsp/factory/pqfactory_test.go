@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPQFactoryName(t *testing.T) {
+	f := &PQFactory{}
+	assert.Equal(t, f.Name(), PostQuantumFactoryName)
+}
+
+func TestPQFactoryGetInvalidArgs(t *testing.T) {
+	f := &PQFactory{}
+
+	_, err := f.Get(nil)
+	assert.EqualError(t, err, "Invalid config. It must not be nil.")
+
+	_, err = f.Get(&FactoryOpts{})
+	assert.EqualError(t, err, "Invalid config. It must not be nil.")
+
+	_, err = f.Get(&FactoryOpts{SwOpts: &SwOpts{}})
+	assert.Error(t, err)
+}
+
+func TestPQFactoryGet(t *testing.T) {
+	opts := &FactoryOpts{
+		ProviderName: PostQuantumFactoryName,
+		SwOpts: &SwOpts{
+			SecLevel:   256,
+			HashFamily: "SHA2",
+			Ephemeral:  true,
+		},
+	}
+	csp, err := GetBCCSPFromOpts(opts)
+	assert.NoError(t, err)
+
+	k, err := csp.KeyGen(&bccsp.DilithiumKeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+	signature, err := csp.Sign(k, []byte("digest"), nil)
+	assert.NoError(t, err)
+	valid, err := csp.Verify(k, signature, []byte("digest"), nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// the classical algorithms are supported as well
+	_, err = csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+}
@@ -43,6 +43,16 @@ func (f *SWFactory) Get(config *FactoryOpts) (bccsp.BCCSP, error) {
 
 	swOpts := config.SwOpts
 
+	ks, err := swKeyStore(swOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return sw.NewWithParams(swOpts.SecLevel, swOpts.HashFamily, ks)
+}
+
+// swKeyStore returns the key store of the software-based BCCSP
+func swKeyStore(swOpts *SwOpts) (bccsp.KeyStore, error) {
 	var ks bccsp.KeyStore
 	if swOpts.Ephemeral == true {
 		ks = sw.NewDummyKeyStore()
@@ -58,8 +68,7 @@ func (f *SWFactory) Get(config *FactoryOpts) (bccsp.BCCSP, error) {
 		// Default to ephemeral key store
 		ks = sw.NewDummyKeyStore()
 	}
-
-	return sw.NewWithParams(swOpts.SecLevel, swOpts.HashFamily, ks)
+	return ks, nil
 }
 
 // SwOpts contains options for the SWFactory
@@ -20,6 +20,9 @@ const (
 const (
 	// AlgNoRevocation means no revocation support
 	AlgNoRevocation RevocationAlgorithm = iota
+	// AlgPlainSignature means revocation support by listing the signatures of the
+	// revocation authority on the unrevoked revocation handles of each epoch
+	AlgPlainSignature
 )
 
 // IdemixIssuerKeyGenOpts contains the options for the Idemix Issuer key-generation.
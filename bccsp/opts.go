@@ -45,6 +45,10 @@ const (
 	// RSA at 4096 bit security level.
 	RSA4096 = "RSA4096"
 
+	// DILITHIUM post-quantum lattice based signature scheme (key gen, import, sign, verify),
+	// at the security level of Dilithium2. Experimental.
+	DILITHIUM = "DILITHIUM"
+
 	// AES Advanced Encryption Standard at the default security level.
 	// Each BCCSP may or may not support default security level. If not supported than
 	// an error will be returned.
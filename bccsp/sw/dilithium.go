@@ -0,0 +1,106 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"crypto/rand"
+	"reflect"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/dilithium"
+	"github.com/pkg/errors"
+)
+
+type dilithiumSigner struct{}
+
+// Sign signs the digest itself: Dilithium hashes the messages it signs.
+func (s *dilithiumSigner) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) ([]byte, error) {
+	return dilithium.Sign(k.(*dilithiumPrivateKey).privKey, digest), nil
+}
+
+type dilithiumPrivateKeyVerifier struct{}
+
+func (v *dilithiumPrivateKeyVerifier) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (bool, error) {
+	return dilithium.Verify(k.(*dilithiumPrivateKey).privKey.Public(), digest, signature), nil
+}
+
+type dilithiumPublicKeyKeyVerifier struct{}
+
+func (v *dilithiumPublicKeyKeyVerifier) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (bool, error) {
+	return dilithium.Verify(k.(*dilithiumPublicKey).pubKey, digest, signature), nil
+}
+
+type dilithiumKeyGenerator struct{}
+
+func (kg *dilithiumKeyGenerator) KeyGen(opts bccsp.KeyGenOpts) (bccsp.Key, error) {
+	privKey, err := dilithium.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed generating Dilithium key")
+	}
+
+	return &dilithiumPrivateKey{privKey}, nil
+}
+
+type dilithiumPKIXPublicKeyImportOptsKeyImporter struct{}
+
+func (*dilithiumPKIXPublicKeyImportOptsKeyImporter) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (bccsp.Key, error) {
+	der, ok := raw.([]byte)
+	if !ok {
+		return nil, errors.New("Invalid raw material. Expected byte array.")
+	}
+
+	if len(der) == 0 {
+		return nil, errors.New("Invalid raw. It must not be nil.")
+	}
+
+	pubKey, err := dilithium.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed converting PKIX to Dilithium public key")
+	}
+
+	return &dilithiumPublicKey{pubKey}, nil
+}
+
+type dilithiumPrivateKeyImportOptsKeyImporter struct{}
+
+func (*dilithiumPrivateKeyImportOptsKeyImporter) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (bccsp.Key, error) {
+	der, ok := raw.([]byte)
+	if !ok {
+		return nil, errors.New("[DilithiumPrivateKeyImportOpts] Invalid raw material. Expected byte array.")
+	}
+
+	if len(der) == 0 {
+		return nil, errors.New("[DilithiumPrivateKeyImportOpts] Invalid raw. It must not be nil.")
+	}
+
+	privKey, err := dilithium.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed converting PKCS#8 to Dilithium private key")
+	}
+
+	return &dilithiumPrivateKey{privKey}, nil
+}
+
+// NewPostQuantumWithParams returns a new instance of the software-based BCCSP
+// set at the passed security level, hash family and KeyStore, which also
+// supports the experimental Dilithium post-quantum signatures.
+func NewPostQuantumWithParams(securityLevel int, hashFamily string, keyStore bccsp.KeyStore) (bccsp.BCCSP, error) {
+	csp, err := NewWithParams(securityLevel, hashFamily, keyStore)
+	if err != nil {
+		return nil, err
+	}
+	swbccsp := csp.(*CSP)
+
+	swbccsp.AddWrapper(reflect.TypeOf(&dilithiumPrivateKey{}), &dilithiumSigner{})
+	swbccsp.AddWrapper(reflect.TypeOf(&dilithiumPrivateKey{}), &dilithiumPrivateKeyVerifier{})
+	swbccsp.AddWrapper(reflect.TypeOf(&dilithiumPublicKey{}), &dilithiumPublicKeyKeyVerifier{})
+	swbccsp.AddWrapper(reflect.TypeOf(&bccsp.DilithiumKeyGenOpts{}), &dilithiumKeyGenerator{})
+	swbccsp.AddWrapper(reflect.TypeOf(&bccsp.DilithiumPKIXPublicKeyImportOpts{}), &dilithiumPKIXPublicKeyImportOptsKeyImporter{})
+	swbccsp.AddWrapper(reflect.TypeOf(&bccsp.DilithiumPrivateKeyImportOpts{}), &dilithiumPrivateKeyImportOptsKeyImporter{})
+
+	return swbccsp, nil
+}
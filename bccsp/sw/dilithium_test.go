@@ -0,0 +1,120 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/dilithium"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDilithiumKeys(t *testing.T) {
+	t.Parallel()
+
+	privKey := dilithium.NewKeyFromSeed(make([]byte, 32))
+	k := &dilithiumPrivateKey{privKey}
+	assert.False(t, k.Symmetric())
+	assert.True(t, k.Private())
+	_, err := k.Bytes()
+	assert.EqualError(t, err, "Not supported.")
+	hash := sha256.Sum256(privKey.Public().Bytes())
+	assert.Equal(t, hash[:], k.SKI())
+
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+	assert.False(t, pk.Symmetric())
+	assert.False(t, pk.Private())
+	assert.Equal(t, k.SKI(), pk.SKI())
+	raw, err := pk.Bytes()
+	assert.NoError(t, err)
+	pubKey, err := dilithium.ParsePKIXPublicKey(raw)
+	assert.NoError(t, err)
+	assert.True(t, pubKey.Equal(privKey.Public()))
+
+	assert.Nil(t, (&dilithiumPrivateKey{}).SKI())
+	assert.Nil(t, (&dilithiumPublicKey{}).SKI())
+	_, err = (&dilithiumPublicKey{}).Bytes()
+	assert.EqualError(t, err, "Failed marshalling key. Key is nil.")
+}
+
+func TestDilithiumCSP(t *testing.T) {
+	t.Parallel()
+
+	tempDir, err := ioutil.TempDir("", "dilithium")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	ks, err := NewFileBasedKeyStore(nil, tempDir, false)
+	assert.NoError(t, err)
+
+	// the experimental scheme is only supported by the post-quantum provider
+	csp, err := NewWithParams(256, "SHA2", ks)
+	assert.NoError(t, err)
+	_, err = csp.KeyGen(&bccsp.DilithiumKeyGenOpts{})
+	assert.Error(t, err)
+
+	csp, err = NewPostQuantumWithParams(256, "SHA2", ks)
+	assert.NoError(t, err)
+	k, err := csp.KeyGen(&bccsp.DilithiumKeyGenOpts{})
+	assert.NoError(t, err)
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+
+	digest, err := csp.Hash([]byte("hello world"), &bccsp.SHAOpts{})
+	assert.NoError(t, err)
+	signature, err := csp.Sign(k, digest, nil)
+	assert.NoError(t, err)
+	assert.Len(t, signature, dilithium.SignatureSize)
+
+	valid, err := csp.Verify(k, signature, digest, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	valid, err = csp.Verify(pk, signature, digest, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	valid, err = csp.Verify(pk, signature, append(digest, 0), nil)
+	assert.NoError(t, err)
+	assert.False(t, valid)
+
+	// the private key is retrieved from the key store
+	stored, err := csp.GetKey(k.SKI())
+	assert.NoError(t, err)
+	assert.IsType(t, &dilithiumPrivateKey{}, stored)
+	valid, err = csp.Verify(stored, signature, digest, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	raw, err := pk.Bytes()
+	assert.NoError(t, err)
+	imported, err := csp.KeyImport(raw, &bccsp.DilithiumPKIXPublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Equal(t, pk.SKI(), imported.SKI())
+	_, err = csp.KeyImport(raw[1:], &bccsp.DilithiumPKIXPublicKeyImportOpts{Temporary: true})
+	assert.Error(t, err)
+	_, err = csp.KeyImport("raw", &bccsp.DilithiumPKIXPublicKeyImportOpts{Temporary: true})
+	assert.EqualError(t, err, "Failed importing key with opts [&{true}]: Invalid raw material. Expected byte array.")
+
+	der, err := dilithium.MarshalPKCS8PrivateKey(stored.(*dilithiumPrivateKey).privKey)
+	assert.NoError(t, err)
+	importedKey, err := csp.KeyImport(der, &bccsp.DilithiumPrivateKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Equal(t, k.SKI(), importedKey.SKI())
+	_, err = csp.KeyImport(nil, &bccsp.DilithiumPrivateKeyImportOpts{Temporary: true})
+	assert.Error(t, err)
+
+	// the public key is stored and retrieved as well
+	ks2, err := NewFileBasedKeyStore(nil, tempDir, false)
+	assert.NoError(t, err)
+	assert.NoError(t, ks2.StoreKey(&dilithiumPublicKey{dilithium.NewKeyFromSeed(make([]byte, 32)).Public()}))
+	storedPk, err := ks2.GetKey((&dilithiumPublicKey{dilithium.NewKeyFromSeed(make([]byte, 32)).Public()}).SKI())
+	assert.NoError(t, err)
+	assert.IsType(t, &dilithiumPublicKey{}, storedPk)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"crypto/sha256"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/dilithium"
+	"github.com/pkg/errors"
+)
+
+type dilithiumPrivateKey struct {
+	privKey *dilithium.PrivateKey
+}
+
+// Bytes converts this key to its byte representation,
+// if this operation is allowed.
+func (k *dilithiumPrivateKey) Bytes() ([]byte, error) {
+	return nil, errors.New("Not supported.")
+}
+
+// SKI returns the subject key identifier of this key.
+func (k *dilithiumPrivateKey) SKI() []byte {
+	if k.privKey == nil {
+		return nil
+	}
+
+	return dilithiumSKI(k.privKey.Public())
+}
+
+// Symmetric returns true if this key is a symmetric key,
+// false is this key is asymmetric
+func (k *dilithiumPrivateKey) Symmetric() bool {
+	return false
+}
+
+// Private returns true if this key is an asymmetric private key,
+// false otherwise.
+func (k *dilithiumPrivateKey) Private() bool {
+	return true
+}
+
+// PublicKey returns the corresponding public key part of an asymmetric public/private key pair.
+// This method returns an error in symmetric key schemes.
+func (k *dilithiumPrivateKey) PublicKey() (bccsp.Key, error) {
+	return &dilithiumPublicKey{k.privKey.Public()}, nil
+}
+
+type dilithiumPublicKey struct {
+	pubKey *dilithium.PublicKey
+}
+
+// Bytes converts this key to its byte representation,
+// if this operation is allowed.
+func (k *dilithiumPublicKey) Bytes() ([]byte, error) {
+	if k.pubKey == nil {
+		return nil, errors.New("Failed marshalling key. Key is nil.")
+	}
+	raw, err := dilithium.MarshalPKIXPublicKey(k.pubKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed marshalling key")
+	}
+	return raw, nil
+}
+
+// SKI returns the subject key identifier of this key.
+func (k *dilithiumPublicKey) SKI() []byte {
+	if k.pubKey == nil {
+		return nil
+	}
+
+	return dilithiumSKI(k.pubKey)
+}
+
+// Symmetric returns true if this key is a symmetric key,
+// false is this key is asymmetric
+func (k *dilithiumPublicKey) Symmetric() bool {
+	return false
+}
+
+// Private returns true if this key is an asymmetric private key,
+// false otherwise.
+func (k *dilithiumPublicKey) Private() bool {
+	return false
+}
+
+// PublicKey returns the corresponding public key part of an asymmetric public/private key pair.
+// This method returns an error in symmetric key schemes.
+func (k *dilithiumPublicKey) PublicKey() (bccsp.Key, error) {
+	return k, nil
+}
+
+// dilithiumSKI hashes the encoding of the public key
+func dilithiumSKI(pk *dilithium.PublicKey) []byte {
+	hash := sha256.Sum256(pk.Bytes())
+	return hash[:]
+}
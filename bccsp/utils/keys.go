@@ -26,6 +26,8 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+
+	"github.com/hyperledger/fabric/bccsp/dilithium"
 )
 
 // struct to hold info required for PKCS#8
@@ -142,6 +144,21 @@ func PrivateKeyToPEM(privateKey interface{}, pwd []byte) ([]byte, error) {
 				Bytes: raw,
 			},
 		), nil
+	case *dilithium.PrivateKey:
+		if k == nil {
+			return nil, errors.New("Invalid dilithium private key. It must be different from nil.")
+		}
+		raw, err := dilithium.MarshalPKCS8PrivateKey(k)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling dilithium key to asn1 [%s]", err)
+		}
+
+		return pem.EncodeToMemory(
+			&pem.Block{
+				Type:  "PRIVATE KEY",
+				Bytes: raw,
+			},
+		), nil
 	default:
 		return nil, errors.New("Invalid key type. It must be *ecdsa.PrivateKey or *rsa.PrivateKey")
 	}
@@ -202,6 +219,10 @@ func DERToPrivateKey(der []byte) (key interface{}, err error) {
 		return
 	}
 
+	if key, err = dilithium.ParsePKCS8PrivateKey(der); err == nil {
+		return
+	}
+
 	return nil, errors.New("Invalid key type. The DER must contain an rsa.PrivateKey or ecdsa.PrivateKey")
 }
 
@@ -336,6 +357,22 @@ func PublicKeyToPEM(publicKey interface{}, pwd []byte) ([]byte, error) {
 			},
 		), nil
 
+	case *dilithium.PublicKey:
+		if k == nil {
+			return nil, errors.New("Invalid dilithium public key. It must be different from nil.")
+		}
+		PubASN1, err := dilithium.MarshalPKIXPublicKey(k)
+		if err != nil {
+			return nil, err
+		}
+
+		return pem.EncodeToMemory(
+			&pem.Block{
+				Type:  "PUBLIC KEY",
+				Bytes: PubASN1,
+			},
+		), nil
+
 	default:
 		return nil, errors.New("Invalid key type. It must be *ecdsa.PublicKey or *rsa.PublicKey")
 	}
@@ -370,6 +407,12 @@ func PublicKeyToDER(publicKey interface{}) ([]byte, error) {
 
 		return PubASN1, nil
 
+	case *dilithium.PublicKey:
+		if k == nil {
+			return nil, errors.New("Invalid dilithium public key. It must be different from nil.")
+		}
+		return dilithium.MarshalPKIXPublicKey(k)
+
 	default:
 		return nil, errors.New("Invalid key type. It must be *ecdsa.PublicKey or *rsa.PublicKey")
 	}
@@ -454,6 +497,11 @@ func DERToPublicKey(raw []byte) (pub interface{}, err error) {
 	}
 
 	key, err := x509.ParsePKIXPublicKey(raw)
+	if err != nil {
+		if dilithiumKey, dilithiumErr := dilithium.ParsePKIXPublicKey(raw); dilithiumErr == nil {
+			return dilithiumKey, nil
+		}
+	}
 
 	return key, err
 }
@@ -26,6 +26,7 @@ import (
 	"encoding/pem"
 	"testing"
 
+	"github.com/hyperledger/fabric/bccsp/dilithium"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -344,6 +345,32 @@ func TestAESKey(t *testing.T) {
 	assert.Equal(t, k, k2)
 }
 
+func TestDilithiumKeys(t *testing.T) {
+	key, err := dilithium.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	pemKey, err := PrivateKeyToPEM(key, nil)
+	assert.NoError(t, err)
+	keyFromPEM, err := PEMtoPrivateKey(pemKey, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, key.Bytes(), keyFromPEM.(*dilithium.PrivateKey).Bytes())
+
+	pemPub, err := PublicKeyToPEM(key.Public(), nil)
+	assert.NoError(t, err)
+	pubFromPEM, err := PEMtoPublicKey(pemPub, nil)
+	assert.NoError(t, err)
+	assert.True(t, key.Public().Equal(pubFromPEM.(*dilithium.PublicKey)))
+
+	der, err := PublicKeyToDER(key.Public())
+	assert.NoError(t, err)
+	pub, err := DERToPublicKey(der)
+	assert.NoError(t, err)
+	assert.True(t, key.Public().Equal(pub.(*dilithium.PublicKey)))
+
+	_, err = DERToPublicKey(der[1:])
+	assert.Error(t, err)
+}
+
 func TestDERToPublicKey(t *testing.T) {
 	_, err := DERToPublicKey(nil)
 	assert.Error(t, err)
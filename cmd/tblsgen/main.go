@@ -0,0 +1,208 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+// tblsgen deals the threshold BLS key shares of a consenter set, with which
+// the orderers sign the headers of the blocks they write, combines the
+// signature shares of the copies of a block written by distinct consenters
+// into the signature of the consenter set, and verifies it with the public
+// key of the consenter set.
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto/tbls"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const (
+	publicKeyFile   = "PublicKey"
+	publicSharesDir = "publicshares"
+	keySharesDir    = "keyshares"
+)
+
+// command line flags
+var (
+	app = kingpin.New("tblsgen", "Utility for dealing and using the threshold signing keys of a consenter set")
+
+	deal          = app.Command("deal", "Deal the key shares of a new consenter set")
+	dealThreshold = deal.Flag("threshold", "The number of signature shares combining into a signature").Required().Int()
+	dealShares    = deal.Flag("shares", "The number of consenters").Required().Int()
+	dealOutput    = deal.Flag("output", "The directory to write the keys to").Default("tbls-config").String()
+
+	combine             = app.Command("combine", "Combine the signature shares of the copies of a block written by distinct consenters")
+	combineThreshold    = combine.Flag("threshold", "The number of signature shares combining into a signature").Required().Int()
+	combinePublicShares = combine.Flag("publicShares", "The directory of the public keys of the key shares").Required().String()
+	combineBlocks       = combine.Flag("block", "A file containing a copy of the block, repeated for each copy").Required().Strings()
+	combineSignature    = combine.Flag("signature", "The file to write the signature to").Required().String()
+
+	verify          = app.Command("verify", "Verify the signature of the consenter set of a block header")
+	verifyPublicKey = verify.Flag("publicKey", "The file containing the public key of the consenter set").Required().String()
+	verifyBlock     = verify.Flag("block", "The file containing the block").Required().String()
+	verifySignature = verify.Flag("signature", "The file containing the signature").Required().String()
+)
+
+func main() {
+	app.HelpFlag.Short('h')
+
+	switch kingpin.MustParse(app.Parse(os.Args[1:])) {
+
+	case deal.FullCommand():
+		handleError(dealKeys(*dealThreshold, *dealShares, *dealOutput))
+
+	case combine.FullCommand():
+		handleError(combineSignatures(*combineThreshold, *combinePublicShares, *combineBlocks, *combineSignature))
+
+	case verify.FullCommand():
+		valid, err := verifyBlockHeader(*verifyPublicKey, *verifyBlock, *verifySignature)
+		handleError(err)
+		if !valid {
+			fmt.Fprintln(os.Stderr, "The signature of the block header is invalid")
+			os.Exit(1)
+		}
+		fmt.Println("The signature of the block header is valid")
+	}
+}
+
+// dealKeys writes the public key of a new consenter set to the output
+// directory, the key share of consenter i to keyshares/i, and its public key
+// to publicshares/i.
+func dealKeys(threshold, shares int, output string) error {
+	if _, err := os.Stat(output); err == nil {
+		return errors.Errorf("directory %s already exists", output)
+	}
+	publicKey, keyShares, publicShares, err := tbls.Deal(threshold, shares)
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range []string{keySharesDir, publicSharesDir} {
+		if err := os.MkdirAll(filepath.Join(output, dir), 0700); err != nil {
+			return errors.Wrapf(err, "failed creating directory %s", dir)
+		}
+	}
+	if err := writeFile(filepath.Join(output, publicKeyFile), publicKey.Bytes()); err != nil {
+		return err
+	}
+	for i := range keyShares {
+		name := strconv.Itoa(i + 1)
+		if err := writeFile(filepath.Join(output, keySharesDir, name), keyShares[i].Bytes()); err != nil {
+			return err
+		}
+		if err := writeFile(filepath.Join(output, publicSharesDir, name), publicShares[i].Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// combineSignatures writes the signature of the consenter set combined from
+// the signature shares of the blocks.
+func combineSignatures(threshold int, publicSharesPath string, blockFiles []string, signatureFile string) error {
+	publicShares, err := readPublicShares(publicSharesPath)
+	if err != nil {
+		return err
+	}
+	blocks := make([]*cb.Block, len(blockFiles))
+	for i, file := range blockFiles {
+		if blocks[i], err = readBlock(file); err != nil {
+			return err
+		}
+	}
+	signature, err := tbls.CombineBlockSignatures(threshold, publicShares, blocks)
+	if err != nil {
+		return errors.WithMessage(err, "failed combining the signature shares")
+	}
+	return writeFile(signatureFile, signature.Bytes())
+}
+
+// verifyBlockHeader returns true if the signature is the signature of the
+// header of the block by the consenter set of the public key.
+func verifyBlockHeader(publicKeyFile, blockFile, signatureFile string) (bool, error) {
+	encoded, err := ioutil.ReadFile(publicKeyFile)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed reading %s", publicKeyFile)
+	}
+	publicKey, err := tbls.PublicKeyFromBytes(encoded)
+	if err != nil {
+		return false, err
+	}
+	block, err := readBlock(blockFile)
+	if err != nil {
+		return false, err
+	}
+	if block.Header == nil {
+		return false, errors.Errorf("block in %s has no header", blockFile)
+	}
+	encoded, err = ioutil.ReadFile(signatureFile)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed reading %s", signatureFile)
+	}
+	signature, err := tbls.SignatureFromBytes(encoded)
+	if err != nil {
+		return false, err
+	}
+	return tbls.VerifyBlockHeader(publicKey, block.Header, signature), nil
+}
+
+// readPublicShares reads the public keys of the key shares, from the file 1
+// up to the first missing one.
+func readPublicShares(dir string) ([]*tbls.PublicKey, error) {
+	var publicShares []*tbls.PublicKey
+	for i := 1; ; i++ {
+		path := filepath.Join(dir, strconv.Itoa(i))
+		encoded, err := ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed reading %s", path)
+		}
+		publicShare, err := tbls.PublicKeyFromBytes(encoded)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("failed loading %s", path))
+		}
+		publicShares = append(publicShares, publicShare)
+	}
+	if len(publicShares) == 0 {
+		return nil, errors.Errorf("no public keys of key shares in %s", dir)
+	}
+	return publicShares, nil
+}
+
+func readBlock(path string) (*cb.Block, error) {
+	encoded, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading %s", path)
+	}
+	block := &cb.Block{}
+	if err := proto.Unmarshal(encoded, block); err != nil {
+		return nil, errors.Wrapf(err, "failed unmarshaling block in %s", path)
+	}
+	return block, nil
+}
+
+func writeFile(path string, data []byte) error {
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		return errors.Wrapf(err, "failed writing %s", path)
+	}
+	return nil
+}
+
+func handleError(err error) {
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto/tbls"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSignedBlock writes the copy of block 7 written by the consenter of the
+// key share in the directory, and returns its path.
+func writeSignedBlock(t *testing.T, dir, keyShareFile string) string {
+	encoded, err := ioutil.ReadFile(keyShareFile)
+	require.NoError(t, err)
+	keyShare, err := tbls.KeyShareFromBytes(encoded)
+	require.NoError(t, err)
+
+	block := cb.NewBlock(7, []byte("previous-hash"))
+	block.Header.DataHash = []byte("data-hash")
+	metadata, err := proto.Marshal(&cb.Metadata{Value: tbls.SignBlockHeader(keyShare, block.Header)})
+	require.NoError(t, err)
+	block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = metadata
+
+	encoded, err = proto.Marshal(block)
+	require.NoError(t, err)
+	path := filepath.Join(dir, "block-"+filepath.Base(keyShareFile))
+	require.NoError(t, ioutil.WriteFile(path, encoded, 0600))
+	return path
+}
+
+func TestDealCombineVerify(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tblsgen")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	output := filepath.Join(dir, "tbls-config")
+
+	require.NoError(t, dealKeys(2, 3, output))
+	for _, name := range []string{"PublicKey", "keyshares/1", "keyshares/3", "publicshares/1", "publicshares/3"} {
+		assert.FileExists(t, filepath.Join(output, name))
+	}
+	assert.EqualError(t, dealKeys(2, 3, output), "directory "+output+" already exists")
+
+	blocks := []string{
+		writeSignedBlock(t, dir, filepath.Join(output, "keyshares", "3")),
+		writeSignedBlock(t, dir, filepath.Join(output, "keyshares", "1")),
+	}
+	signature := filepath.Join(dir, "signature")
+	require.NoError(t, combineSignatures(2, filepath.Join(output, "publicshares"), blocks, signature))
+
+	valid, err := verifyBlockHeader(filepath.Join(output, "PublicKey"), blocks[0], signature)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	t.Run("requires threshold copies", func(t *testing.T) {
+		err := combineSignatures(2, filepath.Join(output, "publicshares"), blocks[:1], signature)
+		assert.EqualError(t, err, "failed combining the signature shares: 1 signature shares of distinct members, 2 required")
+	})
+
+	t.Run("rejects the signature of another consenter set", func(t *testing.T) {
+		other := filepath.Join(dir, "other")
+		require.NoError(t, dealKeys(2, 3, other))
+		valid, err := verifyBlockHeader(filepath.Join(other, "PublicKey"), blocks[0], signature)
+		require.NoError(t, err)
+		assert.False(t, valid)
+	})
+
+	t.Run("requires public shares", func(t *testing.T) {
+		err := combineSignatures(2, dir, blocks, signature)
+		assert.EqualError(t, err, "no public keys of key shares in "+dir)
+	})
+}
+
+func TestDealInvalidThreshold(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tblsgen")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	err = dealKeys(4, 3, filepath.Join(dir, "tbls-config"))
+	assert.EqualError(t, err, "invalid threshold 4 of 3 shares")
+}
@@ -0,0 +1,133 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+// tokencrosscheck reads the blocks of a channel from two peers, replays their
+// token transactions and reports the first block on which the peers disagree
+// on the validity of a transaction or on the balances of an owner and token
+// type. It helps debugging suspected nondeterminism in token validation.
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/cmd/common/comm"
+	"github.com/hyperledger/fabric/cmd/common/signer"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/peerblocks"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/token/crosscheck"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+var logger = flogging.MustGetLogger("tokencrosscheck")
+
+// command line flags
+var (
+	app = kingpin.New("tokencrosscheck", "Cross-checks the token ledgers of two peers of a channel")
+
+	channel    = app.Flag("channel", "The channel to check").Required().String()
+	peerA      = app.Flag("peerA", "The address of the first peer").Required().String()
+	peerB      = app.Flag("peerB", "The address of the second peer").Required().String()
+	lastBlock  = app.Flag("to", "The number of the last block to check, committed by both peers").Required().Uint64()
+	mspID      = app.Flag("mspid", "The MSP ID of the client identity").Required().String()
+	userCert   = app.Flag("userCert", "Path to the certificate of the client identity").Required().String()
+	userKey    = app.Flag("userKey", "Path to the private key of the client identity").Required().String()
+	peerATLSCA = app.Flag("peerATLSCA", "Path to the TLS root CA certificate of the first peer, enables TLS").String()
+	peerBTLSCA = app.Flag("peerBTLSCA", "Path to the TLS root CA certificate of the second peer, enables TLS").String()
+	tlsCert    = app.Flag("tlsCert", "Path to the client TLS certificate").String()
+	tlsKey     = app.Flag("tlsKey", "Path to the client TLS key").String()
+)
+
+func main() {
+	app.HelpFlag.Short('h')
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+
+	factory.InitFactories(nil)
+
+	s, err := signer.NewSigner(signer.Config{
+		MSPID:        *mspID,
+		IdentityPath: *userCert,
+		KeyPath:      *userKey,
+	})
+	if err != nil {
+		logger.Fatalf("%s", err)
+	}
+
+	blocksA, err := newPeerBlocks(s, *peerA, *peerATLSCA)
+	if err != nil {
+		logger.Fatalf("%s", err)
+	}
+	blocksB, err := newPeerBlocks(s, *peerB, *peerBTLSCA)
+	if err != nil {
+		logger.Fatalf("%s", err)
+	}
+
+	checker := &crosscheck.Checker{
+		A: crosscheck.Peer{Name: *peerA, Blocks: blocksA},
+		B: crosscheck.Peer{Name: *peerB, Blocks: blocksB},
+	}
+	divergence, err := checker.Check(*lastBlock)
+	if err != nil {
+		logger.Fatalf("failed cross-checking channel %s: %s", *channel, err)
+	}
+	if divergence != nil {
+		fmt.Println(checker.Report(divergence))
+		os.Exit(1)
+	}
+	fmt.Printf("token ledgers of %s and %s match up to block %d\n", *peerA, *peerB, *lastBlock)
+}
+
+func newPeerBlocks(s *signer.Signer, address, peerTLSCA string) (*peerblocks.Provider, error) {
+	client, err := comm.NewClient(comm.Config{
+		CertPath:       *tlsCert,
+		KeyPath:        *tlsKey,
+		PeerCACertPath: peerTLSCA,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var tlsCertHash []byte
+	if peerTLSCA != "" {
+		tlsCertHash = client.TLSCertHash
+	}
+
+	return &peerblocks.Provider{
+		Channel:     *channel,
+		Signer:      &localSigner{Signer: s},
+		TLSCertHash: tlsCertHash,
+		Connect: func(ctx context.Context) (peerblocks.DeliverStream, error) {
+			conn, err := client.NewConnection(address, "")
+			if err != nil {
+				return nil, err
+			}
+			go func() {
+				<-ctx.Done()
+				conn.Close()
+			}()
+			return pb.NewDeliverClient(conn).Deliver(ctx)
+		},
+	}, nil
+}
+
+// localSigner adapts the command line signer to a crypto.LocalSigner.
+type localSigner struct {
+	*signer.Signer
+}
+
+func (l *localSigner) NewSignatureHeader() (*cb.SignatureHeader, error) {
+	nonce, err := crypto.GetRandomNonce()
+	if err != nil {
+		return nil, err
+	}
+	return &cb.SignatureHeader{Creator: l.Creator, Nonce: nonce}, nil
+}
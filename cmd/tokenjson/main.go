@@ -0,0 +1,111 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+// tokenjson converts token messages, such as TokenTransaction or Command,
+// between their protobuf encoding and canonical JSON, for debugging and for
+// tools that do not link the token protos.
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// command line flags
+var (
+	app = kingpin.New("tokenjson", "Converts token messages between protobuf and canonical JSON")
+
+	bytesEncoding = app.Flag("bytes", "The encoding of bytes fields in JSON: base64 or hex").Default(string(token.Base64Bytes)).Enum(string(token.Base64Bytes), string(token.HexBytes))
+
+	encode       = app.Command("encode", "Converts a JSON document to a protobuf token message.")
+	encodeType   = encode.Flag("type", "The type of the token message, for example 'TokenTransaction'.").Required().String()
+	encodeSource = encode.Flag("input", "A file containing the JSON document.").Default(os.Stdin.Name()).File()
+	encodeDest   = encode.Flag("output", "A file to write the protobuf message to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
+	decode       = app.Command("decode", "Converts a protobuf token message to JSON.")
+	decodeType   = decode.Flag("type", "The type of the token message, for example 'TokenTransaction'.").Required().String()
+	decodeIndent = decode.Flag("indent", "Indent the JSON document.").Bool()
+	decodeSource = decode.Flag("input", "A file containing the protobuf message.").Default(os.Stdin.Name()).File()
+	decodeDest   = decode.Flag("output", "A file to write the JSON document to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+)
+
+func main() {
+	app.HelpFlag.Short('h')
+	switch kingpin.MustParse(app.Parse(os.Args[1:])) {
+	case encode.FullCommand():
+		defer (*encodeSource).Close()
+		defer (*encodeDest).Close()
+		codec := &token.JSONCodec{Bytes: token.BytesEncoding(*bytesEncoding)}
+		if err := encodeMessage(codec, *encodeType, *encodeSource, *encodeDest); err != nil {
+			app.Fatalf("Error encoding: %s", err)
+		}
+	case decode.FullCommand():
+		defer (*decodeSource).Close()
+		defer (*decodeDest).Close()
+		codec := &token.JSONCodec{Bytes: token.BytesEncoding(*bytesEncoding)}
+		if *decodeIndent {
+			codec.Indent = "  "
+		}
+		if err := decodeMessage(codec, *decodeType, *decodeSource, *decodeDest); err != nil {
+			app.Fatalf("Error decoding: %s", err)
+		}
+	}
+}
+
+func encodeMessage(codec *token.JSONCodec, msgName string, input, output *os.File) error {
+	msg, err := token.NewMessage(msgName)
+	if err != nil {
+		return err
+	}
+
+	in, err := ioutil.ReadAll(input)
+	if err != nil {
+		return errors.Wrapf(err, "error reading input")
+	}
+	if err := codec.Unmarshal(in, msg); err != nil {
+		return err
+	}
+
+	out, err := proto.Marshal(msg)
+	if err != nil {
+		return errors.Wrapf(err, "error marshaling")
+	}
+	if _, err := output.Write(out); err != nil {
+		return errors.Wrapf(err, "error writing output")
+	}
+	return nil
+}
+
+func decodeMessage(codec *token.JSONCodec, msgName string, input, output *os.File) error {
+	msg, err := token.NewMessage(msgName)
+	if err != nil {
+		return err
+	}
+
+	in, err := ioutil.ReadAll(input)
+	if err != nil {
+		return errors.Wrapf(err, "error reading input")
+	}
+	if err := proto.Unmarshal(in, msg); err != nil {
+		return errors.Wrapf(err, "error unmarshaling")
+	}
+
+	out, err := codec.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(output, "%s\n", out); err != nil {
+		return errors.Wrapf(err, "error writing output")
+	}
+	return nil
+}
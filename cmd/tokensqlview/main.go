@@ -0,0 +1,198 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+// tokensqlview follows the blocks of a channel from a peer and maintains a
+// relational view of token owners, outputs and transfers in Postgres. Balance
+// and history queries are served over HTTP from that view, and optionally
+// through a read-only GraphQL endpoint authenticated with mutual TLS.
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/cmd/common/comm"
+	"github.com/hyperledger/fabric/cmd/common/signer"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/peerblocks"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/token/graphql"
+	"github.com/hyperledger/fabric/token/sqlview"
+	_ "github.com/lib/pq"
+	"github.com/pkg/errors"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+var logger = flogging.MustGetLogger("tokensqlview")
+
+// command line flags
+var (
+	app = kingpin.New("tokensqlview", "Maintains an off-chain SQL view of token balances for a channel")
+
+	channel       = app.Flag("channel", "The channel to follow").Required().String()
+	peerAddress   = app.Flag("peer", "The address of the peer to read blocks from").Required().String()
+	mspID         = app.Flag("mspid", "The MSP ID of the client identity").Required().String()
+	userCert      = app.Flag("userCert", "Path to the certificate of the client identity").Required().String()
+	userKey       = app.Flag("userKey", "Path to the private key of the client identity").Required().String()
+	peerTLSCA     = app.Flag("peerTLSCA", "Path to the TLS root CA certificate of the peer, enables TLS").String()
+	tlsCert       = app.Flag("tlsCert", "Path to the client TLS certificate").String()
+	tlsKey        = app.Flag("tlsKey", "Path to the client TLS key").String()
+	database      = app.Flag("database", "The Postgres connection string").Required().String()
+	listenAddress = app.Flag("listen", "The address to serve balance and history queries on").Default("127.0.0.1:9450").String()
+	retryInterval = app.Flag("retryInterval", "How long to wait before retrying after a failure").Default("5s").Duration()
+
+	graphqlListen   = app.Flag("graphqlListen", "The address to serve GraphQL queries on, disabled when empty").String()
+	graphqlTLSCert  = app.Flag("graphqlTLSCert", "Path to the TLS certificate of the GraphQL server").String()
+	graphqlTLSKey   = app.Flag("graphqlTLSKey", "Path to the TLS key of the GraphQL server").String()
+	graphqlClientCA = app.Flag("graphqlClientCA", "Path to the CA certificate that issues GraphQL client certificates").String()
+	graphqlMSPID    = app.Flag("graphqlMSPID", "The MSP ID of the GraphQL client identities").String()
+	auditors        = app.Flag("auditor", "Hex encoded identity allowed to query any owner over GraphQL").Strings()
+)
+
+func main() {
+	app.HelpFlag.Short('h')
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+
+	factory.InitFactories(nil)
+
+	db, err := sql.Open("postgres", *database)
+	if err != nil {
+		logger.Fatalf("failed opening database: %s", err)
+	}
+	defer db.Close()
+
+	view := &sqlview.View{DB: db}
+	err = view.CreateSchema()
+	if err != nil {
+		logger.Fatalf("%s", err)
+	}
+
+	blocks, err := newPeerBlocks()
+	if err != nil {
+		logger.Fatalf("%s", err)
+	}
+
+	go func() {
+		err := http.ListenAndServe(*listenAddress, sqlview.NewHandler(view))
+		logger.Fatalf("query server stopped: %s", err)
+	}()
+
+	if *graphqlListen != "" {
+		server, err := newGraphQLServer(view)
+		if err != nil {
+			logger.Fatalf("%s", err)
+		}
+		go func() {
+			err := server.ListenAndServeTLS(*graphqlTLSCert, *graphqlTLSKey)
+			logger.Fatalf("graphql server stopped: %s", err)
+		}()
+	}
+
+	for {
+		err = view.Follow(*channel, blocks, *retryInterval, nil)
+		if err != nil {
+			logger.Warningf("failed following channel %s, reconnecting in %s: %s", *channel, *retryInterval, err)
+		}
+		time.Sleep(*retryInterval)
+	}
+}
+
+func newGraphQLServer(view *sqlview.View) (*http.Server, error) {
+	caPEM, err := ioutil.ReadFile(*graphqlClientCA)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed reading GraphQL client CA")
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caPEM) {
+		return nil, errors.Errorf("no certificates found in %s", *graphqlClientCA)
+	}
+
+	authenticator := &graphql.CertificateAuthenticator{MSPID: *graphqlMSPID}
+	for _, auditor := range *auditors {
+		identity, err := hex.DecodeString(auditor)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid auditor identity %s", auditor)
+		}
+		authenticator.Auditors = append(authenticator.Auditors, identity)
+	}
+
+	backend := &graphql.ViewBackend{View: view, Channel: *channel}
+	return &http.Server{
+		Addr:    *graphqlListen,
+		Handler: graphql.NewHandler(backend, authenticator),
+		TLSConfig: &tls.Config{
+			ClientAuth: tls.RequireAndVerifyClientCert,
+			ClientCAs:  clientCAs,
+			MinVersion: tls.VersionTLS12,
+		},
+	}, nil
+}
+
+func newPeerBlocks() (*peerblocks.Provider, error) {
+	s, err := signer.NewSigner(signer.Config{
+		MSPID:        *mspID,
+		IdentityPath: *userCert,
+		KeyPath:      *userKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := comm.NewClient(comm.Config{
+		CertPath:       *tlsCert,
+		KeyPath:        *tlsKey,
+		PeerCACertPath: *peerTLSCA,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var tlsCertHash []byte
+	if *peerTLSCA != "" {
+		tlsCertHash = client.TLSCertHash
+	}
+
+	return &peerblocks.Provider{
+		Channel:     *channel,
+		Signer:      &localSigner{Signer: s},
+		TLSCertHash: tlsCertHash,
+		Connect: func(ctx context.Context) (peerblocks.DeliverStream, error) {
+			conn, err := client.NewConnection(*peerAddress, "")
+			if err != nil {
+				return nil, err
+			}
+			go func() {
+				<-ctx.Done()
+				conn.Close()
+			}()
+			return pb.NewDeliverClient(conn).Deliver(ctx)
+		},
+	}, nil
+}
+
+// localSigner adapts the command line signer to a crypto.LocalSigner.
+type localSigner struct {
+	*signer.Signer
+}
+
+func (l *localSigner) NewSignatureHeader() (*cb.SignatureHeader, error) {
+	nonce, err := crypto.GetRandomNonce()
+	if err != nil {
+		return nil, err
+	}
+	return &cb.SignatureHeader{Creator: l.Creator, Nonce: nonce}, nil
+}
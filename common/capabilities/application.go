@@ -27,6 +27,17 @@ const (
 
 	// ApplicationResourcesTreeExperimental is the capabilties string for private data using the experimental feature of collections/sideDB.
 	ApplicationResourcesTreeExperimental = "V1_1_RESOURCETREE_EXPERIMENTAL"
+
+	// ApplicationWASMExperimental is the capabilities string for the experimental WebAssembly chaincode runtime.
+	ApplicationWASMExperimental = "V1_4_WASM_EXPERIMENTAL"
+
+	// ApplicationTokenEncryptionExperimental is the capabilities string for the experimental
+	// encryption of token transactions to the committing peers.
+	ApplicationTokenEncryptionExperimental = "V1_4_TOKEN_ENCRYPTION_EXPERIMENTAL"
+
+	// ApplicationTxExpirationExperimental is the capabilities string for the experimental
+	// expiration of transactions at a block height or time.
+	ApplicationTxExpirationExperimental = "V1_4_TX_EXPIRATION_EXPERIMENTAL"
 )
 
 // ApplicationProvider provides capabilities information for application level config.
@@ -36,6 +47,9 @@ type ApplicationProvider struct {
 	v12                    bool
 	v13                    bool
 	v11PvtDataExperimental bool
+	wasmExperimental       bool
+	tokenEncryption        bool
+	txExpiration           bool
 }
 
 // NewApplicationProvider creates a application capabilities provider.
@@ -46,6 +60,9 @@ func NewApplicationProvider(capabilities map[string]*cb.Capability) *Application
 	_, ap.v12 = capabilities[ApplicationV1_2]
 	_, ap.v13 = capabilities[ApplicationV1_3]
 	_, ap.v11PvtDataExperimental = capabilities[ApplicationPvtDataExperimental]
+	_, ap.wasmExperimental = capabilities[ApplicationWASMExperimental]
+	_, ap.tokenEncryption = capabilities[ApplicationTokenEncryptionExperimental]
+	_, ap.txExpiration = capabilities[ApplicationTxExpirationExperimental]
 	return ap
 }
 
@@ -114,6 +131,24 @@ func (ap *ApplicationProvider) FabToken() bool {
 	return false
 }
 
+// WASMChaincode returns true if WebAssembly chaincode may be deployed on this channel.
+// The WebAssembly runtime is experimental and has to be enabled explicitly.
+func (ap *ApplicationProvider) WASMChaincode() bool {
+	return ap.wasmExperimental
+}
+
+// TokenEncryption returns true if the token transactions of this channel may be
+// encrypted to the committing peers, so that the orderers cannot read them.
+func (ap *ApplicationProvider) TokenEncryption() bool {
+	return ap.tokenEncryption
+}
+
+// TxExpiration returns true if the transactions of this channel are invalidated
+// once the block height or time they may be committed until has passed.
+func (ap *ApplicationProvider) TxExpiration() bool {
+	return ap.txExpiration
+}
+
 // HasCapability returns true if the capability is supported by this binary.
 func (ap *ApplicationProvider) HasCapability(capability string) bool {
 	switch capability {
@@ -128,6 +163,12 @@ func (ap *ApplicationProvider) HasCapability(capability string) bool {
 		return true
 	case ApplicationResourcesTreeExperimental:
 		return true
+	case ApplicationWASMExperimental:
+		return true
+	case ApplicationTokenEncryptionExperimental:
+		return true
+	case ApplicationTxExpirationExperimental:
+		return true
 	default:
 		return false
 	}
@@ -67,6 +67,42 @@ func TestFabToken(t *testing.T) {
 	assert.False(t, ap.FabToken())
 }
 
+func TestApplicationWASMExperimental(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{})
+	assert.False(t, ap.WASMChaincode())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3:             {},
+		ApplicationWASMExperimental: {},
+	})
+	assert.NoError(t, ap.Supported())
+	assert.True(t, ap.WASMChaincode())
+}
+
+func TestApplicationTokenEncryptionExperimental(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{})
+	assert.False(t, ap.TokenEncryption())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3:                        {},
+		ApplicationTokenEncryptionExperimental: {},
+	})
+	assert.NoError(t, ap.Supported())
+	assert.True(t, ap.TokenEncryption())
+}
+
+func TestApplicationTxExpirationExperimental(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{})
+	assert.False(t, ap.TxExpiration())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3:                     {},
+		ApplicationTxExpirationExperimental: {},
+	})
+	assert.NoError(t, ap.Supported())
+	assert.True(t, ap.TxExpiration())
+}
+
 func TestHasCapability(t *testing.T) {
 	ap := NewApplicationProvider(map[string]*cb.Capability{})
 	assert.True(t, ap.HasCapability(ApplicationV1_1))
@@ -74,5 +110,8 @@ func TestHasCapability(t *testing.T) {
 	assert.True(t, ap.HasCapability(ApplicationV1_3))
 	assert.True(t, ap.HasCapability(ApplicationPvtDataExperimental))
 	assert.True(t, ap.HasCapability(ApplicationResourcesTreeExperimental))
+	assert.True(t, ap.HasCapability(ApplicationWASMExperimental))
+	assert.True(t, ap.HasCapability(ApplicationTokenEncryptionExperimental))
+	assert.True(t, ap.HasCapability(ApplicationTxExpirationExperimental))
 	assert.False(t, ap.HasCapability("default"))
 }
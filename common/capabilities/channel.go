@@ -19,13 +19,24 @@ const (
 
 	// ChannelV1_3 is the capabilties string for standard new non-backwards compatible fabric v1.3 channel capabilities.
 	ChannelV1_3 = "V1_3"
+
+	// ChannelHashingSuiteExperimental is the capabilities string for hashing the blocks and computing
+	// the transaction IDs of the channel with its hashing algorithm. It is not supported on etcdraft
+	// channels, as cluster replication verifies their blocks with SHA256.
+	ChannelHashingSuiteExperimental = "V1_4_HASHING_SUITE_EXPERIMENTAL"
+
+	// ChannelPostQuantumExperimental is the capabilities string for requiring the post-quantum
+	// signatures of the identities of hybrid certificates, on top of the v1.3 channel capabilities.
+	ChannelPostQuantumExperimental = "V1_4_PQ_EXPERIMENTAL"
 )
 
 // ChannelProvider provides capabilities information for channel level config.
 type ChannelProvider struct {
 	*registry
-	v11 bool
-	v13 bool
+	v11          bool
+	v13          bool
+	hashingSuite bool
+	postQuantum  bool
 }
 
 // NewChannelProvider creates a channel capabilities provider.
@@ -34,6 +45,8 @@ func NewChannelProvider(capabilities map[string]*cb.Capability) *ChannelProvider
 	cp.registry = newRegistry(cp, capabilities)
 	_, cp.v11 = capabilities[ChannelV1_1]
 	_, cp.v13 = capabilities[ChannelV1_3]
+	_, cp.hashingSuite = capabilities[ChannelHashingSuiteExperimental]
+	_, cp.postQuantum = capabilities[ChannelPostQuantumExperimental]
 	return cp
 }
 
@@ -46,6 +59,10 @@ func (cp *ChannelProvider) Type() string {
 func (cp *ChannelProvider) HasCapability(capability string) bool {
 	switch capability {
 	// Add new capability names here
+	case ChannelPostQuantumExperimental:
+		return true
+	case ChannelHashingSuiteExperimental:
+		return true
 	case ChannelV1_3:
 		return true
 	case ChannelV1_1:
@@ -58,6 +75,8 @@ func (cp *ChannelProvider) HasCapability(capability string) bool {
 // MSPVersion returns the level of MSP support required by this channel.
 func (cp *ChannelProvider) MSPVersion() msp.MSPVersion {
 	switch {
+	case cp.postQuantum:
+		return msp.MSPv1_4_PQ
 	case cp.v13:
 		return msp.MSPv1_3
 	case cp.v11:
@@ -66,3 +85,9 @@ func (cp *ChannelProvider) MSPVersion() msp.MSPVersion {
 		return msp.MSPv1_0
 	}
 }
+
+// HashingSuite returns true if the blocks and the transaction IDs of this channel are hashed with
+// the hashing algorithm of the channel, instead of SHA256.
+func (cp *ChannelProvider) HashingSuite() bool {
+	return cp.hashingSuite
+}
@@ -18,6 +18,7 @@ func TestChannelV10(t *testing.T) {
 	op := NewChannelProvider(map[string]*cb.Capability{})
 	assert.NoError(t, op.Supported())
 	assert.True(t, op.MSPVersion() == msp.MSPv1_0)
+	assert.False(t, op.HashingSuite())
 }
 
 func TestChannelV11(t *testing.T) {
@@ -36,3 +37,21 @@ func TestChannelV13(t *testing.T) {
 	assert.NoError(t, op.Supported())
 	assert.True(t, op.MSPVersion() == msp.MSPv1_3)
 }
+
+func TestChannelHashingSuite(t *testing.T) {
+	op := NewChannelProvider(map[string]*cb.Capability{
+		ChannelV1_3:                     {},
+		ChannelHashingSuiteExperimental: {},
+	})
+	assert.NoError(t, op.Supported())
+	assert.True(t, op.HashingSuite())
+}
+
+func TestChannelPostQuantum(t *testing.T) {
+	op := NewChannelProvider(map[string]*cb.Capability{
+		ChannelV1_3:                    {},
+		ChannelPostQuantumExperimental: {},
+	})
+	assert.NoError(t, op.Supported())
+	assert.True(t, op.MSPVersion() == msp.MSPv1_4_PQ)
+}
@@ -23,6 +23,17 @@ const (
 	// consensus-type migration commands. Migration is supported from Kafka to Raft only.
 	// If not present, these config updates will be rejected.
 	OrdererV2_0 = "V2_0"
+
+	// OrdererIngressQuotasExperimental is the capabilities string for the experimental
+	// per-organization ingress quotas of the orderer channel config. The orderers
+	// which predate them reject the configs with the IngressQuotas value.
+	OrdererIngressQuotasExperimental = "V1_4_INGRESS_QUOTAS_EXPERIMENTAL"
+
+	// OrdererPriorityExperimental is the capabilities string for the experimental
+	// priority ordering of the messages which satisfy the PriorityWriters policy of
+	// the orderer channel config. The orderers which predate it ignore the priority
+	// of the messages.
+	OrdererPriorityExperimental = "V1_4_PRIORITY_EXPERIMENTAL"
 )
 
 // OrdererProvider provides capabilities information for orderer level config.
@@ -30,6 +41,8 @@ type OrdererProvider struct {
 	*registry
 	v11BugFixes   bool
 	kafka2RaftMig bool
+	ingressQuotas bool
+	priority      bool
 }
 
 // NewOrdererProvider creates an orderer capabilities provider.
@@ -38,6 +51,8 @@ func NewOrdererProvider(capabilities map[string]*cb.Capability) *OrdererProvider
 	cp.registry = newRegistry(cp, capabilities)
 	_, cp.v11BugFixes = capabilities[OrdererV1_1]
 	_, cp.kafka2RaftMig = capabilities[OrdererV2_0]
+	_, cp.ingressQuotas = capabilities[OrdererIngressQuotasExperimental]
+	_, cp.priority = capabilities[OrdererPriorityExperimental]
 	return cp
 }
 
@@ -54,6 +69,10 @@ func (cp *OrdererProvider) HasCapability(capability string) bool {
 		return true
 	case OrdererV2_0:
 		return true
+	case OrdererIngressQuotasExperimental:
+		return true
+	case OrdererPriorityExperimental:
+		return true
 	default:
 		return false
 	}
@@ -81,3 +100,15 @@ func (cp *OrdererProvider) ExpirationCheck() bool {
 func (cp *OrdererProvider) Kafka2RaftMigration() bool {
 	return cp.kafka2RaftMig
 }
+
+// IngressQuotas specifies whether the orderer config may set per-organization
+// ingress quotas, and whether the orderer enforces them.
+func (cp *OrdererProvider) IngressQuotas() bool {
+	return cp.ingressQuotas
+}
+
+// Priority specifies whether the orderer honors the priority requested by the
+// messages, provided they satisfy the PriorityWriters policy of the channel.
+func (cp *OrdererProvider) Priority() bool {
+	return cp.priority
+}
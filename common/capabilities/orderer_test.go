@@ -45,6 +45,32 @@ func TestOrdererV20(t *testing.T) {
 	assert.True(t, op.Kafka2RaftMigration())
 }
 
+func TestOrdererIngressQuotasExperimental(t *testing.T) {
+	op := NewOrdererProvider(map[string]*cb.Capability{
+		OrdererV1_1: {},
+	})
+	assert.False(t, op.IngressQuotas())
+
+	op = NewOrdererProvider(map[string]*cb.Capability{
+		OrdererV1_1: {}, OrdererIngressQuotasExperimental: {},
+	})
+	assert.NoError(t, op.Supported())
+	assert.True(t, op.IngressQuotas())
+}
+
+func TestOrdererPriorityExperimental(t *testing.T) {
+	op := NewOrdererProvider(map[string]*cb.Capability{
+		OrdererV1_1: {},
+	})
+	assert.False(t, op.Priority())
+
+	op = NewOrdererProvider(map[string]*cb.Capability{
+		OrdererV1_1: {}, OrdererPriorityExperimental: {},
+	})
+	assert.NoError(t, op.Supported())
+	assert.True(t, op.Priority())
+}
+
 func TestNotSuported(t *testing.T) {
 	op := NewOrdererProvider(map[string]*cb.Capability{
 		OrdererV1_1: {}, OrdererV2_0: {}, "Bogus_Not_suported": {},
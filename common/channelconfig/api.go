@@ -32,6 +32,10 @@ type ApplicationOrg interface {
 
 	// AnchorPeers returns the list of gossip anchor peers
 	AnchorPeers() []*pb.AnchorPeer
+
+	// TokenEncryptionKey returns the DER encoded public key to which token
+	// transactions are encrypted for the peers of the org, or nil
+	TokenEncryptionKey() []byte
 }
 
 // Application stores the common shared application config
@@ -44,6 +48,21 @@ type Application interface {
 
 	// Capabilities defines the capabilities for the application portion of a channel
 	Capabilities() ApplicationCapabilities
+
+	// TokenSupply returns the limits on the issuance of tokens, by token type
+	TokenSupply() map[string]*pb.TokenSupplyLimit
+
+	// TokenTypeNamespaces returns the issuance policies of the hierarchical
+	// token types, by namespace prefix
+	TokenTypeNamespaces() map[string]*pb.TokenTypeNamespace
+
+	// TokenMetadataSchemas returns the schemas of the metadata of token
+	// outputs, by token type
+	TokenMetadataSchemas() map[string]*pb.TokenMetadataSchema
+
+	// TokenDriver returns the name of the driver of the token management
+	// system, empty when the channel uses the default one
+	TokenDriver() string
 }
 
 // Channel gives read only access to the channel configuration
@@ -52,6 +71,13 @@ type Channel interface {
 	// such as computing block hashes, and CreationPolicy digests
 	HashingAlgorithm() func(input []byte) []byte
 
+	// HashingSuite returns the algorithm hashing the blocks and computing the
+	// transaction IDs of the channel
+	HashingSuite() func(input []byte) []byte
+
+	// HashingSuiteName returns the name of the algorithm returned by HashingSuite
+	HashingSuiteName() string
+
 	// BlockDataHashingStructureWidth returns the width to use when constructing the
 	// Merkle tree to compute the BlockData hash
 	BlockDataHashingStructureWidth() uint32
@@ -106,6 +132,10 @@ type Orderer interface {
 	// used for ordering
 	KafkaBrokers() []string
 
+	// IngressQuotas returns the broadcast quotas keyed by the MSP ID of the
+	// organization they apply to
+	IngressQuotas() map[string]*ab.IngressQuota
+
 	// Organizations returns the organizations for the ordering service
 	Organizations() map[string]Org
 
@@ -121,6 +151,10 @@ type ChannelCapabilities interface {
 	// MSPVersion specifies the version of the MSP this channel must understand, including the MSP types
 	// and MSP principal types.
 	MSPVersion() msp.MSPVersion
+
+	// HashingSuite returns true if the blocks and the transaction IDs of this channel are hashed
+	// with the hashing algorithm of the channel, instead of SHA256.
+	HashingSuite() bool
 }
 
 // ApplicationCapabilities defines the capabilities for the application portion of a channel
@@ -169,6 +203,17 @@ type ApplicationCapabilities interface {
 
 	// FabToken returns true if this channel supports FabToken functions
 	FabToken() bool
+
+	// WASMChaincode returns true if WebAssembly chaincode may be deployed on this channel
+	WASMChaincode() bool
+
+	// TokenEncryption returns true if the token transactions of this channel
+	// may be encrypted to the committing peers
+	TokenEncryption() bool
+
+	// TxExpiration returns true if the transactions of this channel are
+	// invalidated once they have expired
+	TxExpiration() bool
 }
 
 // OrdererCapabilities defines the capabilities for the orderer portion of a channel
@@ -190,6 +235,14 @@ type OrdererCapabilities interface {
 
 	// Kafka2RaftMigration checks whether the orderer permits a Kafka to Raft migration.
 	Kafka2RaftMigration() bool
+
+	// IngressQuotas specifies whether the orderer config may set per-organization
+	// ingress quotas, and whether the orderer enforces them.
+	IngressQuotas() bool
+
+	// Priority specifies whether the orderer honors the priority requested by the
+	// messages, provided they satisfy the PriorityWriters policy of the channel.
+	Priority() bool
 }
 
 // PolicyMapper is an interface for
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package channelconfig
 
 import (
+	"strings"
+
 	"github.com/hyperledger/fabric/common/capabilities"
 	cb "github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
@@ -19,12 +21,31 @@ const (
 
 	// ACLsKey is the name of the ACLs config
 	ACLsKey = "ACLs"
+
+	// TokenSupplyKey is the name of the token supply limits config
+	TokenSupplyKey = "TokenSupply"
+
+	// TokenTypeNamespacesKey is the name of the token type namespaces config
+	TokenTypeNamespacesKey = "TokenTypeNamespaces"
+
+	// TokenMetadataSchemasKey is the name of the token metadata schemas config
+	TokenMetadataSchemasKey = "TokenMetadataSchemas"
+
+	// TokenDriverKey is the name of the token driver config
+	TokenDriverKey = "TokenDriver"
+
+	// maxTokenMetadataSize caps the maximum size of the metadata of token outputs
+	maxTokenMetadataSize = 64 * 1024
 )
 
 // ApplicationProtos is used as the source of the ApplicationConfig
 type ApplicationProtos struct {
-	ACLs         *pb.ACLs
-	Capabilities *cb.Capabilities
+	ACLs                 *pb.ACLs
+	Capabilities         *cb.Capabilities
+	TokenSupply          *pb.TokenSupply
+	TokenTypeNamespaces  *pb.TokenTypeNamespaces
+	TokenMetadataSchemas *pb.TokenMetadataSchemas
+	TokenDriver          *pb.TokenDriver
 }
 
 // ApplicationConfig implements the Application interface
@@ -50,12 +71,51 @@ func NewApplicationConfig(appGroup *cb.ConfigGroup, mspConfig *MSPConfigHandler)
 		}
 	}
 
+	if _, ok := appGroup.Values[TokenSupplyKey]; ok {
+		if !ac.Capabilities().FabToken() {
+			return nil, errors.New("TokenSupply may not be specified without the required capability")
+		}
+		if err := validateTokenSupply(ac.protos.TokenSupply); err != nil {
+			return nil, errors.WithMessage(err, "invalid TokenSupply")
+		}
+	}
+
+	if _, ok := appGroup.Values[TokenTypeNamespacesKey]; ok {
+		if !ac.Capabilities().FabToken() {
+			return nil, errors.New("TokenTypeNamespaces may not be specified without the required capability")
+		}
+		if err := validateTokenTypeNamespaces(ac.protos.TokenTypeNamespaces); err != nil {
+			return nil, errors.WithMessage(err, "invalid TokenTypeNamespaces")
+		}
+	}
+
+	if _, ok := appGroup.Values[TokenMetadataSchemasKey]; ok {
+		if !ac.Capabilities().FabToken() {
+			return nil, errors.New("TokenMetadataSchemas may not be specified without the required capability")
+		}
+		if err := validateTokenMetadataSchemas(ac.protos.TokenMetadataSchemas); err != nil {
+			return nil, errors.WithMessage(err, "invalid TokenMetadataSchemas")
+		}
+	}
+
+	if _, ok := appGroup.Values[TokenDriverKey]; ok {
+		if !ac.Capabilities().FabToken() {
+			return nil, errors.New("TokenDriver may not be specified without the required capability")
+		}
+		if err := validateTokenDriver(ac.protos.TokenDriver); err != nil {
+			return nil, errors.WithMessage(err, "invalid TokenDriver")
+		}
+	}
+
 	var err error
 	for orgName, orgGroup := range appGroup.Groups {
 		ac.applicationOrgs[orgName], err = NewApplicationOrgConfig(orgName, orgGroup, mspConfig)
 		if err != nil {
 			return nil, err
 		}
+		if ac.applicationOrgs[orgName].TokenEncryptionKey() != nil && !ac.Capabilities().TokenEncryption() {
+			return nil, errors.New("TokenEncryptionKey may not be specified without the required capability")
+		}
 	}
 
 	return ac, nil
@@ -77,3 +137,98 @@ func (ac *ApplicationConfig) APIPolicyMapper() PolicyMapper {
 
 	return pm
 }
+
+// TokenSupply returns the limits on the issuance of tokens, by token type
+func (ac *ApplicationConfig) TokenSupply() map[string]*pb.TokenSupplyLimit {
+	return ac.protos.TokenSupply.GetLimits()
+}
+
+// TokenTypeNamespaces returns the issuance policies of the hierarchical token
+// types, by namespace prefix
+func (ac *ApplicationConfig) TokenTypeNamespaces() map[string]*pb.TokenTypeNamespace {
+	return ac.protos.TokenTypeNamespaces.GetNamespaces()
+}
+
+// TokenMetadataSchemas returns the schemas of the metadata of token outputs,
+// by token type
+func (ac *ApplicationConfig) TokenMetadataSchemas() map[string]*pb.TokenMetadataSchema {
+	return ac.protos.TokenMetadataSchemas.GetSchemas()
+}
+
+// TokenDriver returns the name of the driver of the token management system,
+// empty when the channel uses the default one
+func (ac *ApplicationConfig) TokenDriver() string {
+	return ac.protos.TokenDriver.GetName()
+}
+
+func validateTokenSupply(supply *pb.TokenSupply) error {
+	for tokenType, limit := range supply.GetLimits() {
+		if limit == nil {
+			return errors.Errorf("limit for token type '%s' is nil", tokenType)
+		}
+		if limit.MaxPerPeriod == 0 {
+			if limit.PeriodBlocks != 0 {
+				return errors.Errorf("period_blocks for token type '%s' requires max_per_period", tokenType)
+			}
+			continue
+		}
+		if limit.PeriodBlocks == 0 {
+			return errors.Errorf("max_per_period for token type '%s' requires period_blocks", tokenType)
+		}
+	}
+	return nil
+}
+
+func validateTokenTypeNamespaces(namespaces *pb.TokenTypeNamespaces) error {
+	for prefix, namespace := range namespaces.GetNamespaces() {
+		for _, segment := range strings.Split(prefix, "/") {
+			if segment == "" {
+				return errors.Errorf("namespace '%s' has an empty segment", prefix)
+			}
+		}
+		if namespace == nil {
+			return errors.Errorf("policy for namespace '%s' is nil", prefix)
+		}
+		if len(namespace.Issuers) == 0 {
+			return errors.Errorf("namespace '%s' has no issuers", prefix)
+		}
+		for _, issuer := range namespace.Issuers {
+			if issuer == "" {
+				return errors.Errorf("namespace '%s' has an empty issuer", prefix)
+			}
+		}
+	}
+	return nil
+}
+
+func validateTokenMetadataSchemas(schemas *pb.TokenMetadataSchemas) error {
+	for tokenType, schema := range schemas.GetSchemas() {
+		if schema == nil {
+			return errors.Errorf("metadata schema for token type '%s' is nil", tokenType)
+		}
+		if schema.MaxSize > maxTokenMetadataSize {
+			return errors.Errorf("maximum metadata size %d of token type '%s' exceeds %d bytes", schema.MaxSize, tokenType, maxTokenMetadataSize)
+		}
+		for name, field := range schema.Fields {
+			if name == "" {
+				return errors.Errorf("metadata schema of token type '%s' has a field with no name", tokenType)
+			}
+			if field == nil {
+				return errors.Errorf("metadata field '%s' of token type '%s' is nil", name, tokenType)
+			}
+			switch field.Type {
+			case "string", "number", "boolean", "date":
+			default:
+				return errors.Errorf("metadata field '%s' of token type '%s' has unknown type '%s'", name, tokenType, field.Type)
+			}
+		}
+	}
+	return nil
+}
+
+func validateTokenDriver(driver *pb.TokenDriver) error {
+	if driver.GetName() == "" {
+		return errors.New("driver name is empty")
+	}
+	return nil
+}
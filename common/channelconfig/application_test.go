@@ -7,11 +7,16 @@ SPDX-License-Identifier: Apache-2.0
 package channelconfig
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/capabilities"
 	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	. "github.com/onsi/gomega"
 )
@@ -52,3 +57,177 @@ func TestACL(t *testing.T) {
 		g.Expect(err).To(MatchError("ACLs may not be specified without the required capability"))
 	})
 }
+
+func TestTokenSupply(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	t.Run("MissingCapability", func(t *testing.T) {
+		cg := &cb.ConfigGroup{
+			Values: map[string]*cb.ConfigValue{
+				TokenSupplyKey: {
+					Value: utils.MarshalOrPanic(
+						TokenSupplyValue(map[string]*pb.TokenSupplyLimit{"XYZ": {MaxTotalSupply: 100}}).Value(),
+					),
+				},
+			},
+		}
+		_, err := NewApplicationConfig(cg, nil)
+		g.Expect(err).To(MatchError("TokenSupply may not be specified without the required capability"))
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		err := validateTokenSupply(&pb.TokenSupply{Limits: map[string]*pb.TokenSupplyLimit{
+			"XYZ": {MaxTotalSupply: 100},
+			"PDQ": {MaxPerPeriod: 10, PeriodBlocks: 100},
+		}})
+		g.Expect(err).NotTo(HaveOccurred())
+
+		err = validateTokenSupply(&pb.TokenSupply{Limits: map[string]*pb.TokenSupplyLimit{"XYZ": {PeriodBlocks: 100}}})
+		g.Expect(err).To(MatchError("period_blocks for token type 'XYZ' requires max_per_period"))
+
+		err = validateTokenSupply(&pb.TokenSupply{Limits: map[string]*pb.TokenSupplyLimit{"XYZ": {MaxPerPeriod: 10}}})
+		g.Expect(err).To(MatchError("max_per_period for token type 'XYZ' requires period_blocks"))
+	})
+}
+
+func TestTokenTypeNamespaces(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	t.Run("MissingCapability", func(t *testing.T) {
+		cg := &cb.ConfigGroup{
+			Values: map[string]*cb.ConfigValue{
+				TokenTypeNamespacesKey: {
+					Value: utils.MarshalOrPanic(
+						TokenTypeNamespacesValue(map[string]*pb.TokenTypeNamespace{"bank1": {Issuers: []string{"Org1MSP"}}}).Value(),
+					),
+				},
+			},
+		}
+		_, err := NewApplicationConfig(cg, nil)
+		g.Expect(err).To(MatchError("TokenTypeNamespaces may not be specified without the required capability"))
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		err := validateTokenTypeNamespaces(&pb.TokenTypeNamespaces{Namespaces: map[string]*pb.TokenTypeNamespace{
+			"bank1":        {Issuers: []string{"Org1MSP"}},
+			"bank1/retail": {Issuers: []string{"Org1MSP", "Org2MSP"}, AdminsOnly: true},
+		}})
+		g.Expect(err).NotTo(HaveOccurred())
+
+		err = validateTokenTypeNamespaces(&pb.TokenTypeNamespaces{Namespaces: map[string]*pb.TokenTypeNamespace{"bank1/": {Issuers: []string{"Org1MSP"}}}})
+		g.Expect(err).To(MatchError("namespace 'bank1/' has an empty segment"))
+
+		err = validateTokenTypeNamespaces(&pb.TokenTypeNamespaces{Namespaces: map[string]*pb.TokenTypeNamespace{"": {Issuers: []string{"Org1MSP"}}}})
+		g.Expect(err).To(MatchError("namespace '' has an empty segment"))
+
+		err = validateTokenTypeNamespaces(&pb.TokenTypeNamespaces{Namespaces: map[string]*pb.TokenTypeNamespace{"bank1": nil}})
+		g.Expect(err).To(MatchError("policy for namespace 'bank1' is nil"))
+
+		err = validateTokenTypeNamespaces(&pb.TokenTypeNamespaces{Namespaces: map[string]*pb.TokenTypeNamespace{"bank1": {}}})
+		g.Expect(err).To(MatchError("namespace 'bank1' has no issuers"))
+
+		err = validateTokenTypeNamespaces(&pb.TokenTypeNamespaces{Namespaces: map[string]*pb.TokenTypeNamespace{"bank1": {Issuers: []string{""}}}})
+		g.Expect(err).To(MatchError("namespace 'bank1' has an empty issuer"))
+	})
+}
+
+func TestTokenMetadataSchemas(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	t.Run("MissingCapability", func(t *testing.T) {
+		cg := &cb.ConfigGroup{
+			Values: map[string]*cb.ConfigValue{
+				TokenMetadataSchemasKey: {
+					Value: utils.MarshalOrPanic(
+						TokenMetadataSchemasValue(map[string]*pb.TokenMetadataSchema{"BOND": {}}).Value(),
+					),
+				},
+			},
+		}
+		_, err := NewApplicationConfig(cg, nil)
+		g.Expect(err).To(MatchError("TokenMetadataSchemas may not be specified without the required capability"))
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		err := validateTokenMetadataSchemas(&pb.TokenMetadataSchemas{Schemas: map[string]*pb.TokenMetadataSchema{
+			"BOND": {
+				Fields: map[string]*pb.TokenMetadataField{
+					"maturity": {Type: "date", Required: true},
+					"coupon":   {Type: "number"},
+					"issuer":   {Type: "string"},
+					"callable": {Type: "boolean"},
+				},
+				MaxSize: 512,
+			},
+		}})
+		g.Expect(err).NotTo(HaveOccurred())
+
+		err = validateTokenMetadataSchemas(&pb.TokenMetadataSchemas{Schemas: map[string]*pb.TokenMetadataSchema{"BOND": nil}})
+		g.Expect(err).To(MatchError("metadata schema for token type 'BOND' is nil"))
+
+		err = validateTokenMetadataSchemas(&pb.TokenMetadataSchemas{Schemas: map[string]*pb.TokenMetadataSchema{"BOND": {MaxSize: 1 << 20}}})
+		g.Expect(err).To(MatchError("maximum metadata size 1048576 of token type 'BOND' exceeds 65536 bytes"))
+
+		err = validateTokenMetadataSchemas(&pb.TokenMetadataSchemas{Schemas: map[string]*pb.TokenMetadataSchema{"BOND": {Fields: map[string]*pb.TokenMetadataField{"": {Type: "string"}}}}})
+		g.Expect(err).To(MatchError("metadata schema of token type 'BOND' has a field with no name"))
+
+		err = validateTokenMetadataSchemas(&pb.TokenMetadataSchemas{Schemas: map[string]*pb.TokenMetadataSchema{"BOND": {Fields: map[string]*pb.TokenMetadataField{"maturity": nil}}}})
+		g.Expect(err).To(MatchError("metadata field 'maturity' of token type 'BOND' is nil"))
+
+		err = validateTokenMetadataSchemas(&pb.TokenMetadataSchemas{Schemas: map[string]*pb.TokenMetadataSchema{"BOND": {Fields: map[string]*pb.TokenMetadataField{"maturity": {Type: "timestamp"}}}}})
+		g.Expect(err).To(MatchError("metadata field 'maturity' of token type 'BOND' has unknown type 'timestamp'"))
+	})
+}
+
+func TestTokenDriver(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	t.Run("MissingCapability", func(t *testing.T) {
+		cg := &cb.ConfigGroup{
+			Values: map[string]*cb.ConfigValue{
+				TokenDriverKey: {
+					Value: utils.MarshalOrPanic(TokenDriverValue("zk").Value()),
+				},
+			},
+		}
+		_, err := NewApplicationConfig(cg, nil)
+		g.Expect(err).To(MatchError("TokenDriver may not be specified without the required capability"))
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		g.Expect(validateTokenDriver(&pb.TokenDriver{Name: "zk"})).To(Succeed())
+		g.Expect(validateTokenDriver(&pb.TokenDriver{})).To(MatchError("driver name is empty"))
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		ac, err := NewApplicationConfig(&cb.ConfigGroup{}, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(ac.TokenDriver()).To(BeEmpty())
+	})
+}
+
+func TestTokenEncryptionKey(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).NotTo(HaveOccurred())
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(validateTokenEncryptionKey(der)).To(Succeed())
+
+	g.Expect(validateTokenEncryptionKey([]byte("garbage"))).To(MatchError(ContainSubstring("failed parsing public key")))
+
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	g.Expect(err).NotTo(HaveOccurred())
+	der, err = x509.MarshalPKIXPublicKey(&p384.PublicKey)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(validateTokenEncryptionKey(der)).To(MatchError("public key is not an ECDSA P-256 key"))
+
+	aoc := &ApplicationOrgConfig{
+		name:   "Org1",
+		protos: &ApplicationOrgProtos{TokenEncryptionKey: TokenEncryptionKeyValue(der).Value().(*pb.TokenEncryptionKey)},
+	}
+	g.Expect(aoc.TokenEncryptionKey()).To(Equal(der))
+	g.Expect(aoc.Validate()).To(MatchError("invalid TokenEncryptionKey for org Org1: public key is not an ECDSA P-256 key"))
+	g.Expect((&ApplicationOrgConfig{protos: &ApplicationOrgProtos{}}).TokenEncryptionKey()).To(BeNil())
+}
@@ -7,6 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package channelconfig
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
 	"fmt"
 
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -17,11 +20,15 @@ import (
 const (
 	// AnchorPeersKey is the key name for the AnchorPeers ConfigValue
 	AnchorPeersKey = "AnchorPeers"
+
+	// TokenEncryptionKeyKey is the key name for the TokenEncryptionKey ConfigValue
+	TokenEncryptionKeyKey = "TokenEncryptionKey"
 )
 
 // ApplicationOrgProtos are deserialized from the config
 type ApplicationOrgProtos struct {
-	AnchorPeers *pb.AnchorPeers
+	AnchorPeers        *pb.AnchorPeers
+	TokenEncryptionKey *pb.TokenEncryptionKey
 }
 
 // ApplicationOrgConfig defines the configuration for an application org
@@ -66,7 +73,30 @@ func (aog *ApplicationOrgConfig) AnchorPeers() []*pb.AnchorPeer {
 	return aog.protos.AnchorPeers.AnchorPeers
 }
 
+// TokenEncryptionKey returns the DER encoded public key to which token
+// transactions are encrypted for the peers of this Organization, or nil
+func (aog *ApplicationOrgConfig) TokenEncryptionKey() []byte {
+	return aog.protos.TokenEncryptionKey.GetPublicKey()
+}
+
 func (aoc *ApplicationOrgConfig) Validate() error {
 	logger.Debugf("Anchor peers for org %s are %v", aoc.name, aoc.protos.AnchorPeers)
+	if key := aoc.TokenEncryptionKey(); key != nil {
+		if err := validateTokenEncryptionKey(key); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("invalid TokenEncryptionKey for org %s", aoc.name))
+		}
+	}
 	return aoc.OrganizationConfig.Validate()
 }
+
+func validateTokenEncryptionKey(der []byte) error {
+	pk, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return errors.Wrap(err, "failed parsing public key")
+	}
+	ecdsaPk, ok := pk.(*ecdsa.PublicKey)
+	if !ok || ecdsaPk.Curve != elliptic.P256() {
+		return errors.New("public key is not an ECDSA P-256 key")
+	}
+	return nil
+}
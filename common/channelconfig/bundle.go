@@ -80,6 +80,12 @@ func (b *Bundle) ConfigtxValidator() configtx.Validator {
 // ValidateNew checks if a new bundle's contained configuration is valid to be derived from the current bundle.
 // This allows checks of the nature "Make sure that the consensus type did not change".
 func (b *Bundle) ValidateNew(nb Resources) error {
+	// Blocks hashed with different algorithms cannot be chained, and transaction
+	// IDs computed with different algorithms cannot be checked for duplicates
+	if b.channelConfig.HashingSuiteName() != nb.ChannelConfig().HashingSuiteName() {
+		return errors.Errorf("Attempted to change hashing suite from %s to %s", b.channelConfig.HashingSuiteName(), nb.ChannelConfig().HashingSuiteName())
+	}
+
 	if oc, ok := b.OrdererConfig(); ok {
 		noc, ok := nb.OrdererConfig()
 		if !ok {
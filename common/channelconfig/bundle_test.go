@@ -204,6 +204,19 @@ func TestValidateNew(t *testing.T) {
 		assert.Error(t, err)
 		assert.Regexp(t, "Consortium consortium1 org org3 attempted to change MSP ID from", err.Error())
 	})
+
+	t.Run("ChangedHashingSuite", func(t *testing.T) {
+		cb := &Bundle{
+			channelConfig: &ChannelConfig{hashingSuiteName: "SHA256"},
+		}
+
+		nb := &Bundle{
+			channelConfig: &ChannelConfig{hashingSuiteName: "SHA3_256"},
+		}
+
+		err := cb.ValidateNew(nb)
+		assert.EqualError(t, err, "Attempted to change hashing suite from SHA256 to SHA3_256")
+	})
 }
 
 func TestValidateNewWithConsensusMigration(t *testing.T) {
@@ -12,9 +12,11 @@ import (
 
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/configtx"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/msp"
 	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 )
 
@@ -46,6 +48,13 @@ type ChannelValues interface {
 	// such as computing block hashes, and CreationPolicy digests
 	HashingAlgorithm() func(input []byte) []byte
 
+	// HashingSuite returns the algorithm hashing the blocks and computing the
+	// transaction IDs of the channel
+	HashingSuite() func(input []byte) []byte
+
+	// HashingSuiteName returns the name of the algorithm returned by HashingSuite
+	HashingSuiteName() string
+
 	// BlockDataHashingStructureWidth returns the width to use when constructing the
 	// Merkle tree to compute the BlockData hash
 	BlockDataHashingStructureWidth() uint32
@@ -69,6 +78,9 @@ type ChannelConfig struct {
 
 	hashingAlgorithm func(input []byte) []byte
 
+	hashingSuite     func(input []byte) []byte
+	hashingSuiteName string
+
 	mspManager msp.MSPManager
 
 	appConfig         *ApplicationConfig
@@ -110,6 +122,10 @@ func NewChannelConfig(channelGroup *cb.ConfigGroup) (*ChannelConfig, error) {
 		}
 	}
 
+	if err := cc.validateConsensusHashingSuite(); err != nil {
+		return nil, err
+	}
+
 	if cc.mspManager, err = mspConfigHandler.CreateMSPManager(); err != nil {
 		return nil, err
 	}
@@ -117,6 +133,46 @@ func NewChannelConfig(channelGroup *cb.ConfigGroup) (*ChannelConfig, error) {
 	return cc, nil
 }
 
+// HashingSuiteFromConfigBlock returns the hashing suite of the channel of the
+// config block. The hashing suite of a channel cannot change, so that the
+// blocks of a channel are chained with the hashing suite of its genesis block.
+func HashingSuiteFromConfigBlock(block *cb.Block) (func(input []byte) []byte, error) {
+	envelope, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to extract envelope from config block")
+	}
+	payload, err := utils.UnmarshalPayload(envelope.Payload)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to unmarshal payload from envelope")
+	}
+	configEnvelope, err := configtx.UnmarshalConfigEnvelope(payload.Data)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to unmarshal config envelope from payload")
+	}
+	if configEnvelope.Config == nil || configEnvelope.Config.ChannelGroup == nil {
+		return nil, errors.New("config block has no channel group")
+	}
+
+	cc := &ChannelConfig{protos: &ChannelProtos{}}
+	if err := DeserializeProtoValuesFromGroup(configEnvelope.Config.ChannelGroup, cc.protos); err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize values")
+	}
+	if err := cc.validateHashingAlgorithm(); err != nil {
+		return nil, err
+	}
+	return cc.HashingSuite(), nil
+}
+
+// HashingSuiteFromGenesisBlock returns the hashing suite of the chain of the
+// genesis block: the hashing suite of its channel when the genesis block is a
+// config block, SHA256 otherwise.
+func HashingSuiteFromGenesisBlock(block *cb.Block) (func(input []byte) []byte, error) {
+	if !utils.IsConfigBlock(block) {
+		return util.ComputeSHA256, nil
+	}
+	return HashingSuiteFromConfigBlock(block)
+}
+
 // MSPManager returns the MSP manager for this config
 func (cc *ChannelConfig) MSPManager() msp.MSPManager {
 	return cc.mspManager
@@ -142,6 +198,18 @@ func (cc *ChannelConfig) HashingAlgorithm() func(input []byte) []byte {
 	return cc.hashingAlgorithm
 }
 
+// HashingSuite returns the algorithm hashing the blocks and computing the
+// transaction IDs of the channel: its hashing algorithm when the hashing suite
+// capability is enabled, SHA256 otherwise.
+func (cc *ChannelConfig) HashingSuite() func(input []byte) []byte {
+	return cc.hashingSuite
+}
+
+// HashingSuiteName returns the name of the algorithm returned by HashingSuite
+func (cc *ChannelConfig) HashingSuiteName() string {
+	return cc.hashingSuiteName
+}
+
 // BlockDataHashingStructure returns the width to use when forming the block data hashing structure
 func (cc *ChannelConfig) BlockDataHashingStructureWidth() uint32 {
 	return cc.protos.BlockDataHashingStructure.Width
@@ -159,7 +227,7 @@ func (cc *ChannelConfig) ConsortiumName() string {
 
 // Capabilities returns information about the available capabilities for this channel
 func (cc *ChannelConfig) Capabilities() ChannelCapabilities {
-	return capabilities.NewChannelProvider(cc.protos.Capabilities.Capabilities)
+	return capabilities.NewChannelProvider(cc.protos.Capabilities.GetCapabilities())
 }
 
 // Validate inspects the generated configuration protos and ensures that the values are correct
@@ -177,16 +245,48 @@ func (cc *ChannelConfig) Validate() error {
 	return nil
 }
 
-func (cc *ChannelConfig) validateHashingAlgorithm() error {
-	switch cc.protos.HashingAlgorithm.Name {
+// HashingSuiteByName returns the hash function of a channel hashing algorithm
+func HashingSuiteByName(name string) (func(input []byte) []byte, error) {
+	switch name {
 	case bccsp.SHA256:
-		cc.hashingAlgorithm = util.ComputeSHA256
+		return util.ComputeSHA256, nil
 	case bccsp.SHA3_256:
-		cc.hashingAlgorithm = util.ComputeSHA3256
+		return util.ComputeSHA3256, nil
+	case bccsp.SHA3_384:
+		return util.ComputeSHA3384, nil
 	default:
-		return fmt.Errorf("Unknown hashing algorithm type: %s", cc.protos.HashingAlgorithm.Name)
+		return nil, fmt.Errorf("Unknown hashing algorithm type: %s", name)
 	}
+}
 
+func (cc *ChannelConfig) validateHashingAlgorithm() error {
+	hashingAlgorithm, err := HashingSuiteByName(cc.protos.HashingAlgorithm.Name)
+	if err != nil {
+		return err
+	}
+	cc.hashingAlgorithm = hashingAlgorithm
+
+	// Nodes without the hashing suite capability hash blocks and transaction
+	// IDs with SHA256 whatever the hashing algorithm of the channel
+	if cc.Capabilities().HashingSuite() {
+		cc.hashingSuite, cc.hashingSuiteName = cc.hashingAlgorithm, cc.protos.HashingAlgorithm.Name
+	} else {
+		cc.hashingSuite, cc.hashingSuiteName = util.ComputeSHA256, bccsp.SHA256
+	}
+
+	return nil
+}
+
+// validateConsensusHashingSuite rejects the hashing suite capability on etcdraft
+// channels, as cluster replication verifies their hash chains with SHA256.
+func (cc *ChannelConfig) validateConsensusHashingSuite() error {
+	if cc.ordererConfig == nil || cc.ordererConfig.ConsensusType() != "etcdraft" {
+		return nil
+	}
+	if cc.Capabilities().HashingSuite() {
+		return fmt.Errorf("The %s channel capability is not supported with the etcdraft consensus type",
+			capabilities.ChannelHashingSuiteExperimental)
+	}
 	return nil
 }
 
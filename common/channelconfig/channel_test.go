@@ -12,8 +12,11 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/genesis"
 	"github.com/hyperledger/fabric/common/util"
 	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -49,6 +52,101 @@ func TestHashingAlgorithm(t *testing.T) {
 
 	assert.Equal(t, reflect.ValueOf(util.ComputeSHA3256).Pointer(), reflect.ValueOf(cc.HashingAlgorithm()).Pointer(),
 		"Unexpected hashing algorithm returned")
+
+	cc = &ChannelConfig{protos: &ChannelProtos{HashingAlgorithm: &cb.HashingAlgorithm{Name: bccsp.SHA3_384}}}
+	assert.NoError(t, cc.validateHashingAlgorithm(), "Allowed hashing algorith SHA3_384 supplied")
+
+	assert.Equal(t, reflect.ValueOf(util.ComputeSHA3384).Pointer(), reflect.ValueOf(cc.HashingAlgorithm()).Pointer(),
+		"Unexpected hashing algorithm returned")
+}
+
+func TestHashingSuite(t *testing.T) {
+	// without the capability, blocks and transaction IDs are hashed with SHA256
+	cc := &ChannelConfig{protos: &ChannelProtos{HashingAlgorithm: &cb.HashingAlgorithm{Name: bccsp.SHA3_256}}}
+	assert.NoError(t, cc.validateHashingAlgorithm())
+	assert.Equal(t, reflect.ValueOf(util.ComputeSHA256).Pointer(), reflect.ValueOf(cc.HashingSuite()).Pointer(),
+		"Unexpected hashing suite returned")
+	assert.Equal(t, bccsp.SHA256, cc.HashingSuiteName())
+
+	cc = &ChannelConfig{protos: &ChannelProtos{
+		HashingAlgorithm: &cb.HashingAlgorithm{Name: bccsp.SHA3_384},
+		Capabilities: &cb.Capabilities{Capabilities: map[string]*cb.Capability{
+			capabilities.ChannelHashingSuiteExperimental: {},
+		}},
+	}}
+	assert.NoError(t, cc.validateHashingAlgorithm())
+	assert.Equal(t, reflect.ValueOf(util.ComputeSHA3384).Pointer(), reflect.ValueOf(cc.HashingSuite()).Pointer(),
+		"Unexpected hashing suite returned")
+	assert.Equal(t, bccsp.SHA3_384, cc.HashingSuiteName())
+}
+
+func TestHashingSuiteFromConfigBlock(t *testing.T) {
+	newBlock := func(algorithm string, caps map[string]*cb.Capability) *cb.Block {
+		block, err := genesis.NewFactoryImpl(&cb.ConfigGroup{
+			Values: map[string]*cb.ConfigValue{
+				HashingAlgorithmKey: {Value: utils.MarshalOrPanic(&cb.HashingAlgorithm{Name: algorithm})},
+				CapabilitiesKey:     {Value: utils.MarshalOrPanic(&cb.Capabilities{Capabilities: caps})},
+			},
+		}).Block("mychannel")
+		assert.NoError(t, err)
+		return block
+	}
+
+	hashingSuite, err := HashingSuiteFromConfigBlock(newBlock(bccsp.SHA3_256, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, reflect.ValueOf(util.ComputeSHA256).Pointer(), reflect.ValueOf(hashingSuite).Pointer())
+
+	hashingSuite, err = HashingSuiteFromConfigBlock(newBlock(bccsp.SHA3_256, map[string]*cb.Capability{
+		capabilities.ChannelHashingSuiteExperimental: {},
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, reflect.ValueOf(util.ComputeSHA3256).Pointer(), reflect.ValueOf(hashingSuite).Pointer())
+
+	_, err = HashingSuiteFromConfigBlock(newBlock("MD5", nil))
+	assert.EqualError(t, err, "Unknown hashing algorithm type: MD5")
+
+	_, err = HashingSuiteFromConfigBlock(&cb.Block{Data: &cb.BlockData{}})
+	assert.Error(t, err)
+
+	// chains whose genesis block is not a config block hash their blocks with SHA256
+	hashingSuite, err = HashingSuiteFromGenesisBlock(cb.NewBlock(0, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, reflect.ValueOf(util.ComputeSHA256).Pointer(), reflect.ValueOf(hashingSuite).Pointer())
+	_, err = HashingSuiteFromGenesisBlock(newBlock("MD5", nil))
+	assert.EqualError(t, err, "Unknown hashing algorithm type: MD5")
+}
+
+func TestHashingSuiteWithEtcdraft(t *testing.T) {
+	channelGroup := func(consensusType string, caps map[string]bool) *cb.ConfigGroup {
+		ordererGroup := cb.NewConfigGroup()
+		for _, value := range []*StandardConfigValue{
+			ConsensusTypeValue(consensusType, nil),
+			BatchSizeValue(10, 1000, 500),
+			BatchTimeoutValue("1s"),
+		} {
+			ordererGroup.Values[value.Key()] = &cb.ConfigValue{Value: utils.MarshalOrPanic(value.Value())}
+		}
+		group := cb.NewConfigGroup()
+		group.Groups[OrdererGroupKey] = ordererGroup
+		for _, value := range []*StandardConfigValue{
+			HashingAlgorithmValue(),
+			BlockDataHashingStructureValue(),
+			OrdererAddressesValue([]string{"127.0.0.1:7050"}),
+			CapabilitiesValue(caps),
+		} {
+			group.Values[value.Key()] = &cb.ConfigValue{Value: utils.MarshalOrPanic(value.Value())}
+		}
+		return group
+	}
+
+	_, err := NewChannelConfig(channelGroup("etcdraft", map[string]bool{capabilities.ChannelHashingSuiteExperimental: true}))
+	assert.EqualError(t, err, "The V1_4_HASHING_SUITE_EXPERIMENTAL channel capability is not supported with the etcdraft consensus type")
+
+	_, err = NewChannelConfig(channelGroup("etcdraft", map[string]bool{}))
+	assert.NoError(t, err)
+
+	_, err = NewChannelConfig(channelGroup("kafka", map[string]bool{capabilities.ChannelHashingSuiteExperimental: true}))
+	assert.NoError(t, err)
 }
 
 func TestBlockDataHashingStructure(t *testing.T) {
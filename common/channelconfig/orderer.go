@@ -39,6 +39,9 @@ const (
 
 	// KafkaBrokersKey is the cb.ConfigItem type key name for the KafkaBrokers message.
 	KafkaBrokersKey = "KafkaBrokers"
+
+	// IngressQuotasKey is the cb.ConfigItem type key name for the IngressQuotas message.
+	IngressQuotasKey = "IngressQuotas"
 )
 
 // OrdererProtos is used as the source of the OrdererConfig.
@@ -48,6 +51,7 @@ type OrdererProtos struct {
 	BatchTimeout        *ab.BatchTimeout
 	KafkaBrokers        *ab.KafkaBrokers
 	ChannelRestrictions *ab.ChannelRestrictions
+	IngressQuotas       *ab.IngressQuotas
 	Capabilities        *cb.Capabilities
 }
 
@@ -70,6 +74,12 @@ func NewOrdererConfig(ordererGroup *cb.ConfigGroup, mspConfig *MSPConfigHandler)
 		return nil, errors.Wrap(err, "failed to deserialize values")
 	}
 
+	// the orderers predating ingress quotas reject the value, so only the
+	// channels which require the capability may set it
+	if _, ok := ordererGroup.Values[IngressQuotasKey]; ok && !oc.Capabilities().IngressQuotas() {
+		return nil, errors.Errorf("ingress quotas require the %s orderer capability", capabilities.OrdererIngressQuotasExperimental)
+	}
+
 	if err := oc.Validate(); err != nil {
 		return nil, err
 	}
@@ -125,6 +135,12 @@ func (oc *OrdererConfig) MaxChannelsCount() uint64 {
 	return oc.protos.ChannelRestrictions.MaxCount
 }
 
+// IngressQuotas returns the broadcast quotas keyed by the MSP ID of the
+// organization they apply to.
+func (oc *OrdererConfig) IngressQuotas() map[string]*ab.IngressQuota {
+	return oc.protos.IngressQuotas.Quotas
+}
+
 // Organizations returns a map of the orgs in the channel.
 func (oc *OrdererConfig) Organizations() map[string]Org {
 	return oc.orgs
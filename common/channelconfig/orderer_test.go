@@ -9,7 +9,10 @@ package channelconfig
 import (
 	"testing"
 
+	"github.com/hyperledger/fabric/common/capabilities"
+	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -49,3 +52,26 @@ func TestKafkaBrokers(t *testing.T) {
 	oc = &OrdererConfig{protos: &OrdererProtos{KafkaBrokers: &ab.KafkaBrokers{Brokers: []string{"127.0.0.1", "foo.bar", "127.0.0.1:-1", "localhost:65536", "foo.bar.:9092", ".127.0.0.1:9092", "-foo.bar:9092"}}}}
 	assert.Error(t, oc.validateKafkaBrokers(), "Invalid kafka brokers")
 }
+
+func TestIngressQuotas(t *testing.T) {
+	ordererGroup := func(capabilities map[string]bool) *cb.ConfigGroup {
+		group := cb.NewConfigGroup()
+		for _, value := range []*StandardConfigValue{
+			BatchSizeValue(10, 1000, 500),
+			BatchTimeoutValue("1s"),
+			IngressQuotasValue(map[string]*ab.IngressQuota{"Org1MSP": {MessagesPerSecond: 1}}),
+			CapabilitiesValue(capabilities),
+		} {
+			group.Values[value.Key()] = &cb.ConfigValue{Value: utils.MarshalOrPanic(value.Value())}
+		}
+		return group
+	}
+
+	_, err := NewOrdererConfig(ordererGroup(map[string]bool{}), nil)
+	assert.EqualError(t, err, "ingress quotas require the V1_4_INGRESS_QUOTAS_EXPERIMENTAL orderer capability")
+
+	oc, err := NewOrdererConfig(ordererGroup(map[string]bool{capabilities.OrdererIngressQuotasExperimental: true}), nil)
+	assert.NoError(t, err)
+	assert.True(t, oc.Capabilities().IngressQuotas())
+	assert.Equal(t, uint32(1), oc.IngressQuotas()["Org1MSP"].MessagesPerSecond)
+}
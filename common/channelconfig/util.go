@@ -148,6 +148,17 @@ func ChannelRestrictionsValue(maxChannelCount uint64) *StandardConfigValue {
 	}
 }
 
+// IngressQuotasValue returns the config definition for the per-organization broadcast quotas.
+// It is a value for the /Channel/Orderer group.
+func IngressQuotasValue(quotas map[string]*ab.IngressQuota) *StandardConfigValue {
+	return &StandardConfigValue{
+		key: IngressQuotasKey,
+		value: &ab.IngressQuotas{
+			Quotas: quotas,
+		},
+	}
+}
+
 // KafkaBrokersValue returns the config definition for the addresses of the ordering service's Kafka brokers.
 // It is a value for the /Channel/Orderer group.
 func KafkaBrokersValue(brokers []string) *StandardConfigValue {
@@ -222,3 +233,49 @@ func ACLValues(acls map[string]string) *StandardConfigValue {
 		value: a,
 	}
 }
+
+// TokenSupplyValue returns the config definition for the limits on the issuance of tokens.
+// It is a value for the /Channel/Application/.
+func TokenSupplyValue(limits map[string]*pb.TokenSupplyLimit) *StandardConfigValue {
+	return &StandardConfigValue{
+		key:   TokenSupplyKey,
+		value: &pb.TokenSupply{Limits: limits},
+	}
+}
+
+// TokenEncryptionKeyValue returns the config definition for the public key to
+// which token transactions are encrypted for the peers of an org.
+// It is a value for the /Channel/Application/<org>/.
+func TokenEncryptionKeyValue(publicKey []byte) *StandardConfigValue {
+	return &StandardConfigValue{
+		key:   TokenEncryptionKeyKey,
+		value: &pb.TokenEncryptionKey{PublicKey: publicKey},
+	}
+}
+
+// TokenTypeNamespacesValue returns the config definition for the issuance policies of the
+// hierarchical token types. It is a value for the /Channel/Application/.
+func TokenTypeNamespacesValue(namespaces map[string]*pb.TokenTypeNamespace) *StandardConfigValue {
+	return &StandardConfigValue{
+		key:   TokenTypeNamespacesKey,
+		value: &pb.TokenTypeNamespaces{Namespaces: namespaces},
+	}
+}
+
+// TokenMetadataSchemasValue returns the config definition for the schemas of the metadata
+// of token outputs. It is a value for the /Channel/Application/.
+func TokenMetadataSchemasValue(schemas map[string]*pb.TokenMetadataSchema) *StandardConfigValue {
+	return &StandardConfigValue{
+		key:   TokenMetadataSchemasKey,
+		value: &pb.TokenMetadataSchemas{Schemas: schemas},
+	}
+}
+
+// TokenDriverValue returns the config definition for the driver of the token management
+// system. It is a value for the /Channel/Application/.
+func TokenDriverValue(name string) *StandardConfigValue {
+	return &StandardConfigValue{
+		key:   TokenDriverKey,
+		value: &pb.TokenDriver{Name: name},
+	}
+}
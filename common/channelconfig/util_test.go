@@ -11,6 +11,7 @@ import (
 
 	cb "github.com/hyperledger/fabric/protos/common"
 	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	ab "github.com/hyperledger/fabric/protos/orderer"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/stretchr/testify/assert"
 )
@@ -36,10 +37,15 @@ func TestUtilsBasic(t *testing.T) {
 	basicTest(t, BatchSizeValue(1, 2, 3))
 	basicTest(t, BatchTimeoutValue("1s"))
 	basicTest(t, ChannelRestrictionsValue(7))
+	basicTest(t, IngressQuotasValue(map[string]*ab.IngressQuota{"foo": {MessagesPerSecond: 1}}))
 	basicTest(t, KafkaBrokersValue([]string{"foo:1", "bar:2"}))
 	basicTest(t, MSPValue(&mspprotos.MSPConfig{}))
 	basicTest(t, CapabilitiesValue(map[string]bool{"foo": true, "bar": false}))
 	basicTest(t, AnchorPeersValue([]*pb.AnchorPeer{{}, {}}))
 	basicTest(t, ChannelCreationPolicyValue(&cb.Policy{}))
 	basicTest(t, ACLValues(map[string]string{"foo": "fooval", "bar": "barval"}))
+	basicTest(t, TokenSupplyValue(map[string]*pb.TokenSupplyLimit{"foo": {MaxTotalSupply: 100}}))
+	basicTest(t, TokenTypeNamespacesValue(map[string]*pb.TokenTypeNamespace{"foo": {Issuers: []string{"Org1MSP"}}}))
+	basicTest(t, TokenMetadataSchemasValue(map[string]*pb.TokenMetadataSchema{"foo": {Fields: map[string]*pb.TokenMetadataField{"maturity": {Type: "date"}}}}))
+	basicTest(t, TokenDriverValue("plain"))
 }
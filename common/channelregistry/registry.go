@@ -0,0 +1,204 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package channelregistry records which ordering service created each channel
+// ID, so that the ordering services of a consortium deployment sharing the
+// registry do not create channels with colliding IDs, and so that configtxgen
+// and the peers can detect such collisions before using a channel.
+package channelregistry
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// ErrNotRegistered is returned when looking up a channel ID absent from the registry.
+var ErrNotRegistered = errors.New("channel ID not registered")
+
+// Entry is the registration of a channel ID.
+type Entry struct {
+	ChannelID        string `json:"channel_id"`
+	OrderingService  string `json:"ordering_service"`
+	Consortium       string `json:"consortium,omitempty"`
+	GenesisBlockHash string `json:"genesis_block_hash"`
+}
+
+// CollisionError is returned when a channel ID is registered for another channel.
+type CollisionError struct {
+	// Registered is the existing registration of the channel ID
+	Registered *Entry
+	// GenesisBlockMismatch is set when the channel ID is registered for the
+	// same ordering service, but with another genesis block
+	GenesisBlockMismatch bool
+}
+
+func (e *CollisionError) Error() string {
+	msg := fmt.Sprintf("channel ID %s is already registered by ordering service %s", e.Registered.ChannelID, e.Registered.OrderingService)
+	if e.Registered.Consortium != "" {
+		msg += fmt.Sprintf(" for consortium %s", e.Registered.Consortium)
+	}
+	if e.GenesisBlockMismatch {
+		msg += " with another genesis block"
+	}
+	return msg
+}
+
+// Registry stores the registrations of the channel IDs.
+type Registry interface {
+	// Lookup returns the registration of the channel ID, or ErrNotRegistered
+	Lookup(channelID string) (*Entry, error)
+
+	// Register registers the channel ID of the entry, unless it is already
+	// registered. Registering the same entry again succeeds.
+	Register(entry *Entry) error
+}
+
+// NewEntry creates the registration of the channel created by the ordering
+// service with the genesis block.
+func NewEntry(orderingService, consortium string, genesisBlock *cb.Block) (*Entry, error) {
+	channelID, err := utils.GetChainIDFromBlock(genesisBlock)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to extract channel ID from genesis block")
+	}
+	return &Entry{
+		ChannelID:        channelID,
+		OrderingService:  orderingService,
+		Consortium:       consortium,
+		GenesisBlockHash: hex.EncodeToString(genesisBlock.Header.Hash()),
+	}, nil
+}
+
+// Check returns a CollisionError if the channel ID is registered by another
+// ordering service than the given one, or registered at all if the ordering
+// service is empty.
+func Check(registry Registry, channelID, orderingService string) error {
+	entry, err := registry.Lookup(channelID)
+	if err == ErrNotRegistered {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if orderingService == "" || entry.OrderingService != orderingService {
+		return &CollisionError{Registered: entry}
+	}
+	return nil
+}
+
+// VerifyGenesisBlock returns a CollisionError if the channel ID of the genesis
+// block is registered for another genesis block, and ErrNotRegistered if it is
+// not registered.
+func VerifyGenesisBlock(registry Registry, genesisBlock *cb.Block) error {
+	channelID, err := utils.GetChainIDFromBlock(genesisBlock)
+	if err != nil {
+		return errors.WithMessage(err, "failed to extract channel ID from genesis block")
+	}
+	entry, err := registry.Lookup(channelID)
+	if err != nil {
+		return err
+	}
+	if entry.GenesisBlockHash != hex.EncodeToString(genesisBlock.Header.Hash()) {
+		return &CollisionError{Registered: entry, GenesisBlockMismatch: true}
+	}
+	return nil
+}
+
+// DirRegistry is a Registry storing each registration in its own file of a
+// directory, which the ordering services share through a network file system.
+type DirRegistry struct {
+	Dir string
+}
+
+// NewDirRegistry creates a DirRegistry in dir, creating the directory if needed.
+func NewDirRegistry(dir string) (*DirRegistry, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "failed to create channel registry directory %s", dir)
+	}
+	return &DirRegistry{Dir: dir}, nil
+}
+
+// Lookup returns the registration of the channel ID, or ErrNotRegistered.
+func (r *DirRegistry) Lookup(channelID string) (*Entry, error) {
+	path, err := r.path(channelID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, ErrNotRegistered
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read registration of channel ID %s", channelID)
+	}
+	entry := &Entry{}
+	if err := json.Unmarshal(data, entry); err != nil {
+		return nil, errors.Wrapf(err, "malformed registration of channel ID %s", channelID)
+	}
+	return entry, nil
+}
+
+// Register registers the channel ID of the entry, unless it is already
+// registered. The registration file is written aside and then linked into
+// place, so that concurrent registrations of a channel ID are decided by the
+// file system and that a registration is never read partially written.
+func (r *DirRegistry) Register(entry *Entry) error {
+	path, err := r.path(entry.ChannelID)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal registration")
+	}
+
+	tmp, err := ioutil.TempFile(r.Dir, ".register-")
+	if err != nil {
+		return errors.Wrap(err, "failed to create registration file")
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to write registration of channel ID %s", entry.ChannelID)
+	}
+
+	err = os.Link(tmp.Name(), path)
+	if err == nil {
+		return nil
+	}
+	if !os.IsExist(err) {
+		return errors.Wrapf(err, "failed to register channel ID %s", entry.ChannelID)
+	}
+	registered, err := r.Lookup(entry.ChannelID)
+	if err != nil {
+		return err
+	}
+	if *registered != *entry {
+		return &CollisionError{Registered: registered, GenesisBlockMismatch: registered.OrderingService == entry.OrderingService}
+	}
+	return nil
+}
+
+func (r *DirRegistry) path(channelID string) (string, error) {
+	if channelID == "" || channelID == "." || channelID == ".." || strings.ContainsAny(channelID, `/\`) {
+		return "", errors.Errorf("invalid channel ID '%s'", channelID)
+	}
+	return filepath.Join(r.Dir, channelID+".json"), nil
+}
@@ -0,0 +1,153 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channelregistry
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func genesisBlock(channelID string, data string) *cb.Block {
+	block := cb.NewBlock(0, nil)
+	env := &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: utils.MakePayloadHeader(utils.MakeChannelHeader(cb.HeaderType_CONFIG, 0, channelID, 0), utils.MakeSignatureHeader(nil, nil)),
+			Data:   []byte(data),
+		}),
+	}
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(env)}
+	block.Header.DataHash = block.Data.Hash()
+	return block
+}
+
+func newRegistry(t *testing.T) (*DirRegistry, func()) {
+	dir, err := ioutil.TempDir("", "channelregistry")
+	assert.NoError(t, err)
+	r, err := NewDirRegistry(filepath.Join(dir, "registry"))
+	assert.NoError(t, err)
+	return r, func() { os.RemoveAll(dir) }
+}
+
+func TestRegister(t *testing.T) {
+	r, cleanup := newRegistry(t)
+	defer cleanup()
+
+	_, err := r.Lookup("foo")
+	assert.Equal(t, ErrNotRegistered, err)
+
+	block := genesisBlock("foo", "config")
+	entry, err := NewEntry("service1", "SampleConsortium", block)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", entry.ChannelID)
+	assert.NoError(t, r.Register(entry))
+	assert.NoError(t, r.Register(entry), "registering the same entry again succeeds")
+
+	registered, err := r.Lookup("foo")
+	assert.NoError(t, err)
+	assert.Equal(t, entry, registered)
+
+	other, err := NewEntry("service2", "OtherConsortium", genesisBlock("foo", "other config"))
+	assert.NoError(t, err)
+	err = r.Register(other)
+	assert.EqualError(t, err, "channel ID foo is already registered by ordering service service1 for consortium SampleConsortium")
+	assert.IsType(t, &CollisionError{}, err)
+
+	recreated, err := NewEntry("service1", "SampleConsortium", genesisBlock("foo", "other config"))
+	assert.NoError(t, err)
+	assert.EqualError(t, r.Register(recreated), "channel ID foo is already registered by ordering service service1 for consortium SampleConsortium with another genesis block")
+
+	files, err := ioutil.ReadDir(r.Dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1, "no temporary registration file is left")
+}
+
+func TestConcurrentRegister(t *testing.T) {
+	r, cleanup := newRegistry(t)
+	defer cleanup()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < cap(errs); i++ {
+		entry, err := NewEntry(fmt.Sprintf("service%d", i), "", genesisBlock("foo", "config"))
+		assert.NoError(t, err)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- r.Register(entry)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	var succeeded int
+	for err := range errs {
+		if err == nil {
+			succeeded++
+		}
+	}
+	assert.Equal(t, 1, succeeded)
+}
+
+func TestCheck(t *testing.T) {
+	r, cleanup := newRegistry(t)
+	defer cleanup()
+
+	entry, err := NewEntry("service1", "", genesisBlock("foo", "config"))
+	assert.NoError(t, err)
+	assert.NoError(t, r.Register(entry))
+
+	assert.NoError(t, Check(r, "bar", ""))
+	assert.NoError(t, Check(r, "bar", "service2"))
+	assert.NoError(t, Check(r, "foo", "service1"))
+	assert.EqualError(t, Check(r, "foo", "service2"), "channel ID foo is already registered by ordering service service1")
+	assert.EqualError(t, Check(r, "foo", ""), "channel ID foo is already registered by ordering service service1")
+}
+
+func TestVerifyGenesisBlock(t *testing.T) {
+	r, cleanup := newRegistry(t)
+	defer cleanup()
+
+	block := genesisBlock("foo", "config")
+	entry, err := NewEntry("service1", "", block)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Register(entry))
+
+	assert.NoError(t, VerifyGenesisBlock(r, block))
+	assert.EqualError(t, VerifyGenesisBlock(r, genesisBlock("foo", "other config")), "channel ID foo is already registered by ordering service service1 with another genesis block")
+	assert.Equal(t, ErrNotRegistered, VerifyGenesisBlock(r, genesisBlock("bar", "config")))
+	assert.Error(t, VerifyGenesisBlock(r, cb.NewBlock(0, nil)))
+}
+
+func TestInvalidChannelID(t *testing.T) {
+	r, cleanup := newRegistry(t)
+	defer cleanup()
+
+	for _, channelID := range []string{"", "..", "../foo", `foo\bar`} {
+		_, err := r.Lookup(channelID)
+		assert.EqualError(t, err, "invalid channel ID '"+channelID+"'")
+		assert.Error(t, r.Register(&Entry{ChannelID: channelID}))
+	}
+}
+
+func TestNewDirRegistryFailure(t *testing.T) {
+	f, err := ioutil.TempFile("", "channelregistry")
+	assert.NoError(t, err)
+	f.Close()
+	defer os.Remove(f.Name())
+
+	_, err = NewDirRegistry(filepath.Join(f.Name(), "registry"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to create channel registry directory")
+}
@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tbls
+
+import (
+	"bytes"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// The orderers signing the blocks with key shares of the consenter set set
+// the value of the signatures metadata of the blocks they write to their
+// signature share of the block header. The value is covered by the signature
+// of the orderer, so the blocks remain valid for the peers, while any
+// threshold of copies of a block written by distinct consenters combine into
+// a signature of the consenter set, which proves the validity of the block
+// header to the holders of the public key of the consenter set alone.
+
+// SignBlockHeader returns the signature share of the block header, as set in
+// the value of the signatures metadata of the block.
+func SignBlockHeader(keyShare *KeyShare, header *cb.BlockHeader) []byte {
+	return keyShare.Sign(header.Bytes()).Bytes()
+}
+
+// BlockSignatureShare returns the signature share of the header of the
+// block, by the consenter which wrote the block.
+func BlockSignatureShare(block *cb.Block) (*SignatureShare, error) {
+	if block.GetHeader() == nil {
+		return nil, errors.New("block has no header")
+	}
+	metadata, err := utils.GetMetadataFromBlock(block, cb.BlockMetadataIndex_SIGNATURES)
+	if err != nil {
+		return nil, err
+	}
+	if len(metadata.Value) == 0 {
+		return nil, errors.Errorf("block %d has no signature share", block.Header.Number)
+	}
+	return SignatureShareFromBytes(metadata.Value)
+}
+
+// CombineBlockSignatures combines the signature shares of the copies of a
+// block written by threshold distinct consenters into the signature of the
+// consenter set of the block header. The shares that the public keys of the
+// key shares, indexed by the index of the consenters minus one, do not verify
+// are skipped.
+func CombineBlockSignatures(threshold int, publicShares []*PublicKey, blocks []*cb.Block) (*Signature, error) {
+	if len(blocks) == 0 {
+		return nil, errors.New("no blocks")
+	}
+	var header []byte
+	var shares []*SignatureShare
+	for i, block := range blocks {
+		if block.GetHeader() == nil {
+			return nil, errors.New("block has no header")
+		}
+		if i == 0 {
+			header = block.Header.Bytes()
+		} else if !bytes.Equal(block.Header.Bytes(), header) {
+			return nil, errors.Errorf("block %d does not have the header of block %d", block.GetHeader().GetNumber(), blocks[0].GetHeader().GetNumber())
+		}
+		share, err := BlockSignatureShare(block)
+		if err != nil {
+			continue
+		}
+		if share.Index > uint32(len(publicShares)) || !publicShares[share.Index-1].VerifyShare(header, share) {
+			continue
+		}
+		shares = append(shares, share)
+	}
+	return Combine(threshold, shares)
+}
+
+// VerifyBlockHeader returns true if the signature is a signature of the block
+// header by the consenter set of the public key.
+func VerifyBlockHeader(publicKey *PublicKey, header *cb.BlockHeader, signature *Signature) bool {
+	return publicKey.Verify(header.Bytes(), signature)
+}
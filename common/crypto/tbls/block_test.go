@@ -0,0 +1,77 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tbls
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signedBlock returns the copy of the block written by the consenter of the
+// key share.
+func signedBlock(t *testing.T, keyShare *KeyShare, number uint64) *cb.Block {
+	block := cb.NewBlock(number, []byte("previous-hash"))
+	block.Header.DataHash = []byte("data-hash")
+	value := SignBlockHeader(keyShare, block.Header)
+	metadata, err := proto.Marshal(&cb.Metadata{
+		Value:      value,
+		Signatures: []*cb.MetadataSignature{{SignatureHeader: []byte("header"), Signature: []byte("signature")}},
+	})
+	require.NoError(t, err)
+	block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = metadata
+	return block
+}
+
+func TestCombineBlockSignatures(t *testing.T) {
+	publicKey, keyShares, publicShares, err := Deal(2, 3)
+	require.NoError(t, err)
+
+	blocks := []*cb.Block{signedBlock(t, keyShares[2], 7), signedBlock(t, keyShares[0], 7)}
+	share, err := BlockSignatureShare(blocks[0])
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), share.Index)
+
+	signature, err := CombineBlockSignatures(2, publicShares, blocks)
+	require.NoError(t, err)
+	assert.True(t, VerifyBlockHeader(publicKey, blocks[0].Header, signature))
+	assert.False(t, VerifyBlockHeader(publicKey, signedBlock(t, keyShares[0], 8).Header, signature))
+
+	t.Run("skips the invalid shares", func(t *testing.T) {
+		forged := signedBlock(t, keyShares[1], 7)
+		metadata, err := proto.Marshal(&cb.Metadata{Value: keyShares[1].Sign([]byte("other")).Bytes()})
+		require.NoError(t, err)
+		forged.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = metadata
+		unsigned := cb.NewBlock(7, []byte("previous-hash"))
+		unsigned.Header.DataHash = []byte("data-hash")
+
+		_, err = CombineBlockSignatures(2, publicShares, []*cb.Block{blocks[0], forged, unsigned})
+		assert.EqualError(t, err, "1 signature shares of distinct members, 2 required")
+	})
+
+	t.Run("rejects copies of distinct blocks", func(t *testing.T) {
+		_, err := CombineBlockSignatures(2, publicShares, []*cb.Block{blocks[0], signedBlock(t, keyShares[1], 8)})
+		assert.EqualError(t, err, "block 8 does not have the header of block 7")
+	})
+
+	t.Run("requires blocks", func(t *testing.T) {
+		_, err := CombineBlockSignatures(2, publicShares, nil)
+		assert.EqualError(t, err, "no blocks")
+	})
+}
+
+func TestBlockSignatureShare(t *testing.T) {
+	block := cb.NewBlock(3, nil)
+	_, err := BlockSignatureShare(block)
+	assert.EqualError(t, err, "block 3 has no signature share")
+
+	_, err = BlockSignatureShare(&cb.Block{})
+	assert.EqualError(t, err, "block has no header")
+}
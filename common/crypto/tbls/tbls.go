@@ -0,0 +1,299 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package tbls implements threshold BLS signatures over the FP256BN pairing
+// friendly curve, also used by Idemix. The secret key of a group is split by
+// a dealer into key shares, any threshold of which reconstruct it, so that
+// the signature shares of a message by any threshold of the members of the
+// group combine into a single signature of the group, verifiable with the
+// public key of the group alone.
+//
+// Signatures are points of G1, 33 bytes compressed, and public keys points of
+// G2.
+package tbls
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/hyperledger/fabric-amcl/amcl"
+	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
+	"github.com/pkg/errors"
+)
+
+const (
+	scalarSize    = int(FP256BN.MODBYTES)
+	signatureSize = scalarSize + 1
+	publicKeySize = 4 * scalarSize
+)
+
+// domain separates the hashes of the messages signed with this package from
+// the other uses of the curve
+var domain = []byte("FABRIC-TBLS-FP256BN-V1")
+
+var groupOrder = FP256BN.NewBIGints(FP256BN.CURVE_Order)
+
+// A KeyShare is the share of the secret key of a group held by a member, with
+// the index of the member, from 1 to the number of shares.
+type KeyShare struct {
+	Index  uint32
+	secret *FP256BN.BIG
+}
+
+// A PublicKey is the public key of a group, or the public key of the key
+// share of a member, which verifies its signature shares.
+type PublicKey struct {
+	point *FP256BN.ECP2
+}
+
+// A Signature is a signature of a group.
+type Signature struct {
+	point *FP256BN.ECP
+}
+
+// A SignatureShare is the share of a signature of a group by a member.
+type SignatureShare struct {
+	Index uint32
+	point *FP256BN.ECP
+}
+
+// Deal splits a new secret key into key shares, any threshold of which sign
+// for the group, and returns the public key of the group, the key shares, and
+// the public keys of the key shares.
+func Deal(threshold, shares int) (*PublicKey, []*KeyShare, []*PublicKey, error) {
+	if threshold < 1 || threshold > shares {
+		return nil, nil, nil, errors.Errorf("invalid threshold %d of %d shares", threshold, shares)
+	}
+	rng, err := newRand()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// the secret key is the constant term of a random polynomial of degree
+	// threshold-1, and the key share of member i its value at i
+	coefficients := make([]*FP256BN.BIG, threshold)
+	for i := range coefficients {
+		coefficients[i] = FP256BN.Randomnum(groupOrder, rng)
+	}
+	keyShares := make([]*KeyShare, shares)
+	publicShares := make([]*PublicKey, shares)
+	for i := range keyShares {
+		keyShares[i] = &KeyShare{Index: uint32(i + 1), secret: evaluate(coefficients, uint32(i+1))}
+		publicShares[i] = keyShares[i].PublicKey()
+	}
+	return &PublicKey{point: FP256BN.ECP2_generator().Mul(coefficients[0])}, keyShares, publicShares, nil
+}
+
+func newRand() (*amcl.RAND, error) {
+	seed := make([]byte, 32)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, errors.Wrap(err, "failed getting randomness for seed")
+	}
+	rng := amcl.NewRAND()
+	rng.Clean()
+	rng.Seed(len(seed), seed)
+	return rng, nil
+}
+
+// evaluate returns the value of the polynomial at x, modulo the group order.
+func evaluate(coefficients []*FP256BN.BIG, x uint32) *FP256BN.BIG {
+	bx := FP256BN.NewBIGint(int(x))
+	value := FP256BN.NewBIGint(0)
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		value = FP256BN.Modmul(value, bx, groupOrder)
+		value = value.Plus(coefficients[i])
+		value.Mod(groupOrder)
+	}
+	return value
+}
+
+// hash maps a message to a point of G1.
+func hash(message []byte) *FP256BN.ECP {
+	h := sha256.New()
+	h.Write(domain)
+	h.Write(message)
+	return FP256BN.ECP_mapit(h.Sum(nil))
+}
+
+// PublicKey returns the public key of the key share.
+func (k *KeyShare) PublicKey() *PublicKey {
+	return &PublicKey{point: FP256BN.ECP2_generator().Mul(k.secret)}
+}
+
+// Sign returns the signature share of the message.
+func (k *KeyShare) Sign(message []byte) *SignatureShare {
+	return &SignatureShare{Index: k.Index, point: hash(message).Mul(k.secret)}
+}
+
+// Verify returns true if the signature is a signature of the message by the
+// group of the public key.
+func (pk *PublicKey) Verify(message []byte, signature *Signature) bool {
+	return verify(pk.point, message, signature.point)
+}
+
+// VerifyShare returns true if the signature share is a share of the message
+// by the key share of the public key.
+func (pk *PublicKey) VerifyShare(message []byte, share *SignatureShare) bool {
+	return verify(pk.point, message, share.point)
+}
+
+// verify checks that e(signature, g2) = e(hash(message), publicKey).
+func verify(publicKey *FP256BN.ECP2, message []byte, signature *FP256BN.ECP) bool {
+	if publicKey.Is_infinity() || signature.Is_infinity() {
+		return false
+	}
+	left := FP256BN.Fexp(FP256BN.Ate(FP256BN.ECP2_generator(), signature))
+	right := FP256BN.Fexp(FP256BN.Ate(publicKey, hash(message)))
+	return left.Equals(right)
+}
+
+// Combine combines the signature shares of a message by threshold distinct
+// members into the signature of the group. The shares beyond the threshold
+// are ignored. The shares are not verified: the combined signature is only
+// valid if they all are.
+func Combine(threshold int, shares []*SignatureShare) (*Signature, error) {
+	if threshold < 1 {
+		return nil, errors.Errorf("invalid threshold %d", threshold)
+	}
+	var selected []*SignatureShare
+	seen := map[uint32]bool{}
+	for _, share := range shares {
+		if share.Index == 0 || seen[share.Index] {
+			continue
+		}
+		seen[share.Index] = true
+		selected = append(selected, share)
+		if len(selected) == threshold {
+			break
+		}
+	}
+	if len(selected) < threshold {
+		return nil, errors.Errorf("%d signature shares of distinct members, %d required", len(selected), threshold)
+	}
+
+	// the signature of the group is the value at 0 of the polynomial through
+	// the shares, interpolated in the exponent
+	point := FP256BN.NewECP()
+	for _, share := range selected {
+		point.Add(share.point.Mul(lagrange(share.Index, selected)))
+	}
+	return &Signature{point: point}, nil
+}
+
+// lagrange returns the Lagrange coefficient at 0 of the share of member i
+// among the shares, that is the product of j/(j-i) over the other members j.
+func lagrange(i uint32, shares []*SignatureShare) *FP256BN.BIG {
+	bi := FP256BN.NewBIGint(int(i))
+	numerator := FP256BN.NewBIGint(1)
+	denominator := FP256BN.NewBIGint(1)
+	for _, share := range shares {
+		if share.Index == i {
+			continue
+		}
+		bj := FP256BN.NewBIGint(int(share.Index))
+		numerator = FP256BN.Modmul(numerator, bj, groupOrder)
+		difference := bj.Plus(FP256BN.Modneg(bi, groupOrder))
+		difference.Mod(groupOrder)
+		denominator = FP256BN.Modmul(denominator, difference, groupOrder)
+	}
+	denominator.Invmodp(groupOrder)
+	return FP256BN.Modmul(numerator, denominator, groupOrder)
+}
+
+// Bytes encodes the key share.
+func (k *KeyShare) Bytes() []byte {
+	b := make([]byte, 4+scalarSize)
+	binary.BigEndian.PutUint32(b, k.Index)
+	k.secret.ToBytes(b[4:])
+	return b
+}
+
+// KeyShareFromBytes decodes a key share encoded by Bytes.
+func KeyShareFromBytes(b []byte) (*KeyShare, error) {
+	if len(b) != 4+scalarSize {
+		return nil, errors.New("invalid key share encoding")
+	}
+	index := binary.BigEndian.Uint32(b)
+	secret := FP256BN.FromBytes(b[4:])
+	// the secret must be reduced modulo the group order
+	reduced := FP256BN.NewBIGcopy(secret)
+	reduced.Mod(groupOrder)
+	encoded := make([]byte, scalarSize)
+	reduced.ToBytes(encoded)
+	if index == 0 || !bytes.Equal(encoded, b[4:]) {
+		return nil, errors.New("invalid key share encoding")
+	}
+	return &KeyShare{Index: index, secret: secret}, nil
+}
+
+// Bytes encodes the public key.
+func (pk *PublicKey) Bytes() []byte {
+	b := make([]byte, publicKeySize)
+	pk.point.ToBytes(b)
+	return b
+}
+
+// PublicKeyFromBytes decodes a public key encoded by Bytes.
+func PublicKeyFromBytes(b []byte) (*PublicKey, error) {
+	if len(b) != publicKeySize {
+		return nil, errors.New("invalid public key encoding")
+	}
+	point := FP256BN.ECP2_fromBytes(b)
+	if point.Is_infinity() {
+		return nil, errors.New("invalid public key encoding")
+	}
+	return &PublicKey{point: point}, nil
+}
+
+// Bytes encodes the signature.
+func (s *Signature) Bytes() []byte {
+	b := make([]byte, signatureSize)
+	s.point.ToBytes(b, true)
+	return b
+}
+
+// SignatureFromBytes decodes a signature encoded by Bytes.
+func SignatureFromBytes(b []byte) (*Signature, error) {
+	point, err := pointFromBytes(b)
+	if err != nil {
+		return nil, errors.New("invalid signature encoding")
+	}
+	return &Signature{point: point}, nil
+}
+
+// Bytes encodes the signature share.
+func (s *SignatureShare) Bytes() []byte {
+	b := make([]byte, 4+signatureSize)
+	binary.BigEndian.PutUint32(b, s.Index)
+	s.point.ToBytes(b[4:], true)
+	return b
+}
+
+// SignatureShareFromBytes decodes a signature share encoded by Bytes.
+func SignatureShareFromBytes(b []byte) (*SignatureShare, error) {
+	if len(b) != 4+signatureSize {
+		return nil, errors.New("invalid signature share encoding")
+	}
+	index := binary.BigEndian.Uint32(b)
+	point, err := pointFromBytes(b[4:])
+	if index == 0 || err != nil {
+		return nil, errors.New("invalid signature share encoding")
+	}
+	return &SignatureShare{Index: index, point: point}, nil
+}
+
+func pointFromBytes(b []byte) (*FP256BN.ECP, error) {
+	if len(b) != signatureSize || (b[0] != 0x02 && b[0] != 0x03) {
+		return nil, errors.New("invalid point encoding")
+	}
+	point := FP256BN.ECP_fromBytes(b)
+	if point.Is_infinity() {
+		return nil, errors.New("invalid point encoding")
+	}
+	return point, nil
+}
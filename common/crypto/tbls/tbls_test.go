@@ -0,0 +1,105 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tbls
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThresholdSignature(t *testing.T) {
+	publicKey, keyShares, publicShares, err := Deal(3, 5)
+	require.NoError(t, err)
+	require.Len(t, keyShares, 5)
+	require.Len(t, publicShares, 5)
+
+	message := []byte("message")
+	shares := make([]*SignatureShare, len(keyShares))
+	for i, keyShare := range keyShares {
+		assert.Equal(t, uint32(i+1), keyShare.Index)
+		shares[i] = keyShare.Sign(message)
+		assert.True(t, publicShares[i].VerifyShare(message, shares[i]))
+		assert.False(t, publicShares[(i+1)%5].VerifyShare(message, shares[i]))
+	}
+
+	// any threshold of shares combine into the same signature
+	signature, err := Combine(3, shares[:3])
+	require.NoError(t, err)
+	assert.True(t, publicKey.Verify(message, signature))
+	other, err := Combine(3, []*SignatureShare{shares[4], shares[1], shares[3]})
+	require.NoError(t, err)
+	assert.Equal(t, signature.Bytes(), other.Bytes())
+
+	assert.False(t, publicKey.Verify([]byte("other message"), signature))
+	assert.False(t, publicShares[0].Verify(message, signature))
+
+	// fewer shares than the threshold do not
+	signature, err = Combine(2, shares[:2])
+	require.NoError(t, err)
+	assert.False(t, publicKey.Verify(message, signature))
+}
+
+func TestCombine(t *testing.T) {
+	_, keyShares, _, err := Deal(2, 3)
+	require.NoError(t, err)
+	share := keyShares[0].Sign([]byte("message"))
+
+	_, err = Combine(2, []*SignatureShare{share, share})
+	assert.EqualError(t, err, "1 signature shares of distinct members, 2 required")
+	_, err = Combine(0, []*SignatureShare{share})
+	assert.EqualError(t, err, "invalid threshold 0")
+}
+
+func TestDeal(t *testing.T) {
+	_, _, _, err := Deal(4, 3)
+	assert.EqualError(t, err, "invalid threshold 4 of 3 shares")
+	_, _, _, err = Deal(0, 3)
+	assert.EqualError(t, err, "invalid threshold 0 of 3 shares")
+
+	publicKey, keyShares, _, err := Deal(1, 1)
+	require.NoError(t, err)
+	signature, err := Combine(1, []*SignatureShare{keyShares[0].Sign([]byte("message"))})
+	require.NoError(t, err)
+	assert.True(t, publicKey.Verify([]byte("message"), signature))
+}
+
+func TestEncoding(t *testing.T) {
+	publicKey, keyShares, _, err := Deal(2, 2)
+	require.NoError(t, err)
+	message := []byte("message")
+
+	keyShare, err := KeyShareFromBytes(keyShares[1].Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), keyShare.Index)
+	share, err := SignatureShareFromBytes(keyShare.Sign(message).Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), share.Index)
+
+	signature, err := Combine(2, []*SignatureShare{keyShares[0].Sign(message), share})
+	require.NoError(t, err)
+	decodedSignature, err := SignatureFromBytes(signature.Bytes())
+	require.NoError(t, err)
+	decodedPublicKey, err := PublicKeyFromBytes(publicKey.Bytes())
+	require.NoError(t, err)
+	assert.True(t, decodedPublicKey.Verify(message, decodedSignature))
+	assert.Len(t, signature.Bytes(), 33)
+
+	_, err = KeyShareFromBytes([]byte("garbage"))
+	assert.EqualError(t, err, "invalid key share encoding")
+	invalid := keyShare.Bytes()
+	invalid[0], invalid[1], invalid[2], invalid[3] = 0, 0, 0, 0
+	_, err = KeyShareFromBytes(invalid)
+	assert.EqualError(t, err, "invalid key share encoding")
+	_, err = PublicKeyFromBytes(make([]byte, 128))
+	assert.EqualError(t, err, "invalid public key encoding")
+	_, err = SignatureFromBytes(make([]byte, 33))
+	assert.EqualError(t, err, "invalid signature encoding")
+	_, err = SignatureShareFromBytes([]byte("garbage"))
+	assert.EqualError(t, err, "invalid signature share encoding")
+}
@@ -24,6 +24,8 @@ import (
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var logger = flogging.MustGetLogger("common.deliver")
@@ -91,6 +93,11 @@ type Handler struct {
 	TimeWindow       time.Duration
 	BindingInspector Inspector
 	Metrics          *Metrics
+	// Limiter, when set, limits the sessions and block rate of each client identity.
+	Limiter *SessionLimiter
+	// MemoryLimiter, when set, limits the memory held by the blocks pending
+	// delivery, disconnecting the slowest consumers when the limit is hit.
+	MemoryLimiter *MemoryLimiter
 }
 
 //go:generate counterfeiter -o mock/receiver.go -fake-name Receiver . Receiver
@@ -115,6 +122,19 @@ type Filtered interface {
 	IsFiltered() bool
 }
 
+// SubscriberAware is implemented by response senders which tailor the
+// blocks they send to the identity of the subscriber of a deliver request
+type SubscriberAware interface {
+	SetSubscriber(identity []byte)
+}
+
+// SeekExtensionAware is implemented by response senders which tailor the
+// blocks they send to the extension of the channel header of a deliver
+// request. SetSeekExtension returns an error if the extension is invalid.
+type SeekExtensionAware interface {
+	SetSeekExtension(extension []byte) error
+}
+
 // Server is a polymorphic structure to support generalization of this handler
 // to be able to deliver different type of responses.
 type Server struct {
@@ -247,6 +267,34 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 		return cb.Status_FORBIDDEN, nil
 	}
 
+	var identity []byte
+	subscriberAware, isSubscriberAware := srv.ResponseSender.(SubscriberAware)
+	if h.Limiter != nil || isSubscriberAware {
+		shdr, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+		if err != nil {
+			logger.Warningf("[channel: %s] Failed to unmarshal signature header from %s: %s", chdr.ChannelId, addr, err)
+			return cb.Status_BAD_REQUEST, nil
+		}
+		identity = shdr.Creator
+	}
+	if isSubscriberAware {
+		subscriberAware.SetSubscriber(identity)
+	}
+	if seekExtensionAware, ok := srv.ResponseSender.(SeekExtensionAware); ok {
+		if err := seekExtensionAware.SetSeekExtension(chdr.Extension); err != nil {
+			logger.Warningf("[channel: %s] Received deliver request from %s with invalid extension: %s", chdr.ChannelId, addr, err)
+			return cb.Status_BAD_REQUEST, nil
+		}
+	}
+	if h.Limiter != nil {
+		release, ok := h.Limiter.Acquire(identity)
+		if !ok {
+			logger.Warningf("[channel: %s] Rejecting deliver request for %s because its identity has too many open sessions", chdr.ChannelId, addr)
+			return cb.Status_SERVICE_UNAVAILABLE, nil
+		}
+		defer release()
+	}
+
 	seekInfo := &ab.SeekInfo{}
 	if err = proto.Unmarshal(payload.Data, seekInfo); err != nil {
 		logger.Warningf("[channel: %s] Received a signed deliver request from %s with malformed seekInfo payload: %s", chdr.ChannelId, addr, err)
@@ -316,9 +364,16 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 			return cb.Status_FORBIDDEN, nil
 		}
 
+		if h.Limiter != nil {
+			if err := h.Limiter.WaitBlock(ctx, identity); err != nil {
+				logger.Debugf("Context canceled, aborting wait for block rate limit")
+				return cb.Status_INTERNAL_SERVER_ERROR, errors.Wrapf(err, "context finished before block sent")
+			}
+		}
+
 		logger.Debugf("[channel: %s] Delivering block for (%p) for %s", chdr.ChannelId, seekInfo, addr)
 
-		if err := srv.SendBlockResponse(block); err != nil {
+		if err := h.sendBlock(srv, block, labels); err != nil {
 			logger.Warningf("[channel: %s] Error sending to %s: %s", chdr.ChannelId, addr, err)
 			return cb.Status_INTERNAL_SERVER_ERROR, err
 		}
@@ -335,6 +390,35 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 	return cb.Status_SUCCESS, nil
 }
 
+// sendBlock sends a block within the memory limit of pending deliveries, if
+// any. The stream is disconnected with a RESOURCE_EXHAUSTED status if the
+// delivery is shed, as the consumer is then one of the slowest: the send is
+// left to fail when the stream is closed.
+func (h *Handler) sendBlock(srv *Server, block *cb.Block, labels []string) error {
+	if h.MemoryLimiter == nil {
+		return srv.SendBlockResponse(block)
+	}
+
+	shed, release, ok := h.MemoryLimiter.Reserve(int64(proto.Size(block)))
+	if !ok {
+		h.Metrics.StreamsShed.With(labels...).Add(1)
+		return status.Errorf(codes.ResourceExhausted, "block %d exceeds the memory limit of pending deliveries", block.Header.Number)
+	}
+	defer release()
+
+	sent := make(chan error, 1)
+	go func() {
+		sent <- srv.SendBlockResponse(block)
+	}()
+	select {
+	case err := <-sent:
+		return err
+	case <-shed:
+		h.Metrics.StreamsShed.With(labels...).Add(1)
+		return status.Errorf(codes.ResourceExhausted, "delivery of block %d shed: the memory limit of pending deliveries was hit and this consumer is too slow", block.Header.Number)
+	}
+}
+
 func (h *Handler) validateChannelHeader(ctx context.Context, chdr *cb.ChannelHeader) error {
 	if chdr.GetTimestamp() == nil {
 		err := errors.New("channel header in envelope must contain timestamp")
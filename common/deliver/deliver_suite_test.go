@@ -20,6 +20,18 @@ type filteredResponseSender interface {
 	deliver.Filtered
 }
 
+//go:generate counterfeiter -o mock/subscriber_aware_response_sender.go -fake-name SubscriberAwareResponseSender . subscriberAwareResponseSender
+type subscriberAwareResponseSender interface {
+	deliver.ResponseSender
+	deliver.SubscriberAware
+}
+
+//go:generate counterfeiter -o mock/seek_extension_aware_response_sender.go -fake-name SeekExtensionAwareResponseSender . seekExtensionAwareResponseSender
+type seekExtensionAwareResponseSender interface {
+	deliver.ResponseSender
+	deliver.SeekExtensionAware
+}
+
 func TestDeliver(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Deliver Suite")
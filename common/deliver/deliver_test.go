@@ -25,6 +25,8 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -101,6 +103,7 @@ var _ = Describe("Deliver", func() {
 			fakeRequestsReceived  *metricsfakes.Counter
 			fakeRequestsCompleted *metricsfakes.Counter
 			fakeBlocksSent        *metricsfakes.Counter
+			fakeStreamsShed       *metricsfakes.Counter
 
 			handler *deliver.Handler
 			server  *deliver.Server
@@ -149,6 +152,8 @@ var _ = Describe("Deliver", func() {
 			fakeRequestsCompleted.WithReturns(fakeRequestsCompleted)
 			fakeBlocksSent = &metricsfakes.Counter{}
 			fakeBlocksSent.WithReturns(fakeBlocksSent)
+			fakeStreamsShed = &metricsfakes.Counter{}
+			fakeStreamsShed.WithReturns(fakeStreamsShed)
 
 			deliverMetrics := &deliver.Metrics{
 				StreamsOpened:     fakeStreamsOpened,
@@ -156,6 +161,7 @@ var _ = Describe("Deliver", func() {
 				RequestsReceived:  fakeRequestsReceived,
 				RequestsCompleted: fakeRequestsCompleted,
 				BlocksSent:        fakeBlocksSent,
+				StreamsShed:       fakeStreamsShed,
 			}
 
 			handler = &deliver.Handler{
@@ -366,6 +372,128 @@ var _ = Describe("Deliver", func() {
 			})
 		})
 
+		Context("when the identity of the client is limited", func() {
+			BeforeEach(func() {
+				fakeBlockIterator.NextStub = func() (*cb.Block, cb.Status) {
+					blk := &cb.Block{
+						Header: &cb.BlockHeader{Number: 994 + uint64(fakeBlockIterator.NextCallCount())},
+					}
+					return blk, cb.Status_SUCCESS
+				}
+				seekInfo = &ab.SeekInfo{
+					Start: &ab.SeekPosition{
+						Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 995}},
+					},
+					Stop: seekNewest,
+				}
+			})
+
+			Context("when it has too many open sessions", func() {
+				BeforeEach(func() {
+					handler.Limiter = deliver.NewSessionLimiter(deliver.Limits{MaxSessions: 1})
+					_, ok := handler.Limiter.Acquire(nil)
+					Expect(ok).To(BeTrue())
+				})
+
+				It("sends status service unavailable", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(0))
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+					resp := fakeResponseSender.SendStatusResponseArgsForCall(0)
+					Expect(resp).To(Equal(cb.Status_SERVICE_UNAVAILABLE))
+				})
+			})
+
+			Context("when its block rate is limited", func() {
+				BeforeEach(func() {
+					handler.Limiter = deliver.NewSessionLimiter(deliver.Limits{BlocksPerSecond: 1000, BlockBurst: 2})
+				})
+
+				It("sends all requested blocks and releases the session", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(5))
+
+					_, ok := handler.Limiter.Acquire(nil)
+					Expect(ok).To(BeTrue())
+				})
+
+				Context("when the client disconnects while waiting", func() {
+					BeforeEach(func() {
+						handler.Limiter = deliver.NewSessionLimiter(deliver.Limits{BlocksPerSecond: 0.001, BlockBurst: 2})
+					})
+
+					It("aborts the deliver stream", func() {
+						ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+						defer cancel()
+						err := handler.Handle(ctx, server)
+						Expect(err).To(MatchError("context finished before block sent: context deadline exceeded"))
+						Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(2))
+					})
+				})
+			})
+		})
+
+		Context("when the memory of pending deliveries is limited", func() {
+			BeforeEach(func() {
+				handler.MemoryLimiter = deliver.NewMemoryLimiter(1024)
+			})
+
+			It("sends the blocks and releases their memory", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(1))
+				Expect(handler.MemoryLimiter.Used()).To(BeZero())
+				Expect(fakeStreamsShed.AddCallCount()).To(Equal(0))
+			})
+
+			Context("when the delivery is shed for other deliveries", func() {
+				var unblock chan struct{}
+
+				BeforeEach(func() {
+					unblock = make(chan struct{})
+					fakeResponseSender.SendBlockResponseStub = func(*cb.Block) error {
+						// the consumer is slow enough for the next delivery to
+						// shed this one
+						_, release, ok := handler.MemoryLimiter.Reserve(1024)
+						Expect(ok).To(BeTrue())
+						defer release()
+						<-unblock
+						return errors.New("stream closed")
+					}
+				})
+
+				AfterEach(func() {
+					close(unblock)
+				})
+
+				It("disconnects the consumer with a resource exhausted status", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
+					Expect(err).To(MatchError(ContainSubstring("delivery of block 100 shed")))
+
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(0))
+					Expect(fakeStreamsShed.AddCallCount()).To(Equal(1))
+					Expect(fakeStreamsShed.WithArgsForCall(0)).To(Equal([]string{"channel", "chain-id", "filtered", "false"}))
+				})
+			})
+
+			Context("when a block exceeds the limit", func() {
+				BeforeEach(func() {
+					handler.MemoryLimiter = deliver.NewMemoryLimiter(1)
+				})
+
+				It("disconnects the consumer with a resource exhausted status", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
+					Expect(err).To(MatchError(ContainSubstring("block 100 exceeds the memory limit of pending deliveries")))
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(0))
+				})
+			})
+		})
+
 		Context("when seek info is configured to stop at the oldest block", func() {
 			BeforeEach(func() {
 				seekInfo = &ab.SeekInfo{Start: &ab.SeekPosition{}, Stop: seekOldest}
@@ -488,6 +616,68 @@ var _ = Describe("Deliver", func() {
 			})
 		})
 
+		Context("when the response sender is subscriber aware", func() {
+			var fakeResponseSender *mock.SubscriberAwareResponseSender
+
+			BeforeEach(func() {
+				fakeResponseSender = &mock.SubscriberAwareResponseSender{}
+				server.ResponseSender = fakeResponseSender
+			})
+
+			JustBeforeEach(func() {
+				envelope.Payload = utils.MarshalOrPanic(&cb.Payload{
+					Header: &cb.Header{
+						ChannelHeader:   channelHeaderPayload,
+						SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{Creator: []byte("subscriber")}),
+					},
+					Data: seekInfoPayload,
+				})
+			})
+
+			It("sets the creator of the request as the subscriber before sending blocks", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeResponseSender.SetSubscriberCallCount()).To(Equal(1))
+				Expect(fakeResponseSender.SetSubscriberArgsForCall(0)).To(Equal([]byte("subscriber")))
+				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the response sender is seek extension aware", func() {
+			var fakeResponseSender *mock.SeekExtensionAwareResponseSender
+
+			BeforeEach(func() {
+				channelHeader.Extension = []byte("extension")
+				fakeResponseSender = &mock.SeekExtensionAwareResponseSender{}
+				server.ResponseSender = fakeResponseSender
+			})
+
+			It("sets the extension of the channel header before sending blocks", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeResponseSender.SetSeekExtensionCallCount()).To(Equal(1))
+				Expect(fakeResponseSender.SetSeekExtensionArgsForCall(0)).To(Equal([]byte("extension")))
+				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(1))
+			})
+
+			Context("when the extension is invalid", func() {
+				BeforeEach(func() {
+					fakeResponseSender.SetSeekExtensionReturns(errors.New("bad-extension"))
+				})
+
+				It("sends a bad request message", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(0))
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+					Expect(fakeResponseSender.SendStatusResponseArgsForCall(0)).To(Equal(cb.Status_BAD_REQUEST))
+				})
+			})
+		})
+
 		Context("when sending the block fails", func() {
 			BeforeEach(func() {
 				fakeResponseSender.SendBlockResponseReturns(errors.New("send-fails"))
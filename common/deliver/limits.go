@@ -0,0 +1,144 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deliver
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limits caps the deliver resources a single client identity may use across
+// all of its streams. A zero value disables the corresponding limit.
+type Limits struct {
+	// MaxSessions is the maximum number of concurrent deliver requests.
+	MaxSessions int
+	// BlocksPerSecond is the maximum rate at which blocks are sent.
+	BlocksPerSecond float64
+	// BlockBurst is the number of blocks that may be sent at once before
+	// BlocksPerSecond applies. It is at least one.
+	BlockBurst int
+}
+
+// SessionLimiter enforces Limits per client identity. Requests beyond the
+// session limit are rejected, while blocks beyond the rate limit are delayed
+// until the identity is allowed to receive them.
+type SessionLimiter struct {
+	limits Limits
+	now    func() time.Time
+	sleep  func(context.Context, time.Duration) error
+
+	mutex      sync.Mutex
+	identities map[string]*identityUsage
+}
+
+type identityUsage struct {
+	sessions int
+	tokens   float64
+	last     time.Time
+}
+
+// NewSessionLimiter creates a SessionLimiter enforcing limits.
+func NewSessionLimiter(limits Limits) *SessionLimiter {
+	if limits.BlockBurst < 1 {
+		limits.BlockBurst = 1
+	}
+	return &SessionLimiter{
+		limits:     limits,
+		now:        time.Now,
+		sleep:      sleepContext,
+		identities: map[string]*identityUsage{},
+	}
+}
+
+// Acquire reserves a session for the identity. It returns false if the
+// identity already has the maximum number of sessions; otherwise the returned
+// function must be called when the session ends.
+func (s *SessionLimiter) Acquire(identity []byte) (release func(), ok bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	key := string(identity)
+	usage := s.usage(key)
+	if s.limits.MaxSessions > 0 && usage.sessions >= s.limits.MaxSessions {
+		return nil, false
+	}
+	usage.sessions++
+
+	return func() {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		usage.sessions--
+		if usage.sessions == 0 && s.idle(usage) {
+			delete(s.identities, key)
+		}
+	}, true
+}
+
+// WaitBlock blocks until the identity may be sent another block or the
+// context is done.
+func (s *SessionLimiter) WaitBlock(ctx context.Context, identity []byte) error {
+	if s.limits.BlocksPerSecond <= 0 {
+		return nil
+	}
+
+	s.mutex.Lock()
+	usage := s.usage(string(identity))
+	now := s.now()
+	burst := float64(s.limits.BlockBurst)
+	usage.tokens += now.Sub(usage.last).Seconds() * s.limits.BlocksPerSecond
+	if usage.tokens > burst {
+		usage.tokens = burst
+	}
+	usage.last = now
+	// Reserve the block now so that concurrent sessions of the same
+	// identity queue up behind each other instead of all waking at once.
+	usage.tokens--
+	wait := time.Duration(0)
+	if usage.tokens < 0 {
+		wait = time.Duration(-usage.tokens / s.limits.BlocksPerSecond * float64(time.Second))
+	}
+	s.mutex.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+	return s.sleep(ctx, wait)
+}
+
+func (s *SessionLimiter) usage(key string) *identityUsage {
+	usage, ok := s.identities[key]
+	if !ok {
+		usage = &identityUsage{
+			tokens: float64(s.limits.BlockBurst),
+			last:   s.now(),
+		}
+		s.identities[key] = usage
+	}
+	return usage
+}
+
+// idle returns true when the usage would have refilled to its burst, so
+// forgetting it does not grant the identity any extra blocks.
+func (s *SessionLimiter) idle(usage *identityUsage) bool {
+	if s.limits.BlocksPerSecond <= 0 {
+		return true
+	}
+	deficit := float64(s.limits.BlockBurst) - usage.tokens
+	return s.now().Sub(usage.last).Seconds()*s.limits.BlocksPerSecond >= deficit
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deliver_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/fabric/common/deliver"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SessionLimiter", func() {
+	var limiter *deliver.SessionLimiter
+
+	Describe("Acquire", func() {
+		BeforeEach(func() {
+			limiter = deliver.NewSessionLimiter(deliver.Limits{MaxSessions: 2})
+		})
+
+		It("limits the concurrent sessions of each identity", func() {
+			release1, ok := limiter.Acquire([]byte("alice"))
+			Expect(ok).To(BeTrue())
+			_, ok = limiter.Acquire([]byte("alice"))
+			Expect(ok).To(BeTrue())
+			_, ok = limiter.Acquire([]byte("alice"))
+			Expect(ok).To(BeFalse())
+
+			_, ok = limiter.Acquire([]byte("bob"))
+			Expect(ok).To(BeTrue())
+
+			release1()
+			_, ok = limiter.Acquire([]byte("alice"))
+			Expect(ok).To(BeTrue())
+		})
+
+		Context("when sessions are not limited", func() {
+			BeforeEach(func() {
+				limiter = deliver.NewSessionLimiter(deliver.Limits{})
+			})
+
+			It("always succeeds", func() {
+				for i := 0; i < 100; i++ {
+					_, ok := limiter.Acquire([]byte("alice"))
+					Expect(ok).To(BeTrue())
+				}
+			})
+		})
+	})
+
+	Describe("WaitBlock", func() {
+		BeforeEach(func() {
+			limiter = deliver.NewSessionLimiter(deliver.Limits{BlocksPerSecond: 0.001, BlockBurst: 3})
+		})
+
+		It("allows a burst of blocks without waiting", func() {
+			for i := 0; i < 3; i++ {
+				Expect(limiter.WaitBlock(context.Background(), []byte("alice"))).To(Succeed())
+			}
+		})
+
+		It("waits for the block rate once the burst is used up", func() {
+			for i := 0; i < 3; i++ {
+				Expect(limiter.WaitBlock(context.Background(), []byte("alice"))).To(Succeed())
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			Expect(limiter.WaitBlock(ctx, []byte("alice"))).To(MatchError(context.DeadlineExceeded))
+
+			Expect(limiter.WaitBlock(context.Background(), []byte("bob"))).To(Succeed())
+		})
+
+		It("paces blocks at the configured rate", func() {
+			limiter = deliver.NewSessionLimiter(deliver.Limits{BlocksPerSecond: 100})
+			start := time.Now()
+			for i := 0; i < 4; i++ {
+				Expect(limiter.WaitBlock(context.Background(), []byte("alice"))).To(Succeed())
+			}
+			Expect(time.Since(start)).To(BeNumerically(">=", 25*time.Millisecond))
+		})
+	})
+})
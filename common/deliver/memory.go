@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deliver
+
+import (
+	"sync"
+)
+
+// MemoryLimiter accounts for the memory held by the blocks pending delivery
+// across all the deliver streams, which grows with the number of consumers
+// that read their streams slower than blocks are committed. When a new
+// delivery would exceed the limit, the deliveries that have been pending the
+// longest, those of the slowest consumers, are shed to make room for it.
+type MemoryLimiter struct {
+	limit int64
+
+	mutex   sync.Mutex
+	used    int64
+	pending []*pendingDelivery
+}
+
+type pendingDelivery struct {
+	size int64
+	shed chan struct{}
+}
+
+// NewMemoryLimiter creates a MemoryLimiter holding at most limit bytes of
+// pending deliveries.
+func NewMemoryLimiter(limit int64) *MemoryLimiter {
+	return &MemoryLimiter{limit: limit}
+}
+
+// Reserve accounts for a delivery of size bytes. It returns false if the
+// delivery alone exceeds the limit. Otherwise, the returned channel is closed
+// if the delivery is shed, in which case its stream must be disconnected, and
+// the returned function must be called once the delivery completes.
+func (m *MemoryLimiter) Reserve(size int64) (shed <-chan struct{}, release func(), ok bool) {
+	if size > m.limit {
+		return nil, nil, false
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	// pending deliveries are kept in the order they were reserved, so the
+	// first ones are the oldest
+	for m.used+size > m.limit && len(m.pending) > 0 {
+		oldest := m.pending[0]
+		m.pending = m.pending[1:]
+		m.used -= oldest.size
+		close(oldest.shed)
+	}
+
+	delivery := &pendingDelivery{size: size, shed: make(chan struct{})}
+	m.pending = append(m.pending, delivery)
+	m.used += size
+
+	return delivery.shed, func() { m.release(delivery) }, true
+}
+
+// Used returns the number of bytes held by pending deliveries.
+func (m *MemoryLimiter) Used() int64 {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.used
+}
+
+func (m *MemoryLimiter) release(delivery *pendingDelivery) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for i, d := range m.pending {
+		if d == delivery {
+			m.pending = append(m.pending[:i], m.pending[i+1:]...)
+			m.used -= d.size
+			return
+		}
+	}
+	// the delivery was shed and is no longer accounted for
+}
@@ -0,0 +1,60 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deliver_test
+
+import (
+	"github.com/hyperledger/fabric/common/deliver"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MemoryLimiter", func() {
+	var limiter *deliver.MemoryLimiter
+
+	BeforeEach(func() {
+		limiter = deliver.NewMemoryLimiter(100)
+	})
+
+	It("accounts for the pending deliveries until they are released", func() {
+		_, release1, ok := limiter.Reserve(40)
+		Expect(ok).To(BeTrue())
+		_, release2, ok := limiter.Reserve(60)
+		Expect(ok).To(BeTrue())
+		Expect(limiter.Used()).To(Equal(int64(100)))
+
+		release1()
+		Expect(limiter.Used()).To(Equal(int64(60)))
+		release2()
+		Expect(limiter.Used()).To(BeZero())
+	})
+
+	It("sheds the oldest deliveries when the limit is hit", func() {
+		shed1, release1, _ := limiter.Reserve(30)
+		shed2, _, _ := limiter.Reserve(30)
+		shed3, _, _ := limiter.Reserve(30)
+
+		shed4, _, ok := limiter.Reserve(50)
+		Expect(ok).To(BeTrue())
+		Expect(shed1).To(BeClosed())
+		Expect(shed2).To(BeClosed())
+		Expect(shed3).NotTo(BeClosed())
+		Expect(shed4).NotTo(BeClosed())
+		Expect(limiter.Used()).To(Equal(int64(80)))
+
+		By("ignoring the release of shed deliveries")
+		release1()
+		Expect(limiter.Used()).To(Equal(int64(80)))
+	})
+
+	It("rejects deliveries exceeding the limit alone", func() {
+		shed, _, _ := limiter.Reserve(10)
+		_, _, ok := limiter.Reserve(101)
+		Expect(ok).To(BeFalse())
+		Expect(shed).NotTo(BeClosed())
+		Expect(limiter.Used()).To(Equal(int64(10)))
+	})
+})
@@ -44,6 +44,14 @@ var (
 		LabelNames:   []string{"channel", "filtered"},
 		StatsdFormat: "%{#fqname}.%{channel}.%{filtered}",
 	}
+
+	streamsShed = metrics.CounterOpts{
+		Namespace:    "deliver",
+		Name:         "streams_shed",
+		Help:         "The number of deliver streams disconnected because the memory limit of pending deliveries was hit.",
+		LabelNames:   []string{"channel", "filtered"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{filtered}",
+	}
 )
 
 type Metrics struct {
@@ -52,6 +60,7 @@ type Metrics struct {
 	RequestsReceived  metrics.Counter
 	RequestsCompleted metrics.Counter
 	BlocksSent        metrics.Counter
+	StreamsShed       metrics.Counter
 }
 
 func NewMetrics(p metrics.Provider) *Metrics {
@@ -61,5 +70,6 @@ func NewMetrics(p metrics.Provider) *Metrics {
 		RequestsReceived:  p.NewCounter(requestsReceived),
 		RequestsCompleted: p.NewCounter(requestsCompleted),
 		BlocksSent:        p.NewCounter(blocksSent),
+		StreamsShed:       p.NewCounter(streamsShed),
 	}
 }
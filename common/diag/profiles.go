@@ -0,0 +1,247 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diag
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// captureTimeFormat names the directories of the captures, so that they sort
+// in the order they were captured.
+const captureTimeFormat = "20060102T150405.000000000Z"
+
+// reasonFile is the file of a capture holding the reason it was triggered.
+const reasonFile = "reason"
+
+// ProfileCapturer captures profiles of the process to a directory when it is
+// triggered, typically by a load threshold being crossed, and retains the
+// last captures, so that transient incidents can be analyzed after the fact
+// with go tool pprof. Each capture is a directory holding a file per profile
+// and the reason of the capture.
+type ProfileCapturer struct {
+	// Dir is the directory of the captures
+	Dir string
+	// Profiles are the names of the runtime/pprof profiles captured, such as
+	// heap or goroutine
+	Profiles []string
+	// Retain is the number of captures retained, the older ones are removed
+	Retain int
+	// MinInterval is the minimum time between two captures. Triggers within
+	// MinInterval of the last capture are ignored.
+	MinInterval time.Duration
+	Logger      Logger
+
+	mutex     sync.Mutex
+	last      time.Time
+	capturing bool
+}
+
+// Capture is a capture retained by a ProfileCapturer.
+type Capture struct {
+	Name     string    `json:"name"`
+	Time     time.Time `json:"time"`
+	Reason   string    `json:"reason"`
+	Profiles []string  `json:"profiles"`
+}
+
+// Trigger captures the profiles in the background, unless a capture is in
+// progress or happened within MinInterval. It returns true if a capture was
+// started.
+func (c *ProfileCapturer) Trigger(reason string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	now := time.Now()
+	if c.capturing || (!c.last.IsZero() && now.Sub(c.last) < c.MinInterval) {
+		return false
+	}
+	c.capturing = true
+	c.last = now
+
+	go func() {
+		defer func() {
+			c.mutex.Lock()
+			c.capturing = false
+			c.mutex.Unlock()
+		}()
+		capture, err := c.Capture(now, reason)
+		if err != nil {
+			c.Logger.Errorf("failed capturing profiles: %s", err)
+			return
+		}
+		c.Logger.Infof("Captured profiles %s in %s: %s", strings.Join(capture.Profiles, ", "), filepath.Join(c.Dir, capture.Name), reason)
+	}()
+	return true
+}
+
+// Capture captures the profiles synchronously and removes the captures
+// beyond the retained ones.
+func (c *ProfileCapturer) Capture(t time.Time, reason string) (*Capture, error) {
+	capture := &Capture{
+		Name:   t.UTC().Format(captureTimeFormat),
+		Time:   t,
+		Reason: reason,
+	}
+	dir := filepath.Join(c.Dir, capture.Name)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, errors.Wrapf(err, "failed creating directory %s", dir)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, reasonFile), []byte(reason), 0640); err != nil {
+		return nil, errors.Wrap(err, "failed writing reason")
+	}
+	for _, name := range c.Profiles {
+		if err := writeProfile(filepath.Join(dir, name+".pprof"), name); err != nil {
+			return nil, err
+		}
+		capture.Profiles = append(capture.Profiles, name)
+	}
+
+	return capture, c.prune()
+}
+
+func writeProfile(path, name string) error {
+	profile := pprof.Lookup(name)
+	if profile == nil {
+		return errors.Errorf("unknown profile %s", name)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0640)
+	if err != nil {
+		return errors.Wrapf(err, "failed creating profile %s", name)
+	}
+	defer f.Close()
+	if err := profile.WriteTo(f, 0); err != nil {
+		return errors.Wrapf(err, "failed writing profile %s", name)
+	}
+	return nil
+}
+
+// prune removes the oldest captures beyond the retained ones.
+func (c *ProfileCapturer) prune() error {
+	names, err := c.captureNames()
+	if err != nil {
+		return err
+	}
+	for len(names) > c.Retain {
+		if err := os.RemoveAll(filepath.Join(c.Dir, names[0])); err != nil {
+			return errors.Wrapf(err, "failed removing capture %s", names[0])
+		}
+		names = names[1:]
+	}
+	return nil
+}
+
+// captureNames returns the names of the captures, oldest first.
+func (c *ProfileCapturer) captureNames() ([]string, error) {
+	infos, err := ioutil.ReadDir(c.Dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading directory %s", c.Dir)
+	}
+	var names []string
+	for _, info := range infos {
+		if _, err := time.Parse(captureTimeFormat, info.Name()); err == nil && info.IsDir() {
+			names = append(names, info.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Captures returns the retained captures, oldest first.
+func (c *ProfileCapturer) Captures() ([]*Capture, error) {
+	names, err := c.captureNames()
+	if err != nil {
+		return nil, err
+	}
+	captures := []*Capture{}
+	for _, name := range names {
+		t, _ := time.Parse(captureTimeFormat, name)
+		capture := &Capture{Name: name, Time: t}
+		reason, err := ioutil.ReadFile(filepath.Join(c.Dir, name, reasonFile))
+		if err == nil {
+			capture.Reason = string(reason)
+		}
+		files, err := filepath.Glob(filepath.Join(c.Dir, name, "*.pprof"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed listing profiles of capture %s", name)
+		}
+		for _, file := range files {
+			capture.Profiles = append(capture.Profiles, strings.TrimSuffix(filepath.Base(file), ".pprof"))
+		}
+		captures = append(captures, capture)
+	}
+	return captures, nil
+}
+
+// ServeHTTP serves the retained captures, with the path it is registered at
+// stripped from the request. A GET request on / returns the list of
+// captures; a GET request on the name of a capture followed by the name of a
+// profile, for example /20190102T150405.000000000Z/heap, returns the profile.
+func (c *ProfileCapturer) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		c.sendError(resp, http.StatusBadRequest, errors.Errorf("invalid request method: %s", req.Method))
+		return
+	}
+
+	path := strings.Trim(req.URL.Path, "/")
+	if path != "" {
+		parts := strings.Split(path, "/")
+		if len(parts) != 2 {
+			c.sendError(resp, http.StatusNotFound, errors.Errorf("invalid path: %s", req.URL.Path))
+			return
+		}
+		c.serveProfile(resp, req, parts[0], parts[1])
+		return
+	}
+
+	captures, err := c.Captures()
+	if err != nil {
+		c.Logger.Errorf("failed listing profile captures: %s", err)
+		c.sendError(resp, http.StatusInternalServerError, err)
+		return
+	}
+	resp.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(resp).Encode(captures)
+}
+
+func (c *ProfileCapturer) serveProfile(resp http.ResponseWriter, req *http.Request, capture, profile string) {
+	if _, err := time.Parse(captureTimeFormat, capture); err != nil {
+		c.sendError(resp, http.StatusNotFound, errors.Errorf("capture %s not found", capture))
+		return
+	}
+	path := filepath.Join(c.Dir, capture, filepath.Base(profile)+".pprof")
+	if _, err := os.Stat(path); err != nil {
+		c.sendError(resp, http.StatusNotFound, errors.Errorf("profile %s of capture %s not found", profile, capture))
+		return
+	}
+	resp.Header().Set("Content-Type", "application/octet-stream")
+	resp.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s-%s.pprof", capture, profile))
+	http.ServeFile(resp, req, path)
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (c *ProfileCapturer) sendError(resp http.ResponseWriter, code int, err error) {
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+	json.NewEncoder(resp).Encode(&errorResponse{Error: err.Error()})
+}
@@ -0,0 +1,110 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diag_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/diag"
+	"github.com/hyperledger/fabric/common/flogging/floggingtest"
+	. "github.com/onsi/gomega"
+)
+
+func newProfileCapturer(t *testing.T) (*diag.ProfileCapturer, func()) {
+	dir, err := ioutil.TempDir("", "profiles")
+	if err != nil {
+		t.Fatalf("failed creating temp dir: %s", err)
+	}
+	logger, _ := floggingtest.NewTestLogger(t)
+	capturer := &diag.ProfileCapturer{
+		Dir:      dir,
+		Profiles: []string{"heap", "goroutine"},
+		Retain:   2,
+		Logger:   logger,
+	}
+	return capturer, func() { os.RemoveAll(dir) }
+}
+
+func TestProfileCapturerCapture(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	capturer, cleanup := newProfileCapturer(t)
+	defer cleanup()
+
+	start := time.Date(2019, 1, 2, 15, 4, 5, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		capture, err := capturer.Capture(start.Add(time.Duration(i)*time.Second), "reason")
+		gt.Expect(err).NotTo(HaveOccurred())
+		gt.Expect(capture.Profiles).To(Equal([]string{"heap", "goroutine"}))
+	}
+
+	captures, err := capturer.Captures()
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(captures).To(HaveLen(2))
+	gt.Expect(captures[0].Name).To(Equal("20190102T150406.000000000Z"))
+	gt.Expect(captures[0].Time).To(Equal(start.Add(time.Second)))
+	gt.Expect(captures[0].Reason).To(Equal("reason"))
+	gt.Expect(captures[0].Profiles).To(ConsistOf("heap", "goroutine"))
+	gt.Expect(captures[1].Name).To(Equal("20190102T150407.000000000Z"))
+	gt.Expect(filepath.Join(capturer.Dir, "20190102T150405.000000000Z")).NotTo(BeADirectory())
+
+	capturer.Profiles = []string{"missing"}
+	_, err = capturer.Capture(start, "reason")
+	gt.Expect(err).To(MatchError("unknown profile missing"))
+}
+
+func TestProfileCapturerTrigger(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	capturer, cleanup := newProfileCapturer(t)
+	defer cleanup()
+	capturer.MinInterval = time.Hour
+
+	gt.Expect(capturer.Trigger("commit latency")).To(BeTrue())
+	gt.Expect(capturer.Trigger("queue depth")).To(BeFalse())
+
+	gt.Eventually(func() ([]*diag.Capture, error) { return capturer.Captures() }).Should(HaveLen(1))
+	captures, err := capturer.Captures()
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(captures[0].Reason).To(Equal("commit latency"))
+}
+
+func TestProfileCapturerServeHTTP(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	capturer, cleanup := newProfileCapturer(t)
+	defer cleanup()
+
+	_, err := capturer.Capture(time.Date(2019, 1, 2, 15, 4, 5, 0, time.UTC), "reason")
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	resp := httptest.NewRecorder()
+	capturer.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/", nil))
+	gt.Expect(resp.Code).To(Equal(http.StatusOK))
+	var captures []*diag.Capture
+	gt.Expect(json.Unmarshal(resp.Body.Bytes(), &captures)).To(Succeed())
+	gt.Expect(captures).To(HaveLen(1))
+	gt.Expect(captures[0].Name).To(Equal("20190102T150405.000000000Z"))
+
+	resp = httptest.NewRecorder()
+	capturer.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/20190102T150405.000000000Z/heap", nil))
+	gt.Expect(resp.Code).To(Equal(http.StatusOK))
+	gt.Expect(resp.Body.Len()).NotTo(BeZero())
+
+	resp = httptest.NewRecorder()
+	capturer.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/20190102T150405.000000000Z/block", nil))
+	gt.Expect(resp.Code).To(Equal(http.StatusNotFound))
+	gt.Expect(resp.Body.String()).To(MatchJSON(`{"error": "profile block of capture 20190102T150405.000000000Z not found"}`))
+
+	resp = httptest.NewRecorder()
+	capturer.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/", nil))
+	gt.Expect(resp.Code).To(Equal(http.StatusBadRequest))
+}
@@ -0,0 +1,129 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diag
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+// ThresholdProvider is a metrics.Provider which calls Trigger whenever a
+// gauge is set to, or a histogram observes, a value above the threshold of
+// its metric, such as the commit latency or the depth of a queue of a
+// channel. The metrics are otherwise those of the wrapped Provider.
+type ThresholdProvider struct {
+	metrics.Provider
+	// Thresholds are the thresholds of the metrics, indexed by fully
+	// qualified name, e.g. ledger_block_processing_time
+	Thresholds map[string]float64
+	// Trigger is called with the metric, its labels and the value above the
+	// threshold
+	Trigger func(reason string)
+}
+
+func (p *ThresholdProvider) NewGauge(o metrics.GaugeOpts) metrics.Gauge {
+	gauge := p.Provider.NewGauge(o)
+	t := p.threshold(o.Namespace, o.Subsystem, o.Name)
+	if t == nil {
+		return gauge
+	}
+	return &thresholdGauge{Gauge: gauge, threshold: t}
+}
+
+func (p *ThresholdProvider) NewHistogram(o metrics.HistogramOpts) metrics.Histogram {
+	histogram := p.Provider.NewHistogram(o)
+	t := p.threshold(o.Namespace, o.Subsystem, o.Name)
+	if t == nil {
+		return histogram
+	}
+	return &thresholdHistogram{Histogram: histogram, threshold: t}
+}
+
+func (p *ThresholdProvider) threshold(namespace, subsystem, name string) *threshold {
+	var parts []string
+	for _, part := range []string{namespace, subsystem, name} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	fqname := strings.Join(parts, "_")
+	limit, ok := p.Thresholds[fqname]
+	if !ok {
+		return nil
+	}
+	return &threshold{
+		name:    fqname,
+		limit:   limit,
+		trigger: p.Trigger,
+	}
+}
+
+type threshold struct {
+	name        string
+	limit       float64
+	labelValues []string
+	trigger     func(reason string)
+}
+
+func (t *threshold) with(labelValues []string) *threshold {
+	return &threshold{
+		name:        t.name,
+		limit:       t.limit,
+		labelValues: append(append([]string{}, t.labelValues...), labelValues...),
+		trigger:     t.trigger,
+	}
+}
+
+func (t *threshold) check(value float64) {
+	if value <= t.limit {
+		return
+	}
+	// label values are passed to With as pairs of label name and value
+	var labels []string
+	for i := 0; i+1 < len(t.labelValues); i += 2 {
+		labels = append(labels, fmt.Sprintf("%s=%s", t.labelValues[i], t.labelValues[i+1]))
+	}
+	t.trigger(fmt.Sprintf("%s{%s} is %g, above threshold %g", t.name, strings.Join(labels, ","), value, t.limit))
+}
+
+// thresholdGauge checks the values the gauge is set to. Additions are not
+// checked, as the value of the gauge is not known.
+type thresholdGauge struct {
+	metrics.Gauge
+	threshold *threshold
+}
+
+func (g *thresholdGauge) With(labelValues ...string) metrics.Gauge {
+	return &thresholdGauge{
+		Gauge:     g.Gauge.With(labelValues...),
+		threshold: g.threshold.with(labelValues),
+	}
+}
+
+func (g *thresholdGauge) Set(value float64) {
+	g.Gauge.Set(value)
+	g.threshold.check(value)
+}
+
+type thresholdHistogram struct {
+	metrics.Histogram
+	threshold *threshold
+}
+
+func (h *thresholdHistogram) With(labelValues ...string) metrics.Histogram {
+	return &thresholdHistogram{
+		Histogram: h.Histogram.With(labelValues...),
+		threshold: h.threshold.with(labelValues),
+	}
+}
+
+func (h *thresholdHistogram) Observe(value float64) {
+	h.Histogram.Observe(value)
+	h.threshold.check(value)
+}
@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diag_test
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/diag"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	. "github.com/onsi/gomega"
+)
+
+func TestThresholdProvider(t *testing.T) {
+	gt := NewGomegaWithT(t)
+
+	fakeProvider := &metricsfakes.Provider{}
+	fakeGauge := &metricsfakes.Gauge{}
+	fakeGauge.WithReturns(fakeGauge)
+	fakeProvider.NewGaugeReturns(fakeGauge)
+	fakeHistogram := &metricsfakes.Histogram{}
+	fakeHistogram.WithReturns(fakeHistogram)
+	fakeProvider.NewHistogramReturns(fakeHistogram)
+
+	var reasons []string
+	provider := &diag.ThresholdProvider{
+		Provider: fakeProvider,
+		Thresholds: map[string]float64{
+			"ledger_block_processing_time":     2,
+			"gossip_state_payload_buffer_size": 100,
+		},
+		Trigger: func(reason string) { reasons = append(reasons, reason) },
+	}
+
+	histogram := provider.NewHistogram(metrics.HistogramOpts{Namespace: "ledger", Name: "block_processing_time", LabelNames: []string{"channel"}})
+	histogram.With("channel", "mychannel").Observe(1.5)
+	histogram.With("channel", "mychannel").Observe(2.5)
+	gt.Expect(fakeHistogram.ObserveCallCount()).To(Equal(2))
+	gt.Expect(reasons).To(Equal([]string{"ledger_block_processing_time{channel=mychannel} is 2.5, above threshold 2"}))
+
+	gauge := provider.NewGauge(metrics.GaugeOpts{Namespace: "gossip", Subsystem: "state", Name: "payload_buffer_size", LabelNames: []string{"channel"}})
+	gauge.With("channel", "mychannel").Set(101)
+	gauge.With("channel", "mychannel").Add(1000)
+	gt.Expect(fakeGauge.SetCallCount()).To(Equal(1))
+	gt.Expect(fakeGauge.AddCallCount()).To(Equal(1))
+	gt.Expect(reasons).To(HaveLen(2))
+	gt.Expect(reasons[1]).To(Equal("gossip_state_payload_buffer_size{channel=mychannel} is 101, above threshold 100"))
+
+	other := provider.NewGauge(metrics.GaugeOpts{Namespace: "gossip", Name: "other"})
+	gt.Expect(other).To(BeIdenticalTo(fakeGauge))
+	other.Set(1e9)
+	gt.Expect(reasons).To(HaveLen(2))
+}
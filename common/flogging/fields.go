@@ -0,0 +1,49 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flogging
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Keys of the structured fields identifying the subject of a log record. The
+// logger name is recorded under ModuleKey by the JSON encoding.
+const (
+	ModuleKey   = "module"
+	ChannelKey  = "channel"
+	TxIDKey     = "txid"
+	IdentityKey = "identity"
+	// CorrelationIDKey identifies the records of the requests preceding a
+	// transaction, before its ID is known
+	CorrelationIDKey = "correlationid"
+)
+
+// IdentityHash returns the hex encoded SHA256 hash of a serialized identity, so
+// that the records of an identity can be correlated without logging its
+// certificate.
+func IdentityHash(identity []byte) string {
+	hash := sha256.Sum256(identity)
+	return hex.EncodeToString(hash[:])
+}
+
+// ForTransaction returns a logger adding the channel, the transaction ID and
+// the hash of the creator identity of a transaction to its records. Empty
+// values are omitted.
+func (f *FabricLogger) ForTransaction(channel, txID string, creator []byte) *FabricLogger {
+	var fields []interface{}
+	if channel != "" {
+		fields = append(fields, ChannelKey, channel)
+	}
+	if txID != "" {
+		fields = append(fields, TxIDKey, txID)
+	}
+	if len(creator) != 0 {
+		fields = append(fields, IdentityKey, IdentityHash(creator))
+	}
+	return f.With(fields...)
+}
@@ -0,0 +1,42 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flogging_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentityHash(t *testing.T) {
+	hash := sha256.Sum256([]byte("creator"))
+	assert.Equal(t, hex.EncodeToString(hash[:]), flogging.IdentityHash([]byte("creator")))
+}
+
+func TestForTransaction(t *testing.T) {
+	flogging.Reset()
+	defer flogging.Reset()
+
+	buf := &bytes.Buffer{}
+	flogging.Init(flogging.Config{
+		Format:  "json",
+		LogSpec: "DEBUG",
+		Writer:  buf,
+	})
+
+	logger := flogging.MustGetLogger("token.server")
+	logger.ForTransaction("mychannel", "tx1", []byte("creator")).Info("processed command")
+	assert.Regexp(t, `{"level":"info","ts":\d+.\d+,"module":"token.server","caller":"flogging/fields_test.go:\d+","msg":"processed command","channel":"mychannel","txid":"tx1","identity":"`+flogging.IdentityHash([]byte("creator"))+`"}\s+`, buf.String())
+
+	buf.Reset()
+	logger.ForTransaction("mychannel", "", nil).Info("processed command")
+	assert.Regexp(t, `"msg":"processed command","channel":"mychannel"}\s+$`, buf.String())
+}
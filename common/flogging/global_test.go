@@ -61,7 +61,7 @@ func TestGlobalInitJSON(t *testing.T) {
 	logger := flogging.MustGetLogger("testlogger")
 	logger.Debug("this is a message")
 
-	assert.Regexp(t, `{"level":"debug","ts":\d+.\d+,"name":"testlogger","caller":"flogging/global_test.go:\d+","msg":"this is a message"}\s+`, buf.String())
+	assert.Regexp(t, `{"level":"debug","ts":\d+.\d+,"module":"testlogger","caller":"flogging/global_test.go:\d+","msg":"this is a message"}\s+`, buf.String())
 }
 
 func TestGlobalInitLogfmt(t *testing.T) {
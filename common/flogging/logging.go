@@ -22,7 +22,8 @@ import (
 // Config is used to provide dependencies to a Logging instance.
 type Config struct {
 	// Format is the log record format specifier for the Logging instance. If the
-	// spec is the string "json", log records will be formatted as JSON. Any
+	// spec is the string "json", log records will be formatted as JSON, with the
+	// logger name recorded as the module field. Any
 	// other string will be provided to the FormatEncoder. Please see
 	// fabenc.ParseFormat for details on the supported verbs.
 	//
@@ -215,11 +216,13 @@ func (s *Logging) ZapLogger(name string) *zap.Logger {
 	levelEnabler := zap.LevelEnablerFunc(func(l zapcore.Level) bool { return true })
 
 	s.mutex.RLock()
+	jsonEncoderConfig := s.encoderConfig
+	jsonEncoderConfig.NameKey = ModuleKey
 	core := &Core{
 		LevelEnabler: levelEnabler,
 		Levels:       s.LoggerLevels,
 		Encoders: map[Encoding]zapcore.Encoder{
-			JSON:    zapcore.NewJSONEncoder(s.encoderConfig),
+			JSON:    zapcore.NewJSONEncoder(jsonEncoderConfig),
 			CONSOLE: fabenc.NewFormatEncoder(s.multiFormatter),
 			LOGFMT:  zaplogfmt.NewEncoder(s.encoderConfig),
 		},
@@ -44,6 +44,8 @@ type blockfileMgr struct {
 	cpInfoCond        *sync.Cond
 	currentFileWriter *blockfileWriter
 	bcInfo            atomic.Value
+	// hash is the hashing suite of the chain; nil hashes the blocks with SHA256
+	hash func([]byte) []byte
 }
 
 /*
@@ -151,13 +153,16 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 		PreviousBlockHash: nil}
 
 	if !cpInfo.isChainEmpty {
+		if err := mgr.loadHashingSuite(); err != nil {
+			panic(fmt.Sprintf("Could not determine the hashing suite of the chain: %s", err))
+		}
 		//If start up is a restart of an existing storage, sync the index from block storage and update BlockchainInfo for external API's
 		mgr.syncIndex()
 		lastBlockHeader, err := mgr.retrieveBlockHeaderByNumber(cpInfo.lastBlockNumber)
 		if err != nil {
 			panic(fmt.Sprintf("Could not retrieve header of the last block form file: %s", err))
 		}
-		lastBlockHash := lastBlockHeader.Hash()
+		lastBlockHash := mgr.blockHash(lastBlockHeader)
 		previousBlockHash := lastBlockHeader.PreviousHash
 		bcInfo = &common.BlockchainInfo{
 			Height:            cpInfo.lastBlockNumber + 1,
@@ -168,6 +173,40 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 	return mgr
 }
 
+// loadHashingSuite determines the hashing suite of the chain from its genesis block,
+// the first block of the first block file
+func (mgr *blockfileMgr) loadHashingSuite() error {
+	if mgr.conf.hashingSuite == nil {
+		return nil
+	}
+	stream, err := newBlockfileStream(mgr.rootDir, 0, 0)
+	if err != nil {
+		return err
+	}
+	defer stream.close()
+	blockBytes, err := stream.nextBlockBytes()
+	if err != nil {
+		return err
+	}
+	if blockBytes == nil {
+		return errors.New("genesis block not found")
+	}
+	genesisBlock, err := deserializeBlock(blockBytes)
+	if err != nil {
+		return err
+	}
+	mgr.hash, err = mgr.conf.hashingSuite(genesisBlock)
+	return err
+}
+
+// blockHash returns the hash of the block header with the hashing suite of the chain
+func (mgr *blockfileMgr) blockHash(header *common.BlockHeader) []byte {
+	if mgr.hash == nil {
+		return header.Hash()
+	}
+	return header.HashWith(mgr.hash)
+}
+
 //cp = checkpointInfo, from the database gets the file suffix and the size of
 // the file of where the last block was written.  Also retrieves contains the
 // last block number that was written.  At init
@@ -256,11 +295,18 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 			bcInfo.CurrentBlockHash, block.Header.PreviousHash,
 		)
 	}
+	if block.Header.Number == 0 && mgr.conf.hashingSuite != nil {
+		hash, err := mgr.conf.hashingSuite(block)
+		if err != nil {
+			return errors.WithMessage(err, "error determining the hashing suite of the chain")
+		}
+		mgr.hash = hash
+	}
 	blockBytes, info, err := serializeBlock(block)
 	if err != nil {
 		return errors.WithMessage(err, "error serializing block")
 	}
-	blockHash := block.Header.Hash()
+	blockHash := mgr.blockHash(block.Header)
 	//Get the location / offset where each transaction starts in the block and where the block ends
 	txOffsets := info.txOffsets
 	currentOffset := mgr.cpInfo.latestFileChunksize
@@ -408,7 +454,7 @@ func (mgr *blockfileMgr) syncIndex() error {
 		}
 
 		//Update the blockIndexInfo with what was actually stored in file system
-		blockIdxInfo.blockHash = info.blockHeader.Hash()
+		blockIdxInfo.blockHash = mgr.blockHash(info.blockHeader)
 		blockIdxInfo.blockNum = info.blockHeader.Number
 		blockIdxInfo.flp = &fileLocPointer{fileSuffixNum: blockPlacementInfo.fileNum,
 			locPointer: locPointer{offset: int(blockPlacementInfo.blockStartOffset)}}
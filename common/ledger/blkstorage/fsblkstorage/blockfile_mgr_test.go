@@ -21,10 +21,12 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
 	ledgerutil "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
 	putil "github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -54,6 +56,52 @@ func TestAddBlockWithWrongHash(t *testing.T) {
 	t.Logf("err = %s", err)
 }
 
+func TestBlockfileMgrHashingSuite(t *testing.T) {
+	hashingSuite := func(genesisBlock *common.Block) (func([]byte) []byte, error) {
+		return util.ComputeSHA3256, nil
+	}
+	env := newTestEnv(t, NewConfWithHashingSuite(testPath(), 0, hashingSuite))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blocks := testutil.ConstructTestBlocks(t, 5)
+	for i := 1; i < len(blocks); i++ {
+		blocks[i].Header.PreviousHash = blocks[i-1].Header.HashWith(util.ComputeSHA3256)
+	}
+
+	// blocks chained with SHA256 are rejected
+	assert.NoError(t, blkfileMgrWrapper.blockfileMgr.addBlock(blocks[0]))
+	sha256Block := testutil.ConstructTestBlocks(t, 2)[1]
+	sha256Block.Header.PreviousHash = blocks[0].Header.Hash()
+	err := blkfileMgrWrapper.blockfileMgr.addBlock(sha256Block)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected Previous block hash")
+
+	blkfileMgrWrapper.addBlocks(blocks[1:])
+	lastHash := blocks[4].Header.HashWith(util.ComputeSHA3256)
+	assert.Equal(t, lastHash, blkfileMgrWrapper.blockfileMgr.getBlockchainInfo().CurrentBlockHash)
+	blkfileMgrWrapper.close()
+
+	// the hashing suite is restored from the genesis block on restart
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	assert.Equal(t, lastHash, blkfileMgrWrapper.blockfileMgr.getBlockchainInfo().CurrentBlockHash)
+	for _, block := range blocks {
+		b, err := blkfileMgrWrapper.blockfileMgr.retrieveBlockByHash(block.Header.HashWith(util.ComputeSHA3256))
+		assert.NoError(t, err)
+		assert.Equal(t, block, b)
+	}
+
+	failingSuite := func(genesisBlock *common.Block) (func([]byte) []byte, error) {
+		return nil, errors.New("unknown hashing suite")
+	}
+	failingEnv := newTestEnv(t, NewConfWithHashingSuite(testPath(), 0, failingSuite))
+	defer failingEnv.Cleanup()
+	failingWrapper := newTestBlockfileWrapper(failingEnv, "testLedger")
+	defer failingWrapper.close()
+	err = failingWrapper.blockfileMgr.addBlock(blocks[0])
+	assert.EqualError(t, err, "error determining the hashing suite of the chain: unknown hashing suite")
+}
+
 func TestBlockfileMgrCrashDuringWriting(t *testing.T) {
 	testBlockfileMgrCrashDuringWriting(t, 10, 2, 1000, 10)
 	testBlockfileMgrCrashDuringWriting(t, 10, 2, 1000, 1)
@@ -16,7 +16,11 @@ limitations under the License.
 
 package fsblkstorage
 
-import "path/filepath"
+import (
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/protos/common"
+)
 
 const (
 	// ChainsDir is the name of the directory containing the channel ledgers.
@@ -26,19 +30,30 @@ const (
 	defaultMaxBlockfileSize = 64 * 1024 * 1024 // bytes
 )
 
+// HashingSuite returns the algorithm hashing the blocks of a chain from the genesis block of the chain
+type HashingSuite func(genesisBlock *common.Block) (func([]byte) []byte, error)
+
 // Conf encapsulates all the configurations for `FsBlockStore`
 type Conf struct {
 	blockStorageDir  string
 	maxBlockfileSize int
+	hashingSuite     HashingSuite
 }
 
 // NewConf constructs new `Conf`.
 // blockStorageDir is the top level folder under which `FsBlockStore` manages its data
 func NewConf(blockStorageDir string, maxBlockfileSize int) *Conf {
+	return NewConfWithHashingSuite(blockStorageDir, maxBlockfileSize, nil)
+}
+
+// NewConfWithHashingSuite constructs new `Conf` whose chains hash their blocks
+// with the hashing suite of their genesis block. A nil hashingSuite hashes the
+// blocks with SHA256.
+func NewConfWithHashingSuite(blockStorageDir string, maxBlockfileSize int, hashingSuite HashingSuite) *Conf {
 	if maxBlockfileSize <= 0 {
 		maxBlockfileSize = defaultMaxBlockfileSize
 	}
-	return &Conf{blockStorageDir, maxBlockfileSize}
+	return &Conf{blockStorageDir, maxBlockfileSize, hashingSuite}
 }
 
 func (conf *Conf) getIndexDir() string {
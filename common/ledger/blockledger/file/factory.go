@@ -19,6 +19,7 @@ package fileledger
 import (
 	"sync"
 
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
@@ -65,13 +66,18 @@ func (flf *fileLedgerFactory) Close() {
 	flf.blkstorageProvider.Close()
 }
 
-// New creates a new ledger factory
-func New(directory string) blockledger.Factory {
+// New creates a new ledger factory. If indexTxIDs is set, the ledgers index
+// the blocks by the identifiers of their transactions, which are only indexed
+// from the next block appended when a ledger was not indexing them before.
+func New(directory string, indexTxIDs bool) blockledger.Factory {
+	attrsToIndex := []blkstorage.IndexableAttr{blkstorage.IndexableAttrBlockNum}
+	if indexTxIDs {
+		attrsToIndex = append(attrsToIndex, blkstorage.IndexableAttrTxID, blkstorage.IndexableAttrBlockTxID)
+	}
 	return &fileLedgerFactory{
 		blkstorageProvider: fsblkstorage.NewProvider(
-			fsblkstorage.NewConf(directory, -1),
-			&blkstorage.IndexConfig{
-				AttrsToIndex: []blkstorage.IndexableAttr{blkstorage.IndexableAttrBlockNum}},
+			fsblkstorage.NewConfWithHashingSuite(directory, -1, channelconfig.HashingSuiteFromGenesisBlock),
+			&blkstorage.IndexConfig{AttrsToIndex: attrsToIndex},
 		),
 		ledgers: make(map[string]blockledger.ReadWriter),
 	}
@@ -9,9 +9,12 @@ package fileledger
 import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
 )
 
 var logger = flogging.MustGetLogger("common.ledger.blockledger.file")
@@ -28,6 +31,7 @@ type FileLedgerBlockStore interface {
 	AddBlock(block *cb.Block) error
 	GetBlockchainInfo() (*cb.BlockchainInfo, error)
 	RetrieveBlocks(startBlockNumber uint64) (ledger.ResultsIterator, error)
+	RetrieveBlockByTxID(txID string) (*cb.Block, error)
 }
 
 // NewFileLedger creates a new FileLedger for interaction with the ledger
@@ -102,6 +106,36 @@ func (fl *FileLedger) Height() uint64 {
 	return info.Height
 }
 
+// TxPosition returns the number of the block including the transaction and the
+// index of the transaction in that block
+func (fl *FileLedger) TxPosition(txID string) (uint64, uint32, error) {
+	block, err := fl.blockStore.RetrieveBlockByTxID(txID)
+	switch err {
+	case nil:
+	case blkstorage.ErrNotFoundInIndex:
+		return 0, 0, blockledger.ErrTxNotFound
+	case blkstorage.ErrAttrNotIndexed:
+		return 0, 0, blockledger.ErrTxIndexDisabled
+	default:
+		return 0, 0, err
+	}
+
+	for i, envBytes := range block.GetData().GetData() {
+		env, err := utils.UnmarshalEnvelope(envBytes)
+		if err != nil {
+			continue
+		}
+		chdr, err := utils.ChannelHeader(env)
+		if err != nil {
+			continue
+		}
+		if chdr.TxId == txID {
+			return block.Header.Number, uint32(i), nil
+		}
+	}
+	return 0, 0, errors.Errorf("transaction %s not found in block %d indexed for it", txID, block.Header.Number)
+}
+
 // Append a new block to the ledger
 func (fl *FileLedger) Append(block *cb.Block) error {
 	err := fl.blockStore.AddBlock(block)
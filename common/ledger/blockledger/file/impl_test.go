@@ -20,6 +20,7 @@ import (
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -40,7 +41,7 @@ func initialize(t *testing.T) (*testEnv, *FileLedger) {
 	name, err := ioutil.TempDir("", "hyperledger_fabric")
 	assert.NoError(t, err, "Error creating temp dir: %s", err)
 
-	flf := New(name).(*fileLedgerFactory)
+	flf := New(name, false).(*fileLedgerFactory)
 	fl, err := flf.GetOrCreate(genesisconfig.TestChainID)
 	assert.NoError(t, err, "Error GetOrCreate chain")
 
@@ -154,7 +155,7 @@ func TestReinitialization(t *testing.T) {
 	tev.shutDown()
 
 	// re-initialize the ledger provider (not the test ledger itself!)
-	provider2 := New(tev.location)
+	provider2 := New(tev.location, false)
 
 	// assert expected ledgers exist
 	chains := provider2.ChainIDs()
@@ -295,3 +296,47 @@ func TestBlockstoreError(t *testing.T) {
 		assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, status, "Expected service unavailable error")
 	}
 }
+
+func txEnvelope(txID string) *cb.Envelope {
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{ChannelId: genesisconfig.TestChainID, TxId: txID}),
+			},
+		}),
+	}
+}
+
+func TestTxPosition(t *testing.T) {
+	name, err := ioutil.TempDir("", "hyperledger_fabric")
+	assert.NoError(t, err, "Error creating temp dir: %s", err)
+	defer os.RemoveAll(name)
+
+	flf := New(name, true)
+	defer flf.Close()
+	rw, err := flf.GetOrCreate(genesisconfig.TestChainID)
+	assert.NoError(t, err, "Error GetOrCreate chain")
+	fl := rw.(*FileLedger)
+	assert.NoError(t, fl.Append(genesisBlock))
+	assert.NoError(t, fl.Append(blockledger.CreateNextBlock(fl, []*cb.Envelope{txEnvelope("tx1"), txEnvelope("tx2")})))
+	assert.NoError(t, fl.Append(blockledger.CreateNextBlock(fl, []*cb.Envelope{txEnvelope("tx3"), txEnvelope("tx1")})))
+
+	blockNumber, txIndex, err := fl.TxPosition("tx2")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), blockNumber)
+	assert.Equal(t, uint32(1), txIndex)
+
+	// A duplicate transaction is located at its first inclusion
+	blockNumber, txIndex, err = fl.TxPosition("tx1")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), blockNumber)
+	assert.Equal(t, uint32(0), txIndex)
+
+	_, _, err = fl.TxPosition("tx4")
+	assert.Equal(t, blockledger.ErrTxNotFound, err)
+
+	tev, fl := initialize(t)
+	defer tev.tearDown()
+	_, _, err = fl.TxPosition("tx1")
+	assert.Equal(t, blockledger.ErrTxIndexDisabled, err)
+}
@@ -9,6 +9,17 @@ package blockledger
 import (
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrTxNotFound is returned by a TxLocator for the transactions which
+	// are not included in any block of the ledger.
+	ErrTxNotFound = errors.New("transaction not found")
+
+	// ErrTxIndexDisabled is returned by a TxLocator whose ledger does not
+	// index the transaction identifiers.
+	ErrTxIndexDisabled = errors.New("transaction index disabled")
 )
 
 // Factory retrieves or creates new ledgers by chainID
@@ -42,6 +53,14 @@ type Reader interface {
 	Height() uint64
 }
 
+// TxLocator is implemented by the ledgers able to locate the block including
+// a transaction
+type TxLocator interface {
+	// TxPosition returns the number of the first block including the
+	// transaction and the index of the transaction in that block
+	TxPosition(txID string) (blockNumber uint64, txIndex uint32, err error)
+}
+
 // Writer allows the caller to modify the ledger
 type Writer interface {
 	// Append a new block to the ledger
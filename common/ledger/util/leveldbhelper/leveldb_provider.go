@@ -123,6 +123,11 @@ func NewUpdateBatch() *UpdateBatch {
 	return &UpdateBatch{make(map[string][]byte)}
 }
 
+// NewUpdateBatchWithSize constructs an instance of a Batch sized for the given number of entries
+func NewUpdateBatchWithSize(size int) *UpdateBatch {
+	return &UpdateBatch{make(map[string][]byte, size)}
+}
+
 // Put adds a KV
 func (batch *UpdateBatch) Put(key []byte, value []byte) {
 	if value == nil {
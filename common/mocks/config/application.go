@@ -8,21 +8,43 @@ package config
 
 import (
 	"github.com/hyperledger/fabric/common/channelconfig"
+	pb "github.com/hyperledger/fabric/protos/peer"
 )
 
 type MockApplication struct {
-	CapabilitiesRv channelconfig.ApplicationCapabilities
-	Acls           map[string]string
+	CapabilitiesRv         channelconfig.ApplicationCapabilities
+	Acls                   map[string]string
+	TokenSupplyRv          map[string]*pb.TokenSupplyLimit
+	TokenTypeNamespacesRv  map[string]*pb.TokenTypeNamespace
+	TokenMetadataSchemasRv map[string]*pb.TokenMetadataSchema
+	TokenDriverRv          string
+	OrganizationsRv        map[string]channelconfig.ApplicationOrg
 }
 
 func (m *MockApplication) Organizations() map[string]channelconfig.ApplicationOrg {
-	return nil
+	return m.OrganizationsRv
 }
 
 func (m *MockApplication) Capabilities() channelconfig.ApplicationCapabilities {
 	return m.CapabilitiesRv
 }
 
+func (m *MockApplication) TokenSupply() map[string]*pb.TokenSupplyLimit {
+	return m.TokenSupplyRv
+}
+
+func (m *MockApplication) TokenTypeNamespaces() map[string]*pb.TokenTypeNamespace {
+	return m.TokenTypeNamespacesRv
+}
+
+func (m *MockApplication) TokenMetadataSchemas() map[string]*pb.TokenMetadataSchema {
+	return m.TokenMetadataSchemasRv
+}
+
+func (m *MockApplication) TokenDriver() string {
+	return m.TokenDriverRv
+}
+
 func (m *MockApplication) PolicyRefForAPI(apiName string) string {
 	if m.Acls == nil {
 		return ""
@@ -35,6 +57,29 @@ func (m *MockApplication) APIPolicyMapper() channelconfig.PolicyMapper {
 	return m
 }
 
+type MockApplicationOrg struct {
+	NameRv               string
+	MSPIDRv              string
+	AnchorPeersRv        []*pb.AnchorPeer
+	TokenEncryptionKeyRv []byte
+}
+
+func (mao *MockApplicationOrg) Name() string {
+	return mao.NameRv
+}
+
+func (mao *MockApplicationOrg) MSPID() string {
+	return mao.MSPIDRv
+}
+
+func (mao *MockApplicationOrg) AnchorPeers() []*pb.AnchorPeer {
+	return mao.AnchorPeersRv
+}
+
+func (mao *MockApplicationOrg) TokenEncryptionKey() []byte {
+	return mao.TokenEncryptionKeyRv
+}
+
 type MockApplicationCapabilities struct {
 	SupportedRv                  error
 	ForbidDuplicateTXIdInBlockRv bool
@@ -47,6 +92,9 @@ type MockApplicationCapabilities struct {
 	KeyLevelEndorsementRv        bool
 	V1_3ValidationRv             bool
 	FabTokenRv                   bool
+	WASMChaincodeRv              bool
+	TokenEncryptionRv            bool
+	TxExpirationRv               bool
 }
 
 func (mac *MockApplicationCapabilities) Supported() error {
@@ -92,3 +140,15 @@ func (mac *MockApplicationCapabilities) V1_3Validation() bool {
 func (mac *MockApplicationCapabilities) FabToken() bool {
 	return mac.FabTokenRv
 }
+
+func (mac *MockApplicationCapabilities) WASMChaincode() bool {
+	return mac.WASMChaincodeRv
+}
+
+func (mac *MockApplicationCapabilities) TokenEncryption() bool {
+	return mac.TokenEncryptionRv
+}
+
+func (mac *MockApplicationCapabilities) TxExpiration() bool {
+	return mac.TxExpirationRv
+}
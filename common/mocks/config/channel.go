@@ -30,6 +30,10 @@ func nearIdentityHash(input []byte) []byte {
 type Channel struct {
 	// HashingAlgorithmVal is returned as the result of HashingAlgorithm() if set
 	HashingAlgorithmVal func([]byte) []byte
+	// HashingSuiteVal is returned as the result of HashingSuite() if set
+	HashingSuiteVal func([]byte) []byte
+	// HashingSuiteNameVal is returned as the result of HashingSuiteName()
+	HashingSuiteNameVal string
 	// BlockDataHashingStructureWidthVal is returned as the result of BlockDataHashingStructureWidth()
 	BlockDataHashingStructureWidthVal uint32
 	// OrdererAddressesVal is returned as the result of OrdererAddresses()
@@ -46,6 +50,19 @@ func (scm *Channel) HashingAlgorithm() func([]byte) []byte {
 	return scm.HashingAlgorithmVal
 }
 
+// HashingSuite returns the HashingSuiteVal if set, otherwise SHA256
+func (scm *Channel) HashingSuite() func([]byte) []byte {
+	if scm.HashingSuiteVal == nil {
+		return util.ComputeSHA256
+	}
+	return scm.HashingSuiteVal
+}
+
+// HashingSuiteName returns the HashingSuiteNameVal
+func (scm *Channel) HashingSuiteName() string {
+	return scm.HashingSuiteNameVal
+}
+
 // BlockDataHashingStructureWidth returns the BlockDataHashingStructureWidthVal
 func (scm *Channel) BlockDataHashingStructureWidth() uint32 {
 	return scm.BlockDataHashingStructureWidthVal
@@ -68,6 +85,9 @@ type ChannelCapabilities struct {
 
 	// MSPVersionVal is returned by MSPVersion()
 	MSPVersionVal msp.MSPVersion
+
+	// HashingSuiteVal is returned by HashingSuite()
+	HashingSuiteVal bool
 }
 
 // Supported returns SupportedErr
@@ -79,3 +99,8 @@ func (cc *ChannelCapabilities) Supported() error {
 func (cc *ChannelCapabilities) MSPVersion() msp.MSPVersion {
 	return cc.MSPVersionVal
 }
+
+// HashingSuite returns HashingSuiteVal
+func (cc *ChannelCapabilities) HashingSuite() bool {
+	return cc.HashingSuiteVal
+}
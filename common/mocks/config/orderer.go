@@ -32,6 +32,8 @@ type Orderer struct {
 	KafkaBrokersVal []string
 	// MaxChannelsCountVal is returns as the result of MaxChannelsCount()
 	MaxChannelsCountVal uint64
+	// IngressQuotasVal is returned as the result of IngressQuotas()
+	IngressQuotasVal map[string]*ab.IngressQuota
 	// OrganizationsVal is returned as the result of Organizations()
 	OrganizationsVal map[string]channelconfig.Org
 	// CapabilitiesVal is returned as the result of Capabilities()
@@ -78,6 +80,11 @@ func (o *Orderer) MaxChannelsCount() uint64 {
 	return o.MaxChannelsCountVal
 }
 
+// IngressQuotas returns the IngressQuotasVal
+func (o *Orderer) IngressQuotas() map[string]*ab.IngressQuota {
+	return o.IngressQuotasVal
+}
+
 // Organizations returns OrganizationsVal
 func (o *Orderer) Organizations() map[string]channelconfig.Org {
 	return o.OrganizationsVal
@@ -103,6 +110,12 @@ type OrdererCapabilities struct {
 	ExpirationVal bool
 
 	Kafka2RaftMigVal bool
+
+	// IngressQuotasVal is returned by IngressQuotas()
+	IngressQuotasVal bool
+
+	// PriorityVal is returned by Priority()
+	PriorityVal bool
 }
 
 // Supported returns SupportedErr
@@ -130,3 +143,13 @@ func (oc *OrdererCapabilities) ExpirationCheck() bool {
 func (oc *OrdererCapabilities) Kafka2RaftMigration() bool {
 	return oc.Kafka2RaftMigVal
 }
+
+// IngressQuotas returns IngressQuotasVal
+func (oc *OrdererCapabilities) IngressQuotas() bool {
+	return oc.IngressQuotasVal
+}
+
+// Priority returns PriorityVal
+func (oc *OrdererCapabilities) Priority() bool {
+	return oc.PriorityVal
+}
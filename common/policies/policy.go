@@ -46,6 +46,9 @@ const (
 	// ChannelApplicationAdmins is the label for the channel's application admin policy
 	ChannelApplicationAdmins = PathSeparator + ChannelPrefix + PathSeparator + ApplicationPrefix + PathSeparator + "Admins"
 
+	// ChannelOrdererPriorityWriters is the label for the policy the messages requesting a priority ordering must satisfy
+	ChannelOrdererPriorityWriters = PathSeparator + ChannelPrefix + PathSeparator + OrdererPrefix + PathSeparator + "PriorityWriters"
+
 	// BlockValidation is the label for the policy which should validate the block signatures for the channel
 	BlockValidation = PathSeparator + ChannelPrefix + PathSeparator + OrdererPrefix + PathSeparator + "BlockValidation"
 )
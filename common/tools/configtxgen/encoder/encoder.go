@@ -7,6 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package encoder
 
 import (
+	"encoding/pem"
+	"io/ioutil"
+
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/common/channelconfig"
@@ -19,6 +22,7 @@ import (
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/msp"
 	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -203,6 +207,17 @@ func NewOrdererGroup(conf *genesisconfig.Orderer) (*cb.ConfigGroup, error) {
 	addValue(ordererGroup, channelconfig.BatchTimeoutValue(conf.BatchTimeout.String()), channelconfig.AdminsPolicyKey)
 	addValue(ordererGroup, channelconfig.ChannelRestrictionsValue(conf.MaxChannels), channelconfig.AdminsPolicyKey)
 
+	if len(conf.IngressQuotas) > 0 {
+		quotas := map[string]*ab.IngressQuota{}
+		for mspID, quota := range conf.IngressQuotas {
+			quotas[mspID] = &ab.IngressQuota{
+				MessagesPerSecond: quota.MessagesPerSecond,
+				BytesPerSecond:    quota.BytesPerSecond,
+			}
+		}
+		addValue(ordererGroup, channelconfig.IngressQuotasValue(quotas), channelconfig.AdminsPolicyKey)
+	}
+
 	if len(conf.Capabilities) > 0 {
 		addValue(ordererGroup, channelconfig.CapabilitiesValue(conf.Capabilities), channelconfig.AdminsPolicyKey)
 	}
@@ -277,6 +292,64 @@ func NewApplicationGroup(conf *genesisconfig.Application) (*cb.ConfigGroup, erro
 		addValue(applicationGroup, channelconfig.ACLValues(conf.ACLs), channelconfig.AdminsPolicyKey)
 	}
 
+	if len(conf.TokenSupply) > 0 {
+		limits := make(map[string]*pb.TokenSupplyLimit, len(conf.TokenSupply))
+		for tokenType, limit := range conf.TokenSupply {
+			if limit == nil {
+				continue
+			}
+			limits[tokenType] = &pb.TokenSupplyLimit{
+				MaxTotalSupply: limit.MaxTotalSupply,
+				MaxPerPeriod:   limit.MaxPerPeriod,
+				PeriodBlocks:   limit.PeriodBlocks,
+			}
+		}
+		addValue(applicationGroup, channelconfig.TokenSupplyValue(limits), channelconfig.AdminsPolicyKey)
+	}
+
+	if len(conf.TokenTypeNamespaces) > 0 {
+		namespaces := make(map[string]*pb.TokenTypeNamespace, len(conf.TokenTypeNamespaces))
+		for prefix, namespace := range conf.TokenTypeNamespaces {
+			if namespace == nil {
+				continue
+			}
+			namespaces[prefix] = &pb.TokenTypeNamespace{
+				Issuers:    namespace.Issuers,
+				AdminsOnly: namespace.AdminsOnly,
+			}
+		}
+		addValue(applicationGroup, channelconfig.TokenTypeNamespacesValue(namespaces), channelconfig.AdminsPolicyKey)
+	}
+
+	if len(conf.TokenMetadataSchemas) > 0 {
+		schemas := make(map[string]*pb.TokenMetadataSchema, len(conf.TokenMetadataSchemas))
+		for tokenType, schema := range conf.TokenMetadataSchemas {
+			if schema == nil {
+				continue
+			}
+			fields := make(map[string]*pb.TokenMetadataField, len(schema.Fields))
+			for name, field := range schema.Fields {
+				if field == nil {
+					continue
+				}
+				fields[name] = &pb.TokenMetadataField{
+					Type:     field.Type,
+					Required: field.Required,
+				}
+			}
+			schemas[tokenType] = &pb.TokenMetadataSchema{
+				Fields:        fields,
+				Respecifiable: schema.Respecifiable,
+				MaxSize:       schema.MaxSize,
+			}
+		}
+		addValue(applicationGroup, channelconfig.TokenMetadataSchemasValue(schemas), channelconfig.AdminsPolicyKey)
+	}
+
+	if conf.TokenDriver != "" {
+		addValue(applicationGroup, channelconfig.TokenDriverValue(conf.TokenDriver), channelconfig.AdminsPolicyKey)
+	}
+
 	if len(conf.Capabilities) > 0 {
 		addValue(applicationGroup, channelconfig.CapabilitiesValue(conf.Capabilities), channelconfig.AdminsPolicyKey)
 	}
@@ -321,6 +394,18 @@ func NewApplicationOrgGroup(conf *genesisconfig.Organization) (*cb.ConfigGroup,
 	}
 	addValue(applicationOrgGroup, channelconfig.AnchorPeersValue(anchorProtos), channelconfig.AdminsPolicyKey)
 
+	if conf.TokenEncryptionKey != "" {
+		raw, err := ioutil.ReadFile(conf.TokenEncryptionKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error loading token encryption key for org %s", conf.Name)
+		}
+		block, _ := pem.Decode(raw)
+		if block == nil || block.Type != "PUBLIC KEY" {
+			return nil, errors.Errorf("token encryption key for org %s is not a PEM encoded public key", conf.Name)
+		}
+		addValue(applicationOrgGroup, channelconfig.TokenEncryptionKeyValue(block.Bytes), channelconfig.AdminsPolicyKey)
+	}
+
 	applicationOrgGroup.ModPolicy = channelconfig.AdminsPolicyKey
 	return applicationOrgGroup, nil
 }
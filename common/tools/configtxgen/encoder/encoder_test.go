@@ -7,6 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package encoder
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
@@ -20,6 +27,7 @@ import (
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -168,6 +176,40 @@ func TestNewApplicationGroup(t *testing.T) {
 		assert.NotNil(t, group)
 	})
 
+	t.Run("Application with token encryption keys", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		require.NoError(t, err)
+		keyFile, err := ioutil.TempFile("", "token-encryption-key")
+		require.NoError(t, err)
+		defer os.Remove(keyFile.Name())
+		_, err = keyFile.Write(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+		require.NoError(t, err)
+		keyFile.Close()
+
+		config := configtxgentest.Load(genesisconfig.SampleSingleMSPChannelProfile)
+		config.Application.Organizations[0].TokenEncryptionKey = keyFile.Name()
+		group, err := NewApplicationGroup(config.Application)
+		assert.NoError(t, err)
+		value := group.Groups[config.Application.Organizations[0].Name].Values[channelconfig.TokenEncryptionKeyKey]
+		require.NotNil(t, value)
+		tokenEncryptionKey := &pb.TokenEncryptionKey{}
+		require.NoError(t, proto.Unmarshal(value.Value, tokenEncryptionKey))
+		assert.Equal(t, der, tokenEncryptionKey.PublicKey)
+
+		config.Application.Organizations[0].TokenEncryptionKey = "missing.pem"
+		_, err = NewApplicationGroup(config.Application)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "error loading token encryption key")
+
+		config.Application.Organizations[0].TokenEncryptionKey = keyFile.Name()
+		require.NoError(t, ioutil.WriteFile(keyFile.Name(), der, 0600))
+		_, err = NewApplicationGroup(config.Application)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "is not a PEM encoded public key")
+	})
+
 	t.Run("Application unknown MSP", func(t *testing.T) {
 		config := configtxgentest.Load(genesisconfig.SampleSingleMSPChannelProfile)
 		config.Application.Organizations[0] = &genesisconfig.Organization{Name: "FakeOrg", ID: "FakeOrg"}
@@ -255,6 +297,23 @@ func TestNewOrdererGroup(t *testing.T) {
 		assert.Nil(t, group)
 	})
 
+	t.Run("Ingress quotas", func(t *testing.T) {
+		config := configtxgentest.Load(genesisconfig.SampleDevModeSoloProfile)
+		group, err := NewOrdererGroup(config.Orderer)
+		require.NoError(t, err)
+		assert.NotContains(t, group.GetValues(), channelconfig.IngressQuotasKey)
+
+		config.Orderer.IngressQuotas = map[string]genesisconfig.IngressQuota{
+			"SampleOrg": {MessagesPerSecond: 10, BytesPerSecond: 1024},
+		}
+		group, err = NewOrdererGroup(config.Orderer)
+		require.NoError(t, err)
+		quotas := &ab.IngressQuotas{}
+		err = proto.Unmarshal(group.GetValues()[channelconfig.IngressQuotasKey].GetValue(), quotas)
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(&ab.IngressQuota{MessagesPerSecond: 10, BytesPerSecond: 1024}, quotas.Quotas["SampleOrg"]))
+	})
+
 	t.Run("etcd/raft-based Orderer", func(t *testing.T) {
 		config := configtxgentest.Load(genesisconfig.SampleDevModeEtcdRaftProfile)
 		group, _ := NewOrdererGroup(config.Orderer)
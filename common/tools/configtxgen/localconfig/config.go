@@ -113,11 +113,43 @@ type Consortium struct {
 // Application encodes the application-level configuration needed in config
 // transactions.
 type Application struct {
-	Organizations []*Organization    `yaml:"Organizations"`
-	Capabilities  map[string]bool    `yaml:"Capabilities"`
-	Resources     *Resources         `yaml:"Resources"`
-	Policies      map[string]*Policy `yaml:"Policies"`
-	ACLs          map[string]string  `yaml:"ACLs"`
+	Organizations        []*Organization                 `yaml:"Organizations"`
+	Capabilities         map[string]bool                 `yaml:"Capabilities"`
+	Resources            *Resources                      `yaml:"Resources"`
+	Policies             map[string]*Policy              `yaml:"Policies"`
+	ACLs                 map[string]string               `yaml:"ACLs"`
+	TokenSupply          map[string]*TokenSupplyLimit    `yaml:"TokenSupply"`
+	TokenTypeNamespaces  map[string]*TokenTypeNamespace  `yaml:"TokenTypeNamespaces"`
+	TokenMetadataSchemas map[string]*TokenMetadataSchema `yaml:"TokenMetadataSchemas"`
+	TokenDriver          string                          `yaml:"TokenDriver"`
+}
+
+// TokenSupplyLimit encodes the limits on the issuance of a token type.
+type TokenSupplyLimit struct {
+	MaxTotalSupply uint64 `yaml:"MaxTotalSupply"`
+	MaxPerPeriod   uint64 `yaml:"MaxPerPeriod"`
+	PeriodBlocks   uint64 `yaml:"PeriodBlocks"`
+}
+
+// TokenTypeNamespace encodes the issuance policy of the token types prefixed
+// by a namespace.
+type TokenTypeNamespace struct {
+	Issuers    []string `yaml:"Issuers"`
+	AdminsOnly bool     `yaml:"AdminsOnly"`
+}
+
+// TokenMetadataSchema encodes the schema of the metadata of the outputs of a
+// token type.
+type TokenMetadataSchema struct {
+	Fields        map[string]*TokenMetadataField `yaml:"Fields"`
+	Respecifiable bool                           `yaml:"Respecifiable"`
+	MaxSize       uint32                         `yaml:"MaxSize"`
+}
+
+// TokenMetadataField encodes a field of token metadata.
+type TokenMetadataField struct {
+	Type     string `yaml:"Type"`
+	Required bool   `yaml:"Required"`
 }
 
 // Resources encodes the application-level resources configuration needed to
@@ -140,6 +172,10 @@ type Organization struct {
 	// for both orderers and applications.
 	AnchorPeers []*AnchorPeer `yaml:"AnchorPeers"`
 
+	// TokenEncryptionKey is the path of the PEM encoded ECDSA P-256 public
+	// key to which token transactions are encrypted for the peers of the org.
+	TokenEncryptionKey string `yaml:"TokenEncryptionKey"`
+
 	// AdminPrincipal is deprecated and may be removed in a future release
 	// it was used for modifying the default policy generation, but policies
 	// may now be specified explicitly so it is redundant and unnecessary
@@ -155,16 +191,24 @@ type AnchorPeer struct {
 // Orderer contains configuration which is used for the
 // bootstrapping of an orderer by the provisional bootstrapper.
 type Orderer struct {
-	OrdererType   string             `yaml:"OrdererType"`
-	Addresses     []string           `yaml:"Addresses"`
-	BatchTimeout  time.Duration      `yaml:"BatchTimeout"`
-	BatchSize     BatchSize          `yaml:"BatchSize"`
-	Kafka         Kafka              `yaml:"Kafka"`
-	EtcdRaft      *etcdraft.Metadata `yaml:"EtcdRaft"`
-	Organizations []*Organization    `yaml:"Organizations"`
-	MaxChannels   uint64             `yaml:"MaxChannels"`
-	Capabilities  map[string]bool    `yaml:"Capabilities"`
-	Policies      map[string]*Policy `yaml:"Policies"`
+	OrdererType   string                  `yaml:"OrdererType"`
+	Addresses     []string                `yaml:"Addresses"`
+	BatchTimeout  time.Duration           `yaml:"BatchTimeout"`
+	BatchSize     BatchSize               `yaml:"BatchSize"`
+	Kafka         Kafka                   `yaml:"Kafka"`
+	EtcdRaft      *etcdraft.Metadata      `yaml:"EtcdRaft"`
+	Organizations []*Organization         `yaml:"Organizations"`
+	MaxChannels   uint64                  `yaml:"MaxChannels"`
+	IngressQuotas map[string]IngressQuota `yaml:"IngressQuotas"`
+	Capabilities  map[string]bool         `yaml:"Capabilities"`
+	Policies      map[string]*Policy      `yaml:"Policies"`
+}
+
+// IngressQuota limits the rate at which the members of an organization may
+// broadcast to a channel.
+type IngressQuota struct {
+	MessagesPerSecond uint32 `yaml:"MessagesPerSecond"`
+	BytesPerSecond    uint64 `yaml:"BytesPerSecond"`
 }
 
 // BatchSize contains configuration affecting the size of batches.
@@ -463,4 +507,7 @@ loop:
 
 func translatePaths(configDir string, org *Organization) {
 	cf.TranslatePathInPlace(configDir, &org.MSPDir)
+	if org.TokenEncryptionKey != "" {
+		cf.TranslatePathInPlace(configDir, &org.TokenEncryptionKey)
+	}
 }
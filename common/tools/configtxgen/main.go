@@ -15,6 +15,7 @@ import (
 
 	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/channelregistry"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
@@ -61,6 +62,14 @@ func doOutputChannelCreateTx(conf *genesisconfig.Profile, channelID string, outp
 	return nil
 }
 
+func doCheckChannelRegistry(registryDir, orderingServiceID, channelID string) error {
+	logger.Info("Checking channel registry")
+	if _, err := os.Stat(registryDir); err != nil {
+		return fmt.Errorf("Error opening channel registry: %s", err)
+	}
+	return channelregistry.Check(&channelregistry.DirRegistry{Dir: registryDir}, channelID, orderingServiceID)
+}
+
 func doOutputAnchorPeersUpdate(conf *genesisconfig.Profile, channelID string, outputAnchorPeersUpdate string, asOrg string) error {
 	logger.Info("Generating anchor peer update")
 	if asOrg == "" {
@@ -204,7 +213,7 @@ func doPrintOrg(t *genesisconfig.TopLevel, printOrg string) error {
 }
 
 func main() {
-	var outputBlock, outputChannelCreateTx, profile, configPath, channelID, inspectBlock, inspectChannelCreateTx, outputAnchorPeersUpdate, asOrg, printOrg string
+	var outputBlock, outputChannelCreateTx, profile, configPath, channelID, inspectBlock, inspectChannelCreateTx, outputAnchorPeersUpdate, asOrg, printOrg, channelRegistry, orderingServiceID string
 
 	flag.StringVar(&outputBlock, "outputBlock", "", "The path to write the genesis block to (if set)")
 	flag.StringVar(&channelID, "channelID", "", "The channel ID to use in the configtx")
@@ -216,6 +225,8 @@ func main() {
 	flag.StringVar(&outputAnchorPeersUpdate, "outputAnchorPeersUpdate", "", "Creates an config update to update an anchor peer (works only with the default channel creation, and only for the first update)")
 	flag.StringVar(&asOrg, "asOrg", "", "Performs the config generation as a particular organization (by name), only including values in the write set that org (likely) has privilege to set")
 	flag.StringVar(&printOrg, "printOrg", "", "Prints the definition of an organization as JSON. (useful for adding an org to a channel manually)")
+	flag.StringVar(&channelRegistry, "channelRegistry", "", "The directory of the channel registry shared by the ordering services, to fail the genesis block and channel creation tx outputs if the channel ID is already registered (if set)")
+	flag.StringVar(&orderingServiceID, "orderingServiceID", "", "The ordering service which may have registered the channel ID in the channel registry (if set)")
 
 	version := flag.Bool("version", false, "Show version information")
 
@@ -268,6 +279,12 @@ func main() {
 		topLevelConfig = genesisconfig.LoadTopLevel()
 	}
 
+	if channelRegistry != "" && (outputBlock != "" || outputChannelCreateTx != "") {
+		if err := doCheckChannelRegistry(channelRegistry, orderingServiceID, channelID); err != nil {
+			logger.Fatalf("Error on channelRegistry: %s", err)
+		}
+	}
+
 	if outputBlock != "" {
 		if err := doOutputBlock(profileConfig, channelID, outputBlock); err != nil {
 			logger.Fatalf("Error on outputBlock: %s", err)
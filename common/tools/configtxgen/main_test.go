@@ -24,6 +24,7 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/channelregistry"
 	"github.com/hyperledger/fabric/common/tools/configtxgen/configtxgentest"
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
 	"github.com/hyperledger/fabric/core/config/configtest"
@@ -200,3 +201,21 @@ func TestPrintOrg(t *testing.T) {
 	assert.Error(t, err, "Fake org")
 	assert.Regexp(t, "bad org definition", err.Error())
 }
+
+func TestCheckChannelRegistry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "channelregistry")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	registry := &channelregistry.DirRegistry{Dir: dir}
+	assert.NoError(t, registry.Register(&channelregistry.Entry{ChannelID: "foo", OrderingService: "service1"}))
+
+	assert.NoError(t, doCheckChannelRegistry(dir, "", "bar"), "Unregistered channel ID")
+	assert.NoError(t, doCheckChannelRegistry(dir, "service1", "foo"), "Channel ID registered by the same ordering service")
+	assert.EqualError(t, doCheckChannelRegistry(dir, "service2", "foo"), "channel ID foo is already registered by ordering service service1")
+	assert.EqualError(t, doCheckChannelRegistry(dir, "", "foo"), "channel ID foo is already registered by ordering service service1")
+
+	err = doCheckChannelRegistry(filepath.Join(dir, "missing"), "", "bar")
+	assert.Error(t, err, "Missing registry directory")
+	assert.Contains(t, err.Error(), "Error opening channel registry")
+}
@@ -0,0 +1,81 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package update
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+// ApplicationOrgByMSPID returns the name of the application organization of
+// config whose MSP has the given ID.
+func ApplicationOrgByMSPID(config *cb.Config, mspID string) (string, error) {
+	application := config.GetChannelGroup().GetGroups()[channelconfig.ApplicationGroupKey]
+	if application == nil {
+		return "", fmt.Errorf("config has no application group")
+	}
+	for name, org := range application.Groups {
+		value := org.Values[channelconfig.MSPKey]
+		if value == nil {
+			continue
+		}
+		mspConfig := &msp.MSPConfig{}
+		if err := proto.Unmarshal(value.Value, mspConfig); err != nil {
+			return "", fmt.Errorf("invalid MSP of application organization %s: %s", name, err)
+		}
+		fabricConfig := &msp.FabricMSPConfig{}
+		if err := proto.Unmarshal(mspConfig.Config, fabricConfig); err != nil {
+			continue
+		}
+		if fabricConfig.Name == mspID {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("no application organization has MSP ID %s", mspID)
+}
+
+// AnchorPeers computes the update of config, the current config of channel
+// channelID, setting the anchor peers of the application organization org.
+// It returns a nil update when the organization already has these anchor
+// peers, so that the update can be computed again after peers are added.
+func AnchorPeers(channelID string, config *cb.Config, org string, anchorPeers []*pb.AnchorPeer) (*cb.ConfigUpdate, error) {
+	application := config.GetChannelGroup().GetGroups()[channelconfig.ApplicationGroupKey]
+	if application == nil || application.Groups[org] == nil {
+		return nil, fmt.Errorf("config has no application organization %s", org)
+	}
+
+	updated := proto.Clone(config).(*cb.Config)
+	orgGroup := updated.ChannelGroup.Groups[channelconfig.ApplicationGroupKey].Groups[org]
+	if orgGroup.Values == nil {
+		orgGroup.Values = map[string]*cb.ConfigValue{}
+	}
+	value := orgGroup.Values[channelconfig.AnchorPeersKey]
+	if value == nil {
+		value = &cb.ConfigValue{ModPolicy: channelconfig.AdminsPolicyKey}
+		orgGroup.Values[channelconfig.AnchorPeersKey] = value
+	}
+	current := &pb.AnchorPeers{}
+	if err := proto.Unmarshal(value.Value, current); err != nil {
+		return nil, fmt.Errorf("invalid anchor peers of organization %s: %s", org, err)
+	}
+	if proto.Equal(current, &pb.AnchorPeers{AnchorPeers: anchorPeers}) {
+		return nil, nil
+	}
+	value.Value, _ = proto.Marshal(channelconfig.AnchorPeersValue(anchorPeers).Value())
+
+	configUpdate, err := Compute(config, updated)
+	if err != nil {
+		return nil, err
+	}
+	configUpdate.ChannelId = channelID
+	return configUpdate, nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package update
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func testAnchorsConfig() *cb.Config {
+	org := func(mspID string) *cb.ConfigGroup {
+		fabricConfig, _ := proto.Marshal(&msp.FabricMSPConfig{Name: mspID})
+		mspValue, _ := proto.Marshal(&msp.MSPConfig{Config: fabricConfig})
+		group := cb.NewConfigGroup()
+		group.Values[channelconfig.MSPKey] = &cb.ConfigValue{Value: mspValue, ModPolicy: channelconfig.AdminsPolicyKey}
+		return group
+	}
+	application := cb.NewConfigGroup()
+	application.Groups["Org1"] = org("Org1MSP")
+	application.Groups["Org2"] = org("Org2MSP")
+	channel := cb.NewConfigGroup()
+	channel.Groups[channelconfig.ApplicationGroupKey] = application
+	return &cb.Config{ChannelGroup: channel}
+}
+
+func TestApplicationOrgByMSPID(t *testing.T) {
+	config := testAnchorsConfig()
+
+	org, err := ApplicationOrgByMSPID(config, "Org2MSP")
+	assert.NoError(t, err)
+	assert.Equal(t, "Org2", org)
+
+	_, err = ApplicationOrgByMSPID(config, "Org3MSP")
+	assert.EqualError(t, err, "no application organization has MSP ID Org3MSP")
+
+	_, err = ApplicationOrgByMSPID(&cb.Config{ChannelGroup: cb.NewConfigGroup()}, "Org1MSP")
+	assert.EqualError(t, err, "config has no application group")
+}
+
+func TestAnchorPeers(t *testing.T) {
+	config := testAnchorsConfig()
+	anchors := []*pb.AnchorPeer{{Host: "peer0.org1", Port: 7051}}
+
+	configUpdate, err := AnchorPeers("testchannel", config, "Org1", anchors)
+	assert.NoError(t, err)
+	assert.Equal(t, "testchannel", configUpdate.ChannelId)
+	org := configUpdate.WriteSet.Groups[channelconfig.ApplicationGroupKey].Groups["Org1"]
+	value := org.Values[channelconfig.AnchorPeersKey]
+	assert.Equal(t, channelconfig.AdminsPolicyKey, value.ModPolicy)
+	anchorPeers := &pb.AnchorPeers{}
+	assert.NoError(t, proto.Unmarshal(value.Value, anchorPeers))
+	assert.True(t, proto.Equal(&pb.AnchorPeers{AnchorPeers: anchors}, anchorPeers))
+	assert.Nil(t, configUpdate.WriteSet.Groups[channelconfig.ApplicationGroupKey].Groups["Org2"])
+
+	// once applied, the same anchor peers need no update
+	config.ChannelGroup.Groups[channelconfig.ApplicationGroupKey].Groups["Org1"].Values[channelconfig.AnchorPeersKey] = value
+	configUpdate, err = AnchorPeers("testchannel", config, "Org1", anchors)
+	assert.NoError(t, err)
+	assert.Nil(t, configUpdate)
+
+	_, err = AnchorPeers("testchannel", config, "Org3", anchors)
+	assert.EqualError(t, err, "config has no application organization Org3")
+}
@@ -0,0 +1,136 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package idemixca
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/update"
+	"github.com/hyperledger/fabric/msp"
+	cb "github.com/hyperledger/fabric/protos/common"
+	m "github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// MSPConfigUpdate creates the unsigned config update transaction replacing
+// the MSP of the organizations with the same MSP ID as the Idemix MSP config
+// in the channel of the config block, for instance after rotating the issuer
+// key. The signer of the MSP config, if any, is left out of the channel config.
+// The transaction must be signed by the admins of the channel before being
+// submitted, e.g. with peer channel signconfigtx and peer channel update.
+func MSPConfigUpdate(configBlock *cb.Block, mspConfig *m.MSPConfig) (*cb.Envelope, error) {
+	if mspConfig.Type != int32(msp.IDEMIX) {
+		return nil, errors.Errorf("MSP config type %d is not Idemix", mspConfig.Type)
+	}
+	idemixConfig := &m.IdemixMSPConfig{}
+	if err := proto.Unmarshal(mspConfig.Config, idemixConfig); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal Idemix MSP config")
+	}
+	idemixConfig.Signer = nil
+	idemixConfigBytes, err := proto.Marshal(idemixConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal Idemix MSP config")
+	}
+	mspConfigBytes, err := proto.Marshal(&m.MSPConfig{Type: mspConfig.Type, Config: idemixConfigBytes})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal MSP config")
+	}
+
+	envelope, err := utils.ExtractEnvelope(configBlock, 0)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to extract config envelope from block")
+	}
+	payload, err := utils.UnmarshalPayload(envelope.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Header == nil {
+		return nil, errors.New("config envelope has no header")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+	if chdr.Type != int32(cb.HeaderType_CONFIG) {
+		return nil, errors.Errorf("block is not a config block, its transaction is of type %s", cb.HeaderType(chdr.Type))
+	}
+	configEnvelope, err := configtx.UnmarshalConfigEnvelope(payload.Data)
+	if err != nil {
+		return nil, err
+	}
+	if configEnvelope.Config == nil || configEnvelope.Config.ChannelGroup == nil {
+		return nil, errors.New("config block has no channel group")
+	}
+
+	updated := proto.Clone(configEnvelope.Config).(*cb.Config)
+	replaced, err := replaceMSP(updated.ChannelGroup, idemixConfig.Name, mspConfigBytes)
+	if err != nil {
+		return nil, err
+	}
+	if replaced == 0 {
+		return nil, errors.Errorf("no organization with MSP ID %s in the config of channel %s", idemixConfig.Name, chdr.ChannelId)
+	}
+
+	configUpdate, err := update.Compute(configEnvelope.Config, updated)
+	if err != nil {
+		return nil, err
+	}
+	configUpdate.ChannelId = chdr.ChannelId
+	configUpdateBytes, err := proto.Marshal(configUpdate)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal config update")
+	}
+
+	return utils.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, chdr.ChannelId, nil, &cb.ConfigUpdateEnvelope{ConfigUpdate: configUpdateBytes}, 0, 0)
+}
+
+// replaceMSP replaces the MSP values with the given MSP ID in the group and
+// its subgroups, and returns the number of values replaced.
+func replaceMSP(group *cb.ConfigGroup, mspID string, mspConfig []byte) (int, error) {
+	var replaced int
+	if value, ok := group.Values[channelconfig.MSPKey]; ok {
+		name, err := mspName(value.Value)
+		if err != nil {
+			return 0, err
+		}
+		if name == mspID {
+			value.Value = mspConfig
+			replaced++
+		}
+	}
+	for _, subgroup := range group.Groups {
+		n, err := replaceMSP(subgroup, mspID, mspConfig)
+		if err != nil {
+			return 0, err
+		}
+		replaced += n
+	}
+	return replaced, nil
+}
+
+func mspName(mspConfigBytes []byte) (string, error) {
+	mspConfig := &m.MSPConfig{}
+	if err := proto.Unmarshal(mspConfigBytes, mspConfig); err != nil {
+		return "", errors.Wrap(err, "failed to unmarshal MSP config of channel")
+	}
+	switch mspConfig.Type {
+	case int32(msp.IDEMIX):
+		idemixConfig := &m.IdemixMSPConfig{}
+		if err := proto.Unmarshal(mspConfig.Config, idemixConfig); err != nil {
+			return "", errors.Wrap(err, "failed to unmarshal Idemix MSP config of channel")
+		}
+		return idemixConfig.Name, nil
+	default:
+		fabricConfig := &m.FabricMSPConfig{}
+		if err := proto.Unmarshal(mspConfig.Config, fabricConfig); err != nil {
+			return "", errors.Wrap(err, "failed to unmarshal MSP config of channel")
+		}
+		return fabricConfig.Name, nil
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package idemixca
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/msp"
+	cb "github.com/hyperledger/fabric/protos/common"
+	m "github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func idemixMSPConfig(name string, ipk []byte, signer *m.IdemixMSPSignerConfig) *m.MSPConfig {
+	return &m.MSPConfig{
+		Type:   int32(msp.IDEMIX),
+		Config: utils.MarshalOrPanic(&m.IdemixMSPConfig{Name: name, Ipk: ipk, Signer: signer}),
+	}
+}
+
+func configBlock(config *cb.Config) *cb.Block {
+	block := cb.NewBlock(0, nil)
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(&cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: utils.MakePayloadHeader(utils.MakeChannelHeader(cb.HeaderType_CONFIG, 0, "mychannel", 0), utils.MakeSignatureHeader(nil, nil)),
+			Data:   utils.MarshalOrPanic(&cb.ConfigEnvelope{Config: config}),
+		}),
+	})}
+	return block
+}
+
+func TestMSPConfigUpdate(t *testing.T) {
+	config := &cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			Groups: map[string]*cb.ConfigGroup{
+				channelconfig.ApplicationGroupKey: {
+					Groups: map[string]*cb.ConfigGroup{
+						"IdemixOrg": {
+							Values: map[string]*cb.ConfigValue{
+								channelconfig.MSPKey: {
+									Value:     utils.MarshalOrPanic(idemixMSPConfig("IdemixMSP", []byte("old ipk"), nil)),
+									ModPolicy: "Admins",
+								},
+							},
+						},
+						"FabricOrg": {
+							Values: map[string]*cb.ConfigValue{
+								channelconfig.MSPKey: {
+									Value: utils.MarshalOrPanic(&m.MSPConfig{
+										Config: utils.MarshalOrPanic(&m.FabricMSPConfig{Name: "FabricMSP"}),
+									}),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	newConfig := idemixMSPConfig("IdemixMSP", []byte("new ipk"), &m.IdemixMSPSignerConfig{EnrollmentId: "admin"})
+	env, err := MSPConfigUpdate(configBlock(config), newConfig)
+	assert.NoError(t, err)
+
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	assert.NoError(t, err)
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(cb.HeaderType_CONFIG_UPDATE), chdr.Type)
+	assert.Equal(t, "mychannel", chdr.ChannelId)
+	configUpdateEnv, err := configtx.UnmarshalConfigUpdateEnvelope(payload.Data)
+	assert.NoError(t, err)
+	configUpdate, err := configtx.UnmarshalConfigUpdate(configUpdateEnv.ConfigUpdate)
+	assert.NoError(t, err)
+	assert.Equal(t, "mychannel", configUpdate.ChannelId)
+
+	groups := configUpdate.WriteSet.Groups[channelconfig.ApplicationGroupKey].Groups
+	assert.NotContains(t, groups, "FabricOrg")
+	value := groups["IdemixOrg"].Values[channelconfig.MSPKey]
+	assert.Equal(t, uint64(1), value.Version)
+	assert.Equal(t, "Admins", value.ModPolicy)
+	mspConfig := &m.MSPConfig{}
+	assert.NoError(t, proto.Unmarshal(value.Value, mspConfig))
+	idemixConfig := &m.IdemixMSPConfig{}
+	assert.NoError(t, proto.Unmarshal(mspConfig.Config, idemixConfig))
+	assert.Equal(t, []byte("new ipk"), idemixConfig.Ipk)
+	assert.Nil(t, idemixConfig.Signer, "the signer is left out of the channel config")
+
+	_, err = MSPConfigUpdate(configBlock(config), idemixMSPConfig("OtherMSP", []byte("new ipk"), nil))
+	assert.EqualError(t, err, "no organization with MSP ID OtherMSP in the config of channel mychannel")
+
+	_, err = MSPConfigUpdate(configBlock(config), idemixMSPConfig("IdemixMSP", []byte("old ipk"), nil))
+	assert.EqualError(t, err, "no differences detected between original and updated config")
+
+	_, err = MSPConfigUpdate(configBlock(config), &m.MSPConfig{Config: utils.MarshalOrPanic(&m.FabricMSPConfig{Name: "FabricMSP"})})
+	assert.EqualError(t, err, "MSP config type 0 is not Idemix")
+
+	_, err = MSPConfigUpdate(cb.NewBlock(1, nil), newConfig)
+	assert.Error(t, err)
+}
@@ -0,0 +1,156 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package idemixca
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
+	"github.com/hyperledger/fabric/idemix"
+	"github.com/hyperledger/fabric/msp"
+	m "github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+// CredentialRequest is the request of a user for a credential, the
+// counterpart of a certificate signing request. It proves the knowledge of
+// the user secret key without disclosing it to the issuer, and lists the
+// attribute values requested for the credential.
+type CredentialRequest struct {
+	OrganizationalUnit string `json:"organizational_unit"`
+	Role               int    `json:"role"`
+	EnrollmentID       string `json:"enrollment_id"`
+	// CredRequest is the serialized idemix.CredRequest
+	CredRequest []byte `json:"cred_request"`
+}
+
+// CredentialResponse is the credential issued for a CredentialRequest.
+type CredentialResponse struct {
+	OrganizationalUnit string `json:"organizational_unit"`
+	Role               int    `json:"role"`
+	EnrollmentID       string `json:"enrollment_id"`
+	RevocationHandle   int    `json:"revocation_handle"`
+	// Credential is the serialized idemix.Credential
+	Credential []byte `json:"credential"`
+	// CredentialRevocationInformation is the serialized idemix.CredentialRevocationInformation
+	CredentialRevocationInformation []byte `json:"credential_revocation_information"`
+}
+
+// NewCredentialRequest generates a fresh user secret and a request for a
+// credential with the given attributes from the issuer of the public key.
+// It returns the request to send to the issuer, and the serialized user
+// secret key to keep until the credential is issued.
+func NewCredentialRequest(roleMask int, ouString string, enrollmentId string, ipk *idemix.IssuerPublicKey) (*CredentialRequest, []byte, error) {
+	if ouString == "" {
+		return nil, nil, errors.Errorf("the OU attribute value is empty")
+	}
+	if enrollmentId == "" {
+		return nil, nil, errors.Errorf("the enrollment id value is empty")
+	}
+
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "Error getting PRNG")
+	}
+	sk := idemix.RandModOrder(rng)
+	// NOTE the nonce is chosen by the user, as the requests are exchanged offline
+	ni := idemix.BigToBytes(idemix.RandModOrder(rng))
+	credRequestBytes, err := proto.Marshal(idemix.NewCredRequest(sk, ni, ipk, rng))
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "failed to marshal credential request")
+	}
+
+	req := &CredentialRequest{
+		OrganizationalUnit: ouString,
+		Role:               roleMask,
+		EnrollmentID:       enrollmentId,
+		CredRequest:        credRequestBytes,
+	}
+	return req, idemix.BigToBytes(sk), nil
+}
+
+// IssueCredential verifies the credential request and issues a credential
+// with the requested attributes and the given revocation handle.
+func IssueCredential(req *CredentialRequest, revocationHandle int, key *idemix.IssuerKey, revKey *ecdsa.PrivateKey) (*CredentialResponse, error) {
+	if req.OrganizationalUnit == "" {
+		return nil, errors.Errorf("the OU attribute value is empty")
+	}
+	if req.EnrollmentID == "" {
+		return nil, errors.Errorf("the enrollment id value is empty")
+	}
+
+	credRequest := &idemix.CredRequest{}
+	if err := proto.Unmarshal(req.CredRequest, credRequest); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal credential request")
+	}
+	if err := credRequest.Check(key.Ipk); err != nil {
+		return nil, errors.WithMessage(err, "invalid credential request")
+	}
+
+	attrs := make([]*FP256BN.BIG, 4)
+	attrs[msp.AttributeIndexOU] = idemix.HashModOrder([]byte(req.OrganizationalUnit))
+	attrs[msp.AttributeIndexRole] = FP256BN.NewBIGint(req.Role)
+	attrs[msp.AttributeIndexEnrollmentId] = idemix.HashModOrder([]byte(req.EnrollmentID))
+	attrs[msp.AttributeIndexRevocationHandle] = FP256BN.NewBIGint(revocationHandle)
+
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, errors.WithMessage(err, "Error getting PRNG")
+	}
+	cred, err := idemix.NewCredential(key, credRequest, attrs, rng)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to generate a credential")
+	}
+	credBytes, err := proto.Marshal(cred)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to marshal credential")
+	}
+
+	// NOTE currently, idemixca creates CRI's with "ALG_NO_REVOCATION"
+	cri, err := idemix.CreateCRI(revKey, []*FP256BN.BIG{FP256BN.NewBIGint(revocationHandle)}, 0, idemix.ALG_NO_REVOCATION, rng)
+	if err != nil {
+		return nil, err
+	}
+	criBytes, err := proto.Marshal(cri)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to marshal CRI")
+	}
+
+	return &CredentialResponse{
+		OrganizationalUnit:              req.OrganizationalUnit,
+		Role:                            req.Role,
+		EnrollmentID:                    req.EnrollmentID,
+		RevocationHandle:                revocationHandle,
+		Credential:                      credBytes,
+		CredentialRevocationInformation: criBytes,
+	}, nil
+}
+
+// NewSignerConfigFromCredential creates the signer config of the user from
+// the issued credential and the user secret key of the credential request,
+// after verifying the credential against the issuer public key.
+func NewSignerConfigFromCredential(resp *CredentialResponse, sk []byte, ipk *idemix.IssuerPublicKey) ([]byte, error) {
+	cred := &idemix.Credential{}
+	if err := proto.Unmarshal(resp.Credential, cred); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal credential")
+	}
+	if err := cred.Ver(FP256BN.FromBytes(sk), ipk); err != nil {
+		return nil, errors.WithMessage(err, "credential does not verify against the issuer public key and the user secret key")
+	}
+
+	signer := &m.IdemixMSPSignerConfig{
+		Cred:                            resp.Credential,
+		Sk:                              sk,
+		OrganizationalUnitIdentifier:    resp.OrganizationalUnit,
+		Role:                            int32(resp.Role),
+		EnrollmentId:                    resp.EnrollmentID,
+		CredentialRevocationInformation: resp.CredentialRevocationInformation,
+	}
+
+	return proto.Marshal(signer)
+}
@@ -122,3 +122,58 @@ func setupMSP() error {
 
 	return msp.Setup(mspConfig)
 }
+
+func TestCredentialRequest(t *testing.T) {
+	cleanup()
+
+	isk, ipkBytes, err := GenerateIssuerKey()
+	assert.NoError(t, err)
+	revocationkey, err := idemix.GenerateLongTermRevocationKey()
+	assert.NoError(t, err)
+	ipk := &idemix.IssuerPublicKey{}
+	assert.NoError(t, proto.Unmarshal(ipkBytes, ipk))
+	key := &idemix.IssuerKey{Isk: isk, Ipk: ipk}
+
+	encodedRevocationPK, err := x509.MarshalPKIXPublicKey(revocationkey.Public())
+	assert.NoError(t, err)
+	pemEncodedRevocationPK := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: encodedRevocationPK})
+	writeVerifierToFile(ipkBytes, pemEncodedRevocationPK)
+
+	req, sk, err := NewCredentialRequest(m.GetRoleMaskFromIdemixRole(m.ADMIN), "OU1", "enrollmentid1", ipk)
+	assert.NoError(t, err)
+	resp, err := IssueCredential(req, 1234, key, revocationkey)
+	assert.NoError(t, err)
+	assert.Equal(t, "OU1", resp.OrganizationalUnit)
+	assert.Equal(t, 1234, resp.RevocationHandle)
+
+	conf, err := NewSignerConfigFromCredential(resp, sk, ipk)
+	assert.NoError(t, err)
+	cleanupSigner()
+	assert.NoError(t, writeSignerToFile(conf))
+	assert.NoError(t, setupMSP())
+
+	// The credential is bound to the secret key of the request
+	_, otherSk, err := NewCredentialRequest(m.GetRoleMaskFromIdemixRole(m.ADMIN), "OU1", "enrollmentid1", ipk)
+	assert.NoError(t, err)
+	_, err = NewSignerConfigFromCredential(resp, otherSk, ipk)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "credential does not verify")
+
+	// A request for another issuer is rejected
+	_, otherIpkBytes, err := GenerateIssuerKey()
+	assert.NoError(t, err)
+	otherIpk := &idemix.IssuerPublicKey{}
+	assert.NoError(t, proto.Unmarshal(otherIpkBytes, otherIpk))
+	otherReq, _, err := NewCredentialRequest(m.GetRoleMaskFromIdemixRole(m.MEMBER), "OU1", "enrollmentid2", otherIpk)
+	assert.NoError(t, err)
+	_, err = IssueCredential(otherReq, 1, key, revocationkey)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid credential request")
+
+	_, _, err = NewCredentialRequest(m.GetRoleMaskFromIdemixRole(m.MEMBER), "", "enrollmentid", ipk)
+	assert.EqualError(t, err, "the OU attribute value is empty")
+	_, _, err = NewCredentialRequest(m.GetRoleMaskFromIdemixRole(m.MEMBER), "OU1", "", ipk)
+	assert.EqualError(t, err, "the enrollment id value is empty")
+	_, err = IssueCredential(&CredentialRequest{OrganizationalUnit: "OU1"}, 1, key, revocationkey)
+	assert.EqualError(t, err, "the enrollment id value is empty")
+}
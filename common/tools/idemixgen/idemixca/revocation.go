@@ -0,0 +1,109 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package idemixca
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
+	"github.com/hyperledger/fabric/idemix"
+	"github.com/hyperledger/fabric/msp"
+	m "github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+// GenerateCRI creates the serialized credential revocation information of
+// the epoch, with which the users holding one of the unrevoked handles prove
+// that they are not revoked. The channels must be updated to the epoch for
+// the revocation to take effect, e.g. with SetRevocationEpoch.
+func GenerateCRI(unrevokedHandles []int, epoch int, revKey *ecdsa.PrivateKey) ([]byte, error) {
+	if epoch <= 0 {
+		return nil, errors.Errorf("invalid epoch %d, the epoch of a revocation must be positive", epoch)
+	}
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, errors.WithMessage(err, "Error getting PRNG")
+	}
+	handles := make([]*FP256BN.BIG, len(unrevokedHandles))
+	for i, rh := range unrevokedHandles {
+		handles[i] = FP256BN.NewBIGint(rh)
+	}
+	cri, err := idemix.CreateCRI(revKey, handles, epoch, idemix.ALG_PLAIN_SIGNATURE, rng)
+	if err != nil {
+		return nil, err
+	}
+	criBytes, err := proto.Marshal(cri)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to marshal CRI")
+	}
+	return criBytes, nil
+}
+
+// UpdateSignerCRI replaces the credential revocation information of the signer
+// config, after verifying that it was issued by the revocation authority and
+// that it does not revoke the credential of the signer.
+func UpdateSignerCRI(signerConfigBytes []byte, criBytes []byte, revPk *ecdsa.PublicKey) ([]byte, error) {
+	signer := &m.IdemixMSPSignerConfig{}
+	if err := proto.Unmarshal(signerConfigBytes, signer); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal signer config")
+	}
+	cred := &idemix.Credential{}
+	if err := proto.Unmarshal(signer.Cred, cred); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal credential")
+	}
+	cri := &idemix.CredentialRevocationInformation{}
+	if err := proto.Unmarshal(criBytes, cri); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal CRI")
+	}
+	if err := idemix.VerifyEpochPK(revPk, cri.EpochPk, cri.EpochPkSig, int(cri.Epoch), idemix.RevocationAlgorithm(cri.RevocationAlg)); err != nil {
+		return nil, errors.WithMessage(err, "CRI was not issued by the revocation authority")
+	}
+
+	if cri.RevocationAlg == int32(idemix.ALG_PLAIN_SIGNATURE) {
+		if len(cred.Attrs) <= msp.AttributeIndexRevocationHandle {
+			return nil, errors.New("credential has no revocation handle")
+		}
+		revocationData := &idemix.PlainSigRevocationData{}
+		if err := proto.Unmarshal(cri.RevocationData, revocationData); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal revocation data")
+		}
+		unrevoked := false
+		for _, signature := range revocationData.Signatures {
+			if bytes.Equal(signature.RevocationHandle, cred.Attrs[msp.AttributeIndexRevocationHandle]) {
+				unrevoked = true
+				break
+			}
+		}
+		if !unrevoked {
+			return nil, errors.Errorf("the credential of enrollment id %s is revoked in epoch %d", signer.EnrollmentId, cri.Epoch)
+		}
+	}
+
+	signer.CredentialRevocationInformation = criBytes
+	return proto.Marshal(signer)
+}
+
+// SetRevocationEpoch sets the revocation epoch of the Idemix MSP config, so
+// that the MSP only accepts the identities proving that they are not revoked
+// with the credential revocation information of the epoch.
+func SetRevocationEpoch(mspConfig *m.MSPConfig, epoch int) (*m.MSPConfig, error) {
+	if mspConfig.Type != int32(msp.IDEMIX) {
+		return nil, errors.Errorf("MSP config type %d is not Idemix", mspConfig.Type)
+	}
+	idemixConfig := &m.IdemixMSPConfig{}
+	if err := proto.Unmarshal(mspConfig.Config, idemixConfig); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal Idemix MSP config")
+	}
+	idemixConfig.Epoch = int64(epoch)
+	idemixConfigBytes, err := proto.Marshal(idemixConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal Idemix MSP config")
+	}
+	return &m.MSPConfig{Type: mspConfig.Type, Config: idemixConfigBytes}, nil
+}
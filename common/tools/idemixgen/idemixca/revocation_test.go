@@ -0,0 +1,85 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package idemixca
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/idemix"
+	m "github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevocation(t *testing.T) {
+	isk, ipkBytes, err := GenerateIssuerKey()
+	assert.NoError(t, err)
+	revocationkey, err := idemix.GenerateLongTermRevocationKey()
+	assert.NoError(t, err)
+	ipk := &idemix.IssuerPublicKey{}
+	assert.NoError(t, proto.Unmarshal(ipkBytes, ipk))
+	key := &idemix.IssuerKey{Isk: isk, Ipk: ipk}
+	encodedRevocationPK, err := x509.MarshalPKIXPublicKey(revocationkey.Public())
+	assert.NoError(t, err)
+	pemEncodedRevocationPK := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: encodedRevocationPK})
+
+	unrevoked, err := GenerateSignerConfig(m.GetRoleMaskFromIdemixRole(m.MEMBER), "OU1", "enrollmentid1", 1, key, revocationkey)
+	assert.NoError(t, err)
+	revoked, err := GenerateSignerConfig(m.GetRoleMaskFromIdemixRole(m.MEMBER), "OU1", "enrollmentid2", 2, key, revocationkey)
+	assert.NoError(t, err)
+
+	_, err = GenerateCRI([]int{1}, 0, revocationkey)
+	assert.EqualError(t, err, "invalid epoch 0, the epoch of a revocation must be positive")
+
+	// revoke the handle 2 in epoch 1
+	cri, err := GenerateCRI([]int{1, 3}, 1, revocationkey)
+	assert.NoError(t, err)
+
+	updated, err := UpdateSignerCRI(unrevoked, cri, &revocationkey.PublicKey)
+	assert.NoError(t, err)
+	_, err = UpdateSignerCRI(revoked, cri, &revocationkey.PublicKey)
+	assert.EqualError(t, err, "the credential of enrollment id enrollmentid2 is revoked in epoch 1")
+	otherRevocationKey, err := idemix.GenerateLongTermRevocationKey()
+	assert.NoError(t, err)
+	_, err = UpdateSignerCRI(unrevoked, cri, &otherRevocationKey.PublicKey)
+	assert.EqualError(t, err, "CRI was not issued by the revocation authority: EpochPKSig invalid")
+
+	newMSP := func(signerConfig []byte, epoch int) m.MSP {
+		idemixMSP, err := m.New(&m.IdemixNewOpts{NewBaseOpts: m.NewBaseOpts{Version: m.MSPv1_1}})
+		assert.NoError(t, err)
+		idemixConfig := &msp.IdemixMSPConfig{Name: "TestName", Ipk: ipkBytes, RevocationPk: pemEncodedRevocationPK}
+		if signerConfig != nil {
+			idemixConfig.Signer = &msp.IdemixMSPSignerConfig{}
+			assert.NoError(t, proto.Unmarshal(signerConfig, idemixConfig.Signer))
+		}
+		conf, err := SetRevocationEpoch(&msp.MSPConfig{Type: int32(m.IDEMIX), Config: utils.MarshalOrPanic(idemixConfig)}, epoch)
+		assert.NoError(t, err)
+		assert.NoError(t, idemixMSP.Setup(conf))
+		return idemixMSP
+	}
+	verifier := newMSP(nil, 1)
+
+	validate := func(signer m.MSP) error {
+		id, err := signer.GetDefaultSigningIdentity()
+		assert.NoError(t, err)
+		serialized, err := id.Serialize()
+		assert.NoError(t, err)
+		deserialized, err := verifier.DeserializeIdentity(serialized)
+		assert.NoError(t, err)
+		return verifier.Validate(deserialized)
+	}
+
+	// the signer with the CRI of the epoch is valid
+	assert.NoError(t, validate(newMSP(updated, 1)))
+	// the signers with the CRI of the previous epoch are not
+	assert.Error(t, validate(newMSP(unrevoked, 0)))
+	assert.Error(t, validate(newMSP(revoked, 0)))
+}
@@ -9,30 +9,38 @@ package main
 // idemixgen is a command line tool that generates the CA's keys and
 // generates MSP configs for siging and for verification
 // This tool can be used to setup the peers and CA to support
-// the Identity Mixer MSP
+// the Identity Mixer MSP, to issue credentials to users from their
+// credential requests, to rotate the CA's keys in the channels, and
+// to revoke credentials per epoch
 
 import (
 	"crypto/ecdsa"
 	"crypto/x509"
+	"encoding/json"
 	"encoding/pem"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/tools/idemixgen/idemixca"
 	"github.com/hyperledger/fabric/common/tools/idemixgen/metadata"
 	"github.com/hyperledger/fabric/idemix"
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 	"gopkg.in/alecthomas/kingpin.v2"
 )
 
 const (
 	IdemixDirIssuer             = "ca"
+	IdemixDirRetired            = "retired"
 	IdemixConfigIssuerSecretKey = "IssuerSecretKey"
 	IdemixConfigRevocationKey   = "RevocationKey"
+	IdemixConfigSecretKey       = "SecretKey"
 )
 
 // command line flags
@@ -48,6 +56,36 @@ var (
 	genCredEnrollmentId     = genSignerConfig.Flag("enrollmentId", "The enrollment id of the default signer").Short('e').String()
 	genCredRevocationHandle = genSignerConfig.Flag("revocationHandle", "The handle used to revoke this signer").Short('r').Int()
 
+	genCredRequest             = app.Command("credrequest", "Generate a user secret and a credential request to send to the CA")
+	genCredRequestOU           = genCredRequest.Flag("org-unit", "The Organizational Unit of the user").Short('u').Required().String()
+	genCredRequestIsAdmin      = genCredRequest.Flag("admin", "Request an admin credential").Short('a').Bool()
+	genCredRequestEnrollmentId = genCredRequest.Flag("enrollmentId", "The enrollment id of the user").Short('e').Required().String()
+	genCredRequestFile         = genCredRequest.Flag("request", "The file to write the credential request to").Required().String()
+
+	issueCred                 = app.Command("issue", "Issue a credential for a credential request with the CA key material")
+	issueCredRequestFile      = issueCred.Flag("request", "The file containing the credential request").Required().String()
+	issueCredRevocationHandle = issueCred.Flag("revocationHandle", "The handle used to revoke this user").Short('r').Required().Int()
+	issueCredFile             = issueCred.Flag("credential", "The file to write the credential to").Required().String()
+
+	importCred     = app.Command("import-credential", "Generate the default signer for this Idemix MSP from the credential issued for the credential request")
+	importCredFile = importCred.Flag("credential", "The file containing the credential").Required().String()
+
+	rotateIssuerKey = app.Command("ca-rotate", "Replace the CA key, retiring the current one")
+
+	genCRI          = app.Command("cri", "Generate the credential revocation information of an epoch, revoking the handles not listed")
+	genCRIEpoch     = genCRI.Flag("epoch", "The revocation epoch, greater than the previous one").Required().Int()
+	genCRIUnrevoked = genCRI.Flag("unrevoked", "A revocation handle that is not revoked in the epoch, repeated for each handle").Short('r').Ints()
+	genCRIFile      = genCRI.Flag("cri", "The file to write the credential revocation information to").Required().String()
+
+	importCRI     = app.Command("import-cri", "Update the default signer for this Idemix MSP with the credential revocation information of the new epoch")
+	importCRIFile = importCRI.Flag("cri", "The file containing the credential revocation information").Required().String()
+
+	genConfigUpdate      = app.Command("channel-update", "Generate the config update replacing the Idemix MSP of a channel with this one")
+	genConfigUpdateBlock = genConfigUpdate.Flag("configBlock", "The file containing the latest config block of the channel").Required().String()
+	genConfigUpdateMSPID = genConfigUpdate.Flag("mspId", "The MSP ID of the organization in the channel").Required().String()
+	genConfigUpdateFile  = genConfigUpdate.Flag("update", "The file to write the config update transaction to").Required().String()
+	genConfigUpdateEpoch = genConfigUpdate.Flag("epoch", "The revocation epoch of the latest credential revocation information").Default("0").Int()
+
 	version = app.Command("version", "Show version information")
 )
 
@@ -103,6 +141,104 @@ func main() {
 		handleError(os.Mkdir(filepath.Join(*outputDir, msp.IdemixConfigDirUser), 0770))
 		writeFile(filepath.Join(*outputDir, msp.IdemixConfigDirUser, msp.IdemixConfigFileSigner), config)
 
+	case genCredRequest.FullCommand():
+		roleMask := msp.GetRoleMaskFromIdemixRole(msp.MEMBER)
+		if *genCredRequestIsAdmin {
+			roleMask = msp.GetRoleMaskFromIdemixRole(msp.ADMIN)
+		}
+		req, sk, err := idemixca.NewCredentialRequest(roleMask, *genCredRequestOU, *genCredRequestEnrollmentId, readIssuerPublicKey(filepath.Join(*outputDir, msp.IdemixConfigDirMsp)))
+		handleError(err)
+		reqBytes, err := json.MarshalIndent(req, "", "\t")
+		handleError(err)
+
+		path := filepath.Join(*outputDir, msp.IdemixConfigDirUser)
+		checkDirectoryNotExists(path, fmt.Sprintf("This MSP config already contains a directory \"%s\"", path))
+
+		// The secret key is kept until the credential is imported
+		handleError(os.Mkdir(path, 0770))
+		handleError(ioutil.WriteFile(filepath.Join(path, IdemixConfigSecretKey), sk, 0600))
+		writeFile(*genCredRequestFile, reqBytes)
+
+	case issueCred.FullCommand():
+		reqBytes, err := ioutil.ReadFile(*issueCredRequestFile)
+		handleError(errors.Wrapf(err, "failed to open credential request file: %s", *issueCredRequestFile))
+		req := &idemixca.CredentialRequest{}
+		handleError(errors.Wrap(json.Unmarshal(reqBytes, req), "failed to parse credential request"))
+
+		resp, err := idemixca.IssueCredential(req, *issueCredRevocationHandle, readIssuerKey(), readRevocationKey())
+		handleError(err)
+		respBytes, err := json.MarshalIndent(resp, "", "\t")
+		handleError(err)
+		writeFile(*issueCredFile, respBytes)
+		fmt.Printf("Issued credential to enrollment id %s of organizational unit %s with role %d and revocation handle %d\n", resp.EnrollmentID, resp.OrganizationalUnit, resp.Role, resp.RevocationHandle)
+
+	case importCred.FullCommand():
+		respBytes, err := ioutil.ReadFile(*importCredFile)
+		handleError(errors.Wrapf(err, "failed to open credential file: %s", *importCredFile))
+		resp := &idemixca.CredentialResponse{}
+		handleError(errors.Wrap(json.Unmarshal(respBytes, resp), "failed to parse credential"))
+
+		skPath := filepath.Join(*outputDir, msp.IdemixConfigDirUser, IdemixConfigSecretKey)
+		sk, err := ioutil.ReadFile(skPath)
+		handleError(errors.Wrapf(err, "failed to open user secret key file: %s", skPath))
+		config, err := idemixca.NewSignerConfigFromCredential(resp, sk, readIssuerPublicKey(filepath.Join(*outputDir, msp.IdemixConfigDirMsp)))
+		handleError(err)
+
+		path := filepath.Join(*outputDir, msp.IdemixConfigDirUser, msp.IdemixConfigFileSigner)
+		checkDirectoryNotExists(path, fmt.Sprintf("This MSP config already contains a signer config \"%s\"", path))
+		writeFile(path, config)
+		// The signer config holds the secret key from now on
+		handleError(os.Remove(skPath))
+
+	case rotateIssuerKey.FullCommand():
+		// Read the current key first, to fail if there is none
+		readIssuerKey()
+		isk, ipk, err := idemixca.GenerateIssuerKey()
+		handleError(err)
+
+		caDir := filepath.Join(*outputDir, IdemixDirIssuer)
+		retiredDir := filepath.Join(caDir, IdemixDirRetired, strconv.FormatInt(time.Now().Unix(), 10))
+		checkDirectoryNotExists(retiredDir, fmt.Sprintf("Directory %s already exists", retiredDir))
+		handleError(os.MkdirAll(retiredDir, 0770))
+		for _, name := range []string{IdemixConfigIssuerSecretKey, msp.IdemixConfigFileIssuerPublicKey} {
+			handleError(os.Rename(filepath.Join(caDir, name), filepath.Join(retiredDir, name)))
+		}
+		writeFile(filepath.Join(caDir, IdemixConfigIssuerSecretKey), isk)
+		writeFile(filepath.Join(caDir, msp.IdemixConfigFileIssuerPublicKey), ipk)
+		writeFile(filepath.Join(*outputDir, msp.IdemixConfigDirMsp, msp.IdemixConfigFileIssuerPublicKey), ipk)
+		fmt.Printf("Retired the previous CA key to %s. The credentials issued with it no longer verify once the channels are updated with channel-update, and must be issued again.\n", retiredDir)
+
+	case genCRI.FullCommand():
+		cri, err := idemixca.GenerateCRI(*genCRIUnrevoked, *genCRIEpoch, readRevocationKey())
+		handleError(err)
+		writeFile(*genCRIFile, cri)
+		fmt.Printf("Revoked the handles not listed in epoch %d. The channels must be updated to the epoch with channel-update, and the unrevoked users must import the credential revocation information with import-cri.\n", *genCRIEpoch)
+
+	case importCRI.FullCommand():
+		cri, err := ioutil.ReadFile(*importCRIFile)
+		handleError(errors.Wrapf(err, "failed to open credential revocation information file: %s", *importCRIFile))
+		path := filepath.Join(*outputDir, msp.IdemixConfigDirUser, msp.IdemixConfigFileSigner)
+		signerConfig, err := ioutil.ReadFile(path)
+		handleError(errors.Wrapf(err, "failed to open signer config file: %s", path))
+
+		config, err := idemixca.UpdateSignerCRI(signerConfig, cri, readRevocationPublicKey())
+		handleError(err)
+		writeFile(path, config)
+
+	case genConfigUpdate.FullCommand():
+		blockBytes, err := ioutil.ReadFile(*genConfigUpdateBlock)
+		handleError(errors.Wrapf(err, "failed to open config block file: %s", *genConfigUpdateBlock))
+		block, err := utils.UnmarshalBlock(blockBytes)
+		handleError(errors.Wrap(err, "failed to unmarshal config block"))
+		mspConfig, err := msp.GetIdemixMspConfig(*outputDir, *genConfigUpdateMSPID)
+		handleError(err)
+		mspConfig, err = idemixca.SetRevocationEpoch(mspConfig, *genConfigUpdateEpoch)
+		handleError(err)
+
+		env, err := idemixca.MSPConfigUpdate(block, mspConfig)
+		handleError(err)
+		writeFile(*genConfigUpdateFile, utils.MarshalOrPanic(env))
+
 	case version.FullCommand():
 		printVersion()
 	}
@@ -124,16 +260,22 @@ func readIssuerKey() *idemix.IssuerKey {
 	if err != nil {
 		handleError(errors.Wrapf(err, "failed to open issuer secret key file: %s", path))
 	}
-	path = filepath.Join(*outputDir, IdemixDirIssuer, msp.IdemixConfigFileIssuerPublicKey)
+	ipk := readIssuerPublicKey(filepath.Join(*outputDir, IdemixDirIssuer))
+	key := &idemix.IssuerKey{Isk: isk, Ipk: ipk}
+
+	return key
+}
+
+// readIssuerPublicKey reads the issuer public key from the given directory
+func readIssuerPublicKey(dir string) *idemix.IssuerPublicKey {
+	path := filepath.Join(dir, msp.IdemixConfigFileIssuerPublicKey)
 	ipkBytes, err := ioutil.ReadFile(path)
 	if err != nil {
 		handleError(errors.Wrapf(err, "failed to open issuer public key file: %s", path))
 	}
 	ipk := &idemix.IssuerPublicKey{}
 	handleError(proto.Unmarshal(ipkBytes, ipk))
-	key := &idemix.IssuerKey{Isk: isk, Ipk: ipk}
-
-	return key
+	return ipk
 }
 
 func readRevocationKey() *ecdsa.PrivateKey {
@@ -153,6 +295,28 @@ func readRevocationKey() *ecdsa.PrivateKey {
 	return key
 }
 
+// readRevocationPublicKey reads the revocation public key of the MSP config
+func readRevocationPublicKey() *ecdsa.PublicKey {
+	path := filepath.Join(*outputDir, msp.IdemixConfigDirMsp, msp.IdemixConfigFileRevocationPublicKey)
+	keyBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		handleError(errors.Wrapf(err, "failed to open revocation public key file: %s", path))
+	}
+
+	block, _ := pem.Decode(keyBytes)
+	if block == nil {
+		handleError(errors.Errorf("failed to decode ECDSA public key"))
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	handleError(err)
+	ecdsaKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		handleError(errors.Errorf("revocation public key is not an ECDSA public key"))
+	}
+
+	return ecdsaKey
+}
+
 // checkDirectoryNotExists checks whether a directory with the given path already exists and exits if this is the case
 func checkDirectoryNotExists(path string, errorMessage string) {
 	_, err := os.Stat(path)
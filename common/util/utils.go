@@ -59,6 +59,15 @@ func ComputeSHA3256(data []byte) (hash []byte) {
 	return
 }
 
+// ComputeSHA3384 returns SHA3-384 on data
+func ComputeSHA3384(data []byte) (hash []byte) {
+	hash, err := factory.GetDefault().Hash(data, &bccsp.SHA3_384Opts{})
+	if err != nil {
+		panic(fmt.Errorf("Failed computing SHA3_384 on [% x]", data))
+	}
+	return
+}
+
 // GenerateBytesUUID returns a UUID based on RFC 4122 returning the generated bytes
 func GenerateBytesUUID() []byte {
 	uuid := make([]byte, 16)
@@ -45,6 +45,18 @@ func TestComputeSHA3256(t *testing.T) {
 	}
 }
 
+func TestComputeSHA3384(t *testing.T) {
+	if bytes.Compare(ComputeSHA3384([]byte("foobar")), ComputeSHA3384([]byte("foobar"))) != 0 {
+		t.Fatalf("Expected hashes to match, but they did not match")
+	}
+	if bytes.Compare(ComputeSHA3384([]byte("foobar1")), ComputeSHA3384([]byte("foobar2"))) == 0 {
+		t.Fatalf("Expected hashes to be different, but they match")
+	}
+	if len(ComputeSHA3384([]byte("foobar"))) != 48 {
+		t.Fatalf("Expected a 48 bytes hash")
+	}
+}
+
 func TestUUIDGeneration(t *testing.T) {
 	uuid := GenerateUUID()
 	if len(uuid) != 36 {
@@ -21,6 +21,7 @@ import (
 const (
 	CHANNELREADERS = policies.ChannelApplicationReaders
 	CHANNELWRITERS = policies.ChannelApplicationWriters
+	CHANNELADMINS  = policies.ChannelApplicationAdmins
 )
 
 //defaultACLProvider used if resource-based ACL Provider is not provided or
@@ -75,6 +76,7 @@ func (d *defaultACLProvider) initialize() {
 	d.cResourcePolicyMap[resources.Qscc_GetBlockByHash] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Qscc_GetTransactionByID] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Qscc_GetBlockByTxID] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetInvalidationReason] = CHANNELREADERS
 
 	//--------------- CSCC resources -----------
 	//p resources (implemented by the chaincode currently)
@@ -93,10 +95,14 @@ func (d *defaultACLProvider) initialize() {
 	d.cResourcePolicyMap[resources.Token_Issue] = CHANNELWRITERS
 	d.cResourcePolicyMap[resources.Token_Transfer] = CHANNELWRITERS
 	d.cResourcePolicyMap[resources.Token_List] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Token_Redeem] = CHANNELWRITERS
+	d.cResourcePolicyMap[resources.Token_Govern] = CHANNELADMINS
+	d.cResourcePolicyMap[resources.Token_Submit] = CHANNELWRITERS
 
 	//Event resources
 	d.cResourcePolicyMap[resources.Event_Block] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Event_FilteredBlock] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Event_BlockAndPrivateData] = CHANNELREADERS
 }
 
 //this should cover an exhaustive list of everything called from the peer
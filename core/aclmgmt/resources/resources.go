@@ -23,11 +23,12 @@ const (
 	Lscc_GetCollectionsConfig      = "lscc/GetCollectionsConfig"
 
 	//Qscc resources
-	Qscc_GetChainInfo       = "qscc/GetChainInfo"
-	Qscc_GetBlockByNumber   = "qscc/GetBlockByNumber"
-	Qscc_GetBlockByHash     = "qscc/GetBlockByHash"
-	Qscc_GetTransactionByID = "qscc/GetTransactionByID"
-	Qscc_GetBlockByTxID     = "qscc/GetBlockByTxID"
+	Qscc_GetChainInfo          = "qscc/GetChainInfo"
+	Qscc_GetBlockByNumber      = "qscc/GetBlockByNumber"
+	Qscc_GetBlockByHash        = "qscc/GetBlockByHash"
+	Qscc_GetTransactionByID    = "qscc/GetTransactionByID"
+	Qscc_GetBlockByTxID        = "qscc/GetBlockByTxID"
+	Qscc_GetInvalidationReason = "qscc/GetInvalidationReason"
 
 	//Cscc resources
 	Cscc_JoinChain                = "cscc/JoinChain"
@@ -41,11 +42,15 @@ const (
 	Peer_ChaincodeToChaincode = "peer/ChaincodeToChaincode"
 
 	//Events
-	Event_Block         = "event/Block"
-	Event_FilteredBlock = "event/FilteredBlock"
+	Event_Block               = "event/Block"
+	Event_FilteredBlock       = "event/FilteredBlock"
+	Event_BlockAndPrivateData = "event/BlockAndPrivateData"
 
 	//Token resources
 	Token_Issue    = "token/Issue"
 	Token_Transfer = "token/Transfer"
 	Token_List     = "token/List"
+	Token_Redeem   = "token/Redeem"
+	Token_Govern   = "token/Govern"
+	Token_Submit   = "token/Submit"
 )
@@ -167,6 +167,9 @@ func (c *ContainerRuntime) LaunchConfig(cname string, ccType string) (*LaunchCon
 		lc.Args = []string{"/root/chaincode-java/start", "--peerAddress", c.PeerAddress}
 	case pb.ChaincodeSpec_NODE.String():
 		lc.Args = []string{"/bin/sh", "-c", fmt.Sprintf("cd /usr/local/src; npm start -- --peer.address %s", c.PeerAddress)}
+	case pb.ChaincodeSpec_WASM.String():
+		// wasm chaincode is executed by the peer and connects to it in process
+		lc.Args = []string{}
 	default:
 		return nil, errors.Errorf("unknown chaincodeType: %s", ccType)
 	}
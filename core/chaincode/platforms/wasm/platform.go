@@ -0,0 +1,157 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wasm
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/chaincode/platforms"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/ccmetadata"
+	cutil "github.com/hyperledger/fabric/core/container/util"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+const (
+	// ModuleFile is the name of the WebAssembly module in the build context
+	// produced by GenerateDockerBuild.
+	ModuleFile = "chaincode.wasm"
+
+	// packageModuleFile is the name of the module in the code package.
+	packageModuleFile = "src/" + ModuleFile
+)
+
+// magic is the preamble of a version 1 WebAssembly binary module.
+var magic = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+// Platform for chaincode written to WebAssembly. The chaincode is not run
+// in a container but by the peer's WebAssembly runtime, so the docker build
+// for this platform only carries the module.
+type Platform struct {
+}
+
+// Name returns the name of this platform
+func (wasmPlatform *Platform) Name() string {
+	return pb.ChaincodeSpec_WASM.String()
+}
+
+// ValidatePath validates that the path is a WebAssembly module
+func (wasmPlatform *Platform) ValidatePath(path string) error {
+	if filepath.Ext(path) != ".wasm" {
+		return fmt.Errorf("path to wasm chaincode must be a .wasm file: %s", path)
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat wasm module %s: %s", path, err)
+	}
+	if !fi.Mode().IsRegular() {
+		return fmt.Errorf("wasm module %s is not a regular file", path)
+	}
+	return nil
+}
+
+// ValidateCodePackage checks that the package contains a single WebAssembly
+// module and, optionally, META-INF content
+func (wasmPlatform *Platform) ValidateCodePackage(code []byte) error {
+	if len(code) == 0 {
+		// Nothing to validate if no CodePackage was included
+		return nil
+	}
+
+	_, err := ExtractModule(code)
+	return err
+}
+
+// GetDeploymentPayload packages the module found at path
+func (wasmPlatform *Platform) GetDeploymentPayload(path string) ([]byte, error) {
+	module, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wasm module %s: %s", path, err)
+	}
+	if !bytes.HasPrefix(module, magic) {
+		return nil, fmt.Errorf("%s is not a WebAssembly binary module", path)
+	}
+
+	payload := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(payload)
+	tw := tar.NewWriter(gw)
+
+	err = cutil.WriteBytesToPackage(packageModuleFile, module, tw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write wasm module to package: %s", err)
+	}
+
+	tw.Close()
+	gw.Close()
+
+	return payload.Bytes(), nil
+}
+
+// GenerateDockerfile returns a placeholder as the module is executed by the
+// peer rather than in a container
+func (wasmPlatform *Platform) GenerateDockerfile() (string, error) {
+	return "FROM scratch", nil
+}
+
+// GenerateDockerBuild adds the module from the code package to the build context
+func (wasmPlatform *Platform) GenerateDockerBuild(path string, code []byte, tw *tar.Writer) error {
+	module, err := ExtractModule(code)
+	if err != nil {
+		return err
+	}
+
+	return cutil.WriteBytesToPackage(ModuleFile, module, tw)
+}
+
+// GetMetadataProvider fetches metadata provider given deployment spec
+func (wasmPlatform *Platform) GetMetadataProvider(code []byte) platforms.MetadataProvider {
+	return &ccmetadata.TargzMetadataProvider{Code: code}
+}
+
+// ExtractModule returns the WebAssembly module held by a code package.
+func ExtractModule(code []byte) ([]byte, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(code))
+	if err != nil {
+		return nil, fmt.Errorf("failure opening codepackage gzip stream: %s", err)
+	}
+	tr := tar.NewReader(gr)
+
+	var module []byte
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			// We only get here if there are no more entries to scan
+			break
+		}
+
+		switch {
+		case header.Name == packageModuleFile:
+			module, err = ioutil.ReadAll(tr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read wasm module from package: %s", err)
+			}
+		case strings.HasPrefix(header.Name, "META-INF/"):
+		default:
+			return nil, fmt.Errorf("illegal file detected in payload: \"%s\"", header.Name)
+		}
+	}
+
+	if module == nil {
+		return nil, fmt.Errorf("no %s found in the chaincode package", packageModuleFile)
+	}
+	if !bytes.HasPrefix(module, magic) {
+		return nil, fmt.Errorf("%s is not a WebAssembly binary module", packageModuleFile)
+	}
+
+	return module, nil
+}
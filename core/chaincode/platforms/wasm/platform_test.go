@@ -0,0 +1,127 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wasm
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/platforms"
+	cutil "github.com/hyperledger/fabric/core/container/util"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ = platforms.Platform(&Platform{})
+
+var platform = &Platform{}
+
+func makeCodePackage(t *testing.T, files map[string][]byte) []byte {
+	payload := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(payload)
+	tw := tar.NewWriter(gw)
+	for name, contents := range files {
+		require.NoError(t, cutil.WriteBytesToPackage(name, contents, tw))
+	}
+	tw.Close()
+	gw.Close()
+	return payload.Bytes()
+}
+
+func TestName(t *testing.T) {
+	assert.Equal(t, pb.ChaincodeSpec_WASM.String(), platform.Name())
+}
+
+func TestValidatePath(t *testing.T) {
+	assert.NoError(t, platform.ValidatePath("testdata/chaincode.wasm"))
+	assert.EqualError(t, platform.ValidatePath("testdata"), "path to wasm chaincode must be a .wasm file: testdata")
+	assert.Contains(t, platform.ValidatePath("testdata/missing.wasm").Error(), "failed to stat wasm module testdata/missing.wasm")
+}
+
+func TestGetDeploymentPayload(t *testing.T) {
+	module, err := ioutil.ReadFile("testdata/chaincode.wasm")
+	require.NoError(t, err)
+
+	payload, err := platform.GetDeploymentPayload("testdata/chaincode.wasm")
+	require.NoError(t, err)
+	assert.NoError(t, platform.ValidateCodePackage(payload))
+
+	extracted, err := ExtractModule(payload)
+	require.NoError(t, err)
+	assert.Equal(t, module, extracted)
+
+	_, err = platform.GetDeploymentPayload("testdata/invalid.wasm")
+	assert.EqualError(t, err, "testdata/invalid.wasm is not a WebAssembly binary module")
+}
+
+func TestValidateCodePackage(t *testing.T) {
+	module, err := ioutil.ReadFile("testdata/chaincode.wasm")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		files  map[string][]byte
+		errMsg string
+	}{
+		{
+			name:  "module with metadata",
+			files: map[string][]byte{"src/chaincode.wasm": module, "META-INF/statedb/couchdb/indexes/index.json": []byte("{}")},
+		},
+		{
+			name:   "no module",
+			files:  map[string][]byte{"META-INF/statedb/couchdb/indexes/index.json": []byte("{}")},
+			errMsg: "no src/chaincode.wasm found in the chaincode package",
+		},
+		{
+			name:   "unexpected file",
+			files:  map[string][]byte{"src/chaincode.wasm": module, "src/other": []byte("data")},
+			errMsg: `illegal file detected in payload: "src/other"`,
+		},
+		{
+			name:   "not a module",
+			files:  map[string][]byte{"src/chaincode.wasm": []byte("data")},
+			errMsg: "src/chaincode.wasm is not a WebAssembly binary module",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := platform.ValidateCodePackage(makeCodePackage(t, tt.files))
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.errMsg)
+			}
+		})
+	}
+
+	assert.NoError(t, platform.ValidateCodePackage(nil))
+	assert.Contains(t, platform.ValidateCodePackage([]byte("garbage")).Error(), "failure opening codepackage gzip stream")
+}
+
+func TestGenerateDockerBuild(t *testing.T) {
+	module, err := ioutil.ReadFile("testdata/chaincode.wasm")
+	require.NoError(t, err)
+
+	buf := bytes.NewBuffer(nil)
+	tw := tar.NewWriter(buf)
+	err = platform.GenerateDockerBuild("", makeCodePackage(t, map[string][]byte{"src/chaincode.wasm": module}), tw)
+	require.NoError(t, err)
+	tw.Close()
+
+	tr := tar.NewReader(buf)
+	header, err := tr.Next()
+	require.NoError(t, err)
+	assert.Equal(t, ModuleFile, header.Name)
+	contents, err := ioutil.ReadAll(tr)
+	require.NoError(t, err)
+	assert.Equal(t, module, contents)
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"net"
 	"time"
 
 	"github.com/pkg/errors"
@@ -57,6 +58,26 @@ func NewGRPCClient(config ClientConfig) (*GRPCClient, error) {
 	kap.PermitWithoutStream = true
 	// set keepalive
 	client.dialOpts = append(client.dialOpts, grpc.WithKeepaliveParams(kap))
+	// set compression
+	err = ValidateCompression(config.Compression)
+	if err != nil {
+		return client, err
+	}
+	client.dialOpts = append(client.dialOpts, ClientCompressionOptions(config.Compression)...)
+	// set proxy
+	if config.Proxy != "" {
+		dialer, err := NewProxyDialer(config.Proxy)
+		if err != nil {
+			return client, err
+		}
+		client.dialOpts = append(client.dialOpts, grpc.WithDialer(
+			func(address string, timeout time.Duration) (net.Conn, error) {
+				ctx, cancel := context.WithTimeout(context.Background(), timeout)
+				defer cancel()
+				return dialer(ctx, address)
+			},
+		))
+	}
 	// Unless asynchronous connect is set, make connection establishment blocking.
 	if !config.AsyncConnect {
 		client.dialOpts = append(client.dialOpts, grpc.WithBlock())
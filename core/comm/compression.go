@@ -0,0 +1,139 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"compress/gzip"
+	"io"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+const (
+	// CompressionGzip compresses messages with gzip
+	CompressionGzip = "gzip"
+	// CompressionSnappy compresses messages with the snappy framing format
+	CompressionSnappy = "snappy"
+)
+
+// The compressors are registered with gRPC so that every server accepts
+// compressed requests and compresses its responses with the encoding of
+// the request. Clients opt in with ClientCompressionOptions.
+func init() {
+	encoding.RegisterCompressor(&gzipCompressor{})
+	encoding.RegisterCompressor(&snappyCompressor{})
+}
+
+// ValidateCompression returns an error if the compression is not supported.
+// The empty string disables compression.
+func ValidateCompression(compression string) error {
+	switch compression {
+	case "", CompressionGzip, CompressionSnappy:
+		return nil
+	default:
+		return errors.Errorf("unsupported compression '%s'", compression)
+	}
+}
+
+// ClientCompressionOptions returns gRPC dial options compressing the requests,
+// and therefore the responses, of a client with the given compression.
+// If compression is empty, no options are returned.
+func ClientCompressionOptions(compression string) []grpc.DialOption {
+	if compression == "" {
+		return nil
+	}
+	return []grpc.DialOption{grpc.WithDefaultCallOptions(grpc.UseCompressor(compression))}
+}
+
+type gzipCompressor struct {
+	writers sync.Pool
+	readers sync.Pool
+}
+
+type gzipWriter struct {
+	*gzip.Writer
+	pool *sync.Pool
+}
+
+func (w *gzipWriter) Close() error {
+	defer w.pool.Put(w)
+	return w.Writer.Close()
+}
+
+func (c *gzipCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	if z, ok := c.writers.Get().(*gzipWriter); ok {
+		z.Writer.Reset(w)
+		return z, nil
+	}
+	return &gzipWriter{Writer: gzip.NewWriter(w), pool: &c.writers}, nil
+}
+
+type gzipReader struct {
+	*gzip.Reader
+	pool *sync.Pool
+}
+
+func (r *gzipReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if err == io.EOF {
+		r.pool.Put(r)
+	}
+	return n, err
+}
+
+func (c *gzipCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	if z, ok := c.readers.Get().(*gzipReader); ok {
+		if err := z.Reader.Reset(r); err != nil {
+			c.readers.Put(z)
+			return nil, err
+		}
+		return z, nil
+	}
+	z, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return &gzipReader{Reader: z, pool: &c.readers}, nil
+}
+
+func (c *gzipCompressor) Name() string {
+	return CompressionGzip
+}
+
+type snappyCompressor struct {
+	writers sync.Pool
+}
+
+type snappyWriter struct {
+	*snappy.Writer
+	pool *sync.Pool
+}
+
+func (w *snappyWriter) Close() error {
+	defer w.pool.Put(w)
+	return w.Writer.Close()
+}
+
+func (c *snappyCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	if s, ok := c.writers.Get().(*snappyWriter); ok {
+		s.Writer.Reset(w)
+		return s, nil
+	}
+	return &snappyWriter{Writer: snappy.NewBufferedWriter(w), pool: &c.writers}, nil
+}
+
+func (c *snappyCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	return snappy.NewReader(r), nil
+}
+
+func (c *snappyCompressor) Name() string {
+	return CompressionSnappy
+}
@@ -0,0 +1,98 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/comm"
+	testpb "github.com/hyperledger/fabric/core/comm/testdata/grpc"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/encoding"
+)
+
+func TestCompressors(t *testing.T) {
+	t.Parallel()
+
+	payload := bytes.Repeat([]byte("fabtoken"), 1000)
+	for _, name := range []string{comm.CompressionGzip, comm.CompressionSnappy} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			compressor := encoding.GetCompressor(name)
+			assert.NotNil(t, compressor)
+			assert.Equal(t, name, compressor.Name())
+
+			// run twice to exercise pooled writers and readers
+			for i := 0; i < 2; i++ {
+				compressed := &bytes.Buffer{}
+				w, err := compressor.Compress(compressed)
+				assert.NoError(t, err)
+				_, err = w.Write(payload)
+				assert.NoError(t, err)
+				assert.NoError(t, w.Close())
+				assert.True(t, compressed.Len() < len(payload))
+
+				r, err := compressor.Decompress(compressed)
+				assert.NoError(t, err)
+				decompressed, err := ioutil.ReadAll(r)
+				assert.NoError(t, err)
+				assert.Equal(t, payload, decompressed)
+			}
+		})
+	}
+}
+
+func TestValidateCompression(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, comm.ValidateCompression(""))
+	assert.NoError(t, comm.ValidateCompression("gzip"))
+	assert.NoError(t, comm.ValidateCompression("snappy"))
+	assert.EqualError(t, comm.ValidateCompression("lzma"), "unsupported compression 'lzma'")
+
+	_, err := comm.NewGRPCClient(comm.ClientConfig{Compression: "lzma"})
+	assert.EqualError(t, err, "unsupported compression 'lzma'")
+}
+
+func TestClientCompression(t *testing.T) {
+	t.Parallel()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	srv, err := comm.NewGRPCServerFromListener(lis, comm.ServerConfig{})
+	assert.NoError(t, err)
+	testpb.RegisterEchoServiceServer(srv.Server(), &echoServer{})
+	defer srv.Stop()
+	go srv.Start()
+
+	echo := &testpb.Echo{Payload: bytes.Repeat([]byte{0}, 100*1024)}
+	for _, compression := range []string{comm.CompressionGzip, comm.CompressionSnappy} {
+		compression := compression
+		t.Run(compression, func(t *testing.T) {
+			client, err := comm.NewGRPCClient(comm.ClientConfig{Timeout: testTimeout, Compression: compression})
+			assert.NoError(t, err)
+			// the limit applies to the messages on the wire, so the
+			// call only succeeds if the request is compressed
+			client.SetMaxSendMsgSize(10 * 1024)
+
+			conn, err := client.NewConnection(lis.Addr().String(), "")
+			assert.NoError(t, err)
+			defer conn.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			resp, err := testpb.NewEchoServiceClient(conn).EchoCall(ctx, echo)
+			assert.NoError(t, err)
+			assert.True(t, proto.Equal(echo, resp))
+		})
+	}
+}
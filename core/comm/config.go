@@ -75,6 +75,11 @@ type ClientConfig struct {
 	Timeout time.Duration
 	// AsyncConnect makes connection creation non blocking
 	AsyncConnect bool
+	// Compression, when set, is used to compress requests and responses
+	Compression string
+	// Proxy, when set, is the URL of the HTTP CONNECT or SOCKS5 proxy
+	// through which connections are established
+	Proxy string
 }
 
 // SecureOptions defines the security parameters (e.g. TLS) for a
@@ -0,0 +1,310 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ProxyDialer dials a network address through a proxy
+type ProxyDialer func(ctx context.Context, address string) (net.Conn, error)
+
+// NewProxyDialer returns a dialer tunneling connections through the proxy at
+// proxyURL. The http scheme uses HTTP CONNECT, the socks5 and socks5h schemes
+// use SOCKS5; credentials in the URL are used to authenticate with the proxy.
+func NewProxyDialer(proxyURL string) (ProxyDialer, error) {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid proxy URL %s", proxyURL)
+	}
+	if u.Host == "" {
+		return nil, errors.Errorf("invalid proxy URL %s: missing host", proxyURL)
+	}
+
+	var handshake func(conn net.Conn, address string, user *url.Userinfo) error
+	port := ""
+	switch u.Scheme {
+	case "http":
+		handshake, port = httpConnectHandshake, "80"
+	case "socks5", "socks5h":
+		handshake, port = socks5Handshake, "1080"
+	default:
+		return nil, errors.Errorf("unsupported proxy scheme '%s'", u.Scheme)
+	}
+	proxyAddress := u.Host
+	if u.Port() == "" {
+		proxyAddress = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	return func(ctx context.Context, address string) (net.Conn, error) {
+		dialer := &net.Dialer{}
+		conn, err := dialer.DialContext(ctx, "tcp", proxyAddress)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed connecting to proxy %s", proxyAddress)
+		}
+		if deadline, ok := ctx.Deadline(); ok {
+			conn.SetDeadline(deadline)
+		}
+		if err := handshake(conn, address, u.User); err != nil {
+			conn.Close()
+			return nil, errors.WithMessage(err, fmt.Sprintf("proxy %s failed connecting to %s", proxyAddress, address))
+		}
+		conn.SetDeadline(time.Time{})
+		return conn, nil
+	}, nil
+}
+
+// ProxyFromEnvironment returns the proxy URL to use for connections to address
+// according to the HTTPS_PROXY, ALL_PROXY and NO_PROXY environment variables,
+// or their lowercase versions. HTTPS_PROXY takes precedence over ALL_PROXY.
+// The empty string is returned when the connection should not be proxied;
+// connections to loopback addresses are never proxied.
+func ProxyFromEnvironment(address string) string {
+	proxy := getEnvAny("HTTPS_PROXY", "https_proxy", "ALL_PROXY", "all_proxy")
+	if proxy == "" {
+		return ""
+	}
+	if !useProxy(address, getEnvAny("NO_PROXY", "no_proxy")) {
+		return ""
+	}
+	// mirror the net/http convention of proxies configured without a scheme
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+	return proxy
+}
+
+func getEnvAny(names ...string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// useProxy returns whether the connection to address should be proxied given
+// the comma separated NO_PROXY entries: host names, domain suffixes, IP
+// addresses or CIDR blocks, each optionally with a port, or "*".
+func useProxy(address, noProxy string) bool {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+	host = strings.ToLower(host)
+	if host == "localhost" {
+		return false
+	}
+	ip := net.ParseIP(host)
+	if ip != nil && ip.IsLoopback() {
+		return false
+	}
+
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if entry == "*" {
+			return false
+		}
+		if _, cidr, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && cidr.Contains(ip) {
+				return false
+			}
+			continue
+		}
+		if h, p, err := net.SplitHostPort(entry); err == nil {
+			if p != port {
+				continue
+			}
+			entry = h
+		}
+		if entryIP := net.ParseIP(entry); entryIP != nil {
+			if ip != nil && entryIP.Equal(ip) {
+				return false
+			}
+			continue
+		}
+		entry = strings.TrimPrefix(entry, ".")
+		if host == entry || strings.HasSuffix(host, "."+entry) {
+			return false
+		}
+	}
+	return true
+}
+
+func httpConnectHandshake(conn net.Conn, address string, user *url.Userinfo) error {
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Host: address},
+		Host:   address,
+		Header: http.Header{},
+	}
+	if user != nil {
+		password, _ := user.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := req.Write(conn); err != nil {
+		return errors.Wrap(err, "failed sending CONNECT request")
+	}
+
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, req)
+	if err != nil {
+		return errors.Wrap(err, "failed reading CONNECT response")
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("CONNECT request returned status %s", resp.Status)
+	}
+	// the proxy does not send data until the client speaks, so there is
+	// nothing buffered past the response
+	if r.Buffered() > 0 {
+		return errors.New("unexpected data following CONNECT response")
+	}
+	return nil
+}
+
+const (
+	socks5Version       = 0x05
+	socks5NoAuth        = 0x00
+	socks5UserPass      = 0x02
+	socks5NoAcceptable  = 0xff
+	socks5Connect       = 0x01
+	socks5AddrIPv4      = 0x01
+	socks5AddrDomain    = 0x03
+	socks5AddrIPv6      = 0x04
+	socks5UserPassBasic = 0x01
+)
+
+// socks5Handshake implements the CONNECT command of RFC 1928 with the
+// username/password authentication of RFC 1929. Host names are resolved by the proxy.
+func socks5Handshake(conn net.Conn, address string, user *url.Userinfo) error {
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return errors.Wrapf(err, "invalid address %s", address)
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return errors.Wrapf(err, "invalid port in address %s", address)
+	}
+
+	methods := []byte{socks5NoAuth}
+	if user != nil {
+		methods = []byte{socks5NoAuth, socks5UserPass}
+	}
+	if _, err := conn.Write(append([]byte{socks5Version, byte(len(methods))}, methods...)); err != nil {
+		return errors.Wrap(err, "failed sending SOCKS5 greeting")
+	}
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return errors.Wrap(err, "failed reading SOCKS5 greeting")
+	}
+	if reply[0] != socks5Version {
+		return errors.Errorf("unexpected SOCKS version %d", reply[0])
+	}
+	switch reply[1] {
+	case socks5NoAuth:
+	case socks5UserPass:
+		if user == nil {
+			return errors.New("SOCKS5 proxy requires authentication")
+		}
+		if err := socks5Authenticate(conn, user); err != nil {
+			return err
+		}
+	case socks5NoAcceptable:
+		return errors.New("no acceptable SOCKS5 authentication method")
+	default:
+		return errors.Errorf("unsupported SOCKS5 authentication method %d", reply[1])
+	}
+
+	req := []byte{socks5Version, socks5Connect, 0x00}
+	if ip := net.ParseIP(host); ip != nil {
+		if ip4 := ip.To4(); ip4 != nil {
+			req = append(append(req, socks5AddrIPv4), ip4...)
+		} else {
+			req = append(append(req, socks5AddrIPv6), ip.To16()...)
+		}
+	} else {
+		if len(host) > 255 {
+			return errors.Errorf("host name %s too long", host)
+		}
+		req = append(append(req, socks5AddrDomain, byte(len(host))), host...)
+	}
+	req = append(req, 0, 0)
+	binary.BigEndian.PutUint16(req[len(req)-2:], uint16(port))
+	if _, err := conn.Write(req); err != nil {
+		return errors.Wrap(err, "failed sending SOCKS5 connect request")
+	}
+
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return errors.Wrap(err, "failed reading SOCKS5 connect reply")
+	}
+	if header[1] != 0x00 {
+		return errors.Errorf("SOCKS5 connect request failed with code %d", header[1])
+	}
+	// discard the bound address
+	var boundLen int
+	switch header[3] {
+	case socks5AddrIPv4:
+		boundLen = net.IPv4len
+	case socks5AddrIPv6:
+		boundLen = net.IPv6len
+	case socks5AddrDomain:
+		l := make([]byte, 1)
+		if _, err := io.ReadFull(conn, l); err != nil {
+			return errors.Wrap(err, "failed reading SOCKS5 connect reply")
+		}
+		boundLen = int(l[0])
+	default:
+		return errors.Errorf("unsupported SOCKS5 address type %d", header[3])
+	}
+	if _, err := io.ReadFull(conn, make([]byte, boundLen+2)); err != nil {
+		return errors.Wrap(err, "failed reading SOCKS5 connect reply")
+	}
+	return nil
+}
+
+func socks5Authenticate(conn net.Conn, user *url.Userinfo) error {
+	username := user.Username()
+	password, _ := user.Password()
+	if len(username) > 255 || len(password) > 255 {
+		return errors.New("SOCKS5 credentials too long")
+	}
+	req := []byte{socks5UserPassBasic, byte(len(username))}
+	req = append(req, username...)
+	req = append(req, byte(len(password)))
+	req = append(req, password...)
+	if _, err := conn.Write(req); err != nil {
+		return errors.Wrap(err, "failed sending SOCKS5 credentials")
+	}
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return errors.Wrap(err, "failed reading SOCKS5 authentication reply")
+	}
+	if reply[1] != 0x00 {
+		return errors.New("SOCKS5 authentication failed")
+	}
+	return nil
+}
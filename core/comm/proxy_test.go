@@ -0,0 +1,254 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseProxy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		address  string
+		noProxy  string
+		expected bool
+	}{
+		{"peer0.org1.example.com:7051", "", true},
+		{"localhost:7051", "", false},
+		{"127.0.0.1:7051", "", false},
+		{"[::1]:7051", "", false},
+		{"peer0.org1.example.com:7051", "*", false},
+		{"peer0.org1.example.com:7051", "example.com", false},
+		{"peer0.org1.example.com:7051", ".example.com", false},
+		{"peer0.org1.example.com:7051", "EXAMPLE.COM", false},
+		{"peer0.org1.example.com:7051", "ample.com", true},
+		{"peer0.org1.example.com:7051", "other.org, org1.example.com", false},
+		{"peer0.org1.example.com:7051", "example.com:7051", false},
+		{"peer0.org1.example.com:7051", "example.com:7050", true},
+		{"10.1.2.3:7051", "10.0.0.0/8", false},
+		{"192.168.1.3:7051", "10.0.0.0/8", true},
+		{"10.1.2.3:7051", "10.1.2.3", false},
+		{"10.1.2.4:7051", "10.1.2.3", true},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, useProxy(test.address, test.noProxy), "address %s, NO_PROXY %s", test.address, test.noProxy)
+	}
+}
+
+func TestProxyFromEnvironment(t *testing.T) {
+	for _, name := range []string{"HTTPS_PROXY", "https_proxy", "ALL_PROXY", "all_proxy", "NO_PROXY", "no_proxy"} {
+		defer os.Setenv(name, os.Getenv(name))
+		os.Unsetenv(name)
+	}
+
+	assert.Equal(t, "", ProxyFromEnvironment("peer0:7051"))
+
+	os.Setenv("ALL_PROXY", "socks5://proxy:1080")
+	assert.Equal(t, "socks5://proxy:1080", ProxyFromEnvironment("peer0:7051"))
+
+	os.Setenv("https_proxy", "proxy:3128")
+	assert.Equal(t, "http://proxy:3128", ProxyFromEnvironment("peer0:7051"))
+	assert.Equal(t, "", ProxyFromEnvironment("127.0.0.1:7051"))
+
+	os.Setenv("NO_PROXY", "peer0")
+	assert.Equal(t, "", ProxyFromEnvironment("peer0:7051"))
+	assert.Equal(t, "http://proxy:3128", ProxyFromEnvironment("peer1:7051"))
+}
+
+func TestNewProxyDialerBadURL(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewProxyDialer("ftp://proxy:21")
+	assert.EqualError(t, err, "unsupported proxy scheme 'ftp'")
+	_, err = NewProxyDialer("socks5://")
+	assert.EqualError(t, err, "invalid proxy URL socks5://: missing host")
+	_, err = NewProxyDialer("http://proxy:port")
+	assert.Error(t, err)
+
+	_, err = NewGRPCClient(ClientConfig{Proxy: "ftp://proxy:21"})
+	assert.EqualError(t, err, "unsupported proxy scheme 'ftp'")
+}
+
+func TestProxyDialer(t *testing.T) {
+	t.Parallel()
+
+	target := echoListener(t)
+	defer target.Close()
+
+	tests := []struct {
+		name   string
+		scheme string
+		proxy  func(conn net.Conn) (requested, target string)
+		user   string
+	}{
+		{name: "http", scheme: "http", proxy: httpConnectProxy},
+		{name: "http with credentials", scheme: "http", proxy: httpConnectProxy, user: "alice:secret@"},
+		{name: "socks5", scheme: "socks5", proxy: socks5Proxy},
+		{name: "socks5 with credentials", scheme: "socks5", proxy: socks5Proxy, user: "alice:secret@"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			requests := make(chan string, 1)
+			proxy := serveProxy(t, func(conn net.Conn) {
+				requested, target := test.proxy(conn)
+				requests <- requested
+				tunnel(conn, target)
+			})
+			defer proxy.Close()
+
+			dialer, err := NewProxyDialer(test.scheme + "://" + test.user + proxy.Addr().String())
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			conn, err := dialer(ctx, target.Addr().String())
+			require.NoError(t, err)
+			defer conn.Close()
+
+			_, err = conn.Write([]byte("ping"))
+			require.NoError(t, err)
+			reply := make([]byte, 4)
+			_, err = io.ReadFull(conn, reply)
+			require.NoError(t, err)
+			assert.Equal(t, "ping", string(reply))
+
+			expected := target.Addr().String()
+			if test.user != "" {
+				expected = "alice:secret@" + expected
+			}
+			assert.Equal(t, expected, <-requests)
+		})
+	}
+}
+
+func TestProxyDialerRejected(t *testing.T) {
+	t.Parallel()
+
+	proxy := serveProxy(t, func(conn net.Conn) {
+		r := bufio.NewReader(conn)
+		req, err := http.ReadRequest(r)
+		if err != nil {
+			return
+		}
+		resp := &http.Response{StatusCode: http.StatusForbidden, ProtoMajor: 1, ProtoMinor: 1, Request: req}
+		resp.Write(conn)
+	})
+	defer proxy.Close()
+
+	dialer, err := NewProxyDialer("http://" + proxy.Addr().String())
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = dialer(ctx, "peer0:7051")
+	assert.EqualError(t, err, "proxy "+proxy.Addr().String()+" failed connecting to peer0:7051: CONNECT request returned status 403 Forbidden")
+}
+
+func echoListener(t *testing.T) net.Listener {
+	return serveProxy(t, func(conn net.Conn) {
+		io.Copy(conn, conn)
+	})
+}
+
+func serveProxy(t *testing.T, handle func(conn net.Conn)) net.Listener {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				handle(conn)
+			}()
+		}
+	}()
+	return lis
+}
+
+// tunnel connects conn to address and copies data in both directions
+func tunnel(conn net.Conn, address string) {
+	target, err := net.Dial("tcp", address)
+	if err != nil {
+		return
+	}
+	defer target.Close()
+	go io.Copy(target, conn)
+	io.Copy(conn, target)
+}
+
+// httpConnectProxy handles a CONNECT request and returns the requested
+// address, prefixed with the proxy credentials if any, and the target address
+func httpConnectProxy(conn net.Conn) (string, string) {
+	req, err := http.ReadRequest(bufio.NewReader(conn))
+	if err != nil || req.Method != http.MethodConnect {
+		return "", ""
+	}
+	requested := req.Host
+	if auth := req.Header.Get("Proxy-Authorization"); auth != "" {
+		r := &http.Request{Header: http.Header{"Authorization": []string{auth}}}
+		user, password, _ := r.BasicAuth()
+		requested = user + ":" + password + "@" + requested
+	}
+	resp := &http.Response{StatusCode: http.StatusOK, ProtoMajor: 1, ProtoMinor: 1, Request: req}
+	resp.Write(conn)
+	return requested, req.Host
+}
+
+// socks5Proxy handles a SOCKS5 connect request and returns the requested
+// address, prefixed with the proxy credentials if any, and the target address
+func socks5Proxy(conn net.Conn) (string, string) {
+	greeting := make([]byte, 2)
+	if _, err := io.ReadFull(conn, greeting); err != nil {
+		return "", ""
+	}
+	methods := make([]byte, greeting[1])
+	io.ReadFull(conn, methods)
+
+	credentials := ""
+	if len(methods) == 2 && methods[1] == socks5UserPass {
+		conn.Write([]byte{socks5Version, socks5UserPass})
+		header := make([]byte, 2)
+		io.ReadFull(conn, header)
+		user := make([]byte, header[1])
+		io.ReadFull(conn, user)
+		l := make([]byte, 1)
+		io.ReadFull(conn, l)
+		password := make([]byte, l[0])
+		io.ReadFull(conn, password)
+		conn.Write([]byte{socks5UserPassBasic, 0x00})
+		credentials = string(user) + ":" + string(password) + "@"
+	} else {
+		conn.Write([]byte{socks5Version, socks5NoAuth})
+	}
+
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil || header[3] != socks5AddrIPv4 {
+		return "", ""
+	}
+	addr := make([]byte, net.IPv4len+2)
+	io.ReadFull(conn, addr)
+	address := net.JoinHostPort(net.IP(addr[:net.IPv4len]).String(), strconv.Itoa(int(binary.BigEndian.Uint16(addr[net.IPv4len:]))))
+
+	conn.Write([]byte{socks5Version, 0x00, 0x00, socks5AddrIPv4, 0, 0, 0, 0, 0, 0})
+	return credentials + address, address
+}
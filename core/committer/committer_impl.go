@@ -49,8 +49,15 @@ type PeerLedgerSupport interface {
 type LedgerCommitter struct {
 	PeerLedgerSupport
 	eventer ConfigBlockEventer
+	// CommitListener, when set, is called with each block once it is
+	// committed, carrying the final validation flags of its transactions
+	CommitListener BlockCommitListener
 }
 
+// BlockCommitListener callback function proto type to define action
+// upon the commit of a block
+type BlockCommitListener func(block *common.Block)
+
 // ConfigBlockEventer callback function proto type to define action
 // upon arrival on new configuaration update block
 type ConfigBlockEventer func(block *common.Block) error
@@ -94,6 +101,10 @@ func (lc *LedgerCommitter) CommitWithPvtData(blockAndPvtData *ledger.BlockAndPvt
 		return err
 	}
 
+	if lc.CommitListener != nil {
+		lc.CommitListener(blockAndPvtData.Block)
+	}
+
 	return nil
 }
 
@@ -206,6 +206,28 @@ func TestNewLedgerCommitterReactive(t *testing.T) {
 	assert.Equal(t, int32(1), atomic.LoadInt32(&configArrived))
 }
 
+func TestLedgerCommitterCommitListener(t *testing.T) {
+	t.Parallel()
+	gb, ledger := createLedger("TestLedger")
+	block1 := testutil.ConstructBlock(t, 1, gb.Header.DataHash, [][]byte{{1, 2, 3, 4}}, true)
+
+	var committed []*common.Block
+	committer := NewLedgerCommitter(ledger)
+	committer.CommitListener = func(block *common.Block) {
+		committed = append(committed, block)
+	}
+
+	ledger.On("CommitWithPvtData", mock.Anything).Return(errors.New("commit failed")).Once()
+	err := committer.CommitWithPvtData(&ledger2.BlockAndPvtData{Block: block1})
+	assert.EqualError(t, err, "commit failed")
+	assert.Empty(t, committed)
+
+	ledger.On("CommitWithPvtData", mock.Anything).Return(nil)
+	err = committer.CommitWithPvtData(&ledger2.BlockAndPvtData{Block: block1})
+	assert.NoError(t, err)
+	assert.Equal(t, []*common.Block{block1}, committed)
+}
+
 func TestNewLedgerCommitterReactiveFailedConfigUpdate(t *testing.T) {
 	t.Parallel()
 	chainID := "TestLedger"
@@ -0,0 +1,218 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package invalidation
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultAdvisorMinConflicts = 1
+	defaultAdvisorMaxTxIDs     = 5
+	defaultAdvisorMaxKeys      = 10000
+
+	// maxConflictsPerKey bounds the conflicts remembered for each key, so
+	// that the count of a key reported by the advisor saturates
+	maxConflictsPerKey = 1000
+)
+
+// Key is a key of the world state of a channel.
+type Key struct {
+	Namespace string
+	Key       string
+}
+
+// An Advisor tracks the keys of the channels that recently caused read
+// conflicts, the hotspots, and advises clients of the hotspots among the keys
+// of their transactions, so that they can delay or change the transactions
+// bound to conflict rather than blindly retrying them.
+type Advisor struct {
+	// Window is how long a conflict on a key counts towards its hotspot.
+	// Zero means the conflicts never expire, within MaxKeys.
+	Window time.Duration
+	// MinConflicts is the number of conflicts within the window from which a
+	// key is a hotspot. Zero means 1.
+	MinConflicts int
+	// MaxTxIDs is the number of most recent conflicting transactions
+	// reported for each hotspot. Zero means 5.
+	MaxTxIDs int
+	// MaxKeys bounds the keys tracked for each channel; the keys that
+	// conflicted least recently are forgotten beyond it. Zero means 10000.
+	MaxKeys int
+
+	now      func() time.Time
+	mutex    sync.Mutex
+	channels map[string]map[Key]*hotspot
+}
+
+// hotspot holds the recent conflicts on a key, oldest first
+type hotspot struct {
+	conflicts []conflict
+}
+
+type conflict struct {
+	at   time.Time
+	txID string
+}
+
+// Record records that the transaction was invalidated by a conflict on the
+// key.
+func (a *Advisor) Record(channelID string, key Key, txID string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.channels == nil {
+		a.channels = map[string]map[Key]*hotspot{}
+	}
+	keys := a.channels[channelID]
+	if keys == nil {
+		keys = map[Key]*hotspot{}
+		a.channels[channelID] = keys
+	}
+	now := a.time()
+	h, ok := keys[key]
+	if !ok {
+		if len(keys) >= a.maxKeys() {
+			a.evict(keys, now)
+		}
+		h = &hotspot{}
+		keys[key] = h
+	}
+	h.conflicts = append(h.conflicts, conflict{at: now, txID: txID})
+	if len(h.conflicts) > maxConflictsPerKey {
+		h.conflicts = h.conflicts[len(h.conflicts)-maxConflictsPerKey:]
+	}
+}
+
+// Advise returns the hotspots among the keys, the most conflicting first, or
+// nil if none of the keys is a hotspot.
+func (a *Advisor) Advise(channelID string, keys []Key) *peer.ConflictAdvisory {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	now := a.time()
+	var hotspots []*peer.ConflictHotspot
+	seen := map[Key]bool{}
+	for _, key := range keys {
+		h := a.channels[channelID][key]
+		if h == nil || seen[key] {
+			continue
+		}
+		seen[key] = true
+		h.expire(a.cutoff(now))
+		if len(h.conflicts) < a.minConflicts() {
+			continue
+		}
+		hotspots = append(hotspots, &peer.ConflictHotspot{
+			Namespace: key.Namespace,
+			Key:       key.Key,
+			Conflicts: uint64(len(h.conflicts)),
+			TxIds:     h.recentTxIDs(a.maxTxIDs()),
+		})
+	}
+	if len(hotspots) == 0 {
+		return nil
+	}
+	sort.SliceStable(hotspots, func(i, j int) bool {
+		return hotspots[i].Conflicts > hotspots[j].Conflicts
+	})
+	return &peer.ConflictAdvisory{Hotspots: hotspots}
+}
+
+// AdviseSimulation returns the hotspots among the keys read by the public
+// simulation results of a proposal, or nil if it read none.
+func (a *Advisor) AdviseSimulation(channelID string, simulationResults []byte) (*peer.ConflictAdvisory, error) {
+	txRWSet := &rwsetutil.TxRwSet{}
+	if err := txRWSet.FromProtoBytes(simulationResults); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling simulation results")
+	}
+	var keys []Key
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		for _, read := range nsRWSet.KvRwSet.GetReads() {
+			keys = append(keys, Key{Namespace: nsRWSet.NameSpace, Key: read.Key})
+		}
+	}
+	return a.Advise(channelID, keys), nil
+}
+
+// evict forgets the expired keys, or the key that conflicted least recently
+// if none expired
+func (a *Advisor) evict(keys map[Key]*hotspot, now time.Time) {
+	var oldest Key
+	var oldestAt time.Time
+	for key, h := range keys {
+		h.expire(a.cutoff(now))
+		if len(h.conflicts) == 0 {
+			delete(keys, key)
+			continue
+		}
+		if at := h.conflicts[len(h.conflicts)-1].at; oldestAt.IsZero() || at.Before(oldestAt) {
+			oldest, oldestAt = key, at
+		}
+	}
+	if len(keys) >= a.maxKeys() {
+		delete(keys, oldest)
+	}
+}
+
+// cutoff returns the time before which the conflicts no longer count
+func (a *Advisor) cutoff(now time.Time) time.Time {
+	if a.Window <= 0 {
+		return time.Time{}
+	}
+	return now.Add(-a.Window)
+}
+
+func (h *hotspot) expire(cutoff time.Time) {
+	i := 0
+	for i < len(h.conflicts) && h.conflicts[i].at.Before(cutoff) {
+		i++
+	}
+	h.conflicts = h.conflicts[i:]
+}
+
+func (h *hotspot) recentTxIDs(max int) []string {
+	var txIDs []string
+	for i := len(h.conflicts) - 1; i >= 0 && len(txIDs) < max; i-- {
+		txIDs = append(txIDs, h.conflicts[i].txID)
+	}
+	return txIDs
+}
+
+func (a *Advisor) time() time.Time {
+	if a.now != nil {
+		return a.now()
+	}
+	return time.Now()
+}
+
+func (a *Advisor) minConflicts() int {
+	if a.MinConflicts > 0 {
+		return a.MinConflicts
+	}
+	return defaultAdvisorMinConflicts
+}
+
+func (a *Advisor) maxTxIDs() int {
+	if a.MaxTxIDs > 0 {
+		return a.MaxTxIDs
+	}
+	return defaultAdvisorMaxTxIDs
+}
+
+func (a *Advisor) maxKeys() int {
+	if a.MaxKeys > 0 {
+		return a.MaxKeys
+	}
+	return defaultAdvisorMaxKeys
+}
@@ -0,0 +1,96 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package invalidation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdvisor(t *testing.T) {
+	now := time.Date(2018, 11, 1, 10, 30, 0, 0, time.UTC)
+	advisor := &Advisor{Window: time.Minute, MinConflicts: 2, MaxTxIDs: 2, now: func() time.Time { return now }}
+	key1 := Key{Namespace: "mycc", Key: "key1"}
+	key2 := Key{Namespace: "mycc", Key: "key2"}
+
+	advisor.Record("channel-1", key1, "tx1")
+	assert.Nil(t, advisor.Advise("channel-1", []Key{key1, key2}))
+
+	now = now.Add(10 * time.Second)
+	advisor.Record("channel-1", key1, "tx2")
+	advisor.Record("channel-1", key1, "tx3")
+	advisor.Record("channel-1", key2, "tx3")
+	advisor.Record("channel-1", key2, "tx4")
+	advisor.Record("channel-2", key2, "tx5")
+	assert.Equal(t, &peer.ConflictAdvisory{Hotspots: []*peer.ConflictHotspot{
+		{Namespace: "mycc", Key: "key1", Conflicts: 3, TxIds: []string{"tx3", "tx2"}},
+		{Namespace: "mycc", Key: "key2", Conflicts: 2, TxIds: []string{"tx4", "tx3"}},
+	}}, advisor.Advise("channel-1", []Key{key2, key1, key1}))
+	assert.Nil(t, advisor.Advise("channel-2", []Key{key1, key2}))
+
+	// the conflicts out of the window no longer count
+	now = now.Add(55 * time.Second)
+	assert.Equal(t, &peer.ConflictAdvisory{Hotspots: []*peer.ConflictHotspot{
+		{Namespace: "mycc", Key: "key1", Conflicts: 2, TxIds: []string{"tx3", "tx2"}},
+		{Namespace: "mycc", Key: "key2", Conflicts: 2, TxIds: []string{"tx4", "tx3"}},
+	}}, advisor.Advise("channel-1", []Key{key1, key2}))
+	now = now.Add(10 * time.Second)
+	assert.Nil(t, advisor.Advise("channel-1", []Key{key1, key2}))
+}
+
+func TestAdvisorMaxKeys(t *testing.T) {
+	now := time.Date(2018, 11, 1, 10, 30, 0, 0, time.UTC)
+	advisor := &Advisor{MaxKeys: 2, now: func() time.Time { return now }}
+	keys := []Key{{Namespace: "mycc", Key: "key1"}, {Namespace: "mycc", Key: "key2"}, {Namespace: "mycc", Key: "key3"}}
+
+	advisor.Record("channel-1", keys[0], "tx1")
+	now = now.Add(time.Second)
+	advisor.Record("channel-1", keys[1], "tx2")
+	now = now.Add(time.Second)
+	advisor.Record("channel-1", keys[0], "tx3")
+	now = now.Add(time.Second)
+	// the key that conflicted least recently is forgotten
+	advisor.Record("channel-1", keys[2], "tx4")
+
+	advisory := advisor.Advise("channel-1", keys)
+	require.NotNil(t, advisory)
+	var advised []string
+	for _, hotspot := range advisory.Hotspots {
+		advised = append(advised, hotspot.Key)
+	}
+	assert.Equal(t, []string{"key1", "key3"}, advised)
+	assert.Len(t, advisor.channels["channel-1"], 2)
+}
+
+func TestAdvisorSimulation(t *testing.T) {
+	advisor := &Advisor{}
+	advisor.Record("channel-1", Key{Namespace: "mycc", Key: "key1"}, "tx1")
+
+	txRWSet := &rwsetutil.TxRwSet{NsRwSets: []*rwsetutil.NsRwSet{
+		{NameSpace: "lscc", KvRwSet: &kvrwset.KVRWSet{Reads: []*kvrwset.KVRead{{Key: "mycc"}}}},
+		{NameSpace: "mycc", KvRwSet: &kvrwset.KVRWSet{
+			Reads:  []*kvrwset.KVRead{{Key: "key1"}, {Key: "key2"}},
+			Writes: []*kvrwset.KVWrite{{Key: "key1"}},
+		}},
+	}}
+	simulationResults, err := txRWSet.ToProtoBytes()
+	require.NoError(t, err)
+	advisory, err := advisor.AdviseSimulation("channel-1", simulationResults)
+	require.NoError(t, err)
+	assert.Equal(t, &peer.ConflictAdvisory{Hotspots: []*peer.ConflictHotspot{
+		{Namespace: "mycc", Key: "key1", Conflicts: 1, TxIds: []string{"tx1"}},
+	}}, advisory)
+
+	_, err = advisor.AdviseSimulation("channel-1", []byte("garbage"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package invalidation
+
+import (
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+var (
+	retainedReasons = metrics.GaugeOpts{
+		Namespace:    "committer",
+		Subsystem:    "invalidation",
+		Name:         "retained_reasons",
+		Help:         "The number of invalidation reasons retained.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	retainedBytes = metrics.GaugeOpts{
+		Namespace:    "committer",
+		Subsystem:    "invalidation",
+		Name:         "retained_bytes",
+		Help:         "The total size in bytes of the invalidation reasons retained.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	purgedReasons = metrics.CounterOpts{
+		Namespace:    "committer",
+		Subsystem:    "invalidation",
+		Name:         "purged_reasons",
+		Help:         "The number of invalidation reasons purged, by cause.",
+		LabelNames:   []string{"channel", "cause"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{cause}",
+	}
+)
+
+// Metrics are the metrics of the retention of the invalidation reasons.
+type Metrics struct {
+	RetainedReasons metrics.Gauge
+	RetainedBytes   metrics.Gauge
+	PurgedReasons   metrics.Counter
+}
+
+func NewMetrics(p metrics.Provider) *Metrics {
+	return &Metrics{
+		RetainedReasons: p.NewGauge(retainedReasons),
+		RetainedBytes:   p.NewGauge(retainedBytes),
+		PurgedReasons:   p.NewCounter(purgedReasons),
+	}
+}
+
+func (m *Metrics) retained(channel string, st *stats) {
+	if m == nil {
+		return
+	}
+	m.RetainedReasons.With("channel", channel).Set(float64(st.count))
+	m.RetainedBytes.With("channel", channel).Set(float64(st.size))
+}
+
+func (m *Metrics) purged(channel, cause string, n float64) {
+	if m == nil {
+		return
+	}
+	m.PurgedReasons.With("channel", channel, "cause", cause).Add(n)
+}
@@ -0,0 +1,180 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package invalidation
+
+import (
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	"github.com/pkg/errors"
+)
+
+//go:generate counterfeiter -o mock/ledger.go -fake-name Ledger . Ledger
+
+// Ledger is the part of the ledger of a channel the invalidation reasons are
+// completed from.
+type Ledger interface {
+	GetTransactionByID(txID string) (*peer.ProcessedTransaction, error)
+	GetBlockByTxID(txID string) (*common.Block, error)
+	GetBlockByNumber(blockNumber uint64) (*common.Block, error)
+	NewQueryExecutor() (ledger.QueryExecutor, error)
+	NewHistoryQueryExecutor() (ledger.HistoryQueryExecutor, error)
+}
+
+// Reason returns the reason the transaction was invalidated, assembled from
+// the ledger and from the reason retained in the store, if any. The store may
+// be nil, in which case only the validation code and the position of the
+// transaction are returned. Valid transactions are returned with the VALID code.
+func Reason(l Ledger, store *Store, txID string) (*peer.InvalidationReason, error) {
+	processedTx, err := l.GetTransactionByID(txID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed retrieving transaction")
+	}
+	block, err := l.GetBlockByTxID(txID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed retrieving block of transaction")
+	}
+	txNum, err := txNumber(block, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	code := peer.TxValidationCode(processedTx.ValidationCode)
+	reason := &peer.InvalidationReason{}
+	if store != nil && code != peer.TxValidationCode_VALID {
+		retained, err := store.Get(txID)
+		if err != nil {
+			return nil, err
+		}
+		if retained != nil {
+			reason = retained
+		}
+	}
+	reason.TxId = txID
+	reason.BlockNumber = block.Header.Number
+	reason.TxNumber = txNum
+	reason.ValidationCode = code
+
+	blocks := map[uint64]*common.Block{block.Header.Number: block}
+	for _, conflict := range reason.ReadConflicts {
+		if conflict.WriterTxId == "" && conflict.CommittedVersion != nil {
+			conflict.WriterTxId = writer(l, blocks, conflict.CommittedVersion.BlockNum, conflict.CommittedVersion.TxNum)
+		}
+		if conflict.Namespace != plain.Namespace {
+			continue
+		}
+		if input := plain.ParseSpentKey(conflict.Key); input != nil {
+			reason.SpentTokenInputs = append(reason.SpentTokenInputs, &peer.SpentTokenInput{
+				TxId:        input.TxId,
+				Index:       input.Index,
+				SpentByTxId: conflict.WriterTxId,
+			})
+		}
+	}
+
+	// the inputs of the token transactions are retained as is, and only
+	// reported if they are found spent
+	var spentInputs []*peer.SpentTokenInput
+	for _, input := range reason.SpentTokenInputs {
+		if input.SpentByTxId == "" {
+			spent, spender, err := spentBy(l, &token.InputId{TxId: input.TxId, Index: input.Index})
+			if err != nil {
+				return nil, err
+			}
+			if !spent {
+				continue
+			}
+			input.SpentByTxId = spender
+		}
+		spentInputs = append(spentInputs, input)
+	}
+	reason.SpentTokenInputs = spentInputs
+
+	return reason, nil
+}
+
+// txNumber returns the position of the first transaction of the block with the ID
+func txNumber(block *common.Block, txID string) (uint64, error) {
+	for i := range block.Data.Data {
+		if txIDAt(block, i) == txID {
+			return uint64(i), nil
+		}
+	}
+	return 0, errors.Errorf("transaction %s not found in block %d", txID, block.Header.Number)
+}
+
+// writer returns the ID of the transaction at the position in the block, or an
+// empty string if it cannot be retrieved
+func writer(l Ledger, blocks map[uint64]*common.Block, blockNum, txNum uint64) string {
+	block, ok := blocks[blockNum]
+	if !ok {
+		var err error
+		if block, err = l.GetBlockByNumber(blockNum); err != nil {
+			return ""
+		}
+		blocks[blockNum] = block
+	}
+	return txIDAt(block, int(txNum))
+}
+
+func txIDAt(block *common.Block, txNum int) string {
+	if txNum >= len(block.Data.Data) {
+		return ""
+	}
+	env, err := utils.GetEnvelopeFromBlock(block.Data.Data[txNum])
+	if err != nil {
+		return ""
+	}
+	chdr, err := utils.ChannelHeader(env)
+	if err != nil {
+		return ""
+	}
+	return chdr.TxId
+}
+
+// spentBy returns whether the token input is spent in the committed state and,
+// if the history database is enabled, the ID of the transaction that spent it
+func spentBy(l Ledger, input *token.InputId) (bool, string, error) {
+	key, err := plain.SpentKey(input)
+	if err != nil {
+		// such an input can never be spent
+		return false, "", nil
+	}
+	qe, err := l.NewQueryExecutor()
+	if err != nil {
+		return false, "", errors.WithMessage(err, "failed creating query executor")
+	}
+	marker, err := qe.GetState(plain.Namespace, key)
+	qe.Done()
+	if err != nil {
+		return false, "", errors.WithMessage(err, "failed retrieving token input")
+	}
+	if marker == nil {
+		return false, "", nil
+	}
+
+	hqe, err := l.NewHistoryQueryExecutor()
+	if err != nil {
+		return true, "", nil
+	}
+	itr, err := hqe.GetHistoryForKey(plain.Namespace, key)
+	if err != nil {
+		// the history database is disabled
+		return true, "", nil
+	}
+	defer itr.Close()
+	// the input is marked spent once, by the transaction spending it
+	res, err := itr.Next()
+	if err != nil || res == nil {
+		return true, "", nil
+	}
+	return true, res.(*queryresult.KeyModification).TxId, nil
+}
@@ -0,0 +1,197 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package invalidation_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/mock"
+	"github.com/hyperledger/fabric/core/committer/invalidation"
+	invalidationmock "github.com/hyperledger/fabric/core/committer/invalidation/mock"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBlock(number uint64, txIDs ...string) *common.Block {
+	block := common.NewBlock(number, nil)
+	for _, txID := range txIDs {
+		env := &common.Envelope{Payload: utils.MarshalOrPanic(&common.Payload{
+			Header: &common.Header{ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{TxId: txID})},
+		})}
+		block.Data.Data = append(block.Data.Data, utils.MarshalOrPanic(env))
+	}
+	return block
+}
+
+func newLedger(code peer.TxValidationCode, blocks ...*common.Block) *invalidationmock.Ledger {
+	l := &invalidationmock.Ledger{}
+	l.GetTransactionByIDReturns(&peer.ProcessedTransaction{ValidationCode: int32(code)}, nil)
+	l.GetBlockByTxIDReturns(blocks[len(blocks)-1], nil)
+	l.GetBlockByNumberStub = func(number uint64) (*common.Block, error) {
+		for _, block := range blocks {
+			if block.Header.Number == number {
+				return block, nil
+			}
+		}
+		return nil, errors.New("no such block")
+	}
+	l.NewQueryExecutorReturns(&mock.TxSimulator{}, nil)
+	l.NewHistoryQueryExecutorReturns(nil, errors.New("history database is disabled"))
+	return l
+}
+
+func TestReasonValidTransaction(t *testing.T) {
+	provider, cleanup := newProvider(t)
+	defer cleanup()
+	store := provider.OpenStore("channel-1")
+	require.NoError(t, store.Put(&peer.InvalidationReason{TxId: "tx1", Details: "stale"}))
+
+	reason, err := invalidation.Reason(newLedger(peer.TxValidationCode_VALID, newBlock(4, "tx0", "tx1")), store, "tx1")
+	require.NoError(t, err)
+	assert.Equal(t, &peer.InvalidationReason{TxId: "tx1", BlockNumber: 4, TxNumber: 1}, reason)
+}
+
+func TestReasonReadConflict(t *testing.T) {
+	provider, cleanup := newProvider(t)
+	defer cleanup()
+	store := provider.OpenStore("channel-1")
+
+	spentKey, err := plain.SpentKey(&token.InputId{TxId: "tx-token", Index: 1})
+	require.NoError(t, err)
+	require.NoError(t, store.Put(&peer.InvalidationReason{
+		TxId:           "tx2",
+		ValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT,
+		ReadConflicts: []*peer.ReadConflict{
+			{
+				Namespace:        "mycc",
+				Key:              "key1",
+				ReadVersion:      &kvrwset.Version{BlockNum: 1, TxNum: 0},
+				CommittedVersion: &kvrwset.Version{BlockNum: 3, TxNum: 1},
+			},
+			{
+				Namespace:        plain.Namespace,
+				Key:              spentKey,
+				CommittedVersion: &kvrwset.Version{BlockNum: 5, TxNum: 0},
+			},
+		},
+	}))
+
+	l := newLedger(peer.TxValidationCode_MVCC_READ_CONFLICT, newBlock(3, "tx0", "tx1"), newBlock(5, "tx-spend", "tx2"))
+	reason, err := invalidation.Reason(l, store, "tx2")
+	require.NoError(t, err)
+	assert.Equal(t, "tx2", reason.TxId)
+	assert.Equal(t, uint64(5), reason.BlockNumber)
+	assert.Equal(t, uint64(1), reason.TxNumber)
+	assert.Equal(t, peer.TxValidationCode_MVCC_READ_CONFLICT, reason.ValidationCode)
+	require.Len(t, reason.ReadConflicts, 2)
+	assert.Equal(t, "tx1", reason.ReadConflicts[0].WriterTxId)
+	assert.Equal(t, "tx-spend", reason.ReadConflicts[1].WriterTxId)
+	assert.Equal(t, []*peer.SpentTokenInput{{TxId: "tx-token", Index: 1, SpentByTxId: "tx-spend"}}, reason.SpentTokenInputs)
+	// only the block of the committed version not already retrieved is read
+	assert.Equal(t, 1, l.GetBlockByNumberCallCount())
+}
+
+func TestReasonSpentTokenInputs(t *testing.T) {
+	provider, cleanup := newProvider(t)
+	defer cleanup()
+	store := provider.OpenStore("channel-1")
+	require.NoError(t, store.Put(&peer.InvalidationReason{
+		TxId:             "tx0",
+		ValidationCode:   peer.TxValidationCode_INVALID_OTHER_REASON,
+		Details:          "verification: input already spent",
+		SpentTokenInputs: []*peer.SpentTokenInput{{TxId: "tx-spent", Index: 0}, {TxId: "tx-unspent", Index: 0}},
+	}))
+
+	qe := &mock.TxSimulator{}
+	qe.GetStateStub = func(namespace, key string) ([]byte, error) {
+		if input := plain.ParseSpentKey(key); input != nil && input.TxId == "tx-spent" {
+			return []byte{1}, nil
+		}
+		return nil, nil
+	}
+	itr := &mock.QueryResultsIterator{}
+	itr.NextReturns(&queryresult.KeyModification{TxId: "tx-spender"}, nil)
+	hqe := &mock.HistoryQueryExecutor{}
+	hqe.GetHistoryForKeyReturns(itr, nil)
+
+	l := newLedger(peer.TxValidationCode_INVALID_OTHER_REASON, newBlock(2, "tx0"))
+	l.NewQueryExecutorReturns(qe, nil)
+	l.NewHistoryQueryExecutorReturns(hqe, nil)
+	reason, err := invalidation.Reason(l, store, "tx0")
+	require.NoError(t, err)
+	assert.Equal(t, "verification: input already spent", reason.Details)
+	assert.Equal(t, []*peer.SpentTokenInput{{TxId: "tx-spent", Index: 0, SpentByTxId: "tx-spender"}}, reason.SpentTokenInputs)
+	assert.Equal(t, 2, qe.DoneCallCount())
+	assert.Equal(t, 1, itr.CloseCallCount())
+
+	// without the history database the spender is not known
+	l.NewHistoryQueryExecutorReturns(nil, errors.New("history database is disabled"))
+	reason, err = invalidation.Reason(l, store, "tx0")
+	require.NoError(t, err)
+	assert.Equal(t, []*peer.SpentTokenInput{{TxId: "tx-spent", Index: 0}}, reason.SpentTokenInputs)
+}
+
+func TestReasonWithoutStore(t *testing.T) {
+	reason, err := invalidation.Reason(newLedger(peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE, newBlock(7, "tx0")), nil, "tx0")
+	require.NoError(t, err)
+	assert.Equal(t, &peer.InvalidationReason{
+		TxId:           "tx0",
+		BlockNumber:    7,
+		ValidationCode: peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE,
+	}, reason)
+}
+
+func TestReasonErrors(t *testing.T) {
+	l := newLedger(peer.TxValidationCode_VALID, newBlock(1, "tx0"))
+	l.GetTransactionByIDReturns(nil, errors.New("no such transaction ID [tx1] in index"))
+	_, err := invalidation.Reason(l, nil, "tx1")
+	assert.EqualError(t, err, "failed retrieving transaction: no such transaction ID [tx1] in index")
+
+	l = newLedger(peer.TxValidationCode_VALID, newBlock(1, "tx0"))
+	l.GetBlockByTxIDReturns(nil, errors.New("boom"))
+	_, err = invalidation.Reason(l, nil, "tx0")
+	assert.EqualError(t, err, "failed retrieving block of transaction: boom")
+
+	l = newLedger(peer.TxValidationCode_VALID, newBlock(1, "tx0"))
+	_, err = invalidation.Reason(l, nil, "tx1")
+	assert.EqualError(t, err, "transaction tx1 not found in block 1")
+
+	provider, cleanup := newProvider(t)
+	defer cleanup()
+	store := provider.OpenStore("channel-1")
+	require.NoError(t, store.Put(&peer.InvalidationReason{
+		TxId:             "tx0",
+		SpentTokenInputs: []*peer.SpentTokenInput{{TxId: "tx-spent", Index: 0}},
+	}))
+	l = newLedger(peer.TxValidationCode_INVALID_OTHER_REASON, newBlock(1, "tx0"))
+	qe := &mock.TxSimulator{}
+	qe.GetStateReturns(nil, errors.New("boom"))
+	l.NewQueryExecutorReturns(qe, nil)
+	_, err = invalidation.Reason(l, store, "tx0")
+	assert.EqualError(t, err, "failed retrieving token input: boom")
+}
+
+func newProvider(t *testing.T) (*invalidation.Provider, func()) {
+	path, err := ioutil.TempDir("", "invalidation")
+	require.NoError(t, err)
+	provider := invalidation.NewProvider(path, invalidation.Retention{Period: time.Hour}, nil)
+	return provider, func() {
+		provider.Close()
+		os.RemoveAll(path)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package invalidation
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	"github.com/hyperledger/fabric/token/transaction"
+)
+
+// Recorder records the reasons transactions are invalidated in the stores of
+// the Provider, as reported by the transaction validator, by the ledger on
+// read conflicts and by the token transaction processor. Failing to record a
+// reason is logged; it does not fail the commit of its block.
+type Recorder struct {
+	Provider *Provider
+	Logger   *flogging.FabricLogger
+	// Advisor, when set, tracks the keys of the read conflicts and of the
+	// inputs of the token transactions failing verification.
+	Advisor *Advisor
+}
+
+// RecordInvalidation records a reason reported by the transaction validator.
+func (r *Recorder) RecordInvalidation(channelID string, reason *peer.InvalidationReason) {
+	r.put(channelID, reason)
+}
+
+// HandleReadConflicts records the read conflicts reported by the ledger, then
+// that the block is committed.
+func (r *Recorder) HandleReadConflicts(ledgerID string, blockNum uint64, conflicts []*ledger.TxReadConflict) {
+	for _, conflict := range conflicts {
+		// the keys of private data are hashed, and are not tracked
+		if r.Advisor != nil && conflict.Conflict.Key != "" && conflict.Conflict.Collection == "" {
+			r.Advisor.Record(ledgerID, Key{Namespace: conflict.Conflict.Namespace, Key: conflict.Conflict.Key}, conflict.TxID)
+		}
+		r.put(ledgerID, &peer.InvalidationReason{
+			TxId:           conflict.TxID,
+			BlockNumber:    blockNum,
+			TxNumber:       conflict.TxNum,
+			ValidationCode: conflict.ValidationCode,
+			ReadConflicts:  []*peer.ReadConflict{conflict.Conflict},
+		})
+	}
+	if err := r.Provider.OpenStore(ledgerID).Committed(blockNum); err != nil {
+		r.Logger.Errorf("failed purging the invalidation reasons of channel %s at block %d: %s", ledgerID, blockNum, err)
+	}
+}
+
+// RecordInvalidTx records a token transaction found invalid by the token
+// transaction processor, along with the inputs it spends, which the query of
+// the reason checks for having been spent already, and the Advisor tracks as
+// possibly conflicting. The transaction is attributed to the block being
+// committed.
+func (r *Recorder) RecordInvalidTx(ch *common.ChannelHeader, reason string, txEnv *common.Envelope, err error) {
+	invalidationReason := &peer.InvalidationReason{
+		TxId: ch.TxId,
+		// the ledger marks the transactions failing custom processing with this code
+		ValidationCode: peer.TxValidationCode_INVALID_OTHER_REASON,
+		Details:        fmt.Sprintf("%s: %s", reason, err),
+	}
+	if reason == transaction.ReasonVerification {
+		// the envelope is already known to hold a token transaction
+		if _, ttx, _, err := transaction.UnmarshalTokenTransaction(txEnv.Payload); err == nil {
+			for _, input := range plain.SpentInputs(ttx) {
+				invalidationReason.SpentTokenInputs = append(invalidationReason.SpentTokenInputs, &peer.SpentTokenInput{
+					TxId:  input.TxId,
+					Index: input.Index,
+				})
+				if spentKey, err := plain.SpentKey(input); err == nil && r.Advisor != nil {
+					r.Advisor.Record(ch.ChannelId, Key{Namespace: plain.Namespace, Key: spentKey}, ch.TxId)
+				}
+			}
+		}
+	}
+	r.put(ch.ChannelId, invalidationReason)
+}
+
+func (r *Recorder) put(channelID string, reason *peer.InvalidationReason) {
+	if err := r.Provider.OpenStore(channelID).Put(reason); err != nil {
+		r.Logger.Errorf("failed recording the invalidation reason of transaction %s of channel %s: %s", reason.TxId, channelID, err)
+	}
+}
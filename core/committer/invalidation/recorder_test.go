@@ -0,0 +1,125 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package invalidation_test
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/committer/invalidation"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	"github.com/hyperledger/fabric/token/transaction"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	provider, cleanup := newProvider(t)
+	defer cleanup()
+	recorder := &invalidation.Recorder{Provider: provider, Logger: flogging.MustGetLogger("test")}
+
+	recorder.RecordInvalidation("channel-1", &peer.InvalidationReason{
+		TxId:           "tx0",
+		ValidationCode: peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE,
+		EndorsementPolicyFailure: &peer.EndorsementPolicyFailure{
+			Namespace: "mycc",
+			Policy:    "OR('Org1MSP.member')",
+		},
+	})
+	reason, err := provider.OpenStore("channel-1").Get("tx0")
+	require.NoError(t, err)
+	assert.Equal(t, "mycc", reason.EndorsementPolicyFailure.Namespace)
+
+	conflict := &peer.ReadConflict{Namespace: "mycc", Key: "key1", CommittedVersion: &kvrwset.Version{BlockNum: 2}}
+	recorder.HandleReadConflicts("channel-1", 3, []*ledger.TxReadConflict{
+		{TxID: "tx1", TxNum: 1, ValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT, Conflict: conflict},
+	})
+	reason, err = provider.OpenStore("channel-1").Get("tx1")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), reason.BlockNumber)
+	assert.Equal(t, uint64(1), reason.TxNumber)
+	assert.Equal(t, peer.TxValidationCode_MVCC_READ_CONFLICT, reason.ValidationCode)
+	assert.Equal(t, []*peer.ReadConflict{conflict}, reason.ReadConflicts)
+}
+
+func TestRecorderAdvisor(t *testing.T) {
+	provider, cleanup := newProvider(t)
+	defer cleanup()
+	advisor := &invalidation.Advisor{}
+	recorder := &invalidation.Recorder{Provider: provider, Logger: flogging.MustGetLogger("test"), Advisor: advisor}
+
+	recorder.HandleReadConflicts("channel-1", 3, []*ledger.TxReadConflict{
+		{TxID: "tx1", ValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT, Conflict: &peer.ReadConflict{Namespace: "mycc", Key: "key1"}},
+		{TxID: "tx2", ValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT, Conflict: &peer.ReadConflict{Namespace: "mycc", Collection: "coll", KeyHash: []byte("hash")}},
+		{TxID: "tx3", ValidationCode: peer.TxValidationCode_PHANTOM_READ_CONFLICT, Conflict: &peer.ReadConflict{Namespace: "mycc", RangeStartKey: "a", RangeEndKey: "z"}},
+	})
+	assert.Equal(t, &peer.ConflictAdvisory{Hotspots: []*peer.ConflictHotspot{
+		{Namespace: "mycc", Key: "key1", Conflicts: 1, TxIds: []string{"tx1"}},
+	}}, advisor.Advise("channel-1", []invalidation.Key{{Namespace: "mycc", Key: "key1"}, {Namespace: "mycc"}}))
+
+	input := &token.InputId{TxId: "tx-token", Index: 0}
+	ttx := &token.TokenTransaction{Action: &token.TokenTransaction_PlainAction{PlainAction: &token.PlainTokenAction{
+		Data: &token.PlainTokenAction_PlainTransfer{PlainTransfer: &token.PlainTransfer{Inputs: []*token.InputId{input}}},
+	}}}
+	ch := &common.ChannelHeader{Type: int32(common.HeaderType_TOKEN_TRANSACTION), ChannelId: "channel-1", TxId: "tx4"}
+	txEnv := &common.Envelope{Payload: utils.MarshalOrPanic(&common.Payload{
+		Header: &common.Header{
+			ChannelHeader:   utils.MarshalOrPanic(ch),
+			SignatureHeader: utils.MarshalOrPanic(&common.SignatureHeader{Creator: []byte("creator")}),
+		},
+		Data: utils.MarshalOrPanic(ttx),
+	})}
+	recorder.RecordInvalidTx(ch, transaction.ReasonVerification, txEnv, errors.New("input already spent"))
+	spentKey, err := plain.SpentKey(input)
+	require.NoError(t, err)
+	assert.Equal(t, &peer.ConflictAdvisory{Hotspots: []*peer.ConflictHotspot{
+		{Namespace: plain.Namespace, Key: spentKey, Conflicts: 1, TxIds: []string{"tx4"}},
+	}}, advisor.Advise("channel-1", []invalidation.Key{{Namespace: plain.Namespace, Key: spentKey}}))
+}
+
+func TestRecorderRecordInvalidTx(t *testing.T) {
+	provider, cleanup := newProvider(t)
+	defer cleanup()
+	recorder := &invalidation.Recorder{Provider: provider, Logger: flogging.MustGetLogger("test")}
+
+	inputs := []*token.InputId{{TxId: "tx-token", Index: 0}}
+	ttx := &token.TokenTransaction{Action: &token.TokenTransaction_PlainAction{PlainAction: &token.PlainTokenAction{
+		Data: &token.PlainTokenAction_PlainTransfer{PlainTransfer: &token.PlainTransfer{Inputs: inputs}},
+	}}}
+	ch := &common.ChannelHeader{Type: int32(common.HeaderType_TOKEN_TRANSACTION), ChannelId: "channel-1", TxId: "tx0"}
+	txEnv := &common.Envelope{Payload: utils.MarshalOrPanic(&common.Payload{
+		Header: &common.Header{
+			ChannelHeader:   utils.MarshalOrPanic(ch),
+			SignatureHeader: utils.MarshalOrPanic(&common.SignatureHeader{Creator: []byte("creator")}),
+		},
+		Data: utils.MarshalOrPanic(ttx),
+	})}
+
+	// the transactions are attributed to the block after the last one committed
+	recorder.HandleReadConflicts("channel-1", 6, nil)
+	recorder.RecordInvalidTx(ch, transaction.ReasonVerification, txEnv, errors.New("input already spent"))
+	reason, err := provider.OpenStore("channel-1").Get("tx0")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), reason.BlockNumber)
+	assert.Equal(t, peer.TxValidationCode_INVALID_OTHER_REASON, reason.ValidationCode)
+	assert.Equal(t, "verification: input already spent", reason.Details)
+	assert.Equal(t, []*peer.SpentTokenInput{{TxId: "tx-token", Index: 0}}, reason.SpentTokenInputs)
+
+	ch.TxId = "tx1"
+	recorder.RecordInvalidTx(ch, transaction.ReasonBudget, txEnv, errors.New("budget exceeded"))
+	reason, err = provider.OpenStore("channel-1").Get("tx1")
+	require.NoError(t, err)
+	assert.Equal(t, "budget: budget exceeded", reason.Details)
+	assert.Empty(t, reason.SpentTokenInputs)
+}
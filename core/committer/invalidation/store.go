@@ -0,0 +1,364 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package invalidation
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+var (
+	txIDPrefix     = []byte{'t'}
+	recordedPrefix = []byte{'r'}
+	blockPrefix    = []byte{'b'}
+	heightKey      = []byte{'h'}
+	statsKey       = []byte{'s'}
+)
+
+// The causes the reasons are purged for
+const (
+	// PurgeExpired is the cause of the reasons retained longer than the retention period
+	PurgeExpired = "expired"
+	// PurgeBlocks is the cause of the reasons of the blocks past the retained blocks
+	PurgeBlocks = "blocks"
+	// PurgeSize is the cause of the reasons purged to bound the size of the store
+	PurgeSize = "size"
+)
+
+// Retention bounds the reasons retained by the store of each channel. The
+// reasons are purged as soon as any of the bounds is exceeded; a zero bound
+// does not apply.
+type Retention struct {
+	// Period is how long the reasons are retained after they are recorded
+	Period time.Duration
+	// Blocks is the number of most recent blocks the reasons are retained for
+	Blocks uint64
+	// MaxSize is the total size in bytes of the reasons retained; the oldest
+	// are purged beyond it
+	MaxSize uint64
+}
+
+// Provider provides the stores of the invalidation reasons of the channels,
+// kept in a single leveldb.
+type Provider struct {
+	Retention Retention
+	Metrics   *Metrics
+
+	dbProvider *leveldbhelper.Provider
+	mutex      sync.Mutex
+	stores     map[string]*Store
+}
+
+// NewProvider returns a provider of invalidation reason stores kept in the
+// leveldb at the path.
+func NewProvider(path string, retention Retention, metrics *Metrics) *Provider {
+	return &Provider{
+		Retention:  retention,
+		Metrics:    metrics,
+		dbProvider: leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: path}),
+		stores:     map[string]*Store{},
+	}
+}
+
+// OpenStore returns the invalidation reason store of the channel.
+func (p *Provider) OpenStore(channel string) *Store {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	s, ok := p.stores[channel]
+	if !ok {
+		s = &Store{
+			channel:   channel,
+			db:        p.dbProvider.GetDBHandle(channel),
+			retention: p.Retention,
+			metrics:   p.Metrics,
+			now:       time.Now,
+		}
+		if st, err := s.stats(); err == nil {
+			s.metrics.retained(channel, st)
+		}
+		p.stores[channel] = s
+	}
+	return s
+}
+
+// Close closes the leveldb of the provider.
+func (p *Provider) Close() {
+	p.dbProvider.Close()
+}
+
+// Store keeps the invalidation reasons of the transactions of a channel within
+// the bounds of the retention. The reasons are indexed by transaction ID, by
+// the time they are recorded and by the block of their transaction, so that
+// the ones out of bounds can be purged.
+type Store struct {
+	channel   string
+	db        *leveldbhelper.DBHandle
+	retention Retention
+	metrics   *Metrics
+	now       func() time.Time
+	mutex     sync.Mutex
+}
+
+// stats are the number and the total size of the reasons retained
+type stats struct {
+	count uint64
+	size  uint64
+}
+
+// entry locates a retained reason in the indexes
+type entry struct {
+	txID       string
+	blockNum   uint64
+	recordedAt uint64
+	size       uint64
+}
+
+// Put records the reason, unless a reason for the transaction is already
+// recorded, as happens when a block is committed again after a crash. The
+// reasons without a block number are attributed to the block being committed.
+// The reasons out of the bounds of the retention are purged.
+func (s *Store) Put(reason *peer.InvalidationReason) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	existing, err := s.db.Get(txIDKey(reason.TxId))
+	if err != nil {
+		return errors.Wrap(err, "failed reading invalidation reason")
+	}
+	if existing != nil {
+		return nil
+	}
+
+	now := s.now()
+	reason = proto.Clone(reason).(*peer.InvalidationReason)
+	if reason.RecordedAt, err = ptypes.TimestampProto(now); err != nil {
+		return errors.Wrap(err, "failed recording invalidation time")
+	}
+	if reason.BlockNumber == 0 {
+		if reason.BlockNumber, err = s.height(); err != nil {
+			return err
+		}
+	}
+	raw, err := proto.Marshal(reason)
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling invalidation reason")
+	}
+	if s.retention.MaxSize > 0 && uint64(len(raw)) > s.retention.MaxSize {
+		s.metrics.purged(s.channel, PurgeSize, 1)
+		return nil
+	}
+
+	st, err := s.stats()
+	if err != nil {
+		return err
+	}
+	batch := leveldbhelper.NewUpdateBatch()
+	e := &entry{txID: reason.TxId, blockNum: reason.BlockNumber, recordedAt: uint64(now.UnixNano()), size: uint64(len(raw))}
+	batch.Put(txIDKey(e.txID), raw)
+	batch.Put(recordedKey(e.recordedAt, e.txID), encodeUint64s(e.blockNum, e.size))
+	batch.Put(blockKey(e.blockNum, e.txID), encodeUint64s(e.recordedAt, e.size))
+	st.count++
+	st.size += e.size
+
+	p := &purge{store: s, batch: batch, stats: st, purged: map[string]bool{}}
+	if s.retention.Period > 0 {
+		if err := p.expired(now.Add(-s.retention.Period)); err != nil {
+			return err
+		}
+	}
+	if s.retention.MaxSize > 0 {
+		if err := p.oversized(); err != nil {
+			return err
+		}
+	}
+	return s.write(p)
+}
+
+// Committed records that the block is committed, and purges the reasons of the
+// blocks past the number of blocks retained.
+func (s *Store) Committed(blockNum uint64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	st, err := s.stats()
+	if err != nil {
+		return err
+	}
+	batch := leveldbhelper.NewUpdateBatch()
+	batch.Put(heightKey, encodeUint64s(blockNum+1))
+	p := &purge{store: s, batch: batch, stats: st, purged: map[string]bool{}}
+	if s.retention.Blocks > 0 && blockNum+1 > s.retention.Blocks {
+		if err := p.blocks(blockNum + 1 - s.retention.Blocks); err != nil {
+			return err
+		}
+	}
+	return s.write(p)
+}
+
+// Get returns the reason the transaction was invalidated, or nil if none is
+// retained.
+func (s *Store) Get(txID string) (*peer.InvalidationReason, error) {
+	raw, err := s.db.Get(txIDKey(txID))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed reading invalidation reason")
+	}
+	if raw == nil {
+		return nil, nil
+	}
+	reason := &peer.InvalidationReason{}
+	if err := proto.Unmarshal(raw, reason); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling invalidation reason")
+	}
+	if s.retention.Period > 0 {
+		// expired reasons are only purged on the next Put
+		recordedAt, err := ptypes.Timestamp(reason.RecordedAt)
+		if err == nil && recordedAt.Before(s.now().Add(-s.retention.Period)) {
+			return nil, nil
+		}
+	}
+	return reason, nil
+}
+
+func (s *Store) write(p *purge) error {
+	p.batch.Put(statsKey, encodeUint64s(p.stats.count, p.stats.size))
+	if err := s.db.WriteBatch(p.batch, true); err != nil {
+		return errors.Wrap(err, "failed writing invalidation reasons")
+	}
+	for cause, n := range p.causes {
+		s.metrics.purged(s.channel, cause, n)
+	}
+	s.metrics.retained(s.channel, p.stats)
+	return nil
+}
+
+// height returns the number of the block being committed, or zero if no block
+// is known to be committed yet
+func (s *Store) height() (uint64, error) {
+	raw, err := s.db.Get(heightKey)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed reading the height of the invalidation reasons")
+	}
+	if raw == nil {
+		return 0, nil
+	}
+	return binary.BigEndian.Uint64(raw), nil
+}
+
+func (s *Store) stats() (*stats, error) {
+	raw, err := s.db.Get(statsKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed reading the size of the invalidation reasons")
+	}
+	if raw == nil {
+		return &stats{}, nil
+	}
+	return &stats{count: binary.BigEndian.Uint64(raw), size: binary.BigEndian.Uint64(raw[8:])}, nil
+}
+
+// purge adds the deletion of the reasons out of the bounds of the retention to
+// a batch
+type purge struct {
+	store  *Store
+	batch  *leveldbhelper.UpdateBatch
+	stats  *stats
+	purged map[string]bool
+	causes map[string]float64
+}
+
+// expired purges the reasons recorded before the cutoff
+func (p *purge) expired(cutoff time.Time) error {
+	itr := p.store.db.GetIterator(recordedPrefix, recordedKey(uint64(cutoff.UnixNano()), ""))
+	defer itr.Release()
+	for itr.Next() {
+		p.delete(recordedEntry(itr.Key(), itr.Value()), PurgeExpired)
+	}
+	return errors.Wrap(itr.Error(), "failed iterating invalidation reasons")
+}
+
+// blocks purges the reasons of the blocks before the cutoff
+func (p *purge) blocks(cutoff uint64) error {
+	itr := p.store.db.GetIterator(blockPrefix, blockKey(cutoff, ""))
+	defer itr.Release()
+	for itr.Next() {
+		p.delete(blockEntry(itr.Key(), itr.Value()), PurgeBlocks)
+	}
+	return errors.Wrap(itr.Error(), "failed iterating invalidation reasons")
+}
+
+// oversized purges the oldest reasons until their total size is within bounds
+func (p *purge) oversized() error {
+	itr := p.store.db.GetIterator(recordedPrefix, []byte{recordedPrefix[0] + 1})
+	defer itr.Release()
+	for p.stats.size > p.store.retention.MaxSize && itr.Next() {
+		p.delete(recordedEntry(itr.Key(), itr.Value()), PurgeSize)
+	}
+	return errors.Wrap(itr.Error(), "failed iterating invalidation reasons")
+}
+
+func (p *purge) delete(e *entry, cause string) {
+	if p.purged[e.txID] {
+		return
+	}
+	p.purged[e.txID] = true
+	p.batch.Delete(txIDKey(e.txID))
+	p.batch.Delete(recordedKey(e.recordedAt, e.txID))
+	p.batch.Delete(blockKey(e.blockNum, e.txID))
+	p.stats.count--
+	p.stats.size -= e.size
+	if p.causes == nil {
+		p.causes = map[string]float64{}
+	}
+	p.causes[cause]++
+}
+
+func recordedEntry(key, value []byte) *entry {
+	key = key[len(recordedPrefix):]
+	return &entry{
+		txID:       string(key[8:]),
+		recordedAt: binary.BigEndian.Uint64(key),
+		blockNum:   binary.BigEndian.Uint64(value),
+		size:       binary.BigEndian.Uint64(value[8:]),
+	}
+}
+
+func blockEntry(key, value []byte) *entry {
+	key = key[len(blockPrefix):]
+	return &entry{
+		txID:       string(key[8:]),
+		blockNum:   binary.BigEndian.Uint64(key),
+		recordedAt: binary.BigEndian.Uint64(value),
+		size:       binary.BigEndian.Uint64(value[8:]),
+	}
+}
+
+func encodeUint64s(values ...uint64) []byte {
+	b := make([]byte, 8*len(values))
+	for i, v := range values {
+		binary.BigEndian.PutUint64(b[8*i:], v)
+	}
+	return b
+}
+
+func txIDKey(txID string) []byte {
+	return append(append([]byte{}, txIDPrefix...), txID...)
+}
+
+func recordedKey(recordedAt uint64, txID string) []byte {
+	return append(append(append([]byte{}, recordedPrefix...), encodeUint64s(recordedAt)...), txID...)
+}
+
+func blockKey(blockNum uint64, txID string) []byte {
+	return append(append(append([]byte{}, blockPrefix...), encodeUint64s(blockNum)...), txID...)
+}
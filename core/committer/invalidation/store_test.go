@@ -0,0 +1,216 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package invalidation
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProvider(t *testing.T, retention Retention) (*Provider, func()) {
+	path, err := ioutil.TempDir("", "invalidation")
+	require.NoError(t, err)
+	provider := NewProvider(path, retention, nil)
+	return provider, func() {
+		provider.Close()
+		os.RemoveAll(path)
+	}
+}
+
+// retainedTxIDs returns the IDs of the transactions of the reasons retained,
+// oldest first, checking that the indexes are consistent
+func retainedTxIDs(t *testing.T, s *Store) []string {
+	var recorded, blocks []string
+	itr := s.db.GetIterator(recordedPrefix, []byte{'r' + 1})
+	for itr.Next() {
+		recorded = append(recorded, recordedEntry(itr.Key(), itr.Value()).txID)
+	}
+	itr.Release()
+	itr = s.db.GetIterator(blockPrefix, []byte{'b' + 1})
+	for itr.Next() {
+		blocks = append(blocks, blockEntry(itr.Key(), itr.Value()).txID)
+	}
+	itr.Release()
+	assert.ElementsMatch(t, recorded, blocks)
+	for _, txID := range recorded {
+		raw, err := s.db.Get(txIDKey(txID))
+		require.NoError(t, err)
+		assert.NotNil(t, raw)
+	}
+	st, err := s.stats()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(len(recorded)), st.count)
+	return recorded
+}
+
+func TestStore(t *testing.T) {
+	provider, cleanup := newTestProvider(t, Retention{Period: time.Hour})
+	defer cleanup()
+
+	store := provider.OpenStore("channel-1")
+	assert.True(t, store == provider.OpenStore("channel-1"))
+	now := time.Date(2018, 11, 1, 10, 30, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	reason := &peer.InvalidationReason{TxId: "tx0", BlockNumber: 3, ValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT}
+	require.NoError(t, store.Put(reason))
+	// a transaction committed again is recorded once
+	require.NoError(t, store.Put(&peer.InvalidationReason{TxId: "tx0", ValidationCode: peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE}))
+
+	retained, err := store.Get("tx0")
+	require.NoError(t, err)
+	recordedAt, err := ptypes.TimestampProto(now)
+	require.NoError(t, err)
+	assert.Equal(t, &peer.InvalidationReason{
+		TxId:           "tx0",
+		BlockNumber:    3,
+		ValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT,
+		RecordedAt:     recordedAt,
+	}, retained)
+	// the reason passed to Put is left as is
+	assert.Nil(t, reason.RecordedAt)
+
+	retained, err = store.Get("tx1")
+	require.NoError(t, err)
+	assert.Nil(t, retained)
+	retained, err = provider.OpenStore("channel-2").Get("tx0")
+	require.NoError(t, err)
+	assert.Nil(t, retained)
+
+	// expired reasons are not returned, and are purged by the next Put
+	now = now.Add(time.Hour + time.Second)
+	retained, err = store.Get("tx0")
+	require.NoError(t, err)
+	assert.Nil(t, retained)
+	require.NoError(t, store.Put(&peer.InvalidationReason{TxId: "tx1", BlockNumber: 4}))
+	assert.Equal(t, []string{"tx1"}, retainedTxIDs(t, store))
+	retained, err = store.Get("tx1")
+	require.NoError(t, err)
+	assert.NotNil(t, retained)
+}
+
+func TestStoreIndefiniteRetention(t *testing.T) {
+	provider, cleanup := newTestProvider(t, Retention{})
+	defer cleanup()
+
+	store := provider.OpenStore("channel-1")
+	now := time.Date(2018, 11, 1, 10, 30, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	require.NoError(t, store.Put(&peer.InvalidationReason{TxId: "tx0"}))
+
+	now = now.AddDate(10, 0, 0)
+	for i := uint64(0); i < 100; i++ {
+		require.NoError(t, store.Committed(i))
+	}
+	require.NoError(t, store.Put(&peer.InvalidationReason{TxId: "tx1"}))
+	retained, err := store.Get("tx0")
+	require.NoError(t, err)
+	assert.Equal(t, "tx0", retained.TxId)
+	assert.Equal(t, []string{"tx0", "tx1"}, retainedTxIDs(t, store))
+}
+
+func TestStoreRetainedBlocks(t *testing.T) {
+	provider, cleanup := newTestProvider(t, Retention{Blocks: 2})
+	defer cleanup()
+	store := provider.OpenStore("channel-1")
+
+	// the reasons without a block number are attributed to the block being committed
+	require.NoError(t, store.Committed(4))
+	require.NoError(t, store.Put(&peer.InvalidationReason{TxId: "tx5"}))
+	retained, err := store.Get("tx5")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), retained.BlockNumber)
+	require.NoError(t, store.Put(&peer.InvalidationReason{TxId: "tx5-1", BlockNumber: 5}))
+	require.NoError(t, store.Committed(5))
+	require.NoError(t, store.Put(&peer.InvalidationReason{TxId: "tx6", BlockNumber: 6}))
+	require.NoError(t, store.Committed(6))
+	assert.Equal(t, []string{"tx5", "tx5-1", "tx6"}, retainedTxIDs(t, store))
+
+	// the reasons of block 5 are purged once block 7 is committed
+	require.NoError(t, store.Committed(7))
+	assert.Equal(t, []string{"tx6"}, retainedTxIDs(t, store))
+	retained, err = store.Get("tx5")
+	require.NoError(t, err)
+	assert.Nil(t, retained)
+
+	// the height is retained across restarts
+	store.db = nil
+	delete(provider.stores, "channel-1")
+	store = provider.OpenStore("channel-1")
+	require.NoError(t, store.Put(&peer.InvalidationReason{TxId: "tx8"}))
+	retained, err = store.Get("tx8")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(8), retained.BlockNumber)
+}
+
+func TestStoreMaxSize(t *testing.T) {
+	reasonSize := func(txID string) uint64 {
+		reason := &peer.InvalidationReason{TxId: txID, BlockNumber: 1, Details: strings.Repeat("x", 100)}
+		reason.RecordedAt, _ = ptypes.TimestampProto(time.Date(2018, 11, 1, 10, 30, 1, 0, time.UTC))
+		return uint64(proto.Size(reason))
+	}
+	provider, cleanup := newTestProvider(t, Retention{MaxSize: 2 * reasonSize("tx0")})
+	defer cleanup()
+	store := provider.OpenStore("channel-1")
+	now := time.Date(2018, 11, 1, 10, 30, 0, 0, time.UTC)
+	store.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	for _, txID := range []string{"tx0", "tx1", "tx2"} {
+		require.NoError(t, store.Put(&peer.InvalidationReason{TxId: txID, BlockNumber: 1, Details: strings.Repeat("x", 100)}))
+	}
+	assert.Equal(t, []string{"tx1", "tx2"}, retainedTxIDs(t, store))
+	st, err := store.stats()
+	require.NoError(t, err)
+	assert.Equal(t, 2*reasonSize("tx0"), st.size)
+
+	// a reason larger than the bound is not recorded
+	require.NoError(t, store.Put(&peer.InvalidationReason{TxId: "tx3", BlockNumber: 1, Details: strings.Repeat("x", 1000)}))
+	assert.Equal(t, []string{"tx1", "tx2"}, retainedTxIDs(t, store))
+}
+
+func TestStoreMetrics(t *testing.T) {
+	retainedReasons := &metricsfakes.Gauge{}
+	retainedReasons.WithReturns(retainedReasons)
+	retainedBytes := &metricsfakes.Gauge{}
+	retainedBytes.WithReturns(retainedBytes)
+	purgedReasons := &metricsfakes.Counter{}
+	purgedReasons.WithReturns(purgedReasons)
+
+	provider, cleanup := newTestProvider(t, Retention{Blocks: 1})
+	defer cleanup()
+	provider.Metrics = &Metrics{RetainedReasons: retainedReasons, RetainedBytes: retainedBytes, PurgedReasons: purgedReasons}
+
+	store := provider.OpenStore("channel-1")
+	require.Equal(t, 1, retainedReasons.SetCallCount())
+	assert.Equal(t, []string{"channel", "channel-1"}, retainedReasons.WithArgsForCall(0))
+	assert.Equal(t, float64(0), retainedReasons.SetArgsForCall(0))
+
+	require.NoError(t, store.Put(&peer.InvalidationReason{TxId: "tx0", BlockNumber: 1}))
+	require.NoError(t, store.Put(&peer.InvalidationReason{TxId: "tx1", BlockNumber: 1}))
+	assert.Equal(t, float64(2), retainedReasons.SetArgsForCall(2))
+	assert.NotZero(t, retainedBytes.SetArgsForCall(2))
+
+	require.NoError(t, store.Committed(2))
+	assert.Equal(t, float64(0), retainedReasons.SetArgsForCall(3))
+	assert.Equal(t, float64(0), retainedBytes.SetArgsForCall(3))
+	require.Equal(t, 1, purgedReasons.AddCallCount())
+	assert.Equal(t, []string{"channel", "channel-1", "cause", PurgeBlocks}, purgedReasons.WithArgsForCall(0))
+	assert.Equal(t, float64(2), purgedReasons.AddArgsForCall(0))
+}
@@ -0,0 +1,143 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package txvalidator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	commonerrors "github.com/hyperledger/fabric/common/errors"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// InvalidationRecorder records the reasons transactions were invalidated by the validator
+type InvalidationRecorder interface {
+	RecordInvalidation(channelID string, reason *peer.InvalidationReason)
+}
+
+// endorsementPolicyError marks an endorsement policy failure with the namespace
+// and the policy that the endorsements of the transaction did not satisfy
+type endorsementPolicyError struct {
+	*commonerrors.VSCCEndorsementPolicyError
+	namespace string
+	policy    []byte
+}
+
+// invalidationReason returns the reason a transaction was invalidated by vscc,
+// or nil if the reasons are not recorded
+func (v *TxValidator) invalidationReason(txID string, code peer.TxValidationCode, err error, payload *common.Payload) *peer.InvalidationReason {
+	if v.InvalidationRecorder == nil {
+		return nil
+	}
+	reason := &peer.InvalidationReason{
+		TxId:           txID,
+		ValidationCode: code,
+		Details:        err.Error(),
+	}
+	if policyErr, ok := err.(*endorsementPolicyError); ok {
+		reason.EndorsementPolicyFailure = policyFailure(policyErr, payload, v.Support.MSPManager())
+	}
+	return reason
+}
+
+// policyFailure describes the endorsement policy that the endorsements of the
+// transaction in payload did not satisfy
+func policyFailure(policyErr *endorsementPolicyError, payload *common.Payload, mspManager msp.MSPManager) *peer.EndorsementPolicyFailure {
+	failure := &peer.EndorsementPolicyFailure{Namespace: policyErr.namespace}
+
+	var endorsers []msp.Identity
+	for _, endorsement := range endorsements(payload) {
+		sID := &mspprotos.SerializedIdentity{}
+		if err := proto.Unmarshal(endorsement.Endorser, sID); err != nil {
+			continue
+		}
+		failure.Endorsers = append(failure.Endorsers, sID.Mspid)
+		if mspManager == nil {
+			continue
+		}
+		if id, err := mspManager.DeserializeIdentity(endorsement.Endorser); err == nil {
+			endorsers = append(endorsers, id)
+		}
+	}
+
+	envelope := &common.SignaturePolicyEnvelope{}
+	if err := proto.Unmarshal(policyErr.policy, envelope); err != nil || envelope.Rule == nil {
+		// the policy is not a signature policy, so it cannot be described
+		return failure
+	}
+	failure.Policy = policyString(envelope.Rule, envelope.Identities)
+	for _, principal := range envelope.Identities {
+		satisfied := false
+		for _, id := range endorsers {
+			if id.SatisfiesPrincipal(principal) == nil {
+				satisfied = true
+				break
+			}
+		}
+		if !satisfied {
+			failure.UnsatisfiedPrincipals = append(failure.UnsatisfiedPrincipals, principalString(principal))
+		}
+	}
+	return failure
+}
+
+// endorsements returns the endorsements of the chaincode action of an endorser transaction
+func endorsements(payload *common.Payload) []*peer.Endorsement {
+	tx, err := utils.GetTransaction(payload.Data)
+	if err != nil || len(tx.Actions) == 0 {
+		return nil
+	}
+	ccActionPayload, err := utils.GetChaincodeActionPayload(tx.Actions[0].Payload)
+	if err != nil || ccActionPayload.Action == nil {
+		return nil
+	}
+	return ccActionPayload.Action.Endorsements
+}
+
+// policyString renders a signature policy in the syntax of the policy
+// expressions of the peer CLI, such as OR('Org1MSP.member', 'Org2MSP.member')
+func policyString(policy *common.SignaturePolicy, identities []*mspprotos.MSPPrincipal) string {
+	switch t := policy.Type.(type) {
+	case *common.SignaturePolicy_SignedBy:
+		if t.SignedBy < 0 || int(t.SignedBy) >= len(identities) {
+			return fmt.Sprintf("SignedBy(%d)", t.SignedBy)
+		}
+		return principalString(identities[t.SignedBy])
+	case *common.SignaturePolicy_NOutOf_:
+		var rules []string
+		for _, rule := range t.NOutOf.Rules {
+			rules = append(rules, policyString(rule, identities))
+		}
+		switch int(t.NOutOf.N) {
+		case 1:
+			return fmt.Sprintf("OR(%s)", strings.Join(rules, ", "))
+		case len(rules):
+			return fmt.Sprintf("AND(%s)", strings.Join(rules, ", "))
+		default:
+			return fmt.Sprintf("OutOf(%d, %s)", t.NOutOf.N, strings.Join(rules, ", "))
+		}
+	default:
+		return ""
+	}
+}
+
+// principalString renders a principal as 'MSPID.role', or as its
+// classification if the principal is not a role
+func principalString(principal *mspprotos.MSPPrincipal) string {
+	if principal.PrincipalClassification == mspprotos.MSPPrincipal_ROLE {
+		role := &mspprotos.MSPRole{}
+		if err := proto.Unmarshal(principal.Principal, role); err == nil {
+			return fmt.Sprintf("'%s.%s'", role.MspIdentifier, strings.ToLower(role.Role.String()))
+		}
+	}
+	return principal.PrincipalClassification.String()
+}
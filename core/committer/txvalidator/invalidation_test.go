@@ -0,0 +1,80 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package txvalidator
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	commonerrors "github.com/hyperledger/fabric/common/errors"
+	"github.com/hyperledger/fabric/discovery/support/mocks"
+	"github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyString(t *testing.T) {
+	for _, policy := range []string{
+		"OR('Org1MSP.member', 'Org2MSP.member')",
+		"AND('Org1MSP.admin', OR('Org2MSP.peer', 'Org3MSP.client'))",
+		"OutOf(2, 'Org1MSP.member', 'Org2MSP.member', 'Org3MSP.member')",
+	} {
+		envelope, err := cauthdsl.FromString(policy)
+		assert.NoError(t, err)
+		assert.Equal(t, policy, policyString(envelope.Rule, envelope.Identities))
+	}
+
+	principal := &mspprotos.MSPPrincipal{PrincipalClassification: mspprotos.MSPPrincipal_IDENTITY}
+	assert.Equal(t, "IDENTITY", principalString(principal))
+	assert.Equal(t, "SignedBy(1)", policyString(cauthdsl.SignedBy(1), []*mspprotos.MSPPrincipal{principal}))
+}
+
+func TestPolicyFailure(t *testing.T) {
+	envelope, err := cauthdsl.FromString("AND('Org1MSP.member', 'Org2MSP.member')")
+	assert.NoError(t, err)
+	endorser := utils.MarshalOrPanic(&mspprotos.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("endorser")})
+	payload := &common.Payload{Data: utils.MarshalOrPanic(&peer.Transaction{
+		Actions: []*peer.TransactionAction{{Payload: utils.MarshalOrPanic(&peer.ChaincodeActionPayload{
+			Action: &peer.ChaincodeEndorsedAction{Endorsements: []*peer.Endorsement{{Endorser: endorser}}},
+		})}},
+	})}
+
+	id := &mocks.Identity{}
+	id.SatisfiesPrincipalStub = func(principal *mspprotos.MSPPrincipal) error {
+		role := &mspprotos.MSPRole{}
+		proto.Unmarshal(principal.Principal, role)
+		if role.MspIdentifier != "Org1MSP" {
+			return errors.New("principal not satisfied")
+		}
+		return nil
+	}
+	mspManager := &mocks.MSPManager{}
+	mspManager.DeserializeIdentityReturns(id, nil)
+
+	policyErr := &endorsementPolicyError{
+		VSCCEndorsementPolicyError: &commonerrors.VSCCEndorsementPolicyError{Err: errors.New("policy not satisfied")},
+		namespace:                  "mycc",
+		policy:                     utils.MarshalOrPanic(envelope),
+	}
+	assert.Equal(t, &peer.EndorsementPolicyFailure{
+		Namespace:             "mycc",
+		Policy:                "AND('Org1MSP.member', 'Org2MSP.member')",
+		Endorsers:             []string{"Org1MSP"},
+		UnsatisfiedPrincipals: []string{"'Org2MSP.member'"},
+	}, policyFailure(policyErr, payload, mspManager))
+
+	// policies that are not signature policies are not described
+	policyErr.policy = []byte("not a policy")
+	assert.Equal(t, &peer.EndorsementPolicyFailure{
+		Namespace: "mycc",
+		Endorsers: []string{"Org1MSP"},
+	}, policyFailure(policyErr, payload, mspManager))
+}
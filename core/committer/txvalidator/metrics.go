@@ -0,0 +1,41 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package txvalidator
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+var blockValidationTimeOpts = metrics.HistogramOpts{
+	Namespace:    "committer",
+	Subsystem:    "",
+	Name:         "block_validation_time",
+	Help:         "Time taken in seconds for validating the transactions of a block.",
+	LabelNames:   []string{"channel"},
+	StatsdFormat: "%{#fqname}.%{channel}",
+	Buckets:      []float64{0.005, 0.01, 0.015, 0.05, 0.1, 1, 10},
+}
+
+// Metrics are the metrics of the validation of blocks.
+type Metrics struct {
+	BlockValidationTime metrics.Histogram
+}
+
+func NewMetrics(p metrics.Provider) *Metrics {
+	return &Metrics{
+		BlockValidationTime: p.NewHistogram(blockValidationTimeOpts),
+	}
+}
+
+func (m *Metrics) updateBlockValidationTime(channel string, timeTaken time.Duration) {
+	if m == nil {
+		return
+	}
+	m.BlockValidationTime.With("channel", channel).Observe(timeTaken.Seconds())
+}
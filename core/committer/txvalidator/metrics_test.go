@@ -0,0 +1,34 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package txvalidator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	fakeHist := &metricsfakes.Histogram{}
+	fakeHist.WithReturns(fakeHist)
+	fakeProvider := &metricsfakes.Provider{}
+	fakeProvider.NewHistogramReturns(fakeHist)
+
+	m := NewMetrics(fakeProvider)
+	assert.Equal(t, blockValidationTimeOpts, fakeProvider.NewHistogramArgsForCall(0))
+
+	m.updateBlockValidationTime("mychannel", 2*time.Second)
+	assert.Equal(t, []string{"channel", "mychannel"}, fakeHist.WithArgsForCall(0))
+	assert.Equal(t, float64(2), fakeHist.ObserveArgsForCall(0))
+
+	// nil metrics record nothing
+	var disabled *Metrics
+	disabled.updateBlockValidationTime("mychannel", time.Second)
+	assert.Equal(t, 1, fakeHist.ObserveCallCount())
+}
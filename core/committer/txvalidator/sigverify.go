@@ -0,0 +1,169 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package txvalidator
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// A SignatureVerifier verifies the signatures of the transactions of a block
+// upfront, on a pool of workers, before the transactions are validated. The
+// MSPs remember the valid signatures, so that the validation of the creators
+// and of the endorsements of the transactions does not verify them again;
+// blocks with few transactions but many endorsements, or with expensive
+// signatures, are then verified on all the workers instead of the ones
+// validating their transactions.
+type SignatureVerifier struct {
+	// Workers is the number of goroutines verifying signatures; zero disables the verifier
+	Workers int
+	// BatchSize is the number of signatures a worker verifies at once; when
+	// zero, the signatures are spread evenly among the workers. ECDSA
+	// signatures have no batch verification, so the signatures of a batch are
+	// verified one by one, sharing the deserialization of their signers.
+	BatchSize int
+}
+
+func (s *SignatureVerifier) enabled() bool {
+	return s != nil && s.Workers > 0
+}
+
+// signedData is a signature of a transaction
+type signedData struct {
+	identity  []byte
+	data      []byte
+	signature []byte
+}
+
+// verifyBlock verifies the signatures of the transactions of the block with
+// the identities of the deserializer. Invalid signatures are not reported:
+// they are verified again, and reported, by the validation of their
+// transactions.
+func (s *SignatureVerifier) verifyBlock(block *common.Block, deserializer msp.IdentityDeserializer) int {
+	signatures := blockSignatures(block)
+	if len(signatures) == 0 {
+		return 0
+	}
+	// the signatures of an identity are batched together
+	sort.SliceStable(signatures, func(i, j int) bool {
+		return string(signatures[i].identity) < string(signatures[j].identity)
+	})
+
+	batchSize := s.BatchSize
+	if batchSize <= 0 {
+		batchSize = (len(signatures) + s.Workers - 1) / s.Workers
+	}
+	batches := make(chan []*signedData, (len(signatures)+batchSize-1)/batchSize)
+	for start := 0; start < len(signatures); start += batchSize {
+		end := start + batchSize
+		if end > len(signatures) {
+			end = len(signatures)
+		}
+		batches <- signatures[start:end]
+	}
+	close(batches)
+
+	workers := s.Workers
+	if workers > len(batches) {
+		workers = len(batches)
+	}
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				verifyBatch(batch, deserializer)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return len(signatures)
+}
+
+func verifyBatch(batch []*signedData, deserializer msp.IdentityDeserializer) {
+	var identity msp.Identity
+	var serializedIdentity []byte
+	for _, sd := range batch {
+		if identity == nil || string(sd.identity) != string(serializedIdentity) {
+			var err error
+			serializedIdentity = sd.identity
+			identity, err = deserializer.DeserializeIdentity(sd.identity)
+			if err != nil {
+				logger.Debugf("Failed deserializing signer upfront: %s", err)
+				identity = nil
+				continue
+			}
+		}
+		if err := identity.Verify(sd.data, sd.signature); err != nil {
+			logger.Debugf("Invalid signature of %s found upfront: %s", identity.GetIdentifier(), err)
+		}
+	}
+}
+
+// blockSignatures returns the signatures of the creators of the transactions
+// of the block, and of the endorsements of the endorser transactions, as they
+// are verified by their validation.
+func blockSignatures(block *common.Block) []*signedData {
+	var signatures []*signedData
+	seen := make(map[string]struct{})
+	add := func(sd *signedData) {
+		key := string(sd.signature) + string(sd.identity)
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+		signatures = append(signatures, sd)
+	}
+
+	for _, d := range block.Data.Data {
+		env, err := utils.GetEnvelopeFromBlock(d)
+		if err != nil || env == nil {
+			continue
+		}
+		payload, err := utils.UnmarshalPayload(env.Payload)
+		if err != nil || payload.Header == nil {
+			continue
+		}
+		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil || chdr.Type == int32(common.HeaderType_CONFIG) {
+			// config transactions are alone in their block
+			continue
+		}
+		shdr, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+		if err != nil {
+			continue
+		}
+		add(&signedData{identity: shdr.Creator, data: env.Payload, signature: env.Signature})
+
+		if chdr.Type != int32(common.HeaderType_ENDORSER_TRANSACTION) {
+			continue
+		}
+		tx, err := utils.GetTransaction(payload.Data)
+		if err != nil {
+			continue
+		}
+		for _, action := range tx.Actions {
+			actionPayload, err := utils.GetChaincodeActionPayload(action.Payload)
+			if err != nil || actionPayload.Action == nil {
+				continue
+			}
+			for _, endorsement := range actionPayload.Action.Endorsements {
+				data := make([]byte, len(actionPayload.Action.ProposalResponsePayload)+len(endorsement.Endorser))
+				copy(data, actionPayload.Action.ProposalResponsePayload)
+				copy(data[len(actionPayload.Action.ProposalResponsePayload):], endorsement.Endorser)
+				add(&signedData{identity: endorsement.Endorser, data: data, signature: endorsement.Signature})
+			}
+		}
+	}
+	return signatures
+}
@@ -0,0 +1,154 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package txvalidator
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/configtx/test"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	ledgerUtil "github.com/hyperledger/fabric/core/ledger/util"
+	mocktxvalidator "github.com/hyperledger/fabric/core/mocks/txvalidator"
+	"github.com/hyperledger/fabric/msp"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	ptestutils "github.com/hyperledger/fabric/protos/testutils"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sync/semaphore"
+)
+
+// countingDeserializer counts the verifications of the identities it deserializes
+type countingDeserializer struct {
+	msp.IdentityDeserializer
+	lock     sync.Mutex
+	verified int
+	failed   int
+}
+
+func (d *countingDeserializer) DeserializeIdentity(serializedIdentity []byte) (msp.Identity, error) {
+	id, err := d.IdentityDeserializer.DeserializeIdentity(serializedIdentity)
+	if err != nil {
+		return nil, err
+	}
+	return &countingIdentity{Identity: id, deserializer: d}, nil
+}
+
+type countingIdentity struct {
+	msp.Identity
+	deserializer *countingDeserializer
+}
+
+func (id *countingIdentity) Verify(msg []byte, sig []byte) error {
+	err := id.Identity.Verify(msg, sig)
+	id.deserializer.lock.Lock()
+	defer id.deserializer.lock.Unlock()
+	if err != nil {
+		id.deserializer.failed++
+	} else {
+		id.deserializer.verified++
+	}
+	return err
+}
+
+func TestSignatureVerifier(t *testing.T) {
+	signer := mspmgmt.GetLocalSigningIdentityOrPanic()
+	ccid := &peer.ChaincodeID{Name: "foo", Version: "v1"}
+
+	newBlock := func(n int) *common.Block {
+		var envs []*common.Envelope
+		for i := 0; i < n; i++ {
+			env, _, err := ptestutils.ConstructSignedTxEnv(util.GetTestChainID(), ccid, nil, []byte("results"), "", nil, nil, signer)
+			assert.NoError(t, err)
+			envs = append(envs, env)
+		}
+		return testutil.NewBlock(envs, 1, []byte("previous"))
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		var nilVerifier *SignatureVerifier
+		assert.False(t, nilVerifier.enabled())
+		assert.False(t, (&SignatureVerifier{}).enabled())
+		assert.True(t, (&SignatureVerifier{Workers: 1}).enabled())
+	})
+
+	t.Run("collects the creator and endorsement signatures", func(t *testing.T) {
+		block := newBlock(3)
+		signatures := blockSignatures(block)
+		// a creator and an endorsement per transaction
+		assert.Len(t, signatures, 6)
+
+		// duplicated transactions are verified once
+		block.Data.Data = append(block.Data.Data, block.Data.Data[0])
+		assert.Len(t, blockSignatures(block), 6)
+
+		// malformed transactions are skipped
+		block.Data.Data = append(block.Data.Data, []byte("garbage"))
+		assert.Len(t, blockSignatures(block), 6)
+	})
+
+	for _, verifier := range []*SignatureVerifier{
+		{Workers: 1},
+		{Workers: 4},
+		{Workers: 4, BatchSize: 3},
+		{Workers: 16, BatchSize: 1},
+	} {
+		deserializer := &countingDeserializer{IdentityDeserializer: mspmgmt.GetLocalMSP()}
+		assert.Equal(t, 20, verifier.verifyBlock(newBlock(10), deserializer))
+		assert.Equal(t, 20, deserializer.verified)
+		assert.Equal(t, 0, deserializer.failed)
+	}
+
+	t.Run("invalid signatures", func(t *testing.T) {
+		block := newBlock(2)
+		env, _, err := ptestutils.ConstructSignedTxEnv(util.GetTestChainID(), ccid, nil, []byte("results"), "", nil, nil, signer)
+		assert.NoError(t, err)
+		env.Signature = []byte("invalid")
+		block.Data.Data = append(block.Data.Data, testutil.NewBlock([]*common.Envelope{env}, 1, nil).Data.Data[0])
+
+		deserializer := &countingDeserializer{IdentityDeserializer: mspmgmt.GetLocalMSP()}
+		assert.Equal(t, 6, (&SignatureVerifier{Workers: 2}).verifyBlock(block, deserializer))
+		assert.Equal(t, 5, deserializer.verified)
+		assert.Equal(t, 1, deserializer.failed)
+	})
+
+	t.Run("empty block", func(t *testing.T) {
+		deserializer := &countingDeserializer{IdentityDeserializer: mspmgmt.GetLocalMSP()}
+		assert.Equal(t, 0, (&SignatureVerifier{Workers: 2}).verifyBlock(testutil.NewBlock(nil, 1, nil), deserializer))
+	})
+}
+
+func TestValidateWithSignatureVerifier(t *testing.T) {
+	viper.Set("peer.fileSystemPath", "/tmp/fabric/txvalidatortest")
+	ledgermgmt.InitializeTestEnv()
+	defer ledgermgmt.CleanupTestEnv()
+
+	gb, _ := test.MakeGenesisBlock("TestLedger")
+	ledger, _ := ledgermgmt.CreateLedger(gb)
+	defer ledger.Close()
+
+	mspManager := msp.NewMSPManager()
+	assert.NoError(t, mspManager.Setup([]msp.MSP{mspmgmt.GetLocalMSP()}))
+	support := &mocktxvalidator.Support{LedgerVal: ledger, ACVal: &config.MockApplicationCapabilities{}, MSPManagerVal: mspManager}
+	vcs := struct {
+		*mocktxvalidator.Support
+		*semaphore.Weighted
+	}{support, semaphore.NewWeighted(10)}
+	tValidator := &TxValidator{ChainID: "TestLedger", Support: vcs, Vscc: &slowVsccValidator{}, SignatureVerifier: &SignatureVerifier{Workers: 2}}
+
+	block := testutil.ConstructBlock(t, 1, gb.Header.Hash(), [][]byte{[]byte("tx-0"), []byte("tx-1")}, true)
+	assert.NoError(t, tValidator.Validate(block))
+	assert.Equal(t, 1, support.MSPManagerInvokeCount())
+	txsfltr := ledgerUtil.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	assert.True(t, txsfltr.IsSetTo(0, peer.TxValidationCode_VALID))
+	assert.True(t, txsfltr.IsSetTo(1, peer.TxValidationCode_VALID))
+}
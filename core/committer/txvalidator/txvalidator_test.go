@@ -52,7 +52,7 @@ func testValidationWithNTXes(t *testing.T, ledger ledger2.PeerLedger, gbHash []b
 		*mocktxvalidator.Support
 		*semaphore.Weighted
 	}{&mocktxvalidator.Support{LedgerVal: ledger, ACVal: &config.MockApplicationCapabilities{}}, semaphore.NewWeighted(10)}
-	tValidator := &TxValidator{"", vcs, mockVsccValidator}
+	tValidator := &TxValidator{"", vcs, mockVsccValidator, nil, nil, nil, nil, nil}
 
 	bcInfo, _ := ledger.GetBlockchainInfo()
 	assert.Equal(t, &common.BlockchainInfo{
@@ -128,7 +128,7 @@ func TestBlockValidationDuplicateTXId(t *testing.T) {
 		*mocktxvalidator.Support
 		*semaphore.Weighted
 	}{&mocktxvalidator.Support{LedgerVal: ledger, ACVal: acv}, semaphore.NewWeighted(10)}
-	tValidator := &TxValidator{"", vcs, mockVsccValidator}
+	tValidator := &TxValidator{"", vcs, mockVsccValidator, nil, nil, nil, nil, nil}
 
 	bcInfo, _ := ledger.GetBlockchainInfo()
 	assert.Equal(t, &common.BlockchainInfo{
@@ -216,7 +216,7 @@ func TestTxValidationFailure_InvalidTxid(t *testing.T) {
 		*mocktxvalidator.Support
 		*semaphore.Weighted
 	}{&mocktxvalidator.Support{LedgerVal: ledger, ACVal: &config.MockApplicationCapabilities{}}, semaphore.NewWeighted(10)}
-	tValidator := &TxValidator{"", vcs, &validator.MockVsccValidator{}}
+	tValidator := &TxValidator{"", vcs, &validator.MockVsccValidator{}, nil, nil, nil, nil, nil}
 
 	mockSigner, err := mspmgmt.GetLocalMSP().GetDefaultSigningIdentity()
 	assert.NoError(t, err)
@@ -291,6 +291,65 @@ func TestTxValidationFailure_InvalidTxid(t *testing.T) {
 	assert.True(t, txsfltr.Flag(0) == peer.TxValidationCode_BAD_PROPOSAL_TXID)
 }
 
+func TestTxValidationFailure_Expired(t *testing.T) {
+	viper.Set("peer.fileSystemPath", "/tmp/fabric/txvalidatortest")
+	ledgermgmt.InitializeTestEnv()
+	defer ledgermgmt.CleanupTestEnv()
+
+	gb, _ := test.MakeGenesisBlock("TestLedger")
+	ledger, _ := ledgermgmt.CreateLedger(gb)
+	defer ledger.Close()
+
+	vcs := struct {
+		*mocktxvalidator.Support
+		*semaphore.Weighted
+	}{&mocktxvalidator.Support{LedgerVal: ledger, ACVal: &config.MockApplicationCapabilities{TxExpirationRv: true}}, semaphore.NewWeighted(10)}
+	tValidator := &TxValidator{"", vcs, &validator.MockVsccValidator{}, nil, nil, nil, nil, nil}
+
+	mockSigner, err := mspmgmt.GetLocalMSP().GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	mockSignerSerialized, err := mockSigner.Serialize()
+	assert.NoError(t, err)
+
+	// Create an endorsement transaction which may be committed up to block 1
+	spec := &peer.ChaincodeSpec{
+		Type:        peer.ChaincodeSpec_GOLANG,
+		ChaincodeId: &peer.ChaincodeID{Name: "mycc"},
+		Input:       &peer.ChaincodeInput{Args: [][]byte{[]byte("invoke")}},
+	}
+	prop, _, err := utils.CreateChaincodeProposal(common.HeaderType_ENDORSER_TRANSACTION, util2.GetTestChainID(), &peer.ChaincodeInvocationSpec{ChaincodeSpec: spec}, mockSignerSerialized)
+	assert.NoError(t, err)
+	hdr, err := utils.GetHeader(prop.Header)
+	assert.NoError(t, err)
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	assert.NoError(t, err)
+	chdr.NotValidAfterBlock = 1
+	hdr.ChannelHeader = utils.MarshalOrPanic(chdr)
+	prop.Header = utils.MarshalOrPanic(hdr)
+
+	presp, err := utils.CreateProposalResponse(prop.Header, prop.Payload, &peer.Response{Status: 200}, nil, nil, spec.ChaincodeId, nil, mockSigner)
+	assert.NoError(t, err)
+	env, err := utils.CreateSignedTx(prop, mockSigner, presp)
+	assert.NoError(t, err)
+
+	block := testutil.NewBlock([]*common.Envelope{env}, 1, gb.Header.Hash())
+	tValidator.Validate(block)
+	txsfltr := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	assert.True(t, txsfltr.IsSetTo(0, peer.TxValidationCode_VALID))
+
+	// The same transaction in a later block has expired
+	block = testutil.NewBlock([]*common.Envelope{env}, 2, block.Header.Hash())
+	tValidator.Validate(block)
+	txsfltr = util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	assert.True(t, txsfltr.IsSetTo(0, peer.TxValidationCode_EXPIRED_TRANSACTION))
+
+	// Transactions do not expire on channels without the capability
+	vcs.Support.ACVal = &config.MockApplicationCapabilities{}
+	tValidator.Validate(block)
+	txsfltr = util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	assert.True(t, txsfltr.IsSetTo(0, peer.TxValidationCode_VALID))
+}
+
 func createCCUpgradeEnvelope(chainID, chaincodeName, chaincodeVersion string, signer msp.SigningIdentity) (*common.Envelope, error) {
 	creator, err := signer.Serialize()
 	if err != nil {
@@ -76,6 +76,16 @@ type TxValidator struct {
 	ChainID string
 	Support Support
 	Vscc    vsccValidator
+	// Watchdog bounds the validation time of each transaction; nil disables it
+	Watchdog *Watchdog
+	// Metrics records the validation time of blocks; nil disables it
+	Metrics *Metrics
+	// SignatureVerifier verifies the signatures of blocks upfront; nil disables it
+	SignatureVerifier *SignatureVerifier
+	// HashingSuite computes the transaction IDs of the channel; nil stands for SHA256
+	HashingSuite func([]byte) []byte
+	// InvalidationRecorder records the reasons transactions fail vscc validation; nil disables it
+	InvalidationRecorder InvalidationRecorder
 }
 
 var logger = flogging.MustGetLogger("committer.txvalidator")
@@ -93,6 +103,7 @@ type blockValidationResult struct {
 	txsUpgradedChaincode *sysccprovider.ChaincodeInstance
 	err                  error
 	txid                 string
+	reason               *peer.InvalidationReason
 }
 
 // NewTxValidator creates new transactions validator
@@ -145,6 +156,13 @@ func (v *TxValidator) Validate(block *common.Block) error {
 	txsUpgradedChaincodes := make(map[int]*sysccprovider.ChaincodeInstance)
 	// array of txids
 	txidArray := make([]string, len(block.Data.Data))
+	// reasons of the transactions invalidated by vscc
+	var reasons []*peer.InvalidationReason
+
+	if v.SignatureVerifier.enabled() {
+		n := v.SignatureVerifier.verifyBlock(block, v.Support.MSPManager())
+		logger.Debugf("[%s] Verified %d signatures of block [%d] upfront", v.ChainID, n, block.Header.Number)
+	}
 
 	results := make(chan *blockValidationResult)
 	go func() {
@@ -153,9 +171,7 @@ func (v *TxValidator) Validate(block *common.Block) error {
 			v.Support.Acquire(context.Background(), 1)
 
 			go func(index int, data []byte) {
-				defer v.Support.Release(1)
-
-				v.validateTx(&blockValidationRequest{
+				v.watchTx(&blockValidationRequest{
 					d:     data,
 					block: block,
 					tIdx:  index,
@@ -186,6 +202,11 @@ func (v *TxValidator) Validate(block *common.Block) error {
 			logger.Debugf("got result for idx %d, code %d", res.tIdx, res.validationCode)
 
 			txsfltr.SetFlag(res.tIdx, res.validationCode)
+			if res.reason != nil {
+				res.reason.BlockNumber = block.Header.Number
+				res.reason.TxNumber = uint64(res.tIdx)
+				reasons = append(reasons, res.reason)
+			}
 
 			if res.validationCode == peer.TxValidationCode_VALID {
 				if res.txsChaincodeName != nil {
@@ -228,8 +249,13 @@ func (v *TxValidator) Validate(block *common.Block) error {
 
 	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = txsfltr
 
-	elapsedValidation := time.Since(startValidation) / time.Millisecond // duration in ms
-	logger.Infof("[%s] Validated block [%d] in %dms", v.ChainID, block.Header.Number, elapsedValidation)
+	for _, reason := range reasons {
+		v.InvalidationRecorder.RecordInvalidation(v.ChainID, reason)
+	}
+
+	elapsedValidation := time.Since(startValidation)
+	logger.Infof("[%s] Validated block [%d] in %dms", v.ChainID, block.Header.Number, elapsedValidation/time.Millisecond)
+	v.Metrics.updateBlockValidationTime(v.ChainID, elapsedValidation)
 
 	return nil
 }
@@ -298,7 +324,7 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 		var txsChaincodeName *sysccprovider.ChaincodeInstance
 		var txsUpgradedChaincode *sysccprovider.ChaincodeInstance
 
-		if payload, txResult = validation.ValidateTransaction(env, v.Support.Capabilities()); txResult != peer.TxValidationCode_VALID {
+		if payload, txResult = validation.ValidateTransactionWithHashingSuite(env, v.Support.Capabilities(), v.HashingSuite); txResult != peer.TxValidationCode_VALID {
 			logger.Errorf("Invalid transaction with index %d", tIdx)
 			results <- &blockValidationResult{
 				tIdx:           tIdx,
@@ -318,10 +344,12 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 		}
 
 		channel := chdr.ChannelId
-		logger.Debugf("Transaction is for channel %s", channel)
+		// correlate the validation records with the ones of the client and orderer
+		lg := logger.ForTransaction(channel, chdr.TxId, nil)
+		lg.Debugf("Transaction is for channel %s", channel)
 
 		if !v.chainExists(channel) {
-			logger.Errorf("Dropping transaction for non-existent channel %s", channel)
+			lg.Errorf("Dropping transaction for non-existent channel %s", channel)
 			results <- &blockValidationResult{
 				tIdx:           tIdx,
 				validationCode: peer.TxValidationCode_TARGET_CHAIN_NOT_FOUND,
@@ -340,11 +368,21 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 				return
 			}
 
+			// Check the transaction has not expired before validating its endorsements
+			if v.Support.Capabilities().TxExpiration() && utils.IsExpired(chdr, block.Header.Number) {
+				lg.Warningf("Transaction %s expired before block %d", txID, block.Header.Number)
+				results <- &blockValidationResult{
+					tIdx:           tIdx,
+					validationCode: peer.TxValidationCode_EXPIRED_TRANSACTION,
+				}
+				return
+			}
+
 			// Validate tx with vscc and policy
-			logger.Debug("Validating transaction vscc tx validate")
+			lg.Debug("Validating transaction vscc tx validate")
 			err, cde := v.Vscc.VSCCValidateTx(tIdx, payload, d, block)
 			if err != nil {
-				logger.Errorf("VSCCValidateTx for transaction txId = %s returned error: %s", txID, err)
+				lg.Errorf("VSCCValidateTx for transaction txId = %s returned error: %s", txID, err)
 				switch err.(type) {
 				case *commonerrors.VSCCExecutionFailureError:
 					results <- &blockValidationResult{
@@ -362,6 +400,7 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 					results <- &blockValidationResult{
 						tIdx:           tIdx,
 						validationCode: cde,
+						reason:         v.invalidationReason(txID, cde, err, payload),
 					}
 					return
 				}
@@ -369,7 +408,7 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 
 			invokeCC, upgradeCC, err := v.getTxCCInstance(payload)
 			if err != nil {
-				logger.Errorf("Get chaincode instance from transaction txId = %s returned error: %+v", txID, err)
+				lg.Errorf("Get chaincode instance from transaction txId = %s returned error: %+v", txID, err)
 				results <- &blockValidationResult{
 					tIdx:           tIdx,
 					validationCode: peer.TxValidationCode_INVALID_OTHER_REASON,
@@ -378,7 +417,7 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 			}
 			txsChaincodeName = invokeCC
 			if upgradeCC != nil {
-				logger.Infof("Find chaincode upgrade transaction for chaincode %s on channel %s with new version %s", upgradeCC.ChaincodeName, upgradeCC.ChainID, upgradeCC.ChaincodeVersion)
+				lg.Infof("Find chaincode upgrade transaction for chaincode %s on channel %s with new version %s", upgradeCC.ChaincodeName, upgradeCC.ChainID, upgradeCC.ChaincodeVersion)
 				txsUpgradedChaincode = upgradeCC
 			}
 			// FAB-12971 comment out below block before v1.4 cut. Will uncomment after v1.4.
@@ -404,6 +443,15 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 						return
 					}
 
+					if v.Support.Capabilities().TxExpiration() && utils.IsExpired(chdr, block.Header.Number) {
+						logger.Warningf("Transaction %s expired before block %d", txID, block.Header.Number)
+						results <- &blockValidationResult{
+							tIdx:           tIdx,
+							validationCode: peer.TxValidationCode_EXPIRED_TRANSACTION,
+						}
+						return
+					}
+
 					// Set the namespace of the invocation field
 					txsChaincodeName = &sysccprovider.ChaincodeInstance{
 						ChainID:          channel,
@@ -414,7 +462,7 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 			configEnvelope, err := configtx.UnmarshalConfigEnvelope(payload.Data)
 			if err != nil {
 				err = errors.WithMessage(err, "error unmarshalling config which passed initial validity checks")
-				logger.Criticalf("%+v", err)
+				lg.Criticalf("%+v", err)
 				results <- &blockValidationResult{
 					tIdx: tIdx,
 					err:  err,
@@ -424,16 +472,16 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 
 			if err := v.Support.Apply(configEnvelope); err != nil {
 				err = errors.WithMessage(err, "error validating config which passed initial validity checks")
-				logger.Criticalf("%+v", err)
+				lg.Criticalf("%+v", err)
 				results <- &blockValidationResult{
 					tIdx: tIdx,
 					err:  err,
 				}
 				return
 			}
-			logger.Debugf("config transaction received for chain %s", channel)
+			lg.Debugf("config transaction received for chain %s", channel)
 		} else {
-			logger.Warningf("Unknown transaction type [%s] in block number [%d] transaction index [%d]",
+			lg.Warningf("Unknown transaction type [%s] in block number [%d] transaction index [%d]",
 				common.HeaderType(chdr.Type), block.Header.Number, tIdx)
 			results <- &blockValidationResult{
 				tIdx:           tIdx,
@@ -443,7 +491,7 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 		}
 
 		if _, err := proto.Marshal(env); err != nil {
-			logger.Warningf("Cannot marshal transaction: %s", err)
+			lg.Warningf("Cannot marshal transaction: %s", err)
 			results <- &blockValidationResult{
 				tIdx:           tIdx,
 				validationCode: peer.TxValidationCode_MARSHAL_TX_ERROR,
@@ -692,3 +740,15 @@ func (ds *dynamicCapabilities) V1_2Validation() bool {
 func (ds *dynamicCapabilities) V1_3Validation() bool {
 	return ds.support.Capabilities().V1_3Validation()
 }
+
+func (ds *dynamicCapabilities) WASMChaincode() bool {
+	return ds.support.Capabilities().WASMChaincode()
+}
+
+func (ds *dynamicCapabilities) TokenEncryption() bool {
+	return ds.support.Capabilities().TokenEncryption()
+}
+
+func (ds *dynamicCapabilities) TxExpiration() bool {
+	return ds.support.Capabilities().TxExpiration()
+}
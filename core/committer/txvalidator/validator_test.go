@@ -489,6 +489,56 @@ func testInvokeNoRWSet(t *testing.T, l ledger.PeerLedger, v txvalidator.Validato
 	assertInvalid(b, t, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
 }
 
+type invalidationRecorder struct {
+	channelID string
+	reasons   []*peer.InvalidationReason
+}
+
+func (r *invalidationRecorder) RecordInvalidation(channelID string, reason *peer.InvalidationReason) {
+	r.channelID = channelID
+	r.reasons = append(r.reasons, reason)
+}
+
+func TestInvalidationRecorder(t *testing.T) {
+	mspmgr := &mocks2.MSPManager{}
+	idThatDoesNotSatisfyPrincipal := &mocks2.Identity{}
+	idThatDoesNotSatisfyPrincipal.SatisfiesPrincipalReturns(errors.New("principal not satisfied"))
+	idThatDoesNotSatisfyPrincipal.GetIdentifierReturns(&msp.IdentityIdentifier{})
+	mspmgr.DeserializeIdentityReturns(idThatDoesNotSatisfyPrincipal, nil)
+
+	l, v := setupLedgerAndValidatorExplicitWithMSP(t, v13Capabilities(), &builtin.DefaultValidation{}, mspmgr)
+	defer ledgermgmt.CleanupTestEnv()
+	defer l.Close()
+	recorder := &invalidationRecorder{}
+	v.(*txvalidator.TxValidator).InvalidationRecorder = recorder
+
+	ccID := "mycc"
+	putCCInfo(l, ccID, signedByAnyMember([]string{"SampleOrg"}), t)
+
+	tx := getEnv(ccID, nil, createRWset(t, ccID), t)
+	b := &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}, Header: &common.BlockHeader{Number: 2}}
+
+	err := v.Validate(b)
+	assert.NoError(t, err)
+	assertInvalid(b, t, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
+
+	chdr, err := utils.ChannelHeader(tx)
+	assert.NoError(t, err)
+	assert.Len(t, recorder.reasons, 1)
+	reason := recorder.reasons[0]
+	assert.Equal(t, chdr.TxId, reason.TxId)
+	assert.Equal(t, uint64(2), reason.BlockNumber)
+	assert.Equal(t, uint64(0), reason.TxNumber)
+	assert.Equal(t, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE, reason.ValidationCode)
+	assert.NotEmpty(t, reason.Details)
+	assert.Equal(t, &peer.EndorsementPolicyFailure{
+		Namespace:             ccID,
+		Policy:                "OR('SampleOrg.member')",
+		Endorsers:             []string{"SampleOrg"},
+		UnsatisfiedPrincipals: []string{"'SampleOrg.member'"},
+	}, reason.EndorsementPolicyFailure)
+}
+
 // SerializedIdentity mock for the parallel validation test
 type mockSI struct {
 	SerializedID []byte
@@ -206,9 +206,9 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 				VSCCName:  vscc.ChaincodeName,
 			}
 			if err = v.VSCCValidateTxForCC(ctx); err != nil {
-				switch err.(type) {
+				switch e := err.(type) {
 				case *commonerrors.VSCCEndorsementPolicyError:
-					return err, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE
+					return &endorsementPolicyError{VSCCEndorsementPolicyError: e, namespace: ns, policy: policy}, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE
 				default:
 					return err, peer.TxValidationCode_INVALID_OTHER_REASON
 				}
@@ -247,9 +247,9 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 			VSCCName:  vscc.ChaincodeName,
 		}
 		if err = v.VSCCValidateTxForCC(ctx); err != nil {
-			switch err.(type) {
+			switch e := err.(type) {
 			case *commonerrors.VSCCEndorsementPolicyError:
-				return err, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE
+				return &endorsementPolicyError{VSCCEndorsementPolicyError: e, namespace: ccID, policy: policy}, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE
 			default:
 				return err, peer.TxValidationCode_INVALID_OTHER_REASON
 			}
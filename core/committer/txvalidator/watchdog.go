@@ -0,0 +1,84 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package txvalidator
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// A Watchdog bounds the time spent validating a single transaction, so that
+// a pathological transaction cannot stall the commit of its block indefinitely.
+type Watchdog struct {
+	// Budget is the maximum time to validate a transaction; zero disables the watchdog
+	Budget time.Duration
+	// Invalidate marks the transactions exceeding the budget as invalid, without waiting
+	// for their validation to complete; otherwise they are only reported.
+	// Config transactions are never invalidated, since their validation updates the
+	// channel configuration.
+	Invalidate bool
+}
+
+func (w *Watchdog) enabled() bool {
+	return w != nil && w.Budget > 0
+}
+
+// watchTx validates the transaction of req under the watchdog, and releases the
+// validation worker once the validation completes.
+func (v *TxValidator) watchTx(req *blockValidationRequest, results chan<- *blockValidationResult) {
+	if !v.Watchdog.enabled() {
+		defer v.Support.Release(1)
+		v.validateTx(req, results)
+		return
+	}
+
+	start := time.Now()
+	// the result is buffered so that an abandoned validation does not block forever
+	result := make(chan *blockValidationResult, 1)
+	go func() {
+		defer v.Support.Release(1)
+		v.validateTx(req, result)
+	}()
+
+	timer := time.NewTimer(v.Watchdog.Budget)
+	defer timer.Stop()
+	select {
+	case res := <-result:
+		results <- res
+		return
+	case <-timer.C:
+	}
+
+	if !v.Watchdog.Invalidate || isConfigTx(req.d) {
+		logger.Warningf("[%s] Validation of transaction %d in block %d exceeds its budget of %s", v.ChainID, req.tIdx, req.block.Header.Number, v.Watchdog.Budget)
+		res := <-result
+		logger.Warningf("[%s] Validation of transaction %d in block %d completed in %s", v.ChainID, req.tIdx, req.block.Header.Number, time.Since(start))
+		results <- res
+		return
+	}
+
+	logger.Errorf("[%s] Invalidating transaction %d in block %d: validation exceeds its budget of %s", v.ChainID, req.tIdx, req.block.Header.Number, v.Watchdog.Budget)
+	results <- &blockValidationResult{
+		tIdx:           req.tIdx,
+		validationCode: peer.TxValidationCode_INVALID_OTHER_REASON,
+	}
+}
+
+func isConfigTx(d []byte) bool {
+	env, err := utils.GetEnvelopeFromBlock(d)
+	if err != nil {
+		return false
+	}
+	chdr, err := utils.ChannelHeader(env)
+	if err != nil {
+		return false
+	}
+	return common.HeaderType(chdr.Type) == common.HeaderType_CONFIG
+}
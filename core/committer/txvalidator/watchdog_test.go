@@ -0,0 +1,109 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package txvalidator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/configtx/test"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	mocktxvalidator "github.com/hyperledger/fabric/core/mocks/txvalidator"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sync/semaphore"
+)
+
+// slowVsccValidator validates the transactions at the passed indexes only once release is closed
+type slowVsccValidator struct {
+	slow    map[int]bool
+	release chan struct{}
+}
+
+func (v *slowVsccValidator) VSCCValidateTx(seq int, payload *common.Payload, envBytes []byte, block *common.Block) (error, peer.TxValidationCode) {
+	if v.slow[seq] {
+		<-v.release
+	}
+	return nil, peer.TxValidationCode_VALID
+}
+
+func TestWatchdog(t *testing.T) {
+	viper.Set("peer.fileSystemPath", "/tmp/fabric/txvalidatortest")
+	ledgermgmt.InitializeTestEnv()
+	defer ledgermgmt.CleanupTestEnv()
+
+	gb, _ := test.MakeGenesisBlock("TestLedger")
+	gbHash := gb.Header.Hash()
+	ledger, _ := ledgermgmt.CreateLedger(gb)
+	defer ledger.Close()
+
+	sem := semaphore.NewWeighted(10)
+	vcs := struct {
+		*mocktxvalidator.Support
+		*semaphore.Weighted
+	}{&mocktxvalidator.Support{LedgerVal: ledger, ACVal: &config.MockApplicationCapabilities{}}, sem}
+
+	newBlock := func() *common.Block {
+		return testutil.ConstructBlock(t, 1, gbHash, [][]byte{[]byte("tx-0"), []byte("tx-1")}, true)
+	}
+
+	t.Run("reports slow transactions", func(t *testing.T) {
+		vscc := &slowVsccValidator{slow: map[int]bool{1: true}, release: make(chan struct{})}
+		tValidator := &TxValidator{ChainID: "TestLedger", Support: vcs, Vscc: vscc, Watchdog: &Watchdog{Budget: 10 * time.Millisecond}}
+		time.AfterFunc(100*time.Millisecond, func() { close(vscc.release) })
+
+		block := newBlock()
+		err := tValidator.Validate(block)
+		assert.NoError(t, err)
+
+		txsfltr := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+		assert.True(t, txsfltr.IsSetTo(0, peer.TxValidationCode_VALID))
+		assert.True(t, txsfltr.IsSetTo(1, peer.TxValidationCode_VALID))
+		assert.True(t, sem.TryAcquire(10))
+		sem.Release(10)
+	})
+
+	t.Run("invalidates slow transactions", func(t *testing.T) {
+		vscc := &slowVsccValidator{slow: map[int]bool{1: true}, release: make(chan struct{})}
+		tValidator := &TxValidator{ChainID: "TestLedger", Support: vcs, Vscc: vscc, Watchdog: &Watchdog{Budget: 10 * time.Millisecond, Invalidate: true}}
+
+		block := newBlock()
+		err := tValidator.Validate(block)
+		assert.NoError(t, err)
+
+		txsfltr := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+		assert.True(t, txsfltr.IsSetTo(0, peer.TxValidationCode_VALID))
+		assert.True(t, txsfltr.IsSetTo(1, peer.TxValidationCode_INVALID_OTHER_REASON))
+
+		// the worker of the abandoned validation is released once it completes
+		assert.False(t, sem.TryAcquire(10))
+		close(vscc.release)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(t, sem.Acquire(ctx, 10))
+		sem.Release(10)
+	})
+
+	t.Run("is disabled without a budget", func(t *testing.T) {
+		vscc := &slowVsccValidator{slow: map[int]bool{1: true}, release: make(chan struct{})}
+		tValidator := &TxValidator{ChainID: "TestLedger", Support: vcs, Vscc: vscc, Watchdog: &Watchdog{Invalidate: true}}
+		time.AfterFunc(50*time.Millisecond, func() { close(vscc.release) })
+
+		block := newBlock()
+		err := tValidator.Validate(block)
+		assert.NoError(t, err)
+
+		txsfltr := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+		assert.True(t, txsfltr.IsSetTo(1, peer.TxValidationCode_VALID))
+	})
+}
@@ -497,7 +497,7 @@ type ChaincodeContainerInfo struct {
 	Type        string
 	CodePackage []byte
 
-	// ContainerType is not a great name, but 'DOCKER' and 'SYSTEM' are the valid types
+	// ContainerType is not a great name, but 'DOCKER', 'SYSTEM' and 'WASM' are the valid types
 	ContainerType string
 }
 
@@ -532,11 +532,17 @@ type ChaincodeProvider interface {
 }
 
 func DeploymentSpecToChaincodeContainerInfo(cds *pb.ChaincodeDeploymentSpec) *ChaincodeContainerInfo {
+	containerType := cds.ExecEnv.String()
+	if cds.CCType() == pb.ChaincodeSpec_WASM.String() {
+		// WebAssembly chaincode always runs in the peer's wasm runtime
+		containerType = "WASM"
+	}
+
 	return &ChaincodeContainerInfo{
 		Name:          cds.Name(),
 		Version:       cds.Version(),
 		Path:          cds.Path(),
 		Type:          cds.CCType(),
-		ContainerType: cds.ExecEnv.String(),
+		ContainerType: containerType,
 	}
 }
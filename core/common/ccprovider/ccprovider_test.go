@@ -162,3 +162,25 @@ func setupDirectoryStructure(t *testing.T) (string, map[string][]byte) {
 
 	return tmp, hashes
 }
+
+func TestDeploymentSpecToChaincodeContainerInfo(t *testing.T) {
+	cds := &peer.ChaincodeDeploymentSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{
+			Type:        peer.ChaincodeSpec_GOLANG,
+			ChaincodeId: &peer.ChaincodeID{Name: "mycc", Version: "1.0", Path: "github.com/mycc"},
+		},
+	}
+	ccci := ccprovider.DeploymentSpecToChaincodeContainerInfo(cds)
+	assert.Equal(t, &ccprovider.ChaincodeContainerInfo{
+		Name:          "mycc",
+		Version:       "1.0",
+		Path:          "github.com/mycc",
+		Type:          "GOLANG",
+		ContainerType: "DOCKER",
+	}, ccci)
+
+	cds.ChaincodeSpec.Type = peer.ChaincodeSpec_WASM
+	ccci = ccprovider.DeploymentSpecToChaincodeContainerInfo(cds)
+	assert.Equal(t, "WASM", ccci.Type)
+	assert.Equal(t, "WASM", ccci.ContainerType)
+}
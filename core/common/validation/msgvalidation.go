@@ -74,6 +74,15 @@ func validateChaincodeProposalMessage(prop *pb.Proposal, hdr *common.Header) (*p
 // this function returns Header and ChaincodeHeaderExtension messages since they
 // have been unmarshalled and validated
 func ValidateProposalMessage(signedProp *pb.SignedProposal) (*pb.Proposal, *common.Header, *pb.ChaincodeHeaderExtension, error) {
+	return ValidateProposalMessageWithHashingSuite(signedProp, nil)
+}
+
+// ValidateProposalMessageWithHashingSuite checks the validity of a
+// SignedProposal message like ValidateProposalMessage, checking its
+// transaction ID with the hashing suite of its channel returned by
+// hashingSuite. A nil hashingSuite, or a nil hashing suite for the channel,
+// checks the transaction ID with SHA256.
+func ValidateProposalMessageWithHashingSuite(signedProp *pb.SignedProposal, hashingSuite func(channelID string) func([]byte) []byte) (*pb.Proposal, *common.Header, *pb.ChaincodeHeaderExtension, error) {
 	if signedProp == nil {
 		return nil, nil, nil, errors.New("nil arguments")
 	}
@@ -117,10 +126,11 @@ func ValidateProposalMessage(signedProp *pb.SignedProposal) (*pb.Proposal, *comm
 	// Verify that the transaction ID has been computed properly.
 	// This check is needed to ensure that the lookup into the ledger
 	// for the same TxID catches duplicates.
-	err = utils.CheckTxID(
-		chdr.TxId,
-		shdr.Nonce,
-		shdr.Creator)
+	var hash func([]byte) []byte
+	if hashingSuite != nil {
+		hash = hashingSuite(chdr.ChannelId)
+	}
+	err = utils.CheckTxIDWithHash(chdr.TxId, shdr.Nonce, shdr.Creator, hash)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -372,6 +382,13 @@ func validateEndorserTransaction(data []byte, hdr *common.Header) error {
 
 // ValidateTransaction checks that the transaction envelope is properly formed
 func ValidateTransaction(e *common.Envelope, c channelconfig.ApplicationCapabilities) (*common.Payload, pb.TxValidationCode) {
+	return ValidateTransactionWithHashingSuite(e, c, nil)
+}
+
+// ValidateTransactionWithHashingSuite checks that the transaction envelope is
+// properly formed, and that its transaction ID is computed with the hashing
+// suite of the channel; a nil hashing suite stands for SHA256
+func ValidateTransactionWithHashingSuite(e *common.Envelope, c channelconfig.ApplicationCapabilities, hashingSuite func([]byte) []byte) (*common.Payload, pb.TxValidationCode) {
 	putilsLogger.Debugf("ValidateTransactionEnvelope starts for envelope %p", e)
 
 	// check for nil argument
@@ -411,10 +428,11 @@ func ValidateTransaction(e *common.Envelope, c channelconfig.ApplicationCapabili
 		// Verify that the transaction ID has been computed properly.
 		// This check is needed to ensure that the lookup into the ledger
 		// for the same TxID catches duplicates.
-		err = utils.CheckTxID(
+		err = utils.CheckTxIDWithHash(
 			chdr.TxId,
 			shdr.Nonce,
-			shdr.Creator)
+			shdr.Creator,
+			hashingSuite)
 
 		if err != nil {
 			putilsLogger.Errorf("CheckTxID returns err %s", err)
@@ -446,10 +464,11 @@ func ValidateTransaction(e *common.Envelope, c channelconfig.ApplicationCapabili
 		// Verify that the transaction ID has been computed properly.
 		// This check is needed to ensure that the lookup into the ledger
 		// for the same TxID catches duplicates.
-		err = utils.CheckTxID(
+		err = utils.CheckTxIDWithHash(
 			chdr.TxId,
 			shdr.Nonce,
-			shdr.Creator)
+			shdr.Creator,
+			hashingSuite)
 
 		if err != nil {
 			putilsLogger.Errorf("CheckTxID returns err %s", err)
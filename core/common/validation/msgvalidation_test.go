@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/protos/common"
@@ -117,3 +118,30 @@ func TestValidateProposalMessage(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), fmt.Sprintf("access denied: channel [%s] creator org [%s]", util.GetTestChainID(), signerMSPId))
 }
+
+func TestValidateWithHashingSuite(t *testing.T) {
+	response := &peer.Response{Status: 200}
+	simRes := []byte("simulation_result")
+
+	// the transaction IDs of the test proposals are computed with SHA256
+	env, err := createTestTransactionEnvelope(util.GetTestChainID(), response, simRes)
+	assert.NoError(t, err)
+	_, txResult := ValidateTransactionWithHashingSuite(env, &config.MockApplicationCapabilities{}, util.ComputeSHA256)
+	assert.Equal(t, peer.TxValidationCode_VALID, txResult)
+	_, txResult = ValidateTransactionWithHashingSuite(env, &config.MockApplicationCapabilities{}, util.ComputeSHA3256)
+	assert.Equal(t, peer.TxValidationCode_BAD_PROPOSAL_TXID, txResult)
+
+	_, sProp, err := createTestProposalAndSignedProposal(util.GetTestChainID())
+	assert.NoError(t, err)
+	hashingSuite := func(channelID string) func([]byte) []byte {
+		if channelID == util.GetTestChainID() {
+			return util.ComputeSHA3256
+		}
+		return nil
+	}
+	_, _, _, err = ValidateProposalMessageWithHashingSuite(sProp, hashingSuite)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid txid")
+	_, _, _, err = ValidateProposalMessageWithHashingSuite(sProp, func(string) func([]byte) []byte { return nil })
+	assert.NoError(t, err)
+}
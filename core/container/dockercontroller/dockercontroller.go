@@ -171,9 +171,81 @@ func getDockerHostConfig() *docker.HostConfig {
 	}
 }
 
-func (vm *DockerVM) createContainer(client dockerClient, imageID, containerID string, args, env []string, attachStdout bool) error {
+// ResourceLimits bounds the resources available to a chaincode container.
+// A zero value leaves the corresponding setting of vm.docker.hostConfig in place.
+type ResourceLimits struct {
+	// Memory is the memory limit in bytes.
+	Memory int64 `mapstructure:"memory"`
+	// CPUs is the number of CPUs the container may use, e.g. 0.5.
+	CPUs float64 `mapstructure:"cpus"`
+	// Pids is the maximum number of processes in the container.
+	Pids int64 `mapstructure:"pids"`
+}
+
+// resourceLimitsOverride overrides the resource limits of the chaincode of
+// the name. The overrides are listed rather than keyed by name, as viper lower
+// cases keys and chaincode names are case sensitive.
+type resourceLimitsOverride struct {
+	Name           string `mapstructure:"name"`
+	ResourceLimits `mapstructure:",squash"`
+}
+
+// cpuPeriod is the CFS scheduler period, in microseconds, used to enforce a CPU limit.
+const cpuPeriod = 100000
+
+// getResourceLimits returns the limits configured for the named chaincode in
+// chaincode.resourceLimits. Limits that are not overridden for the chaincode
+// are taken from the defaults.
+func getResourceLimits(ccName string) ResourceLimits {
+	var limits ResourceLimits
+	err := viper.UnmarshalKey("chaincode.resourceLimits.default", &limits)
+	if err != nil {
+		dockerLogger.Warningf("load chaincode.resourceLimits.default failed, error: %s", err)
+	}
+
+	var overrides []resourceLimitsOverride
+	err = viper.UnmarshalKey("chaincode.resourceLimits.overrides", &overrides)
+	if err != nil {
+		dockerLogger.Warningf("load chaincode.resourceLimits.overrides failed, error: %s", err)
+	}
+	for _, override := range overrides {
+		if override.Name != ccName {
+			continue
+		}
+		if override.Memory != 0 {
+			limits.Memory = override.Memory
+		}
+		if override.CPUs != 0 {
+			limits.CPUs = override.CPUs
+		}
+		if override.Pids != 0 {
+			limits.Pids = override.Pids
+		}
+	}
+	return limits
+}
+
+// applyResourceLimits returns a copy of hostConfig with the limits applied.
+func applyResourceLimits(hostConfig *docker.HostConfig, limits ResourceLimits) *docker.HostConfig {
+	hc := *hostConfig
+	if limits.Memory > 0 {
+		hc.Memory = limits.Memory
+		// do not let the container swap its way past the limit
+		hc.MemorySwap = limits.Memory
+	}
+	if limits.CPUs > 0 {
+		hc.CPUPeriod = cpuPeriod
+		hc.CPUQuota = int64(limits.CPUs * cpuPeriod)
+	}
+	if limits.Pids > 0 {
+		hc.PidsLimit = limits.Pids
+	}
+	return &hc
+}
+
+func (vm *DockerVM) createContainer(client dockerClient, imageID, containerID string, args, env []string, attachStdout bool, limits ResourceLimits) error {
 	logger := dockerLogger.With("imageID", imageID, "containerID", containerID)
-	logger.Debugw("create container")
+	logger.Debugw("create container", "limits", limits)
 	_, err := client.CreateContainer(docker.CreateContainerOptions{
 		Name: containerID,
 		Config: &docker.Config{
@@ -183,7 +255,7 @@ func (vm *DockerVM) createContainer(client dockerClient, imageID, containerID st
 			AttachStdout: attachStdout,
 			AttachStderr: attachStdout,
 		},
-		HostConfig: getDockerHostConfig(),
+		HostConfig: applyResourceLimits(getDockerHostConfig(), limits),
 	})
 	if err != nil {
 		return err
@@ -243,7 +315,8 @@ func (vm *DockerVM) Start(ccid ccintf.CCID, args, env []string, filesToUpload ma
 
 	vm.stopInternal(client, containerName, 0, false, false)
 
-	err = vm.createContainer(client, imageName, containerName, args, env, attachStdout)
+	limits := getResourceLimits(ccid.Name)
+	err = vm.createContainer(client, imageName, containerName, args, env, attachStdout, limits)
 	if err == docker.ErrNoSuchImage {
 		reader, err := builder.Build()
 		if err != nil {
@@ -255,7 +328,7 @@ func (vm *DockerVM) Start(ccid ccintf.CCID, args, env []string, filesToUpload ma
 			return err
 		}
 
-		err = vm.createContainer(client, imageName, containerName, args, env, attachStdout)
+		err = vm.createContainer(client, imageName, containerName, args, env, attachStdout, limits)
 		if err != nil {
 			logger.Errorf("failed to create container: %s", err)
 			return err
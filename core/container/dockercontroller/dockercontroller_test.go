@@ -81,6 +81,62 @@ func TestGetDockerHostConfig(t *testing.T) {
 	assert.Equal(t, int64(0), hostConfig.CPUShares)
 }
 
+func TestGetResourceLimits(t *testing.T) {
+	coreutil.SetupTestConfig()
+	defer viper.Set("chaincode.resourceLimits", nil)
+
+	limits := getResourceLimits("mycc")
+	assert.Equal(t, ResourceLimits{}, limits)
+
+	viper.Set("chaincode.resourceLimits.default", map[string]interface{}{"memory": 1024, "cpus": 0.5, "pids": 64})
+	viper.Set("chaincode.resourceLimits.overrides", []interface{}{
+		map[string]interface{}{"name": "MyCC", "cpus": 2},
+		map[string]interface{}{"name": "mycc", "memory": 512},
+	})
+
+	limits = getResourceLimits("othercc")
+	assert.Equal(t, ResourceLimits{Memory: 1024, CPUs: 0.5, Pids: 64}, limits)
+
+	limits = getResourceLimits("MyCC")
+	assert.Equal(t, ResourceLimits{Memory: 1024, CPUs: 2, Pids: 64}, limits)
+
+	// chaincode names are case sensitive
+	limits = getResourceLimits("mycc")
+	assert.Equal(t, ResourceLimits{Memory: 512, CPUs: 0.5, Pids: 64}, limits)
+	limits = getResourceLimits("MYCC")
+	assert.Equal(t, ResourceLimits{Memory: 1024, CPUs: 0.5, Pids: 64}, limits)
+}
+
+func TestApplyResourceLimits(t *testing.T) {
+	base := &docker.HostConfig{NetworkMode: "host", Memory: 2048, CPUShares: 1}
+
+	hc := applyResourceLimits(base, ResourceLimits{})
+	assert.Equal(t, base, hc)
+	assert.False(t, base == hc, "host config should be copied")
+
+	hc = applyResourceLimits(base, ResourceLimits{Memory: 1024, CPUs: 1.5, Pids: 128})
+	assert.Equal(t, "host", hc.NetworkMode)
+	assert.Equal(t, int64(1024), hc.Memory)
+	assert.Equal(t, int64(1024), hc.MemorySwap)
+	assert.Equal(t, int64(100000), hc.CPUPeriod)
+	assert.Equal(t, int64(150000), hc.CPUQuota)
+	assert.Equal(t, int64(128), hc.PidsLimit)
+	assert.Equal(t, int64(2048), base.Memory, "the shared host config must not be modified")
+}
+
+func TestCreateContainerResourceLimits(t *testing.T) {
+	coreutil.SetupTestConfig()
+	hostConfig = nil
+	client := &mockClient{}
+	dvm := DockerVM{}
+
+	err := dvm.createContainer(client, "image", "container", nil, nil, false, ResourceLimits{Pids: 32})
+	assert.NoError(t, err)
+	require.NotNil(t, client.createOptions.HostConfig)
+	assert.Equal(t, int64(32), client.createOptions.HostConfig.PidsLimit)
+	assert.Equal(t, int64(0), getDockerHostConfig().PidsLimit)
+}
+
 func Test_Start(t *testing.T) {
 	gt := NewGomegaWithT(t)
 	dvm := DockerVM{
@@ -439,12 +495,14 @@ type mockClient struct {
 	waitErr     error
 
 	attachToContainerStub func(docker.AttachToContainerOptions) error
+	createOptions         docker.CreateContainerOptions
 }
 
 var getClientErr, createErr, uploadErr, noSuchImgErr, buildErr, removeImgErr,
 	startErr, stopErr, killErr, removeErr bool
 
 func (c *mockClient) CreateContainer(options docker.CreateContainerOptions) (*docker.Container, error) {
+	c.createOptions = options
 	if createErr {
 		return nil, errors.New("Error creating the container")
 	}
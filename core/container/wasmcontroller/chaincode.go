@@ -0,0 +1,276 @@
+// +build wasmruntime
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wasmcontroller
+
+import (
+	"context"
+	"encoding/binary"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+)
+
+// HostModule is the name of the module whose functions are made available
+// to WebAssembly chaincode. Modules may not import anything else; in
+// particular no WASI functions, clocks or sources of randomness are
+// provided, which keeps the execution deterministic.
+//
+// The host functions are:
+//
+//	get_args() i64
+//	get_tx_id() i64
+//	get_channel_id() i64
+//	get_state(key_ptr, key_len i32) i64
+//	put_state(key_ptr, key_len, value_ptr, value_len i32)
+//	del_state(key_ptr, key_len i32)
+//	set_event(name_ptr, name_len, payload_ptr, payload_len i32)
+//	set_response(status, message_ptr, message_len, payload_ptr, payload_len i32)
+//	log(message_ptr, message_len i32)
+//
+// Functions that return data copy it into a buffer obtained from the
+// module's exported alloc function and return the buffer's pointer in the
+// upper 32 bits and its length in the lower 32 bits; a result of 0 means the
+// data is empty. get_args encodes each argument as a little endian uint32
+// length followed by the argument bytes. A failing host function traps the
+// module, which fails the transaction.
+//
+// Modules must export their memory as "memory" along with the functions
+// alloc(size i32) i32, init() and invoke(). The response of init and invoke
+// is the one passed to set_response, or an empty success when it is not
+// called.
+const HostModule = "fabric"
+
+var requiredExports = []string{"alloc", "init", "invoke"}
+
+// Config holds the resource limits applied to WebAssembly chaincode.
+type Config struct {
+	// MemoryLimitPages is the maximum number of 64KiB pages of memory a
+	// module instance may use.
+	MemoryLimitPages uint32
+	// ExecuteTimeout bounds the execution of a single init or invoke.
+	ExecuteTimeout time.Duration
+}
+
+// Chaincode is a shim.Chaincode backed by a WebAssembly module. Every
+// transaction runs in a new instance of the module so no state survives
+// between transactions other than what is written to the ledger.
+type Chaincode struct {
+	runtime  wazero.Runtime
+	compiled wazero.CompiledModule
+	timeout  time.Duration
+}
+
+type transactionKey struct{}
+
+// transaction holds the state of a single init or invoke.
+type transaction struct {
+	stub     shim.ChaincodeStubInterface
+	response pb.Response
+}
+
+// NewChaincode compiles the module and checks that it satisfies the
+// chaincode ABI.
+func NewChaincode(module []byte, config Config) (*Chaincode, error) {
+	ctx := context.Background()
+
+	rc := wazero.NewRuntimeConfig().WithCloseOnContextDone(true)
+	if config.MemoryLimitPages > 0 {
+		rc = rc.WithMemoryLimitPages(config.MemoryLimitPages)
+	}
+	r := wazero.NewRuntimeWithConfig(ctx, rc)
+
+	_, err := r.NewHostModuleBuilder(HostModule).
+		NewFunctionBuilder().WithFunc(hostGetArgs).Export("get_args").
+		NewFunctionBuilder().WithFunc(hostGetTxID).Export("get_tx_id").
+		NewFunctionBuilder().WithFunc(hostGetChannelID).Export("get_channel_id").
+		NewFunctionBuilder().WithFunc(hostGetState).Export("get_state").
+		NewFunctionBuilder().WithFunc(hostPutState).Export("put_state").
+		NewFunctionBuilder().WithFunc(hostDelState).Export("del_state").
+		NewFunctionBuilder().WithFunc(hostSetEvent).Export("set_event").
+		NewFunctionBuilder().WithFunc(hostSetResponse).Export("set_response").
+		NewFunctionBuilder().WithFunc(hostLog).Export("log").
+		Instantiate(ctx)
+	if err != nil {
+		r.Close(ctx)
+		return nil, errors.Wrap(err, "failed to instantiate host module")
+	}
+
+	compiled, err := r.CompileModule(ctx, module)
+	if err != nil {
+		r.Close(ctx)
+		return nil, errors.Wrap(err, "failed to compile wasm module")
+	}
+
+	for _, imp := range compiled.ImportedFunctions() {
+		moduleName, name, _ := imp.Import()
+		if moduleName != HostModule {
+			r.Close(ctx)
+			return nil, errors.Errorf("wasm module imports %s.%s, only %s functions are available", moduleName, name, HostModule)
+		}
+	}
+	exports := compiled.ExportedFunctions()
+	for _, name := range requiredExports {
+		if _, ok := exports[name]; !ok {
+			r.Close(ctx)
+			return nil, errors.Errorf("wasm module does not export %s", name)
+		}
+	}
+	if _, ok := compiled.ExportedMemories()["memory"]; !ok {
+		r.Close(ctx)
+		return nil, errors.New("wasm module does not export memory")
+	}
+
+	return &Chaincode{
+		runtime:  r,
+		compiled: compiled,
+		timeout:  config.ExecuteTimeout,
+	}, nil
+}
+
+// Init calls the module's init function.
+func (c *Chaincode) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return c.call(stub, "init")
+}
+
+// Invoke calls the module's invoke function.
+func (c *Chaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	return c.call(stub, "invoke")
+}
+
+// Close releases the compiled module.
+func (c *Chaincode) Close() error {
+	return c.runtime.Close(context.Background())
+}
+
+func (c *Chaincode) call(stub shim.ChaincodeStubInterface, function string) pb.Response {
+	ctx := context.Background()
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	tx := &transaction{stub: stub, response: shim.Success(nil)}
+	ctx = context.WithValue(ctx, transactionKey{}, tx)
+
+	// an anonymous module so that transactions can run concurrently
+	mod, err := c.runtime.InstantiateModule(ctx, c.compiled, wazero.NewModuleConfig().WithName("").WithStartFunctions())
+	if err != nil {
+		return shim.Error(errors.WithMessage(err, "failed to instantiate wasm module").Error())
+	}
+	defer mod.Close(context.Background())
+
+	_, err = mod.ExportedFunction(function).Call(ctx)
+	if err != nil {
+		return shim.Error(errors.WithMessage(err, "wasm chaincode "+function+" failed").Error())
+	}
+
+	return tx.response
+}
+
+func currentTransaction(ctx context.Context) *transaction {
+	return ctx.Value(transactionKey{}).(*transaction)
+}
+
+// read copies a buffer out of the module's memory.
+func read(m api.Module, ptr, length uint32) []byte {
+	buf, ok := m.Memory().Read(ptr, length)
+	if !ok {
+		panic(errors.Errorf("buffer [%d, %d) is out of range of memory", ptr, uint64(ptr)+uint64(length)))
+	}
+	return append([]byte{}, buf...)
+}
+
+// write copies data into a buffer allocated by the module.
+func write(ctx context.Context, m api.Module, data []byte) uint64 {
+	if len(data) == 0 {
+		return 0
+	}
+
+	results, err := m.ExportedFunction("alloc").Call(ctx, uint64(len(data)))
+	if err != nil {
+		panic(errors.WithMessage(err, "alloc failed"))
+	}
+	ptr := uint32(results[0])
+	if !m.Memory().Write(ptr, data) {
+		panic(errors.Errorf("alloc returned a buffer at %d that is out of range of memory", ptr))
+	}
+	return uint64(ptr)<<32 | uint64(len(data))
+}
+
+func hostGetArgs(ctx context.Context, m api.Module) uint64 {
+	var encoded []byte
+	for _, arg := range currentTransaction(ctx).stub.GetArgs() {
+		var length [4]byte
+		binary.LittleEndian.PutUint32(length[:], uint32(len(arg)))
+		encoded = append(encoded, length[:]...)
+		encoded = append(encoded, arg...)
+	}
+	return write(ctx, m, encoded)
+}
+
+func hostGetTxID(ctx context.Context, m api.Module) uint64 {
+	return write(ctx, m, []byte(currentTransaction(ctx).stub.GetTxID()))
+}
+
+func hostGetChannelID(ctx context.Context, m api.Module) uint64 {
+	return write(ctx, m, []byte(currentTransaction(ctx).stub.GetChannelID()))
+}
+
+func hostGetState(ctx context.Context, m api.Module, keyPtr, keyLen uint32) uint64 {
+	value, err := currentTransaction(ctx).stub.GetState(string(read(m, keyPtr, keyLen)))
+	if err != nil {
+		panic(errors.WithMessage(err, "get_state failed"))
+	}
+	return write(ctx, m, value)
+}
+
+func hostPutState(ctx context.Context, m api.Module, keyPtr, keyLen, valuePtr, valueLen uint32) {
+	err := currentTransaction(ctx).stub.PutState(string(read(m, keyPtr, keyLen)), read(m, valuePtr, valueLen))
+	if err != nil {
+		panic(errors.WithMessage(err, "put_state failed"))
+	}
+}
+
+func hostDelState(ctx context.Context, m api.Module, keyPtr, keyLen uint32) {
+	err := currentTransaction(ctx).stub.DelState(string(read(m, keyPtr, keyLen)))
+	if err != nil {
+		panic(errors.WithMessage(err, "del_state failed"))
+	}
+}
+
+func hostSetEvent(ctx context.Context, m api.Module, namePtr, nameLen, payloadPtr, payloadLen uint32) {
+	err := currentTransaction(ctx).stub.SetEvent(string(read(m, namePtr, nameLen)), read(m, payloadPtr, payloadLen))
+	if err != nil {
+		panic(errors.WithMessage(err, "set_event failed"))
+	}
+}
+
+func hostSetResponse(ctx context.Context, m api.Module, status, messagePtr, messageLen, payloadPtr, payloadLen uint32) {
+	currentTransaction(ctx).response = pb.Response{
+		Status:  int32(status),
+		Message: string(read(m, messagePtr, messageLen)),
+		Payload: read(m, payloadPtr, payloadLen),
+	}
+}
+
+func hostLog(ctx context.Context, m api.Module, messagePtr, messageLen uint32) {
+	wasmLogger.Debugf("[%s] %s", shortTxID(currentTransaction(ctx).stub.GetTxID()), read(m, messagePtr, messageLen))
+}
+
+func shortTxID(txID string) string {
+	if len(txID) < 8 {
+		return txID
+	}
+	return txID[0:8]
+}
@@ -0,0 +1,81 @@
+// +build wasmruntime
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wasmcontroller
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadModule(t *testing.T, name string) []byte {
+	module, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return module
+}
+
+func TestNewChaincode(t *testing.T) {
+	tests := []struct {
+		module string
+		errMsg string
+	}{
+		{module: "simple.wasm"},
+		{module: "wasi.wasm", errMsg: "wasm module imports wasi_snapshot_preview1.proc_exit, only fabric functions are available"},
+		{module: "noinvoke.wasm", errMsg: "wasm module does not export invoke"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.module, func(t *testing.T) {
+			cc, err := NewChaincode(loadModule(t, tt.module), Config{})
+			if tt.errMsg != "" {
+				assert.EqualError(t, err, tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.NoError(t, cc.Close())
+		})
+	}
+
+	_, err := NewChaincode([]byte("garbage"), Config{})
+	assert.Contains(t, err.Error(), "failed to compile wasm module")
+}
+
+func TestChaincodeInitInvoke(t *testing.T) {
+	cc, err := NewChaincode(loadModule(t, "simple.wasm"), Config{MemoryLimitPages: 16, ExecuteTimeout: time.Minute})
+	require.NoError(t, err)
+	defer cc.Close()
+
+	stub := shim.NewMockStub("simple", cc)
+
+	resp := stub.MockInit("tx1", [][]byte{[]byte("init")})
+	assert.Equal(t, int32(shim.OK), resp.Status, resp.Message)
+	assert.Equal(t, []byte("100"), stub.State["a"])
+
+	resp = stub.MockInvoke("tx2", [][]byte{[]byte("invoke"), []byte("xy")})
+	assert.Equal(t, int32(shim.OK), resp.Status, resp.Message)
+	assert.Equal(t, []byte("100"), resp.Payload)
+	assert.Equal(t, []byte{6, 0, 0, 0, 'i', 'n', 'v', 'o', 'k', 'e', 2, 0, 0, 0, 'x', 'y'}, stub.State["args"])
+}
+
+func TestChaincodeInvokeTimeout(t *testing.T) {
+	cc, err := NewChaincode(loadModule(t, "loop.wasm"), Config{ExecuteTimeout: 100 * time.Millisecond})
+	require.NoError(t, err)
+	defer cc.Close()
+
+	stub := shim.NewMockStub("loop", cc)
+
+	resp := stub.MockInvoke("tx1", [][]byte{[]byte("invoke")})
+	assert.Equal(t, int32(shim.ERROR), resp.Status)
+	assert.Contains(t, resp.Message, "wasm chaincode invoke failed")
+}
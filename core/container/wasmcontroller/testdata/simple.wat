@@ -0,0 +1,29 @@
+;; simple.wasm: init stores a=100, invoke stores the encoded arguments under
+;; "args" and responds with the value of a.
+(module
+  (import "fabric" "get_args" (func $get_args (result i64)))
+  (import "fabric" "get_state" (func $get_state (param i32 i32) (result i64)))
+  (import "fabric" "put_state" (func $put_state (param i32 i32 i32 i32)))
+  (import "fabric" "set_response" (func $set_response (param i32 i32 i32 i32 i32)))
+  (memory (export "memory") 1)
+  (global $heap (mut i32) (i32.const 1024))
+  (data (i32.const 0) "a")
+  (data (i32.const 16) "100")
+  (data (i32.const 32) "args")
+  (func (export "alloc") (param $size i32) (result i32)
+    (local $ptr i32)
+    (local.set $ptr (global.get $heap))
+    (global.set $heap (i32.add (global.get $heap) (local.get $size)))
+    (local.get $ptr))
+  (func (export "init")
+    (call $put_state (i32.const 0) (i32.const 1) (i32.const 16) (i32.const 3)))
+  (func (export "invoke")
+    (local $buf i64)
+    (local.set $buf (call $get_args))
+    (call $put_state (i32.const 32) (i32.const 4)
+      (i32.wrap_i64 (i64.shr_u (local.get $buf) (i64.const 32)))
+      (i32.wrap_i64 (local.get $buf)))
+    (local.set $buf (call $get_state (i32.const 0) (i32.const 1)))
+    (call $set_response (i32.const 200) (i32.const 0) (i32.const 0)
+      (i32.wrap_i64 (i64.shr_u (local.get $buf) (i64.const 32)))
+      (i32.wrap_i64 (local.get $buf)))))
@@ -0,0 +1,12 @@
+;; wasi.wasm: imports a function that the chaincode runtime does not provide.
+(module
+  (import "wasi_snapshot_preview1" "proc_exit" (func $proc_exit))
+  (memory (export "memory") 1)
+  (global $heap (mut i32) (i32.const 1024))
+  (func (export "alloc") (param $size i32) (result i32)
+    (local $ptr i32)
+    (local.set $ptr (global.get $heap))
+    (global.set $heap (i32.add (global.get $heap) (local.get $size)))
+    (local.get $ptr))
+  (func (export "init"))
+  (func (export "invoke")))
@@ -0,0 +1,145 @@
+// +build wasmruntime
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package wasmcontroller runs WebAssembly chaincode in the peer process. The
+// wazero runtime it depends on needs a newer Go toolchain than the one fabric
+// is built with, so the package is only built with the wasmruntime build tag.
+package wasmcontroller
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"io/ioutil"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/wasm"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/inproccontroller"
+	"github.com/pkg/errors"
+)
+
+// ContainerType is the string which the wasm container type
+// is registered with the container.VMController
+const ContainerType = "WASM"
+
+var wasmLogger = flogging.MustGetLogger("wasmcontroller")
+
+// Registry is the registry of in process chaincode used to run the
+// compiled modules.
+type Registry interface {
+	container.VMProvider
+	Register(ccid *ccintf.CCID, cc shim.Chaincode) error
+}
+
+// Provider implements container.VMProvider for WebAssembly chaincode.
+type Provider struct {
+	Registry Registry
+	Config   Config
+}
+
+// NewProvider creates a Provider that runs WebAssembly chaincode through
+// the in process chaincode registry.
+func NewProvider(registry Registry, config Config) *Provider {
+	return &Provider{
+		Registry: registry,
+		Config:   config,
+	}
+}
+
+// NewVM creates a new WasmVM instance
+func (p *Provider) NewVM() container.VM {
+	return &WasmVM{
+		registry: p.Registry,
+		inproc:   p.Registry.NewVM(),
+		config:   p.Config,
+	}
+}
+
+// WasmVM is a vm that runs WebAssembly chaincode in the peer process. The
+// module is compiled when the chaincode is started and is then run as in
+// process chaincode, talking to the peer over an in process stream.
+type WasmVM struct {
+	registry Registry
+	inproc   container.VM
+	config   Config
+}
+
+// Start compiles the module from the build context produced by the builder
+// and starts it
+func (vm *WasmVM) Start(ccid ccintf.CCID, args []string, env []string, filesToUpload map[string][]byte, builder container.Builder) error {
+	reader, err := builder.Build()
+	if err != nil {
+		return errors.Wrap(err, "failed to build wasm chaincode")
+	}
+	module, err := readModule(reader)
+	if err != nil {
+		return err
+	}
+
+	cc, err := NewChaincode(module, vm.config)
+	if err != nil {
+		return errors.WithMessage(err, "failed to load wasm chaincode "+ccid.GetName())
+	}
+
+	err = vm.registry.Register(&ccid, cc)
+	switch err.(type) {
+	case nil:
+	case inproccontroller.SysCCRegisteredErr:
+		// the chaincode is being restarted; the name and version identify
+		// the same module so the registered instance is used
+		cc.Close()
+	default:
+		cc.Close()
+		return errors.WithMessage(err, "failed to register wasm chaincode "+ccid.GetName())
+	}
+
+	wasmLogger.Debugf("starting wasm chaincode %s", ccid.GetName())
+	return vm.inproc.Start(ccid, args, env, nil, nil)
+}
+
+// Stop stops the chaincode
+func (vm *WasmVM) Stop(ccid ccintf.CCID, timeout uint, dontkill bool, dontremove bool) error {
+	return vm.inproc.Stop(ccid, timeout, dontkill, dontremove)
+}
+
+// Wait will block until the chaincode is stopped.
+func (vm *WasmVM) Wait(ccid ccintf.CCID) (int, error) {
+	return vm.inproc.Wait(ccid)
+}
+
+// HealthCheck is provided in order to implement the VMProvider interface.
+// It always returns nil.
+func (vm *WasmVM) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+// readModule extracts the module from a gzipped build context.
+func readModule(reader io.Reader) ([]byte, error) {
+	gr, err := gzip.NewReader(reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open wasm build context")
+	}
+	tr := tar.NewReader(gr)
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, errors.Errorf("%s not found in wasm build context", wasm.ModuleFile)
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read wasm build context")
+		}
+		if header.Name == wasm.ModuleFile {
+			return ioutil.ReadAll(tr)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// +build wasmruntime
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wasmcontroller
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/platforms/wasm"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/inproccontroller"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeVM struct {
+	started []ccintf.CCID
+	stopped []ccintf.CCID
+}
+
+func (f *fakeVM) Start(ccid ccintf.CCID, args []string, env []string, filesToUpload map[string][]byte, builder container.Builder) error {
+	f.started = append(f.started, ccid)
+	return nil
+}
+
+func (f *fakeVM) Stop(ccid ccintf.CCID, timeout uint, dontkill bool, dontremove bool) error {
+	f.stopped = append(f.stopped, ccid)
+	return nil
+}
+
+func (f *fakeVM) Wait(ccid ccintf.CCID) (int, error) { return 0, nil }
+
+func (f *fakeVM) HealthCheck(ctx context.Context) error { return nil }
+
+type fakeRegistry struct {
+	vm         *fakeVM
+	registered map[string]shim.Chaincode
+	err        error
+}
+
+func (f *fakeRegistry) NewVM() container.VM { return f.vm }
+
+func (f *fakeRegistry) Register(ccid *ccintf.CCID, cc shim.Chaincode) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.registered[ccid.GetName()] = cc
+	return nil
+}
+
+type fakeBuilder struct {
+	files map[string][]byte
+	err   error
+}
+
+func (f *fakeBuilder) Build() (io.Reader, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	buf := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for name, contents := range f.files {
+		tw.WriteHeader(&tar.Header{Name: name, Size: int64(len(contents)), Mode: 0100644})
+		tw.Write(contents)
+	}
+	tw.Close()
+	gw.Close()
+	return buf, nil
+}
+
+func TestWasmVMStart(t *testing.T) {
+	ccid := ccintf.CCID{Name: "simple", Version: "1.0"}
+	builder := &fakeBuilder{files: map[string][]byte{
+		"Dockerfile":    []byte("FROM scratch"),
+		wasm.ModuleFile: loadModule(t, "simple.wasm"),
+	}}
+
+	t.Run("success", func(t *testing.T) {
+		registry := &fakeRegistry{vm: &fakeVM{}, registered: map[string]shim.Chaincode{}}
+		vm := NewProvider(registry, Config{}).NewVM()
+
+		err := vm.Start(ccid, nil, []string{"CORE_CHAINCODE_ID_NAME=simple:1.0"}, nil, builder)
+		require.NoError(t, err)
+		assert.IsType(t, &Chaincode{}, registry.registered["simple-1.0"])
+		assert.Equal(t, []ccintf.CCID{ccid}, registry.vm.started)
+
+		err = vm.Stop(ccid, 0, false, false)
+		assert.NoError(t, err)
+		assert.Equal(t, []ccintf.CCID{ccid}, registry.vm.stopped)
+	})
+
+	t.Run("already registered", func(t *testing.T) {
+		registry := &fakeRegistry{vm: &fakeVM{}, err: inproccontroller.SysCCRegisteredErr("simple-1.0")}
+		vm := NewProvider(registry, Config{}).NewVM()
+
+		err := vm.Start(ccid, nil, nil, nil, builder)
+		require.NoError(t, err)
+		assert.Equal(t, []ccintf.CCID{ccid}, registry.vm.started)
+	})
+
+	t.Run("registration fails", func(t *testing.T) {
+		registry := &fakeRegistry{vm: &fakeVM{}, err: errors.New("boom")}
+		vm := NewProvider(registry, Config{}).NewVM()
+
+		err := vm.Start(ccid, nil, nil, nil, builder)
+		assert.EqualError(t, err, "failed to register wasm chaincode simple-1.0: boom")
+		assert.Empty(t, registry.vm.started)
+	})
+
+	t.Run("build fails", func(t *testing.T) {
+		registry := &fakeRegistry{vm: &fakeVM{}}
+		vm := NewProvider(registry, Config{}).NewVM()
+
+		err := vm.Start(ccid, nil, nil, nil, &fakeBuilder{err: errors.New("boom")})
+		assert.EqualError(t, err, "failed to build wasm chaincode: boom")
+	})
+
+	t.Run("module missing", func(t *testing.T) {
+		registry := &fakeRegistry{vm: &fakeVM{}}
+		vm := NewProvider(registry, Config{}).NewVM()
+
+		err := vm.Start(ccid, nil, nil, nil, &fakeBuilder{files: map[string][]byte{"Dockerfile": []byte("FROM scratch")}})
+		assert.EqualError(t, err, "chaincode.wasm not found in wasm build context")
+	})
+
+	t.Run("invalid module", func(t *testing.T) {
+		registry := &fakeRegistry{vm: &fakeVM{}}
+		vm := NewProvider(registry, Config{}).NewVM()
+
+		err := vm.Start(ccid, nil, nil, nil, &fakeBuilder{files: map[string][]byte{wasm.ModuleFile: loadModule(t, "noinvoke.wasm")}})
+		assert.EqualError(t, err, "failed to load wasm chaincode simple-1.0: wasm module does not export invoke")
+	})
+}
@@ -44,6 +44,10 @@ func getReConnectBackoffThreshold() float64 {
 	return util.GetFloat64OrDefault("peer.deliveryclient.reConnectBackoffThreshold", defaultReConnectBackoffThreshold)
 }
 
+func getCompression() string {
+	return viper.GetString("peer.deliveryclient.compression")
+}
+
 func staticRootsEnabled() bool {
 	return viper.GetBool("peer.deliveryclient.staticRootsEnabled")
 }
@@ -253,6 +257,12 @@ func DefaultConnectionFactory(channelID string) func(endpoint string) (*grpc.Cli
 				"peer.keepalive.deliveryClient.timeout")
 		}
 		dialOpts = append(dialOpts, comm.ClientKeepaliveOptions(kaOpts)...)
+		// compress the blocks delivered by the ordering service, if configured
+		compression := getCompression()
+		if err := comm.ValidateCompression(compression); err != nil {
+			return nil, fmt.Errorf("invalid peer.deliveryclient.compression: %v", err)
+		}
+		dialOpts = append(dialOpts, comm.ClientCompressionOptions(compression)...)
 
 		if viper.GetBool("peer.tls.enabled") {
 			creds, err := comm.GetCredentialSupport().GetDeliverServiceCredentials(channelID, staticRootsEnabled())
@@ -89,6 +89,10 @@ type Support interface {
 	// and whether the Application config exists
 	GetApplicationConfig(cid string) (channelconfig.Application, bool)
 
+	// GetHashingSuite returns the algorithm computing the transaction IDs of
+	// the Channel, or nil for SHA256
+	GetHashingSuite(channelID string) func([]byte) []byte
+
 	// NewQueryCreator creates a new QueryCreator
 	NewQueryCreator(channel string) (QueryCreator, error)
 
@@ -106,6 +110,18 @@ type Endorser struct {
 	PlatformRegistry      *platforms.Registry
 	PvtRWSetAssembler
 	Metrics *EndorserMetrics
+	// ConflictAdvisor, when set, advises the clients of the keys read by
+	// their proposals that recently caused read conflicts.
+	ConflictAdvisor ConflictAdvisor
+}
+
+//go:generate mockery -dir . -name ConflictAdvisor -case underscore -output mocks/
+
+// ConflictAdvisor advises clients of the conflict hotspots of a channel.
+type ConflictAdvisor interface {
+	// AdviseSimulation returns the hotspots among the keys read by the public
+	// simulation results of a proposal, or nil if it read none.
+	AdviseSimulation(channelID string, simulationResults []byte) (*pb.ConflictAdvisory, error)
 }
 
 // validateResult provides the result of endorseProposal verification
@@ -348,7 +364,7 @@ func (e *Endorser) endorseProposal(_ context.Context, chainID string, txid strin
 func (e *Endorser) preProcess(signedProp *pb.SignedProposal) (*validateResult, error) {
 	vr := &validateResult{}
 	// at first, we check whether the message is valid
-	prop, hdr, hdrExt, err := validation.ValidateProposalMessage(signedProp)
+	prop, hdr, hdrExt, err := validation.ValidateProposalMessageWithHashingSuite(signedProp, e.s.GetHashingSuite)
 
 	if err != nil {
 		e.Metrics.ProposalValidationFailed.Add(1)
@@ -557,6 +573,16 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 	// chaincode invocation
 	pResp.Response = res
 
+	if chainID != "" && e.ConflictAdvisor != nil {
+		// the advisory is not endorsed, so failing to compute it does not
+		// fail the proposal
+		advisory, err := e.ConflictAdvisor.AdviseSimulation(chainID, simulationResult)
+		if err != nil {
+			endorserLogger.Warningf("[%s][%s] failed advising of conflict hotspots: %s", chainID, shorttxid(txid), err)
+		}
+		pResp.ConflictAdvisory = advisory
+	}
+
 	// total failed proposals = ProposalsReceived-SuccessfulProposals
 	e.Metrics.SuccessfulProposals.Add(1)
 	success = true
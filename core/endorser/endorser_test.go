@@ -558,6 +558,78 @@ func TestEndorserGoodPath(t *testing.T) {
 	assert.EqualValues(t, 1, fakeMetrics.successfulProposals.AddArgsForCall(0))
 }
 
+func TestEndorserHashingSuite(t *testing.T) {
+	m := &mock.Mock{}
+	m.On("Sign", mock.Anything).Return([]byte{1, 2, 3, 4, 5}, nil)
+	m.On("Serialize").Return([]byte{1, 1, 1}, nil)
+	m.On("GetTxSimulator", mock.Anything, mock.Anything).Return(newMockTxSim(), nil)
+	support := &em.MockSupport{
+		Mock:                       m,
+		GetApplicationConfigBoolRv: true,
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
+		GetTransactionByIDErr:      errors.New(""),
+		ChaincodeDefinitionRv:      &ccprovider.ChaincodeData{Name: "ccid", Version: "0", Escc: "ESCC"},
+		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
+		GetHashingSuiteRv:          util.ComputeSHA3256,
+	}
+	attachPluginEndorser(support, nil)
+	es := endorser.NewEndorserServer(pvtEmptyDistributor, support, platforms.NewRegistry(&golang.Platform{}), &disabled.Provider{})
+
+	// the transaction id of the proposal is computed with SHA256
+	pResp, err := es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+	assert.Error(t, err)
+	assert.EqualValues(t, 500, pResp.Response.Status)
+	assert.Contains(t, pResp.Response.Message, "invalid txid")
+
+	// the transaction id of the proposal is computed with the hashing suite
+	// of the channel
+	cis := &pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{Type: 1, ChaincodeId: &pb.ChaincodeID{Name: "ccid", Version: "0"}, Input: &pb.ChaincodeInput{}}}
+	creator, err := signer.Serialize()
+	assert.NoError(t, err)
+	prop, _, err := utils.CreateChaincodeProposalWithTxIDTransientAndHash(common.HeaderType_ENDORSER_TRANSACTION, util.GetTestChainID(), cis, creator, "", nil, util.ComputeSHA3256)
+	assert.NoError(t, err)
+	signedProp, err := utils.GetSignedProposal(prop, signer)
+	assert.NoError(t, err)
+
+	pResp, err = es.ProcessProposal(context.Background(), signedProp)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 200, pResp.Response.Status)
+}
+
+func TestEndorserConflictAdvisory(t *testing.T) {
+	m := &mock.Mock{}
+	m.On("Sign", mock.Anything).Return([]byte{1, 2, 3, 4, 5}, nil)
+	m.On("Serialize").Return([]byte{1, 1, 1}, nil)
+	m.On("GetTxSimulator", mock.Anything, mock.Anything).Return(newMockTxSim(), nil)
+	support := &em.MockSupport{
+		Mock:                       m,
+		GetApplicationConfigBoolRv: true,
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
+		GetTransactionByIDErr:      errors.New(""),
+		ChaincodeDefinitionRv:      &ccprovider.ChaincodeData{Name: "ccid", Version: "0", Escc: "ESCC"},
+		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
+	}
+	attachPluginEndorser(support, nil)
+	es := endorser.NewEndorserServer(pvtEmptyDistributor, support, platforms.NewRegistry(&golang.Platform{}), &disabled.Provider{})
+
+	advisory := &pb.ConflictAdvisory{Hotspots: []*pb.ConflictHotspot{{Namespace: "ccid", Key: "key1", Conflicts: 2, TxIds: []string{"tx2", "tx1"}}}}
+	advisor := &mocks.ConflictAdvisor{}
+	advisor.On("AdviseSimulation", util.GetTestChainID(), mock.Anything).Return(advisory, nil).Once()
+	es.ConflictAdvisor = advisor
+
+	pResp, err := es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 200, pResp.Response.Status)
+	assert.Equal(t, advisory, pResp.ConflictAdvisory)
+
+	// failing to advise does not fail the proposal
+	advisor.On("AdviseSimulation", util.GetTestChainID(), mock.Anything).Return(nil, errors.New("boom"))
+	pResp, err = es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 200, pResp.Response.Status)
+	assert.Nil(t, pResp.ConflictAdvisory)
+}
+
 func TestEndorserChaincodeCallLogging(t *testing.T) {
 	gt := NewGomegaWithT(t)
 	m := &mock.Mock{}
@@ -0,0 +1,33 @@
+// Code generated by mockery v1.0.0
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+import peer "github.com/hyperledger/fabric/protos/peer"
+
+// ConflictAdvisor is an autogenerated mock type for the ConflictAdvisor type
+type ConflictAdvisor struct {
+	mock.Mock
+}
+
+// AdviseSimulation provides a mock function with given fields: channelID, simulationResults
+func (_m *ConflictAdvisor) AdviseSimulation(channelID string, simulationResults []byte) (*peer.ConflictAdvisory, error) {
+	ret := _m.Called(channelID, simulationResults)
+
+	var r0 *peer.ConflictAdvisory
+	if rf, ok := ret.Get(0).(func(string, []byte) *peer.ConflictAdvisory); ok {
+		r0 = rf(channelID, simulationResults)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*peer.ConflictAdvisory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []byte) error); ok {
+		r1 = rf(channelID, simulationResults)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -224,6 +224,17 @@ type Support struct {
 		result1 channelconfig.Application
 		result2 bool
 	}
+	GetHashingSuiteStub        func(channelID string) func([]byte) []byte
+	getHashingSuiteMutex       sync.RWMutex
+	getHashingSuiteArgsForCall []struct {
+		channelID string
+	}
+	getHashingSuiteReturns struct {
+		result1 func([]byte) []byte
+	}
+	getHashingSuiteReturnsOnCall map[int]struct {
+		result1 func([]byte) []byte
+	}
 	NewQueryCreatorStub        func(channel string) (endorser_test.QueryCreator, error)
 	newQueryCreatorMutex       sync.RWMutex
 	newQueryCreatorArgsForCall []struct {
@@ -1050,6 +1061,54 @@ func (fake *Support) GetApplicationConfigReturnsOnCall(i int, result1 channelcon
 	}{result1, result2}
 }
 
+func (fake *Support) GetHashingSuite(channelID string) func([]byte) []byte {
+	fake.getHashingSuiteMutex.Lock()
+	ret, specificReturn := fake.getHashingSuiteReturnsOnCall[len(fake.getHashingSuiteArgsForCall)]
+	fake.getHashingSuiteArgsForCall = append(fake.getHashingSuiteArgsForCall, struct {
+		channelID string
+	}{channelID})
+	fake.recordInvocation("GetHashingSuite", []interface{}{channelID})
+	fake.getHashingSuiteMutex.Unlock()
+	if fake.GetHashingSuiteStub != nil {
+		return fake.GetHashingSuiteStub(channelID)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.getHashingSuiteReturns.result1
+}
+
+func (fake *Support) GetHashingSuiteCallCount() int {
+	fake.getHashingSuiteMutex.RLock()
+	defer fake.getHashingSuiteMutex.RUnlock()
+	return len(fake.getHashingSuiteArgsForCall)
+}
+
+func (fake *Support) GetHashingSuiteArgsForCall(i int) string {
+	fake.getHashingSuiteMutex.RLock()
+	defer fake.getHashingSuiteMutex.RUnlock()
+	return fake.getHashingSuiteArgsForCall[i].channelID
+}
+
+func (fake *Support) GetHashingSuiteReturns(result1 func([]byte) []byte) {
+	fake.GetHashingSuiteStub = nil
+	fake.getHashingSuiteReturns = struct {
+		result1 func([]byte) []byte
+	}{result1}
+}
+
+func (fake *Support) GetHashingSuiteReturnsOnCall(i int, result1 func([]byte) []byte) {
+	fake.GetHashingSuiteStub = nil
+	if fake.getHashingSuiteReturnsOnCall == nil {
+		fake.getHashingSuiteReturnsOnCall = make(map[int]struct {
+			result1 func([]byte) []byte
+		})
+	}
+	fake.getHashingSuiteReturnsOnCall[i] = struct {
+		result1 func([]byte) []byte
+	}{result1}
+}
+
 func (fake *Support) NewQueryCreator(channel string) (endorser_test.QueryCreator, error) {
 	fake.newQueryCreatorMutex.Lock()
 	ret, specificReturn := fake.newQueryCreatorReturnsOnCall[len(fake.newQueryCreatorArgsForCall)]
@@ -1236,6 +1295,8 @@ func (fake *Support) Invocations() map[string][][]interface{} {
 	defer fake.getChaincodeDeploymentSpecFSMutex.RUnlock()
 	fake.getApplicationConfigMutex.RLock()
 	defer fake.getApplicationConfigMutex.RUnlock()
+	fake.getHashingSuiteMutex.RLock()
+	defer fake.getHashingSuiteMutex.RUnlock()
 	fake.newQueryCreatorMutex.RLock()
 	defer fake.newQueryCreatorMutex.RUnlock()
 	fake.endorseWithPluginMutex.RLock()
@@ -127,7 +127,8 @@ func (pbc *pluginsByChannel) createPluginIfAbsent(channel string) (endorsement.P
 func (pbc *pluginsByChannel) initPlugin(plugin endorsement.Plugin, channel string) (endorsement.Plugin, error) {
 	var dependencies []endorsement.Dependency
 	var err error
-	// If this is a channel endorsement, add the channel state as a dependency
+	// If this is a channel endorsement, add the channel state as a dependency.
+	// The channel state also provides read-only access to the token namespace.
 	if channel != "" {
 		query, err := pbc.pe.NewQueryCreator(channel)
 		if err != nil {
@@ -325,3 +325,73 @@ func TestTransientStore(t *testing.T) {
 	assert.True(t, proto.Equal(rws, txrws))
 	scanner.AssertCalled(t, "Close")
 }
+
+type fakeTokenEndorsementPlugin struct {
+	TokenStateFetcher
+}
+
+func (fep *fakeTokenEndorsementPlugin) Endorse(payload []byte, sp *peer.SignedProposal) (*peer.Endorsement, []byte, error) {
+	state, err := fep.TokenStateFetcher.FetchTokenState()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer state.Done()
+	value, err := state.GetState("token1")
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, value, nil
+}
+
+func (fep *fakeTokenEndorsementPlugin) Init(dependencies ...endorsement.Dependency) error {
+	for _, dep := range dependencies {
+		if state, isState := dep.(TokenStateFetcher); isState {
+			fep.TokenStateFetcher = state
+			return nil
+		}
+	}
+	panic("could not find TokenState dependency")
+}
+
+func TestTokenState(t *testing.T) {
+	plugin := &fakeTokenEndorsementPlugin{}
+	factory := &mocks.PluginFactory{}
+	factory.On("New").Return(plugin)
+	sif := &mocks.SigningIdentityFetcher{}
+	cs := &mocks.ChannelStateRetriever{}
+	queryCreator := &mocks.QueryCreator{}
+	queryCreator.On("NewQueryExecutor").Return(ledger.NewMockQueryExecutor(map[string]map[string][]byte{
+		"tms":  {"token1": []byte("token-value")},
+		"mycc": {"token1": []byte("chaincode-value")},
+	}), nil)
+	cs.On("NewQueryCreator", "mychannel").Return(queryCreator, nil)
+
+	pluginEndorser := endorser.NewPluginEndorser(&endorser.PluginSupport{
+		ChannelStateRetriever:  cs,
+		SigningIdentityFetcher: sif,
+		PluginMapper: endorser.MapBasedPluginMapper{
+			"plugin": factory,
+		},
+		TransientStoreRetriever: transientStoreRetriever(),
+	})
+
+	proposal, _, err := utils.CreateChaincodeProposal(common.HeaderType_ENDORSER_TRANSACTION, "mychannel", &peer.ChaincodeInvocationSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{
+			ChaincodeId: &peer.ChaincodeID{Name: "mycc"},
+		},
+	}, []byte{1, 2, 3})
+	assert.NoError(t, err)
+	ctx := endorser.Context{
+		Response:   &peer.Response{},
+		PluginName: "plugin",
+		Proposal:   proposal,
+		ChaincodeID: &peer.ChaincodeID{
+			Name: "mycc",
+		},
+		Channel: "mychannel",
+	}
+
+	resp, err := pluginEndorser.EndorseWithPlugin(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("token-value"), resp.Payload)
+}
@@ -7,15 +7,18 @@ SPDX-License-Identifier: Apache-2.0
 package endorser
 
 import (
+	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/core/handlers/endorsement/api/state"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/transientstore"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	tk "github.com/hyperledger/fabric/token"
 	"github.com/pkg/errors"
 )
 
-//go:generate mockery -dir . -name QueryCreator -case underscore -output mocks/
 // QueryCreator creates new QueryExecutors
+//
+//go:generate mockery -dir . -name QueryCreator -case underscore -output mocks/
 type QueryCreator interface {
 	NewQueryExecutor() (ledger.QueryExecutor, error)
 }
@@ -39,6 +42,16 @@ func (cs *ChannelState) FetchState() (endorsement.State, error) {
 	}, nil
 }
 
+// FetchTokenState fetches read-only access to the token namespace
+func (cs *ChannelState) FetchTokenState() (endorsement.TokenState, error) {
+	qe, err := cs.NewQueryExecutor()
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenStateContext{queryExecutor: qe}, nil
+}
+
 // StateContext defines an execution context that interacts with the state
 type StateContext struct {
 	transientstore.Store
@@ -68,3 +81,28 @@ func (sc *StateContext) GetTransientByTXID(txID string) ([]*rwset.TxPvtReadWrite
 	}
 	return data, nil
 }
+
+// TokenStateContext defines an execution context that reads from the token namespace
+type TokenStateContext struct {
+	queryExecutor ledger.QueryExecutor
+}
+
+// GetState gets the value for the given key in the token namespace
+func (tsc *TokenStateContext) GetState(key string) ([]byte, error) {
+	return tsc.queryExecutor.GetState(tk.Namespace, key)
+}
+
+// GetStateMultipleKeys gets the values for multiple keys in the token namespace
+func (tsc *TokenStateContext) GetStateMultipleKeys(keys []string) ([][]byte, error) {
+	return tsc.queryExecutor.GetStateMultipleKeys(tk.Namespace, keys)
+}
+
+// GetStateRangeScanIterator returns an iterator over a range of keys in the token namespace
+func (tsc *TokenStateContext) GetStateRangeScanIterator(startKey string, endKey string) (commonledger.ResultsIterator, error) {
+	return tsc.queryExecutor.GetStateRangeScanIterator(tk.Namespace, startKey, endKey)
+}
+
+// Done releases resources occupied by the TokenStateContext
+func (tsc *TokenStateContext) Done() {
+	tsc.queryExecutor.Done()
+}
@@ -181,3 +181,12 @@ func (s *SupportImpl) CheckInstantiationPolicy(name, version string, cd ccprovid
 func (s *SupportImpl) GetApplicationConfig(cid string) (channelconfig.Application, bool) {
 	return s.PeerSupport.GetApplicationConfig(cid)
 }
+
+// GetHashingSuite returns the algorithm computing the transaction IDs of the
+// Channel, or nil if the Channel does not exist
+func (s *SupportImpl) GetHashingSuite(channelID string) func([]byte) []byte {
+	if res := s.Peer.GetChannelConfig(channelID); res != nil {
+		return res.ChannelConfig().HashingSuite()
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package filter
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric/core/handlers/auth"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// NewQueryOnlyFilter creates a new Filter that refuses to endorse proposals of
+// query-only peers, except the proposals to the given chaincodes that only query
+// the ledger, such as the query system chaincode.
+func NewQueryOnlyFilter(queryChaincodes ...string) auth.Filter {
+	allowed := make(map[string]struct{}, len(queryChaincodes))
+	for _, name := range queryChaincodes {
+		allowed[name] = struct{}{}
+	}
+	return &queryOnlyFilter{queryChaincodes: allowed}
+}
+
+type queryOnlyFilter struct {
+	next            peer.EndorserServer
+	queryChaincodes map[string]struct{}
+}
+
+// Init initializes the Filter with the next EndorserServer
+func (f *queryOnlyFilter) Init(next peer.EndorserServer) {
+	f.next = next
+}
+
+func proposalChaincode(signedProp *peer.SignedProposal) (string, error) {
+	prop, err := utils.GetProposal(signedProp.ProposalBytes)
+	if err != nil {
+		return "", errors.Wrap(err, "failed parsing proposal")
+	}
+
+	hdr, err := utils.GetHeader(prop.Header)
+	if err != nil {
+		return "", errors.Wrap(err, "failed parsing header")
+	}
+
+	hdrExt, err := utils.GetChaincodeHeaderExtension(hdr)
+	if err != nil {
+		return "", errors.Wrap(err, "failed parsing chaincode header extension")
+	}
+	return hdrExt.GetChaincodeId().GetName(), nil
+}
+
+// ProcessProposal processes a signed proposal
+func (f *queryOnlyFilter) ProcessProposal(ctx context.Context, signedProp *peer.SignedProposal) (*peer.ProposalResponse, error) {
+	chaincode, err := proposalChaincode(signedProp)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := f.queryChaincodes[chaincode]; !ok {
+		return nil, errors.Errorf("peer is query-only and does not endorse proposals to chaincode %s", chaincode)
+	}
+	return f.next.ProcessProposal(ctx, signedProp)
+}
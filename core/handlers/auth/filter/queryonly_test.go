@@ -0,0 +1,64 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package filter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func createChaincodeSignedProposal(t *testing.T, chaincode string) *peer.SignedProposal {
+	cis := &peer.ChaincodeInvocationSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{
+			ChaincodeId: &peer.ChaincodeID{Name: chaincode},
+			Input:       &peer.ChaincodeInput{Args: [][]byte{[]byte("GetChainInfo"), []byte("mychannel")}},
+		},
+	}
+	prop, _, err := utils.CreateChaincodeProposal(common.HeaderType_ENDORSER_TRANSACTION, "mychannel", cis, []byte("creator"))
+	assert.NoError(t, err)
+	propBytes, err := proto.Marshal(prop)
+	assert.NoError(t, err)
+	return &peer.SignedProposal{ProposalBytes: propBytes}
+}
+
+func TestQueryOnlyFilter(t *testing.T) {
+	nextEndorser := &mockEndorserServer{}
+	auth := NewQueryOnlyFilter("qscc")
+	auth.Init(nextEndorser)
+
+	// Scenario I: Proposal to a query chaincode
+	_, err := auth.ProcessProposal(context.Background(), createChaincodeSignedProposal(t, "qscc"))
+	assert.NoError(t, err)
+	assert.True(t, nextEndorser.invoked)
+	nextEndorser.invoked = false
+
+	// Scenario II: Proposal to another chaincode
+	_, err = auth.ProcessProposal(context.Background(), createChaincodeSignedProposal(t, "mycc"))
+	assert.EqualError(t, err, "peer is query-only and does not endorse proposals to chaincode mycc")
+	assert.False(t, nextEndorser.invoked)
+
+	// Scenario III: Malformed proposal
+	sp := createChaincodeSignedProposal(t, "qscc")
+	sp.ProposalBytes = append(sp.ProposalBytes, 0)
+	_, err = auth.ProcessProposal(context.Background(), sp)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed parsing proposal")
+	assert.False(t, nextEndorser.invoked)
+
+	// Scenario IV: Malformed header
+	sp = createSignedProposalWithInvalidHeader(t, createX509Identity(t, "notExpiredCert.pem"))
+	_, err = auth.ProcessProposal(context.Background(), sp)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed parsing header")
+	assert.False(t, nextEndorser.invoked)
+}
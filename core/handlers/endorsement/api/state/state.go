@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package endorsement
 
 import (
+	"github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/core/handlers/endorsement/api"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
 )
@@ -33,3 +34,29 @@ type StateFetcher interface {
 	// FetchState fetches state
 	FetchState() (State, error)
 }
+
+// TokenState defines read-only interaction with the token namespace
+// of the world state
+type TokenState interface {
+	// GetState gets the value for the given key in the token namespace
+	GetState(key string) ([]byte, error)
+
+	// GetStateMultipleKeys gets the values for multiple keys in the token namespace in a single call
+	GetStateMultipleKeys(keys []string) ([][]byte, error)
+
+	// GetStateRangeScanIterator returns an iterator over the keys of the token namespace
+	// between startKey (inclusive) and endKey (exclusive).
+	// The returned ResultsIterator contains results of type *KV which is defined in protos/ledger/queryresult.
+	GetStateRangeScanIterator(startKey string, endKey string) (ledger.ResultsIterator, error)
+
+	// Done releases resources occupied by the TokenState
+	Done()
+}
+
+// TokenStateFetcher retrieves an instance of the token state
+type TokenStateFetcher interface {
+	endorsement.Dependency
+
+	// FetchTokenState fetches the token state
+	FetchTokenState() (TokenState, error)
+}
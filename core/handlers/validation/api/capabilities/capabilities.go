@@ -54,4 +54,13 @@ type Capabilities interface {
 
 	// FabToken returns true if fabric token function is supported.
 	FabToken() bool
+
+	// WASMChaincode returns true if WebAssembly chaincode may be deployed.
+	WASMChaincode() bool
+
+	// TokenEncryption returns true if token transactions may be encrypted to the committing peers.
+	TokenEncryption() bool
+
+	// TxExpiration returns true if expired transactions are invalidated.
+	TxExpiration() bool
 }
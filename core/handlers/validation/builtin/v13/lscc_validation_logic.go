@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/java"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/node"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/wasm"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/privdata"
 	. "github.com/hyperledger/fabric/core/handlers/validation/api/state"
@@ -379,16 +380,21 @@ func (vscc *Validator) ValidateLSCCInvocation(
 			return policyErr(fmt.Errorf("Wrong number of arguments for invocation lscc(%s): received %d", lsccFunc, len(lsccArgs)))
 		}
 
-		cdsArgs, err := utils.GetChaincodeDeploymentSpec(lsccArgs[1], platforms.NewRegistry(
-			// XXX We should definitely _not_ have this external dependency in VSCC
-			// as adding a platform could cause non-determinism.  This is yet another
-			// reason why all of this custom LSCC validation at commit time has no
-			// long term hope of staying deterministic and needs to be removed.
+		// XXX We should definitely _not_ have this external dependency in VSCC
+		// as adding a platform could cause non-determinism.  This is yet another
+		// reason why all of this custom LSCC validation at commit time has no
+		// long term hope of staying deterministic and needs to be removed.
+		supportedPlatforms := []platforms.Platform{
 			&golang.Platform{},
 			&node.Platform{},
 			&java.Platform{},
 			&car.Platform{},
-		))
+		}
+		// the wasm platform is only known to peers that support the capability
+		if ac.WASMChaincode() {
+			supportedPlatforms = append(supportedPlatforms, &wasm.Platform{})
+		}
+		cdsArgs, err := utils.GetChaincodeDeploymentSpec(lsccArgs[1], platforms.NewRegistry(supportedPlatforms...))
 
 		if err != nil {
 			return policyErr(fmt.Errorf("GetChaincodeDeploymentSpec error %s", err))
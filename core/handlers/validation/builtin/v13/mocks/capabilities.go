@@ -120,6 +120,34 @@ func (_m *Capabilities) Supported() error {
 	return r0
 }
 
+// TokenEncryption provides a mock function with given fields:
+func (_m *Capabilities) TokenEncryption() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// TxExpiration provides a mock function with given fields:
+func (_m *Capabilities) TxExpiration() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // V1_1Validation provides a mock function with given fields:
 func (_m *Capabilities) V1_1Validation() bool {
 	ret := _m.Called()
@@ -161,3 +189,17 @@ func (_m *Capabilities) V1_3Validation() bool {
 
 	return r0
 }
+
+// WASMChaincode provides a mock function with given fields:
+func (_m *Capabilities) WASMChaincode() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
@@ -33,3 +33,12 @@ func (e *InvalidTxError) Error() string {
 type Processor interface {
 	GenerateSimulationResults(txEnvelop *common.Envelope, simulator ledger.TxSimulator, initializingLedger bool) error
 }
+
+// BlockProcessor is a Processor whose processing of a transaction depends on the number of the block
+// the transaction is in, e.g. to apply limits over ranges of blocks. The ledger calls
+// `GenerateSimulationResultsInBlock` instead of `GenerateSimulationResults` on the processors that implement it.
+// As the blocks are committed in order, the processing stays deterministic across the peers.
+type BlockProcessor interface {
+	Processor
+	GenerateSimulationResultsInBlock(txEnvelop *common.Envelope, blockNum uint64, simulator ledger.TxSimulator, initializingLedger bool) error
+}
@@ -21,6 +21,8 @@ const (
 	PvtdataExpiry Category = iota
 	// MetadataPresenceIndicator maintains the bookkeeping about whether metadata is ever set for a namespace
 	MetadataPresenceIndicator
+	// CustomTxWriteSets maintains the write-ahead records of the write sets of the custom transactions of a block
+	CustomTxWriteSets
 )
 
 // Provider provides handle to different bookkeepers for the given ledger
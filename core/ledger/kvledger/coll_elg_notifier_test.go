@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package kvledger
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/hyperledger/fabric/core/ledger"
@@ -45,6 +46,7 @@ func TestCollElgNotifier(t *testing.T) {
 		mockDeployedChaincodeInfoProvider,
 		mockMembershipInfoProvider,
 		make(map[string]collElgListener),
+		sync.RWMutex{},
 	}
 	collElgNotifier.registerListener("testLedger", mockCollElgListener)
 
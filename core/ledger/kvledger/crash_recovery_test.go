@@ -0,0 +1,196 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	lgrutil "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// balanceTxProcessor adds the amount of a transaction to the balance of an
+// account in the state, as the processor of token transactions updates the
+// outputs and the spent keys, so that its write sets depend on the state it
+// runs against. It rejects the transactions when it is unavailable, as the
+// processor of token transactions does while the channel config is not loaded.
+type balanceTxProcessor struct {
+	unavailable bool
+}
+
+func (p *balanceTxProcessor) GenerateSimulationResults(txEnvelop *common.Envelope, simulator ledger.TxSimulator, initializingLedger bool) error {
+	if p.unavailable {
+		return &customtx.InvalidTxError{Msg: "processor unavailable"}
+	}
+	payload := utils.UnmarshalPayloadOrPanic(txEnvelop.Payload)
+	chHdr, _ := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	chainid := chHdr.ChannelId
+	kvw := &kvrwset.KVWrite{}
+	if err := proto.Unmarshal(payload.Data, kvw); err != nil {
+		return err
+	}
+	amount, err := strconv.Atoi(string(kvw.Value))
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: err.Error()}
+	}
+	balance, err := getBalance(simulator, chainid, kvw.Key)
+	if err != nil {
+		return err
+	}
+	return simulator.SetState(chainid, kvw.Key, []byte(strconv.Itoa(balance+amount)))
+}
+
+func getBalance(qe ledger.SimpleQueryExecutor, chainid, account string) (int, error) {
+	val, err := qe.GetState(chainid, account)
+	if err != nil || val == nil {
+		return 0, err
+	}
+	return strconv.Atoi(string(val))
+}
+
+func TestCrashRecoveryOfCustomTxs(t *testing.T) {
+	// the peer stops at every sub-step of the commit of block 2
+	crashes := []struct {
+		name  string
+		crash func(t *testing.T, l *kvLedger, provider *Provider, blockAndPvtdata *ledger.BlockAndPvtData)
+	}{
+		{
+			name: "after validating the block",
+			crash: func(t *testing.T, l *kvLedger, provider *Provider, blockAndPvtdata *ledger.BlockAndPvtData) {
+				_, err := l.txtmgmt.ValidateAndPrepare(blockAndPvtdata, true)
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "after committing to the block storage",
+			crash: func(t *testing.T, l *kvLedger, provider *Provider, blockAndPvtdata *ledger.BlockAndPvtData) {
+				_, err := l.txtmgmt.ValidateAndPrepare(blockAndPvtdata, true)
+				require.NoError(t, err)
+				require.NoError(t, l.blockStore.CommitWithPvtData(blockAndPvtdata))
+			},
+		},
+		{
+			name: "while committing to the state DB",
+			crash: func(t *testing.T, l *kvLedger, provider *Provider, blockAndPvtdata *ledger.BlockAndPvtData) {
+				_, err := l.txtmgmt.ValidateAndPrepare(blockAndPvtdata, true)
+				require.NoError(t, err)
+				require.NoError(t, l.blockStore.CommitWithPvtData(blockAndPvtdata))
+				require.NoError(t, l.txtmgmt.Commit())
+				// the updates of the block are in the state DB but the savepoint
+				// is not, as when the updates of CouchDB are partially committed
+				db, err := provider.vdbProvider.GetDBHandle(l.ledgerID)
+				require.NoError(t, err)
+				require.NoError(t, db.ApplyPrivacyAwareUpdates(privacyenabledstate.NewUpdateBatch(), version.NewHeight(1, 0)))
+			},
+		},
+		{
+			name: "after committing to the state DB",
+			crash: func(t *testing.T, l *kvLedger, provider *Provider, blockAndPvtdata *ledger.BlockAndPvtData) {
+				_, err := l.txtmgmt.ValidateAndPrepare(blockAndPvtdata, true)
+				require.NoError(t, err)
+				require.NoError(t, l.blockStore.CommitWithPvtData(blockAndPvtdata))
+				require.NoError(t, l.txtmgmt.Commit())
+			},
+		},
+		{
+			name: "after committing to the history DB",
+			crash: func(t *testing.T, l *kvLedger, provider *Provider, blockAndPvtdata *ledger.BlockAndPvtData) {
+				require.NoError(t, l.CommitWithPvtData(blockAndPvtdata))
+			},
+		},
+	}
+
+	for _, tt := range crashes {
+		t.Run(tt.name, func(t *testing.T) {
+			testCrashRecoveryOfCustomTxs(t, tt.crash, false)
+		})
+		// the channel config is not loaded yet when the peer opens the ledgers
+		t.Run(tt.name+" with the processor unavailable", func(t *testing.T) {
+			testCrashRecoveryOfCustomTxs(t, tt.crash, true)
+		})
+	}
+}
+
+func testCrashRecoveryOfCustomTxs(t *testing.T, crash func(*testing.T, *kvLedger, *Provider, *ledger.BlockAndPvtData), unavailable bool) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	processor := &balanceTxProcessor{}
+	customtx.InitializeTestEnv(customtx.Processors{100: processor})
+
+	chainid := "testLedger"
+	provider := testutilNewProvider(t)
+	_, gb := testutil.NewBlockGenerator(t, chainid, false)
+	lgr, err := provider.Create(gb)
+	require.NoError(t, err)
+
+	blk1 := testutil.NewBlock([]*common.Envelope{createCustomTx(t, 100, chainid, "alice", "5")}, 1, gb.Header.Hash())
+	require.NoError(t, lgr.CommitWithPvtData(&ledger.BlockAndPvtData{Block: blk1}))
+	checkBalancesForTest(t, lgr, chainid, 1, map[string]int{"alice": 5, "bob": 0})
+
+	blk2 := testutil.NewBlock([]*common.Envelope{
+		createCustomTx(t, 100, chainid, "alice", "3"),
+		createCustomTx(t, 100, chainid, "bob", "4"),
+	}, 2, blk1.Header.Hash())
+	crash(t, lgr.(*kvLedger), provider.(*Provider), &ledger.BlockAndPvtData{Block: blk2})
+	lgr.Close()
+	provider.Close()
+
+	processor.unavailable = unavailable
+	provider = testutilNewProvider(t)
+	defer provider.Close()
+	lgr, err = provider.Open(chainid)
+	require.NoError(t, err)
+	defer lgr.Close()
+	processor.unavailable = false
+
+	info, err := lgr.GetBlockchainInfo()
+	require.NoError(t, err)
+	if info.Height == 2 {
+		// the block did not reach the block storage and is delivered again
+		checkBalancesForTest(t, lgr, chainid, 1, map[string]int{"alice": 5, "bob": 0})
+		require.NoError(t, lgr.CommitWithPvtData(&ledger.BlockAndPvtData{Block: blk2}))
+	}
+	checkBalancesForTest(t, lgr, chainid, 2, map[string]int{"alice": 8, "bob": 4})
+	historySavepoint, err := lgr.(*kvLedger).historyDB.GetLastSavepoint()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), historySavepoint.BlockNum)
+	blockPersisted, err := lgr.GetBlockByNumber(2)
+	require.NoError(t, err)
+	txFilter := lgrutil.TxValidationFlags(blockPersisted.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	assert.Equal(t, peer.TxValidationCode_VALID, txFilter.Flag(0))
+	assert.Equal(t, peer.TxValidationCode_VALID, txFilter.Flag(1))
+
+	blk3 := testutil.NewBlock([]*common.Envelope{createCustomTx(t, 100, chainid, "alice", "1")}, 3, blk2.Header.Hash())
+	require.NoError(t, lgr.CommitWithPvtData(&ledger.BlockAndPvtData{Block: blk3}))
+	checkBalancesForTest(t, lgr, chainid, 3, map[string]int{"alice": 9, "bob": 4})
+}
+
+func checkBalancesForTest(t *testing.T, l ledger.PeerLedger, chainid string, savepoint uint64, expectedBalances map[string]int) {
+	actualSavepoint, err := l.(*kvLedger).txtmgmt.GetLastSavepoint()
+	require.NoError(t, err)
+	assert.Equal(t, savepoint, actualSavepoint.BlockNum)
+	qe, err := l.NewQueryExecutor()
+	require.NoError(t, err)
+	defer qe.Done()
+	for account, expectedBalance := range expectedBalances {
+		balance, err := getBalance(qe, chainid, account)
+		require.NoError(t, err)
+		assert.Equal(t, expectedBalance, balance, "balance of %s", account)
+	}
+}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package kvledger
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
@@ -38,6 +39,21 @@ func (ctp *customTxProcessor) GenerateSimulationResults(txEnvelop *common.Envelo
 	return simulator.SetState(chainid, kvw.Key, kvw.Value)
 }
 
+// blockTxProcessor sets the key of a transaction to the number of its block
+type blockTxProcessor struct {
+	customTxProcessor
+}
+
+func (btp *blockTxProcessor) GenerateSimulationResultsInBlock(txEnvelop *common.Envelope, blockNum uint64, simulator ledger.TxSimulator, initializingLedger bool) error {
+	payload := utils.UnmarshalPayloadOrPanic(txEnvelop.Payload)
+	chHdr, _ := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	kvw := &kvrwset.KVWrite{}
+	if err := proto.Unmarshal(payload.Data, kvw); err != nil {
+		return err
+	}
+	return simulator.SetState(chHdr.ChannelId, kvw.Key, []byte(strconv.FormatUint(blockNum, 10)))
+}
+
 func TestCustomProcessor(t *testing.T) {
 	env := newTestEnv(t)
 	defer env.cleanup()
@@ -100,3 +116,34 @@ func createCustomTx(t *testing.T, txType common.HeaderType, chainid, key, val st
 	assert.NoError(t, err)
 	return txEnv
 }
+
+func TestCustomBlockProcessor(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	chainid := "testLedger"
+	customtx.InitializeTestEnv(customtx.Processors{100: &blockTxProcessor{}})
+
+	_, gb := testutil.NewBlockGenerator(t, chainid, false)
+	lgr, err := provider.Create(gb)
+	assert.NoError(t, err)
+	defer lgr.Close()
+
+	blk1 := testutil.NewBlock([]*common.Envelope{createCustomTx(t, 100, chainid, "key1", "value1")}, 1, gb.Header.Hash())
+	assert.NoError(t, lgr.CommitWithPvtData(&ledger.BlockAndPvtData{Block: blk1}))
+	blk2 := testutil.NewBlock([]*common.Envelope{createCustomTx(t, 100, chainid, "key2", "value2")}, 2, blk1.Header.Hash())
+	assert.NoError(t, lgr.CommitWithPvtData(&ledger.BlockAndPvtData{Block: blk2}))
+
+	// the processor is given the number of the block of each transaction
+	qe, err := lgr.NewQueryExecutor()
+	assert.NoError(t, err)
+	defer qe.Done()
+	val, err := qe.GetState(chainid, "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", string(val))
+	val, err = qe.GetState(chainid, "key2")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", string(val))
+}
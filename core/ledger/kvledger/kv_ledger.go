@@ -41,6 +41,8 @@ type kvLedger struct {
 	configHistoryRetriever ledger.ConfigHistoryRetriever
 	blockAPIsRWLock        *sync.RWMutex
 	stats                  *ledgerStats
+	stateListenersTimer    *stateListenersTimer
+	readConflictListener   ledger.ReadConflictListener
 }
 
 // NewKVLedger constructs new `KVLedger`
@@ -58,7 +60,7 @@ func newKVLedger(
 	logger.Debugf("Creating KVLedger ledgerID=%s: ", ledgerID)
 	// Create a kvLedger for this chain/ledger, which encasulates the underlying
 	// id store, blockstore, txmgr (state database), history database
-	l := &kvLedger{ledgerID: ledgerID, blockStore: blockStore, historyDB: historyDB, blockAPIsRWLock: &sync.RWMutex{}}
+	l := &kvLedger{ledgerID: ledgerID, blockStore: blockStore, historyDB: historyDB, blockAPIsRWLock: &sync.RWMutex{}, stateListenersTimer: &stateListenersTimer{}}
 
 	// TODO Move the function `GetChaincodeEventListener` to ledger interface and
 	// this functionality of regiserting for events to ledgermgmt package so that this
@@ -69,7 +71,7 @@ func newKVLedger(
 		cceventmgmt.GetMgr().Register(ledgerID, ccEventListener)
 	}
 	btlPolicy := pvtdatapolicy.ConstructBTLPolicy(&collectionInfoRetriever{l, ccInfoProvider})
-	if err := l.initTxMgr(versionedDB, stateListeners, btlPolicy, bookkeeperProvider, ccInfoProvider); err != nil {
+	if err := l.initTxMgr(versionedDB, l.stateListenersTimer.wrap(stateListeners), btlPolicy, bookkeeperProvider, ccInfoProvider); err != nil {
 		return nil, err
 	}
 	l.initBlockStore(btlPolicy)
@@ -298,6 +300,8 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) er
 	blockNo := pvtdataAndBlock.Block.Header.Number
 
 	startBlockProcessing := time.Now()
+	// discard the time spent by the state listeners outside of the commit of blocks, such as during recovery
+	l.stateListenersTimer.reset()
 	logger.Debugf("[%s] Validating state for block [%d]", l.ledgerID, blockNo)
 	txstatsInfo, err := l.txtmgmt.ValidateAndPrepare(pvtdataAndBlock, true)
 	if err != nil {
@@ -314,6 +318,22 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) er
 	}
 	elapsedCommitBlockStorage := time.Since(startCommitBlockStorage)
 
+	// History database only depends on the block, so it can be written in parallel with state
+	historyEnabled := ledgerconfig.IsHistoryDBEnabled()
+	concurrentHistoryCommit := historyEnabled && ledgerconfig.IsHistoryDBCommitConcurrent()
+	historyCommitted := make(chan time.Duration, 1)
+	commitHistory := func() {
+		startCommitHistory := time.Now()
+		logger.Debugf("[%s] Committing block [%d] transactions to history database", l.ledgerID, blockNo)
+		if err := l.historyDB.Commit(block); err != nil {
+			panic(errors.WithMessage(err, "Error during commit to history db"))
+		}
+		historyCommitted <- time.Since(startCommitHistory)
+	}
+	if concurrentHistoryCommit {
+		go commitHistory()
+	}
+
 	startCommitState := time.Now()
 	logger.Debugf("[%s] Committing block [%d] transactions to state database", l.ledgerID, blockNo)
 	if err = l.txtmgmt.Commit(); err != nil {
@@ -321,13 +341,11 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) er
 	}
 	elapsedCommitState := time.Since(startCommitState)
 
-	// History database could be written in parallel with state and/or async as a future optimization,
-	// although it has not been a bottleneck...no need to clutter the log with elapsed duration.
-	if ledgerconfig.IsHistoryDBEnabled() {
-		logger.Debugf("[%s] Committing block [%d] transactions to history database", l.ledgerID, blockNo)
-		if err := l.historyDB.Commit(block); err != nil {
-			panic(errors.WithMessage(err, "Error during commit to history db"))
-		}
+	if historyEnabled && !concurrentHistoryCommit {
+		commitHistory()
+	}
+	if historyEnabled {
+		l.stats.updateHistorydbCommitTime(<-historyCommitted)
 	}
 
 	elapsedCommitWithPvtData := time.Since(startBlockProcessing)
@@ -345,9 +363,33 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) er
 		elapsedCommitState,
 		txstatsInfo,
 	)
+	l.stats.updateTokenProcessingTime(txstatsInfo)
+	l.stats.updateStateListenersTime(l.stateListenersTimer.reset())
+	l.notifyReadConflicts(blockNo, txstatsInfo)
 	return nil
 }
 
+// notifyReadConflicts passes the transactions of the block that were invalidated
+// by read conflicts to the read conflict listener, if any
+func (l *kvLedger) notifyReadConflicts(blockNum uint64, txstatsInfo []*txmgr.TxStatInfo) {
+	if l.readConflictListener == nil {
+		return
+	}
+	var conflicts []*ledger.TxReadConflict
+	for txNum, txStatInfo := range txstatsInfo {
+		if txStatInfo.ReadConflict == nil {
+			continue
+		}
+		conflicts = append(conflicts, &ledger.TxReadConflict{
+			TxID:           txStatInfo.TxID,
+			TxNum:          uint64(txNum),
+			ValidationCode: txStatInfo.ValidationCode,
+			Conflict:       txStatInfo.ReadConflict,
+		})
+	}
+	l.readConflictListener.HandleReadConflicts(l.ledgerID, blockNum, conflicts)
+}
+
 func (l *kvLedger) updateBlockStats(
 	blockNum uint64,
 	blockProcessingTime time.Duration,
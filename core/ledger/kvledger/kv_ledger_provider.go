@@ -9,6 +9,7 @@ package kvledger
 import (
 	"bytes"
 	"fmt"
+	"sync"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
@@ -75,6 +76,7 @@ func (provider *Provider) Initialize(initializer *ledger.Initializer) error {
 		initializer.DeployedChaincodeInfoProvider,
 		initializer.MembershipInfoProvider,
 		make(map[string]collElgListener),
+		sync.RWMutex{},
 	}
 	stateListeners := initializer.StateListeners
 	stateListeners = append(stateListeners, collElgNotifier)
@@ -176,6 +178,7 @@ func (provider *Provider) openInternal(ledgerID string) (ledger.PeerLedger, erro
 	if err != nil {
 		return nil, err
 	}
+	l.readConflictListener = provider.initializer.ReadConflictListener
 	return l, nil
 }
 
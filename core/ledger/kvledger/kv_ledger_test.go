@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package kvledger
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
@@ -20,6 +21,7 @@ import (
 	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/peer"
 	putils "github.com/hyperledger/fabric/protos/utils"
 	"github.com/spf13/viper"
@@ -551,6 +553,114 @@ func TestLedgerWithCouchDbEnabledWithBinaryAndJSONData(t *testing.T) {
 	}
 }
 
+func TestKVLedgerConcurrentHistoryCommit(t *testing.T) {
+	viper.Set("ledger.history.commitConcurrently", true)
+	defer viper.Set("ledger.history.commitConcurrently", false)
+
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, _ := provider.Create(gb)
+	defer ledger.Close()
+
+	for i := 0; i < 3; i++ {
+		simulator, _ := ledger.NewTxSimulator(util.GenerateUUID())
+		simulator.SetState("ns1", "key1", []byte(fmt.Sprintf("value%d", i)))
+		simulator.Done()
+		simRes, _ := simulator.GetTxSimulationResults()
+		pubSimBytes, _ := simRes.GetPubSimulationBytes()
+		assert.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: bg.NextBlock([][]byte{pubSimBytes})}))
+	}
+
+	qe, err := ledger.NewQueryExecutor()
+	assert.NoError(t, err)
+	value, err := qe.GetState("ns1", "key1")
+	qe.Done()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value2"), value)
+
+	qhistory, err := ledger.NewHistoryQueryExecutor()
+	assert.NoError(t, err)
+	itr, err := qhistory.GetHistoryForKey("ns1", "key1")
+	assert.NoError(t, err)
+	count := 0
+	for {
+		kmod, _ := itr.Next()
+		if kmod == nil {
+			break
+		}
+		count++
+	}
+	assert.Equal(t, 3, count)
+}
+
+type readConflictRecorder struct {
+	ledgerID  string
+	blockNum  uint64
+	conflicts []*lgr.TxReadConflict
+}
+
+func (r *readConflictRecorder) HandleReadConflicts(ledgerID string, blockNum uint64, conflicts []*lgr.TxReadConflict) {
+	r.ledgerID, r.blockNum, r.conflicts = ledgerID, blockNum, conflicts
+}
+
+func TestKVLedgerReadConflictListener(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, _ := provider.Create(gb)
+	defer ledger.Close()
+	recorder := &readConflictRecorder{}
+	ledger.(*kvLedger).readConflictListener = recorder
+
+	// both transactions read and update key1, so the second one conflicts with the first one
+	var txs [][]byte
+	for i := 0; i < 2; i++ {
+		simulator, _ := ledger.NewTxSimulator(util.GenerateUUID())
+		_, err := simulator.GetState("ns1", "key1")
+		assert.NoError(t, err)
+		simulator.SetState("ns1", "key1", []byte(fmt.Sprintf("value%d", i)))
+		simulator.Done()
+		simRes, _ := simulator.GetTxSimulationResults()
+		pubSimBytes, _ := simRes.GetPubSimulationBytes()
+		txs = append(txs, pubSimBytes)
+	}
+	block := bg.NextBlock(txs)
+	assert.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block}))
+
+	chdr, err := putils.ChannelHeader(putils.ExtractEnvelopeOrPanic(block, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, "testLedger", recorder.ledgerID)
+	assert.Equal(t, uint64(1), recorder.blockNum)
+	assert.Equal(t, []*lgr.TxReadConflict{
+		{
+			TxID:           chdr.TxId,
+			TxNum:          1,
+			ValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT,
+			Conflict: &peer.ReadConflict{
+				Namespace:        "ns1",
+				Key:              "key1",
+				CommittedVersion: &kvrwset.Version{BlockNum: 1, TxNum: 0},
+				UpdatedInBlock:   true,
+			},
+		},
+	}, recorder.conflicts)
+
+	// blocks without read conflicts are notified with no conflicts
+	simulator, _ := ledger.NewTxSimulator(util.GenerateUUID())
+	simulator.SetState("ns1", "key2", []byte("value"))
+	simulator.Done()
+	simRes, _ := simulator.GetTxSimulationResults()
+	pubSimBytes, _ := simRes.GetPubSimulationBytes()
+	assert.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: bg.NextBlock([][]byte{pubSimBytes})}))
+	assert.Equal(t, uint64(2), recorder.blockNum)
+	assert.Nil(t, recorder.conflicts)
+}
+
 func prepareNextBlockWithMissingPvtDataForTest(t *testing.T, l lgr.PeerLedger, bg *testutil.BlockGenerator,
 	txid string, pubKVs map[string]string, pvtKVs map[string]string) (*lgr.BlockAndPvtData, *lgr.TxPvtData) {
 
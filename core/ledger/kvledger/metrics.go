@@ -6,10 +6,13 @@ SPDX-License-Identifier: Apache-2.0
 package kvledger
 
 import (
+	"sync/atomic"
 	"time"
 
 	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
+	"github.com/hyperledger/fabric/protos/common"
 )
 
 type stats struct {
@@ -17,6 +20,9 @@ type stats struct {
 	blockProcessingTime    metrics.Histogram
 	blockstorageCommitTime metrics.Histogram
 	statedbCommitTime      metrics.Histogram
+	historydbCommitTime    metrics.Histogram
+	tokenProcessingTime    metrics.Histogram
+	stateListenersTime     metrics.Histogram
 	transactionsCount      metrics.Counter
 }
 
@@ -26,6 +32,9 @@ func newStats(metricsProvider metrics.Provider) *stats {
 	stats.blockProcessingTime = metricsProvider.NewHistogram(blockProcessingTimeOpts)
 	stats.blockstorageCommitTime = metricsProvider.NewHistogram(blockstorageCommitTimeOpts)
 	stats.statedbCommitTime = metricsProvider.NewHistogram(statedbCommitTimeOpts)
+	stats.historydbCommitTime = metricsProvider.NewHistogram(historydbCommitTimeOpts)
+	stats.tokenProcessingTime = metricsProvider.NewHistogram(tokenProcessingTimeOpts)
+	stats.stateListenersTime = metricsProvider.NewHistogram(stateListenersTimeOpts)
 	stats.transactionsCount = metricsProvider.NewCounter(transactionCountOpts)
 	return stats
 }
@@ -59,6 +68,30 @@ func (s *ledgerStats) updateStatedbCommitTime(timeTaken time.Duration) {
 	s.stats.statedbCommitTime.With("channel", s.ledgerid).Observe(timeTaken.Seconds())
 }
 
+func (s *ledgerStats) updateHistorydbCommitTime(timeTaken time.Duration) {
+	s.stats.historydbCommitTime.With("channel", s.ledgerid).Observe(timeTaken.Seconds())
+}
+
+func (s *ledgerStats) updateStateListenersTime(timeTaken time.Duration) {
+	s.stats.stateListenersTime.With("channel", s.ledgerid).Observe(timeTaken.Seconds())
+}
+
+// updateTokenProcessingTime records the time taken for processing the token transactions
+// of a block, if any
+func (s *ledgerStats) updateTokenProcessingTime(txstatsInfo []*txmgr.TxStatInfo) {
+	var timeTaken time.Duration
+	tokenTxs := false
+	for _, txstat := range txstatsInfo {
+		if txstat.TxType == common.HeaderType_TOKEN_TRANSACTION {
+			timeTaken += txstat.ProcessingTime
+			tokenTxs = true
+		}
+	}
+	if tokenTxs {
+		s.stats.tokenProcessingTime.With("channel", s.ledgerid).Observe(timeTaken.Seconds())
+	}
+}
+
 func (s *ledgerStats) updateTransactionsStats(
 	txstatsInfo []*txmgr.TxStatInfo,
 ) {
@@ -82,6 +115,45 @@ func (s *ledgerStats) updateTransactionsStats(
 	}
 }
 
+// stateListenersTimer measures the time spent by the state listeners of a ledger
+// handling the state updates of a block, and being notified of its commit
+type stateListenersTimer struct {
+	elapsed int64
+}
+
+// wrap returns the listeners, measured by the timer
+func (t *stateListenersTimer) wrap(listeners []ledger.StateListener) []ledger.StateListener {
+	var timed []ledger.StateListener
+	for _, l := range listeners {
+		timed = append(timed, &timedStateListener{StateListener: l, timer: t})
+	}
+	return timed
+}
+
+// reset returns the time measured since the previous reset
+func (t *stateListenersTimer) reset() time.Duration {
+	return time.Duration(atomic.SwapInt64(&t.elapsed, 0))
+}
+
+func (t *stateListenersTimer) add(start time.Time) {
+	atomic.AddInt64(&t.elapsed, int64(time.Since(start)))
+}
+
+type timedStateListener struct {
+	ledger.StateListener
+	timer *stateListenersTimer
+}
+
+func (l *timedStateListener) HandleStateUpdates(trigger *ledger.StateUpdateTrigger) error {
+	defer l.timer.add(time.Now())
+	return l.StateListener.HandleStateUpdates(trigger)
+}
+
+func (l *timedStateListener) StateCommitDone(channelID string) {
+	defer l.timer.add(time.Now())
+	l.StateListener.StateCommitDone(channelID)
+}
+
 var (
 	blockchainHeightOpts = metrics.GaugeOpts{
 		Namespace:    "ledger",
@@ -122,6 +194,36 @@ var (
 		Buckets:      []float64{0.005, 0.01, 0.015, 0.05, 0.1, 1, 10},
 	}
 
+	historydbCommitTimeOpts = metrics.HistogramOpts{
+		Namespace:    "ledger",
+		Subsystem:    "",
+		Name:         "historydb_commit_time",
+		Help:         "Time taken in seconds for committing block changes to history db.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+		Buckets:      []float64{0.005, 0.01, 0.015, 0.05, 0.1, 1, 10},
+	}
+
+	tokenProcessingTimeOpts = metrics.HistogramOpts{
+		Namespace:    "ledger",
+		Subsystem:    "",
+		Name:         "token_processing_time",
+		Help:         "Time taken in seconds for processing the token transactions of a block.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+		Buckets:      []float64{0.005, 0.01, 0.015, 0.05, 0.1, 1, 10},
+	}
+
+	stateListenersTimeOpts = metrics.HistogramOpts{
+		Namespace:    "ledger",
+		Subsystem:    "",
+		Name:         "state_listeners_time",
+		Help:         "Time taken in seconds for notifying the state listeners of block changes.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+		Buckets:      []float64{0.005, 0.01, 0.015, 0.05, 0.1, 1, 10},
+	}
+
 	transactionCountOpts = metrics.CounterOpts{
 		Namespace:    "ledger",
 		Subsystem:    "",
@@ -175,12 +175,77 @@ func TestStatsBlockCommit(t *testing.T) {
 	)
 }
 
+func TestStatsCommitStages(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	testMetricProvider := testutilConstructMetricProvider()
+	provider, err := NewProvider()
+	assert.NoError(t, err)
+	provider.Initialize(&lgr.Initializer{
+		DeployedChaincodeInfoProvider: &mock.DeployedChaincodeInfoProvider{},
+		MetricsProvider:               testMetricProvider.fakeProvider,
+	})
+	defer provider.Close()
+
+	ledgerid := "ledger1"
+	_, gb := testutil.NewBlockGenerator(t, ledgerid, false)
+	l, err := provider.Create(gb)
+	assert.NoError(t, err)
+	ledger := l.(*kvLedger)
+	defer ledger.Close()
+
+	// the genesis block has no token transactions
+	assert.Equal(t, 0, testMetricProvider.fakeTokenProcessingTimeHist.ObserveCallCount())
+	assert.Equal(t, 1, testMetricProvider.fakeStateListenersTimeHist.ObserveCallCount())
+	assert.Equal(t, []string{"channel", ledgerid}, testMetricProvider.fakeStateListenersTimeHist.WithArgsForCall(0))
+
+	ledger.stats.updateTokenProcessingTime([]*txmgr.TxStatInfo{
+		{TxType: common.HeaderType_TOKEN_TRANSACTION, ProcessingTime: time.Second},
+		{TxType: common.HeaderType_ENDORSER_TRANSACTION, ProcessingTime: time.Minute},
+		{TxType: common.HeaderType_TOKEN_TRANSACTION, ProcessingTime: 2 * time.Second},
+	})
+	assert.Equal(t, 1, testMetricProvider.fakeTokenProcessingTimeHist.ObserveCallCount())
+	assert.Equal(t, []string{"channel", ledgerid}, testMetricProvider.fakeTokenProcessingTimeHist.WithArgsForCall(0))
+	assert.Equal(t, float64(3), testMetricProvider.fakeTokenProcessingTimeHist.ObserveArgsForCall(0))
+
+	// the history database is enabled in the tests of this package
+	assert.Equal(t, 1, testMetricProvider.fakeHistorydbCommitTimeHist.ObserveCallCount())
+	ledger.stats.updateHistorydbCommitTime(4 * time.Second)
+	assert.Equal(t, []string{"channel", ledgerid}, testMetricProvider.fakeHistorydbCommitTimeHist.WithArgsForCall(1))
+	assert.Equal(t, float64(4), testMetricProvider.fakeHistorydbCommitTimeHist.ObserveArgsForCall(1))
+}
+
+func TestStateListenersTimer(t *testing.T) {
+	listener := &mock.StateListener{}
+	listener.InterestedInNamespacesReturns([]string{"ns"})
+	listener.HandleStateUpdatesStub = func(*lgr.StateUpdateTrigger) error {
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	}
+	timer := &stateListenersTimer{}
+
+	timed := timer.wrap([]lgr.StateListener{listener})
+	assert.Len(t, timed, 1)
+	assert.Equal(t, []string{"ns"}, timed[0].InterestedInNamespaces())
+	assert.NoError(t, timed[0].HandleStateUpdates(&lgr.StateUpdateTrigger{}))
+	timed[0].StateCommitDone("ledger1")
+	assert.Equal(t, 1, listener.HandleStateUpdatesCallCount())
+	assert.Equal(t, 1, listener.StateCommitDoneCallCount())
+
+	assert.True(t, timer.reset() >= 10*time.Millisecond)
+	assert.Equal(t, time.Duration(0), timer.reset())
+	assert.Nil(t, timer.wrap(nil))
+}
+
 type testMetricProvider struct {
 	fakeProvider                   *metricsfakes.Provider
 	fakeBlockchainHeightGauge      *metricsfakes.Gauge
 	fakeBlockProcessingTimeHist    *metricsfakes.Histogram
 	fakeBlockstorageCommitTimeHist *metricsfakes.Histogram
 	fakeStatedbCommitTimeHist      *metricsfakes.Histogram
+	fakeHistorydbCommitTimeHist    *metricsfakes.Histogram
+	fakeTokenProcessingTimeHist    *metricsfakes.Histogram
+	fakeStateListenersTimeHist     *metricsfakes.Histogram
 	fakeTransactionsCount          *metricsfakes.Counter
 }
 
@@ -190,6 +255,9 @@ func testutilConstructMetricProvider() *testMetricProvider {
 	fakeBlockProcessingTimeHist := testutilConstructHist()
 	fakeBlockstorageCommitTimeHist := testutilConstructHist()
 	fakeStatedbCommitTimeHist := testutilConstructHist()
+	fakeHistorydbCommitTimeHist := testutilConstructHist()
+	fakeTokenProcessingTimeHist := testutilConstructHist()
+	fakeStateListenersTimeHist := testutilConstructHist()
 	fakeTransactionsCount := testutilConstructCounter()
 	fakeProvider.NewGaugeStub = func(opts metrics.GaugeOpts) metrics.Gauge {
 		switch opts.Name {
@@ -206,6 +274,12 @@ func testutilConstructMetricProvider() *testMetricProvider {
 			return fakeBlockstorageCommitTimeHist
 		case statedbCommitTimeOpts.Name:
 			return fakeStatedbCommitTimeHist
+		case historydbCommitTimeOpts.Name:
+			return fakeHistorydbCommitTimeHist
+		case tokenProcessingTimeOpts.Name:
+			return fakeTokenProcessingTimeHist
+		case stateListenersTimeOpts.Name:
+			return fakeStateListenersTimeHist
 		}
 		return nil
 	}
@@ -223,6 +297,9 @@ func testutilConstructMetricProvider() *testMetricProvider {
 		fakeBlockProcessingTimeHist,
 		fakeBlockstorageCommitTimeHist,
 		fakeStatedbCommitTimeHist,
+		fakeHistorydbCommitTimeHist,
+		fakeTokenProcessingTimeHist,
+		fakeStateListenersTimeHist,
 		fakeTransactionsCount,
 	}
 }
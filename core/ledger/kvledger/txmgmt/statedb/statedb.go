@@ -135,14 +135,20 @@ func newNsUpdates() *nsUpdates {
 	return &nsUpdates{make(map[string]*VersionedValue)}
 }
 
+func newNsUpdatesWithSize(size int) *nsUpdates {
+	return &nsUpdates{make(map[string]*VersionedValue, size)}
+}
+
 // UpdateBatch encloses the details of multiple `updates`
 type UpdateBatch struct {
 	updates map[string]*nsUpdates
+	// sizes holds the reserved sizes of the namespaces not updated yet
+	sizes map[string]int
 }
 
 // NewUpdateBatch constructs an instance of a Batch
 func NewUpdateBatch() *UpdateBatch {
-	return &UpdateBatch{make(map[string]*nsUpdates)}
+	return &UpdateBatch{updates: make(map[string]*nsUpdates)}
 }
 
 // Get returns the VersionedValue for the given namespace and key
@@ -198,6 +204,28 @@ func (batch *UpdateBatch) GetUpdatedNamespaces() []string {
 	return namespaces
 }
 
+// Reserve sizes the updates of a namespace for the given number of keys, so that
+// namespaces receiving many writes in a block, such as the token namespace, are not
+// rehashed while the batch is built. It has no effect if the namespace is already updated.
+func (batch *UpdateBatch) Reserve(ns string, size int) {
+	if _, ok := batch.updates[ns]; ok || size <= 0 {
+		return
+	}
+	if batch.sizes == nil {
+		batch.sizes = make(map[string]int)
+	}
+	batch.sizes[ns] = size
+}
+
+// Len returns the number of updates in the batch, across all the namespaces
+func (batch *UpdateBatch) Len() int {
+	size := 0
+	for _, nsUpdates := range batch.updates {
+		size += len(nsUpdates.m)
+	}
+	return size
+}
+
 // Update updates the batch with a latest entry for a namespace and a key
 func (batch *UpdateBatch) Update(ns string, key string, vv *VersionedValue) {
 	batch.getOrCreateNsUpdates(ns).m[key] = vv
@@ -226,7 +254,12 @@ func (batch *UpdateBatch) GetRangeScanIterator(ns string, startKey string, endKe
 func (batch *UpdateBatch) getOrCreateNsUpdates(ns string) *nsUpdates {
 	nsUpdates := batch.updates[ns]
 	if nsUpdates == nil {
-		nsUpdates = newNsUpdates()
+		if size, ok := batch.sizes[ns]; ok {
+			nsUpdates = newNsUpdatesWithSize(size)
+			delete(batch.sizes, ns)
+		} else {
+			nsUpdates = newNsUpdates()
+		}
 		batch.updates[ns] = nsUpdates
 	}
 	return nsUpdates
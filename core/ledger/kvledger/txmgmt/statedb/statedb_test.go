@@ -17,6 +17,7 @@ limitations under the License.
 package statedb
 
 import (
+	"fmt"
 	"sort"
 	"testing"
 
@@ -95,6 +96,43 @@ func TestPutGetDeleteExistsGetUpdates(t *testing.T) {
 
 }
 
+func TestReserveAndLen(t *testing.T) {
+	batch := NewUpdateBatch()
+	assert.Equal(t, 0, batch.Len())
+
+	// a reserved namespace is not updated until a key is written to it
+	batch.Reserve("tms", 100)
+	assert.Empty(t, batch.GetUpdatedNamespaces())
+	assert.Nil(t, batch.GetUpdates("tms"))
+
+	batch.Put("tms", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch.Delete("tms", "key2", version.NewHeight(1, 2))
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 3))
+	assert.Equal(t, 3, batch.Len())
+	assert.Len(t, batch.GetUpdates("tms"), 2)
+
+	// reserving an updated namespace keeps its updates
+	batch.Reserve("tms", 10)
+	assert.Len(t, batch.GetUpdates("tms"), 2)
+	assert.Equal(t, 3, batch.Len())
+}
+
+func BenchmarkUpdateBatchPut(b *testing.B) {
+	for _, reserve := range []bool{false, true} {
+		b.Run(fmt.Sprintf("reserve=%t", reserve), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				batch := NewUpdateBatch()
+				if reserve {
+					batch.Reserve("tms", 10000)
+				}
+				for j := 0; j < 10000; j++ {
+					batch.Put("tms", fmt.Sprintf("\x00tokenOutput\x00tx%d\x000\x00", j), []byte("output"), version.NewHeight(1, uint64(j)))
+				}
+			}
+		})
+	}
+}
+
 func TestUpdateBatchIterator(t *testing.T) {
 	batch := NewUpdateBatch()
 	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
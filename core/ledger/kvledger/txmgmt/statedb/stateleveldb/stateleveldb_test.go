@@ -17,6 +17,7 @@ limitations under the License.
 package stateleveldb
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
@@ -136,3 +137,22 @@ func TestApplyUpdatesWithNilHeight(t *testing.T) {
 	defer env.Cleanup()
 	commontests.TestApplyUpdatesWithNilHeight(t, env.DBProvider)
 }
+
+func BenchmarkApplyUpdates(b *testing.B) {
+	env := NewTestVDBEnv(b)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testapplyupdates")
+	assert.NoError(b, err)
+
+	// a block spending and creating many token outputs
+	batch := statedb.NewUpdateBatch()
+	for i := 0; i < 5000; i++ {
+		batch.Put("tms", fmt.Sprintf("\x00tokenOutput\x00tx%d\x000\x00", i), []byte("output"), version.NewHeight(1, uint64(i)))
+		batch.Delete("tms", fmt.Sprintf("\x00tokenOutput\x00spent%d\x000\x00", i), version.NewHeight(1, uint64(i)))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		assert.NoError(b, db.ApplyUpdates(batch, version.NewHeight(uint64(i+1), 0)))
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package txmgr
 
 import (
+	"time"
+
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/protos/common"
@@ -39,10 +41,15 @@ type TxMgr interface {
 
 // TxStatInfo encapsulates information about a transaction
 type TxStatInfo struct {
+	TxID           string
 	ValidationCode peer.TxValidationCode
 	TxType         common.HeaderType
 	ChaincodeID    *peer.ChaincodeID
 	NumCollections int
+	// ProcessingTime is the time taken for the custom processing of non-endorser transactions
+	ProcessingTime time.Duration
+	// ReadConflict is the read that invalidated the transaction during mvcc validation, if any
+	ReadConflict *peer.ReadConflict
 }
 
 // ErrUnsupportedTransaction is expected to be thrown if a unsupported query is performed in an update transaction
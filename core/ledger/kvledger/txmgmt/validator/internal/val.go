@@ -36,6 +36,9 @@ type Transaction struct {
 	ID             string
 	RWSet          *rwsetutil.TxRwSet
 	ValidationCode peer.TxValidationCode
+	// ReadConflict is the first read found to conflict with the committed
+	// state, if the transaction failed mvcc validation
+	ReadConflict *peer.ReadConflict
 }
 
 // PubAndHashUpdates encapsulates public and hash updates. The intended use of this to hold the updates
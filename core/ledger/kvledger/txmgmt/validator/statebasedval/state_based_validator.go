@@ -98,6 +98,7 @@ func (v *Validator) ValidateAndPrepareBatch(block *internal.Block, doMVCCValidat
 	}
 
 	updates := internal.NewPubAndHashUpdates()
+	reservePubUpdates(block, updates.PubUpdates)
 	for _, tx := range block.Txs {
 		var validationCode peer.TxValidationCode
 		var err error
@@ -113,11 +114,32 @@ func (v *Validator) ValidateAndPrepareBatch(block *internal.Block, doMVCCValidat
 		} else {
 			logger.Warningf("Block [%d] Transaction index [%d] TxId [%s] marked as invalid by state validator. Reason code [%s]",
 				block.Num, tx.IndexInBlock, tx.ID, validationCode.String())
+			if tx.ReadConflict, err = v.findReadConflict(tx.RWSet, updates); err != nil {
+				return nil, err
+			}
 		}
 	}
 	return updates, nil
 }
 
+// reservePubUpdates sizes the public updates of each namespace for the writes of
+// the transactions in the block, so that blocks with many writes to a namespace,
+// such as the token namespace on UTXO spikes, build their batch without rehashing.
+func reservePubUpdates(block *internal.Block, pubUpdates *privacyenabledstate.PubUpdateBatch) {
+	if len(block.Txs) < 2 {
+		return
+	}
+	sizes := make(map[string]int)
+	for _, tx := range block.Txs {
+		for _, nsRWSet := range tx.RWSet.NsRwSets {
+			sizes[nsRWSet.NameSpace] += len(nsRWSet.KvRwSet.GetWrites())
+		}
+	}
+	for ns, size := range sizes {
+		pubUpdates.Reserve(ns, size)
+	}
+}
+
 // validateEndorserTX validates endorser transaction
 func (v *Validator) validateEndorserTX(
 	txRWSet *rwsetutil.TxRwSet,
@@ -282,3 +304,68 @@ func (v *Validator) validateKVReadHash(ns, coll string, kvReadHash *kvrwset.KVRe
 	}
 	return true, nil
 }
+
+// findReadConflict returns the first read of an invalidated transaction that
+// conflicts with the committed state or with the updates of the preceding valid
+// transactions of the block, in the order in which validateTx checks them
+func (v *Validator) findReadConflict(txRWSet *rwsetutil.TxRwSet, updates *internal.PubAndHashUpdates) (*peer.ReadConflict, error) {
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		ns := nsRWSet.NameSpace
+		for _, kvRead := range nsRWSet.KvRwSet.Reads {
+			conflict := &peer.ReadConflict{Namespace: ns, Key: kvRead.Key, ReadVersion: kvRead.Version}
+			var committedVersion *version.Height
+			if vv := updates.PubUpdates.Get(ns, kvRead.Key); vv != nil {
+				conflict.UpdatedInBlock = true
+				committedVersion = vv.Version
+			} else {
+				var err error
+				if committedVersion, err = v.db.GetVersion(ns, kvRead.Key); err != nil {
+					return nil, err
+				}
+				if version.AreSame(committedVersion, rwsetutil.NewVersion(kvRead.Version)) {
+					continue
+				}
+			}
+			conflict.CommittedVersion = protoVersion(committedVersion)
+			return conflict, nil
+		}
+		for _, rqi := range nsRWSet.KvRwSet.RangeQueriesInfo {
+			valid, err := v.validateRangeQuery(ns, rqi, updates.PubUpdates)
+			if err != nil {
+				return nil, err
+			}
+			if !valid {
+				return &peer.ReadConflict{Namespace: ns, RangeStartKey: rqi.StartKey, RangeEndKey: rqi.EndKey}, nil
+			}
+		}
+		for _, collHashedRWSet := range nsRWSet.CollHashedRwSets {
+			coll := collHashedRWSet.CollectionName
+			for _, kvReadHash := range collHashedRWSet.HashedRwSet.HashedReads {
+				conflict := &peer.ReadConflict{Namespace: ns, Collection: coll, KeyHash: kvReadHash.KeyHash, ReadVersion: kvReadHash.Version}
+				var committedVersion *version.Height
+				if vv := updates.HashUpdates.Get(ns, coll, string(kvReadHash.KeyHash)); vv != nil {
+					conflict.UpdatedInBlock = true
+					committedVersion = vv.Version
+				} else {
+					var err error
+					if committedVersion, err = v.db.GetKeyHashVersion(ns, coll, kvReadHash.KeyHash); err != nil {
+						return nil, err
+					}
+					if version.AreSame(committedVersion, rwsetutil.NewVersion(kvReadHash.Version)) {
+						continue
+					}
+				}
+				conflict.CommittedVersion = protoVersion(committedVersion)
+				return conflict, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func protoVersion(height *version.Height) *kvrwset.Version {
+	if height == nil {
+		return nil
+	}
+	return &kvrwset.Version{BlockNum: height.BlockNum, TxNum: height.TxNum}
+}
@@ -219,6 +219,78 @@ func TestValidator(t *testing.T) {
 	checkValidation(t, validator, getTestPubSimulationRWSet(t, rwsetBuilder4, rwsetBuilder5), []int{1})
 }
 
+func TestReadConflict(t *testing.T) {
+	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+
+	//populate db with initial data
+	batch := privacyenabledstate.NewUpdateBatch()
+	batch.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 0))
+	batch.PubUpdates.Put("ns1", "key2", []byte("value2"), version.NewHeight(1, 1))
+	batch.HashUpdates.Put("ns1", "coll1", util.ComputeStringHash("key3"), util.ComputeStringHash("value3"), version.NewHeight(1, 2))
+	db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, 2))
+
+	validator := NewValidator(db)
+
+	// tx0 is valid and updates key2, tx1 reads a stale version of key1,
+	// tx2 reads key2 updated by tx0, tx3 reads a stale version of the
+	// private key3 and tx4 has a phantom read
+	rwsetBuilder0 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder0.AddToReadSet("ns1", "key2", version.NewHeight(1, 1))
+	rwsetBuilder0.AddToWriteSet("ns1", "key2", []byte("value2_new"))
+	rwsetBuilder1 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder1.AddToReadSet("ns1", "key1", nil)
+	rwsetBuilder2 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder2.AddToReadSet("ns1", "key1", version.NewHeight(1, 0))
+	rwsetBuilder2.AddToReadSet("ns1", "key2", version.NewHeight(1, 1))
+	rwsetBuilder3 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder3.AddToHashedReadSet("ns1", "coll1", "key3", version.NewHeight(1, 1))
+	rwsetBuilder4 := rwsetutil.NewRWSetBuilder()
+	rqi4 := &kvrwset.RangeQueryInfo{StartKey: "key1", EndKey: "key3", ItrExhausted: true}
+	rqi4.SetRawReads([]*kvrwset.KVRead{rwsetutil.NewKVRead("key1", version.NewHeight(1, 0))})
+	rwsetBuilder4.AddToRangeQuerySet("ns1", rqi4)
+
+	var txs []*internal.Transaction
+	for i, txRWSet := range getTestPubSimulationRWSet(t, rwsetBuilder0, rwsetBuilder1, rwsetBuilder2, rwsetBuilder3, rwsetBuilder4) {
+		txs = append(txs, &internal.Transaction{ID: fmt.Sprintf("txid-%d", i), IndexInBlock: i, RWSet: txRWSet})
+	}
+	_, err := validator.ValidateAndPrepareBatch(&internal.Block{Num: 2, Txs: txs}, true)
+	assert.NoError(t, err)
+
+	assert.Equal(t, peer.TxValidationCode_VALID, txs[0].ValidationCode)
+	assert.Nil(t, txs[0].ReadConflict)
+	assert.Equal(t, peer.TxValidationCode_MVCC_READ_CONFLICT, txs[1].ValidationCode)
+	assert.Equal(t, &peer.ReadConflict{
+		Namespace:        "ns1",
+		Key:              "key1",
+		CommittedVersion: &kvrwset.Version{BlockNum: 1, TxNum: 0},
+	}, txs[1].ReadConflict)
+	assert.Equal(t, peer.TxValidationCode_MVCC_READ_CONFLICT, txs[2].ValidationCode)
+	assert.Equal(t, &peer.ReadConflict{
+		Namespace:        "ns1",
+		Key:              "key2",
+		ReadVersion:      &kvrwset.Version{BlockNum: 1, TxNum: 1},
+		CommittedVersion: &kvrwset.Version{BlockNum: 2, TxNum: 0},
+		UpdatedInBlock:   true,
+	}, txs[2].ReadConflict)
+	assert.Equal(t, peer.TxValidationCode_MVCC_READ_CONFLICT, txs[3].ValidationCode)
+	assert.Equal(t, &peer.ReadConflict{
+		Namespace:        "ns1",
+		Collection:       "coll1",
+		KeyHash:          util.ComputeStringHash("key3"),
+		ReadVersion:      &kvrwset.Version{BlockNum: 1, TxNum: 1},
+		CommittedVersion: &kvrwset.Version{BlockNum: 1, TxNum: 2},
+	}, txs[3].ReadConflict)
+	assert.Equal(t, peer.TxValidationCode_PHANTOM_READ_CONFLICT, txs[4].ValidationCode)
+	assert.Equal(t, &peer.ReadConflict{
+		Namespace:     "ns1",
+		RangeStartKey: "key1",
+		RangeEndKey:   "key3",
+	}, txs[4].ReadConflict)
+}
+
 func TestPhantomValidation(t *testing.T) {
 	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
 	testDBEnv.Init(t)
@@ -344,6 +416,61 @@ func TestPhantomHashBasedValidation(t *testing.T) {
 	checkValidation(t, validator, getTestPubSimulationRWSet(t, rwsetBuilder2), []int{0})
 }
 
+func TestReservePubUpdates(t *testing.T) {
+	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+	validator := NewValidator(db)
+
+	var builders []*rwsetutil.RWSetBuilder
+	for i := 0; i < 10; i++ {
+		rwsetBuilder := rwsetutil.NewRWSetBuilder()
+		rwsetBuilder.AddToWriteSet("tms", fmt.Sprintf("key%d", i), []byte("value"))
+		rwsetBuilder.AddToWriteSet("tms", "shared", []byte(fmt.Sprintf("value%d", i)))
+		builders = append(builders, rwsetBuilder)
+	}
+	var txs []*internal.Transaction
+	for i, txRWSet := range getTestPubSimulationRWSet(t, builders...) {
+		txs = append(txs, &internal.Transaction{ID: fmt.Sprintf("txid-%d", i), IndexInBlock: i, RWSet: txRWSet})
+	}
+
+	updates, err := validator.ValidateAndPrepareBatch(&internal.Block{Num: 1, Txs: txs}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tms"}, updates.PubUpdates.GetUpdatedNamespaces())
+	assert.Equal(t, 11, updates.PubUpdates.Len())
+	assert.Equal(t, []byte("value9"), updates.PubUpdates.Get("tms", "shared").Value)
+}
+
+func BenchmarkValidateAndPrepareBatch(b *testing.B) {
+	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	testDBEnv.Init(b)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+	validator := NewValidator(db)
+
+	// a block of token transactions, each spending an output and creating two
+	var txs []*internal.Transaction
+	for i := 0; i < 1000; i++ {
+		rwsetBuilder := rwsetutil.NewRWSetBuilder()
+		rwsetBuilder.AddToReadSet("tms", fmt.Sprintf("spent%d", i), nil)
+		rwsetBuilder.AddToWriteSet("tms", fmt.Sprintf("spent%d", i), nil)
+		rwsetBuilder.AddToWriteSet("tms", fmt.Sprintf("output%d-0", i), []byte("output"))
+		rwsetBuilder.AddToWriteSet("tms", fmt.Sprintf("output%d-1", i), []byte("output"))
+		simRes, err := rwsetBuilder.GetTxSimulationResults()
+		assert.NoError(b, err)
+		txRWSet, err := rwsetutil.TxRwSetFromProtoMsg(simRes.PubSimulationResults)
+		assert.NoError(b, err)
+		txs = append(txs, &internal.Transaction{ID: fmt.Sprintf("txid-%d", i), IndexInBlock: i, RWSet: txRWSet})
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := validator.ValidateAndPrepareBatch(&internal.Block{Num: 1, Txs: txs}, true)
+		assert.NoError(b, err)
+	}
+}
+
 func checkValidation(t *testing.T, val *Validator, transRWSets []*rwsetutil.TxRwSet, expectedInvalidTxIndexes []int) {
 	var trans []*internal.Transaction
 	for i, tranRWSet := range transRWSets {
@@ -9,6 +9,7 @@ package valimpl
 import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator"
@@ -28,12 +29,19 @@ type DefaultImpl struct {
 	txmgr             txmgr.TxMgr
 	db                privacyenabledstate.DB
 	internalValidator internal.Validator
+	writeAheadLog     *writeAheadLog
 }
 
 // NewStatebasedValidator constructs a validator that internally manages statebased validator and in addition
-// handles the tasks that are agnostic to a particular validation scheme such as parsing the block and handling the pvt data
-func NewStatebasedValidator(txmgr txmgr.TxMgr, db privacyenabledstate.DB) validator.Validator {
-	return &DefaultImpl{txmgr, db, statebasedval.NewValidator(db)}
+// handles the tasks that are agnostic to a particular validation scheme such as parsing the block and handling the pvt data.
+// The write sets of the custom transactions are recorded in the bookkeeping of the ledger, if a bookkeeping provider is passed,
+// so that the recovery of the state DB reproduces them
+func NewStatebasedValidator(txmgr txmgr.TxMgr, db privacyenabledstate.DB, ledgerid string, bookkeepingProvider bookkeeping.Provider) validator.Validator {
+	impl := &DefaultImpl{txmgr: txmgr, db: db, internalValidator: statebasedval.NewValidator(db)}
+	if bookkeepingProvider != nil {
+		impl.writeAheadLog = newWriteAheadLog(ledgerid, bookkeepingProvider)
+	}
+	return impl
 }
 
 // ValidateAndPrepareBatch implements the function in interface validator.Validator
@@ -47,10 +55,23 @@ func (impl *DefaultImpl) ValidateAndPrepareBatch(blockAndPvtdata *ledger.BlockAn
 	var pvtUpdates *privacyenabledstate.PvtUpdateBatch
 	var err error
 
+	writeSets := customTxWriteSets{}
+	if !doMVCCValidation && impl.writeAheadLog != nil {
+		// the block is recommitted to the state DB from the block storage
+		if writeSets, err = impl.writeAheadLog.retrieve(block.Header.Number); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	logger.Debug("preprocessing ProtoBlock...")
-	if internalBlock, txsStatInfo, err = preprocessProtoBlock(impl.txmgr, impl.db.ValidateKeyValue, block, doMVCCValidation); err != nil {
+	if internalBlock, txsStatInfo, err = preprocessProtoBlock(impl.txmgr, impl.db.ValidateKeyValue, block, doMVCCValidation, writeSets); err != nil {
 		return nil, nil, err
 	}
+	if doMVCCValidation && impl.writeAheadLog != nil {
+		if err = impl.writeAheadLog.record(block.Header.Number, writeSets); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	if pubAndHashUpdates, err = impl.internalValidator.ValidateAndPrepareBatch(internalBlock, doMVCCValidation); err != nil {
 		return nil, nil, err
@@ -67,6 +88,9 @@ func (impl *DefaultImpl) ValidateAndPrepareBatch(blockAndPvtdata *ledger.BlockAn
 	for i := range txsFilter {
 		txsStatInfo[i].ValidationCode = txsFilter.Flag(i)
 	}
+	for _, tx := range internalBlock.Txs {
+		txsStatInfo[tx.IndexInBlock].ReadConflict = tx.ReadConflict
+	}
 	return &privacyenabledstate.UpdateBatch{
 		PubUpdates:  pubAndHashUpdates.PubUpdates,
 		HashUpdates: pubAndHashUpdates.HashUpdates,
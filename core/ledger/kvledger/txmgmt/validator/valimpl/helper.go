@@ -9,6 +9,7 @@ package valimpl
 import (
 	"bytes"
 	"fmt"
+	"time"
 
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/customtx"
@@ -94,10 +95,12 @@ func validatePvtdata(tx *internal.Transaction, pvtdata *ledger.TxPvtData) error
 }
 
 // preprocessProtoBlock parses the proto instance of block into 'Block' structure.
-// The retuned 'Block' structure contains only transactions that are endorser transactions and are not alredy marked as invalid
+// The retuned 'Block' structure contains only transactions that are endorser transactions and are not alredy marked as invalid.
+// The write sets of the custom transactions are added to 'writeSets', unless they are already there, in which case they are
+// used instead of processing the transactions again
 func preprocessProtoBlock(txMgr txmgr.TxMgr,
 	validateKVFunc func(key string, value []byte) error,
-	block *common.Block, doMVCCValidation bool,
+	block *common.Block, doMVCCValidation bool, writeSets customTxWriteSets,
 ) (*internal.Block, []*txmgr.TxStatInfo, error) {
 	b := &internal.Block{Num: block.Header.Number}
 	txsStatInfo := []*txmgr.TxStatInfo{}
@@ -128,6 +131,7 @@ func preprocessProtoBlock(txMgr txmgr.TxMgr,
 		}
 
 		var txRWSet *rwsetutil.TxRwSet
+		txStatInfo.TxID = chdr.TxId
 		txType := common.HeaderType(chdr.Type)
 		logger.Debugf("txType=%s", txType)
 		txStatInfo.TxType = txType
@@ -145,13 +149,24 @@ func preprocessProtoBlock(txMgr txmgr.TxMgr,
 				continue
 			}
 		} else {
-			rwsetProto, err := processNonEndorserTx(env, chdr.TxId, txType, txMgr, !doMVCCValidation)
-			if _, ok := err.(*customtx.InvalidTxError); ok {
-				txsFilter.SetFlag(txIndex, peer.TxValidationCode_INVALID_OTHER_REASON)
-				continue
-			}
-			if err != nil {
-				return nil, nil, err
+			// the expiration of transactions depends on the capabilities of the
+			// channel, so it is checked by the committer, which has already
+			// marked the expired transactions as invalid
+			rwsetProto, recorded := writeSets[txIndex]
+			if !recorded {
+				startProcessing := time.Now()
+				rwsetProto, err = processNonEndorserTx(env, chdr.TxId, txType, block.Header.Number, txMgr, !doMVCCValidation)
+				txStatInfo.ProcessingTime = time.Since(startProcessing)
+				if _, ok := err.(*customtx.InvalidTxError); ok {
+					txsFilter.SetFlag(txIndex, peer.TxValidationCode_INVALID_OTHER_REASON)
+					continue
+				}
+				if err != nil {
+					return nil, nil, err
+				}
+				if rwsetProto != nil && writeSets != nil {
+					writeSets[txIndex] = rwsetProto
+				}
 			}
 			if rwsetProto != nil {
 				if txRWSet, err = rwsetutil.TxRwSetFromProtoMsg(rwsetProto); err != nil {
@@ -174,7 +189,7 @@ func preprocessProtoBlock(txMgr txmgr.TxMgr,
 	return b, txsStatInfo, nil
 }
 
-func processNonEndorserTx(txEnv *common.Envelope, txid string, txType common.HeaderType, txmgr txmgr.TxMgr, synchingState bool) (*rwset.TxReadWriteSet, error) {
+func processNonEndorserTx(txEnv *common.Envelope, txid string, txType common.HeaderType, blockNum uint64, txmgr txmgr.TxMgr, synchingState bool) (*rwset.TxReadWriteSet, error) {
 	logger.Debugf("Performing custom processing for transaction [txid=%s], [txType=%s]", txid, txType)
 	processor := customtx.GetProcessor(txType)
 	logger.Debugf("Processor for custom tx processing:%#v", processor)
@@ -189,7 +204,12 @@ func processNonEndorserTx(txEnv *common.Envelope, txid string, txType common.Hea
 		return nil, err
 	}
 	defer sim.Done()
-	if err = processor.GenerateSimulationResults(txEnv, sim, synchingState); err != nil {
+	if blockProcessor, ok := processor.(customtx.BlockProcessor); ok {
+		err = blockProcessor.GenerateSimulationResultsInBlock(txEnv, blockNum, sim, synchingState)
+	} else {
+		err = processor.GenerateSimulationResults(txEnv, sim, synchingState)
+	}
+	if err != nil {
 		return nil, err
 	}
 	if simRes, err = sim.GetTxSimulationResults(); err != nil {
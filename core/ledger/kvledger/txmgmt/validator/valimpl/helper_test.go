@@ -25,6 +25,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	lutils "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/peer"
 	putils "github.com/hyperledger/fabric/protos/utils"
 	logging "github.com/op/go-logging"
@@ -110,7 +111,7 @@ func TestValidateAndPreparePvtBatch(t *testing.T) {
 	alwaysValidKVFunc := func(key string, value []byte) error {
 		return nil
 	}
-	actualPreProcessedBlock, _, err := preprocessProtoBlock(nil, alwaysValidKVFunc, block, false)
+	actualPreProcessedBlock, _, err := preprocessProtoBlock(nil, alwaysValidKVFunc, block, false, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedPerProcessedBlock, actualPreProcessedBlock)
 
@@ -147,21 +148,21 @@ func TestPreprocessProtoBlock(t *testing.T) {
 	// good block
 	//_, gb := testutil.NewBlockGenerator(t, "testLedger", false)
 	gb := testutil.ConstructTestBlock(t, 10, 1, 1)
-	_, _, err := preprocessProtoBlock(nil, allwaysValidKVfunc, gb, false)
+	_, _, err := preprocessProtoBlock(nil, allwaysValidKVfunc, gb, false, nil)
 	assert.NoError(t, err)
 	// bad envelope
 	gb = testutil.ConstructTestBlock(t, 11, 1, 1)
 	gb.Data = &common.BlockData{Data: [][]byte{{123}}}
 	gb.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] =
 		lutils.NewTxValidationFlagsSetValue(len(gb.Data.Data), peer.TxValidationCode_VALID)
-	_, _, err = preprocessProtoBlock(nil, allwaysValidKVfunc, gb, false)
+	_, _, err = preprocessProtoBlock(nil, allwaysValidKVfunc, gb, false, nil)
 	assert.Error(t, err)
 	t.Log(err)
 	// bad payload
 	gb = testutil.ConstructTestBlock(t, 12, 1, 1)
 	envBytes, _ := putils.GetBytesEnvelope(&common.Envelope{Payload: []byte{123}})
 	gb.Data = &common.BlockData{Data: [][]byte{envBytes}}
-	_, _, err = preprocessProtoBlock(nil, allwaysValidKVfunc, gb, false)
+	_, _, err = preprocessProtoBlock(nil, allwaysValidKVfunc, gb, false, nil)
 	assert.Error(t, err)
 	t.Log(err)
 	// bad channel header
@@ -171,7 +172,7 @@ func TestPreprocessProtoBlock(t *testing.T) {
 	})
 	envBytes, _ = putils.GetBytesEnvelope(&common.Envelope{Payload: payloadBytes})
 	gb.Data = &common.BlockData{Data: [][]byte{envBytes}}
-	_, _, err = preprocessProtoBlock(nil, allwaysValidKVfunc, gb, false)
+	_, _, err = preprocessProtoBlock(nil, allwaysValidKVfunc, gb, false, nil)
 	assert.Error(t, err)
 	t.Log(err)
 
@@ -185,7 +186,7 @@ func TestPreprocessProtoBlock(t *testing.T) {
 	flags := lutils.NewTxValidationFlags(len(gb.Data.Data))
 	flags.SetFlag(0, peer.TxValidationCode_BAD_CHANNEL_HEADER)
 	gb.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = flags
-	_, _, err = preprocessProtoBlock(nil, allwaysValidKVfunc, gb, false)
+	_, _, err = preprocessProtoBlock(nil, allwaysValidKVfunc, gb, false, nil)
 	assert.NoError(t, err) // invalid filter should take precendence
 
 	// new block
@@ -203,7 +204,7 @@ func TestPreprocessProtoBlock(t *testing.T) {
 	l, recorder := floggingtest.NewTestLogger(t)
 	logger = l
 
-	_, _, err = preprocessProtoBlock(nil, allwaysValidKVfunc, gb, false)
+	_, _, err = preprocessProtoBlock(nil, allwaysValidKVfunc, gb, false, nil)
 	assert.NoError(t, err)
 	expected := fmt.Sprintf(
 		"Channel [%s]: Block [%d] Transaction index [%d] TxId [%s] marked as invalid by committer. Reason code [%s]",
@@ -240,7 +241,7 @@ func TestPreprocessProtoBlockInvalidWriteset(t *testing.T) {
 	assert.True(t, txfilter.IsValid(0))
 	assert.True(t, txfilter.IsValid(1)) // both txs are valid initially at the time of block cutting
 
-	internalBlock, _, err := preprocessProtoBlock(nil, kvValidationFunc, block, false)
+	internalBlock, _, err := preprocessProtoBlock(nil, kvValidationFunc, block, false, nil)
 	assert.NoError(t, err)
 	assert.False(t, txfilter.IsValid(0)) // tx at index 0 should be marked as invalid
 	assert.True(t, txfilter.IsValid(1))  // tx at index 1 should be marked as valid
@@ -303,18 +304,25 @@ func TestTxStatsInfoWithConfigTx(t *testing.T) {
 	testDBEnv.Init(t)
 	defer testDBEnv.Cleanup()
 	testDB := testDBEnv.GetDBHandle("emptydb")
-	v := NewStatebasedValidator(nil, testDB)
+	v := NewStatebasedValidator(nil, testDB, "", nil)
 
 	gb := testutil.ConstructTestBlocks(t, 1)[0]
+	chdr, err := putils.ChannelHeader(putils.ExtractEnvelopeOrPanic(gb, 0))
+	assert.NoError(t, err)
 	_, txStatsInfo, err := v.ValidateAndPrepareBatch(&ledger.BlockAndPvtData{Block: gb}, true)
 	assert.NoError(t, err)
 	expectedTxStatInfo := []*txmgr.TxStatInfo{
 		{
+			TxID:           chdr.TxId,
 			TxType:         common.HeaderType_CONFIG,
 			ValidationCode: peer.TxValidationCode_VALID,
 		},
 	}
 	t.Logf("txStatsInfo=%s\n", spew.Sdump(txStatsInfo))
+	// the processing time of the config transaction is measured, but cannot be predicted
+	assert.Len(t, txStatsInfo, 1)
+	assert.True(t, txStatsInfo[0].ProcessingTime > 0)
+	txStatsInfo[0].ProcessingTime = 0
 	assert.Equal(t, expectedTxStatInfo, txStatsInfo)
 }
 
@@ -323,7 +331,7 @@ func TestTxStatsInfo(t *testing.T) {
 	testDBEnv.Init(t)
 	defer testDBEnv.Cleanup()
 	testDB := testDBEnv.GetDBHandle("emptydb")
-	v := NewStatebasedValidator(nil, testDB)
+	v := NewStatebasedValidator(nil, testDB, "", nil)
 
 	// create a block with 4 endorser transactions
 	tx1SimulationResults, _ := testutilGenerateTxSimulationResultsAsBytes(t,
@@ -400,20 +408,29 @@ func TestTxStatsInfo(t *testing.T) {
 	assert.NoError(t, err)
 	expectedTxStatInfo := []*txmgr.TxStatInfo{
 		{
+			TxID:           "tx_1",
 			TxType:         common.HeaderType_ENDORSER_TRANSACTION,
 			ValidationCode: peer.TxValidationCode_VALID,
 			ChaincodeID:    &peer.ChaincodeID{Name: "cc_1", Version: "cc_1_v1"},
 		},
 		{
+			TxID:           "tx_2",
 			TxType:         common.HeaderType_ENDORSER_TRANSACTION,
 			ValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT,
 			ChaincodeID:    &peer.ChaincodeID{Name: "cc_2", Version: "cc_2_v1"},
+			ReadConflict: &peer.ReadConflict{
+				Namespace:        "ns1",
+				Key:              "key1",
+				CommittedVersion: &kvrwset.Version{BlockNum: 5, TxNum: 0},
+				UpdatedInBlock:   true,
+			},
 		},
 		{
 			TxType:         -1,
 			ValidationCode: peer.TxValidationCode_BAD_PAYLOAD,
 		},
 		{
+			TxID:           "tx_4",
 			TxType:         common.HeaderType_ENDORSER_TRANSACTION,
 			ValidationCode: peer.TxValidationCode_VALID,
 			ChaincodeID:    &peer.ChaincodeID{Name: "cc_4", Version: "cc_4_v1"},
@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package valimpl
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/pkg/errors"
+)
+
+const writeSetPrefix = 'w'
+
+// customTxWriteSets holds the write sets of the custom transactions of a block,
+// such as token transactions, by the index of the transactions in the block.
+type customTxWriteSets map[int]*rwset.TxReadWriteSet
+
+// writeAheadLog records the write sets that the processors of the custom
+// transactions produce for the transactions of a block before the block is
+// committed to the block storage. The processors of token transactions verify
+// them against the state DB and the channel config, so processing them again
+// when the state DB is recovered from the block storage does not produce the
+// same write sets if the peer stopped while committing the updates of the
+// block to the state DB, which then already holds some of them, e.g. as the
+// updates of the namespaces of CouchDB are not committed atomically, or as
+// the channel config is not loaded while the ledgers are opened. The recorded
+// write sets are applied as they are instead, so that the recovery reproduces
+// the state the peer would have committed.
+type writeAheadLog struct {
+	db *leveldbhelper.DBHandle
+}
+
+func newWriteAheadLog(ledgerid string, provider bookkeeping.Provider) *writeAheadLog {
+	return &writeAheadLog{provider.GetDBHandle(ledgerid, bookkeeping.CustomTxWriteSets)}
+}
+
+// record replaces the recorded write sets by the ones of the block. The
+// blocks are committed in order, so the updates of the previous blocks are
+// committed to the state DB and their write sets are no longer needed.
+func (l *writeAheadLog) record(blockNum uint64, writeSets customTxWriteSets) error {
+	batch := leveldbhelper.NewUpdateBatch()
+	itr := l.db.GetIterator([]byte{writeSetPrefix}, []byte{writeSetPrefix + 1})
+	for itr.Next() {
+		batch.Delete(append([]byte{}, itr.Key()...))
+	}
+	itr.Release()
+	if err := itr.Error(); err != nil {
+		return errors.Wrap(err, "failed reading the recorded write sets of custom transactions")
+	}
+
+	for txIndex, writeSet := range writeSets {
+		bytes, err := proto.Marshal(writeSet)
+		if err != nil {
+			return errors.Wrapf(err, "failed marshaling the write set of transaction %d of block %d", txIndex, blockNum)
+		}
+		batch.Put(writeSetKey(blockNum, uint64(txIndex)), append([]byte{}, bytes...))
+	}
+	if batch.Len() == 0 {
+		return nil
+	}
+	return l.db.WriteBatch(batch, true)
+}
+
+// retrieve returns the recorded write sets of the block, or nil if they were
+// not recorded, such as when the state DB is rebuilt from the first block.
+func (l *writeAheadLog) retrieve(blockNum uint64) (customTxWriteSets, error) {
+	itr := l.db.GetIterator(writeSetKey(blockNum, 0), writeSetKey(blockNum+1, 0))
+	defer itr.Release()
+	var writeSets customTxWriteSets
+	for itr.Next() {
+		key := itr.Key()
+		_, n := util.DecodeOrderPreservingVarUint64(key[1:])
+		txIndex, _ := util.DecodeOrderPreservingVarUint64(key[1+n:])
+		writeSet := &rwset.TxReadWriteSet{}
+		if err := proto.Unmarshal(itr.Value(), writeSet); err != nil {
+			return nil, errors.Wrapf(err, "failed unmarshaling the recorded write set of transaction %d of block %d", txIndex, blockNum)
+		}
+		if writeSets == nil {
+			writeSets = customTxWriteSets{}
+		}
+		writeSets[int(txIndex)] = writeSet
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrap(err, "failed reading the recorded write sets of custom transactions")
+	}
+	return writeSets, nil
+}
+
+func writeSetKey(blockNum, txIndex uint64) []byte {
+	key := []byte{writeSetPrefix}
+	key = append(key, util.EncodeOrderPreservingVarUint64(blockNum)...)
+	return append(key, util.EncodeOrderPreservingVarUint64(txIndex)...)
+}
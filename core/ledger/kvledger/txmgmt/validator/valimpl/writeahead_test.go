@@ -0,0 +1,72 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package valimpl
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteAheadLog(t *testing.T) {
+	bookkeepingEnv := bookkeeping.NewTestEnv(t)
+	defer bookkeepingEnv.Cleanup()
+	log := newWriteAheadLog("testLedger", bookkeepingEnv.TestProvider)
+
+	writeSets, err := log.retrieve(1)
+	require.NoError(t, err)
+	assert.Nil(t, writeSets)
+
+	writeSet := func(ns string) *rwset.TxReadWriteSet {
+		return &rwset.TxReadWriteSet{
+			DataModel: rwset.TxReadWriteSet_KV,
+			NsRwset:   []*rwset.NsReadWriteSet{{Namespace: ns, Rwset: []byte("rwset")}},
+		}
+	}
+	require.NoError(t, log.record(1, customTxWriteSets{0: writeSet("ns0"), 300: writeSet("ns300")}))
+	writeSets, err = log.retrieve(1)
+	require.NoError(t, err)
+	require.Len(t, writeSets, 2)
+	assert.True(t, proto.Equal(writeSet("ns0"), writeSets[0]))
+	assert.True(t, proto.Equal(writeSet("ns300"), writeSets[300]))
+
+	// the write sets of a block are not retrieved for its neighbours
+	writeSets, err = log.retrieve(0)
+	require.NoError(t, err)
+	assert.Nil(t, writeSets)
+	writeSets, err = log.retrieve(2)
+	require.NoError(t, err)
+	assert.Nil(t, writeSets)
+
+	// the write sets of the next block replace the ones of the previous block
+	require.NoError(t, log.record(2, customTxWriteSets{1: writeSet("ns1")}))
+	writeSets, err = log.retrieve(1)
+	require.NoError(t, err)
+	assert.Nil(t, writeSets)
+	writeSets, err = log.retrieve(2)
+	require.NoError(t, err)
+	require.Len(t, writeSets, 1)
+	assert.True(t, proto.Equal(writeSet("ns1"), writeSets[1]))
+
+	// the write sets are removed once a block has none
+	require.NoError(t, log.record(3, customTxWriteSets{}))
+	writeSets, err = log.retrieve(2)
+	require.NoError(t, err)
+	assert.Nil(t, writeSets)
+
+	// the write sets of the ledgers are kept apart
+	otherLog := newWriteAheadLog("otherLedger", bookkeepingEnv.TestProvider)
+	require.NoError(t, otherLog.record(4, customTxWriteSets{0: writeSet("other")}))
+	require.NoError(t, log.record(4, customTxWriteSets{0: writeSet("ns0")}))
+	writeSets, err = otherLog.retrieve(4)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(writeSet("other"), writeSets[0]))
+}
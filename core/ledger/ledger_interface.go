@@ -26,6 +26,7 @@ type Initializer struct {
 	MembershipInfoProvider        MembershipInfoProvider
 	MetricsProvider               metrics.Provider
 	HealthCheckRegistry           HealthCheckRegistry
+	ReadConflictListener          ReadConflictListener
 }
 
 // PeerLedgerProvider provides handle to ledger instances
@@ -365,6 +366,24 @@ type StateUpdateTrigger struct {
 // StateUpdates is the generic type to represent the state updates
 type StateUpdates map[string]interface{}
 
+// ReadConflictListener is notified of the transactions of a block that the ledger
+// invalidated because of read conflicts. Function `HandleReadConflicts` is invoked
+// once per block after the block is committed, with no conflicts if the block
+// contains no such transactions, so that the listener can track the committed
+// blocks.
+type ReadConflictListener interface {
+	HandleReadConflicts(ledgerID string, blockNum uint64, conflicts []*TxReadConflict)
+}
+
+// TxReadConflict describes a transaction invalidated because one of its reads
+// conflicted with the committed state
+type TxReadConflict struct {
+	TxID           string
+	TxNum          uint64
+	ValidationCode peer.TxValidationCode
+	Conflict       *peer.ReadConflict
+}
+
 // ConfigHistoryRetriever allow retrieving history of collection configs
 type ConfigHistoryRetriever interface {
 	CollectionConfigAt(blockNum uint64, chaincodeName string) (*CollectionConfigInfo, error)
@@ -34,6 +34,7 @@ const confPvtdataStore = "pvtdataStore"
 const confTotalQueryLimit = "ledger.state.totalQueryLimit"
 const confInternalQueryLimit = "ledger.state.couchDBConfig.internalQueryLimit"
 const confEnableHistoryDatabase = "ledger.history.enableHistoryDatabase"
+const confCommitHistoryDatabaseConcurrently = "ledger.history.commitConcurrently"
 const confMaxBatchSize = "ledger.state.couchDBConfig.maxBatchUpdateSize"
 const confAutoWarmIndexes = "ledger.state.couchDBConfig.autoWarmIndexes"
 const confWarmIndexesAfterNBlocks = "ledger.state.couchDBConfig.warmIndexesAfterNBlocks"
@@ -153,6 +154,12 @@ func IsHistoryDBEnabled() bool {
 	return viper.GetBool(confEnableHistoryDatabase)
 }
 
+// IsHistoryDBCommitConcurrent exposes the commitConcurrently variable, which indicates
+// whether the history database is committed concurrently with the state database
+func IsHistoryDBCommitConcurrent() bool {
+	return viper.GetBool(confCommitHistoryDatabaseConcurrently)
+}
+
 // IsQueryReadsHashingEnabled enables or disables computing of hash
 // of range query results for phantom item validation
 func IsQueryReadsHashingEnabled() bool {
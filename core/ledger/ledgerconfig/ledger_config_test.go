@@ -196,6 +196,20 @@ func TestIsHistoryDBEnabledFalse(t *testing.T) {
 	assert.False(t, updatedValue) //test config returns false
 }
 
+func TestIsHistoryDBCommitConcurrentDefault(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defaultValue := IsHistoryDBCommitConcurrent()
+	assert.False(t, defaultValue) //test default config is false
+}
+
+func TestIsHistoryDBCommitConcurrentTrue(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	viper.Set("ledger.history.commitConcurrently", true)
+	updatedValue := IsHistoryDBCommitConcurrent()
+	assert.True(t, updatedValue) //test config returns true
+}
+
 func TestIsAutoWarmIndexesEnabledDefault(t *testing.T) {
 	setUpCoreYAMLConfig()
 	defaultValue := IsAutoWarmIndexesEnabled()
@@ -32,6 +32,7 @@ var ErrLedgerAlreadyOpened = errors.New("ledger already opened")
 var ErrLedgerMgmtNotInitialized = errors.New("ledger mgmt should be initialized before using")
 
 var openedLedgers map[string]ledger.PeerLedger
+var openingLedgers map[string]struct{}
 var ledgerProvider ledger.PeerLedgerProvider
 var lock sync.Mutex
 var initialized bool
@@ -45,6 +46,7 @@ type Initializer struct {
 	MembershipInfoProvider        ledger.MembershipInfoProvider
 	MetricsProvider               metrics.Provider
 	HealthCheckRegistry           ledger.HealthCheckRegistry
+	ReadConflictListener          ledger.ReadConflictListener
 }
 
 // Initialize initializes ledgermgmt
@@ -60,6 +62,7 @@ func initialize(initializer *Initializer) {
 	defer lock.Unlock()
 	initialized = true
 	openedLedgers = make(map[string]ledger.PeerLedger)
+	openingLedgers = make(map[string]struct{})
 	customtx.Initialize(initializer.CustomTxProcessors)
 	cceventmgmt.Initialize(&chaincodeInfoProviderImpl{
 		initializer.PlatformRegistry,
@@ -76,6 +79,7 @@ func initialize(initializer *Initializer) {
 		MembershipInfoProvider:        initializer.MembershipInfoProvider,
 		MetricsProvider:               initializer.MetricsProvider,
 		HealthCheckRegistry:           initializer.HealthCheckRegistry,
+		ReadConflictListener:          initializer.ReadConflictListener,
 	})
 	ledgerProvider = provider
 	logger.Info("ledger mgmt initialized")
@@ -106,22 +110,38 @@ func CreateLedger(genesisBlock *common.Block) (ledger.PeerLedger, error) {
 	return l, nil
 }
 
-// OpenLedger returns a ledger for the given id
+// OpenLedger returns a ledger for the given id. Distinct ledgers may be opened
+// concurrently, as opening a ledger recovers its state and history databases
+// from the block store, which takes a while on peers with many channels.
 func OpenLedger(id string) (ledger.PeerLedger, error) {
 	logger.Infof("Opening ledger with id = %s", id)
 	lock.Lock()
-	defer lock.Unlock()
 	if !initialized {
+		lock.Unlock()
 		return nil, ErrLedgerMgmtNotInitialized
 	}
-	l, ok := openedLedgers[id]
-	if ok {
+	_, opened := openedLedgers[id]
+	_, opening := openingLedgers[id]
+	if opened || opening {
+		lock.Unlock()
 		return nil, ErrLedgerAlreadyOpened
 	}
+	openingLedgers[id] = struct{}{}
+	lock.Unlock()
+
 	l, err := ledgerProvider.Open(id)
+
+	lock.Lock()
+	defer lock.Unlock()
+	delete(openingLedgers, id)
 	if err != nil {
 		return nil, err
 	}
+	if openedLedgers == nil {
+		// ledger mgmt was closed while the ledger was being opened
+		l.Close()
+		return nil, ErrLedgerMgmtNotInitialized
+	}
 	l = wrapLedger(id, l)
 	openedLedgers[id] = l
 	logger.Infof("Opened ledger with id = %s", id)
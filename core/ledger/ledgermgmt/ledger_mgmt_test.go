@@ -19,6 +19,7 @@ package ledgermgmt
 import (
 	"fmt"
 	"os"
+	"sync"
 	"testing"
 
 	"github.com/hyperledger/fabric/common/metrics/disabled"
@@ -99,6 +100,48 @@ func TestLedgerMgmt(t *testing.T) {
 	Close()
 }
 
+func TestOpenLedgersConcurrently(t *testing.T) {
+	InitializeTestEnv()
+	defer CleanupTestEnv()
+
+	numLedgers := 10
+	for i := 0; i < numLedgers; i++ {
+		gb, _ := test.MakeGenesisBlock(constructTestLedgerID(i))
+		_, err := CreateLedger(gb)
+		assert.NoError(t, err)
+	}
+	Close()
+	InitializeExistingTestEnvWithInitializer(nil)
+
+	// every ledger is opened twice, only one of which must succeed
+	errs := make(chan error, 2*numLedgers)
+	var wg sync.WaitGroup
+	for i := 0; i < 2*numLedgers; i++ {
+		wg.Add(1)
+		go func(ledgerID string) {
+			defer wg.Done()
+			_, err := OpenLedger(ledgerID)
+			errs <- err
+		}(constructTestLedgerID(i % numLedgers))
+	}
+	wg.Wait()
+	close(errs)
+
+	var opened, alreadyOpened int
+	for err := range errs {
+		switch err {
+		case nil:
+			opened++
+		case ErrLedgerAlreadyOpened:
+			alreadyOpened++
+		default:
+			t.Fatalf("unexpected error opening ledger: %s", err)
+		}
+	}
+	assert.Equal(t, numLedgers, opened)
+	assert.Equal(t, numLedgers, alreadyOpened)
+}
+
 func TestChaincodeInfoProvider(t *testing.T) {
 	InitializeTestEnv()
 	defer CleanupTestEnv()
@@ -9,6 +9,7 @@ package ledgerstorage
 import (
 	"sync"
 
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
@@ -49,7 +50,7 @@ func NewProvider() *Provider {
 	}
 	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
 	blockStoreProvider := fsblkstorage.NewProvider(
-		fsblkstorage.NewConf(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize()),
+		fsblkstorage.NewConfWithHashingSuite(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize(), channelconfig.HashingSuiteFromGenesisBlock),
 		indexConfig)
 
 	pvtStoreProvider := pvtdatastorage.NewProvider()
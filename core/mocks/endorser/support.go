@@ -40,6 +40,7 @@ type MockSupport struct {
 	IsJavaErr                        error
 	GetApplicationConfigRv           channelconfig.Application
 	GetApplicationConfigBoolRv       bool
+	GetHashingSuiteRv                func([]byte) []byte
 }
 
 func (s *MockSupport) Serialize() ([]byte, error) {
@@ -125,3 +126,7 @@ func (s *MockSupport) CheckInstantiationPolicy(name, version string, cd ccprovid
 func (s *MockSupport) GetApplicationConfig(cid string) (channelconfig.Application, bool) {
 	return s.GetApplicationConfigRv, s.GetApplicationConfigBoolRv
 }
+
+func (s *MockSupport) GetHashingSuite(channelID string) func([]byte) []byte {
+	return s.GetHashingSuiteRv
+}
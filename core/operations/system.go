@@ -142,6 +142,12 @@ func (s *System) RegisterChecker(component string, checker healthz.HealthChecker
 	return s.healthHandler.RegisterChecker(component, checker)
 }
 
+// RegisterHandler hosts h at path. The endpoint requires a client certificate
+// when TLS is enabled, like the logging and metrics endpoints.
+func (s *System) RegisterHandler(path string, h http.Handler) {
+	s.mux.Handle(path, s.handlerChain(h, s.options.TLS.Enabled))
+}
+
 func (s *System) initializeServer() {
 	s.mux = http.NewServeMux()
 	s.httpServer = &http.Server{
@@ -93,6 +93,24 @@ var _ = Describe("System", func() {
 		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
 	})
 
+	It("hosts registered handlers on secure endpoints", func() {
+		system.RegisterHandler("/custom", http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			resp.WriteHeader(http.StatusTeapot)
+		}))
+		err := system.Start()
+		Expect(err).NotTo(HaveOccurred())
+
+		customURL := fmt.Sprintf("https://%s/custom", system.Addr())
+		resp, err := client.Get(customURL)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusTeapot))
+		resp.Body.Close()
+
+		resp, err = unauthClient.Get(customURL)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+	})
+
 	Context("when TLS is disabled", func() {
 		BeforeEach(func() {
 			options.TLS.Enabled = false
@@ -20,12 +20,14 @@ SPDX-License-Identifier: Apache-2.0
 package peer
 
 import (
+	"crypto/ecdsa"
 	"crypto/tls"
 	"fmt"
 	"io/ioutil"
 	"net"
 	"path/filepath"
 
+	"github.com/hyperledger/fabric/bccsp/utils"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/config"
 	pb "github.com/hyperledger/fabric/protos/peer"
@@ -253,3 +255,25 @@ func GetClientCertificate() (tls.Certificate, error) {
 	}
 	return cert, nil
 }
+
+// GetTokenEncryptionKey returns the private key of the TokenEncryptionKey of
+// the org of the peer, with which it decrypts the encrypted token
+// transactions, or nil if peer.tokenEncryption.keyFile is not set
+func GetTokenEncryptionKey() (*ecdsa.PrivateKey, error) {
+	if viper.GetString("peer.tokenEncryption.keyFile") == "" {
+		return nil, nil
+	}
+	raw, err := ioutil.ReadFile(config.GetPath("peer.tokenEncryption.keyFile"))
+	if err != nil {
+		return nil, errors.Wrap(err, "error loading token encryption key")
+	}
+	key, err := utils.PEMtoPrivateKey(raw, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error parsing token encryption key")
+	}
+	ecdsaKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("token encryption key is not an ECDSA key")
+	}
+	return ecdsaKey, nil
+}
@@ -317,3 +317,27 @@ func TestGetClientCertificate(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expected, cert)
 }
+
+func TestGetTokenEncryptionKey(t *testing.T) {
+	defer viper.Set("peer.tokenEncryption.keyFile", "")
+
+	viper.Set("peer.tokenEncryption.keyFile", "")
+	key, err := GetTokenEncryptionKey()
+	assert.NoError(t, err)
+	assert.Nil(t, key)
+
+	viper.Set("peer.tokenEncryption.keyFile", filepath.Join("testdata", "Org1-server1-key.pem"))
+	key, err = GetTokenEncryptionKey()
+	assert.NoError(t, err)
+	assert.NotNil(t, key)
+
+	viper.Set("peer.tokenEncryption.keyFile", filepath.Join("testdata", "Org1-server1-cert.pem"))
+	_, err = GetTokenEncryptionKey()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error parsing token encryption key")
+
+	viper.Set("peer.tokenEncryption.keyFile", filepath.Join("testdata", "missing-key.pem"))
+	_, err = GetTokenEncryptionKey()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error loading token encryption key")
+}
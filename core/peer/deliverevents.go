@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package peer
 
 import (
+	"crypto/rand"
 	"runtime/debug"
 	"time"
 
@@ -17,8 +18,12 @@ import (
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/msp"
 	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
 	"github.com/hyperledger/fabric/protos/utils"
+	tk "github.com/hyperledger/fabric/token"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
@@ -29,10 +34,24 @@ var logger = flogging.MustGetLogger("common.deliverevents")
 // given resource name
 type PolicyCheckerProvider func(resourceName string) deliver.PolicyCheckerFunc
 
+// PrivateDataProvider provides the private data of the blocks delivered with
+// private data
+type PrivateDataProvider interface {
+	// PrivateData returns the private data of the transactions of block
+	// blockNum of the channel, keyed by transaction sequence number in the
+	// block, restricted to the collections the organization mspID is a
+	// member of.
+	PrivateData(channelID string, blockNum uint64, mspID string) (map[uint64]*rwset.TxPvtReadWriteSet, error)
+}
+
 // server holds the dependencies necessary to create a deliver server
 type server struct {
 	dh                    *deliver.Handler
 	policyCheckerProvider PolicyCheckerProvider
+	privateData           PrivateDataProvider
+	// tokenTransactions adds the outputs of token transactions to the
+	// filtered blocks
+	tokenTransactions bool
 }
 
 // blockResponseSender structure used to send block responses
@@ -59,6 +78,20 @@ func (brs *blockResponseSender) SendBlockResponse(block *common.Block) error {
 // filteredBlockResponseSender structure used to send filtered block responses
 type filteredBlockResponseSender struct {
 	peer.Deliver_DeliverFilteredServer
+	// tokens, when set, filters the outputs of token transactions for the
+	// subscriber
+	tokens *tokenFilter
+	// filter, when set, restricts the transactions sent to the subscriber
+	filter *eventFilter
+}
+
+// tokenFilter filters the outputs of token transactions for a subscriber. The
+// owners of the outputs are replaced by hashes keyed with the keys of the
+// owners, derived from a secret of the deliver stream, so that the subscriber
+// can only resolve the outputs it owns, with its own key.
+type tokenFilter struct {
+	secret   []byte
+	ownerKey []byte
 }
 
 // SendStatusResponse generates status reply proto message
@@ -75,11 +108,34 @@ func (fbrs *filteredBlockResponseSender) IsFiltered() bool {
 	return true
 }
 
+// SetSubscriber sets the identity whose token outputs the subscriber can
+// resolve in the filtered blocks.
+func (fbrs *filteredBlockResponseSender) SetSubscriber(identity []byte) {
+	if fbrs.tokens != nil {
+		fbrs.tokens.ownerKey = tk.OwnerKey(fbrs.tokens.secret, identity)
+	}
+}
+
+// SetSeekExtension sets the event filter carried by the extension of the
+// deliver request, if any.
+func (fbrs *filteredBlockResponseSender) SetSeekExtension(extension []byte) error {
+	if len(extension) == 0 {
+		fbrs.filter = nil
+		return nil
+	}
+	filter := &peer.EventFilter{}
+	if err := proto.Unmarshal(extension, filter); err != nil {
+		return errors.Wrap(err, "error unmarshaling event filter")
+	}
+	fbrs.filter = (*eventFilter)(filter)
+	return nil
+}
+
 // SendBlockResponse generates deliver response with block message
 func (fbrs *filteredBlockResponseSender) SendBlockResponse(block *common.Block) error {
 	// Generates filtered block response
 	b := blockEvent(*block)
-	filteredBlock, err := b.toFilteredBlock()
+	filteredBlock, err := b.toFilteredBlock(fbrs.tokens, fbrs.filter)
 	if err != nil {
 		logger.Warningf("Failed to generate filtered block due to: %s", err)
 		return fbrs.SendStatusResponse(common.Status_BAD_REQUEST)
@@ -90,6 +146,62 @@ func (fbrs *filteredBlockResponseSender) SendBlockResponse(block *common.Block)
 	return fbrs.Send(response)
 }
 
+// blockAndPrivateDataResponseSender structure used to send block responses
+// along with the private data the subscriber is eligible to
+type blockAndPrivateDataResponseSender struct {
+	peer.Deliver_DeliverWithPrivateDataServer
+	privateData PrivateDataProvider
+	// mspID is the MSP ID of the subscriber
+	mspID string
+}
+
+// SendStatusResponse generates status reply proto message
+func (bprs *blockAndPrivateDataResponseSender) SendStatusResponse(status common.Status) error {
+	reply := &peer.DeliverResponse{
+		Type: &peer.DeliverResponse_Status{Status: status},
+	}
+	return bprs.Send(reply)
+}
+
+// SetSubscriber sets the organization whose collections the private data
+// sent to the subscriber is restricted to.
+func (bprs *blockAndPrivateDataResponseSender) SetSubscriber(identity []byte) {
+	sID := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(identity, sID); err != nil {
+		logger.Warningf("Failed to unmarshal the identity of the subscriber: %s", err)
+		bprs.mspID = ""
+		return
+	}
+	bprs.mspID = sID.Mspid
+}
+
+// SendBlockResponse generates deliver response with block and private data
+// message
+func (bprs *blockAndPrivateDataResponseSender) SendBlockResponse(block *common.Block) error {
+	channelID, err := utils.GetChainIDFromBlock(block)
+	if err != nil {
+		logger.Warningf("Failed to get the channel of block %d: %s", block.Header.Number, err)
+		return bprs.SendStatusResponse(common.Status_BAD_REQUEST)
+	}
+	var privateDataMap map[uint64]*rwset.TxPvtReadWriteSet
+	if bprs.mspID != "" {
+		privateDataMap, err = bprs.privateData.PrivateData(channelID, block.Header.Number, bprs.mspID)
+		if err != nil {
+			logger.Warningf("Failed to get the private data of block %d of channel %s: %s", block.Header.Number, channelID, err)
+			return bprs.SendStatusResponse(common.Status_INTERNAL_SERVER_ERROR)
+		}
+	}
+	response := &peer.DeliverResponse{
+		Type: &peer.DeliverResponse_BlockAndPrivateData{
+			BlockAndPrivateData: &peer.BlockAndPrivateData{
+				Block:          block,
+				PrivateDataMap: privateDataMap,
+			},
+		},
+	}
+	return bprs.Send(response)
+}
+
 // transactionActions aliasing for peer.TransactionAction pointers slice
 type transactionActions []*peer.TransactionAction
 
@@ -101,13 +213,21 @@ type blockEvent common.Block
 func (s *server) DeliverFiltered(srv peer.Deliver_DeliverFilteredServer) error {
 	logger.Debugf("Starting new DeliverFiltered handler")
 	defer dumpStacktraceOnPanic()
+	responseSender := &filteredBlockResponseSender{
+		Deliver_DeliverFilteredServer: srv,
+	}
+	if s.tokenTransactions {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return errors.Wrap(err, "failed generating the owner secret of the deliver stream")
+		}
+		responseSender.tokens = &tokenFilter{secret: secret}
+	}
 	// getting policy checker based on resources.Event_FilteredBlock resource name
 	deliverServer := &deliver.Server{
-		Receiver:      srv,
-		PolicyChecker: s.policyCheckerProvider(resources.Event_FilteredBlock),
-		ResponseSender: &filteredBlockResponseSender{
-			Deliver_DeliverFilteredServer: srv,
-		},
+		Receiver:       srv,
+		PolicyChecker:  s.policyCheckerProvider(resources.Event_FilteredBlock),
+		ResponseSender: responseSender,
 	}
 	return s.dh.Handle(srv.Context(), deliverServer)
 }
@@ -127,9 +247,29 @@ func (s *server) Deliver(srv peer.Deliver_DeliverServer) (err error) {
 	return s.dh.Handle(srv.Context(), deliverServer)
 }
 
-// NewDeliverEventsServer creates a peer.Deliver server to deliver block and
-// filtered block events
-func NewDeliverEventsServer(mutualTLS bool, policyCheckerProvider PolicyCheckerProvider, chainManager deliver.ChainManager, metricsProvider metrics.Provider) peer.DeliverServer {
+// DeliverWithPrivateData sends a stream of blocks and the private data the
+// client is eligible to after commitment
+func (s *server) DeliverWithPrivateData(srv peer.Deliver_DeliverWithPrivateDataServer) error {
+	logger.Debugf("Starting new DeliverWithPrivateData handler")
+	defer dumpStacktraceOnPanic()
+	if s.privateData == nil {
+		return errors.New("private data delivery is not supported by this peer")
+	}
+	// getting policy checker based on resources.Event_BlockAndPrivateData resource name
+	deliverServer := &deliver.Server{
+		PolicyChecker: s.policyCheckerProvider(resources.Event_BlockAndPrivateData),
+		Receiver:      srv,
+		ResponseSender: &blockAndPrivateDataResponseSender{
+			Deliver_DeliverWithPrivateDataServer: srv,
+			privateData:                          s.privateData,
+		},
+	}
+	return s.dh.Handle(srv.Context(), deliverServer)
+}
+
+// NewDeliverEventsServer creates a peer.Deliver server to deliver block,
+// filtered block and block and private data events
+func NewDeliverEventsServer(mutualTLS bool, policyCheckerProvider PolicyCheckerProvider, chainManager deliver.ChainManager, privateData PrivateDataProvider, metricsProvider metrics.Provider) peer.DeliverServer {
 	timeWindow := viper.GetDuration("peer.authentication.timewindow")
 	if timeWindow == 0 {
 		defaultTimeWindow := 15 * time.Minute
@@ -137,9 +277,23 @@ func NewDeliverEventsServer(mutualTLS bool, policyCheckerProvider PolicyCheckerP
 		timeWindow = defaultTimeWindow
 	}
 	metrics := deliver.NewMetrics(metricsProvider)
+	dh := deliver.NewHandler(chainManager, timeWindow, mutualTLS, metrics)
+	limits := deliver.Limits{
+		MaxSessions:     viper.GetInt("peer.deliverLimits.maxSessionsPerIdentity"),
+		BlocksPerSecond: viper.GetFloat64("peer.deliverLimits.blocksPerSecond"),
+		BlockBurst:      viper.GetInt("peer.deliverLimits.blockBurst"),
+	}
+	if limits.MaxSessions > 0 || limits.BlocksPerSecond > 0 {
+		dh.Limiter = deliver.NewSessionLimiter(limits)
+	}
+	if maxPendingBytes := viper.GetInt("peer.deliverLimits.maxPendingBytes"); maxPendingBytes > 0 {
+		dh.MemoryLimiter = deliver.NewMemoryLimiter(int64(maxPendingBytes))
+	}
 	return &server{
-		dh:                    deliver.NewHandler(chainManager, timeWindow, mutualTLS, metrics),
+		dh:                    dh,
 		policyCheckerProvider: policyCheckerProvider,
+		privateData:           privateData,
+		tokenTransactions:     viper.GetBool("peer.filteredBlocks.tokenTransactions"),
 	}
 }
 
@@ -154,10 +308,13 @@ func (s *server) sendProducer(srv peer.Deliver_DeliverFilteredServer) func(msg p
 	}
 }
 
-func (block *blockEvent) toFilteredBlock() (*peer.FilteredBlock, error) {
+func (block *blockEvent) toFilteredBlock(tokens *tokenFilter, filter *eventFilter) (*peer.FilteredBlock, error) {
 	filteredBlock := &peer.FilteredBlock{
 		Number: block.Header.Number,
 	}
+	if tokens != nil {
+		filteredBlock.OwnerKey = tokens.ownerKey
+	}
 
 	txsFltr := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
 	for txIndex, ebytes := range block.Data.Data {
@@ -193,6 +350,9 @@ func (block *blockEvent) toFilteredBlock() (*peer.FilteredBlock, error) {
 		}
 
 		filteredBlock.ChannelId = chdr.ChannelId
+		if !filter.keepsType(common.HeaderType(chdr.Type)) {
+			continue
+		}
 
 		filteredTransaction := &peer.FilteredTransaction{
 			Txid:             chdr.TxId,
@@ -206,11 +366,28 @@ func (block *blockEvent) toFilteredBlock() (*peer.FilteredBlock, error) {
 				return nil, errors.WithMessage(err, "error unmarshal transaction payload for block event")
 			}
 
-			filteredTransaction.Data, err = transactionActions(tx.Actions).toFilteredActions()
+			actions, err := transactionActions(tx.Actions).toFilteredActions()
 			if err != nil {
 				logger.Errorf(err.Error())
 				return nil, err
 			}
+			if filter != nil {
+				actions.TransactionActions.ChaincodeActions = filter.chaincodeActions(actions.TransactionActions.ChaincodeActions)
+				if len(actions.TransactionActions.ChaincodeActions) == 0 {
+					continue
+				}
+			}
+			filteredTransaction.Data = actions
+		}
+
+		if filteredTransaction.Type == common.HeaderType_TOKEN_TRANSACTION && tokens != nil {
+			tokenTransaction, err := tokens.toFilteredTokenTransaction(chdr.TxId, payload.Data)
+			if err != nil {
+				// invalid token transactions may carry anything
+				logger.Debugf("could not filter token transaction %s: %s", chdr.TxId, err)
+			} else {
+				filteredTransaction.Data = tokenTransaction
+			}
 		}
 
 		filteredBlock.FilteredTransactions = append(filteredBlock.FilteredTransactions, filteredTransaction)
@@ -262,6 +439,105 @@ func (ta transactionActions) toFilteredActions() (*peer.FilteredTransaction_Tran
 	}, nil
 }
 
+// eventFilter is an alias for peer.EventFilter, used to extend it with the
+// matching of transactions.
+type eventFilter peer.EventFilter
+
+// keepsType returns true if the transactions of the type pass the filter: a
+// filter keeps the endorser transactions, whose chaincode events it matches,
+// and the token transactions if it asks for them. A nil filter keeps all the
+// transactions.
+func (filter *eventFilter) keepsType(typ common.HeaderType) bool {
+	if filter == nil {
+		return true
+	}
+	switch typ {
+	case common.HeaderType_ENDORSER_TRANSACTION:
+		return true
+	case common.HeaderType_TOKEN_TRANSACTION:
+		return filter.TokenTransactions
+	default:
+		return false
+	}
+}
+
+// chaincodeActions returns the chaincode actions whose events match the
+// chaincode IDs and the event names of the filter. An empty list matches
+// every chaincode ID or event name.
+func (filter *eventFilter) chaincodeActions(actions []*peer.FilteredChaincodeAction) []*peer.FilteredChaincodeAction {
+	var matching []*peer.FilteredChaincodeAction
+	for _, action := range actions {
+		event := action.GetChaincodeEvent()
+		if matches(filter.ChaincodeIds, event.GetChaincodeId()) && matches(filter.EventNames, event.GetEventName()) {
+			matching = append(matching, action)
+		}
+	}
+	return matching
+}
+
+func matches(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// toFilteredTokenTransaction returns the outputs of a plain token transaction,
+// with their owners hashed. The outputs of encrypted token transactions are
+// not disclosed.
+func (tf *tokenFilter) toFilteredTokenTransaction(txID string, data []byte) (*peer.FilteredTransaction_TokenTransaction, error) {
+	ttx := &token.TokenTransaction{}
+	if err := proto.Unmarshal(data, ttx); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling token transaction")
+	}
+
+	filtered := &peer.FilteredTokenTransaction{}
+	addOutput := func(owner []byte, tokenType string, quantity uint64) {
+		output := &peer.FilteredTokenOutput{Type: tokenType, Quantity: quantity}
+		// the outputs of redeem transactions have no owner
+		if len(owner) != 0 {
+			output.OwnerHash = tk.OwnerHash(tk.OwnerKey(tf.secret, owner), txID, len(filtered.Outputs))
+		}
+		filtered.Outputs = append(filtered.Outputs, output)
+	}
+	addOutputs := func(outputs []*token.PlainOutput) {
+		for _, output := range outputs {
+			addOutput(output.Owner, output.Type, output.Quantity)
+		}
+	}
+	addDelegatedOutput := func(output *token.PlainDelegatedOutput) {
+		if output != nil {
+			addOutput(output.Owner, output.Type, output.Quantity)
+		}
+	}
+
+	switch action := ttx.GetPlainAction().GetData().(type) {
+	case *token.PlainTokenAction_PlainImport:
+		addOutputs(action.PlainImport.GetOutputs())
+	case *token.PlainTokenAction_PlainTransfer:
+		addOutputs(action.PlainTransfer.GetOutputs())
+	case *token.PlainTokenAction_PlainRedeem:
+		addOutputs(action.PlainRedeem.GetOutputs())
+	case *token.PlainTokenAction_PlainApprove:
+		if output := action.PlainApprove.GetOutput(); output != nil {
+			addOutput(output.Owner, output.Type, output.Quantity)
+		}
+		for _, output := range action.PlainApprove.GetDelegatedOutputs() {
+			addDelegatedOutput(output)
+		}
+	case *token.PlainTokenAction_PlainTransfer_From:
+		addOutputs(action.PlainTransfer_From.GetOutputs())
+		addDelegatedOutput(action.PlainTransfer_From.GetDelegatedOutput())
+	}
+
+	return &peer.FilteredTransaction_TokenTransaction{TokenTransaction: filtered}, nil
+}
+
 func dumpStacktraceOnPanic() {
 	func() {
 		if r := recover(); r != nil {
@@ -18,10 +18,16 @@ import (
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/msp"
 	"github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
 	"github.com/hyperledger/fabric/protos/utils"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -167,6 +173,204 @@ func TestFilteredBlockResponseSenderIsFiltered(t *testing.T) {
 	assert.True(t, filtered.IsFiltered(), "should return true from IsFiltered")
 }
 
+func TestFilteredBlockResponseSenderSubscriber(t *testing.T) {
+	var fbrs interface{} = &filteredBlockResponseSender{}
+	subscriberAware, ok := fbrs.(deliver.SubscriberAware)
+	assert.True(t, ok, "should be subscriber aware")
+	subscriberAware.SetSubscriber([]byte("alice"))
+
+	fbrs = &filteredBlockResponseSender{tokens: &tokenFilter{secret: []byte("secret")}}
+	fbrs.(deliver.SubscriberAware).SetSubscriber([]byte("alice"))
+	assert.Equal(t, tk.OwnerKey([]byte("secret"), []byte("alice")), fbrs.(*filteredBlockResponseSender).tokens.ownerKey)
+}
+
+func TestToFilteredBlockTokenTransactions(t *testing.T) {
+	secret := []byte("secret")
+	tokenTx := &token.TokenTransaction{
+		Action: &token.TokenTransaction_PlainAction{PlainAction: &token.PlainTokenAction{
+			Data: &token.PlainTokenAction_PlainTransfer{PlainTransfer: &token.PlainTransfer{
+				Outputs: []*token.PlainOutput{
+					{Owner: []byte("bob"), Type: "USD", Quantity: 10},
+					{Owner: []byte("alice"), Type: "USD", Quantity: 90},
+				},
+			}},
+		}},
+	}
+	envelopes := []*common.Envelope{
+		{Payload: utils.MarshalOrPanic(&common.Payload{
+			Header: &common.Header{ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{
+				Type: int32(common.HeaderType_TOKEN_TRANSACTION), ChannelId: "testchannel", TxId: "tx1",
+			})},
+			Data: utils.MarshalOrPanic(tokenTx),
+		})},
+		{Payload: utils.MarshalOrPanic(&common.Payload{
+			Header: &common.Header{ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{
+				Type: int32(common.HeaderType_TOKEN_TRANSACTION), ChannelId: "testchannel", TxId: "tx2",
+			})},
+			Data: []byte("garbage"),
+		})},
+	}
+	block, err := createTestBlock(envelopes)
+	assert.NoError(t, err)
+	b := blockEvent(*block)
+
+	filteredBlock, err := b.toFilteredBlock(nil, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, filteredBlock.OwnerKey)
+	assert.Nil(t, filteredBlock.FilteredTransactions[0].Data)
+
+	aliceKey := tk.OwnerKey(secret, []byte("alice"))
+	filteredBlock, err = b.toFilteredBlock(&tokenFilter{secret: secret, ownerKey: aliceKey}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, aliceKey, filteredBlock.OwnerKey)
+	assert.Len(t, filteredBlock.FilteredTransactions, 2)
+	outputs := filteredBlock.FilteredTransactions[0].GetTokenTransaction().GetOutputs()
+	assert.Equal(t, []*peer.FilteredTokenOutput{
+		{OwnerHash: tk.OwnerHash(tk.OwnerKey(secret, []byte("bob")), "tx1", 0), Type: "USD", Quantity: 10},
+		{OwnerHash: tk.OwnerHash(aliceKey, "tx1", 1), Type: "USD", Quantity: 90},
+	}, outputs)
+	assert.Nil(t, filteredBlock.FilteredTransactions[1].Data)
+	assert.Equal(t, map[string][]int{"tx1": {1}}, tk.OwnedOutputs(filteredBlock))
+}
+
+func TestFilteredBlockResponseSenderSeekExtension(t *testing.T) {
+	var fbrs interface{} = &filteredBlockResponseSender{}
+	seekExtensionAware, ok := fbrs.(deliver.SeekExtensionAware)
+	assert.True(t, ok, "should be seek extension aware")
+
+	err := seekExtensionAware.SetSeekExtension(utils.MarshalOrPanic(&peer.EventFilter{EventNames: []string{"event"}}))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"event"}, fbrs.(*filteredBlockResponseSender).filter.EventNames)
+
+	err = seekExtensionAware.SetSeekExtension(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, fbrs.(*filteredBlockResponseSender).filter)
+
+	err = seekExtensionAware.SetSeekExtension([]byte("garbage"))
+	assert.Contains(t, err.Error(), "error unmarshaling event filter")
+}
+
+func TestToFilteredBlockEventFilter(t *testing.T) {
+	var envelopes []*common.Envelope
+	for _, event := range []struct{ chaincode, name, txID string }{
+		{"cc1", "event1", "tx1"},
+		{"cc1", "event2", "tx2"},
+		{"cc2", "event1", "tx3"},
+	} {
+		action, err := createChaincodeAction(event.chaincode, event.name, event.txID)
+		assert.NoError(t, err)
+		payload, err := createEndorsement("testchannel", event.txID, action)
+		assert.NoError(t, err)
+		envelopes = append(envelopes, &common.Envelope{Payload: utils.MarshalOrPanic(payload)})
+	}
+	envelopes = append(envelopes, &common.Envelope{Payload: utils.MarshalOrPanic(&common.Payload{
+		Header: &common.Header{ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{
+			Type: int32(common.HeaderType_TOKEN_TRANSACTION), ChannelId: "testchannel", TxId: "tx4",
+		})},
+	})})
+	block, err := createTestBlock(envelopes)
+	assert.NoError(t, err)
+	b := blockEvent(*block)
+
+	txIDs := func(filter *eventFilter) []string {
+		filteredBlock, err := b.toFilteredBlock(nil, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, "testchannel", filteredBlock.ChannelId)
+		txIDs := []string{}
+		for _, tx := range filteredBlock.FilteredTransactions {
+			txIDs = append(txIDs, tx.Txid)
+		}
+		return txIDs
+	}
+
+	assert.Equal(t, []string{"tx1", "tx2", "tx3", "tx4"}, txIDs(nil))
+	assert.Equal(t, []string{"tx1", "tx2", "tx3"}, txIDs(&eventFilter{}))
+	assert.Equal(t, []string{"tx1", "tx2"}, txIDs(&eventFilter{ChaincodeIds: []string{"cc1"}}))
+	assert.Equal(t, []string{"tx1", "tx3"}, txIDs(&eventFilter{EventNames: []string{"event1"}}))
+	assert.Equal(t, []string{"tx3", "tx4"}, txIDs(&eventFilter{ChaincodeIds: []string{"cc2"}, TokenTransactions: true}))
+	assert.Equal(t, []string{}, txIDs(&eventFilter{ChaincodeIds: []string{"cc3"}}))
+}
+
+// mockPrivateDataProvider returns the private data of its map, keyed by MSP ID
+type mockPrivateDataProvider map[string]map[uint64]*rwset.TxPvtReadWriteSet
+
+func (m mockPrivateDataProvider) PrivateData(channelID string, blockNum uint64, mspID string) (map[uint64]*rwset.TxPvtReadWriteSet, error) {
+	if mspID == "BrokenMSP" {
+		return nil, errors.New("banana")
+	}
+	return m[mspID], nil
+}
+
+func TestBlockAndPrivateDataResponseSender(t *testing.T) {
+	block, err := createTestBlock([]*common.Envelope{{Payload: utils.MarshalOrPanic(&common.Payload{
+		Header: &common.Header{ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{ChannelId: "testchannel"})},
+	})}})
+	assert.NoError(t, err)
+	org1Data := map[uint64]*rwset.TxPvtReadWriteSet{
+		0: {NsPvtRwset: []*rwset.NsPvtReadWriteSet{{Namespace: "mycc"}}},
+	}
+
+	var responses []*peer.DeliverResponse
+	deliverServer := &mockDeliverServer{}
+	deliverServer.On("Send", mock.Anything).Run(func(args mock.Arguments) {
+		responses = append(responses, args.Get(0).(*peer.DeliverResponse))
+	}).Return(nil)
+	var bprs interface{} = &blockAndPrivateDataResponseSender{
+		Deliver_DeliverWithPrivateDataServer: deliverServer,
+		privateData:                          mockPrivateDataProvider{"Org1MSP": org1Data},
+	}
+	subscriberAware, ok := bprs.(deliver.SubscriberAware)
+	assert.True(t, ok, "should be subscriber aware")
+	_, ok = bprs.(deliver.Filtered)
+	assert.False(t, ok, "should not be filtered")
+	responseSender := bprs.(deliver.ResponseSender)
+
+	subscriberAware.SetSubscriber(utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP"}))
+	assert.NoError(t, responseSender.SendBlockResponse(block))
+	subscriberAware.SetSubscriber(utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org2MSP"}))
+	assert.NoError(t, responseSender.SendBlockResponse(block))
+	subscriberAware.SetSubscriber([]byte("garbage"))
+	assert.NoError(t, responseSender.SendBlockResponse(block))
+	subscriberAware.SetSubscriber(utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "BrokenMSP"}))
+	assert.NoError(t, responseSender.SendBlockResponse(block))
+
+	assert.Len(t, responses, 4)
+	assert.Equal(t, block, responses[0].GetBlockAndPrivateData().GetBlock())
+	assert.Equal(t, org1Data, responses[0].GetBlockAndPrivateData().GetPrivateDataMap())
+	assert.Equal(t, block, responses[1].GetBlockAndPrivateData().GetBlock())
+	assert.Empty(t, responses[1].GetBlockAndPrivateData().GetPrivateDataMap())
+	assert.Equal(t, block, responses[2].GetBlockAndPrivateData().GetBlock())
+	assert.Empty(t, responses[2].GetBlockAndPrivateData().GetPrivateDataMap())
+	assert.Equal(t, common.Status_INTERNAL_SERVER_ERROR, responses[3].GetStatus())
+}
+
+func TestEventsServerResources(t *testing.T) {
+	viper.Set("peer.authentication.timewindow", "1s")
+	var checked []string
+	policyCheckerProvider := func(resourceName string) deliver.PolicyCheckerFunc {
+		checked = append(checked, resourceName)
+		return func(_ *common.Envelope, _ string) error {
+			return nil
+		}
+	}
+	newDeliverServer := func() *mockDeliverServer {
+		deliverServer := &mockDeliverServer{}
+		deliverServer.On("Context").Return(peer2.NewContext(context.TODO(), &peer2.Peer{}))
+		deliverServer.On("Recv").Return(nil, io.EOF)
+		return deliverServer
+	}
+
+	server := NewDeliverEventsServer(false, policyCheckerProvider, &mockChainManager{}, mockPrivateDataProvider{}, &disabled.Provider{})
+	assert.NoError(t, server.Deliver(newDeliverServer()))
+	assert.NoError(t, server.DeliverFiltered(newDeliverServer()))
+	assert.NoError(t, server.DeliverWithPrivateData(newDeliverServer()))
+	assert.Equal(t, []string{resources.Event_Block, resources.Event_FilteredBlock, resources.Event_BlockAndPrivateData}, checked)
+
+	server = NewDeliverEventsServer(false, policyCheckerProvider, &mockChainManager{}, nil, &disabled.Provider{})
+	err := server.DeliverWithPrivateData(newDeliverServer())
+	assert.EqualError(t, err, "private data delivery is not supported by this peer")
+}
+
 func TestEventsServer_DeliverFiltered(t *testing.T) {
 	viper.Set("peer.authentication.timewindow", "1s")
 	tests := []testCase{
@@ -383,6 +587,7 @@ func TestEventsServer_DeliverFiltered(t *testing.T) {
 				false,
 				defaultPolicyCheckerProvider,
 				chainManager,
+				nil,
 				&disabled.Provider{},
 			)
 			err := server.DeliverFiltered(deliverServer)
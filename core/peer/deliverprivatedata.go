@@ -0,0 +1,108 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/pkg/errors"
+)
+
+// DeliverPrivateDataProvider provides the private data of the blocks of the
+// channels of the peer to the deliver service
+type DeliverPrivateDataProvider struct {
+}
+
+// PrivateData returns the private data of the transactions of a block,
+// restricted to the collections the organization mspID is a member of.
+func (DeliverPrivateDataProvider) PrivateData(channelID string, blockNum uint64, mspID string) (map[uint64]*rwset.TxPvtReadWriteSet, error) {
+	l := GetLedger(channelID)
+	if l == nil {
+		return nil, errors.Errorf("channel %s not found", channelID)
+	}
+	pvtData, err := l.GetPvtDataByNum(blockNum, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed retrieving private data")
+	}
+	store := privdata.NewSimpleCollectionStore(&CollectionSupport{PeerLedger: l})
+	memberOrgs := func(namespace, collection string) ([]string, error) {
+		policy, err := store.RetrieveCollectionAccessPolicy(common.CollectionCriteria{
+			Channel:    channelID,
+			Namespace:  namespace,
+			Collection: collection,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return policy.MemberOrgs(), nil
+	}
+	return eligiblePrivateData(pvtData, mspID, memberOrgs)
+}
+
+// eligiblePrivateData returns the private data of the collections whose
+// member organizations, as returned by memberOrgs, include mspID, keyed by
+// transaction sequence number in the block. Transactions without eligible
+// private data are left out.
+func eligiblePrivateData(pvtData []*ledger.TxPvtData, mspID string, memberOrgs func(namespace, collection string) ([]string, error)) (map[uint64]*rwset.TxPvtReadWriteSet, error) {
+	// the eligibility of each collection is looked up once per block
+	eligible := map[string]map[string]bool{}
+	isEligible := func(namespace, collection string) (bool, error) {
+		if e, ok := eligible[namespace][collection]; ok {
+			return e, nil
+		}
+		orgs, err := memberOrgs(namespace, collection)
+		switch err.(type) {
+		case nil:
+		case privdata.NoSuchCollectionError:
+			// the collection has been removed from the chaincode definition
+			orgs = nil
+		default:
+			return false, errors.WithMessage(err, "failed retrieving collection access policy")
+		}
+		e := false
+		for _, org := range orgs {
+			if org == mspID {
+				e = true
+				break
+			}
+		}
+		if eligible[namespace] == nil {
+			eligible[namespace] = map[string]bool{}
+		}
+		eligible[namespace][collection] = e
+		return e, nil
+	}
+
+	privateDataMap := map[uint64]*rwset.TxPvtReadWriteSet{}
+	for _, txPvtData := range pvtData {
+		if txPvtData.WriteSet == nil {
+			continue
+		}
+		filtered := &rwset.TxPvtReadWriteSet{DataModel: txPvtData.WriteSet.DataModel}
+		for _, nsPvtRwset := range txPvtData.WriteSet.NsPvtRwset {
+			filteredNs := &rwset.NsPvtReadWriteSet{Namespace: nsPvtRwset.Namespace}
+			for _, collPvtRwset := range nsPvtRwset.CollectionPvtRwset {
+				e, err := isEligible(nsPvtRwset.Namespace, collPvtRwset.CollectionName)
+				if err != nil {
+					return nil, err
+				}
+				if e {
+					filteredNs.CollectionPvtRwset = append(filteredNs.CollectionPvtRwset, collPvtRwset)
+				}
+			}
+			if len(filteredNs.CollectionPvtRwset) != 0 {
+				filtered.NsPvtRwset = append(filtered.NsPvtRwset, filteredNs)
+			}
+		}
+		if len(filtered.NsPvtRwset) != 0 {
+			privateDataMap[txPvtData.SeqInBlock] = filtered
+		}
+	}
+	return privateDataMap, nil
+}
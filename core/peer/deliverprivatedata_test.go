@@ -0,0 +1,82 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEligiblePrivateData(t *testing.T) {
+	coll := func(name string) *rwset.CollectionPvtReadWriteSet {
+		return &rwset.CollectionPvtReadWriteSet{CollectionName: name, Rwset: []byte(name)}
+	}
+	pvtData := []*ledger.TxPvtData{
+		{SeqInBlock: 0, WriteSet: &rwset.TxPvtReadWriteSet{NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+			{Namespace: "mycc", CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{coll("org1"), coll("org2"), coll("all")}},
+		}}},
+		{SeqInBlock: 2, WriteSet: &rwset.TxPvtReadWriteSet{NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+			{Namespace: "mycc", CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{coll("org2")}},
+			{Namespace: "othercc", CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{coll("removed")}},
+		}}},
+		{SeqInBlock: 3},
+	}
+	lookups := 0
+	memberOrgs := func(namespace, collection string) ([]string, error) {
+		lookups++
+		switch collection {
+		case "org1":
+			return []string{"Org1MSP"}, nil
+		case "org2":
+			return []string{"Org2MSP"}, nil
+		case "all":
+			return []string{"Org1MSP", "Org2MSP"}, nil
+		case "removed":
+			return nil, privdata.NoSuchCollectionError(common.CollectionCriteria{Namespace: namespace, Collection: collection})
+		default:
+			return nil, errors.New("banana")
+		}
+	}
+
+	privateDataMap, err := eligiblePrivateData(pvtData, "Org1MSP", memberOrgs)
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint64]*rwset.TxPvtReadWriteSet{
+		0: {NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+			{Namespace: "mycc", CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{coll("org1"), coll("all")}},
+		}},
+	}, privateDataMap)
+	// collection access policies are looked up once per block
+	assert.Equal(t, 4, lookups)
+
+	privateDataMap, err = eligiblePrivateData(pvtData, "Org2MSP", memberOrgs)
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint64]*rwset.TxPvtReadWriteSet{
+		0: {NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+			{Namespace: "mycc", CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{coll("org2"), coll("all")}},
+		}},
+		2: {NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+			{Namespace: "mycc", CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{coll("org2")}},
+		}},
+	}, privateDataMap)
+
+	pvtData = append(pvtData, &ledger.TxPvtData{SeqInBlock: 4, WriteSet: &rwset.TxPvtReadWriteSet{NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+		{Namespace: "mycc", CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{coll("broken")}},
+	}}})
+	_, err = eligiblePrivateData(pvtData, "Org1MSP", memberOrgs)
+	assert.EqualError(t, err, "failed retrieving collection access policy: banana")
+}
+
+func TestDeliverPrivateDataProviderChannelNotFound(t *testing.T) {
+	_, err := DeliverPrivateDataProvider{}.PrivateData("nonexistent", 0, "Org1MSP")
+	assert.EqualError(t, err, "channel nonexistent not found")
+}
@@ -9,6 +9,7 @@ package peer
 import (
 	"fmt"
 	"net"
+	"path/filepath"
 	"runtime"
 	"sync"
 
@@ -25,10 +26,12 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/committer"
+	"github.com/hyperledger/fabric/core/committer/invalidation"
 	"github.com/hyperledger/fabric/core/committer/txvalidator"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
+	"github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/customtx"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
@@ -40,6 +43,8 @@ import (
 	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/hyperledger/fabric/token/deadletter"
+	"github.com/hyperledger/fabric/token/tms/driver"
 	"github.com/hyperledger/fabric/token/tms/manager"
 	"github.com/hyperledger/fabric/token/transaction"
 	"github.com/pkg/errors"
@@ -53,8 +58,12 @@ var peerServer *comm.GRPCServer
 
 var configTxProcessor = newConfigTxProcessor()
 var tokenTxProcessor = &transaction.Processor{
-	TMSManager: &manager.Manager{
-		IdentityDeserializerManager: &manager.FabricIdentityDeserializerManager{}}}
+	TMSManager: &driver.Manager{
+		Registry: driver.Default(),
+		Dependencies: &driver.Dependencies{
+			IdentityDeserializerManager: &manager.FabricIdentityDeserializerManager{},
+			ApplicationConfig:           getApplicationConfig,
+		}}}
 var ConfigTxProcessors = customtx.Processors{
 	common.HeaderType_CONFIG:            configTxProcessor,
 	common.HeaderType_TOKEN_TRANSACTION: tokenTxProcessor,
@@ -199,6 +208,132 @@ func MockSetMSPIDGetter(mspIDGetter func(string) []string) {
 // there are not too many concurrent tx validation goroutines
 var validationWorkersSemaphore *semaphore.Weighted
 
+// validationWatchdog bounds the validation time of each transaction
+var validationWatchdog *txvalidator.Watchdog
+
+// validationMetrics records the validation time of blocks
+var validationMetrics *txvalidator.Metrics
+
+// signatureVerifier verifies the signatures of blocks before their validation
+var signatureVerifier *txvalidator.SignatureVerifier
+
+// tokenDeadletters retains the invalid token transactions of the channels
+var tokenDeadletters struct {
+	sync.Mutex
+	provider *deadletter.Provider
+}
+
+// openTokenDeadletters returns the provider of the stores of the invalid token
+// transactions, opening it on first use.
+func openTokenDeadletters() *deadletter.Provider {
+	tokenDeadletters.Lock()
+	defer tokenDeadletters.Unlock()
+	if tokenDeadletters.provider == nil {
+		tokenDeadletters.provider = deadletter.NewProvider(
+			filepath.Join(config.GetPath("peer.fileSystemPath"), "tokenDeadletters"),
+			viper.GetInt("peer.tokenDeadletters.maxEntries"),
+		)
+	}
+	return tokenDeadletters.provider
+}
+
+// GetTokenDeadletters returns the provider of the stores of the invalid token
+// transactions, or nil if they are not retained.
+func GetTokenDeadletters() *deadletter.Provider {
+	tokenDeadletters.Lock()
+	defer tokenDeadletters.Unlock()
+	return tokenDeadletters.provider
+}
+
+// invalidationReasons retains the reasons transactions of the channels are
+// invalidated
+var invalidationReasons struct {
+	sync.Mutex
+	provider *invalidation.Provider
+}
+
+// invalidationRecorder records the reasons transactions are invalidated, or is
+// nil if they are not retained
+var invalidationRecorder *invalidation.Recorder
+
+// openInvalidationReasons returns the provider of the stores of the reasons
+// transactions are invalidated, opening it on first use.
+func openInvalidationReasons(metricsProvider metrics.Provider) *invalidation.Provider {
+	invalidationReasons.Lock()
+	defer invalidationReasons.Unlock()
+	if invalidationReasons.provider == nil {
+		invalidationReasons.provider = invalidation.NewProvider(
+			filepath.Join(config.GetPath("peer.fileSystemPath"), "invalidationReasons"),
+			invalidation.Retention{
+				Period:  viper.GetDuration("peer.validation.invalidationReasons.retention"),
+				Blocks:  uint64(viper.GetInt("peer.validation.invalidationReasons.retainBlocks")),
+				MaxSize: uint64(viper.GetInt("peer.validation.invalidationReasons.maxSize")),
+			},
+			invalidation.NewMetrics(metricsProvider),
+		)
+	}
+	return invalidationReasons.provider
+}
+
+// GetInvalidationReasons returns the provider of the stores of the reasons
+// transactions are invalidated, or nil if they are not retained.
+func GetInvalidationReasons() *invalidation.Provider {
+	invalidationReasons.Lock()
+	defer invalidationReasons.Unlock()
+	return invalidationReasons.provider
+}
+
+// conflictAdvisor tracks the conflict hotspots of the channels
+var conflictAdvisor struct {
+	sync.Mutex
+	advisor *invalidation.Advisor
+}
+
+// GetConflictAdvisor returns the advisor of the conflict hotspots of the
+// channels, creating it on first use, or nil if clients are not advised of
+// them. The advisor tracks the conflicts recorded with the invalidation
+// reasons, so it requires them to be enabled.
+func GetConflictAdvisor() *invalidation.Advisor {
+	if !viper.GetBool("peer.validation.invalidationReasons.enabled") ||
+		!viper.GetBool("peer.validation.invalidationReasons.conflictAdvisory.enabled") {
+		return nil
+	}
+	conflictAdvisor.Lock()
+	defer conflictAdvisor.Unlock()
+	if conflictAdvisor.advisor == nil {
+		conflictAdvisor.advisor = &invalidation.Advisor{
+			Window:       viper.GetDuration("peer.validation.invalidationReasons.conflictAdvisory.window"),
+			MinConflicts: viper.GetInt("peer.validation.invalidationReasons.conflictAdvisory.minConflicts"),
+			MaxTxIDs:     viper.GetInt("peer.validation.invalidationReasons.conflictAdvisory.maxTxIDs"),
+			MaxKeys:      viper.GetInt("peer.validation.invalidationReasons.conflictAdvisory.maxKeys"),
+		}
+	}
+	return conflictAdvisor.advisor
+}
+
+// blockCommitListeners are notified of the blocks committed on the channels
+var blockCommitListeners struct {
+	sync.RWMutex
+	listeners []func(channelID string, block *common.Block)
+}
+
+// AddBlockCommitListener registers a listener called with every block
+// committed on the channels of the peer, once committed, from the commit
+// pipeline of the channel. Listeners must not block.
+func AddBlockCommitListener(listener func(channelID string, block *common.Block)) {
+	blockCommitListeners.Lock()
+	defer blockCommitListeners.Unlock()
+	blockCommitListeners.listeners = append(blockCommitListeners.listeners, listener)
+}
+
+func notifyBlockCommitted(channelID string, block *common.Block) {
+	blockCommitListeners.RLock()
+	defer blockCommitListeners.RUnlock()
+	for _, listener := range blockCommitListeners.listeners {
+		listener(channelID, block)
+	}
+}
+
 // Initialize sets up any chains that the peer has from the persistence. This
 // function should be called at the start up when the ledger and gossip
 // ready
@@ -211,43 +346,137 @@ func Initialize(init func(string), ccp ccprovider.ChaincodeProvider, sccp sysccp
 	}
 	validationWorkersSemaphore = semaphore.NewWeighted(int64(nWorkers))
 
-	pluginMapper = pm
-	chainInitializer = init
+	validationWatchdog = &txvalidator.Watchdog{
+		Budget:     viper.GetDuration("peer.validation.txTimeBudget"),
+		Invalidate: viper.GetBool("peer.validation.invalidateOverBudget"),
+	}
+	tokenTxProcessor.ValidationBudget = validationWatchdog.Budget
+	tokenTxProcessor.InvalidateOverBudget = validationWatchdog.Invalidate
+	validationMetrics = txvalidator.NewMetrics(metricsProvider)
+	signatureVerifier = &txvalidator.SignatureVerifier{
+		Workers:   viper.GetInt("peer.validation.signatureWorkers"),
+		BatchSize: viper.GetInt("peer.validation.signatureBatchSize"),
+	}
+	tokenEncryptionKey, err := GetTokenEncryptionKey()
+	if err != nil {
+		panic(fmt.Errorf("Error in initializing token decryption: %s", err))
+	}
+	// without a key, the peer still checks the encrypted transactions
+	tokenTxProcessor.Decrypter = &transaction.ChannelConfigDecrypter{
+		MSPID:             viper.GetString("peer.localMspId"),
+		Key:               tokenEncryptionKey,
+		ApplicationConfig: getApplicationConfig,
+	}
+	tokenDeadletterRecorder := &deadletter.Recorder{
+		Metrics: deadletter.NewMetrics(metricsProvider),
+		Logger:  flogging.MustGetLogger("token.deadletter"),
+	}
+	if viper.GetBool("peer.tokenDeadletters.enabled") {
+		tokenDeadletterRecorder.Provider = openTokenDeadletters()
+	}
+	tokenTxProcessor.InvalidTxRecorder = tokenDeadletterRecorder
 
-	var cb *common.Block
-	var ledger ledger.PeerLedger
-	ledgermgmt.Initialize(&ledgermgmt.Initializer{
+	ledgerInitializer := &ledgermgmt.Initializer{
 		CustomTxProcessors:            ConfigTxProcessors,
 		PlatformRegistry:              pr,
 		DeployedChaincodeInfoProvider: deployedCCInfoProvider,
 		MembershipInfoProvider:        membershipProvider,
 		MetricsProvider:               metricsProvider,
-	})
+	}
+	invalidationRecorder = nil
+	if viper.GetBool("peer.validation.invalidationReasons.enabled") {
+		invalidationRecorder = &invalidation.Recorder{
+			Provider: openInvalidationReasons(metricsProvider),
+			Logger:   flogging.MustGetLogger("committer.invalidation"),
+			Advisor:  GetConflictAdvisor(),
+		}
+		tokenTxProcessor.InvalidTxRecorder = transaction.InvalidTxRecorders{tokenDeadletterRecorder, invalidationRecorder}
+		ledgerInitializer.ReadConflictListener = invalidationRecorder
+	}
+
+	pluginMapper = pm
+	chainInitializer = init
+
+	ledgermgmt.Initialize(ledgerInitializer)
 	ledgerIds, err := ledgermgmt.GetLedgerIDs()
 	if err != nil {
 		panic(fmt.Errorf("Error in initializing ledgermgmt: %s", err))
 	}
+	nChannelWorkers := viper.GetInt("peer.channelInitWorkers")
+	if nChannelWorkers <= 0 {
+		nChannelWorkers = runtime.NumCPU()
+	}
 	for _, cid := range ledgerIds {
-		peerLogger.Infof("Loading chain %s", cid)
-		if ledger, err = ledgermgmt.OpenLedger(cid); err != nil {
-			peerLogger.Warningf("Failed to load ledger %s(%s)", cid, err)
-			peerLogger.Debugf("Error while loading ledger %s with message %s. We continue to the next ledger rather than abort.", cid, err)
-			continue
-		}
-		if cb, err = getCurrConfigBlockFromLedger(ledger); err != nil {
-			peerLogger.Warningf("Failed to find config block on ledger %s(%s)", cid, err)
-			peerLogger.Debugf("Error while looking for config block on ledger %s with message %s. We continue to the next ledger rather than abort.", cid, err)
+		channelReadiness.pending(cid)
+	}
+	// The ledgers are opened in parallel, as opening them recovers their
+	// state, history and token indexes, but the chains are created one at a
+	// time as their ledgers are opened.
+	for oc := range openLedgers(ledgerIds, nChannelWorkers) {
+		if oc.err != nil {
+			channelReadiness.failed(oc.cid, oc.err)
 			continue
 		}
 		// Create a chain if we get a valid ledger with config block
-		if err = createChain(cid, ledger, cb, ccp, sccp, pm); err != nil {
-			peerLogger.Warningf("Failed to load chain %s(%s)", cid, err)
-			peerLogger.Debugf("Error reloading chain %s with message %s. We continue to the next chain rather than abort.", cid, err)
+		if err = createChain(oc.cid, oc.ledger, oc.cb, ccp, sccp, pm); err != nil {
+			peerLogger.Warningf("Failed to load chain %s(%s)", oc.cid, err)
+			peerLogger.Debugf("Error reloading chain %s with message %s. We continue to the next chain rather than abort.", oc.cid, err)
+			channelReadiness.failed(oc.cid, err)
 			continue
 		}
 
-		InitChain(cid)
+		InitChain(oc.cid)
+	}
+}
+
+// openedChannel is the ledger of a channel opened at startup, with the current
+// config block of the channel.
+type openedChannel struct {
+	cid    string
+	ledger ledger.PeerLedger
+	cb     *common.Block
+	err    error
+}
+
+// openLedgers opens the ledgers of the channels with up to nWorkers goroutines
+// and returns the channels as their ledgers are opened.
+func openLedgers(ledgerIds []string, nWorkers int) <-chan *openedChannel {
+	opened := make(chan *openedChannel, len(ledgerIds))
+	workers := make(chan struct{}, nWorkers)
+	go func() {
+		var wg sync.WaitGroup
+		for _, cid := range ledgerIds {
+			workers <- struct{}{}
+			wg.Add(1)
+			go func(cid string) {
+				defer func() {
+					<-workers
+					wg.Done()
+				}()
+				opened <- openChannel(cid)
+			}(cid)
+		}
+		wg.Wait()
+		close(opened)
+	}()
+	return opened
+}
+
+func openChannel(cid string) *openedChannel {
+	peerLogger.Infof("Loading chain %s", cid)
+	l, err := ledgermgmt.OpenLedger(cid)
+	if err != nil {
+		peerLogger.Warningf("Failed to load ledger %s(%s)", cid, err)
+		peerLogger.Debugf("Error while loading ledger %s with message %s. We continue to the next ledger rather than abort.", cid, err)
+		return &openedChannel{cid: cid, err: err}
+	}
+	cb, err := getCurrConfigBlockFromLedger(l)
+	if err != nil {
+		peerLogger.Warningf("Failed to find config block on ledger %s(%s)", cid, err)
+		peerLogger.Debugf("Error while looking for config block on ledger %s with message %s. We continue to the next ledger rather than abort.", cid, err)
+		return &openedChannel{cid: cid, err: err}
 	}
+	return &openedChannel{cid: cid, ledger: l, cb: cb}
 }
 
 // InitChain takes care to initialize chain after peer joined, for example deploys system CCs
@@ -257,6 +486,7 @@ func InitChain(cid string) {
 		peerLogger.Debugf("Initializing channel %s", cid)
 		chainInitializer(cid)
 	}
+	channelReadiness.ready(cid)
 }
 
 func getCurrConfigBlockFromLedger(ledger ledger.PeerLedger) (*common.Block, error) {
@@ -383,6 +613,13 @@ func createChain(cid string, ledger ledger.PeerLedger, cb *common.Block, ccp ccp
 		*semaphore.Weighted
 	}{cs, validationWorkersSemaphore}
 	validator := txvalidator.NewTxValidator(cid, vcs, sccp, pm)
+	validator.Watchdog = validationWatchdog
+	validator.Metrics = validationMetrics
+	validator.SignatureVerifier = signatureVerifier
+	validator.HashingSuite = bundle.ChannelConfig().HashingSuite()
+	if invalidationRecorder != nil {
+		validator.InvalidationRecorder = invalidationRecorder
+	}
 	c := committer.NewLedgerCommitterReactive(ledger, func(block *common.Block) error {
 		chainID, err := utils.GetChainIDFromBlock(block)
 		if err != nil {
@@ -390,6 +627,9 @@ func createChain(cid string, ledger ledger.PeerLedger, cb *common.Block, ccp ccp
 		}
 		return SetCurrConfigBlock(block, chainID)
 	})
+	c.CommitListener = func(block *common.Block) {
+		notifyBlockCommitted(cid, block)
+	}
 
 	ordererAddresses := bundle.ChannelConfig().OrdererAddresses()
 	if len(ordererAddresses) == 0 {
@@ -473,6 +713,25 @@ func GetChannelConfig(cid string) channelconfig.Resources {
 	return nil
 }
 
+// GetHashingSuite returns the hashing suite of the chain with channel ID. Note
+// that this call returns nil if chain cid has not been created.
+func GetHashingSuite(cid string) func([]byte) []byte {
+	if res := GetStableChannelConfig(cid); res != nil {
+		return res.ChannelConfig().HashingSuite()
+	}
+	return nil
+}
+
+// getApplicationConfig returns the application config of the chain with chain ID
+// and whether it exists.
+func getApplicationConfig(cid string) (channelconfig.Application, bool) {
+	cs := GetChannelConfig(cid)
+	if cs == nil {
+		return nil, false
+	}
+	return cs.ApplicationConfig()
+}
+
 // GetPolicyManager returns the policy manager of the chain with chain ID. Note that this
 // call returns nil if chain cid has not been created.
 func GetPolicyManager(cid string) policies.Manager {
@@ -754,6 +1013,10 @@ func (flbs fileLedgerBlockStore) RetrieveBlocks(startBlockNumber uint64) (common
 	return flbs.GetBlocksIterator(startBlockNumber)
 }
 
+func (flbs fileLedgerBlockStore) RetrieveBlockByTxID(txID string) (*common.Block, error) {
+	return flbs.GetBlockByTxID(txID)
+}
+
 // NewConfigSupport returns
 func NewConfigSupport() cc.Manager {
 	return &configSupport{}
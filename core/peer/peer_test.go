@@ -30,6 +30,7 @@ import (
 	"github.com/hyperledger/fabric/msp/mgmt/testtools"
 	peergossip "github.com/hyperledger/fabric/peer/gossip"
 	"github.com/hyperledger/fabric/peer/gossip/mocks"
+	"github.com/hyperledger/fabric/protos/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
@@ -88,6 +89,15 @@ func TestInitialize(t *testing.T) {
 	defer cleanup()
 
 	Initialize(nil, &ccprovider.MockCcProviderImpl{}, (&mscc.MocksccProviderFactory{}).NewSystemChaincodeProvider(), txvalidator.MapBasedPluginMapper(map[string]validation.PluginFactory{}), nil, &ledgermocks.DeployedChaincodeInfoProvider{}, nil, &disabled.Provider{})
+
+	// the ledgers that cannot be opened are reported as well
+	var opened []string
+	for oc := range openLedgers([]string{"ch1", "ch2", "ch3"}, 2) {
+		assert.Error(t, oc.err)
+		assert.Nil(t, oc.ledger)
+		opened = append(opened, oc.cid)
+	}
+	assert.ElementsMatch(t, []string{"ch1", "ch2", "ch3"}, opened)
 }
 
 func TestCreateChainFromBlock(t *testing.T) {
@@ -110,7 +120,7 @@ func TestCreateChainFromBlock(t *testing.T) {
 	msptesttools.LoadMSPSetupForTesting()
 
 	identity, _ := mgmt.GetLocalSigningIdentityOrPanic().Serialize()
-	messageCryptoService := peergossip.NewMCS(&mocks.ChannelPolicyManagerGetter{}, localmsp.NewSigner(), mgmt.NewDeserializersManager())
+	messageCryptoService := peergossip.NewMCS(&mocks.ChannelPolicyManagerGetter{}, localmsp.NewSigner(), mgmt.NewDeserializersManager(), nil)
 	secAdv := peergossip.NewSecurityAdvisor(mgmt.NewDeserializersManager())
 	var defaultSecureDialOpts = func() []grpc.DialOption {
 		var dialOpts []grpc.DialOption
@@ -199,12 +209,34 @@ func TestCreateChainFromBlock(t *testing.T) {
 	chains.Unlock()
 }
 
+func TestBlockCommitListeners(t *testing.T) {
+	defer func() {
+		blockCommitListeners.Lock()
+		blockCommitListeners.listeners = nil
+		blockCommitListeners.Unlock()
+	}()
+
+	var notified []string
+	AddBlockCommitListener(func(channelID string, block *common.Block) {
+		notified = append(notified, fmt.Sprintf("first %s %d", channelID, block.Header.Number))
+	})
+	AddBlockCommitListener(func(channelID string, block *common.Block) {
+		notified = append(notified, fmt.Sprintf("second %s %d", channelID, block.Header.Number))
+	})
+
+	notifyBlockCommitted("testchannel", &common.Block{Header: &common.BlockHeader{Number: 3}})
+	assert.Equal(t, []string{"first testchannel 3", "second testchannel 3"}, notified)
+}
+
 func TestGetLocalIP(t *testing.T) {
 	ip := GetLocalIP()
 	t.Log(ip)
 }
 
 func TestDeliverSupportManager(t *testing.T) {
+	cleanup := setupPeerFS(t)
+	defer cleanup()
+
 	// reset chains for testing
 	MockInitialize()
 
@@ -0,0 +1,187 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+)
+
+// ChannelState is the initialization state of a channel of the peer.
+type ChannelState string
+
+const (
+	// ChannelPending is the state of the channels whose ledger is being
+	// opened at startup.
+	ChannelPending ChannelState = "pending"
+	// ChannelReady is the state of the channels the peer serves.
+	ChannelReady ChannelState = "ready"
+	// ChannelFailed is the state of the channels the peer failed to load at
+	// startup.
+	ChannelFailed ChannelState = "failed"
+)
+
+// ChannelReadiness reports the initialization of a channel of the peer.
+type ChannelReadiness struct {
+	Channel string       `json:"channel"`
+	State   ChannelState `json:"state"`
+	Error   string       `json:"error,omitempty"`
+	// Duration is the time the channel took to initialize, or has taken so
+	// far while it is pending.
+	Duration string `json:"duration"`
+}
+
+type channelStatus struct {
+	state ChannelState
+	err   error
+	start time.Time
+	end   time.Time
+}
+
+// readiness tracks the initialization of the channels of the peer.
+type readiness struct {
+	sync.RWMutex
+	channels map[string]*channelStatus
+}
+
+var channelReadiness = &readiness{channels: map[string]*channelStatus{}}
+
+func (r *readiness) pending(cid string) {
+	r.Lock()
+	defer r.Unlock()
+	r.channels[cid] = &channelStatus{state: ChannelPending, start: time.Now()}
+}
+
+func (r *readiness) ready(cid string) {
+	r.done(cid, ChannelReady, nil)
+}
+
+func (r *readiness) failed(cid string, err error) {
+	r.done(cid, ChannelFailed, err)
+}
+
+func (r *readiness) done(cid string, state ChannelState, err error) {
+	r.Lock()
+	defer r.Unlock()
+	now := time.Now()
+	status, ok := r.channels[cid]
+	if !ok {
+		// channels joined after startup are not pending beforehand
+		status = &channelStatus{start: now}
+		r.channels[cid] = status
+	}
+	status.state = state
+	status.err = err
+	status.end = now
+}
+
+func (r *readiness) list() []ChannelReadiness {
+	r.RLock()
+	defer r.RUnlock()
+	var list []ChannelReadiness
+	for cid, status := range r.channels {
+		end := status.end
+		if status.state == ChannelPending {
+			end = time.Now()
+		}
+		cr := ChannelReadiness{
+			Channel:  cid,
+			State:    status.state,
+			Duration: end.Sub(status.start).String(),
+		}
+		if status.err != nil {
+			cr.Error = status.err.Error()
+		}
+		list = append(list, cr)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Channel < list[j].Channel })
+	return list
+}
+
+// GetChannelsReadiness returns the initialization state of the channels of
+// the peer, sorted by channel ID.
+func GetChannelsReadiness() []ChannelReadiness {
+	return channelReadiness.list()
+}
+
+// Readiness is the initialization state of the channels of the peer, as
+// reported by a ReadinessHandler.
+type Readiness struct {
+	// Ready is false while the ledger of a channel is being opened.
+	Ready    bool               `json:"ready"`
+	Channels []ChannelReadiness `json:"channels"`
+}
+
+// ReadinessHandler reports the initialization state of the channels of the
+// peer on the operations endpoint. It responds with status 503 while the
+// ledgers of channels are being opened at startup, so that the peer is only
+// routed requests once it serves all its channels; channels that failed to
+// load are reported but do not hold up the readiness of the peer. The state
+// of a single channel is reported with the 'channel' query parameter.
+type ReadinessHandler struct {
+	// Readiness returns the initialization state of the channels of the peer,
+	// such as GetChannelsReadiness.
+	Readiness func() []ChannelReadiness
+	Logger    *flogging.FabricLogger
+}
+
+type readinessErrorResponse struct {
+	Error string `json:"error"`
+}
+
+func (h *ReadinessHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		h.sendResponse(resp, http.StatusBadRequest, fmt.Errorf("invalid request method: %s", req.Method))
+		return
+	}
+
+	channels := h.Readiness()
+	if channel := req.URL.Query().Get("channel"); channel != "" {
+		var found []ChannelReadiness
+		for _, cr := range channels {
+			if cr.Channel == channel {
+				found = append(found, cr)
+			}
+		}
+		if len(found) == 0 {
+			h.sendResponse(resp, http.StatusNotFound, errors.Errorf("channel %s not found", channel))
+			return
+		}
+		channels = found
+	}
+
+	readiness := &Readiness{Ready: true, Channels: channels}
+	for _, cr := range channels {
+		if cr.State == ChannelPending {
+			readiness.Ready = false
+		}
+	}
+	code := http.StatusOK
+	if !readiness.Ready {
+		code = http.StatusServiceUnavailable
+	}
+	h.sendResponse(resp, code, readiness)
+}
+
+func (h *ReadinessHandler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	if err, ok := payload.(error); ok {
+		payload = &readinessErrorResponse{Error: err.Error()}
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+	if err := json.NewEncoder(resp).Encode(payload); err != nil {
+		h.Logger.Errorw("failed to encode payload", "error", err)
+	}
+}
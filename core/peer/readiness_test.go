@@ -0,0 +1,113 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadiness(t *testing.T) {
+	r := &readiness{channels: map[string]*channelStatus{}}
+	r.pending("ch2")
+	r.pending("ch1")
+	r.pending("ch3")
+	r.ready("ch1")
+	r.failed("ch3", errors.New("no config block"))
+	r.ready("joined")
+
+	list := r.list()
+	require.Len(t, list, 4)
+	assert.Equal(t, "ch1", list[0].Channel)
+	assert.Equal(t, ChannelReady, list[0].State)
+	assert.Empty(t, list[0].Error)
+	assert.Equal(t, "ch2", list[1].Channel)
+	assert.Equal(t, ChannelPending, list[1].State)
+	assert.Equal(t, "ch3", list[2].Channel)
+	assert.Equal(t, ChannelFailed, list[2].State)
+	assert.Equal(t, "no config block", list[2].Error)
+	assert.Equal(t, "joined", list[3].Channel)
+	assert.Equal(t, ChannelReady, list[3].State)
+	for _, cr := range list {
+		assert.NotEmpty(t, cr.Duration)
+	}
+}
+
+func TestReadinessHandler(t *testing.T) {
+	channels := []ChannelReadiness{
+		{Channel: "ch1", State: ChannelReady, Duration: "1s"},
+		{Channel: "ch2", State: ChannelPending, Duration: "2s"},
+		{Channel: "ch3", State: ChannelFailed, Error: "no config block", Duration: "3s"},
+	}
+	handler := &ReadinessHandler{
+		Readiness: func() []ChannelReadiness { return channels },
+		Logger:    flogging.MustGetLogger("test"),
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		target       string
+		expectedCode int
+		expected     *Readiness
+	}{
+		{
+			name:         "pending channel",
+			method:       http.MethodGet,
+			target:       "/channels/readiness",
+			expectedCode: http.StatusServiceUnavailable,
+			expected:     &Readiness{Ready: false, Channels: channels},
+		},
+		{
+			name:         "ready channel",
+			method:       http.MethodGet,
+			target:       "/channels/readiness?channel=ch1",
+			expectedCode: http.StatusOK,
+			expected:     &Readiness{Ready: true, Channels: channels[:1]},
+		},
+		{
+			name:         "failed channel",
+			method:       http.MethodGet,
+			target:       "/channels/readiness?channel=ch3",
+			expectedCode: http.StatusOK,
+			expected:     &Readiness{Ready: true, Channels: channels[2:]},
+		},
+		{
+			name:         "unknown channel",
+			method:       http.MethodGet,
+			target:       "/channels/readiness?channel=ch4",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "invalid method",
+			method:       http.MethodPost,
+			target:       "/channels/readiness",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, httptest.NewRequest(tt.method, tt.target, nil))
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			assert.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+			if tt.expected == nil {
+				return
+			}
+			readiness := &Readiness{}
+			require.NoError(t, json.Unmarshal(resp.Body.Bytes(), readiness))
+			assert.Equal(t, tt.expected, readiness)
+		})
+	}
+}
@@ -0,0 +1,12 @@
+// +build tokendriver_frozen
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+// The frozen token driver is compiled in with the tokendriver_frozen build tag.
+import _ "github.com/hyperledger/fabric/token/tms/driver/frozen"
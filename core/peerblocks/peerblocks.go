@@ -0,0 +1,117 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peerblocks
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/ledger"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+//go:generate counterfeiter -o mock/deliver_stream.go -fake-name DeliverStream . DeliverStream
+
+// DeliverStream is the subset of a peer deliver stream used to read blocks.
+type DeliverStream interface {
+	Send(*cb.Envelope) error
+	Recv() (*pb.DeliverResponse, error)
+	CloseSend() error
+}
+
+// Provider reads the committed blocks of a channel from the deliver service
+// of a peer, for the processes following the ledger of a peer from outside of
+// it, such as a standby peer or the token tools.
+type Provider struct {
+	Channel     string
+	Signer      crypto.LocalSigner
+	TLSCertHash []byte
+	// Connect opens a new deliver stream to the peer.
+	Connect func(ctx context.Context) (DeliverStream, error)
+}
+
+// GetBlocksIterator requests the blocks of the channel starting from
+// startBlockNumber and returns an iterator over them. The iterator blocks
+// until new blocks are committed.
+func (p *Provider) GetBlocksIterator(startBlockNumber uint64) (ledger.ResultsIterator, error) {
+	seekInfo := &ab.SeekInfo{
+		Start: &ab.SeekPosition{
+			Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: startBlockNumber}},
+		},
+		Stop: &ab.SeekPosition{
+			Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: math.MaxUint64}},
+		},
+		Behavior: ab.SeekInfo_BLOCK_UNTIL_READY,
+	}
+	env, err := utils.CreateSignedEnvelopeWithTLSBinding(cb.HeaderType_DELIVER_SEEK_INFO, p.Channel, p.Signer, seekInfo, 0, 0, p.TLSCertHash)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed creating seek envelope")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := p.Connect(ctx)
+	if err != nil {
+		cancel()
+		return nil, errors.WithMessage(err, "failed connecting to deliver service")
+	}
+	err = stream.Send(env)
+	if err != nil {
+		cancel()
+		return nil, errors.Wrap(err, "failed sending seek envelope")
+	}
+	stream.CloseSend()
+
+	return &blocksIterator{stream: stream, cancel: cancel}, nil
+}
+
+type blocksIterator struct {
+	stream DeliverStream
+	cancel context.CancelFunc
+
+	mutex  sync.Mutex
+	closed bool
+}
+
+// Next returns the next block, or nil once the iterator has been closed.
+func (b *blocksIterator) Next() (ledger.QueryResult, error) {
+	resp, err := b.stream.Recv()
+	if b.isClosed() {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed receiving block")
+	}
+
+	switch t := resp.Type.(type) {
+	case *pb.DeliverResponse_Block:
+		return t.Block, nil
+	case *pb.DeliverResponse_Status:
+		return nil, errors.Errorf("deliver service returned status %s", t.Status)
+	default:
+		return nil, errors.Errorf("unexpected deliver response type %T", t)
+	}
+}
+
+// Close cancels the deliver stream.
+func (b *blocksIterator) Close() {
+	b.mutex.Lock()
+	b.closed = true
+	b.mutex.Unlock()
+	b.cancel()
+}
+
+func (b *blocksIterator) isClosed() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.closed
+}
@@ -0,0 +1,121 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peerblocks_test
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	mockcrypto "github.com/hyperledger/fabric/common/mocks/crypto"
+	"github.com/hyperledger/fabric/core/peerblocks"
+	"github.com/hyperledger/fabric/core/peerblocks/mock"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Provider", func() {
+	var (
+		fakeStream *mock.DeliverStream
+		connectCtx context.Context
+		blocks     *peerblocks.Provider
+	)
+
+	BeforeEach(func() {
+		fakeStream = &mock.DeliverStream{}
+		blocks = &peerblocks.Provider{
+			Channel:     "testchannel",
+			Signer:      &mockcrypto.LocalSigner{Identity: []byte("creator")},
+			TLSCertHash: []byte("tls-cert-hash"),
+			Connect: func(ctx context.Context) (peerblocks.DeliverStream, error) {
+				connectCtx = ctx
+				return fakeStream, nil
+			},
+		}
+	})
+
+	It("seeks from the requested block", func() {
+		_, err := blocks.GetBlocksIterator(7)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeStream.SendCallCount()).To(Equal(1))
+		Expect(fakeStream.CloseSendCallCount()).To(Equal(1))
+
+		env := fakeStream.SendArgsForCall(0)
+		payload := &cb.Payload{}
+		Expect(proto.Unmarshal(env.Payload, payload)).To(Succeed())
+		chdr := &cb.ChannelHeader{}
+		Expect(proto.Unmarshal(payload.Header.ChannelHeader, chdr)).To(Succeed())
+		Expect(chdr.ChannelId).To(Equal("testchannel"))
+		Expect(chdr.Type).To(Equal(int32(cb.HeaderType_DELIVER_SEEK_INFO)))
+		Expect(chdr.TlsCertHash).To(Equal([]byte("tls-cert-hash")))
+
+		seekInfo := &ab.SeekInfo{}
+		Expect(proto.Unmarshal(payload.Data, seekInfo)).To(Succeed())
+		Expect(seekInfo.Start.GetSpecified().Number).To(Equal(uint64(7)))
+		Expect(seekInfo.Behavior).To(Equal(ab.SeekInfo_BLOCK_UNTIL_READY))
+	})
+
+	It("returns the delivered blocks", func() {
+		block := &cb.Block{Header: &cb.BlockHeader{Number: 7}}
+		fakeStream.RecvReturns(&pb.DeliverResponse{Type: &pb.DeliverResponse_Block{Block: block}}, nil)
+
+		iterator, err := blocks.GetBlocksIterator(7)
+		Expect(err).NotTo(HaveOccurred())
+		result, err := iterator.Next()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(block))
+	})
+
+	It("returns an error when the peer returns a status", func() {
+		fakeStream.RecvReturns(&pb.DeliverResponse{Type: &pb.DeliverResponse_Status{Status: cb.Status_FORBIDDEN}}, nil)
+
+		iterator, err := blocks.GetBlocksIterator(7)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = iterator.Next()
+		Expect(err).To(MatchError("deliver service returned status FORBIDDEN"))
+	})
+
+	It("cancels the stream and stops when closed", func() {
+		fakeStream.RecvReturns(nil, errors.New("context canceled"))
+
+		iterator, err := blocks.GetBlocksIterator(7)
+		Expect(err).NotTo(HaveOccurred())
+		iterator.Close()
+		Expect(connectCtx.Err()).To(Equal(context.Canceled))
+
+		result, err := iterator.Next()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(BeNil())
+	})
+
+	Context("when connecting fails", func() {
+		BeforeEach(func() {
+			blocks.Connect = func(context.Context) (peerblocks.DeliverStream, error) {
+				return nil, errors.New("unreachable")
+			}
+		})
+
+		It("returns an error", func() {
+			_, err := blocks.GetBlocksIterator(7)
+			Expect(err).To(MatchError("failed connecting to deliver service: unreachable"))
+		})
+	})
+
+	Context("when sending the seek envelope fails", func() {
+		BeforeEach(func() {
+			fakeStream.SendReturns(errors.New("broken"))
+		})
+
+		It("returns an error", func() {
+			_, err := blocks.GetBlocksIterator(7)
+			Expect(err).To(MatchError("failed sending seek envelope: broken"))
+		})
+	})
+})
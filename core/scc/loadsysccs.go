@@ -10,24 +10,40 @@ import (
 	"fmt"
 	"os"
 	"plugin"
+	"runtime/debug"
+	"strings"
 	"sync"
 
+	"github.com/hyperledger/fabric/common/metadata"
 	"github.com/hyperledger/fabric/common/viperutil"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/pkg/errors"
 )
 
 const (
 	sccFactoryMethod = "New"
+
+	// sccVersionMethod is an optional plugin symbol of type func() string
+	// which reports the version of the plugin
+	sccVersionMethod = "Version"
+
+	// sccPeerVersionsMethod is an optional plugin symbol of type
+	// func() []string which reports the peer versions the plugin was
+	// built for. An entry of "1.4" matches peer versions 1.4, 1.4.x and
+	// 1.4-<suffix>
+	sccPeerVersionsMethod = "PeerVersions"
 )
 
 // PluginConfig SCC plugin configuration
 type PluginConfig struct {
-	Enabled           bool   `mapstructure:"enabled" yaml:"enabled"`
-	Name              string `mapstructure:"name" yaml:"name"`
-	Path              string `mapstructure:"path" yaml:"path"`
-	InvokableExternal bool   `mapstructure:"invokableExternal" yaml:"invokableExternal"`
-	InvokableCC2CC    bool   `mapstructure:"invokableCC2CC" yaml:"invokableCC2CC"`
+	Enabled           bool     `mapstructure:"enabled" yaml:"enabled"`
+	Name              string   `mapstructure:"name" yaml:"name"`
+	Path              string   `mapstructure:"path" yaml:"path"`
+	InvokableExternal bool     `mapstructure:"invokableExternal" yaml:"invokableExternal"`
+	InvokableCC2CC    bool     `mapstructure:"invokableCC2CC" yaml:"invokableCC2CC"`
+	Version           string   `mapstructure:"version" yaml:"version"`
+	Channels          []string `mapstructure:"channels" yaml:"channels"`
 }
 
 var once sync.Once
@@ -48,21 +64,23 @@ func loadSysCCs(p *Provider) []*SystemChaincode {
 
 func loadSysCCsWithConfig(configs []*PluginConfig) {
 	for _, conf := range configs {
-		plugin := loadPlugin(conf.Path)
+		plugin := loadPlugin(conf)
 		chaincode := &SystemChaincode{
 			Enabled:           conf.Enabled,
 			Name:              conf.Name,
 			Path:              conf.Path,
-			Chaincode:         *plugin,
+			Chaincode:         newPluginChaincode(conf.Name, *plugin, conf.Channels),
 			InvokableExternal: conf.InvokableExternal,
 			InvokableCC2CC:    conf.InvokableCC2CC,
+			Channels:          conf.Channels,
 		}
 		sccPlugins = append(sccPlugins, chaincode)
 		sysccLogger.Infof("Successfully loaded SCC %s from path %s", chaincode.Name, chaincode.Path)
 	}
 }
 
-func loadPlugin(path string) *shim.Chaincode {
+func loadPlugin(conf *PluginConfig) *shim.Chaincode {
+	path := conf.Path
 	if _, err := os.Stat(path); err != nil {
 		panic(fmt.Errorf("Could not find plugin at path %s: %s", path, err))
 	}
@@ -72,6 +90,11 @@ func loadPlugin(path string) *shim.Chaincode {
 		panic(fmt.Errorf("Error opening plugin at path %s: %s", path, err))
 	}
 
+	err = checkPluginVersion(conf, p.Lookup, metadata.Version)
+	if err != nil {
+		panic(fmt.Errorf("Plugin at path %s cannot be loaded: %s", path, err))
+	}
+
 	sccFactorySymbol, err := p.Lookup(sccFactoryMethod)
 	if err != nil {
 		panic(fmt.Errorf(
@@ -88,3 +111,83 @@ func loadPlugin(path string) *shim.Chaincode {
 
 	return &scc
 }
+
+// checkPluginVersion verifies that the plugin reports the version pinned in
+// its configuration, if any, and that it declares compatibility with the
+// running peer version, if it declares any compatibility at all.
+func checkPluginVersion(conf *PluginConfig, lookup func(string) (plugin.Symbol, error), peerVersion string) error {
+	if conf.Version != "" {
+		versionSymbol, err := lookup(sccVersionMethod)
+		if err != nil {
+			return errors.Errorf("version %s is required but the plugin does not export %s", conf.Version, sccVersionMethod)
+		}
+		version, ok := versionSymbol.(func() string)
+		if !ok {
+			return errors.Errorf("function %s does not match expected definition func() string", sccVersionMethod)
+		}
+		if v := version(); v != conf.Version {
+			return errors.Errorf("plugin version %s does not match required version %s", v, conf.Version)
+		}
+	}
+
+	peerVersionsSymbol, err := lookup(sccPeerVersionsMethod)
+	if err != nil {
+		// plugins which do not declare compatibility are assumed compatible
+		return nil
+	}
+	peerVersions, ok := peerVersionsSymbol.(func() []string)
+	if !ok {
+		return errors.Errorf("function %s does not match expected definition func() []string", sccPeerVersionsMethod)
+	}
+	supported := peerVersions()
+	for _, v := range supported {
+		if peerVersion == v || strings.HasPrefix(peerVersion, v+".") || strings.HasPrefix(peerVersion, v+"-") {
+			return nil
+		}
+	}
+	return errors.Errorf("plugin supports peer versions %v but the peer is at version %s", supported, peerVersion)
+}
+
+// pluginChaincode wraps a chaincode loaded from a plugin so that a panic in
+// the plugin fails the request instead of the peer, and so that the plugin
+// can only be invoked on the channels it is enabled for.
+type pluginChaincode struct {
+	name     string
+	cc       shim.Chaincode
+	channels map[string]struct{}
+}
+
+func newPluginChaincode(name string, cc shim.Chaincode, channels []string) *pluginChaincode {
+	pcc := &pluginChaincode{name: name, cc: cc}
+	if len(channels) > 0 {
+		pcc.channels = map[string]struct{}{}
+		for _, channel := range channels {
+			pcc.channels[channel] = struct{}{}
+		}
+	}
+	return pcc
+}
+
+// Init implements the chaincode shim interface
+func (p *pluginChaincode) Init(stub shim.ChaincodeStubInterface) (resp pb.Response) {
+	defer p.recoverPanic("Init", &resp)
+	return p.cc.Init(stub)
+}
+
+// Invoke implements the chaincode shim interface
+func (p *pluginChaincode) Invoke(stub shim.ChaincodeStubInterface) (resp pb.Response) {
+	if p.channels != nil {
+		if _, ok := p.channels[stub.GetChannelID()]; !ok {
+			return shim.Error(fmt.Sprintf("system chaincode %s is not enabled on channel '%s'", p.name, stub.GetChannelID()))
+		}
+	}
+	defer p.recoverPanic("Invoke", &resp)
+	return p.cc.Invoke(stub)
+}
+
+func (p *pluginChaincode) recoverPanic(method string, resp *pb.Response) {
+	if r := recover(); r != nil {
+		sysccLogger.Errorf("system chaincode plugin %s panicked during %s: %v\n%s", p.name, method, r, debug.Stack())
+		*resp = shim.Error(fmt.Sprintf("system chaincode %s failed: %v", p.name, r))
+	}
+}
@@ -45,6 +45,7 @@ func TestLoadSCCPlugin(t *testing.T) {
         path: %s
         invokableExternal: true
         invokableCC2CC: true
+        version: 1.0.0
   `, pluginName, pluginPath)
 	viper.SetConfigType("yaml")
 	viper.ReadConfig(bytes.NewBuffer([]byte(testConfig)))
@@ -56,7 +57,7 @@ func TestLoadSCCPlugin(t *testing.T) {
 }
 
 func TestLoadSCCPluginInvalid(t *testing.T) {
-	assert.Panics(t, func() { loadPlugin("missing.so") }, "expected panic with invalid path")
+	assert.Panics(t, func() { loadPlugin(&PluginConfig{Path: "missing.so"}) }, "expected panic with invalid path")
 }
 
 // raceEnabled is set to true when the race build tag is enabled.
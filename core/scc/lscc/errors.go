@@ -140,3 +140,10 @@ type PrivateChannelDataNotAvailable string
 func (f PrivateChannelDataNotAvailable) Error() string {
 	return "as V1_2 or later capability is not enabled, private channel collections and data are not available"
 }
+
+// WASMChaincodeNotAvailable when the V1_4_WASM_EXPERIMENTAL capability is not enabled
+type WASMChaincodeNotAvailable string
+
+func (f WASMChaincodeNotAvailable) Error() string {
+	return fmt.Sprintf("as V1_4_WASM_EXPERIMENTAL capability is not enabled, wasm chaincode %s cannot be deployed", string(f))
+}
@@ -863,6 +863,11 @@ func (lscc *LifeCycleSysCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response
 			return shim.Error(fmt.Sprintf("error unmarshaling ChaincodeDeploymentSpec: %s", err))
 		}
 
+		// wasm chaincode is experimental and has to be enabled on the channel
+		if cds.ChaincodeSpec != nil && cds.ChaincodeSpec.Type == pb.ChaincodeSpec_WASM && !ac.Capabilities().WASMChaincode() {
+			return shim.Error(WASMChaincodeNotAvailable(cds.ChaincodeSpec.GetChaincodeId().GetName()).Error())
+		}
+
 		// optional arguments here (they can each be nil and may or may not be present)
 		// args[3] is a marshalled SignaturePolicyEnvelope representing the endorsement policy
 		// args[4] is the name of escc
@@ -325,6 +325,40 @@ func createCollectionConfig(collectionName string, signaturePolicyEnvelope *comm
 	}
 }
 
+func TestDeployWASMChaincode(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		mocksccProvider := (&mscc.MocksccProviderFactory{
+			ApplicationConfigBool: true,
+			ApplicationConfigRv: &config.MockApplication{
+				CapabilitiesRv: &config.MockApplicationCapabilities{
+					WASMChaincodeRv: enabled,
+				},
+			},
+		}).NewSystemChaincodeProvider().(*mscc.MocksccProviderImpl)
+
+		scc := New(mocksccProvider, mockAclProvider, platforms.NewRegistry(&golang.Platform{}))
+		scc.Support = &lscc.MockSupport{GetChaincodeFromLocalStorageErr: errors.New("no package")}
+		stub := shim.NewMockStub("lscc", scc)
+		res := stub.MockInit("1", nil)
+		assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+
+		cds := &pb.ChaincodeDeploymentSpec{
+			ChaincodeSpec: &pb.ChaincodeSpec{
+				Type:        pb.ChaincodeSpec_WASM,
+				ChaincodeId: &pb.ChaincodeID{Name: "wasmcc", Version: "1.0"},
+			},
+		}
+		res = stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte("deploy"), []byte("test"), utils.MarshalOrPanic(cds)}, nil)
+		assert.NotEqual(t, int32(shim.OK), res.Status)
+		if enabled {
+			// the deployment proceeds until the package is looked up
+			assert.Equal(t, "cannot get package for chaincode (wasmcc:1.0)", res.Message)
+		} else {
+			assert.Equal(t, WASMChaincodeNotAvailable("wasmcc").Error(), res.Message)
+		}
+	}
+}
+
 func testDeploy(t *testing.T, ccname string, version string, path string, forceBlankCCName bool, forceBlankVersion bool, install bool, expectedErrorMsg string, scc *LifeCycleSysCC, stub *shim.MockStub, collectionConfigBytes []byte) {
 	if scc == nil {
 		scc = New(NewMockProvider(), mockAclProvider, platforms.NewRegistry(&golang.Platform{}))
@@ -0,0 +1,161 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package scc
+
+import (
+	"errors"
+	"plugin"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+type panickingChaincode struct{}
+
+func (panickingChaincode) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	panic("init boom")
+}
+
+func (panickingChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	panic("invoke boom")
+}
+
+type okChaincode struct{}
+
+func (okChaincode) Init(stub shim.ChaincodeStubInterface) pb.Response { return shim.Success(nil) }
+func (okChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success([]byte("ok"))
+}
+
+func TestPluginChaincodeRecoversPanics(t *testing.T) {
+	stub := shim.NewMockStub("panicky", newPluginChaincode("panicky", panickingChaincode{}, nil))
+
+	resp := stub.MockInit("tx1", nil)
+	assert.Equal(t, int32(shim.ERROR), resp.Status)
+	assert.Equal(t, "system chaincode panicky failed: init boom", resp.Message)
+
+	resp = stub.MockInvoke("tx2", nil)
+	assert.Equal(t, int32(shim.ERROR), resp.Status)
+	assert.Equal(t, "system chaincode panicky failed: invoke boom", resp.Message)
+}
+
+func TestPluginChaincodeChannels(t *testing.T) {
+	stub := shim.NewMockStub("scoped", newPluginChaincode("scoped", okChaincode{}, []string{"enabled"}))
+
+	stub.ChannelID = "enabled"
+	resp := stub.MockInvoke("tx1", nil)
+	assert.Equal(t, int32(shim.OK), resp.Status)
+	assert.Equal(t, []byte("ok"), resp.Payload)
+
+	stub.ChannelID = "other"
+	resp = stub.MockInvoke("tx2", nil)
+	assert.Equal(t, int32(shim.ERROR), resp.Status)
+	assert.Equal(t, "system chaincode scoped is not enabled on channel 'other'", resp.Message)
+
+	stub = shim.NewMockStub("unscoped", newPluginChaincode("unscoped", okChaincode{}, nil))
+	stub.ChannelID = "other"
+	resp = stub.MockInvoke("tx3", nil)
+	assert.Equal(t, int32(shim.OK), resp.Status)
+}
+
+func TestCheckPluginVersion(t *testing.T) {
+	symbols := func(syms map[string]plugin.Symbol) func(string) (plugin.Symbol, error) {
+		return func(name string) (plugin.Symbol, error) {
+			if sym, ok := syms[name]; ok {
+				return sym, nil
+			}
+			return nil, errors.New("symbol not found")
+		}
+	}
+	version := func(v string) func() string { return func() string { return v } }
+	peerVersions := func(v ...string) func() []string { return func() []string { return v } }
+
+	tests := []struct {
+		name        string
+		conf        *PluginConfig
+		symbols     map[string]plugin.Symbol
+		peerVersion string
+		expectedErr string
+	}{
+		{
+			name:        "no version information",
+			conf:        &PluginConfig{},
+			peerVersion: "1.4.0",
+		},
+		{
+			name:        "pinned version matches",
+			conf:        &PluginConfig{Version: "2.1.0"},
+			symbols:     map[string]plugin.Symbol{"Version": version("2.1.0")},
+			peerVersion: "1.4.0",
+		},
+		{
+			name:        "pinned version mismatch",
+			conf:        &PluginConfig{Version: "2.1.0"},
+			symbols:     map[string]plugin.Symbol{"Version": version("2.0.0")},
+			peerVersion: "1.4.0",
+			expectedErr: "plugin version 2.0.0 does not match required version 2.1.0",
+		},
+		{
+			name:        "pinned version not exported",
+			conf:        &PluginConfig{Version: "2.1.0"},
+			peerVersion: "1.4.0",
+			expectedErr: "version 2.1.0 is required but the plugin does not export Version",
+		},
+		{
+			name:        "version has wrong type",
+			conf:        &PluginConfig{Version: "2.1.0"},
+			symbols:     map[string]plugin.Symbol{"Version": "2.1.0"},
+			peerVersion: "1.4.0",
+			expectedErr: "function Version does not match expected definition func() string",
+		},
+		{
+			name:        "peer version matches minor release",
+			conf:        &PluginConfig{},
+			symbols:     map[string]plugin.Symbol{"PeerVersions": peerVersions("1.3", "1.4")},
+			peerVersion: "1.4.2",
+		},
+		{
+			name:        "peer version matches exactly",
+			conf:        &PluginConfig{},
+			symbols:     map[string]plugin.Symbol{"PeerVersions": peerVersions("1.4.2")},
+			peerVersion: "1.4.2",
+		},
+		{
+			name:        "peer version matches snapshot",
+			conf:        &PluginConfig{},
+			symbols:     map[string]plugin.Symbol{"PeerVersions": peerVersions("1.4")},
+			peerVersion: "1.4-snapshot-abcdef",
+		},
+		{
+			name:        "peer version not supported",
+			conf:        &PluginConfig{},
+			symbols:     map[string]plugin.Symbol{"PeerVersions": peerVersions("1.4")},
+			peerVersion: "1.40.0",
+			expectedErr: "plugin supports peer versions [1.4] but the peer is at version 1.40.0",
+		},
+		{
+			name:        "peer versions has wrong type",
+			conf:        &PluginConfig{},
+			symbols:     map[string]plugin.Symbol{"PeerVersions": []string{"1.4"}},
+			peerVersion: "1.4.0",
+			expectedErr: "function PeerVersions does not match expected definition func() []string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPluginVersion(tt.conf, symbols(tt.symbols), tt.peerVersion)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
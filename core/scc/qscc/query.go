@@ -13,6 +13,7 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/committer/invalidation"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/peer"
 	pb "github.com/hyperledger/fabric/protos/peer"
@@ -40,6 +41,7 @@ func (e *LedgerQuerier) Enabled() bool             { return true }
 // - GetBlockByNumber returns a block
 // - GetBlockByHash returns a block
 // - GetTransactionByID returns a transaction
+// - GetInvalidationReason returns the reason a transaction was invalidated
 type LedgerQuerier struct {
 	aclProvider aclmgmt.ACLProvider
 }
@@ -48,11 +50,12 @@ var qscclogger = flogging.MustGetLogger("qscc")
 
 // These are function names from Invoke first parameter
 const (
-	GetChainInfo       string = "GetChainInfo"
-	GetBlockByNumber   string = "GetBlockByNumber"
-	GetBlockByHash     string = "GetBlockByHash"
-	GetTransactionByID string = "GetTransactionByID"
-	GetBlockByTxID     string = "GetBlockByTxID"
+	GetChainInfo          string = "GetChainInfo"
+	GetBlockByNumber      string = "GetBlockByNumber"
+	GetBlockByHash        string = "GetBlockByHash"
+	GetTransactionByID    string = "GetTransactionByID"
+	GetBlockByTxID        string = "GetBlockByTxID"
+	GetInvalidationReason string = "GetInvalidationReason"
 )
 
 // Init is called once per chain when the chain is created.
@@ -71,6 +74,8 @@ func (e *LedgerQuerier) Init(stub shim.ChaincodeStubInterface) pb.Response {
 // # GetBlockByNumber: Return the block specified by block number in args[2]
 // # GetBlockByHash: Return the block specified by block hash in args[2]
 // # GetTransactionByID: Return the transaction specified by ID in args[2]
+// # GetInvalidationReason: Return the InvalidationReason of the transaction
+// specified by ID in args[2]
 func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
 
@@ -115,6 +120,8 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return getChainInfo(targetLedger)
 	case GetBlockByTxID:
 		return getBlockByTxID(targetLedger, args[2])
+	case GetInvalidationReason:
+		return getInvalidationReason(cid, targetLedger, args[2])
 	}
 
 	return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
@@ -214,6 +221,31 @@ func getBlockByTxID(vledger ledger.PeerLedger, rawTxID []byte) pb.Response {
 	return shim.Success(bytes)
 }
 
+func getInvalidationReason(cid string, vledger ledger.PeerLedger, rawTxID []byte) pb.Response {
+	if rawTxID == nil {
+		return shim.Error("Transaction ID must not be nil.")
+	}
+	txID := string(rawTxID)
+
+	// the reasons are not retained when disabled, only the validation code
+	// and the position of the transaction are returned then
+	var store *invalidation.Store
+	if provider := peer.GetInvalidationReasons(); provider != nil {
+		store = provider.OpenStore(cid)
+	}
+	reason, err := invalidation.Reason(vledger, store, txID)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get invalidation reason for txID %s, error %s", txID, err))
+	}
+
+	bytes, err := utils.Marshal(reason)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
+
 func getACLResource(fname string) string {
 	return "qscc/" + fname
 }
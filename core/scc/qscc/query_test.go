@@ -21,6 +21,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/aclmgmt/mocks"
@@ -179,6 +180,28 @@ func TestQueryGetBlockByTxID(t *testing.T) {
 	assert.Equal(t, int32(shim.ERROR), res.Status, "GetBlockByTxID should have failed with blank txId.")
 }
 
+func TestQueryGetInvalidationReason(t *testing.T) {
+	chainid := "mytestchainid9"
+	path := tempDir(t, "test9")
+	defer os.RemoveAll(path)
+
+	stub, err := setupTestLedger(chainid, path)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	args := [][]byte{[]byte(GetInvalidationReason), []byte(chainid), []byte(nil)}
+	prop := resetProvider(resources.Qscc_GetInvalidationReason, chainid, &peer2.SignedProposal{}, nil)
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetInvalidationReason should have failed with nil txId.")
+
+	args = [][]byte{[]byte(GetInvalidationReason), []byte(chainid), []byte("unknown")}
+	prop = resetProvider(resources.Qscc_GetInvalidationReason, chainid, &peer2.SignedProposal{}, nil)
+	res = stub.MockInvokeWithSignedProposal("2", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetInvalidationReason should have failed with unknown txId.")
+	assert.Contains(t, res.Message, "Failed to get invalidation reason for txID unknown")
+}
+
 func TestFailingAccessControl(t *testing.T) {
 	chainid := "mytestchainid6"
 	path := tempDir(t, "test6")
@@ -252,6 +275,18 @@ func TestFailingAccessControl(t *testing.T) {
 	assert.Contains(t, res.Message, "Failed access control")
 	// assert that the expectations were met
 	mockAclProvider.AssertExpectations(t)
+
+	// GetInvalidationReason
+	args = [][]byte{[]byte(GetInvalidationReason), []byte(chainid), []byte("1")}
+	sProp, _ = utils.MockSignedEndorserProposalOrPanic(chainid, &peer2.ChaincodeSpec{}, []byte("Alice"), []byte("msg1"))
+	sProp.Signature = sProp.ProposalBytes
+	// Set the ACLProvider to have a failure
+	resetProvider(resources.Qscc_GetInvalidationReason, chainid, sProp, errors.New("Failed access control"))
+	res = stub.MockInvokeWithSignedProposal("2", args, sProp)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetInvalidationReason must fail: %s", res.Message)
+	assert.Contains(t, res.Message, "Failed access control")
+	// assert that the expectations were met
+	mockAclProvider.AssertExpectations(t)
 }
 
 func TestQueryNonexistentFunction(t *testing.T) {
@@ -322,6 +357,16 @@ func TestQueryGeneratedBlock(t *testing.T) {
 					prop = resetProvider(resources.Qscc_GetTransactionByID, chainid, &peer2.SignedProposal{}, nil)
 					res = stub.MockInvokeWithSignedProposal("4", args, prop)
 					assert.Equal(t, int32(shim.OK), res.Status, "GetTransactionById should have succeeded for txid: %s", chdr.TxId)
+
+					args = [][]byte{[]byte(GetInvalidationReason), []byte(chainid), []byte(chdr.TxId)}
+					prop = resetProvider(resources.Qscc_GetInvalidationReason, chainid, &peer2.SignedProposal{}, nil)
+					res = stub.MockInvokeWithSignedProposal("5", args, prop)
+					assert.Equal(t, int32(shim.OK), res.Status, "GetInvalidationReason should have succeeded for txid: %s", chdr.TxId)
+					reason := &peer2.InvalidationReason{}
+					require.NoError(t, proto.Unmarshal(res.Payload, reason))
+					assert.Equal(t, chdr.TxId, reason.TxId)
+					assert.Equal(t, uint64(1), reason.BlockNumber)
+					assert.Equal(t, peer2.TxValidationCode_VALID, reason.ValidationCode)
 				}
 			}
 		}
@@ -73,6 +73,24 @@ func TestDeploy(t *testing.T) {
 	}})
 }
 
+func TestDeployChannelScoped(t *testing.T) {
+	ccp := &ccprovider2.MockCcProviderImpl{}
+	scoped := &SysCCWrapper{SCC: &SystemChaincode{
+		Enabled:  true,
+		Name:     "invokableCC2CCButNotExternal",
+		Channels: []string{"a"},
+	}}
+	assert.True(t, scoped.EnabledForChannel(""))
+	assert.True(t, scoped.EnabledForChannel("a"))
+	assert.False(t, scoped.EnabledForChannel("b"))
+
+	// the channel has no ledger, so deploying would panic if it were not skipped
+	assert.NotPanics(t, func() {
+		err := deploySysCC("b", ccp, scoped)
+		assert.NoError(t, err)
+	})
+}
+
 func TestDeDeploySysCC(t *testing.T) {
 	p := newTestProvider()
 	ccp := &ccprovider2.MockCcProviderImpl{}
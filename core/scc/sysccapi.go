@@ -59,6 +59,10 @@ type SystemChaincode struct {
 	// Enabled a convenient switch to enable/disable system chaincode without
 	// having to remove entry from importsysccs.go
 	Enabled bool
+
+	// Channels restricts the channels the system chaincode
+	// is deployed on; when empty it is deployed on all channels
+	Channels []string
 }
 
 type SysCCWrapper struct {
@@ -73,6 +77,20 @@ func (sccw *SysCCWrapper) InvokableExternal() bool   { return sccw.SCC.Invokable
 func (sccw *SysCCWrapper) InvokableCC2CC() bool      { return sccw.SCC.InvokableCC2CC }
 func (sccw *SysCCWrapper) Enabled() bool             { return sccw.SCC.Enabled }
 
+// EnabledForChannel returns whether the system chaincode should be deployed
+// on the given channel
+func (sccw *SysCCWrapper) EnabledForChannel(chainID string) bool {
+	if chainID == "" || len(sccw.SCC.Channels) == 0 {
+		return true
+	}
+	for _, channel := range sccw.SCC.Channels {
+		if channel == chainID {
+			return true
+		}
+	}
+	return false
+}
+
 type SelfDescribingSysCC interface {
 	//Unique name of the system chaincode
 	Name() string
@@ -102,6 +120,14 @@ type SelfDescribingSysCC interface {
 	Enabled() bool
 }
 
+// ChannelScopedSysCC is implemented by system chaincodes which are only
+// deployed on a subset of channels
+type ChannelScopedSysCC interface {
+	// EnabledForChannel returns whether the system chaincode
+	// should be deployed on the given channel
+	EnabledForChannel(chainID string) bool
+}
+
 // registerSysCC registers the given system chaincode with the peer
 func (p *Provider) registerSysCC(syscc SelfDescribingSysCC) (bool, error) {
 	if !syscc.Enabled() || !isWhitelisted(syscc) {
@@ -137,6 +163,11 @@ func deploySysCC(chainID string, ccprov ccprovider.ChaincodeProvider, syscc Self
 		return nil
 	}
 
+	if scoped, ok := syscc.(ChannelScopedSysCC); ok && !scoped.EnabledForChannel(chainID) {
+		sysccLogger.Infof("system chaincode %s not enabled on channel %s", syscc.Name(), chainID)
+		return nil
+	}
+
 	txid := util.GenerateUUID()
 
 	// Note, this structure is barely initialized,
@@ -0,0 +1,305 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package standby
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/flogging"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/gossip/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("standby")
+
+//go:generate counterfeiter -o mock/ledger.go -fake-name Ledger . Ledger
+
+// Ledger is the local ledger of a channel the blocks are replicated to.
+type Ledger interface {
+	GetBlockchainInfo() (*cb.BlockchainInfo, error)
+	GetBlockByNumber(blockNumber uint64) (*cb.Block, error)
+	CommitWithPvtData(blockAndPvtdata *ledger.BlockAndPvtData) error
+	Close()
+}
+
+//go:generate counterfeiter -o mock/block_iterator_provider.go -fake-name BlockIteratorProvider . BlockIteratorProvider
+
+// BlockIteratorProvider provides the blocks of a channel committed by the primary peer.
+type BlockIteratorProvider interface {
+	GetBlocksIterator(startBlockNumber uint64) (commonledger.ResultsIterator, error)
+}
+
+//go:generate counterfeiter -o mock/block_verifier.go -fake-name BlockVerifier . BlockVerifier
+
+// BlockVerifier verifies the blocks of a channel replicated from the primary
+// peer, like the message crypto service of gossip verifies the blocks
+// received from other peers.
+type BlockVerifier interface {
+	VerifyBlock(chainID common.ChainID, seqNum uint64, signedBlock []byte) error
+}
+
+// A Replicator replicates the blocks of a channel committed by the primary
+// peer to the local ledger of the channel. The blocks carry the validation
+// flags set by the primary, so they are committed without being validated
+// again; private data is not replicated. The blocks are nonetheless verified
+// against the orderer signatures, so that the standby only commits blocks
+// ordered for the channel, whatever the primary delivers.
+type Replicator struct {
+	ChannelID string
+	Blocks    BlockIteratorProvider
+	// Verifier verifies the blocks before they are committed, with the
+	// policy manager and the hashing suite of the channel returned by Manager
+	// and HashingSuite. The genesis block, which the ledger is created from,
+	// is trusted like the one a peer joins a channel with.
+	Verifier BlockVerifier
+	// Ledger is the local ledger of the channel, nil if it does not exist yet.
+	Ledger Ledger
+	// CreateLedger creates the local ledger of the channel from its genesis
+	// block, when the standby has not joined the channel yet.
+	CreateLedger func(genesisBlock *cb.Block) (Ledger, error)
+
+	mutex sync.Mutex
+	// closed is set once the ledger is closed, with the height it was closed at
+	closed       bool
+	closedHeight uint64
+
+	// bundle is the config of the channel the blocks are verified with
+	configMutex sync.RWMutex
+	bundle      *channelconfig.Bundle
+}
+
+// Manager returns the policy manager of the channel config of the replicated
+// blocks, whose BlockValidation policy the blocks are verified with.
+func (r *Replicator) Manager(channelID string) (policies.Manager, bool) {
+	bundle := r.config()
+	if channelID != r.ChannelID || bundle == nil {
+		return nil, false
+	}
+	return bundle.PolicyManager(), true
+}
+
+// HashingSuite returns the hashing suite of the channel, with which the data
+// hashes of the blocks are verified.
+func (r *Replicator) HashingSuite(channelID string) func([]byte) []byte {
+	bundle := r.config()
+	if channelID != r.ChannelID || bundle == nil {
+		return nil
+	}
+	return bundle.ChannelConfig().HashingSuite()
+}
+
+func (r *Replicator) config() *channelconfig.Bundle {
+	r.configMutex.RLock()
+	defer r.configMutex.RUnlock()
+	return r.bundle
+}
+
+func (r *Replicator) setConfig(bundle *channelconfig.Bundle) {
+	r.configMutex.Lock()
+	defer r.configMutex.Unlock()
+	r.bundle = bundle
+}
+
+// Height returns the height of the local ledger of the channel.
+func (r *Replicator) Height() (uint64, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.height()
+}
+
+func (r *Replicator) height() (uint64, error) {
+	if r.closed {
+		return r.closedHeight, nil
+	}
+	if r.Ledger == nil {
+		return 0, nil
+	}
+	info, err := r.Ledger.GetBlockchainInfo()
+	if err != nil {
+		return 0, errors.WithMessage(err, "failed retrieving blockchain info")
+	}
+	return info.Height, nil
+}
+
+// Replicate commits the blocks of the primary to the local ledger, starting
+// from the height of the local ledger, until the iterator is closed or done
+// is closed.
+func (r *Replicator) Replicate(done <-chan struct{}) error {
+	next, err := r.Height()
+	if err != nil {
+		return err
+	}
+
+	iterator, err := r.Blocks.GetBlocksIterator(next)
+	if err != nil {
+		return errors.WithMessage(err, "failed creating block iterator")
+	}
+	defer iterator.Close()
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-done:
+			iterator.Close()
+		case <-stop:
+		}
+	}()
+
+	for {
+		result, err := iterator.Next()
+		if err != nil {
+			return errors.WithMessage(err, "failed reading block")
+		}
+		block, ok := result.(*cb.Block)
+		if !ok || block == nil {
+			logger.Infof("[%s] block iterator closed, stopping replication", r.ChannelID)
+			return nil
+		}
+		err = r.commit(block)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (r *Replicator) commit(block *cb.Block) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.closed {
+		return errors.New("ledger closed")
+	}
+	height, err := r.height()
+	if err != nil {
+		return err
+	}
+	if block.Header == nil || block.Header.Number != height {
+		return errors.Errorf("received block [%d] while expecting block [%d]", block.GetHeader().GetNumber(), height)
+	}
+
+	if r.Ledger == nil {
+		bundle, err := bundleFromConfigBlock(block)
+		if err != nil {
+			return errors.WithMessage(err, "failed loading channel config from genesis block")
+		}
+		ledger, err := r.CreateLedger(block)
+		if err != nil {
+			return errors.WithMessage(err, "failed creating ledger from genesis block")
+		}
+		r.Ledger = ledger
+		r.setConfig(bundle)
+		logger.Infof("[%s] created ledger from genesis block", r.ChannelID)
+		return nil
+	}
+
+	err = r.verify(block)
+	if err != nil {
+		return err
+	}
+	var bundle *channelconfig.Bundle
+	if utils.IsConfigBlock(block) {
+		bundle, err = bundleFromConfigBlock(block)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("failed loading channel config from block [%d]", block.Header.Number))
+		}
+	}
+
+	err = r.Ledger.CommitWithPvtData(&ledger.BlockAndPvtData{Block: block})
+	if err != nil {
+		return errors.WithMessage(err, "failed committing block")
+	}
+	if bundle != nil {
+		r.setConfig(bundle)
+	}
+	logger.Debugf("[%s] replicated block [%d]", r.ChannelID, block.Header.Number)
+	return nil
+}
+
+// verify verifies the block with the Verifier, after loading the channel
+// config from the last config block of the ledger if needed.
+func (r *Replicator) verify(block *cb.Block) error {
+	if r.config() == nil {
+		err := r.loadConfig()
+		if err != nil {
+			return err
+		}
+	}
+
+	signedBlock, err := proto.Marshal(block)
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling block")
+	}
+	err = r.Verifier.VerifyBlock(common.ChainID(r.ChannelID), block.Header.Number, signedBlock)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed verifying block [%d]", block.Header.Number))
+	}
+	return nil
+}
+
+// loadConfig loads the channel config from the last config block of the
+// ledger.
+func (r *Replicator) loadConfig() error {
+	info, err := r.Ledger.GetBlockchainInfo()
+	if err != nil {
+		return errors.WithMessage(err, "failed retrieving blockchain info")
+	}
+	lastBlock, err := r.Ledger.GetBlockByNumber(info.Height - 1)
+	if err != nil {
+		return errors.WithMessage(err, "failed retrieving last block")
+	}
+	index, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return errors.WithMessage(err, "failed retrieving last config index")
+	}
+	configBlock, err := r.Ledger.GetBlockByNumber(index)
+	if err != nil {
+		return errors.WithMessage(err, "failed retrieving last config block")
+	}
+	bundle, err := bundleFromConfigBlock(configBlock)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed loading channel config from block [%d]", index))
+	}
+	r.setConfig(bundle)
+	return nil
+}
+
+func bundleFromConfigBlock(block *cb.Block) (*channelconfig.Bundle, error) {
+	envelope, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return nil, err
+	}
+	return channelconfig.NewBundleFromEnvelope(envelope)
+}
+
+// Close closes the local ledger of the channel, if any, so that it can be
+// opened again once the standby is promoted.
+func (r *Replicator) Close() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.closed {
+		return
+	}
+	height, err := r.height()
+	if err != nil {
+		logger.Warningf("[%s] failed retrieving ledger height: %s", r.ChannelID, err)
+	}
+	if r.Ledger != nil {
+		r.Ledger.Close()
+		r.Ledger = nil
+	}
+	r.closed = true
+	r.closedHeight = height
+}
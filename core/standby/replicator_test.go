@@ -0,0 +1,324 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package standby_test
+
+import (
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/standby"
+	"github.com/hyperledger/fabric/core/standby/mock"
+	"github.com/hyperledger/fabric/gossip/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+// newLedger returns a fake ledger whose height grows with the committed
+// blocks, and whose blocks are all config blocks.
+func newLedger(height uint64) *mock.Ledger {
+	var mutex sync.Mutex
+	fakeLedger := &mock.Ledger{}
+	fakeLedger.GetBlockByNumberReturns(configBlock(0), nil)
+	fakeLedger.GetBlockchainInfoStub = func() (*cb.BlockchainInfo, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return &cb.BlockchainInfo{Height: height}, nil
+	}
+	fakeLedger.CommitWithPvtDataStub = func(*ledger.BlockAndPvtData) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		height++
+		return nil
+	}
+	return fakeLedger
+}
+
+// newIterator returns a fake iterator over the given blocks, which blocks
+// once they are exhausted until it is closed.
+func newIterator(blocks ...*cb.Block) *mock.ResultsIterator {
+	closed := make(chan struct{})
+	var once sync.Once
+	fakeIterator := &mock.ResultsIterator{}
+	fakeIterator.NextStub = func() (commonledger.QueryResult, error) {
+		if fakeIterator.NextCallCount() <= len(blocks) {
+			return blocks[fakeIterator.NextCallCount()-1], nil
+		}
+		<-closed
+		return nil, nil
+	}
+	fakeIterator.CloseStub = func() {
+		once.Do(func() { close(closed) })
+	}
+	return fakeIterator
+}
+
+func block(number uint64) *cb.Block {
+	return &cb.Block{Header: &cb.BlockHeader{Number: number}}
+}
+
+// configBlock returns a config block of the channel with the given number.
+func configBlock(number uint64) *cb.Block {
+	block, err := configtxtest.MakeGenesisBlock("testchannel")
+	Expect(err).NotTo(HaveOccurred())
+	block.Header.Number = number
+	return block
+}
+
+var _ = Describe("Replicator", func() {
+	var (
+		fakeLedger   *mock.Ledger
+		fakeIterator *mock.ResultsIterator
+		fakeBlocks   *mock.BlockIteratorProvider
+		fakeVerifier *mock.BlockVerifier
+		replicator   *standby.Replicator
+		done         chan struct{}
+	)
+
+	BeforeEach(func() {
+		fakeLedger = newLedger(5)
+		fakeIterator = newIterator(block(5), block(6))
+		fakeBlocks = &mock.BlockIteratorProvider{}
+		fakeBlocks.GetBlocksIteratorReturns(fakeIterator, nil)
+		fakeVerifier = &mock.BlockVerifier{}
+		replicator = &standby.Replicator{
+			ChannelID: "testchannel",
+			Blocks:    fakeBlocks,
+			Ledger:    fakeLedger,
+			Verifier:  fakeVerifier,
+		}
+		done = make(chan struct{})
+	})
+
+	It("commits the blocks from the height of the ledger", func() {
+		go func() {
+			Eventually(fakeLedger.CommitWithPvtDataCallCount).Should(Equal(2))
+			close(done)
+		}()
+		err := replicator.Replicate(done)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeBlocks.GetBlocksIteratorCallCount()).To(Equal(1))
+		Expect(fakeBlocks.GetBlocksIteratorArgsForCall(0)).To(Equal(uint64(5)))
+		Expect(proto.Equal(fakeLedger.CommitWithPvtDataArgsForCall(0).Block, block(5))).To(BeTrue())
+		Expect(proto.Equal(fakeLedger.CommitWithPvtDataArgsForCall(1).Block, block(6))).To(BeTrue())
+		Expect(fakeIterator.CloseCallCount()).To(BeNumerically(">=", 1))
+
+		height, err := replicator.Height()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(height).To(Equal(uint64(7)))
+	})
+
+	It("verifies the blocks with the config of the channel before committing them", func() {
+		go func() {
+			Eventually(fakeLedger.CommitWithPvtDataCallCount).Should(Equal(2))
+			close(done)
+		}()
+		err := replicator.Replicate(done)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeVerifier.VerifyBlockCallCount()).To(Equal(2))
+		chainID, seqNum, signedBlock := fakeVerifier.VerifyBlockArgsForCall(1)
+		Expect(chainID).To(Equal(common.ChainID("testchannel")))
+		Expect(seqNum).To(Equal(uint64(6)))
+		Expect(signedBlock).To(Equal(utils.MarshalOrPanic(block(6))))
+
+		Expect(fakeLedger.GetBlockByNumberCallCount()).To(Equal(2))
+		Expect(fakeLedger.GetBlockByNumberArgsForCall(0)).To(Equal(uint64(4)))
+		Expect(fakeLedger.GetBlockByNumberArgsForCall(1)).To(Equal(uint64(0)))
+		policyManager, ok := replicator.Manager("testchannel")
+		Expect(ok).To(BeTrue())
+		Expect(policyManager).NotTo(BeNil())
+		Expect(replicator.HashingSuite("testchannel")).NotTo(BeNil())
+
+		policyManager, ok = replicator.Manager("otherchannel")
+		Expect(ok).To(BeFalse())
+		Expect(policyManager).To(BeNil())
+		Expect(replicator.HashingSuite("otherchannel")).To(BeNil())
+	})
+
+	Context("when a block fails verification", func() {
+		BeforeEach(func() {
+			fakeVerifier.VerifyBlockReturns(errors.New("banana"))
+		})
+
+		It("returns an error without committing the block", func() {
+			err := replicator.Replicate(done)
+			Expect(err).To(MatchError("failed verifying block [5]: banana"))
+			Expect(fakeLedger.CommitWithPvtDataCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the config of the channel cannot be loaded from the ledger", func() {
+		BeforeEach(func() {
+			fakeLedger.GetBlockByNumberReturns(nil, errors.New("banana"))
+		})
+
+		It("returns an error without committing the block", func() {
+			err := replicator.Replicate(done)
+			Expect(err).To(MatchError("failed retrieving last block: banana"))
+			Expect(fakeVerifier.VerifyBlockCallCount()).To(Equal(0))
+			Expect(fakeLedger.CommitWithPvtDataCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when a config block is replicated", func() {
+		BeforeEach(func() {
+			fakeIterator = newIterator(configBlock(5), block(6))
+			fakeBlocks.GetBlocksIteratorReturns(fakeIterator, nil)
+		})
+
+		It("verifies the next blocks with the new config", func() {
+			var policyManagers []interface{}
+			fakeVerifier.VerifyBlockStub = func(common.ChainID, uint64, []byte) error {
+				policyManager, _ := replicator.Manager("testchannel")
+				policyManagers = append(policyManagers, policyManager)
+				return nil
+			}
+			go func() {
+				Eventually(fakeLedger.CommitWithPvtDataCallCount).Should(Equal(2))
+				close(done)
+			}()
+			err := replicator.Replicate(done)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(policyManagers).To(HaveLen(2))
+			Expect(policyManagers[1]).NotTo(BeIdenticalTo(policyManagers[0]))
+		})
+	})
+
+	Context("when the ledger does not exist", func() {
+		var genesisBlock, createdWith *cb.Block
+
+		BeforeEach(func() {
+			genesisBlock = configBlock(0)
+			createdWith = nil
+			fakeIterator = newIterator(genesisBlock, block(1))
+			fakeBlocks.GetBlocksIteratorReturns(fakeIterator, nil)
+			replicator.Ledger = nil
+			replicator.CreateLedger = func(genesisBlock *cb.Block) (standby.Ledger, error) {
+				createdWith = genesisBlock
+				fakeLedger = newLedger(1)
+				return fakeLedger, nil
+			}
+		})
+
+		It("creates the ledger from the genesis block", func() {
+			go func() {
+				Eventually(fakeIterator.NextCallCount).Should(Equal(3))
+				close(done)
+			}()
+			err := replicator.Replicate(done)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeBlocks.GetBlocksIteratorArgsForCall(0)).To(Equal(uint64(0)))
+			Expect(createdWith).To(Equal(genesisBlock))
+			height, err := replicator.Height()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(height).To(Equal(uint64(2)))
+
+			// the genesis block is trusted, and the next blocks are verified
+			// with its config
+			Expect(fakeVerifier.VerifyBlockCallCount()).To(Equal(1))
+			_, seqNum, _ := fakeVerifier.VerifyBlockArgsForCall(0)
+			Expect(seqNum).To(Equal(uint64(1)))
+			Expect(fakeLedger.GetBlockByNumberCallCount()).To(Equal(0))
+			_, ok := replicator.Manager("testchannel")
+			Expect(ok).To(BeTrue())
+		})
+
+		Context("when the genesis block is not a config block", func() {
+			BeforeEach(func() {
+				fakeBlocks.GetBlocksIteratorReturns(newIterator(block(0)), nil)
+			})
+
+			It("returns an error without creating the ledger", func() {
+				err := replicator.Replicate(done)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed loading channel config from genesis block"))
+				Expect(createdWith).To(BeNil())
+			})
+		})
+
+		Context("when creating the ledger fails", func() {
+			BeforeEach(func() {
+				replicator.CreateLedger = func(*cb.Block) (standby.Ledger, error) {
+					return nil, errors.New("banana")
+				}
+			})
+
+			It("returns the error", func() {
+				err := replicator.Replicate(done)
+				Expect(err).To(MatchError("failed creating ledger from genesis block: banana"))
+			})
+		})
+	})
+
+	Context("when an unexpected block is delivered", func() {
+		BeforeEach(func() {
+			fakeBlocks.GetBlocksIteratorReturns(newIterator(block(6)), nil)
+		})
+
+		It("returns an error", func() {
+			err := replicator.Replicate(done)
+			Expect(err).To(MatchError("received block [6] while expecting block [5]"))
+			Expect(fakeLedger.CommitWithPvtDataCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when committing a block fails", func() {
+		BeforeEach(func() {
+			fakeLedger.CommitWithPvtDataStub = nil
+			fakeLedger.CommitWithPvtDataReturns(errors.New("banana"))
+		})
+
+		It("returns the error", func() {
+			err := replicator.Replicate(done)
+			Expect(err).To(MatchError("failed committing block: banana"))
+		})
+	})
+
+	Context("when the iterator cannot be created", func() {
+		BeforeEach(func() {
+			fakeBlocks.GetBlocksIteratorReturns(nil, errors.New("banana"))
+		})
+
+		It("returns the error", func() {
+			err := replicator.Replicate(done)
+			Expect(err).To(MatchError("failed creating block iterator: banana"))
+		})
+	})
+
+	Context("when reading a block fails", func() {
+		BeforeEach(func() {
+			fakeIterator.NextStub = nil
+			fakeIterator.NextReturns(nil, errors.New("banana"))
+		})
+
+		It("returns the error", func() {
+			err := replicator.Replicate(done)
+			Expect(err).To(MatchError("failed reading block: banana"))
+		})
+	})
+
+	Describe("Close", func() {
+		It("closes the ledger and keeps reporting its height", func() {
+			replicator.Close()
+			replicator.Close()
+			Expect(fakeLedger.CloseCallCount()).To(Equal(1))
+
+			height, err := replicator.Height()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(height).To(Equal(uint64(5)))
+		})
+	})
+})
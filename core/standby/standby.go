@@ -0,0 +1,149 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package standby
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// A Standby keeps the ledgers of a warm standby peer up to date with the
+// blocks committed by a primary peer. The standby peer does not join gossip
+// nor serve clients until it is promoted, either with Promote or with a POST
+// request to its operations handler.
+type Standby struct {
+	Replicators []*Replicator
+	// RetryInterval is the time to wait before replicating a channel again
+	// after a failure, such as the primary becoming unreachable.
+	RetryInterval time.Duration
+
+	once     sync.Once
+	promoted chan struct{}
+	stopped  chan struct{}
+}
+
+func (s *Standby) init() {
+	s.once.Do(func() {
+		s.promoted = make(chan struct{})
+		s.stopped = make(chan struct{})
+	})
+}
+
+// Run replicates the channels until the standby is promoted. It returns once
+// the replication stopped and the ledgers are closed.
+func (s *Standby) Run() {
+	s.init()
+	defer close(s.stopped)
+
+	var wg sync.WaitGroup
+	for _, r := range s.Replicators {
+		wg.Add(1)
+		go func(r *Replicator) {
+			defer wg.Done()
+			s.replicate(r)
+		}(r)
+	}
+	wg.Wait()
+
+	for _, r := range s.Replicators {
+		r.Close()
+	}
+}
+
+func (s *Standby) replicate(r *Replicator) {
+	for {
+		err := r.Replicate(s.promoted)
+		if err != nil {
+			logger.Warningf("[%s] failed replicating from primary, retrying in %s: %s", r.ChannelID, s.RetryInterval, err)
+		}
+		select {
+		case <-s.promoted:
+			return
+		case <-time.After(s.RetryInterval):
+		}
+	}
+}
+
+// Promote stops the replication. It can be called more than once.
+func (s *Standby) Promote() {
+	s.init()
+	select {
+	case <-s.promoted:
+	default:
+		logger.Info("Promoting standby peer")
+		close(s.promoted)
+	}
+}
+
+func (s *Standby) isPromoted() bool {
+	s.init()
+	select {
+	case <-s.promoted:
+		return true
+	default:
+		return false
+	}
+}
+
+// Status is the replication status of a standby peer.
+type Status struct {
+	Promoted bool              `json:"promoted"`
+	Channels map[string]uint64 `json:"channels"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// ServeHTTP reports the status of the standby on GET requests, with the ledger
+// height of each channel. POST requests promote the standby, and return once
+// the replication stopped.
+func (s *Standby) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	s.init()
+	switch req.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		s.Promote()
+		select {
+		case <-s.stopped:
+		case <-req.Context().Done():
+			sendResponse(resp, http.StatusServiceUnavailable, fmt.Errorf("request canceled while stopping replication"))
+			return
+		}
+	default:
+		sendResponse(resp, http.StatusBadRequest, fmt.Errorf("invalid request method: %s", req.Method))
+		return
+	}
+
+	status := &Status{
+		Promoted: s.isPromoted(),
+		Channels: map[string]uint64{},
+	}
+	for _, r := range s.Replicators {
+		height, err := r.Height()
+		if err != nil {
+			sendResponse(resp, http.StatusInternalServerError, fmt.Errorf("failed retrieving height of channel %s: %s", r.ChannelID, err))
+			return
+		}
+		status.Channels[r.ChannelID] = height
+	}
+	sendResponse(resp, http.StatusOK, status)
+}
+
+func sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	if err, ok := payload.(error); ok {
+		payload = &errorResponse{Error: err.Error()}
+	}
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+	if err := json.NewEncoder(resp).Encode(payload); err != nil {
+		logger.Errorw("failed to encode payload", "error", err)
+	}
+}
@@ -0,0 +1,26 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package standby_test
+
+import (
+	"testing"
+
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+//go:generate counterfeiter -o mock/results_iterator.go -fake-name ResultsIterator . resultsIterator
+
+type resultsIterator interface {
+	commonledger.ResultsIterator
+}
+
+func TestStandby(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Standby Suite")
+}
@@ -0,0 +1,126 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package standby_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/hyperledger/fabric/core/standby"
+	"github.com/hyperledger/fabric/core/standby/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Standby", func() {
+	var (
+		fakeLedger *mock.Ledger
+		fakeBlocks *mock.BlockIteratorProvider
+		sb         *standby.Standby
+		stopped    chan struct{}
+	)
+
+	BeforeEach(func() {
+		fakeLedger = newLedger(5)
+		fakeBlocks = &mock.BlockIteratorProvider{}
+		fakeBlocks.GetBlocksIteratorReturns(newIterator(block(5)), nil)
+		sb = &standby.Standby{
+			Replicators: []*standby.Replicator{{
+				ChannelID: "testchannel",
+				Blocks:    fakeBlocks,
+				Ledger:    fakeLedger,
+				Verifier:  &mock.BlockVerifier{},
+			}},
+			RetryInterval: 10 * time.Millisecond,
+		}
+		stopped = make(chan struct{})
+	})
+
+	run := func() {
+		sb, stopped := sb, stopped
+		go func() {
+			sb.Run()
+			close(stopped)
+		}()
+	}
+
+	It("replicates the channels until promoted", func() {
+		run()
+		Eventually(fakeLedger.CommitWithPvtDataCallCount).Should(Equal(1))
+		Consistently(stopped).ShouldNot(BeClosed())
+
+		sb.Promote()
+		Eventually(stopped).Should(BeClosed())
+		Expect(fakeLedger.CloseCallCount()).To(Equal(1))
+		sb.Promote()
+	})
+
+	Context("when the replication fails", func() {
+		BeforeEach(func() {
+			fakeBlocks.GetBlocksIteratorReturnsOnCall(0, nil, errors.New("unreachable"))
+		})
+
+		It("retries", func() {
+			run()
+			defer sb.Promote()
+			Eventually(fakeLedger.CommitWithPvtDataCallCount).Should(Equal(1))
+			Expect(fakeBlocks.GetBlocksIteratorCallCount()).To(Equal(2))
+		})
+	})
+
+	Describe("ServeHTTP", func() {
+		var status *standby.Status
+
+		decode := func(resp *httptest.ResponseRecorder) {
+			status = &standby.Status{}
+			Expect(json.Unmarshal(resp.Body.Bytes(), status)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			run()
+			Eventually(fakeLedger.CommitWithPvtDataCallCount).Should(Equal(1))
+		})
+
+		AfterEach(func() {
+			sb.Promote()
+		})
+
+		It("reports the status of the standby", func() {
+			resp := httptest.NewRecorder()
+			sb.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/standby", nil))
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			decode(resp)
+			Expect(status).To(Equal(&standby.Status{
+				Promoted: false,
+				Channels: map[string]uint64{"testchannel": 6},
+			}))
+		})
+
+		It("promotes the standby on POST requests", func() {
+			resp := httptest.NewRecorder()
+			sb.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/standby", nil))
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(stopped).To(BeClosed())
+			Expect(fakeLedger.CloseCallCount()).To(Equal(1))
+			decode(resp)
+			Expect(status).To(Equal(&standby.Status{
+				Promoted: true,
+				Channels: map[string]uint64{"testchannel": 6},
+			}))
+		})
+
+		It("rejects other methods", func() {
+			resp := httptest.NewRecorder()
+			sb.ServeHTTP(resp, httptest.NewRequest(http.MethodPut, "/standby", nil))
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "invalid request method: PUT"}`))
+		})
+	})
+})
@@ -117,6 +117,7 @@ type channelPeer struct {
 	Endpoint     string
 	Identity     string
 	Chaincodes   []string
+	QueryOnly    bool `json:",omitempty"`
 }
 
 type localPeer struct {
@@ -139,10 +140,12 @@ func (scr *simpleChannelResponse) Peers() ([]*discovery.Peer, error) {
 
 func rawPeerToChannelPeer(p *discovery.Peer) channelPeer {
 	var ledgerHeight uint64
+	var queryOnly bool
 	var ccs []string
 	if p.StateInfoMessage != nil && p.StateInfoMessage.GetStateInfo() != nil && p.StateInfoMessage.GetStateInfo().Properties != nil {
 		properties := p.StateInfoMessage.GetStateInfo().Properties
 		ledgerHeight = properties.LedgerHeight
+		queryOnly = properties.QueryOnly
 		for _, cc := range properties.Chaincodes {
 			if cc == nil {
 				continue
@@ -162,6 +165,7 @@ func rawPeerToChannelPeer(p *discovery.Peer) channelPeer {
 		LedgerHeight: ledgerHeight,
 		Identity:     string(sID.IdBytes),
 		Chaincodes:   ccs,
+		QueryOnly:    queryOnly,
 	}
 }
 
@@ -517,7 +517,8 @@ func (l layouts) groupsSet() map[string]struct{} {
 
 func peersWithChaincode(metadata ...*chaincode.Metadata) func(member NetworkMember) bool {
 	return func(member NetworkMember) bool {
-		if member.Properties == nil {
+		// query-only peers do not endorse, so they are never selected as endorsers
+		if member.Properties == nil || member.Properties.QueryOnly {
 			return false
 		}
 		for _, ccMD := range metadata {
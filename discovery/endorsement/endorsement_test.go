@@ -163,6 +163,33 @@ func TestPeersForEndorsement(t *testing.T) {
 		}, extractPeers(desc))
 	})
 
+	t.Run("QueryOnlyPeers", func(t *testing.T) {
+		// Scenario: Policy is found and there are enough peers to satisfy
+		// 2 principal combinations: p0 and p6, or p12 alone.
+		// However, p12 is a query-only peer and does not endorse,
+		// so only the combination of p0 and p6 can be satisfied.
+		pb := principalBuilder{}
+		policy := pb.newSet().addPrincipal(peerRole("p0")).addPrincipal(peerRole("p6")).
+			newSet().addPrincipal(peerRole("p12")).buildPolicy()
+		peers := peerSet{
+			newPeer(0).withChaincode(cc, "1.0"),
+			newPeer(6).withChaincode(cc, "1.0"),
+			newPeer(12).withChaincode(cc, "1.0").queryOnly(),
+		}
+		g.On("PeersOfChannel").Return(peers.toMembers()).Once()
+		mf.On("Metadata").Return(&chaincode.Metadata{Name: cc, Version: "1.0"}).Once()
+		analyzer := NewEndorsementAnalyzer(g, pf, &principalEvaluatorMock{}, mf)
+		pf.On("PolicyByChaincode", cc).Return(policy).Once()
+		desc, err := analyzer.PeersForEndorsement(channel, &discoveryprotos.ChaincodeInterest{Chaincodes: []*discoveryprotos.ChaincodeCall{{Name: cc}}})
+		assert.NoError(t, err)
+		assert.NotNil(t, desc)
+		assert.Len(t, desc.Layouts, 1)
+		assert.Equal(t, map[string]struct{}{
+			peerIdentityString("p0"): {},
+			peerIdentityString("p6"): {},
+		}, extractPeers(desc))
+	})
+
 	t.Run("WrongVersionInstalled", func(t *testing.T) {
 		// Scenario V: Policy is found, and there are enough peers to satisfy policy combinations,
 		// but all peers have the wrong version installed on them.
@@ -601,6 +628,14 @@ func (pi *peerInfo) withChaincode(name, version string) *peerInfo {
 	return pi
 }
 
+func (pi *peerInfo) queryOnly() *peerInfo {
+	if pi.Properties == nil {
+		pi.Properties = &gossip.Properties{}
+	}
+	pi.Properties.QueryOnly = true
+	return pi
+}
+
 type gossipMock struct {
 	mock.Mock
 }
@@ -16,6 +16,12 @@ func New() shim.Chaincode {
 	return &scc{}
 }
 
+// Version returns the version of the plugin. It is optional and is checked
+// against the version configured for the plugin, if any.
+func Version() string {
+	return "1.0.0"
+}
+
 type scc struct{}
 
 // Init implements the chaincode shim interface
@@ -75,6 +75,8 @@ type NetworkMember struct {
 	PKIid            common.PKIidType
 	InternalEndpoint string
 	Properties       *proto.Properties
+	// AdvertisedEndpoints are published to foreign organizations in addition to Endpoint
+	AdvertisedEndpoints []*proto.AdvertisedEndpoint
 	*proto.Envelope
 }
 
@@ -123,6 +125,9 @@ type Discovery interface {
 	// GetMembership returns the alive members in the view
 	GetMembership() []NetworkMember
 
+	// GetDeadMembership returns the members in the view that are considered dead
+	GetDeadMembership() []NetworkMember
+
 	// InitiateSync makes the instance ask a given number of peers
 	// for their membership information
 	InitiateSync(peerNum int)
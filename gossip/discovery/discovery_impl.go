@@ -88,6 +88,7 @@ type gossipDiscoveryImpl struct {
 	port             int
 	logger           util.Logger
 	disclosurePolicy DisclosurePolicy
+	selectEndpoint   EndpointSelector
 	pubsub           *util.PubSub
 
 	aliveTimeInterval            time.Duration
@@ -96,8 +97,9 @@ type gossipDiscoveryImpl struct {
 	reconnectInterval            time.Duration
 }
 
-// NewDiscoveryService returns a new discovery service with the comm module passed and the crypto service passed
-func NewDiscoveryService(self NetworkMember, comm CommService, crypt CryptoService, disPol DisclosurePolicy) Discovery {
+// NewDiscoveryService returns a new discovery service with the comm module passed and the crypto service passed.
+// The endpoint selector picks the endpoint remote members are reached through; when nil, their external endpoint is used.
+func NewDiscoveryService(self NetworkMember, comm CommService, crypt CryptoService, disPol DisclosurePolicy, selectEndpoint EndpointSelector) Discovery {
 	d := &gossipDiscoveryImpl{
 		self:             self,
 		incTime:          uint64(time.Now().UnixNano()),
@@ -114,6 +116,7 @@ func NewDiscoveryService(self NetworkMember, comm CommService, crypt CryptoServi
 		toDieFlag:        int32(0),
 		logger:           util.GetLogger(util.DiscoveryLogger, self.InternalEndpoint),
 		disclosurePolicy: disPol,
+		selectEndpoint:   selectEndpoint,
 		pubsub:           util.NewPubSub(),
 
 		aliveTimeInterval:            getAliveTimeInterval(),
@@ -263,7 +266,7 @@ func (d *gossipDiscoveryImpl) InitiateSync(peerNum int) {
 			internalEndpoint = aliveMembersAsSlice[i].Envelope.SecretEnvelope.InternalEndpoint()
 		}
 		netMember := &NetworkMember{
-			Endpoint:         pulledPeer.Endpoint,
+			Endpoint:         d.endpointOf(pulledPeer),
 			Metadata:         pulledPeer.Metadata,
 			PKIid:            pulledPeer.PkiId,
 			InternalEndpoint: internalEndpoint,
@@ -416,7 +419,7 @@ func (d *gossipDiscoveryImpl) sendMemResponse(targetMember *proto.Member, intern
 	d.logger.Debug("Entering", targetMember)
 
 	targetPeer := &NetworkMember{
-		Endpoint:         targetMember.Endpoint,
+		Endpoint:         d.endpointOf(targetMember),
 		Metadata:         targetMember.Metadata,
 		PKIid:            targetMember.PkiId,
 		InternalEndpoint: internalEndpoint,
@@ -595,7 +598,7 @@ func (d *gossipDiscoveryImpl) resurrectMember(am *proto.SignedGossipMessage, t p
 	}
 
 	d.id2Member[string(pkiID)] = &NetworkMember{
-		Endpoint:         member.Endpoint,
+		Endpoint:         d.endpointOf(member),
 		Metadata:         member.Metadata,
 		PKIid:            member.PkiId,
 		InternalEndpoint: internalEndpoint,
@@ -763,6 +766,7 @@ func (d *gossipDiscoveryImpl) aliveMsgAndInternalEndpoint() (*proto.GossipMessag
 	d.seqNum++
 	seqNum := d.seqNum
 	endpoint := d.self.Endpoint
+	advertisedEndpoints := d.self.AdvertisedEndpoints
 	meta := d.self.Metadata
 	pkiID := d.self.PKIid
 	internalEndpoint := d.self.InternalEndpoint
@@ -771,9 +775,10 @@ func (d *gossipDiscoveryImpl) aliveMsgAndInternalEndpoint() (*proto.GossipMessag
 		Content: &proto.GossipMessage_AliveMsg{
 			AliveMsg: &proto.AliveMessage{
 				Membership: &proto.Member{
-					Endpoint: endpoint,
-					Metadata: meta,
-					PkiId:    pkiID,
+					Endpoint:            endpoint,
+					Metadata:            meta,
+					PkiId:               pkiID,
+					AdvertisedEndpoints: advertisedEndpoints,
 				},
 				Timestamp: &proto.PeerTime{
 					IncNum: uint64(d.incTime),
@@ -828,7 +833,7 @@ func (d *gossipDiscoveryImpl) learnExistingMembers(aliveArr []*proto.SignedGossi
 
 		// update member's data
 		member := d.id2Member[string(am.Membership.PkiId)]
-		member.Endpoint = am.Membership.Endpoint
+		member.Endpoint = d.endpointOf(am.Membership)
 		member.Metadata = am.Membership.Metadata
 		member.InternalEndpoint = internalEndpoint
 
@@ -915,7 +920,7 @@ func (d *gossipDiscoveryImpl) learnNewMembers(aliveMembers []*proto.SignedGossip
 			}
 
 			d.id2Member[string(member.Membership.PkiId)] = &NetworkMember{
-				Endpoint:         member.Membership.Endpoint,
+				Endpoint:         d.endpointOf(member.Membership),
 				Metadata:         member.Membership.Metadata,
 				PKIid:            member.Membership.PkiId,
 				InternalEndpoint: internalEndpoint,
@@ -936,7 +941,7 @@ func (d *gossipDiscoveryImpl) GetMembership() []NetworkMember {
 		member := m.GetAliveMsg()
 		response = append(response, NetworkMember{
 			PKIid:            member.Membership.PkiId,
-			Endpoint:         member.Membership.Endpoint,
+			Endpoint:         d.endpointOf(member.Membership),
 			Metadata:         member.Membership.Metadata,
 			InternalEndpoint: d.id2Member[string(m.GetAliveMsg().Membership.PkiId)].InternalEndpoint,
 			Envelope:         m.Envelope,
@@ -946,6 +951,32 @@ func (d *gossipDiscoveryImpl) GetMembership() []NetworkMember {
 
 }
 
+func (d *gossipDiscoveryImpl) GetDeadMembership() []NetworkMember {
+	if d.toDie() {
+		return []NetworkMember{}
+	}
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	response := []NetworkMember{}
+	for _, m := range d.deadMembership.ToSlice() {
+		member := m.GetAliveMsg()
+		var internalEndpoint string
+		// dead members are forgotten once expired
+		if netMember := d.id2Member[string(member.Membership.PkiId)]; netMember != nil {
+			internalEndpoint = netMember.InternalEndpoint
+		}
+		response = append(response, NetworkMember{
+			PKIid:            member.Membership.PkiId,
+			Endpoint:         d.endpointOf(member.Membership),
+			Metadata:         member.Membership.Metadata,
+			InternalEndpoint: internalEndpoint,
+			Envelope:         m.Envelope,
+		})
+	}
+	return response
+}
+
 func tsToTime(ts uint64) time.Time {
 	return time.Unix(int64(0), int64(ts))
 }
@@ -974,11 +1005,20 @@ func (d *gossipDiscoveryImpl) Self() NetworkMember {
 	}
 	mem := msg.GetAliveMsg().Membership
 	return NetworkMember{
-		Endpoint: mem.Endpoint,
-		Metadata: mem.Metadata,
-		PKIid:    mem.PkiId,
-		Envelope: env,
+		Endpoint:            mem.Endpoint,
+		Metadata:            mem.Metadata,
+		PKIid:               mem.PkiId,
+		AdvertisedEndpoints: mem.AdvertisedEndpoints,
+		Envelope:            env,
+	}
+}
+
+// endpointOf returns the endpoint the given remote member is reached through
+func (d *gossipDiscoveryImpl) endpointOf(member *proto.Member) string {
+	if d.selectEndpoint == nil {
+		return member.Endpoint
 	}
+	return d.selectEndpoint(member)
 }
 
 func (d *gossipDiscoveryImpl) toDie() bool {
@@ -383,7 +383,7 @@ func createDiscoveryInstanceThatGossipsWithInterceptors(port int, id string, boo
 	}
 	s := grpc.NewServer()
 
-	discSvc := NewDiscoveryService(self, comm, comm, pol)
+	discSvc := NewDiscoveryService(self, comm, comm, pol, nil)
 	for _, bootPeer := range bootstrapPeers {
 		bp := bootPeer
 		discSvc.Connect(NetworkMember{Endpoint: bp, InternalEndpoint: bootPeer}, func() (*PeerIdentification, error) {
@@ -824,6 +824,12 @@ func TestExpiration(t *testing.T) {
 	waitUntilOrFailBlocking(t, instances[nodeNum-2].Stop)
 
 	assertMembership(t, instances[:len(instances)-2], nodeNum-3)
+	for _, inst := range instances[:len(instances)-2] {
+		inst := inst
+		waitUntilOrFail(t, func() bool {
+			return len(inst.GetDeadMembership()) == 2
+		})
+	}
 
 	stopAction := &sync.WaitGroup{}
 	for i, inst := range instances {
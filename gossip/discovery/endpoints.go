@@ -0,0 +1,56 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	proto "github.com/hyperledger/fabric/protos/gossip"
+)
+
+// EndpointSelector returns the endpoint through which a member
+// is reached, out of the endpoints it advertises
+type EndpointSelector func(member *proto.Member) string
+
+// SelectEndpoint returns the endpoint peers and clients of the given organization
+// should use to reach the member: the endpoint with the highest priority among
+// the ones advertised to the organization, or the member's external endpoint
+// when none is. Deprecated endpoints are selected only when no other endpoint,
+// including the external endpoint, is available.
+func SelectEndpoint(member *proto.Member, org string) string {
+	var selected *proto.AdvertisedEndpoint
+	for _, endpoint := range member.AdvertisedEndpoints {
+		if endpoint.Endpoint == "" || !advertisedTo(endpoint, org) {
+			continue
+		}
+		if selected == nil || preferred(endpoint, selected) {
+			selected = endpoint
+		}
+	}
+	if selected == nil || (selected.Deprecated && member.Endpoint != "") {
+		return member.Endpoint
+	}
+	return selected.Endpoint
+}
+
+func advertisedTo(endpoint *proto.AdvertisedEndpoint, org string) bool {
+	if len(endpoint.Orgs) == 0 {
+		return true
+	}
+	for _, o := range endpoint.Orgs {
+		if o == org {
+			return true
+		}
+	}
+	return false
+}
+
+// preferred returns whether e is preferred over the currently selected endpoint
+func preferred(e, selected *proto.AdvertisedEndpoint) bool {
+	if e.Deprecated != selected.Deprecated {
+		return !e.Deprecated
+	}
+	return e.Priority > selected.Priority
+}
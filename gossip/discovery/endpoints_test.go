@@ -0,0 +1,58 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"testing"
+
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectEndpoint(t *testing.T) {
+	gateway := &proto.AdvertisedEndpoint{Endpoint: "gateway:443", Priority: 10, Orgs: []string{"Org2MSP"}}
+	nat := &proto.AdvertisedEndpoint{Endpoint: "nat:7051", Priority: 5}
+	old := &proto.AdvertisedEndpoint{Endpoint: "old:7051", Priority: 100, Deprecated: true}
+
+	for _, test := range []struct {
+		name        string
+		external    string
+		advertised  []*proto.AdvertisedEndpoint
+		org         string
+		expectation string
+	}{
+		{name: "no advertised endpoints", external: "peer:7051", org: "Org2MSP", expectation: "peer:7051"},
+		{name: "highest priority", external: "peer:7051", advertised: []*proto.AdvertisedEndpoint{nat, gateway}, org: "Org2MSP", expectation: "gateway:443"},
+		{name: "restricted to other orgs", external: "peer:7051", advertised: []*proto.AdvertisedEndpoint{nat, gateway}, org: "Org3MSP", expectation: "nat:7051"},
+		{name: "none advertised to org", external: "peer:7051", advertised: []*proto.AdvertisedEndpoint{gateway}, org: "Org3MSP", expectation: "peer:7051"},
+		{name: "none advertised without external endpoint", advertised: []*proto.AdvertisedEndpoint{gateway}, org: "Org3MSP", expectation: ""},
+		{name: "deprecated loses to lower priority", external: "peer:7051", advertised: []*proto.AdvertisedEndpoint{old, nat}, org: "Org2MSP", expectation: "nat:7051"},
+		{name: "deprecated loses to external endpoint", external: "peer:7051", advertised: []*proto.AdvertisedEndpoint{old}, org: "Org2MSP", expectation: "peer:7051"},
+		{name: "deprecated as last resort", advertised: []*proto.AdvertisedEndpoint{old}, org: "Org2MSP", expectation: "old:7051"},
+		{name: "empty endpoint ignored", external: "peer:7051", advertised: []*proto.AdvertisedEndpoint{{Priority: 1}}, org: "Org2MSP", expectation: "peer:7051"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			member := &proto.Member{Endpoint: test.external, AdvertisedEndpoints: test.advertised}
+			assert.Equal(t, test.expectation, SelectEndpoint(member, test.org))
+		})
+	}
+}
+
+func TestEndpointOf(t *testing.T) {
+	member := &proto.Member{
+		Endpoint:            "peer:7051",
+		AdvertisedEndpoints: []*proto.AdvertisedEndpoint{{Endpoint: "gateway:443"}},
+	}
+
+	d := &gossipDiscoveryImpl{}
+	assert.Equal(t, "peer:7051", d.endpointOf(member))
+
+	d.selectEndpoint = func(m *proto.Member) string {
+		return SelectEndpoint(m, "Org2MSP")
+	}
+	assert.Equal(t, "gateway:443", d.endpointOf(member))
+}
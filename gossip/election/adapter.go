@@ -45,6 +45,9 @@ type gossip interface {
 	// Peers returns the NetworkMembers considered alive
 	Peers() []discovery.NetworkMember
 
+	// SelfMembershipInfo returns the peer's membership information
+	SelfMembershipInfo() discovery.NetworkMember
+
 	// Accept returns a dedicated read-only channel for messages sent by other nodes that match a certain predicate.
 	// If passThrough is false, the messages are processed by the gossip layer beforehand.
 	// If passThrough is true, the gossip layer doesn't intervene and the messages
@@ -64,6 +67,9 @@ type adapterImpl struct {
 
 	channel common.ChainID
 
+	// preferredLeaders are the endpoints of the leader preference list
+	preferredLeaders []string
+
 	logger util.Logger
 
 	doneCh   chan struct{}
@@ -81,6 +87,8 @@ func NewAdapter(gossip gossip, pkiid common.PKIidType, channel common.ChainID) L
 
 		channel: channel,
 
+		preferredLeaders: getPreferredLeaders(),
+
 		logger: util.GetLogger(util.ElectionLogger, ""),
 
 		doneCh:   make(chan struct{}),
@@ -152,6 +160,30 @@ func (ai *adapterImpl) Peers() []Peer {
 	return res
 }
 
+// Rank returns the position in the leader preference list of the endpoint
+// of the peer of the given ID, or -1 if the peer is not alive or its
+// endpoint is not in the list.
+func (ai *adapterImpl) Rank(id peerID) int {
+	if len(ai.preferredLeaders) == 0 {
+		return -1
+	}
+	members := ai.gossip.Peers()
+	if bytes.Equal(id, ai.selfPKIid) {
+		members = []discovery.NetworkMember{ai.gossip.SelfMembershipInfo()}
+	}
+	for _, member := range members {
+		if !bytes.Equal(member.PKIid, id) {
+			continue
+		}
+		for i, endpoint := range ai.preferredLeaders {
+			if endpoint == member.Endpoint || endpoint == member.InternalEndpoint {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
 func (ai *adapterImpl) Stop() {
 	stopFunc := func() {
 		close(ai.doneCh)
@@ -94,6 +94,25 @@ func TestAdapterImpl_Peers(t *testing.T) {
 
 }
 
+func TestAdapterImpl_Rank(t *testing.T) {
+	_, adapters := createCluster(0, 1, 2)
+	adapter := adapters["Peer0"]
+
+	for _, id := range []byte{0, 1, 2} {
+		if rank := adapter.Rank(peerID{id}); rank != -1 {
+			t.Errorf("Peer%d should not be ranked without preference list, not %d", id, rank)
+		}
+	}
+
+	adapter.preferredLeaders = []string{"Peer2", "Peer0", "Peer5"}
+	expected := map[byte]int{0: 1, 1: -1, 2: 0, 5: -1}
+	for id, rank := range expected {
+		if actual := adapter.Rank(peerID{id}); actual != rank {
+			t.Errorf("Peer%d should have rank %d, not %d", id, rank, actual)
+		}
+	}
+}
+
 func TestAdapterImpl_Stop(t *testing.T) {
 	_, adapters := createCluster(0, 1, 2, 3, 4, 5)
 
@@ -189,6 +208,10 @@ func (g *peerMockGossip) Peers() []discovery.NetworkMember {
 	return res
 }
 
+func (g *peerMockGossip) SelfMembershipInfo() discovery.NetworkMember {
+	return *g.member
+}
+
 func (g *peerMockGossip) Accept(acceptor common.MessageAcceptor, passThrough bool) (<-chan *proto.GossipMessage, <-chan proto.ReceivedMessage) {
 	ch := make(chan *proto.GossipMessage, 100)
 	g.acceptorLock.Lock()
@@ -19,7 +19,8 @@ import (
 
 // Gossip leader election module
 // Algorithm properties:
-// - Peers break symmetry by comparing IDs
+// - Peers break symmetry by comparing their ranks in the leader preference
+//   list of the organization, then their IDs
 // - Each peer is either a leader or a follower,
 //   and the aim is to have exactly 1 leader if the membership view
 //   is the same for all peers
@@ -51,9 +52,12 @@ import (
 //		If you are the leader:
 //			Broadcast leadership declaration
 //			If a leadership declaration was received from
-// 			a peer with a lower ID,
+// 			a better candidate,
 //			become a follower
 //		Else, you're a follower:
+//			If a leadership declaration was received from
+//			a peer ranked after you in the preference list:
+//				become the leader
 //			If haven't received a leadership declaration within
 // 			a time threshold:
 //				set leaderKnown to false
@@ -64,7 +68,7 @@ import (
 //	If received a leadership declaration:
 //		return
 //	Iterate over all proposal messages collected.
-// 	If a proposal message from a peer with an ID lower
+// 	If a proposal message from a better candidate
 // 	than yourself was received, return.
 //	Else, declare yourself a leader
 
@@ -82,6 +86,10 @@ type LeaderElectionAdapter interface {
 
 	// Peers returns a list of peers considered alive
 	Peers() []Peer
+
+	// Rank returns the position of the peer of the given ID in the leader
+	// preference list of the organization, or -1 if it is not in the list
+	Rank(id peerID) int
 }
 
 type leadershipCallback func(isLeader bool)
@@ -128,7 +136,7 @@ func noopCallback(_ bool) {
 }
 
 // NewLeaderElectionService returns a new LeaderElectionService
-func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, callback leadershipCallback) LeaderElectionService {
+func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, callback leadershipCallback, metrics *Metrics) LeaderElectionService {
 	if len(id) == 0 {
 		panic("Empty id")
 	}
@@ -140,6 +148,7 @@ func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, callback
 		interruptChan: make(chan struct{}, 1),
 		logger:        util.GetLogger(util.ElectionLogger, ""),
 		callback:      noopCallback,
+		metrics:       metrics,
 	}
 
 	if callback != nil {
@@ -162,11 +171,13 @@ type leaderElectionSvcImpl struct {
 	toDie         int32
 	leaderExists  int32
 	yield         int32
+	takeover      int32
 	sleeping      bool
 	adapter       LeaderElectionAdapter
 	logger        util.Logger
 	callback      leadershipCallback
 	yieldTimer    *time.Timer
+	metrics       *Metrics
 }
 
 func (le *leaderElectionSvcImpl) start() {
@@ -212,8 +223,12 @@ func (le *leaderElectionSvcImpl) handleMessage(msg Msg) {
 		if le.sleeping && len(le.interruptChan) == 0 {
 			le.interruptChan <- struct{}{}
 		}
-		if bytes.Compare(msg.SenderID(), le.id) < 0 && le.IsLeader() {
+		if le.precedes(msg.SenderID(), le.id) && le.IsLeader() {
 			le.stopBeingLeader()
+			le.metrics.preempted()
+		} else if le.outranks(le.id, msg.SenderID()) && !le.IsLeader() && !le.isYielding() {
+			// The leader is ranked after us in the leader preference list
+			atomic.StoreInt32(&le.takeover, int32(1))
 		}
 	} else {
 		// We shouldn't get here
@@ -262,6 +277,9 @@ func (le *leaderElectionSvcImpl) run() {
 		}
 		if le.IsLeader() {
 			le.leader()
+		} else if atomic.CompareAndSwapInt32(&le.takeover, int32(1), int32(0)) && !le.isYielding() {
+			le.logger.Info(le.id, ": Taking over the leadership from a peer ranked after us")
+			le.beLeader()
 		} else {
 			le.follower()
 		}
@@ -295,7 +313,7 @@ func (le *leaderElectionSvcImpl) leaderElection() {
 	// for being a leader
 	for _, o := range le.proposals.ToArray() {
 		id := o.(string)
-		if bytes.Compare(peerID(id), le.id) < 0 {
+		if le.precedes(peerID(id), le.id) {
 			return
 		}
 	}
@@ -321,6 +339,10 @@ func (le *leaderElectionSvcImpl) follower() {
 	atomic.StoreInt32(&le.leaderExists, int32(0))
 	select {
 	case <-time.After(getLeaderAliveThreshold()):
+		if !le.isLeaderExists() {
+			le.logger.Info(le.id, ": No leadership declaration received within", getLeaderAliveThreshold())
+			le.metrics.leaseExpired()
+		}
 	case <-le.stopChan:
 		le.stopChan <- struct{}{}
 	}
@@ -332,6 +354,26 @@ func (le *leaderElectionSvcImpl) leader() {
 	le.waitForInterrupt(getLeadershipDeclarationInterval())
 }
 
+// precedes returns whether the peer of ID a is a better candidate for being
+// a leader than the peer of ID b. Peers in the leader preference list come
+// first, in the order of the list, and the other peers are ordered by ID.
+func (le *leaderElectionSvcImpl) precedes(a, b peerID) bool {
+	if le.outranks(a, b) {
+		return true
+	}
+	if le.outranks(b, a) {
+		return false
+	}
+	return bytes.Compare(a, b) < 0
+}
+
+// outranks returns whether the peer of ID a comes before the peer of ID b
+// in the leader preference list.
+func (le *leaderElectionSvcImpl) outranks(a, b peerID) bool {
+	rankA, rankB := le.adapter.Rank(a), le.adapter.Rank(b)
+	return rankA != -1 && (rankB == -1 || rankA < rankB)
+}
+
 // waitForMembershipStabilization waits for membership view to stabilize
 // or until a time limit expires, or until a peer declares itself as a leader
 func (le *leaderElectionSvcImpl) waitForMembershipStabilization(timeLimit time.Duration) {
@@ -380,13 +422,16 @@ func (le *leaderElectionSvcImpl) IsLeader() bool {
 
 func (le *leaderElectionSvcImpl) beLeader() {
 	le.logger.Info(le.id, ": Becoming a leader")
+	atomic.StoreInt32(&le.takeover, int32(0))
 	atomic.StoreInt32(&le.isLeader, int32(1))
+	le.metrics.leader(true)
 	le.callback(true)
 }
 
 func (le *leaderElectionSvcImpl) stopBeingLeader() {
 	le.logger.Info(le.id, "Stopped being a leader")
 	atomic.StoreInt32(&le.isLeader, int32(0))
+	le.metrics.leader(false)
 	le.callback(false)
 }
 
@@ -433,6 +478,7 @@ func (le *leaderElectionSvcImpl) Stop() {
 	atomic.StoreInt32(&le.toDie, int32(1))
 	le.stopChan <- struct{}{}
 	le.stopWG.Wait()
+	le.metrics.leader(false)
 }
 
 // SetStartupGracePeriod configures startup grace period interval,
@@ -452,6 +498,13 @@ func SetLeaderAliveThreshold(t time.Duration) {
 	viper.Set("peer.gossip.election.leaderAliveThreshold", t)
 }
 
+// SetLeaseRenewalInterval configures the interval at which the leader
+// declares its leadership again, which must be shorter than the leader
+// alive threshold
+func SetLeaseRenewalInterval(t time.Duration) {
+	viper.Set("peer.gossip.election.leaseRenewalInterval", t)
+}
+
 // SetLeaderElectionDuration configures expected leadership election duration,
 // interval to wait until leader election will be completed
 func SetLeaderElectionDuration(t time.Duration) {
@@ -471,13 +524,28 @@ func getLeaderAliveThreshold() time.Duration {
 }
 
 func getLeadershipDeclarationInterval() time.Duration {
-	return time.Duration(getLeaderAliveThreshold() / 2)
+	threshold := getLeaderAliveThreshold()
+	interval := util.GetDurationOrDefault("peer.gossip.election.leaseRenewalInterval", threshold/2)
+	if interval <= 0 || interval >= threshold {
+		return threshold / 2
+	}
+	return interval
 }
 
 func getLeaderElectionDuration() time.Duration {
 	return util.GetDurationOrDefault("peer.gossip.election.leaderElectionDuration", time.Second*5)
 }
 
+// SetPreferredLeaders configures the leader preference list, the endpoints
+// of the peers of the organization to elect as leader first, in order
+func SetPreferredLeaders(endpoints []string) {
+	viper.Set("peer.gossip.election.preferredLeaders", endpoints)
+}
+
+func getPreferredLeaders() []string {
+	return viper.GetStringSlice("peer.gossip.election.preferredLeaders")
+}
+
 // GetMsgExpirationTimeout return leadership message expiration timeout
 func GetMsgExpirationTimeout() time.Duration {
 	return getLeaderAliveThreshold() * 10
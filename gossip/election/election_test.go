@@ -14,6 +14,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	"github.com/hyperledger/fabric/core/config/configtest"
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/spf13/viper"
@@ -61,6 +62,7 @@ type peer struct {
 	leaderFromCallback bool
 	callbackInvoked    bool
 	lock               sync.RWMutex
+	ranks              map[string]int
 	LeaderElectionService
 }
 
@@ -123,6 +125,13 @@ func (p *peer) Peers() []Peer {
 	return peers
 }
 
+func (p *peer) Rank(id peerID) int {
+	if rank, ok := p.ranks[string(id)]; ok {
+		return rank
+	}
+	return -1
+}
+
 func (p *peer) leaderCallback(isLeader bool) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
@@ -157,10 +166,14 @@ func createPeers(spawnInterval time.Duration, ids ...int) []*peer {
 }
 
 func createPeer(id int, peerMap map[string]*peer, l *sync.RWMutex) *peer {
+	return createRankedPeer(id, peerMap, l, nil, nil)
+}
+
+func createRankedPeer(id int, peerMap map[string]*peer, l *sync.RWMutex, ranks map[string]int, metrics *Metrics) *peer {
 	idStr := fmt.Sprintf("p%d", id)
 	c := make(chan Msg, 100)
-	p := &peer{id: idStr, peers: peerMap, sharedLock: l, msgChan: c, mockedMethods: make(map[string]struct{}), leaderFromCallback: false, callbackInvoked: false}
-	p.LeaderElectionService = NewLeaderElectionService(p, idStr, p.leaderCallback)
+	p := &peer{id: idStr, peers: peerMap, sharedLock: l, msgChan: c, mockedMethods: make(map[string]struct{}), leaderFromCallback: false, callbackInvoked: false, ranks: ranks}
+	p.LeaderElectionService = NewLeaderElectionService(p, idStr, p.leaderCallback, metrics)
 	l.Lock()
 	peerMap[idStr] = p
 	l.Unlock()
@@ -308,6 +321,40 @@ func TestLeadershipTakeover(t *testing.T) {
 	assert.Equal(t, "p2", leaders[0])
 }
 
+func TestPreferredLeaders(t *testing.T) {
+	t.Parallel()
+	// Scenario: p0 and p1 spawn, then p2, with p2 and p1 first in the leader
+	// preference list. After a while, p2 stops.
+	// expected outcome: p1 is elected although its ID is higher than the ID of p0,
+	// p2 takes over the leadership once it spawns, and p1 notices that the
+	// leadership of p2 expired
+	ranks := map[string]int{"p2": 0, "p1": 1}
+	leaderGauge := &metricsfakes.Gauge{}
+	leaseExpirations := &metricsfakes.Counter{}
+	preemptions := &metricsfakes.Counter{}
+	p1Metrics := &Metrics{Leader: leaderGauge, LeaseExpirations: leaseExpirations, Preemptions: preemptions}
+
+	peerMap := make(map[string]*peer)
+	l := &sync.RWMutex{}
+	p0 := createRankedPeer(0, peerMap, l, ranks, nil)
+	p1 := createRankedPeer(1, peerMap, l, ranks, p1Metrics)
+	leaders := waitForLeaderElection(t, []*peer{p0, p1})
+	assert.Equal(t, []string{"p1"}, leaders)
+	assert.Equal(t, float64(1), leaderGauge.SetArgsForCall(leaderGauge.SetCallCount()-1))
+
+	p2 := createRankedPeer(2, peerMap, l, ranks, nil)
+	waitForBoolFunc(t, p2.IsLeader, true, "p2 should take over the leadership")
+	waitForBoolFunc(t, p1.IsLeader, false, "p1 should relinquish the leadership")
+	assert.False(t, p0.IsLeader())
+	assert.Equal(t, 1, preemptions.AddCallCount())
+	assert.Equal(t, float64(0), leaderGauge.SetArgsForCall(leaderGauge.SetCallCount()-1))
+
+	p2.Stop()
+	waitForBoolFunc(t, func() bool { return leaseExpirations.AddCallCount() > 0 }, true, "the leadership of p2 should expire")
+	p0.Stop()
+	p1.Stop()
+}
+
 func TestYield(t *testing.T) {
 	t.Parallel()
 	// Scenario: Peers spawn and a leader is elected.
@@ -451,6 +498,13 @@ func TestConfigFromFile(t *testing.T) {
 	assert.Equal(t, time.Second*10, getLeaderAliveThreshold())
 	assert.Equal(t, time.Second*5, getLeaderElectionDuration())
 	assert.Equal(t, getLeaderAliveThreshold()/2, getLeadershipDeclarationInterval())
+	assert.Empty(t, getPreferredLeaders())
+
+	// Verify the lease renewal interval has to be shorter than the leader alive threshold
+	SetLeaseRenewalInterval(time.Second * 2)
+	assert.Equal(t, time.Second*2, getLeadershipDeclarationInterval())
+	SetLeaseRenewalInterval(time.Second * 10)
+	assert.Equal(t, time.Second*5, getLeadershipDeclarationInterval())
 
 	//Verify reading the values from config file
 	viper.Reset()
@@ -465,7 +519,8 @@ func TestConfigFromFile(t *testing.T) {
 	assert.Equal(t, time.Second, getMembershipSampleInterval())
 	assert.Equal(t, time.Second*10, getLeaderAliveThreshold())
 	assert.Equal(t, time.Second*5, getLeaderElectionDuration())
-	assert.Equal(t, getLeaderAliveThreshold()/2, getLeadershipDeclarationInterval())
+	assert.Equal(t, time.Second*5, getLeadershipDeclarationInterval())
+	assert.Empty(t, getPreferredLeaders())
 }
 
 func waitForBoolFunc(t *testing.T, f func() bool, expectedValue bool, msgAndArgs ...interface{}) {
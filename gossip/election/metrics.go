@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package election
+
+import "github.com/hyperledger/fabric/common/metrics"
+
+var (
+	leaderGaugeOpts = metrics.GaugeOpts{
+		Namespace:    "gossip",
+		Subsystem:    "leader_election",
+		Name:         "leader",
+		Help:         "Whether the peer is the leader of its organization on the channel (1) or not (0).",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	leaseExpirationsCounterOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "leader_election",
+		Name:         "lease_expirations",
+		Help:         "The number of times no leadership declaration was received within the leader alive threshold.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	preemptionsCounterOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "leader_election",
+		Name:         "preemptions",
+		Help:         "The number of times the peer relinquished the leadership to a better candidate.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+)
+
+// Metrics are the metrics of the leader election services.
+type Metrics struct {
+	Leader           metrics.Gauge
+	LeaseExpirations metrics.Counter
+	Preemptions      metrics.Counter
+}
+
+func NewMetrics(p metrics.Provider) *Metrics {
+	return &Metrics{
+		Leader:           p.NewGauge(leaderGaugeOpts),
+		LeaseExpirations: p.NewCounter(leaseExpirationsCounterOpts),
+		Preemptions:      p.NewCounter(preemptionsCounterOpts),
+	}
+}
+
+// ForChannel returns the metrics of the leader election service of the
+// given channel.
+func (m *Metrics) ForChannel(channel string) *Metrics {
+	if m == nil {
+		return nil
+	}
+	return &Metrics{
+		Leader:           m.Leader.With("channel", channel),
+		LeaseExpirations: m.LeaseExpirations.With("channel", channel),
+		Preemptions:      m.Preemptions.With("channel", channel),
+	}
+}
+
+func (m *Metrics) leader(isLeader bool) {
+	if m == nil {
+		return
+	}
+	if isLeader {
+		m.Leader.Set(1)
+	} else {
+		m.Leader.Set(0)
+	}
+}
+
+func (m *Metrics) leaseExpired() {
+	if m == nil {
+		return
+	}
+	m.LeaseExpirations.Add(1)
+}
+
+func (m *Metrics) preempted() {
+	if m == nil {
+		return
+	}
+	m.Preemptions.Add(1)
+}
@@ -41,6 +41,7 @@ type Config struct {
 	BlockExpirationInterval     time.Duration
 	StateInfoCacheSweepInterval time.Duration
 	TimeForMembershipTracker    time.Duration
+	QueryOnly                   bool
 }
 
 // GossipChannel defines an object that deals with all channel-related messages
@@ -869,6 +870,7 @@ func (gc *gossipChannel) updateProperties(ledgerHeight uint64, chaincodes []*pro
 			LeftChannel:  leftChannel,
 			LedgerHeight: ledgerHeight,
 			Chaincodes:   chaincodes,
+			QueryOnly:    gc.GetConf().QueryOnly,
 		},
 	}
 	m := &proto.GossipMessage{
@@ -312,6 +312,32 @@ func TestSelf(t *testing.T) {
 	assert.Equal(t, gMsg.GetStateInfo().PkiId, []byte("1"))
 }
 
+func TestSelfQueryOnly(t *testing.T) {
+	t.Parallel()
+
+	cs := &cryptoService{}
+	pkiID1 := common.PKIidType("1")
+	jcm := &joinChanMsg{
+		members2AnchorPeers: map[string][]api.AnchorPeer{
+			string(orgInChannelA): {},
+		},
+	}
+	queryOnlyConf := conf
+	queryOnlyConf.QueryOnly = true
+	adapter := new(gossipAdapterMock)
+	adapter.On("GetConf").Return(queryOnlyConf)
+	configureAdapter(adapter)
+	adapter.On("Gossip", mock.Anything)
+	gc := NewGossipChannel(pkiID1, orgInChannelA, cs, channelA, adapter, jcm)
+	gc.UpdateLedgerHeight(1)
+	assert.True(t, gc.Self().GetStateInfo().Properties.QueryOnly)
+
+	// the role is kept when the chaincodes are updated
+	gc.UpdateChaincodes([]*proto.Chaincode{{Name: "mycc", Version: "1.0"}})
+	assert.True(t, gc.Self().GetStateInfo().Properties.QueryOnly)
+	assert.Equal(t, uint64(1), gc.Self().GetStateInfo().Properties.LedgerHeight)
+}
+
 func TestMsgStoreNotExpire(t *testing.T) {
 	t.Parallel()
 
@@ -125,6 +125,7 @@ func (ga *gossipAdapterImpl) GetConf() channel.Config {
 		BlockExpirationInterval:     ga.conf.PullInterval * 100,
 		StateInfoCacheSweepInterval: ga.conf.PullInterval * 5,
 		TimeForMembershipTracker:    ga.conf.TimeForMembershipTracker,
+		QueryOnly:                   ga.conf.QueryOnly,
 	}
 }
 
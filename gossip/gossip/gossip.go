@@ -134,4 +134,8 @@ type Config struct {
 	InternalEndpoint         string        // Endpoint we publish to peers in our organization
 	ExternalEndpoint         string        // Peer publishes this endpoint instead of SelfEndpoint to foreign organizations
 	TimeForMembershipTracker time.Duration // Determines time for polling with membershipTracker
+
+	AdvertisedEndpoints []*proto.AdvertisedEndpoint // Additional endpoints published to foreign organizations
+
+	QueryOnly bool // Peer advertises it only commits blocks and serves queries, without endorsing
 }
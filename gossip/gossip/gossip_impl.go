@@ -111,13 +111,13 @@ func NewGossipService(conf *Config, s *grpc.Server, sa api.SecurityAdvisor,
 
 	g.discAdapter = g.newDiscoveryAdapter()
 	g.disSecAdap = g.newDiscoverySecurityAdapter()
-	g.disc = discovery.NewDiscoveryService(g.selfNetworkMember(), g.discAdapter, g.disSecAdap, g.disclosurePolicy)
+	g.disc = discovery.NewDiscoveryService(g.selfNetworkMember(), g.discAdapter, g.disSecAdap, g.disclosurePolicy, g.selectEndpoint)
 	g.logger.Infof("Creating gossip service with self membership of %s", g.selfNetworkMember())
 
 	g.certPuller = g.createCertStorePuller()
 	g.certStore = newCertStore(g.certPuller, g.idMapper, selfIdentity, mcs)
 
-	if g.conf.ExternalEndpoint == "" {
+	if g.conf.ExternalEndpoint == "" && len(g.conf.AdvertisedEndpoints) == 0 {
 		g.logger.Warning("External endpoint is empty, peer will not be accessible outside of its organization")
 	}
 	// Adding delta for handlePresumedDead and
@@ -141,10 +141,11 @@ func (g *gossipServiceImpl) newStateInfoMsgStore() msgstore.MessageStore {
 
 func (g *gossipServiceImpl) selfNetworkMember() discovery.NetworkMember {
 	self := discovery.NetworkMember{
-		Endpoint:         g.conf.ExternalEndpoint,
-		PKIid:            g.comm.GetPKIid(),
-		Metadata:         []byte{},
-		InternalEndpoint: g.conf.InternalEndpoint,
+		Endpoint:            g.conf.ExternalEndpoint,
+		PKIid:               g.comm.GetPKIid(),
+		Metadata:            []byte{},
+		InternalEndpoint:    g.conf.InternalEndpoint,
+		AdvertisedEndpoints: g.conf.AdvertisedEndpoints,
 	}
 	if g.disc != nil {
 		self.Metadata = g.disc.Self().Metadata
@@ -240,8 +241,8 @@ func (g *gossipServiceImpl) learnAnchorPeers(channel string, orgOfAnchorPeers ap
 		}
 
 		inOurOrg := bytes.Equal(g.selfOrg, orgOfAnchorPeers)
-		if !inOurOrg && g.selfNetworkMember().Endpoint == "" {
-			g.logger.Infof("Anchor peer %s:%d isn't in our org(%v) and we have no external endpoint, skipping", ap.Host, ap.Port, string(orgOfAnchorPeers))
+		if !inOurOrg && !g.advertisesEndpointTo(orgOfAnchorPeers) {
+			g.logger.Infof("Anchor peer %s:%d isn't in our org(%v) and we have no external endpoint for it, skipping", ap.Host, ap.Port, string(orgOfAnchorPeers))
 			continue
 		}
 		identifier := func() (*discovery.PeerIdentification, error) {
@@ -473,7 +474,7 @@ func (g *gossipServiceImpl) gossipBatch(msgs []*emittedGossipMessage) {
 			return false
 		}
 		member := msg.GetAliveMsg().Membership
-		return member.Endpoint == "" && g.isInMyorg(discovery.NetworkMember{PKIid: member.PkiId})
+		return member.Endpoint == "" && len(member.AdvertisedEndpoints) == 0 && g.isInMyorg(discovery.NetworkMember{PKIid: member.PkiId})
 	}
 	isOrgRestricted := func(o interface{}) bool {
 		return aliveMsgsWithNoEndpointAndInOurOrg(o) || o.(*emittedGossipMessage).IsOrgRestricted()
@@ -715,6 +716,12 @@ func (g *gossipServiceImpl) Peers() []discovery.NetworkMember {
 	return g.disc.GetMembership()
 }
 
+// DeadPeers returns the NetworkMembers considered dead, which are
+// forgotten once their membership expires
+func (g *gossipServiceImpl) DeadPeers() []discovery.NetworkMember {
+	return g.disc.GetDeadMembership()
+}
+
 // PeersOfChannel returns the NetworkMembers considered alive
 // and also subscribed to the channel given
 func (g *gossipServiceImpl) PeersOfChannel(channel common.ChainID) []discovery.NetworkMember {
@@ -1173,6 +1180,18 @@ func (g *gossipServiceImpl) connect2BootstrapPeers() {
 
 }
 
+// selectEndpoint returns the endpoint through which peers of our org reach the given member
+func (g *gossipServiceImpl) selectEndpoint(member *proto.Member) string {
+	return discovery.SelectEndpoint(member, string(g.selfOrg))
+}
+
+// advertisesEndpointTo returns whether we publish an endpoint to peers of the given org
+func (g *gossipServiceImpl) advertisesEndpointTo(org api.OrgIdentityType) bool {
+	self := g.selfNetworkMember()
+	member := &proto.Member{Endpoint: self.Endpoint, AdvertisedEndpoints: self.AdvertisedEndpoints}
+	return discovery.SelectEndpoint(member, string(org)) != ""
+}
+
 func (g *gossipServiceImpl) hasExternalEndpoint(PKIID common.PKIidType) bool {
 	if nm := g.disc.Lookup(PKIID); nm != nil {
 		return nm.Endpoint != ""
@@ -1261,8 +1280,9 @@ func (g *gossipServiceImpl) disclosurePolicy(remotePeer *discovery.NetworkMember
 			}
 
 			// Pass the alive message only if the alive message is in the same org as the remote peer
-			// or the message has an external endpoint, and the remote peer also has one
-			return bytes.Equal(org, remotePeerOrg) || msg.GetAliveMsg().Membership.Endpoint != "" && remotePeer.Endpoint != ""
+			// or the message has an external endpoint advertised to the remote peer's org, and the remote peer also has one
+			advertisedEndpoint := discovery.SelectEndpoint(msg.GetAliveMsg().Membership, string(remotePeerOrg))
+			return bytes.Equal(org, remotePeerOrg) || advertisedEndpoint != "" && remotePeer.Endpoint != ""
 		}, func(msg *proto.SignedGossipMessage) *proto.Envelope {
 			envelope := protoG.Clone(msg.Envelope).(*proto.Envelope)
 			if !bytes.Equal(g.selfOrg, remotePeerOrg) {
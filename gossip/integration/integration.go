@@ -15,6 +15,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/gossip"
 	"github.com/hyperledger/fabric/gossip/util"
+	proto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
@@ -53,6 +54,7 @@ func newConfig(selfEndpoint string, externalEndpoint string, certs *common.TLSCe
 		SkipBlockVerification:      viper.GetBool("peer.gossip.skipBlockVerification"),
 		TLSCerts:                   certs,
 		TimeForMembershipTracker:   util.GetDurationOrDefault("peer.gossip.membershipTrackerInterval", 5*time.Second),
+		QueryOnly:                  viper.GetBool("peer.queryOnly"),
 	}
 
 	return conf, nil
@@ -69,8 +71,43 @@ func NewGossipComponent(peerIdentity []byte, endpoint string, s *grpc.Server,
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	conf.AdvertisedEndpoints, err = advertisedEndpoints()
+	if err != nil {
+		return nil, err
+	}
 	gossipInstance := gossip.NewGossipService(conf, s, secAdv, cryptSvc,
 		peerIdentity, secureDialOpts)
 
 	return gossipInstance, nil
 }
+
+// advertisedEndpoint is the configuration of an endpoint published to foreign organizations
+type advertisedEndpoint struct {
+	Endpoint   string
+	Priority   int32
+	Deprecated bool
+	Orgs       []string
+}
+
+// advertisedEndpoints reads the additional endpoints the peer publishes to foreign organizations
+func advertisedEndpoints() ([]*proto.AdvertisedEndpoint, error) {
+	var configured []advertisedEndpoint
+	err := viper.UnmarshalKey("peer.gossip.advertisedEndpoints", &configured)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed parsing peer.gossip.advertisedEndpoints")
+	}
+
+	var endpoints []*proto.AdvertisedEndpoint
+	for _, e := range configured {
+		if _, _, err := net.SplitHostPort(e.Endpoint); err != nil {
+			return nil, errors.Wrapf(err, "misconfigured advertised endpoint %s", e.Endpoint)
+		}
+		endpoints = append(endpoints, &proto.AdvertisedEndpoint{
+			Endpoint:   e.Endpoint,
+			Priority:   e.Priority,
+			Deprecated: e.Deprecated,
+			Orgs:       e.Orgs,
+		})
+	}
+	return endpoints, nil
+}
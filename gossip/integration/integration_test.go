@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/msp/mgmt/testtools"
+	proto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
@@ -69,6 +70,29 @@ func TestNewGossipCryptoService(t *testing.T) {
 	go s3.Serve(ll3)
 }
 
+func TestAdvertisedEndpoints(t *testing.T) {
+	defer viper.Set("peer.gossip.advertisedEndpoints", nil)
+
+	endpoints, err := advertisedEndpoints()
+	assert.NoError(t, err)
+	assert.Empty(t, endpoints)
+
+	viper.Set("peer.gossip.advertisedEndpoints", []map[string]interface{}{
+		{"endpoint": "gateway.org1.example.com:443", "priority": 10, "orgs": []string{"Org2MSP"}},
+		{"endpoint": "old.org1.example.com:7051", "deprecated": true},
+	})
+	endpoints, err = advertisedEndpoints()
+	assert.NoError(t, err)
+	assert.Equal(t, []*proto.AdvertisedEndpoint{
+		{Endpoint: "gateway.org1.example.com:443", Priority: 10, Orgs: []string{"Org2MSP"}},
+		{Endpoint: "old.org1.example.com:7051", Deprecated: true},
+	}, endpoints)
+
+	viper.Set("peer.gossip.advertisedEndpoints", []map[string]interface{}{{"endpoint": "no-port"}})
+	_, err = advertisedEndpoints()
+	assert.Contains(t, err.Error(), "misconfigured advertised endpoint no-port")
+}
+
 func setupTestEnv() {
 	viper.SetConfigName("core")
 	viper.SetEnvPrefix("CORE")
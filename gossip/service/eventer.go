@@ -112,3 +112,9 @@ func (ag *appGrp) MSPID() string {
 func (ag *appGrp) AnchorPeers() []*peer.AnchorPeer {
 	return ag.anchorPeers
 }
+
+// TokenEncryptionKey returns nil, as the token encryption keys of the orgs
+// are of no concern to gossip
+func (ag *appGrp) TokenEncryptionKey() []byte {
+	return nil
+}
@@ -0,0 +1,130 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"time"
+
+	gossipCommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/spf13/viper"
+)
+
+// staticLeaderFailover switches a peer that relies on the static leaders of
+// its organization to pull the blocks of a channel to the leader election of
+// the channel while none of the static leaders is reachable, and back once
+// one of them is reachable again.
+type staticLeaderFailover struct {
+	chainID string
+	// timeout is how long the static leaders have to be unreachable
+	// before the peer switches to the leader election
+	timeout time.Duration
+	// interval is how often the reachability of the static leaders is checked
+	interval time.Duration
+	// reachable returns whether one of the static leaders is reachable
+	reachable func() bool
+	// startElection and stopElection start and stop the leader election
+	// of the channel
+	startElection func()
+	stopElection  func()
+	metrics       *Metrics
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+func (f *staticLeaderFailover) run() {
+	defer close(f.doneCh)
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	lastSeen := time.Now()
+	failedOver := false
+	for {
+		select {
+		case <-f.stopCh:
+			return
+		case <-ticker.C:
+		}
+
+		if f.reachable() {
+			lastSeen = time.Now()
+			if failedOver {
+				logger.Infof("A static leader of channel %s is reachable again, stopping leader election", f.chainID)
+				f.stopElection()
+				failedOver = false
+				f.metrics.StaticLeaderFailover.With("channel", f.chainID).Set(0)
+			}
+			continue
+		}
+		if !failedOver && time.Since(lastSeen) >= f.timeout {
+			logger.Warningf("No static leader of channel %s reachable for %s, starting leader election", f.chainID, f.timeout)
+			f.startElection()
+			failedOver = true
+			f.metrics.StaticLeaderFailover.With("channel", f.chainID).Set(1)
+			f.metrics.StaticLeaderFailovers.With("channel", f.chainID).Add(1)
+		}
+	}
+}
+
+// Stop stops monitoring the static leaders. The leader election started by
+// the failover, if any, is left running.
+func (f *staticLeaderFailover) Stop() {
+	close(f.stopCh)
+	<-f.doneCh
+}
+
+// newStaticLeaderFailover starts monitoring the static leaders of the
+// channel, or returns nil when no static leaders are configured.
+func (g *gossipServiceImpl) newStaticLeaderFailover(chainID string, callback func(bool)) *staticLeaderFailover {
+	leaders := getStaticLeaders()
+	if len(leaders) == 0 {
+		return nil
+	}
+	f := &staticLeaderFailover{
+		chainID:  chainID,
+		timeout:  util.GetDurationOrDefault("peer.gossip.staticLeaderFailover.timeout", 30*time.Second),
+		interval: util.GetDurationOrDefault("peer.gossip.election.membershipSampleInterval", time.Second),
+		reachable: func() bool {
+			for _, member := range g.PeersOfChannel(gossipCommon.ChainID(chainID)) {
+				for _, leader := range leaders {
+					if leader == member.Endpoint || leader == member.InternalEndpoint {
+						return true
+					}
+				}
+			}
+			return false
+		},
+		startElection: func() {
+			g.lock.Lock()
+			defer g.lock.Unlock()
+			g.leaderElection[chainID] = g.newLeaderElectionComponent(chainID, callback)
+		},
+		stopElection: func() {
+			g.lock.Lock()
+			le := g.leaderElection[chainID]
+			delete(g.leaderElection, chainID)
+			g.lock.Unlock()
+			if le == nil {
+				return
+			}
+			le.Stop()
+			if le.IsLeader() {
+				callback(false)
+			}
+		},
+		metrics: getMetrics(),
+		stopCh:  make(chan struct{}),
+		doneCh:  make(chan struct{}),
+	}
+	go f.run()
+	return f
+}
+
+func getStaticLeaders() []string {
+	return viper.GetStringSlice("peer.gossip.staticLeaderFailover.leaders")
+}
@@ -0,0 +1,68 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticLeaderFailover(t *testing.T) {
+	var reachable, started, stopped int32
+	gauge := &metricsfakes.Gauge{}
+	gauge.WithReturns(gauge)
+	counter := &metricsfakes.Counter{}
+	counter.WithReturns(counter)
+
+	f := &staticLeaderFailover{
+		chainID:       "testchannel",
+		timeout:       200 * time.Millisecond,
+		interval:      10 * time.Millisecond,
+		reachable:     func() bool { return atomic.LoadInt32(&reachable) == 1 },
+		startElection: func() { atomic.AddInt32(&started, 1) },
+		stopElection:  func() { atomic.AddInt32(&stopped, 1) },
+		metrics:       &Metrics{StaticLeaderFailover: gauge, StaticLeaderFailovers: counter},
+		stopCh:        make(chan struct{}),
+		doneCh:        make(chan struct{}),
+	}
+	waitFor := func(cond func() bool) bool {
+		for end := time.Now().Add(time.Second); time.Now().Before(end); time.Sleep(10 * time.Millisecond) {
+			if cond() {
+				return true
+			}
+		}
+		return false
+	}
+	atomic.StoreInt32(&reachable, 1)
+	go f.run()
+
+	// The static leader is reachable
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&started))
+
+	// The static leader is unreachable for longer than the timeout
+	atomic.StoreInt32(&reachable, 0)
+	assert.True(t, waitFor(func() bool { return atomic.LoadInt32(&started) == 1 }))
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&started), "leader election should be started once")
+	assert.Equal(t, 1, counter.AddCallCount())
+	assert.Equal(t, []string{"channel", "testchannel"}, counter.WithArgsForCall(0))
+	assert.Equal(t, float64(1), gauge.SetArgsForCall(0))
+
+	// The static leader is reachable again
+	atomic.StoreInt32(&reachable, 1)
+	assert.True(t, waitFor(func() bool { return atomic.LoadInt32(&stopped) == 1 }))
+	assert.Equal(t, float64(0), gauge.SetArgsForCall(1))
+
+	f.Stop()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&started))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&stopped))
+}
@@ -50,6 +50,8 @@ type GossipService interface {
 	InitializeChannel(chainID string, endpoints []string, support Support)
 	// AddPayload appends message payload to for given chain
 	AddPayload(chainID string, payload *gproto.Payload) error
+	// Membership returns the current view of the gossip network of the peer
+	Membership() *Membership
 }
 
 // DeliveryServiceFactory factory to create and initialize delivery service instance
@@ -89,9 +91,11 @@ type gossipServiceImpl struct {
 	privateHandlers map[string]privateHandler
 	chains          map[string]state.GossipStateProvider
 	leaderElection  map[string]election.LeaderElectionService
+	failovers       map[string]*staticLeaderFailover
 	deliveryService map[string]deliverclient.DeliverService
 	deliveryFactory DeliveryServiceFactory
 	lock            sync.RWMutex
+	stopOnce        sync.Once
 	mcs             api.MessageCryptoService
 	peerIdentity    []byte
 	secAdv          api.SecurityAdvisor
@@ -158,6 +162,7 @@ func InitGossipServiceCustomDeliveryFactory(peerIdentity []byte, endpoint string
 			privateHandlers: make(map[string]privateHandler),
 			chains:          make(map[string]state.GossipStateProvider),
 			leaderElection:  make(map[string]election.LeaderElectionService),
+			failovers:       make(map[string]*staticLeaderFailover),
 			deliveryService: make(map[string]deliverclient.DeliverService),
 			deliveryFactory: factory,
 			peerIdentity:    peerIdentity,
@@ -262,7 +267,7 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, endpoints []string
 	}
 	g.privateHandlers[chainID].reconciler.Start()
 
-	g.chains[chainID] = state.NewGossipStateProvider(chainID, servicesAdapter, coordinator)
+	g.chains[chainID] = state.NewGossipStateProvider(chainID, servicesAdapter, coordinator, getMetrics().State)
 	if g.deliveryService[chainID] == nil {
 		var err error
 		g.deliveryService[chainID], err = g.deliveryFactory.Service(g, endpoints, g.mcs)
@@ -295,6 +300,9 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, endpoints []string
 			g.deliveryService[chainID].StartDeliverForChannel(chainID, support.Committer, func() {})
 		} else {
 			logger.Debug("This peer is not configured to connect to ordering service for blocks delivery, channel", chainID)
+			if f := g.newStaticLeaderFailover(chainID, g.onStatusChangeFactory(chainID, support.Committer)); f != nil {
+				g.failovers[chainID] = f
+			}
 		}
 	} else {
 		logger.Warning("Delivery client is down won't be able to pull blocks for chain", chainID)
@@ -358,8 +366,25 @@ func (g *gossipServiceImpl) AddPayload(chainID string, payload *gproto.Payload)
 	return g.chains[chainID].AddPayload(payload)
 }
 
-// Stop stops the gossip component
+// Stop stops the gossip component. It stops the commit of blocks, once the
+// blocks being committed are committed, and closes the ledgers of the
+// channels. Calls after the first one have no effect.
 func (g *gossipServiceImpl) Stop() {
+	g.stopOnce.Do(g.stop)
+}
+
+func (g *gossipServiceImpl) stop() {
+	// The failovers take the lock when switching to or from leader election
+	g.lock.RLock()
+	var failovers []*staticLeaderFailover
+	for _, f := range g.failovers {
+		failovers = append(failovers, f)
+	}
+	g.lock.RUnlock()
+	for _, f := range failovers {
+		f.Stop()
+	}
+
 	g.lock.Lock()
 	defer g.lock.Unlock()
 
@@ -382,7 +407,7 @@ func (g *gossipServiceImpl) Stop() {
 func (g *gossipServiceImpl) newLeaderElectionComponent(chainID string, callback func(bool)) election.LeaderElectionService {
 	PKIid := g.mcs.GetPKIidOfCert(g.peerIdentity)
 	adapter := election.NewAdapter(g, PKIid, gossipCommon.ChainID(chainID))
-	return election.NewLeaderElectionService(adapter, string(PKIid), callback)
+	return election.NewLeaderElectionService(adapter, string(PKIid), callback, getMetrics().Election.ForChannel(chainID))
 }
 
 func (g *gossipServiceImpl) amIinChannel(myOrg string, config Config) bool {
@@ -401,7 +426,10 @@ func (g *gossipServiceImpl) onStatusChangeFactory(chainID string, committer bloc
 				g.lock.RLock()
 				le := g.leaderElection[chainID]
 				g.lock.RUnlock()
-				le.Yield()
+				// The leader election may have been stopped by a static leader failover
+				if le != nil {
+					le.Yield()
+				}
 			}
 			logger.Info("Elected as a leader, starting delivery service for channel", chainID)
 			if err := g.deliveryService[chainID].StartDeliverForChannel(chainID, committer, yield); err != nil {
@@ -79,7 +79,7 @@ func TestInitGossipService(t *testing.T) {
 	for i := 0; i < 10; i++ {
 		go func() {
 			defer wg.Done()
-			messageCryptoService := peergossip.NewMCS(&mocks.ChannelPolicyManagerGetter{}, localmsp.NewSigner(), mgmt.NewDeserializersManager())
+			messageCryptoService := peergossip.NewMCS(&mocks.ChannelPolicyManagerGetter{}, localmsp.NewSigner(), mgmt.NewDeserializersManager(), nil)
 			secAdv := peergossip.NewSecurityAdvisor(mgmt.NewDeserializersManager())
 			err := InitGossipService(identity, "localhost:5611", grpcServer, nil, messageCryptoService,
 				secAdv, nil)
@@ -224,6 +224,14 @@ func TestWithStaticDeliverClientLeader(t *testing.T) {
 		assert.True(t, gossips[i].(*gossipServiceImpl).deliveryService[channelName].(*mockDeliverService).running[channelName], "Block deliverer not started for peer %d", i)
 	}
 
+	membership := gossips[0].Membership()
+	assert.Equal(t, "1.2.3.4:20200", membership.Self.Endpoint)
+	assert.Equal(t, string(orgInChannelA), membership.Self.MSPID)
+	assert.Empty(t, membership.Dead)
+	assert.Len(t, membership.Channels, 2)
+	assert.True(t, membership.Channels["chanA"].Leader)
+	assert.True(t, membership.Channels["chanB"].Leader)
+
 	stopPeers(gossips)
 }
 
@@ -264,6 +272,8 @@ func TestWithStaticDeliverClientNotLeader(t *testing.T) {
 		assert.False(t, gossips[i].(*gossipServiceImpl).deliveryService[channelName].(*mockDeliverService).running[channelName], "Block deliverer should not be started for peer %d", i)
 	}
 
+	assert.False(t, gossips[0].Membership().Channels[channelName].Leader)
+
 	stopPeers(gossips)
 }
 
@@ -677,6 +687,7 @@ func newGossipInstance(portPrefix int, id int, maxMsgCount int, boot ...int) Gos
 		gossipSvc:       gossip,
 		chains:          make(map[string]state.GossipStateProvider),
 		leaderElection:  make(map[string]election.LeaderElectionService),
+		failovers:       make(map[string]*staticLeaderFailover),
 		privateHandlers: make(map[string]privateHandler),
 		deliveryService: make(map[string]deliverclient.DeliverService),
 		deliveryFactory: &deliveryFactoryImpl{},
@@ -756,6 +767,13 @@ func (*naiveCryptoService) Verify(peerIdentity api.PeerIdentityType, signature,
 
 var orgInChannelA = api.OrgIdentityType("ORG1")
 
+func TestStopTwice(t *testing.T) {
+	// The peer stops gossip when it shuts down, and again when it exits
+	g := newGossipInstance(20600, 0, 100)
+	g.Stop()
+	assert.NotPanics(t, g.Stop)
+}
+
 func TestInvalidInitialization(t *testing.T) {
 	// Test whenever gossip service is indeed singleton
 	grpcServer := grpc.NewServer()
@@ -128,6 +128,10 @@ func (ao *appOrgMock) AnchorPeers() []*peer.AnchorPeer {
 	return []*peer.AnchorPeer{}
 }
 
+func (ao *appOrgMock) TokenEncryptionKey() []byte {
+	return nil
+}
+
 type configMock struct {
 	orgs2AppOrgs map[string]channelconfig.ApplicationOrg
 }
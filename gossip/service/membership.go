@@ -0,0 +1,108 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"sort"
+
+	gossipCommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/spf13/viper"
+)
+
+// PeerInfo describes a member of the gossip network.
+type PeerInfo struct {
+	Endpoint         string `json:"endpoint"`
+	InternalEndpoint string `json:"internalEndpoint,omitempty"`
+	PKIID            string `json:"pkiId"`
+	MSPID            string `json:"mspId,omitempty"`
+	// LedgerHeight is the ledger height the peer advertises on a channel
+	LedgerHeight uint64 `json:"ledgerHeight,omitempty"`
+}
+
+// ChannelMembership describes the view of the peer on a channel it has joined.
+type ChannelMembership struct {
+	// Leader tells whether the peer pulls the blocks of the channel from
+	// the ordering service on behalf of its organization
+	Leader bool `json:"leader"`
+	// LedgerHeight is the ledger height the peer advertises on the channel
+	LedgerHeight uint64     `json:"ledgerHeight"`
+	Peers        []PeerInfo `json:"peers"`
+}
+
+// Membership is the view of the gossip network of the peer.
+type Membership struct {
+	Self     PeerInfo                      `json:"self"`
+	Alive    []PeerInfo                    `json:"alive"`
+	Dead     []PeerInfo                    `json:"dead"`
+	Channels map[string]*ChannelMembership `json:"channels"`
+}
+
+// deadPeersProvider is implemented by the gossip instances that track the
+// peers they consider dead.
+type deadPeersProvider interface {
+	DeadPeers() []discovery.NetworkMember
+}
+
+// Membership returns the current view of the gossip network of the peer.
+func (g *gossipServiceImpl) Membership() *Membership {
+	identities := g.IdentityInfo().ByID()
+	peerInfo := func(member discovery.NetworkMember) PeerInfo {
+		info := PeerInfo{
+			Endpoint:         member.Endpoint,
+			InternalEndpoint: member.InternalEndpoint,
+			PKIID:            member.PKIid.String(),
+			MSPID:            string(identities[string(member.PKIid)].Organization),
+		}
+		if member.Properties != nil {
+			info.LedgerHeight = member.Properties.LedgerHeight
+		}
+		return info
+	}
+	peerInfos := func(members []discovery.NetworkMember) []PeerInfo {
+		infos := []PeerInfo{}
+		for _, member := range members {
+			infos = append(infos, peerInfo(member))
+		}
+		sort.Slice(infos, func(i, j int) bool { return infos[i].Endpoint < infos[j].Endpoint })
+		return infos
+	}
+
+	membership := &Membership{
+		Self:     peerInfo(g.SelfMembershipInfo()),
+		Alive:    peerInfos(g.Peers()),
+		Dead:     []PeerInfo{},
+		Channels: map[string]*ChannelMembership{},
+	}
+	if dp, ok := g.gossipSvc.(deadPeersProvider); ok {
+		membership.Dead = peerInfos(dp.DeadPeers())
+	}
+
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	for chainID := range g.chains {
+		channel := &ChannelMembership{
+			Leader: g.isLeader(chainID),
+			Peers:  peerInfos(g.PeersOfChannel(gossipCommon.ChainID(chainID))),
+		}
+		if msg := g.SelfChannelInfo(gossipCommon.ChainID(chainID)); msg != nil {
+			channel.LedgerHeight = msg.GetStateInfo().GetProperties().GetLedgerHeight()
+		}
+		membership.Channels[chainID] = channel
+	}
+	return membership
+}
+
+// isLeader tells whether the peer pulls the blocks of the channel from the
+// ordering service, either as the elected or as the static leader of its
+// organization. It must be called with the lock held.
+func (g *gossipServiceImpl) isLeader(chainID string) bool {
+	if le, ok := g.leaderElection[chainID]; ok {
+		return le.IsLeader()
+	}
+	return g.deliveryService[chainID] != nil && viper.GetBool("peer.gossip.orgLeader")
+}
@@ -0,0 +1,60 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+)
+
+// MembershipHandler serves the view of the gossip network of the peer. GET
+// requests return the alive and dead peers and the membership of every
+// channel the peer has joined, or only of the channel passed in the channel
+// query parameter.
+type MembershipHandler struct {
+	// Membership returns the current view of the gossip network of the peer
+	Membership func() *Membership
+	Logger     *flogging.FabricLogger
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (h *MembershipHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		h.sendResponse(resp, http.StatusBadRequest, fmt.Errorf("invalid request method: %s", req.Method))
+		return
+	}
+
+	membership := h.Membership()
+	if channel := req.URL.Query().Get("channel"); channel != "" {
+		channelMembership, ok := membership.Channels[channel]
+		if !ok {
+			h.sendResponse(resp, http.StatusNotFound, errors.Errorf("channel %s not found", channel))
+			return
+		}
+		membership.Channels = map[string]*ChannelMembership{channel: channelMembership}
+	}
+	h.sendResponse(resp, http.StatusOK, membership)
+}
+
+func (h *MembershipHandler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	if err, ok := payload.(error); ok {
+		payload = &errorResponse{Error: err.Error()}
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+	if err := json.NewEncoder(resp).Encode(payload); err != nil {
+		h.Logger.Errorw("failed to encode payload", "error", err)
+	}
+}
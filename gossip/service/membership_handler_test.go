@@ -0,0 +1,65 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMembershipHandler(t *testing.T) {
+	handler := &MembershipHandler{
+		Membership: func() *Membership {
+			return &Membership{
+				Self:  PeerInfo{Endpoint: "peer0:7051", PKIID: "0a", MSPID: "Org1MSP"},
+				Alive: []PeerInfo{{Endpoint: "peer1:7051", PKIID: "0b", MSPID: "Org1MSP"}},
+				Dead:  []PeerInfo{{Endpoint: "peer2:7051", PKIID: "0c", MSPID: "Org2MSP"}},
+				Channels: map[string]*ChannelMembership{
+					"channel-1": {Leader: true, LedgerHeight: 5, Peers: []PeerInfo{{Endpoint: "peer1:7051", PKIID: "0b", MSPID: "Org1MSP", LedgerHeight: 4}}},
+					"channel-2": {LedgerHeight: 1, Peers: []PeerInfo{}},
+				},
+			}
+		},
+		Logger: flogging.MustGetLogger("test"),
+	}
+
+	peers := `"self":{"endpoint":"peer0:7051","pkiId":"0a","mspId":"Org1MSP"},` +
+		`"alive":[{"endpoint":"peer1:7051","pkiId":"0b","mspId":"Org1MSP"}],` +
+		`"dead":[{"endpoint":"peer2:7051","pkiId":"0c","mspId":"Org2MSP"}]`
+	channel1 := `"channel-1":{"leader":true,"ledgerHeight":5,"peers":[{"endpoint":"peer1:7051","pkiId":"0b","mspId":"Org1MSP","ledgerHeight":4}]}`
+	channel2 := `"channel-2":{"leader":false,"ledgerHeight":1,"peers":[]}`
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		code     int
+		response string
+	}{
+		{"all channels", http.MethodGet, "/gossip/membership", http.StatusOK,
+			`{` + peers + `,"channels":{` + channel1 + `,` + channel2 + `}}`},
+		{"one channel", http.MethodGet, "/gossip/membership?channel=channel-2", http.StatusOK,
+			`{` + peers + `,"channels":{` + channel2 + `}}`},
+		{"unknown channel", http.MethodGet, "/gossip/membership?channel=channel-3", http.StatusNotFound,
+			`{"error":"channel channel-3 not found"}`},
+		{"invalid method", http.MethodPost, "/gossip/membership", http.StatusBadRequest,
+			`{"error":"invalid request method: POST"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.target, nil))
+			assert.Equal(t, tt.code, recorder.Code)
+			assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			assert.JSONEq(t, tt.response, recorder.Body.String())
+		})
+	}
+}
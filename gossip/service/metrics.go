@@ -0,0 +1,73 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/gossip/election"
+	"github.com/hyperledger/fabric/gossip/state"
+)
+
+var (
+	staticLeaderFailoverGaugeOpts = metrics.GaugeOpts{
+		Namespace:    "gossip",
+		Subsystem:    "leader_election",
+		Name:         "static_leader_failover",
+		Help:         "Whether the peer runs the leader election on the channel because the static leaders are unreachable (1) or not (0).",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	staticLeaderFailoversCounterOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "leader_election",
+		Name:         "static_leader_failovers",
+		Help:         "The number of times the peer switched to leader election on the channel because the static leaders were unreachable.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+)
+
+// Metrics are the metrics of the leader election, of the static leader
+// failover and of the state providers of the channels.
+type Metrics struct {
+	Election              *election.Metrics
+	State                 *state.Metrics
+	StaticLeaderFailover  metrics.Gauge
+	StaticLeaderFailovers metrics.Counter
+}
+
+func NewMetrics(p metrics.Provider) *Metrics {
+	return &Metrics{
+		Election:              election.NewMetrics(p),
+		State:                 state.NewMetrics(p),
+		StaticLeaderFailover:  p.NewGauge(staticLeaderFailoverGaugeOpts),
+		StaticLeaderFailovers: p.NewCounter(staticLeaderFailoversCounterOpts),
+	}
+}
+
+var (
+	metricsLock    sync.RWMutex
+	serviceMetrics = NewMetrics(&disabled.Provider{})
+)
+
+// ConfigureMetrics sets the provider of the metrics of the gossip service.
+// It is meant to be called once, before the gossip service is initialized,
+// as the metrics are registered with the provider.
+func ConfigureMetrics(p metrics.Provider) {
+	metricsLock.Lock()
+	defer metricsLock.Unlock()
+	serviceMetrics = NewMetrics(p)
+}
+
+func getMetrics() *Metrics {
+	metricsLock.RLock()
+	defer metricsLock.RUnlock()
+	return serviceMetrics
+}
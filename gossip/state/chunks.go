@@ -0,0 +1,156 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"bytes"
+
+	pb "github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/util"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/pkg/errors"
+)
+
+const (
+	// defStateTransferChunkSize is the default maximum size of the payloads
+	// of a state response, well under the maximum gRPC message size.
+	defStateTransferChunkSize = 16 * 1024 * 1024
+
+	// maxChunksPerBlock bounds the memory a responder can make the requester
+	// allocate for the reassembly of a single block.
+	maxChunksPerBlock = 1024
+)
+
+// stateResponses returns the state responses to send back for the given
+// payloads. The payloads fitting in chunkSize are sent in a single response,
+// and the remaining payloads are left for the requester to ask for again. If
+// the first payload alone exceeds chunkSize, it is split into chunks, each
+// sent in its own response.
+func stateResponses(nonce uint64, channel string, payloads []*proto.Payload, chunkSize int) ([]*proto.GossipMessage, error) {
+	size := 0
+	for i, payload := range payloads {
+		size += pb.Size(payload)
+		if size <= chunkSize {
+			continue
+		}
+		if i > 0 {
+			payloads = payloads[:i]
+			break
+		}
+
+		chunks, err := splitPayload(payload, chunkSize)
+		if err != nil {
+			return nil, err
+		}
+		var msgs []*proto.GossipMessage
+		for _, chunk := range chunks {
+			msgs = append(msgs, stateResponseMessage(nonce, channel, &proto.RemoteStateResponse{Chunk: chunk}))
+		}
+		return msgs, nil
+	}
+
+	response := &proto.RemoteStateResponse{Payloads: payloads}
+	if response.Payloads == nil {
+		response.Payloads = make([]*proto.Payload, 0)
+	}
+	return []*proto.GossipMessage{stateResponseMessage(nonce, channel, response)}, nil
+}
+
+func stateResponseMessage(nonce uint64, channel string, response *proto.RemoteStateResponse) *proto.GossipMessage {
+	return &proto.GossipMessage{
+		// Copy nonce field from the request, so it will be possible to match response
+		Nonce:   nonce,
+		Tag:     proto.GossipMessage_CHAN_OR_ORG,
+		Channel: []byte(channel),
+		Content: &proto.GossipMessage_StateResponse{StateResponse: response},
+	}
+}
+
+// splitPayload splits the marshaled payload into chunks of at most chunkSize bytes.
+func splitPayload(payload *proto.Payload, chunkSize int) ([]*proto.BlockChunk, error) {
+	if chunkSize <= 0 {
+		return nil, errors.Errorf("invalid chunk size %d", chunkSize)
+	}
+	data, err := pb.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed marshaling payload of block [%d]", payload.SeqNum)
+	}
+	total := (len(data) + chunkSize - 1) / chunkSize
+	if total > maxChunksPerBlock {
+		return nil, errors.Errorf("block [%d] of %d bytes needs %d chunks, more than the maximum of %d", payload.SeqNum, len(data), total, maxChunksPerBlock)
+	}
+
+	payloadDigest := util.ComputeSHA256(data)
+	chunks := make([]*proto.BlockChunk, 0, total)
+	for i := 0; i < total; i++ {
+		end := (i + 1) * chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		chunkData := data[i*chunkSize : end]
+		chunks = append(chunks, &proto.BlockChunk{
+			SeqNum:        payload.SeqNum,
+			Index:         uint32(i),
+			Total:         uint32(total),
+			Data:          chunkData,
+			Digest:        util.ComputeSHA256(chunkData),
+			PayloadDigest: payloadDigest,
+		})
+	}
+	return chunks, nil
+}
+
+// chunkAssembler reassembles the payload of a block from its chunks.
+type chunkAssembler struct {
+	first    *proto.BlockChunk
+	chunks   [][]byte
+	have     []bool
+	received int
+}
+
+// add adds a chunk to the payload being reassembled, and returns the payload
+// once all its chunks have been received and verified.
+func (a *chunkAssembler) add(chunk *proto.BlockChunk) (*proto.Payload, error) {
+	if chunk.Total == 0 || chunk.Total > maxChunksPerBlock {
+		return nil, errors.Errorf("invalid chunk count %d for block [%d]", chunk.Total, chunk.SeqNum)
+	}
+	if chunk.Index >= chunk.Total {
+		return nil, errors.Errorf("chunk index %d out of range for block [%d] of %d chunks", chunk.Index, chunk.SeqNum, chunk.Total)
+	}
+	if !bytes.Equal(util.ComputeSHA256(chunk.Data), chunk.Digest) {
+		return nil, errors.Errorf("digest mismatch for chunk %d of block [%d]", chunk.Index, chunk.SeqNum)
+	}
+
+	if a.first == nil {
+		a.first = chunk
+		a.chunks = make([][]byte, chunk.Total)
+		a.have = make([]bool, chunk.Total)
+	} else if chunk.SeqNum != a.first.SeqNum || chunk.Total != a.first.Total || !bytes.Equal(chunk.PayloadDigest, a.first.PayloadDigest) {
+		return nil, errors.Errorf("chunk %d of block [%d] does not belong to block [%d] being reassembled", chunk.Index, chunk.SeqNum, a.first.SeqNum)
+	}
+	if !a.have[chunk.Index] {
+		a.chunks[chunk.Index] = chunk.Data
+		a.have[chunk.Index] = true
+		a.received++
+	}
+	if a.received < len(a.chunks) {
+		return nil, nil
+	}
+
+	data := bytes.Join(a.chunks, nil)
+	if !bytes.Equal(util.ComputeSHA256(data), a.first.PayloadDigest) {
+		return nil, errors.Errorf("digest mismatch for reassembled block [%d]", a.first.SeqNum)
+	}
+	payload := &proto.Payload{}
+	if err := pb.Unmarshal(data, payload); err != nil {
+		return nil, errors.Wrapf(err, "failed unmarshaling reassembled block [%d]", a.first.SeqNum)
+	}
+	if payload.SeqNum != a.first.SeqNum {
+		return nil, errors.Errorf("reassembled payload of block [%d] claims sequence %d", a.first.SeqNum, payload.SeqNum)
+	}
+	return payload, nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"math/rand"
+	"testing"
+
+	pb "github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/util"
+	pcomm "github.com/hyperledger/fabric/protos/common"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
+)
+
+func largePayload(t *testing.T, seqNum uint64, size int) *proto.Payload {
+	data := make([]byte, size)
+	rand.Read(data)
+	block := pcomm.NewBlock(seqNum, []byte{})
+	// Pad the block without adding transactions
+	block.Header.DataHash = data
+	blockBytes, err := pb.Marshal(block)
+	assert.NoError(t, err)
+	return &proto.Payload{SeqNum: seqNum, Data: blockBytes}
+}
+
+func TestSplitAndReassemblePayload(t *testing.T) {
+	payload := largePayload(t, 5, 10000)
+	payload.PrivateData = [][]byte{[]byte("pvt")}
+	chunks, err := splitPayload(payload, 1024)
+	assert.NoError(t, err)
+	assert.Len(t, chunks, 10)
+
+	// Chunks are reassembled in any order, and duplicates are ignored
+	assembler := &chunkAssembler{}
+	for _, i := range rand.Perm(len(chunks))[1:] {
+		p, err := assembler.add(chunks[i])
+		assert.NoError(t, err)
+		assert.Nil(t, p)
+		p, err = assembler.add(chunks[i])
+		assert.NoError(t, err)
+		assert.Nil(t, p)
+	}
+	for i := range chunks {
+		p, err := assembler.add(chunks[i])
+		assert.NoError(t, err)
+		if p != nil {
+			assert.True(t, pb.Equal(payload, p))
+			return
+		}
+	}
+	t.Fatal("payload was not reassembled")
+}
+
+func TestSplitPayloadErrors(t *testing.T) {
+	_, err := splitPayload(largePayload(t, 5, 100), 0)
+	assert.EqualError(t, err, "invalid chunk size 0")
+
+	_, err = splitPayload(largePayload(t, 5, 2000), 1)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "more than the maximum of 1024")
+}
+
+func TestReassemblePayloadIntegrity(t *testing.T) {
+	newChunks := func() []*proto.BlockChunk {
+		chunks, err := splitPayload(largePayload(t, 5, 3000), 1024)
+		assert.NoError(t, err)
+		return chunks
+	}
+
+	tests := []struct {
+		name   string
+		tamper func(chunks []*proto.BlockChunk)
+		err    string
+	}{
+		{
+			name:   "tampered chunk data",
+			tamper: func(chunks []*proto.BlockChunk) { chunks[1].Data[0]++ },
+			err:    "digest mismatch for chunk 1 of block [5]",
+		},
+		{
+			name: "tampered chunk data and digest",
+			tamper: func(chunks []*proto.BlockChunk) {
+				chunks[1].Data = append([]byte{}, chunks[1].Data...)
+				chunks[1].Data[0]++
+				chunks[1].Digest = util.ComputeSHA256(chunks[1].Data)
+			},
+			err: "digest mismatch for reassembled block [5]",
+		},
+		{
+			name:   "index out of range",
+			tamper: func(chunks []*proto.BlockChunk) { chunks[1].Index = 3 },
+			err:    "chunk index 3 out of range for block [5] of 3 chunks",
+		},
+		{
+			name:   "invalid chunk count",
+			tamper: func(chunks []*proto.BlockChunk) { chunks[0].Total = maxChunksPerBlock + 1 },
+			err:    "invalid chunk count 1025 for block [5]",
+		},
+		{
+			name:   "chunk of another block",
+			tamper: func(chunks []*proto.BlockChunk) { chunks[1].SeqNum = 6 },
+			err:    "chunk 1 of block [6] does not belong to block [5] being reassembled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks := newChunks()
+			tt.tamper(chunks)
+			assembler := &chunkAssembler{}
+			var err error
+			for _, chunk := range chunks {
+				if _, err = assembler.add(chunk); err != nil {
+					break
+				}
+			}
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestStateResponses(t *testing.T) {
+	small := []*proto.Payload{largePayload(t, 1, 100), largePayload(t, 2, 100), largePayload(t, 3, 100)}
+
+	// All payloads fit in a single response
+	msgs, err := stateResponses(42, "testchannel", small, 1024)
+	assert.NoError(t, err)
+	assert.Len(t, msgs, 1)
+	assert.Equal(t, uint64(42), msgs[0].Nonce)
+	assert.Equal(t, []byte("testchannel"), msgs[0].Channel)
+	assert.Equal(t, small, msgs[0].GetStateResponse().Payloads)
+
+	// The payloads exceeding the chunk size are left out
+	msgs, err = stateResponses(42, "testchannel", small, pb.Size(small[0])+pb.Size(small[1]))
+	assert.NoError(t, err)
+	assert.Len(t, msgs, 1)
+	assert.Equal(t, small[:2], msgs[0].GetStateResponse().Payloads)
+
+	// A first payload exceeding the chunk size is sent in chunks
+	large := append([]*proto.Payload{largePayload(t, 0, 3000)}, small...)
+	msgs, err = stateResponses(42, "testchannel", large, 1024)
+	assert.NoError(t, err)
+	assert.Len(t, msgs, 3)
+	for i, msg := range msgs {
+		assert.Equal(t, uint64(42), msg.Nonce)
+		assert.Nil(t, msg.GetStateResponse().Payloads)
+		assert.Equal(t, uint32(i), msg.GetStateResponse().Chunk.Index)
+		assert.NoError(t, msg.IsTagLegal())
+	}
+
+	// No payloads
+	msgs, err = stateResponses(42, "testchannel", nil, 1024)
+	assert.NoError(t, err)
+	assert.Len(t, msgs, 1)
+	assert.NotNil(t, msgs[0].GetStateResponse().Payloads)
+	assert.Empty(t, msgs[0].GetStateResponse().Payloads)
+}
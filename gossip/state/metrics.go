@@ -0,0 +1,31 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+var payloadBufferSizeOpts = metrics.GaugeOpts{
+	Namespace:    "gossip",
+	Subsystem:    "state",
+	Name:         "payload_buffer_size",
+	Help:         "The number of blocks received by the peer that wait in the buffer of the channel to be committed.",
+	LabelNames:   []string{"channel"},
+	StatsdFormat: "%{#fqname}.%{channel}",
+}
+
+// Metrics are the metrics of the state providers of the channels.
+type Metrics struct {
+	PayloadBufferSize metrics.Gauge
+}
+
+func NewMetrics(p metrics.Provider) *Metrics {
+	return &Metrics{
+		PayloadBufferSize: p.NewGauge(payloadBufferSizeOpts),
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 	pb "github.com/golang/protobuf/proto"
 	vsccErrors "github.com/hyperledger/fabric/common/errors"
+	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/comm"
 	common2 "github.com/hyperledger/fabric/gossip/common"
@@ -147,13 +148,20 @@ type GossipStateProviderImpl struct {
 	once sync.Once
 
 	stateTransferActive int32
+
+	// chunkSize is the maximum size of the payloads of a state response
+	chunkSize int
+
+	// payloadBufferSize is the gauge of the size of the payload buffer of
+	// the channel
+	payloadBufferSize metrics.Gauge
 }
 
 var logger = util.GetLogger(util.StateLogger, "")
 
 // NewGossipStateProvider creates state provider with coordinator instance
 // to orchestrate arrival of private rwsets and blocks before committing them into the ledger.
-func NewGossipStateProvider(chainID string, services *ServicesMediator, ledger ledgerResources) GossipStateProvider {
+func NewGossipStateProvider(chainID string, services *ServicesMediator, ledger ledgerResources, stateMetrics *Metrics) GossipStateProvider {
 
 	gossipChan, _ := services.Accept(func(message interface{}) bool {
 		// Get only data messages
@@ -224,6 +232,10 @@ func NewGossipStateProvider(chainID string, services *ServicesMediator, ledger l
 		stateTransferActive: 0,
 
 		once: sync.Once{},
+
+		chunkSize: stateTransferChunkSize(),
+
+		payloadBufferSize: stateMetrics.PayloadBufferSize.With("channel", chainID),
 	}
 
 	logger.Infof("Updating metadata information, "+
@@ -406,7 +418,7 @@ func (s *GossipStateProviderImpl) handleStateRequest(msg proto.ReceivedMessage)
 
 	endSeqNum := min(currentHeight, request.EndSeqNum)
 
-	response := &proto.RemoteStateResponse{Payloads: make([]*proto.Payload, 0)}
+	var payloads []*proto.Payload
 	for seqNum := request.StartSeqNum; seqNum <= endSeqNum; seqNum++ {
 		logger.Debug("Reading block ", seqNum, " with private data from the coordinator service")
 		connInfo := msg.GetConnectionInfo()
@@ -445,26 +457,27 @@ func (s *GossipStateProviderImpl) handleStateRequest(msg proto.ReceivedMessage)
 		}
 
 		// Appending result to the response
-		response.Payloads = append(response.Payloads, &proto.Payload{
+		payloads = append(payloads, &proto.Payload{
 			SeqNum:      seqNum,
 			Data:        blockBytes,
 			PrivateData: pvtBytes,
 		})
 	}
-	// Sending back response with missing blocks
-	msg.Respond(&proto.GossipMessage{
-		// Copy nonce field from the request, so it will be possible to match response
-		Nonce:   msg.GetGossipMessage().Nonce,
-		Tag:     proto.GossipMessage_CHAN_OR_ORG,
-		Channel: []byte(s.chainID),
-		Content: &proto.GossipMessage_StateResponse{StateResponse: response},
-	})
+
+	responses, err := stateResponses(msg.GetGossipMessage().Nonce, s.chainID, payloads, s.chunkSize)
+	if err != nil {
+		logger.Errorf("Failed creating state response for blocks [%d...%d]: %+v", request.StartSeqNum, endSeqNum, err)
+		return
+	}
+	// Sending back response with missing blocks, split into chunks
+	// when a block is too large for a single message
+	for _, response := range responses {
+		msg.Respond(response)
+	}
 }
 
-func (s *GossipStateProviderImpl) handleStateResponse(msg proto.ReceivedMessage) (uint64, error) {
+func (s *GossipStateProviderImpl) handleStateResponse(response *proto.RemoteStateResponse) (uint64, error) {
 	max := uint64(0)
-	// Send signal that response for given nonce has been received
-	response := msg.GetGossipMessage().GetStateResponse()
 	// Extract payloads, verify and push into buffer
 	if len(response.GetPayloads()) == 0 {
 		return uint64(0), errors.New("Received state transfer response without payload")
@@ -534,6 +547,7 @@ func (s *GossipStateProviderImpl) deliverPayloads() {
 			logger.Debugf("[%s] Ready to transfer payloads (blocks) to the ledger, next block number is = [%d]", s.chainID, s.payloads.Next())
 			// Collect all subsequent payloads
 			for payload := s.payloads.Pop(); payload != nil; payload = s.payloads.Pop() {
+				s.payloadBufferSize.Set(float64(s.payloads.Size()))
 				rawBlock := &common.Block{}
 				if err := pb.Unmarshal(payload.Data, rawBlock); err != nil {
 					logger.Errorf("Error getting block with seqNum = %d due to (%+v)...dropping block", payload.SeqNum, errors.WithStack(err))
@@ -562,6 +576,15 @@ func (s *GossipStateProviderImpl) deliverPayloads() {
 					}
 					logger.Panicf("Cannot commit block to the ledger due to %+v", errors.WithStack(err))
 				}
+				// Stop once the block is committed rather than after the
+				// blocks that follow it, so that the peer shuts down promptly
+				select {
+				case <-s.stopCh:
+					s.stopCh <- struct{}{}
+					logger.Debug("State provider has been stopped, finishing to push new blocks.")
+					return
+				default:
+				}
 			}
 		case <-s.stopCh:
 			s.stopCh <- struct{}{}
@@ -659,7 +682,16 @@ func (s *GossipStateProviderImpl) requestBlocksInRange(start uint64, end uint64)
 					continue
 				}
 				// Got corresponding response for state request, can continue
-				index, err := s.handleStateResponse(msg)
+				response, stopped, err := s.reassembleStateResponse(msg)
+				if stopped {
+					return
+				}
+				if err != nil {
+					logger.Warningf("Wasn't able to reassemble state response for "+
+						"blocks [%d...%d], due to %+v", prev, next, err)
+					continue
+				}
+				index, err := s.handleStateResponse(response)
 				if err != nil {
 					logger.Warningf("Wasn't able to process state response for "+
 						"blocks [%d...%d], due to %+v", prev, next, errors.WithStack(err))
@@ -676,6 +708,46 @@ func (s *GossipStateProviderImpl) requestBlocksInRange(start uint64, end uint64)
 	}
 }
 
+// reassembleStateResponse returns the state response carried by the message.
+// When the response carries the first chunk of a block, it waits for the
+// remaining chunks to the same request, and returns a response with the
+// reassembled block. It reports whether the state provider was stopped
+// in the meantime.
+func (s *GossipStateProviderImpl) reassembleStateResponse(msg proto.ReceivedMessage) (*proto.RemoteStateResponse, bool, error) {
+	nonce := msg.GetGossipMessage().Nonce
+	response := msg.GetGossipMessage().GetStateResponse()
+	assembler := &chunkAssembler{}
+	for {
+		chunk := response.GetChunk()
+		if chunk == nil {
+			return response, false, nil
+		}
+		payload, err := assembler.add(chunk)
+		if err != nil {
+			return nil, false, err
+		}
+		if payload != nil {
+			logger.Debugf("[%s] Reassembled block [%d] from %d chunks", s.chainID, payload.SeqNum, chunk.Total)
+			return &proto.RemoteStateResponse{Payloads: []*proto.Payload{payload}}, false, nil
+		}
+
+		response = nil
+		for response == nil {
+			select {
+			case msg := <-s.stateResponseCh:
+				if msg.GetGossipMessage().Nonce == nonce {
+					response = msg.GetGossipMessage().GetStateResponse()
+				}
+			case <-time.After(defAntiEntropyStateResponseTimeout):
+				return nil, false, errors.Errorf("timed out waiting for chunk of block [%d]", chunk.SeqNum)
+			case <-s.stopCh:
+				s.stopCh <- struct{}{}
+				return nil, true, nil
+			}
+		}
+	}
+}
+
 // stateRequestMessage generates state request message for given blocks in range [beginSeq...endSeq]
 func (s *GossipStateProviderImpl) stateRequestMessage(beginSeq uint64, endSeq uint64) *proto.GossipMessage {
 	return &proto.GossipMessage{
@@ -762,6 +834,7 @@ func (s *GossipStateProviderImpl) addPayload(payload *proto.Payload, blockingMod
 	}
 
 	s.payloads.Push(payload)
+	s.payloadBufferSize.Set(float64(s.payloads.Size()))
 	return nil
 }
 
@@ -781,6 +854,15 @@ func (s *GossipStateProviderImpl) commitBlock(block *common.Block, pvtData util.
 	return nil
 }
 
+// stateTransferChunkSize returns the maximum size of the payloads of a state
+// response, beyond which blocks are sent in chunks.
+func stateTransferChunkSize() int {
+	if size := viper.GetInt("peer.gossip.stateTransferChunkSize"); size > 0 {
+		return size
+	}
+	return defStateTransferChunkSize
+}
+
 func min(a uint64, b uint64) uint64 {
 	return b ^ ((a ^ b) & (-(uint64(a-b) >> 63)))
 }
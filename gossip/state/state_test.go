@@ -21,6 +21,7 @@ import (
 	"github.com/hyperledger/fabric/common/configtx/test"
 	errors2 "github.com/hyperledger/fabric/common/errors"
 	"github.com/hyperledger/fabric/common/flogging/floggingtest"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/committer"
 	"github.com/hyperledger/fabric/core/committer/txvalidator"
@@ -382,7 +383,7 @@ func newPeerNodeWithGossipWithValidator(config *gossip.Config, committer committ
 		TransientStore: &mockTransientStore{},
 		Committer:      committer,
 	}, pcomm.SignedData{})
-	sp := NewGossipStateProvider(util.GetTestChainID(), servicesAdapater, coord)
+	sp := NewGossipStateProvider(util.GetTestChainID(), servicesAdapater, coord, NewMetrics(&disabled.Provider{}))
 	if sp == nil {
 		return nil
 	}
@@ -535,6 +536,64 @@ func TestLargeBlockGap(t *testing.T) {
 	}
 }
 
+func TestChunkedStateTransfer(t *testing.T) {
+	// Scenario: the peer is missing blocks too large to be sent in a
+	// single state response. The imaginary peer it requests them from
+	// responds with each block split into chunks, which the peer
+	// reassembles and commits.
+	t.Parallel()
+	mc := &mockCommitter{Mock: &mock.Mock{}}
+	blocksPassedToLedger := make(chan uint64, 10)
+	mc.On("CommitWithPvtData", mock.Anything).Run(func(arg mock.Arguments) {
+		blocksPassedToLedger <- arg.Get(0).(*pcomm.Block).Header.Number
+	})
+	msgsFromPeer := make(chan proto.ReceivedMessage)
+	mc.On("LedgerHeight", mock.Anything).Return(uint64(1), nil)
+	g := &mocks.GossipMock{}
+	membership := []discovery.NetworkMember{
+		{
+			PKIid:    common.PKIidType("a"),
+			Endpoint: "a",
+			Properties: &proto.Properties{
+				LedgerHeight: 4,
+			},
+		}}
+	g.On("PeersOfChannel", mock.Anything).Return(membership)
+	g.On("Accept", mock.Anything, false).Return(make(<-chan *proto.GossipMessage), nil)
+	g.On("Accept", mock.Anything, true).Return(nil, msgsFromPeer)
+	g.On("Send", mock.Anything, mock.Anything).Run(func(arguments mock.Arguments) {
+		msg := arguments.Get(0).(*proto.GossipMessage)
+		req := msg.GetStateRequest()
+		var payloads []*proto.Payload
+		for seq := req.StartSeqNum; seq <= req.EndSeqNum; seq++ {
+			payloads = append(payloads, largePayload(t, seq, 10000))
+		}
+		responses, err := stateResponses(msg.Nonce, util.GetTestChainID(), payloads, 4096)
+		assert.NoError(t, err)
+		assert.Len(t, responses, 3)
+		go func() {
+			for _, res := range responses {
+				sMsg, _ := res.NoopSign()
+				msgsFromPeer <- &comm.ReceivedMessageImpl{
+					SignedGossipMessage: sMsg,
+				}
+			}
+		}()
+	})
+	portPrefix := portStartRange + 220
+	p := newPeerNodeWithGossip(newGossipConfig(portPrefix, 0), mc, noopPeerIdentityAcceptor, g)
+	defer p.shutdown()
+
+	for expectedSequence := 1; expectedSequence < 4; expectedSequence++ {
+		select {
+		case blockSeq := <-blocksPassedToLedger:
+			assert.Equal(t, expectedSequence, int(blockSeq))
+		case <-time.After(defAntiEntropyInterval + 10*time.Second):
+			t.Fatalf("block %d was not committed", expectedSequence)
+		}
+	}
+}
+
 func TestOverPopulation(t *testing.T) {
 	// Scenario: Add to the state provider blocks
 	// with a gap in between, and ensure that the payload buffer
@@ -587,13 +646,19 @@ func TestOverPopulation(t *testing.T) {
 	}
 
 	// Ensure only blocks 1-4 were passed to the ledger
-	close(blocksPassedToLedger)
-	i := 1
-	for seq := range blocksPassedToLedger {
-		assert.Equal(t, uint64(i), seq)
-		i++
+	for i := 1; i <= 4; i++ {
+		select {
+		case seq := <-blocksPassedToLedger:
+			assert.Equal(t, uint64(i), seq)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("block %d was not passed to the ledger", i)
+		}
+	}
+	select {
+	case seq := <-blocksPassedToLedger:
+		t.Fatalf("block %d was passed to the ledger", seq)
+	default:
 	}
-	assert.Equal(t, 5, i)
 
 	// Ensure we don't store too many blocks in memory
 	sp := p.s
@@ -1388,7 +1453,7 @@ func TestTransferOfPrivateRWSet(t *testing.T) {
 	coord1.On("Close")
 
 	servicesAdapater := &ServicesMediator{GossipAdapter: g, MCSAdapter: &cryptoServiceMock{acceptor: noopPeerIdentityAcceptor}}
-	st := NewGossipStateProvider(chainID, servicesAdapater, coord1)
+	st := NewGossipStateProvider(chainID, servicesAdapater, coord1, NewMetrics(&disabled.Provider{}))
 	defer st.Stop()
 
 	// Mocked state request message
@@ -1620,11 +1685,11 @@ func TestTransferOfPvtDataBetweenPeers(t *testing.T) {
 	cryptoService := &cryptoServiceMock{acceptor: noopPeerIdentityAcceptor}
 
 	mediator := &ServicesMediator{GossipAdapter: peers["peer1"], MCSAdapter: cryptoService}
-	peer1State := NewGossipStateProvider(chainID, mediator, peers["peer1"].coord)
+	peer1State := NewGossipStateProvider(chainID, mediator, peers["peer1"].coord, NewMetrics(&disabled.Provider{}))
 	defer peer1State.Stop()
 
 	mediator = &ServicesMediator{GossipAdapter: peers["peer2"], MCSAdapter: cryptoService}
-	peer2State := NewGossipStateProvider(chainID, mediator, peers["peer2"].coord)
+	peer2State := NewGossipStateProvider(chainID, mediator, peers["peer2"].coord, NewMetrics(&disabled.Provider{}))
 	defer peer2State.Stop()
 
 	// Make sure state was replicated
@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
 	"github.com/stretchr/testify/assert"
 )
@@ -162,3 +163,94 @@ func TestIdemix(t *testing.T) {
 		return
 	}
 }
+
+func TestPlainSignatureRevocation(t *testing.T) {
+	rng, err := GetRand()
+	assert.NoError(t, err)
+
+	AttributeNames := []string{"Attr1", "Attr2", "Attr3", "Attr4", "Attr5"}
+	key, err := NewIssuerKey(AttributeNames, rng)
+	assert.NoError(t, err)
+	revocationKey, err := GenerateLongTermRevocationKey()
+	assert.NoError(t, err)
+
+	// issue two credentials with revocation handles 1 and 2
+	rhindex := 4
+	newCredential := func(rh int) (*Credential, *FP256BN.BIG) {
+		attrs := make([]*FP256BN.BIG, len(AttributeNames))
+		for i := range AttributeNames {
+			attrs[i] = FP256BN.NewBIGint(i)
+		}
+		attrs[rhindex] = FP256BN.NewBIGint(rh)
+		sk := RandModOrder(rng)
+		cred, err := NewCredential(key, NewCredRequest(sk, BigToBytes(RandModOrder(rng)), key.Ipk, rng), attrs, rng)
+		assert.NoError(t, err)
+		return cred, sk
+	}
+	cred1, sk1 := newCredential(1)
+	cred2, sk2 := newCredential(2)
+
+	// revoke the credential with handle 2 in epoch 1
+	epoch := 1
+	cri, err := CreateCRI(revocationKey, []*FP256BN.BIG{FP256BN.NewBIGint(1), FP256BN.NewBIGint(3)}, epoch, ALG_PLAIN_SIGNATURE, rng)
+	assert.NoError(t, err)
+	assert.NoError(t, VerifyEpochPK(&revocationKey.PublicKey, cri.EpochPk, cri.EpochPkSig, int(cri.Epoch), RevocationAlgorithm(cri.RevocationAlg)))
+
+	disclosure := []byte{0, 1, 1, 1, 0}
+	msg := []byte{1, 2, 3, 4, 5}
+	attrs := []*FP256BN.BIG{nil, FP256BN.NewBIGint(1), FP256BN.NewBIGint(2), FP256BN.NewBIGint(3), nil}
+
+	// an unrevoked signer proves that it is not revoked
+	Nym, RandNym := MakeNym(sk1, key.Ipk, rng)
+	sig, err := NewSignature(cred1, sk1, Nym, RandNym, key.Ipk, disclosure, msg, rhindex, cri, rng)
+	assert.NoError(t, err)
+	assert.NoError(t, sig.Ver(disclosure, key.Ipk, msg, attrs, rhindex, &revocationKey.PublicKey, epoch))
+
+	// the signature is not valid in another epoch
+	err = sig.Ver(disclosure, key.Ipk, msg, attrs, rhindex, &revocationKey.PublicKey, epoch+1)
+	assert.EqualError(t, err, "signature invalid: signature is for epoch 1, current epoch is 2")
+
+	// the signature is not valid under another revocation authority
+	otherRevocationKey, err := GenerateLongTermRevocationKey()
+	assert.NoError(t, err)
+	err = sig.Ver(disclosure, key.Ipk, msg, attrs, rhindex, &otherRevocationKey.PublicKey, epoch)
+	assert.EqualError(t, err, "signature invalid: revocation epoch public key is invalid: EpochPKSig invalid")
+
+	// the non-revocation proof must hold under the epoch key
+	_, otherEpochPk := WBBKeyGen(rng)
+	sig.RevocationEpochPk = Ecp2ToProto(otherEpochPk)
+	assert.Error(t, sig.Ver(disclosure, key.Ipk, msg, attrs, rhindex, &revocationKey.PublicKey, epoch))
+	sig.RevocationEpochPk = cri.EpochPk
+
+	// the non-revocation proof is bound to the signature
+	otherSig, err := NewSignature(cred1, sk1, Nym, RandNym, key.Ipk, disclosure, msg, rhindex, cri, rng)
+	assert.NoError(t, err)
+	sig.NonRevocationProof = otherSig.NonRevocationProof
+	assert.Error(t, sig.Ver(disclosure, key.Ipk, msg, attrs, rhindex, &revocationKey.PublicKey, epoch))
+
+	// a signature disclosing the revocation handle is invalid
+	_, err = NewSignature(cred1, sk1, Nym, RandNym, key.Ipk, []byte{0, 1, 1, 1, 1}, msg, rhindex, cri, rng)
+	assert.Error(t, err)
+
+	// a revoked signer cannot prove that it is not revoked
+	Nym, RandNym = MakeNym(sk2, key.Ipk, rng)
+	_, err = NewSignature(cred2, sk2, Nym, RandNym, key.Ipk, disclosure, msg, rhindex, cri, rng)
+	assert.EqualError(t, err, "failed to compute non-revoked proof: revocation handle is revoked in epoch 1")
+
+	// nor by reusing the signature of another handle
+	signatures := &PlainSigRevocationData{}
+	assert.NoError(t, proto.Unmarshal(cri.RevocationData, signatures))
+	signatures.Signatures[0].RevocationHandle = BigToBytes(FP256BN.NewBIGint(2))
+	cri.RevocationData, err = proto.Marshal(signatures)
+	assert.NoError(t, err)
+	sig, err = NewSignature(cred2, sk2, Nym, RandNym, key.Ipk, disclosure, msg, rhindex, cri, rng)
+	assert.NoError(t, err)
+	assert.Error(t, sig.Ver(disclosure, key.Ipk, msg, attrs, rhindex, &revocationKey.PublicKey, epoch))
+
+	// a signer with a CRI of a previous epoch is rejected
+	oldCri, err := CreateCRI(revocationKey, nil, epoch-1, ALG_NO_REVOCATION, rng)
+	assert.NoError(t, err)
+	sig, err = NewSignature(cred2, sk2, Nym, RandNym, key.Ipk, disclosure, msg, rhindex, oldCri, rng)
+	assert.NoError(t, err)
+	assert.EqualError(t, sig.Ver(disclosure, key.Ipk, msg, attrs, rhindex, &revocationKey.PublicKey, epoch), "signature invalid: signature is for epoch 0, current epoch is 1")
+}
@@ -7,6 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package idemix
 
 import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-amcl/amcl"
 	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
 	"github.com/pkg/errors"
@@ -34,11 +37,77 @@ func (prover *nopNonRevokedProver) getNonRevokedProof(chal *FP256BN.BIG) (*NonRe
 	return ret, nil
 }
 
+// plainSigNonRevokedProver is the nonRevokedProver of ALG_PLAIN_SIGNATURE.
+// It proves the knowledge of a weak Boneh-Boyen signature sigma of the
+// revocation authority on the hidden revocation handle rh, valid under the
+// epoch public key, without revealing sigma nor rh:
+// it blinds the signature as sigmaPrime = sigma^r, sets
+// sigmaBar = g1^r * sigmaPrime^(-rh) = sigmaPrime^epochSk, and proves the
+// knowledge of r and rh. The revocation handle is the one the main proof
+// of the signature refers to, as both proofs share its randomness rRh.
+type plainSigNonRevokedProver struct {
+	r          *FP256BN.BIG
+	rR         *FP256BN.BIG
+	sigmaPrime *FP256BN.ECP
+	sigmaBar   *FP256BN.ECP
+}
+
+func (prover *plainSigNonRevokedProver) getFSContribution(rh *FP256BN.BIG, rRh *FP256BN.BIG, cri *CredentialRevocationInformation, rng *amcl.RAND) ([]byte, error) {
+	revocationData := &PlainSigRevocationData{}
+	if err := proto.Unmarshal(cri.RevocationData, revocationData); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal revocation data")
+	}
+	var sigma *FP256BN.ECP
+	rhBytes := BigToBytes(rh)
+	for _, signature := range revocationData.Signatures {
+		if bytes.Equal(signature.RevocationHandle, rhBytes) {
+			sigma = EcpFromProto(signature.RhSignature)
+			break
+		}
+	}
+	if sigma == nil {
+		return nil, errors.Errorf("revocation handle is revoked in epoch %d", cri.Epoch)
+	}
+
+	prover.r = RandModOrder(rng)
+	prover.rR = RandModOrder(rng)
+	prover.sigmaPrime = sigma.Mul(prover.r)
+	prover.sigmaBar = GenG1.Mul(prover.r)
+	prover.sigmaBar.Sub(prover.sigmaPrime.Mul(rh))
+
+	t := GenG1.Mul(prover.rR)
+	t.Sub(prover.sigmaPrime.Mul(rRh))
+
+	contribution := make([]byte, ProofBytes[ALG_PLAIN_SIGNATURE])
+	index := appendBytesG1(contribution, 0, prover.sigmaPrime)
+	index = appendBytesG1(contribution, index, prover.sigmaBar)
+	appendBytesG1(contribution, index, t)
+	return contribution, nil
+}
+
+func (prover *plainSigNonRevokedProver) getNonRevokedProof(chal *FP256BN.BIG) (*NonRevocationProof, error) {
+	proof := &PlainSigNonRevocationProof{
+		SigmaPrime: EcpToProto(prover.sigmaPrime),
+		SigmaBar:   EcpToProto(prover.sigmaBar),
+		ProofSR:    BigToBytes(Modadd(prover.rR, FP256BN.Modmul(chal, prover.r, GroupOrder), GroupOrder)),
+	}
+	proofBytes, err := proto.Marshal(proof)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal non-revocation proof")
+	}
+	return &NonRevocationProof{
+		RevocationAlg:      int32(ALG_PLAIN_SIGNATURE),
+		NonRevocationProof: proofBytes,
+	}, nil
+}
+
 // getNonRevocationProver returns the nonRevokedProver bound to the passed revocation algorithm
 func getNonRevocationProver(algorithm RevocationAlgorithm) (nonRevokedProver, error) {
 	switch algorithm {
 	case ALG_NO_REVOCATION:
 		return &nopNonRevokedProver{}, nil
+	case ALG_PLAIN_SIGNATURE:
+		return &plainSigNonRevokedProver{}, nil
 	default:
 		// unknown revocation algorithm
 		return nil, errors.Errorf("unknown revocation algorithm %d", algorithm)
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package idemix
 
 import (
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
 	"github.com/pkg/errors"
 )
@@ -24,11 +25,52 @@ func (verifier *nopNonRevocationVerifier) recomputeFSContribution(proof *NonRevo
 	return nil, nil
 }
 
+// plainSigNonRevocationVerifier is the nonRevocationVerifier of ALG_PLAIN_SIGNATURE
+type plainSigNonRevocationVerifier struct{}
+
+func (verifier *plainSigNonRevocationVerifier) recomputeFSContribution(proof *NonRevocationProof, chal *FP256BN.BIG, epochPK *FP256BN.ECP2, proofSRh *FP256BN.BIG) ([]byte, error) {
+	plainSigProof := &PlainSigNonRevocationProof{}
+	if err := proto.Unmarshal(proof.NonRevocationProof, plainSigProof); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal non-revocation proof")
+	}
+	if plainSigProof.SigmaPrime == nil || plainSigProof.SigmaBar == nil {
+		return nil, errors.Errorf("non-revocation proof invalid: missing blinded signature")
+	}
+	sigmaPrime := EcpFromProto(plainSigProof.SigmaPrime)
+	sigmaBar := EcpFromProto(plainSigProof.SigmaBar)
+	proofSR := FP256BN.FromBytes(plainSigProof.ProofSR)
+
+	// check that sigmaBar = sigmaPrime^epochSk, i.e., e(sigmaPrime, epochPK) = e(sigmaBar, g2)
+	if sigmaPrime.Is_infinity() {
+		return nil, errors.Errorf("non-revocation proof invalid: sigmaPrime = 1")
+	}
+	temp1 := FP256BN.Ate(epochPK, sigmaPrime)
+	temp2 := FP256BN.Ate(GenG2, sigmaBar)
+	temp2.Inverse()
+	temp1.Mul(temp2)
+	if !FP256BN.Fexp(temp1).Isunity() {
+		return nil, errors.Errorf("non-revocation proof invalid: sigmaPrime and sigmaBar don't have the expected structure")
+	}
+
+	// recompute t = g1^sR * sigmaPrime^(-sRh) * sigmaBar^(-c)
+	t := GenG1.Mul(proofSR)
+	t.Sub(sigmaPrime.Mul(proofSRh))
+	t.Sub(sigmaBar.Mul(chal))
+
+	contribution := make([]byte, ProofBytes[ALG_PLAIN_SIGNATURE])
+	index := appendBytesG1(contribution, 0, sigmaPrime)
+	index = appendBytesG1(contribution, index, sigmaBar)
+	appendBytesG1(contribution, index, t)
+	return contribution, nil
+}
+
 // getNonRevocationVerifier returns the nonRevocationVerifier bound to the passed revocation algorithm
 func getNonRevocationVerifier(algorithm RevocationAlgorithm) (nonRevocationVerifier, error) {
 	switch algorithm {
 	case ALG_NO_REVOCATION:
 		return &nopNonRevocationVerifier{}, nil
+	case ALG_PLAIN_SIGNATURE:
+		return &plainSigNonRevocationVerifier{}, nil
 	default:
 		// unknown revocation algorithm
 		return nil, errors.Errorf("unknown revocation algorithm %d", algorithm)
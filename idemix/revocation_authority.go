@@ -23,10 +23,12 @@ type RevocationAlgorithm int32
 
 const (
 	ALG_NO_REVOCATION RevocationAlgorithm = iota
+	ALG_PLAIN_SIGNATURE
 )
 
 var ProofBytes = map[RevocationAlgorithm]int{
-	ALG_NO_REVOCATION: 0,
+	ALG_NO_REVOCATION:   0,
+	ALG_PLAIN_SIGNATURE: 3 * (2*FieldBytes + 1),
 }
 
 // GenerateLongTermRevocationKey generates a long term signing key that will be used for revocation
@@ -38,6 +40,9 @@ func GenerateLongTermRevocationKey() (*ecdsa.PrivateKey, error) {
 // Users can use the CRI to prove that they are not revoked.
 // Note that when not using revocation (i.e., alg = ALG_NO_REVOCATION), the entered unrevokedHandles are not used,
 // and the resulting CRI can be used by any signer.
+// With ALG_PLAIN_SIGNATURE, the CRI contains a signature on each unrevoked handle
+// under a fresh epoch key, so that only the signers holding an unrevoked handle
+// can prove that they are not revoked in the epoch.
 func CreateCRI(key *ecdsa.PrivateKey, unrevokedHandles []*FP256BN.BIG, epoch int, alg RevocationAlgorithm, rng *amcl.RAND) (*CredentialRevocationInformation, error) {
 	if key == nil || rng == nil {
 		return nil, errors.Errorf("CreateCRI received nil input")
@@ -46,13 +51,18 @@ func CreateCRI(key *ecdsa.PrivateKey, unrevokedHandles []*FP256BN.BIG, epoch int
 	cri.RevocationAlg = int32(alg)
 	cri.Epoch = int64(epoch)
 
-	if alg == ALG_NO_REVOCATION {
+	var epochSk *FP256BN.BIG
+	switch alg {
+	case ALG_NO_REVOCATION:
 		// put a dummy PK in the proto
 		cri.EpochPk = Ecp2ToProto(GenG2)
-	} else {
+	case ALG_PLAIN_SIGNATURE:
 		// create epoch key
-		_, epochPk := WBBKeyGen(rng)
+		var epochPk *FP256BN.ECP2
+		epochSk, epochPk = WBBKeyGen(rng)
 		cri.EpochPk = Ecp2ToProto(epochPk)
+	default:
+		return nil, errors.Errorf("the specified revocation algorithm is not supported.")
 	}
 
 	// sign epoch + epoch key with long term key
@@ -68,11 +78,22 @@ func CreateCRI(key *ecdsa.PrivateKey, unrevokedHandles []*FP256BN.BIG, epoch int
 		return nil, err
 	}
 
-	if alg == ALG_NO_REVOCATION {
-		return cri, nil
-	} else {
-		return nil, errors.Errorf("the specified revocation algorithm is not supported.")
+	if alg == ALG_PLAIN_SIGNATURE {
+		// sign the unrevoked handles with the epoch key, which is discarded afterwards
+		revocationData := &PlainSigRevocationData{}
+		for _, rh := range unrevokedHandles {
+			revocationData.Signatures = append(revocationData.Signatures, &MessageSignature{
+				RevocationHandle: BigToBytes(rh),
+				RhSignature:      EcpToProto(WBBSign(epochSk, rh)),
+			})
+		}
+		cri.RevocationData, err = proto.Marshal(revocationData)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal revocation data")
+		}
 	}
+
+	return cri, nil
 }
 
 // VerifyEpochPK verifies that the revocation PK for a certain epoch is valid,
@@ -257,6 +257,16 @@ func (sig *Signature) Ver(Disclosure []byte, ipk *IssuerPublicKey, msg []byte, a
 		return errors.Errorf("Attribute %d is disclosed but is also used as revocation handle, which should remain hidden.", rhIndex)
 	}
 
+	// Verify that the epoch public key was issued by the revocation authority for the current epoch,
+	// which also applies when the revocation authority decided not to use revocation in the epoch
+	if sig.Epoch != int64(epoch) {
+		return errors.Errorf("signature invalid: signature is for epoch %d, current epoch is %d", sig.Epoch, epoch)
+	}
+	err := VerifyEpochPK(revPk, sig.RevocationEpochPk, sig.RevocationPkSig, int(sig.Epoch), RevocationAlgorithm(sig.NonRevocationProof.RevocationAlg))
+	if err != nil {
+		return errors.WithMessage(err, "signature invalid: revocation epoch public key is invalid")
+	}
+
 	HiddenIndices := hiddenIndices(Disclosure)
 
 	// Parse signature
@@ -0,0 +1,119 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package org.hyperledger.fabric.integration.simple;
+
+import java.nio.charset.StandardCharsets;
+import java.util.List;
+
+import org.hyperledger.fabric.shim.ChaincodeBase;
+import org.hyperledger.fabric.shim.ChaincodeStub;
+
+// SimpleChaincode is the java counterpart of the golang simple chaincode used
+// by the integration tests. It supports the same functions and responses.
+public class SimpleChaincode extends ChaincodeBase {
+
+    @Override
+    public Response init(ChaincodeStub stub) {
+        List<String> args = stub.getParameters();
+        if (args.size() != 4) {
+            return newErrorResponse("Incorrect number of arguments. Expecting 4");
+        }
+
+        int aval, bval;
+        try {
+            aval = Integer.parseInt(args.get(1));
+            bval = Integer.parseInt(args.get(3));
+        } catch (NumberFormatException e) {
+            return newErrorResponse("Expecting integer value for asset holding");
+        }
+
+        stub.putStringState(args.get(0), Integer.toString(aval));
+        stub.putStringState(args.get(2), Integer.toString(bval));
+        return newSuccessResponse();
+    }
+
+    @Override
+    public Response invoke(ChaincodeStub stub) {
+        String function = stub.getFunction();
+        List<String> args = stub.getParameters();
+        switch (function) {
+        case "invoke":
+            return invoke(stub, args);
+        case "delete":
+            return delete(stub, args);
+        case "query":
+            return query(stub, args);
+        case "respond":
+            return respond(args);
+        default:
+            return newErrorResponse("Invalid invoke function name. Expecting \"invoke\", \"delete\", \"query\", or \"respond\"");
+        }
+    }
+
+    private Response invoke(ChaincodeStub stub, List<String> args) {
+        if (args.size() != 3) {
+            return newErrorResponse("Incorrect number of arguments. Expecting 3");
+        }
+
+        int x;
+        try {
+            x = Integer.parseInt(args.get(2));
+        } catch (NumberFormatException e) {
+            return newErrorResponse("Invalid transaction amount, expecting a integer value");
+        }
+
+        String avalStr = stub.getStringState(args.get(0));
+        String bvalStr = stub.getStringState(args.get(1));
+        if (avalStr == null || avalStr.isEmpty() || bvalStr == null || bvalStr.isEmpty()) {
+            return newErrorResponse("Entity not found");
+        }
+
+        int aval = Integer.parseInt(avalStr) - x;
+        int bval = Integer.parseInt(bvalStr) + x;
+        stub.putStringState(args.get(0), Integer.toString(aval));
+        stub.putStringState(args.get(1), Integer.toString(bval));
+        return newSuccessResponse();
+    }
+
+    private Response delete(ChaincodeStub stub, List<String> args) {
+        if (args.size() != 1) {
+            return newErrorResponse("Incorrect number of arguments. Expecting 1");
+        }
+        stub.delState(args.get(0));
+        return newSuccessResponse();
+    }
+
+    private Response query(ChaincodeStub stub, List<String> args) {
+        if (args.size() != 1) {
+            return newErrorResponse("Incorrect number of arguments. Expecting name of the person to query");
+        }
+
+        String val = stub.getStringState(args.get(0));
+        if (val == null || val.isEmpty()) {
+            return newErrorResponse("{\"Error\":\"Nil amount for " + args.get(0) + "\"}");
+        }
+        return newSuccessResponse(val.getBytes(StandardCharsets.UTF_8));
+    }
+
+    private Response respond(List<String> args) {
+        if (args.size() != 3) {
+            return newErrorResponse("expected three arguments");
+        }
+
+        int status;
+        try {
+            status = Integer.parseInt(args.get(0));
+        } catch (NumberFormatException e) {
+            return newErrorResponse(e.getMessage());
+        }
+        return new Response(status, args.get(1), args.get(2).getBytes(StandardCharsets.UTF_8));
+    }
+
+    public static void main(String[] args) {
+        new SimpleChaincode().start(args);
+    }
+}
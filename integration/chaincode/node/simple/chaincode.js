@@ -0,0 +1,120 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+'use strict';
+
+const shim = require('fabric-shim');
+
+// Simple is the node.js counterpart of the golang simple chaincode used by
+// the integration tests. It supports the same functions and responses.
+const Simple = class {
+	async Init(stub) {
+		const args = stub.getFunctionAndParameters().params;
+		if (args.length !== 4) {
+			return shim.error('Incorrect number of arguments. Expecting 4');
+		}
+
+		const aval = parseInt(args[1]);
+		const bval = parseInt(args[3]);
+		if (isNaN(aval) || isNaN(bval)) {
+			return shim.error('Expecting integer value for asset holding');
+		}
+
+		try {
+			await stub.putState(args[0], Buffer.from(aval.toString()));
+			await stub.putState(args[2], Buffer.from(bval.toString()));
+		} catch (err) {
+			return shim.error(err.message);
+		}
+		return shim.success();
+	}
+
+	async Invoke(stub) {
+		const ret = stub.getFunctionAndParameters();
+		switch (ret.fcn) {
+		case 'invoke':
+			return this.invoke(stub, ret.params);
+		case 'delete':
+			return this.delete(stub, ret.params);
+		case 'query':
+			return this.query(stub, ret.params);
+		case 'respond':
+			return this.respond(stub, ret.params);
+		default:
+			return shim.error('Invalid invoke function name. Expecting "invoke", "delete", "query", or "respond"');
+		}
+	}
+
+	async invoke(stub, args) {
+		if (args.length !== 3) {
+			return shim.error('Incorrect number of arguments. Expecting 3');
+		}
+
+		const x = parseInt(args[2]);
+		if (isNaN(x)) {
+			return shim.error('Invalid transaction amount, expecting a integer value');
+		}
+
+		const avalBytes = await stub.getState(args[0]);
+		if (!avalBytes || avalBytes.length === 0) {
+			return shim.error('Entity not found');
+		}
+		const bvalBytes = await stub.getState(args[1]);
+		if (!bvalBytes || bvalBytes.length === 0) {
+			return shim.error('Entity not found');
+		}
+
+		const aval = parseInt(avalBytes.toString()) - x;
+		const bval = parseInt(bvalBytes.toString()) + x;
+
+		try {
+			await stub.putState(args[0], Buffer.from(aval.toString()));
+			await stub.putState(args[1], Buffer.from(bval.toString()));
+		} catch (err) {
+			return shim.error(err.message);
+		}
+		return shim.success();
+	}
+
+	async delete(stub, args) {
+		if (args.length !== 1) {
+			return shim.error('Incorrect number of arguments. Expecting 1');
+		}
+
+		try {
+			await stub.deleteState(args[0]);
+		} catch (err) {
+			return shim.error('Failed to delete state');
+		}
+		return shim.success();
+	}
+
+	async query(stub, args) {
+		if (args.length !== 1) {
+			return shim.error('Incorrect number of arguments. Expecting name of the person to query');
+		}
+
+		const avalBytes = await stub.getState(args[0]);
+		if (!avalBytes || avalBytes.length === 0) {
+			return shim.error(`{"Error":"Nil amount for ${args[0]}"}`);
+		}
+		return shim.success(avalBytes);
+	}
+
+	async respond(stub, args) {
+		if (args.length !== 3) {
+			return shim.error('expected three arguments');
+		}
+
+		const status = parseInt(args[0]);
+		if (isNaN(status)) {
+			return shim.error(`Invalid status: ${args[0]}`);
+		}
+		return {status: status, message: args[1], payload: Buffer.from(args[2])};
+	}
+};
+
+shim.start(new Simple());
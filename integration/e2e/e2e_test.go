@@ -123,6 +123,41 @@ var _ = Describe("EndToEnd", func() {
 		})
 	})
 
+	Describe("basic solo network with non-golang chaincode", func() {
+		BeforeEach(func() {
+			network = nwo.New(nwo.BasicSolo(), testDir, client, BasePort(), components)
+			network.GenerateConfigTree()
+			network.Bootstrap()
+
+			networkRunner := network.NetworkGroupRunner()
+			process = ifrit.Invoke(networkRunner)
+			Eventually(process.Ready(), network.EventuallyTimeout).Should(BeClosed())
+		})
+
+		deployAndRun := func(lang, path string) {
+			orderer := network.Orderer("orderer")
+			peer := network.Peer("Org1", "peer1")
+
+			network.CreateAndJoinChannel(orderer, "testchannel")
+
+			By("deploying the " + lang + " chaincode")
+			chaincode.Lang = lang
+			chaincode.Path = path
+			nwo.DeployChaincode(network, "testchannel", orderer, chaincode)
+
+			RunQueryInvokeQuery(network, orderer, peer, "testchannel")
+			RunRespondWith(network, orderer, peer, "testchannel")
+		}
+
+		It("executes node chaincode", func() {
+			deployAndRun(nwo.NodeChaincode, "../chaincode/node/simple")
+		})
+
+		It("executes java chaincode", func() {
+			deployAndRun(nwo.JavaChaincode, "../chaincode/java/simple")
+		})
+	})
+
 	Describe("basic kafka network with 2 orgs", func() {
 		BeforeEach(func() {
 			network = nwo.New(nwo.BasicKafka(), testDir, client, BasePort(), components)
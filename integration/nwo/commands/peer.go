@@ -6,6 +6,8 @@ SPDX-License-Identifier: Apache-2.0
 
 package commands
 
+import "strings"
+
 type NodeStart struct {
 	PeerID string
 	Dir    string
@@ -272,6 +274,7 @@ type ChaincodeUpgrade struct {
 	Orderer           string
 	Ctor              string
 	Policy            string
+	Lang              string // optional
 	CollectionsConfig string // optional
 }
 
@@ -295,6 +298,9 @@ func (c ChaincodeUpgrade) Args() []string {
 	if c.CollectionsConfig != "" {
 		args = append(args, "--collections-config", c.CollectionsConfig)
 	}
+	if c.Lang != "" {
+		args = append(args, "--lang", c.Lang)
+	}
 	return args
 }
 
@@ -332,6 +338,25 @@ func (c ChannelUpdate) Args() []string {
 	}
 }
 
+type ChannelUpdateAnchors struct {
+	ChannelID   string
+	Orderer     string
+	AnchorPeers []string
+}
+
+func (c ChannelUpdateAnchors) SessionName() string {
+	return "peer-channel-updateanchors"
+}
+
+func (c ChannelUpdateAnchors) Args() []string {
+	return []string{
+		"channel", "updateanchors",
+		"--channelID", c.ChannelID,
+		"--orderer", c.Orderer,
+		"--anchorPeers", strings.Join(c.AnchorPeers, ","),
+	}
+}
+
 type ChannelInfo struct {
 	ChannelID string
 }
@@ -18,6 +18,7 @@ peer:
   listenAddress: 127.0.0.1:{{ .PeerPort Peer "Listen" }}
   chaincodeListenAddress: 0.0.0.0:{{ .PeerPort Peer "Chaincode" }}
   gomaxprocs: -1
+  queryOnly: false
   keepalive:
     minInterval: 60s
     client:
@@ -100,8 +101,8 @@ peer:
     reconnectTotalTimeThreshold: 3600s
   localMspType: bccsp
   profile:
-    enabled:     false
-    listenAddress: 127.0.0.1:{{ .PeerPort Peer "ProfilePort" }}
+    enabled:     {{ .ProfilingEnabled }}
+    listenAddress: 127.0.0.1:{{ .PeerPort Peer "Profile" }}
   adminService:
   handlers:
     authFilters:
@@ -116,6 +117,9 @@ peer:
       vscc:
         name: DefaultValidation
   validatorPoolSize:
+  validation:
+    txTimeBudget: 0s
+    invalidateOverBudget: false
   discovery:
     enabled: true
     authCacheEnabled: true
@@ -186,6 +190,7 @@ ledger:
       warmIndexesAfterNBlocks: 1
   history:
     enableHistoryDatabase: true
+    commitConcurrently: false
 
 operations:
   listenAddress: 127.0.0.1:{{ .PeerPort Peer "Operations" }}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	"github.com/hyperledger/fabric/integration/nwo/commands"
 	. "github.com/onsi/gomega"
@@ -17,17 +18,37 @@ import (
 	"github.com/onsi/gomega/gexec"
 )
 
+// Chaincode languages supported by the peer CLI.
+const (
+	GolangChaincode = "golang"
+	NodeChaincode   = "node"
+	JavaChaincode   = "java"
+)
+
 type Chaincode struct {
-	Name              string
-	Version           string
+	Name    string
+	Version string
+	// Path is the import path of golang chaincode or the source directory of
+	// node and java chaincode. A relative source directory is resolved
+	// against the current working directory.
 	Path              string
 	Ctor              string
 	Policy            string
-	Lang              string
+	Lang              string // optional, defaults to golang
 	CollectionsConfig string // optional
 	PackageFile       string
 }
 
+// sourcePath returns the path passed to the peer CLI for the chaincode.
+func (c Chaincode) sourcePath() string {
+	if c.Lang == "" || c.Lang == GolangChaincode || filepath.IsAbs(c.Path) {
+		return c.Path
+	}
+	path, err := filepath.Abs(c.Path)
+	Expect(err).NotTo(HaveOccurred())
+	return path
+}
+
 // DeployChaincode is a helper that will install chaincode to all peers that
 // are connected to the specified channel, instantiate the chaincode on one of
 // the peers, and wait for the instantiation to complete on all of the peers.
@@ -66,7 +87,7 @@ func PackageChaincode(n *Network, chaincode Chaincode, peer *Peer) {
 	sess, err := n.PeerAdminSession(peer, commands.ChaincodePackage{
 		Name:       chaincode.Name,
 		Version:    chaincode.Version,
-		Path:       chaincode.Path,
+		Path:       chaincode.sourcePath(),
 		Lang:       chaincode.Lang,
 		OutputFile: chaincode.PackageFile,
 	})
@@ -79,7 +100,7 @@ func InstallChaincode(n *Network, chaincode Chaincode, peers ...*Peer) {
 		sess, err := n.PeerAdminSession(p, commands.ChaincodeInstall{
 			Name:        chaincode.Name,
 			Version:     chaincode.Version,
-			Path:        chaincode.Path,
+			Path:        chaincode.sourcePath(),
 			Lang:        chaincode.Lang,
 			PackageFile: chaincode.PackageFile,
 		})
@@ -137,6 +158,7 @@ func UpgradeChaincode(n *Network, channel string, orderer *Orderer, chaincode Ch
 		Version:           chaincode.Version,
 		Ctor:              chaincode.Ctor,
 		Policy:            chaincode.Policy,
+		Lang:              chaincode.Lang,
 		CollectionsConfig: chaincode.CollectionsConfig,
 	})
 	Expect(err).NotTo(HaveOccurred())
@@ -35,6 +35,7 @@ type Peer struct {
 	ChaincodeAddress       string          `yaml:"chaincodeAddress,omitempty"`
 	Address                string          `yaml:"address,omitempty"`
 	AddressAutoDetect      bool            `yaml:"addressAutoDetect"`
+	QueryOnly              bool            `yaml:"queryOnly"`
 	Keepalive              *Keepalive      `yaml:"keepalive,omitempty"`
 	Gossip                 *Gossip         `yaml:"gossip,omitempty"`
 	Events                 *Events         `yaml:"events,omitempty"`
@@ -49,11 +50,17 @@ type Peer struct {
 	AdminService           *Service        `yaml:"adminService,omitempty"`
 	Handlers               *Handlers       `yaml:"handlers,omitempty"`
 	ValidatorPoolSize      int             `yaml:"validatorPoolSize,omitempty"`
+	Validation             *Validation     `yaml:"validation,omitempty"`
 	Discovery              *Discovery      `yaml:"discovery,omitempty"`
 
 	ExtraProperties map[string]interface{} `yaml:",inline,omitempty"`
 }
 
+type Validation struct {
+	TxTimeBudget         time.Duration `yaml:"txTimeBudget,omitempty"`
+	InvalidateOverBudget bool          `yaml:"invalidateOverBudget"`
+}
+
 type Keepalive struct {
 	MinInterval    time.Duration    `yaml:"minInterval,omitempty"`
 	Client         *ClientKeepalive `yaml:"client,omitempty"`
@@ -66,34 +73,42 @@ type ClientKeepalive struct {
 }
 
 type Gossip struct {
-	Bootstrap                  string          `yaml:"bootstrap,omitempty"`
-	UseLeaderElection          bool            `yaml:"useLeaderElection"`
-	OrgLeader                  bool            `yaml:"orgLeader"`
-	Endpoint                   string          `yaml:"endpoint,omitempty"`
-	MaxBlockCountToStore       int             `yaml:"maxBlockCountToStore,omitempty"`
-	MaxPropagationBurstLatency time.Duration   `yaml:"maxPropagationBurstLatency,omitempty"`
-	MaxPropagationBurstSize    int             `yaml:"maxPropagationBurstSize,omitempty"`
-	PropagateIterations        int             `yaml:"propagateIterations,omitempty"`
-	PropagatePeerNum           int             `yaml:"propagatePeerNum,omitempty"`
-	PullInterval               time.Duration   `yaml:"pullInterval,omitempty"`
-	PullPeerNum                int             `yaml:"pullPeerNum,omitempty"`
-	RequestStateInfoInterval   time.Duration   `yaml:"requestStateInfoInterval,omitempty"`
-	PublishStateInfoInterval   time.Duration   `yaml:"publishStateInfoInterval,omitempty"`
-	StateInfoRetentionInterval time.Duration   `yaml:"stateInfoRetentionInterval,omitempty"`
-	PublishCertPeriod          time.Duration   `yaml:"publishCertPeriod,omitempty"`
-	DialTimeout                time.Duration   `yaml:"dialTimeout,omitempty"`
-	ConnTimeout                time.Duration   `yaml:"connTimeout,omitempty"`
-	RecvBuffSize               int             `yaml:"recvBuffSize,omitempty"`
-	SendBuffSize               int             `yaml:"sendBuffSize,omitempty"`
-	DigestWaitTime             time.Duration   `yaml:"digestWaitTime,omitempty"`
-	RequestWaitTime            time.Duration   `yaml:"requestWaitTime,omitempty"`
-	ResponseWaitTime           time.Duration   `yaml:"responseWaitTime,omitempty"`
-	AliveTimeInterval          time.Duration   `yaml:"aliveTimeInterval,omitempty"`
-	AliveExpirationTimeout     time.Duration   `yaml:"aliveExpirationTimeout,omitempty"`
-	ReconnectInterval          time.Duration   `yaml:"reconnectInterval,omitempty"`
-	ExternalEndpoint           string          `yaml:"externalEndpoint,omitempty"`
-	Election                   *GossipElection `yaml:"election,omitempty"`
-	PvtData                    *GossipPvtData  `yaml:"pvtData,omitempty"`
+	Bootstrap                  string                     `yaml:"bootstrap,omitempty"`
+	UseLeaderElection          bool                       `yaml:"useLeaderElection"`
+	OrgLeader                  bool                       `yaml:"orgLeader"`
+	Endpoint                   string                     `yaml:"endpoint,omitempty"`
+	MaxBlockCountToStore       int                        `yaml:"maxBlockCountToStore,omitempty"`
+	MaxPropagationBurstLatency time.Duration              `yaml:"maxPropagationBurstLatency,omitempty"`
+	MaxPropagationBurstSize    int                        `yaml:"maxPropagationBurstSize,omitempty"`
+	PropagateIterations        int                        `yaml:"propagateIterations,omitempty"`
+	PropagatePeerNum           int                        `yaml:"propagatePeerNum,omitempty"`
+	PullInterval               time.Duration              `yaml:"pullInterval,omitempty"`
+	PullPeerNum                int                        `yaml:"pullPeerNum,omitempty"`
+	RequestStateInfoInterval   time.Duration              `yaml:"requestStateInfoInterval,omitempty"`
+	PublishStateInfoInterval   time.Duration              `yaml:"publishStateInfoInterval,omitempty"`
+	StateInfoRetentionInterval time.Duration              `yaml:"stateInfoRetentionInterval,omitempty"`
+	PublishCertPeriod          time.Duration              `yaml:"publishCertPeriod,omitempty"`
+	DialTimeout                time.Duration              `yaml:"dialTimeout,omitempty"`
+	ConnTimeout                time.Duration              `yaml:"connTimeout,omitempty"`
+	RecvBuffSize               int                        `yaml:"recvBuffSize,omitempty"`
+	SendBuffSize               int                        `yaml:"sendBuffSize,omitempty"`
+	DigestWaitTime             time.Duration              `yaml:"digestWaitTime,omitempty"`
+	RequestWaitTime            time.Duration              `yaml:"requestWaitTime,omitempty"`
+	ResponseWaitTime           time.Duration              `yaml:"responseWaitTime,omitempty"`
+	AliveTimeInterval          time.Duration              `yaml:"aliveTimeInterval,omitempty"`
+	AliveExpirationTimeout     time.Duration              `yaml:"aliveExpirationTimeout,omitempty"`
+	ReconnectInterval          time.Duration              `yaml:"reconnectInterval,omitempty"`
+	ExternalEndpoint           string                     `yaml:"externalEndpoint,omitempty"`
+	AdvertisedEndpoints        []GossipAdvertisedEndpoint `yaml:"advertisedEndpoints,omitempty"`
+	Election                   *GossipElection            `yaml:"election,omitempty"`
+	PvtData                    *GossipPvtData             `yaml:"pvtData,omitempty"`
+}
+
+type GossipAdvertisedEndpoint struct {
+	Endpoint   string   `yaml:"endpoint,omitempty"`
+	Priority   int32    `yaml:"priority,omitempty"`
+	Deprecated bool     `yaml:"deprecated,omitempty"`
+	Orgs       []string `yaml:"orgs,omitempty"`
 }
 
 type GossipElection struct {
@@ -262,6 +277,7 @@ type CouchDBConfig struct {
 
 type HistoryConfig struct {
 	EnableHistoryDatabase bool `yaml:"enableHistoryDatabase"`
+	CommitConcurrently    bool `yaml:"commitConcurrently"`
 }
 
 type Operations struct {
@@ -0,0 +1,25 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fabricconfig
+
+// TokenClient is the configuration of a token client. The keys are the
+// names of the fields of the ClientConfig of the token client.
+type TokenClient struct {
+	ChannelID     string                 `yaml:"channelId,omitempty" json:"channelId,omitempty"`
+	MSPDir        string                 `yaml:"mspDir,omitempty" json:"mspDir,omitempty"`
+	MSPID         string                 `yaml:"mspId,omitempty" json:"mspId,omitempty"`
+	TLSEnabled    bool                   `yaml:"tlsEnabled" json:"tlsEnabled"`
+	OrdererCfg    *TokenClientConnection `yaml:"ordererCfg,omitempty" json:"ordererCfg,omitempty"`
+	CommitPeerCfg *TokenClientConnection `yaml:"commitPeerCfg,omitempty" json:"commitPeerCfg,omitempty"`
+	ProverPeerCfg *TokenClientConnection `yaml:"proverPeerCfg,omitempty" json:"proverPeerCfg,omitempty"`
+}
+
+type TokenClientConnection struct {
+	Address            string `yaml:"address,omitempty" json:"address,omitempty"`
+	TLSRootCertFile    string `yaml:"tlsRootCertFile,omitempty" json:"tlsRootCertFile,omitempty"`
+	ServerNameOverride string `yaml:"serverNameOverride,omitempty" json:"serverNameOverride,omitempty"`
+}
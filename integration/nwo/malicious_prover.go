@@ -0,0 +1,144 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package nwo
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/protos/token"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+// TamperFunc manipulates the token transaction a prover assembled for the
+// command, e.g. to redirect its outputs or to inflate their quantities.
+type TamperFunc func(command *token.Command, tx *token.TokenTransaction)
+
+// MaliciousProver is a prover test double. It forwards the commands of token
+// clients to the prover of a peer and tampers with the token transactions in
+// the responses before returning them, so that tests can check how clients
+// and committers defend against a byzantine prover. It serves TLS with the
+// certificate of the peer, so that the token clients of the peer trust it.
+//
+// The signatures of the tampered responses are left unchanged and no longer
+// match their content.
+type MaliciousProver struct {
+	Address string
+	// Tamper, when set, tampers with the token transactions; the responses
+	// are forwarded unchanged otherwise
+	Tamper TamperFunc
+
+	proverAddress string
+	caPEM         []byte
+	certPEM       []byte
+	keyPEM        []byte
+}
+
+// MaliciousProver returns a malicious prover in front of the prover of the
+// peer, on a port reserved in the network. Run the returned prover with
+// ifrit, e.g. ifrit.Invoke(maliciousProver), and point the prover peer of
+// the token clients to its address.
+func (n *Network) MaliciousProver(p *Peer, tamper TamperFunc) *MaliciousProver {
+	tlsDir := n.PeerLocalTLSDir(p)
+	caPEM, err := ioutil.ReadFile(filepath.Join(tlsDir, "ca.crt"))
+	Expect(err).NotTo(HaveOccurred())
+	certPEM, err := ioutil.ReadFile(filepath.Join(tlsDir, "server.crt"))
+	Expect(err).NotTo(HaveOccurred())
+	keyPEM, err := ioutil.ReadFile(filepath.Join(tlsDir, "server.key"))
+	Expect(err).NotTo(HaveOccurred())
+
+	return &MaliciousProver{
+		Address:       fmt.Sprintf("127.0.0.1:%d", n.ReservePort()),
+		Tamper:        tamper,
+		proverAddress: n.PeerAddress(p, ListenPort),
+		caPEM:         caPEM,
+		certPEM:       certPEM,
+		keyPEM:        keyPEM,
+	}
+}
+
+// Run serves the malicious prover until it is signaled.
+func (m *MaliciousProver) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	grpcClient, err := comm.NewGRPCClient(comm.ClientConfig{
+		Timeout: 10 * time.Second,
+		SecOpts: &comm.SecureOptions{UseTLS: true, ServerRootCAs: [][]byte{m.caPEM}},
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed creating the client of the prover")
+	}
+	conn, err := grpcClient.NewConnection(m.proverAddress, "")
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed connecting to the prover %s", m.proverAddress))
+	}
+	defer conn.Close()
+
+	server, err := comm.NewGRPCServer(m.Address, comm.ServerConfig{
+		SecOpts: &comm.SecureOptions{UseTLS: true, Certificate: m.certPEM, Key: m.keyPEM},
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed creating the malicious prover server")
+	}
+	token.RegisterProverServer(server.Server(), &tamperingProver{
+		prover: token.NewProverClient(conn),
+		tamper: m.Tamper,
+	})
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- server.Start() }()
+	close(ready)
+
+	select {
+	case <-signals:
+		server.Stop()
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
+
+// tamperingProver forwards the commands to the prover and tampers with the
+// token transactions it responds.
+type tamperingProver struct {
+	prover token.ProverClient
+	tamper TamperFunc
+}
+
+func (t *tamperingProver) ProcessCommand(ctx context.Context, sc *token.SignedCommand) (*token.SignedCommandResponse, error) {
+	scr, err := t.prover.ProcessCommand(ctx, sc)
+	if err != nil || t.tamper == nil {
+		return scr, err
+	}
+
+	response := &token.CommandResponse{}
+	err = proto.Unmarshal(scr.Response, response)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling command response")
+	}
+	tx := response.GetTokenTransaction()
+	if tx == nil {
+		return scr, nil
+	}
+	command := &token.Command{}
+	err = proto.Unmarshal(sc.Command, command)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling command")
+	}
+
+	t.tamper(command, tx)
+	tampered, err := proto.Marshal(response)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling command response")
+	}
+	return &token.SignedCommandResponse{Response: tampered, Signature: scr.Signature}, nil
+}
@@ -8,6 +8,7 @@ package nwo
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -136,6 +137,13 @@ type Network struct {
 	EventuallyTimeout time.Duration
 	MetricsProvider   string
 	StatsdEndpoint    string
+	// ProfilingEnabled serves the pprof endpoints of the peers and the
+	// orderers on their profile ports.
+	ProfilingEnabled bool
+	// PeerBinaries are the binaries of the peers that run another version
+	// than Components.Peer(), such as a release fetched with FetchRelease,
+	// by peer ID.
+	PeerBinaries map[string]string
 
 	PortsByBrokerID  map[string]Ports
 	PortsByOrdererID map[string]Ports
@@ -311,6 +319,71 @@ func (n *Network) WritePeerConfig(p *Peer, config *fabricconfig.Core) {
 	Expect(err).NotTo(HaveOccurred())
 }
 
+// TokenClientDir returns the path to the directory of the configuration
+// files of the token clients.
+func (n *Network) TokenClientDir() string {
+	return filepath.Join(n.RootDir, "tokenclients")
+}
+
+// TokenClientConfigPath returns the path to the configuration file, with the
+// extension of its format, of the token client of the specified user of the
+// peer on the channel.
+func (n *Network) TokenClientConfigPath(p *Peer, channel, user, ext string) string {
+	return filepath.Join(n.TokenClientDir(), fmt.Sprintf("%s@%s-%s.%s", user, p.ID(), channel, ext))
+}
+
+// TokenClientConfig generates the YAML configuration file of a token client of
+// the specified user of the peer on the channel, and returns its path. The
+// client uses the peer as its prover and commit peer, and submits its
+// transactions to the orderer.
+func (n *Network) TokenClientConfig(p *Peer, o *Orderer, channel, user string) string {
+	configBytes, err := yaml.Marshal(n.tokenClientConfig(p, o, channel, user))
+	Expect(err).NotTo(HaveOccurred())
+
+	return n.writeTokenClientConfig(n.TokenClientConfigPath(p, channel, user, "yaml"), configBytes)
+}
+
+// TokenClientConfigJSON is like TokenClientConfig, but the configuration file
+// is JSON.
+func (n *Network) TokenClientConfigJSON(p *Peer, o *Orderer, channel, user string) string {
+	configBytes, err := json.MarshalIndent(n.tokenClientConfig(p, o, channel, user), "", "  ")
+	Expect(err).NotTo(HaveOccurred())
+
+	return n.writeTokenClientConfig(n.TokenClientConfigPath(p, channel, user, "json"), configBytes)
+}
+
+func (n *Network) tokenClientConfig(p *Peer, o *Orderer, channel, user string) *fabricconfig.TokenClient {
+	org := n.Organization(p.Organization)
+	Expect(org).NotTo(BeNil())
+
+	peerCfg := &fabricconfig.TokenClientConnection{
+		Address:         n.PeerAddress(p, ListenPort),
+		TLSRootCertFile: filepath.Join(n.PeerLocalTLSDir(p), "ca.crt"),
+	}
+	return &fabricconfig.TokenClient{
+		ChannelID:  channel,
+		MSPDir:     n.PeerUserMSPDir(p, user),
+		MSPID:      org.MSPID,
+		TLSEnabled: true,
+		OrdererCfg: &fabricconfig.TokenClientConnection{
+			Address:         n.OrdererAddress(o, ListenPort),
+			TLSRootCertFile: filepath.Join(n.OrdererLocalTLSDir(o), "ca.crt"),
+		},
+		CommitPeerCfg: peerCfg,
+		ProverPeerCfg: peerCfg,
+	}
+}
+
+func (n *Network) writeTokenClientConfig(path string, configBytes []byte) string {
+	err := os.MkdirAll(n.TokenClientDir(), 0755)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = ioutil.WriteFile(path, configBytes, 0644)
+	Expect(err).NotTo(HaveOccurred())
+
+	return path
+}
+
 // peerUserCryptoDir returns the path to the directory containing the
 // certificates and keys for the specified user of the peer.
 func (n *Network) peerUserCryptoDir(p *Peer, user, cryptoMaterialType string) string {
@@ -676,40 +749,42 @@ func (n *Network) CreateAndJoinChannel(o *Orderer, channelName string) {
 	n.JoinChannel(channelName, o, peers...)
 }
 
-// UpdateChannelAnchors determines the anchor peers for the specified channel,
-// creates an anchor peer update transaction for each organization, and submits
-// the update transactions to the orderer.
+// UpdateChannelAnchors determines the anchor peers for the specified channel
+// and updates the anchor peers of each organization that has some. The
+// updates are computed from the current config of the channel, so this can
+// be called again after peers are added to the network.
 func (n *Network) UpdateChannelAnchors(o *Orderer, channelName string) {
-	tempFile, err := ioutil.TempFile("", "update-anchors")
-	Expect(err).NotTo(HaveOccurred())
-	tempFile.Close()
-	defer os.Remove(tempFile.Name())
-
-	peersByOrg := map[string]*Peer{}
+	orgs := map[string]bool{}
 	for _, p := range n.AnchorsForChannel(channelName) {
-		peersByOrg[p.Organization] = p
+		if !orgs[p.Organization] {
+			orgs[p.Organization] = true
+			n.UpdateOrgAnchors(o, channelName, p.Organization)
+		}
 	}
+}
 
-	for orgName, p := range peersByOrg {
-		anchorUpdate := commands.OutputAnchorPeersUpdate{
-			OutputAnchorPeersUpdate: tempFile.Name(),
-			ChannelID:               channelName,
-			Profile:                 n.ProfileForChannel(channelName),
-			ConfigPath:              n.RootDir,
-			AsOrg:                   orgName,
+// UpdateOrgAnchors sets the anchor peers of the organization for the
+// specified channel to its peers that are anchors for the channel. An admin
+// of the organization submits the update, which is skipped when the anchor
+// peers are already up to date.
+func (n *Network) UpdateOrgAnchors(o *Orderer, channelName, orgName string) {
+	var anchors []*Peer
+	var endpoints []string
+	for _, p := range n.AnchorsForChannel(channelName) {
+		if p.Organization == orgName {
+			anchors = append(anchors, p)
+			endpoints = append(endpoints, n.PeerAddress(p, ListenPort))
 		}
-		sess, err := n.ConfigTxGen(anchorUpdate)
-		Expect(err).NotTo(HaveOccurred())
-		Eventually(sess, n.EventuallyTimeout).Should(gexec.Exit(0))
-
-		sess, err = n.PeerAdminSession(p, commands.ChannelUpdate{
-			ChannelID: channelName,
-			Orderer:   n.OrdererAddress(o, ListenPort),
-			File:      tempFile.Name(),
-		})
-		Expect(err).NotTo(HaveOccurred())
-		Eventually(sess, n.EventuallyTimeout).Should(gexec.Exit(0))
 	}
+	Expect(anchors).NotTo(BeEmpty(), "organization %s has no anchor peer for channel %s", orgName, channelName)
+
+	sess, err := n.PeerAdminSession(anchors[0], commands.ChannelUpdateAnchors{
+		ChannelID:   channelName,
+		Orderer:     n.OrdererAddress(o, ListenPort),
+		AnchorPeers: endpoints,
+	})
+	Expect(err).NotTo(HaveOccurred())
+	Eventually(sess, n.EventuallyTimeout).Should(gexec.Exit(0))
 }
 
 // CreateChannel will submit an existing create channel transaction to the
@@ -904,6 +979,10 @@ func (n *Network) PeerRunner(p *Peer) *ginkgomon.Runner {
 		commands.NodeStart{PeerID: p.ID()},
 		fmt.Sprintf("FABRIC_CFG_PATH=%s", n.PeerDir(p)),
 	)
+	if binary, ok := n.PeerBinaries[p.ID()]; ok {
+		cmd.Path = binary
+		cmd.Args[0] = binary
+	}
 
 	return ginkgomon.New(ginkgomon.Config{
 		AnsiColorCode:     n.nextColor(),
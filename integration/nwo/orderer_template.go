@@ -41,7 +41,7 @@ General:
   LocalMSPDir: {{ $w.OrdererLocalMSPDir Orderer }}
   LocalMSPID: {{ ($w.Organization Orderer.Organization).MSPID }}
   Profile:
-    Enabled: false
+    Enabled: {{ .ProfilingEnabled }}
     Address: 127.0.0.1:{{ .OrdererPort Orderer "Profile" }}
   BCCSP:
     Default: SW
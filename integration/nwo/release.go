@@ -0,0 +1,130 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package nwo
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+
+	. "github.com/onsi/gomega"
+)
+
+// ReleaseURL is the template of the URL of the binaries of a fabric release,
+// formatted with the platform, such as linux-amd64, and the version. The
+// FABRIC_RELEASE_URL environment variable overrides it.
+const ReleaseURL = "https://nexus.hyperledger.org/content/repositories/releases/org/hyperledger/fabric/hyperledger-fabric/%[1]s-%[2]s/hyperledger-fabric-%[1]s-%[2]s.tar.gz"
+
+// FetchRelease fetches the peer and orderer binaries of a fabric release, such
+// as 1.4.0, so that networks can run released versions alongside the binaries
+// built from the sources, e.g. to test version skew. The archives of the
+// releases are kept in the FABRIC_RELEASE_CACHE directory, when set.
+func (c *Components) FetchRelease(version string) {
+	if c.Paths == nil {
+		c.Paths = map[string]string{}
+	}
+
+	archive := releaseArchive(version)
+	if os.Getenv("FABRIC_RELEASE_CACHE") == "" {
+		defer os.RemoveAll(filepath.Dir(archive))
+	}
+
+	binDir, err := ioutil.TempDir("", "fabric-"+version)
+	Expect(err).NotTo(HaveOccurred())
+	binaries := map[string]bool{"peer": true, "orderer": true}
+	extractBinaries(archive, binDir, binaries)
+	for name := range binaries {
+		binary := filepath.Join(binDir, name)
+		Expect(binary).To(BeAnExistingFile(), "release %s has no %s binary", version, name)
+		c.Paths[releaseKey(name, version)] = binary
+	}
+}
+
+// ReleasedPeer returns the peer binary of a release fetched with FetchRelease.
+func (c *Components) ReleasedPeer(version string) string {
+	return c.Paths[releaseKey("peer", version)]
+}
+
+// ReleasedOrderer returns the orderer binary of a release fetched with
+// FetchRelease.
+func (c *Components) ReleasedOrderer(version string) string {
+	return c.Paths[releaseKey("orderer", version)]
+}
+
+func releaseKey(name, version string) string {
+	return name + "-" + version
+}
+
+// releaseArchive downloads the archive of the binaries of a release, unless
+// it is cached, and returns its path.
+func releaseArchive(version string) string {
+	urlTemplate := os.Getenv("FABRIC_RELEASE_URL")
+	if urlTemplate == "" {
+		urlTemplate = ReleaseURL
+	}
+	url := fmt.Sprintf(urlTemplate, runtime.GOOS+"-"+runtime.GOARCH, version)
+
+	cacheDir := os.Getenv("FABRIC_RELEASE_CACHE")
+	if cacheDir == "" {
+		var err error
+		cacheDir, err = ioutil.TempDir("", "fabric-release")
+		Expect(err).NotTo(HaveOccurred())
+	}
+	archive := filepath.Join(cacheDir, path.Base(url))
+	if _, err := os.Stat(archive); err == nil {
+		return archive
+	}
+
+	resp, err := http.Get(url)
+	Expect(err).NotTo(HaveOccurred())
+	defer resp.Body.Close()
+	Expect(resp.StatusCode).To(Equal(http.StatusOK), "failed downloading %s", url)
+
+	// download next to the archive, so that failed downloads are not cached
+	tmp, err := ioutil.TempFile(cacheDir, path.Base(url))
+	Expect(err).NotTo(HaveOccurred())
+	_, err = io.Copy(tmp, resp.Body)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(tmp.Close()).To(Succeed())
+	Expect(os.Rename(tmp.Name(), archive)).To(Succeed())
+	return archive
+}
+
+// extractBinaries extracts the binaries of the bin directory of the archive
+// of a release to dir.
+func extractBinaries(archive, dir string, binaries map[string]bool) {
+	f, err := os.Open(archive)
+	Expect(err).NotTo(HaveOccurred())
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	Expect(err).NotTo(HaveOccurred())
+	tr := tar.NewReader(gz)
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return
+		}
+		Expect(err).NotTo(HaveOccurred())
+		name := path.Base(header.Name)
+		if path.Base(path.Dir(header.Name)) != "bin" || !binaries[name] {
+			continue
+		}
+		out, err := os.OpenFile(filepath.Join(dir, name), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = io.Copy(out, tr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out.Close()).To(Succeed())
+	}
+}
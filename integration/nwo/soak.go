@@ -0,0 +1,309 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package nwo
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/integration/nwo/commands"
+	"github.com/hyperledger/fabric/protos/common"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+// The environment variables configuring the soak profile. Soak tests are
+// skipped unless SoakDurationEnvVar is set.
+const (
+	SoakDurationEnvVar           = "FABRIC_SOAK_DURATION"
+	SoakRestartIntervalEnvVar    = "FABRIC_SOAK_RESTART_INTERVAL"
+	SoakWarmupEnvVar             = "FABRIC_SOAK_WARMUP"
+	SoakProfileDirEnvVar         = "FABRIC_SOAK_PROFILE_DIR"
+	SoakMaxGoroutineGrowthEnvVar = "FABRIC_SOAK_MAX_GOROUTINE_GROWTH"
+	SoakMaxHeapGrowthEnvVar      = "FABRIC_SOAK_MAX_HEAP_GROWTH"
+)
+
+// SoakProfile is the profile of a soak test, which keeps a network busy for
+// a long time while restarting its components, to catch state divergence and
+// resource leaks that short tests do not exhibit.
+type SoakProfile struct {
+	// Duration is how long the network is kept busy
+	Duration time.Duration
+	// RestartInterval is how long the network is kept busy between two
+	// restarts of a component
+	RestartInterval time.Duration
+	// Warmup is how long a process runs before its resources are taken as
+	// the baseline of its growth
+	Warmup time.Duration
+	// ProfileDir is the directory the heap profiles are captured in; it is
+	// kept after the network is cleaned up
+	ProfileDir string
+	// MaxGoroutineGrowth is the number of goroutines a process may gain
+	// over its baseline
+	MaxGoroutineGrowth int
+	// MaxHeapGrowth is the share of its baseline heap a process may gain
+	MaxHeapGrowth float64
+}
+
+// SoakProfileFromEnv returns the soak profile configured by the environment,
+// and false when soak tests are not enabled. Durations are parsed with
+// time.ParseDuration, e.g. FABRIC_SOAK_DURATION=4h.
+func SoakProfileFromEnv() (*SoakProfile, bool) {
+	duration := os.Getenv(SoakDurationEnvVar)
+	if duration == "" {
+		return nil, false
+	}
+	profile := &SoakProfile{
+		Duration:           parseDurationEnv(SoakDurationEnvVar, duration),
+		RestartInterval:    10 * time.Minute,
+		Warmup:             5 * time.Minute,
+		ProfileDir:         os.Getenv(SoakProfileDirEnvVar),
+		MaxGoroutineGrowth: 50,
+		MaxHeapGrowth:      1,
+	}
+	if v := os.Getenv(SoakRestartIntervalEnvVar); v != "" {
+		profile.RestartInterval = parseDurationEnv(SoakRestartIntervalEnvVar, v)
+	}
+	if v := os.Getenv(SoakWarmupEnvVar); v != "" {
+		profile.Warmup = parseDurationEnv(SoakWarmupEnvVar, v)
+	}
+	if v := os.Getenv(SoakMaxGoroutineGrowthEnvVar); v != "" {
+		growth, err := strconv.Atoi(v)
+		Expect(err).NotTo(HaveOccurred(), "invalid %s", SoakMaxGoroutineGrowthEnvVar)
+		profile.MaxGoroutineGrowth = growth
+	}
+	if v := os.Getenv(SoakMaxHeapGrowthEnvVar); v != "" {
+		growth, err := strconv.ParseFloat(v, 64)
+		Expect(err).NotTo(HaveOccurred(), "invalid %s", SoakMaxHeapGrowthEnvVar)
+		profile.MaxHeapGrowth = growth
+	}
+	if profile.ProfileDir == "" {
+		dir, err := ioutil.TempDir("", "fabric-soak-profiles")
+		Expect(err).NotTo(HaveOccurred())
+		profile.ProfileDir = dir
+	}
+	return profile, true
+}
+
+func parseDurationEnv(name, value string) time.Duration {
+	d, err := time.ParseDuration(value)
+	Expect(err).NotTo(HaveOccurred(), "invalid %s", name)
+	return d
+}
+
+// ResourceSample is the resource usage of a process at a point in time.
+type ResourceSample struct {
+	Time       time.Time
+	Goroutines int
+	HeapInuse  uint64
+	// HeapProfile is the path to the heap profile captured with the sample
+	HeapProfile string
+}
+
+// ResourceMonitor samples the resources of the processes of a network over a
+// soak test, through their pprof endpoints, and checks that they do not grow
+// over the lifetime of each process. The network must be created with
+// ProfilingEnabled.
+type ResourceMonitor struct {
+	Network *Network
+	Profile *SoakProfile
+
+	started map[string]time.Time
+	samples map[string][]ResourceSample
+}
+
+// NewResourceMonitor returns a monitor of the processes of the network.
+func NewResourceMonitor(n *Network, profile *SoakProfile) *ResourceMonitor {
+	Expect(n.ProfilingEnabled).To(BeTrue(), "resources are sampled through the pprof endpoints")
+	err := os.MkdirAll(profile.ProfileDir, 0755)
+	Expect(err).NotTo(HaveOccurred())
+	return &ResourceMonitor{
+		Network: n,
+		Profile: profile,
+		started: map[string]time.Time{},
+		samples: map[string][]ResourceSample{},
+	}
+}
+
+// Started records that the process with the passed name was (re)started;
+// its earlier samples no longer count towards its growth.
+func (m *ResourceMonitor) Started(name string) {
+	m.started[name] = time.Now()
+	m.samples[name] = nil
+}
+
+// SamplePeer samples the resources of the peer.
+func (m *ResourceMonitor) SamplePeer(p *Peer) ResourceSample {
+	return m.sample(p.ID(), m.Network.PeerAddress(p, ProfilePort))
+}
+
+// SampleOrderer samples the resources of the orderer.
+func (m *ResourceMonitor) SampleOrderer(o *Orderer) ResourceSample {
+	return m.sample(o.ID(), m.Network.OrdererAddress(o, ProfilePort))
+}
+
+func (m *ResourceMonitor) sample(name, address string) ResourceSample {
+	if _, ok := m.started[name]; !ok {
+		m.Started(name)
+	}
+	now := time.Now()
+	heapProfile := filepath.Join(m.Profile.ProfileDir, fmt.Sprintf("%s-%s.heap.pb.gz", name, now.Format("20060102T150405")))
+	sample := ResourceSample{
+		Time:        now,
+		Goroutines:  goroutineCount(address),
+		HeapInuse:   heapInuse(address),
+		HeapProfile: heapProfile,
+	}
+	saveProfile(address, "heap", heapProfile)
+	m.samples[name] = append(m.samples[name], sample)
+	fmt.Fprintf(GinkgoWriter, "%s: %d goroutines, %d bytes of heap in use, profile %s\n", name, sample.Goroutines, sample.HeapInuse, heapProfile)
+	return sample
+}
+
+// CheckGrowth checks that the resources of the process did not grow beyond
+// the limits of the profile since its baseline, the first sample taken after
+// the warmup. A leak persists across samples while garbage does not, so the
+// heap is compared at its lowest over the last three samples.
+func (m *ResourceMonitor) CheckGrowth(name string) {
+	var baseline *ResourceSample
+	samples := m.samples[name]
+	for i := range samples {
+		if samples[i].Time.Sub(m.started[name]) >= m.Profile.Warmup {
+			baseline = &samples[i]
+			samples = samples[i+1:]
+			break
+		}
+	}
+	if baseline == nil || len(samples) == 0 {
+		return
+	}
+
+	last := samples[len(samples)-1]
+	Expect(last.Goroutines).To(
+		BeNumerically("<=", baseline.Goroutines+m.Profile.MaxGoroutineGrowth),
+		"goroutines of %s grew from %d to %d", name, baseline.Goroutines, last.Goroutines,
+	)
+
+	heap := last.HeapInuse
+	for _, s := range samples[max(0, len(samples)-3):] {
+		if s.HeapInuse < heap {
+			heap = s.HeapInuse
+		}
+	}
+	limit := uint64(float64(baseline.HeapInuse) * (1 + m.Profile.MaxHeapGrowth))
+	Expect(heap).To(
+		BeNumerically("<=", limit),
+		"heap of %s grew from %d to %d bytes; see the profiles %s and %s", name, baseline.HeapInuse, heap, baseline.HeapProfile, last.HeapProfile,
+	)
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// goroutineCount returns the number of goroutines of the process serving
+// pprof at address.
+func goroutineCount(address string) int {
+	body := profileBody(address, "goroutine?debug=1")
+	defer body.Close()
+
+	// the first line reads "goroutine profile: total N"
+	line, err := bufio.NewReader(body).ReadString('\n')
+	Expect(err).NotTo(HaveOccurred())
+	fields := strings.Fields(line)
+	Expect(fields).NotTo(BeEmpty())
+	count, err := strconv.Atoi(fields[len(fields)-1])
+	Expect(err).NotTo(HaveOccurred(), "unexpected goroutine profile header %q", line)
+	return count
+}
+
+// heapInuse returns the bytes of heap in use by the process serving pprof at
+// address, as reported by the runtime statistics of its heap profile.
+func heapInuse(address string) uint64 {
+	body := profileBody(address, "heap?debug=1")
+	defer body.Close()
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "# HeapInuse = ") {
+			inuse, err := strconv.ParseUint(strings.TrimPrefix(line, "# HeapInuse = "), 10, 64)
+			Expect(err).NotTo(HaveOccurred())
+			return inuse
+		}
+	}
+	Expect(scanner.Err()).NotTo(HaveOccurred())
+	Fail(fmt.Sprintf("no HeapInuse in the heap profile of %s", address))
+	return 0
+}
+
+func saveProfile(address, profile, path string) {
+	body := profileBody(address, profile)
+	defer body.Close()
+
+	f, err := os.Create(path)
+	Expect(err).NotTo(HaveOccurred())
+	defer f.Close()
+	_, err = io.Copy(f, body)
+	Expect(err).NotTo(HaveOccurred())
+}
+
+func profileBody(address, profile string) io.ReadCloser {
+	resp, err := http.Get(fmt.Sprintf("http://%s/debug/pprof/%s", address, profile))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	return resp.Body
+}
+
+// BlockchainInfo returns the height and the hash of the last block of the
+// ledger of the channel on the peer.
+func (n *Network) BlockchainInfo(p *Peer, channel string) *common.BlockchainInfo {
+	sess, err := n.PeerAdminSession(p, commands.ChannelInfo{ChannelID: channel})
+	Expect(err).NotTo(HaveOccurred())
+	Eventually(sess, n.EventuallyTimeout).Should(gexec.Exit(0))
+
+	info := &common.BlockchainInfo{}
+	output := strings.TrimPrefix(string(sess.Buffer().Contents()), "Blockchain info:")
+	err = json.Unmarshal([]byte(output), info)
+	Expect(err).NotTo(HaveOccurred())
+	return info
+}
+
+// CheckLedgersConverge waits for the ledgers of the channel on the peers to
+// reach the same height, and checks that they end with the same block.
+func (n *Network) CheckLedgersConverge(channel string, peers ...*Peer) {
+	Expect(peers).NotTo(BeEmpty())
+	height := func(p *Peer) func() uint64 {
+		return func() uint64 { return n.BlockchainInfo(p, channel).Height }
+	}
+
+	reference := n.BlockchainInfo(peers[0], channel)
+	for _, p := range peers[1:] {
+		Eventually(height(p), n.EventuallyTimeout).Should(BeNumerically(">=", reference.Height))
+	}
+	// the reference may have moved on in the meantime
+	reference = n.BlockchainInfo(peers[0], channel)
+	for _, p := range peers[1:] {
+		Eventually(height(p), n.EventuallyTimeout).Should(Equal(reference.Height))
+		info := n.BlockchainInfo(p, channel)
+		Expect(info.CurrentBlockHash).To(Equal(reference.CurrentBlockHash), "ledger of %s diverged from %s at height %d", p.ID(), peers[0].ID(), info.Height)
+	}
+}
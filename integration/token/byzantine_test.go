@@ -0,0 +1,272 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"io/ioutil"
+	"os"
+	"syscall"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/integration/nwo"
+	"github.com/hyperledger/fabric/msp"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	pmsp "github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	tokenclient "github.com/hyperledger/fabric/token/client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("Byzantine prover", func() {
+	var (
+		testDir string
+		client  *docker.Client
+		network *nwo.Network
+		process ifrit.Process
+
+		orderer *nwo.Orderer
+		peer    *nwo.Peer
+
+		maliciousProver *nwo.MaliciousProver
+		proverProcess   ifrit.Process
+
+		txSubmitter *tokenclient.TxSubmitter
+		signer      tk.SigningIdentity
+		honest      *tokenclient.ProverPeer
+		malicious   *tokenclient.ProverPeer
+
+		bob     []byte
+		mallory []byte
+		owned   []*token.TokenOutput
+	)
+
+	BeforeEach(func() {
+		Skip("Skipping token e2e test until token transaction is enabled after v1.4")
+
+		var err error
+		testDir, err = ioutil.TempDir("", "token-byzantine")
+		Expect(err).NotTo(HaveOccurred())
+
+		client, err = docker.NewClientFromEnv()
+		Expect(err).NotTo(HaveOccurred())
+
+		network = nwo.New(nwo.BasicSolo(), testDir, client, 31000, components)
+		network.GenerateConfigTree()
+		err = updateConfigtx(network)
+		Expect(err).NotTo(HaveOccurred())
+		network.Bootstrap()
+
+		networkRunner := network.NetworkGroupRunner()
+		process = ifrit.Invoke(networkRunner)
+		Eventually(process.Ready()).Should(BeClosed())
+
+		orderer = network.Orderer("orderer")
+		network.CreateAndJoinChannel(orderer, "testchannel")
+		peer = network.Peer("Org1", "peer1")
+
+		maliciousProver = network.MaliciousProver(peer, nil)
+		proverProcess = ifrit.Invoke(maliciousProver)
+		Eventually(proverProcess.Ready()).Should(BeClosed())
+
+		config, err := tokenclient.LoadConfig(network.TokenClientConfig(peer, orderer, "testchannel", "User1"))
+		Expect(err).NotTo(HaveOccurred())
+		txSubmitter, err = tokenclient.NewTxSubmitter(config)
+		Expect(err).NotTo(HaveOccurred())
+		mspSigner, err := mspmgmt.GetLocalMSP().GetDefaultSigningIdentity()
+		Expect(err).NotTo(HaveOccurred())
+		signer = &signingIdentity{SigningIdentity: mspSigner}
+
+		honest, err = tokenclient.NewProverPeer(config)
+		Expect(err).NotTo(HaveOccurred())
+		maliciousConfig := *config
+		maliciousConfig.ProverPeerCfg.Address = maliciousProver.Address
+		malicious, err = tokenclient.NewProverPeer(&maliciousConfig)
+		Expect(err).NotTo(HaveOccurred())
+
+		bob = serializedIdentity(network, network.Peer("Org1", "peer1"), "User2")
+		mallory = serializedIdentity(network, network.Peer("Org2", "peer1"), "User1")
+
+		By("issuing tokens with the honest prover")
+		creator, err := signer.Serialize()
+		Expect(err).NotTo(HaveOccurred())
+		response, err := honest.RequestImport([]*token.TokenToIssue{{Recipient: creator, Type: "PDQ", Quantity: 100}}, signer)
+		Expect(err).NotTo(HaveOccurred())
+		committed, err := submit(txSubmitter, tokenTransaction(response))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(committed).To(BeTrue())
+
+		owned, err = honest.ListTokens(signer)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(owned).To(HaveLen(1))
+	})
+
+	AfterEach(func() {
+		if txSubmitter != nil {
+			txSubmitter.Close()
+		}
+		for _, prover := range []*tokenclient.ProverPeer{honest, malicious} {
+			if prover != nil {
+				prover.Close()
+			}
+		}
+		if proverProcess != nil {
+			proverProcess.Signal(syscall.SIGTERM)
+			Eventually(proverProcess.Wait(), time.Minute).Should(Receive())
+		}
+		if process != nil {
+			process.Signal(syscall.SIGTERM)
+			Eventually(process.Wait(), time.Minute).Should(Receive())
+		}
+		if network != nil {
+			network.Cleanup()
+		}
+		os.RemoveAll(testDir)
+	})
+
+	Context("when the prover redirects the outputs of a transfer", func() {
+		BeforeEach(func() {
+			maliciousProver.Tamper = func(command *token.Command, tx *token.TokenTransaction) {
+				for _, output := range tx.GetPlainAction().GetPlainTransfer().GetOutputs() {
+					output.Owner = mallory
+				}
+			}
+		})
+
+		It("is detected by the client, as the committer cannot tell", func() {
+			tokenIDs := [][]byte{owned[0].Id}
+			shares := []*token.RecipientTransferShare{{Recipient: bob, Quantity: 100}}
+			response, err := malicious.RequestTransfer(tokenIDs, shares, signer)
+			Expect(err).NotTo(HaveOccurred())
+			tx := tokenTransaction(response)
+
+			By("verifying the transaction against the request")
+			err = tokenclient.VerifyTransfer(tx, tokenIDs, shares)
+			Expect(errors.Cause(err)).To(Equal(tokenclient.ErrUnexpectedTransaction))
+			Expect(err).To(MatchError(ContainSubstring("owned by another recipient")))
+
+			By("submitting the transaction regardless")
+			committed, err := submit(txSubmitter, tx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(committed).To(BeTrue())
+			remaining, err := honest.ListTokens(signer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(remaining).To(BeEmpty())
+		})
+	})
+
+	Context("when the prover inflates the quantity of a transfer", func() {
+		BeforeEach(func() {
+			maliciousProver.Tamper = func(command *token.Command, tx *token.TokenTransaction) {
+				for _, output := range tx.GetPlainAction().GetPlainTransfer().GetOutputs() {
+					output.Quantity += 1000
+				}
+			}
+		})
+
+		It("is detected by the client and invalidated by the committer", func() {
+			tokenIDs := [][]byte{owned[0].Id}
+			shares := []*token.RecipientTransferShare{{Recipient: bob, Quantity: 100}}
+			response, err := malicious.RequestTransfer(tokenIDs, shares, signer)
+			Expect(err).NotTo(HaveOccurred())
+			tx := tokenTransaction(response)
+
+			By("verifying the transaction against the request")
+			err = tokenclient.VerifyTransfer(tx, tokenIDs, shares)
+			Expect(err).To(MatchError(ContainSubstring("transfers 1100 tokens instead of 100")))
+
+			By("submitting the transaction regardless")
+			committed, err := submit(txSubmitter, tx)
+			Expect(err).To(MatchError(ContainSubstring("status is not valid")))
+			Expect(committed).To(BeFalse())
+			remaining, err := honest.ListTokens(signer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(remaining).To(HaveLen(1))
+			Expect(remaining[0].Quantity).To(Equal(uint64(100)))
+		})
+	})
+
+	Context("when the prover inflates the remainder of a redemption", func() {
+		BeforeEach(func() {
+			maliciousProver.Tamper = func(command *token.Command, tx *token.TokenTransaction) {
+				outputs := tx.GetPlainAction().GetPlainRedeem().GetOutputs()
+				if len(outputs) == 2 {
+					outputs[1].Quantity += 1000
+				}
+			}
+		})
+
+		It("is invalidated by the committer", func() {
+			tokenIDs := [][]byte{owned[0].Id}
+			response, err := malicious.RequestRedeem(tokenIDs, 10, signer)
+			Expect(err).NotTo(HaveOccurred())
+			tx := tokenTransaction(response)
+
+			By("verifying the transaction against the request, which does not cover the remainder")
+			err = tokenclient.VerifyRedeem(tx, tokenIDs, 10)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("submitting the transaction")
+			committed, err := submit(txSubmitter, tx)
+			Expect(err).To(MatchError(ContainSubstring("status is not valid")))
+			Expect(committed).To(BeFalse())
+			remaining, err := honest.ListTokens(signer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(remaining).To(HaveLen(1))
+			Expect(remaining[0].Quantity).To(Equal(uint64(100)))
+		})
+	})
+})
+
+// signingIdentity adapts the signing identities of the MSP to the ones of the
+// token clients.
+type signingIdentity struct {
+	msp.SigningIdentity
+}
+
+func (s *signingIdentity) GetPublicVersion() tk.Identity {
+	return s.SigningIdentity.GetPublicVersion()
+}
+
+// serializedIdentity returns the serialized identity of the user of the
+// organization of the peer.
+func serializedIdentity(n *nwo.Network, p *nwo.Peer, user string) []byte {
+	cert, err := ioutil.ReadFile(n.PeerUserCert(p, user))
+	Expect(err).NotTo(HaveOccurred())
+	serialized, err := proto.Marshal(&pmsp.SerializedIdentity{
+		Mspid:   n.Organization(p.Organization).MSPID,
+		IdBytes: cert,
+	})
+	Expect(err).NotTo(HaveOccurred())
+	return serialized
+}
+
+// tokenTransaction returns the token transaction of the serialized command
+// response of a prover.
+func tokenTransaction(commandResponse []byte) *token.TokenTransaction {
+	response := &token.CommandResponse{}
+	err := proto.Unmarshal(commandResponse, response)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(response.GetErr()).To(BeNil())
+	Expect(response.GetTokenTransaction()).NotTo(BeNil())
+	return response.GetTokenTransaction()
+}
+
+// submit submits the token transaction and waits for it to be committed.
+func submit(txSubmitter *tokenclient.TxSubmitter, tx *token.TokenTransaction) (bool, error) {
+	txBytes, err := proto.Marshal(tx)
+	Expect(err).NotTo(HaveOccurred())
+	_, txEnvelope, err := txSubmitter.CreateTxEnvelope(txBytes)
+	Expect(err).NotTo(HaveOccurred())
+	committed, _, err := txSubmitter.SubmitTransaction(txEnvelope, 60)
+	return committed, err
+}
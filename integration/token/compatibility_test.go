@@ -0,0 +1,214 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"syscall"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/integration/nwo"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	tokenclient "github.com/hyperledger/fabric/token/client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"github.com/tedsuo/ifrit"
+)
+
+// previousRelease returns the release of fabric preceding the sources, whose
+// peers and clients run against the ones of the sources.
+func previousRelease() string {
+	if release := os.Getenv("TOKEN_PREVIOUS_RELEASE"); release != "" {
+		return release
+	}
+	return "1.4.0"
+}
+
+var _ = Describe("Token version skew", func() {
+	var (
+		testDir string
+		client  *docker.Client
+		network *nwo.Network
+		process ifrit.Process
+
+		orderer *nwo.Orderer
+		peer    *nwo.Peer
+
+		txSubmitter *tokenclient.TxSubmitter
+		prover      *tokenclient.ProverPeer
+		signer      tk.SigningIdentity
+	)
+
+	BeforeEach(func() {
+		Skip("Skipping token e2e test until token transaction is enabled after v1.4")
+
+		var err error
+		testDir, err = ioutil.TempDir("", "token-compatibility")
+		Expect(err).NotTo(HaveOccurred())
+
+		client, err = docker.NewClientFromEnv()
+		Expect(err).NotTo(HaveOccurred())
+
+		network = nwo.New(nwo.BasicSolo(), testDir, client, 32000, components)
+		network.GenerateConfigTree()
+		err = updateConfigtx(network)
+		Expect(err).NotTo(HaveOccurred())
+		network.Bootstrap()
+
+		orderer = network.Orderer("orderer")
+		peer = network.Peer("Org1", "peer1")
+	})
+
+	// start starts the network and sets up the clients of User1 of Org1
+	start := func() {
+		networkRunner := network.NetworkGroupRunner()
+		process = ifrit.Invoke(networkRunner)
+		Eventually(process.Ready()).Should(BeClosed())
+		network.CreateAndJoinChannel(orderer, "testchannel")
+
+		config, err := tokenclient.LoadConfig(network.TokenClientConfig(peer, orderer, "testchannel", "User1"))
+		Expect(err).NotTo(HaveOccurred())
+		txSubmitter, err = tokenclient.NewTxSubmitter(config)
+		Expect(err).NotTo(HaveOccurred())
+		prover, err = tokenclient.NewProverPeer(config)
+		Expect(err).NotTo(HaveOccurred())
+		mspSigner, err := mspmgmt.GetLocalMSP().GetDefaultSigningIdentity()
+		Expect(err).NotTo(HaveOccurred())
+		signer = &signingIdentity{SigningIdentity: mspSigner}
+	}
+
+	AfterEach(func() {
+		if txSubmitter != nil {
+			txSubmitter.Close()
+		}
+		if prover != nil {
+			prover.Close()
+		}
+		if process != nil {
+			process.Signal(syscall.SIGTERM)
+			Eventually(process.Wait(), time.Minute).Should(Receive())
+		}
+		if network != nil {
+			network.Cleanup()
+		}
+		os.RemoveAll(testDir)
+	})
+
+	Context("when the client is newer than the peer", func() {
+		BeforeEach(func() {
+			components.FetchRelease(previousRelease())
+			network.PeerBinaries = map[string]string{peer.ID(): components.ReleasedPeer(previousRelease())}
+			start()
+		})
+
+		It("issues and lists tokens with the commands the peer knows", func() {
+			creator, err := signer.Serialize()
+			Expect(err).NotTo(HaveOccurred())
+			response, err := prover.RequestImport([]*token.TokenToIssue{{Recipient: creator, Type: "PDQ", Quantity: 100}}, signer)
+			Expect(err).NotTo(HaveOccurred())
+			committed, err := submit(txSubmitter, tokenTransaction(response))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(committed).To(BeTrue())
+
+			owned, err := prover.ListTokens(signer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(owned).To(HaveLen(1))
+			Expect(owned[0].Quantity).To(Equal(uint64(100)))
+		})
+
+		It("derives the channel capabilities the peer does not report", func() {
+			capabilities := &tokenclient.ChannelCapabilities{ChannelId: "testchannel", Prover: prover, SigningIdentity: signer}
+			Expect(capabilities.CheckFabToken(context.Background())).To(Succeed())
+			Expect(capabilities.CheckHashingSuite(context.Background(), "")).To(Succeed())
+			_, err := capabilities.TokenEncryptionKeys(context.Background())
+			Expect(err).To(MatchError("token transactions cannot be encrypted on channel testchannel"))
+		})
+
+		It("reports the commands the peer does not know as an incompatibility", func() {
+			_, err := prover.RequestSupplyAttestationContext(context.Background(), nil, signer)
+			Expect(errors.Cause(err)).To(BeAssignableToTypeOf(&tokenclient.IncompatibleProverError{}))
+			Expect(err).To(MatchError(ContainSubstring("prover peer does not support SupplyAttestationRequest commands, it may run an older version than the client")))
+		})
+	})
+
+	Context("when the client is older than the peer", func() {
+		BeforeEach(func() {
+			start()
+		})
+
+		It("issues and lists tokens with the commands of the older client", func() {
+			creator, err := signer.Serialize()
+			Expect(err).NotTo(HaveOccurred())
+
+			response := processLegacyCommand(prover, signer, &token.Command_ImportRequest{
+				ImportRequest: &token.ImportRequest{
+					TokensToIssue: []*token.TokenToIssue{{Recipient: creator, Type: "PDQ", Quantity: 100}},
+				},
+			})
+			Expect(response.GetErr()).To(BeNil())
+			committed, err := submit(txSubmitter, response.GetTokenTransaction())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(committed).To(BeTrue())
+
+			response = processLegacyCommand(prover, signer, &token.Command_ListRequest{ListRequest: &token.ListRequest{}})
+			Expect(response.GetErr()).To(BeNil())
+			Expect(response.GetUnspentTokens().GetTokens()).To(HaveLen(1))
+		})
+	})
+})
+
+// processLegacyCommand sends a command as the clients of the previous release
+// do: their headers carry no extensions, and their commands are assembled
+// without the capability checks of the current clients. No token client is
+// released with the binaries of fabric, so the wire format of the older
+// clients is reproduced here.
+func processLegacyCommand(prover *tokenclient.ProverPeer, signer tk.SigningIdentity, payload interface{}) *token.CommandResponse {
+	creator, err := signer.Serialize()
+	Expect(err).NotTo(HaveOccurred())
+	nonce := make([]byte, 32)
+	_, err = rand.Read(nonce)
+	Expect(err).NotTo(HaveOccurred())
+
+	command := &token.Command{
+		Header: &token.Header{
+			Timestamp: ptypes.TimestampNow(),
+			ChannelId: prover.ChannelID,
+			Nonce:     nonce,
+			Creator:   creator,
+		},
+	}
+	switch p := payload.(type) {
+	case *token.Command_ImportRequest:
+		command.Payload = p
+	case *token.Command_ListRequest:
+		command.Payload = p
+	default:
+		Fail(fmt.Sprintf("the older clients do not send %T commands", payload))
+	}
+	raw, err := proto.Marshal(command)
+	Expect(err).NotTo(HaveOccurred())
+	signature, err := signer.Sign(raw)
+	Expect(err).NotTo(HaveOccurred())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	scr, err := prover.ProverClient.ProcessCommand(ctx, &token.SignedCommand{Command: raw, Signature: signature})
+	Expect(err).NotTo(HaveOccurred())
+	response := &token.CommandResponse{}
+	Expect(proto.Unmarshal(scr.Response, response)).To(Succeed())
+	return response
+}
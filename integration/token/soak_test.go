@@ -0,0 +1,223 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/hyperledger/fabric/integration/nwo"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	tokenclient "github.com/hyperledger/fabric/token/client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/ginkgomon"
+)
+
+// The soak test runs for the duration of the soak profile of the environment,
+// e.g. FABRIC_SOAK_DURATION=4h ginkgo -focus "Token soak" integration/token, and is
+// skipped otherwise.
+var _ = Describe("Token soak", func() {
+	var (
+		profile *nwo.SoakProfile
+		testDir string
+		client  *docker.Client
+		network *nwo.Network
+		monitor *nwo.ResourceMonitor
+
+		orderer   *nwo.Orderer
+		peers     []*nwo.Peer
+		processes map[string]ifrit.Process
+		runners   map[string]func() *ginkgomon.Runner
+	)
+
+	BeforeEach(func() {
+		var ok bool
+		profile, ok = nwo.SoakProfileFromEnv()
+		if !ok {
+			Skip(fmt.Sprintf("Skipping soak test; set %s to run it", nwo.SoakDurationEnvVar))
+		}
+		Skip("Skipping token e2e test until token transaction is enabled after v1.4")
+
+		var err error
+		testDir, err = ioutil.TempDir("", "token-soak")
+		Expect(err).NotTo(HaveOccurred())
+
+		client, err = docker.NewClientFromEnv()
+		Expect(err).NotTo(HaveOccurred())
+
+		network = nwo.New(nwo.BasicSolo(), testDir, client, 32000, components)
+		network.ProfilingEnabled = true
+		network.GenerateConfigTree()
+		err = updateConfigtx(network)
+		Expect(err).NotTo(HaveOccurred())
+		network.Bootstrap()
+
+		// each process runs on its own, so that they can be restarted one
+		// at a time
+		orderer = network.Orderer("orderer")
+		peers = network.Peers
+		processes = map[string]ifrit.Process{}
+		runners = map[string]func() *ginkgomon.Runner{
+			orderer.ID(): func() *ginkgomon.Runner { return network.OrdererRunner(orderer) },
+		}
+		for _, p := range peers {
+			p := p
+			runners[p.ID()] = func() *ginkgomon.Runner { return network.PeerRunner(p) }
+		}
+		for _, name := range append([]string{orderer.ID()}, peerIDs(peers)...) {
+			processes[name] = ifrit.Invoke(runners[name]())
+			Eventually(processes[name].Ready(), network.EventuallyTimeout).Should(BeClosed())
+		}
+
+		network.CreateAndJoinChannel(orderer, "testchannel")
+		monitor = nwo.NewResourceMonitor(network, profile)
+	})
+
+	AfterEach(func() {
+		for _, process := range processes {
+			process.Signal(syscall.SIGTERM)
+			Eventually(process.Wait(), time.Minute).Should(Receive())
+		}
+		if network != nil {
+			network.Cleanup()
+		}
+		os.RemoveAll(testDir)
+		if profile != nil {
+			fmt.Fprintf(GinkgoWriter, "heap profiles are kept in %s\n", profile.ProfileDir)
+		}
+	})
+
+	It("keeps the ledgers consistent and the resources bounded", func() {
+		issuer := network.Peer("Org1", "peer1")
+		bob := serializedIdentity(network, network.Peer("Org1", "peer1"), "User2")
+		rotation := append([]string{orderer.ID()}, peerIDs(peers)...)
+
+		var balance uint64
+		deadline := time.Now().Add(profile.Duration)
+		for round := 0; time.Now().Before(deadline); round++ {
+			By(fmt.Sprintf("issuing and transferring tokens in round %d", round))
+			balance += exchangeTokens(network, issuer, orderer, bob, time.Now().Add(profile.RestartInterval))
+
+			name := rotation[round%len(rotation)]
+			By("restarting " + name)
+			processes[name].Signal(syscall.SIGTERM)
+			Eventually(processes[name].Wait(), network.EventuallyTimeout).Should(Receive())
+			processes[name] = ifrit.Invoke(runners[name]())
+			Eventually(processes[name].Ready(), network.EventuallyTimeout).Should(BeClosed())
+			monitor.Started(name)
+
+			By("checking that the ledgers have not diverged")
+			network.CheckLedgersConverge("testchannel", peers...)
+			for _, p := range peers {
+				Eventually(func() uint64 { return tokenBalance(network, issuer, p, orderer) }, network.EventuallyTimeout).Should(Equal(balance))
+			}
+
+			By("checking that the resources have not grown")
+			monitor.SampleOrderer(orderer)
+			monitor.CheckGrowth(orderer.ID())
+			for _, p := range peers {
+				monitor.SamplePeer(p)
+				monitor.CheckGrowth(p.ID())
+			}
+		}
+	})
+})
+
+func peerIDs(peers []*nwo.Peer) []string {
+	var ids []string
+	for _, p := range peers {
+		ids = append(ids, p.ID())
+	}
+	return ids
+}
+
+// tokenClients returns a prover peer and a transaction submitter for User1 of
+// the issuer, using the prover of the peer.
+func tokenClients(n *nwo.Network, issuer, p *nwo.Peer, o *nwo.Orderer) (*tokenclient.ProverPeer, *tokenclient.TxSubmitter, tk.SigningIdentity) {
+	config, err := tokenclient.LoadConfig(n.TokenClientConfig(issuer, o, "testchannel", "User1"))
+	Expect(err).NotTo(HaveOccurred())
+	config.ProverPeerCfg.Address = n.PeerAddress(p, nwo.ListenPort)
+	config.ProverPeerCfg.TlsRootCertFile = filepath.Join(n.PeerLocalTLSDir(p), "ca.crt")
+
+	txSubmitter, err := tokenclient.NewTxSubmitter(config)
+	Expect(err).NotTo(HaveOccurred())
+	prover, err := tokenclient.NewProverPeer(config)
+	Expect(err).NotTo(HaveOccurred())
+	mspSigner, err := mspmgmt.GetLocalMSP().GetDefaultSigningIdentity()
+	Expect(err).NotTo(HaveOccurred())
+	return prover, txSubmitter, &signingIdentity{SigningIdentity: mspSigner}
+}
+
+// exchangeTokens keeps issuing tokens to User1 of the issuer, and
+// transferring one of each to the recipient and the rest back to User1,
+// until the deadline. It returns how many tokens User1 gained.
+func exchangeTokens(n *nwo.Network, issuer *nwo.Peer, o *nwo.Orderer, recipient []byte, deadline time.Time) uint64 {
+	// the clients are created anew after each restart
+	prover, txSubmitter, signer := tokenClients(n, issuer, issuer, o)
+	defer prover.Close()
+	defer txSubmitter.Close()
+	creator, err := signer.Serialize()
+	Expect(err).NotTo(HaveOccurred())
+
+	var gained uint64
+	for time.Now().Before(deadline) {
+		response, err := prover.RequestImport([]*token.TokenToIssue{{Recipient: creator, Type: "PDQ", Quantity: 10}}, signer)
+		Expect(err).NotTo(HaveOccurred())
+		committed, err := submit(txSubmitter, tokenTransaction(response))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(committed).To(BeTrue())
+
+		owned, err := prover.ListTokens(signer)
+		Expect(err).NotTo(HaveOccurred())
+		var spent *token.TokenOutput
+		for _, output := range owned {
+			if output.Quantity > 1 {
+				spent = output
+				break
+			}
+		}
+		Expect(spent).NotTo(BeNil())
+
+		shares := []*token.RecipientTransferShare{
+			{Recipient: recipient, Quantity: 1},
+			{Recipient: creator, Quantity: spent.Quantity - 1},
+		}
+		response, err = prover.RequestTransfer([][]byte{spent.Id}, shares, signer)
+		Expect(err).NotTo(HaveOccurred())
+		committed, err = submit(txSubmitter, tokenTransaction(response))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(committed).To(BeTrue())
+
+		gained += 9
+	}
+	return gained
+}
+
+// tokenBalance returns how many tokens User1 of the issuer owns, according to
+// the prover of the peer.
+func tokenBalance(n *nwo.Network, issuer, p *nwo.Peer, o *nwo.Orderer) uint64 {
+	prover, txSubmitter, signer := tokenClients(n, issuer, p, o)
+	defer prover.Close()
+	defer txSubmitter.Close()
+	owned, err := prover.ListTokens(signer)
+	Expect(err).NotTo(HaveOccurred())
+
+	var balance uint64
+	for _, output := range owned {
+		balance += output.Quantity
+	}
+	return balance
+}
@@ -10,7 +10,6 @@ import (
 	"bytes"
 	"io/ioutil"
 	"os"
-	"path/filepath"
 	"syscall"
 	"time"
 
@@ -90,37 +89,12 @@ var _ = Describe("Token EndToEnd", func() {
 })
 
 func RunTokenTransactionSubmit(n *nwo.Network, orderer *nwo.Orderer, peer *nwo.Peer) {
-	user := "User1"
-	mspDir := n.PeerUserMSPDir(peer, user)
-	mspId := "Org1MSP"
-
-	ordererAddr := n.OrdererAddress(orderer, nwo.ListenPort)
-	ordererTlsRootCertFile := filepath.Join(n.OrdererLocalTLSDir(orderer), "ca.crt")
-
-	peerAddr := n.PeerAddress(peer, nwo.ListenPort)
-	peerTlsRootCertFile := filepath.Join(n.PeerLocalTLSDir(peer), "ca.crt")
-
-	ordererCfg := tokenclient.ConnectionConfig{
-		Address:         ordererAddr,
-		TlsRootCertFile: ordererTlsRootCertFile,
-	}
-
-	commitPeerCfg := tokenclient.ConnectionConfig{
-		Address:         peerAddr,
-		TlsRootCertFile: peerTlsRootCertFile,
-	}
-
-	config := &tokenclient.ClientConfig{
-		ChannelId:     "testchannel",
-		MspDir:        mspDir,
-		MspId:         mspId,
-		TlsEnabled:    true,
-		OrdererCfg:    ordererCfg,
-		CommitPeerCfg: commitPeerCfg,
-	}
+	config, err := tokenclient.LoadConfig(n.TokenClientConfig(peer, orderer, "testchannel", "User1"))
+	Expect(err).NotTo(HaveOccurred())
 
 	txSubmitter, err := tokenclient.NewTxSubmitter(config)
 	Expect(err).NotTo(HaveOccurred())
+	defer txSubmitter.Close()
 
 	mockTokenTx := &token.TokenTransaction{
 		Action: &token.TokenTransaction_PlainAction{
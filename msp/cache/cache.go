@@ -7,30 +7,82 @@ SPDX-License-Identifier: Apache-2.0
 package cache
 
 import (
+	"crypto/sha256"
+	"sync"
+
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/msp"
 	pmsp "github.com/hyperledger/fabric/protos/msp"
 	"github.com/pkg/errors"
 )
 
+// DefaultCacheSize is the number of entries of each cache of the MSPs
+// created before Configure is called, or configured with a size of 0.
+const DefaultCacheSize = 100
+
+// verifyCacheSize is the number of valid signatures remembered by an MSP.
+// It is larger than the other caches, so that the signatures of a block
+// verified upfront by the committer are still cached when the transactions
+// of the block are validated.
+const verifyCacheSize = 4096
+
 const (
-	deserializeIdentityCacheSize = 100
-	validateIdentityCacheSize    = 100
-	satisfiesPrincipalCacheSize  = 100
+	deserializeIdentityCacheName = "deserialize_identity"
+	validateIdentityCacheName    = "validate_identity"
+	satisfiesPrincipalCacheName  = "satisfies_principal"
+	verifyCacheName              = "verify"
 )
 
 var mspLogger = flogging.MustGetLogger("msp")
 
+// Options configures the caches of the MSPs created by New.
+type Options struct {
+	// CacheSize is the maximum number of entries of each cache of an MSP.
+	CacheSize int
+	// MetricsProvider records the hits, misses and evictions of the caches.
+	MetricsProvider metrics.Provider
+}
+
+var (
+	optionsLock  sync.RWMutex
+	cacheSize    = DefaultCacheSize
+	cacheMetrics = NewMetrics(&disabled.Provider{})
+)
+
+// Configure sets the size and the metrics of the caches of the MSPs created
+// afterwards. It is meant to be called once, when the node starts, as the
+// metrics are registered with the provider.
+func Configure(o Options) {
+	optionsLock.Lock()
+	defer optionsLock.Unlock()
+
+	cacheSize = DefaultCacheSize
+	if o.CacheSize > 0 {
+		cacheSize = o.CacheSize
+	}
+	cacheMetrics = NewMetrics(&disabled.Provider{})
+	if o.MetricsProvider != nil {
+		cacheMetrics = NewMetrics(o.MetricsProvider)
+	}
+}
+
 func New(o msp.MSP) (msp.MSP, error) {
 	mspLogger.Debugf("Creating Cache-MSP instance")
 	if o == nil {
 		return nil, errors.Errorf("Invalid passed MSP. It must be different from nil.")
 	}
 
-	theMsp := &cachedMSP{MSP: o}
-	theMsp.deserializeIdentityCache = newSecondChanceCache(deserializeIdentityCacheSize)
-	theMsp.satisfiesPrincipalCache = newSecondChanceCache(satisfiesPrincipalCacheSize)
-	theMsp.validateIdentityCache = newSecondChanceCache(validateIdentityCacheSize)
+	optionsLock.RLock()
+	size, m := cacheSize, cacheMetrics
+	optionsLock.RUnlock()
+
+	theMsp := &cachedMSP{MSP: o, metrics: m}
+	theMsp.deserializeIdentityCache = newLRUCache(size)
+	theMsp.satisfiesPrincipalCache = newLRUCache(size)
+	theMsp.validateIdentityCache = newLRUCache(size)
+	theMsp.verifyCache = newLRUCache(verifyCacheSize)
 
 	return theMsp, nil
 }
@@ -38,15 +90,21 @@ func New(o msp.MSP) (msp.MSP, error) {
 type cachedMSP struct {
 	msp.MSP
 
-	// cache for DeserializeIdentity.
-	deserializeIdentityCache *secondChanceCache
+	metrics *Metrics
+
+	// cache for DeserializeIdentity, keyed by the hash of the serialized identity.
+	deserializeIdentityCache *lruCache
 
 	// cache for validateIdentity
-	validateIdentityCache *secondChanceCache
+	validateIdentityCache *lruCache
 
 	// basically a map of principals=>identities=>stringified to booleans
 	// specifying whether this identity satisfies this principal
-	satisfiesPrincipalCache *secondChanceCache
+	satisfiesPrincipalCache *lruCache
+
+	// cache of the valid signatures of the identities, keyed by the hash
+	// of the identity, the message and the signature
+	verifyCache *lruCache
 }
 
 type cachedIdentity struct {
@@ -62,8 +120,13 @@ func (id *cachedIdentity) Validate() error {
 	return id.cache.Validate(id.Identity)
 }
 
+func (id *cachedIdentity) Verify(msg []byte, sig []byte) error {
+	return id.cache.verify(id.Identity, msg, sig)
+}
+
 func (c *cachedMSP) DeserializeIdentity(serializedIdentity []byte) (msp.Identity, error) {
-	id, ok := c.deserializeIdentityCache.get(string(serializedIdentity))
+	key := deserializeIdentityKey(serializedIdentity)
+	id, ok := c.get(c.deserializeIdentityCache, deserializeIdentityCacheName, key)
 	if ok {
 		return &cachedIdentity{
 			cache:    c,
@@ -73,7 +136,7 @@ func (c *cachedMSP) DeserializeIdentity(serializedIdentity []byte) (msp.Identity
 
 	id, err := c.MSP.DeserializeIdentity(serializedIdentity)
 	if err == nil {
-		c.deserializeIdentityCache.add(string(serializedIdentity), id)
+		c.add(c.deserializeIdentityCache, deserializeIdentityCacheName, key, id)
 		return &cachedIdentity{
 			cache:    c,
 			Identity: id.(msp.Identity),
@@ -82,17 +145,21 @@ func (c *cachedMSP) DeserializeIdentity(serializedIdentity []byte) (msp.Identity
 	return nil, err
 }
 
+// Setup sets up the underlying MSP and invalidates the caches, as the new
+// config may change the validity of the identities, e.g. with a new CRL.
 func (c *cachedMSP) Setup(config *pmsp.MSPConfig) error {
 	c.cleanCash()
+	// the results computed during the setup may come from the previous config
+	defer c.cleanCash()
 
 	return c.MSP.Setup(config)
 }
 
 func (c *cachedMSP) Validate(id msp.Identity) error {
 	identifier := id.GetIdentifier()
-	key := string(identifier.Mspid + ":" + identifier.Id)
+	key := validateIdentityKey(identifier)
 
-	_, ok := c.validateIdentityCache.get(key)
+	_, ok := c.get(c.validateIdentityCache, validateIdentityCacheName, key)
 	if ok {
 		// cache only stores if the identity is valid.
 		return nil
@@ -100,19 +167,16 @@ func (c *cachedMSP) Validate(id msp.Identity) error {
 
 	err := c.MSP.Validate(id)
 	if err == nil {
-		c.validateIdentityCache.add(key, true)
+		c.add(c.validateIdentityCache, validateIdentityCacheName, key, true)
 	}
 
 	return err
 }
 
 func (c *cachedMSP) SatisfiesPrincipal(id msp.Identity, principal *pmsp.MSPPrincipal) error {
-	identifier := id.GetIdentifier()
-	identityKey := string(identifier.Mspid + ":" + identifier.Id)
-	principalKey := string(principal.PrincipalClassification) + string(principal.Principal)
-	key := identityKey + principalKey
+	key := satisfiesPrincipalKey(id.GetIdentifier(), principal)
 
-	v, ok := c.satisfiesPrincipalCache.get(key)
+	v, ok := c.get(c.satisfiesPrincipalCache, satisfiesPrincipalCacheName, key)
 	if ok {
 		if v == nil {
 			return nil
@@ -123,14 +187,77 @@ func (c *cachedMSP) SatisfiesPrincipal(id msp.Identity, principal *pmsp.MSPPrinc
 
 	err := c.MSP.SatisfiesPrincipal(id, principal)
 
-	c.satisfiesPrincipalCache.add(key, err)
+	c.add(c.satisfiesPrincipalCache, satisfiesPrincipalCacheName, key, err)
+	return err
+}
+
+// verify verifies the signature of the identity over the message, unless
+// the same signature was already found to be valid.
+func (c *cachedMSP) verify(id msp.Identity, msg []byte, sig []byte) error {
+	key := verifyKey(id.GetIdentifier(), msg, sig)
+
+	_, ok := c.get(c.verifyCache, verifyCacheName, key)
+	if ok {
+		// cache only stores valid signatures.
+		return nil
+	}
+
+	err := id.Verify(msg, sig)
+	if err == nil {
+		c.add(c.verifyCache, verifyCacheName, key, true)
+	}
+
 	return err
 }
 
+func (c *cachedMSP) get(cache *lruCache, name, key string) (interface{}, bool) {
+	v, ok := cache.get(key)
+	if ok {
+		c.metrics.Hits.With("cache", name).Add(1)
+	} else {
+		c.metrics.Misses.With("cache", name).Add(1)
+	}
+	return v, ok
+}
+
+func (c *cachedMSP) add(cache *lruCache, name, key string, value interface{}) {
+	if cache.add(key, value) {
+		c.metrics.Evictions.With("cache", name).Add(1)
+	}
+}
+
 func (c *cachedMSP) cleanCash() error {
-	c.deserializeIdentityCache = newSecondChanceCache(deserializeIdentityCacheSize)
-	c.satisfiesPrincipalCache = newSecondChanceCache(satisfiesPrincipalCacheSize)
-	c.validateIdentityCache = newSecondChanceCache(validateIdentityCacheSize)
+	c.deserializeIdentityCache.purge()
+	c.satisfiesPrincipalCache.purge()
+	c.validateIdentityCache.purge()
+	c.verifyCache.purge()
 
 	return nil
 }
+
+func deserializeIdentityKey(serializedIdentity []byte) string {
+	hash := sha256.Sum256(serializedIdentity)
+	return string(hash[:])
+}
+
+func validateIdentityKey(identifier *msp.IdentityIdentifier) string {
+	return identifier.Mspid + ":" + identifier.Id
+}
+
+func satisfiesPrincipalKey(identifier *msp.IdentityIdentifier, principal *pmsp.MSPPrincipal) string {
+	h := sha256.New()
+	h.Write([]byte(validateIdentityKey(identifier)))
+	h.Write([]byte{0, byte(principal.PrincipalClassification)})
+	h.Write(principal.Principal)
+	return string(h.Sum(nil))
+}
+
+func verifyKey(identifier *msp.IdentityIdentifier, msg []byte, sig []byte) string {
+	msgHash := sha256.Sum256(msg)
+	h := sha256.New()
+	h.Write([]byte(validateIdentityKey(identifier)))
+	h.Write([]byte{0})
+	h.Write(msgHash[:])
+	h.Write(sig)
+	return string(h.Sum(nil))
+}
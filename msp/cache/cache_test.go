@@ -10,6 +10,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/msp/mocks"
 	msp2 "github.com/hyperledger/fabric/protos/msp"
@@ -41,6 +42,7 @@ func TestSetup(t *testing.T) {
 	assert.Equal(t, 0, i.(*cachedMSP).deserializeIdentityCache.len())
 	assert.Equal(t, 0, i.(*cachedMSP).satisfiesPrincipalCache.len())
 	assert.Equal(t, 0, i.(*cachedMSP).validateIdentityCache.len())
+	assert.Equal(t, 0, i.(*cachedMSP).verifyCache.len())
 }
 
 func TestGetType(t *testing.T) {
@@ -152,7 +154,7 @@ func TestDeserializeIdentity(t *testing.T) {
 
 	mockMSP.AssertExpectations(t)
 	// Check the cache
-	_, ok := wrappedMSP.(*cachedMSP).deserializeIdentityCache.get(string(serializedIdentity))
+	_, ok := wrappedMSP.(*cachedMSP).deserializeIdentityCache.get(deserializeIdentityKey(serializedIdentity))
 	assert.True(t, ok)
 
 	// Check the same object is returned
@@ -170,7 +172,7 @@ func TestDeserializeIdentity(t *testing.T) {
 	assert.Contains(t, err.Error(), "Invalid identity")
 	mockMSP.AssertExpectations(t)
 
-	_, ok = wrappedMSP.(*cachedMSP).deserializeIdentityCache.get(string(serializedIdentity))
+	_, ok = wrappedMSP.(*cachedMSP).deserializeIdentityCache.get(deserializeIdentityKey(serializedIdentity))
 	assert.False(t, ok)
 }
 
@@ -188,9 +190,8 @@ func TestValidate(t *testing.T) {
 	mockIdentity.AssertExpectations(t)
 	mockMSP.AssertExpectations(t)
 	// Check the cache
-	identifier := mockIdentity.GetIdentifier()
-	key := string(identifier.Mspid + ":" + identifier.Id)
-	v, ok := i.(*cachedMSP).validateIdentityCache.get(string(key))
+	key := validateIdentityKey(mockIdentity.GetIdentifier())
+	v, ok := i.(*cachedMSP).validateIdentityCache.get(key)
 	assert.True(t, ok)
 	assert.True(t, v.(bool))
 
@@ -207,9 +208,8 @@ func TestValidate(t *testing.T) {
 	mockIdentity.AssertExpectations(t)
 	mockMSP.AssertExpectations(t)
 	// Check the cache
-	identifier = mockIdentity.GetIdentifier()
-	key = string(identifier.Mspid + ":" + identifier.Id)
-	_, ok = i.(*cachedMSP).validateIdentityCache.get(string(key))
+	key = validateIdentityKey(mockIdentity.GetIdentifier())
+	_, ok = i.(*cachedMSP).validateIdentityCache.get(key)
 	assert.False(t, ok)
 }
 
@@ -289,10 +289,7 @@ func TestSatisfiesPrincipal(t *testing.T) {
 	mockIdentity.AssertExpectations(t)
 	mockMSP.AssertExpectations(t)
 	// Check the cache
-	identifier := mockIdentity.GetIdentifier()
-	identityKey := string(identifier.Mspid + ":" + identifier.Id)
-	principalKey := string(mockMSPPrincipal.PrincipalClassification) + string(mockMSPPrincipal.Principal)
-	key := identityKey + principalKey
+	key := satisfiesPrincipalKey(mockIdentity.GetIdentifier(), mockMSPPrincipal)
 	v, ok := i.(*cachedMSP).satisfiesPrincipalCache.get(key)
 	assert.True(t, ok)
 	assert.Nil(t, v)
@@ -312,12 +309,107 @@ func TestSatisfiesPrincipal(t *testing.T) {
 	mockIdentity.AssertExpectations(t)
 	mockMSP.AssertExpectations(t)
 	// Check the cache
-	identifier = mockIdentity.GetIdentifier()
-	identityKey = string(identifier.Mspid + ":" + identifier.Id)
-	principalKey = string(mockMSPPrincipal.PrincipalClassification) + string(mockMSPPrincipal.Principal)
-	key = identityKey + principalKey
+	key = satisfiesPrincipalKey(mockIdentity.GetIdentifier(), mockMSPPrincipal)
 	v, ok = i.(*cachedMSP).satisfiesPrincipalCache.get(key)
 	assert.True(t, ok)
 	assert.NotNil(t, v)
 	assert.Contains(t, "Invalid", v.(error).Error())
 }
+
+func TestSetupInvalidatesCache(t *testing.T) {
+	mockMSP := &mocks.MockMSP{}
+	i, err := New(mockMSP)
+	assert.NoError(t, err)
+
+	mockIdentity := &mocks.MockIdentity{ID: "Alice"}
+	mockIdentity.On("GetIdentifier").Return(&msp.IdentityIdentifier{Mspid: "MSP", Id: "Alice"})
+	mockMSP.On("DeserializeIdentity", []byte{1, 2, 3}).Return(mockIdentity, nil)
+	mockMSP.On("Validate", mockIdentity).Return(nil).Once()
+	_, err = i.DeserializeIdentity([]byte{1, 2, 3})
+	assert.NoError(t, err)
+	assert.NoError(t, i.Validate(mockIdentity))
+
+	// the identity is revoked by the new config
+	mockMSP.On("Setup", (*msp2.MSPConfig)(nil)).Return(nil)
+	assert.NoError(t, i.Setup(nil))
+	assert.Equal(t, 0, i.(*cachedMSP).deserializeIdentityCache.len())
+	assert.Equal(t, 0, i.(*cachedMSP).validateIdentityCache.len())
+
+	mockMSP.On("Validate", mockIdentity).Return(errors.New("identity is revoked")).Once()
+	assert.EqualError(t, i.Validate(mockIdentity), "identity is revoked")
+	mockMSP.AssertNumberOfCalls(t, "Validate", 2)
+}
+
+func TestConfigure(t *testing.T) {
+	defer Configure(Options{})
+
+	provider := &metricsfakes.Provider{}
+	counter := &metricsfakes.Counter{}
+	counter.WithReturns(counter)
+	provider.NewCounterReturns(counter)
+	Configure(Options{CacheSize: 1, MetricsProvider: provider})
+	assert.Equal(t, 3, provider.NewCounterCallCount())
+
+	mockMSP := &mocks.MockMSP{}
+	i, err := New(mockMSP)
+	assert.NoError(t, err)
+
+	mockMSP.On("DeserializeIdentity", []byte{1}).Return(&mocks.MockIdentity{ID: "Alice"}, nil)
+	mockMSP.On("DeserializeIdentity", []byte{2}).Return(&mocks.MockIdentity{ID: "Bob"}, nil)
+	// miss
+	_, err = i.DeserializeIdentity([]byte{1})
+	assert.NoError(t, err)
+	// hit
+	_, err = i.DeserializeIdentity([]byte{1})
+	assert.NoError(t, err)
+	// miss, and Alice is evicted
+	_, err = i.DeserializeIdentity([]byte{2})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, i.(*cachedMSP).deserializeIdentityCache.len())
+	mockMSP.AssertNumberOfCalls(t, "DeserializeIdentity", 2)
+
+	hits, misses, evictions := provider.NewCounterArgsForCall(0), provider.NewCounterArgsForCall(1), provider.NewCounterArgsForCall(2)
+	assert.Equal(t, "hits", hits.Name)
+	assert.Equal(t, "misses", misses.Name)
+	assert.Equal(t, "evictions", evictions.Name)
+	assert.Equal(t, 4, counter.WithCallCount())
+	for n := 0; n < counter.WithCallCount(); n++ {
+		assert.Equal(t, []string{"cache", "deserialize_identity"}, counter.WithArgsForCall(n))
+	}
+	assert.Equal(t, 4, counter.AddCallCount())
+}
+
+func TestVerify(t *testing.T) {
+	mockMSP := &mocks.MockMSP{}
+	i, err := New(mockMSP)
+	assert.NoError(t, err)
+
+	mockIdentity := &mocks.MockIdentity{ID: "Alice"}
+	mockIdentity.On("GetIdentifier").Return(&msp.IdentityIdentifier{Mspid: "MSP", Id: "Alice"})
+	mockIdentity.On("Verify", []byte("msg"), []byte("sig")).Return(nil)
+	mockIdentity.On("Verify", []byte("msg"), []byte("bad-sig")).Return(errors.New("invalid signature"))
+	mockMSP.On("DeserializeIdentity", []byte{1, 2, 3}).Return(mockIdentity, nil)
+	id, err := i.DeserializeIdentity([]byte{1, 2, 3})
+	assert.NoError(t, err)
+
+	// Check valid signatures are cached
+	assert.NoError(t, id.Verify([]byte("msg"), []byte("sig")))
+	assert.NoError(t, id.Verify([]byte("msg"), []byte("sig")))
+	mockIdentity.AssertNumberOfCalls(t, "Verify", 1)
+	_, ok := i.(*cachedMSP).verifyCache.get(verifyKey(mockIdentity.GetIdentifier(), []byte("msg"), []byte("sig")))
+	assert.True(t, ok)
+
+	// Check invalid signatures are not cached
+	assert.EqualError(t, id.Verify([]byte("msg"), []byte("bad-sig")), "invalid signature")
+	assert.EqualError(t, id.Verify([]byte("msg"), []byte("bad-sig")), "invalid signature")
+	mockIdentity.AssertNumberOfCalls(t, "Verify", 3)
+
+	// Check the signatures are verified again after a setup
+	mockMSP.On("Setup", (*msp2.MSPConfig)(nil)).Return(nil)
+	assert.NoError(t, i.Setup(nil))
+	assert.Equal(t, 0, i.(*cachedMSP).verifyCache.len())
+	id, err = i.DeserializeIdentity([]byte{1, 2, 3})
+	assert.NoError(t, err)
+	assert.NoError(t, id.Verify([]byte("msg"), []byte("sig")))
+	mockIdentity.AssertNumberOfCalls(t, "Verify", 4)
+}
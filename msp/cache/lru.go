@@ -0,0 +1,93 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// lruCache holds key-value items with a limited size.
+// When the number of cached items exceeds the limit, the least
+// recently used item gets purged.
+type lruCache struct {
+	size int
+
+	// manages mapping between keys and elements of the recency list
+	table map[string]*list.Element
+
+	// holds the cached items, the most recently used at the front
+	items *list.List
+
+	// get moves items in the recency list, so it requires the write lock as well
+	lock sync.Mutex
+}
+
+type cacheItem struct {
+	key   string
+	value interface{}
+}
+
+func newLRUCache(cacheSize int) *lruCache {
+	return &lruCache{
+		size:  cacheSize,
+		table: make(map[string]*list.Element),
+		items: list.New(),
+	}
+}
+
+func (cache *lruCache) len() int {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	return len(cache.table)
+}
+
+func (cache *lruCache) get(key string) (interface{}, bool) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	elem, ok := cache.table[key]
+	if !ok {
+		return nil, false
+	}
+	cache.items.MoveToFront(elem)
+
+	return elem.Value.(*cacheItem).value, true
+}
+
+// add stores the item in the cache, and returns whether an item
+// was evicted to make room for it.
+func (cache *lruCache) add(key string, value interface{}) bool {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	if elem, ok := cache.table[key]; ok {
+		elem.Value.(*cacheItem).value = value
+		cache.items.MoveToFront(elem)
+		return false
+	}
+
+	cache.table[key] = cache.items.PushFront(&cacheItem{key: key, value: value})
+	if len(cache.table) <= cache.size {
+		return false
+	}
+
+	victim := cache.items.Back()
+	cache.items.Remove(victim)
+	delete(cache.table, victim.Value.(*cacheItem).key)
+	return true
+}
+
+// purge removes all the items from the cache.
+func (cache *lruCache) purge() {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	cache.table = make(map[string]*list.Element)
+	cache.items.Init()
+}
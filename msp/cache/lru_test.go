@@ -14,49 +14,56 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func TestSecondChanceCache(t *testing.T) {
-	cache := newSecondChanceCache(2)
+func TestLRUCache(t *testing.T) {
+	cache := newLRUCache(2)
 	assert.NotNil(t, cache)
 
-	cache.add("a", "xyz")
+	assert.False(t, cache.add("a", "xyz"))
 
 	obj, ok := cache.get("a")
 	assert.True(t, ok)
 	assert.Equal(t, "xyz", obj.(string))
 
-	cache.add("b", "123")
+	assert.False(t, cache.add("b", "123"))
 
-	obj, ok = cache.get("b")
+	// a is used more recently than b
+	_, ok = cache.get("a")
 	assert.True(t, ok)
-	assert.Equal(t, "123", obj.(string))
 
-	cache.add("c", "777")
+	// b is evicted
+	assert.True(t, cache.add("c", "777"))
+	assert.Equal(t, 2, cache.len())
 
+	_, ok = cache.get("b")
+	assert.False(t, ok)
 	obj, ok = cache.get("c")
 	assert.True(t, ok)
 	assert.Equal(t, "777", obj.(string))
 
-	_, ok = cache.get("a")
-	assert.False(t, ok)
-
-	_, ok = cache.get("b")
-	assert.True(t, ok)
-
-	cache.add("b", "456")
-
-	obj, ok = cache.get("b")
+	// updating a item does not evict and marks it as used
+	assert.False(t, cache.add("a", "456"))
+	obj, ok = cache.get("a")
 	assert.True(t, ok)
 	assert.Equal(t, "456", obj.(string))
 
-	cache.add("d", "555")
+	assert.True(t, cache.add("d", "555"))
+	_, ok = cache.get("c")
+	assert.False(t, ok)
+	_, ok = cache.get("a")
+	assert.True(t, ok)
 
-	obj, ok = cache.get("b")
-	_, ok = cache.get("b")
+	cache.purge()
+	assert.Equal(t, 0, cache.len())
+	_, ok = cache.get("a")
 	assert.False(t, ok)
+
+	assert.False(t, cache.add("e", "888"))
+	assert.False(t, cache.add("f", "999"))
+	assert.Equal(t, 2, cache.len())
 }
 
-func TestSecondChanceCacheConcurrent(t *testing.T) {
-	cache := newSecondChanceCache(25)
+func TestLRUCacheConcurrent(t *testing.T) {
+	cache := newLRUCache(25)
 
 	workers := 16
 	wg := sync.WaitGroup{}
@@ -88,22 +95,19 @@ func TestSecondChanceCacheConcurrent(t *testing.T) {
 				}
 				cache.add(key2, val2)
 
-				key4 := fmt.Sprintf("key4-%d", j)
-				val4 := key4
-				val, ok = cache.get(key4)
-				if ok {
-					assert.Equal(t, val4, val.(string))
-				}
-				cache.add(key4, val4)
-
 				val, ok = cache.get(key3)
 				if ok {
 					assert.Equal(t, val3, val.(string))
 				}
+
+				if j%1000 == 0 {
+					cache.purge()
+				}
 			}
 
 			wg.Done()
 		}()
 	}
 	wg.Wait()
+	assert.True(t, cache.len() <= 25)
 }
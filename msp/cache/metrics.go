@@ -0,0 +1,50 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cache
+
+import "github.com/hyperledger/fabric/common/metrics"
+
+var (
+	hitsCounterOpts = metrics.CounterOpts{
+		Namespace:    "msp",
+		Subsystem:    "cache",
+		Name:         "hits",
+		Help:         "The number of lookups of the MSP caches that found the result of a previous call.",
+		LabelNames:   []string{"cache"},
+		StatsdFormat: "%{#fqname}.%{cache}",
+	}
+	missesCounterOpts = metrics.CounterOpts{
+		Namespace:    "msp",
+		Subsystem:    "cache",
+		Name:         "misses",
+		Help:         "The number of lookups of the MSP caches that had to call the underlying MSP.",
+		LabelNames:   []string{"cache"},
+		StatsdFormat: "%{#fqname}.%{cache}",
+	}
+	evictionsCounterOpts = metrics.CounterOpts{
+		Namespace:    "msp",
+		Subsystem:    "cache",
+		Name:         "evictions",
+		Help:         "The number of entries evicted from the MSP caches to stay within their size.",
+		LabelNames:   []string{"cache"},
+		StatsdFormat: "%{#fqname}.%{cache}",
+	}
+)
+
+type Metrics struct {
+	Hits      metrics.Counter
+	Misses    metrics.Counter
+	Evictions metrics.Counter
+}
+
+func NewMetrics(p metrics.Provider) *Metrics {
+	return &Metrics{
+		Hits:      p.NewCounter(hitsCounterOpts),
+		Misses:    p.NewCounter(missesCounterOpts),
+		Evictions: p.NewCounter(evictionsCounterOpts),
+	}
+}
@@ -0,0 +1,293 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+var testCertSerial int64
+
+// issueTestCert issues a certificate for the template, signed by the parent
+// or self-signed if the parent is nil
+func issueTestCert(t *testing.T, template *x509.Certificate, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	testCertSerial++
+	template.SerialNumber = big.NewInt(testCertSerial)
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	if template.IsCA {
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	} else {
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+	}
+
+	parentCert, parentKey := template, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+
+	return &testCert{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+func newTestCA(t *testing.T, name string, parent *testCert) *testCert {
+	return issueTestCert(t, &x509.Certificate{Subject: pkix.Name{CommonName: name}, IsCA: true}, parent)
+}
+
+func newTestIdentityCert(t *testing.T, dnsName string, extKeyUsage []x509.ExtKeyUsage, parent *testCert) *testCert {
+	return issueTestCert(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: dnsName},
+		DNSNames:    []string{dnsName},
+		ExtKeyUsage: extKeyUsage,
+	}, parent)
+}
+
+func setupConstrainedMSP(t *testing.T, root *testCert, intermediates []*testCert, constraints *msp.FabricCertificateConstraints) (MSP, error) {
+	conf := &msp.FabricMSPConfig{
+		Name:                   "ConstrainedMSP",
+		RootCerts:              [][]byte{root.pem},
+		CertificateConstraints: constraints,
+	}
+	for _, intermediate := range intermediates {
+		conf.IntermediateCerts = append(conf.IntermediateCerts, intermediate.pem)
+	}
+	confBytes, err := proto.Marshal(conf)
+	assert.NoError(t, err)
+
+	thisMSP, err := newBccspMsp(MSPv1_1)
+	assert.NoError(t, err)
+	return thisMSP, thisMSP.Setup(&msp.MSPConfig{Type: int32(FABRIC), Config: confBytes})
+}
+
+func validateTestCert(t *testing.T, thisMSP MSP, cert *testCert) error {
+	serialized, err := proto.Marshal(&msp.SerializedIdentity{Mspid: "ConstrainedMSP", IdBytes: cert.pem})
+	assert.NoError(t, err)
+	id, err := thisMSP.DeserializeIdentity(serialized)
+	if err != nil {
+		return err
+	}
+	return thisMSP.Validate(id)
+}
+
+func TestCertificateConstraintsChainLength(t *testing.T) {
+	root := newTestCA(t, "root", nil)
+	direct := newTestIdentityCert(t, "peer0.org1.example.com", nil, root)
+	intermediate := newTestCA(t, "intermediate", root)
+	indirect := newTestIdentityCert(t, "peer1.org1.example.com", nil, intermediate)
+
+	thisMSP, err := setupConstrainedMSP(t, root, nil, &msp.FabricCertificateConstraints{MaxChainLength: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, validateTestCert(t, thisMSP, direct))
+
+	thisMSP, err = setupConstrainedMSP(t, root, []*testCert{intermediate}, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, validateTestCert(t, thisMSP, indirect))
+
+	thisMSP, err = setupConstrainedMSP(t, root, []*testCert{intermediate}, &msp.FabricCertificateConstraints{MaxChainLength: 3})
+	assert.NoError(t, err)
+	assert.NoError(t, validateTestCert(t, thisMSP, indirect))
+
+	thisMSP, err = setupConstrainedMSP(t, root, []*testCert{intermediate}, &msp.FabricCertificateConstraints{MaxChainLength: 2})
+	assert.NoError(t, err)
+	err = validateTestCert(t, thisMSP, indirect)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the certification chain of length 3 exceeds the maximum chain length 2 of MSP ConstrainedMSP")
+
+	_, err = setupConstrainedMSP(t, root, nil, &msp.FabricCertificateConstraints{MaxChainLength: 1})
+	assert.EqualError(t, err, "invalid certificate constraints: the maximum chain length must be at least 2, to include the identity and root CA certificates")
+}
+
+func TestCertificateConstraintsCAPathLength(t *testing.T) {
+	// the root CA allows a single intermediate CA below it
+	root := issueTestCert(t, &x509.Certificate{Subject: pkix.Name{CommonName: "root"}, IsCA: true, MaxPathLen: 1}, nil)
+	intermediate := newTestCA(t, "intermediate", root)
+	subIntermediate := newTestCA(t, "subintermediate", intermediate)
+
+	thisMSP, err := setupConstrainedMSP(t, root, []*testCert{intermediate}, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, validateTestCert(t, thisMSP, newTestIdentityCert(t, "peer0.org1.example.com", nil, intermediate)))
+
+	thisMSP, err = setupConstrainedMSP(t, root, []*testCert{intermediate, subIntermediate}, nil)
+	assert.NoError(t, err)
+	err = validateTestCert(t, thisMSP, newTestIdentityCert(t, "peer1.org1.example.com", nil, subIntermediate))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the certification path exceeds the path length constraint of CA certificate [CN=root]")
+
+	// an intermediate CA with a path length of zero only issues identities
+	leafCA := issueTestCert(t, &x509.Certificate{Subject: pkix.Name{CommonName: "leafca"}, IsCA: true, MaxPathLen: 0, MaxPathLenZero: true}, root)
+	subCA := newTestCA(t, "subca", leafCA)
+	thisMSP, err = setupConstrainedMSP(t, root, []*testCert{leafCA, subCA}, nil)
+	assert.NoError(t, err)
+	err = validateTestCert(t, thisMSP, newTestIdentityCert(t, "peer2.org1.example.com", nil, subCA))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the certification path exceeds the path length constraint of CA certificate [CN=leafca]")
+}
+
+func TestCertificateConstraintsCANameConstraints(t *testing.T) {
+	root := newTestCA(t, "root", nil)
+	intermediate := issueTestCert(t, &x509.Certificate{Subject: pkix.Name{CommonName: "intermediate"}, IsCA: true, PermittedDNSDomains: []string{"org1.example.com"}}, root)
+
+	thisMSP, err := setupConstrainedMSP(t, root, []*testCert{intermediate}, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, validateTestCert(t, thisMSP, newTestIdentityCert(t, "peer0.org1.example.com", nil, intermediate)))
+
+	err = validateTestCert(t, thisMSP, newTestIdentityCert(t, "peer0.org2.example.com", nil, intermediate))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "certificate [CN=peer0.org2.example.com] is not permitted by the name constraints of its CA certificates")
+}
+
+func TestCertificateConstraintsDNSDomains(t *testing.T) {
+	root := newTestCA(t, "root", nil)
+
+	thisMSP, err := setupConstrainedMSP(t, root, nil, &msp.FabricCertificateConstraints{
+		PermittedDnsDomains: []string{"example.com", ".example.org"},
+		ExcludedDnsDomains:  []string{"org2.example.com"},
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, validateTestCert(t, thisMSP, newTestIdentityCert(t, "example.com", nil, root)))
+	assert.NoError(t, validateTestCert(t, thisMSP, newTestIdentityCert(t, "peer0.org1.example.com", nil, root)))
+	assert.NoError(t, validateTestCert(t, thisMSP, newTestIdentityCert(t, "peer0.example.org", nil, root)))
+
+	err = validateTestCert(t, thisMSP, newTestIdentityCert(t, "example.org", nil, root))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "DNS name [example.org] is not in the permitted DNS domains [example.com .example.org] of MSP ConstrainedMSP")
+
+	err = validateTestCert(t, thisMSP, newTestIdentityCert(t, "peer0.example.net", nil, root))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "DNS name [peer0.example.net] is not in the permitted DNS domains")
+
+	err = validateTestCert(t, thisMSP, newTestIdentityCert(t, "peer0.org2.example.com", nil, root))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "DNS name [peer0.org2.example.com] is in the excluded DNS domain [org2.example.com] of MSP ConstrainedMSP")
+
+	// identities without DNS names are not constrained
+	assert.NoError(t, validateTestCert(t, thisMSP, issueTestCert(t, &x509.Certificate{Subject: pkix.Name{CommonName: "user1"}}, root)))
+
+	_, err = setupConstrainedMSP(t, root, nil, &msp.FabricCertificateConstraints{ExcludedDnsDomains: []string{""}})
+	assert.EqualError(t, err, "invalid certificate constraints: invalid DNS domain []")
+}
+
+func TestCertificateConstraintsExtKeyUsages(t *testing.T) {
+	root := newTestCA(t, "root", nil)
+	clientCert := newTestIdentityCert(t, "client.example.com", []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, root)
+	serverCert := newTestIdentityCert(t, "server.example.com", []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}, root)
+	noUsageCert := newTestIdentityCert(t, "any.example.com", nil, root)
+
+	thisMSP, err := setupConstrainedMSP(t, root, nil, &msp.FabricCertificateConstraints{ExtendedKeyUsages: []string{"clientAuth"}})
+	assert.NoError(t, err)
+	assert.NoError(t, validateTestCert(t, thisMSP, clientCert))
+	assert.NoError(t, validateTestCert(t, thisMSP, noUsageCert))
+	err = validateTestCert(t, thisMSP, serverCert)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "certificate [CN=server.example.com] and its CA certificates do not allow the extended key usages required by the MSP")
+
+	thisMSP, err = setupConstrainedMSP(t, root, nil, &msp.FabricCertificateConstraints{ExtendedKeyUsages: []string{"clientAuth", "serverAuth"}})
+	assert.NoError(t, err)
+	assert.NoError(t, validateTestCert(t, thisMSP, clientCert))
+	assert.NoError(t, validateTestCert(t, thisMSP, serverCert))
+
+	// the extended key usages of the CA certificates restrict the ones of the identities
+	serverCA := issueTestCert(t, &x509.Certificate{Subject: pkix.Name{CommonName: "serverca"}, IsCA: true, ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}}, root)
+	thisMSP, err = setupConstrainedMSP(t, root, []*testCert{serverCA}, &msp.FabricCertificateConstraints{ExtendedKeyUsages: []string{"clientAuth"}})
+	assert.NoError(t, err)
+	err = validateTestCert(t, thisMSP, newTestIdentityCert(t, "client.example.com", []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, serverCA))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "certificate [CN=client.example.com] and its CA certificates do not allow the extended key usages required by the MSP")
+
+	_, err = setupConstrainedMSP(t, root, nil, &msp.FabricCertificateConstraints{ExtendedKeyUsages: []string{"peerAuth"}})
+	assert.EqualError(t, err, "invalid certificate constraints: unknown extended key usage [peerAuth]")
+}
+
+func TestMatchDNSDomain(t *testing.T) {
+	tests := []struct {
+		name    string
+		domain  string
+		matches bool
+	}{
+		{"example.com", "example.com", true},
+		{"peer0.example.com", "example.com", true},
+		{"PEER0.Example.com.", "example.COM", true},
+		{"example.com", ".example.com", false},
+		{"peer0.example.com", ".example.com", true},
+		{"badexample.com", "example.com", false},
+		{"example.com", "peer0.example.com", false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.matches, matchDNSDomain(test.name, test.domain), "name %s, domain %s", test.name, test.domain)
+	}
+}
+
+func TestCertificateConstraintsConfiguration(t *testing.T) {
+	dir, err := ioutil.TempDir("", "certconstraints")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	root := newTestCA(t, "root", nil)
+	admin := newTestIdentityCert(t, "admin.example.com", nil, root)
+	for subdir, cert := range map[string]*testCert{cacerts: root, admincerts: admin} {
+		assert.NoError(t, os.Mkdir(filepath.Join(dir, subdir), 0755))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, subdir, "cert.pem"), cert.pem, 0644))
+	}
+	configuration := `CertificateConstraints:
+  MaxChainLength: 2
+  PermittedDNSDomains:
+    - example.com
+  ExcludedDNSDomains:
+    - org2.example.com
+  ExtendedKeyUsages:
+    - clientAuth
+`
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, configfilename), []byte(configuration), 0644))
+
+	mspConfig, err := GetVerifyingMspConfig(dir, "ConstrainedMSP", ProviderTypeToString(FABRIC))
+	assert.NoError(t, err)
+	conf := &msp.FabricMSPConfig{}
+	assert.NoError(t, proto.Unmarshal(mspConfig.Config, conf))
+	assert.True(t, proto.Equal(&msp.FabricCertificateConstraints{
+		MaxChainLength:      2,
+		PermittedDnsDomains: []string{"example.com"},
+		ExcludedDnsDomains:  []string{"org2.example.com"},
+		ExtendedKeyUsages:   []string{"clientAuth"},
+	}, conf.CertificateConstraints))
+
+	thisMSP, err := newBccspMsp(MSPv1_1)
+	assert.NoError(t, err)
+	assert.NoError(t, thisMSP.Setup(mspConfig))
+}
@@ -43,6 +43,20 @@ type NodeOUs struct {
 	PeerOUIdentifier *OrganizationalUnitIdentifiersConfiguration `yaml:"PeerOUIdentifier,omitempty"`
 }
 
+// CertificateConstraints contains the constraints the certification paths of
+// the identities must satisfy, on top of the constraints of the CA certificates.
+type CertificateConstraints struct {
+	// MaxChainLength limits the number of certificates in the certification
+	// chain of an identity, the identity and root CA certificates included
+	MaxChainLength uint32 `yaml:"MaxChainLength,omitempty"`
+	// PermittedDNSDomains lists the domains the DNS names of the identities must be in
+	PermittedDNSDomains []string `yaml:"PermittedDNSDomains,omitempty"`
+	// ExcludedDNSDomains lists the domains the DNS names of the identities must not be in
+	ExcludedDNSDomains []string `yaml:"ExcludedDNSDomains,omitempty"`
+	// ExtendedKeyUsages lists the extended key usages the identities must allow one of
+	ExtendedKeyUsages []string `yaml:"ExtendedKeyUsages,omitempty"`
+}
+
 // Configuration represents the accessory configuration an MSP can be equipped with.
 // By default, this configuration is stored in a yaml file
 type Configuration struct {
@@ -52,6 +66,8 @@ type Configuration struct {
 	// NodeOUs enables the MSP to tell apart clients, peers and orderers based
 	// on the identity's OU.
 	NodeOUs *NodeOUs `yaml:"NodeOUs,omitempty"`
+	// CertificateConstraints restricts the certification paths of the identities
+	CertificateConstraints *CertificateConstraints `yaml:"CertificateConstraints,omitempty"`
 }
 
 func readFile(file string) ([]byte, error) {
@@ -134,7 +150,7 @@ func SetupBCCSPKeystoreConfig(bccspConfig *factory.FactoryOpts, keystoreDir stri
 		bccspConfig = factory.GetDefaultOpts()
 	}
 
-	if bccspConfig.ProviderName == "SW" {
+	if bccspConfig.ProviderName == "SW" || bccspConfig.ProviderName == "PQ" {
 		if bccspConfig.SwOpts == nil {
 			bccspConfig.SwOpts = factory.GetDefaultOpts().SwOpts
 		}
@@ -257,6 +273,7 @@ func getMspConfig(dir string, ID string, sigid *msp.SigningIdentityInfo) (*msp.M
 	// otherwise skip it
 	var ouis []*msp.FabricOUIdentifier
 	var nodeOUs *msp.FabricNodeOUs
+	var certConstraints *msp.FabricCertificateConstraints
 	_, err = os.Stat(configFile)
 	if err == nil {
 		// load the file, if there is a failure in loading it then
@@ -325,6 +342,16 @@ func getMspConfig(dir string, ID string, sigid *msp.SigningIdentityInfo) (*msp.M
 				nodeOUs.PeerOuIdentifier.Certificate = raw
 			}
 		}
+
+		// Prepare CertificateConstraints
+		if c := configuration.CertificateConstraints; c != nil {
+			certConstraints = &msp.FabricCertificateConstraints{
+				MaxChainLength:      c.MaxChainLength,
+				PermittedDnsDomains: c.PermittedDNSDomains,
+				ExcludedDnsDomains:  c.ExcludedDNSDomains,
+				ExtendedKeyUsages:   c.ExtendedKeyUsages,
+			}
+		}
 	} else {
 		mspLogger.Debugf("MSP configuration file not found at [%s]: [%s]", configFile, err)
 	}
@@ -348,6 +375,7 @@ func getMspConfig(dir string, ID string, sigid *msp.SigningIdentityInfo) (*msp.M
 		TlsRootCerts:                  tlsCACerts,
 		TlsIntermediateCerts:          tlsIntermediateCerts,
 		FabricNodeOus:                 nodeOUs,
+		CertificateConstraints:        certConstraints,
 	}
 
 	fmpsjs, _ := proto.Marshal(fmspconf)
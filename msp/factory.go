@@ -16,6 +16,8 @@ const (
 	MSPv1_0 = iota
 	MSPv1_1
 	MSPv1_3
+	// MSPv1_4_PQ is MSPv1_3 verifying the post-quantum signatures of the hybrid certificates
+	MSPv1_4_PQ
 )
 
 // NewOpts represent
@@ -54,6 +56,8 @@ func New(opts NewOpts) (MSP, error) {
 			return newBccspMsp(MSPv1_1)
 		case MSPv1_3:
 			return newBccspMsp(MSPv1_3)
+		case MSPv1_4_PQ:
+			return newBccspMsp(MSPv1_4_PQ)
 		default:
 			return nil, errors.Errorf("Invalid *BCCSPNewOpts. Version not recognized [%v]", opts.GetVersion())
 		}
@@ -61,6 +65,9 @@ func New(opts NewOpts) (MSP, error) {
 		switch opts.GetVersion() {
 		case MSPv1_3:
 			return newIdemixMsp(MSPv1_3)
+		case MSPv1_4_PQ:
+			// Idemix signatures have no post-quantum counterpart
+			return newIdemixMsp(MSPv1_3)
 		case MSPv1_1:
 			return newIdemixMsp(MSPv1_1)
 		default:
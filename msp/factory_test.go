@@ -58,6 +58,18 @@ func TestNew(t *testing.T) {
 		runtime.FuncForPC(reflect.ValueOf(i.(*bccspmsp).validateIdentityOUsV11).Pointer()).Name(),
 	)
 
+	i, err = New(&BCCSPNewOpts{NewBaseOpts{Version: MSPv1_4_PQ}})
+	assert.NoError(t, err)
+	assert.Equal(t, MSPVersion(MSPv1_4_PQ), i.(*bccspmsp).version)
+	assert.Equal(t,
+		runtime.FuncForPC(reflect.ValueOf(i.(*bccspmsp).internalSatisfiesPrincipalInternalFunc).Pointer()).Name(),
+		runtime.FuncForPC(reflect.ValueOf(i.(*bccspmsp).satisfiesPrincipalInternalV13).Pointer()).Name(),
+	)
+
+	i, err = New(&IdemixNewOpts{NewBaseOpts{Version: MSPv1_4_PQ}})
+	assert.NoError(t, err)
+	assert.Equal(t, MSPVersion(MSPv1_3), i.(*idemixmsp).version)
+
 	i, err = New(&IdemixNewOpts{NewBaseOpts{Version: MSPv1_0}})
 	assert.Error(t, err)
 	assert.Nil(t, i)
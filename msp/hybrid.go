@@ -0,0 +1,92 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/pkg/errors"
+)
+
+// oidSubjectAltPublicKeyInfo is the extension of the hybrid certificates
+// carrying, next to their classical public key, an alternative post-quantum
+// public key, as a DER SubjectPublicKeyInfo.
+var oidSubjectAltPublicKeyInfo = asn1.ObjectIdentifier{2, 5, 29, 72}
+
+// NewPostQuantumExtension returns the non critical certificate extension
+// carrying the post-quantum public key of a hybrid certificate. The
+// signatures of the identities of hybrid certificates are hybrid signatures,
+// carrying both their classical ECDSA signature and their post-quantum
+// signature. The post-quantum signatures are verified only by the MSPs of
+// the channels with the post-quantum capability.
+func NewPostQuantumExtension(pk bccsp.Key) (pkix.Extension, error) {
+	raw, err := pk.Bytes()
+	if err != nil {
+		return pkix.Extension{}, errors.Wrap(err, "failed marshalling post-quantum public key")
+	}
+	return pkix.Extension{Id: oidSubjectAltPublicKeyInfo, Value: raw}, nil
+}
+
+// getPostQuantumPublicKey returns the post-quantum public key of a hybrid
+// certificate, or nil if the certificate has a classical public key only
+func (msp *bccspmsp) getPostQuantumPublicKey(cert *x509.Certificate) (bccsp.Key, error) {
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(oidSubjectAltPublicKeyInfo) {
+			continue
+		}
+		pk, err := msp.bccsp.KeyImport(ext.Value, &bccsp.DilithiumPKIXPublicKeyImportOpts{Temporary: true})
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed importing post-quantum public key")
+		}
+		return pk, nil
+	}
+	return nil, nil
+}
+
+// isHybridCertificate returns true if the certificate carries a post-quantum
+// public key, whether or not the BCCSP of the MSP supports it
+func isHybridCertificate(cert *x509.Certificate) bool {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidSubjectAltPublicKeyInfo) {
+			return true
+		}
+	}
+	return false
+}
+
+// hybridSignature is the signature of the identities of hybrid certificates:
+//
+//	HybridSignature ::= SEQUENCE {
+//	  classical    OCTET STRING, -- DER encoded ECDSA signature
+//	  postQuantum  OCTET STRING }
+type hybridSignature struct {
+	Classical   []byte
+	PostQuantum []byte
+}
+
+func marshalHybridSignature(classical, postQuantum []byte) ([]byte, error) {
+	sig, err := asn1.Marshal(hybridSignature{Classical: classical, PostQuantum: postQuantum})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshalling hybrid signature")
+	}
+	return sig, nil
+}
+
+func unmarshalHybridSignature(raw []byte) (*hybridSignature, error) {
+	sig := &hybridSignature{}
+	rest, err := asn1.Unmarshal(raw, sig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshalling hybrid signature")
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("failed unmarshalling hybrid signature: trailing data")
+	}
+	return sig, nil
+}
@@ -0,0 +1,145 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/dilithium"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupHybridMSP(t *testing.T, version MSPVersion, csp bccsp.BCCSP, root *testCert, signer *testCert) (MSP, error) {
+	conf := &msp.FabricMSPConfig{
+		Name:      "HybridMSP",
+		RootCerts: [][]byte{root.pem},
+	}
+	if signer != nil {
+		der, err := x509.MarshalECPrivateKey(signer.key)
+		assert.NoError(t, err)
+		conf.SigningIdentity = &msp.SigningIdentityInfo{
+			PublicSigner:  signer.pem,
+			PrivateSigner: &msp.KeyInfo{KeyMaterial: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})},
+		}
+	}
+	confBytes, err := proto.Marshal(conf)
+	assert.NoError(t, err)
+
+	thisMSP, err := newBccspMsp(version)
+	assert.NoError(t, err)
+	if csp != nil {
+		thisMSP.(*bccspmsp).bccsp = csp
+	}
+	return thisMSP, thisMSP.Setup(&msp.MSPConfig{Type: int32(FABRIC), Config: confBytes})
+}
+
+func TestHybridCertificates(t *testing.T) {
+	csp, err := sw.NewPostQuantumWithParams(256, "SHA2", sw.NewInMemoryKeyStore())
+	assert.NoError(t, err)
+	pqKey, err := csp.KeyGen(&bccsp.DilithiumKeyGenOpts{})
+	assert.NoError(t, err)
+	pqPk, err := pqKey.PublicKey()
+	assert.NoError(t, err)
+	ext, err := NewPostQuantumExtension(pqPk)
+	assert.NoError(t, err)
+	assert.False(t, ext.Critical)
+
+	root := newTestCA(t, "root", nil)
+	hybrid := issueTestCert(t, &x509.Certificate{
+		Subject:         pkix.Name{CommonName: "peer0.org1.example.com"},
+		ExtraExtensions: []pkix.Extension{ext},
+	}, root)
+	classical := newTestIdentityCert(t, "peer1.org1.example.com", nil, root)
+
+	// the local MSP signs with both keys
+	localMSP, err := setupHybridMSP(t, MSPv1_0, csp, root, hybrid)
+	assert.NoError(t, err)
+	signer, err := localMSP.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	msg := []byte("hello world")
+	sig, err := signer.Sign(msg)
+	assert.NoError(t, err)
+	hybridSig, err := unmarshalHybridSignature(sig)
+	assert.NoError(t, err)
+	assert.Len(t, hybridSig.PostQuantum, dilithium.SignatureSize)
+	classicalSig := hybridSig.Classical
+	serialized, err := signer.Serialize()
+	assert.NoError(t, err)
+
+	pqMSP, err := setupHybridMSP(t, MSPv1_4_PQ, csp, root, nil)
+	assert.NoError(t, err)
+	id, err := pqMSP.DeserializeIdentity(serialized)
+	assert.NoError(t, err)
+	assert.NoError(t, pqMSP.Validate(id))
+	assert.NoError(t, id.Verify(msg, sig))
+	err = id.Verify(msg, classicalSig)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed unmarshalling hybrid signature")
+	noPQSig, err := marshalHybridSignature(classicalSig, nil)
+	assert.NoError(t, err)
+	assert.EqualError(t, id.Verify(msg, noPQSig), "The post-quantum signature is missing")
+	tamperedPQSig := append([]byte(nil), hybridSig.PostQuantum...)
+	tamperedPQSig[len(tamperedPQSig)-1] ^= 1
+	tampered, err := marshalHybridSignature(classicalSig, tamperedPQSig)
+	assert.NoError(t, err)
+	assert.EqualError(t, id.Verify(msg, tampered), "The post-quantum signature is invalid")
+	tamperedClassicalSig := append([]byte(nil), classicalSig...)
+	tamperedClassicalSig[len(tamperedClassicalSig)-1] ^= 1
+	tampered, err = marshalHybridSignature(tamperedClassicalSig, hybridSig.PostQuantum)
+	assert.NoError(t, err)
+	assert.Error(t, id.Verify(msg, tampered))
+	assert.EqualError(t, id.Verify(msg, append(sig, 0)), "failed unmarshalling hybrid signature: trailing data")
+
+	// the MSPs without the post-quantum capability verify the classical signatures only
+	for _, legacyMSP := range []func() (MSP, error){
+		func() (MSP, error) { return setupHybridMSP(t, MSPv1_3, csp, root, nil) },
+		func() (MSP, error) { return setupHybridMSP(t, MSPv1_3, nil, root, nil) },
+	} {
+		thisMSP, err := legacyMSP()
+		assert.NoError(t, err)
+		id, err := thisMSP.DeserializeIdentity(serialized)
+		assert.NoError(t, err)
+		assert.NoError(t, id.Verify(msg, sig))
+		assert.NoError(t, id.Verify(msg, classicalSig))
+		assert.NoError(t, id.Verify(msg, noPQSig))
+		assert.Error(t, id.Verify(msg, tampered))
+	}
+
+	// the post-quantum MSPs require a BCCSP supporting the post-quantum keys
+	swMSP, err := setupHybridMSP(t, MSPv1_4_PQ, nil, root, nil)
+	assert.NoError(t, err)
+	_, err = swMSP.DeserializeIdentity(serialized)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed importing post-quantum public key")
+
+	// classical certificates are unaffected
+	classicalMSP, err := setupHybridMSP(t, MSPv1_0, csp, root, classical)
+	assert.NoError(t, err)
+	classicalSigner, err := classicalMSP.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	sig, err = classicalSigner.Sign(msg)
+	assert.NoError(t, err)
+	serialized, err = classicalSigner.Serialize()
+	assert.NoError(t, err)
+	id, err = pqMSP.DeserializeIdentity(serialized)
+	assert.NoError(t, err)
+	assert.NoError(t, id.Verify(msg, sig))
+
+	// the post-quantum private key must be found to sign
+	emptyCSP, err := sw.NewPostQuantumWithParams(256, "SHA2", sw.NewInMemoryKeyStore())
+	assert.NoError(t, err)
+	_, err = setupHybridMSP(t, MSPv1_0, emptyCSP, root, hybrid)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed finding post-quantum private key")
+}
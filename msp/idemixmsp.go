@@ -144,6 +144,7 @@ func (msp *idemixmsp) Setup(conf1 *m.MSPConfig) error {
 		return errors.WithMessage(err, "failed to import revocation public key")
 	}
 	msp.revocationPK = RevocationPublicKey
+	msp.epoch = int(conf.Epoch)
 
 	if conf.Signer == nil {
 		// No credential in config, so we don't setup a default signer
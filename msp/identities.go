@@ -34,6 +34,9 @@ type identity struct {
 	// this is the public key of this instance
 	pk bccsp.Key
 
+	// pqPk is the post-quantum public key of this instance, if its certificate is hybrid
+	pqPk bccsp.Key
+
 	// reference to the MSP that "owns" this identity
 	msp *bccspmsp
 }
@@ -49,6 +52,16 @@ func newIdentity(cert *x509.Certificate, pk bccsp.Key, msp *bccspmsp) (Identity,
 		return nil, err
 	}
 
+	pqPk, err := msp.getPostQuantumPublicKey(cert)
+	if err != nil {
+		if msp.version >= MSPv1_4_PQ {
+			return nil, err
+		}
+		// without the post-quantum capability, hybrid certificates are classical ones
+		mspIdentityLogger.Debugf("Ignoring post-quantum public key of hybrid certificate: %s", err)
+		pqPk = nil
+	}
+
 	// Compute identity identifier
 
 	// Use the hash of the identity's certificate as id in the IdentityIdentifier
@@ -66,7 +79,7 @@ func newIdentity(cert *x509.Certificate, pk bccsp.Key, msp *bccspmsp) (Identity,
 		Mspid: msp.name,
 		Id:    hex.EncodeToString(digest)}
 
-	return &identity{id: id, cert: cert, pk: pk, msp: msp}, nil
+	return &identity{id: id, cert: cert, pk: pk, pqPk: pqPk, msp: msp}, nil
 }
 
 // ExpiresAt returns the time at which the Identity expires.
@@ -160,13 +173,39 @@ func (id *identity) Verify(msg []byte, sig []byte) error {
 		mspIdentityLogger.Debugf("Verify: sig = %s", hex.Dump(sig))
 	}
 
-	valid, err := id.msp.bccsp.Verify(id.pk, sig, digest, nil)
+	// the MSPs of the channels with the post-quantum capability require the
+	// post-quantum signatures of the hybrid certificates, while the others
+	// verify their classical signatures, hybrid or not, only
+	requirePQ := id.pqPk != nil && id.msp.version >= MSPv1_4_PQ
+	classicalSig, pqSig := sig, []byte(nil)
+	if isHybridCertificate(id.cert) {
+		hybridSig, err := unmarshalHybridSignature(sig)
+		if err == nil {
+			classicalSig, pqSig = hybridSig.Classical, hybridSig.PostQuantum
+		} else if requirePQ {
+			return err
+		}
+	}
+	if requirePQ && len(pqSig) == 0 {
+		return errors.New("The post-quantum signature is missing")
+	}
+
+	valid, err := id.msp.bccsp.Verify(id.pk, classicalSig, digest, nil)
 	if err != nil {
 		return errors.WithMessage(err, "could not determine the validity of the signature")
 	} else if !valid {
 		return errors.New("The signature is invalid")
 	}
 
+	if requirePQ {
+		valid, err = id.msp.bccsp.Verify(id.pqPk, pqSig, digest, nil)
+		if err != nil {
+			return errors.WithMessage(err, "could not determine the validity of the post-quantum signature")
+		} else if !valid {
+			return errors.New("The post-quantum signature is invalid")
+		}
+	}
+
 	return nil
 }
 
@@ -206,15 +245,18 @@ type signingidentity struct {
 
 	// signer corresponds to the object that can produce signatures from this identity
 	signer crypto.Signer
+
+	// pqSigner produces the post-quantum signatures of hybrid certificates, if any
+	pqSigner crypto.Signer
 }
 
-func newSigningIdentity(cert *x509.Certificate, pk bccsp.Key, signer crypto.Signer, msp *bccspmsp) (SigningIdentity, error) {
+func newSigningIdentity(cert *x509.Certificate, pk bccsp.Key, signer crypto.Signer, pqSigner crypto.Signer, msp *bccspmsp) (SigningIdentity, error) {
 	//mspIdentityLogger.Infof("Creating signing identity instance for ID %s", id)
 	mspId, err := newIdentity(cert, pk, msp)
 	if err != nil {
 		return nil, err
 	}
-	return &signingidentity{identity: *mspId.(*identity), signer: signer}, nil
+	return &signingidentity{identity: *mspId.(*identity), signer: signer, pqSigner: pqSigner}, nil
 }
 
 // Sign produces a signature over msg, signed by this instance
@@ -240,7 +282,17 @@ func (id *signingidentity) Sign(msg []byte) ([]byte, error) {
 	mspIdentityLogger.Debugf("Sign: digest: %X \n", digest)
 
 	// Sign
-	return id.signer.Sign(rand.Reader, digest, nil)
+	sig, err := id.signer.Sign(rand.Reader, digest, nil)
+	if err != nil || id.pqSigner == nil {
+		return sig, err
+	}
+
+	// hybrid signature
+	pqSig, err := id.pqSigner.Sign(rand.Reader, digest, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed computing post-quantum signature")
+	}
+	return marshalHybridSignature(sig, pqSig)
 }
 
 // GetPublicVersion returns the public version of this identity,
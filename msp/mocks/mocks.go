@@ -108,8 +108,8 @@ func (*MockIdentity) GetOrganizationalUnits() []*msp.OUIdentifier {
 	panic("implement me")
 }
 
-func (*MockIdentity) Verify(msg []byte, sig []byte) error {
-	panic("implement me")
+func (m *MockIdentity) Verify(msg []byte, sig []byte) error {
+	return m.Called(msg, sig).Error(0)
 }
 
 func (*MockIdentity) Serialize() ([]byte, error) {
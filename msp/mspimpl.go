@@ -8,6 +8,7 @@ package msp
 
 import (
 	"bytes"
+	"crypto"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/hex"
@@ -94,6 +95,10 @@ type bccspmsp struct {
 	// These are the OUIdentifiers of the clients, peers and orderers.
 	// They are used to tell apart these entities
 	clientOU, peerOU *OUIdentifier
+
+	// certConstraints contains the constraints on the certification paths
+	// of the identities, if any
+	certConstraints *m.FabricCertificateConstraints
 }
 
 // newBccspMsp returns an MSP instance backed up by a BCCSP
@@ -116,7 +121,7 @@ func newBccspMsp(version MSPVersion) (MSP, error) {
 		theMsp.internalSetupFunc = theMsp.setupV11
 		theMsp.internalValidateIdentityOusFunc = theMsp.validateIdentityOUsV11
 		theMsp.internalSatisfiesPrincipalInternalFunc = theMsp.satisfiesPrincipalInternalPreV13
-	case MSPv1_3:
+	case MSPv1_3, MSPv1_4_PQ:
 		theMsp.internalSetupFunc = theMsp.setupV11
 		theMsp.internalValidateIdentityOusFunc = theMsp.validateIdentityOUsV11
 		theMsp.internalSatisfiesPrincipalInternalFunc = theMsp.satisfiesPrincipalInternalV13
@@ -199,7 +204,20 @@ func (msp *bccspmsp) getSigningIdentityFromConf(sidInfo *m.SigningIdentityInfo)
 		return nil, errors.WithMessage(err, "getIdentityFromBytes error: Failed initializing bccspCryptoSigner")
 	}
 
-	return newSigningIdentity(idPub.(*identity).cert, idPub.(*identity).pk, peerSigner, msp)
+	// get the post-quantum signer of hybrid certificates
+	var pqSigner crypto.Signer
+	if pqPk := idPub.(*identity).pqPk; pqPk != nil {
+		pqPrivKey, err := msp.bccsp.GetKey(pqPk.SKI())
+		if err != nil {
+			return nil, errors.WithMessage(err, "getIdentityFromBytes error: Failed finding post-quantum private key")
+		}
+		pqSigner, err = signer.New(msp.bccsp, pqPrivKey)
+		if err != nil {
+			return nil, errors.WithMessage(err, "getIdentityFromBytes error: Failed initializing post-quantum bccspCryptoSigner")
+		}
+	}
+
+	return newSigningIdentity(idPub.(*identity).cert, idPub.(*identity).pk, peerSigner, pqSigner, msp)
 }
 
 // Setup sets up the internal data structures
@@ -603,7 +621,7 @@ func (msp *bccspmsp) getUniqueValidationChain(cert *x509.Certificate, opts x509.
 	}
 	validationChains, err := cert.Verify(opts)
 	if err != nil {
-		return nil, errors.WithMessage(err, "the supplied identity is not valid")
+		return nil, errors.WithMessage(describeVerificationError(err), "the supplied identity is not valid")
 	}
 
 	// we only support a single validation chain;
@@ -162,6 +162,50 @@ func (msp *bccspmsp) setupCAs(conf *m.FabricMSPConfig) error {
 	return nil
 }
 
+// extKeyUsages maps the names of the extended key usages that can be
+// required by the certificate constraints to their x509 value
+var extKeyUsages = map[string]x509.ExtKeyUsage{
+	"any":             x509.ExtKeyUsageAny,
+	"serverAuth":      x509.ExtKeyUsageServerAuth,
+	"clientAuth":      x509.ExtKeyUsageClientAuth,
+	"codeSigning":     x509.ExtKeyUsageCodeSigning,
+	"emailProtection": x509.ExtKeyUsageEmailProtection,
+	"timeStamping":    x509.ExtKeyUsageTimeStamping,
+	"OCSPSigning":     x509.ExtKeyUsageOCSPSigning,
+}
+
+func (msp *bccspmsp) setupCertificateConstraints(conf *m.FabricMSPConfig) error {
+	constraints := conf.CertificateConstraints
+	if constraints == nil {
+		return nil
+	}
+
+	if constraints.MaxChainLength == 1 {
+		return errors.New("invalid certificate constraints: the maximum chain length must be at least 2, to include the identity and root CA certificates")
+	}
+	for _, domain := range append(constraints.PermittedDnsDomains, constraints.ExcludedDnsDomains...) {
+		if domain == "" || domain == "." {
+			return errors.Errorf("invalid certificate constraints: invalid DNS domain [%s]", domain)
+		}
+	}
+
+	keyUsages := make([]x509.ExtKeyUsage, 0, len(constraints.ExtendedKeyUsages))
+	for _, name := range constraints.ExtendedKeyUsages {
+		keyUsage, ok := extKeyUsages[name]
+		if !ok {
+			return errors.Errorf("invalid certificate constraints: unknown extended key usage [%s]", name)
+		}
+		keyUsages = append(keyUsages, keyUsage)
+	}
+	// the verify options are applied when validating the certification chains
+	if len(keyUsages) > 0 {
+		msp.opts.KeyUsages = keyUsages
+	}
+	msp.certConstraints = constraints
+
+	return nil
+}
+
 func (msp *bccspmsp) setupAdmins(conf *m.FabricMSPConfig) error {
 	// make and fill the set of admin certs (if present)
 	msp.admins = make([]Identity, len(conf.Admins))
@@ -409,6 +453,11 @@ func (msp *bccspmsp) preSetupV1(conf *m.FabricMSPConfig) error {
 		return err
 	}
 
+	// Setup the certificate constraints
+	if err := msp.setupCertificateConstraints(conf); err != nil {
+		return err
+	}
+
 	// setup the signer (if present)
 	if err := msp.setupSigningIdentity(conf); err != nil {
 		return err
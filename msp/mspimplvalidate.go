@@ -13,6 +13,7 @@ import (
 	"encoding/asn1"
 	"math/big"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -29,6 +30,11 @@ func (msp *bccspmsp) validateIdentity(id *identity) error {
 		return errors.WithMessage(err, "could not validate identity against certification chain")
 	}
 
+	err = msp.validateCertificateConstraints(id.cert, validationChain)
+	if err != nil {
+		return errors.WithMessage(err, "could not validate identity against the certificate constraints")
+	}
+
 	err = msp.internalValidateIdentityOusFunc(id)
 	if err != nil {
 		return errors.WithMessage(err, "could not validate identity's OUs")
@@ -126,6 +132,76 @@ func (msp *bccspmsp) validateCertAgainstChain(cert *x509.Certificate, validation
 	return nil
 }
 
+// validateCertificateConstraints checks the certification chain against the
+// certificate constraints of the MSP. The extended key usages are checked
+// when the chain is built, as part of the verify options.
+func (msp *bccspmsp) validateCertificateConstraints(cert *x509.Certificate, validationChain []*x509.Certificate) error {
+	constraints := msp.certConstraints
+	if constraints == nil {
+		return nil
+	}
+
+	if constraints.MaxChainLength != 0 && len(validationChain) > int(constraints.MaxChainLength) {
+		return errors.Errorf("the certification chain of length %d exceeds the maximum chain length %d of MSP %s", len(validationChain), constraints.MaxChainLength, msp.name)
+	}
+
+	for _, name := range cert.DNSNames {
+		if len(constraints.PermittedDnsDomains) > 0 {
+			permitted := false
+			for _, domain := range constraints.PermittedDnsDomains {
+				if matchDNSDomain(name, domain) {
+					permitted = true
+					break
+				}
+			}
+			if !permitted {
+				return errors.Errorf("DNS name [%s] is not in the permitted DNS domains %v of MSP %s", name, constraints.PermittedDnsDomains, msp.name)
+			}
+		}
+		for _, domain := range constraints.ExcludedDnsDomains {
+			if matchDNSDomain(name, domain) {
+				return errors.Errorf("DNS name [%s] is in the excluded DNS domain [%s] of MSP %s", name, domain, msp.name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// matchDNSDomain tells whether the DNS name is in the domain, following the
+// semantics of the X.509 name constraints: a domain with a leading dot only
+// matches its subdomains, otherwise it also matches the name itself.
+func matchDNSDomain(name, domain string) bool {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	domain = strings.ToLower(domain)
+	if strings.HasPrefix(domain, ".") {
+		return strings.HasSuffix(name, domain)
+	}
+	return name == domain || strings.HasSuffix(name, "."+domain)
+}
+
+// describeVerificationError explains the x509 verification errors caused by
+// the constraints of the CA certificates and by the extended key usages.
+func describeVerificationError(err error) error {
+	invalidErr, ok := err.(x509.CertificateInvalidError)
+	if !ok || invalidErr.Cert == nil {
+		return err
+	}
+	subject := invalidErr.Cert.Subject
+	switch invalidErr.Reason {
+	case x509.TooManyIntermediates:
+		return errors.Errorf("the certification path exceeds the path length constraint of CA certificate [%s]", subject)
+	case x509.CANotAuthorizedForThisName:
+		return errors.Errorf("certificate [%s] is not permitted by the name constraints of its CA certificates: %s", subject, invalidErr.Detail)
+	case x509.CANotAuthorizedForExtKeyUsage:
+		return errors.Errorf("certificate [%s] is not permitted by the extended key usages of its CA certificates: %s", subject, invalidErr.Detail)
+	case x509.IncompatibleUsage:
+		return errors.Errorf("certificate [%s] and its CA certificates do not allow the extended key usages required by the MSP", subject)
+	default:
+		return err
+	}
+}
+
 func (msp *bccspmsp) validateIdentityOUsV1(id *identity) error {
 	// Check that the identity's OUs are compatible with those recognized by this MSP,
 	// meaning that the intersection is not empty.
@@ -39,14 +39,17 @@ type receiver struct {
 	PendingBatchStartTime time.Time
 	ChannelID             string
 	Metrics               *Metrics
+	CutPolicy             CutPolicy
 }
 
-// NewReceiverImpl creates a Receiver implementation based on the given configtxorderer manager
-func NewReceiverImpl(channelID string, sharedConfigFetcher OrdererConfigFetcher, metrics *Metrics) Receiver {
+// NewReceiverImpl creates a Receiver implementation based on the given configtxorderer manager.
+// The cut policy, if not nil, may cut the pending batch before it reaches the batch size.
+func NewReceiverImpl(channelID string, sharedConfigFetcher OrdererConfigFetcher, metrics *Metrics, cutPolicy CutPolicy) Receiver {
 	return &receiver{
 		sharedConfigFetcher: sharedConfigFetcher,
 		Metrics:             metrics,
 		ChannelID:           channelID,
+		CutPolicy:           cutPolicy,
 	}
 }
 
@@ -57,7 +60,7 @@ func NewReceiverImpl(channelID string, sharedConfigFetcher OrdererConfigFetcher,
 // messageBatches length: 0, pending: true
 //   - no batch is cut and there are messages pending
 // messageBatches length: 1, pending: false
-//   - the message count reaches BatchSize.MaxMessageCount
+//   - the message count reaches BatchSize.MaxMessageCount, or the cut policy cuts the batch
 // messageBatches length: 1, pending: true
 //   - the current message will cause the pending batch size in bytes to exceed BatchSize.PreferredMaxBytes.
 // messageBatches length: 2, pending: false
@@ -118,6 +121,14 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 		messageBatch := r.Cut()
 		messageBatches = append(messageBatches, messageBatch)
 		pending = false
+		return
+	}
+
+	if r.CutPolicy != nil && r.CutPolicy.CutAfter(msg, r.pendingBatch, r.PendingBatchStartTime) {
+		logger.Debugf("Cut policy met, cutting batch")
+		messageBatch := r.Cut()
+		messageBatches = append(messageBatches, messageBatch)
+		pending = false
 	}
 
 	return
@@ -37,7 +37,7 @@ var _ = Describe("Blockcutter", func() {
 			BlockFillDuration: fakeBlockFillDuration,
 		}
 
-		bc = blockcutter.NewReceiverImpl("mychannel", fakeConfigFetcher, metrics)
+		bc = blockcutter.NewReceiverImpl("mychannel", fakeConfigFetcher, metrics, nil)
 	})
 
 	Describe("Ordered", func() {
@@ -173,6 +173,53 @@ var _ = Describe("Blockcutter", func() {
 			})
 		})
 
+		Context("when the cut policy cuts the batch", func() {
+			var fakeCutPolicy *mock.CutPolicy
+
+			BeforeEach(func() {
+				fakeConfig.BatchSizeReturns(&ab.BatchSize{
+					MaxMessageCount:   3,
+					PreferredMaxBytes: 100,
+				})
+				fakeCutPolicy = &mock.CutPolicy{}
+				fakeCutPolicy.CutAfterReturnsOnCall(1, true)
+				bc = blockcutter.NewReceiverImpl("mychannel", fakeConfigFetcher, metrics, fakeCutPolicy)
+			})
+
+			It("cuts the batch including the message", func() {
+				batches, pending := bc.Ordered(message)
+				Expect(batches).To(BeEmpty())
+				Expect(pending).To(BeTrue())
+
+				batches, pending = bc.Ordered(message)
+				Expect(batches).To(Equal([][]*cb.Envelope{{message, message}}))
+				Expect(pending).To(BeFalse())
+
+				Expect(fakeCutPolicy.CutAfterCallCount()).To(Equal(2))
+				msg, pendingBatch, pendingSince := fakeCutPolicy.CutAfterArgsForCall(1)
+				Expect(msg).To(Equal(message))
+				Expect(pendingBatch).To(Equal([]*cb.Envelope{message, message}))
+				Expect(pendingSince).NotTo(BeZero())
+				Expect(fakeBlockFillDuration.ObserveCallCount()).To(Equal(1))
+			})
+
+			Context("when the batch size is met", func() {
+				BeforeEach(func() {
+					fakeConfig.BatchSizeReturns(&ab.BatchSize{
+						MaxMessageCount:   2,
+						PreferredMaxBytes: 100,
+					})
+				})
+
+				It("does not consult the cut policy", func() {
+					bc.Ordered(message)
+					batches, _ := bc.Ordered(message)
+					Expect(batches).To(HaveLen(1))
+					Expect(fakeCutPolicy.CutAfterCallCount()).To(Equal(1))
+				})
+			})
+		})
+
 		Context("when the orderer config cannot be retrieved", func() {
 			BeforeEach(func() {
 				fakeConfigFetcher.OrdererConfigReturns(nil, false)
@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockcutter
+
+import (
+	"time"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+//go:generate counterfeiter -o mock/cut_policy.go --fake-name CutPolicy . CutPolicy
+
+// CutPolicy decides when to cut the pending batch beyond the batch size and
+// batch timeout of the channel configuration, for instance to order urgent
+// messages without waiting for the batch to fill.
+type CutPolicy interface {
+	// CutAfter is invoked once the message has been added to the pending
+	// batch, and returns true if the pending batch must be cut right away.
+	// pendingSince is the time the first message of the batch was enqueued.
+	CutAfter(msg *cb.Envelope, pendingBatch []*cb.Envelope, pendingSince time.Time) bool
+}
+
+// CutPolicies cuts the pending batch when any of its policies does.
+type CutPolicies []CutPolicy
+
+// CutAfter returns true if any of the policies returns true.
+func (c CutPolicies) CutAfter(msg *cb.Envelope, pendingBatch []*cb.Envelope, pendingSince time.Time) bool {
+	for _, policy := range c {
+		if policy.CutAfter(msg, pendingBatch, pendingSince) {
+			return true
+		}
+	}
+	return false
+}
+
+// PriorityCutPolicy cuts the pending batch as soon as a message of one of
+// the priority header types is enqueued.
+type PriorityCutPolicy struct {
+	HeaderTypes []cb.HeaderType
+}
+
+// CutAfter returns true if the message has one of the priority header types.
+func (p *PriorityCutPolicy) CutAfter(msg *cb.Envelope, pendingBatch []*cb.Envelope, pendingSince time.Time) bool {
+	chdr, err := utils.ChannelHeader(msg)
+	if err != nil {
+		logger.Debugf("Could not extract the channel header of the message, not cutting batch: %s", err)
+		return false
+	}
+	for _, headerType := range p.HeaderTypes {
+		if cb.HeaderType(chdr.Type) == headerType {
+			logger.Debugf("Message of priority header type %s enqueued, cutting batch", headerType)
+			return true
+		}
+	}
+	return false
+}
+
+// LatencyCutPolicy cuts the pending batch when a message is enqueued after
+// the first message of the batch has waited longer than the target latency.
+// The batch timeout still bounds the latency when no message arrives.
+type LatencyCutPolicy struct {
+	Target time.Duration
+	// Now returns the current time, time.Now if nil
+	Now func() time.Time
+}
+
+// CutAfter returns true if the pending batch is older than the target latency.
+func (l *LatencyCutPolicy) CutAfter(msg *cb.Envelope, pendingBatch []*cb.Envelope, pendingSince time.Time) bool {
+	now := time.Now
+	if l.Now != nil {
+		now = l.Now
+	}
+	if pendingSince.IsZero() || now().Sub(pendingSince) < l.Target {
+		return false
+	}
+	logger.Debugf("Pending batch exceeded the latency target of %s, cutting batch", l.Target)
+	return true
+}
@@ -0,0 +1,92 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockcutter_test
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric/orderer/common/blockcutter"
+	"github.com/hyperledger/fabric/orderer/common/blockcutter/mock"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func envelopeOfType(headerType cb.HeaderType) *cb.Envelope {
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
+					Type:      int32(headerType),
+					ChannelId: "mychannel",
+				}),
+			},
+		}),
+	}
+}
+
+var _ = Describe("CutPolicy", func() {
+	Describe("PriorityCutPolicy", func() {
+		var policy *blockcutter.PriorityCutPolicy
+
+		BeforeEach(func() {
+			policy = &blockcutter.PriorityCutPolicy{
+				HeaderTypes: []cb.HeaderType{cb.HeaderType_TOKEN_TRANSACTION},
+			}
+		})
+
+		It("cuts after messages of a priority header type", func() {
+			Expect(policy.CutAfter(envelopeOfType(cb.HeaderType_TOKEN_TRANSACTION), nil, time.Now())).To(BeTrue())
+		})
+
+		It("does not cut after other messages", func() {
+			Expect(policy.CutAfter(envelopeOfType(cb.HeaderType_ENDORSER_TRANSACTION), nil, time.Now())).To(BeFalse())
+		})
+
+		It("does not cut after malformed messages", func() {
+			Expect(policy.CutAfter(&cb.Envelope{Payload: []byte("garbage")}, nil, time.Now())).To(BeFalse())
+		})
+	})
+
+	Describe("LatencyCutPolicy", func() {
+		var (
+			policy *blockcutter.LatencyCutPolicy
+			now    time.Time
+		)
+
+		BeforeEach(func() {
+			now = time.Unix(1000, 0)
+			policy = &blockcutter.LatencyCutPolicy{
+				Target: time.Second,
+				Now:    func() time.Time { return now },
+			}
+		})
+
+		It("cuts batches older than the target", func() {
+			Expect(policy.CutAfter(&cb.Envelope{}, nil, now.Add(-time.Second))).To(BeTrue())
+		})
+
+		It("does not cut younger batches", func() {
+			Expect(policy.CutAfter(&cb.Envelope{}, nil, now.Add(-time.Millisecond))).To(BeFalse())
+		})
+
+		It("does not cut batches without a start time", func() {
+			Expect(policy.CutAfter(&cb.Envelope{}, nil, time.Time{})).To(BeFalse())
+		})
+	})
+
+	Describe("CutPolicies", func() {
+		It("cuts when any of the policies does", func() {
+			cutting := &mock.CutPolicy{}
+			cutting.CutAfterReturns(true)
+			Expect(blockcutter.CutPolicies{&mock.CutPolicy{}, cutting}.CutAfter(&cb.Envelope{}, nil, time.Now())).To(BeTrue())
+			Expect(blockcutter.CutPolicies{&mock.CutPolicy{}, &mock.CutPolicy{}}.CutAfter(&cb.Envelope{}, nil, time.Now())).To(BeFalse())
+			Expect(blockcutter.CutPolicies{}.CutAfter(&cb.Envelope{}, nil, time.Now())).To(BeFalse())
+		})
+	})
+})
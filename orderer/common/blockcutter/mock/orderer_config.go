@@ -80,6 +80,16 @@ type OrdererConfig struct {
 	consensusTypeReturnsOnCall map[int]struct {
 		result1 string
 	}
+	IngressQuotasStub        func() map[string]*orderer.IngressQuota
+	ingressQuotasMutex       sync.RWMutex
+	ingressQuotasArgsForCall []struct {
+	}
+	ingressQuotasReturns struct {
+		result1 map[string]*orderer.IngressQuota
+	}
+	ingressQuotasReturnsOnCall map[int]struct {
+		result1 map[string]*orderer.IngressQuota
+	}
 	KafkaBrokersStub        func() []string
 	kafkaBrokersMutex       sync.RWMutex
 	kafkaBrokersArgsForCall []struct {
@@ -478,6 +488,58 @@ func (fake *OrdererConfig) ConsensusTypeReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *OrdererConfig) IngressQuotas() map[string]*orderer.IngressQuota {
+	fake.ingressQuotasMutex.Lock()
+	ret, specificReturn := fake.ingressQuotasReturnsOnCall[len(fake.ingressQuotasArgsForCall)]
+	fake.ingressQuotasArgsForCall = append(fake.ingressQuotasArgsForCall, struct {
+	}{})
+	fake.recordInvocation("IngressQuotas", []interface{}{})
+	fake.ingressQuotasMutex.Unlock()
+	if fake.IngressQuotasStub != nil {
+		return fake.IngressQuotasStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.ingressQuotasReturns
+	return fakeReturns.result1
+}
+
+func (fake *OrdererConfig) IngressQuotasCallCount() int {
+	fake.ingressQuotasMutex.RLock()
+	defer fake.ingressQuotasMutex.RUnlock()
+	return len(fake.ingressQuotasArgsForCall)
+}
+
+func (fake *OrdererConfig) IngressQuotasCalls(stub func() map[string]*orderer.IngressQuota) {
+	fake.ingressQuotasMutex.Lock()
+	defer fake.ingressQuotasMutex.Unlock()
+	fake.IngressQuotasStub = stub
+}
+
+func (fake *OrdererConfig) IngressQuotasReturns(result1 map[string]*orderer.IngressQuota) {
+	fake.ingressQuotasMutex.Lock()
+	defer fake.ingressQuotasMutex.Unlock()
+	fake.IngressQuotasStub = nil
+	fake.ingressQuotasReturns = struct {
+		result1 map[string]*orderer.IngressQuota
+	}{result1}
+}
+
+func (fake *OrdererConfig) IngressQuotasReturnsOnCall(i int, result1 map[string]*orderer.IngressQuota) {
+	fake.ingressQuotasMutex.Lock()
+	defer fake.ingressQuotasMutex.Unlock()
+	fake.IngressQuotasStub = nil
+	if fake.ingressQuotasReturnsOnCall == nil {
+		fake.ingressQuotasReturnsOnCall = make(map[int]struct {
+			result1 map[string]*orderer.IngressQuota
+		})
+	}
+	fake.ingressQuotasReturnsOnCall[i] = struct {
+		result1 map[string]*orderer.IngressQuota
+	}{result1}
+}
+
 func (fake *OrdererConfig) KafkaBrokers() []string {
 	fake.kafkaBrokersMutex.Lock()
 	ret, specificReturn := fake.kafkaBrokersReturnsOnCall[len(fake.kafkaBrokersArgsForCall)]
@@ -651,6 +713,8 @@ func (fake *OrdererConfig) Invocations() map[string][][]interface{} {
 	defer fake.consensusMigrationStateMutex.RUnlock()
 	fake.consensusTypeMutex.RLock()
 	defer fake.consensusTypeMutex.RUnlock()
+	fake.ingressQuotasMutex.RLock()
+	defer fake.ingressQuotasMutex.RUnlock()
 	fake.kafkaBrokersMutex.RLock()
 	defer fake.kafkaBrokersMutex.RUnlock()
 	fake.maxChannelsCountMutex.RLock()
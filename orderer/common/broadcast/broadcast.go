@@ -10,6 +10,7 @@ import (
 	"io"
 	"time"
 
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
@@ -36,6 +37,12 @@ type ChannelSupportRegistrar interface {
 type ChannelSupport interface {
 	msgprocessor.Processor
 	Consenter
+
+	// OrdererConfig returns the current orderer config of the channel
+	OrdererConfig() (channelconfig.Orderer, bool)
+
+	// ApplicationConfig returns the current application config of the channel
+	ApplicationConfig() (channelconfig.Application, bool)
 }
 
 // Consenter provides methods to send messages through consensus
@@ -60,6 +67,15 @@ type Consenter interface {
 type Handler struct {
 	SupportRegistrar ChannelSupportRegistrar
 	Metrics          *Metrics
+	// IngressLimiter, when set, enforces the per-organization ingress quotas
+	// of the channels on normal messages.
+	IngressLimiter *IngressLimiter
+	// PriorityLanes, when set, schedules the enqueuing of normal messages
+	// by the priority of their channel header.
+	PriorityLanes *PriorityLanes
+	// TxExpirationChecker, when set, rejects the normal messages whose not
+	// valid after time has passed.
+	TxExpirationChecker *TxExpirationChecker
 }
 
 // Handle reads requests from a Broadcast stream, processes them, and returns the responses to the stream
@@ -157,55 +173,106 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 		return &ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST, Info: err.Error()}
 	}
 
+	// correlate the records of the transaction across the client, orderer and committing peers
+	lg := logger.ForTransaction(chdr.ChannelId, chdr.TxId, nil)
+
 	if !isConfig {
-		logger.Debugf("[channel: %s] Broadcast is processing normal message from %s with txid '%s' of type %s", chdr.ChannelId, addr, chdr.TxId, cb.HeaderType_name[chdr.Type])
+		lg.Debugf("[channel: %s] Broadcast is processing normal message from %s with txid '%s' of type %s", chdr.ChannelId, addr, chdr.TxId, cb.HeaderType_name[chdr.Type])
 
 		configSeq, err := processor.ProcessNormalMsg(msg)
 		if err != nil {
-			logger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s because of error: %s", chdr.ChannelId, addr, err)
+			lg.Warningf("[channel: %s] Rejecting broadcast of normal message from %s because of error: %s", chdr.ChannelId, addr, err)
 			return &ab.BroadcastResponse{Status: ClassifyError(err), Info: err.Error()}
 		}
+		if err = bh.checkNotExpired(processor, chdr); err != nil {
+			lg.Warningf("[channel: %s] Rejecting broadcast of expired normal message from %s: %s", chdr.ChannelId, addr, err)
+			return &ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST, Info: err.Error()}
+		}
 		tracker.EndValidate()
 
+		if err = bh.admit(chdr.ChannelId, processor, msg); err != nil {
+			lg.Warningf("[channel: %s] Rejecting broadcast of normal message from %s with SERVICE_UNAVAILABLE: %s", chdr.ChannelId, addr, err)
+			return &ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()}
+		}
+
 		tracker.BeginEnqueue()
+		if bh.PriorityLanes != nil {
+			release := bh.PriorityLanes.Acquire(chdr.ChannelId, priority(processor, chdr))
+			defer release()
+		}
 		if err = processor.WaitReady(); err != nil {
-			logger.Warningf("[channel: %s] Rejecting broadcast of message from %s with SERVICE_UNAVAILABLE: rejected by Consenter: %s", chdr.ChannelId, addr, err)
+			lg.Warningf("[channel: %s] Rejecting broadcast of message from %s with SERVICE_UNAVAILABLE: rejected by Consenter: %s", chdr.ChannelId, addr, err)
 			return &ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()}
 		}
 
 		err = processor.Order(msg, configSeq)
 		if err != nil {
-			logger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s with SERVICE_UNAVAILABLE: rejected by Order: %s", chdr.ChannelId, addr, err)
+			lg.Warningf("[channel: %s] Rejecting broadcast of normal message from %s with SERVICE_UNAVAILABLE: rejected by Order: %s", chdr.ChannelId, addr, err)
 			return &ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()}
 		}
 	} else { // isConfig
-		logger.Debugf("[channel: %s] Broadcast is processing config update message from %s", chdr.ChannelId, addr)
+		lg.Debugf("[channel: %s] Broadcast is processing config update message from %s", chdr.ChannelId, addr)
 
 		config, configSeq, err := processor.ProcessConfigUpdateMsg(msg)
 		if err != nil {
-			logger.Warningf("[channel: %s] Rejecting broadcast of config message from %s because of error: %s", chdr.ChannelId, addr, err)
+			lg.Warningf("[channel: %s] Rejecting broadcast of config message from %s because of error: %s", chdr.ChannelId, addr, err)
 			return &ab.BroadcastResponse{Status: ClassifyError(err), Info: err.Error()}
 		}
 		tracker.EndValidate()
 
 		tracker.BeginEnqueue()
 		if err = processor.WaitReady(); err != nil {
-			logger.Warningf("[channel: %s] Rejecting broadcast of message from %s with SERVICE_UNAVAILABLE: rejected by Consenter: %s", chdr.ChannelId, addr, err)
+			lg.Warningf("[channel: %s] Rejecting broadcast of message from %s with SERVICE_UNAVAILABLE: rejected by Consenter: %s", chdr.ChannelId, addr, err)
 			return &ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()}
 		}
 
 		err = processor.Configure(config, configSeq)
 		if err != nil {
-			logger.Warningf("[channel: %s] Rejecting broadcast of config message from %s with SERVICE_UNAVAILABLE: rejected by Configure: %s", chdr.ChannelId, addr, err)
+			lg.Warningf("[channel: %s] Rejecting broadcast of config message from %s with SERVICE_UNAVAILABLE: rejected by Configure: %s", chdr.ChannelId, addr, err)
 			return &ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()}
 		}
 	}
 
-	logger.Debugf("[channel: %s] Broadcast has successfully enqueued message of type %s from %s", chdr.ChannelId, cb.HeaderType_name[chdr.Type], addr)
+	lg.Debugf("[channel: %s] Broadcast has successfully enqueued message of type %s from %s", chdr.ChannelId, cb.HeaderType_name[chdr.Type], addr)
 
 	return &ab.BroadcastResponse{Status: cb.Status_SUCCESS}
 }
 
+// admit applies the ingress quotas of the channel to a validated normal message.
+func (bh *Handler) admit(channelID string, support ChannelSupport, msg *cb.Envelope) error {
+	if bh.IngressLimiter == nil {
+		return nil
+	}
+	ordererConfig, ok := support.OrdererConfig()
+	if !ok {
+		return nil
+	}
+	return bh.IngressLimiter.Admit(channelID, ordererConfig, msg)
+}
+
+// checkNotExpired checks the not valid after time of a validated normal message.
+func (bh *Handler) checkNotExpired(support ChannelSupport, chdr *cb.ChannelHeader) error {
+	if bh.TxExpirationChecker == nil {
+		return nil
+	}
+	applicationConfig, ok := support.ApplicationConfig()
+	if !ok {
+		return nil
+	}
+	return bh.TxExpirationChecker.Check(applicationConfig, chdr)
+}
+
+// priority returns the priority of a validated normal message. The priority
+// filter only authorizes the priorities on the channels with the Priority
+// orderer capability, so it is ignored on the other channels.
+func priority(support ChannelSupport, chdr *cb.ChannelHeader) uint32 {
+	ordererConfig, ok := support.OrdererConfig()
+	if !ok || !ordererConfig.Capabilities().Priority() {
+		return 0
+	}
+	return chdr.Priority
+}
+
 // ClassifyError converts an error type into a status code.
 func ClassifyError(err error) cb.Status {
 	switch errors.Cause(err) {
@@ -10,11 +10,13 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/orderer/common/broadcast"
 	"github.com/hyperledger/fabric/orderer/common/broadcast/mock"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
@@ -197,6 +199,93 @@ var _ = Describe("Broadcast", func() {
 			})
 		})
 
+		Context("when the messages are scheduled by priority", func() {
+			BeforeEach(func() {
+				fakeABServer.RecvReturnsOnCall(1, fakeMsg, nil)
+				fakeABServer.RecvReturnsOnCall(2, nil, io.EOF)
+				handler.PriorityLanes = broadcast.NewPriorityLanes(1, 10)
+			})
+
+			It("releases the lane once each message is enqueued", func() {
+				err := handler.Handle(fakeABServer)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeSupport.OrderCallCount()).To(Equal(2))
+				Expect(fakeABServer.SendCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when the organization has exhausted its ingress quota", func() {
+			BeforeEach(func() {
+				fakeMsg = envelopeFrom("Org1MSP", nil)
+				fakeABServer.RecvReturns(fakeMsg, nil)
+				fakeSupport.OrdererConfigReturns(&mockconfig.Orderer{
+					IngressQuotasVal: map[string]*ab.IngressQuota{
+						"Org1MSP": {MessagesPerSecond: 1},
+					},
+					CapabilitiesVal: &mockconfig.OrdererCapabilities{IngressQuotasVal: true},
+				}, true)
+				handler.IngressLimiter = broadcast.NewIngressLimiter()
+				Expect(handler.IngressLimiter.Admit("fake-channel", &mockconfig.Orderer{
+					IngressQuotasVal: map[string]*ab.IngressQuota{
+						"Org1MSP": {MessagesPerSecond: 1},
+					},
+					CapabilitiesVal: &mockconfig.OrdererCapabilities{IngressQuotasVal: true},
+				}, fakeMsg)).To(Succeed())
+			})
+
+			It("returns the error to the client with a service unavailable status", func() {
+				err := handler.Handle(fakeABServer)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeSupport.OrderCallCount()).To(Equal(0))
+				Expect(fakeABServer.SendCallCount()).To(Equal(1))
+				Expect(proto.Equal(
+					fakeABServer.SendArgsForCall(0),
+					&ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: "Org1MSP: ingress quota exceeded"}),
+				).To(BeTrue())
+			})
+		})
+
+		Context("when the message has expired", func() {
+			BeforeEach(func() {
+				fakeSupportRegistrar.BroadcastChannelSupportReturns(&cb.ChannelHeader{
+					Type:          3,
+					ChannelId:     "fake-channel",
+					TxId:          "tx1",
+					NotValidAfter: timestampAt(time.Unix(999, 0)),
+				}, false, fakeSupport, nil)
+				fakeSupport.ApplicationConfigReturns(&mockconfig.MockApplication{
+					CapabilitiesRv: &mockconfig.MockApplicationCapabilities{TxExpirationRv: true},
+				}, true)
+				handler.TxExpirationChecker = broadcast.NewTxExpirationChecker()
+			})
+
+			It("returns the error to the client with a bad request status", func() {
+				err := handler.Handle(fakeABServer)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeSupport.OrderCallCount()).To(Equal(0))
+				Expect(fakeABServer.SendCallCount()).To(Equal(1))
+				Expect(proto.Equal(
+					fakeABServer.SendArgsForCall(0),
+					&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST, Info: "transaction tx1 expired at 1970-01-01T00:16:39Z"}),
+				).To(BeTrue())
+			})
+
+			Context("when the channel has no application config", func() {
+				BeforeEach(func() {
+					fakeSupport.ApplicationConfigReturns(nil, false)
+				})
+
+				It("enqueues the message to the consenter", func() {
+					err := handler.Handle(fakeABServer)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeSupport.OrderCallCount()).To(Equal(1))
+				})
+			})
+		})
+
 		Context("when the send to the client fails", func() {
 			BeforeEach(func() {
 				fakeABServer.SendReturns(fmt.Errorf("send-error"))
@@ -0,0 +1,54 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// TxExpirationChecker rejects the normal messages whose not valid after time
+// has passed, so that a transaction stuck at a client and resubmitted later is
+// not ordered. The time is checked against the clock of the orderer receiving
+// the message, so it is only checked on broadcast, and not when the consenters
+// re-validate the messages after a config update, on which the orderers must
+// all agree. The committing peers enforce the not valid after block of the
+// transactions, whatever the clocks of the orderers.
+type TxExpirationChecker struct {
+	now func() time.Time
+}
+
+// NewTxExpirationChecker creates a TxExpirationChecker which checks the
+// messages against the local clock.
+func NewTxExpirationChecker() *TxExpirationChecker {
+	return &TxExpirationChecker{now: time.Now}
+}
+
+// Check returns an error if the not valid after time of the message has
+// passed. The time is only checked on the channels with the TxExpiration
+// application capability.
+func (tc *TxExpirationChecker) Check(applicationConfig channelconfig.Application, chdr *cb.ChannelHeader) error {
+	if !applicationConfig.Capabilities().TxExpiration() {
+		return nil
+	}
+	if chdr.NotValidAfter == nil {
+		return nil
+	}
+
+	notValidAfter, err := ptypes.Timestamp(chdr.NotValidAfter)
+	if err != nil {
+		return errors.Wrap(err, "invalid not valid after time")
+	}
+	if tc.now().After(notValidAfter) {
+		return errors.Errorf("transaction %s expired at %s", chdr.TxId, notValidAfter.Format(time.RFC3339))
+	}
+	return nil
+}
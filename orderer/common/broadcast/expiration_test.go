@@ -0,0 +1,72 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast_test
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/orderer/common/broadcast"
+	cb "github.com/hyperledger/fabric/protos/common"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func timestampAt(t time.Time) *timestamp.Timestamp {
+	ts, err := ptypes.TimestampProto(t)
+	Expect(err).NotTo(HaveOccurred())
+	return ts
+}
+
+var _ = Describe("TxExpirationChecker", func() {
+	var (
+		checker           *broadcast.TxExpirationChecker
+		applicationConfig *mockconfig.MockApplication
+	)
+
+	BeforeEach(func() {
+		checker = broadcast.NewTxExpirationChecker()
+		applicationConfig = &mockconfig.MockApplication{
+			CapabilitiesRv: &mockconfig.MockApplicationCapabilities{TxExpirationRv: true},
+		}
+	})
+
+	It("accepts messages without a not valid after time", func() {
+		Expect(checker.Check(applicationConfig, &cb.ChannelHeader{TxId: "tx1"})).To(Succeed())
+	})
+
+	It("accepts messages whose not valid after time has not passed", func() {
+		chdr := &cb.ChannelHeader{TxId: "tx1", NotValidAfter: timestampAt(time.Now().Add(time.Hour))}
+		Expect(checker.Check(applicationConfig, chdr)).To(Succeed())
+	})
+
+	It("rejects messages whose not valid after time has passed", func() {
+		notValidAfter := time.Unix(999, 0).UTC()
+		chdr := &cb.ChannelHeader{TxId: "tx1", NotValidAfter: timestampAt(notValidAfter)}
+		Expect(checker.Check(applicationConfig, chdr)).To(MatchError("transaction tx1 expired at 1970-01-01T00:16:39Z"))
+	})
+
+	It("rejects messages with an invalid not valid after time", func() {
+		chdr := &cb.ChannelHeader{TxId: "tx1", NotValidAfter: &timestamp.Timestamp{Nanos: -1}}
+		err := checker.Check(applicationConfig, chdr)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("invalid not valid after time"))
+	})
+
+	Context("when the channel does not have the TxExpiration capability", func() {
+		BeforeEach(func() {
+			applicationConfig.CapabilitiesRv = &mockconfig.MockApplicationCapabilities{}
+		})
+
+		It("accepts expired messages", func() {
+			chdr := &cb.ChannelHeader{TxId: "tx1", NotValidAfter: timestampAt(time.Unix(999, 0))}
+			Expect(checker.Check(applicationConfig, chdr)).To(Succeed())
+		})
+	})
+})
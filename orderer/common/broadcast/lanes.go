@@ -0,0 +1,119 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast
+
+import (
+	"sync"
+)
+
+// PriorityLanes schedules the enqueuing of the normal messages of each
+// channel by priority. At most maxInFlight messages of a channel are being
+// enqueued at a time, and when the channel is busy the waiting message of the
+// highest priority is enqueued next. A message which has been overtaken by
+// maxSkips messages of a higher priority is enqueued next regardless of its
+// priority, so that the bulk traffic is not starved.
+type PriorityLanes struct {
+	maxInFlight int
+	maxSkips    int
+
+	mutex    sync.Mutex
+	channels map[string]*lanes
+}
+
+// NewPriorityLanes creates a PriorityLanes enqueuing at most maxInFlight
+// messages per channel at a time.
+func NewPriorityLanes(maxInFlight, maxSkips int) *PriorityLanes {
+	if maxInFlight < 1 {
+		maxInFlight = 1
+	}
+	return &PriorityLanes{
+		maxInFlight: maxInFlight,
+		maxSkips:    maxSkips,
+		channels:    map[string]*lanes{},
+	}
+}
+
+// Acquire blocks until a message of the given priority may be enqueued on the
+// channel. The returned function must be called once the message has been
+// enqueued.
+func (pl *PriorityLanes) Acquire(channelID string, priority uint32) (release func()) {
+	pl.mutex.Lock()
+	l, ok := pl.channels[channelID]
+	if !ok {
+		l = &lanes{maxInFlight: pl.maxInFlight, maxSkips: pl.maxSkips}
+		pl.channels[channelID] = l
+	}
+	pl.mutex.Unlock()
+
+	l.acquire(priority)
+	return l.release
+}
+
+// lanes schedules the messages of a single channel.
+type lanes struct {
+	maxInFlight int
+	maxSkips    int
+
+	mutex    sync.Mutex
+	inFlight int
+	waiting  []*laneWaiter // in arrival order
+}
+
+type laneWaiter struct {
+	priority uint32
+	skipped  int
+	ready    chan struct{}
+}
+
+func (l *lanes) acquire(priority uint32) {
+	l.mutex.Lock()
+	if l.inFlight < l.maxInFlight && len(l.waiting) == 0 {
+		l.inFlight++
+		l.mutex.Unlock()
+		return
+	}
+	w := &laneWaiter{priority: priority, ready: make(chan struct{})}
+	l.waiting = append(l.waiting, w)
+	l.mutex.Unlock()
+
+	<-w.ready
+}
+
+func (l *lanes) release() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.inFlight--
+	for l.inFlight < l.maxInFlight && len(l.waiting) > 0 {
+		i := l.next()
+		w := l.waiting[i]
+		l.waiting = append(l.waiting[:i], l.waiting[i+1:]...)
+		for _, other := range l.waiting {
+			if other.priority < w.priority {
+				other.skipped++
+			}
+		}
+		l.inFlight++
+		close(w.ready)
+	}
+}
+
+// next returns the index of the waiting message to enqueue next: the oldest
+// message which was skipped too many times, otherwise the oldest message of
+// the highest priority.
+func (l *lanes) next() int {
+	next := 0
+	for i, w := range l.waiting {
+		if l.maxSkips > 0 && w.skipped >= l.maxSkips {
+			return i
+		}
+		if w.priority > l.waiting[next].priority {
+			next = i
+		}
+	}
+	return next
+}
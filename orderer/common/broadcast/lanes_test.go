@@ -0,0 +1,74 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func (l *lanes) waitingCount() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return len(l.waiting)
+}
+
+func TestPriorityLanes(t *testing.T) {
+	gt := NewGomegaWithT(t)
+
+	pl := NewPriorityLanes(1, 2)
+	release := pl.Acquire("mychannel", 0)
+	l := pl.channels["mychannel"]
+
+	// Queue the messages in a known arrival order, while the only slot is taken
+	enqueued := make(chan string, 5)
+	queue := func(name string, priority uint32) {
+		count := l.waitingCount()
+		go func() {
+			r := pl.Acquire("mychannel", priority)
+			enqueued <- name
+			r()
+		}()
+		gt.Eventually(l.waitingCount).Should(Equal(count + 1))
+	}
+	queue("bulk", 0)
+	queue("high1", 2)
+	queue("low", 1)
+	queue("high2", 2)
+	queue("high3", 2)
+
+	release()
+
+	var order []string
+	for range [5]struct{}{} {
+		order = append(order, <-enqueued)
+	}
+	// The bulk message is overtaken by at most two messages of a higher priority
+	gt.Expect(order).To(Equal([]string{"high1", "high2", "bulk", "low", "high3"}))
+}
+
+func TestPriorityLanesChannels(t *testing.T) {
+	gt := NewGomegaWithT(t)
+
+	pl := NewPriorityLanes(0, 0)
+	release1 := pl.Acquire("channel1", 0)
+	// Channels are scheduled independently of each other
+	release2 := pl.Acquire("channel2", 0)
+
+	acquired := make(chan struct{})
+	go func() {
+		pl.Acquire("channel1", 0)()
+		close(acquired)
+	}()
+	gt.Consistently(acquired).ShouldNot(BeClosed())
+
+	release2()
+	gt.Consistently(acquired).ShouldNot(BeClosed())
+	release1()
+	gt.Eventually(acquired).Should(BeClosed())
+}
@@ -2,18 +2,31 @@
 package mock
 
 import (
-	"sync"
+	sync "sync"
 
-	"github.com/hyperledger/fabric/orderer/common/broadcast"
-	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
-	cb "github.com/hyperledger/fabric/protos/common"
+	channelconfig "github.com/hyperledger/fabric/common/channelconfig"
+	broadcast "github.com/hyperledger/fabric/orderer/common/broadcast"
+	msgprocessor "github.com/hyperledger/fabric/orderer/common/msgprocessor"
+	common "github.com/hyperledger/fabric/protos/common"
 )
 
 type ChannelSupport struct {
-	ClassifyMsgStub        func(chdr *cb.ChannelHeader) msgprocessor.Classification
+	ApplicationConfigStub        func() (channelconfig.Application, bool)
+	applicationConfigMutex       sync.RWMutex
+	applicationConfigArgsForCall []struct {
+	}
+	applicationConfigReturns struct {
+		result1 channelconfig.Application
+		result2 bool
+	}
+	applicationConfigReturnsOnCall map[int]struct {
+		result1 channelconfig.Application
+		result2 bool
+	}
+	ClassifyMsgStub        func(*common.ChannelHeader) msgprocessor.Classification
 	classifyMsgMutex       sync.RWMutex
 	classifyMsgArgsForCall []struct {
-		chdr *cb.ChannelHeader
+		arg1 *common.ChannelHeader
 	}
 	classifyMsgReturns struct {
 		result1 msgprocessor.Classification
@@ -21,77 +34,90 @@ type ChannelSupport struct {
 	classifyMsgReturnsOnCall map[int]struct {
 		result1 msgprocessor.Classification
 	}
-	ProcessNormalMsgStub        func(env *cb.Envelope) (configSeq uint64, err error)
-	processNormalMsgMutex       sync.RWMutex
-	processNormalMsgArgsForCall []struct {
-		env *cb.Envelope
+	ConfigureStub        func(*common.Envelope, uint64) error
+	configureMutex       sync.RWMutex
+	configureArgsForCall []struct {
+		arg1 *common.Envelope
+		arg2 uint64
 	}
-	processNormalMsgReturns struct {
-		result1 uint64
-		result2 error
+	configureReturns struct {
+		result1 error
 	}
-	processNormalMsgReturnsOnCall map[int]struct {
-		result1 uint64
-		result2 error
+	configureReturnsOnCall map[int]struct {
+		result1 error
 	}
-	ProcessConfigUpdateMsgStub        func(env *cb.Envelope) (config *cb.Envelope, configSeq uint64, err error)
-	processConfigUpdateMsgMutex       sync.RWMutex
-	processConfigUpdateMsgArgsForCall []struct {
-		env *cb.Envelope
+	OrderStub        func(*common.Envelope, uint64) error
+	orderMutex       sync.RWMutex
+	orderArgsForCall []struct {
+		arg1 *common.Envelope
+		arg2 uint64
 	}
-	processConfigUpdateMsgReturns struct {
-		result1 *cb.Envelope
-		result2 uint64
-		result3 error
+	orderReturns struct {
+		result1 error
 	}
-	processConfigUpdateMsgReturnsOnCall map[int]struct {
-		result1 *cb.Envelope
-		result2 uint64
-		result3 error
+	orderReturnsOnCall map[int]struct {
+		result1 error
+	}
+	OrdererConfigStub        func() (channelconfig.Orderer, bool)
+	ordererConfigMutex       sync.RWMutex
+	ordererConfigArgsForCall []struct {
+	}
+	ordererConfigReturns struct {
+		result1 channelconfig.Orderer
+		result2 bool
+	}
+	ordererConfigReturnsOnCall map[int]struct {
+		result1 channelconfig.Orderer
+		result2 bool
 	}
-	ProcessConfigMsgStub        func(env *cb.Envelope) (*cb.Envelope, uint64, error)
+	ProcessConfigMsgStub        func(*common.Envelope) (*common.Envelope, uint64, error)
 	processConfigMsgMutex       sync.RWMutex
 	processConfigMsgArgsForCall []struct {
-		env *cb.Envelope
+		arg1 *common.Envelope
 	}
 	processConfigMsgReturns struct {
-		result1 *cb.Envelope
+		result1 *common.Envelope
 		result2 uint64
 		result3 error
 	}
 	processConfigMsgReturnsOnCall map[int]struct {
-		result1 *cb.Envelope
+		result1 *common.Envelope
 		result2 uint64
 		result3 error
 	}
-	OrderStub        func(env *cb.Envelope, configSeq uint64) error
-	orderMutex       sync.RWMutex
-	orderArgsForCall []struct {
-		env       *cb.Envelope
-		configSeq uint64
+	ProcessConfigUpdateMsgStub        func(*common.Envelope) (*common.Envelope, uint64, error)
+	processConfigUpdateMsgMutex       sync.RWMutex
+	processConfigUpdateMsgArgsForCall []struct {
+		arg1 *common.Envelope
 	}
-	orderReturns struct {
-		result1 error
+	processConfigUpdateMsgReturns struct {
+		result1 *common.Envelope
+		result2 uint64
+		result3 error
 	}
-	orderReturnsOnCall map[int]struct {
-		result1 error
+	processConfigUpdateMsgReturnsOnCall map[int]struct {
+		result1 *common.Envelope
+		result2 uint64
+		result3 error
 	}
-	ConfigureStub        func(config *cb.Envelope, configSeq uint64) error
-	configureMutex       sync.RWMutex
-	configureArgsForCall []struct {
-		config    *cb.Envelope
-		configSeq uint64
+	ProcessNormalMsgStub        func(*common.Envelope) (uint64, error)
+	processNormalMsgMutex       sync.RWMutex
+	processNormalMsgArgsForCall []struct {
+		arg1 *common.Envelope
 	}
-	configureReturns struct {
-		result1 error
+	processNormalMsgReturns struct {
+		result1 uint64
+		result2 error
 	}
-	configureReturnsOnCall map[int]struct {
-		result1 error
+	processNormalMsgReturnsOnCall map[int]struct {
+		result1 uint64
+		result2 error
 	}
 	WaitReadyStub        func() error
 	waitReadyMutex       sync.RWMutex
-	waitReadyArgsForCall []struct{}
-	waitReadyReturns     struct {
+	waitReadyArgsForCall []struct {
+	}
+	waitReadyReturns struct {
 		result1 error
 	}
 	waitReadyReturnsOnCall map[int]struct {
@@ -101,21 +127,77 @@ type ChannelSupport struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *ChannelSupport) ClassifyMsg(chdr *cb.ChannelHeader) msgprocessor.Classification {
+func (fake *ChannelSupport) ApplicationConfig() (channelconfig.Application, bool) {
+	fake.applicationConfigMutex.Lock()
+	ret, specificReturn := fake.applicationConfigReturnsOnCall[len(fake.applicationConfigArgsForCall)]
+	fake.applicationConfigArgsForCall = append(fake.applicationConfigArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ApplicationConfig", []interface{}{})
+	fake.applicationConfigMutex.Unlock()
+	if fake.ApplicationConfigStub != nil {
+		return fake.ApplicationConfigStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.applicationConfigReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChannelSupport) ApplicationConfigCallCount() int {
+	fake.applicationConfigMutex.RLock()
+	defer fake.applicationConfigMutex.RUnlock()
+	return len(fake.applicationConfigArgsForCall)
+}
+
+func (fake *ChannelSupport) ApplicationConfigCalls(stub func() (channelconfig.Application, bool)) {
+	fake.applicationConfigMutex.Lock()
+	defer fake.applicationConfigMutex.Unlock()
+	fake.ApplicationConfigStub = stub
+}
+
+func (fake *ChannelSupport) ApplicationConfigReturns(result1 channelconfig.Application, result2 bool) {
+	fake.applicationConfigMutex.Lock()
+	defer fake.applicationConfigMutex.Unlock()
+	fake.ApplicationConfigStub = nil
+	fake.applicationConfigReturns = struct {
+		result1 channelconfig.Application
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *ChannelSupport) ApplicationConfigReturnsOnCall(i int, result1 channelconfig.Application, result2 bool) {
+	fake.applicationConfigMutex.Lock()
+	defer fake.applicationConfigMutex.Unlock()
+	fake.ApplicationConfigStub = nil
+	if fake.applicationConfigReturnsOnCall == nil {
+		fake.applicationConfigReturnsOnCall = make(map[int]struct {
+			result1 channelconfig.Application
+			result2 bool
+		})
+	}
+	fake.applicationConfigReturnsOnCall[i] = struct {
+		result1 channelconfig.Application
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *ChannelSupport) ClassifyMsg(arg1 *common.ChannelHeader) msgprocessor.Classification {
 	fake.classifyMsgMutex.Lock()
 	ret, specificReturn := fake.classifyMsgReturnsOnCall[len(fake.classifyMsgArgsForCall)]
 	fake.classifyMsgArgsForCall = append(fake.classifyMsgArgsForCall, struct {
-		chdr *cb.ChannelHeader
-	}{chdr})
-	fake.recordInvocation("ClassifyMsg", []interface{}{chdr})
+		arg1 *common.ChannelHeader
+	}{arg1})
+	fake.recordInvocation("ClassifyMsg", []interface{}{arg1})
 	fake.classifyMsgMutex.Unlock()
 	if fake.ClassifyMsgStub != nil {
-		return fake.ClassifyMsgStub(chdr)
+		return fake.ClassifyMsgStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.classifyMsgReturns.result1
+	fakeReturns := fake.classifyMsgReturns
+	return fakeReturns.result1
 }
 
 func (fake *ChannelSupport) ClassifyMsgCallCount() int {
@@ -124,13 +206,22 @@ func (fake *ChannelSupport) ClassifyMsgCallCount() int {
 	return len(fake.classifyMsgArgsForCall)
 }
 
-func (fake *ChannelSupport) ClassifyMsgArgsForCall(i int) *cb.ChannelHeader {
+func (fake *ChannelSupport) ClassifyMsgCalls(stub func(*common.ChannelHeader) msgprocessor.Classification) {
+	fake.classifyMsgMutex.Lock()
+	defer fake.classifyMsgMutex.Unlock()
+	fake.ClassifyMsgStub = stub
+}
+
+func (fake *ChannelSupport) ClassifyMsgArgsForCall(i int) *common.ChannelHeader {
 	fake.classifyMsgMutex.RLock()
 	defer fake.classifyMsgMutex.RUnlock()
-	return fake.classifyMsgArgsForCall[i].chdr
+	argsForCall := fake.classifyMsgArgsForCall[i]
+	return argsForCall.arg1
 }
 
 func (fake *ChannelSupport) ClassifyMsgReturns(result1 msgprocessor.Classification) {
+	fake.classifyMsgMutex.Lock()
+	defer fake.classifyMsgMutex.Unlock()
 	fake.ClassifyMsgStub = nil
 	fake.classifyMsgReturns = struct {
 		result1 msgprocessor.Classification
@@ -138,6 +229,8 @@ func (fake *ChannelSupport) ClassifyMsgReturns(result1 msgprocessor.Classificati
 }
 
 func (fake *ChannelSupport) ClassifyMsgReturnsOnCall(i int, result1 msgprocessor.Classification) {
+	fake.classifyMsgMutex.Lock()
+	defer fake.classifyMsgMutex.Unlock()
 	fake.ClassifyMsgStub = nil
 	if fake.classifyMsgReturnsOnCall == nil {
 		fake.classifyMsgReturnsOnCall = make(map[int]struct {
@@ -149,126 +242,199 @@ func (fake *ChannelSupport) ClassifyMsgReturnsOnCall(i int, result1 msgprocessor
 	}{result1}
 }
 
-func (fake *ChannelSupport) ProcessNormalMsg(env *cb.Envelope) (configSeq uint64, err error) {
-	fake.processNormalMsgMutex.Lock()
-	ret, specificReturn := fake.processNormalMsgReturnsOnCall[len(fake.processNormalMsgArgsForCall)]
-	fake.processNormalMsgArgsForCall = append(fake.processNormalMsgArgsForCall, struct {
-		env *cb.Envelope
-	}{env})
-	fake.recordInvocation("ProcessNormalMsg", []interface{}{env})
-	fake.processNormalMsgMutex.Unlock()
-	if fake.ProcessNormalMsgStub != nil {
-		return fake.ProcessNormalMsgStub(env)
+func (fake *ChannelSupport) Configure(arg1 *common.Envelope, arg2 uint64) error {
+	fake.configureMutex.Lock()
+	ret, specificReturn := fake.configureReturnsOnCall[len(fake.configureArgsForCall)]
+	fake.configureArgsForCall = append(fake.configureArgsForCall, struct {
+		arg1 *common.Envelope
+		arg2 uint64
+	}{arg1, arg2})
+	fake.recordInvocation("Configure", []interface{}{arg1, arg2})
+	fake.configureMutex.Unlock()
+	if fake.ConfigureStub != nil {
+		return fake.ConfigureStub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fake.processNormalMsgReturns.result1, fake.processNormalMsgReturns.result2
+	fakeReturns := fake.configureReturns
+	return fakeReturns.result1
 }
 
-func (fake *ChannelSupport) ProcessNormalMsgCallCount() int {
-	fake.processNormalMsgMutex.RLock()
-	defer fake.processNormalMsgMutex.RUnlock()
-	return len(fake.processNormalMsgArgsForCall)
+func (fake *ChannelSupport) ConfigureCallCount() int {
+	fake.configureMutex.RLock()
+	defer fake.configureMutex.RUnlock()
+	return len(fake.configureArgsForCall)
 }
 
-func (fake *ChannelSupport) ProcessNormalMsgArgsForCall(i int) *cb.Envelope {
-	fake.processNormalMsgMutex.RLock()
-	defer fake.processNormalMsgMutex.RUnlock()
-	return fake.processNormalMsgArgsForCall[i].env
+func (fake *ChannelSupport) ConfigureCalls(stub func(*common.Envelope, uint64) error) {
+	fake.configureMutex.Lock()
+	defer fake.configureMutex.Unlock()
+	fake.ConfigureStub = stub
 }
 
-func (fake *ChannelSupport) ProcessNormalMsgReturns(result1 uint64, result2 error) {
-	fake.ProcessNormalMsgStub = nil
-	fake.processNormalMsgReturns = struct {
-		result1 uint64
-		result2 error
-	}{result1, result2}
+func (fake *ChannelSupport) ConfigureArgsForCall(i int) (*common.Envelope, uint64) {
+	fake.configureMutex.RLock()
+	defer fake.configureMutex.RUnlock()
+	argsForCall := fake.configureArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *ChannelSupport) ProcessNormalMsgReturnsOnCall(i int, result1 uint64, result2 error) {
-	fake.ProcessNormalMsgStub = nil
-	if fake.processNormalMsgReturnsOnCall == nil {
-		fake.processNormalMsgReturnsOnCall = make(map[int]struct {
-			result1 uint64
-			result2 error
+func (fake *ChannelSupport) ConfigureReturns(result1 error) {
+	fake.configureMutex.Lock()
+	defer fake.configureMutex.Unlock()
+	fake.ConfigureStub = nil
+	fake.configureReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ChannelSupport) ConfigureReturnsOnCall(i int, result1 error) {
+	fake.configureMutex.Lock()
+	defer fake.configureMutex.Unlock()
+	fake.ConfigureStub = nil
+	if fake.configureReturnsOnCall == nil {
+		fake.configureReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.processNormalMsgReturnsOnCall[i] = struct {
-		result1 uint64
-		result2 error
-	}{result1, result2}
+	fake.configureReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *ChannelSupport) ProcessConfigUpdateMsg(env *cb.Envelope) (config *cb.Envelope, configSeq uint64, err error) {
-	fake.processConfigUpdateMsgMutex.Lock()
-	ret, specificReturn := fake.processConfigUpdateMsgReturnsOnCall[len(fake.processConfigUpdateMsgArgsForCall)]
-	fake.processConfigUpdateMsgArgsForCall = append(fake.processConfigUpdateMsgArgsForCall, struct {
-		env *cb.Envelope
-	}{env})
-	fake.recordInvocation("ProcessConfigUpdateMsg", []interface{}{env})
-	fake.processConfigUpdateMsgMutex.Unlock()
-	if fake.ProcessConfigUpdateMsgStub != nil {
-		return fake.ProcessConfigUpdateMsgStub(env)
+func (fake *ChannelSupport) Order(arg1 *common.Envelope, arg2 uint64) error {
+	fake.orderMutex.Lock()
+	ret, specificReturn := fake.orderReturnsOnCall[len(fake.orderArgsForCall)]
+	fake.orderArgsForCall = append(fake.orderArgsForCall, struct {
+		arg1 *common.Envelope
+		arg2 uint64
+	}{arg1, arg2})
+	fake.recordInvocation("Order", []interface{}{arg1, arg2})
+	fake.orderMutex.Unlock()
+	if fake.OrderStub != nil {
+		return fake.OrderStub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2, ret.result3
+		return ret.result1
 	}
-	return fake.processConfigUpdateMsgReturns.result1, fake.processConfigUpdateMsgReturns.result2, fake.processConfigUpdateMsgReturns.result3
+	fakeReturns := fake.orderReturns
+	return fakeReturns.result1
 }
 
-func (fake *ChannelSupport) ProcessConfigUpdateMsgCallCount() int {
-	fake.processConfigUpdateMsgMutex.RLock()
-	defer fake.processConfigUpdateMsgMutex.RUnlock()
-	return len(fake.processConfigUpdateMsgArgsForCall)
+func (fake *ChannelSupport) OrderCallCount() int {
+	fake.orderMutex.RLock()
+	defer fake.orderMutex.RUnlock()
+	return len(fake.orderArgsForCall)
 }
 
-func (fake *ChannelSupport) ProcessConfigUpdateMsgArgsForCall(i int) *cb.Envelope {
-	fake.processConfigUpdateMsgMutex.RLock()
-	defer fake.processConfigUpdateMsgMutex.RUnlock()
-	return fake.processConfigUpdateMsgArgsForCall[i].env
+func (fake *ChannelSupport) OrderCalls(stub func(*common.Envelope, uint64) error) {
+	fake.orderMutex.Lock()
+	defer fake.orderMutex.Unlock()
+	fake.OrderStub = stub
 }
 
-func (fake *ChannelSupport) ProcessConfigUpdateMsgReturns(result1 *cb.Envelope, result2 uint64, result3 error) {
-	fake.ProcessConfigUpdateMsgStub = nil
-	fake.processConfigUpdateMsgReturns = struct {
-		result1 *cb.Envelope
-		result2 uint64
-		result3 error
-	}{result1, result2, result3}
+func (fake *ChannelSupport) OrderArgsForCall(i int) (*common.Envelope, uint64) {
+	fake.orderMutex.RLock()
+	defer fake.orderMutex.RUnlock()
+	argsForCall := fake.orderArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *ChannelSupport) ProcessConfigUpdateMsgReturnsOnCall(i int, result1 *cb.Envelope, result2 uint64, result3 error) {
-	fake.ProcessConfigUpdateMsgStub = nil
-	if fake.processConfigUpdateMsgReturnsOnCall == nil {
-		fake.processConfigUpdateMsgReturnsOnCall = make(map[int]struct {
-			result1 *cb.Envelope
-			result2 uint64
-			result3 error
+func (fake *ChannelSupport) OrderReturns(result1 error) {
+	fake.orderMutex.Lock()
+	defer fake.orderMutex.Unlock()
+	fake.OrderStub = nil
+	fake.orderReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ChannelSupport) OrderReturnsOnCall(i int, result1 error) {
+	fake.orderMutex.Lock()
+	defer fake.orderMutex.Unlock()
+	fake.OrderStub = nil
+	if fake.orderReturnsOnCall == nil {
+		fake.orderReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.processConfigUpdateMsgReturnsOnCall[i] = struct {
-		result1 *cb.Envelope
-		result2 uint64
-		result3 error
-	}{result1, result2, result3}
+	fake.orderReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *ChannelSupport) ProcessConfigMsg(env *cb.Envelope) (*cb.Envelope, uint64, error) {
+func (fake *ChannelSupport) OrdererConfig() (channelconfig.Orderer, bool) {
+	fake.ordererConfigMutex.Lock()
+	ret, specificReturn := fake.ordererConfigReturnsOnCall[len(fake.ordererConfigArgsForCall)]
+	fake.ordererConfigArgsForCall = append(fake.ordererConfigArgsForCall, struct {
+	}{})
+	fake.recordInvocation("OrdererConfig", []interface{}{})
+	fake.ordererConfigMutex.Unlock()
+	if fake.OrdererConfigStub != nil {
+		return fake.OrdererConfigStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.ordererConfigReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChannelSupport) OrdererConfigCallCount() int {
+	fake.ordererConfigMutex.RLock()
+	defer fake.ordererConfigMutex.RUnlock()
+	return len(fake.ordererConfigArgsForCall)
+}
+
+func (fake *ChannelSupport) OrdererConfigCalls(stub func() (channelconfig.Orderer, bool)) {
+	fake.ordererConfigMutex.Lock()
+	defer fake.ordererConfigMutex.Unlock()
+	fake.OrdererConfigStub = stub
+}
+
+func (fake *ChannelSupport) OrdererConfigReturns(result1 channelconfig.Orderer, result2 bool) {
+	fake.ordererConfigMutex.Lock()
+	defer fake.ordererConfigMutex.Unlock()
+	fake.OrdererConfigStub = nil
+	fake.ordererConfigReturns = struct {
+		result1 channelconfig.Orderer
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *ChannelSupport) OrdererConfigReturnsOnCall(i int, result1 channelconfig.Orderer, result2 bool) {
+	fake.ordererConfigMutex.Lock()
+	defer fake.ordererConfigMutex.Unlock()
+	fake.OrdererConfigStub = nil
+	if fake.ordererConfigReturnsOnCall == nil {
+		fake.ordererConfigReturnsOnCall = make(map[int]struct {
+			result1 channelconfig.Orderer
+			result2 bool
+		})
+	}
+	fake.ordererConfigReturnsOnCall[i] = struct {
+		result1 channelconfig.Orderer
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *ChannelSupport) ProcessConfigMsg(arg1 *common.Envelope) (*common.Envelope, uint64, error) {
 	fake.processConfigMsgMutex.Lock()
 	ret, specificReturn := fake.processConfigMsgReturnsOnCall[len(fake.processConfigMsgArgsForCall)]
 	fake.processConfigMsgArgsForCall = append(fake.processConfigMsgArgsForCall, struct {
-		env *cb.Envelope
-	}{env})
-	fake.recordInvocation("ProcessConfigMsg", []interface{}{env})
+		arg1 *common.Envelope
+	}{arg1})
+	fake.recordInvocation("ProcessConfigMsg", []interface{}{arg1})
 	fake.processConfigMsgMutex.Unlock()
 	if fake.ProcessConfigMsgStub != nil {
-		return fake.ProcessConfigMsgStub(env)
+		return fake.ProcessConfigMsgStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
 	}
-	return fake.processConfigMsgReturns.result1, fake.processConfigMsgReturns.result2, fake.processConfigMsgReturns.result3
+	fakeReturns := fake.processConfigMsgReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
 }
 
 func (fake *ChannelSupport) ProcessConfigMsgCallCount() int {
@@ -277,139 +443,182 @@ func (fake *ChannelSupport) ProcessConfigMsgCallCount() int {
 	return len(fake.processConfigMsgArgsForCall)
 }
 
-func (fake *ChannelSupport) ProcessConfigMsgArgsForCall(i int) *cb.Envelope {
+func (fake *ChannelSupport) ProcessConfigMsgCalls(stub func(*common.Envelope) (*common.Envelope, uint64, error)) {
+	fake.processConfigMsgMutex.Lock()
+	defer fake.processConfigMsgMutex.Unlock()
+	fake.ProcessConfigMsgStub = stub
+}
+
+func (fake *ChannelSupport) ProcessConfigMsgArgsForCall(i int) *common.Envelope {
 	fake.processConfigMsgMutex.RLock()
 	defer fake.processConfigMsgMutex.RUnlock()
-	return fake.processConfigMsgArgsForCall[i].env
+	argsForCall := fake.processConfigMsgArgsForCall[i]
+	return argsForCall.arg1
 }
 
-func (fake *ChannelSupport) ProcessConfigMsgReturns(result1 *cb.Envelope, result2 uint64, result3 error) {
+func (fake *ChannelSupport) ProcessConfigMsgReturns(result1 *common.Envelope, result2 uint64, result3 error) {
+	fake.processConfigMsgMutex.Lock()
+	defer fake.processConfigMsgMutex.Unlock()
 	fake.ProcessConfigMsgStub = nil
 	fake.processConfigMsgReturns = struct {
-		result1 *cb.Envelope
+		result1 *common.Envelope
 		result2 uint64
 		result3 error
 	}{result1, result2, result3}
 }
 
-func (fake *ChannelSupport) ProcessConfigMsgReturnsOnCall(i int, result1 *cb.Envelope, result2 uint64, result3 error) {
+func (fake *ChannelSupport) ProcessConfigMsgReturnsOnCall(i int, result1 *common.Envelope, result2 uint64, result3 error) {
+	fake.processConfigMsgMutex.Lock()
+	defer fake.processConfigMsgMutex.Unlock()
 	fake.ProcessConfigMsgStub = nil
 	if fake.processConfigMsgReturnsOnCall == nil {
 		fake.processConfigMsgReturnsOnCall = make(map[int]struct {
-			result1 *cb.Envelope
+			result1 *common.Envelope
 			result2 uint64
 			result3 error
 		})
 	}
 	fake.processConfigMsgReturnsOnCall[i] = struct {
-		result1 *cb.Envelope
+		result1 *common.Envelope
 		result2 uint64
 		result3 error
 	}{result1, result2, result3}
 }
 
-func (fake *ChannelSupport) Order(env *cb.Envelope, configSeq uint64) error {
-	fake.orderMutex.Lock()
-	ret, specificReturn := fake.orderReturnsOnCall[len(fake.orderArgsForCall)]
-	fake.orderArgsForCall = append(fake.orderArgsForCall, struct {
-		env       *cb.Envelope
-		configSeq uint64
-	}{env, configSeq})
-	fake.recordInvocation("Order", []interface{}{env, configSeq})
-	fake.orderMutex.Unlock()
-	if fake.OrderStub != nil {
-		return fake.OrderStub(env, configSeq)
+func (fake *ChannelSupport) ProcessConfigUpdateMsg(arg1 *common.Envelope) (*common.Envelope, uint64, error) {
+	fake.processConfigUpdateMsgMutex.Lock()
+	ret, specificReturn := fake.processConfigUpdateMsgReturnsOnCall[len(fake.processConfigUpdateMsgArgsForCall)]
+	fake.processConfigUpdateMsgArgsForCall = append(fake.processConfigUpdateMsgArgsForCall, struct {
+		arg1 *common.Envelope
+	}{arg1})
+	fake.recordInvocation("ProcessConfigUpdateMsg", []interface{}{arg1})
+	fake.processConfigUpdateMsgMutex.Unlock()
+	if fake.ProcessConfigUpdateMsgStub != nil {
+		return fake.ProcessConfigUpdateMsgStub(arg1)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2, ret.result3
 	}
-	return fake.orderReturns.result1
+	fakeReturns := fake.processConfigUpdateMsgReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
 }
 
-func (fake *ChannelSupport) OrderCallCount() int {
-	fake.orderMutex.RLock()
-	defer fake.orderMutex.RUnlock()
-	return len(fake.orderArgsForCall)
+func (fake *ChannelSupport) ProcessConfigUpdateMsgCallCount() int {
+	fake.processConfigUpdateMsgMutex.RLock()
+	defer fake.processConfigUpdateMsgMutex.RUnlock()
+	return len(fake.processConfigUpdateMsgArgsForCall)
 }
 
-func (fake *ChannelSupport) OrderArgsForCall(i int) (*cb.Envelope, uint64) {
-	fake.orderMutex.RLock()
-	defer fake.orderMutex.RUnlock()
-	return fake.orderArgsForCall[i].env, fake.orderArgsForCall[i].configSeq
+func (fake *ChannelSupport) ProcessConfigUpdateMsgCalls(stub func(*common.Envelope) (*common.Envelope, uint64, error)) {
+	fake.processConfigUpdateMsgMutex.Lock()
+	defer fake.processConfigUpdateMsgMutex.Unlock()
+	fake.ProcessConfigUpdateMsgStub = stub
 }
 
-func (fake *ChannelSupport) OrderReturns(result1 error) {
-	fake.OrderStub = nil
-	fake.orderReturns = struct {
-		result1 error
-	}{result1}
+func (fake *ChannelSupport) ProcessConfigUpdateMsgArgsForCall(i int) *common.Envelope {
+	fake.processConfigUpdateMsgMutex.RLock()
+	defer fake.processConfigUpdateMsgMutex.RUnlock()
+	argsForCall := fake.processConfigUpdateMsgArgsForCall[i]
+	return argsForCall.arg1
 }
 
-func (fake *ChannelSupport) OrderReturnsOnCall(i int, result1 error) {
-	fake.OrderStub = nil
-	if fake.orderReturnsOnCall == nil {
-		fake.orderReturnsOnCall = make(map[int]struct {
-			result1 error
+func (fake *ChannelSupport) ProcessConfigUpdateMsgReturns(result1 *common.Envelope, result2 uint64, result3 error) {
+	fake.processConfigUpdateMsgMutex.Lock()
+	defer fake.processConfigUpdateMsgMutex.Unlock()
+	fake.ProcessConfigUpdateMsgStub = nil
+	fake.processConfigUpdateMsgReturns = struct {
+		result1 *common.Envelope
+		result2 uint64
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *ChannelSupport) ProcessConfigUpdateMsgReturnsOnCall(i int, result1 *common.Envelope, result2 uint64, result3 error) {
+	fake.processConfigUpdateMsgMutex.Lock()
+	defer fake.processConfigUpdateMsgMutex.Unlock()
+	fake.ProcessConfigUpdateMsgStub = nil
+	if fake.processConfigUpdateMsgReturnsOnCall == nil {
+		fake.processConfigUpdateMsgReturnsOnCall = make(map[int]struct {
+			result1 *common.Envelope
+			result2 uint64
+			result3 error
 		})
 	}
-	fake.orderReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+	fake.processConfigUpdateMsgReturnsOnCall[i] = struct {
+		result1 *common.Envelope
+		result2 uint64
+		result3 error
+	}{result1, result2, result3}
 }
 
-func (fake *ChannelSupport) Configure(config *cb.Envelope, configSeq uint64) error {
-	fake.configureMutex.Lock()
-	ret, specificReturn := fake.configureReturnsOnCall[len(fake.configureArgsForCall)]
-	fake.configureArgsForCall = append(fake.configureArgsForCall, struct {
-		config    *cb.Envelope
-		configSeq uint64
-	}{config, configSeq})
-	fake.recordInvocation("Configure", []interface{}{config, configSeq})
-	fake.configureMutex.Unlock()
-	if fake.ConfigureStub != nil {
-		return fake.ConfigureStub(config, configSeq)
+func (fake *ChannelSupport) ProcessNormalMsg(arg1 *common.Envelope) (uint64, error) {
+	fake.processNormalMsgMutex.Lock()
+	ret, specificReturn := fake.processNormalMsgReturnsOnCall[len(fake.processNormalMsgArgsForCall)]
+	fake.processNormalMsgArgsForCall = append(fake.processNormalMsgArgsForCall, struct {
+		arg1 *common.Envelope
+	}{arg1})
+	fake.recordInvocation("ProcessNormalMsg", []interface{}{arg1})
+	fake.processNormalMsgMutex.Unlock()
+	if fake.ProcessNormalMsgStub != nil {
+		return fake.ProcessNormalMsgStub(arg1)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fake.configureReturns.result1
+	fakeReturns := fake.processNormalMsgReturns
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *ChannelSupport) ConfigureCallCount() int {
-	fake.configureMutex.RLock()
-	defer fake.configureMutex.RUnlock()
-	return len(fake.configureArgsForCall)
+func (fake *ChannelSupport) ProcessNormalMsgCallCount() int {
+	fake.processNormalMsgMutex.RLock()
+	defer fake.processNormalMsgMutex.RUnlock()
+	return len(fake.processNormalMsgArgsForCall)
 }
 
-func (fake *ChannelSupport) ConfigureArgsForCall(i int) (*cb.Envelope, uint64) {
-	fake.configureMutex.RLock()
-	defer fake.configureMutex.RUnlock()
-	return fake.configureArgsForCall[i].config, fake.configureArgsForCall[i].configSeq
+func (fake *ChannelSupport) ProcessNormalMsgCalls(stub func(*common.Envelope) (uint64, error)) {
+	fake.processNormalMsgMutex.Lock()
+	defer fake.processNormalMsgMutex.Unlock()
+	fake.ProcessNormalMsgStub = stub
 }
 
-func (fake *ChannelSupport) ConfigureReturns(result1 error) {
-	fake.ConfigureStub = nil
-	fake.configureReturns = struct {
-		result1 error
-	}{result1}
+func (fake *ChannelSupport) ProcessNormalMsgArgsForCall(i int) *common.Envelope {
+	fake.processNormalMsgMutex.RLock()
+	defer fake.processNormalMsgMutex.RUnlock()
+	argsForCall := fake.processNormalMsgArgsForCall[i]
+	return argsForCall.arg1
 }
 
-func (fake *ChannelSupport) ConfigureReturnsOnCall(i int, result1 error) {
-	fake.ConfigureStub = nil
-	if fake.configureReturnsOnCall == nil {
-		fake.configureReturnsOnCall = make(map[int]struct {
-			result1 error
+func (fake *ChannelSupport) ProcessNormalMsgReturns(result1 uint64, result2 error) {
+	fake.processNormalMsgMutex.Lock()
+	defer fake.processNormalMsgMutex.Unlock()
+	fake.ProcessNormalMsgStub = nil
+	fake.processNormalMsgReturns = struct {
+		result1 uint64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChannelSupport) ProcessNormalMsgReturnsOnCall(i int, result1 uint64, result2 error) {
+	fake.processNormalMsgMutex.Lock()
+	defer fake.processNormalMsgMutex.Unlock()
+	fake.ProcessNormalMsgStub = nil
+	if fake.processNormalMsgReturnsOnCall == nil {
+		fake.processNormalMsgReturnsOnCall = make(map[int]struct {
+			result1 uint64
+			result2 error
 		})
 	}
-	fake.configureReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+	fake.processNormalMsgReturnsOnCall[i] = struct {
+		result1 uint64
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *ChannelSupport) WaitReady() error {
 	fake.waitReadyMutex.Lock()
 	ret, specificReturn := fake.waitReadyReturnsOnCall[len(fake.waitReadyArgsForCall)]
-	fake.waitReadyArgsForCall = append(fake.waitReadyArgsForCall, struct{}{})
+	fake.waitReadyArgsForCall = append(fake.waitReadyArgsForCall, struct {
+	}{})
 	fake.recordInvocation("WaitReady", []interface{}{})
 	fake.waitReadyMutex.Unlock()
 	if fake.WaitReadyStub != nil {
@@ -418,7 +627,8 @@ func (fake *ChannelSupport) WaitReady() error {
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.waitReadyReturns.result1
+	fakeReturns := fake.waitReadyReturns
+	return fakeReturns.result1
 }
 
 func (fake *ChannelSupport) WaitReadyCallCount() int {
@@ -427,7 +637,15 @@ func (fake *ChannelSupport) WaitReadyCallCount() int {
 	return len(fake.waitReadyArgsForCall)
 }
 
+func (fake *ChannelSupport) WaitReadyCalls(stub func() error) {
+	fake.waitReadyMutex.Lock()
+	defer fake.waitReadyMutex.Unlock()
+	fake.WaitReadyStub = stub
+}
+
 func (fake *ChannelSupport) WaitReadyReturns(result1 error) {
+	fake.waitReadyMutex.Lock()
+	defer fake.waitReadyMutex.Unlock()
 	fake.WaitReadyStub = nil
 	fake.waitReadyReturns = struct {
 		result1 error
@@ -435,6 +653,8 @@ func (fake *ChannelSupport) WaitReadyReturns(result1 error) {
 }
 
 func (fake *ChannelSupport) WaitReadyReturnsOnCall(i int, result1 error) {
+	fake.waitReadyMutex.Lock()
+	defer fake.waitReadyMutex.Unlock()
 	fake.WaitReadyStub = nil
 	if fake.waitReadyReturnsOnCall == nil {
 		fake.waitReadyReturnsOnCall = make(map[int]struct {
@@ -449,18 +669,22 @@ func (fake *ChannelSupport) WaitReadyReturnsOnCall(i int, result1 error) {
 func (fake *ChannelSupport) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.applicationConfigMutex.RLock()
+	defer fake.applicationConfigMutex.RUnlock()
 	fake.classifyMsgMutex.RLock()
 	defer fake.classifyMsgMutex.RUnlock()
-	fake.processNormalMsgMutex.RLock()
-	defer fake.processNormalMsgMutex.RUnlock()
-	fake.processConfigUpdateMsgMutex.RLock()
-	defer fake.processConfigUpdateMsgMutex.RUnlock()
-	fake.processConfigMsgMutex.RLock()
-	defer fake.processConfigMsgMutex.RUnlock()
-	fake.orderMutex.RLock()
-	defer fake.orderMutex.RUnlock()
 	fake.configureMutex.RLock()
 	defer fake.configureMutex.RUnlock()
+	fake.orderMutex.RLock()
+	defer fake.orderMutex.RUnlock()
+	fake.ordererConfigMutex.RLock()
+	defer fake.ordererConfigMutex.RUnlock()
+	fake.processConfigMsgMutex.RLock()
+	defer fake.processConfigMsgMutex.RUnlock()
+	fake.processConfigUpdateMsgMutex.RLock()
+	defer fake.processConfigUpdateMsgMutex.RUnlock()
+	fake.processNormalMsgMutex.RLock()
+	defer fake.processNormalMsgMutex.RUnlock()
 	fake.waitReadyMutex.RLock()
 	defer fake.waitReadyMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -0,0 +1,165 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// ErrQuotaExceeded is returned for messages from an organization which has
+// exhausted its ingress quota on the channel.
+var ErrQuotaExceeded = errors.New("ingress quota exceeded")
+
+type quotaKey struct {
+	channelID string
+	mspID     string
+}
+
+// IngressLimiter enforces the per-organization ingress quotas defined in the
+// orderer config of each channel, so that a single organization cannot starve
+// a channel. Each organization may burst up to one second worth of its quota.
+type IngressLimiter struct {
+	now func() time.Time
+
+	mutex   sync.Mutex
+	buckets map[quotaKey]*quotaBucket
+}
+
+// NewIngressLimiter creates an IngressLimiter with no usage recorded.
+func NewIngressLimiter() *IngressLimiter {
+	return &IngressLimiter{
+		now:     time.Now,
+		buckets: map[quotaKey]*quotaBucket{},
+	}
+}
+
+// Admit records the message against the quota of its creator's organization
+// and returns ErrQuotaExceeded if the quota has been used up. The quotas are
+// only enforced on the channels with the IngressQuotas orderer capability.
+func (il *IngressLimiter) Admit(channelID string, ordererConfig channelconfig.Orderer, message *cb.Envelope) error {
+	if !ordererConfig.Capabilities().IngressQuotas() {
+		return nil
+	}
+	quotas := ordererConfig.IngressQuotas()
+	if len(quotas) == 0 {
+		return nil
+	}
+
+	mspID, err := creatorMSPID(message)
+	if err != nil {
+		return err
+	}
+	quota, ok := quotas[mspID]
+	if !ok {
+		return nil
+	}
+
+	il.mutex.Lock()
+	defer il.mutex.Unlock()
+
+	now := il.now()
+	key := quotaKey{channelID: channelID, mspID: mspID}
+	bucket, ok := il.buckets[key]
+	if !ok || !proto.Equal(bucket.quota, quota) {
+		bucket = newQuotaBucket(quota, now)
+		il.buckets[key] = bucket
+	}
+	if !bucket.take(now, float64(len(message.Payload)+len(message.Signature))) {
+		return errors.WithMessage(ErrQuotaExceeded, mspID)
+	}
+	return nil
+}
+
+func creatorMSPID(message *cb.Envelope) (string, error) {
+	payload, err := utils.UnmarshalPayload(message.Payload)
+	if err != nil {
+		return "", err
+	}
+	if payload.Header == nil {
+		return "", errors.New("missing header")
+	}
+	shdr, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+	if err != nil {
+		return "", err
+	}
+	sID := &msp.SerializedIdentity{}
+	err = proto.Unmarshal(shdr.Creator, sID)
+	if err != nil {
+		return "", errors.Wrap(err, "could not unmarshal creator")
+	}
+	return sID.Mspid, nil
+}
+
+// quotaBucket is a token bucket for the messages and bytes of one organization.
+type quotaBucket struct {
+	quota    *ab.IngressQuota
+	messages float64
+	bytes    float64
+	last     time.Time
+}
+
+func newQuotaBucket(quota *ab.IngressQuota, now time.Time) *quotaBucket {
+	return &quotaBucket{
+		quota:    quota,
+		messages: float64(quota.MessagesPerSecond),
+		bytes:    float64(quota.BytesPerSecond),
+		last:     now,
+	}
+}
+
+// take refills the bucket for the time elapsed since the last message and
+// consumes one message of the given size. A full bucket always admits a
+// message, even one larger than the byte quota.
+func (b *quotaBucket) take(now time.Time, size float64) bool {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed > 0 {
+		b.last = now
+	}
+
+	maxMessages := float64(b.quota.MessagesPerSecond)
+	maxBytes := float64(b.quota.BytesPerSecond)
+	if maxMessages > 0 {
+		b.messages = refill(b.messages, maxMessages, elapsed)
+		if b.messages < 1 {
+			return false
+		}
+	}
+	if maxBytes > 0 {
+		b.bytes = refill(b.bytes, maxBytes, elapsed)
+		if b.bytes < size && b.bytes < maxBytes {
+			return false
+		}
+	}
+
+	if maxMessages > 0 {
+		b.messages--
+	}
+	if maxBytes > 0 {
+		b.bytes -= size
+	}
+	return true
+}
+
+func refill(current, rate, elapsed float64) float64 {
+	if elapsed <= 0 {
+		return current
+	}
+	current += rate * elapsed
+	if current > rate {
+		return rate
+	}
+	return current
+}
@@ -0,0 +1,134 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast_test
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/orderer/common/broadcast"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+func envelopeFrom(mspID string, data []byte) *cb.Envelope {
+	creator, err := proto.Marshal(&msp.SerializedIdentity{Mspid: mspID, IdBytes: []byte("cert")})
+	Expect(err).NotTo(HaveOccurred())
+	shdr, err := proto.Marshal(&cb.SignatureHeader{Creator: creator})
+	Expect(err).NotTo(HaveOccurred())
+	payload, err := proto.Marshal(&cb.Payload{
+		Header: &cb.Header{SignatureHeader: shdr},
+		Data:   data,
+	})
+	Expect(err).NotTo(HaveOccurred())
+	return &cb.Envelope{Payload: payload}
+}
+
+var _ = Describe("IngressLimiter", func() {
+	var (
+		limiter       *broadcast.IngressLimiter
+		ordererConfig *mockconfig.Orderer
+	)
+
+	BeforeEach(func() {
+		limiter = broadcast.NewIngressLimiter()
+		ordererConfig = &mockconfig.Orderer{
+			IngressQuotasVal: map[string]*ab.IngressQuota{
+				"Org1MSP": {MessagesPerSecond: 2},
+			},
+			CapabilitiesVal: &mockconfig.OrdererCapabilities{IngressQuotasVal: true},
+		}
+	})
+
+	It("rejects messages beyond the message quota of the organization", func() {
+		msg := envelopeFrom("Org1MSP", nil)
+		Expect(limiter.Admit("fake-channel", ordererConfig, msg)).To(Succeed())
+		Expect(limiter.Admit("fake-channel", ordererConfig, msg)).To(Succeed())
+
+		err := limiter.Admit("fake-channel", ordererConfig, msg)
+		Expect(errors.Cause(err)).To(Equal(broadcast.ErrQuotaExceeded))
+		Expect(err).To(MatchError("Org1MSP: ingress quota exceeded"))
+	})
+
+	It("does not limit other organizations or channels", func() {
+		msg := envelopeFrom("Org1MSP", nil)
+		Expect(limiter.Admit("fake-channel", ordererConfig, msg)).To(Succeed())
+		Expect(limiter.Admit("fake-channel", ordererConfig, msg)).To(Succeed())
+
+		Expect(limiter.Admit("other-channel", ordererConfig, msg)).To(Succeed())
+		for i := 0; i < 10; i++ {
+			Expect(limiter.Admit("fake-channel", ordererConfig, envelopeFrom("Org2MSP", nil))).To(Succeed())
+		}
+	})
+
+	It("refills the quota over time", func() {
+		ordererConfig.IngressQuotasVal["Org1MSP"].MessagesPerSecond = 100
+		msg := envelopeFrom("Org1MSP", nil)
+		for i := 0; i < 100; i++ {
+			Expect(limiter.Admit("fake-channel", ordererConfig, msg)).To(Succeed())
+		}
+		Expect(limiter.Admit("fake-channel", ordererConfig, msg)).NotTo(Succeed())
+
+		time.Sleep(20 * time.Millisecond)
+		Expect(limiter.Admit("fake-channel", ordererConfig, msg)).To(Succeed())
+	})
+
+	It("resets the usage when the quota is reconfigured", func() {
+		msg := envelopeFrom("Org1MSP", nil)
+		Expect(limiter.Admit("fake-channel", ordererConfig, msg)).To(Succeed())
+		Expect(limiter.Admit("fake-channel", ordererConfig, msg)).To(Succeed())
+
+		ordererConfig.IngressQuotasVal = map[string]*ab.IngressQuota{
+			"Org1MSP": {MessagesPerSecond: 3},
+		}
+		Expect(limiter.Admit("fake-channel", ordererConfig, msg)).To(Succeed())
+	})
+
+	Context("when the organization has a byte quota", func() {
+		BeforeEach(func() {
+			ordererConfig.IngressQuotasVal["Org1MSP"] = &ab.IngressQuota{BytesPerSecond: 1000}
+		})
+
+		It("rejects messages once the bytes are used up", func() {
+			msg := envelopeFrom("Org1MSP", make([]byte, 400))
+			Expect(limiter.Admit("fake-channel", ordererConfig, msg)).To(Succeed())
+			Expect(limiter.Admit("fake-channel", ordererConfig, msg)).To(Succeed())
+			Expect(limiter.Admit("fake-channel", ordererConfig, msg)).NotTo(Succeed())
+		})
+
+		It("admits a single message larger than the quota when none has been used", func() {
+			msg := envelopeFrom("Org1MSP", make([]byte, 2000))
+			Expect(limiter.Admit("fake-channel", ordererConfig, msg)).To(Succeed())
+			Expect(limiter.Admit("fake-channel", ordererConfig, msg)).NotTo(Succeed())
+		})
+	})
+
+	Context("when the channel does not have the capability", func() {
+		BeforeEach(func() {
+			ordererConfig.CapabilitiesVal = &mockconfig.OrdererCapabilities{}
+		})
+
+		It("does not enforce the quotas", func() {
+			msg := envelopeFrom("Org1MSP", nil)
+			for i := 0; i < 10; i++ {
+				Expect(limiter.Admit("fake-channel", ordererConfig, msg)).To(Succeed())
+			}
+		})
+	})
+
+	Context("when the creator cannot be determined", func() {
+		It("returns an error", func() {
+			err := limiter.Admit("fake-channel", ordererConfig, &cb.Envelope{Payload: []byte("garbage")})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
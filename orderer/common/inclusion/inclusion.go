@@ -0,0 +1,165 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inclusion
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/deliver"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("orderer.common.inclusion")
+
+//go:generate counterfeiter -o mock/chain_manager.go --fake-name ChainManager . ChainManager
+
+// ChainManager provides a way for the Handler to look up the Chain of a channel
+type ChainManager interface {
+	// GetChain returns the chain of the channel, or nil if it does not exist
+	GetChain(channelID string) Chain
+}
+
+//go:generate counterfeiter -o mock/chain.go --fake-name Chain . Chain
+
+// Chain provides the ledger and the policies of a channel
+type Chain interface {
+	deliver.ConfigSequencer
+	msgprocessor.SigFilterSupport
+
+	// Reader returns the ledger of the channel
+	Reader() blockledger.Reader
+}
+
+// Handler serves the inclusion requests of the clients, which locate the
+// block including a transaction without having to deliver the blocks.
+type Handler struct {
+	ChainManager     ChainManager
+	TimeWindow       time.Duration
+	BindingInspector deliver.Inspector
+}
+
+// NewHandler creates a Handler for the channels of the chain manager.
+func NewHandler(cm ChainManager, timeWindow time.Duration, mutualTLS bool) *Handler {
+	return &Handler{
+		ChainManager:     cm,
+		TimeWindow:       timeWindow,
+		BindingInspector: deliver.InspectorFunc(comm.NewBindingInspector(mutualTLS, deliver.ExtractChannelHeaderCertHash)),
+	}
+}
+
+// Inclusion returns the position of the transaction of the query in the
+// ledger of the channel, if the requester is a reader of the channel.
+func (h *Handler) Inclusion(ctx context.Context, envelope *cb.Envelope) (*ab.InclusionResponse, error) {
+	addr := util.ExtractRemoteAddress(ctx)
+	payload, err := utils.UnmarshalPayload(envelope.Payload)
+	if err != nil {
+		logger.Warningf("Received an envelope from %s with no payload: %s", addr, err)
+		return statusResponse(cb.Status_BAD_REQUEST, err), nil
+	}
+	if payload.Header == nil {
+		logger.Warningf("Malformed envelope received from %s with bad header", addr)
+		return statusResponse(cb.Status_BAD_REQUEST, errors.New("missing header")), nil
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		logger.Warningf("Failed to unmarshal channel header from %s: %s", addr, err)
+		return statusResponse(cb.Status_BAD_REQUEST, err), nil
+	}
+	if err := h.validateChannelHeader(ctx, chdr); err != nil {
+		logger.Warningf("Rejecting inclusion request from %s due to envelope validation error: %s", addr, err)
+		return statusResponse(cb.Status_BAD_REQUEST, err), nil
+	}
+
+	chain := h.ChainManager.GetChain(chdr.ChannelId)
+	if chain == nil {
+		logger.Debugf("Rejecting inclusion request from %s because channel %s not found", addr, chdr.ChannelId)
+		return statusResponse(cb.Status_NOT_FOUND, errors.Errorf("channel %s not found", chdr.ChannelId)), nil
+	}
+
+	policyChecker := deliver.PolicyCheckerFunc(func(env *cb.Envelope, channelID string) error {
+		return msgprocessor.NewSigFilter(policies.ChannelReaders, chain).Apply(env)
+	})
+	accessControl, err := deliver.NewSessionAC(chain, envelope, policyChecker, chdr.ChannelId, crypto.ExpiresAt)
+	if err != nil {
+		logger.Warningf("[channel: %s] failed to create access control object due to %s", chdr.ChannelId, err)
+		return statusResponse(cb.Status_BAD_REQUEST, err), nil
+	}
+	if err := accessControl.Evaluate(); err != nil {
+		logger.Warningf("[channel: %s] Client authorization revoked for inclusion request from %s: %s", chdr.ChannelId, addr, err)
+		return statusResponse(cb.Status_FORBIDDEN, err), nil
+	}
+
+	query := &ab.InclusionQuery{}
+	if err := proto.Unmarshal(payload.Data, query); err != nil {
+		logger.Warningf("[channel: %s] Received a bad inclusion query from %s: %s", chdr.ChannelId, addr, err)
+		return statusResponse(cb.Status_BAD_REQUEST, errors.Wrap(err, "malformed inclusion query")), nil
+	}
+	if query.TxId == "" {
+		return statusResponse(cb.Status_BAD_REQUEST, errors.New("missing transaction ID")), nil
+	}
+
+	reader := chain.Reader()
+	locator, ok := reader.(blockledger.TxLocator)
+	if !ok {
+		return statusResponse(cb.Status_NOT_IMPLEMENTED, errors.New("ledger does not locate transactions")), nil
+	}
+	// The height is read first, so that it never precedes the block reported
+	height := reader.Height()
+	blockNumber, txIndex, err := locator.TxPosition(query.TxId)
+	switch err {
+	case nil:
+	case blockledger.ErrTxNotFound:
+		return &ab.InclusionResponse{Status: cb.Status_NOT_FOUND, Info: err.Error(), Height: height}, nil
+	case blockledger.ErrTxIndexDisabled:
+		return statusResponse(cb.Status_NOT_IMPLEMENTED, err), nil
+	default:
+		logger.Errorf("[channel: %s] Failed to locate transaction %s: %s", chdr.ChannelId, query.TxId, err)
+		return statusResponse(cb.Status_INTERNAL_SERVER_ERROR, err), nil
+	}
+	if blockNumber >= height {
+		height = blockNumber + 1
+	}
+
+	logger.Debugf("[channel: %s] Transaction %s requested by %s is included in block %d", chdr.ChannelId, query.TxId, addr, blockNumber)
+	return &ab.InclusionResponse{
+		Status:      cb.Status_SUCCESS,
+		BlockNumber: blockNumber,
+		TxIndex:     txIndex,
+		Height:      height,
+	}, nil
+}
+
+func (h *Handler) validateChannelHeader(ctx context.Context, chdr *cb.ChannelHeader) error {
+	if chdr.GetTimestamp() == nil {
+		return errors.New("channel header in envelope must contain timestamp")
+	}
+
+	envTime := time.Unix(chdr.GetTimestamp().Seconds, int64(chdr.GetTimestamp().Nanos)).UTC()
+	serverTime := time.Now()
+	if math.Abs(float64(serverTime.UnixNano()-envTime.UnixNano())) > float64(h.TimeWindow.Nanoseconds()) {
+		return errors.Errorf("envelope timestamp %s is more than %s apart from current server time %s", envTime, h.TimeWindow, serverTime)
+	}
+
+	return h.BindingInspector.Inspect(ctx, chdr)
+}
+
+func statusResponse(status cb.Status, err error) *ab.InclusionResponse {
+	return &ab.InclusionResponse{Status: status, Info: err.Error()}
+}
@@ -0,0 +1,27 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inclusion_test
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+//go:generate counterfeiter -o mock/tx_locating_reader.go --fake-name TxLocatingReader . txLocatingReader
+type txLocatingReader interface {
+	blockledger.Reader
+	blockledger.TxLocator
+}
+
+func TestInclusion(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Inclusion Suite")
+}
@@ -0,0 +1,237 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inclusion_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric/common/deliver"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	ramledger "github.com/hyperledger/fabric/common/ledger/blockledger/ram"
+	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
+	"github.com/hyperledger/fabric/orderer/common/inclusion"
+	"github.com/hyperledger/fabric/orderer/common/inclusion/mock"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Inclusion", func() {
+	var (
+		fakeChainManager *mock.ChainManager
+		fakeChain        *mock.Chain
+		fakeReader       *mock.TxLocatingReader
+		fakePolicy       *mockpolicies.Policy
+		handler          *inclusion.Handler
+		chdr             *cb.ChannelHeader
+		queryData        []byte
+	)
+
+	envelope := func() *cb.Envelope {
+		return &cb.Envelope{
+			Payload: utils.MarshalOrPanic(&cb.Payload{
+				Header: &cb.Header{
+					ChannelHeader:   utils.MarshalOrPanic(chdr),
+					SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{Creator: []byte("creator")}),
+				},
+				Data: queryData,
+			}),
+		}
+	}
+
+	BeforeEach(func() {
+		fakePolicy = &mockpolicies.Policy{}
+		fakeReader = &mock.TxLocatingReader{}
+		fakeReader.HeightReturns(10)
+		fakeReader.TxPositionReturns(7, 2, nil)
+
+		fakeChain = &mock.Chain{}
+		fakeChain.PolicyManagerReturns(&mockpolicies.Manager{Policy: fakePolicy})
+		fakeChain.ReaderReturns(fakeReader)
+
+		fakeChainManager = &mock.ChainManager{}
+		fakeChainManager.GetChainReturns(fakeChain)
+
+		handler = &inclusion.Handler{
+			ChainManager: fakeChainManager,
+			TimeWindow:   time.Minute,
+			BindingInspector: deliver.InspectorFunc(func(context.Context, proto.Message) error {
+				return nil
+			}),
+		}
+
+		chdr = &cb.ChannelHeader{
+			ChannelId: "mychannel",
+			Timestamp: &timestamp.Timestamp{Seconds: time.Now().Unix()},
+		}
+		queryData = utils.MarshalOrPanic(&ab.InclusionQuery{TxId: "tx1"})
+	})
+
+	It("returns the position of the transaction", func() {
+		resp, err := handler.Inclusion(context.Background(), envelope())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(proto.Equal(resp, &ab.InclusionResponse{
+			Status:      cb.Status_SUCCESS,
+			BlockNumber: 7,
+			TxIndex:     2,
+			Height:      10,
+		})).To(BeTrue())
+
+		Expect(fakeChainManager.GetChainArgsForCall(0)).To(Equal("mychannel"))
+		Expect(fakeReader.TxPositionArgsForCall(0)).To(Equal("tx1"))
+	})
+
+	Context("when the transaction was included after the height was read", func() {
+		BeforeEach(func() {
+			fakeReader.TxPositionReturns(10, 0, nil)
+		})
+
+		It("reports a height including the block", func() {
+			resp, err := handler.Inclusion(context.Background(), envelope())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Status).To(Equal(cb.Status_SUCCESS))
+			Expect(resp.Height).To(Equal(uint64(11)))
+		})
+	})
+
+	Context("when the transaction is not included yet", func() {
+		BeforeEach(func() {
+			fakeReader.TxPositionReturns(0, 0, blockledger.ErrTxNotFound)
+		})
+
+		It("returns a not found status with the height", func() {
+			resp, err := handler.Inclusion(context.Background(), envelope())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(resp, &ab.InclusionResponse{
+				Status: cb.Status_NOT_FOUND,
+				Info:   "transaction not found",
+				Height: 10,
+			})).To(BeTrue())
+		})
+	})
+
+	Context("when the ledger does not index the transactions", func() {
+		BeforeEach(func() {
+			fakeReader.TxPositionReturns(0, 0, blockledger.ErrTxIndexDisabled)
+		})
+
+		It("returns a not implemented status", func() {
+			resp, err := handler.Inclusion(context.Background(), envelope())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Status).To(Equal(cb.Status_NOT_IMPLEMENTED))
+			Expect(resp.Info).To(Equal("transaction index disabled"))
+		})
+	})
+
+	Context("when the ledger cannot locate transactions", func() {
+		BeforeEach(func() {
+			rl, err := ramledger.New(10).GetOrCreate("mychannel")
+			Expect(err).NotTo(HaveOccurred())
+			fakeChain.ReaderReturns(rl)
+		})
+
+		It("returns a not implemented status", func() {
+			resp, err := handler.Inclusion(context.Background(), envelope())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Status).To(Equal(cb.Status_NOT_IMPLEMENTED))
+			Expect(resp.Info).To(Equal("ledger does not locate transactions"))
+		})
+	})
+
+	Context("when the transaction cannot be located", func() {
+		BeforeEach(func() {
+			fakeReader.TxPositionReturns(0, 0, errors.New("disk failure"))
+		})
+
+		It("returns an internal server error status", func() {
+			resp, err := handler.Inclusion(context.Background(), envelope())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Status).To(Equal(cb.Status_INTERNAL_SERVER_ERROR))
+			Expect(resp.Info).To(Equal("disk failure"))
+		})
+	})
+
+	Context("when the requester is not a reader of the channel", func() {
+		BeforeEach(func() {
+			fakePolicy.Err = errors.New("not a reader")
+		})
+
+		It("returns a forbidden status", func() {
+			resp, err := handler.Inclusion(context.Background(), envelope())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Status).To(Equal(cb.Status_FORBIDDEN))
+			Expect(fakeReader.TxPositionCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the channel does not exist", func() {
+		BeforeEach(func() {
+			fakeChainManager.GetChainReturns(nil)
+		})
+
+		It("returns a not found status", func() {
+			resp, err := handler.Inclusion(context.Background(), envelope())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Status).To(Equal(cb.Status_NOT_FOUND))
+			Expect(resp.Info).To(Equal("channel mychannel not found"))
+		})
+	})
+
+	Context("when the envelope timestamp is outside the time window", func() {
+		BeforeEach(func() {
+			chdr.Timestamp = &timestamp.Timestamp{Seconds: time.Now().Add(-time.Hour).Unix()}
+		})
+
+		It("returns a bad request status", func() {
+			resp, err := handler.Inclusion(context.Background(), envelope())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Status).To(Equal(cb.Status_BAD_REQUEST))
+			Expect(resp.Info).To(ContainSubstring("more than 1m0s apart from current server time"))
+			Expect(fakeChainManager.GetChainCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the envelope has no payload", func() {
+		It("returns a bad request status", func() {
+			resp, err := handler.Inclusion(context.Background(), &cb.Envelope{Payload: []byte("garbage")})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Status).To(Equal(cb.Status_BAD_REQUEST))
+		})
+	})
+
+	Context("when the query is malformed", func() {
+		BeforeEach(func() {
+			queryData = []byte("garbage")
+		})
+
+		It("returns a bad request status", func() {
+			resp, err := handler.Inclusion(context.Background(), envelope())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Status).To(Equal(cb.Status_BAD_REQUEST))
+			Expect(resp.Info).To(ContainSubstring("malformed inclusion query"))
+		})
+	})
+
+	Context("when the query has no transaction ID", func() {
+		BeforeEach(func() {
+			queryData = nil
+		})
+
+		It("returns a bad request status", func() {
+			resp, err := handler.Inclusion(context.Background(), envelope())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Status).To(Equal(cb.Status_BAD_REQUEST))
+			Expect(resp.Info).To(Equal("missing transaction ID"))
+		})
+	})
+})
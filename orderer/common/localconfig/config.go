@@ -40,21 +40,27 @@ type TopLevel struct {
 
 // General contains config which should be common among all orderer types.
 type General struct {
-	LedgerType     string
-	ListenAddress  string
-	ListenPort     uint16
-	TLS            TLS
-	Cluster        Cluster
-	Keepalive      Keepalive
-	GenesisMethod  string
-	GenesisProfile string
-	SystemChannel  string
-	GenesisFile    string
-	Profile        Profile
-	LocalMSPDir    string
-	LocalMSPID     string
-	BCCSP          *bccsp.FactoryOpts
-	Authentication Authentication
+	LedgerType       string
+	ListenAddress    string
+	ListenPort       uint16
+	TLS              TLS
+	Cluster          Cluster
+	Keepalive        Keepalive
+	GenesisMethod    string
+	GenesisProfile   string
+	SystemChannel    string
+	GenesisFile      string
+	Profile          Profile
+	LocalMSPDir      string
+	LocalMSPID       string
+	MSPCacheSize     int
+	BCCSP            *bccsp.FactoryOpts
+	Authentication   Authentication
+	LogFormat        string
+	BatchCut         BatchCut
+	PriorityLanes    PriorityLanes
+	ChannelRegistry  ChannelRegistry
+	ThresholdSigning ThresholdSigning
 }
 
 type Cluster struct {
@@ -104,6 +110,53 @@ type Authentication struct {
 	TimeWindow time.Duration
 }
 
+// BatchCut contains configuration for the policies cutting batches before
+// they reach the batch size of the channel configuration.
+type BatchCut struct {
+	// Default is the policy of the channels not listed in Channels.
+	Default  BatchCutPolicy
+	Channels map[string]BatchCutPolicy
+}
+
+// ChannelRegistry contains configuration for the registry of the channel IDs
+// shared by the ordering services of a deployment.
+type ChannelRegistry struct {
+	// Directory is the shared directory of the registry, empty to disable it.
+	Directory string
+	// OrderingServiceID identifies the ordering service in the registry, the
+	// system channel ID if empty.
+	OrderingServiceID string
+}
+
+// ThresholdSigning contains configuration for the signature shares of the
+// blocks by the key share of the orderer in the consenter set.
+type ThresholdSigning struct {
+	// KeyShareFile is the file of the key share of the orderer, as dealt by
+	// tblsgen, empty or missing to disable the signature shares.
+	KeyShareFile string
+}
+
+// PriorityLanes contains configuration for the scheduling of the broadcast
+// messages by the priority of their channel header.
+type PriorityLanes struct {
+	Enabled bool
+	// MaxInFlight is the number of messages of a channel enqueued at a time.
+	MaxInFlight int
+	// MaxSkips is the number of messages of a higher priority which may be
+	// enqueued ahead of a waiting message, zero for no limit.
+	MaxSkips int
+}
+
+// BatchCutPolicy contains the batch cut policy of a channel.
+type BatchCutPolicy struct {
+	// PriorityHeaderTypes are the header types of the messages the pending
+	// batch is cut right after, such as TOKEN_TRANSACTION.
+	PriorityHeaderTypes []string
+	// MaxLatency is the longest the first message of a batch waits before
+	// the batch is cut as another message arrives, zero to disable.
+	MaxLatency time.Duration
+}
+
 // Profile contains configuration for Go pprof profiling.
 type Profile struct {
 	Enabled bool
@@ -114,6 +167,9 @@ type Profile struct {
 type FileLedger struct {
 	Location string
 	Prefix   string
+	// IndexTxIDs indexes the blocks by the identifiers of their transactions,
+	// so that the clients can query the inclusion of their transactions.
+	IndexTxIDs bool
 }
 
 // RAMLedger contains configuration for the RAM ledger.
@@ -222,12 +278,18 @@ var Defaults = TopLevel{
 		Cluster: Cluster{
 			ReplicationMaxRetries: 12,
 		},
-		LocalMSPDir: "msp",
-		LocalMSPID:  "SampleOrg",
-		BCCSP:       bccsp.GetDefaultOpts(),
+		LocalMSPDir:  "msp",
+		LocalMSPID:   "SampleOrg",
+		MSPCacheSize: 100,
+		BCCSP:        bccsp.GetDefaultOpts(),
 		Authentication: Authentication{
 			TimeWindow: time.Duration(15 * time.Minute),
 		},
+		PriorityLanes: PriorityLanes{
+			Enabled:     false,
+			MaxInFlight: 1,
+			MaxSkips:    10,
+		},
 	},
 	RAMLedger: RAMLedger{
 		HistorySize: 10000,
@@ -320,6 +382,9 @@ func (c *TopLevel) completeInitialization(configDir string) {
 		coreconfig.TranslatePathInPlace(configDir, &c.General.TLS.Certificate)
 		coreconfig.TranslatePathInPlace(configDir, &c.General.GenesisFile)
 		coreconfig.TranslatePathInPlace(configDir, &c.General.LocalMSPDir)
+		if c.General.ThresholdSigning.KeyShareFile != "" {
+			coreconfig.TranslatePathInPlace(configDir, &c.General.ThresholdSigning.KeyShareFile)
+		}
 	}()
 
 	for {
@@ -345,6 +410,9 @@ func (c *TopLevel) completeInitialization(configDir string) {
 			c.General.SystemChannel = Defaults.General.SystemChannel
 		case c.General.Cluster.ReplicationMaxRetries == 0:
 			c.General.Cluster.ReplicationMaxRetries = 12
+		case c.General.PriorityLanes.Enabled && c.General.PriorityLanes.MaxInFlight <= 0:
+			logger.Infof("General.PriorityLanes.MaxInFlight unset, setting to %d", Defaults.General.PriorityLanes.MaxInFlight)
+			c.General.PriorityLanes.MaxInFlight = Defaults.General.PriorityLanes.MaxInFlight
 
 		case c.Kafka.TLS.Enabled && c.Kafka.TLS.Certificate == "":
 			logger.Panicf("General.Kafka.TLS.Certificate must be set if General.Kafka.TLS.Enabled is set to true.")
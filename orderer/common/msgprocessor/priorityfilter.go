@@ -0,0 +1,74 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/policies"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// PriorityFilterSupport provides the resources required for the priority filter
+type PriorityFilterSupport interface {
+	SigFilterSupport
+
+	// OrdererConfig returns the config.Orderer for the channel
+	// and whether the Orderer config exists
+	OrdererConfig() (channelconfig.Orderer, bool)
+}
+
+// PriorityFilter rejects the messages requesting a priority ordering unless
+// they satisfy the priority writers policy of the channel. The priority is
+// ignored, as by the orderers which predate it, on the channels without the
+// Priority orderer capability.
+type PriorityFilter struct {
+	support PriorityFilterSupport
+}
+
+// NewPriorityFilter creates a new priority filter, the orderer config and the
+// policy manager are called at every evaluation to retrieve their latest version.
+func NewPriorityFilter(support PriorityFilterSupport) *PriorityFilter {
+	return &PriorityFilter{support: support}
+}
+
+// Apply forwards the messages without a priority, the messages of the channels
+// without the Priority capability, and the messages with a priority which
+// satisfy the priority writers policy.
+func (pf *PriorityFilter) Apply(message *cb.Envelope) error {
+	chdr, err := utils.ChannelHeader(message)
+	if err != nil {
+		return errors.WithMessage(err, "could not extract channel header")
+	}
+	if chdr.Priority == 0 {
+		return nil
+	}
+
+	ordererConfig, ok := pf.support.OrdererConfig()
+	if !ok {
+		logger.Panic("Programming error: orderer config not found")
+	}
+	if !ordererConfig.Capabilities().Priority() {
+		return nil
+	}
+
+	policy, ok := pf.support.PolicyManager().GetPolicy(policies.ChannelOrdererPriorityWriters)
+	if !ok {
+		return errors.Wrapf(errors.WithStack(ErrPermissionDenied), "priority %d requested but the channel has no %s policy", chdr.Priority, policies.ChannelOrdererPriorityWriters)
+	}
+
+	signedData, err := message.AsSignedData()
+	if err != nil {
+		return errors.Errorf("could not convert message to signedData: %s", err)
+	}
+	err = policy.Evaluate(signedData)
+	if err != nil {
+		return errors.Wrap(errors.WithStack(ErrPermissionDenied), err.Error())
+	}
+	return nil
+}
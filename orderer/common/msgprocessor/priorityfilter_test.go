@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"fmt"
+	"testing"
+
+	mockchannelconfig "github.com/hyperledger/fabric/common/mocks/config"
+	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
+	"github.com/hyperledger/fabric/common/policies"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func makePriorityEnvelope(priority uint32) *cb.Envelope {
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader:   utils.MarshalOrPanic(&cb.ChannelHeader{ChannelId: "foo", Priority: priority}),
+				SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{}),
+			},
+		}),
+	}
+}
+
+func TestPriorityFilter(t *testing.T) {
+	ordererConfig := &mockchannelconfig.Orderer{
+		CapabilitiesVal: &mockchannelconfig.OrdererCapabilities{PriorityVal: true},
+	}
+	priorityWriters := func(policy *mockpolicies.Policy) *mockchannelconfig.Resources {
+		return &mockchannelconfig.Resources{
+			PolicyManagerVal: &mockpolicies.Manager{
+				PolicyMap: map[string]policies.Policy{policies.ChannelOrdererPriorityWriters: policy},
+			},
+			OrdererConfigVal: ordererConfig,
+		}
+	}
+	noPriorityWriters := &mockchannelconfig.Resources{
+		PolicyManagerVal: &mockpolicies.Manager{},
+		OrdererConfigVal: ordererConfig,
+	}
+
+	t.Run("no priority", func(t *testing.T) {
+		err := NewPriorityFilter(noPriorityWriters).Apply(makePriorityEnvelope(0))
+		assert.NoError(t, err)
+	})
+
+	t.Run("authorized priority", func(t *testing.T) {
+		err := NewPriorityFilter(priorityWriters(&mockpolicies.Policy{})).Apply(makePriorityEnvelope(1))
+		assert.NoError(t, err)
+	})
+
+	t.Run("unauthorized priority", func(t *testing.T) {
+		err := NewPriorityFilter(priorityWriters(&mockpolicies.Policy{Err: fmt.Errorf("Error")})).Apply(makePriorityEnvelope(1))
+		assert.Equal(t, ErrPermissionDenied, errors.Cause(err))
+	})
+
+	t.Run("missing policy", func(t *testing.T) {
+		err := NewPriorityFilter(noPriorityWriters).Apply(makePriorityEnvelope(2))
+		assert.Equal(t, ErrPermissionDenied, errors.Cause(err))
+		assert.Contains(t, err.Error(), "priority 2 requested but the channel has no /Channel/Orderer/PriorityWriters policy")
+	})
+
+	t.Run("no priority capability", func(t *testing.T) {
+		err := NewPriorityFilter(&mockchannelconfig.Resources{
+			PolicyManagerVal: &mockpolicies.Manager{},
+			OrdererConfigVal: &mockchannelconfig.Orderer{CapabilitiesVal: &mockchannelconfig.OrdererCapabilities{}},
+		}).Apply(makePriorityEnvelope(2))
+		assert.NoError(t, err)
+	})
+
+	t.Run("missing orderer config", func(t *testing.T) {
+		filter := NewPriorityFilter(&mockchannelconfig.Resources{PolicyManagerVal: &mockpolicies.Manager{}})
+		assert.Panics(t, func() { filter.Apply(makePriorityEnvelope(2)) })
+	})
+
+	t.Run("missing channel header", func(t *testing.T) {
+		err := NewPriorityFilter(priorityWriters(&mockpolicies.Policy{})).Apply(makeEnvelope())
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "could not extract channel header")
+	})
+}
@@ -50,6 +50,7 @@ func CreateSystemChannelFilters(chainCreator ChainCreator, ledgerResources chann
 		NewExpirationRejectRule(ledgerResources),
 		NewSizeFilter(ordererConfig),
 		NewSigFilter(policies.ChannelWriters, ledgerResources),
+		NewPriorityFilter(ledgerResources),
 		NewSystemChannelFilter(ledgerResources, chainCreator),
 	})
 }
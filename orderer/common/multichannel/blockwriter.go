@@ -13,6 +13,7 @@ import (
 	newchannelconfig "github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/configtx"
 	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/crypto/tbls"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	"github.com/hyperledger/fabric/common/util"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -25,6 +26,7 @@ type blockWriterSupport interface {
 	configtx.Validator
 	Update(*newchannelconfig.Bundle)
 	CreateBundle(channelID string, config *cb.Config) (*newchannelconfig.Bundle, error)
+	ChannelConfig() newchannelconfig.Channel
 }
 
 // BlockWriter efficiently writes the blockchain to disk.
@@ -38,6 +40,8 @@ type BlockWriter struct {
 	lastConfigSeq      uint64
 	lastBlock          *cb.Block
 	committingBlock    sync.Mutex
+	// hashingSuite hashes the blocks of the channel; nil hashes them with SHA256
+	hashingSuite func([]byte) []byte
 }
 
 func newBlockWriter(lastBlock *cb.Block, r *Registrar, support blockWriterSupport) *BlockWriter {
@@ -46,6 +50,8 @@ func newBlockWriter(lastBlock *cb.Block, r *Registrar, support blockWriterSuppor
 		lastConfigSeq: support.Sequence(),
 		lastBlock:     lastBlock,
 		registrar:     r,
+		// the hashing suite of a channel cannot change
+		hashingSuite: support.ChannelConfig().HashingSuite(),
 	}
 
 	// If this is the genesis block, the lastconfig field may be empty, and, the last config block is necessarily block 0
@@ -64,7 +70,11 @@ func newBlockWriter(lastBlock *cb.Block, r *Registrar, support blockWriterSuppor
 
 // CreateNextBlock creates a new block with the next block number, and the given contents.
 func (bw *BlockWriter) CreateNextBlock(messages []*cb.Envelope) *cb.Block {
-	previousBlockHash := bw.lastBlock.Header.Hash()
+	hashingSuite := bw.hashingSuite
+	if hashingSuite == nil {
+		hashingSuite = util.ComputeSHA256
+	}
+	previousBlockHash := bw.lastBlock.Header.HashWith(hashingSuite)
 
 	data := &cb.BlockData{
 		Data: make([][]byte, len(messages)),
@@ -79,7 +89,7 @@ func (bw *BlockWriter) CreateNextBlock(messages []*cb.Envelope) *cb.Block {
 	}
 
 	block := cb.NewBlock(bw.lastBlock.Header.Number+1, previousBlockHash)
-	block.Header.DataHash = data.Hash()
+	block.Header.DataHash = data.HashWith(hashingSuite)
 	block.Data = data
 
 	return block
@@ -178,8 +188,12 @@ func (bw *BlockWriter) addBlockSignature(block *cb.Block) {
 	}
 
 	// Note, this value is intentionally nil, as this metadata is only about the signature, there is no additional metadata
-	// information required beyond the fact that the metadata item is signed.
+	// information required beyond the fact that the metadata item is signed, unless the orderer holds a key share of the
+	// consenter set, in which case it is the signature share of the block header.
 	blockSignatureValue := []byte(nil)
+	if bw.registrar != nil && bw.registrar.keyShare != nil {
+		blockSignatureValue = tbls.SignBlockHeader(bw.registrar.keyShare, block.Header)
+	}
 
 	blockSignature.Signature = utils.SignOrPanic(bw.support, util.ConcatenateBytes(blockSignatureValue, blockSignature.SignatureHeader, block.Header.Bytes()))
 
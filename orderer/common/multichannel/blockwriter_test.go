@@ -21,9 +21,12 @@ import (
 
 	newchannelconfig "github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/crypto/tbls"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	mockconfigtx "github.com/hyperledger/fabric/common/mocks/configtx"
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/common/util"
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
@@ -41,6 +44,10 @@ func (mbws mockBlockWriterSupport) CreateBundle(channelID string, config *cb.Con
 	return nil, nil
 }
 
+func (mbws mockBlockWriterSupport) ChannelConfig() newchannelconfig.Channel {
+	return &mockconfig.Channel{HashingSuiteVal: util.ComputeSHA3256}
+}
+
 func TestCreateBlock(t *testing.T) {
 	seedBlock := cb.NewBlock(7, []byte("lasthash"))
 	seedBlock.Data.Data = [][]byte{[]byte("somebytes")}
@@ -55,6 +62,23 @@ func TestCreateBlock(t *testing.T) {
 	assert.Equal(t, seedBlock.Header.Hash(), block.Header.PreviousHash)
 }
 
+func TestCreateBlockWithHashingSuite(t *testing.T) {
+	seedBlock := cb.NewBlock(7, []byte("lasthash"))
+	seedBlock.Data.Data = [][]byte{[]byte("somebytes")}
+	seedBlock.Metadata.Metadata[cb.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&cb.Metadata{
+		Value: utils.MarshalOrPanic(&cb.LastConfig{Index: 0}),
+	})
+
+	bw := newBlockWriter(seedBlock, nil, &mockBlockWriterSupport{Validator: &mockconfigtx.Validator{ChainIDVal: "mychannel"}})
+	block := bw.CreateNextBlock([]*cb.Envelope{
+		{Payload: []byte("some other bytes")},
+	})
+
+	assert.Equal(t, seedBlock.Header.Number+1, block.Header.Number)
+	assert.Equal(t, block.Data.HashWith(util.ComputeSHA3256), block.Header.DataHash)
+	assert.Equal(t, seedBlock.Header.HashWith(util.ComputeSHA3256), block.Header.PreviousHash)
+}
+
 func TestBlockSignature(t *testing.T) {
 	bw := &BlockWriter{
 		support: &mockBlockWriterSupport{
@@ -70,6 +94,26 @@ func TestBlockSignature(t *testing.T) {
 	assert.NotNil(t, md.Signatures, "Should have signature")
 }
 
+func TestBlockSignatureShare(t *testing.T) {
+	publicKey, keyShares, _, err := tbls.Deal(1, 1)
+	assert.NoError(t, err)
+	bw := &BlockWriter{
+		support: &mockBlockWriterSupport{
+			LocalSigner: mockCrypto(),
+		},
+		registrar: &Registrar{keyShare: keyShares[0]},
+	}
+
+	block := cb.NewBlock(7, []byte("foo"))
+	bw.addBlockSignature(block)
+
+	share, err := tbls.BlockSignatureShare(block)
+	assert.NoError(t, err)
+	signature, err := tbls.Combine(1, []*tbls.SignatureShare{share})
+	assert.NoError(t, err)
+	assert.True(t, tbls.VerifyBlockHeader(publicKey, block.Header, signature))
+}
+
 func TestBlockLastConfig(t *testing.T) {
 	lastConfigSeq := uint64(6)
 	newConfigSeq := lastConfigSeq + 1
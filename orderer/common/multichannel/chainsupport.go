@@ -12,6 +12,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -46,14 +47,21 @@ func newChainSupport(
 		logger.Fatalf("[channel: %s] Error extracting orderer metadata: %s", ledgerResources.ConfigtxValidator().ChainID(), err)
 	}
 
+	chainID := ledgerResources.ConfigtxValidator().ChainID()
+	cutPolicy, err := newCutPolicy(registrar.config.General.BatchCut, chainID, ledgerResources.SharedConfig().ConsensusType())
+	if err != nil {
+		logger.Panicf("[channel: %s] Error creating batch cut policy: %s", chainID, err)
+	}
+
 	// Construct limited support needed as a parameter for additional support
 	cs := &ChainSupport{
 		ledgerResources: ledgerResources,
 		LocalSigner:     signer,
 		cutter: blockcutter.NewReceiverImpl(
-			ledgerResources.ConfigtxValidator().ChainID(),
+			chainID,
 			ledgerResources,
 			blockcutterMetrics,
+			cutPolicy,
 		),
 	}
 
@@ -172,3 +180,39 @@ func (cs *ChainSupport) VerifyBlockSignature(sd []*cb.SignedData, envelope *cb.C
 	}
 	return nil
 }
+
+// newCutPolicy returns the batch cut policy configured for the channel, or nil
+// if batches are only cut according to the channel configuration.
+func newCutPolicy(config localconfig.BatchCut, chainID, consensusType string) (blockcutter.CutPolicy, error) {
+	policyConfig, ok := config.Channels[chainID]
+	if !ok {
+		policyConfig = config.Default
+	}
+
+	var cutPolicies blockcutter.CutPolicies
+	if len(policyConfig.PriorityHeaderTypes) > 0 {
+		priority := &blockcutter.PriorityCutPolicy{}
+		for _, name := range policyConfig.PriorityHeaderTypes {
+			headerType, ok := cb.HeaderType_value[name]
+			if !ok {
+				return nil, errors.Errorf("unknown header type %s", name)
+			}
+			priority.HeaderTypes = append(priority.HeaderTypes, cb.HeaderType(headerType))
+		}
+		cutPolicies = append(cutPolicies, priority)
+	}
+	if policyConfig.MaxLatency > 0 {
+		// Kafka based chains cut batches on every node independently, so
+		// cutting them according to the local clock would fork the chain.
+		if consensusType == "kafka" {
+			logger.Warningf("[channel: %s] Ignoring the batch cut latency target of %s with the kafka consensus type", chainID, policyConfig.MaxLatency)
+		} else {
+			cutPolicies = append(cutPolicies, &blockcutter.LatencyCutPolicy{Target: policyConfig.MaxLatency})
+		}
+	}
+
+	if len(cutPolicies) == 0 {
+		return nil, nil
+	}
+	return cutPolicies, nil
+}
@@ -8,6 +8,7 @@ package multichannel
 
 import (
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/deliver/mock"
@@ -19,6 +20,8 @@ import (
 	"github.com/hyperledger/fabric/common/tools/configtxgen/configtxgentest"
 	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
 	"github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/blockcutter"
+	orderercfg "github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/orderer"
 	"github.com/pkg/errors"
@@ -106,3 +109,38 @@ func testConfigEnvelope(t *testing.T) *common.ConfigEnvelope {
 		},
 	}
 }
+
+func TestNewCutPolicy(t *testing.T) {
+	config := orderercfg.BatchCut{
+		Default: orderercfg.BatchCutPolicy{
+			PriorityHeaderTypes: []string{"TOKEN_TRANSACTION"},
+		},
+		Channels: map[string]orderercfg.BatchCutPolicy{
+			"latency": {MaxLatency: time.Second},
+			"none":    {},
+			"invalid": {PriorityHeaderTypes: []string{"FOO"}},
+		},
+	}
+
+	policy, err := newCutPolicy(config, "mychannel", "solo")
+	assert.NoError(t, err)
+	assert.Equal(t, blockcutter.CutPolicies{
+		&blockcutter.PriorityCutPolicy{HeaderTypes: []common.HeaderType{common.HeaderType_TOKEN_TRANSACTION}},
+	}, policy)
+
+	policy, err = newCutPolicy(config, "latency", "etcdraft")
+	assert.NoError(t, err)
+	assert.Equal(t, blockcutter.CutPolicies{&blockcutter.LatencyCutPolicy{Target: time.Second}}, policy)
+
+	// The latency target is ignored by kafka based channels
+	policy, err = newCutPolicy(config, "latency", "kafka")
+	assert.NoError(t, err)
+	assert.Nil(t, policy)
+
+	policy, err = newCutPolicy(config, "none", "solo")
+	assert.NoError(t, err)
+	assert.Nil(t, policy)
+
+	_, err = newCutPolicy(config, "invalid", "solo")
+	assert.EqualError(t, err, "unknown header type FOO")
+}
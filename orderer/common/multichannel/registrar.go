@@ -11,15 +11,21 @@ package multichannel
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
 	"sync"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/channelregistry"
 	"github.com/hyperledger/fabric/common/configtx"
 	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/crypto/tbls"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -91,6 +97,7 @@ type ledgerResources struct {
 
 // Registrar serves as a point of access and control for the individual channel resources.
 type Registrar struct {
+	config localconfig.TopLevel
 	lock   sync.RWMutex
 	chains map[string]*ChainSupport
 
@@ -102,6 +109,11 @@ type Registrar struct {
 	systemChannel      *ChainSupport
 	templator          msgprocessor.ChannelConfigTemplator
 	callbacks          []channelconfig.BundleActor
+	channelRegistry    channelregistry.Registry
+	orderingServiceID  string
+	// keyShare signs the headers of the blocks, when threshold signing is
+	// enabled
+	keyShare *tbls.KeyShare
 }
 
 // ConfigBlock retrieves the last configuration block from the given ledger.
@@ -125,9 +137,10 @@ func configTx(reader blockledger.Reader) *cb.Envelope {
 }
 
 // NewRegistrar produces an instance of a *Registrar.
-func NewRegistrar(ledgerFactory blockledger.Factory,
+func NewRegistrar(config localconfig.TopLevel, ledgerFactory blockledger.Factory,
 	signer crypto.LocalSigner, metricsProvider metrics.Provider, callbacks ...channelconfig.BundleActor) *Registrar {
 	r := &Registrar{
+		config:             config,
 		chains:             make(map[string]*ChainSupport),
 		ledgerFactory:      ledgerFactory,
 		signer:             signer,
@@ -135,9 +148,43 @@ func NewRegistrar(ledgerFactory blockledger.Factory,
 		callbacks:          callbacks,
 	}
 
+	if dir := config.General.ChannelRegistry.Directory; dir != "" {
+		registry, err := channelregistry.NewDirRegistry(dir)
+		if err != nil {
+			logger.Panicf("Failed to open channel registry: %s", err)
+		}
+		r.channelRegistry = registry
+		r.orderingServiceID = config.General.ChannelRegistry.OrderingServiceID
+	}
+
+	if path := config.General.ThresholdSigning.KeyShareFile; path != "" {
+		r.keyShare = loadKeyShare(path)
+	}
+
 	return r
 }
 
+// loadKeyShare loads the key share for threshold signing from the file, as
+// dealt by tblsgen. A missing file disables threshold signing, so that the
+// orderers of a consenter set may be configured before the key shares are
+// dealt; a file which cannot be loaded is fatal.
+func loadKeyShare(path string) *tbls.KeyShare {
+	encoded, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		logger.Warningf("Key share file %s does not exist, threshold signing is disabled; deal the key shares with tblsgen", path)
+		return nil
+	}
+	if err != nil {
+		logger.Panicf("Failed to read the key share for threshold signing: %s", err)
+	}
+	keyShare, err := tbls.KeyShareFromBytes(encoded)
+	if err != nil {
+		logger.Panicf("Failed to load the key share for threshold signing from %s: %s", path, err)
+	}
+	logger.Infof("Signing the blocks with the key share of consenter %d", keyShare.Index)
+	return keyShare
+}
+
 func (r *Registrar) Initialize(consenters map[string]consensus.Consenter) {
 	r.consenters = consenters
 	existingChains := r.ledgerFactory.ChainIDs()
@@ -200,6 +247,18 @@ func (r *Registrar) Initialize(consenters map[string]consensus.Consenter) {
 	if r.systemChannelID == "" {
 		logger.Panicf("No system chain found.  If bootstrapping, does your system channel contain a consortiums group definition?")
 	}
+
+	if r.channelRegistry != nil {
+		if r.orderingServiceID == "" {
+			r.orderingServiceID = r.systemChannelID
+		}
+		// Register the channels created before the registry was enabled
+		for chainID, chain := range r.chains {
+			if err := r.registerChannel(chain); err != nil {
+				logger.Warningf("Failed to register existing channel %s: %s", chainID, err)
+			}
+		}
+	}
 }
 
 // SystemChannelID returns the ChannelID for the system channel.
@@ -317,6 +376,14 @@ func (r *Registrar) newChain(configtx *cb.Envelope) {
 
 	logger.Infof("Created and starting new chain %s", chainID)
 
+	if r.channelRegistry != nil {
+		// The creation was checked against the registry when validated, but
+		// another ordering service may have registered the ID in the meantime
+		if err := r.registerChannel(cs); err != nil {
+			logger.Errorf("Failed to register channel %s: %s", chainID, err)
+		}
+	}
+
 	newChains[string(chainID)] = cs
 	cs.start()
 
@@ -332,8 +399,50 @@ func (r *Registrar) ChannelsCount() int {
 }
 
 // NewChannelConfig produces a new template channel configuration based on the system channel's current config.
+// If the channel registry is enabled, it fails if the channel ID is registered by another ordering service.
 func (r *Registrar) NewChannelConfig(envConfigUpdate *cb.Envelope) (channelconfig.Resources, error) {
-	return r.templator.NewChannelConfig(envConfigUpdate)
+	res, err := r.templator.NewChannelConfig(envConfigUpdate)
+	if err != nil || r.channelRegistry == nil {
+		return res, err
+	}
+	if err := channelregistry.Check(r.channelRegistry, res.ConfigtxValidator().ChainID(), r.orderingServiceID); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// registerChannel registers the channel ID of the chain with its genesis block in the channel registry.
+func (r *Registrar) registerChannel(cs *ChainSupport) error {
+	genesisBlock := blockledger.GetBlock(cs, 0)
+	if genesisBlock == nil {
+		return errors.New("genesis block not found")
+	}
+	entry, err := channelregistry.NewEntry(r.orderingServiceID, consortiumName(configTx(cs)), genesisBlock)
+	if err != nil {
+		return err
+	}
+	return r.channelRegistry.Register(entry)
+}
+
+// consortiumName returns the consortium of the channel config, if any.
+func consortiumName(configTx *cb.Envelope) string {
+	payload, err := utils.UnmarshalPayload(configTx.Payload)
+	if err != nil {
+		return ""
+	}
+	configEnvelope, err := configtx.UnmarshalConfigEnvelope(payload.Data)
+	if err != nil || configEnvelope.Config == nil || configEnvelope.Config.ChannelGroup == nil {
+		return ""
+	}
+	value, ok := configEnvelope.Config.ChannelGroup.Values[channelconfig.ConsortiumKey]
+	if !ok {
+		return ""
+	}
+	consortium := &cb.Consortium{}
+	if err := proto.Unmarshal(value.Value, consortium); err != nil {
+		return ""
+	}
+	return consortium.Name
 }
 
 // CreateBundle calls channelconfig.NewBundle
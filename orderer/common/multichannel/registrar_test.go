@@ -7,10 +7,15 @@ SPDX-License-Identifier: Apache-2.0
 package multichannel
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelregistry"
 	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/crypto/tbls"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	ramledger "github.com/hyperledger/fabric/common/ledger/blockledger/ram"
@@ -24,6 +29,7 @@ import (
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
@@ -111,7 +117,7 @@ func TestNoSystemChain(t *testing.T) {
 	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
 
 	assert.Panics(t, func() {
-		NewRegistrar(lf, mockCrypto(), &disabled.Provider{}).Initialize(consenters)
+		NewRegistrar(localconfig.TopLevel{}, lf, mockCrypto(), &disabled.Provider{}).Initialize(consenters)
 	}, "Should have panicked when starting without a system chain")
 }
 
@@ -131,7 +137,7 @@ func TestMultiSystemChannel(t *testing.T) {
 	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
 
 	assert.Panics(t, func() {
-		NewRegistrar(lf, mockCrypto(), &disabled.Provider{}).Initialize(consenters)
+		NewRegistrar(localconfig.TopLevel{}, lf, mockCrypto(), &disabled.Provider{}).Initialize(consenters)
 	}, "Two system channels should have caused panic")
 }
 
@@ -142,7 +148,7 @@ func TestManagerImpl(t *testing.T) {
 	consenters := make(map[string]consensus.Consenter)
 	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
 
-	manager := NewRegistrar(lf, mockCrypto(), &disabled.Provider{})
+	manager := NewRegistrar(localconfig.TopLevel{}, lf, mockCrypto(), &disabled.Provider{})
 	manager.Initialize(consenters)
 
 	chainSupport := manager.GetChain("Fake")
@@ -175,7 +181,7 @@ func TestCreateChain(t *testing.T) {
 	consenters := make(map[string]consensus.Consenter)
 	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
 
-	manager := NewRegistrar(lf, mockCrypto(), &disabled.Provider{})
+	manager := NewRegistrar(localconfig.TopLevel{}, lf, mockCrypto(), &disabled.Provider{})
 	manager.Initialize(consenters)
 
 	ledger, err := lf.GetOrCreate("mychannel")
@@ -214,7 +220,7 @@ func TestNewChain(t *testing.T) {
 	consenters := make(map[string]consensus.Consenter)
 	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
 
-	manager := NewRegistrar(lf, mockCrypto(), &disabled.Provider{})
+	manager := NewRegistrar(localconfig.TopLevel{}, lf, mockCrypto(), &disabled.Provider{})
 	manager.Initialize(consenters)
 	orglessChannelConf := configtxgentest.Load(genesisconfig.SampleSingleMSPChannelProfile)
 	orglessChannelConf.Application.Organizations = nil
@@ -293,6 +299,59 @@ func TestNewChain(t *testing.T) {
 	assert.Equal(t, expectedLastConfigSeq, rcs.lastConfigSeq, "On restart, incorrect lastConfigSeq")
 }
 
+func TestChannelRegistry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "channelregistry")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	lf, rl := NewRAMLedgerAndFactory(10)
+	consenters := map[string]consensus.Consenter{conf.Orderer.OrdererType: &mockConsenter{}}
+	config := localconfig.TopLevel{}
+	config.General.ChannelRegistry.Directory = dir
+	manager := NewRegistrar(config, lf, mockCrypto(), &disabled.Provider{})
+	manager.Initialize(consenters)
+
+	registry := &channelregistry.DirRegistry{Dir: dir}
+	entry, err := registry.Lookup(genesisconfig.TestChainID)
+	assert.NoError(t, err, "the existing system channel is registered")
+	assert.Equal(t, genesisconfig.TestChainID, entry.OrderingService, "the ordering service defaults to the system channel ID")
+	assert.NoError(t, channelregistry.VerifyGenesisBlock(registry, genesisBlock))
+
+	orglessChannelConf := configtxgentest.Load(genesisconfig.SampleSingleMSPChannelProfile)
+	orglessChannelConf.Application.Organizations = nil
+	createChannel := func(channelID string) error {
+		envConfigUpdate, err := encoder.MakeChannelCreationTransaction(channelID, mockCrypto(), orglessChannelConf)
+		assert.NoError(t, err)
+		res, err := manager.NewChannelConfig(envConfigUpdate)
+		if err != nil {
+			return err
+		}
+		configEnv, err := res.ConfigtxValidator().ProposeConfigUpdate(envConfigUpdate)
+		assert.NoError(t, err)
+		ingressTx, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG, channelID, mockCrypto(), configEnv, msgVersion, epoch)
+		assert.NoError(t, err)
+		// The channel is created before the orderer transaction is written
+		it, _ := rl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: rl.Height()}}})
+		defer it.Close()
+		manager.GetChain(manager.SystemChannelID()).Configure(wrapConfigTx(ingressTx), 0)
+		_, status := it.Next()
+		assert.Equal(t, cb.Status_SUCCESS, status)
+		return nil
+	}
+
+	assert.NoError(t, createChannel("mychannel"))
+	assert.NotNil(t, manager.GetChain("mychannel"))
+	entry, err = registry.Lookup("mychannel")
+	assert.NoError(t, err, "the new channel is registered")
+	assert.Equal(t, genesisconfig.SampleConsortiumName, entry.Consortium)
+	assert.NoError(t, channelregistry.VerifyGenesisBlock(registry, blockledger.GetBlock(manager.GetChain("mychannel"), 0)))
+
+	assert.NoError(t, registry.Register(&channelregistry.Entry{ChannelID: "taken", OrderingService: "service2"}))
+	err = createChannel("taken")
+	assert.EqualError(t, err, "channel ID taken is already registered by ordering service service2")
+	assert.Nil(t, manager.GetChain("taken"))
+}
+
 func testLastConfigBlockNumber(t *testing.T, block *cb.Block, expectedBlockNumber uint64) {
 	metadataItem := &cb.Metadata{}
 	err := proto.Unmarshal(block.Metadata.Metadata[cb.BlockMetadataIndex_LAST_CONFIG], metadataItem)
@@ -371,10 +430,34 @@ func TestResourcesCheck(t *testing.T) {
 func TestBroadcastChannelSupportRejection(t *testing.T) {
 	ledgerFactory, _ := NewRAMLedgerAndFactory(10)
 	mockConsenters := map[string]consensus.Consenter{conf.Orderer.OrdererType: &mockConsenter{}}
-	registrar := NewRegistrar(ledgerFactory, mockCrypto(), &disabled.Provider{})
+	registrar := NewRegistrar(localconfig.TopLevel{}, ledgerFactory, mockCrypto(), &disabled.Provider{})
 	registrar.Initialize(mockConsenters)
 	randomValue := 1
 	configTx := makeConfigTx(genesisconfig.TestChainID, randomValue)
 	_, _, _, err := registrar.BroadcastChannelSupport(configTx)
 	assert.Error(t, err, "Messages of type HeaderType_CONFIG should return an error.")
 }
+
+func TestThresholdSigningKeyShare(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thresholdsigning")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, keyShares, _, err := tbls.Deal(2, 3)
+	assert.NoError(t, err)
+	path := filepath.Join(dir, "keyshare")
+	assert.NoError(t, ioutil.WriteFile(path, keyShares[1].Bytes(), 0600))
+
+	lf, _ := NewRAMLedgerAndFactory(10)
+	config := localconfig.TopLevel{}
+	config.General.ThresholdSigning.KeyShareFile = path
+	manager := NewRegistrar(config, lf, mockCrypto(), &disabled.Provider{})
+	assert.Equal(t, keyShares[1].Bytes(), manager.keyShare.Bytes())
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte("garbage"), 0600))
+	assert.Panics(t, func() { NewRegistrar(config, lf, mockCrypto(), &disabled.Provider{}) })
+
+	config.General.ThresholdSigning.KeyShareFile = filepath.Join(dir, "missing")
+	manager = NewRegistrar(config, lf, mockCrypto(), &disabled.Provider{})
+	assert.Nil(t, manager.keyShare)
+}
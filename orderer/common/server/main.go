@@ -37,9 +37,12 @@ import (
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/operations"
 	"github.com/hyperledger/fabric/msp"
+	mspcache "github.com/hyperledger/fabric/msp/cache"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/orderer/common/bootstrap/file"
+	"github.com/hyperledger/fabric/orderer/common/broadcast"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/inclusion"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/metadata"
 	"github.com/hyperledger/fabric/orderer/common/multichannel"
@@ -83,7 +86,7 @@ func Main() {
 		logger.Error("failed to parse config: ", err)
 		os.Exit(1)
 	}
-	initializeLogging()
+	initializeLogging(conf)
 	initializeLocalMsp(conf)
 
 	prettyPrintStruct(conf)
@@ -123,6 +126,7 @@ func Start(cmd string, conf *localconfig.TopLevel) {
 	metricsProvider := opsSystem.Provider
 	logObserver := floggingmetrics.NewObserver(metricsProvider)
 	flogging.Global.SetObserver(logObserver)
+	mspcache.Configure(mspcache.Options{CacheSize: conf.General.MSPCacheSize, MetricsProvider: metricsProvider})
 
 	serverConfig := initializeServerConfig(conf, metricsProvider)
 	grpcServer := initializeGrpcServer(conf, serverConfig)
@@ -158,7 +162,11 @@ func Start(cmd string, conf *localconfig.TopLevel) {
 
 	manager := initializeMultichannelRegistrar(bootstrapBlock, r, clusterDialer, clusterServerConfig, clusterGRPCServer, conf, signer, metricsProvider, opsSystem, lf, tlsCallback)
 	mutualTLS := serverConfig.SecOpts.UseTLS && serverConfig.SecOpts.RequireClientCert
-	server := NewServer(manager, metricsProvider, &conf.Debug, conf.General.Authentication.TimeWindow, mutualTLS)
+	var priorityLanes *broadcast.PriorityLanes
+	if conf.General.PriorityLanes.Enabled {
+		priorityLanes = broadcast.NewPriorityLanes(conf.General.PriorityLanes.MaxInFlight, conf.General.PriorityLanes.MaxSkips)
+	}
+	server := NewServer(manager, metricsProvider, &conf.Debug, conf.General.Authentication.TimeWindow, mutualTLS, priorityLanes)
 
 	logger.Infof("Starting %s", metadata.GetVersionInfo())
 	go handleSignals(addPlatformSignals(map[os.Signal]func(){
@@ -177,13 +185,17 @@ func Start(cmd string, conf *localconfig.TopLevel) {
 
 	initializeProfilingService(conf)
 	ab.RegisterAtomicBroadcastServer(grpcServer.Server(), server)
+	ab.RegisterTxInclusionServer(grpcServer.Server(), inclusion.NewHandler(inclusionSupport{Registrar: manager}, conf.General.Authentication.TimeWindow, mutualTLS))
 	logger.Info("Beginning to serve requests")
 	grpcServer.Start()
 }
 
-func initializeLogging() {
+func initializeLogging(conf *localconfig.TopLevel) {
 	loggingSpec := os.Getenv("FABRIC_LOGGING_SPEC")
 	loggingFormat := os.Getenv("FABRIC_LOGGING_FORMAT")
+	if loggingFormat == "" {
+		loggingFormat = conf.General.LogFormat
+	}
 	flogging.Init(flogging.Config{
 		Format:  loggingFormat,
 		Writer:  os.Stderr,
@@ -522,7 +534,7 @@ func initializeMultichannelRegistrar(
 
 	consenters := make(map[string]consensus.Consenter)
 
-	registrar := multichannel.NewRegistrar(lf, signer, metricsProvider, callbacks...)
+	registrar := multichannel.NewRegistrar(*conf, lf, signer, metricsProvider, callbacks...)
 
 	consenters["solo"] = solo.New()
 	var kafkaMetrics *kafka.Metrics
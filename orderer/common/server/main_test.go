@@ -43,11 +43,25 @@ import (
 func TestInitializeLogging(t *testing.T) {
 	origEnvValue := os.Getenv("FABRIC_LOGGING_SPEC")
 	os.Setenv("FABRIC_LOGGING_SPEC", "foo=debug")
-	initializeLogging()
+	initializeLogging(&localconfig.TopLevel{})
 	assert.Equal(t, "debug", flogging.Global.Level("foo").String())
 	os.Setenv("FABRIC_LOGGING_SPEC", origEnvValue)
 }
 
+func TestInitializeLoggingFormat(t *testing.T) {
+	defer flogging.Reset()
+	origEnvValue := os.Getenv("FABRIC_LOGGING_FORMAT")
+	defer os.Setenv("FABRIC_LOGGING_FORMAT", origEnvValue)
+
+	os.Unsetenv("FABRIC_LOGGING_FORMAT")
+	initializeLogging(&localconfig.TopLevel{General: localconfig.General{LogFormat: "json"}})
+	assert.Equal(t, flogging.Encoding(flogging.JSON), flogging.Global.Encoding())
+
+	os.Setenv("FABRIC_LOGGING_FORMAT", "logfmt")
+	initializeLogging(&localconfig.TopLevel{General: localconfig.General{LogFormat: "json"}})
+	assert.Equal(t, flogging.Encoding(flogging.LOGFMT), flogging.Global.Encoding())
+}
+
 func TestInitializeProfilingService(t *testing.T) {
 	origEnvValue := os.Getenv("FABRIC_LOGGING_SPEC")
 	defer os.Setenv("FABRIC_LOGGING_SPEC", origEnvValue)
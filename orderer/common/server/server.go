@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/orderer/common/broadcast"
+	"github.com/hyperledger/fabric/orderer/common/inclusion"
 	localconfig "github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	"github.com/hyperledger/fabric/orderer/common/multichannel"
@@ -46,6 +47,18 @@ func (ds deliverSupport) GetChain(chainID string) deliver.Chain {
 	return chain
 }
 
+type inclusionSupport struct {
+	*multichannel.Registrar
+}
+
+func (is inclusionSupport) GetChain(chainID string) inclusion.Chain {
+	chain := is.Registrar.GetChain(chainID)
+	if chain == nil {
+		return nil
+	}
+	return chain
+}
+
 type server struct {
 	bh    *broadcast.Handler
 	dh    *deliver.Handler
@@ -72,12 +85,15 @@ func (rs *responseSender) SendBlockResponse(block *cb.Block) error {
 }
 
 // NewServer creates an ab.AtomicBroadcastServer based on the broadcast target and ledger Reader
-func NewServer(r *multichannel.Registrar, metricsProvider metrics.Provider, debug *localconfig.Debug, timeWindow time.Duration, mutualTLS bool) ab.AtomicBroadcastServer {
+func NewServer(r *multichannel.Registrar, metricsProvider metrics.Provider, debug *localconfig.Debug, timeWindow time.Duration, mutualTLS bool, priorityLanes *broadcast.PriorityLanes) ab.AtomicBroadcastServer {
 	s := &server{
 		dh: deliver.NewHandler(deliverSupport{Registrar: r}, timeWindow, mutualTLS, deliver.NewMetrics(metricsProvider)),
 		bh: &broadcast.Handler{
-			SupportRegistrar: broadcastSupport{Registrar: r},
-			Metrics:          broadcast.NewMetrics(metricsProvider),
+			SupportRegistrar:    broadcastSupport{Registrar: r},
+			Metrics:             broadcast.NewMetrics(metricsProvider),
+			IngressLimiter:      broadcast.NewIngressLimiter(),
+			PriorityLanes:       priorityLanes,
+			TxExpirationChecker: broadcast.NewTxExpirationChecker(),
 		},
 		debug:     debug,
 		Registrar: r,
@@ -9,6 +9,7 @@ package etcdraft
 import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/util"
 	cb "github.com/hyperledger/fabric/protos/common"
 )
 
@@ -18,6 +19,9 @@ type blockCreator struct {
 	hash   []byte
 	number uint64
 
+	// hashingSuite hashes the blocks of the channel; nil hashes them with SHA256
+	hashingSuite func([]byte) []byte
+
 	logger *flogging.FabricLogger
 }
 
@@ -34,11 +38,16 @@ func (bc *blockCreator) createNextBlock(envs []*cb.Envelope) *cb.Block {
 		}
 	}
 
+	hashingSuite := bc.hashingSuite
+	if hashingSuite == nil {
+		hashingSuite = util.ComputeSHA256
+	}
+
 	block := cb.NewBlock(bc.number+1, bc.hash)
-	block.Header.DataHash = data.Hash()
+	block.Header.DataHash = data.HashWith(hashingSuite)
 	block.Data = data
 
-	bc.hash = block.Header.Hash()
+	bc.hash = block.Header.HashWith(hashingSuite)
 	bc.number++
 
 	return block
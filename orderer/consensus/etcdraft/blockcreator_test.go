@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/util"
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
@@ -39,3 +40,20 @@ func TestCreateNextBlock(t *testing.T) {
 	assert.Equal(t, third.Data.Hash(), third.Header.DataHash)
 	assert.Equal(t, second.Header.Hash(), third.Header.PreviousHash)
 }
+
+func TestCreateNextBlockWithHashingSuite(t *testing.T) {
+	first := cb.NewBlock(0, []byte("firsthash"))
+	bc := &blockCreator{
+		hash:         first.Header.HashWith(util.ComputeSHA3256),
+		number:       first.Header.Number,
+		hashingSuite: util.ComputeSHA3256,
+		logger:       flogging.NewFabricLogger(zap.NewNop()),
+	}
+
+	second := bc.createNextBlock([]*cb.Envelope{{Payload: []byte("some other bytes")}})
+	assert.Equal(t, second.Data.HashWith(util.ComputeSHA3256), second.Header.DataHash)
+	assert.Equal(t, first.Header.HashWith(util.ComputeSHA3256), second.Header.PreviousHash)
+
+	third := bc.createNextBlock([]*cb.Envelope{{Payload: []byte("some other bytes")}})
+	assert.Equal(t, second.Header.HashWith(util.ComputeSHA3256), third.Header.PreviousHash)
+}
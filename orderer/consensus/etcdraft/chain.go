@@ -83,6 +83,13 @@ type Options struct {
 	MaxInflightMsgs int
 
 	RaftMetadata *etcdraft.RaftMetadata
+
+	// ReceiveQueueDir, when set, is where the normal envelopes received for
+	// ordering are persisted until they are included in a block.
+	ReceiveQueueDir string
+	// This is configurable mainly for testing purpose. Users are not
+	// expected to alter this. Instead, DefaultReceiveQueueRetention is used.
+	ReceiveQueueRetention time.Duration
 }
 
 type submit struct {
@@ -123,6 +130,8 @@ type Chain struct {
 	confState        raftpb.ConfState // Etcdraft requires ConfState to be persisted within snapshot
 	puller           BlockPuller      // Deliver client to pull blocks from other OSNs
 
+	receiveQueue *ReceiveQueue // Persists the envelopes received until they are ordered, if enabled
+
 	fresh bool // indicate if this is a fresh raft node
 
 	node *node
@@ -183,6 +192,16 @@ func NewChain(
 		opts:             opts,
 	}
 
+	if opts.ReceiveQueueDir != "" {
+		c.receiveQueue, err = OpenReceiveQueue(opts.ReceiveQueueDir, lg)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to open receive queue")
+		}
+		if c.opts.ReceiveQueueRetention == 0 {
+			c.opts.ReceiveQueueRetention = DefaultReceiveQueueRetention
+		}
+	}
+
 	// DO NOT use Applied option in config, see https://github.com/etcd-io/etcd/issues/10217
 	// We guard against replay of written blocks in `entriesToApply` instead.
 	config := &raft.Config{
@@ -228,11 +247,54 @@ func (c *Chain) Start() {
 	close(c.startC)
 
 	go c.serveRequest()
+
+	if c.receiveQueue != nil {
+		go c.resubmitReceived()
+	}
 }
 
 // Order submits normal type transactions for ordering.
 func (c *Chain) Order(env *common.Envelope, configSeq uint64) error {
-	return c.Submit(&orderer.SubmitRequest{LastValidationSeq: configSeq, Content: env, Channel: c.channelID}, 0)
+	req := &orderer.SubmitRequest{LastValidationSeq: configSeq, Content: env, Channel: c.channelID}
+	if c.receiveQueue == nil {
+		return c.Submit(req, 0)
+	}
+
+	key, err := c.receiveQueue.Add(req)
+	if err != nil {
+		return errors.WithMessage(err, "failed to persist envelope")
+	}
+	if err := c.Submit(req, 0); err != nil {
+		c.receiveQueue.Remove(key)
+		return err
+	}
+	return nil
+}
+
+// resubmitReceived submits again the envelopes of the receive queue which
+// were not ordered before the chain was last stopped, retrying as long as
+// there is no Raft leader.
+func (c *Chain) resubmitReceived() {
+	reqs := c.receiveQueue.Pending(c.opts.ReceiveQueueRetention)
+	if len(reqs) == 0 {
+		return
+	}
+	c.logger.Infof("Submitting %d envelopes received but not ordered before restart", len(reqs))
+
+	for _, req := range reqs {
+		for {
+			err := c.Submit(req, 0)
+			if err == nil {
+				break
+			}
+			c.logger.Debugf("Failed to submit envelope from the receive queue, retrying: %s", err)
+			select {
+			case <-c.clock.After(c.opts.TickInterval):
+			case <-c.doneC:
+				return
+			}
+		}
+	}
 }
 
 // Configure submits config type transactions for ordering.
@@ -408,10 +470,12 @@ func (c *Chain) serveRequest() {
 		submitC = nil
 
 		lastBlock := c.support.Block(c.support.Height() - 1)
+		hashingSuite := c.support.ChannelConfig().HashingSuite()
 		bc = &blockCreator{
-			hash:   lastBlock.Header.Hash(),
-			number: lastBlock.Header.Number,
-			logger: c.logger,
+			hash:         lastBlock.Header.HashWith(hashingSuite),
+			number:       lastBlock.Header.Number,
+			hashingSuite: hashingSuite,
+			logger:       c.logger,
 		}
 
 		// if there is unfinished ConfChange, we should resume the effort to propose it as
@@ -536,6 +600,10 @@ func (c *Chain) serveRequest() {
 }
 
 func (c *Chain) writeBlock(block *common.Block, index uint64) {
+	if c.receiveQueue != nil {
+		defer c.receiveQueue.Ordered(block)
+	}
+
 	if utils.IsConfigBlock(block) {
 		c.writeConfigBlock(block, index)
 		return
@@ -631,6 +699,9 @@ func (c *Chain) catchUp(snap *raftpb.Snapshot) error {
 		} else {
 			c.support.WriteBlock(block, nil)
 		}
+		if c.receiveQueue != nil {
+			c.receiveQueue.Ordered(block)
+		}
 
 		next++
 	}
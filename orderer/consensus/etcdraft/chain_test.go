@@ -111,6 +111,7 @@ var _ = Describe("Chain", func() {
 
 			support = &consensusmocks.FakeConsenterSupport{}
 			support.ChainIDReturns(channelID)
+			support.ChannelConfigReturns(&mockconfig.Channel{})
 			consenterMetadata = createMetadata(1, tlsCA)
 			support.SharedConfigReturns(&mockconfig.Orderer{
 				BatchTimeoutVal:      time.Hour,
@@ -204,6 +205,59 @@ var _ = Describe("Chain", func() {
 			})
 		})
 
+		Context("when the receive queue is enabled", func() {
+			var queueDir string
+
+			queued := func() []os.FileInfo {
+				files, err := ioutil.ReadDir(queueDir)
+				Expect(err).NotTo(HaveOccurred())
+				return files
+			}
+
+			BeforeEach(func() {
+				queueDir = path.Join(dataDir, "queue")
+				opts.ReceiveQueueDir = queueDir
+				close(cutter.Block)
+				cutter.CutNext = true
+			})
+
+			It("drops the envelopes which could not be submitted", func() {
+				err := chain.Order(env, 0)
+				Expect(err).To(MatchError("no Raft leader"))
+				Expect(queued()).To(BeEmpty())
+			})
+
+			It("drops the envelopes once they are ordered", func() {
+				campaign(clock, observeC)
+
+				err := chain.Order(env, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(1))
+				Eventually(queued, LongEventualTimeout).Should(BeEmpty())
+			})
+
+			Context("when envelopes were received but not ordered before a restart", func() {
+				BeforeEach(func() {
+					q, err := etcdraft.OpenReceiveQueue(queueDir, logger)
+					Expect(err).NotTo(HaveOccurred())
+					_, err = q.Add(&orderer.SubmitRequest{Channel: channelID, Content: env})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("submits them again once there is a Raft leader", func() {
+					campaign(clock, observeC)
+
+					Eventually(func() int {
+						clock.Increment(interval)
+						return support.WriteBlockCallCount()
+					}, LongEventualTimeout).Should(Equal(1))
+					block, _ := support.WriteBlockArgsForCall(0)
+					Expect(block.Data.Data).To(Equal([][]byte{marshalOrPanic(env)}))
+					Eventually(queued, LongEventualTimeout).Should(BeEmpty())
+				})
+			})
+		})
+
 		Context("when Raft leader is elected", func() {
 			JustBeforeEach(func() {
 				campaign(clock, observeC)
@@ -1045,6 +1099,7 @@ var _ = Describe("Chain", func() {
 					// for block creator initialization
 					support.HeightReturns(1)
 					support.BlockReturns(getSeedBlock())
+					support.ChannelConfigReturns(&mockconfig.Channel{})
 				})
 
 				When("WAL dir is a file", func() {
@@ -2338,6 +2393,7 @@ func newChain(timeout time.Duration, channel string, dataDir string, id uint64,
 	support := &consensusmocks.FakeConsenterSupport{}
 	support.ChainIDReturns(channel)
 	support.SharedConfigReturns(&mockconfig.Orderer{BatchTimeoutVal: timeout})
+	support.ChannelConfigReturns(&mockconfig.Channel{})
 
 	cutter := mockblockcutter.NewReceiver()
 	close(cutter.Block)
@@ -55,6 +55,9 @@ type ChainGetter interface {
 type Config struct {
 	WALDir  string // WAL data of <my-channel> is stored in WALDir/<my-channel>
 	SnapDir string // Snapshots of <my-channel> are stored in SnapDir/<my-channel>
+	// The envelopes of <my-channel> received but not yet ordered are stored in
+	// ReceiveQueueDir/<my-channel>, or not persisted if ReceiveQueueDir is empty
+	ReceiveQueueDir string
 }
 
 // Consenter implements etddraft consenter
@@ -167,6 +170,9 @@ func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *co
 		WALDir:  path.Join(c.EtcdRaftConfig.WALDir, support.ChainID()),
 		SnapDir: path.Join(c.EtcdRaftConfig.SnapDir, support.ChainID()),
 	}
+	if c.EtcdRaftConfig.ReceiveQueueDir != "" {
+		opts.ReceiveQueueDir = path.Join(c.EtcdRaftConfig.ReceiveQueueDir, support.ChainID())
+	}
 
 	rpc := &cluster.RPC{
 		Channel:             support.ChainID(),
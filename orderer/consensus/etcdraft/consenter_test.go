@@ -44,6 +44,7 @@ var _ = Describe("Consenter", func() {
 	BeforeEach(func() {
 		chainGetter = &mocks.ChainGetter{}
 		support = &consensusmocks.FakeConsenterSupport{}
+		support.ChannelConfigReturns(&mockconfig.Channel{})
 		dataDir, err = ioutil.TempDir("", "snap-")
 		Expect(err).NotTo(HaveOccurred())
 		walDir = path.Join(dataDir, "wal-")
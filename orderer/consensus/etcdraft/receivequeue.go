@@ -0,0 +1,221 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package etcdraft
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// DefaultReceiveQueueRetention is the age beyond which the envelopes of the
+// receive queue are dropped instead of being submitted again on restart.
+const DefaultReceiveQueueRetention = time.Hour
+
+const receiveQueueTmpSuffix = ".tmp"
+
+// ReceiveQueue persists the normal envelopes received for ordering until
+// they are included in a block, so that the envelopes which were acknowledged
+// to the clients are submitted again after a restart instead of being lost.
+// Each envelope is stored in its own file, named after its arrival sequence
+// and its digest.
+type ReceiveQueue struct {
+	dir    string
+	logger *flogging.FabricLogger
+
+	mutex   sync.Mutex
+	nextSeq uint64
+	pending map[string]string // envelope digest to file name
+}
+
+// OpenReceiveQueue opens the receive queue stored in dir, creating it if needed.
+func OpenReceiveQueue(dir string, logger *flogging.FabricLogger) (*ReceiveQueue, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, errors.Wrapf(err, "failed to create receive queue directory %s", dir)
+	}
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read receive queue directory %s", dir)
+	}
+
+	q := &ReceiveQueue{
+		dir:     dir,
+		logger:  logger,
+		pending: map[string]string{},
+	}
+	for _, info := range infos {
+		name := info.Name()
+		if strings.HasSuffix(name, receiveQueueTmpSuffix) {
+			// The envelope was not acknowledged before the crash
+			os.Remove(filepath.Join(dir, name))
+			continue
+		}
+		var seq uint64
+		var digest string
+		if _, err := fmt.Sscanf(name, "%020d-%s", &seq, &digest); err != nil {
+			logger.Warningf("Ignoring unexpected file %s in receive queue directory %s", name, dir)
+			continue
+		}
+		q.pending[digest] = name
+		if seq >= q.nextSeq {
+			q.nextSeq = seq + 1
+		}
+	}
+	return q, nil
+}
+
+// Add persists the submit request of a normal envelope, and returns the key
+// to remove it with if it could not be submitted. The key is empty if the
+// envelope was already in the queue.
+func (q *ReceiveQueue) Add(req *orderer.SubmitRequest) (string, error) {
+	data, err := proto.Marshal(req)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal submit request")
+	}
+	digest := envelopeDigest(req.Content)
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if _, exists := q.pending[digest]; exists {
+		return "", nil
+	}
+	name := fmt.Sprintf("%020d-%s", q.nextSeq, digest)
+	if err := writeFileSync(filepath.Join(q.dir, name), data); err != nil {
+		return "", err
+	}
+	q.nextSeq++
+	q.pending[digest] = name
+	return digest, nil
+}
+
+// Remove removes the envelope with the given key from the queue.
+func (q *ReceiveQueue) Remove(key string) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.remove(key)
+}
+
+func (q *ReceiveQueue) remove(digest string) {
+	name, exists := q.pending[digest]
+	if !exists {
+		return
+	}
+	delete(q.pending, digest)
+	if err := os.Remove(filepath.Join(q.dir, name)); err != nil && !os.IsNotExist(err) {
+		q.logger.Warningf("Failed to remove %s from the receive queue: %s", name, err)
+	}
+}
+
+// Ordered removes the envelopes included in the block from the queue.
+func (q *ReceiveQueue) Ordered(block *common.Block) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if len(q.pending) == 0 || block.Data == nil {
+		return
+	}
+	for _, envBytes := range block.Data.Data {
+		env, err := utils.UnmarshalEnvelope(envBytes)
+		if err != nil {
+			continue
+		}
+		q.remove(envelopeDigest(env))
+	}
+}
+
+// Len returns the number of envelopes in the queue.
+func (q *ReceiveQueue) Len() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return len(q.pending)
+}
+
+// Pending returns the submit requests of the queue in their arrival order,
+// after dropping the requests received longer than retention ago.
+func (q *ReceiveQueue) Pending(retention time.Duration) []*orderer.SubmitRequest {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	var names []string
+	digests := map[string]string{}
+	for digest, name := range q.pending {
+		names = append(names, name)
+		digests[name] = digest
+	}
+	sort.Strings(names)
+
+	var reqs []*orderer.SubmitRequest
+	for _, name := range names {
+		path := filepath.Join(q.dir, name)
+		info, err := os.Stat(path)
+		if err == nil && time.Since(info.ModTime()) > retention {
+			q.logger.Warningf("Dropping envelope %s received more than %s ago from the receive queue", digests[name], retention)
+			q.remove(digests[name])
+			continue
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			q.logger.Warningf("Dropping unreadable envelope %s from the receive queue: %s", digests[name], err)
+			q.remove(digests[name])
+			continue
+		}
+		req := &orderer.SubmitRequest{}
+		if err := proto.Unmarshal(data, req); err != nil {
+			q.logger.Warningf("Dropping malformed envelope %s from the receive queue: %s", digests[name], err)
+			q.remove(digests[name])
+			continue
+		}
+		reqs = append(reqs, req)
+	}
+	return reqs
+}
+
+// envelopeDigest identifies an envelope independently of its marshaling.
+func envelopeDigest(env *common.Envelope) string {
+	return hex.EncodeToString(util.ComputeSHA256(util.ConcatenateBytes(env.GetPayload(), env.GetSignature())))
+}
+
+// writeFileSync writes the file atomically and durably, through a temporary
+// file renamed once synced.
+func writeFileSync(path string, data []byte) error {
+	tmp := path + receiveQueueTmpSuffix
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0640)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create %s", tmp)
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return errors.Wrapf(err, "failed to write %s", tmp)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return errors.Wrapf(err, "failed to rename %s", tmp)
+	}
+	return nil
+}
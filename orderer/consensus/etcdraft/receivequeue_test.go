@@ -0,0 +1,118 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package etcdraft
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func submitRequest(data string) *orderer.SubmitRequest {
+	return &orderer.SubmitRequest{
+		Channel:           "foo",
+		LastValidationSeq: 3,
+		Content:           &common.Envelope{Payload: []byte(data), Signature: []byte("signature")},
+	}
+}
+
+func TestReceiveQueue(t *testing.T) {
+	dir, err := ioutil.TempDir("", "receive-queue-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	logger := flogging.MustGetLogger("test")
+
+	q, err := OpenReceiveQueue(filepath.Join(dir, "foo"), logger)
+	assert.NoError(t, err)
+	reqs := []*orderer.SubmitRequest{submitRequest("a"), submitRequest("b"), submitRequest("c"), submitRequest("d")}
+	for _, req := range reqs {
+		key, err := q.Add(req)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, key)
+	}
+	key, err := q.Add(submitRequest("a"))
+	assert.NoError(t, err)
+	assert.Empty(t, key, "duplicate envelopes are not added again")
+	assert.Equal(t, 4, q.Len())
+
+	// The envelopes which could not be submitted are removed
+	q.Remove(envelopeDigest(reqs[3].Content))
+	// The envelopes included in a block are removed
+	block := common.NewBlock(1, nil)
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(reqs[1].Content), utils.MarshalOrPanic(submitRequest("e").Content)}
+	q.Ordered(block)
+	assert.Equal(t, 2, q.Len())
+
+	// An envelope being written when crashing is discarded
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "foo", "00000000000000000009-deadbeef.tmp"), []byte{1}, 0640))
+
+	q, err = OpenReceiveQueue(filepath.Join(dir, "foo"), logger)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, q.Len())
+	pending := q.Pending(time.Hour)
+	assert.Len(t, pending, 2)
+	assert.True(t, proto.Equal(reqs[0], pending[0]))
+	assert.True(t, proto.Equal(reqs[2], pending[1]))
+
+	// New envelopes are queued after the persisted ones
+	_, err = q.Add(submitRequest("f"))
+	assert.NoError(t, err)
+	pending = q.Pending(time.Hour)
+	assert.Len(t, pending, 3)
+	assert.Equal(t, []byte("f"), pending[2].Content.Payload)
+
+	files, err := ioutil.ReadDir(filepath.Join(dir, "foo"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 3)
+}
+
+func TestReceiveQueueRetention(t *testing.T) {
+	dir, err := ioutil.TempDir("", "receive-queue-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	q, err := OpenReceiveQueue(dir, flogging.MustGetLogger("test"))
+	assert.NoError(t, err)
+	_, err = q.Add(submitRequest("old"))
+	assert.NoError(t, err)
+	_, err = q.Add(submitRequest("new"))
+	assert.NoError(t, err)
+
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+	old := time.Now().Add(-2 * time.Hour)
+	assert.NoError(t, os.Chtimes(filepath.Join(dir, files[0].Name()), old, old))
+
+	pending := q.Pending(time.Hour)
+	assert.Len(t, pending, 1)
+	assert.Equal(t, []byte("new"), pending[0].Content.Payload)
+	assert.Equal(t, 1, q.Len())
+	files, err = ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+}
+
+func TestOpenReceiveQueueFailure(t *testing.T) {
+	f, err := ioutil.TempFile("", "receive-queue-")
+	assert.NoError(t, err)
+	f.Close()
+	defer os.Remove(f.Name())
+
+	_, err = OpenReceiveQueue(filepath.Join(f.Name(), "foo"), flogging.MustGetLogger("test"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to create receive queue directory")
+}
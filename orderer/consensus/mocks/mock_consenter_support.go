@@ -120,6 +120,15 @@ type FakeConsenterSupport struct {
 	sharedConfigReturnsOnCall map[int]struct {
 		result1 channelconfig.Orderer
 	}
+	ChannelConfigStub        func() channelconfig.Channel
+	channelConfigMutex       sync.RWMutex
+	channelConfigArgsForCall []struct{}
+	channelConfigReturns     struct {
+		result1 channelconfig.Channel
+	}
+	channelConfigReturnsOnCall map[int]struct {
+		result1 channelconfig.Channel
+	}
 	CreateNextBlockStub        func(messages []*cb.Envelope) *cb.Block
 	createNextBlockMutex       sync.RWMutex
 	createNextBlockArgsForCall []struct {
@@ -603,6 +612,8 @@ func (fake *FakeConsenterSupport) SharedConfig() channelconfig.Orderer {
 func (fake *FakeConsenterSupport) SharedConfigCallCount() int {
 	fake.sharedConfigMutex.RLock()
 	defer fake.sharedConfigMutex.RUnlock()
+	fake.channelConfigMutex.RLock()
+	defer fake.channelConfigMutex.RUnlock()
 	return len(fake.sharedConfigArgsForCall)
 }
 
@@ -613,6 +624,34 @@ func (fake *FakeConsenterSupport) SharedConfigReturns(result1 channelconfig.Orde
 	}{result1}
 }
 
+func (fake *FakeConsenterSupport) ChannelConfig() channelconfig.Channel {
+	fake.channelConfigMutex.Lock()
+	ret, specificReturn := fake.channelConfigReturnsOnCall[len(fake.channelConfigArgsForCall)]
+	fake.channelConfigArgsForCall = append(fake.channelConfigArgsForCall, struct{}{})
+	fake.recordInvocation("ChannelConfig", []interface{}{})
+	fake.channelConfigMutex.Unlock()
+	if fake.ChannelConfigStub != nil {
+		return fake.ChannelConfigStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.channelConfigReturns.result1
+}
+
+func (fake *FakeConsenterSupport) ChannelConfigCallCount() int {
+	fake.channelConfigMutex.RLock()
+	defer fake.channelConfigMutex.RUnlock()
+	return len(fake.channelConfigArgsForCall)
+}
+
+func (fake *FakeConsenterSupport) ChannelConfigReturns(result1 channelconfig.Channel) {
+	fake.ChannelConfigStub = nil
+	fake.channelConfigReturns = struct {
+		result1 channelconfig.Channel
+	}{result1}
+}
+
 func (fake *FakeConsenterSupport) SharedConfigReturnsOnCall(i int, result1 channelconfig.Orderer) {
 	fake.SharedConfigStub = nil
 	if fake.sharedConfigReturnsOnCall == nil {
@@ -927,6 +966,8 @@ func (fake *FakeConsenterSupport) Invocations() map[string][][]interface{} {
 	defer fake.blockCutterMutex.RUnlock()
 	fake.sharedConfigMutex.RLock()
 	defer fake.sharedConfigMutex.RUnlock()
+	fake.channelConfigMutex.RLock()
+	defer fake.channelConfigMutex.RUnlock()
 	fake.createNextBlockMutex.RLock()
 	defer fake.createNextBlockMutex.RUnlock()
 	fake.blockMutex.RLock()
@@ -16,6 +16,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/java"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/node"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/wasm"
 	"github.com/hyperledger/fabric/peer/common"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -37,6 +38,7 @@ var platformRegistry = platforms.NewRegistry(
 	&car.Platform{},
 	&java.Platform{},
 	&node.Platform{},
+	&wasm.Platform{},
 )
 
 func addFlags(cmd *cobra.Command) {
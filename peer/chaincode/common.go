@@ -108,7 +108,8 @@ func chaincodeInvokeOrQuery(cmd *cobra.Command, invoke bool, cf *ChaincodeCmdFac
 		cf.Certificate,
 		cf.EndorserClients,
 		cf.DeliverClients,
-		cf.BroadcastClient)
+		cf.BroadcastClient,
+		cf.HashingSuite)
 
 	if err != nil {
 		return errors.Errorf("%s - proposal response: %v", err, proposalResp)
@@ -338,6 +339,7 @@ type ChaincodeCmdFactory struct {
 	Certificate     tls.Certificate
 	Signer          msp.SigningIdentity
 	BroadcastClient common.BroadcastClient
+	HashingSuite    func([]byte) []byte
 }
 
 // InitCmdFactory init the ChaincodeCmdFactory with default clients
@@ -379,6 +381,16 @@ func InitCmdFactory(cmdName string, isEndorserRequired, isOrdererRequired bool)
 		return nil, errors.WithMessage(err, "error getting default signer")
 	}
 
+	// the transaction ids of the proposals are computed with the hashing
+	// suite of the channel
+	var hashingSuite func([]byte) []byte
+	if isEndorserRequired && channelID != "" {
+		hashingSuite, err = common.GetHashingSuiteOfChainFnc(channelID, signer, endorserClients[0])
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("error getting channel (%s) hashing suite", channelID))
+		}
+	}
+
 	var broadcastClient common.BroadcastClient
 	if isOrdererRequired {
 		if len(common.OrderingEndpoint) == 0 {
@@ -411,6 +423,7 @@ func InitCmdFactory(cmdName string, isEndorserRequired, isOrdererRequired bool)
 		Signer:          signer,
 		BroadcastClient: broadcastClient,
 		Certificate:     certificate,
+		HashingSuite:    hashingSuite,
 	}, nil
 }
 
@@ -433,6 +446,7 @@ func ChaincodeInvokeOrQuery(
 	endorserClients []pb.EndorserClient,
 	deliverClients []api.PeerDeliverClient,
 	bc common.BroadcastClient,
+	hashingSuite func([]byte) []byte,
 ) (*pb.ProposalResponse, error) {
 	// Build the ChaincodeInvocationSpec message
 	invocation := &pb.ChaincodeInvocationSpec{ChaincodeSpec: spec}
@@ -455,7 +469,7 @@ func ChaincodeInvokeOrQuery(
 		}
 	}
 
-	prop, txid, err := putils.CreateChaincodeProposalWithTxIDAndTransient(pcommon.HeaderType_ENDORSER_TRANSACTION, cID, invocation, creator, txID, tMap)
+	prop, txid, err := putils.CreateChaincodeProposalWithTxIDTransientAndHash(pcommon.HeaderType_ENDORSER_TRANSACTION, cID, invocation, creator, txID, tMap, hashingSuite)
 	if err != nil {
 		return nil, errors.WithMessage(err, fmt.Sprintf("error creating proposal for %s", funcName))
 	}
@@ -549,6 +549,7 @@ func TestChaincodeInvokeOrQuery_waitForEvent(t *testing.T) {
 		mockCF.EndorserClients,
 		mockCF.DeliverClients,
 		mockCF.BroadcastClient,
+		mockCF.HashingSuite,
 	)
 	assert.NoError(t, err)
 
@@ -572,6 +573,7 @@ func TestChaincodeInvokeOrQuery_waitForEvent(t *testing.T) {
 		mockCF.EndorserClients,
 		mockDeliverClients,
 		mockCF.BroadcastClient,
+		mockCF.HashingSuite,
 	)
 	assert.NoError(t, err)
 
@@ -590,6 +592,7 @@ func TestChaincodeInvokeOrQuery_waitForEvent(t *testing.T) {
 		mockCF.EndorserClients,
 		mockDeliverClients,
 		mockCF.BroadcastClient,
+		mockCF.HashingSuite,
 	)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "moist")
@@ -612,6 +615,7 @@ func TestChaincodeInvokeOrQuery_waitForEvent(t *testing.T) {
 		mockCF.EndorserClients,
 		mockDeliverClients,
 		mockCF.BroadcastClient,
+		mockCF.HashingSuite,
 	)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "timed out")
@@ -70,7 +70,7 @@ func instantiate(cmd *cobra.Command, cf *ChaincodeCmdFactory) (*protcommon.Envel
 		return nil, fmt.Errorf("error serializing identity for %s: %s", cf.Signer.GetIdentifier(), err)
 	}
 
-	prop, _, err := utils.CreateDeployProposalFromCDS(channelID, cds, creator, policyMarshalled, []byte(escc), []byte(vscc), collectionConfigBytes)
+	prop, _, err := utils.CreateDeployProposalFromCDSWithHash(channelID, cds, creator, policyMarshalled, []byte(escc), []byte(vscc), collectionConfigBytes, cf.HashingSuite)
 	if err != nil {
 		return nil, fmt.Errorf("error creating proposal  %s: %s", chainFuncName, err)
 	}
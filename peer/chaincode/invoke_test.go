@@ -60,6 +60,7 @@ func TestInvokeCmd(t *testing.T) {
 	t.Logf("Start error case 1: no orderer endpoints")
 	getEndorserClient := common.GetEndorserClientFnc
 	getOrdererEndpointOfChain := common.GetOrdererEndpointOfChainFnc
+	getHashingSuiteOfChain := common.GetHashingSuiteOfChainFnc
 	getBroadcastClient := common.GetBroadcastClientFnc
 	getDefaultSigner := common.GetDefaultSignerFnc
 	getDeliverClient := common.GetDeliverClientFnc
@@ -67,6 +68,7 @@ func TestInvokeCmd(t *testing.T) {
 	defer func() {
 		common.GetEndorserClientFnc = getEndorserClient
 		common.GetOrdererEndpointOfChainFnc = getOrdererEndpointOfChain
+		common.GetHashingSuiteOfChainFnc = getHashingSuiteOfChain
 		common.GetBroadcastClientFnc = getBroadcastClient
 		common.GetDefaultSignerFnc = getDefaultSigner
 		common.GetDeliverClientFnc = getDeliverClient
@@ -78,6 +80,9 @@ func TestInvokeCmd(t *testing.T) {
 	common.GetOrdererEndpointOfChainFnc = func(chainID string, signer msp.SigningIdentity, endorserClient pb.EndorserClient) ([]string, error) {
 		return []string{}, nil
 	}
+	common.GetHashingSuiteOfChainFnc = func(chainID string, signer msp.SigningIdentity, endorserClient pb.EndorserClient) (func([]byte) []byte, error) {
+		return util.ComputeSHA3256, nil
+	}
 	cmd = invokeCmd(nil)
 	addFlags(cmd)
 	args = []string{"-n", "example02", "-c", "{\"Args\": [\"invoke\",\"a\",\"b\",\"10\"]}", "-C", "mychannel"}
@@ -146,11 +151,22 @@ func TestInvokeCmd(t *testing.T) {
 	err = cmd.Execute()
 	assert.Error(t, err)
 
-	// Success case
-	t.Logf("Start success case")
+	// Error case 8: getHashingSuiteOfChainFnc returns error
+	t.Logf("Start error case 8: getHashingSuiteOfChainFnc returns error")
 	common.GetBroadcastClientFnc = func() (common.BroadcastClient, error) {
 		return mockCF.BroadcastClient, nil
 	}
+	common.GetHashingSuiteOfChainFnc = func(chainID string, signer msp.SigningIdentity, endorserClient pb.EndorserClient) (func([]byte) []byte, error) {
+		return nil, errors.New("error")
+	}
+	err = cmd.Execute()
+	assert.EqualError(t, err, "error getting channel (mychannel) hashing suite: error")
+
+	// Success case
+	t.Logf("Start success case")
+	common.GetHashingSuiteOfChainFnc = func(chainID string, signer msp.SigningIdentity, endorserClient pb.EndorserClient) (func([]byte) []byte, error) {
+		return util.ComputeSHA3256, nil
+	}
 	err = cmd.Execute()
 	assert.NoError(t, err)
 }
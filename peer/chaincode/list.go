@@ -76,7 +76,7 @@ func getChaincodes(cmd *cobra.Command, cf *ChaincodeCmdFactory) error {
 	if getInstalledChaincodes && (!getInstantiatedChaincodes) {
 		prop, _, err = utils.CreateGetInstalledChaincodesProposal(creator)
 	} else if getInstantiatedChaincodes && (!getInstalledChaincodes) {
-		prop, _, err = utils.CreateGetChaincodesProposal(channelID, creator)
+		prop, _, err = utils.CreateGetChaincodesProposalWithHash(channelID, creator, cf.HashingSuite)
 	} else {
 		return fmt.Errorf("Must explicitly specify \"--installed\" or \"--instantiated\"")
 	}
@@ -69,7 +69,7 @@ func upgrade(cmd *cobra.Command, cf *ChaincodeCmdFactory) (*protcommon.Envelope,
 		return nil, fmt.Errorf("error serializing identity for %s: %s", cf.Signer.GetIdentifier(), err)
 	}
 
-	prop, _, err := utils.CreateUpgradeProposalFromCDS(channelID, cds, creator, policyMarshalled, []byte(escc), []byte(vscc), collectionConfigBytes)
+	prop, _, err := utils.CreateUpgradeProposalFromCDSWithHash(channelID, cds, creator, policyMarshalled, []byte(escc), []byte(vscc), collectionConfigBytes, cf.HashingSuite)
 	if err != nil {
 		return nil, fmt.Errorf("error creating proposal %s: %s", chainFuncName, err)
 	}
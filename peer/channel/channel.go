@@ -34,12 +34,19 @@ const (
 var (
 	// join related variables.
 	genesisBlockPath string
+	channelRegistry  string
 
 	// create related variables
 	channelID     string
 	channelTxFile string
 	outputBlock   string
 	timeout       time.Duration
+
+	// updateanchors related variables
+	anchorPeers []string
+
+	// invalidation related variables
+	txID string
 )
 
 // Cmd returns the cobra command for Node
@@ -53,6 +60,8 @@ func Cmd(cf *ChannelCmdFactory) *cobra.Command {
 	channelCmd.AddCommand(updateCmd(cf))
 	channelCmd.AddCommand(signconfigtxCmd(cf))
 	channelCmd.AddCommand(getinfoCmd(cf))
+	channelCmd.AddCommand(updateAnchorsCmd(cf))
+	channelCmd.AddCommand(invalidationCmd(cf))
 
 	return channelCmd
 }
@@ -77,6 +86,9 @@ func resetFlags() {
 	flags.StringVarP(&channelTxFile, "file", "f", "", "Configuration transaction file generated by a tool such as configtxgen for submitting to orderer")
 	flags.StringVarP(&outputBlock, "outputBlock", "", common.UndefinedParamValue, `The path to write the genesis block for the channel. (default ./<channelID>.block)`)
 	flags.DurationVarP(&timeout, "timeout", "t", 5*time.Second, "Channel creation timeout")
+	flags.StringSliceVarP(&anchorPeers, "anchorPeers", "", nil, "Comma separated host:port endpoints of the anchor peers of the organization of the local MSP")
+	flags.StringVarP(&txID, "txid", "", "", "The ID of the transaction to get the invalidation reason of")
+	flags.StringVarP(&channelRegistry, "channelRegistry", "", "", "Directory of the channel registry shared by the ordering services, to verify that the genesis block is the one registered for its channel ID")
 }
 
 func attachFlags(cmd *cobra.Command, names []string) {
@@ -92,8 +104,8 @@ func attachFlags(cmd *cobra.Command, names []string) {
 
 var channelCmd = &cobra.Command{
 	Use:   "channel",
-	Short: "Operate a channel: create|fetch|join|list|update|signconfigtx|getinfo.",
-	Long:  "Operate a channel: create|fetch|join|list|update|signconfigtx|getinfo.",
+	Short: "Operate a channel: create|fetch|join|list|update|signconfigtx|getinfo|updateanchors|invalidation.",
+	Long:  "Operate a channel: create|fetch|join|list|update|signconfigtx|getinfo|updateanchors|invalidation.",
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		common.InitCmd(cmd, args)
 		common.SetOrdererEnv(cmd, args)
@@ -0,0 +1,114 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/scc/qscc"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func invalidationCmd(cf *ChannelCmdFactory) *cobra.Command {
+	invalidationCmd := &cobra.Command{
+		Use:   "invalidation",
+		Short: "get the reason a transaction was invalidated.",
+		Long:  "get the reason a transaction of a specified channel was invalidated. Requires '-c' and '--txid'.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return invalidation(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"txid",
+	}
+	attachFlags(invalidationCmd, flagList)
+
+	return invalidationCmd
+}
+
+func (cc *endorserClient) getInvalidationReason(txID string) (*pb.InvalidationReason, error) {
+	invocation := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]),
+			ChaincodeId: &pb.ChaincodeID{Name: "qscc"},
+			Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte(qscc.GetInvalidationReason), []byte(channelID), []byte(txID)}},
+		},
+	}
+
+	c, _ := cc.cf.Signer.Serialize()
+	prop, _, err := utils.CreateProposalFromCIS(cb.HeaderType_ENDORSER_TRANSACTION, "", invocation, c)
+	if err != nil {
+		return nil, errors.WithMessage(err, "cannot create proposal")
+	}
+
+	signedProp, err := utils.GetSignedProposal(prop, cc.cf.Signer)
+	if err != nil {
+		return nil, errors.WithMessage(err, "cannot create signed proposal")
+	}
+
+	proposalResp, err := cc.cf.EndorserClient.ProcessProposal(context.Background(), signedProp)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed sending proposal")
+	}
+
+	if proposalResp.Response == nil || proposalResp.Response.Status != 200 {
+		return nil, errors.Errorf("received bad response, status %d: %s", proposalResp.Response.Status, proposalResp.Response.Message)
+	}
+
+	reason := &pb.InvalidationReason{}
+	if err := proto.Unmarshal(proposalResp.Response.Payload, reason); err != nil {
+		return nil, errors.Wrap(err, "cannot read qscc response")
+	}
+
+	return reason, nil
+}
+
+func invalidation(cmd *cobra.Command, cf *ChannelCmdFactory) error {
+	//the global chainID filled by the "-c" command
+	if channelID == common.UndefinedParamValue {
+		return errors.New("Must supply channel ID")
+	}
+	if txID == "" {
+		return errors.New("Must supply transaction ID")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(EndorserRequired, PeerDeliverNotRequired, OrdererNotRequired)
+		if err != nil {
+			return err
+		}
+	}
+
+	client := &endorserClient{cf}
+
+	reason, err := client.getInvalidationReason(txID)
+	if err != nil {
+		return err
+	}
+	// the validation codes are printed by name
+	m := &jsonpb.Marshaler{Indent: "  "}
+	reasonJSON, err := m.MarshalToString(reason)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Invalidation reason: %s\n", reasonJSON)
+
+	return nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetInvalidationReason(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	mockReason := &pb.InvalidationReason{
+		TxId:           "txid",
+		BlockNumber:    4,
+		ValidationCode: pb.TxValidationCode_MVCC_READ_CONFLICT,
+		ReadConflicts:  []*pb.ReadConflict{{Namespace: "mycc", Key: "key1", WriterTxId: "othertxid"}},
+	}
+	mockPayload, err := proto.Marshal(mockReason)
+	assert.NoError(t, err)
+
+	mockResponse := &pb.ProposalResponse{
+		Response: &pb.Response{
+			Status:  200,
+			Payload: mockPayload,
+		},
+		Endorsement: &pb.Endorsement{},
+	}
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   common.GetMockEndorserClient(mockResponse, nil),
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+
+	cmd := invalidationCmd(mockCF)
+	AddFlags(cmd)
+
+	args := []string{"-c", mockChannel, "--txid", "txid"}
+	cmd.SetArgs(args)
+
+	assert.NoError(t, cmd.Execute())
+}
+
+func TestGetInvalidationReasonBadResponse(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	mockResponse := &pb.ProposalResponse{
+		Response: &pb.Response{
+			Status:  500,
+			Message: "Failed to get invalidation reason for txID txid",
+		},
+		Endorsement: &pb.Endorsement{},
+	}
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   common.GetMockEndorserClient(mockResponse, nil),
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+
+	cmd := invalidationCmd(mockCF)
+	AddFlags(cmd)
+
+	cmd.SetArgs([]string{"-c", mockChannel, "--txid", "txid"})
+
+	assert.EqualError(t, cmd.Execute(), "received bad response, status 500: Failed to get invalidation reason for txID txid")
+}
+
+func TestGetInvalidationReasonMissingArgs(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	if err != nil {
+		t.Fatalf("Get default signer error: %v", err)
+	}
+
+	mockCF := &ChannelCmdFactory{
+		Signer: signer,
+	}
+
+	cmd := invalidationCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--txid", "txid"})
+	assert.EqualError(t, cmd.Execute(), "Must supply channel ID")
+
+	resetFlags()
+	cmd = invalidationCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", mockChannel})
+	assert.EqualError(t, cmd.Execute(), "Must supply transaction ID")
+}
@@ -21,7 +21,9 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"os"
 
+	"github.com/hyperledger/fabric/common/channelregistry"
 	"github.com/hyperledger/fabric/core/scc/cscc"
 	"github.com/hyperledger/fabric/peer/common"
 	pcommon "github.com/hyperledger/fabric/protos/common"
@@ -44,6 +46,7 @@ func joinCmd(cf *ChannelCmdFactory) *cobra.Command {
 	}
 	flagList := []string{
 		"blockpath",
+		"channelRegistry",
 	}
 	attachFlags(joinCmd, flagList)
 
@@ -73,6 +76,11 @@ func getJoinCCSpec() (*pb.ChaincodeSpec, error) {
 	if err != nil {
 		return nil, GBFileNotFoundErr(err.Error())
 	}
+	if channelRegistry != "" {
+		if err := verifyChannelRegistration(gb); err != nil {
+			return nil, err
+		}
+	}
 	// Build the spec
 	input := &pb.ChaincodeInput{Args: [][]byte{[]byte(cscc.JoinChain), gb}}
 
@@ -85,6 +93,28 @@ func getJoinCCSpec() (*pb.ChaincodeSpec, error) {
 	return spec, nil
 }
 
+// verifyChannelRegistration fails if the channel ID of the genesis block is
+// registered in the channel registry with another genesis block, which means
+// that another ordering service created a channel with the same ID.
+func verifyChannelRegistration(gb []byte) error {
+	if _, err := os.Stat(channelRegistry); err != nil {
+		return fmt.Errorf("Error opening channel registry: %s", err)
+	}
+	block, err := putils.GetBlockFromBlockBytes(gb)
+	if err != nil {
+		return fmt.Errorf("Error unmarshaling genesis block: %s", err)
+	}
+	err = channelregistry.VerifyGenesisBlock(&channelregistry.DirRegistry{Dir: channelRegistry}, block)
+	if err == channelregistry.ErrNotRegistered {
+		logger.Warningf("The channel of the genesis block is not registered in the channel registry %s", channelRegistry)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error verifying channel registration: %s", err)
+	}
+	return nil
+}
+
 func executeJoin(cf *ChannelCmdFactory) (err error) {
 	spec, err := getJoinCCSpec()
 	if err != nil {
@@ -23,8 +23,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hyperledger/fabric/common/channelregistry"
 	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	putils "github.com/hyperledger/fabric/protos/utils"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -79,6 +82,68 @@ func TestJoin(t *testing.T) {
 	assert.NoError(t, cmd.Execute(), "expected join command to succeed")
 }
 
+func TestJoinChannelRegistry(t *testing.T) {
+	defer resetFlags()
+
+	InitMSP()
+	resetFlags()
+
+	dir, err := ioutil.TempDir("/tmp", "jointest")
+	assert.NoError(t, err, "Could not create the directory %s", dir)
+	defer os.RemoveAll(dir)
+
+	genesisBlock := func(channelID string, data string) *cb.Block {
+		block := cb.NewBlock(0, nil)
+		block.Data.Data = [][]byte{putils.MarshalOrPanic(&cb.Envelope{
+			Payload: putils.MarshalOrPanic(&cb.Payload{
+				Header: putils.MakePayloadHeader(putils.MakeChannelHeader(cb.HeaderType_CONFIG, 0, channelID, 0), putils.MakeSignatureHeader(nil, nil)),
+				Data:   []byte(data),
+			}),
+		})}
+		block.Header.DataHash = block.Data.Hash()
+		return block
+	}
+	registryDir := filepath.Join(dir, "registry")
+	registry, err := channelregistry.NewDirRegistry(registryDir)
+	assert.NoError(t, err)
+	entry, err := channelregistry.NewEntry("service1", "", genesisBlock("mychannel", "config"))
+	assert.NoError(t, err)
+	assert.NoError(t, registry.Register(entry))
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err, "Get default signer error: %v", err)
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200},
+		Endorsement: &pb.Endorsement{},
+	}
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   common.GetMockEndorserClient(mockResponse, nil),
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+
+	join := func(block *cb.Block, registryDir string) error {
+		blockFile := filepath.Join(dir, "mychannel.block")
+		err := ioutil.WriteFile(blockFile, putils.MarshalOrPanic(block), 0644)
+		assert.NoError(t, err, "Could not write to the file %s", blockFile)
+
+		cmd := joinCmd(mockCF)
+		AddFlags(cmd)
+		cmd.SetArgs([]string{"-b", blockFile, "--channelRegistry", registryDir})
+		return cmd.Execute()
+	}
+
+	assert.NoError(t, join(genesisBlock("mychannel", "config"), registryDir), "expected join of the registered channel to succeed")
+	assert.NoError(t, join(genesisBlock("otherchannel", "config"), registryDir), "expected join of an unregistered channel to succeed")
+
+	err = join(genesisBlock("mychannel", "other config"), registryDir)
+	assert.EqualError(t, err, "Error verifying channel registration: channel ID mychannel is already registered by ordering service service1 with another genesis block")
+
+	err = join(genesisBlock("mychannel", "config"), filepath.Join(dir, "missing"))
+	assert.Error(t, err, "expected join with a missing channel registry to fail")
+	assert.Contains(t, err.Error(), "Error opening channel registry")
+}
+
 func TestJoinNonExistentBlock(t *testing.T) {
 	defer resetFlags()
 
@@ -0,0 +1,149 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"net"
+	"strconv"
+
+	"github.com/hyperledger/fabric/common/configtx"
+	configupdate "github.com/hyperledger/fabric/common/tools/configtxlator/update"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func updateAnchorsCmd(cf *ChannelCmdFactory) *cobra.Command {
+	updateAnchorsCmd := &cobra.Command{
+		Use:   "updateanchors",
+		Short: "Set the anchor peers of the organization.",
+		Long:  "Computes the anchor peers update of the organization of the local MSP from the current config of the channel, then signs and sends it. Requires '-c', '-o', '--anchorPeers'.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateAnchors(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"anchorPeers",
+	}
+	attachFlags(updateAnchorsCmd, flagList)
+
+	return updateAnchorsCmd
+}
+
+func updateAnchors(cmd *cobra.Command, cf *ChannelCmdFactory) error {
+	if channelID == common.UndefinedParamValue {
+		return errors.New("Must supply channel ID")
+	}
+	anchors, err := parseAnchorPeers(anchorPeers)
+	if err != nil {
+		return err
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	if cf == nil {
+		cf, err = InitCmdFactory(EndorserNotRequired, PeerDeliverNotRequired, OrdererRequired)
+		if err != nil {
+			return err
+		}
+	}
+
+	config, err := currentConfig(cf.DeliverClient)
+	if err != nil {
+		return err
+	}
+	mspID := cf.Signer.GetIdentifier().Mspid
+	org, err := configupdate.ApplicationOrgByMSPID(config, mspID)
+	if err != nil {
+		return err
+	}
+	configUpdate, err := configupdate.AnchorPeers(channelID, config, org, anchors)
+	if err != nil {
+		return errors.WithMessage(err, "failed computing anchor peers update")
+	}
+	if configUpdate == nil {
+		logger.Infof("Anchor peers of organization %s are up to date", org)
+		return nil
+	}
+
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, channelID, nil, &cb.ConfigUpdateEnvelope{
+		ConfigUpdate: utils.MarshalOrPanic(configUpdate),
+	}, 0, 0)
+	if err != nil {
+		return err
+	}
+	sEnv, err := sanityCheckAndSignConfigTx(env)
+	if err != nil {
+		return err
+	}
+
+	broadcastClient, err := cf.BroadcastFactory()
+	if err != nil {
+		return errors.WithMessage(err, "error getting broadcast client")
+	}
+	defer broadcastClient.Close()
+	err = broadcastClient.Send(sEnv)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Successfully submitted anchor peers update of organization %s", org)
+	return nil
+}
+
+// currentConfig returns the config of the last config block of the channel.
+func currentConfig(deliverClient deliverClientIntf) (*cb.Config, error) {
+	newest, err := deliverClient.GetNewestBlock()
+	if err != nil {
+		return nil, err
+	}
+	lc, err := utils.GetLastConfigIndexFromBlock(newest)
+	if err != nil {
+		return nil, err
+	}
+	block, err := deliverClient.GetSpecifiedBlock(lc)
+	if err != nil {
+		return nil, err
+	}
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid config block")
+	}
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid config block")
+	}
+	configEnv, err := configtx.UnmarshalConfigEnvelope(payload.Data)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid config block")
+	}
+	return configEnv.Config, nil
+}
+
+// parseAnchorPeers parses host:port endpoints into anchor peers.
+func parseAnchorPeers(endpoints []string) ([]*pb.AnchorPeer, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("Must supply anchor peers")
+	}
+	var anchors []*pb.AnchorPeer
+	for _, endpoint := range endpoints {
+		host, port, err := net.SplitHostPort(endpoint)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid anchor peer %s", endpoint)
+		}
+		portNumber, err := strconv.ParseUint(port, 10, 16)
+		if err != nil {
+			return nil, errors.Errorf("invalid port of anchor peer %s", endpoint)
+		}
+		anchors = append(anchors, &pb.AnchorPeer{Host: host, Port: int32(portNumber)})
+	}
+	return anchors, nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
+	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// configDeliverClient delivers a config block as every block of the channel.
+type configDeliverClient struct {
+	mockDeliverClient
+	block *cb.Block
+}
+
+func (c *configDeliverClient) GetSpecifiedBlock(num uint64) (*cb.Block, error) {
+	return c.block, nil
+}
+
+func (c *configDeliverClient) GetNewestBlock() (*cb.Block, error) {
+	return c.block, nil
+}
+
+type recordingBroadcastClient struct {
+	envelopes []*cb.Envelope
+}
+
+func (r *recordingBroadcastClient) Send(env *cb.Envelope) error {
+	r.envelopes = append(r.envelopes, env)
+	return nil
+}
+
+func (r *recordingBroadcastClient) Close() error {
+	return nil
+}
+
+func TestUpdateAnchors(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	block, err := configtxtest.MakeGenesisBlock(mockChannel)
+	assert.NoError(t, err)
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+	broadcastClient := &recordingBroadcastClient{}
+	mockCF := &ChannelCmdFactory{
+		BroadcastFactory: func() (common.BroadcastClient, error) { return broadcastClient, nil },
+		Signer:           signer,
+		DeliverClient:    &configDeliverClient{block: block},
+	}
+
+	cmd := updateAnchorsCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", mockChannel, "-o", "localhost:7050", "--anchorPeers", "peer0.org1:7051,peer1.org1:8051"})
+	assert.NoError(t, cmd.Execute())
+
+	assert.Len(t, broadcastClient.envelopes, 1)
+	payload, err := utils.UnmarshalPayload(broadcastClient.envelopes[0].Payload)
+	assert.NoError(t, err)
+	configUpdateEnv, err := configtx.UnmarshalConfigUpdateEnvelope(payload.Data)
+	assert.NoError(t, err)
+	assert.Len(t, configUpdateEnv.Signatures, 1)
+	configUpdate, err := configtx.UnmarshalConfigUpdate(configUpdateEnv.ConfigUpdate)
+	assert.NoError(t, err)
+	assert.Equal(t, mockChannel, configUpdate.ChannelId)
+	value := configUpdate.WriteSet.Groups[channelconfig.ApplicationGroupKey].Groups["SampleOrg"].Values[channelconfig.AnchorPeersKey]
+	anchors := &pb.AnchorPeers{}
+	assert.NoError(t, proto.Unmarshal(value.Value, anchors))
+	assert.True(t, proto.Equal(&pb.AnchorPeers{AnchorPeers: []*pb.AnchorPeer{
+		{Host: "peer0.org1", Port: 7051},
+		{Host: "peer1.org1", Port: 8051},
+	}}, anchors))
+}
+
+func TestUpdateAnchorsInvalidAnchorPeers(t *testing.T) {
+	InitMSP()
+
+	for _, args := range [][]string{
+		{"-c", mockChannel, "-o", "localhost:7050"},
+		{"-c", mockChannel, "-o", "localhost:7050", "--anchorPeers", "peer0.org1"},
+		{"-c", mockChannel, "-o", "localhost:7050", "--anchorPeers", "peer0.org1:port"},
+		{"-o", "localhost:7050", "--anchorPeers", "peer0.org1:7051"},
+	} {
+		resetFlags()
+		cmd := updateAnchorsCmd(&ChannelCmdFactory{})
+		AddFlags(cmd)
+		cmd.SetArgs(args)
+		assert.Error(t, cmd.Execute(), "%v", args)
+	}
+}
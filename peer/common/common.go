@@ -74,6 +74,11 @@ var (
 	GetOrdererEndpointOfChainFnc func(chainID string, signer msp.SigningIdentity,
 		endorserClient pb.EndorserClient) ([]string, error)
 
+	// GetHashingSuiteOfChainFnc returns the hashing suite of given chain
+	// by default it is set to GetHashingSuiteOfChain function
+	GetHashingSuiteOfChainFnc func(chainID string, signer msp.SigningIdentity,
+		endorserClient pb.EndorserClient) (func([]byte) []byte, error)
+
 	// GetCertificateFnc is a function that returns the client TLS certificate
 	GetCertificateFnc func() (tls.Certificate, error)
 )
@@ -89,6 +94,7 @@ func init() {
 	GetDefaultSignerFnc = GetDefaultSigner
 	GetBroadcastClientFnc = GetBroadcastClient
 	GetOrdererEndpointOfChainFnc = GetOrdererEndpointOfChain
+	GetHashingSuiteOfChainFnc = GetHashingSuiteOfChain
 	GetDeliverClientFnc = GetDeliverClient
 	GetPeerDeliverClientFnc = GetPeerDeliverClient
 	GetCertificateFnc = GetCertificate
@@ -167,6 +173,41 @@ func GetDefaultSigner() (msp.SigningIdentity, error) {
 
 // GetOrdererEndpointOfChain returns orderer endpoints of given chain
 func GetOrdererEndpointOfChain(chainID string, signer msp.SigningIdentity, endorserClient pb.EndorserClient) ([]string, error) {
+	block, err := getConfigBlockOfChain(chainID, signer, endorserClient)
+	if err != nil {
+		return nil, err
+	}
+
+	envelopeConfig, err := putils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error extracting config block envelope")
+	}
+	bundle, err := channelconfig.NewBundleFromEnvelope(envelopeConfig)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error loading config block")
+	}
+
+	return bundle.ChannelConfig().OrdererAddresses(), nil
+}
+
+// GetHashingSuiteOfChain returns the algorithm computing the transaction ids
+// of given chain
+func GetHashingSuiteOfChain(chainID string, signer msp.SigningIdentity, endorserClient pb.EndorserClient) (func([]byte) []byte, error) {
+	block, err := getConfigBlockOfChain(chainID, signer, endorserClient)
+	if err != nil {
+		return nil, err
+	}
+
+	hashingSuite, err := channelconfig.HashingSuiteFromConfigBlock(block)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error loading config block")
+	}
+
+	return hashingSuite, nil
+}
+
+// getConfigBlockOfChain queries cscc for the config block of given chain
+func getConfigBlockOfChain(chainID string, signer msp.SigningIdentity, endorserClient pb.EndorserClient) (*pcommon.Block, error) {
 	// query cscc for chain config block
 	invocation := &pb.ChaincodeInvocationSpec{
 		ChaincodeSpec: &pb.ChaincodeSpec{
@@ -210,16 +251,7 @@ func GetOrdererEndpointOfChain(chainID string, signer msp.SigningIdentity, endor
 		return nil, errors.WithMessage(err, "error unmarshaling config block")
 	}
 
-	envelopeConfig, err := putils.ExtractEnvelope(block, 0)
-	if err != nil {
-		return nil, errors.WithMessage(err, "error extracting config block envelope")
-	}
-	bundle, err := channelconfig.NewBundleFromEnvelope(envelopeConfig)
-	if err != nil {
-		return nil, errors.WithMessage(err, "error loading config block")
-	}
-
-	return bundle.ChannelConfig().OrdererAddresses(), nil
+	return block, nil
 }
 
 // CheckLogLevel checks that a given log level string is valid
@@ -292,6 +324,9 @@ func InitCmd(cmd *cobra.Command, args []string) {
 
 	loggingSpec := os.Getenv("FABRIC_LOGGING_SPEC")
 	loggingFormat := os.Getenv("FABRIC_LOGGING_FORMAT")
+	if loggingFormat == "" {
+		loggingFormat = viper.GetString("peer.logging.format")
+	}
 
 	flogging.Init(flogging.Config{
 		Format:  loggingFormat,
@@ -41,6 +41,7 @@ type MSPMessageCryptoService struct {
 	channelPolicyManagerGetter policies.ChannelPolicyManagerGetter
 	localSigner                crypto.LocalSigner
 	deserializer               mgmt.DeserializersManager
+	hashingSuite               func(channelID string) func([]byte) []byte
 }
 
 // NewMCS creates a new instance of MSPMessageCryptoService
@@ -49,8 +50,10 @@ type MSPMessageCryptoService struct {
 // 1. a policies.ChannelPolicyManagerGetter that gives access to the policy manager of a given channel via the Manager method.
 // 2. an instance of crypto.LocalSigner
 // 3. an identity deserializer manager
-func NewMCS(channelPolicyManagerGetter policies.ChannelPolicyManagerGetter, localSigner crypto.LocalSigner, deserializer mgmt.DeserializersManager) *MSPMessageCryptoService {
-	return &MSPMessageCryptoService{channelPolicyManagerGetter: channelPolicyManagerGetter, localSigner: localSigner, deserializer: deserializer}
+// 4. a function returning the hashing suite of the channels, with which the data hashes of their blocks are verified.
+//    Without it, or for channels without a hashing suite, blocks are verified with SHA256.
+func NewMCS(channelPolicyManagerGetter policies.ChannelPolicyManagerGetter, localSigner crypto.LocalSigner, deserializer mgmt.DeserializersManager, hashingSuite func(channelID string) func([]byte) []byte) *MSPMessageCryptoService {
+	return &MSPMessageCryptoService{channelPolicyManagerGetter: channelPolicyManagerGetter, localSigner: localSigner, deserializer: deserializer, hashingSuite: hashingSuite}
 }
 
 // ValidateIdentity validates the identity of a remote peer.
@@ -145,7 +148,14 @@ func (s *MSPMessageCryptoService) VerifyBlock(chainID common.ChainID, seqNum uin
 
 	// - Verify that Header.DataHash is equal to the hash of block.Data
 	// This is to ensure that the header is consistent with the data carried by this block
-	if !bytes.Equal(block.Data.Hash(), block.Header.DataHash) {
+	// The data hash of genesis blocks is always computed with SHA256
+	dataHash := block.Data.Hash()
+	if s.hashingSuite != nil && block.Header.Number > 0 {
+		if hashingSuite := s.hashingSuite(channelID); hashingSuite != nil {
+			dataHash = block.Data.HashWith(hashingSuite)
+		}
+	}
+	if !bytes.Equal(dataHash, block.Header.DataHash) {
 		return fmt.Errorf("Header.DataHash is different from Hash(block.Data) for block with id [%d] on channel [%s]", block.Header.Number, chainID)
 	}
 
@@ -50,6 +50,7 @@ func TestPKIidOfCert(t *testing.T) {
 	msgCryptoService := NewMCS(&mocks.ChannelPolicyManagerGetterWithManager{},
 		&mockscrypto.LocalSigner{Identity: []byte("Alice")},
 		deserializersManager,
+		nil,
 	)
 
 	peerIdentity := []byte("Alice")
@@ -79,7 +80,7 @@ func TestPKIidOfCert(t *testing.T) {
 }
 
 func TestPKIidOfNil(t *testing.T) {
-	msgCryptoService := NewMCS(&mocks.ChannelPolicyManagerGetter{}, localmsp.NewSigner(), mgmt.NewDeserializersManager())
+	msgCryptoService := NewMCS(&mocks.ChannelPolicyManagerGetter{}, localmsp.NewSigner(), mgmt.NewDeserializersManager(), nil)
 
 	pkid := msgCryptoService.GetPKIidOfCert(nil)
 	// Check pkid is not nil
@@ -97,6 +98,7 @@ func TestValidateIdentity(t *testing.T) {
 		&mocks.ChannelPolicyManagerGetterWithManager{},
 		&mockscrypto.LocalSigner{Identity: []byte("Charlie")},
 		deserializersManager,
+		nil,
 	)
 
 	err := msgCryptoService.ValidateIdentity([]byte("Alice"))
@@ -128,6 +130,7 @@ func TestSign(t *testing.T) {
 		&mocks.ChannelPolicyManagerGetter{},
 		&mockscrypto.LocalSigner{Identity: []byte("Alice")},
 		mgmt.NewDeserializersManager(),
+		nil,
 	)
 
 	msg := []byte("Hello World!!!")
@@ -158,6 +161,7 @@ func TestVerify(t *testing.T) {
 				"C": &mocks.IdentityDeserializer{Identity: []byte("Dave"), Msg: []byte("msg4"), Mock: mock.Mock{}},
 			},
 		},
+		nil,
 	)
 
 	msg := []byte("msg1")
@@ -202,16 +206,18 @@ func TestVerifyBlock(t *testing.T) {
 		},
 	}
 
+	deserializersManager := &mocks.DeserializersManager{
+		LocalDeserializer: &mocks.IdentityDeserializer{Identity: []byte("Alice"), Msg: []byte("msg1"), Mock: mock.Mock{}},
+		ChannelDeserializers: map[string]msp.IdentityDeserializer{
+			"A": &mocks.IdentityDeserializer{Identity: []byte("Bob"), Msg: []byte("msg2"), Mock: mock.Mock{}},
+			"B": &mocks.IdentityDeserializer{Identity: []byte("Charlie"), Msg: []byte("msg3"), Mock: mock.Mock{}},
+		},
+	}
 	msgCryptoService := NewMCS(
 		policyManagerGetter,
 		aliceSigner,
-		&mocks.DeserializersManager{
-			LocalDeserializer: &mocks.IdentityDeserializer{Identity: []byte("Alice"), Msg: []byte("msg1"), Mock: mock.Mock{}},
-			ChannelDeserializers: map[string]msp.IdentityDeserializer{
-				"A": &mocks.IdentityDeserializer{Identity: []byte("Bob"), Msg: []byte("msg2"), Mock: mock.Mock{}},
-				"B": &mocks.IdentityDeserializer{Identity: []byte("Charlie"), Msg: []byte("msg3"), Mock: mock.Mock{}},
-			},
-		},
+		deserializersManager,
+		nil,
 	)
 
 	// - Prepare testing valid block, Alice signs it.
@@ -243,6 +249,29 @@ func TestVerifyBlock(t *testing.T) {
 	// Check invalid args
 	assert.Error(t, msgCryptoService.VerifyBlock([]byte("C"), 42, []byte{0, 1, 2, 3, 4}))
 	assert.Error(t, msgCryptoService.VerifyBlock([]byte("C"), 42, nil))
+
+	// - Verify the data hashes of the blocks with the hashing suite of the channel
+	msgCryptoService = NewMCS(
+		policyManagerGetter,
+		aliceSigner,
+		deserializersManager,
+		func(channelID string) func([]byte) []byte {
+			if channelID != "C" {
+				return nil
+			}
+			return func([]byte) []byte { return []byte{0} }
+		},
+	)
+	assert.NoError(t, msgCryptoService.VerifyBlock([]byte("C"), 42, blockRaw))
+	blockRaw, msg = mockBlock(t, "C", 42, aliceSigner, nil)
+	policyManagerGetter.Managers["C"].(*mocks.ChannelPolicyManager).Policy.(*mocks.Policy).Deserializer.(*mocks.IdentityDeserializer).Msg = msg
+	err = msgCryptoService.VerifyBlock([]byte("C"), 42, blockRaw)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Header.DataHash is different from Hash(block.Data)")
+	// the data hashes of genesis blocks are computed with SHA256
+	blockRaw, msg = mockBlock(t, "C", 0, aliceSigner, nil)
+	policyManagerGetter.Managers["C"].(*mocks.ChannelPolicyManager).Policy.(*mocks.Policy).Deserializer.(*mocks.IdentityDeserializer).Msg = msg
+	assert.NoError(t, msgCryptoService.VerifyBlock([]byte("C"), 0, blockRaw))
 }
 
 func mockBlock(t *testing.T, channel string, seqNum uint64, localSigner crypto.LocalSigner, dataHash []byte) ([]byte, []byte) {
@@ -329,6 +358,7 @@ func TestExpiration(t *testing.T) {
 		&mocks.ChannelPolicyManagerGetterWithManager{},
 		&mockscrypto.LocalSigner{Identity: []byte("Yacov")},
 		deserializersManager,
+		nil,
 	)
 
 	// Green path I check the expiration date is as expected
@@ -0,0 +1,136 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	operationsAddress  string
+	operationsCAFile   string
+	operationsCertFile string
+	operationsKeyFile  string
+	membershipChannel  string
+)
+
+func membershipCmd() *cobra.Command {
+	flags := nodeMembershipCmd.Flags()
+	flags.StringVarP(&membershipChannel, "channelID", "c", "",
+		"Only show the membership of the given channel")
+	flags.StringVarP(&operationsAddress, "address", "", "",
+		"The address of the operations endpoint of the peer, defaults to operations.listenAddress")
+	flags.StringVarP(&operationsCAFile, "cafile", "", "",
+		"Path to the PEM encoded CA certificate of the operations endpoint, enables TLS")
+	flags.StringVarP(&operationsCertFile, "certfile", "", "",
+		"Path to the PEM encoded client certificate for the operations endpoint")
+	flags.StringVarP(&operationsKeyFile, "keyfile", "", "",
+		"Path to the PEM encoded client key for the operations endpoint")
+
+	return nodeMembershipCmd
+}
+
+var nodeMembershipCmd = &cobra.Command{
+	Use:   "membership",
+	Short: "Returns the gossip membership of the node.",
+	Long:  `Returns the alive and dead peers, the leadership status and the ledger heights of the channels of the running node, as seen by gossip.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected: %s", args)
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		return membership()
+	},
+}
+
+func membership() error {
+	address := operationsAddress
+	if address == "" {
+		address = viper.GetString("operations.listenAddress")
+	}
+	client, scheme, err := operationsClient()
+	if err != nil {
+		return err
+	}
+
+	target := &url.URL{Scheme: scheme, Host: address, Path: "/gossip/membership"}
+	if membershipChannel != "" {
+		target.RawQuery = url.Values{"channel": []string{membershipChannel}}.Encode()
+	}
+	resp, err := client.Get(target.String())
+	if err != nil {
+		return errors.WithMessage(err, "failed querying the operations endpoint of the peer")
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed reading the gossip membership")
+	}
+	if resp.StatusCode != http.StatusOK {
+		errResp := struct {
+			Error string `json:"error"`
+		}{}
+		if json.Unmarshal(body, &errResp) == nil && errResp.Error != "" {
+			return errors.Errorf("failed getting the gossip membership: %s", errResp.Error)
+		}
+		return errors.Errorf("failed getting the gossip membership: %s", resp.Status)
+	}
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, body, "", "  "); err != nil {
+		return errors.Wrap(err, "invalid gossip membership")
+	}
+	fmt.Print(out.String())
+	return nil
+}
+
+// operationsClient returns the HTTP client connecting to the operations
+// endpoint and the scheme of its URLs. TLS is used when a CA certificate is
+// passed or operations.tls.enabled is set.
+func operationsClient() (*http.Client, string, error) {
+	caFile := operationsCAFile
+	if caFile == "" && viper.GetBool("operations.tls.enabled") {
+		caFile = viper.GetString("operations.tls.cert.file")
+	}
+	if caFile == "" {
+		return &http.Client{Timeout: 10 * time.Second}, "http", nil
+	}
+
+	caPEM, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "failed reading CA certificate %s", caFile)
+	}
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(caPEM) {
+		return nil, "", errors.Errorf("no certificate found in %s", caFile)
+	}
+	tlsConfig := &tls.Config{RootCAs: certPool}
+	if operationsCertFile != "" || operationsKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(operationsCertFile, operationsKeyFile)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed loading client certificate")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}, "https", nil
+}
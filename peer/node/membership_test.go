@@ -0,0 +1,51 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMembershipCmd(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		query = req.URL.RawQuery
+		if req.URL.Path != "/gossip/membership" {
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if req.URL.Query().Get("channel") == "unknown" {
+			resp.WriteHeader(http.StatusNotFound)
+			resp.Write([]byte(`{"error":"channel unknown not found"}`))
+			return
+		}
+		resp.Write([]byte(`{"self":{"endpoint":"peer0:7051","pkiId":"0a"},"alive":[],"dead":[],"channels":{}}`))
+	}))
+	defer server.Close()
+	defer func() { operationsAddress, membershipChannel = "", "" }()
+
+	cmd := membershipCmd()
+	cmd.SetArgs([]string{"--address", strings.TrimPrefix(server.URL, "http://"), "-c", "mychannel"})
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "channel=mychannel", query)
+
+	cmd.SetArgs([]string{"--address", strings.TrimPrefix(server.URL, "http://"), "-c", "unknown"})
+	assert.EqualError(t, cmd.Execute(), "failed getting the gossip membership: channel unknown not found")
+
+	cmd.SetArgs([]string{"--address", strings.TrimPrefix(server.URL, "http://"), "--cafile", "nonexistent.pem"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed reading CA certificate nonexistent.pem")
+
+	cmd.SetArgs([]string{"extra"})
+	assert.EqualError(t, cmd.Execute(), "trailing args detected: [extra]")
+}
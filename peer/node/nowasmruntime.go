@@ -0,0 +1,25 @@
+// +build !wasmruntime
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"github.com/hyperledger/fabric/core/chaincode/platforms"
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/inproccontroller"
+)
+
+// Peers built without the wasmruntime build tag do not run WebAssembly
+// chaincode, and reject its deployment as a chaincode of an unknown type.
+func wasmPlatforms() []platforms.Platform {
+	return nil
+}
+
+func wasmVMProviders(ipRegistry *inproccontroller.Registry) map[string]container.VMProvider {
+	return nil
+}
@@ -0,0 +1,154 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/comm"
+	coreconfig "github.com/hyperledger/fabric/core/config"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/hyperledger/fabric/core/peerblocks"
+	"github.com/hyperledger/fabric/core/standby"
+	"github.com/hyperledger/fabric/msp/mgmt"
+	peergossip "github.com/hyperledger/fabric/peer/gossip"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// newStandby returns the standby configured in peer.standby, replicating the
+// configured channels from the deliver service of the primary peer.
+func newStandby() (*standby.Standby, error) {
+	address := viper.GetString("peer.standby.primary.address")
+	if address == "" {
+		return nil, errors.New("peer.standby.primary.address is required")
+	}
+	channels := viper.GetStringSlice("peer.standby.channels")
+	if len(channels) == 0 {
+		return nil, errors.New("peer.standby.channels is required")
+	}
+
+	clientConfig, err := standbyClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	client, err := comm.NewGRPCClient(clientConfig)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create client for primary peer")
+	}
+	var tlsCertHash []byte
+	if client.MutualTLSRequired() {
+		tlsCertHash = util.ComputeSHA256(client.Certificate().Certificate[0])
+	}
+	serverNameOverride := viper.GetString("peer.standby.primary.tls.serverhostoverride")
+
+	ledgerIDs, err := ledgermgmt.GetLedgerIDs()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to list ledgers")
+	}
+	existing := map[string]bool{}
+	for _, id := range ledgerIDs {
+		existing[id] = true
+	}
+
+	var replicators []*standby.Replicator
+	for _, channel := range channels {
+		replicator := &standby.Replicator{
+			ChannelID: channel,
+			Blocks: &peerblocks.Provider{
+				Channel:     channel,
+				Signer:      localmsp.NewSigner(),
+				TLSCertHash: tlsCertHash,
+				Connect: func(ctx context.Context) (peerblocks.DeliverStream, error) {
+					conn, err := client.NewConnection(address, serverNameOverride)
+					if err != nil {
+						return nil, err
+					}
+					go func() {
+						<-ctx.Done()
+						conn.Close()
+					}()
+					return pb.NewDeliverClient(conn).Deliver(ctx)
+				},
+			},
+			CreateLedger: func(genesisBlock *cb.Block) (standby.Ledger, error) {
+				return ledgermgmt.CreateLedger(genesisBlock)
+			},
+		}
+		// the blocks are verified like gossip verifies them, with the
+		// channel config tracked by the replicator
+		replicator.Verifier = peergossip.NewMCS(replicator, localmsp.NewSigner(), mgmt.NewDeserializersManager(), replicator.HashingSuite)
+		if existing[channel] {
+			l, err := ledgermgmt.OpenLedger(channel)
+			if err != nil {
+				return nil, errors.WithMessage(err, fmt.Sprintf("failed to open ledger for channel %s", channel))
+			}
+			replicator.Ledger = l
+		}
+		replicators = append(replicators, replicator)
+	}
+
+	retryInterval := viper.GetDuration("peer.standby.retryInterval")
+	if retryInterval == 0 {
+		retryInterval = 10 * time.Second
+	}
+	return &standby.Standby{
+		Replicators:   replicators,
+		RetryInterval: retryInterval,
+	}, nil
+}
+
+// standbyClientConfig returns the configuration of the client connecting to
+// the primary peer. When TLS is enabled, the primary is trusted with
+// peer.standby.primary.tls.rootcert.file and the client authenticates with the
+// TLS client keypair of the peer.
+func standbyClientConfig() (comm.ClientConfig, error) {
+	clientConfig := comm.ClientConfig{
+		Timeout: viper.GetDuration("peer.client.connTimeout"),
+		SecOpts: &comm.SecureOptions{
+			UseTLS:            viper.GetBool("peer.tls.enabled"),
+			RequireClientCert: viper.GetBool("peer.tls.clientAuthRequired"),
+		},
+	}
+	if clientConfig.Timeout == 0 {
+		clientConfig.Timeout = 3 * time.Second
+	}
+	if !clientConfig.SecOpts.UseTLS {
+		return clientConfig, nil
+	}
+
+	rootCert, err := ioutil.ReadFile(coreconfig.GetPath("peer.standby.primary.tls.rootcert.file"))
+	if err != nil {
+		return clientConfig, errors.Wrap(err, "failed to load peer.standby.primary.tls.rootcert.file")
+	}
+	clientConfig.SecOpts.ServerRootCAs = [][]byte{rootCert}
+
+	if clientConfig.SecOpts.RequireClientCert {
+		keyKey, certKey := "peer.tls.clientKey.file", "peer.tls.clientCert.file"
+		if viper.GetString(keyKey) == "" {
+			keyKey, certKey = "peer.tls.key.file", "peer.tls.cert.file"
+		}
+		key, err := ioutil.ReadFile(coreconfig.GetPath(keyKey))
+		if err != nil {
+			return clientConfig, errors.Wrapf(err, "failed to load %s", keyKey)
+		}
+		cert, err := ioutil.ReadFile(coreconfig.GetPath(certKey))
+		if err != nil {
+			return clientConfig, errors.Wrapf(err, "failed to load %s", certKey)
+		}
+		clientConfig.SecOpts.Key = key
+		clientConfig.SecOpts.Certificate = cert
+	}
+	return clientConfig, nil
+}
@@ -7,11 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package node
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
@@ -20,6 +22,7 @@ import (
 	ccdef "github.com/hyperledger/fabric/common/chaincode"
 	"github.com/hyperledger/fabric/common/crypto/tlsgen"
 	"github.com/hyperledger/fabric/common/deliver"
+	"github.com/hyperledger/fabric/common/diag"
 	"github.com/hyperledger/fabric/common/flogging"
 	floggingmetrics "github.com/hyperledger/fabric/common/flogging/metrics"
 	"github.com/hyperledger/fabric/common/grpclogging"
@@ -46,11 +49,14 @@ import (
 	"github.com/hyperledger/fabric/core/committer/txvalidator"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/privdata"
+	coreconfig "github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/core/container"
 	"github.com/hyperledger/fabric/core/container/dockercontroller"
 	"github.com/hyperledger/fabric/core/container/inproccontroller"
+	"github.com/hyperledger/fabric/core/deliverservice"
 	"github.com/hyperledger/fabric/core/endorser"
 	authHandler "github.com/hyperledger/fabric/core/handlers/auth"
+	"github.com/hyperledger/fabric/core/handlers/auth/filter"
 	endorsement2 "github.com/hyperledger/fabric/core/handlers/endorsement/api"
 	endorsement3 "github.com/hyperledger/fabric/core/handlers/endorsement/api/identities"
 	"github.com/hyperledger/fabric/core/handlers/library"
@@ -73,6 +79,7 @@ import (
 	gossipcommon "github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/service"
 	"github.com/hyperledger/fabric/msp"
+	mspcache "github.com/hyperledger/fabric/msp/cache"
 	"github.com/hyperledger/fabric/msp/mgmt"
 	peergossip "github.com/hyperledger/fabric/peer/gossip"
 	"github.com/hyperledger/fabric/peer/version"
@@ -83,8 +90,15 @@ import (
 	"github.com/hyperledger/fabric/protos/token"
 	"github.com/hyperledger/fabric/protos/transientstore"
 	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/hyperledger/fabric/token/deadletter"
+	"github.com/hyperledger/fabric/token/exporter"
+	tokenidentity "github.com/hyperledger/fabric/token/identity"
+	tokenledger "github.com/hyperledger/fabric/token/ledger"
 	"github.com/hyperledger/fabric/token/server"
+	tokendriver "github.com/hyperledger/fabric/token/tms/driver"
+	"github.com/hyperledger/fabric/token/tms/manager"
 	"github.com/pkg/errors"
+	"github.com/spf13/cast"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
@@ -147,12 +161,12 @@ func serve(args []string) error {
 		aclmgmt.ResourceGetter(peer.GetStableChannelConfig),
 	)
 
-	pr := platforms.NewRegistry(
+	pr := platforms.NewRegistry(append([]platforms.Platform{
 		&golang.Platform{},
 		&node.Platform{},
 		&java.Platform{},
 		&car.Platform{},
-	)
+	}, wasmPlatforms()...)...)
 
 	deployedCCInfoProvider := &lscc.DeployedCCInfoProvider{}
 
@@ -161,6 +175,12 @@ func serve(args []string) error {
 	}
 
 	opsSystem := newOperationsSystem()
+	opsSystem.RegisterHandler("/token/stats", newTokenStatsHandler())
+	opsSystem.RegisterHandler("/token/deadletters", newTokenDeadlettersHandler())
+	opsSystem.RegisterHandler("/channels/readiness", &peer.ReadinessHandler{
+		Readiness: peer.GetChannelsReadiness,
+		Logger:    flogging.MustGetLogger("peer.readiness"),
+	})
 	err := opsSystem.Start()
 	if err != nil {
 		return errors.WithMessage(err, "failed to initialize operations subystems")
@@ -168,8 +188,22 @@ func serve(args []string) error {
 	defer opsSystem.Stop()
 
 	metricsProvider := opsSystem.Provider
+	if viper.GetBool("peer.profile.capture.enabled") {
+		capturer, thresholds, err := newProfileCapturer()
+		if err != nil {
+			return err
+		}
+		opsSystem.RegisterHandler("/debug/profiles/", http.StripPrefix("/debug/profiles", capturer))
+		metricsProvider = &diag.ThresholdProvider{
+			Provider:   metricsProvider,
+			Thresholds: thresholds,
+			Trigger:    func(reason string) { capturer.Trigger(reason) },
+		}
+	}
 	logObserver := floggingmetrics.NewObserver(metricsProvider)
 	flogging.Global.SetObserver(logObserver)
+	mspcache.Configure(mspcache.Options{CacheSize: viper.GetInt("peer.mspCacheSize"), MetricsProvider: metricsProvider})
+	service.ConfigureMetrics(metricsProvider)
 
 	membershipInfoProvider := privdata.NewMembershipInfoProvider(createSelfSignedData(), identityDeserializerFactory)
 	//initialize resource management exit
@@ -197,6 +231,19 @@ func serve(args []string) error {
 		return err
 	}
 
+	// A standby peer only replicates the ledgers of the primary until it is
+	// promoted, and then starts like any other peer with the replicated ledgers.
+	if viper.GetBool("peer.standby.enabled") {
+		sb, err := newStandby()
+		if err != nil {
+			return errors.WithMessage(err, "failed to initialize standby")
+		}
+		opsSystem.RegisterHandler("/standby", sb)
+		logger.Info("Running as a warm standby peer until promoted")
+		sb.Run()
+		logger.Info("Standby peer promoted, starting peer")
+	}
+
 	peerEndpoint, err := peer.GetPeerEndpoint()
 	if err != nil {
 		err = fmt.Errorf("Failed to get Peer Endpoint: %s", err)
@@ -260,7 +307,7 @@ func serve(args []string) error {
 		}
 	}
 
-	abServer := peer.NewDeliverEventsServer(mutualTLS, policyCheckerProvider, &peer.DeliverChainManager{}, metricsProvider)
+	abServer := peer.NewDeliverEventsServer(mutualTLS, policyCheckerProvider, &peer.DeliverChainManager{}, &peer.DeliverPrivateDataProvider{}, metricsProvider)
 	pb.RegisterDeliverServer(peerServer.Server(), abServer)
 
 	// Initialize chaincode service
@@ -288,6 +335,12 @@ func serve(args []string) error {
 	reg := library.InitRegistry(libConf)
 
 	authFilters := reg.Lookup(library.Auth).([]authHandler.Filter)
+	if viper.GetBool("peer.queryOnly") {
+		// query-only peers only serve the proposals of the configuration and
+		// query system chaincodes, so that they can still join channels
+		logger.Info("Starting query-only peer, proposals to application chaincodes will not be endorsed")
+		authFilters = append(authFilters, filter.NewQueryOnlyFilter("cscc", "qscc"))
+	}
 	endorserSupport := &endorser.SupportImpl{
 		SignerSupport:    signingIdentity,
 		Peer:             peer.Default,
@@ -309,6 +362,9 @@ func serve(args []string) error {
 	})
 	endorserSupport.PluginEndorser = pluginEndorser
 	serverEndorser := endorser.NewEndorserServer(privDataDist, endorserSupport, pr, metricsProvider)
+	if advisor := peer.GetConflictAdvisor(); advisor != nil {
+		serverEndorser.ConflictAdvisor = advisor
+	}
 	auth := authHandler.ChainFilters(serverEndorser, authFilters...)
 	// Register the Endorser server
 	pb.RegisterEndorserServer(peerServer.Server(), auth)
@@ -321,14 +377,29 @@ func serve(args []string) error {
 		return err
 	}
 	defer service.GetGossipService().Stop()
+	opsSystem.RegisterHandler("/gossip/membership", &service.MembershipHandler{
+		Membership: service.GetGossipService().Membership,
+		Logger:     flogging.MustGetLogger("gossip.service.membership"),
+	})
 
 	// register prover grpc service
 	// FAB-12971 disable prover service before v1.4 cut. Will uncomment after v1.4 cut
-	// err = registerProverService(peerServer, aclProvider, signingIdentity)
+	var prover *server.Prover
+	// prover, err = registerProverService(peerServer, aclProvider, signingIdentity, metricsProvider)
+	// if err != nil {
+	// 	return err
+	// }
+	// err = opsSystem.RegisterChecker("prover", prover)
 	// if err != nil {
 	// 	return err
 	// }
 
+	// register the token gateway grpc service
+	var tokenGateway *server.Gateway
+	if viper.GetBool("peer.tokenGateway.enabled") {
+		tokenGateway = registerTokenGateway(peerServer, aclProvider, signingIdentity)
+	}
+
 	// initialize system chaincodes
 
 	// deploy system chaincodes
@@ -347,10 +418,29 @@ func serve(args []string) error {
 	})
 	lifecycle.AddListener(onUpdate)
 
+	tokenExporter, err := newTokenExporter()
+	if err != nil {
+		return err
+	}
+	// exporterDone stops the token exporters on shutdown
+	exporterDone := make(chan struct{})
+
+	if err := loadTokenDrivers(); err != nil {
+		return err
+	}
+
 	// this brings up all the channels
 	peer.Initialize(func(cid string) {
 		logger.Debugf("Deploying system CC, for channel <%s>", cid)
 		sccp.DeploySysCCs(cid, ccp)
+		if tokenExporter != nil {
+			go func() {
+				retryInterval := viper.GetDuration("peer.tokenExporter.retryInterval")
+				if err := tokenExporter.Follow(cid, peer.GetLedger(cid), retryInterval, exporterDone); err != nil {
+					logger.Errorf("Token exporter for channel %s stopped: %s", cid, err)
+				}
+			}()
+		}
 		sub, err := lifecycle.NewChannelSubscription(cid, cc.QueryCreatorFunc(func() (cc.Query, error) {
 			return peer.GetLedger(cid).NewQueryExecutor()
 		}))
@@ -405,8 +495,36 @@ func serve(args []string) error {
 
 	logger.Infof("Started peer with ID=[%s], network ID=[%s], address=[%s]", peerEndpoint.Id, networkID, peerEndpoint.Address)
 
-	// Block until grpc server exits
-	return <-serve
+	// Block until grpc server exits or the peer is signaled to stop
+	serveErr := <-serve
+	shutdown(peerServer, prover, tokenGateway, exporterDone)
+	return serveErr
+}
+
+// shutdown stops the peer in an order that never interrupts the commit of a
+// block, so that the ledgers and the indexes derived from them, such as the
+// spent filters of the token namespaces, do not need to be recovered when the
+// peer restarts:
+//  1. the prover, the token gateway and the gRPC server, which serves the
+//     deliver and endorsement requests, stop accepting requests once the
+//     in-flight ones complete;
+//  2. the commit of blocks stops, once the block being committed to the
+//     ledger of each channel is committed, and the ledgers are closed;
+//  3. the indexes are flushed at the height of the ledgers and the stores of
+//     the ledgers are closed.
+func shutdown(peerServer *comm.GRPCServer, prover *server.Prover, tokenGateway *server.Gateway, exporterDone chan struct{}) {
+	logger.Info("Shutting down peer")
+	drainProver(prover)
+	drainTokenGateway(tokenGateway)
+	peerServer.Stop()
+
+	close(exporterDone)
+	service.GetGossipService().Stop()
+	logger.Info("Stopped committing blocks")
+
+	flushProver(prover)
+	ledgermgmt.Close()
+	logger.Info("Peer shut down")
 }
 
 func handleSignals(handlers map[os.Signal]func()) {
@@ -661,6 +779,14 @@ func registerChaincodeSupport(
 		logger.Panicf("failed to register docker health check: %s", err)
 	}
 
+	vmProviders := map[string]container.VMProvider{
+		dockercontroller.ContainerType: dockerProvider,
+		inproccontroller.ContainerType: ipRegistry,
+	}
+	for containerType, vmProvider := range wasmVMProviders(ipRegistry) {
+		vmProviders[containerType] = vmProvider
+	}
+
 	chaincodeSupport := chaincode.NewChaincodeSupport(
 		chaincode.GlobalConfig(),
 		ccEndpoint,
@@ -670,12 +796,7 @@ func registerChaincodeSupport(
 		packageProvider,
 		lsccInst,
 		aclProvider,
-		container.NewVMController(
-			map[string]container.VMProvider{
-				dockercontroller.ContainerType: dockerProvider,
-				inproccontroller.ContainerType: ipRegistry,
-			},
-		),
+		container.NewVMController(vmProviders),
 		sccp,
 		pr,
 		peer.DefaultSupport,
@@ -866,6 +987,7 @@ func initGossipService(policyMgr policies.ChannelPolicyManagerGetter, peerServer
 		policyMgr,
 		localmsp.NewSigner(),
 		mgmt.NewDeserializersManager(),
+		peer.GetHashingSuite,
 	)
 	secAdv := peergossip.NewSecurityAdvisor(mgmt.NewDeserializersManager())
 	bootstrap := viper.GetStringSlice("peer.gossip.bootstrap")
@@ -906,20 +1028,98 @@ func newOperationsSystem() *operations.System {
 	})
 }
 
-func registerProverService(peerServer *comm.GRPCServer, aclProvider aclmgmt.ACLProvider, signingIdentity msp.SigningIdentity) error {
+// newTokenExporter returns the exporter configured in peer.tokenExporter,
+// or nil if the exporter is disabled.
+func newTokenExporter() (*exporter.Exporter, error) {
+	if !viper.GetBool("peer.tokenExporter.enabled") {
+		return nil, nil
+	}
+
+	encoding, err := exporter.EncodingByName(viper.GetString("peer.tokenExporter.format"))
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create token exporter")
+	}
+
+	var sink exporter.Sink
+	switch sinkType := viper.GetString("peer.tokenExporter.sink"); sinkType {
+	case "kafka":
+		kafkaSink, err := exporter.NewKafkaSink(
+			viper.GetStringSlice("peer.tokenExporter.kafka.brokers"),
+			viper.GetString("peer.tokenExporter.kafka.topic"),
+		)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to create token exporter")
+		}
+		kafkaSink.Encoding = encoding
+		sink = kafkaSink
+	case "webhook":
+		url := viper.GetString("peer.tokenExporter.webhook.url")
+		if url == "" {
+			return nil, errors.New("peer.tokenExporter.webhook.url is required")
+		}
+		sink = &exporter.WebhookSink{URL: url, Client: &http.Client{Timeout: 30 * time.Second}, Encoding: encoding}
+	default:
+		return nil, errors.Errorf("unknown token exporter sink type: %s", sinkType)
+	}
+
+	logger.Infof("Token exporter enabled, publishing to %s", viper.GetString("peer.tokenExporter.sink"))
+	return &exporter.Exporter{
+		Sink: sink,
+		Checkpoints: &exporter.FileCheckpointStore{
+			Dir: filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "tokenExporter"),
+		},
+		Decoder: &exporter.Decoder{
+			IncludeEndorserTransactions: viper.GetBool("peer.tokenExporter.includeEndorserTransactions"),
+		},
+	}, nil
+}
+
+func registerProverService(peerServer *comm.GRPCServer, aclProvider aclmgmt.ACLProvider, signingIdentity msp.SigningIdentity, metricsProvider metrics.Provider) (*server.Prover, error) {
 	policyChecker := &server.PolicyBasedAccessControl{
 		ACLProvider: aclProvider,
 		ACLResources: &server.ACLResources{
 			IssueTokens:    resources.Token_Issue,
 			TransferTokens: resources.Token_Transfer,
 			ListTokens:     resources.Token_List,
+			RedeemTokens:   resources.Token_Redeem,
+			GovernTokens:   resources.Token_Govern,
 		},
 	}
 
 	responseMarshaler, err := server.NewResponseMarshaler(signingIdentity)
 	if err != nil {
 		logger.Errorf("Failed to create prover service: %s", err)
-		return err
+		return nil, err
+	}
+
+	ownerEncoding, err := tokenidentity.ParseOwnerEncoding(viper.GetString("peer.prover.ownerEncoding"))
+	if err != nil {
+		logger.Errorf("Failed to create prover service: %s", err)
+		return nil, err
+	}
+
+	var ledgerManager tokenledger.LedgerManager = &server.PeerLedgerManager{}
+	if viper.GetBool("peer.prover.stateCache.enabled") {
+		stateCache := &server.StateCache{
+			LedgerManager: ledgerManager,
+			MaxEntries:    viper.GetInt("peer.prover.stateCache.maxEntries"),
+			Metrics:       server.NewStateCacheMetrics(metricsProvider),
+		}
+		peer.AddBlockCommitListener(stateCache.BlockCommitted)
+		ledgerManager = stateCache
+	}
+
+	var spentFilters *server.SpentFilters
+	if viper.GetBool("peer.prover.spentFilter.enabled") {
+		spentFilters = &server.SpentFilters{
+			Dir:               filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "spentFilters"),
+			Capacity:          viper.GetInt("peer.prover.spentFilter.capacity"),
+			FalsePositiveRate: viper.GetFloat64("peer.prover.spentFilter.falsePositiveRate"),
+			SaveInterval:      uint64(viper.GetInt("peer.prover.spentFilter.saveInterval")),
+			LedgerManager:     &server.PeerLedgerManager{},
+			GetLedger:         server.PeerCommitLedger,
+		}
+		peer.AddBlockCommitListener(spentFilters.BlockCommitted)
 	}
 
 	prover := &server.Prover{
@@ -928,10 +1128,220 @@ func registerProverService(peerServer *comm.GRPCServer, aclProvider aclmgmt.ACLP
 		},
 		Marshaler:     responseMarshaler,
 		PolicyChecker: policyChecker,
+		QueryOnly:     viper.GetBool("peer.queryOnly"),
 		TMSManager: &server.Manager{
-			LedgerManager: &server.PeerLedgerManager{},
+			LedgerManager:               ledgerManager,
+			IdentityDeserializerManager: &manager.FabricIdentityDeserializerManager{},
+			OwnerEncoding:               ownerEncoding,
+			SpentFilters:                spentFilters,
 		},
 	}
+	if spentFilters != nil {
+		prover.Flushers = append(prover.Flushers, spentFilters)
+	}
+	if viper.GetBool("peer.prover.pseudonymQueries") {
+		prover.PseudonymVerifier = &server.IdemixPseudonymVerifier{
+			IdentityDeserializerManager: &manager.FabricIdentityDeserializerManager{},
+		}
+	}
+	if viper.GetBool("peer.prover.supplyAttestations") {
+		prover.SupplyAttestor = &server.LedgerSupplyAttestor{
+			LedgerManager: &server.PeerLedgerManager{},
+			GetLedger:     server.PeerCommitLedger,
+			Signer:        signingIdentity,
+			Time:          time.Now,
+		}
+	}
+	if viper.GetBool("peer.prover.commitAttestations") {
+		prover.CommitAttestor = &server.LedgerCommitAttestor{
+			GetLedger:         server.PeerBlockLedger,
+			CapabilityChecker: prover.CapabilityChecker,
+			Signer:            signingIdentity,
+			Time:              time.Now,
+		}
+	}
+	if window := viper.GetDuration("peer.prover.replayWindow"); window > 0 {
+		prover.ReplayGuard = server.NewReplayGuard(window, time.Now)
+	}
 	token.RegisterProverServer(peerServer.Server(), prover)
+	return prover, nil
+}
+
+// newTokenStatsHandler returns the operations handler serving the statistics
+// of the token namespace of the channels the peer has joined.
+func newTokenStatsHandler() *server.StatsHandler {
+	return &server.StatsHandler{
+		LedgerManager: &server.PeerLedgerManager{},
+		Channels: func() []string {
+			var channels []string
+			for _, info := range peer.GetChannelsInfo() {
+				channels = append(channels, info.ChannelId)
+			}
+			return channels
+		},
+		Logger: flogging.MustGetLogger("token.server.stats"),
+	}
+}
+
+func newTokenDeadlettersHandler() *deadletter.Handler {
+	return &deadletter.Handler{
+		Provider: peer.GetTokenDeadletters,
+		Channels: func() []string {
+			var channels []string
+			for _, info := range peer.GetChannelsInfo() {
+				channels = append(channels, info.ChannelId)
+			}
+			return channels
+		},
+		Logger: flogging.MustGetLogger("token.deadletter"),
+	}
+}
+
+// newProfileCapturer creates the capturer of the profiles of the peer and
+// returns the thresholds of the metrics triggering a capture.
+func newProfileCapturer() (*diag.ProfileCapturer, map[string]float64, error) {
+	thresholds := map[string]float64{}
+	for name, value := range viper.GetStringMap("peer.profile.capture.thresholds") {
+		threshold, err := cast.ToFloat64E(value)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "invalid profile capture threshold of metric %s", name)
+		}
+		thresholds[name] = threshold
+	}
+
+	dir := coreconfig.GetPath("peer.profile.capture.dir")
+	if dir == "" {
+		dir = filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "profiles")
+	}
+	capturer := &diag.ProfileCapturer{
+		Dir:         dir,
+		Profiles:    viper.GetStringSlice("peer.profile.capture.profiles"),
+		Retain:      viper.GetInt("peer.profile.capture.retain"),
+		MinInterval: viper.GetDuration("peer.profile.capture.minInterval"),
+		Logger:      flogging.MustGetLogger("diag"),
+	}
+	if len(capturer.Profiles) == 0 {
+		capturer.Profiles = []string{"heap", "goroutine"}
+	}
+	if capturer.Retain == 0 {
+		capturer.Retain = 10
+	}
+	logger.Infof("Capturing profiles %v to %s when thresholds %v are crossed", capturer.Profiles, dir, thresholds)
+	return capturer, thresholds, nil
+}
+
+// registerTokenGateway registers the gateway submitting the token
+// transactions of constrained clients to the ordering service.
+func registerTokenGateway(peerServer *comm.GRPCServer, aclProvider aclmgmt.ACLProvider, signingIdentity msp.SigningIdentity) *server.Gateway {
+	accessControl := &server.PolicyBasedAccessControl{
+		ACLProvider: aclProvider,
+		ACLResources: &server.ACLResources{
+			TransferTokens: resources.Token_Transfer,
+			ListTokens:     resources.Token_List,
+			SubmitTokens:   resources.Token_Submit,
+		},
+	}
+	gateway := &server.Gateway{
+		Broadcaster: &server.PeerBroadcaster{
+			OrdererAddresses: server.PeerOrdererAddresses,
+			Connect: func(channelID, address string) (*grpc.ClientConn, error) {
+				return deliverclient.DefaultConnectionFactory(channelID)(address)
+			},
+		},
+		CommitWaiter: &server.LedgerCommitWaiter{
+			GetLedger: server.PeerCommitLedger,
+		},
+		EnvelopeChecker: accessControl,
+		CapabilityChecker: &server.TokenCapabilityChecker{
+			PeerOps: peer.Default,
+		},
+		MaxAttempts:   viper.GetInt("peer.tokenGateway.maxAttempts"),
+		RetryInterval: viper.GetDuration("peer.tokenGateway.retryInterval"),
+		CommitTimeout: viper.GetDuration("peer.tokenGateway.commitTimeout"),
+	}
+	if advisor := peer.GetConflictAdvisor(); advisor != nil {
+		gateway.ConflictAdvisor = advisor
+	}
+	if channels := viper.GetStringSlice("peer.tokenGateway.localCommitFastPath.channels"); len(channels) != 0 {
+		notifier := &server.CommitNotifier{GetLedger: server.PeerCommitLedger}
+		peer.AddBlockCommitListener(notifier.BlockCommitted)
+		gateway.FastPath = &server.FastPath{
+			Channels:     map[string]bool{},
+			MSPID:        viper.GetString("peer.localMspId"),
+			CommitWaiter: notifier,
+		}
+		for _, channelID := range channels {
+			gateway.FastPath.Channels[channelID] = true
+		}
+		logger.Infof("Token gateway fast path enabled for intra-org transfers on channels %v", channels)
+	}
+	if viper.GetBool("peer.tokenGateway.commitAttestations") {
+		gateway.CommitAttestor = &server.LedgerCommitAttestor{
+			GetLedger:         server.PeerBlockLedger,
+			CapabilityChecker: gateway.CapabilityChecker,
+			Signer:            signingIdentity,
+			Time:              time.Now,
+		}
+		gateway.PolicyChecker = accessControl
+	}
+	token.RegisterGatewayServer(peerServer.Server(), gateway)
+	return gateway
+}
+
+// drainProver lets in-flight prover commands complete before the peer exits.
+func drainProver(prover *server.Prover) {
+	if prover == nil {
+		return
+	}
+	timeout := viper.GetDuration("peer.prover.drainTimeout")
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	err := prover.Drain(ctx)
+	if err != nil {
+		logger.Warningf("Failed draining prover: %s", err)
+	}
+}
+
+// flushProver saves the state of the prover, such as its spent filters, once
+// the peer stopped committing blocks.
+func flushProver(prover *server.Prover) {
+	if prover == nil {
+		return
+	}
+	err := prover.Flush()
+	if err != nil {
+		logger.Warningf("Failed flushing prover: %s", err)
+	}
+}
+
+// drainTokenGateway lets the submissions in progress complete before the
+// peer exits.
+func drainTokenGateway(gateway *server.Gateway) {
+	if gateway == nil {
+		return
+	}
+	timeout := viper.GetDuration("peer.tokenGateway.drainTimeout")
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	err := gateway.Shutdown(ctx)
+	if err != nil {
+		logger.Warningf("Failed draining token gateway: %s", err)
+	}
+}
+
+// loadTokenDrivers registers the token drivers loaded as Go plugins, by name.
+func loadTokenDrivers() error {
+	for name, library := range viper.GetStringMapString("peer.tokenDrivers") {
+		if err := tokendriver.Default().LoadPlugin(name, library); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("failed loading token driver '%s'", name))
+		}
+	}
+	logger.Infof("Available token drivers: %v", tokendriver.Default().Drivers())
 	return nil
 }
@@ -0,0 +1,34 @@
+// +build wasmruntime
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"github.com/hyperledger/fabric/core/chaincode"
+	"github.com/hyperledger/fabric/core/chaincode/platforms"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/wasm"
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/inproccontroller"
+	"github.com/hyperledger/fabric/core/container/wasmcontroller"
+	"github.com/spf13/viper"
+)
+
+// wasmPlatforms returns the platform of WebAssembly chaincode
+func wasmPlatforms() []platforms.Platform {
+	return []platforms.Platform{&wasm.Platform{}}
+}
+
+// wasmVMProviders returns the provider running WebAssembly chaincode in the peer process
+func wasmVMProviders(ipRegistry *inproccontroller.Registry) map[string]container.VMProvider {
+	return map[string]container.VMProvider{
+		wasmcontroller.ContainerType: wasmcontroller.NewProvider(ipRegistry, wasmcontroller.Config{
+			MemoryLimitPages: uint32(viper.GetInt("chaincode.wasm.memoryLimitPages")),
+			ExecuteTimeout:   chaincode.GlobalConfig().ExecuteTimeout,
+		}),
+	}
+}
@@ -0,0 +1,254 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/scc/qscc"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/hyperledger/fabric/token/transaction"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func inspectCmd(cf *TokenCmdFactory) *cobra.Command {
+	inspectCmd := &cobra.Command{
+		Use:   "inspect",
+		Short: "decode a token transaction of the ledger.",
+		Long:  "fetch a token transaction of a specified channel from the ledger of the peer and print it decoded. Requires '-c' and '--txid'.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return inspect(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"txid",
+	}
+	attachFlags(inspectCmd, flagList)
+
+	return inspectCmd
+}
+
+func inspect(cmd *cobra.Command, cf *TokenCmdFactory) error {
+	//the global chainID filled by the "-c" command
+	if channelID == common.UndefinedParamValue {
+		return errors.New("Must supply channel ID")
+	}
+	if txID == "" {
+		return errors.New("Must supply transaction ID")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory()
+		if err != nil {
+			return err
+		}
+	}
+
+	processedTx, err := getTransactionByID(cf, channelID, txID)
+	if err != nil {
+		return err
+	}
+	if processedTx.TransactionEnvelope == nil {
+		return errors.Errorf("transaction %s has no envelope", txID)
+	}
+
+	chdr, ttx, creator, err := transaction.UnmarshalTokenTransaction(processedTx.TransactionEnvelope.Payload)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("transaction %s is not a token transaction", txID))
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Transaction: %s\n", chdr.TxId)
+	fmt.Fprintf(out, "Channel: %s\n", chdr.ChannelId)
+	fmt.Fprintf(out, "Validation code: %s\n", pb.TxValidationCode(processedTx.ValidationCode))
+	fmt.Fprintf(out, "Creator: %s\n", describeIdentity(creator.Public()))
+	printTokenTransaction(out, ttx)
+
+	return nil
+}
+
+func getTransactionByID(cf *TokenCmdFactory, channelID, txID string) (*pb.ProcessedTransaction, error) {
+	invocation := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]),
+			ChaincodeId: &pb.ChaincodeID{Name: "qscc"},
+			Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte(qscc.GetTransactionByID), []byte(channelID), []byte(txID)}},
+		},
+	}
+
+	c, _ := cf.Signer.Serialize()
+	prop, _, err := utils.CreateProposalFromCIS(cb.HeaderType_ENDORSER_TRANSACTION, "", invocation, c)
+	if err != nil {
+		return nil, errors.WithMessage(err, "cannot create proposal")
+	}
+
+	signedProp, err := utils.GetSignedProposal(prop, cf.Signer)
+	if err != nil {
+		return nil, errors.WithMessage(err, "cannot create signed proposal")
+	}
+
+	proposalResp, err := cf.EndorserClient.ProcessProposal(context.Background(), signedProp)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed sending proposal")
+	}
+
+	if proposalResp.Response == nil || proposalResp.Response.Status != 200 {
+		return nil, errors.Errorf("received bad response, status %d: %s", proposalResp.Response.Status, proposalResp.Response.Message)
+	}
+
+	processedTx := &pb.ProcessedTransaction{}
+	if err := proto.Unmarshal(proposalResp.Response.Payload, processedTx); err != nil {
+		return nil, errors.Wrap(err, "cannot read qscc response")
+	}
+
+	return processedTx, nil
+}
+
+// printTokenTransaction prints the action of a token transaction, with its
+// inputs and outputs.
+func printTokenTransaction(out io.Writer, ttx *token.TokenTransaction) {
+	if len(ttx.ApplicationReference) != 0 {
+		fmt.Fprintf(out, "Application reference: %x\n", ttx.ApplicationReference)
+	}
+
+	switch action := ttx.Action.(type) {
+	case *token.TokenTransaction_PlainAction:
+		printPlainAction(out, action.PlainAction)
+	case *token.TokenTransaction_EncryptedAction:
+		fmt.Fprintln(out, "Action: encrypted")
+		fmt.Fprintln(out, "Recipients:")
+		for _, recipient := range action.EncryptedAction.Recipients {
+			fmt.Fprintf(out, "  %s\n", recipient.MspId)
+		}
+	default:
+		fmt.Fprintf(out, "Action: unknown (%T)\n", ttx.Action)
+	}
+}
+
+func printPlainAction(out io.Writer, action *token.PlainTokenAction) {
+	switch data := action.Data.(type) {
+	case *token.PlainTokenAction_PlainImport:
+		fmt.Fprintln(out, "Action: import")
+		if len(data.PlainImport.SeriesId) != 0 {
+			fmt.Fprintf(out, "Series: %x\n", data.PlainImport.SeriesId)
+		}
+		printOutputs(out, data.PlainImport.Outputs)
+	case *token.PlainTokenAction_PlainTransfer:
+		fmt.Fprintln(out, "Action: transfer")
+		printTransfer(out, data.PlainTransfer)
+	case *token.PlainTokenAction_PlainRedeem:
+		fmt.Fprintln(out, "Action: redeem")
+		printTransfer(out, data.PlainRedeem)
+	case *token.PlainTokenAction_PlainApprove:
+		fmt.Fprintln(out, "Action: approve")
+		printInputs(out, data.PlainApprove.Inputs)
+		printDelegatedOutputs(out, data.PlainApprove.DelegatedOutputs)
+		if data.PlainApprove.Output != nil {
+			printOutputs(out, []*token.PlainOutput{data.PlainApprove.Output})
+		}
+	case *token.PlainTokenAction_PlainTransfer_From:
+		fmt.Fprintln(out, "Action: transfer from")
+		printInputs(out, data.PlainTransfer_From.Inputs)
+		printOutputs(out, data.PlainTransfer_From.Outputs)
+		if data.PlainTransfer_From.DelegatedOutput != nil {
+			printDelegatedOutputs(out, []*token.PlainDelegatedOutput{data.PlainTransfer_From.DelegatedOutput})
+		}
+	case *token.PlainTokenAction_PlainPause:
+		fmt.Fprintln(out, "Action: pause")
+		fmt.Fprintf(out, "Token type: %s\n", data.PlainPause.Type)
+	case *token.PlainTokenAction_PlainResume:
+		fmt.Fprintln(out, "Action: resume")
+		fmt.Fprintf(out, "Token type: %s\n", data.PlainResume.Type)
+	case *token.PlainTokenAction_PlainIssueManifest:
+		fmt.Fprintln(out, "Action: issue manifest")
+		fmt.Fprintf(out, "Batch: %x\n", data.PlainIssueManifest.BatchId)
+		fmt.Fprintln(out, "Chunks:")
+		for i, chunk := range data.PlainIssueManifest.Chunks {
+			fmt.Fprintf(out, "  [%d] %s series %x\n", i, chunk.TxId, chunk.SeriesId)
+		}
+	default:
+		fmt.Fprintf(out, "Action: unknown plain action (%T)\n", action.Data)
+	}
+}
+
+func printTransfer(out io.Writer, transfer *token.PlainTransfer) {
+	printInputs(out, transfer.Inputs)
+	printOutputs(out, transfer.Outputs)
+	if len(transfer.Delegations) != 0 {
+		fmt.Fprintf(out, "Delegations: %d\n", len(transfer.Delegations))
+	}
+}
+
+func printInputs(out io.Writer, inputs []*token.InputId) {
+	fmt.Fprintln(out, "Inputs:")
+	for i, input := range inputs {
+		fmt.Fprintf(out, "  [%d] %s:%d\n", i, input.TxId, input.Index)
+	}
+}
+
+func printOutputs(out io.Writer, outputs []*token.PlainOutput) {
+	fmt.Fprintln(out, "Outputs:")
+	for i, output := range outputs {
+		fmt.Fprintf(out, "  [%d] %d %s owned by %s\n", i, output.Quantity, output.Type, describeIdentity(output.Owner))
+		if len(output.Metadata) != 0 {
+			fmt.Fprintf(out, "      metadata: %s\n", output.Metadata)
+		}
+	}
+}
+
+func printDelegatedOutputs(out io.Writer, outputs []*token.PlainDelegatedOutput) {
+	fmt.Fprintln(out, "Delegated outputs:")
+	for i, output := range outputs {
+		fmt.Fprintf(out, "  [%d] %d %s owned by %s\n", i, output.Quantity, output.Type, describeIdentity(output.Owner))
+		for _, delegatee := range output.Delegatees {
+			fmt.Fprintf(out, "      delegated to %s\n", describeIdentity(delegatee))
+		}
+	}
+}
+
+// describeIdentity returns the MSP ID of a serialized identity or hashed
+// owner, followed by the subject of the certificate of X.509 identities or by
+// the hash of hashed owners.
+func describeIdentity(raw []byte) string {
+	if len(raw) == 0 {
+		return "nobody"
+	}
+
+	// HashedOwner shares the wire format of SerializedIdentity, so both
+	// decode as one, the hash taking the place of the identity bytes
+	sID := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(raw, sID); err != nil || sID.Mspid == "" {
+		return fmt.Sprintf("unknown identity %x", raw)
+	}
+
+	if block, _ := pem.Decode(sID.IdBytes); block != nil {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err == nil {
+			return fmt.Sprintf("%s %s", sID.Mspid, cert.Subject)
+		}
+	}
+	if len(sID.IdBytes) == sha256.Size {
+		return fmt.Sprintf("%s (hashed identity %x)", sID.Mspid, sID.IdBytes)
+	}
+	return fmt.Sprintf("%s (non X.509 identity)", sID.Mspid)
+}
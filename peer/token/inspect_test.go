@@ -0,0 +1,222 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/msp"
+	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	mspproto "github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/hyperledger/fabric/token/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+var once sync.Once
+
+// InitMSP init MSP
+func InitMSP() {
+	once.Do(initMSP)
+}
+
+func initMSP() {
+	err := msptesttools.LoadMSPSetupForTesting()
+	if err != nil {
+		panic(fmt.Errorf("Fatal error when reading MSP config: err %s", err))
+	}
+}
+
+func tokenTransactionResponse(t *testing.T, headerType cb.HeaderType, ttx *token.TokenTransaction, signer msp.SigningIdentity) *pb.ProposalResponse {
+	creator, err := signer.Serialize()
+	assert.NoError(t, err)
+	data, err := proto.Marshal(ttx)
+	assert.NoError(t, err)
+
+	chdr := utils.MakeChannelHeader(headerType, 0, "mychannel", 0)
+	chdr.TxId = "txid"
+	shdr := &cb.SignatureHeader{Creator: creator, Nonce: []byte("nonce")}
+	payload := &cb.Payload{
+		Header: utils.MakePayloadHeader(chdr, shdr),
+		Data:   data,
+	}
+	processedTx := &pb.ProcessedTransaction{
+		TransactionEnvelope: &cb.Envelope{Payload: utils.MarshalOrPanic(payload)},
+		ValidationCode:      int32(pb.TxValidationCode_VALID),
+	}
+
+	return &pb.ProposalResponse{
+		Response: &pb.Response{
+			Status:  200,
+			Payload: utils.MarshalOrPanic(processedTx),
+		},
+		Endorsement: &pb.Endorsement{},
+	}
+}
+
+func TestInspect(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+	owner, err := signer.Serialize()
+	assert.NoError(t, err)
+	hashedOwner, err := identity.HashOwner(owner)
+	assert.NoError(t, err)
+
+	ttx := &token.TokenTransaction{
+		Action: &token.TokenTransaction_PlainAction{
+			PlainAction: &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainTransfer{
+					PlainTransfer: &token.PlainTransfer{
+						Inputs: []*token.InputId{{TxId: "previoustxid", Index: 1}},
+						Outputs: []*token.PlainOutput{
+							{Owner: owner, Type: "PDQ", Quantity: 70},
+							{Owner: hashedOwner, Type: "PDQ", Quantity: 30},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	mockCF := &TokenCmdFactory{
+		EndorserClient: common.GetMockEndorserClient(tokenTransactionResponse(t, cb.HeaderType_TOKEN_TRANSACTION, ttx, signer), nil),
+		Signer:         signer,
+	}
+
+	cmd := inspectCmd(mockCF)
+	out := &bytes.Buffer{}
+	cmd.SetOutput(out)
+	cmd.SetArgs([]string{"-c", "mychannel", "--txid", "txid"})
+	assert.NoError(t, cmd.Execute())
+
+	subject := describeIdentity(owner)
+	assert.Contains(t, subject, "SampleOrg CN=")
+	assert.Equal(t, fmt.Sprintf(`Transaction: txid
+Channel: mychannel
+Validation code: VALID
+Creator: %[1]s
+Action: transfer
+Inputs:
+  [0] previoustxid:1
+Outputs:
+  [0] 70 PDQ owned by %[1]s
+  [1] 30 PDQ owned by SampleOrg (hashed identity %[2]x)
+`, subject, hashedOwnerHash(t, hashedOwner)), out.String())
+}
+
+func hashedOwnerHash(t *testing.T, raw []byte) []byte {
+	hashed := &token.HashedOwner{}
+	assert.NoError(t, proto.Unmarshal(raw, hashed))
+	return hashed.IdentityHash
+}
+
+func TestInspectNotTokenTransaction(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	mockCF := &TokenCmdFactory{
+		EndorserClient: common.GetMockEndorserClient(tokenTransactionResponse(t, cb.HeaderType_ENDORSER_TRANSACTION, &token.TokenTransaction{}, signer), nil),
+		Signer:         signer,
+	}
+
+	cmd := inspectCmd(mockCF)
+	cmd.SetArgs([]string{"-c", "mychannel", "--txid", "txid"})
+	assert.EqualError(t, cmd.Execute(), "transaction txid is not a token transaction: only token transactions are supported, provided type: 3")
+}
+
+func TestInspectBadResponse(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	mockResponse := &pb.ProposalResponse{
+		Response: &pb.Response{
+			Status:  500,
+			Message: "Failed to get transaction with id txid",
+		},
+		Endorsement: &pb.Endorsement{},
+	}
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	mockCF := &TokenCmdFactory{
+		EndorserClient: common.GetMockEndorserClient(mockResponse, nil),
+		Signer:         signer,
+	}
+
+	cmd := inspectCmd(mockCF)
+	cmd.SetArgs([]string{"-c", "mychannel", "--txid", "txid"})
+	assert.EqualError(t, cmd.Execute(), "received bad response, status 500: Failed to get transaction with id txid")
+}
+
+func TestInspectMissingArgs(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	mockCF := &TokenCmdFactory{
+		Signer: signer,
+	}
+
+	cmd := inspectCmd(mockCF)
+	cmd.SetArgs([]string{"--txid", "txid"})
+	assert.EqualError(t, cmd.Execute(), "Must supply channel ID")
+
+	resetFlags()
+	cmd = inspectCmd(mockCF)
+	cmd.SetArgs([]string{"-c", "mychannel"})
+	assert.EqualError(t, cmd.Execute(), "Must supply transaction ID")
+}
+
+func TestPrintTokenTransaction(t *testing.T) {
+	out := &bytes.Buffer{}
+	printTokenTransaction(out, &token.TokenTransaction{
+		Action: &token.TokenTransaction_EncryptedAction{
+			EncryptedAction: &token.EncryptedTokenAction{
+				Recipients: []*token.TokenRecipient{{MspId: "Org1MSP"}, {MspId: "Org2MSP"}},
+			},
+		},
+	})
+	assert.Equal(t, "Action: encrypted\nRecipients:\n  Org1MSP\n  Org2MSP\n", out.String())
+
+	out.Reset()
+	printTokenTransaction(out, &token.TokenTransaction{
+		Action: &token.TokenTransaction_PlainAction{
+			PlainAction: &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainRedeem{
+					PlainRedeem: &token.PlainTransfer{
+						Inputs:  []*token.InputId{{TxId: "previoustxid"}},
+						Outputs: []*token.PlainOutput{{Type: "PDQ", Quantity: 10}},
+					},
+				},
+			},
+		},
+	})
+	assert.Equal(t, "Action: redeem\nInputs:\n  [0] previoustxid:0\nOutputs:\n  [0] 10 PDQ owned by nobody\n", out.String())
+}
+
+func TestDescribeIdentity(t *testing.T) {
+	assert.Equal(t, "nobody", describeIdentity(nil))
+	assert.Equal(t, "unknown identity 0102", describeIdentity([]byte{1, 2}))
+	assert.Equal(t, "Org1MSP (non X.509 identity)", describeIdentity(utils.MarshalOrPanic(&mspproto.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("idemix")})))
+}
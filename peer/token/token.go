@@ -0,0 +1,96 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	tokenFuncName = "token"
+	tokenCmdDes   = "Operate on the token transactions of a channel: inspect."
+)
+
+var logger = flogging.MustGetLogger("cli.token")
+
+var (
+	channelID string
+	txID      string
+)
+
+// Cmd returns the cobra command for Token
+func Cmd(cf *TokenCmdFactory) *cobra.Command {
+	tokenCmd.AddCommand(inspectCmd(cf))
+
+	return tokenCmd
+}
+
+var flags *pflag.FlagSet
+
+func init() {
+	resetFlags()
+}
+
+// Explicitly define a method to facilitate tests
+func resetFlags() {
+	flags = &pflag.FlagSet{}
+
+	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "The channel of the transaction")
+	flags.StringVarP(&txID, "txid", "", "", "The ID of the transaction")
+}
+
+func attachFlags(cmd *cobra.Command, names []string) {
+	cmdFlags := cmd.Flags()
+	for _, name := range names {
+		if flag := flags.Lookup(name); flag != nil {
+			cmdFlags.AddFlag(flag)
+		} else {
+			logger.Fatalf("Could not find flag '%s' to attach to commond '%s'", name, cmd.Name())
+		}
+	}
+}
+
+var tokenCmd = &cobra.Command{
+	Use:              tokenFuncName,
+	Short:            tokenCmdDes,
+	Long:             tokenCmdDes,
+	PersistentPreRun: common.InitCmd,
+}
+
+// TokenCmdFactory holds the clients used by TokenCmd
+type TokenCmdFactory struct {
+	EndorserClient pb.EndorserClient
+	Signer         msp.SigningIdentity
+}
+
+// InitCmdFactory init the TokenCmdFactory with the endorser client of the
+// peer and the default signer
+func InitCmdFactory() (*TokenCmdFactory, error) {
+	signer, err := common.GetDefaultSignerFnc()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error getting default signer")
+	}
+
+	// creating an EndorserClient with these empty parameters will create a
+	// connection using the values of "peer.address" and
+	// "peer.tls.rootcert.file"
+	endorserClient, err := common.GetEndorserClientFnc(common.UndefinedParamValue, common.UndefinedParamValue)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error getting endorser client for token")
+	}
+
+	return &TokenCmdFactory{
+		EndorserClient: endorserClient,
+		Signer:         signer,
+	}, nil
+}
@@ -74,6 +74,12 @@ func (b *BlockHeader) Hash() []byte {
 	return util.ComputeSHA256(b.Bytes())
 }
 
+// HashWith returns the hash of the block header computed with the hashing
+// algorithm of its channel.
+func (b *BlockHeader) HashWith(hash func([]byte) []byte) []byte {
+	return hash(b.Bytes())
+}
+
 // Bytes returns a deterministically serialized version of the BlockData
 // eventually, this should be replaced with a true Merkle tree construction,
 // but for the moment, we assume a Merkle tree of infinite width (uint32_max)
@@ -86,3 +92,9 @@ func (b *BlockData) Bytes() []byte {
 func (b *BlockData) Hash() []byte {
 	return util.ComputeSHA256(b.Bytes())
 }
+
+// HashWith returns the hash of the marshaled representation of the block
+// data computed with the hashing algorithm of its channel.
+func (b *BlockData) HashWith(hash func([]byte) []byte) []byte {
+	return hash(b.Bytes())
+}
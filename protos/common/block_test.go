@@ -49,7 +49,10 @@ func TestBlock(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, asn1Bytes, block.Header.Bytes(), "Incorrect marshaled blockheader bytes")
 	assert.Equal(t, headerHash, block.Header.Hash(), "Incorrect blockheader hash")
-
+	assert.Equal(t, headerHash, block.Header.HashWith(util.ComputeSHA256), "Incorrect blockheader hash")
+	assert.Equal(t, util.ComputeSHA3256(asn1Bytes), block.Header.HashWith(util.ComputeSHA3256), "Incorrect blockheader hash")
+	assert.Equal(t, data.Hash(), data.HashWith(util.ComputeSHA256), "Incorrect blockdata hash")
+	assert.Equal(t, util.ComputeSHA3384(data.Bytes()), data.HashWith(util.ComputeSHA3384), "Incorrect blockdata hash")
 }
 
 func TestGoodBlockHeaderBytes(t *testing.T) {
@@ -61,7 +61,7 @@ func (x Status) String() string {
 	return proto.EnumName(Status_name, int32(x))
 }
 func (Status) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{0}
+	return fileDescriptor_common_8f6fc7ea87c85c64, []int{0}
 }
 
 type HeaderType int32
@@ -105,7 +105,7 @@ func (x HeaderType) String() string {
 	return proto.EnumName(HeaderType_name, int32(x))
 }
 func (HeaderType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{1}
+	return fileDescriptor_common_8f6fc7ea87c85c64, []int{1}
 }
 
 // This enum enlists indexes of the block metadata array
@@ -135,7 +135,7 @@ func (x BlockMetadataIndex) String() string {
 	return proto.EnumName(BlockMetadataIndex_name, int32(x))
 }
 func (BlockMetadataIndex) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{2}
+	return fileDescriptor_common_8f6fc7ea87c85c64, []int{2}
 }
 
 // LastConfig is the encoded value for the Metadata message which is encoded in the LAST_CONFIGURATION block metadata index
@@ -150,7 +150,7 @@ func (m *LastConfig) Reset()         { *m = LastConfig{} }
 func (m *LastConfig) String() string { return proto.CompactTextString(m) }
 func (*LastConfig) ProtoMessage()    {}
 func (*LastConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{0}
+	return fileDescriptor_common_8f6fc7ea87c85c64, []int{0}
 }
 func (m *LastConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LastConfig.Unmarshal(m, b)
@@ -190,7 +190,7 @@ func (m *Metadata) Reset()         { *m = Metadata{} }
 func (m *Metadata) String() string { return proto.CompactTextString(m) }
 func (*Metadata) ProtoMessage()    {}
 func (*Metadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{1}
+	return fileDescriptor_common_8f6fc7ea87c85c64, []int{1}
 }
 func (m *Metadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Metadata.Unmarshal(m, b)
@@ -236,7 +236,7 @@ func (m *MetadataSignature) Reset()         { *m = MetadataSignature{} }
 func (m *MetadataSignature) String() string { return proto.CompactTextString(m) }
 func (*MetadataSignature) ProtoMessage()    {}
 func (*MetadataSignature) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{2}
+	return fileDescriptor_common_8f6fc7ea87c85c64, []int{2}
 }
 func (m *MetadataSignature) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MetadataSignature.Unmarshal(m, b)
@@ -282,7 +282,7 @@ func (m *Header) Reset()         { *m = Header{} }
 func (m *Header) String() string { return proto.CompactTextString(m) }
 func (*Header) ProtoMessage()    {}
 func (*Header) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{3}
+	return fileDescriptor_common_8f6fc7ea87c85c64, []int{3}
 }
 func (m *Header) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Header.Unmarshal(m, b)
@@ -345,17 +345,32 @@ type ChannelHeader struct {
 	Extension []byte `protobuf:"bytes,7,opt,name=extension,proto3" json:"extension,omitempty"`
 	// If mutual TLS is employed, this represents
 	// the hash of the client's TLS certificate
-	TlsCertHash          []byte   `protobuf:"bytes,8,opt,name=tls_cert_hash,json=tlsCertHash,proto3" json:"tls_cert_hash,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	TlsCertHash []byte `protobuf:"bytes,8,opt,name=tls_cert_hash,json=tlsCertHash,proto3" json:"tls_cert_hash,omitempty"`
+	// Priority requests the message be ordered ahead of messages of lower
+	// priority. Messages with a non zero priority are only accepted by the
+	// ordering service if they satisfy the /Channel/Orderer/PriorityWriters
+	// policy of the channel. The priority is ignored on the channels without
+	// the V1_4_PRIORITY_EXPERIMENTAL orderer capability.
+	Priority uint32 `protobuf:"varint,9,opt,name=priority,proto3" json:"priority,omitempty"`
+	// NotValidAfterBlock, when not zero, is the number of the last block the
+	// transaction may be committed in. Committing peers mark the transactions
+	// of later blocks invalid with the EXPIRED_TRANSACTION code.
+	NotValidAfterBlock uint64 `protobuf:"varint,10,opt,name=not_valid_after_block,json=notValidAfterBlock,proto3" json:"not_valid_after_block,omitempty"`
+	// NotValidAfter, when set, is the time after which the ordering service
+	// no longer accepts broadcasts of the transaction, on the channels with the
+	// TxExpiration capability. As committing peers have no common clock, they
+	// only check that it is not earlier than the timestamp.
+	NotValidAfter        *timestamp.Timestamp `protobuf:"bytes,11,opt,name=not_valid_after,json=notValidAfter,proto3" json:"not_valid_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *ChannelHeader) Reset()         { *m = ChannelHeader{} }
 func (m *ChannelHeader) String() string { return proto.CompactTextString(m) }
 func (*ChannelHeader) ProtoMessage()    {}
 func (*ChannelHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{4}
+	return fileDescriptor_common_8f6fc7ea87c85c64, []int{4}
 }
 func (m *ChannelHeader) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChannelHeader.Unmarshal(m, b)
@@ -431,6 +446,27 @@ func (m *ChannelHeader) GetTlsCertHash() []byte {
 	return nil
 }
 
+func (m *ChannelHeader) GetPriority() uint32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *ChannelHeader) GetNotValidAfterBlock() uint64 {
+	if m != nil {
+		return m.NotValidAfterBlock
+	}
+	return 0
+}
+
+func (m *ChannelHeader) GetNotValidAfter() *timestamp.Timestamp {
+	if m != nil {
+		return m.NotValidAfter
+	}
+	return nil
+}
+
 type SignatureHeader struct {
 	// Creator of the message, a marshaled msp.SerializedIdentity
 	Creator []byte `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
@@ -445,7 +481,7 @@ func (m *SignatureHeader) Reset()         { *m = SignatureHeader{} }
 func (m *SignatureHeader) String() string { return proto.CompactTextString(m) }
 func (*SignatureHeader) ProtoMessage()    {}
 func (*SignatureHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{5}
+	return fileDescriptor_common_8f6fc7ea87c85c64, []int{5}
 }
 func (m *SignatureHeader) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignatureHeader.Unmarshal(m, b)
@@ -494,7 +530,7 @@ func (m *Payload) Reset()         { *m = Payload{} }
 func (m *Payload) String() string { return proto.CompactTextString(m) }
 func (*Payload) ProtoMessage()    {}
 func (*Payload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{6}
+	return fileDescriptor_common_8f6fc7ea87c85c64, []int{6}
 }
 func (m *Payload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Payload.Unmarshal(m, b)
@@ -543,7 +579,7 @@ func (m *Envelope) Reset()         { *m = Envelope{} }
 func (m *Envelope) String() string { return proto.CompactTextString(m) }
 func (*Envelope) ProtoMessage()    {}
 func (*Envelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{7}
+	return fileDescriptor_common_8f6fc7ea87c85c64, []int{7}
 }
 func (m *Envelope) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Envelope.Unmarshal(m, b)
@@ -594,7 +630,7 @@ func (m *Block) Reset()         { *m = Block{} }
 func (m *Block) String() string { return proto.CompactTextString(m) }
 func (*Block) ProtoMessage()    {}
 func (*Block) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{8}
+	return fileDescriptor_common_8f6fc7ea87c85c64, []int{8}
 }
 func (m *Block) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Block.Unmarshal(m, b)
@@ -651,7 +687,7 @@ func (m *BlockHeader) Reset()         { *m = BlockHeader{} }
 func (m *BlockHeader) String() string { return proto.CompactTextString(m) }
 func (*BlockHeader) ProtoMessage()    {}
 func (*BlockHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{9}
+	return fileDescriptor_common_8f6fc7ea87c85c64, []int{9}
 }
 func (m *BlockHeader) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockHeader.Unmarshal(m, b)
@@ -703,7 +739,7 @@ func (m *BlockData) Reset()         { *m = BlockData{} }
 func (m *BlockData) String() string { return proto.CompactTextString(m) }
 func (*BlockData) ProtoMessage()    {}
 func (*BlockData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{10}
+	return fileDescriptor_common_8f6fc7ea87c85c64, []int{10}
 }
 func (m *BlockData) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockData.Unmarshal(m, b)
@@ -741,7 +777,7 @@ func (m *BlockMetadata) Reset()         { *m = BlockMetadata{} }
 func (m *BlockMetadata) String() string { return proto.CompactTextString(m) }
 func (*BlockMetadata) ProtoMessage()    {}
 func (*BlockMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{11}
+	return fileDescriptor_common_8f6fc7ea87c85c64, []int{11}
 }
 func (m *BlockMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockMetadata.Unmarshal(m, b)
@@ -786,68 +822,72 @@ func init() {
 	proto.RegisterEnum("common.BlockMetadataIndex", BlockMetadataIndex_name, BlockMetadataIndex_value)
 }
 
-func init() { proto.RegisterFile("common/common.proto", fileDescriptor_common_b374fafc5e1c956e) }
-
-var fileDescriptor_common_b374fafc5e1c956e = []byte{
-	// 959 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x55, 0xcf, 0x6f, 0xe3, 0x44,
-	0x18, 0xdd, 0xc4, 0xf9, 0xf9, 0xa5, 0x69, 0xdd, 0x49, 0xcb, 0x9a, 0xc2, 0x6a, 0x2b, 0xc3, 0xa2,
-	0xd2, 0x4a, 0xa9, 0x28, 0x17, 0x38, 0x3a, 0xf6, 0xb4, 0xb5, 0x9a, 0x8e, 0xc3, 0xd8, 0x59, 0xc4,
-	0x2e, 0x92, 0xe5, 0x24, 0xd3, 0x24, 0x22, 0xb1, 0x23, 0x7b, 0x52, 0xb5, 0x67, 0xee, 0x08, 0x09,
-	0xae, 0xfc, 0x2f, 0x1c, 0x11, 0x7f, 0x0f, 0x88, 0x2b, 0x1a, 0x8f, 0xed, 0x4d, 0xca, 0x4a, 0x9c,
-	0xe2, 0xf7, 0xe6, 0xcd, 0xf7, 0xbd, 0xf9, 0xde, 0xc4, 0x86, 0xce, 0x38, 0x5a, 0x2e, 0xa3, 0xf0,
-	0x5c, 0xfe, 0x74, 0x57, 0x71, 0xc4, 0x23, 0x54, 0x93, 0xe8, 0xe8, 0xe5, 0x34, 0x8a, 0xa6, 0x0b,
-	0x76, 0x9e, 0xb2, 0xa3, 0xf5, 0xdd, 0x39, 0x9f, 0x2f, 0x59, 0xc2, 0x83, 0xe5, 0x4a, 0x0a, 0x75,
-	0x1d, 0xa0, 0x1f, 0x24, 0xdc, 0x8c, 0xc2, 0xbb, 0xf9, 0x14, 0x1d, 0x40, 0x75, 0x1e, 0x4e, 0xd8,
-	0x83, 0x56, 0x3a, 0x2e, 0x9d, 0x54, 0xa8, 0x04, 0xfa, 0x5b, 0x68, 0xdc, 0x32, 0x1e, 0x4c, 0x02,
-	0x1e, 0x08, 0xc5, 0x7d, 0xb0, 0x58, 0xb3, 0x54, 0xb1, 0x43, 0x25, 0x40, 0x5f, 0x03, 0x24, 0xf3,
-	0x69, 0x18, 0xf0, 0x75, 0xcc, 0x12, 0xad, 0x7c, 0xac, 0x9c, 0xb4, 0x2e, 0x3e, 0xec, 0x66, 0x8e,
-	0xf2, 0xbd, 0x6e, 0xae, 0xa0, 0x1b, 0x62, 0xfd, 0x7b, 0xd8, 0xff, 0x8f, 0x00, 0x7d, 0x0e, 0x6a,
-	0x21, 0xf1, 0x67, 0x2c, 0x98, 0xb0, 0x38, 0x6b, 0xb8, 0x57, 0xf0, 0xd7, 0x29, 0x8d, 0x3e, 0x86,
-	0x66, 0x41, 0x69, 0xe5, 0x54, 0xf3, 0x8e, 0xd0, 0xdf, 0x40, 0x2d, 0xd3, 0xbd, 0x82, 0xdd, 0xf1,
-	0x2c, 0x08, 0x43, 0xb6, 0xd8, 0x2e, 0xd8, 0xce, 0xd8, 0x4c, 0xf6, 0xbe, 0xce, 0xe5, 0xf7, 0x76,
-	0xd6, 0x7f, 0x2c, 0x43, 0xdb, 0xdc, 0xda, 0x8c, 0xa0, 0xc2, 0x1f, 0x57, 0x72, 0x36, 0x55, 0x9a,
-	0x3e, 0x23, 0x0d, 0xea, 0xf7, 0x2c, 0x4e, 0xe6, 0x51, 0x98, 0xd6, 0xa9, 0xd2, 0x1c, 0xa2, 0xaf,
-	0xa0, 0x59, 0xa4, 0xa1, 0x29, 0xc7, 0xa5, 0x93, 0xd6, 0xc5, 0x51, 0x57, 0xe6, 0xd5, 0xcd, 0xf3,
-	0xea, 0x7a, 0xb9, 0x82, 0xbe, 0x13, 0xa3, 0x17, 0x00, 0xf9, 0x59, 0xe6, 0x13, 0xad, 0x72, 0x5c,
-	0x3a, 0x69, 0xd2, 0x66, 0xc6, 0xd8, 0x13, 0xd4, 0x81, 0x2a, 0x7f, 0x10, 0x2b, 0xd5, 0x74, 0xa5,
-	0xc2, 0x1f, 0xec, 0x89, 0x08, 0x8e, 0xad, 0xa2, 0xf1, 0x4c, 0xab, 0xc9, 0x68, 0x53, 0x20, 0xa6,
-	0xc7, 0x1e, 0x38, 0x0b, 0x53, 0x7f, 0x75, 0x39, 0xbd, 0x82, 0x40, 0x3a, 0xb4, 0xf9, 0x22, 0xf1,
-	0xc7, 0x2c, 0xe6, 0xfe, 0x2c, 0x48, 0x66, 0x5a, 0x23, 0x55, 0xb4, 0xf8, 0x22, 0x31, 0x59, 0xcc,
-	0xaf, 0x83, 0x64, 0xa6, 0x1b, 0xb0, 0xe7, 0x3e, 0x89, 0x44, 0x83, 0xfa, 0x38, 0x66, 0x01, 0x8f,
-	0xf2, 0x19, 0xe7, 0x50, 0x98, 0x08, 0xa3, 0x70, 0x9c, 0x07, 0x25, 0x81, 0x8e, 0xa1, 0x3e, 0x08,
-	0x1e, 0x17, 0x51, 0x30, 0x41, 0x9f, 0x41, 0x6d, 0x23, 0x9d, 0xd6, 0xc5, 0x6e, 0x7e, 0x89, 0x64,
-	0x69, 0x9a, 0xad, 0x8a, 0x49, 0x8b, 0x1b, 0x93, 0xd5, 0x49, 0x9f, 0xf5, 0x1e, 0x34, 0x70, 0x78,
-	0xcf, 0x16, 0x91, 0x9c, 0xfa, 0x4a, 0x96, 0xcc, 0x2d, 0x64, 0xf0, 0x7f, 0xee, 0xcb, 0x4f, 0x25,
-	0xa8, 0xf6, 0x16, 0xd1, 0xf8, 0x07, 0x74, 0xf6, 0xc4, 0x49, 0x27, 0x77, 0x92, 0x2e, 0x3f, 0xb1,
-	0xf3, 0x6a, 0xc3, 0x4e, 0xeb, 0x62, 0x7f, 0x4b, 0x6a, 0x05, 0x3c, 0x90, 0x0e, 0xd1, 0x17, 0xd0,
-	0x58, 0x66, 0x77, 0x3d, 0x0b, 0xfc, 0x70, 0x4b, 0x9a, 0xff, 0x11, 0x68, 0x21, 0xd3, 0xa7, 0xd0,
-	0xda, 0x68, 0x88, 0x3e, 0x80, 0x5a, 0xb8, 0x5e, 0x8e, 0x32, 0x57, 0x15, 0x9a, 0x21, 0xf4, 0x09,
-	0xb4, 0x57, 0x31, 0xbb, 0x9f, 0x47, 0xeb, 0x44, 0x26, 0x25, 0x4f, 0xb6, 0x93, 0x93, 0x22, 0x2a,
-	0xf4, 0x11, 0x34, 0x45, 0x4d, 0x29, 0x50, 0x52, 0x41, 0x43, 0x10, 0x69, 0x8e, 0x2f, 0xa1, 0x59,
-	0xd8, 0x2d, 0xc6, 0x5b, 0x3a, 0x56, 0x8a, 0xf1, 0x9e, 0x41, 0x7b, 0xcb, 0x24, 0x3a, 0xda, 0x38,
-	0x8d, 0x14, 0x16, 0xf8, 0xf4, 0xf7, 0x12, 0xd4, 0x5c, 0x1e, 0xf0, 0x75, 0x82, 0x5a, 0x50, 0x1f,
-	0x92, 0x1b, 0xe2, 0x7c, 0x4b, 0xd4, 0x67, 0x68, 0x07, 0xea, 0xee, 0xd0, 0x34, 0xb1, 0xeb, 0xaa,
-	0x7f, 0x94, 0x90, 0x0a, 0xad, 0x9e, 0x61, 0xf9, 0x14, 0x7f, 0x33, 0xc4, 0xae, 0xa7, 0xfe, 0xac,
-	0xa0, 0x5d, 0x68, 0x5e, 0x3a, 0xb4, 0x67, 0x5b, 0x16, 0x26, 0xea, 0x2f, 0x29, 0x26, 0x8e, 0xe7,
-	0x5f, 0x3a, 0x43, 0x62, 0xa9, 0xbf, 0x2a, 0xe8, 0x05, 0x68, 0x99, 0xda, 0xc7, 0xc4, 0xb3, 0xbd,
-	0xef, 0x7c, 0xcf, 0x71, 0xfc, 0xbe, 0x41, 0xaf, 0xb0, 0xfa, 0x9b, 0x82, 0x8e, 0xe0, 0xd0, 0x26,
-	0x1e, 0xa6, 0xc4, 0xe8, 0xfb, 0x2e, 0xa6, 0xaf, 0x31, 0xf5, 0x31, 0xa5, 0x0e, 0x55, 0xff, 0x52,
-	0xd0, 0x01, 0xec, 0x89, 0x52, 0xf6, 0xed, 0xa0, 0x8f, 0x6f, 0x31, 0xf1, 0xb0, 0xa5, 0xfe, 0xad,
-	0x20, 0x0d, 0x3a, 0x42, 0x68, 0x9b, 0xd8, 0x1f, 0x12, 0xe3, 0xb5, 0x61, 0xf7, 0x8d, 0x5e, 0x1f,
-	0xab, 0xff, 0x28, 0xa7, 0x7f, 0x96, 0x00, 0xe4, 0xd4, 0x3d, 0xf1, 0x3f, 0x6e, 0x41, 0xfd, 0x16,
-	0xbb, 0xae, 0x71, 0x85, 0xd5, 0x67, 0x08, 0xa0, 0x66, 0x3a, 0xe4, 0xd2, 0xbe, 0x52, 0x4b, 0x68,
-	0x1f, 0xda, 0xf2, 0xd9, 0x1f, 0x0e, 0x2c, 0xc3, 0xc3, 0x6a, 0x19, 0x69, 0x70, 0x80, 0x89, 0xe5,
-	0x50, 0x17, 0x53, 0xdf, 0xa3, 0x06, 0x71, 0x0d, 0xd3, 0xb3, 0x1d, 0xa2, 0x2a, 0xe8, 0x39, 0x74,
-	0x1c, 0x6a, 0x61, 0xfa, 0x64, 0xa1, 0x82, 0x0e, 0x61, 0xdf, 0xc2, 0x7d, 0x5b, 0x38, 0x76, 0x31,
-	0xbe, 0xf1, 0x6d, 0x72, 0xe9, 0xa8, 0x55, 0x41, 0x9b, 0xd7, 0x86, 0x4d, 0x4c, 0xc7, 0xc2, 0xfe,
-	0xc0, 0x30, 0x6f, 0x44, 0xff, 0x9a, 0x68, 0x30, 0xc0, 0x98, 0xfa, 0x86, 0x75, 0x6b, 0x13, 0xdf,
-	0x19, 0x60, 0x6a, 0xa4, 0x75, 0x1a, 0x62, 0x83, 0xe7, 0xdc, 0x60, 0xb2, 0x55, 0xbe, 0x79, 0xfa,
-	0x16, 0xd0, 0x56, 0x78, 0xb6, 0x78, 0xb1, 0xa3, 0x5d, 0x00, 0xd7, 0xbe, 0x22, 0x86, 0x37, 0xa4,
-	0xd8, 0x55, 0x9f, 0xa1, 0x3d, 0x68, 0xf5, 0x0d, 0xd7, 0xf3, 0x8b, 0xb3, 0x3d, 0x87, 0xce, 0x46,
-	0x1d, 0xd7, 0xbf, 0xb4, 0xfb, 0x1e, 0xa6, 0x6a, 0x59, 0x4c, 0x23, 0x3b, 0x87, 0xaa, 0xf4, 0x5c,
-	0xf8, 0x34, 0x8a, 0xa7, 0xdd, 0xd9, 0xe3, 0x8a, 0xc5, 0x0b, 0x36, 0x99, 0xb2, 0xb8, 0x7b, 0x17,
-	0x8c, 0xe2, 0xf9, 0x58, 0xbe, 0xc6, 0x92, 0xec, 0x8e, 0xbf, 0x39, 0x9b, 0xce, 0xf9, 0x6c, 0x3d,
-	0x12, 0xf0, 0x7c, 0x43, 0x7c, 0x2e, 0xc5, 0xf2, 0x1b, 0x95, 0x64, 0xdf, 0xb1, 0x51, 0x2d, 0x85,
-	0x5f, 0xfe, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x63, 0x9b, 0x9f, 0x8b, 0xdf, 0x06, 0x00, 0x00,
+func init() { proto.RegisterFile("common/common.proto", fileDescriptor_common_8f6fc7ea87c85c64) }
+
+var fileDescriptor_common_8f6fc7ea87c85c64 = []byte{
+	// 1018 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x55, 0x5d, 0x6f, 0xe3, 0x44,
+	0x14, 0xdd, 0xd4, 0xf9, 0xbc, 0xd9, 0xb4, 0xee, 0x64, 0xcb, 0x9a, 0xc2, 0x6a, 0x23, 0xc3, 0xa2,
+	0xd2, 0x4a, 0xa9, 0xb6, 0xbc, 0xc0, 0xa3, 0x13, 0x4f, 0x5b, 0xab, 0xa9, 0x1d, 0xc6, 0x4e, 0x11,
+	0xbb, 0x48, 0x23, 0x27, 0x99, 0x26, 0x16, 0x89, 0x1d, 0xd9, 0x93, 0xaa, 0xfd, 0x13, 0x08, 0x09,
+	0xc4, 0x1b, 0xff, 0x85, 0x47, 0xc4, 0xef, 0x01, 0xf1, 0x8a, 0xc6, 0x63, 0x7b, 0x93, 0xb2, 0xd2,
+	0x3e, 0xc5, 0xe7, 0xde, 0x33, 0xf7, 0x9e, 0xb9, 0xf7, 0xc4, 0x86, 0xf6, 0x24, 0x5a, 0x2e, 0xa3,
+	0xf0, 0x54, 0xfe, 0x74, 0x57, 0x71, 0xc4, 0x23, 0x54, 0x95, 0xe8, 0xf0, 0xe5, 0x2c, 0x8a, 0x66,
+	0x0b, 0x76, 0x9a, 0x46, 0xc7, 0xeb, 0xdb, 0x53, 0x1e, 0x2c, 0x59, 0xc2, 0xfd, 0xe5, 0x4a, 0x12,
+	0x75, 0x1d, 0x60, 0xe0, 0x27, 0xbc, 0x1f, 0x85, 0xb7, 0xc1, 0x0c, 0x3d, 0x83, 0x4a, 0x10, 0x4e,
+	0xd9, 0xbd, 0x56, 0xea, 0x94, 0x8e, 0xca, 0x44, 0x02, 0xfd, 0x2d, 0xd4, 0xaf, 0x19, 0xf7, 0xa7,
+	0x3e, 0xf7, 0x05, 0xe3, 0xce, 0x5f, 0xac, 0x59, 0xca, 0x78, 0x4a, 0x24, 0x40, 0xdf, 0x00, 0x24,
+	0xc1, 0x2c, 0xf4, 0xf9, 0x3a, 0x66, 0x89, 0xb6, 0xd3, 0x51, 0x8e, 0x9a, 0x67, 0x1f, 0x77, 0x33,
+	0x45, 0xf9, 0x59, 0x37, 0x67, 0x90, 0x0d, 0xb2, 0xfe, 0x03, 0xec, 0xff, 0x8f, 0x80, 0xbe, 0x04,
+	0xb5, 0xa0, 0xd0, 0x39, 0xf3, 0xa7, 0x2c, 0xce, 0x1a, 0xee, 0x15, 0xf1, 0xcb, 0x34, 0x8c, 0x3e,
+	0x85, 0x46, 0x11, 0xd2, 0x76, 0x52, 0xce, 0xbb, 0x80, 0xfe, 0x06, 0xaa, 0x19, 0xef, 0x15, 0xec,
+	0x4e, 0xe6, 0x7e, 0x18, 0xb2, 0xc5, 0x76, 0xc1, 0x56, 0x16, 0xcd, 0x68, 0xef, 0xeb, 0xbc, 0xf3,
+	0xde, 0xce, 0xfa, 0x6f, 0x0a, 0xb4, 0xfa, 0x5b, 0x87, 0x11, 0x94, 0xf9, 0xc3, 0x4a, 0xce, 0xa6,
+	0x42, 0xd2, 0x67, 0xa4, 0x41, 0xed, 0x8e, 0xc5, 0x49, 0x10, 0x85, 0x69, 0x9d, 0x0a, 0xc9, 0x21,
+	0xfa, 0x1a, 0x1a, 0xc5, 0x36, 0x34, 0xa5, 0x53, 0x3a, 0x6a, 0x9e, 0x1d, 0x76, 0xe5, 0xbe, 0xba,
+	0xf9, 0xbe, 0xba, 0x5e, 0xce, 0x20, 0xef, 0xc8, 0xe8, 0x05, 0x40, 0x7e, 0x97, 0x60, 0xaa, 0x95,
+	0x3b, 0xa5, 0xa3, 0x06, 0x69, 0x64, 0x11, 0x6b, 0x8a, 0xda, 0x50, 0xe1, 0xf7, 0x22, 0x53, 0x49,
+	0x33, 0x65, 0x7e, 0x6f, 0x4d, 0xc5, 0xe2, 0xd8, 0x2a, 0x9a, 0xcc, 0xb5, 0xaa, 0x5c, 0x6d, 0x0a,
+	0xc4, 0xf4, 0xd8, 0x3d, 0x67, 0x61, 0xaa, 0xaf, 0x26, 0xa7, 0x57, 0x04, 0x90, 0x0e, 0x2d, 0xbe,
+	0x48, 0xe8, 0x84, 0xc5, 0x9c, 0xce, 0xfd, 0x64, 0xae, 0xd5, 0x53, 0x46, 0x93, 0x2f, 0x92, 0x3e,
+	0x8b, 0xf9, 0xa5, 0x9f, 0xcc, 0xd1, 0x21, 0xd4, 0x57, 0x71, 0x10, 0xc5, 0x01, 0x7f, 0xd0, 0x1a,
+	0x9d, 0xd2, 0x51, 0x8b, 0x14, 0x18, 0xbd, 0x86, 0x83, 0x30, 0xe2, 0xf4, 0xce, 0x5f, 0x04, 0x53,
+	0xea, 0xdf, 0x72, 0x16, 0xd3, 0xf1, 0x22, 0x9a, 0xfc, 0xa8, 0x41, 0xaa, 0x01, 0x85, 0x11, 0xbf,
+	0x11, 0x39, 0x43, 0xa4, 0x7a, 0x22, 0x83, 0x7a, 0xb0, 0xf7, 0xe8, 0x88, 0xd6, 0xfc, 0xe0, 0x68,
+	0x5a, 0x5b, 0x85, 0x74, 0x03, 0xf6, 0xdc, 0x47, 0x2e, 0xd1, 0xa0, 0x36, 0x89, 0x99, 0xcf, 0xa3,
+	0x7c, 0xed, 0x39, 0x14, 0x73, 0x09, 0xa3, 0x70, 0x92, 0x7b, 0x47, 0x02, 0x1d, 0x43, 0x6d, 0xe8,
+	0x3f, 0x2c, 0x22, 0x7f, 0x8a, 0xbe, 0x80, 0xea, 0x86, 0x61, 0x9a, 0x67, 0xbb, 0xb9, 0xaf, 0x65,
+	0x69, 0x52, 0x9d, 0x17, 0xcb, 0x17, 0x26, 0xce, 0xea, 0xa4, 0xcf, 0x7a, 0x0f, 0xea, 0x38, 0xbc,
+	0x63, 0x8b, 0x48, 0x1a, 0x61, 0x25, 0x4b, 0xe6, 0x12, 0x32, 0xf8, 0x01, 0x0b, 0xff, 0x54, 0x82,
+	0x8a, 0x9c, 0xcd, 0xc9, 0x23, 0x25, 0xed, 0x5c, 0x49, 0x9a, 0x7e, 0x24, 0xe7, 0xd5, 0x86, 0x9c,
+	0xe6, 0xd9, 0xfe, 0x16, 0xd5, 0xf4, 0xb9, 0x2f, 0x15, 0xa2, 0xd7, 0x50, 0x5f, 0x66, 0x7f, 0xbf,
+	0xcc, 0x83, 0x07, 0x5b, 0xd4, 0xfc, 0xbf, 0x49, 0x0a, 0x9a, 0x3e, 0x83, 0xe6, 0x46, 0x43, 0xf4,
+	0x11, 0x54, 0xc3, 0xf5, 0x72, 0x9c, 0xa9, 0x2a, 0x93, 0x0c, 0xa1, 0xcf, 0xa0, 0xb5, 0x8a, 0xd9,
+	0x5d, 0x10, 0xad, 0x13, 0x69, 0x1e, 0x79, 0xb3, 0xa7, 0x79, 0x30, 0x75, 0xcf, 0x27, 0xd0, 0x10,
+	0x35, 0x25, 0x41, 0x49, 0x09, 0x75, 0x11, 0x10, 0x49, 0xfd, 0x25, 0x34, 0x0a, 0xb9, 0xc5, 0x78,
+	0x4b, 0x1d, 0xa5, 0x18, 0xef, 0x09, 0xb4, 0xb6, 0x44, 0x0a, 0x33, 0x16, 0xb7, 0x91, 0xc4, 0x02,
+	0x1f, 0xff, 0x51, 0x82, 0xaa, 0xcb, 0x7d, 0xbe, 0x4e, 0x50, 0x13, 0x6a, 0x23, 0xfb, 0xca, 0x76,
+	0xbe, 0xb3, 0xd5, 0x27, 0xe8, 0x29, 0xd4, 0xdc, 0x51, 0xbf, 0x8f, 0x5d, 0x57, 0xfd, 0xb3, 0x84,
+	0x54, 0x68, 0xf6, 0x0c, 0x93, 0x12, 0xfc, 0xed, 0x08, 0xbb, 0x9e, 0xfa, 0xb3, 0x82, 0x76, 0xa1,
+	0x71, 0xee, 0x90, 0x9e, 0x65, 0x9a, 0xd8, 0x56, 0x7f, 0x49, 0xb1, 0xed, 0x78, 0xf4, 0xdc, 0x19,
+	0xd9, 0xa6, 0xfa, 0xab, 0x82, 0x5e, 0x80, 0x96, 0xb1, 0x29, 0xb6, 0x3d, 0xcb, 0xfb, 0x9e, 0x7a,
+	0x8e, 0x43, 0x07, 0x06, 0xb9, 0xc0, 0xea, 0xef, 0x0a, 0x3a, 0x84, 0x03, 0xcb, 0xf6, 0x30, 0xb1,
+	0x8d, 0x01, 0x75, 0x31, 0xb9, 0xc1, 0x84, 0x62, 0x42, 0x1c, 0xa2, 0xfe, 0xad, 0xa0, 0x67, 0xb0,
+	0x27, 0x4a, 0x59, 0xd7, 0xc3, 0x01, 0xbe, 0xc6, 0xb6, 0x87, 0x4d, 0xf5, 0x1f, 0x05, 0x69, 0xd0,
+	0x16, 0x44, 0xab, 0x8f, 0xe9, 0xc8, 0x36, 0x6e, 0x0c, 0x6b, 0x60, 0xf4, 0x06, 0x58, 0xfd, 0x57,
+	0x39, 0xfe, 0xab, 0x04, 0x20, 0xa7, 0xee, 0x89, 0x57, 0x4b, 0x13, 0x6a, 0xd7, 0xd8, 0x75, 0x8d,
+	0x0b, 0xac, 0x3e, 0x41, 0x00, 0xd5, 0xbe, 0x63, 0x9f, 0x5b, 0x17, 0x6a, 0x09, 0xed, 0x43, 0x4b,
+	0x3e, 0xd3, 0xd1, 0xd0, 0x34, 0x3c, 0xac, 0xee, 0x20, 0x0d, 0x9e, 0x61, 0xdb, 0x74, 0x88, 0x8b,
+	0x09, 0xf5, 0x88, 0x61, 0xbb, 0x46, 0xdf, 0xb3, 0x1c, 0x5b, 0x55, 0xd0, 0x73, 0x68, 0x3b, 0xc4,
+	0xc4, 0xe4, 0x51, 0xa2, 0x8c, 0x0e, 0x60, 0xdf, 0xc4, 0x03, 0x4b, 0x28, 0x76, 0x31, 0xbe, 0xa2,
+	0x96, 0x7d, 0xee, 0xa8, 0x15, 0x11, 0xee, 0x5f, 0x1a, 0x96, 0xdd, 0x77, 0x4c, 0x4c, 0x87, 0x46,
+	0xff, 0x4a, 0xf4, 0xaf, 0x8a, 0x06, 0x43, 0x8c, 0x09, 0x35, 0xcc, 0x6b, 0xcb, 0xa6, 0xce, 0x10,
+	0x13, 0x23, 0xad, 0x53, 0x17, 0x07, 0x3c, 0xe7, 0x0a, 0xdb, 0x5b, 0xe5, 0x1b, 0xc7, 0x6f, 0x01,
+	0x6d, 0x2d, 0xcf, 0x12, 0xdf, 0x1a, 0xb4, 0x0b, 0xe0, 0x5a, 0x17, 0xb6, 0xe1, 0x8d, 0x08, 0x76,
+	0xd5, 0x27, 0x68, 0x0f, 0x9a, 0x03, 0xc3, 0xf5, 0x68, 0x71, 0xb7, 0xe7, 0xd0, 0xde, 0xa8, 0xe3,
+	0xd2, 0x73, 0x6b, 0xe0, 0x61, 0xa2, 0xee, 0x88, 0x69, 0x64, 0xf7, 0x50, 0x95, 0x9e, 0x0b, 0x9f,
+	0x47, 0xf1, 0xac, 0x3b, 0x7f, 0x58, 0xb1, 0x78, 0xc1, 0xa6, 0x33, 0x16, 0x77, 0x6f, 0xfd, 0x71,
+	0x1c, 0x4c, 0xe4, 0xeb, 0x23, 0xc9, 0x3c, 0xfe, 0xe6, 0x64, 0x16, 0xf0, 0xf9, 0x7a, 0x2c, 0xe0,
+	0xe9, 0x06, 0xf9, 0x54, 0x92, 0xe5, 0x67, 0x33, 0xc9, 0x3e, 0xad, 0xe3, 0x6a, 0x0a, 0xbf, 0xfa,
+	0x6f, 0x00, 0x2b, 0x92, 0x45, 0x97, 0x72, 0x07, 0x00, 0x00,
 }
@@ -47,7 +47,7 @@ func (x PullMsgType) String() string {
 	return proto.EnumName(PullMsgType_name, int32(x))
 }
 func (PullMsgType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{0}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{0}
 }
 
 type GossipMessage_Tag int32
@@ -82,7 +82,7 @@ func (x GossipMessage_Tag) String() string {
 	return proto.EnumName(GossipMessage_Tag_name, int32(x))
 }
 func (GossipMessage_Tag) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{3, 0}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{3, 0}
 }
 
 // Envelope contains a marshalled
@@ -102,7 +102,7 @@ func (m *Envelope) Reset()         { *m = Envelope{} }
 func (m *Envelope) String() string { return proto.CompactTextString(m) }
 func (*Envelope) ProtoMessage()    {}
 func (*Envelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{0}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{0}
 }
 func (m *Envelope) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Envelope.Unmarshal(m, b)
@@ -160,7 +160,7 @@ func (m *SecretEnvelope) Reset()         { *m = SecretEnvelope{} }
 func (m *SecretEnvelope) String() string { return proto.CompactTextString(m) }
 func (*SecretEnvelope) ProtoMessage()    {}
 func (*SecretEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{1}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{1}
 }
 func (m *SecretEnvelope) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SecretEnvelope.Unmarshal(m, b)
@@ -210,7 +210,7 @@ func (m *Secret) Reset()         { *m = Secret{} }
 func (m *Secret) String() string { return proto.CompactTextString(m) }
 func (*Secret) ProtoMessage()    {}
 func (*Secret) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{2}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{2}
 }
 func (m *Secret) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Secret.Unmarshal(m, b)
@@ -349,7 +349,7 @@ func (m *GossipMessage) Reset()         { *m = GossipMessage{} }
 func (m *GossipMessage) String() string { return proto.CompactTextString(m) }
 func (*GossipMessage) ProtoMessage()    {}
 func (*GossipMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{3}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{3}
 }
 func (m *GossipMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GossipMessage.Unmarshal(m, b)
@@ -1128,7 +1128,7 @@ func (m *StateInfo) Reset()         { *m = StateInfo{} }
 func (m *StateInfo) String() string { return proto.CompactTextString(m) }
 func (*StateInfo) ProtoMessage()    {}
 func (*StateInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{4}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{4}
 }
 func (m *StateInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateInfo.Unmarshal(m, b)
@@ -1180,6 +1180,7 @@ type Properties struct {
 	LedgerHeight         uint64       `protobuf:"varint,1,opt,name=ledger_height,json=ledgerHeight,proto3" json:"ledger_height,omitempty"`
 	LeftChannel          bool         `protobuf:"varint,2,opt,name=left_channel,json=leftChannel,proto3" json:"left_channel,omitempty"`
 	Chaincodes           []*Chaincode `protobuf:"bytes,3,rep,name=chaincodes,proto3" json:"chaincodes,omitempty"`
+	QueryOnly            bool         `protobuf:"varint,4,opt,name=query_only,json=queryOnly,proto3" json:"query_only,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
 	XXX_unrecognized     []byte       `json:"-"`
 	XXX_sizecache        int32        `json:"-"`
@@ -1189,7 +1190,7 @@ func (m *Properties) Reset()         { *m = Properties{} }
 func (m *Properties) String() string { return proto.CompactTextString(m) }
 func (*Properties) ProtoMessage()    {}
 func (*Properties) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{5}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{5}
 }
 func (m *Properties) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Properties.Unmarshal(m, b)
@@ -1230,6 +1231,13 @@ func (m *Properties) GetChaincodes() []*Chaincode {
 	return nil
 }
 
+func (m *Properties) GetQueryOnly() bool {
+	if m != nil {
+		return m.QueryOnly
+	}
+	return false
+}
+
 // StateInfoSnapshot is an aggregation of StateInfo messages
 type StateInfoSnapshot struct {
 	Elements             []*Envelope `protobuf:"bytes,1,rep,name=elements,proto3" json:"elements,omitempty"`
@@ -1242,7 +1250,7 @@ func (m *StateInfoSnapshot) Reset()         { *m = StateInfoSnapshot{} }
 func (m *StateInfoSnapshot) String() string { return proto.CompactTextString(m) }
 func (*StateInfoSnapshot) ProtoMessage()    {}
 func (*StateInfoSnapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{6}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{6}
 }
 func (m *StateInfoSnapshot) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateInfoSnapshot.Unmarshal(m, b)
@@ -1285,7 +1293,7 @@ func (m *StateInfoPullRequest) Reset()         { *m = StateInfoPullRequest{} }
 func (m *StateInfoPullRequest) String() string { return proto.CompactTextString(m) }
 func (*StateInfoPullRequest) ProtoMessage()    {}
 func (*StateInfoPullRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{7}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{7}
 }
 func (m *StateInfoPullRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateInfoPullRequest.Unmarshal(m, b)
@@ -1328,7 +1336,7 @@ func (m *ConnEstablish) Reset()         { *m = ConnEstablish{} }
 func (m *ConnEstablish) String() string { return proto.CompactTextString(m) }
 func (*ConnEstablish) ProtoMessage()    {}
 func (*ConnEstablish) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{8}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{8}
 }
 func (m *ConnEstablish) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConnEstablish.Unmarshal(m, b)
@@ -1385,7 +1393,7 @@ func (m *PeerIdentity) Reset()         { *m = PeerIdentity{} }
 func (m *PeerIdentity) String() string { return proto.CompactTextString(m) }
 func (*PeerIdentity) ProtoMessage()    {}
 func (*PeerIdentity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{9}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{9}
 }
 func (m *PeerIdentity) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PeerIdentity.Unmarshal(m, b)
@@ -1441,7 +1449,7 @@ func (m *DataRequest) Reset()         { *m = DataRequest{} }
 func (m *DataRequest) String() string { return proto.CompactTextString(m) }
 func (*DataRequest) ProtoMessage()    {}
 func (*DataRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{10}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{10}
 }
 func (m *DataRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DataRequest.Unmarshal(m, b)
@@ -1497,7 +1505,7 @@ func (m *GossipHello) Reset()         { *m = GossipHello{} }
 func (m *GossipHello) String() string { return proto.CompactTextString(m) }
 func (*GossipHello) ProtoMessage()    {}
 func (*GossipHello) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{11}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{11}
 }
 func (m *GossipHello) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GossipHello.Unmarshal(m, b)
@@ -1553,7 +1561,7 @@ func (m *DataUpdate) Reset()         { *m = DataUpdate{} }
 func (m *DataUpdate) String() string { return proto.CompactTextString(m) }
 func (*DataUpdate) ProtoMessage()    {}
 func (*DataUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{12}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{12}
 }
 func (m *DataUpdate) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DataUpdate.Unmarshal(m, b)
@@ -1609,7 +1617,7 @@ func (m *DataDigest) Reset()         { *m = DataDigest{} }
 func (m *DataDigest) String() string { return proto.CompactTextString(m) }
 func (*DataDigest) ProtoMessage()    {}
 func (*DataDigest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{13}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{13}
 }
 func (m *DataDigest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DataDigest.Unmarshal(m, b)
@@ -1662,7 +1670,7 @@ func (m *DataMessage) Reset()         { *m = DataMessage{} }
 func (m *DataMessage) String() string { return proto.CompactTextString(m) }
 func (*DataMessage) ProtoMessage()    {}
 func (*DataMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{14}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{14}
 }
 func (m *DataMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DataMessage.Unmarshal(m, b)
@@ -1703,7 +1711,7 @@ func (m *PrivateDataMessage) Reset()         { *m = PrivateDataMessage{} }
 func (m *PrivateDataMessage) String() string { return proto.CompactTextString(m) }
 func (*PrivateDataMessage) ProtoMessage()    {}
 func (*PrivateDataMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{15}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{15}
 }
 func (m *PrivateDataMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PrivateDataMessage.Unmarshal(m, b)
@@ -1744,7 +1752,7 @@ func (m *Payload) Reset()         { *m = Payload{} }
 func (m *Payload) String() string { return proto.CompactTextString(m) }
 func (*Payload) ProtoMessage()    {}
 func (*Payload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{16}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{16}
 }
 func (m *Payload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Payload.Unmarshal(m, b)
@@ -1804,7 +1812,7 @@ func (m *PrivatePayload) Reset()         { *m = PrivatePayload{} }
 func (m *PrivatePayload) String() string { return proto.CompactTextString(m) }
 func (*PrivatePayload) ProtoMessage()    {}
 func (*PrivatePayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{17}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{17}
 }
 func (m *PrivatePayload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PrivatePayload.Unmarshal(m, b)
@@ -1881,7 +1889,7 @@ func (m *AliveMessage) Reset()         { *m = AliveMessage{} }
 func (m *AliveMessage) String() string { return proto.CompactTextString(m) }
 func (*AliveMessage) ProtoMessage()    {}
 func (*AliveMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{18}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{18}
 }
 func (m *AliveMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AliveMessage.Unmarshal(m, b)
@@ -1937,7 +1945,7 @@ func (m *LeadershipMessage) Reset()         { *m = LeadershipMessage{} }
 func (m *LeadershipMessage) String() string { return proto.CompactTextString(m) }
 func (*LeadershipMessage) ProtoMessage()    {}
 func (*LeadershipMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{19}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{19}
 }
 func (m *LeadershipMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LeadershipMessage.Unmarshal(m, b)
@@ -1991,7 +1999,7 @@ func (m *PeerTime) Reset()         { *m = PeerTime{} }
 func (m *PeerTime) String() string { return proto.CompactTextString(m) }
 func (*PeerTime) ProtoMessage()    {}
 func (*PeerTime) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{20}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{20}
 }
 func (m *PeerTime) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PeerTime.Unmarshal(m, b)
@@ -2039,7 +2047,7 @@ func (m *MembershipRequest) Reset()         { *m = MembershipRequest{} }
 func (m *MembershipRequest) String() string { return proto.CompactTextString(m) }
 func (*MembershipRequest) ProtoMessage()    {}
 func (*MembershipRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{21}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{21}
 }
 func (m *MembershipRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MembershipRequest.Unmarshal(m, b)
@@ -2086,7 +2094,7 @@ func (m *MembershipResponse) Reset()         { *m = MembershipResponse{} }
 func (m *MembershipResponse) String() string { return proto.CompactTextString(m) }
 func (*MembershipResponse) ProtoMessage()    {}
 func (*MembershipResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{22}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{22}
 }
 func (m *MembershipResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MembershipResponse.Unmarshal(m, b)
@@ -2123,19 +2131,20 @@ func (m *MembershipResponse) GetDead() []*Envelope {
 // Member holds membership-related information
 // about a peer
 type Member struct {
-	Endpoint             string   `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
-	Metadata             []byte   `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	PkiId                []byte   `protobuf:"bytes,3,opt,name=pki_id,json=pkiId,proto3" json:"pki_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Endpoint             string                `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Metadata             []byte                `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	PkiId                []byte                `protobuf:"bytes,3,opt,name=pki_id,json=pkiId,proto3" json:"pki_id,omitempty"`
+	AdvertisedEndpoints  []*AdvertisedEndpoint `protobuf:"bytes,4,rep,name=advertised_endpoints,json=advertisedEndpoints,proto3" json:"advertised_endpoints,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *Member) Reset()         { *m = Member{} }
 func (m *Member) String() string { return proto.CompactTextString(m) }
 func (*Member) ProtoMessage()    {}
 func (*Member) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{23}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{23}
 }
 func (m *Member) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Member.Unmarshal(m, b)
@@ -2176,6 +2185,83 @@ func (m *Member) GetPkiId() []byte {
 	return nil
 }
 
+func (m *Member) GetAdvertisedEndpoints() []*AdvertisedEndpoint {
+	if m != nil {
+		return m.AdvertisedEndpoints
+	}
+	return nil
+}
+
+// AdvertisedEndpoint is an additional endpoint a peer
+// publishes to peers of foreign organizations
+type AdvertisedEndpoint struct {
+	Endpoint string `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// Peers use the endpoint with the highest priority
+	// among the ones advertised to their organization
+	Priority int32 `protobuf:"varint,2,opt,name=priority,proto3" json:"priority,omitempty"`
+	// Deprecated endpoints are used only when no other
+	// endpoint is advertised to the organization
+	Deprecated bool `protobuf:"varint,3,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	// Orgs restricts the organizations the endpoint is
+	// advertised to; empty means all organizations
+	Orgs                 []string `protobuf:"bytes,4,rep,name=orgs,proto3" json:"orgs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AdvertisedEndpoint) Reset()         { *m = AdvertisedEndpoint{} }
+func (m *AdvertisedEndpoint) String() string { return proto.CompactTextString(m) }
+func (*AdvertisedEndpoint) ProtoMessage()    {}
+func (*AdvertisedEndpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{24}
+}
+func (m *AdvertisedEndpoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AdvertisedEndpoint.Unmarshal(m, b)
+}
+func (m *AdvertisedEndpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AdvertisedEndpoint.Marshal(b, m, deterministic)
+}
+func (dst *AdvertisedEndpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AdvertisedEndpoint.Merge(dst, src)
+}
+func (m *AdvertisedEndpoint) XXX_Size() int {
+	return xxx_messageInfo_AdvertisedEndpoint.Size(m)
+}
+func (m *AdvertisedEndpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_AdvertisedEndpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AdvertisedEndpoint proto.InternalMessageInfo
+
+func (m *AdvertisedEndpoint) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *AdvertisedEndpoint) GetPriority() int32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *AdvertisedEndpoint) GetDeprecated() bool {
+	if m != nil {
+		return m.Deprecated
+	}
+	return false
+}
+
+func (m *AdvertisedEndpoint) GetOrgs() []string {
+	if m != nil {
+		return m.Orgs
+	}
+	return nil
+}
+
 // Empty is used for pinging and in tests
 type Empty struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -2187,7 +2273,7 @@ func (m *Empty) Reset()         { *m = Empty{} }
 func (m *Empty) String() string { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()    {}
 func (*Empty) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{24}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{25}
 }
 func (m *Empty) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Empty.Unmarshal(m, b)
@@ -2221,7 +2307,7 @@ func (m *RemoteStateRequest) Reset()         { *m = RemoteStateRequest{} }
 func (m *RemoteStateRequest) String() string { return proto.CompactTextString(m) }
 func (*RemoteStateRequest) ProtoMessage()    {}
 func (*RemoteStateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{25}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{26}
 }
 func (m *RemoteStateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemoteStateRequest.Unmarshal(m, b)
@@ -2256,19 +2342,22 @@ func (m *RemoteStateRequest) GetEndSeqNum() uint64 {
 }
 
 // RemoteStateResponse is used to send a set of blocks
-// to a remote peer
+// to a remote peer. A block too large to be sent in a single
+// message is sent instead as a sequence of responses, each
+// carrying one chunk of the block.
 type RemoteStateResponse struct {
-	Payloads             []*Payload `protobuf:"bytes,1,rep,name=payloads,proto3" json:"payloads,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Payloads             []*Payload  `protobuf:"bytes,1,rep,name=payloads,proto3" json:"payloads,omitempty"`
+	Chunk                *BlockChunk `protobuf:"bytes,2,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *RemoteStateResponse) Reset()         { *m = RemoteStateResponse{} }
 func (m *RemoteStateResponse) String() string { return proto.CompactTextString(m) }
 func (*RemoteStateResponse) ProtoMessage()    {}
 func (*RemoteStateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{26}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{27}
 }
 func (m *RemoteStateResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemoteStateResponse.Unmarshal(m, b)
@@ -2295,6 +2384,97 @@ func (m *RemoteStateResponse) GetPayloads() []*Payload {
 	return nil
 }
 
+func (m *RemoteStateResponse) GetChunk() *BlockChunk {
+	if m != nil {
+		return m.Chunk
+	}
+	return nil
+}
+
+// BlockChunk is a fragment of the marshaled Payload of a block.
+// The chunks of a payload are reassembled in order of index,
+// and each chunk and the reassembled payload are verified
+// against their digests.
+type BlockChunk struct {
+	SeqNum uint64 `protobuf:"varint,1,opt,name=seq_num,json=seqNum,proto3" json:"seq_num,omitempty"`
+	Index  uint32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Total  uint32 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Data   []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	// SHA256 digest of data
+	Digest []byte `protobuf:"bytes,5,opt,name=digest,proto3" json:"digest,omitempty"`
+	// SHA256 digest of the whole marshaled payload
+	PayloadDigest        []byte   `protobuf:"bytes,6,opt,name=payload_digest,json=payloadDigest,proto3" json:"payload_digest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockChunk) Reset()         { *m = BlockChunk{} }
+func (m *BlockChunk) String() string { return proto.CompactTextString(m) }
+func (*BlockChunk) ProtoMessage()    {}
+func (*BlockChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{28}
+}
+func (m *BlockChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockChunk.Unmarshal(m, b)
+}
+func (m *BlockChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockChunk.Marshal(b, m, deterministic)
+}
+func (dst *BlockChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockChunk.Merge(dst, src)
+}
+func (m *BlockChunk) XXX_Size() int {
+	return xxx_messageInfo_BlockChunk.Size(m)
+}
+func (m *BlockChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockChunk proto.InternalMessageInfo
+
+func (m *BlockChunk) GetSeqNum() uint64 {
+	if m != nil {
+		return m.SeqNum
+	}
+	return 0
+}
+
+func (m *BlockChunk) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *BlockChunk) GetTotal() uint32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *BlockChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *BlockChunk) GetDigest() []byte {
+	if m != nil {
+		return m.Digest
+	}
+	return nil
+}
+
+func (m *BlockChunk) GetPayloadDigest() []byte {
+	if m != nil {
+		return m.PayloadDigest
+	}
+	return nil
+}
+
 // RemotePrivateDataRequest message used to request
 // missing private rwset
 type RemotePvtDataRequest struct {
@@ -2308,7 +2488,7 @@ func (m *RemotePvtDataRequest) Reset()         { *m = RemotePvtDataRequest{} }
 func (m *RemotePvtDataRequest) String() string { return proto.CompactTextString(m) }
 func (*RemotePvtDataRequest) ProtoMessage()    {}
 func (*RemotePvtDataRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{27}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{29}
 }
 func (m *RemotePvtDataRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemotePvtDataRequest.Unmarshal(m, b)
@@ -2351,7 +2531,7 @@ func (m *PvtDataDigest) Reset()         { *m = PvtDataDigest{} }
 func (m *PvtDataDigest) String() string { return proto.CompactTextString(m) }
 func (*PvtDataDigest) ProtoMessage()    {}
 func (*PvtDataDigest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{28}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{30}
 }
 func (m *PvtDataDigest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PvtDataDigest.Unmarshal(m, b)
@@ -2419,7 +2599,7 @@ func (m *RemotePvtDataResponse) Reset()         { *m = RemotePvtDataResponse{} }
 func (m *RemotePvtDataResponse) String() string { return proto.CompactTextString(m) }
 func (*RemotePvtDataResponse) ProtoMessage()    {}
 func (*RemotePvtDataResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{29}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{31}
 }
 func (m *RemotePvtDataResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemotePvtDataResponse.Unmarshal(m, b)
@@ -2459,7 +2639,7 @@ func (m *PvtDataElement) Reset()         { *m = PvtDataElement{} }
 func (m *PvtDataElement) String() string { return proto.CompactTextString(m) }
 func (*PvtDataElement) ProtoMessage()    {}
 func (*PvtDataElement) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{30}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{32}
 }
 func (m *PvtDataElement) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PvtDataElement.Unmarshal(m, b)
@@ -2509,7 +2689,7 @@ func (m *PvtDataPayload) Reset()         { *m = PvtDataPayload{} }
 func (m *PvtDataPayload) String() string { return proto.CompactTextString(m) }
 func (*PvtDataPayload) ProtoMessage()    {}
 func (*PvtDataPayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{31}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{33}
 }
 func (m *PvtDataPayload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PvtDataPayload.Unmarshal(m, b)
@@ -2554,7 +2734,7 @@ func (m *Acknowledgement) Reset()         { *m = Acknowledgement{} }
 func (m *Acknowledgement) String() string { return proto.CompactTextString(m) }
 func (*Acknowledgement) ProtoMessage()    {}
 func (*Acknowledgement) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{32}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{34}
 }
 func (m *Acknowledgement) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Acknowledgement.Unmarshal(m, b)
@@ -2596,7 +2776,7 @@ func (m *Chaincode) Reset()         { *m = Chaincode{} }
 func (m *Chaincode) String() string { return proto.CompactTextString(m) }
 func (*Chaincode) ProtoMessage()    {}
 func (*Chaincode) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{33}
+	return fileDescriptor_message_e6f9f3ac4cb4ab03, []int{35}
 }
 func (m *Chaincode) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Chaincode.Unmarshal(m, b)
@@ -2662,9 +2842,11 @@ func init() {
 	proto.RegisterType((*MembershipRequest)(nil), "gossip.MembershipRequest")
 	proto.RegisterType((*MembershipResponse)(nil), "gossip.MembershipResponse")
 	proto.RegisterType((*Member)(nil), "gossip.Member")
+	proto.RegisterType((*AdvertisedEndpoint)(nil), "gossip.AdvertisedEndpoint")
 	proto.RegisterType((*Empty)(nil), "gossip.Empty")
 	proto.RegisterType((*RemoteStateRequest)(nil), "gossip.RemoteStateRequest")
 	proto.RegisterType((*RemoteStateResponse)(nil), "gossip.RemoteStateResponse")
+	proto.RegisterType((*BlockChunk)(nil), "gossip.BlockChunk")
 	proto.RegisterType((*RemotePvtDataRequest)(nil), "gossip.RemotePvtDataRequest")
 	proto.RegisterType((*PvtDataDigest)(nil), "gossip.PvtDataDigest")
 	proto.RegisterType((*RemotePvtDataResponse)(nil), "gossip.RemotePvtDataResponse")
@@ -2818,126 +3000,136 @@ var _Gossip_serviceDesc = grpc.ServiceDesc{
 	Metadata: "gossip/message.proto",
 }
 
-func init() { proto.RegisterFile("gossip/message.proto", fileDescriptor_message_7c42328ef5ef9997) }
-
-var fileDescriptor_message_7c42328ef5ef9997 = []byte{
-	// 1874 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x5b, 0x6f, 0xe3, 0xc6,
-	0x15, 0x16, 0x6d, 0x5d, 0x8f, 0x2e, 0x96, 0xc7, 0xde, 0x5d, 0xc6, 0x49, 0x13, 0x87, 0xed, 0x26,
-	0xdb, 0x7a, 0x23, 0x6f, 0x9d, 0x16, 0x0d, 0x90, 0xb6, 0x0b, 0x5b, 0x52, 0x2c, 0x21, 0x2b, 0xad,
-	0x4b, 0x7b, 0xd1, 0xba, 0x2f, 0xc4, 0x98, 0x1c, 0x53, 0xac, 0xc9, 0x21, 0xcd, 0x19, 0x3b, 0xf6,
-	0x63, 0xd1, 0x87, 0x00, 0x7d, 0xe9, 0x6f, 0xe8, 0x53, 0xff, 0x66, 0x31, 0x33, 0xbc, 0x4a, 0xf6,
-	0x02, 0x1b, 0x20, 0x6f, 0x3c, 0xf7, 0x99, 0x33, 0x67, 0xbe, 0x73, 0x86, 0xb0, 0xed, 0x86, 0x8c,
-	0x79, 0xd1, 0x7e, 0x40, 0x18, 0xc3, 0x2e, 0x19, 0x44, 0x71, 0xc8, 0x43, 0x54, 0x57, 0xdc, 0x9d,
-	0x67, 0x76, 0x18, 0x04, 0x21, 0xdd, 0xb7, 0x43, 0xdf, 0x27, 0x36, 0xf7, 0x42, 0xaa, 0x14, 0x8c,
-	0x7f, 0x69, 0xd0, 0x1c, 0xd3, 0x5b, 0xe2, 0x87, 0x11, 0x41, 0x3a, 0x34, 0x22, 0x7c, 0xef, 0x87,
-	0xd8, 0xd1, 0xb5, 0x5d, 0xed, 0x45, 0xc7, 0x4c, 0x49, 0xf4, 0x09, 0xb4, 0x98, 0xe7, 0x52, 0xcc,
-	0x6f, 0x62, 0xa2, 0xaf, 0x49, 0x59, 0xce, 0x40, 0xaf, 0x61, 0x83, 0x11, 0x3b, 0x26, 0xdc, 0x22,
-	0x89, 0x2b, 0x7d, 0x7d, 0x57, 0x7b, 0xd1, 0x3e, 0x78, 0x3a, 0x50, 0xf1, 0x07, 0xa7, 0x52, 0x9c,
-	0x06, 0x32, 0x7b, 0xac, 0x44, 0x1b, 0x13, 0xe8, 0x95, 0x35, 0x7e, 0xea, 0x52, 0x8c, 0x43, 0xa8,
-	0x2b, 0x4f, 0xe8, 0x25, 0xf4, 0x3d, 0xca, 0x49, 0x4c, 0xb1, 0x3f, 0xa6, 0x4e, 0x14, 0x7a, 0x94,
-	0x4b, 0x57, 0xad, 0x49, 0xc5, 0x5c, 0x91, 0x1c, 0xb5, 0xa0, 0x61, 0x87, 0x94, 0x13, 0xca, 0x8d,
-	0x1f, 0xdb, 0xd0, 0x3d, 0x96, 0xcb, 0x9e, 0xa9, 0x5c, 0xa2, 0x6d, 0xa8, 0xd1, 0x90, 0xda, 0x44,
-	0xda, 0x57, 0x4d, 0x45, 0x88, 0x25, 0xda, 0x0b, 0x4c, 0x29, 0xf1, 0x93, 0x65, 0xa4, 0x24, 0xda,
-	0x83, 0x75, 0x8e, 0x5d, 0x99, 0x83, 0xde, 0xc1, 0x47, 0x69, 0x0e, 0x4a, 0x3e, 0x07, 0x67, 0xd8,
-	0x35, 0x85, 0x16, 0xfa, 0x1a, 0x5a, 0xd8, 0xf7, 0x6e, 0x89, 0x15, 0x30, 0x57, 0xaf, 0xc9, 0xb4,
-	0x6d, 0xa7, 0x26, 0x87, 0x42, 0x90, 0x58, 0x4c, 0x2a, 0x66, 0x53, 0x2a, 0xce, 0x98, 0x8b, 0x7e,
-	0x07, 0x8d, 0x80, 0x04, 0x56, 0x4c, 0xae, 0xf5, 0xba, 0x34, 0xc9, 0xa2, 0xcc, 0x48, 0x70, 0x41,
-	0x62, 0xb6, 0xf0, 0x22, 0x93, 0x5c, 0xdf, 0x10, 0xc6, 0x27, 0x15, 0xb3, 0x1e, 0x90, 0xc0, 0x24,
-	0xd7, 0xe8, 0xf7, 0xa9, 0x15, 0xd3, 0x1b, 0xd2, 0x6a, 0xe7, 0x21, 0x2b, 0x16, 0x85, 0x94, 0x91,
-	0xcc, 0x8c, 0xa1, 0x57, 0xd0, 0x74, 0x30, 0xc7, 0x72, 0x81, 0x4d, 0x69, 0xb7, 0x95, 0xda, 0x8d,
-	0x30, 0xc7, 0xf9, 0xfa, 0x1a, 0x42, 0x4d, 0x2c, 0x6f, 0x0f, 0x6a, 0x0b, 0xe2, 0xfb, 0xa1, 0xde,
-	0x2a, 0xab, 0xab, 0x14, 0x4c, 0x84, 0x68, 0x52, 0x31, 0x95, 0x0e, 0xda, 0x4f, 0xdc, 0x3b, 0x9e,
-	0xab, 0x83, 0xd4, 0x47, 0x45, 0xf7, 0x23, 0xcf, 0x55, 0xbb, 0x90, 0xde, 0x47, 0x9e, 0x9b, 0xad,
-	0x47, 0xec, 0xbe, 0xbd, 0xba, 0x9e, 0x7c, 0xdf, 0xd2, 0x42, 0x6d, 0xbc, 0x2d, 0x2d, 0x6e, 0x22,
-	0x07, 0x73, 0xa2, 0x77, 0x56, 0xa3, 0xbc, 0x93, 0x92, 0x49, 0xc5, 0x04, 0x27, 0xa3, 0xd0, 0x73,
-	0xa8, 0x91, 0x20, 0xe2, 0xf7, 0x7a, 0x57, 0x1a, 0x74, 0x53, 0x83, 0xb1, 0x60, 0x8a, 0x0d, 0x48,
-	0x29, 0xda, 0x83, 0xaa, 0x1d, 0x52, 0xaa, 0xf7, 0xa4, 0xd6, 0x93, 0x54, 0x6b, 0x18, 0x52, 0x3a,
-	0x66, 0x1c, 0x5f, 0xf8, 0x1e, 0x5b, 0x4c, 0x2a, 0xa6, 0x54, 0x42, 0x07, 0x00, 0x8c, 0x63, 0x4e,
-	0x2c, 0x8f, 0x5e, 0x86, 0xfa, 0x86, 0x34, 0xd9, 0xcc, 0xae, 0x89, 0x90, 0x4c, 0xe9, 0xa5, 0xc8,
-	0x4e, 0x8b, 0xa5, 0x04, 0x3a, 0x82, 0x9e, 0xb2, 0x61, 0x14, 0x47, 0x6c, 0x11, 0x72, 0xbd, 0x5f,
-	0x3e, 0xf4, 0xcc, 0xee, 0x34, 0x51, 0x98, 0x54, 0xcc, 0xae, 0x34, 0x49, 0x19, 0x68, 0x06, 0x5b,
-	0x79, 0x5c, 0x2b, 0xba, 0xf1, 0x7d, 0x99, 0xbf, 0x4d, 0xe9, 0xe8, 0x93, 0x15, 0x47, 0x27, 0x37,
-	0xbe, 0x9f, 0x27, 0xb2, 0xcf, 0x96, 0xf8, 0xe8, 0x10, 0x94, 0x7f, 0xe1, 0x44, 0x28, 0xe9, 0xa8,
-	0x5c, 0x50, 0x26, 0x09, 0x42, 0x4e, 0xa4, 0xbb, 0xdc, 0x4d, 0x87, 0x15, 0x68, 0x34, 0x4a, 0x77,
-	0x15, 0x27, 0x25, 0xa7, 0x6f, 0x49, 0x1f, 0x1f, 0x3f, 0xe8, 0x23, 0xab, 0xca, 0x2e, 0x2b, 0x32,
-	0x44, 0x6e, 0x7c, 0x82, 0x1d, 0x55, 0xbc, 0xb2, 0x44, 0xb7, 0xcb, 0xb9, 0x79, 0x93, 0x49, 0xf3,
-	0x42, 0xed, 0xe6, 0x26, 0xa2, 0x5c, 0xbf, 0x85, 0x6e, 0x44, 0x48, 0x6c, 0x79, 0x0e, 0xa1, 0xdc,
-	0xe3, 0xf7, 0xfa, 0x93, 0xf2, 0x35, 0x3c, 0x21, 0x24, 0x9e, 0x26, 0x32, 0xb1, 0x8d, 0xa8, 0x40,
-	0x8b, 0xcb, 0x8e, 0xed, 0x2b, 0xfd, 0xa9, 0x34, 0x79, 0x96, 0xdd, 0x5c, 0xfb, 0x8a, 0x86, 0x3f,
-	0xf8, 0xc4, 0x71, 0x49, 0x40, 0xa8, 0xd8, 0xbc, 0xd0, 0x42, 0x7f, 0x06, 0x88, 0x62, 0xef, 0x56,
-	0x65, 0x41, 0x7f, 0x56, 0x4e, 0xbe, 0xda, 0xef, 0xc9, 0x2d, 0x2f, 0x57, 0x71, 0xc1, 0x02, 0xbd,
-	0x2e, 0xd8, 0x33, 0x5d, 0x97, 0xf6, 0xbf, 0x78, 0xc4, 0x3e, 0xcb, 0x58, 0xc1, 0x04, 0xbd, 0x86,
-	0x4e, 0x42, 0x59, 0xa2, 0xd0, 0xf5, 0x8f, 0xca, 0xc7, 0x76, 0xa2, 0x64, 0xe5, 0x6b, 0xdd, 0x8e,
-	0x72, 0xae, 0x61, 0xc1, 0xfa, 0x19, 0x76, 0x51, 0x17, 0x5a, 0xef, 0xe6, 0xa3, 0xf1, 0x77, 0xd3,
-	0xf9, 0x78, 0xd4, 0xaf, 0xa0, 0x16, 0xd4, 0xc6, 0xb3, 0x93, 0xb3, 0xf3, 0xbe, 0x86, 0x3a, 0xd0,
-	0x7c, 0x6b, 0x1e, 0x5b, 0x6f, 0xe7, 0x6f, 0xce, 0xfb, 0x6b, 0x42, 0x6f, 0x38, 0x39, 0x9c, 0x2b,
-	0x72, 0x1d, 0xf5, 0xa1, 0x23, 0xc9, 0xc3, 0xf9, 0xc8, 0x7a, 0x6b, 0x1e, 0xf7, 0xab, 0x68, 0x03,
-	0xda, 0x4a, 0xc1, 0x94, 0x8c, 0x5a, 0x11, 0x89, 0xff, 0xa7, 0x41, 0x2b, 0xab, 0x48, 0x34, 0x80,
-	0x16, 0xf7, 0x02, 0xc2, 0x38, 0x0e, 0x22, 0x89, 0xb8, 0xed, 0x83, 0x7e, 0xf1, 0x84, 0xce, 0xbc,
-	0x80, 0x98, 0xb9, 0x0a, 0x7a, 0x02, 0xf5, 0xe8, 0xca, 0xb3, 0x3c, 0x47, 0x02, 0x71, 0xc7, 0xac,
-	0x45, 0x57, 0xde, 0xd4, 0x41, 0x9f, 0x41, 0x3b, 0xc1, 0x69, 0x6b, 0x76, 0x38, 0xd4, 0xab, 0x52,
-	0x06, 0x09, 0x6b, 0x76, 0x38, 0x14, 0x37, 0x34, 0x8a, 0xc3, 0x88, 0xc4, 0xdc, 0x23, 0x2c, 0x41,
-	0x64, 0x94, 0x27, 0x28, 0x95, 0x98, 0x05, 0x2d, 0xe3, 0x47, 0x0d, 0x20, 0x17, 0xa1, 0x5f, 0x42,
-	0x57, 0x1e, 0x7d, 0x6c, 0x2d, 0x88, 0xe7, 0x2e, 0x78, 0xd2, 0x38, 0x3a, 0x8a, 0x39, 0x91, 0x3c,
-	0xf4, 0x39, 0x74, 0x7c, 0x72, 0xc9, 0xad, 0x62, 0x13, 0x69, 0x9a, 0x6d, 0xc1, 0x1b, 0x26, 0x8d,
-	0xe4, 0xb7, 0x20, 0x16, 0xe6, 0x51, 0x3b, 0x74, 0x08, 0xd3, 0xd7, 0x77, 0xd7, 0x8b, 0x60, 0x31,
-	0x4c, 0x25, 0x66, 0x41, 0xc9, 0x38, 0x84, 0xcd, 0x15, 0x34, 0x40, 0x2f, 0xa1, 0x49, 0x7c, 0x59,
-	0x88, 0x4c, 0xd7, 0xa4, 0x97, 0x2c, 0x73, 0x59, 0x4f, 0xce, 0x34, 0x8c, 0x3f, 0xc0, 0xf6, 0x43,
-	0x38, 0xb0, 0x9c, 0x39, 0x6d, 0x39, 0x73, 0xc6, 0x25, 0x74, 0x4b, 0xa0, 0x57, 0x38, 0x02, 0xad,
-	0x78, 0x04, 0x3b, 0xd0, 0xcc, 0xae, 0x9a, 0x6a, 0x9d, 0x19, 0x8d, 0x0c, 0xe8, 0x72, 0x9f, 0x59,
-	0x36, 0x89, 0xb9, 0xb5, 0xc0, 0x6c, 0x91, 0x1c, 0x5e, 0x9b, 0xfb, 0x6c, 0x48, 0x62, 0x3e, 0xc1,
-	0x6c, 0x61, 0xbc, 0x83, 0x4e, 0xf1, 0x4a, 0x3e, 0x16, 0x06, 0x41, 0x55, 0xb8, 0x49, 0x42, 0xc8,
-	0x6f, 0x11, 0x3a, 0x20, 0x1c, 0xcb, 0xda, 0x57, 0x9e, 0x33, 0xda, 0x08, 0xa0, 0x5d, 0xb8, 0x79,
-	0x8f, 0x77, 0x7d, 0x47, 0x76, 0x24, 0xa6, 0xaf, 0xed, 0xae, 0x8b, 0xae, 0x9f, 0x90, 0x68, 0x00,
-	0xcd, 0x80, 0xb9, 0x16, 0xbf, 0x4f, 0xc6, 0x9f, 0x5e, 0xde, 0x96, 0x44, 0x16, 0x67, 0xcc, 0x3d,
-	0xbb, 0x8f, 0x88, 0xd9, 0x08, 0xd4, 0x87, 0x11, 0x42, 0xbb, 0xd0, 0x0f, 0x1f, 0x09, 0x57, 0x5c,
-	0xef, 0x5a, 0x79, 0xbd, 0x1f, 0x1c, 0xf0, 0x0e, 0x20, 0x6f, 0x75, 0x8f, 0xc4, 0xfb, 0x15, 0x54,
-	0x93, 0x58, 0x0f, 0x57, 0x49, 0xf5, 0x27, 0x45, 0xf6, 0x55, 0x64, 0xd5, 0xca, 0x7f, 0xf6, 0xc4,
-	0x7e, 0xa3, 0xce, 0x31, 0x9d, 0xde, 0x7e, 0x5d, 0x1e, 0x25, 0xdb, 0x07, 0x1b, 0x99, 0xb5, 0x62,
-	0x67, 0xb3, 0xa5, 0xf1, 0x1d, 0xa0, 0x55, 0x04, 0x44, 0xaf, 0x96, 0x1d, 0x3c, 0x5d, 0x82, 0xcb,
-	0x15, 0x3f, 0xe7, 0xd0, 0x48, 0x78, 0xe8, 0x19, 0x34, 0x18, 0xb9, 0xb6, 0xe8, 0x4d, 0x90, 0x6c,
-	0xb7, 0xce, 0xc8, 0xf5, 0xfc, 0x26, 0x10, 0xd5, 0x59, 0x38, 0x55, 0x95, 0xd7, 0xcf, 0x97, 0xd0,
-	0x79, 0x5d, 0x26, 0xa2, 0x84, 0xbf, 0xff, 0x59, 0x83, 0x5e, 0x39, 0x2c, 0xfa, 0x12, 0x36, 0xf2,
-	0xb9, 0xde, 0xa2, 0x38, 0x50, 0x99, 0x6d, 0x99, 0xbd, 0x9c, 0x3d, 0xc7, 0x01, 0x11, 0xa3, 0xb3,
-	0x90, 0xb2, 0x08, 0xdb, 0x6a, 0x74, 0x6e, 0x99, 0x39, 0x03, 0x6d, 0x41, 0x8d, 0xdf, 0xa5, 0x70,
-	0xd9, 0x32, 0xab, 0xfc, 0x6e, 0xea, 0x08, 0x24, 0x4b, 0x57, 0x14, 0xff, 0xc0, 0x08, 0x4f, 0xf0,
-	0x32, 0x5d, 0xa6, 0x29, 0x78, 0xe8, 0x25, 0xa0, 0x54, 0x89, 0x79, 0x41, 0x8a, 0x79, 0x35, 0xb9,
-	0xdd, 0x7e, 0x22, 0x39, 0xf5, 0x82, 0x04, 0xf7, 0xe6, 0x80, 0x0a, 0xcb, 0xb5, 0x43, 0x7a, 0xe9,
-	0xb9, 0x2c, 0x19, 0x63, 0x3f, 0x1b, 0xa8, 0x87, 0xca, 0x60, 0x98, 0x69, 0x0c, 0xa5, 0xc2, 0x09,
-	0xb6, 0xaf, 0xb0, 0x4b, 0xcc, 0x4d, 0x7b, 0x49, 0xc0, 0x8c, 0x7f, 0x6b, 0xd0, 0x29, 0x0e, 0xca,
-	0x68, 0x00, 0x10, 0x64, 0xf3, 0x6c, 0x72, 0x64, 0xbd, 0xf2, 0xa4, 0x6b, 0x16, 0x34, 0x3e, 0xb8,
-	0xb1, 0x14, 0xe1, 0xab, 0x5a, 0x86, 0x2f, 0xe3, 0x9f, 0x1a, 0x6c, 0xae, 0x4c, 0x1c, 0x8f, 0x01,
-	0xd4, 0x87, 0x06, 0x7e, 0x0e, 0x3d, 0x8f, 0x59, 0x0e, 0xb1, 0x7d, 0x1c, 0x63, 0x91, 0x02, 0x79,
-	0x54, 0x4d, 0xb3, 0xeb, 0xb1, 0x51, 0xce, 0x34, 0xfe, 0x08, 0xcd, 0xd4, 0x5a, 0x94, 0x9f, 0x47,
-	0xed, 0x62, 0xf9, 0x79, 0xd4, 0x16, 0xe5, 0x57, 0xa8, 0xcb, 0xb5, 0x62, 0x5d, 0x1a, 0x97, 0xb0,
-	0xb9, 0xf2, 0x86, 0x40, 0xdf, 0x42, 0x9f, 0x11, 0xff, 0x52, 0x0e, 0x8f, 0x71, 0xa0, 0x62, 0x6b,
-	0xe5, 0x05, 0x67, 0x10, 0xb1, 0x21, 0x34, 0xa7, 0xb9, 0xa2, 0xb8, 0xef, 0x62, 0x18, 0xa2, 0xc9,
-	0xbd, 0x56, 0x84, 0x71, 0x01, 0x68, 0xf5, 0xd5, 0x81, 0xbe, 0x80, 0x9a, 0x7c, 0xe4, 0x3c, 0xda,
-	0xa6, 0x94, 0x58, 0xe2, 0x14, 0xc1, 0xce, 0x7b, 0x70, 0x8a, 0x60, 0xc7, 0xf8, 0x2b, 0xd4, 0x55,
-	0x0c, 0x71, 0x66, 0xa4, 0xf4, 0x0a, 0x34, 0x33, 0xfa, 0xbd, 0x18, 0xfb, 0xf0, 0x10, 0x61, 0x34,
-	0xa0, 0x26, 0x1f, 0x01, 0xc6, 0xdf, 0x00, 0xad, 0x8e, 0xba, 0xa2, 0x89, 0x31, 0x8e, 0x63, 0x6e,
-	0x95, 0xaf, 0x7e, 0x5b, 0x32, 0x4f, 0xd5, 0xfd, 0xff, 0x14, 0xda, 0x84, 0x3a, 0x56, 0xf9, 0x10,
-	0x5a, 0x84, 0x3a, 0x4a, 0x6e, 0x1c, 0xc1, 0xd6, 0x03, 0x03, 0x30, 0xda, 0x83, 0x66, 0x82, 0x32,
-	0x69, 0x2b, 0x5f, 0x81, 0xb3, 0x4c, 0xc1, 0x38, 0x86, 0xed, 0x87, 0x86, 0x4a, 0xb4, 0x9f, 0x63,
-	0xad, 0xf2, 0x91, 0x3d, 0x5a, 0x12, 0x45, 0x85, 0xd4, 0x19, 0x04, 0x1b, 0xff, 0xd5, 0xa0, 0x5b,
-	0x12, 0xe5, 0x68, 0xa1, 0x15, 0xd0, 0xe2, 0xfd, 0x00, 0xf3, 0x29, 0x40, 0x7e, 0x7b, 0x13, 0x94,
-	0x29, 0x70, 0xd0, 0xc7, 0xd0, 0xba, 0xf0, 0x43, 0xfb, 0x4a, 0xe4, 0x44, 0x5e, 0xac, 0xaa, 0xd9,
-	0x94, 0x8c, 0x53, 0x72, 0x8d, 0x76, 0xa1, 0x23, 0x52, 0xe5, 0x51, 0x4b, 0xb2, 0x12, 0x74, 0x01,
-	0x46, 0xae, 0xa7, 0xf4, 0x48, 0x70, 0x8c, 0xef, 0xe1, 0xc9, 0x83, 0x13, 0x30, 0x3a, 0x58, 0x99,
-	0x7e, 0x9e, 0x2e, 0x6d, 0x77, 0xac, 0xc4, 0x85, 0x19, 0xe8, 0x1c, 0x7a, 0x65, 0x19, 0xfa, 0x0a,
-	0xea, 0x2a, 0x1b, 0x49, 0xe1, 0x3f, 0x92, 0xb2, 0x44, 0xa9, 0xf8, 0x03, 0x23, 0x69, 0x67, 0x69,
-	0x73, 0xf8, 0x4b, 0xe6, 0x3a, 0x05, 0xf0, 0xe7, 0xb0, 0xc1, 0xef, 0xac, 0xd2, 0xf6, 0x92, 0x81,
-	0x91, 0xdf, 0x9d, 0x66, 0x1b, 0x2c, 0xbb, 0x2c, 0xfe, 0x13, 0x31, 0xbe, 0x84, 0x8d, 0xa5, 0x07,
-	0x87, 0xb8, 0x74, 0x24, 0x8e, 0xc3, 0x38, 0x39, 0x1f, 0x45, 0x18, 0xef, 0xa0, 0x95, 0x8d, 0x8d,
-	0xa2, 0x03, 0x15, 0x9a, 0x85, 0xfc, 0x16, 0x31, 0x6e, 0x49, 0xcc, 0xc4, 0x01, 0xa9, 0xf3, 0x4b,
-	0xc9, 0xf7, 0x4d, 0x4e, 0xbf, 0xf9, 0x13, 0xb4, 0x0b, 0x9d, 0x78, 0xf9, 0x71, 0xd0, 0x85, 0xd6,
-	0xd1, 0x9b, 0xb7, 0xc3, 0xef, 0xad, 0xd9, 0xe9, 0x71, 0x5f, 0x13, 0x6f, 0x80, 0xe9, 0x68, 0x3c,
-	0x3f, 0x9b, 0x9e, 0x9d, 0x4b, 0xce, 0xda, 0xc1, 0x3f, 0xa0, 0xae, 0x26, 0x21, 0xf4, 0x0d, 0x74,
-	0xd4, 0xd7, 0x29, 0x8f, 0x09, 0x0e, 0xd0, 0xca, 0xc5, 0xde, 0x59, 0xe1, 0x18, 0x95, 0x17, 0xda,
-	0x2b, 0x0d, 0x7d, 0x01, 0xd5, 0x13, 0x8f, 0xba, 0xa8, 0xfc, 0x48, 0xdf, 0x29, 0x93, 0x46, 0xe5,
-	0xe8, 0xab, 0xbf, 0xef, 0xb9, 0x1e, 0x5f, 0xdc, 0x5c, 0x88, 0x4e, 0xb3, 0xbf, 0xb8, 0x8f, 0x48,
-	0xac, 0xa6, 0xf2, 0xfd, 0x4b, 0x7c, 0x11, 0x7b, 0xf6, 0xbe, 0xfc, 0x2f, 0xc6, 0xf6, 0x95, 0xd9,
-	0x45, 0x5d, 0x92, 0x5f, 0xff, 0x3f, 0x00, 0x00, 0xff, 0xff, 0xdd, 0x1d, 0xb3, 0x7e, 0x5f, 0x13,
-	0x00, 0x00,
+func init() { proto.RegisterFile("gossip/message.proto", fileDescriptor_message_e6f9f3ac4cb4ab03) }
+
+var fileDescriptor_message_e6f9f3ac4cb4ab03 = []byte{
+	// 2042 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xdd, 0x4f, 0x24, 0xc7,
+	0x11, 0xdf, 0x61, 0x3f, 0xd8, 0xad, 0xfd, 0x60, 0x69, 0xb8, 0xbb, 0x31, 0xb6, 0xcf, 0x64, 0x92,
+	0xb3, 0x2f, 0xe1, 0x0c, 0x17, 0x9c, 0x28, 0x96, 0x9c, 0xe4, 0x04, 0x0b, 0x66, 0x91, 0x6f, 0x81,
+	0x34, 0x9c, 0x14, 0xf2, 0x32, 0x6a, 0x66, 0x9a, 0xd9, 0x09, 0x33, 0x3d, 0xc3, 0x74, 0x2f, 0x86,
+	0xb7, 0x44, 0x79, 0x88, 0x94, 0x97, 0xfc, 0x0d, 0x91, 0xa2, 0xe4, 0xdf, 0x8c, 0xba, 0x7b, 0x3e,
+	0x59, 0xb8, 0xe8, 0x2c, 0xf9, 0x6d, 0xea, 0xb3, 0xbb, 0xab, 0xaa, 0x7f, 0x55, 0x3d, 0xb0, 0xea,
+	0x45, 0x9c, 0xfb, 0xf1, 0x56, 0x48, 0x39, 0x27, 0x1e, 0xdd, 0x8c, 0x93, 0x48, 0x44, 0xa8, 0xa5,
+	0xb9, 0x6b, 0xcf, 0x9c, 0x28, 0x0c, 0x23, 0xb6, 0xe5, 0x44, 0x41, 0x40, 0x1d, 0xe1, 0x47, 0x4c,
+	0x2b, 0x58, 0x7f, 0x33, 0xa0, 0xbd, 0xcf, 0x6e, 0x68, 0x10, 0xc5, 0x14, 0x99, 0xb0, 0x18, 0x93,
+	0xbb, 0x20, 0x22, 0xae, 0x69, 0xac, 0x1b, 0x2f, 0x7b, 0x38, 0x23, 0xd1, 0x27, 0xd0, 0xe1, 0xbe,
+	0xc7, 0x88, 0x98, 0x25, 0xd4, 0x5c, 0x50, 0xb2, 0x82, 0x81, 0xde, 0xc0, 0x12, 0xa7, 0x4e, 0x42,
+	0x85, 0x4d, 0x53, 0x57, 0x66, 0x7d, 0xdd, 0x78, 0xd9, 0xdd, 0x7e, 0xba, 0xa9, 0xd7, 0xdf, 0x3c,
+	0x55, 0xe2, 0x6c, 0x21, 0x3c, 0xe0, 0x15, 0xda, 0x1a, 0xc3, 0xa0, 0xaa, 0xf1, 0x43, 0xb7, 0x62,
+	0xed, 0x40, 0x4b, 0x7b, 0x42, 0xaf, 0x60, 0xe8, 0x33, 0x41, 0x13, 0x46, 0x82, 0x7d, 0xe6, 0xc6,
+	0x91, 0xcf, 0x84, 0x72, 0xd5, 0x19, 0xd7, 0xf0, 0x9c, 0x64, 0xb7, 0x03, 0x8b, 0x4e, 0xc4, 0x04,
+	0x65, 0xc2, 0xfa, 0x7b, 0x17, 0xfa, 0x07, 0x6a, 0xdb, 0x13, 0x1d, 0x4b, 0xb4, 0x0a, 0x4d, 0x16,
+	0x31, 0x87, 0x2a, 0xfb, 0x06, 0xd6, 0x84, 0xdc, 0xa2, 0x33, 0x25, 0x8c, 0xd1, 0x20, 0xdd, 0x46,
+	0x46, 0xa2, 0x0d, 0xa8, 0x0b, 0xe2, 0xa9, 0x18, 0x0c, 0xb6, 0x3f, 0xca, 0x62, 0x50, 0xf1, 0xb9,
+	0x79, 0x46, 0x3c, 0x2c, 0xb5, 0xd0, 0x57, 0xd0, 0x21, 0x81, 0x7f, 0x43, 0xed, 0x90, 0x7b, 0x66,
+	0x53, 0x85, 0x6d, 0x35, 0x33, 0xd9, 0x91, 0x82, 0xd4, 0x62, 0x5c, 0xc3, 0x6d, 0xa5, 0x38, 0xe1,
+	0x1e, 0xfa, 0x15, 0x2c, 0x86, 0x34, 0xb4, 0x13, 0x7a, 0x6d, 0xb6, 0x94, 0x49, 0xbe, 0xca, 0x84,
+	0x86, 0x17, 0x34, 0xe1, 0x53, 0x3f, 0xc6, 0xf4, 0x7a, 0x46, 0xb9, 0x18, 0xd7, 0x70, 0x2b, 0xa4,
+	0x21, 0xa6, 0xd7, 0xe8, 0xd7, 0x99, 0x15, 0x37, 0x17, 0x95, 0xd5, 0xda, 0x43, 0x56, 0x3c, 0x8e,
+	0x18, 0xa7, 0xb9, 0x19, 0x47, 0xaf, 0xa1, 0xed, 0x12, 0x41, 0xd4, 0x06, 0xdb, 0xca, 0x6e, 0x25,
+	0xb3, 0xdb, 0x23, 0x82, 0x14, 0xfb, 0x5b, 0x94, 0x6a, 0x72, 0x7b, 0x1b, 0xd0, 0x9c, 0xd2, 0x20,
+	0x88, 0xcc, 0x4e, 0x55, 0x5d, 0x87, 0x60, 0x2c, 0x45, 0xe3, 0x1a, 0xd6, 0x3a, 0x68, 0x2b, 0x75,
+	0xef, 0xfa, 0x9e, 0x09, 0x4a, 0x1f, 0x95, 0xdd, 0xef, 0xf9, 0x9e, 0x3e, 0x85, 0xf2, 0xbe, 0xe7,
+	0x7b, 0xf9, 0x7e, 0xe4, 0xe9, 0xbb, 0xf3, 0xfb, 0x29, 0xce, 0xad, 0x2c, 0xf4, 0xc1, 0xbb, 0xca,
+	0x62, 0x16, 0xbb, 0x44, 0x50, 0xb3, 0x37, 0xbf, 0xca, 0x3b, 0x25, 0x19, 0xd7, 0x30, 0xb8, 0x39,
+	0x85, 0x5e, 0x40, 0x93, 0x86, 0xb1, 0xb8, 0x33, 0xfb, 0xca, 0xa0, 0x9f, 0x19, 0xec, 0x4b, 0xa6,
+	0x3c, 0x80, 0x92, 0xa2, 0x0d, 0x68, 0x38, 0x11, 0x63, 0xe6, 0x40, 0x69, 0x3d, 0xc9, 0xb4, 0x46,
+	0x11, 0x63, 0xfb, 0x5c, 0x90, 0x8b, 0xc0, 0xe7, 0xd3, 0x71, 0x0d, 0x2b, 0x25, 0xb4, 0x0d, 0xc0,
+	0x05, 0x11, 0xd4, 0xf6, 0xd9, 0x65, 0x64, 0x2e, 0x29, 0x93, 0xe5, 0xfc, 0x9a, 0x48, 0xc9, 0x21,
+	0xbb, 0x94, 0xd1, 0xe9, 0xf0, 0x8c, 0x40, 0xbb, 0x30, 0xd0, 0x36, 0x9c, 0x91, 0x98, 0x4f, 0x23,
+	0x61, 0x0e, 0xab, 0x49, 0xcf, 0xed, 0x4e, 0x53, 0x85, 0x71, 0x0d, 0xf7, 0x95, 0x49, 0xc6, 0x40,
+	0x13, 0x58, 0x29, 0xd6, 0xb5, 0xe3, 0x59, 0x10, 0xa8, 0xf8, 0x2d, 0x2b, 0x47, 0x9f, 0xcc, 0x39,
+	0x3a, 0x99, 0x05, 0x41, 0x11, 0xc8, 0x21, 0xbf, 0xc7, 0x47, 0x3b, 0xa0, 0xfd, 0xdb, 0x89, 0x56,
+	0x32, 0x51, 0xb5, 0xa0, 0x30, 0x0d, 0x23, 0x41, 0x95, 0xbb, 0xc2, 0x4d, 0x8f, 0x97, 0x68, 0xb4,
+	0x97, 0x9d, 0x2a, 0x49, 0x4b, 0xce, 0x5c, 0x51, 0x3e, 0x3e, 0x7e, 0xd0, 0x47, 0x5e, 0x95, 0x7d,
+	0x5e, 0x66, 0xc8, 0xd8, 0x04, 0x94, 0xb8, 0xba, 0x78, 0x55, 0x89, 0xae, 0x56, 0x63, 0xf3, 0x36,
+	0x97, 0x16, 0x85, 0xda, 0x2f, 0x4c, 0x64, 0xb9, 0x7e, 0x03, 0xfd, 0x98, 0xd2, 0xc4, 0xf6, 0x5d,
+	0xca, 0x84, 0x2f, 0xee, 0xcc, 0x27, 0xd5, 0x6b, 0x78, 0x42, 0x69, 0x72, 0x98, 0xca, 0xe4, 0x31,
+	0xe2, 0x12, 0x2d, 0x2f, 0x3b, 0x71, 0xae, 0xcc, 0xa7, 0xca, 0xe4, 0x59, 0x7e, 0x73, 0x9d, 0x2b,
+	0x16, 0x7d, 0x1f, 0x50, 0xd7, 0xa3, 0x21, 0x65, 0xf2, 0xf0, 0x52, 0x0b, 0xfd, 0x1e, 0x20, 0x4e,
+	0xfc, 0x1b, 0x1d, 0x05, 0xf3, 0x59, 0x35, 0xf8, 0xfa, 0xbc, 0x27, 0x37, 0xa2, 0x5a, 0xc5, 0x25,
+	0x0b, 0xf4, 0xa6, 0x64, 0xcf, 0x4d, 0x53, 0xd9, 0x7f, 0xfa, 0x88, 0x7d, 0x1e, 0xb1, 0x92, 0x09,
+	0x7a, 0x03, 0xbd, 0x94, 0xb2, 0x65, 0xa1, 0x9b, 0x1f, 0x55, 0xd3, 0x76, 0xa2, 0x65, 0xd5, 0x6b,
+	0xdd, 0x8d, 0x0b, 0xae, 0x65, 0x43, 0xfd, 0x8c, 0x78, 0xa8, 0x0f, 0x9d, 0x77, 0x47, 0x7b, 0xfb,
+	0xdf, 0x1e, 0x1e, 0xed, 0xef, 0x0d, 0x6b, 0xa8, 0x03, 0xcd, 0xfd, 0xc9, 0xc9, 0xd9, 0xf9, 0xd0,
+	0x40, 0x3d, 0x68, 0x1f, 0xe3, 0x03, 0xfb, 0xf8, 0xe8, 0xed, 0xf9, 0x70, 0x41, 0xea, 0x8d, 0xc6,
+	0x3b, 0x47, 0x9a, 0xac, 0xa3, 0x21, 0xf4, 0x14, 0xb9, 0x73, 0xb4, 0x67, 0x1f, 0xe3, 0x83, 0x61,
+	0x03, 0x2d, 0x41, 0x57, 0x2b, 0x60, 0xc5, 0x68, 0x96, 0x91, 0xf8, 0xbf, 0x06, 0x74, 0xf2, 0x8a,
+	0x44, 0x9b, 0xd0, 0x11, 0x7e, 0x48, 0xb9, 0x20, 0x61, 0xac, 0x10, 0xb7, 0xbb, 0x3d, 0x2c, 0x67,
+	0xe8, 0xcc, 0x0f, 0x29, 0x2e, 0x54, 0xd0, 0x13, 0x68, 0xc5, 0x57, 0xbe, 0xed, 0xbb, 0x0a, 0x88,
+	0x7b, 0xb8, 0x19, 0x5f, 0xf9, 0x87, 0x2e, 0xfa, 0x0c, 0xba, 0x29, 0x4e, 0xdb, 0x93, 0x9d, 0x91,
+	0xd9, 0x50, 0x32, 0x48, 0x59, 0x93, 0x9d, 0x91, 0xbc, 0xa1, 0x71, 0x12, 0xc5, 0x34, 0x11, 0x3e,
+	0xe5, 0x66, 0xb3, 0x8a, 0x15, 0x27, 0xb9, 0x04, 0x97, 0xb4, 0xac, 0xff, 0x18, 0x00, 0x85, 0x08,
+	0xfd, 0x14, 0xfa, 0x2a, 0xf5, 0x89, 0x3d, 0xa5, 0xbe, 0x37, 0x15, 0x69, 0xe3, 0xe8, 0x69, 0xe6,
+	0x58, 0xf1, 0xd0, 0x4f, 0xa0, 0x17, 0xd0, 0x4b, 0x61, 0x97, 0x9b, 0x48, 0x1b, 0x77, 0x25, 0x6f,
+	0xa4, 0x59, 0xe8, 0x97, 0x20, 0x37, 0xe6, 0x33, 0x27, 0x72, 0x29, 0x37, 0xeb, 0xeb, 0xf5, 0x32,
+	0x58, 0x8c, 0x32, 0x09, 0x2e, 0x29, 0xa1, 0x4f, 0x01, 0xae, 0x67, 0x34, 0xb9, 0xb3, 0x23, 0x16,
+	0xdc, 0xa9, 0xd3, 0xb5, 0x71, 0x47, 0x71, 0x8e, 0x59, 0x70, 0x67, 0xed, 0xc0, 0xf2, 0x1c, 0x58,
+	0xa0, 0x57, 0xd0, 0xa6, 0x81, 0xaa, 0x53, 0x6e, 0x1a, 0xeb, 0xf5, 0x72, 0x60, 0xf3, 0x96, 0x9d,
+	0x6b, 0x58, 0xbf, 0x81, 0xd5, 0x87, 0x60, 0xe2, 0x7e, 0x60, 0x8d, 0xfb, 0x81, 0xb5, 0x2e, 0xa1,
+	0x5f, 0xc1, 0xc4, 0x52, 0x86, 0x8c, 0x72, 0x86, 0xd6, 0xa0, 0x9d, 0xdf, 0x44, 0xdd, 0x59, 0x73,
+	0x1a, 0x59, 0xd0, 0x17, 0x01, 0xb7, 0x1d, 0x9a, 0x08, 0x7b, 0x4a, 0xf8, 0x34, 0xcd, 0x6d, 0x57,
+	0x04, 0x7c, 0x44, 0x13, 0x31, 0x26, 0x7c, 0x6a, 0xbd, 0x83, 0x5e, 0xf9, 0xc6, 0x3e, 0xb6, 0x0c,
+	0x82, 0x86, 0x74, 0x93, 0x2e, 0xa1, 0xbe, 0xe5, 0xd2, 0x21, 0x15, 0x44, 0x5d, 0x0d, 0xed, 0x39,
+	0xa7, 0xad, 0x10, 0xba, 0xa5, 0x8b, 0xf9, 0xf8, 0x50, 0xe0, 0xaa, 0x86, 0xc5, 0xcd, 0x85, 0xf5,
+	0xba, 0x1c, 0x0a, 0x52, 0x12, 0x6d, 0x42, 0x3b, 0xe4, 0x9e, 0x2d, 0xee, 0xd2, 0xe9, 0x68, 0x50,
+	0x74, 0x2d, 0x19, 0xc5, 0x09, 0xf7, 0xce, 0xee, 0x62, 0x8a, 0x17, 0x43, 0xfd, 0x61, 0x45, 0xd0,
+	0x2d, 0xb5, 0xcb, 0x47, 0x96, 0x2b, 0xef, 0x77, 0xa1, 0xba, 0xdf, 0x0f, 0x5e, 0xf0, 0x16, 0xa0,
+	0xe8, 0x84, 0x8f, 0xac, 0xf7, 0x33, 0x68, 0xa4, 0x6b, 0x3d, 0x5c, 0x25, 0x8d, 0x1f, 0xb4, 0x72,
+	0x00, 0x50, 0x74, 0xfa, 0x1f, 0x3d, 0xb0, 0x5f, 0x43, 0xb7, 0x84, 0x6f, 0xe8, 0xe7, 0xd5, 0x49,
+	0xb3, 0xbb, 0xbd, 0x94, 0x5b, 0x6b, 0x76, 0x3e, 0x7a, 0x5a, 0xdf, 0x02, 0x9a, 0x07, 0x48, 0xf4,
+	0xfa, 0xbe, 0x83, 0xa7, 0xf7, 0xd0, 0x74, 0xce, 0xcf, 0x39, 0x2c, 0xa6, 0x3c, 0xf4, 0x0c, 0x16,
+	0x39, 0xbd, 0xb6, 0xd9, 0x2c, 0x4c, 0x8f, 0xdb, 0xe2, 0xf4, 0xfa, 0x68, 0x16, 0xca, 0xea, 0x2c,
+	0x65, 0x55, 0x7d, 0x4b, 0xc4, 0xa8, 0x80, 0x77, 0x5d, 0x05, 0xa2, 0x02, 0xcf, 0xff, 0x5c, 0x80,
+	0x41, 0x75, 0x59, 0xf4, 0x05, 0x2c, 0x15, 0x63, 0xbf, 0xcd, 0x48, 0xa8, 0x23, 0xdb, 0xc1, 0x83,
+	0x82, 0x7d, 0x44, 0x42, 0x2a, 0x27, 0x6b, 0x29, 0xe5, 0x31, 0x71, 0xf4, 0x64, 0xdd, 0xc1, 0x05,
+	0x03, 0xad, 0x40, 0x53, 0xdc, 0x66, 0x68, 0xda, 0xc1, 0x0d, 0x71, 0x7b, 0xe8, 0x4a, 0xa0, 0xcb,
+	0x76, 0x94, 0x7c, 0xcf, 0xa9, 0x48, 0xe1, 0x34, 0xdb, 0x26, 0x96, 0x3c, 0xf4, 0x0a, 0x50, 0xa6,
+	0xc4, 0xfd, 0x30, 0x83, 0xc4, 0xa6, 0x3a, 0xee, 0x30, 0x95, 0x9c, 0xfa, 0x61, 0x0a, 0x8b, 0x47,
+	0x80, 0x4a, 0xdb, 0x75, 0x22, 0x76, 0xe9, 0x7b, 0x3c, 0x9d, 0x72, 0x3f, 0xdb, 0xd4, 0xef, 0x98,
+	0xcd, 0x51, 0xae, 0x31, 0x52, 0x0a, 0x27, 0xc4, 0xb9, 0x22, 0x1e, 0xc5, 0xcb, 0xce, 0x3d, 0x01,
+	0xb7, 0xfe, 0x61, 0x40, 0xaf, 0x3c, 0x47, 0xa3, 0x4d, 0x80, 0x30, 0x1f, 0x77, 0xd3, 0x94, 0x0d,
+	0xaa, 0x83, 0x30, 0x2e, 0x69, 0x7c, 0x70, 0xdf, 0x29, 0xc3, 0x57, 0xa3, 0x0a, 0x5f, 0xd6, 0x5f,
+	0x0d, 0x58, 0x9e, 0x1b, 0x48, 0x1e, 0x03, 0xa8, 0x0f, 0x5d, 0xf8, 0x05, 0x0c, 0x7c, 0x6e, 0xbb,
+	0xd4, 0x09, 0x48, 0x42, 0x64, 0x08, 0x54, 0xaa, 0xda, 0xb8, 0xef, 0xf3, 0xbd, 0x82, 0x69, 0xfd,
+	0x16, 0xda, 0x99, 0xb5, 0x2c, 0x3f, 0x9f, 0x39, 0xe5, 0xf2, 0xf3, 0x99, 0x23, 0xcb, 0xaf, 0x54,
+	0x97, 0x0b, 0xe5, 0xba, 0xb4, 0x2e, 0x61, 0x79, 0xee, 0x89, 0x81, 0xbe, 0x81, 0x21, 0xa7, 0xc1,
+	0xa5, 0x9a, 0x2d, 0x93, 0x50, 0xaf, 0x6d, 0xac, 0x1b, 0x0f, 0x42, 0xc4, 0x92, 0xd4, 0x3c, 0x2c,
+	0x14, 0xe5, 0x7d, 0x97, 0xb3, 0x12, 0x4b, 0xef, 0xb5, 0x26, 0xac, 0x0b, 0x40, 0xf3, 0x8f, 0x12,
+	0xf4, 0x39, 0x34, 0xd5, 0x1b, 0xe8, 0xd1, 0x36, 0xa5, 0xc5, 0x0a, 0xa7, 0x28, 0x71, 0xdf, 0x83,
+	0x53, 0x94, 0xb8, 0xb2, 0x6b, 0xb7, 0xf4, 0x22, 0x32, 0x69, 0xb4, 0xf2, 0x4a, 0xc4, 0x39, 0xfd,
+	0x5e, 0x90, 0x7d, 0x64, 0xc8, 0x98, 0xc0, 0x2a, 0x71, 0x6f, 0xe4, 0x34, 0xc0, 0xa9, 0x6b, 0x67,
+	0x9e, 0xb8, 0xd9, 0x58, 0xaf, 0x97, 0xc7, 0xad, 0x9d, 0x5c, 0x27, 0x7b, 0x88, 0xe2, 0x15, 0x32,
+	0xc7, 0xe3, 0xd6, 0x5f, 0x0c, 0x40, 0xf3, 0xba, 0xff, 0x6f, 0xd3, 0x71, 0xe2, 0x47, 0x49, 0xd6,
+	0x44, 0x9b, 0x38, 0xa7, 0xd1, 0x73, 0x00, 0x97, 0xc6, 0x09, 0x75, 0x88, 0xa0, 0x6e, 0x5a, 0x24,
+	0x25, 0x8e, 0xc4, 0x9e, 0x28, 0xf1, 0xf4, 0x6e, 0x3b, 0x58, 0x7d, 0x5b, 0x8b, 0xd0, 0x54, 0xcf,
+	0x1e, 0xeb, 0x8f, 0x80, 0xe6, 0x87, 0x7b, 0xd9, 0x97, 0xb9, 0x20, 0x89, 0xb0, 0xab, 0x68, 0xd6,
+	0x55, 0xcc, 0x53, 0x0d, 0x69, 0xcf, 0xa1, 0x4b, 0x99, 0x6b, 0x57, 0xeb, 0xaa, 0x43, 0x99, 0xab,
+	0xe5, 0x56, 0x00, 0x2b, 0x0f, 0x8c, 0xfc, 0x68, 0x03, 0xda, 0x29, 0x70, 0x66, 0xd3, 0xc9, 0x1c,
+	0x42, 0xe7, 0x0a, 0xe8, 0x25, 0x34, 0x9d, 0xe9, 0x8c, 0x5d, 0x99, 0x0b, 0xd5, 0xb9, 0x6d, 0x37,
+	0x88, 0x9c, 0xab, 0x91, 0x94, 0x60, 0xad, 0x60, 0xfd, 0xdb, 0x00, 0x28, 0xb8, 0x8f, 0x03, 0xf1,
+	0x2a, 0x34, 0x7d, 0xe6, 0xd2, 0x5b, 0xe5, 0xb1, 0x8f, 0x35, 0x21, 0xb9, 0x22, 0x12, 0x24, 0x50,
+	0xd1, 0xeb, 0x63, 0x4d, 0xe4, 0xa0, 0xdd, 0x28, 0x81, 0xf6, 0x53, 0x68, 0xe9, 0x4e, 0xa5, 0x10,
+	0xaf, 0x87, 0x53, 0x4a, 0xde, 0xd6, 0x74, 0xd7, 0x76, 0x2a, 0x6f, 0x29, 0x79, 0x3f, 0xe5, 0xea,
+	0x6e, 0x68, 0x1d, 0xc0, 0xea, 0x43, 0xef, 0x02, 0xb4, 0x55, 0xf4, 0x43, 0x1d, 0x94, 0xfc, 0xdd,
+	0x99, 0x2a, 0x6a, 0xfb, 0xbc, 0x4d, 0x5a, 0xff, 0x32, 0xa0, 0x5f, 0x11, 0x15, 0x88, 0x6e, 0x94,
+	0x10, 0xfd, 0xfd, 0x4d, 0xe0, 0x39, 0x40, 0x81, 0xb0, 0x69, 0x27, 0x28, 0x71, 0xd0, 0xc7, 0xd0,
+	0xb9, 0x90, 0x31, 0x95, 0x49, 0x56, 0x51, 0x68, 0xe0, 0xb6, 0x62, 0x9c, 0xd2, 0x6b, 0xb4, 0x0e,
+	0x3d, 0x19, 0x62, 0x9f, 0xd9, 0x8a, 0x95, 0x76, 0x00, 0xe0, 0xf4, 0xfa, 0x90, 0xa9, 0x4c, 0x58,
+	0xdf, 0xc1, 0x93, 0x07, 0x1f, 0x31, 0x68, 0x7b, 0x6e, 0x42, 0x7d, 0x7a, 0xef, 0xb8, 0xfb, 0x5a,
+	0x5c, 0x9a, 0x53, 0xcf, 0x61, 0x50, 0x95, 0xa1, 0x2f, 0xf3, 0x54, 0x18, 0xd5, 0xa7, 0x7a, 0x35,
+	0x64, 0x59, 0x86, 0x4a, 0xff, 0xa0, 0xd2, 0x91, 0x23, 0x25, 0xad, 0x3f, 0xe4, 0xae, 0xb3, 0x26,
+	0xfb, 0x02, 0x96, 0xc4, 0xad, 0x5d, 0x39, 0x5e, 0x3a, 0xf3, 0x8b, 0xdb, 0xd3, 0xfc, 0x80, 0x55,
+	0x97, 0xe5, 0xdf, 0x5a, 0xd6, 0x17, 0xb0, 0x74, 0xef, 0xcd, 0x28, 0x6b, 0x8c, 0x26, 0x49, 0x94,
+	0xa4, 0xf9, 0xd1, 0x84, 0xf5, 0x0e, 0x3a, 0xf9, 0xe4, 0x2f, 0x0b, 0xae, 0xd4, 0xd0, 0xd5, 0xb7,
+	0x5c, 0xe3, 0x86, 0x26, 0x5c, 0x26, 0x48, 0xe7, 0x2f, 0x23, 0xdf, 0x37, 0xdd, 0xfe, 0xe2, 0x77,
+	0xd0, 0x2d, 0x4d, 0x4b, 0xf7, 0xdf, 0x77, 0x7d, 0xe8, 0xec, 0xbe, 0x3d, 0x1e, 0x7d, 0x67, 0x4f,
+	0x4e, 0x0f, 0x86, 0x86, 0x7c, 0xc6, 0x1d, 0xee, 0xed, 0x1f, 0x9d, 0x1d, 0x9e, 0x9d, 0x2b, 0xce,
+	0xc2, 0xf6, 0x9f, 0xa1, 0xa5, 0xa7, 0x55, 0xf4, 0x35, 0xf4, 0xf4, 0xd7, 0xa9, 0x48, 0x28, 0x09,
+	0xd1, 0x1c, 0xf8, 0xae, 0xcd, 0x71, 0xac, 0xda, 0x4b, 0xe3, 0xb5, 0x81, 0x3e, 0x87, 0xc6, 0x89,
+	0xcf, 0x3c, 0x54, 0xfd, 0xcf, 0xb2, 0x56, 0x25, 0xad, 0xda, 0xee, 0x97, 0x7f, 0xda, 0xf0, 0x7c,
+	0x31, 0x9d, 0x5d, 0xc8, 0x69, 0x60, 0x6b, 0x7a, 0x17, 0xd3, 0x44, 0x3f, 0xac, 0xb6, 0x2e, 0xc9,
+	0x45, 0xe2, 0x3b, 0x5b, 0xea, 0xd7, 0x26, 0xdf, 0xd2, 0x66, 0x17, 0x2d, 0x45, 0x7e, 0xf5, 0xbf,
+	0x01, 0x00, 0xa0, 0x38, 0x55, 0x2a, 0x22, 0x15, 0x00, 0x00,
 }
@@ -36,7 +36,7 @@ func (m *MSPConfig) Reset()         { *m = MSPConfig{} }
 func (m *MSPConfig) String() string { return proto.CompactTextString(m) }
 func (*MSPConfig) ProtoMessage()    {}
 func (*MSPConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_msp_config_e749e5bd1d6d997b, []int{0}
+	return fileDescriptor_msp_config_025d9d43823c70d8, []int{0}
 }
 func (m *MSPConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MSPConfig.Unmarshal(m, b)
@@ -121,17 +121,21 @@ type FabricMSPConfig struct {
 	TlsIntermediateCerts [][]byte `protobuf:"bytes,10,rep,name=tls_intermediate_certs,json=tlsIntermediateCerts,proto3" json:"tls_intermediate_certs,omitempty"`
 	// fabric_node_ous contains the configuration to distinguish clients from peers from orderers
 	// based on the OUs.
-	FabricNodeOus        *FabricNodeOUs `protobuf:"bytes,11,opt,name=fabric_node_ous,json=fabricNodeOus,proto3" json:"fabric_node_ous,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	FabricNodeOus *FabricNodeOUs `protobuf:"bytes,11,opt,name=fabric_node_ous,json=fabricNodeOus,proto3" json:"fabric_node_ous,omitempty"`
+	// certificate_constraints contains the constraints that the certification
+	// paths of the identities must satisfy, in addition to the ones of the
+	// CA certificates.
+	CertificateConstraints *FabricCertificateConstraints `protobuf:"bytes,12,opt,name=certificate_constraints,json=certificateConstraints,proto3" json:"certificate_constraints,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                      `json:"-"`
+	XXX_unrecognized       []byte                        `json:"-"`
+	XXX_sizecache          int32                         `json:"-"`
 }
 
 func (m *FabricMSPConfig) Reset()         { *m = FabricMSPConfig{} }
 func (m *FabricMSPConfig) String() string { return proto.CompactTextString(m) }
 func (*FabricMSPConfig) ProtoMessage()    {}
 func (*FabricMSPConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_msp_config_e749e5bd1d6d997b, []int{1}
+	return fileDescriptor_msp_config_025d9d43823c70d8, []int{1}
 }
 func (m *FabricMSPConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FabricMSPConfig.Unmarshal(m, b)
@@ -228,6 +232,91 @@ func (m *FabricMSPConfig) GetFabricNodeOus() *FabricNodeOUs {
 	return nil
 }
 
+func (m *FabricMSPConfig) GetCertificateConstraints() *FabricCertificateConstraints {
+	if m != nil {
+		return m.CertificateConstraints
+	}
+	return nil
+}
+
+// FabricCertificateConstraints contains the constraints enforced by an MSP on
+// the certification paths of its identities, on top of the path length
+// constraints and name constraints of its CA certificates.
+type FabricCertificateConstraints struct {
+	// MaxChainLength is the maximum number of certificates in the certification
+	// chain of an identity, including the identity certificate and the root CA
+	// certificate. For instance, a value of 2 forbids intermediate CAs.
+	// Zero means no limit.
+	MaxChainLength uint32 `protobuf:"varint,1,opt,name=max_chain_length,json=maxChainLength,proto3" json:"max_chain_length,omitempty"`
+	// PermittedDNSDomains, if not empty, lists the domains that the DNS names of
+	// the identity certificates must be in. A domain with a leading dot only
+	// matches its subdomains.
+	PermittedDnsDomains []string `protobuf:"bytes,2,rep,name=permitted_dns_domains,json=permittedDnsDomains,proto3" json:"permitted_dns_domains,omitempty"`
+	// ExcludedDNSDomains lists the domains that the DNS names of the identity
+	// certificates must not be in.
+	ExcludedDnsDomains []string `protobuf:"bytes,3,rep,name=excluded_dns_domains,json=excludedDnsDomains,proto3" json:"excluded_dns_domains,omitempty"`
+	// ExtendedKeyUsages, if not empty, lists the extended key usages the identity
+	// certificates must allow one of, e.g. "clientAuth" or "serverAuth".
+	// The certificates of the certification path without the extended key usage
+	// extension allow any usage.
+	ExtendedKeyUsages    []string `protobuf:"bytes,4,rep,name=extended_key_usages,json=extendedKeyUsages,proto3" json:"extended_key_usages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FabricCertificateConstraints) Reset()         { *m = FabricCertificateConstraints{} }
+func (m *FabricCertificateConstraints) String() string { return proto.CompactTextString(m) }
+func (*FabricCertificateConstraints) ProtoMessage()    {}
+func (*FabricCertificateConstraints) Descriptor() ([]byte, []int) {
+	return fileDescriptor_msp_config_025d9d43823c70d8, []int{2}
+}
+func (m *FabricCertificateConstraints) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FabricCertificateConstraints.Unmarshal(m, b)
+}
+func (m *FabricCertificateConstraints) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FabricCertificateConstraints.Marshal(b, m, deterministic)
+}
+func (dst *FabricCertificateConstraints) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FabricCertificateConstraints.Merge(dst, src)
+}
+func (m *FabricCertificateConstraints) XXX_Size() int {
+	return xxx_messageInfo_FabricCertificateConstraints.Size(m)
+}
+func (m *FabricCertificateConstraints) XXX_DiscardUnknown() {
+	xxx_messageInfo_FabricCertificateConstraints.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FabricCertificateConstraints proto.InternalMessageInfo
+
+func (m *FabricCertificateConstraints) GetMaxChainLength() uint32 {
+	if m != nil {
+		return m.MaxChainLength
+	}
+	return 0
+}
+
+func (m *FabricCertificateConstraints) GetPermittedDnsDomains() []string {
+	if m != nil {
+		return m.PermittedDnsDomains
+	}
+	return nil
+}
+
+func (m *FabricCertificateConstraints) GetExcludedDnsDomains() []string {
+	if m != nil {
+		return m.ExcludedDnsDomains
+	}
+	return nil
+}
+
+func (m *FabricCertificateConstraints) GetExtendedKeyUsages() []string {
+	if m != nil {
+		return m.ExtendedKeyUsages
+	}
+	return nil
+}
+
 // FabricCryptoConfig contains configuration parameters
 // for the cryptographic algorithms used by the MSP
 // this configuration refers to
@@ -249,7 +338,7 @@ func (m *FabricCryptoConfig) Reset()         { *m = FabricCryptoConfig{} }
 func (m *FabricCryptoConfig) String() string { return proto.CompactTextString(m) }
 func (*FabricCryptoConfig) ProtoMessage()    {}
 func (*FabricCryptoConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_msp_config_e749e5bd1d6d997b, []int{2}
+	return fileDescriptor_msp_config_025d9d43823c70d8, []int{3}
 }
 func (m *FabricCryptoConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FabricCryptoConfig.Unmarshal(m, b)
@@ -305,7 +394,7 @@ func (m *IdemixMSPConfig) Reset()         { *m = IdemixMSPConfig{} }
 func (m *IdemixMSPConfig) String() string { return proto.CompactTextString(m) }
 func (*IdemixMSPConfig) ProtoMessage()    {}
 func (*IdemixMSPConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_msp_config_e749e5bd1d6d997b, []int{3}
+	return fileDescriptor_msp_config_025d9d43823c70d8, []int{4}
 }
 func (m *IdemixMSPConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IdemixMSPConfig.Unmarshal(m, b)
@@ -383,7 +472,7 @@ func (m *IdemixMSPSignerConfig) Reset()         { *m = IdemixMSPSignerConfig{} }
 func (m *IdemixMSPSignerConfig) String() string { return proto.CompactTextString(m) }
 func (*IdemixMSPSignerConfig) ProtoMessage()    {}
 func (*IdemixMSPSignerConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_msp_config_e749e5bd1d6d997b, []int{4}
+	return fileDescriptor_msp_config_025d9d43823c70d8, []int{5}
 }
 func (m *IdemixMSPSignerConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IdemixMSPSignerConfig.Unmarshal(m, b)
@@ -465,7 +554,7 @@ func (m *SigningIdentityInfo) Reset()         { *m = SigningIdentityInfo{} }
 func (m *SigningIdentityInfo) String() string { return proto.CompactTextString(m) }
 func (*SigningIdentityInfo) ProtoMessage()    {}
 func (*SigningIdentityInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_msp_config_e749e5bd1d6d997b, []int{5}
+	return fileDescriptor_msp_config_025d9d43823c70d8, []int{6}
 }
 func (m *SigningIdentityInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SigningIdentityInfo.Unmarshal(m, b)
@@ -520,7 +609,7 @@ func (m *KeyInfo) Reset()         { *m = KeyInfo{} }
 func (m *KeyInfo) String() string { return proto.CompactTextString(m) }
 func (*KeyInfo) ProtoMessage()    {}
 func (*KeyInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_msp_config_e749e5bd1d6d997b, []int{6}
+	return fileDescriptor_msp_config_025d9d43823c70d8, []int{7}
 }
 func (m *KeyInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KeyInfo.Unmarshal(m, b)
@@ -577,7 +666,7 @@ func (m *FabricOUIdentifier) Reset()         { *m = FabricOUIdentifier{} }
 func (m *FabricOUIdentifier) String() string { return proto.CompactTextString(m) }
 func (*FabricOUIdentifier) ProtoMessage()    {}
 func (*FabricOUIdentifier) Descriptor() ([]byte, []int) {
-	return fileDescriptor_msp_config_e749e5bd1d6d997b, []int{7}
+	return fileDescriptor_msp_config_025d9d43823c70d8, []int{8}
 }
 func (m *FabricOUIdentifier) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FabricOUIdentifier.Unmarshal(m, b)
@@ -630,7 +719,7 @@ func (m *FabricNodeOUs) Reset()         { *m = FabricNodeOUs{} }
 func (m *FabricNodeOUs) String() string { return proto.CompactTextString(m) }
 func (*FabricNodeOUs) ProtoMessage()    {}
 func (*FabricNodeOUs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_msp_config_e749e5bd1d6d997b, []int{8}
+	return fileDescriptor_msp_config_025d9d43823c70d8, []int{9}
 }
 func (m *FabricNodeOUs) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FabricNodeOUs.Unmarshal(m, b)
@@ -674,6 +763,7 @@ func (m *FabricNodeOUs) GetPeerOuIdentifier() *FabricOUIdentifier {
 func init() {
 	proto.RegisterType((*MSPConfig)(nil), "msp.MSPConfig")
 	proto.RegisterType((*FabricMSPConfig)(nil), "msp.FabricMSPConfig")
+	proto.RegisterType((*FabricCertificateConstraints)(nil), "msp.FabricCertificateConstraints")
 	proto.RegisterType((*FabricCryptoConfig)(nil), "msp.FabricCryptoConfig")
 	proto.RegisterType((*IdemixMSPConfig)(nil), "msp.IdemixMSPConfig")
 	proto.RegisterType((*IdemixMSPSignerConfig)(nil), "msp.IdemixMSPSignerConfig")
@@ -683,61 +773,70 @@ func init() {
 	proto.RegisterType((*FabricNodeOUs)(nil), "msp.FabricNodeOUs")
 }
 
-func init() { proto.RegisterFile("msp/msp_config.proto", fileDescriptor_msp_config_e749e5bd1d6d997b) }
-
-var fileDescriptor_msp_config_e749e5bd1d6d997b = []byte{
-	// 847 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0x5f, 0x6f, 0xe3, 0x44,
-	0x10, 0x57, 0x92, 0x26, 0x77, 0x99, 0x38, 0x49, 0xd9, 0xeb, 0x15, 0x0b, 0x71, 0x77, 0xa9, 0x01,
-	0x91, 0x17, 0x52, 0xa9, 0x87, 0x84, 0x84, 0x78, 0xba, 0xc2, 0x09, 0x03, 0xa5, 0xd5, 0x56, 0x7d,
-	0xe1, 0xc5, 0xda, 0xd8, 0x9b, 0x64, 0x65, 0x7b, 0xd7, 0xda, 0x5d, 0x9f, 0x08, 0xe2, 0x99, 0x2f,
-	0xc0, 0x77, 0xe0, 0x99, 0x57, 0xbe, 0x1d, 0xda, 0x3f, 0x8d, 0x9d, 0x6b, 0x15, 0x78, 0x9b, 0x9d,
-	0xf9, 0xcd, 0xcf, 0xb3, 0xbf, 0x99, 0x59, 0xc3, 0x49, 0xa9, 0xaa, 0xf3, 0x52, 0x55, 0x49, 0x2a,
-	0xf8, 0x8a, 0xad, 0x17, 0x95, 0x14, 0x5a, 0xa0, 0x5e, 0xa9, 0xaa, 0xe8, 0x2b, 0x18, 0x5e, 0xdd,
-	0xde, 0x5c, 0x5a, 0x3f, 0x42, 0x70, 0xa4, 0xb7, 0x15, 0x0d, 0x3b, 0xb3, 0xce, 0xbc, 0x8f, 0xad,
-	0x8d, 0x4e, 0x61, 0xe0, 0xb2, 0xc2, 0xee, 0xac, 0x33, 0x0f, 0xb0, 0x3f, 0x45, 0x7f, 0x1f, 0xc1,
-	0xf4, 0x2d, 0x59, 0x4a, 0x96, 0xee, 0xe5, 0x73, 0x52, 0xba, 0xfc, 0x21, 0xb6, 0x36, 0x7a, 0x01,
-	0x20, 0x85, 0xd0, 0x49, 0x4a, 0xa5, 0x56, 0x61, 0x77, 0xd6, 0x9b, 0x07, 0x78, 0x68, 0x3c, 0x97,
-	0xc6, 0x81, 0xbe, 0x00, 0xc4, 0xb8, 0xa6, 0xb2, 0xa4, 0x19, 0x23, 0x9a, 0x7a, 0x58, 0xcf, 0xc2,
-	0x3e, 0x68, 0x47, 0x1c, 0xfc, 0x14, 0x06, 0x24, 0x2b, 0x19, 0x57, 0xe1, 0x91, 0x85, 0xf8, 0x13,
-	0xfa, 0x1c, 0xa6, 0x92, 0xbe, 0x13, 0x29, 0xd1, 0x4c, 0xf0, 0xa4, 0x60, 0x4a, 0x87, 0x7d, 0x0b,
-	0x98, 0x34, 0xee, 0x9f, 0x98, 0xd2, 0xe8, 0x12, 0x8e, 0x15, 0x5b, 0x73, 0xc6, 0xd7, 0x09, 0xcb,
-	0x28, 0xd7, 0x4c, 0x6f, 0xc3, 0xc1, 0xac, 0x33, 0x1f, 0x5d, 0x84, 0x8b, 0x52, 0x55, 0x8b, 0x5b,
-	0x17, 0x8c, 0x7d, 0x2c, 0xe6, 0x2b, 0x81, 0xa7, 0x6a, 0xdf, 0x89, 0x12, 0x78, 0x25, 0xe4, 0x9a,
-	0x70, 0xf6, 0x9b, 0x25, 0x26, 0x45, 0x52, 0x73, 0xa6, 0x3d, 0xe1, 0x8a, 0x51, 0xa9, 0xc2, 0x27,
-	0xb3, 0xde, 0x7c, 0x74, 0xf1, 0xa1, 0xe5, 0x74, 0x32, 0x5d, 0xdf, 0xc5, 0xbb, 0x38, 0x7e, 0xb1,
-	0x9f, 0x7f, 0xc7, 0x99, 0x6e, 0xa2, 0x0a, 0x7d, 0x03, 0xe3, 0x54, 0x6e, 0x2b, 0x2d, 0x7c, 0xc7,
-	0xc2, 0xa7, 0xb6, 0xc4, 0x36, 0xdd, 0xa5, 0x8d, 0x3b, 0xe1, 0x71, 0x90, 0xb6, 0x4e, 0xe8, 0x53,
-	0x98, 0xe8, 0x42, 0x25, 0x2d, 0xd9, 0x87, 0x56, 0x8b, 0x40, 0x17, 0x0a, 0xef, 0x94, 0xff, 0x12,
-	0x4e, 0x0d, 0xea, 0x11, 0xf5, 0xc1, 0xa2, 0x4f, 0x74, 0xa1, 0xe2, 0x07, 0x0d, 0xf8, 0x1a, 0xa6,
-	0x2b, 0xfb, 0xfd, 0x84, 0x8b, 0x8c, 0x26, 0xa2, 0x56, 0xe1, 0xc8, 0xd6, 0x86, 0x5a, 0xb5, 0xfd,
-	0x2c, 0x32, 0x7a, 0x7d, 0xa7, 0xf0, 0x78, 0xd5, 0x1c, 0x6b, 0x15, 0xfd, 0xd9, 0x01, 0xf4, 0xb0,
-	0x78, 0x74, 0x01, 0xcf, 0x8d, 0xc0, 0x44, 0xd7, 0x92, 0x26, 0x1b, 0xa2, 0x36, 0xc9, 0x8a, 0x94,
-	0xac, 0xd8, 0xfa, 0x31, 0x7a, 0xb6, 0x0b, 0x7e, 0x4f, 0xd4, 0xe6, 0xad, 0x0d, 0xa1, 0x18, 0xce,
-	0xee, 0xdb, 0xd7, 0x92, 0xdd, 0x67, 0xd7, 0x3c, 0x35, 0xb2, 0xda, 0x81, 0x1d, 0xe2, 0x97, 0xf7,
-	0xc0, 0x46, 0x60, 0x4b, 0xe4, 0x51, 0xd1, 0x5f, 0x1d, 0x98, 0xc6, 0x19, 0x2d, 0xd9, 0xaf, 0x87,
-	0x07, 0xf9, 0x18, 0x7a, 0xac, 0xca, 0xfd, 0x16, 0x18, 0x13, 0x5d, 0xc0, 0xc0, 0xd4, 0x46, 0x65,
-	0xd8, 0xb3, 0x12, 0x7c, 0x64, 0x25, 0xd8, 0x71, 0xdd, 0xda, 0x98, 0xef, 0x90, 0x47, 0xa2, 0x4f,
-	0x60, 0xdc, 0x1a, 0xd4, 0x2a, 0x0f, 0x8f, 0x2c, 0x5f, 0xd0, 0x38, 0x6f, 0x72, 0x74, 0x02, 0x7d,
-	0x5a, 0x89, 0x74, 0x13, 0xf6, 0x67, 0x9d, 0x79, 0x0f, 0xbb, 0x43, 0xf4, 0x47, 0x17, 0x9e, 0x3f,
-	0x4a, 0x6e, 0xca, 0x4d, 0x25, 0xcd, 0x6c, 0xb9, 0x01, 0xb6, 0x36, 0x9a, 0x40, 0x57, 0xdd, 0x57,
-	0xdb, 0x55, 0x39, 0xfa, 0x16, 0x5e, 0x1e, 0x9e, 0x59, 0x7b, 0x89, 0x21, 0xfe, 0xf8, 0xd0, 0x64,
-	0x9a, 0x2f, 0x49, 0x51, 0x50, 0x5b, 0x75, 0x1f, 0x5b, 0xdb, 0x5c, 0x89, 0x72, 0x29, 0x8a, 0xa2,
-	0xa4, 0xdc, 0x10, 0xda, 0xaa, 0x87, 0x38, 0x68, 0x9c, 0x71, 0x86, 0x7e, 0x80, 0x33, 0x53, 0x96,
-	0x21, 0x22, 0x45, 0xd2, 0x92, 0x80, 0xf1, 0x95, 0x90, 0xa5, 0xb5, 0xed, 0x22, 0x06, 0xf8, 0x55,
-	0x03, 0xc4, 0x3b, 0x5c, 0xdc, 0xc0, 0x22, 0x01, 0xcf, 0x1e, 0x59, 0x53, 0x53, 0x47, 0x55, 0x2f,
-	0x0b, 0x96, 0x26, 0xbe, 0x2b, 0x4e, 0x8e, 0xc0, 0x39, 0x9d, 0x60, 0xe8, 0x35, 0x4c, 0x2a, 0xc9,
-	0xde, 0x99, 0x61, 0xf7, 0xa8, 0xae, 0xed, 0x5d, 0x60, 0x7b, 0xf7, 0x23, 0x75, 0x1b, 0x3f, 0xf6,
-	0x18, 0x97, 0x14, 0xdd, 0xc2, 0x13, 0x1f, 0x41, 0x9f, 0xc1, 0x24, 0xa7, 0xed, 0x99, 0xf3, 0x33,
-	0x32, 0xce, 0x69, 0x6b, 0xc0, 0xd0, 0x19, 0x04, 0x06, 0x56, 0x12, 0x4d, 0x25, 0x23, 0x85, 0xef,
-	0xc3, 0x28, 0xa7, 0xdb, 0x2b, 0xef, 0x8a, 0x7e, 0xbf, 0x5f, 0x86, 0xf6, 0xc3, 0x80, 0x66, 0x30,
-	0x32, 0x4b, 0xc8, 0x56, 0x2c, 0x25, 0x9a, 0xfa, 0x2b, 0xb4, 0x5d, 0xff, 0xa3, 0x91, 0xdd, 0xff,
-	0x6e, 0x64, 0xf4, 0x4f, 0x07, 0xc6, 0x7b, 0xcb, 0x6a, 0x9e, 0x56, 0xca, 0xc9, 0xb2, 0x70, 0x1f,
-	0x7d, 0x8a, 0xfd, 0x09, 0xc5, 0x70, 0x92, 0x16, 0xcc, 0xb4, 0x56, 0xd4, 0xef, 0x7f, 0xe5, 0xc0,
-	0x0b, 0x87, 0x5c, 0xd2, 0x75, 0xdd, 0xba, 0xdc, 0x77, 0x80, 0x2a, 0x4a, 0xe5, 0x7b, 0x44, 0xbd,
-	0xc3, 0x44, 0xc7, 0x26, 0xa5, 0x4d, 0xf3, 0x26, 0x81, 0x33, 0x21, 0xd7, 0x8b, 0xcd, 0xb6, 0xa2,
-	0xb2, 0xa0, 0xd9, 0x9a, 0xca, 0x85, 0x7b, 0x68, 0xdc, 0x8f, 0x4d, 0x19, 0xa6, 0x37, 0xc7, 0x57,
-	0xaa, 0x72, 0xeb, 0x71, 0x43, 0xd2, 0x9c, 0xac, 0xe9, 0x2f, 0xf3, 0x35, 0xd3, 0x9b, 0x7a, 0xb9,
-	0x48, 0x45, 0x79, 0xde, 0xca, 0x3d, 0x77, 0xb9, 0xe7, 0x2e, 0xd7, 0xfc, 0x26, 0x97, 0x03, 0x6b,
-	0xbf, 0xfe, 0x37, 0x00, 0x00, 0xff, 0xff, 0x54, 0x67, 0x46, 0xdb, 0x38, 0x07, 0x00, 0x00,
+func init() { proto.RegisterFile("msp/msp_config.proto", fileDescriptor_msp_config_025d9d43823c70d8) }
+
+var fileDescriptor_msp_config_025d9d43823c70d8 = []byte{
+	// 979 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xcd, 0x6e, 0xe3, 0x36,
+	0x10, 0x86, 0xec, 0x75, 0x76, 0x3d, 0x91, 0x9d, 0x2c, 0x93, 0xcd, 0x0a, 0xc5, 0xfe, 0x38, 0x6a,
+	0x8b, 0xfa, 0x52, 0xa7, 0xc8, 0x16, 0x28, 0x50, 0xf4, 0xb4, 0x4e, 0x17, 0x75, 0x77, 0xd3, 0x04,
+	0x0c, 0x72, 0xd9, 0x8b, 0xc0, 0x48, 0xb4, 0x4c, 0x58, 0x22, 0x05, 0x92, 0x5a, 0xc4, 0x45, 0xcf,
+	0x3d, 0xf5, 0xd6, 0x77, 0xe8, 0x3b, 0xf4, 0x65, 0xfa, 0x2c, 0x05, 0x7f, 0x62, 0x29, 0x3f, 0x70,
+	0x7b, 0x23, 0x67, 0xbe, 0xef, 0xe3, 0x70, 0x66, 0x38, 0x12, 0xec, 0x97, 0xaa, 0x3a, 0x2a, 0x55,
+	0x95, 0xa4, 0x82, 0xcf, 0x59, 0x3e, 0xa9, 0xa4, 0xd0, 0x02, 0x75, 0x4b, 0x55, 0xc5, 0xdf, 0x41,
+	0xff, 0xf4, 0xe2, 0x7c, 0x6a, 0xed, 0x08, 0xc1, 0x23, 0xbd, 0xaa, 0x68, 0x14, 0x8c, 0x82, 0x71,
+	0x0f, 0xdb, 0x35, 0x3a, 0x80, 0x2d, 0xc7, 0x8a, 0x3a, 0xa3, 0x60, 0x1c, 0x62, 0xbf, 0x8b, 0xff,
+	0xe8, 0xc1, 0xce, 0x3b, 0x72, 0x25, 0x59, 0x7a, 0x8b, 0xcf, 0x49, 0xe9, 0xf8, 0x7d, 0x6c, 0xd7,
+	0xe8, 0x25, 0x80, 0x14, 0x42, 0x27, 0x29, 0x95, 0x5a, 0x45, 0x9d, 0x51, 0x77, 0x1c, 0xe2, 0xbe,
+	0xb1, 0x4c, 0x8d, 0x01, 0x7d, 0x0d, 0x88, 0x71, 0x4d, 0x65, 0x49, 0x33, 0x46, 0x34, 0xf5, 0xb0,
+	0xae, 0x85, 0x3d, 0x6d, 0x7b, 0x1c, 0xfc, 0x00, 0xb6, 0x48, 0x56, 0x32, 0xae, 0xa2, 0x47, 0x16,
+	0xe2, 0x77, 0xe8, 0x2b, 0xd8, 0x91, 0xf4, 0x93, 0x48, 0x89, 0x66, 0x82, 0x27, 0x05, 0x53, 0x3a,
+	0xea, 0x59, 0xc0, 0xb0, 0x31, 0x7f, 0x60, 0x4a, 0xa3, 0x29, 0xec, 0x2a, 0x96, 0x73, 0xc6, 0xf3,
+	0x84, 0x65, 0x94, 0x6b, 0xa6, 0x57, 0xd1, 0xd6, 0x28, 0x18, 0x6f, 0x1f, 0x47, 0x93, 0x52, 0x55,
+	0x93, 0x0b, 0xe7, 0x9c, 0x79, 0xdf, 0x8c, 0xcf, 0x05, 0xde, 0x51, 0xb7, 0x8d, 0x28, 0x81, 0xd7,
+	0x42, 0xe6, 0x84, 0xb3, 0x5f, 0xad, 0x30, 0x29, 0x92, 0x9a, 0x33, 0xed, 0x05, 0xe7, 0x8c, 0x4a,
+	0x15, 0x3d, 0x1e, 0x75, 0xc7, 0xdb, 0xc7, 0xcf, 0xad, 0xa6, 0x4b, 0xd3, 0xd9, 0xe5, 0x6c, 0xed,
+	0xc7, 0x2f, 0x6f, 0xf3, 0x2f, 0x39, 0xd3, 0x8d, 0x57, 0xa1, 0x1f, 0x60, 0x90, 0xca, 0x55, 0xa5,
+	0x85, 0xaf, 0x58, 0xf4, 0x64, 0x14, 0xdc, 0x91, 0x9b, 0x5a, 0xbf, 0x4b, 0x3c, 0x0e, 0xd3, 0xd6,
+	0x0e, 0x7d, 0x01, 0x43, 0x5d, 0xa8, 0xa4, 0x95, 0xf6, 0xbe, 0xcd, 0x45, 0xa8, 0x0b, 0x85, 0xd7,
+	0x99, 0xff, 0x16, 0x0e, 0x0c, 0xea, 0x81, 0xec, 0x83, 0x45, 0xef, 0xeb, 0x42, 0xcd, 0xee, 0x15,
+	0xe0, 0x7b, 0xd8, 0x99, 0xdb, 0xf3, 0x13, 0x2e, 0x32, 0x9a, 0x88, 0x5a, 0x45, 0xdb, 0x36, 0x36,
+	0xd4, 0x8a, 0xed, 0x17, 0x91, 0xd1, 0xb3, 0x4b, 0x85, 0x07, 0xf3, 0x66, 0x5b, 0x2b, 0xf4, 0x11,
+	0x9e, 0x9b, 0x03, 0xd8, 0x9c, 0xa5, 0xf6, 0x30, 0xc1, 0x95, 0x96, 0x84, 0x71, 0xad, 0xa2, 0xd0,
+	0x6a, 0x1c, 0xb6, 0xef, 0xd7, 0x20, 0xa7, 0x0d, 0x10, 0x1f, 0xa4, 0x0f, 0xda, 0xe3, 0x7f, 0x02,
+	0x78, 0xb1, 0x89, 0x88, 0xc6, 0xb0, 0x5b, 0x92, 0xeb, 0x24, 0x5d, 0x10, 0xc6, 0x93, 0x82, 0xf2,
+	0x5c, 0x2f, 0x6c, 0x9f, 0x0e, 0xf0, 0xb0, 0x24, 0xd7, 0x53, 0x63, 0xfe, 0x60, 0xad, 0xe8, 0x18,
+	0x9e, 0x55, 0x54, 0x96, 0x4c, 0x6b, 0x9a, 0x25, 0x19, 0x57, 0x49, 0x26, 0x4a, 0xc2, 0xb8, 0x6b,
+	0xde, 0x3e, 0xde, 0x5b, 0x3b, 0x4f, 0xb8, 0x3a, 0x71, 0x2e, 0xf4, 0x0d, 0xec, 0xd3, 0xeb, 0xb4,
+	0xa8, 0xb3, 0x3b, 0x94, 0xae, 0xa5, 0xa0, 0x1b, 0x5f, 0x8b, 0x31, 0x81, 0x3d, 0x7a, 0xad, 0x29,
+	0x37, 0x8c, 0x25, 0x5d, 0x25, 0xb5, 0x22, 0x39, 0x75, 0x6d, 0xdd, 0xc7, 0x4f, 0x6f, 0x5c, 0xef,
+	0xe9, 0xea, 0xd2, 0x3a, 0xe2, 0x3f, 0x03, 0x40, 0xf7, 0x2b, 0x6f, 0x82, 0x35, 0xdd, 0x49, 0x74,
+	0x2d, 0x69, 0xb2, 0x20, 0x6a, 0x91, 0xcc, 0x49, 0xc9, 0x8a, 0x95, 0x7f, 0x83, 0x7b, 0x6b, 0xe7,
+	0x4f, 0x44, 0x2d, 0xde, 0x59, 0x17, 0x9a, 0xc1, 0xe1, 0x4d, 0xef, 0xb7, 0x7a, 0xd6, 0xb3, 0x6b,
+	0x9e, 0x9a, 0x9e, 0xb4, 0xaf, 0xbd, 0x8f, 0x5f, 0xdd, 0x00, 0x9b, 0xee, 0xb4, 0x42, 0x1e, 0x15,
+	0xff, 0x15, 0xc0, 0xce, 0x2c, 0xa3, 0x25, 0xbb, 0xde, 0x3c, 0x05, 0x76, 0xa1, 0xcb, 0xaa, 0xa5,
+	0x1f, 0x21, 0x66, 0x89, 0x8e, 0x61, 0xcb, 0xc4, 0x46, 0x65, 0xd4, 0xb5, 0xb5, 0xff, 0xcc, 0xd6,
+	0x7e, 0xad, 0x75, 0x61, 0x7d, 0xbe, 0xbd, 0x3d, 0x12, 0x7d, 0x0e, 0x83, 0xd6, 0x2b, 0xaf, 0x96,
+	0xd1, 0x23, 0xab, 0x17, 0x36, 0xc6, 0xf3, 0x25, 0xda, 0x87, 0x1e, 0xad, 0x44, 0xba, 0x88, 0x7a,
+	0xa3, 0x60, 0xdc, 0xc5, 0x6e, 0x13, 0xff, 0xde, 0x81, 0x67, 0x0f, 0x8a, 0x9b, 0x70, 0x53, 0x49,
+	0x33, 0x1b, 0x6e, 0x88, 0xed, 0x1a, 0x0d, 0xa1, 0xa3, 0x6e, 0xa2, 0xed, 0xa8, 0x25, 0x3a, 0x81,
+	0x57, 0x9b, 0x1f, 0xbc, 0xbd, 0x44, 0x1f, 0xbf, 0xd8, 0xf4, 0xac, 0xcd, 0x49, 0x52, 0x14, 0xd4,
+	0x46, 0xdd, 0xc3, 0x76, 0x6d, 0xae, 0x44, 0xb9, 0x14, 0x45, 0x51, 0x52, 0x6e, 0x04, 0x6d, 0xd4,
+	0x7d, 0x1c, 0x36, 0xc6, 0x59, 0x86, 0x7e, 0x86, 0x43, 0x13, 0x96, 0x11, 0x22, 0x45, 0xd2, 0x4a,
+	0x01, 0xe3, 0x73, 0x21, 0x4b, 0xbb, 0xb6, 0x53, 0x2c, 0xc4, 0xaf, 0x1b, 0x20, 0x5e, 0xe3, 0x66,
+	0x0d, 0x2c, 0x16, 0xb0, 0xf7, 0xc0, 0x8c, 0x33, 0x71, 0x54, 0xf5, 0x55, 0xc1, 0xd2, 0xc4, 0x57,
+	0xc5, 0xa5, 0x23, 0x74, 0x46, 0x97, 0x30, 0xf4, 0x06, 0x86, 0x95, 0x64, 0x9f, 0xcc, 0xe3, 0xf5,
+	0xa8, 0x8e, 0xad, 0x5d, 0x68, 0x6b, 0xf7, 0x9e, 0xba, 0x71, 0x39, 0xf0, 0x18, 0x47, 0x8a, 0x2f,
+	0xe0, 0xb1, 0xf7, 0xa0, 0x2f, 0x61, 0x68, 0x5a, 0xbd, 0x95, 0x36, 0xd7, 0x23, 0x83, 0x25, 0x6d,
+	0x35, 0x18, 0x3a, 0x84, 0xd0, 0xc0, 0x4a, 0xa2, 0xa9, 0x64, 0xa4, 0xf0, 0x75, 0xd8, 0x5e, 0xd2,
+	0xd5, 0xa9, 0x37, 0xc5, 0xbf, 0x01, 0xba, 0x3f, 0x55, 0xd1, 0x08, 0xb6, 0x5b, 0xe3, 0xc1, 0x5f,
+	0xa1, 0x6d, 0xfa, 0x1f, 0x85, 0xec, 0xfc, 0x77, 0x21, 0xe3, 0xbf, 0x03, 0x18, 0xdc, 0x9a, 0x74,
+	0xe6, 0xbb, 0x44, 0x39, 0xb9, 0x2a, 0xdc, 0xa1, 0x4f, 0xb0, 0xdf, 0xa1, 0x19, 0xec, 0xa7, 0x05,
+	0x33, 0xa5, 0x15, 0xf5, 0xdd, 0x53, 0x36, 0x7c, 0x1e, 0x90, 0x23, 0x9d, 0xd5, 0xad, 0xcb, 0xfd,
+	0x08, 0xa8, 0xa2, 0x54, 0xde, 0x11, 0xea, 0x6e, 0x16, 0xda, 0x35, 0x94, 0xb6, 0xcc, 0xdb, 0x04,
+	0x0e, 0x85, 0xcc, 0x27, 0x8b, 0x55, 0x45, 0x65, 0x41, 0xb3, 0x9c, 0xca, 0x89, 0x9b, 0xd2, 0xee,
+	0xaf, 0x40, 0x19, 0xa5, 0xb7, 0xbb, 0xa7, 0xaa, 0x72, 0xcf, 0xe3, 0x9c, 0xa4, 0x4b, 0x92, 0xd3,
+	0x8f, 0xe3, 0x9c, 0xe9, 0x45, 0x7d, 0x35, 0x49, 0x45, 0x79, 0xd4, 0xe2, 0x1e, 0x39, 0xee, 0x91,
+	0xe3, 0x9a, 0x7f, 0x8c, 0xab, 0x2d, 0xbb, 0x7e, 0xf3, 0xef, 0x00, 0xc0, 0x5a, 0x43, 0x46, 0x75,
+	0x08, 0x00, 0x00,
 }
@@ -44,7 +44,7 @@ func (x SeekInfo_SeekBehavior) String() string {
 	return proto.EnumName(SeekInfo_SeekBehavior_name, int32(x))
 }
 func (SeekInfo_SeekBehavior) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_ab_86effae0ebc2388c, []int{5, 0}
+	return fileDescriptor_ab_eab5c492b580a35e, []int{5, 0}
 }
 
 type BroadcastResponse struct {
@@ -61,7 +61,7 @@ func (m *BroadcastResponse) Reset()         { *m = BroadcastResponse{} }
 func (m *BroadcastResponse) String() string { return proto.CompactTextString(m) }
 func (*BroadcastResponse) ProtoMessage()    {}
 func (*BroadcastResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_86effae0ebc2388c, []int{0}
+	return fileDescriptor_ab_eab5c492b580a35e, []int{0}
 }
 func (m *BroadcastResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BroadcastResponse.Unmarshal(m, b)
@@ -105,7 +105,7 @@ func (m *SeekNewest) Reset()         { *m = SeekNewest{} }
 func (m *SeekNewest) String() string { return proto.CompactTextString(m) }
 func (*SeekNewest) ProtoMessage()    {}
 func (*SeekNewest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_86effae0ebc2388c, []int{1}
+	return fileDescriptor_ab_eab5c492b580a35e, []int{1}
 }
 func (m *SeekNewest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekNewest.Unmarshal(m, b)
@@ -135,7 +135,7 @@ func (m *SeekOldest) Reset()         { *m = SeekOldest{} }
 func (m *SeekOldest) String() string { return proto.CompactTextString(m) }
 func (*SeekOldest) ProtoMessage()    {}
 func (*SeekOldest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_86effae0ebc2388c, []int{2}
+	return fileDescriptor_ab_eab5c492b580a35e, []int{2}
 }
 func (m *SeekOldest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekOldest.Unmarshal(m, b)
@@ -166,7 +166,7 @@ func (m *SeekSpecified) Reset()         { *m = SeekSpecified{} }
 func (m *SeekSpecified) String() string { return proto.CompactTextString(m) }
 func (*SeekSpecified) ProtoMessage()    {}
 func (*SeekSpecified) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_86effae0ebc2388c, []int{3}
+	return fileDescriptor_ab_eab5c492b580a35e, []int{3}
 }
 func (m *SeekSpecified) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekSpecified.Unmarshal(m, b)
@@ -208,7 +208,7 @@ func (m *SeekPosition) Reset()         { *m = SeekPosition{} }
 func (m *SeekPosition) String() string { return proto.CompactTextString(m) }
 func (*SeekPosition) ProtoMessage()    {}
 func (*SeekPosition) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_86effae0ebc2388c, []int{4}
+	return fileDescriptor_ab_eab5c492b580a35e, []int{4}
 }
 func (m *SeekPosition) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekPosition.Unmarshal(m, b)
@@ -392,7 +392,7 @@ func (m *SeekInfo) Reset()         { *m = SeekInfo{} }
 func (m *SeekInfo) String() string { return proto.CompactTextString(m) }
 func (*SeekInfo) ProtoMessage()    {}
 func (*SeekInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_86effae0ebc2388c, []int{5}
+	return fileDescriptor_ab_eab5c492b580a35e, []int{5}
 }
 func (m *SeekInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekInfo.Unmarshal(m, b)
@@ -447,7 +447,7 @@ func (m *DeliverResponse) Reset()         { *m = DeliverResponse{} }
 func (m *DeliverResponse) String() string { return proto.CompactTextString(m) }
 func (*DeliverResponse) ProtoMessage()    {}
 func (*DeliverResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_86effae0ebc2388c, []int{6}
+	return fileDescriptor_ab_eab5c492b580a35e, []int{6}
 }
 func (m *DeliverResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeliverResponse.Unmarshal(m, b)
@@ -573,6 +573,119 @@ func _DeliverResponse_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+// InclusionQuery is the payload data of the envelope of an inclusion request,
+// signed by a reader of the channel
+type InclusionQuery struct {
+	TxId                 string   `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InclusionQuery) Reset()         { *m = InclusionQuery{} }
+func (m *InclusionQuery) String() string { return proto.CompactTextString(m) }
+func (*InclusionQuery) ProtoMessage()    {}
+func (*InclusionQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ab_eab5c492b580a35e, []int{7}
+}
+func (m *InclusionQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InclusionQuery.Unmarshal(m, b)
+}
+func (m *InclusionQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InclusionQuery.Marshal(b, m, deterministic)
+}
+func (dst *InclusionQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InclusionQuery.Merge(dst, src)
+}
+func (m *InclusionQuery) XXX_Size() int {
+	return xxx_messageInfo_InclusionQuery.Size(m)
+}
+func (m *InclusionQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_InclusionQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InclusionQuery proto.InternalMessageInfo
+
+func (m *InclusionQuery) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+type InclusionResponse struct {
+	// Status code, SUCCESS if the transaction is included in a block and
+	// NOT_FOUND if it is not included yet
+	Status common.Status `protobuf:"varint,1,opt,name=status,proto3,enum=common.Status" json:"status,omitempty"`
+	// Info string which may contain additional information about the status returned
+	Info                 string   `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	BlockNumber          uint64   `protobuf:"varint,3,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	TxIndex              uint32   `protobuf:"varint,4,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
+	Height               uint64   `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InclusionResponse) Reset()         { *m = InclusionResponse{} }
+func (m *InclusionResponse) String() string { return proto.CompactTextString(m) }
+func (*InclusionResponse) ProtoMessage()    {}
+func (*InclusionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ab_eab5c492b580a35e, []int{8}
+}
+func (m *InclusionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InclusionResponse.Unmarshal(m, b)
+}
+func (m *InclusionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InclusionResponse.Marshal(b, m, deterministic)
+}
+func (dst *InclusionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InclusionResponse.Merge(dst, src)
+}
+func (m *InclusionResponse) XXX_Size() int {
+	return xxx_messageInfo_InclusionResponse.Size(m)
+}
+func (m *InclusionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InclusionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InclusionResponse proto.InternalMessageInfo
+
+func (m *InclusionResponse) GetStatus() common.Status {
+	if m != nil {
+		return m.Status
+	}
+	return common.Status_UNKNOWN
+}
+
+func (m *InclusionResponse) GetInfo() string {
+	if m != nil {
+		return m.Info
+	}
+	return ""
+}
+
+func (m *InclusionResponse) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *InclusionResponse) GetTxIndex() uint32 {
+	if m != nil {
+		return m.TxIndex
+	}
+	return 0
+}
+
+func (m *InclusionResponse) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*BroadcastResponse)(nil), "orderer.BroadcastResponse")
 	proto.RegisterType((*SeekNewest)(nil), "orderer.SeekNewest")
@@ -581,6 +694,8 @@ func init() {
 	proto.RegisterType((*SeekPosition)(nil), "orderer.SeekPosition")
 	proto.RegisterType((*SeekInfo)(nil), "orderer.SeekInfo")
 	proto.RegisterType((*DeliverResponse)(nil), "orderer.DeliverResponse")
+	proto.RegisterType((*InclusionQuery)(nil), "orderer.InclusionQuery")
+	proto.RegisterType((*InclusionResponse)(nil), "orderer.InclusionResponse")
 	proto.RegisterEnum("orderer.SeekInfo_SeekBehavior", SeekInfo_SeekBehavior_name, SeekInfo_SeekBehavior_value)
 }
 
@@ -757,40 +872,113 @@ var _AtomicBroadcast_serviceDesc = grpc.ServiceDesc{
 	Metadata: "orderer/ab.proto",
 }
 
-func init() { proto.RegisterFile("orderer/ab.proto", fileDescriptor_ab_86effae0ebc2388c) }
-
-var fileDescriptor_ab_86effae0ebc2388c = []byte{
-	// 505 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x93, 0xdf, 0x6e, 0x12, 0x51,
-	0x10, 0xc6, 0x59, 0xa5, 0xb4, 0x8c, 0x2d, 0xa5, 0xa7, 0x69, 0x43, 0xb8, 0x30, 0xcd, 0x26, 0x55,
-	0x8c, 0xba, 0x6b, 0x30, 0xf1, 0x42, 0x4d, 0x0c, 0x6b, 0xdb, 0x40, 0x24, 0x60, 0x16, 0x7a, 0xa1,
-	0x37, 0x64, 0x77, 0x19, 0x60, 0xed, 0xb2, 0x67, 0x73, 0xce, 0x01, 0xd3, 0xa7, 0xf0, 0x45, 0x7c,
-	0x24, 0x1f, 0xc6, 0x9c, 0x3f, 0xbb, 0x48, 0xd5, 0x5e, 0xb1, 0x33, 0xf3, 0xfb, 0xce, 0x7c, 0x33,
-	0x19, 0xa0, 0x4e, 0xd9, 0x14, 0x19, 0x32, 0x37, 0x08, 0x9d, 0x8c, 0x51, 0x41, 0xc9, 0xae, 0xc9,
-	0x34, 0x8f, 0x23, 0xba, 0x5c, 0xd2, 0xd4, 0xd5, 0x3f, 0xba, 0x6a, 0x0f, 0xe1, 0xc8, 0x63, 0x34,
-	0x98, 0x46, 0x01, 0x17, 0x3e, 0xf2, 0x8c, 0xa6, 0x1c, 0xc9, 0x13, 0xa8, 0x70, 0x11, 0x88, 0x15,
-	0x6f, 0x58, 0x67, 0x56, 0xab, 0xd6, 0xae, 0x39, 0x46, 0x33, 0x52, 0x59, 0xdf, 0x54, 0x09, 0x81,
-	0x72, 0x9c, 0xce, 0x68, 0xe3, 0xc1, 0x99, 0xd5, 0xaa, 0xfa, 0xea, 0xdb, 0xde, 0x07, 0x18, 0x21,
-	0xde, 0x0c, 0xf0, 0x3b, 0x72, 0x91, 0x47, 0xc3, 0x64, 0x2a, 0xa3, 0xa7, 0x70, 0x20, 0xa3, 0x51,
-	0x86, 0x51, 0x3c, 0x8b, 0x71, 0x4a, 0x4e, 0xa1, 0x92, 0xae, 0x96, 0x21, 0x32, 0xd5, 0xa8, 0xec,
-	0x9b, 0xc8, 0xfe, 0x69, 0xc1, 0xbe, 0x24, 0x3f, 0x53, 0x1e, 0x8b, 0x98, 0xa6, 0xe4, 0x25, 0x54,
-	0x52, 0xf5, 0xa2, 0x02, 0x1f, 0xb5, 0x8f, 0x1d, 0x33, 0x95, 0xb3, 0x69, 0xd6, 0x2d, 0xf9, 0x06,
-	0x92, 0x38, 0x55, 0x2d, 0x95, 0xb5, 0xbb, 0xb8, 0x76, 0x23, 0x71, 0x0d, 0x91, 0x37, 0x50, 0xe5,
-	0xb9, 0xa7, 0xc6, 0x43, 0xa5, 0x38, 0xdd, 0x52, 0x14, 0x8e, 0xbb, 0x25, 0x7f, 0x83, 0x7a, 0x15,
-	0x28, 0x8f, 0x6f, 0x33, 0xb4, 0x7f, 0x59, 0xb0, 0x27, 0xb1, 0x5e, 0x3a, 0xa3, 0xe4, 0x39, 0xec,
-	0x70, 0x11, 0xb0, 0xdc, 0xe9, 0xc9, 0xd6, 0x43, 0xf9, 0x40, 0xbe, 0x66, 0xc8, 0x33, 0x28, 0x73,
-	0x41, 0x33, 0x63, 0xf3, 0x3f, 0xac, 0x42, 0xc8, 0x5b, 0xd8, 0x0b, 0x71, 0x11, 0xac, 0x63, 0xca,
-	0x94, 0xc7, 0x5a, 0xfb, 0xf1, 0x16, 0x2e, 0x9b, 0xab, 0x0f, 0xcf, 0x50, 0x7e, 0xc1, 0xdb, 0xef,
-	0xf5, 0x3a, 0xf3, 0x0a, 0x39, 0x81, 0x23, 0xaf, 0x3f, 0xfc, 0xf8, 0x69, 0x72, 0x3d, 0x18, 0xf7,
-	0xfa, 0x13, 0xff, 0xb2, 0x73, 0xf1, 0xa5, 0x5e, 0x92, 0xe9, 0xab, 0x4e, 0xaf, 0x3f, 0xe9, 0x5d,
-	0x4d, 0x06, 0xc3, 0xb1, 0x49, 0x5b, 0xf6, 0x37, 0x38, 0xbc, 0xc0, 0x24, 0x5e, 0x23, 0x2b, 0x2e,
-	0xa4, 0x75, 0xff, 0x85, 0xc8, 0xdd, 0x9a, 0x1b, 0x39, 0x87, 0x9d, 0x30, 0xa1, 0xd1, 0x8d, 0x19,
-	0xf1, 0x20, 0x07, 0x3d, 0x99, 0xec, 0x96, 0x7c, 0x5d, 0xcd, 0x57, 0xd9, 0xfe, 0x61, 0xc1, 0x61,
-	0x47, 0xd0, 0x65, 0x1c, 0x15, 0x67, 0x49, 0x3e, 0x40, 0x75, 0x13, 0xd4, 0xf3, 0x07, 0x2e, 0xd3,
-	0x35, 0x26, 0x34, 0xc3, 0x66, 0xb3, 0x58, 0xc3, 0x5f, 0x97, 0x6c, 0x97, 0x5a, 0xd6, 0x2b, 0x8b,
-	0xbc, 0x83, 0x5d, 0x33, 0xc0, 0x3f, 0xe4, 0x8d, 0x42, 0x7e, 0x67, 0x48, 0x2d, 0xf6, 0xae, 0xe1,
-	0x9c, 0xb2, 0xb9, 0xb3, 0xb8, 0xcd, 0x90, 0x25, 0x38, 0x9d, 0x23, 0x73, 0x66, 0x41, 0xc8, 0xe2,
-	0x48, 0xff, 0x83, 0x78, 0x2e, 0xff, 0xfa, 0x62, 0x1e, 0x8b, 0xc5, 0x2a, 0x94, 0x0d, 0xdc, 0x3f,
-	0x68, 0x57, 0xd3, 0xae, 0xa6, 0x5d, 0x43, 0x87, 0x15, 0x15, 0xbf, 0xfe, 0x1d, 0x00, 0x00, 0xff,
-	0xff, 0x4b, 0x88, 0xa4, 0x39, 0xb1, 0x03, 0x00, 0x00,
+// TxInclusionClient is the client API for TxInclusion service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TxInclusionClient interface {
+	// Inclusion returns the block including the transaction of an Envelope whose Payload data is a marshaled InclusionQuery.
+	Inclusion(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*InclusionResponse, error)
+}
+
+type txInclusionClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewTxInclusionClient(cc *grpc.ClientConn) TxInclusionClient {
+	return &txInclusionClient{cc}
+}
+
+func (c *txInclusionClient) Inclusion(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*InclusionResponse, error) {
+	out := new(InclusionResponse)
+	err := c.cc.Invoke(ctx, "/orderer.TxInclusion/Inclusion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TxInclusionServer is the server API for TxInclusion service.
+type TxInclusionServer interface {
+	// Inclusion returns the block including the transaction of an Envelope whose Payload data is a marshaled InclusionQuery.
+	Inclusion(context.Context, *common.Envelope) (*InclusionResponse, error)
+}
+
+func RegisterTxInclusionServer(s *grpc.Server, srv TxInclusionServer) {
+	s.RegisterService(&_TxInclusion_serviceDesc, srv)
+}
+
+func _TxInclusion_Inclusion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxInclusionServer).Inclusion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orderer.TxInclusion/Inclusion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxInclusionServer).Inclusion(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _TxInclusion_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "orderer.TxInclusion",
+	HandlerType: (*TxInclusionServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Inclusion",
+			Handler:    _TxInclusion_Inclusion_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "orderer/ab.proto",
+}
+
+func init() { proto.RegisterFile("orderer/ab.proto", fileDescriptor_ab_eab5c492b580a35e) }
+
+var fileDescriptor_ab_eab5c492b580a35e = []byte{
+	// 611 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x94, 0xdf, 0x4e, 0x13, 0x4f,
+	0x14, 0xc7, 0xbb, 0xd0, 0x16, 0x7a, 0x28, 0x05, 0x86, 0x40, 0xf6, 0xc7, 0xc5, 0x2f, 0xb8, 0x09,
+	0x5a, 0xa3, 0xb6, 0xa6, 0x26, 0x5e, 0x88, 0x89, 0xa1, 0x02, 0x61, 0x95, 0xb4, 0x3a, 0x94, 0x0b,
+	0xbd, 0x69, 0xf6, 0xcf, 0xb4, 0x1d, 0x69, 0x77, 0x36, 0x33, 0x53, 0x2c, 0x4f, 0xe1, 0x53, 0x78,
+	0xe7, 0x23, 0xf9, 0x30, 0x66, 0xfe, 0xec, 0x16, 0x84, 0xf4, 0xca, 0xab, 0xee, 0xf7, 0xcc, 0xe7,
+	0xcc, 0xf9, 0xce, 0x99, 0x33, 0x85, 0x4d, 0xc6, 0x63, 0xc2, 0x09, 0x6f, 0x06, 0x61, 0x23, 0xe5,
+	0x4c, 0x32, 0xb4, 0x62, 0x23, 0x7b, 0xdb, 0x11, 0x9b, 0x4c, 0x58, 0xd2, 0x34, 0x3f, 0x66, 0xd5,
+	0xeb, 0xc2, 0x56, 0x9b, 0xb3, 0x20, 0x8e, 0x02, 0x21, 0x31, 0x11, 0x29, 0x4b, 0x04, 0x41, 0x8f,
+	0xa1, 0x2c, 0x64, 0x20, 0xa7, 0xc2, 0x75, 0xf6, 0x9d, 0x7a, 0xad, 0x55, 0x6b, 0xd8, 0x9c, 0x0b,
+	0x1d, 0xc5, 0x76, 0x15, 0x21, 0x28, 0xd2, 0x64, 0xc0, 0xdc, 0xa5, 0x7d, 0xa7, 0x5e, 0xc1, 0xfa,
+	0xdb, 0xab, 0x02, 0x5c, 0x10, 0x72, 0xd5, 0x21, 0xdf, 0x89, 0x90, 0x99, 0xea, 0x8e, 0x63, 0xa5,
+	0x9e, 0xc0, 0xba, 0x52, 0x17, 0x29, 0x89, 0xe8, 0x80, 0x92, 0x18, 0xed, 0x42, 0x39, 0x99, 0x4e,
+	0x42, 0xc2, 0x75, 0xa1, 0x22, 0xb6, 0xca, 0xfb, 0xe5, 0x40, 0x55, 0x91, 0x9f, 0x98, 0xa0, 0x92,
+	0xb2, 0x04, 0xbd, 0x80, 0x72, 0xa2, 0x77, 0xd4, 0xe0, 0x5a, 0x6b, 0xbb, 0x61, 0x4f, 0xd5, 0x98,
+	0x17, 0x3b, 0x2b, 0x60, 0x0b, 0x29, 0x9c, 0xe9, 0x92, 0xee, 0xd2, 0x03, 0xb8, 0x71, 0xa3, 0x70,
+	0x03, 0xa1, 0xd7, 0x50, 0x11, 0x99, 0x27, 0x77, 0x59, 0x67, 0xec, 0xde, 0xc9, 0xc8, 0x1d, 0x9f,
+	0x15, 0xf0, 0x1c, 0x6d, 0x97, 0xa1, 0xd8, 0xbb, 0x49, 0x89, 0xf7, 0xdb, 0x81, 0x55, 0x85, 0xf9,
+	0xc9, 0x80, 0xa1, 0x67, 0x50, 0x12, 0x32, 0xe0, 0x99, 0xd3, 0x9d, 0x3b, 0x1b, 0x65, 0x07, 0xc2,
+	0x86, 0x41, 0x4f, 0xa1, 0x28, 0x24, 0x4b, 0xdd, 0xa5, 0x45, 0xac, 0x46, 0xd0, 0x1b, 0x58, 0x0d,
+	0xc9, 0x28, 0xb8, 0xa6, 0x8c, 0x6b, 0x8f, 0xb5, 0xd6, 0xff, 0x77, 0x70, 0x55, 0x5c, 0x7f, 0xb4,
+	0x2d, 0x85, 0x73, 0xde, 0x7b, 0x0b, 0xd5, 0xdb, 0x2b, 0x68, 0x07, 0xb6, 0xda, 0xe7, 0xdd, 0xf7,
+	0x1f, 0xfb, 0x97, 0x9d, 0x9e, 0x7f, 0xde, 0xc7, 0x27, 0x47, 0xc7, 0x5f, 0x36, 0x0b, 0x2a, 0x7c,
+	0x7a, 0xe4, 0x9f, 0xf7, 0xfd, 0xd3, 0x7e, 0xa7, 0xdb, 0xb3, 0x61, 0xc7, 0xfb, 0x06, 0x1b, 0xc7,
+	0x64, 0x4c, 0xaf, 0x09, 0xcf, 0x27, 0xa4, 0xbe, 0x78, 0x42, 0x54, 0x6f, 0xed, 0x8c, 0x1c, 0x40,
+	0x29, 0x1c, 0xb3, 0xe8, 0xca, 0x1e, 0x71, 0x3d, 0x03, 0xdb, 0x2a, 0x78, 0x56, 0xc0, 0x66, 0x35,
+	0x6f, 0xe5, 0x01, 0xd4, 0xfc, 0x24, 0x1a, 0x4f, 0x05, 0x65, 0xc9, 0xe7, 0x29, 0xe1, 0x37, 0x68,
+	0x1b, 0x4a, 0x72, 0xd6, 0xa7, 0xb1, 0xae, 0x54, 0xc1, 0x45, 0x39, 0xf3, 0x63, 0xef, 0xa7, 0x03,
+	0x5b, 0x39, 0xf7, 0x2f, 0xe6, 0x16, 0x3d, 0x82, 0xaa, 0x76, 0xd2, 0xb7, 0x03, 0xb9, 0xac, 0x07,
+	0x72, 0x4d, 0xc7, 0x3a, 0x3a, 0x84, 0xfe, 0x83, 0x55, 0xe5, 0x24, 0x89, 0xc9, 0xcc, 0x2d, 0xee,
+	0x3b, 0xf5, 0x75, 0xbc, 0x22, 0x67, 0xbe, 0x92, 0x6a, 0x90, 0x47, 0x84, 0x0e, 0x47, 0xd2, 0x2d,
+	0x99, 0x41, 0x36, 0xaa, 0xf5, 0xc3, 0x81, 0x8d, 0x23, 0xc9, 0x26, 0x34, 0xca, 0x5f, 0x19, 0x7a,
+	0x07, 0x95, 0xb9, 0xd8, 0xcc, 0x2c, 0x9e, 0x24, 0xd7, 0x64, 0xcc, 0x52, 0xb2, 0xb7, 0x97, 0xdf,
+	0xea, 0xbd, 0x87, 0xe9, 0x15, 0xea, 0xce, 0x4b, 0x07, 0x1d, 0xc2, 0x8a, 0xbd, 0x8f, 0x07, 0xd2,
+	0xdd, 0x3c, 0xfd, 0xaf, 0x3b, 0x33, 0xc9, 0xad, 0x0f, 0xb0, 0xd6, 0x9b, 0xe5, 0xad, 0x43, 0x87,
+	0x50, 0x99, 0x8b, 0x45, 0x66, 0xee, 0x75, 0xdb, 0x2b, 0xb4, 0x2f, 0xe1, 0x80, 0xf1, 0x61, 0x63,
+	0x74, 0x93, 0x12, 0x3e, 0x26, 0xf1, 0x90, 0xf0, 0xc6, 0x20, 0x08, 0x39, 0x8d, 0xcc, 0x9f, 0x8b,
+	0xc8, 0x92, 0xbf, 0x3e, 0x1f, 0x52, 0x39, 0x9a, 0x86, 0x6a, 0xfb, 0xe6, 0x2d, 0xba, 0x69, 0xe8,
+	0xa6, 0xa1, 0x9b, 0x96, 0x0e, 0xcb, 0x5a, 0xbf, 0xfa, 0x33, 0x00, 0xd1, 0x62, 0x73, 0x5d, 0xcc,
+	0x04, 0x00, 0x00,
 }
@@ -122,6 +122,8 @@ func (docv *DynamicOrdererConfigValue) VariablyOpaqueFieldProto(name string) (pr
 		return &KafkaBrokers{}, nil
 	case "ChannelRestrictions":
 		return &ChannelRestrictions{}, nil
+	case "IngressQuotas":
+		return &IngressQuotas{}, nil
 	case "Capabilities":
 		return &common.Capabilities{}, nil
 	default:
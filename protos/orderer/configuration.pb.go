@@ -49,7 +49,7 @@ func (x ConsensusType_MigrationState) String() string {
 	return proto.EnumName(ConsensusType_MigrationState_name, int32(x))
 }
 func (ConsensusType_MigrationState) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_acd4b7f3e02dac02, []int{0, 0}
+	return fileDescriptor_configuration_deb66d2793daa1d7, []int{0, 0}
 }
 
 type ConsensusType struct {
@@ -75,7 +75,7 @@ func (m *ConsensusType) Reset()         { *m = ConsensusType{} }
 func (m *ConsensusType) String() string { return proto.CompactTextString(m) }
 func (*ConsensusType) ProtoMessage()    {}
 func (*ConsensusType) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_acd4b7f3e02dac02, []int{0}
+	return fileDescriptor_configuration_deb66d2793daa1d7, []int{0}
 }
 func (m *ConsensusType) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConsensusType.Unmarshal(m, b)
@@ -142,7 +142,7 @@ func (m *BatchSize) Reset()         { *m = BatchSize{} }
 func (m *BatchSize) String() string { return proto.CompactTextString(m) }
 func (*BatchSize) ProtoMessage()    {}
 func (*BatchSize) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_acd4b7f3e02dac02, []int{1}
+	return fileDescriptor_configuration_deb66d2793daa1d7, []int{1}
 }
 func (m *BatchSize) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BatchSize.Unmarshal(m, b)
@@ -196,7 +196,7 @@ func (m *BatchTimeout) Reset()         { *m = BatchTimeout{} }
 func (m *BatchTimeout) String() string { return proto.CompactTextString(m) }
 func (*BatchTimeout) ProtoMessage()    {}
 func (*BatchTimeout) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_acd4b7f3e02dac02, []int{2}
+	return fileDescriptor_configuration_deb66d2793daa1d7, []int{2}
 }
 func (m *BatchTimeout) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BatchTimeout.Unmarshal(m, b)
@@ -238,7 +238,7 @@ func (m *KafkaBrokers) Reset()         { *m = KafkaBrokers{} }
 func (m *KafkaBrokers) String() string { return proto.CompactTextString(m) }
 func (*KafkaBrokers) ProtoMessage()    {}
 func (*KafkaBrokers) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_acd4b7f3e02dac02, []int{3}
+	return fileDescriptor_configuration_deb66d2793daa1d7, []int{3}
 }
 func (m *KafkaBrokers) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KafkaBrokers.Unmarshal(m, b)
@@ -277,7 +277,7 @@ func (m *ChannelRestrictions) Reset()         { *m = ChannelRestrictions{} }
 func (m *ChannelRestrictions) String() string { return proto.CompactTextString(m) }
 func (*ChannelRestrictions) ProtoMessage()    {}
 func (*ChannelRestrictions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_acd4b7f3e02dac02, []int{4}
+	return fileDescriptor_configuration_deb66d2793daa1d7, []int{4}
 }
 func (m *ChannelRestrictions) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChannelRestrictions.Unmarshal(m, b)
@@ -304,48 +304,150 @@ func (m *ChannelRestrictions) GetMaxCount() uint64 {
 	return 0
 }
 
+// IngressQuotas is the message which conveys the rate at which the members of
+// each organization may broadcast messages to a channel. It may only be set on
+// the channels with the V1_4_INGRESS_QUOTAS_EXPERIMENTAL orderer capability.
+type IngressQuotas struct {
+	// Keyed by the MSP ID of the organization. Organizations which are not
+	// listed are not limited.
+	Quotas               map[string]*IngressQuota `protobuf:"bytes,1,rep,name=quotas,proto3" json:"quotas,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *IngressQuotas) Reset()         { *m = IngressQuotas{} }
+func (m *IngressQuotas) String() string { return proto.CompactTextString(m) }
+func (*IngressQuotas) ProtoMessage()    {}
+func (*IngressQuotas) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_deb66d2793daa1d7, []int{5}
+}
+func (m *IngressQuotas) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IngressQuotas.Unmarshal(m, b)
+}
+func (m *IngressQuotas) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IngressQuotas.Marshal(b, m, deterministic)
+}
+func (dst *IngressQuotas) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IngressQuotas.Merge(dst, src)
+}
+func (m *IngressQuotas) XXX_Size() int {
+	return xxx_messageInfo_IngressQuotas.Size(m)
+}
+func (m *IngressQuotas) XXX_DiscardUnknown() {
+	xxx_messageInfo_IngressQuotas.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IngressQuotas proto.InternalMessageInfo
+
+func (m *IngressQuotas) GetQuotas() map[string]*IngressQuota {
+	if m != nil {
+		return m.Quotas
+	}
+	return nil
+}
+
+// IngressQuota limits the broadcast rate of a single organization. A value of
+// 0 indicates no limit.
+type IngressQuota struct {
+	MessagesPerSecond    uint32   `protobuf:"varint,1,opt,name=messages_per_second,json=messagesPerSecond,proto3" json:"messages_per_second,omitempty"`
+	BytesPerSecond       uint64   `protobuf:"varint,2,opt,name=bytes_per_second,json=bytesPerSecond,proto3" json:"bytes_per_second,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IngressQuota) Reset()         { *m = IngressQuota{} }
+func (m *IngressQuota) String() string { return proto.CompactTextString(m) }
+func (*IngressQuota) ProtoMessage()    {}
+func (*IngressQuota) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_deb66d2793daa1d7, []int{6}
+}
+func (m *IngressQuota) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IngressQuota.Unmarshal(m, b)
+}
+func (m *IngressQuota) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IngressQuota.Marshal(b, m, deterministic)
+}
+func (dst *IngressQuota) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IngressQuota.Merge(dst, src)
+}
+func (m *IngressQuota) XXX_Size() int {
+	return xxx_messageInfo_IngressQuota.Size(m)
+}
+func (m *IngressQuota) XXX_DiscardUnknown() {
+	xxx_messageInfo_IngressQuota.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IngressQuota proto.InternalMessageInfo
+
+func (m *IngressQuota) GetMessagesPerSecond() uint32 {
+	if m != nil {
+		return m.MessagesPerSecond
+	}
+	return 0
+}
+
+func (m *IngressQuota) GetBytesPerSecond() uint64 {
+	if m != nil {
+		return m.BytesPerSecond
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*ConsensusType)(nil), "orderer.ConsensusType")
 	proto.RegisterType((*BatchSize)(nil), "orderer.BatchSize")
 	proto.RegisterType((*BatchTimeout)(nil), "orderer.BatchTimeout")
 	proto.RegisterType((*KafkaBrokers)(nil), "orderer.KafkaBrokers")
 	proto.RegisterType((*ChannelRestrictions)(nil), "orderer.ChannelRestrictions")
+	proto.RegisterType((*IngressQuotas)(nil), "orderer.IngressQuotas")
+	proto.RegisterMapType((map[string]*IngressQuota)(nil), "orderer.IngressQuotas.QuotasEntry")
+	proto.RegisterType((*IngressQuota)(nil), "orderer.IngressQuota")
 	proto.RegisterEnum("orderer.ConsensusType_MigrationState", ConsensusType_MigrationState_name, ConsensusType_MigrationState_value)
 }
 
 func init() {
-	proto.RegisterFile("orderer/configuration.proto", fileDescriptor_configuration_acd4b7f3e02dac02)
-}
-
-var fileDescriptor_configuration_acd4b7f3e02dac02 = []byte{
-	// 460 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x92, 0xdf, 0x8a, 0xda, 0x40,
-	0x14, 0xc6, 0x1b, 0x95, 0xee, 0x7a, 0x58, 0x35, 0x8e, 0x2d, 0x84, 0xee, 0x8d, 0x08, 0x0b, 0xd2,
-	0x2e, 0x11, 0xb6, 0x4f, 0x60, 0x82, 0x94, 0xa5, 0x44, 0x61, 0x9c, 0x42, 0xe9, 0x4d, 0x98, 0xc4,
-	0x63, 0x0c, 0x6b, 0x32, 0x61, 0x66, 0x02, 0xb1, 0x7d, 0x8e, 0x3e, 0x4c, 0xdf, 0xae, 0xe4, 0x9f,
-	0x7f, 0xee, 0xce, 0xf7, 0x7d, 0xbf, 0x9c, 0x99, 0x39, 0x39, 0xf0, 0x28, 0xe4, 0x0e, 0x25, 0xca,
-	0x45, 0x28, 0xd2, 0x7d, 0x1c, 0xe5, 0x92, 0xeb, 0x58, 0xa4, 0x76, 0x26, 0x85, 0x16, 0xe4, 0xae,
-	0x09, 0x67, 0xff, 0x3a, 0x30, 0x70, 0x45, 0xaa, 0x30, 0x55, 0xb9, 0x62, 0xa7, 0x0c, 0x09, 0x81,
-	0x9e, 0x3e, 0x65, 0x68, 0x19, 0x53, 0x63, 0xde, 0xa7, 0x55, 0x4d, 0x3e, 0xc1, 0x7d, 0x82, 0x9a,
-	0xef, 0xb8, 0xe6, 0x56, 0x67, 0x6a, 0xcc, 0x1f, 0xe8, 0x59, 0x93, 0x35, 0x8c, 0x92, 0x38, 0xaa,
-	0xbb, 0xfb, 0x4a, 0x73, 0x8d, 0x56, 0x77, 0x6a, 0xcc, 0x87, 0x2f, 0x4f, 0x76, 0x73, 0x88, 0x7d,
-	0x73, 0x80, 0xed, 0xb5, 0xf4, 0xb6, 0x84, 0xe9, 0x30, 0xb9, 0xd1, 0xe4, 0x0b, 0x8c, 0x2f, 0xfd,
-	0x42, 0x91, 0x6a, 0x2c, 0xb4, 0xd5, 0x9b, 0x1a, 0xf3, 0x1e, 0x35, 0xcf, 0x81, 0x5b, 0xfb, 0xb3,
-	0x3f, 0x30, 0xbc, 0x6d, 0x47, 0x08, 0x0c, 0xbd, 0xd7, 0x6f, 0xfe, 0x96, 0x2d, 0xd9, 0xca, 0x5f,
-	0x6f, 0xd6, 0x2b, 0xf3, 0x1d, 0x99, 0xc0, 0xe8, 0xe2, 0x6d, 0xd9, 0x92, 0x32, 0xd3, 0x20, 0x1f,
-	0xc0, 0xbc, 0x98, 0xee, 0xc6, 0xf3, 0x5e, 0x99, 0xd9, 0xb9, 0x45, 0x97, 0xce, 0x86, 0x32, 0xb3,
-	0x4b, 0x3e, 0xc2, 0xf8, 0x1a, 0x5d, 0xb3, 0xd5, 0x4f, 0x66, 0xf6, 0x66, 0x7f, 0x0d, 0xe8, 0x3b,
-	0x5c, 0x87, 0x87, 0x6d, 0xfc, 0x1b, 0xc9, 0x67, 0x18, 0x27, 0xbc, 0xf0, 0x13, 0x54, 0x8a, 0x47,
-	0xe8, 0x87, 0x22, 0x4f, 0x75, 0x35, 0xc4, 0x01, 0x1d, 0x25, 0xbc, 0xf0, 0x6a, 0xdf, 0x2d, 0x6d,
-	0xf2, 0x0c, 0x84, 0x07, 0x4a, 0x1c, 0x73, 0x8d, 0x7e, 0xf9, 0x51, 0x70, 0xd2, 0xa8, 0xaa, 0xc9,
-	0x0e, 0xa8, 0xd9, 0x26, 0x1e, 0x2f, 0x9c, 0xd2, 0x27, 0x36, 0x4c, 0x32, 0x89, 0x7b, 0x94, 0x12,
-	0x77, 0x57, 0x78, 0xb7, 0xc2, 0xc7, 0xe7, 0xa8, 0xe5, 0x67, 0x73, 0x78, 0xa8, 0xae, 0xc5, 0xe2,
-	0x04, 0x45, 0xae, 0x89, 0x05, 0x77, 0xba, 0x2e, 0x9b, 0x9f, 0xda, 0xca, 0x92, 0xfc, 0xce, 0xf7,
-	0x6f, 0xdc, 0x91, 0xe2, 0x0d, 0xa5, 0x2a, 0xc9, 0xa0, 0x2e, 0x2d, 0x63, 0xda, 0x2d, 0xc9, 0x46,
-	0xce, 0x5e, 0x60, 0xe2, 0x1e, 0x78, 0x9a, 0xe2, 0x91, 0xa2, 0xd2, 0x32, 0x0e, 0xcb, 0x89, 0x2b,
-	0xf2, 0x08, 0xfd, 0xf2, 0x42, 0x97, 0xc7, 0xf6, 0xe8, 0x7d, 0xc2, 0x8b, 0xea, 0x95, 0xce, 0x0f,
-	0x78, 0x12, 0x32, 0xb2, 0x0f, 0xa7, 0x0c, 0xe5, 0x11, 0x77, 0x11, 0x4a, 0x7b, 0xcf, 0x03, 0x19,
-	0x87, 0xf5, 0x12, 0xaa, 0x76, 0x3f, 0x7e, 0x3d, 0x47, 0xb1, 0x3e, 0xe4, 0x81, 0x1d, 0x8a, 0x64,
-	0x71, 0x45, 0x2f, 0x6a, 0x7a, 0x51, 0xd3, 0x8b, 0x86, 0x0e, 0xde, 0x57, 0xfa, 0xeb, 0xff, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0x9f, 0x9f, 0xe4, 0x13, 0xe1, 0x02, 0x00, 0x00,
+	proto.RegisterFile("orderer/configuration.proto", fileDescriptor_configuration_deb66d2793daa1d7)
+}
+
+var fileDescriptor_configuration_deb66d2793daa1d7 = []byte{
+	// 577 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x93, 0x4f, 0x6f, 0xda, 0x4c,
+	0x10, 0xc6, 0x5f, 0x03, 0x6f, 0xfe, 0x4c, 0x02, 0x31, 0x9b, 0x46, 0x42, 0xc9, 0x05, 0x59, 0x8a,
+	0x84, 0x9a, 0xc8, 0x48, 0xf4, 0x52, 0xe5, 0x16, 0x10, 0xaa, 0xa2, 0x0a, 0x92, 0x2e, 0xae, 0x54,
+	0xf5, 0x62, 0xad, 0xcd, 0x60, 0xac, 0x60, 0xaf, 0xbb, 0xbb, 0xae, 0x70, 0xfb, 0x39, 0x7a, 0xec,
+	0x07, 0xe9, 0xb7, 0xab, 0xbc, 0xb6, 0xc1, 0x48, 0x3d, 0x31, 0xf3, 0x3c, 0xbf, 0x9d, 0xdd, 0x99,
+	0xc1, 0x70, 0xc3, 0xc5, 0x12, 0x05, 0x8a, 0xa1, 0xcf, 0xe3, 0x55, 0x18, 0xa4, 0x82, 0xa9, 0x90,
+	0xc7, 0x76, 0x22, 0xb8, 0xe2, 0xe4, 0xb8, 0x34, 0xad, 0x3f, 0x0d, 0x68, 0x4f, 0x78, 0x2c, 0x31,
+	0x96, 0xa9, 0x74, 0xb2, 0x04, 0x09, 0x81, 0x96, 0xca, 0x12, 0xec, 0x19, 0x7d, 0x63, 0x70, 0x4a,
+	0x75, 0x4c, 0xae, 0xe1, 0x24, 0x42, 0xc5, 0x96, 0x4c, 0xb1, 0x5e, 0xa3, 0x6f, 0x0c, 0xce, 0xe9,
+	0x2e, 0x27, 0x73, 0xb8, 0x88, 0xc2, 0xa0, 0xa8, 0xee, 0x4a, 0xc5, 0x14, 0xf6, 0x9a, 0x7d, 0x63,
+	0xd0, 0x19, 0xdd, 0xda, 0xe5, 0x25, 0xf6, 0xc1, 0x05, 0xf6, 0xac, 0xa2, 0x17, 0x39, 0x4c, 0x3b,
+	0xd1, 0x41, 0x4e, 0xee, 0xa0, 0xbb, 0xaf, 0xe7, 0xf3, 0x58, 0xe1, 0x56, 0xf5, 0x5a, 0x7d, 0x63,
+	0xd0, 0xa2, 0xe6, 0xce, 0x98, 0x14, 0xba, 0xf5, 0x13, 0x3a, 0x87, 0xe5, 0x08, 0x81, 0xce, 0xec,
+	0xe9, 0x83, 0xbb, 0x70, 0x1e, 0x9d, 0xa9, 0x3b, 0x7f, 0x9e, 0x4f, 0xcd, 0xff, 0xc8, 0x25, 0x5c,
+	0xec, 0xb5, 0x85, 0xf3, 0x48, 0x1d, 0xd3, 0x20, 0x6f, 0xc0, 0xdc, 0x8b, 0x93, 0xe7, 0xd9, 0xec,
+	0xc9, 0x31, 0x1b, 0x87, 0xe8, 0xe3, 0xf8, 0x99, 0x3a, 0x66, 0x93, 0x5c, 0x41, 0xb7, 0x8e, 0xce,
+	0x9d, 0xe9, 0x17, 0xc7, 0x6c, 0x59, 0xbf, 0x0c, 0x38, 0x1d, 0x33, 0xe5, 0xaf, 0x17, 0xe1, 0x0f,
+	0x24, 0x6f, 0xa1, 0x1b, 0xb1, 0xad, 0x1b, 0xa1, 0x94, 0x2c, 0x40, 0xd7, 0xe7, 0x69, 0xac, 0xf4,
+	0x10, 0xdb, 0xf4, 0x22, 0x62, 0xdb, 0x59, 0xa1, 0x4f, 0x72, 0x99, 0xdc, 0x03, 0x61, 0x9e, 0xe4,
+	0x9b, 0x54, 0xa1, 0x9b, 0x1f, 0xf2, 0x32, 0x85, 0x52, 0x4f, 0xb6, 0x4d, 0xcd, 0xca, 0x99, 0xb1,
+	0xed, 0x38, 0xd7, 0x89, 0x0d, 0x97, 0x89, 0xc0, 0x15, 0x0a, 0x81, 0xcb, 0x1a, 0xde, 0xd4, 0x78,
+	0x77, 0x67, 0x55, 0xbc, 0x35, 0x80, 0x73, 0xfd, 0x2c, 0x27, 0x8c, 0x90, 0xa7, 0x8a, 0xf4, 0xe0,
+	0x58, 0x15, 0x61, 0xb9, 0xd4, 0x2a, 0xcd, 0xc9, 0x8f, 0x6c, 0xf5, 0xca, 0xc6, 0x82, 0xbf, 0xa2,
+	0x90, 0x39, 0xe9, 0x15, 0x61, 0xcf, 0xe8, 0x37, 0x73, 0xb2, 0x4c, 0xad, 0x11, 0x5c, 0x4e, 0xd6,
+	0x2c, 0x8e, 0x71, 0x43, 0x51, 0x2a, 0x11, 0xfa, 0xf9, 0xc4, 0x25, 0xb9, 0x81, 0xd3, 0xfc, 0x41,
+	0xfb, 0x66, 0x5b, 0xf4, 0x24, 0x62, 0x5b, 0xdd, 0xa5, 0xf5, 0xdb, 0x80, 0xf6, 0x53, 0x1c, 0x08,
+	0x94, 0xf2, 0x53, 0xca, 0x15, 0x93, 0xe4, 0x01, 0x8e, 0xbe, 0xe9, 0x48, 0x97, 0x3f, 0x1b, 0x59,
+	0xbb, 0xbf, 0xc8, 0x01, 0x67, 0x17, 0x3f, 0xd3, 0x58, 0x89, 0x8c, 0x96, 0x27, 0xae, 0x5f, 0xe0,
+	0xac, 0x26, 0x13, 0x13, 0x9a, 0xaf, 0x98, 0x95, 0x0d, 0xe5, 0x21, 0xb9, 0x83, 0xff, 0xbf, 0xb3,
+	0x4d, 0x8a, 0x7a, 0x8e, 0x67, 0xa3, 0xab, 0x7f, 0xd6, 0xa6, 0x05, 0xf3, 0xd0, 0x78, 0x6f, 0x58,
+	0x6b, 0x38, 0xaf, 0x5b, 0xf9, 0x9c, 0xcb, 0xed, 0x49, 0x37, 0x41, 0xe1, 0x4a, 0xf4, 0x79, 0xbc,
+	0x2c, 0x77, 0xd8, 0xad, 0xac, 0x17, 0x14, 0x0b, 0x6d, 0x90, 0x01, 0x98, 0x7a, 0x13, 0x75, 0xb8,
+	0xa1, 0x67, 0xd0, 0xd1, 0xfa, 0x8e, 0x1c, 0x7f, 0x86, 0x5b, 0x2e, 0x02, 0x7b, 0x9d, 0x25, 0x28,
+	0x36, 0xb8, 0x0c, 0x50, 0xd8, 0x2b, 0xe6, 0x89, 0xd0, 0x2f, 0x3e, 0x47, 0x59, 0x3d, 0xf5, 0xeb,
+	0x7d, 0x10, 0xaa, 0x75, 0xea, 0xd9, 0x3e, 0x8f, 0x86, 0x35, 0x7a, 0x58, 0xd0, 0xc3, 0x82, 0x1e,
+	0x96, 0xb4, 0x77, 0xa4, 0xf3, 0x77, 0x7f, 0x07, 0x00, 0x50, 0xac, 0xf0, 0x32, 0xeb, 0x03, 0x00,
+	0x00,
 }
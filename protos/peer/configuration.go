@@ -102,6 +102,14 @@ func (ccv *DynamicApplicationConfigValue) VariablyOpaqueFieldProto(name string)
 		return &common.Capabilities{}, nil
 	case "ACLs":
 		return &ACLs{}, nil
+	case "TokenSupply":
+		return &TokenSupply{}, nil
+	case "TokenTypeNamespaces":
+		return &TokenTypeNamespaces{}, nil
+	case "TokenMetadataSchemas":
+		return &TokenMetadataSchemas{}, nil
+	case "TokenDriver":
+		return &TokenDriver{}, nil
 	default:
 		return nil, fmt.Errorf("Unknown Application ConfigValue name: %s", ccv.name)
 	}
@@ -121,6 +129,8 @@ func (daocv *DynamicApplicationOrgConfigValue) VariablyOpaqueFieldProto(name str
 		return &msp.MSPConfig{}, nil
 	case "AnchorPeers":
 		return &AnchorPeers{}, nil
+	case "TokenEncryptionKey":
+		return &TokenEncryptionKey{}, nil
 	default:
 		return nil, fmt.Errorf("Unknown Application Org ConfigValue name: %s", daocv.name)
 	}
@@ -30,7 +30,7 @@ func (m *AnchorPeers) Reset()         { *m = AnchorPeers{} }
 func (m *AnchorPeers) String() string { return proto.CompactTextString(m) }
 func (*AnchorPeers) ProtoMessage()    {}
 func (*AnchorPeers) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_d9ec63ae33c182ef, []int{0}
+	return fileDescriptor_configuration_ee757c8bae8030b7, []int{0}
 }
 func (m *AnchorPeers) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnchorPeers.Unmarshal(m, b)
@@ -73,7 +73,7 @@ func (m *AnchorPeer) Reset()         { *m = AnchorPeer{} }
 func (m *AnchorPeer) String() string { return proto.CompactTextString(m) }
 func (*AnchorPeer) ProtoMessage()    {}
 func (*AnchorPeer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_d9ec63ae33c182ef, []int{1}
+	return fileDescriptor_configuration_ee757c8bae8030b7, []int{1}
 }
 func (m *AnchorPeer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnchorPeer.Unmarshal(m, b)
@@ -120,7 +120,7 @@ func (m *APIResource) Reset()         { *m = APIResource{} }
 func (m *APIResource) String() string { return proto.CompactTextString(m) }
 func (*APIResource) ProtoMessage()    {}
 func (*APIResource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_d9ec63ae33c182ef, []int{2}
+	return fileDescriptor_configuration_ee757c8bae8030b7, []int{2}
 }
 func (m *APIResource) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_APIResource.Unmarshal(m, b)
@@ -160,7 +160,7 @@ func (m *ACLs) Reset()         { *m = ACLs{} }
 func (m *ACLs) String() string { return proto.CompactTextString(m) }
 func (*ACLs) ProtoMessage()    {}
 func (*ACLs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_d9ec63ae33c182ef, []int{3}
+	return fileDescriptor_configuration_ee757c8bae8030b7, []int{3}
 }
 func (m *ACLs) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ACLs.Unmarshal(m, b)
@@ -187,37 +187,503 @@ func (m *ACLs) GetAcls() map[string]*APIResource {
 	return nil
 }
 
+// TokenEncryptionKey is the public key to which the token transactions of a
+// channel are encrypted for the committing peers of an application org, so
+// that the orderers order the ciphertext of the transactions only. It is a
+// DER encoded PKIX ECDSA P-256 public key.
+type TokenEncryptionKey struct {
+	PublicKey            []byte   `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenEncryptionKey) Reset()         { *m = TokenEncryptionKey{} }
+func (m *TokenEncryptionKey) String() string { return proto.CompactTextString(m) }
+func (*TokenEncryptionKey) ProtoMessage()    {}
+func (*TokenEncryptionKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_ee757c8bae8030b7, []int{4}
+}
+func (m *TokenEncryptionKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenEncryptionKey.Unmarshal(m, b)
+}
+func (m *TokenEncryptionKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenEncryptionKey.Marshal(b, m, deterministic)
+}
+func (dst *TokenEncryptionKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenEncryptionKey.Merge(dst, src)
+}
+func (m *TokenEncryptionKey) XXX_Size() int {
+	return xxx_messageInfo_TokenEncryptionKey.Size(m)
+}
+func (m *TokenEncryptionKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenEncryptionKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenEncryptionKey proto.InternalMessageInfo
+
+func (m *TokenEncryptionKey) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+// TokenSupply limits, for each token type, the issuance of tokens on a channel
+type TokenSupply struct {
+	Limits               map[string]*TokenSupplyLimit `protobuf:"bytes,1,rep,name=limits,proto3" json:"limits,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *TokenSupply) Reset()         { *m = TokenSupply{} }
+func (m *TokenSupply) String() string { return proto.CompactTextString(m) }
+func (*TokenSupply) ProtoMessage()    {}
+func (*TokenSupply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_ee757c8bae8030b7, []int{5}
+}
+func (m *TokenSupply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenSupply.Unmarshal(m, b)
+}
+func (m *TokenSupply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenSupply.Marshal(b, m, deterministic)
+}
+func (dst *TokenSupply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenSupply.Merge(dst, src)
+}
+func (m *TokenSupply) XXX_Size() int {
+	return xxx_messageInfo_TokenSupply.Size(m)
+}
+func (m *TokenSupply) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenSupply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenSupply proto.InternalMessageInfo
+
+func (m *TokenSupply) GetLimits() map[string]*TokenSupplyLimit {
+	if m != nil {
+		return m.Limits
+	}
+	return nil
+}
+
+// TokenSupplyLimit limits the issuance of tokens of a given type
+type TokenSupplyLimit struct {
+	// The maximum quantity of tokens in circulation, i.e. issued and not redeemed; 0 means no limit
+	MaxTotalSupply uint64 `protobuf:"varint,1,opt,name=max_total_supply,json=maxTotalSupply,proto3" json:"max_total_supply,omitempty"`
+	// The maximum quantity of tokens issued per period; 0 means no limit
+	MaxPerPeriod uint64 `protobuf:"varint,2,opt,name=max_per_period,json=maxPerPeriod,proto3" json:"max_per_period,omitempty"`
+	// The length of a period in blocks; required when max_per_period is set.
+	// The periods are counted from the genesis block of the channel
+	PeriodBlocks         uint64   `protobuf:"varint,4,opt,name=period_blocks,json=periodBlocks,proto3" json:"period_blocks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenSupplyLimit) Reset()         { *m = TokenSupplyLimit{} }
+func (m *TokenSupplyLimit) String() string { return proto.CompactTextString(m) }
+func (*TokenSupplyLimit) ProtoMessage()    {}
+func (*TokenSupplyLimit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_ee757c8bae8030b7, []int{6}
+}
+func (m *TokenSupplyLimit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenSupplyLimit.Unmarshal(m, b)
+}
+func (m *TokenSupplyLimit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenSupplyLimit.Marshal(b, m, deterministic)
+}
+func (dst *TokenSupplyLimit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenSupplyLimit.Merge(dst, src)
+}
+func (m *TokenSupplyLimit) XXX_Size() int {
+	return xxx_messageInfo_TokenSupplyLimit.Size(m)
+}
+func (m *TokenSupplyLimit) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenSupplyLimit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenSupplyLimit proto.InternalMessageInfo
+
+func (m *TokenSupplyLimit) GetMaxTotalSupply() uint64 {
+	if m != nil {
+		return m.MaxTotalSupply
+	}
+	return 0
+}
+
+func (m *TokenSupplyLimit) GetMaxPerPeriod() uint64 {
+	if m != nil {
+		return m.MaxPerPeriod
+	}
+	return 0
+}
+
+func (m *TokenSupplyLimit) GetPeriodBlocks() uint64 {
+	if m != nil {
+		return m.PeriodBlocks
+	}
+	return 0
+}
+
+// TokenTypeNamespaces grants orgs authority over the hierarchical token types of
+// a channel, e.g. "bank1/USD", by the namespace prefixing their names. The
+// issuers of a namespace may issue any type in it, e.g. "bank1/EUR", without
+// further config updates.
+type TokenTypeNamespaces struct {
+	// The namespaces, by prefix, e.g. "bank1" or "bank1/retail"; the longest
+	// prefix of a token type decides its namespace
+	Namespaces           map[string]*TokenTypeNamespace `protobuf:"bytes,1,rep,name=namespaces,proto3" json:"namespaces,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *TokenTypeNamespaces) Reset()         { *m = TokenTypeNamespaces{} }
+func (m *TokenTypeNamespaces) String() string { return proto.CompactTextString(m) }
+func (*TokenTypeNamespaces) ProtoMessage()    {}
+func (*TokenTypeNamespaces) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_ee757c8bae8030b7, []int{7}
+}
+func (m *TokenTypeNamespaces) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenTypeNamespaces.Unmarshal(m, b)
+}
+func (m *TokenTypeNamespaces) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenTypeNamespaces.Marshal(b, m, deterministic)
+}
+func (dst *TokenTypeNamespaces) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenTypeNamespaces.Merge(dst, src)
+}
+func (m *TokenTypeNamespaces) XXX_Size() int {
+	return xxx_messageInfo_TokenTypeNamespaces.Size(m)
+}
+func (m *TokenTypeNamespaces) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenTypeNamespaces.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenTypeNamespaces proto.InternalMessageInfo
+
+func (m *TokenTypeNamespaces) GetNamespaces() map[string]*TokenTypeNamespace {
+	if m != nil {
+		return m.Namespaces
+	}
+	return nil
+}
+
+// TokenTypeNamespace is the issuance policy of the token types in a namespace
+type TokenTypeNamespace struct {
+	// The MSP IDs of the orgs whose members may issue the types of the namespace
+	Issuers []string `protobuf:"bytes,1,rep,name=issuers,proto3" json:"issuers,omitempty"`
+	// Whether only the admins of the issuer orgs may issue the types of the namespace
+	AdminsOnly           bool     `protobuf:"varint,2,opt,name=admins_only,json=adminsOnly,proto3" json:"admins_only,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenTypeNamespace) Reset()         { *m = TokenTypeNamespace{} }
+func (m *TokenTypeNamespace) String() string { return proto.CompactTextString(m) }
+func (*TokenTypeNamespace) ProtoMessage()    {}
+func (*TokenTypeNamespace) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_ee757c8bae8030b7, []int{8}
+}
+func (m *TokenTypeNamespace) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenTypeNamespace.Unmarshal(m, b)
+}
+func (m *TokenTypeNamespace) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenTypeNamespace.Marshal(b, m, deterministic)
+}
+func (dst *TokenTypeNamespace) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenTypeNamespace.Merge(dst, src)
+}
+func (m *TokenTypeNamespace) XXX_Size() int {
+	return xxx_messageInfo_TokenTypeNamespace.Size(m)
+}
+func (m *TokenTypeNamespace) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenTypeNamespace.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenTypeNamespace proto.InternalMessageInfo
+
+func (m *TokenTypeNamespace) GetIssuers() []string {
+	if m != nil {
+		return m.Issuers
+	}
+	return nil
+}
+
+func (m *TokenTypeNamespace) GetAdminsOnly() bool {
+	if m != nil {
+		return m.AdminsOnly
+	}
+	return false
+}
+
+// TokenMetadataSchemas is the registry of the schemas of the metadata attached
+// to the outputs of token types
+type TokenMetadataSchemas struct {
+	// The schemas, by token type; the outputs of the other types carry no metadata
+	Schemas              map[string]*TokenMetadataSchema `protobuf:"bytes,1,rep,name=schemas,proto3" json:"schemas,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *TokenMetadataSchemas) Reset()         { *m = TokenMetadataSchemas{} }
+func (m *TokenMetadataSchemas) String() string { return proto.CompactTextString(m) }
+func (*TokenMetadataSchemas) ProtoMessage()    {}
+func (*TokenMetadataSchemas) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_ee757c8bae8030b7, []int{9}
+}
+func (m *TokenMetadataSchemas) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenMetadataSchemas.Unmarshal(m, b)
+}
+func (m *TokenMetadataSchemas) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenMetadataSchemas.Marshal(b, m, deterministic)
+}
+func (dst *TokenMetadataSchemas) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenMetadataSchemas.Merge(dst, src)
+}
+func (m *TokenMetadataSchemas) XXX_Size() int {
+	return xxx_messageInfo_TokenMetadataSchemas.Size(m)
+}
+func (m *TokenMetadataSchemas) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenMetadataSchemas.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenMetadataSchemas proto.InternalMessageInfo
+
+func (m *TokenMetadataSchemas) GetSchemas() map[string]*TokenMetadataSchema {
+	if m != nil {
+		return m.Schemas
+	}
+	return nil
+}
+
+// TokenMetadataSchema is the schema of the metadata of the outputs of a token
+// type, a JSON object of fields
+type TokenMetadataSchema struct {
+	// The fields of the metadata, by name
+	Fields map[string]*TokenMetadataField `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Whether the outputs of transfers and redemptions may re-specify the
+	// metadata of their inputs; by default they preserve it
+	Respecifiable bool `protobuf:"varint,2,opt,name=respecifiable,proto3" json:"respecifiable,omitempty"`
+	// The maximum size in bytes of the metadata; 0 means 1024
+	MaxSize              uint32   `protobuf:"varint,3,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenMetadataSchema) Reset()         { *m = TokenMetadataSchema{} }
+func (m *TokenMetadataSchema) String() string { return proto.CompactTextString(m) }
+func (*TokenMetadataSchema) ProtoMessage()    {}
+func (*TokenMetadataSchema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_ee757c8bae8030b7, []int{10}
+}
+func (m *TokenMetadataSchema) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenMetadataSchema.Unmarshal(m, b)
+}
+func (m *TokenMetadataSchema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenMetadataSchema.Marshal(b, m, deterministic)
+}
+func (dst *TokenMetadataSchema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenMetadataSchema.Merge(dst, src)
+}
+func (m *TokenMetadataSchema) XXX_Size() int {
+	return xxx_messageInfo_TokenMetadataSchema.Size(m)
+}
+func (m *TokenMetadataSchema) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenMetadataSchema.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenMetadataSchema proto.InternalMessageInfo
+
+func (m *TokenMetadataSchema) GetFields() map[string]*TokenMetadataField {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+func (m *TokenMetadataSchema) GetRespecifiable() bool {
+	if m != nil {
+		return m.Respecifiable
+	}
+	return false
+}
+
+func (m *TokenMetadataSchema) GetMaxSize() uint32 {
+	if m != nil {
+		return m.MaxSize
+	}
+	return 0
+}
+
+// TokenMetadataField is a field of the metadata of the outputs of a token type
+type TokenMetadataField struct {
+	// The type of the values of the field: string, number, boolean or date,
+	// a string formatted as YYYY-MM-DD
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// Whether the field is required
+	Required             bool     `protobuf:"varint,2,opt,name=required,proto3" json:"required,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenMetadataField) Reset()         { *m = TokenMetadataField{} }
+func (m *TokenMetadataField) String() string { return proto.CompactTextString(m) }
+func (*TokenMetadataField) ProtoMessage()    {}
+func (*TokenMetadataField) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_ee757c8bae8030b7, []int{11}
+}
+func (m *TokenMetadataField) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenMetadataField.Unmarshal(m, b)
+}
+func (m *TokenMetadataField) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenMetadataField.Marshal(b, m, deterministic)
+}
+func (dst *TokenMetadataField) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenMetadataField.Merge(dst, src)
+}
+func (m *TokenMetadataField) XXX_Size() int {
+	return xxx_messageInfo_TokenMetadataField.Size(m)
+}
+func (m *TokenMetadataField) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenMetadataField.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenMetadataField proto.InternalMessageInfo
+
+func (m *TokenMetadataField) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *TokenMetadataField) GetRequired() bool {
+	if m != nil {
+		return m.Required
+	}
+	return false
+}
+
+// TokenDriver selects the implementation of the token management system of
+// a channel
+type TokenDriver struct {
+	// The name of the driver; the peers must have the driver compiled in or
+	// loaded as a plugin to commit the token transactions of the channel
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenDriver) Reset()         { *m = TokenDriver{} }
+func (m *TokenDriver) String() string { return proto.CompactTextString(m) }
+func (*TokenDriver) ProtoMessage()    {}
+func (*TokenDriver) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_ee757c8bae8030b7, []int{12}
+}
+func (m *TokenDriver) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenDriver.Unmarshal(m, b)
+}
+func (m *TokenDriver) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenDriver.Marshal(b, m, deterministic)
+}
+func (dst *TokenDriver) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenDriver.Merge(dst, src)
+}
+func (m *TokenDriver) XXX_Size() int {
+	return xxx_messageInfo_TokenDriver.Size(m)
+}
+func (m *TokenDriver) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenDriver.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenDriver proto.InternalMessageInfo
+
+func (m *TokenDriver) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*AnchorPeers)(nil), "protos.AnchorPeers")
 	proto.RegisterType((*AnchorPeer)(nil), "protos.AnchorPeer")
 	proto.RegisterType((*APIResource)(nil), "protos.APIResource")
 	proto.RegisterType((*ACLs)(nil), "protos.ACLs")
 	proto.RegisterMapType((map[string]*APIResource)(nil), "protos.ACLs.AclsEntry")
+	proto.RegisterType((*TokenEncryptionKey)(nil), "protos.TokenEncryptionKey")
+	proto.RegisterType((*TokenSupply)(nil), "protos.TokenSupply")
+	proto.RegisterMapType((map[string]*TokenSupplyLimit)(nil), "protos.TokenSupply.LimitsEntry")
+	proto.RegisterType((*TokenSupplyLimit)(nil), "protos.TokenSupplyLimit")
+	proto.RegisterType((*TokenTypeNamespaces)(nil), "protos.TokenTypeNamespaces")
+	proto.RegisterMapType((map[string]*TokenTypeNamespace)(nil), "protos.TokenTypeNamespaces.NamespacesEntry")
+	proto.RegisterType((*TokenTypeNamespace)(nil), "protos.TokenTypeNamespace")
+	proto.RegisterType((*TokenMetadataSchemas)(nil), "protos.TokenMetadataSchemas")
+	proto.RegisterMapType((map[string]*TokenMetadataSchema)(nil), "protos.TokenMetadataSchemas.SchemasEntry")
+	proto.RegisterType((*TokenMetadataSchema)(nil), "protos.TokenMetadataSchema")
+	proto.RegisterMapType((map[string]*TokenMetadataField)(nil), "protos.TokenMetadataSchema.FieldsEntry")
+	proto.RegisterType((*TokenMetadataField)(nil), "protos.TokenMetadataField")
+	proto.RegisterType((*TokenDriver)(nil), "protos.TokenDriver")
 }
 
 func init() {
-	proto.RegisterFile("peer/configuration.proto", fileDescriptor_configuration_d9ec63ae33c182ef)
-}
-
-var fileDescriptor_configuration_d9ec63ae33c182ef = []byte{
-	// 295 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x91, 0xdf, 0x4b, 0xfb, 0x30,
-	0x14, 0xc5, 0xe9, 0x7e, 0x7c, 0x61, 0xb7, 0xdf, 0x07, 0x89, 0x20, 0x45, 0x10, 0x46, 0x9f, 0x36,
-	0x91, 0x14, 0xa6, 0x82, 0xf8, 0x56, 0xa7, 0x0f, 0xc2, 0xc0, 0x91, 0x47, 0x5f, 0x46, 0x16, 0x6f,
-	0x7f, 0x60, 0x6d, 0xca, 0x4d, 0x2a, 0xf4, 0xcd, 0x3f, 0x5d, 0x9a, 0x6c, 0xab, 0x4f, 0x39, 0x39,
-	0xf9, 0x9c, 0xcb, 0x21, 0x17, 0xa2, 0x06, 0x91, 0x12, 0xa5, 0xeb, 0xac, 0xcc, 0x5b, 0x92, 0xb6,
-	0xd4, 0x35, 0x6f, 0x48, 0x5b, 0xcd, 0xfe, 0xb9, 0xc3, 0xc4, 0xcf, 0x10, 0xa6, 0xb5, 0x2a, 0x34,
-	0x6d, 0x11, 0xc9, 0xb0, 0x7b, 0xf8, 0x2f, 0xdd, 0x75, 0xd7, 0x27, 0x4d, 0x14, 0xcc, 0xc7, 0x8b,
-	0x70, 0xc5, 0x7c, 0xc8, 0xf0, 0x01, 0x15, 0xa1, 0x1c, 0x62, 0xf1, 0x1d, 0xc0, 0xf0, 0xc4, 0x18,
-	0x4c, 0x0a, 0x6d, 0x6c, 0x14, 0xcc, 0x83, 0xc5, 0x4c, 0x38, 0xdd, 0x7b, 0x8d, 0x26, 0x1b, 0x8d,
-	0xe6, 0xc1, 0x62, 0x2a, 0x9c, 0x8e, 0x6f, 0x20, 0x4c, 0xb7, 0xaf, 0x02, 0x8d, 0x6e, 0x49, 0x21,
-	0xbb, 0x02, 0x68, 0x74, 0x55, 0xaa, 0x6e, 0x47, 0x98, 0x1d, 0xc2, 0x33, 0xef, 0x08, 0xcc, 0xe2,
-	0x9f, 0x00, 0x26, 0xe9, 0x7a, 0x63, 0xd8, 0x35, 0x4c, 0xa4, 0xaa, 0x8e, 0xdd, 0x2e, 0x4e, 0xdd,
-	0xd6, 0x1b, 0xc3, 0x53, 0x55, 0x99, 0x97, 0xda, 0x52, 0x27, 0x1c, 0x73, 0xb9, 0x81, 0xd9, 0xc9,
-	0x62, 0x67, 0x30, 0xfe, 0xc4, 0xee, 0x30, 0xb9, 0x97, 0x6c, 0x09, 0xd3, 0x6f, 0x59, 0xb5, 0xe8,
-	0x6a, 0x85, 0xab, 0xf3, 0xd3, 0xac, 0xa1, 0x96, 0xf0, 0xc4, 0xe3, 0xe8, 0x21, 0x78, 0x7a, 0x83,
-	0x58, 0x53, 0xce, 0x8b, 0xae, 0x41, 0xaa, 0xf0, 0x23, 0x47, 0xe2, 0x99, 0xdc, 0x53, 0xa9, 0x8e,
-	0xb9, 0xfe, 0xd3, 0xde, 0x97, 0x79, 0x69, 0x8b, 0x76, 0xcf, 0x95, 0xfe, 0x4a, 0xfe, 0xa0, 0x89,
-	0x47, 0x13, 0x8f, 0x26, 0x3d, 0xba, 0xf7, 0x5b, 0xb8, 0xfd, 0x0d, 0x00, 0x00, 0xff, 0xff, 0xae,
-	0x0a, 0x1d, 0x41, 0xa8, 0x01, 0x00, 0x00,
+	proto.RegisterFile("peer/configuration.proto", fileDescriptor_configuration_ee757c8bae8030b7)
+}
+
+var fileDescriptor_configuration_ee757c8bae8030b7 = []byte{
+	// 733 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0xdd, 0x6e, 0xeb, 0x44,
+	0x10, 0x96, 0x4f, 0x7c, 0xd2, 0x64, 0x9c, 0x42, 0xb4, 0x07, 0x21, 0x13, 0x84, 0x4e, 0x30, 0x47,
+	0x22, 0x05, 0xe4, 0xc0, 0x29, 0x08, 0xc4, 0x0d, 0x4a, 0x7f, 0x90, 0xa0, 0x81, 0x46, 0x4e, 0x11,
+	0x82, 0x1b, 0x6b, 0xe3, 0x4c, 0x92, 0x55, 0x6d, 0xaf, 0xd9, 0xb5, 0xab, 0xb8, 0x57, 0xbc, 0x04,
+	0x57, 0xbc, 0x06, 0xb7, 0xbc, 0x12, 0xcf, 0x80, 0xbc, 0x6b, 0x27, 0x76, 0x1b, 0x2a, 0x71, 0x95,
+	0xd9, 0x6f, 0xbe, 0x99, 0xf9, 0xe6, 0xb3, 0xbd, 0x01, 0x3b, 0x41, 0x14, 0xe3, 0x80, 0xc7, 0x2b,
+	0xb6, 0xce, 0x04, 0x4d, 0x19, 0x8f, 0xdd, 0x44, 0xf0, 0x94, 0x93, 0xb6, 0xfa, 0x91, 0xce, 0x05,
+	0x58, 0x93, 0x38, 0xd8, 0x70, 0x31, 0x43, 0x14, 0x92, 0x7c, 0x01, 0x3d, 0xaa, 0x8e, 0x7e, 0x51,
+	0x29, 0x6d, 0x63, 0xd8, 0x1a, 0x59, 0xaf, 0x89, 0x2e, 0x92, 0xee, 0x9e, 0xea, 0x59, 0x74, 0x5f,
+	0xe6, 0x7c, 0x0e, 0xb0, 0x4f, 0x11, 0x02, 0xe6, 0x86, 0xcb, 0xd4, 0x36, 0x86, 0xc6, 0xa8, 0xeb,
+	0xa9, 0xb8, 0xc0, 0x12, 0x2e, 0x52, 0xfb, 0xd9, 0xd0, 0x18, 0x3d, 0xf7, 0x54, 0xec, 0x7c, 0x02,
+	0xd6, 0x64, 0xf6, 0x9d, 0x87, 0x92, 0x67, 0x22, 0x40, 0xf2, 0x1e, 0x40, 0xc2, 0x43, 0x16, 0xe4,
+	0xbe, 0xc0, 0x55, 0x59, 0xdc, 0xd5, 0x88, 0x87, 0x2b, 0xe7, 0x77, 0x03, 0xcc, 0xc9, 0xf9, 0x54,
+	0x92, 0x8f, 0xc0, 0xa4, 0x41, 0x58, 0x69, 0x7b, 0x7b, 0xa7, 0xed, 0x7c, 0x2a, 0xdd, 0x49, 0x10,
+	0xca, 0xcb, 0x38, 0x15, 0xb9, 0xa7, 0x38, 0x83, 0x29, 0x74, 0x77, 0x10, 0xe9, 0x43, 0xeb, 0x16,
+	0xf3, 0xb2, 0x73, 0x11, 0x92, 0x13, 0x78, 0x7e, 0x47, 0xc3, 0x0c, 0x95, 0x2c, 0xeb, 0xf5, 0x8b,
+	0x5d, 0xaf, 0xbd, 0x2c, 0x4f, 0x33, 0xbe, 0x7e, 0xf6, 0x95, 0xe1, 0x9c, 0x02, 0xb9, 0xe1, 0xb7,
+	0x18, 0x5f, 0xc6, 0x81, 0xc8, 0x93, 0xc2, 0xcd, 0x2b, 0xcc, 0x95, 0xee, 0x6c, 0x11, 0xb2, 0xc0,
+	0xaf, 0xba, 0xf7, 0xbc, 0xae, 0x46, 0xae, 0x30, 0x77, 0xfe, 0x34, 0xc0, 0x52, 0x55, 0xf3, 0x2c,
+	0x49, 0xc2, 0x9c, 0x7c, 0x09, 0xed, 0x90, 0x45, 0x2c, 0xad, 0x16, 0x78, 0x59, 0x0d, 0xad, 0x91,
+	0xdc, 0xa9, 0x62, 0xe8, 0x4d, 0x4a, 0xfa, 0x60, 0x0e, 0x56, 0x0d, 0x3e, 0xb0, 0x8d, 0xdb, 0xdc,
+	0xc6, 0x3e, 0xd0, 0x58, 0x35, 0xa8, 0xaf, 0xf4, 0x87, 0x01, 0xfd, 0x87, 0x79, 0x32, 0x82, 0x7e,
+	0x44, 0xb7, 0x7e, 0xca, 0x53, 0x1a, 0xfa, 0x52, 0x25, 0xd4, 0x1c, 0xd3, 0x7b, 0x23, 0xa2, 0xdb,
+	0x9b, 0x02, 0x2e, 0x97, 0x79, 0x05, 0x05, 0xe2, 0x27, 0x58, 0xbc, 0x30, 0x82, 0xf1, 0xa5, 0x9a,
+	0x6d, 0x7a, 0xbd, 0x88, 0x6e, 0x67, 0x28, 0x66, 0x0a, 0x23, 0x1f, 0xc0, 0xb1, 0xce, 0xfa, 0x8b,
+	0x90, 0x07, 0xb7, 0xd2, 0x36, 0x35, 0x49, 0x83, 0x67, 0x0a, 0xfb, 0xde, 0xec, 0xb4, 0xfa, 0xa6,
+	0xd7, 0xd6, 0x98, 0xf3, 0xb7, 0x01, 0x2f, 0x94, 0xae, 0x9b, 0x3c, 0xc1, 0x1f, 0x69, 0x84, 0x32,
+	0xa1, 0x01, 0x4a, 0x72, 0x05, 0x10, 0xef, 0x4e, 0xa5, 0x83, 0x1f, 0x37, 0x16, 0x6d, 0x16, 0xb8,
+	0xfb, 0x50, 0xbb, 0x59, 0x2b, 0x1f, 0xfc, 0x02, 0x6f, 0x3e, 0x48, 0x1f, 0x70, 0xf5, 0xd3, 0xa6,
+	0xab, 0x83, 0xff, 0x1e, 0x56, 0xf7, 0xf5, 0x1a, 0xc8, 0x63, 0x02, 0xb1, 0xe1, 0x88, 0x49, 0x99,
+	0x55, 0x5f, 0x56, 0xd7, 0xab, 0x8e, 0xe4, 0x25, 0x58, 0x74, 0x19, 0xb1, 0x58, 0xfa, 0x3c, 0x0e,
+	0x73, 0x35, 0xab, 0xe3, 0x81, 0x86, 0xae, 0xe3, 0x30, 0x77, 0xfe, 0x32, 0xe0, 0x2d, 0xd5, 0xf1,
+	0x07, 0x4c, 0xe9, 0x92, 0xa6, 0x74, 0x1e, 0x6c, 0x30, 0xa2, 0x92, 0x9c, 0xc3, 0x91, 0xd4, 0x61,
+	0x69, 0xc7, 0x49, 0x43, 0xe1, 0x03, 0xba, 0x5b, 0xfe, 0x6a, 0x33, 0xaa, 0xca, 0xc1, 0xcf, 0xd0,
+	0xab, 0x27, 0x0e, 0xd8, 0xf0, 0x59, 0xd3, 0x86, 0x77, 0x9f, 0x18, 0x52, 0xf7, 0xe1, 0x9f, 0xea,
+	0x39, 0x36, 0x29, 0xe4, 0x1b, 0x68, 0xaf, 0x18, 0x86, 0xcb, 0x4a, 0xf4, 0x87, 0x4f, 0xf4, 0x73,
+	0xbf, 0x55, 0xcc, 0xf2, 0x6b, 0xd0, 0x65, 0xe4, 0x15, 0x1c, 0x0b, 0x94, 0x09, 0x06, 0x6c, 0xc5,
+	0xe8, 0x22, 0xc4, 0xd2, 0xb2, 0x26, 0x48, 0xde, 0x81, 0x4e, 0xf1, 0x7e, 0x4a, 0x76, 0x8f, 0x76,
+	0x6b, 0x68, 0x8c, 0x8e, 0xbd, 0xa3, 0x88, 0x6e, 0xe7, 0xec, 0x1e, 0x07, 0x3f, 0x81, 0x55, 0xeb,
+	0xfb, 0x7f, 0x1f, 0x7c, 0xa5, 0x50, 0xb5, 0xa8, 0x2f, 0x7c, 0x01, 0xe4, 0x31, 0xa1, 0xb8, 0xfe,
+	0xd2, 0x3c, 0xc1, 0xea, 0x4a, 0x2c, 0x62, 0x32, 0x80, 0x8e, 0xc0, 0xdf, 0x32, 0x26, 0x70, 0x59,
+	0x8a, 0xdf, 0x9d, 0x9d, 0xf7, 0xcb, 0x3b, 0xe3, 0x42, 0xb0, 0x3b, 0x7d, 0xa3, 0x16, 0xaf, 0x6d,
+	0x55, 0x5e, 0xc4, 0x67, 0xd7, 0xe0, 0x70, 0xb1, 0x76, 0x37, 0x79, 0x82, 0x22, 0xc4, 0xe5, 0x1a,
+	0x85, 0xbb, 0xa2, 0x0b, 0xc1, 0x82, 0x4a, 0x67, 0x71, 0x83, 0xff, 0x7a, 0xb2, 0x66, 0xe9, 0x26,
+	0x5b, 0xb8, 0x01, 0x8f, 0xc6, 0x35, 0xea, 0x58, 0x53, 0xc7, 0x9a, 0x3a, 0x2e, 0xa8, 0x0b, 0xfd,
+	0x97, 0x70, 0xfa, 0xef, 0x00, 0x04, 0x3a, 0x8b, 0xd3, 0x35, 0x06, 0x00, 0x00,
 }
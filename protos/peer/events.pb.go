@@ -8,6 +8,7 @@ import fmt "fmt"
 import math "math"
 import _ "github.com/golang/protobuf/ptypes/timestamp"
 import common "github.com/hyperledger/fabric/protos/common"
+import rwset "github.com/hyperledger/fabric/protos/ledger/rwset"
 
 import (
 	context "golang.org/x/net/context"
@@ -30,16 +31,19 @@ type FilteredBlock struct {
 	ChannelId            string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
 	Number               uint64                 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
 	FilteredTransactions []*FilteredTransaction `protobuf:"bytes,4,rep,name=filtered_transactions,json=filteredTransactions,proto3" json:"filtered_transactions,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+	// OwnerKey is the key with which the subscriber resolves the token outputs
+	// it owns. It is specific to the subscriber and to the deliver stream.
+	OwnerKey             []byte   `protobuf:"bytes,5,opt,name=owner_key,json=ownerKey,proto3" json:"owner_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *FilteredBlock) Reset()         { *m = FilteredBlock{} }
 func (m *FilteredBlock) String() string { return proto.CompactTextString(m) }
 func (*FilteredBlock) ProtoMessage()    {}
 func (*FilteredBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{0}
+	return fileDescriptor_events_f0e3a09080b02ad1, []int{0}
 }
 func (m *FilteredBlock) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FilteredBlock.Unmarshal(m, b)
@@ -80,6 +84,13 @@ func (m *FilteredBlock) GetFilteredTransactions() []*FilteredTransaction {
 	return nil
 }
 
+func (m *FilteredBlock) GetOwnerKey() []byte {
+	if m != nil {
+		return m.OwnerKey
+	}
+	return nil
+}
+
 // FilteredTransaction is a minimal set of information about a transaction
 // within a block
 type FilteredTransaction struct {
@@ -88,6 +99,7 @@ type FilteredTransaction struct {
 	TxValidationCode TxValidationCode  `protobuf:"varint,3,opt,name=tx_validation_code,json=txValidationCode,proto3,enum=protos.TxValidationCode" json:"tx_validation_code,omitempty"`
 	// Types that are valid to be assigned to Data:
 	//	*FilteredTransaction_TransactionActions
+	//	*FilteredTransaction_TokenTransaction
 	Data                 isFilteredTransaction_Data `protobuf_oneof:"Data"`
 	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
 	XXX_unrecognized     []byte                     `json:"-"`
@@ -98,7 +110,7 @@ func (m *FilteredTransaction) Reset()         { *m = FilteredTransaction{} }
 func (m *FilteredTransaction) String() string { return proto.CompactTextString(m) }
 func (*FilteredTransaction) ProtoMessage()    {}
 func (*FilteredTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{1}
+	return fileDescriptor_events_f0e3a09080b02ad1, []int{1}
 }
 func (m *FilteredTransaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FilteredTransaction.Unmarshal(m, b)
@@ -147,8 +159,14 @@ type FilteredTransaction_TransactionActions struct {
 	TransactionActions *FilteredTransactionActions `protobuf:"bytes,4,opt,name=transaction_actions,json=transactionActions,proto3,oneof"`
 }
 
+type FilteredTransaction_TokenTransaction struct {
+	TokenTransaction *FilteredTokenTransaction `protobuf:"bytes,5,opt,name=token_transaction,json=tokenTransaction,proto3,oneof"`
+}
+
 func (*FilteredTransaction_TransactionActions) isFilteredTransaction_Data() {}
 
+func (*FilteredTransaction_TokenTransaction) isFilteredTransaction_Data() {}
+
 func (m *FilteredTransaction) GetData() isFilteredTransaction_Data {
 	if m != nil {
 		return m.Data
@@ -163,10 +181,18 @@ func (m *FilteredTransaction) GetTransactionActions() *FilteredTransactionAction
 	return nil
 }
 
+func (m *FilteredTransaction) GetTokenTransaction() *FilteredTokenTransaction {
+	if x, ok := m.GetData().(*FilteredTransaction_TokenTransaction); ok {
+		return x.TokenTransaction
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*FilteredTransaction) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _FilteredTransaction_OneofMarshaler, _FilteredTransaction_OneofUnmarshaler, _FilteredTransaction_OneofSizer, []interface{}{
 		(*FilteredTransaction_TransactionActions)(nil),
+		(*FilteredTransaction_TokenTransaction)(nil),
 	}
 }
 
@@ -179,6 +205,11 @@ func _FilteredTransaction_OneofMarshaler(msg proto.Message, b *proto.Buffer) err
 		if err := b.EncodeMessage(x.TransactionActions); err != nil {
 			return err
 		}
+	case *FilteredTransaction_TokenTransaction:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.TokenTransaction); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("FilteredTransaction.Data has unexpected type %T", x)
@@ -197,6 +228,14 @@ func _FilteredTransaction_OneofUnmarshaler(msg proto.Message, tag, wire int, b *
 		err := b.DecodeMessage(msg)
 		m.Data = &FilteredTransaction_TransactionActions{msg}
 		return true, err
+	case 5: // Data.token_transaction
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(FilteredTokenTransaction)
+		err := b.DecodeMessage(msg)
+		m.Data = &FilteredTransaction_TokenTransaction{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -211,6 +250,11 @@ func _FilteredTransaction_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *FilteredTransaction_TokenTransaction:
+		s := proto.Size(x.TokenTransaction)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -231,7 +275,7 @@ func (m *FilteredTransactionActions) Reset()         { *m = FilteredTransactionA
 func (m *FilteredTransactionActions) String() string { return proto.CompactTextString(m) }
 func (*FilteredTransactionActions) ProtoMessage()    {}
 func (*FilteredTransactionActions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{2}
+	return fileDescriptor_events_f0e3a09080b02ad1, []int{2}
 }
 func (m *FilteredTransactionActions) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FilteredTransactionActions.Unmarshal(m, b)
@@ -271,7 +315,7 @@ func (m *FilteredChaincodeAction) Reset()         { *m = FilteredChaincodeAction
 func (m *FilteredChaincodeAction) String() string { return proto.CompactTextString(m) }
 func (*FilteredChaincodeAction) ProtoMessage()    {}
 func (*FilteredChaincodeAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{3}
+	return fileDescriptor_events_f0e3a09080b02ad1, []int{3}
 }
 func (m *FilteredChaincodeAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FilteredChaincodeAction.Unmarshal(m, b)
@@ -298,12 +342,222 @@ func (m *FilteredChaincodeAction) GetChaincodeEvent() *ChaincodeEvent {
 	return nil
 }
 
+// FilteredTokenTransaction is a minimal set of information about a token
+// transaction, which does not disclose the owners of its outputs
+type FilteredTokenTransaction struct {
+	Outputs              []*FilteredTokenOutput `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *FilteredTokenTransaction) Reset()         { *m = FilteredTokenTransaction{} }
+func (m *FilteredTokenTransaction) String() string { return proto.CompactTextString(m) }
+func (*FilteredTokenTransaction) ProtoMessage()    {}
+func (*FilteredTokenTransaction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_events_f0e3a09080b02ad1, []int{4}
+}
+func (m *FilteredTokenTransaction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FilteredTokenTransaction.Unmarshal(m, b)
+}
+func (m *FilteredTokenTransaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FilteredTokenTransaction.Marshal(b, m, deterministic)
+}
+func (dst *FilteredTokenTransaction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FilteredTokenTransaction.Merge(dst, src)
+}
+func (m *FilteredTokenTransaction) XXX_Size() int {
+	return xxx_messageInfo_FilteredTokenTransaction.Size(m)
+}
+func (m *FilteredTokenTransaction) XXX_DiscardUnknown() {
+	xxx_messageInfo_FilteredTokenTransaction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FilteredTokenTransaction proto.InternalMessageInfo
+
+func (m *FilteredTokenTransaction) GetOutputs() []*FilteredTokenOutput {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+// FilteredTokenOutput is an output of a token transaction whose owner is
+// replaced by a hash that only the owner can resolve
+type FilteredTokenOutput struct {
+	// OwnerHash is the HMAC-SHA256, keyed with the OwnerKey of the owner, of
+	// the transaction ID and the index of the output
+	OwnerHash            []byte   `protobuf:"bytes,1,opt,name=owner_hash,json=ownerHash,proto3" json:"owner_hash,omitempty"`
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Quantity             uint64   `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FilteredTokenOutput) Reset()         { *m = FilteredTokenOutput{} }
+func (m *FilteredTokenOutput) String() string { return proto.CompactTextString(m) }
+func (*FilteredTokenOutput) ProtoMessage()    {}
+func (*FilteredTokenOutput) Descriptor() ([]byte, []int) {
+	return fileDescriptor_events_f0e3a09080b02ad1, []int{5}
+}
+func (m *FilteredTokenOutput) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FilteredTokenOutput.Unmarshal(m, b)
+}
+func (m *FilteredTokenOutput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FilteredTokenOutput.Marshal(b, m, deterministic)
+}
+func (dst *FilteredTokenOutput) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FilteredTokenOutput.Merge(dst, src)
+}
+func (m *FilteredTokenOutput) XXX_Size() int {
+	return xxx_messageInfo_FilteredTokenOutput.Size(m)
+}
+func (m *FilteredTokenOutput) XXX_DiscardUnknown() {
+	xxx_messageInfo_FilteredTokenOutput.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FilteredTokenOutput proto.InternalMessageInfo
+
+func (m *FilteredTokenOutput) GetOwnerHash() []byte {
+	if m != nil {
+		return m.OwnerHash
+	}
+	return nil
+}
+
+func (m *FilteredTokenOutput) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *FilteredTokenOutput) GetQuantity() uint64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+// EventFilter restricts the transactions of the filtered blocks delivered to
+// a subscriber. It is set, marshaled, as the extension of the channel header
+// of the DELIVER_SEEK_INFO envelope of DeliverFiltered. The blocks are
+// delivered even when none of their transactions matches, so that
+// subscribers can record their progress.
+type EventFilter struct {
+	// ChaincodeIds, when set, keeps the chaincode events of these chaincodes
+	ChaincodeIds []string `protobuf:"bytes,1,rep,name=chaincode_ids,json=chaincodeIds,proto3" json:"chaincode_ids,omitempty"`
+	// EventNames, when set, keeps the chaincode events with these names
+	EventNames []string `protobuf:"bytes,2,rep,name=event_names,json=eventNames,proto3" json:"event_names,omitempty"`
+	// TokenTransactions keeps the token transactions
+	TokenTransactions    bool     `protobuf:"varint,3,opt,name=token_transactions,json=tokenTransactions,proto3" json:"token_transactions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EventFilter) Reset()         { *m = EventFilter{} }
+func (m *EventFilter) String() string { return proto.CompactTextString(m) }
+func (*EventFilter) ProtoMessage()    {}
+func (*EventFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_events_f0e3a09080b02ad1, []int{6}
+}
+func (m *EventFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventFilter.Unmarshal(m, b)
+}
+func (m *EventFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventFilter.Marshal(b, m, deterministic)
+}
+func (dst *EventFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventFilter.Merge(dst, src)
+}
+func (m *EventFilter) XXX_Size() int {
+	return xxx_messageInfo_EventFilter.Size(m)
+}
+func (m *EventFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventFilter proto.InternalMessageInfo
+
+func (m *EventFilter) GetChaincodeIds() []string {
+	if m != nil {
+		return m.ChaincodeIds
+	}
+	return nil
+}
+
+func (m *EventFilter) GetEventNames() []string {
+	if m != nil {
+		return m.EventNames
+	}
+	return nil
+}
+
+func (m *EventFilter) GetTokenTransactions() bool {
+	if m != nil {
+		return m.TokenTransactions
+	}
+	return false
+}
+
+// BlockAndPrivateData contains a block and the private data of its
+// transactions that the requester is eligible to, keyed by transaction
+// sequence number in the block
+type BlockAndPrivateData struct {
+	Block                *common.Block                       `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	PrivateDataMap       map[uint64]*rwset.TxPvtReadWriteSet `protobuf:"bytes,2,rep,name=private_data_map,json=privateDataMap,proto3" json:"private_data_map,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                            `json:"-"`
+	XXX_unrecognized     []byte                              `json:"-"`
+	XXX_sizecache        int32                               `json:"-"`
+}
+
+func (m *BlockAndPrivateData) Reset()         { *m = BlockAndPrivateData{} }
+func (m *BlockAndPrivateData) String() string { return proto.CompactTextString(m) }
+func (*BlockAndPrivateData) ProtoMessage()    {}
+func (*BlockAndPrivateData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_events_f0e3a09080b02ad1, []int{7}
+}
+func (m *BlockAndPrivateData) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockAndPrivateData.Unmarshal(m, b)
+}
+func (m *BlockAndPrivateData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockAndPrivateData.Marshal(b, m, deterministic)
+}
+func (dst *BlockAndPrivateData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockAndPrivateData.Merge(dst, src)
+}
+func (m *BlockAndPrivateData) XXX_Size() int {
+	return xxx_messageInfo_BlockAndPrivateData.Size(m)
+}
+func (m *BlockAndPrivateData) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockAndPrivateData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockAndPrivateData proto.InternalMessageInfo
+
+func (m *BlockAndPrivateData) GetBlock() *common.Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *BlockAndPrivateData) GetPrivateDataMap() map[uint64]*rwset.TxPvtReadWriteSet {
+	if m != nil {
+		return m.PrivateDataMap
+	}
+	return nil
+}
+
 // DeliverResponse
 type DeliverResponse struct {
 	// Types that are valid to be assigned to Type:
 	//	*DeliverResponse_Status
 	//	*DeliverResponse_Block
 	//	*DeliverResponse_FilteredBlock
+	//	*DeliverResponse_BlockAndPrivateData
 	Type                 isDeliverResponse_Type `protobuf_oneof:"Type"`
 	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
 	XXX_unrecognized     []byte                 `json:"-"`
@@ -314,7 +568,7 @@ func (m *DeliverResponse) Reset()         { *m = DeliverResponse{} }
 func (m *DeliverResponse) String() string { return proto.CompactTextString(m) }
 func (*DeliverResponse) ProtoMessage()    {}
 func (*DeliverResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{4}
+	return fileDescriptor_events_f0e3a09080b02ad1, []int{8}
 }
 func (m *DeliverResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeliverResponse.Unmarshal(m, b)
@@ -350,12 +604,18 @@ type DeliverResponse_FilteredBlock struct {
 	FilteredBlock *FilteredBlock `protobuf:"bytes,3,opt,name=filtered_block,json=filteredBlock,proto3,oneof"`
 }
 
+type DeliverResponse_BlockAndPrivateData struct {
+	BlockAndPrivateData *BlockAndPrivateData `protobuf:"bytes,4,opt,name=block_and_private_data,json=blockAndPrivateData,proto3,oneof"`
+}
+
 func (*DeliverResponse_Status) isDeliverResponse_Type() {}
 
 func (*DeliverResponse_Block) isDeliverResponse_Type() {}
 
 func (*DeliverResponse_FilteredBlock) isDeliverResponse_Type() {}
 
+func (*DeliverResponse_BlockAndPrivateData) isDeliverResponse_Type() {}
+
 func (m *DeliverResponse) GetType() isDeliverResponse_Type {
 	if m != nil {
 		return m.Type
@@ -384,12 +644,20 @@ func (m *DeliverResponse) GetFilteredBlock() *FilteredBlock {
 	return nil
 }
 
+func (m *DeliverResponse) GetBlockAndPrivateData() *BlockAndPrivateData {
+	if x, ok := m.GetType().(*DeliverResponse_BlockAndPrivateData); ok {
+		return x.BlockAndPrivateData
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*DeliverResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _DeliverResponse_OneofMarshaler, _DeliverResponse_OneofUnmarshaler, _DeliverResponse_OneofSizer, []interface{}{
 		(*DeliverResponse_Status)(nil),
 		(*DeliverResponse_Block)(nil),
 		(*DeliverResponse_FilteredBlock)(nil),
+		(*DeliverResponse_BlockAndPrivateData)(nil),
 	}
 }
 
@@ -410,6 +678,11 @@ func _DeliverResponse_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.FilteredBlock); err != nil {
 			return err
 		}
+	case *DeliverResponse_BlockAndPrivateData:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.BlockAndPrivateData); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("DeliverResponse.Type has unexpected type %T", x)
@@ -443,6 +716,14 @@ func _DeliverResponse_OneofUnmarshaler(msg proto.Message, tag, wire int, b *prot
 		err := b.DecodeMessage(msg)
 		m.Type = &DeliverResponse_FilteredBlock{msg}
 		return true, err
+	case 4: // Type.block_and_private_data
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(BlockAndPrivateData)
+		err := b.DecodeMessage(msg)
+		m.Type = &DeliverResponse_BlockAndPrivateData{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -465,6 +746,11 @@ func _DeliverResponse_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *DeliverResponse_BlockAndPrivateData:
+		s := proto.Size(x.BlockAndPrivateData)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -477,6 +763,11 @@ func init() {
 	proto.RegisterType((*FilteredTransaction)(nil), "protos.FilteredTransaction")
 	proto.RegisterType((*FilteredTransactionActions)(nil), "protos.FilteredTransactionActions")
 	proto.RegisterType((*FilteredChaincodeAction)(nil), "protos.FilteredChaincodeAction")
+	proto.RegisterType((*FilteredTokenTransaction)(nil), "protos.FilteredTokenTransaction")
+	proto.RegisterType((*FilteredTokenOutput)(nil), "protos.FilteredTokenOutput")
+	proto.RegisterType((*EventFilter)(nil), "protos.EventFilter")
+	proto.RegisterType((*BlockAndPrivateData)(nil), "protos.BlockAndPrivateData")
+	proto.RegisterMapType((map[uint64]*rwset.TxPvtReadWriteSet)(nil), "protos.BlockAndPrivateData.PrivateDataMapEntry")
 	proto.RegisterType((*DeliverResponse)(nil), "protos.DeliverResponse")
 }
 
@@ -500,6 +791,10 @@ type DeliverClient interface {
 	// Payload data as a marshaled orderer.SeekInfo message,
 	// then a stream of **filtered** block replies is received
 	DeliverFiltered(ctx context.Context, opts ...grpc.CallOption) (Deliver_DeliverFilteredClient, error)
+	// deliver first requires an Envelope of type ab.DELIVER_SEEK_INFO with
+	// Payload data as a marshaled orderer.SeekInfo message,
+	// then a stream of block and private data replies is received
+	DeliverWithPrivateData(ctx context.Context, opts ...grpc.CallOption) (Deliver_DeliverWithPrivateDataClient, error)
 }
 
 type deliverClient struct {
@@ -572,6 +867,37 @@ func (x *deliverDeliverFilteredClient) Recv() (*DeliverResponse, error) {
 	return m, nil
 }
 
+func (c *deliverClient) DeliverWithPrivateData(ctx context.Context, opts ...grpc.CallOption) (Deliver_DeliverWithPrivateDataClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Deliver_serviceDesc.Streams[2], "/protos.Deliver/DeliverWithPrivateData", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &deliverDeliverWithPrivateDataClient{stream}
+	return x, nil
+}
+
+type Deliver_DeliverWithPrivateDataClient interface {
+	Send(*common.Envelope) error
+	Recv() (*DeliverResponse, error)
+	grpc.ClientStream
+}
+
+type deliverDeliverWithPrivateDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *deliverDeliverWithPrivateDataClient) Send(m *common.Envelope) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *deliverDeliverWithPrivateDataClient) Recv() (*DeliverResponse, error) {
+	m := new(DeliverResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // DeliverServer is the server API for Deliver service.
 type DeliverServer interface {
 	// deliver first requires an Envelope of type ab.DELIVER_SEEK_INFO with
@@ -582,6 +908,10 @@ type DeliverServer interface {
 	// Payload data as a marshaled orderer.SeekInfo message,
 	// then a stream of **filtered** block replies is received
 	DeliverFiltered(Deliver_DeliverFilteredServer) error
+	// deliver first requires an Envelope of type ab.DELIVER_SEEK_INFO with
+	// Payload data as a marshaled orderer.SeekInfo message,
+	// then a stream of block and private data replies is received
+	DeliverWithPrivateData(Deliver_DeliverWithPrivateDataServer) error
 }
 
 func RegisterDeliverServer(s *grpc.Server, srv DeliverServer) {
@@ -640,6 +970,32 @@ func (x *deliverDeliverFilteredServer) Recv() (*common.Envelope, error) {
 	return m, nil
 }
 
+func _Deliver_DeliverWithPrivateData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DeliverServer).DeliverWithPrivateData(&deliverDeliverWithPrivateDataServer{stream})
+}
+
+type Deliver_DeliverWithPrivateDataServer interface {
+	Send(*DeliverResponse) error
+	Recv() (*common.Envelope, error)
+	grpc.ServerStream
+}
+
+type deliverDeliverWithPrivateDataServer struct {
+	grpc.ServerStream
+}
+
+func (x *deliverDeliverWithPrivateDataServer) Send(m *DeliverResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *deliverDeliverWithPrivateDataServer) Recv() (*common.Envelope, error) {
+	m := new(common.Envelope)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _Deliver_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.Deliver",
 	HandlerType: (*DeliverServer)(nil),
@@ -657,47 +1013,74 @@ var _Deliver_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "DeliverWithPrivateData",
+			Handler:       _Deliver_DeliverWithPrivateData_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "peer/events.proto",
 }
 
-func init() { proto.RegisterFile("peer/events.proto", fileDescriptor_events_8af932975aef5a3c) }
-
-var fileDescriptor_events_8af932975aef5a3c = []byte{
-	// 560 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0x8e, 0x69, 0x08, 0xea, 0x44, 0x49, 0xdb, 0x2d, 0x4d, 0xa3, 0x20, 0xd4, 0xc8, 0x12, 0xc8,
-	0x5c, 0x62, 0x64, 0x6e, 0x1c, 0x40, 0xa4, 0x3f, 0x0a, 0x12, 0x87, 0x6a, 0x09, 0x1c, 0x7a, 0xc0,
-	0x5a, 0xdb, 0x13, 0xc7, 0xd4, 0xf1, 0x5a, 0xbb, 0x9b, 0x28, 0x79, 0x04, 0xde, 0x80, 0x67, 0xe0,
-	0x09, 0x39, 0x22, 0xaf, 0xbd, 0x49, 0x9a, 0x52, 0x24, 0x4e, 0xf6, 0xce, 0x7c, 0x3f, 0x33, 0xb3,
-	0x63, 0xc3, 0x51, 0x8e, 0x28, 0x5c, 0x5c, 0x60, 0xa6, 0xe4, 0x20, 0x17, 0x5c, 0x71, 0xd2, 0xd0,
-	0x0f, 0xd9, 0x3b, 0x0e, 0xf9, 0x6c, 0xc6, 0x33, 0xb7, 0x7c, 0x94, 0xc9, 0xde, 0x59, 0xcc, 0x79,
-	0x9c, 0xa2, 0xab, 0x4f, 0xc1, 0x7c, 0xe2, 0xaa, 0x64, 0x86, 0x52, 0xb1, 0x59, 0x5e, 0x01, 0x7a,
-	0x5a, 0x30, 0x9c, 0xb2, 0x24, 0x0b, 0x79, 0x84, 0xbe, 0x96, 0xae, 0x72, 0x1d, 0x9d, 0x53, 0x82,
-	0x65, 0x92, 0x85, 0x2a, 0x31, 0xa2, 0xf6, 0x4f, 0x0b, 0x5a, 0x57, 0x49, 0xaa, 0x50, 0x60, 0x34,
-	0x4c, 0x79, 0x78, 0x4b, 0x9e, 0x03, 0x84, 0x53, 0x96, 0x65, 0x98, 0xfa, 0x49, 0xd4, 0xb5, 0xfa,
-	0x96, 0xb3, 0x4f, 0xf7, 0xab, 0xc8, 0xc7, 0x88, 0x74, 0xa0, 0x91, 0xcd, 0x67, 0x01, 0x8a, 0xee,
-	0xa3, 0xbe, 0xe5, 0xd4, 0x69, 0x75, 0x22, 0xd7, 0x70, 0x32, 0xa9, 0x74, 0xfc, 0x2d, 0x1b, 0xd9,
-	0xad, 0xf7, 0xf7, 0x9c, 0xa6, 0xf7, 0xac, 0xf4, 0x93, 0x03, 0x63, 0x36, 0xde, 0x60, 0xe8, 0xd3,
-	0xc9, 0xfd, 0xa0, 0xb4, 0x7f, 0x5b, 0x70, 0xfc, 0x17, 0x34, 0x21, 0x50, 0x57, 0xcb, 0x75, 0x69,
-	0xfa, 0x9d, 0xbc, 0x84, 0xba, 0x5a, 0xe5, 0xa8, 0x6b, 0x6a, 0x7b, 0x64, 0x50, 0x0d, 0x6e, 0x84,
-	0x2c, 0x42, 0x31, 0x5e, 0xe5, 0x48, 0x75, 0x9e, 0x5c, 0x01, 0x51, 0x4b, 0x7f, 0xc1, 0xd2, 0x24,
-	0x62, 0x85, 0x98, 0x5f, 0x0c, 0xaa, 0xbb, 0xa7, 0x59, 0x5d, 0x53, 0xe2, 0x78, 0xf9, 0x75, 0x0d,
-	0x38, 0xe7, 0x11, 0xd2, 0x43, 0xb5, 0x13, 0x21, 0x5f, 0xe0, 0x78, 0xab, 0x49, 0x7f, 0xd3, 0xab,
-	0xe5, 0x34, 0x3d, 0xfb, 0x1f, 0xbd, 0x7e, 0x28, 0x91, 0xa3, 0x1a, 0x25, 0xea, 0x5e, 0x74, 0xd8,
-	0x80, 0xfa, 0x05, 0x53, 0xcc, 0xfe, 0x0e, 0xbd, 0x87, 0xb9, 0xe4, 0x13, 0x1c, 0x6d, 0x2e, 0xd9,
-	0x58, 0x5b, 0x7a, 0xcc, 0x67, 0xbb, 0xd6, 0xe7, 0x06, 0x58, 0x92, 0xe9, 0x61, 0x78, 0x37, 0x20,
-	0xed, 0x1b, 0x38, 0x7d, 0x00, 0x4c, 0xde, 0xc3, 0xc1, 0xce, 0x36, 0xe9, 0xa1, 0x37, 0xbd, 0x8e,
-	0xb1, 0x59, 0x33, 0x2e, 0x8b, 0x2c, 0x6d, 0x87, 0x77, 0xce, 0xf6, 0x2f, 0x0b, 0x0e, 0x2e, 0x30,
-	0x4d, 0x16, 0x28, 0x28, 0xca, 0x9c, 0x67, 0x12, 0x89, 0x03, 0x0d, 0xa9, 0x98, 0x9a, 0x4b, 0xad,
-	0xd5, 0xf6, 0xda, 0xe6, 0xb2, 0x3e, 0xeb, 0xe8, 0xa8, 0x46, 0xab, 0x3c, 0x79, 0x01, 0x8f, 0x83,
-	0x62, 0x25, 0xf5, 0xad, 0x36, 0xbd, 0x96, 0x01, 0xea, 0x3d, 0x1d, 0xd5, 0x68, 0x99, 0x25, 0xef,
-	0xa0, 0xbd, 0xde, 0xbc, 0x12, 0xbf, 0xa7, 0xf1, 0x27, 0xbb, 0xb3, 0x30, 0xbc, 0xd6, 0x64, 0x3b,
-	0x50, 0x0c, 0xbd, 0xd8, 0x10, 0xef, 0x87, 0x05, 0x4f, 0xaa, 0x62, 0xc9, 0xdb, 0xcd, 0xeb, 0xa1,
-	0xb1, 0xbd, 0xcc, 0x16, 0x98, 0xf2, 0x1c, 0x7b, 0xa7, 0x46, 0x78, 0xa7, 0x35, 0xbb, 0xe6, 0x58,
-	0xaf, 0x2d, 0x32, 0x5c, 0xf7, 0x6c, 0x8c, 0xff, 0x5b, 0x63, 0xf8, 0x0d, 0x6c, 0x2e, 0xe2, 0xc1,
-	0x74, 0x95, 0xa3, 0x48, 0x31, 0x8a, 0x51, 0x0c, 0x26, 0x2c, 0x10, 0x49, 0x68, 0x68, 0xc5, 0xe7,
-	0x3c, 0x6c, 0xe9, 0x29, 0xcb, 0x6b, 0x16, 0xde, 0xb2, 0x18, 0x6f, 0x5e, 0xc5, 0x89, 0x9a, 0xce,
-	0x83, 0xc2, 0xcb, 0xdd, 0x62, 0xba, 0x25, 0xb3, 0xfc, 0x6f, 0x48, 0xb7, 0x60, 0x06, 0xe5, 0x8f,
-	0xe6, 0xcd, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xd3, 0x19, 0xd1, 0xa9, 0x84, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("peer/events.proto", fileDescriptor_events_f0e3a09080b02ad1) }
+
+var fileDescriptor_events_f0e3a09080b02ad1 = []byte{
+	// 892 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0xdd, 0x8e, 0xdb, 0x44,
+	0x14, 0x8e, 0xb3, 0xd9, 0xed, 0xe6, 0x64, 0x93, 0x66, 0x27, 0x34, 0xb5, 0xb2, 0x42, 0x1b, 0xb9,
+	0x02, 0x85, 0x0b, 0x62, 0x64, 0x84, 0x84, 0x7a, 0x01, 0x6a, 0xda, 0x2d, 0xa9, 0xf8, 0xe9, 0x32,
+	0x5d, 0xa8, 0x28, 0x12, 0xd6, 0xc4, 0x3e, 0x49, 0xcc, 0x3a, 0xb6, 0xf1, 0x4c, 0xd2, 0xcd, 0x2d,
+	0xef, 0xc4, 0x43, 0x70, 0xcb, 0xa3, 0xf0, 0x00, 0x08, 0x79, 0xc6, 0xe3, 0x78, 0x93, 0x6c, 0x05,
+	0x37, 0xc9, 0xcc, 0x77, 0xbe, 0xf3, 0x33, 0x9f, 0xcf, 0x9c, 0x81, 0xd3, 0x04, 0x31, 0xb5, 0x71,
+	0x85, 0x91, 0xe0, 0xc3, 0x24, 0x8d, 0x45, 0x4c, 0x8e, 0xe4, 0x1f, 0xef, 0x75, 0xbc, 0x78, 0xb1,
+	0x88, 0x23, 0x5b, 0xfd, 0x29, 0x63, 0xef, 0x7c, 0x16, 0xc7, 0xb3, 0x10, 0x6d, 0xb9, 0x9b, 0x2c,
+	0xa7, 0xb6, 0x08, 0x16, 0xc8, 0x05, 0x5b, 0x24, 0x39, 0xa1, 0x27, 0x03, 0x7a, 0x73, 0x16, 0x44,
+	0x5e, 0xec, 0xa3, 0x2b, 0x43, 0xe7, 0xb6, 0xae, 0xb4, 0x89, 0x94, 0x45, 0x9c, 0x79, 0x22, 0x28,
+	0x82, 0x9a, 0x21, 0xfa, 0x33, 0x4c, 0xed, 0xf4, 0x2d, 0x47, 0xa1, 0x7e, 0x95, 0xc5, 0xfa, 0xc3,
+	0x80, 0xe6, 0xf3, 0x20, 0x14, 0x98, 0xa2, 0x3f, 0x0a, 0x63, 0xef, 0x9a, 0xbc, 0x0f, 0xe0, 0xcd,
+	0x59, 0x14, 0x61, 0xe8, 0x06, 0xbe, 0x69, 0xf4, 0x8d, 0x41, 0x9d, 0xd6, 0x73, 0xe4, 0x85, 0x4f,
+	0xba, 0x70, 0x14, 0x2d, 0x17, 0x13, 0x4c, 0xcd, 0x6a, 0xdf, 0x18, 0xd4, 0x68, 0xbe, 0x23, 0x97,
+	0xf0, 0x60, 0x9a, 0xc7, 0x71, 0x4b, 0x05, 0x70, 0xb3, 0xd6, 0x3f, 0x18, 0x34, 0x9c, 0x33, 0x95,
+	0x8f, 0x0f, 0x75, 0xb2, 0xab, 0x0d, 0x87, 0xbe, 0x37, 0xdd, 0x05, 0x39, 0x39, 0x83, 0x7a, 0xfc,
+	0x36, 0xc2, 0xd4, 0xbd, 0xc6, 0xb5, 0x79, 0xd8, 0x37, 0x06, 0x27, 0xf4, 0x58, 0x02, 0x5f, 0xe3,
+	0xda, 0xfa, 0xb3, 0x0a, 0x9d, 0x3d, 0xa1, 0x08, 0x81, 0x9a, 0xb8, 0x29, 0xea, 0x96, 0x6b, 0xf2,
+	0x21, 0xd4, 0xc4, 0x3a, 0x41, 0x59, 0x70, 0xcb, 0x21, 0xc3, 0x5c, 0xef, 0x31, 0x32, 0x1f, 0xd3,
+	0xab, 0x75, 0x82, 0x54, 0xda, 0xc9, 0x73, 0x20, 0xe2, 0xc6, 0x5d, 0xb1, 0x30, 0xf0, 0x59, 0x16,
+	0xcc, 0xcd, 0xf4, 0x35, 0x0f, 0xa4, 0x97, 0xa9, 0xeb, 0xbf, 0xba, 0xf9, 0xb1, 0x20, 0x3c, 0x8d,
+	0x7d, 0xa4, 0x6d, 0xb1, 0x85, 0x90, 0x1f, 0xa0, 0x53, 0x52, 0xc0, 0xdd, 0x08, 0x61, 0x0c, 0x1a,
+	0x8e, 0xf5, 0x0e, 0x21, 0x9e, 0x28, 0xe6, 0xb8, 0x42, 0x89, 0xd8, 0x41, 0xc9, 0x4b, 0x38, 0x15,
+	0xf1, 0x35, 0x46, 0x65, 0x79, 0xa5, 0x2e, 0x0d, 0xa7, 0xbf, 0x13, 0x34, 0x23, 0x96, 0x22, 0x8f,
+	0x2b, 0xb4, 0x2d, 0xb6, 0xb0, 0xd1, 0x11, 0xd4, 0x9e, 0x31, 0xc1, 0xac, 0x5f, 0xa1, 0x77, 0x77,
+	0x31, 0xe4, 0x1b, 0x38, 0xdd, 0x34, 0x9b, 0x3e, 0x8b, 0x21, 0x3f, 0xea, 0xf9, 0x76, 0xda, 0xa7,
+	0x9a, 0xa8, 0x9c, 0x69, 0xdb, 0xbb, 0x0d, 0x70, 0xeb, 0x0d, 0x3c, 0xbc, 0x83, 0x4c, 0xbe, 0x84,
+	0xfb, 0x5b, 0x5d, 0x2d, 0xbf, 0x62, 0xc3, 0xe9, 0xea, 0x34, 0x85, 0xc7, 0x45, 0x66, 0xa5, 0x2d,
+	0xef, 0xd6, 0xde, 0xfa, 0x1e, 0xcc, 0xbb, 0xce, 0x4f, 0x3e, 0x83, 0x7b, 0xf1, 0x52, 0x24, 0x4b,
+	0xa1, 0x6b, 0x3f, 0xdb, 0x2b, 0xd9, 0x4b, 0xc9, 0xa1, 0x9a, 0x6b, 0xf9, 0xd0, 0xd9, 0x63, 0xcf,
+	0xee, 0x88, 0x6a, 0xcd, 0x39, 0xe3, 0x73, 0x59, 0xe5, 0x09, 0x55, 0xcd, 0x3a, 0x66, 0x7c, 0x4e,
+	0x48, 0xa9, 0xe1, 0xea, 0x79, 0x73, 0xf5, 0xe0, 0xf8, 0xb7, 0x25, 0x8b, 0x44, 0x20, 0xd6, 0xb2,
+	0xa5, 0x6a, 0xb4, 0xd8, 0x5b, 0xbf, 0x1b, 0xd0, 0x90, 0x47, 0x50, 0xb9, 0xc8, 0x23, 0x68, 0x6e,
+	0x94, 0x08, 0x7c, 0x55, 0x72, 0x9d, 0x9e, 0x14, 0xe0, 0x0b, 0x9f, 0x93, 0x73, 0x68, 0x48, 0x91,
+	0xdc, 0x88, 0x2d, 0x90, 0x9b, 0x55, 0x49, 0x01, 0x09, 0x7d, 0x97, 0x21, 0xe4, 0x63, 0x20, 0x3b,
+	0xfd, 0xc2, 0x65, 0xee, 0x63, 0x7a, 0xba, 0xdd, 0x0c, 0xdc, 0xfa, 0xdb, 0x80, 0x8e, 0x9c, 0x00,
+	0x4f, 0x22, 0xff, 0x32, 0x0d, 0x56, 0x4c, 0x60, 0xd6, 0x1d, 0xe4, 0x11, 0x1c, 0x4e, 0x32, 0x38,
+	0xff, 0x18, 0x4d, 0x7d, 0x7d, 0x24, 0x97, 0x2a, 0x1b, 0xf9, 0x09, 0xda, 0x89, 0xf2, 0x71, 0x7d,
+	0x26, 0x98, 0xbb, 0x60, 0x89, 0xac, 0xa8, 0xe1, 0xd8, 0x5a, 0xe7, 0x3d, 0xb1, 0x87, 0xa5, 0xf5,
+	0xb7, 0x2c, 0xb9, 0x88, 0x44, 0xba, 0xa6, 0xad, 0xe4, 0x16, 0xd8, 0xfb, 0x19, 0x3a, 0x7b, 0x68,
+	0xa4, 0x0d, 0x07, 0xd9, 0x5c, 0x30, 0xa4, 0x94, 0xd9, 0x92, 0x0c, 0xe1, 0x70, 0xc5, 0xc2, 0xa5,
+	0x92, 0xbd, 0xe1, 0x98, 0x43, 0x35, 0xe7, 0xae, 0x6e, 0x2e, 0x57, 0x82, 0x22, 0xf3, 0x5f, 0xa7,
+	0x81, 0xc0, 0x57, 0x28, 0xa8, 0xa2, 0x3d, 0xae, 0x7e, 0x6e, 0x58, 0xff, 0x18, 0x70, 0xff, 0x19,
+	0x86, 0xc1, 0x0a, 0x53, 0x8a, 0x3c, 0x89, 0x23, 0x8e, 0x64, 0x00, 0x47, 0x5c, 0x30, 0xb1, 0xe4,
+	0x32, 0x78, 0xcb, 0x69, 0xe9, 0x13, 0xbf, 0x92, 0xe8, 0xb8, 0x42, 0x73, 0x3b, 0xf9, 0x40, 0x4b,
+	0x53, 0xdd, 0x23, 0xcd, 0xb8, 0xa2, 0xc5, 0xf9, 0x02, 0x5a, 0xc5, 0x68, 0x54, 0xfc, 0x03, 0xc9,
+	0x7f, 0xb0, 0xdd, 0x82, 0xda, 0xaf, 0x39, 0x2d, 0x03, 0x84, 0x42, 0x57, 0xba, 0xb9, 0x2c, 0xf2,
+	0xdd, 0xb2, 0xcc, 0xf9, 0x48, 0x39, 0x7b, 0x87, 0xc4, 0xe3, 0x0a, 0xed, 0x4c, 0x76, 0xe1, 0xec,
+	0xee, 0x67, 0x93, 0xcf, 0xf9, 0xcb, 0x80, 0x7b, 0xb9, 0x00, 0xe4, 0xf1, 0x66, 0xd9, 0xd6, 0x47,
+	0xb9, 0x88, 0x56, 0x18, 0xc6, 0x09, 0xf6, 0x1e, 0xea, 0x24, 0x5b, 0x72, 0x59, 0x95, 0x81, 0xf1,
+	0x89, 0x41, 0x46, 0x85, 0x8e, 0xfa, 0x30, 0xff, 0x3f, 0xc6, 0x57, 0xd0, 0xcd, 0x0d, 0xaf, 0x03,
+	0x31, 0x2f, 0xf7, 0xe0, 0x7f, 0x0f, 0x95, 0x05, 0x1a, 0xfd, 0x02, 0x56, 0x9c, 0xce, 0x86, 0xf3,
+	0x75, 0x82, 0xa9, 0x7a, 0xf9, 0x86, 0x53, 0x36, 0x49, 0x03, 0x4f, 0x3b, 0x65, 0xcf, 0xe4, 0xa8,
+	0x29, 0xaf, 0x1c, 0xbf, 0x64, 0xde, 0x35, 0x9b, 0xe1, 0x9b, 0x8f, 0x66, 0x81, 0x98, 0x2f, 0x27,
+	0x59, 0x26, 0xbb, 0xe4, 0x69, 0x2b, 0x4f, 0xf5, 0x1e, 0x73, 0x3b, 0xf3, 0x9c, 0xa8, 0x07, 0xfc,
+	0xd3, 0x7f, 0x07, 0x00, 0x3b, 0xf6, 0x7b, 0x17, 0xdc, 0x07, 0x00, 0x00,
 }
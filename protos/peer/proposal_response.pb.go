@@ -40,17 +40,21 @@ type ProposalResponse struct {
 	Payload []byte `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
 	// The endorsement of the proposal, basically
 	// the endorser's signature over the payload
-	Endorsement          *Endorsement `protobuf:"bytes,6,opt,name=endorsement,proto3" json:"endorsement,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Endorsement *Endorsement `protobuf:"bytes,6,opt,name=endorsement,proto3" json:"endorsement,omitempty"`
+	// The keys read by the proposal that recently caused read conflicts on
+	// the channel, if the endorser advises clients of them. It is not covered
+	// by the endorsement.
+	ConflictAdvisory     *ConflictAdvisory `protobuf:"bytes,7,opt,name=conflict_advisory,json=conflictAdvisory,proto3" json:"conflict_advisory,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *ProposalResponse) Reset()         { *m = ProposalResponse{} }
 func (m *ProposalResponse) String() string { return proto.CompactTextString(m) }
 func (*ProposalResponse) ProtoMessage()    {}
 func (*ProposalResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_response_22a755721b685f40, []int{0}
+	return fileDescriptor_proposal_response_9e48152fb698d5ea, []int{0}
 }
 func (m *ProposalResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ProposalResponse.Unmarshal(m, b)
@@ -105,6 +109,13 @@ func (m *ProposalResponse) GetEndorsement() *Endorsement {
 	return nil
 }
 
+func (m *ProposalResponse) GetConflictAdvisory() *ConflictAdvisory {
+	if m != nil {
+		return m.ConflictAdvisory
+	}
+	return nil
+}
+
 // A response with a representation similar to an HTTP response that can
 // be used within another message.
 type Response struct {
@@ -123,7 +134,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_response_22a755721b685f40, []int{1}
+	return fileDescriptor_proposal_response_9e48152fb698d5ea, []int{1}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Response.Unmarshal(m, b)
@@ -201,7 +212,7 @@ func (m *ProposalResponsePayload) Reset()         { *m = ProposalResponsePayload
 func (m *ProposalResponsePayload) String() string { return proto.CompactTextString(m) }
 func (*ProposalResponsePayload) ProtoMessage()    {}
 func (*ProposalResponsePayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_response_22a755721b685f40, []int{2}
+	return fileDescriptor_proposal_response_9e48152fb698d5ea, []int{2}
 }
 func (m *ProposalResponsePayload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ProposalResponsePayload.Unmarshal(m, b)
@@ -259,7 +270,7 @@ func (m *Endorsement) Reset()         { *m = Endorsement{} }
 func (m *Endorsement) String() string { return proto.CompactTextString(m) }
 func (*Endorsement) ProtoMessage()    {}
 func (*Endorsement) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_response_22a755721b685f40, []int{3}
+	return fileDescriptor_proposal_response_9e48152fb698d5ea, []int{3}
 }
 func (m *Endorsement) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Endorsement.Unmarshal(m, b)
@@ -293,40 +304,161 @@ func (m *Endorsement) GetSignature() []byte {
 	return nil
 }
 
+// ConflictAdvisory reports the keys recently found in read conflicts on a
+// channel, so that clients can delay or change the transactions bound to
+// conflict on them rather than blindly retrying.
+type ConflictAdvisory struct {
+	// The hotspots, the most conflicting first
+	Hotspots             []*ConflictHotspot `protobuf:"bytes,1,rep,name=hotspots,proto3" json:"hotspots,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ConflictAdvisory) Reset()         { *m = ConflictAdvisory{} }
+func (m *ConflictAdvisory) String() string { return proto.CompactTextString(m) }
+func (*ConflictAdvisory) ProtoMessage()    {}
+func (*ConflictAdvisory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_proposal_response_9e48152fb698d5ea, []int{4}
+}
+func (m *ConflictAdvisory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConflictAdvisory.Unmarshal(m, b)
+}
+func (m *ConflictAdvisory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConflictAdvisory.Marshal(b, m, deterministic)
+}
+func (dst *ConflictAdvisory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConflictAdvisory.Merge(dst, src)
+}
+func (m *ConflictAdvisory) XXX_Size() int {
+	return xxx_messageInfo_ConflictAdvisory.Size(m)
+}
+func (m *ConflictAdvisory) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConflictAdvisory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConflictAdvisory proto.InternalMessageInfo
+
+func (m *ConflictAdvisory) GetHotspots() []*ConflictHotspot {
+	if m != nil {
+		return m.Hotspots
+	}
+	return nil
+}
+
+// ConflictHotspot is a key recently found in read conflicts.
+type ConflictHotspot struct {
+	// Namespace is the namespace of the key
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Key is the key
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// Conflicts is the number of transactions recently invalidated by
+	// conflicts on the key
+	Conflicts uint64 `protobuf:"varint,3,opt,name=conflicts,proto3" json:"conflicts,omitempty"`
+	// TxIds are the IDs of the transactions most recently invalidated by
+	// conflicts on the key, the most recent first
+	TxIds                []string `protobuf:"bytes,4,rep,name=tx_ids,json=txIds,proto3" json:"tx_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConflictHotspot) Reset()         { *m = ConflictHotspot{} }
+func (m *ConflictHotspot) String() string { return proto.CompactTextString(m) }
+func (*ConflictHotspot) ProtoMessage()    {}
+func (*ConflictHotspot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_proposal_response_9e48152fb698d5ea, []int{5}
+}
+func (m *ConflictHotspot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConflictHotspot.Unmarshal(m, b)
+}
+func (m *ConflictHotspot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConflictHotspot.Marshal(b, m, deterministic)
+}
+func (dst *ConflictHotspot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConflictHotspot.Merge(dst, src)
+}
+func (m *ConflictHotspot) XXX_Size() int {
+	return xxx_messageInfo_ConflictHotspot.Size(m)
+}
+func (m *ConflictHotspot) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConflictHotspot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConflictHotspot proto.InternalMessageInfo
+
+func (m *ConflictHotspot) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ConflictHotspot) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *ConflictHotspot) GetConflicts() uint64 {
+	if m != nil {
+		return m.Conflicts
+	}
+	return 0
+}
+
+func (m *ConflictHotspot) GetTxIds() []string {
+	if m != nil {
+		return m.TxIds
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ProposalResponse)(nil), "protos.ProposalResponse")
 	proto.RegisterType((*Response)(nil), "protos.Response")
 	proto.RegisterType((*ProposalResponsePayload)(nil), "protos.ProposalResponsePayload")
 	proto.RegisterType((*Endorsement)(nil), "protos.Endorsement")
+	proto.RegisterType((*ConflictAdvisory)(nil), "protos.ConflictAdvisory")
+	proto.RegisterType((*ConflictHotspot)(nil), "protos.ConflictHotspot")
 }
 
 func init() {
-	proto.RegisterFile("peer/proposal_response.proto", fileDescriptor_proposal_response_22a755721b685f40)
-}
-
-var fileDescriptor_proposal_response_22a755721b685f40 = []byte{
-	// 367 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0x51, 0x4b, 0xfb, 0x30,
-	0x14, 0xc5, 0xe9, 0xfe, 0xff, 0xcd, 0x2d, 0x9b, 0x30, 0x2a, 0x68, 0x19, 0x03, 0x47, 0x7d, 0x99,
-	0x20, 0x29, 0x28, 0x82, 0xcf, 0x03, 0xd1, 0xc7, 0x11, 0xc4, 0x07, 0x11, 0x24, 0xdd, 0xee, 0xd2,
-	0x62, 0xdb, 0x84, 0xdc, 0x54, 0xdc, 0x07, 0xf6, 0x7b, 0x48, 0xd3, 0xa6, 0xab, 0xe2, 0xd3, 0x38,
-	0x77, 0x27, 0xbf, 0x7b, 0xcf, 0xed, 0x25, 0x73, 0x05, 0xa0, 0x23, 0xa5, 0xa5, 0x92, 0xc8, 0xb3,
-	0x37, 0x0d, 0xa8, 0x64, 0x81, 0x40, 0x95, 0x96, 0x46, 0xfa, 0x03, 0xfb, 0x83, 0xb3, 0x73, 0x21,
-	0xa5, 0xc8, 0x20, 0xb2, 0x32, 0x2e, 0x77, 0x91, 0x49, 0x73, 0x40, 0xc3, 0x73, 0x55, 0x1b, 0xc3,
-	0x2f, 0x8f, 0x4c, 0xd7, 0x0d, 0x84, 0x35, 0x0c, 0x3f, 0x20, 0x47, 0x1f, 0xa0, 0x31, 0x95, 0x45,
-	0xe0, 0x2d, 0xbc, 0x65, 0x9f, 0x39, 0xe9, 0xdf, 0x91, 0x51, 0x4b, 0x08, 0x7a, 0x0b, 0x6f, 0x39,
-	0xbe, 0x9e, 0xd1, 0xba, 0x07, 0x75, 0x3d, 0xe8, 0x93, 0x73, 0xb0, 0x83, 0xd9, 0xbf, 0x22, 0x43,
-	0x37, 0x63, 0xf0, 0xdf, 0x3e, 0x9c, 0xd6, 0x2f, 0x90, 0xba, 0xbe, 0xac, 0x75, 0x54, 0x13, 0x28,
-	0xbe, 0xcf, 0x24, 0xdf, 0x06, 0xfd, 0x85, 0xb7, 0x9c, 0x30, 0x27, 0xfd, 0x5b, 0x32, 0x86, 0x62,
-	0x2b, 0x35, 0x42, 0x0e, 0x85, 0x09, 0x06, 0x16, 0x75, 0xe2, 0x50, 0xf7, 0x87, 0xbf, 0x58, 0xd7,
-	0x17, 0x3e, 0x93, 0x61, 0x1b, 0xef, 0x94, 0x0c, 0xd0, 0x70, 0x53, 0x62, 0x93, 0xae, 0x51, 0x55,
-	0xd3, 0x1c, 0x10, 0xb9, 0x00, 0x1b, 0x6d, 0xc4, 0x9c, 0xec, 0x8e, 0xf3, 0xef, 0xc7, 0x38, 0xe1,
-	0x2b, 0x39, 0xfb, 0xbd, 0xbe, 0x75, 0x33, 0xe9, 0x05, 0x39, 0x6e, 0x3f, 0x4f, 0xc2, 0x31, 0xb1,
-	0xdd, 0x26, 0x6c, 0xe2, 0x8a, 0x8f, 0x1c, 0x13, 0x7f, 0x4e, 0x46, 0xf0, 0x69, 0xa0, 0xb0, 0xcb,
-	0xee, 0x59, 0xc3, 0xa1, 0x10, 0x3e, 0x90, 0x71, 0x27, 0x91, 0x3f, 0x23, 0xc3, 0x26, 0x93, 0x6e,
-	0x60, 0xad, 0xae, 0x40, 0x98, 0x8a, 0x82, 0x9b, 0x52, 0x83, 0x03, 0xb5, 0x85, 0x55, 0x42, 0x42,
-	0xa9, 0x05, 0x4d, 0xf6, 0x0a, 0x74, 0x06, 0x5b, 0x01, 0x9a, 0xee, 0x78, 0xac, 0xd3, 0x8d, 0x5b,
-	0x5c, 0x75, 0x4d, 0xab, 0x3f, 0xa2, 0x6c, 0xde, 0xb9, 0x80, 0x97, 0x4b, 0x91, 0x9a, 0xa4, 0x8c,
-	0xe9, 0x46, 0xe6, 0x51, 0x87, 0x11, 0xd5, 0x8c, 0xfa, 0xba, 0x30, 0xaa, 0x18, 0x71, 0x7d, 0x79,
-	0x37, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x0e, 0x52, 0x0b, 0x35, 0xa0, 0x02, 0x00, 0x00,
+	proto.RegisterFile("peer/proposal_response.proto", fileDescriptor_proposal_response_9e48152fb698d5ea)
+}
+
+var fileDescriptor_proposal_response_9e48152fb698d5ea = []byte{
+	// 483 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0x4f, 0x6b, 0xdb, 0x4e,
+	0x10, 0xc5, 0x7f, 0x63, 0x8f, 0xfd, 0xe3, 0xe7, 0x6e, 0x69, 0x23, 0x4c, 0xa0, 0x46, 0xbd, 0xb8,
+	0x50, 0x24, 0x48, 0x28, 0xf4, 0xda, 0x94, 0x90, 0xf4, 0x16, 0x96, 0xd2, 0x43, 0x29, 0x98, 0xb5,
+	0x34, 0x96, 0x44, 0x2c, 0xed, 0xb2, 0xb3, 0x36, 0xf6, 0x77, 0xea, 0x87, 0x2c, 0x5a, 0xed, 0xca,
+	0xae, 0xe9, 0x49, 0x9a, 0x37, 0x6f, 0xde, 0xcc, 0xbe, 0x61, 0xe0, 0x46, 0x21, 0xea, 0x58, 0x69,
+	0xa9, 0x24, 0x89, 0xed, 0x4a, 0x23, 0x29, 0x59, 0x11, 0x46, 0x4a, 0x4b, 0x23, 0xd9, 0xd0, 0x7e,
+	0x68, 0xfe, 0x2e, 0x93, 0x32, 0xdb, 0x62, 0x6c, 0xc3, 0xf5, 0x6e, 0x13, 0x9b, 0xa2, 0x44, 0x32,
+	0xa2, 0x54, 0x0d, 0x31, 0xfc, 0xdd, 0x85, 0xd9, 0xb3, 0x13, 0xe1, 0x4e, 0x83, 0x05, 0x70, 0xb5,
+	0x47, 0x4d, 0x85, 0xac, 0x82, 0xce, 0xa2, 0xb3, 0x1c, 0x70, 0x1f, 0xb2, 0xcf, 0x30, 0x6e, 0x15,
+	0x82, 0xee, 0xa2, 0xb3, 0x9c, 0xdc, 0xce, 0xa3, 0xa6, 0x47, 0xe4, 0x7b, 0x44, 0xdf, 0x3d, 0x83,
+	0x9f, 0xc8, 0xec, 0x23, 0x8c, 0xfc, 0x8c, 0x41, 0xdf, 0x16, 0xce, 0x9a, 0x0a, 0x8a, 0x7c, 0x5f,
+	0x3e, 0xd2, 0x67, 0x13, 0x28, 0x71, 0xdc, 0x4a, 0x91, 0x06, 0x83, 0x45, 0x67, 0x39, 0xe5, 0x3e,
+	0x64, 0x9f, 0x60, 0x82, 0x55, 0x2a, 0x35, 0x61, 0x89, 0x95, 0x09, 0x86, 0x56, 0xea, 0xb5, 0x97,
+	0x7a, 0x38, 0xa5, 0xf8, 0x39, 0x8f, 0x3d, 0xc0, 0xab, 0x44, 0x56, 0x9b, 0x6d, 0x91, 0x98, 0x95,
+	0x48, 0xf7, 0x05, 0x49, 0x7d, 0x0c, 0xae, 0x6c, 0x71, 0xe0, 0x8b, 0xbf, 0x3a, 0xc2, 0x17, 0x97,
+	0xe7, 0xb3, 0xe4, 0x02, 0x09, 0x7f, 0xc0, 0xa8, 0x75, 0xe9, 0x2d, 0x0c, 0xc9, 0x08, 0xb3, 0x23,
+	0x67, 0x92, 0x8b, 0xea, 0xd9, 0x4b, 0x24, 0x12, 0x19, 0x5a, 0x87, 0xc6, 0xdc, 0x87, 0xe7, 0xaf,
+	0xea, 0xfd, 0xf5, 0xaa, 0xf0, 0x17, 0x5c, 0x5f, 0x6e, 0xe1, 0xd9, 0x3d, 0xf8, 0x3d, 0xfc, 0xd7,
+	0x6e, 0x39, 0x17, 0x94, 0xdb, 0x6e, 0x53, 0x3e, 0xf5, 0xe0, 0x93, 0xa0, 0x9c, 0xdd, 0xc0, 0x18,
+	0x0f, 0x06, 0x2b, 0xbb, 0xb3, 0xae, 0x25, 0x9c, 0x80, 0xf0, 0x11, 0x26, 0x67, 0xc6, 0xb0, 0x39,
+	0x8c, 0x9c, 0x35, 0xda, 0x89, 0xb5, 0x71, 0x2d, 0x44, 0x45, 0x56, 0x09, 0xb3, 0xd3, 0xe8, 0x85,
+	0x5a, 0x20, 0x7c, 0x84, 0xd9, 0xa5, 0x49, 0xec, 0x0e, 0x46, 0xb9, 0x34, 0xa4, 0xa4, 0xa9, 0x8d,
+	0xe8, 0x2d, 0x27, 0xb7, 0xd7, 0x97, 0x86, 0x3e, 0x35, 0x79, 0xde, 0x12, 0xc3, 0x3d, 0xfc, 0x7f,
+	0x91, 0xac, 0x3b, 0x57, 0xa2, 0x44, 0x52, 0x22, 0x41, 0x3b, 0xd6, 0x98, 0x9f, 0x00, 0x36, 0x83,
+	0xde, 0x0b, 0x1e, 0x9d, 0xa1, 0xf5, 0x6f, 0xcd, 0xf7, 0xeb, 0x21, 0x6b, 0x67, 0x9f, 0x9f, 0x00,
+	0xf6, 0x06, 0x86, 0xe6, 0xb0, 0x2a, 0x52, 0x0a, 0xfa, 0x8b, 0xde, 0x72, 0xcc, 0x07, 0xe6, 0xf0,
+	0x2d, 0xa5, 0xfb, 0x1c, 0x42, 0xa9, 0xb3, 0x28, 0x3f, 0x2a, 0xd4, 0x5b, 0x4c, 0x33, 0xd4, 0xd1,
+	0x46, 0xac, 0x75, 0x91, 0xf8, 0x91, 0xeb, 0xab, 0xba, 0xff, 0xc7, 0x2e, 0x92, 0x17, 0x91, 0xe1,
+	0xcf, 0x0f, 0x59, 0x61, 0xf2, 0xdd, 0x3a, 0x4a, 0x64, 0x19, 0x9f, 0x69, 0xc4, 0x8d, 0x46, 0x73,
+	0x65, 0x14, 0xd7, 0x1a, 0xeb, 0xe6, 0x02, 0xef, 0xfe, 0x0c, 0x00, 0x8d, 0xb4, 0xd0, 0xee, 0xa8,
+	0x03, 0x00, 0x00,
 }
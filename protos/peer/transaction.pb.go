@@ -6,8 +6,9 @@ package peer // import "github.com/hyperledger/fabric/protos/peer"
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
-import _ "github.com/golang/protobuf/ptypes/timestamp"
+import timestamp "github.com/golang/protobuf/ptypes/timestamp"
 import common "github.com/hyperledger/fabric/protos/common"
+import kvrwset "github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -48,6 +49,7 @@ const (
 	TxValidationCode_BAD_RWSET                    TxValidationCode = 22
 	TxValidationCode_ILLEGAL_WRITESET             TxValidationCode = 23
 	TxValidationCode_INVALID_WRITESET             TxValidationCode = 24
+	TxValidationCode_EXPIRED_TRANSACTION          TxValidationCode = 25
 	TxValidationCode_NOT_VALIDATED                TxValidationCode = 254
 	TxValidationCode_INVALID_OTHER_REASON         TxValidationCode = 255
 )
@@ -78,6 +80,7 @@ var TxValidationCode_name = map[int32]string{
 	22:  "BAD_RWSET",
 	23:  "ILLEGAL_WRITESET",
 	24:  "INVALID_WRITESET",
+	25:  "EXPIRED_TRANSACTION",
 	254: "NOT_VALIDATED",
 	255: "INVALID_OTHER_REASON",
 }
@@ -107,6 +110,7 @@ var TxValidationCode_value = map[string]int32{
 	"BAD_RWSET":                    22,
 	"ILLEGAL_WRITESET":             23,
 	"INVALID_WRITESET":             24,
+	"EXPIRED_TRANSACTION":          25,
 	"NOT_VALIDATED":                254,
 	"INVALID_OTHER_REASON":         255,
 }
@@ -115,7 +119,7 @@ func (x TxValidationCode) String() string {
 	return proto.EnumName(TxValidationCode_name, int32(x))
 }
 func (TxValidationCode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4fbd1a0e1a50cfab, []int{0}
+	return fileDescriptor_transaction_5c166e662974086c, []int{0}
 }
 
 // Reserved entries in the key-level metadata map
@@ -136,7 +140,7 @@ func (x MetaDataKeys) String() string {
 	return proto.EnumName(MetaDataKeys_name, int32(x))
 }
 func (MetaDataKeys) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4fbd1a0e1a50cfab, []int{1}
+	return fileDescriptor_transaction_5c166e662974086c, []int{1}
 }
 
 // This message is necessary to facilitate the verification of the signature
@@ -159,7 +163,7 @@ func (m *SignedTransaction) Reset()         { *m = SignedTransaction{} }
 func (m *SignedTransaction) String() string { return proto.CompactTextString(m) }
 func (*SignedTransaction) ProtoMessage()    {}
 func (*SignedTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4fbd1a0e1a50cfab, []int{0}
+	return fileDescriptor_transaction_5c166e662974086c, []int{0}
 }
 func (m *SignedTransaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedTransaction.Unmarshal(m, b)
@@ -213,7 +217,7 @@ func (m *ProcessedTransaction) Reset()         { *m = ProcessedTransaction{} }
 func (m *ProcessedTransaction) String() string { return proto.CompactTextString(m) }
 func (*ProcessedTransaction) ProtoMessage()    {}
 func (*ProcessedTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4fbd1a0e1a50cfab, []int{1}
+	return fileDescriptor_transaction_5c166e662974086c, []int{1}
 }
 func (m *ProcessedTransaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ProcessedTransaction.Unmarshal(m, b)
@@ -272,7 +276,7 @@ func (m *Transaction) Reset()         { *m = Transaction{} }
 func (m *Transaction) String() string { return proto.CompactTextString(m) }
 func (*Transaction) ProtoMessage()    {}
 func (*Transaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4fbd1a0e1a50cfab, []int{2}
+	return fileDescriptor_transaction_5c166e662974086c, []int{2}
 }
 func (m *Transaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Transaction.Unmarshal(m, b)
@@ -316,7 +320,7 @@ func (m *TransactionAction) Reset()         { *m = TransactionAction{} }
 func (m *TransactionAction) String() string { return proto.CompactTextString(m) }
 func (*TransactionAction) ProtoMessage()    {}
 func (*TransactionAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4fbd1a0e1a50cfab, []int{3}
+	return fileDescriptor_transaction_5c166e662974086c, []int{3}
 }
 func (m *TransactionAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TransactionAction.Unmarshal(m, b)
@@ -375,7 +379,7 @@ func (m *ChaincodeActionPayload) Reset()         { *m = ChaincodeActionPayload{}
 func (m *ChaincodeActionPayload) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeActionPayload) ProtoMessage()    {}
 func (*ChaincodeActionPayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4fbd1a0e1a50cfab, []int{4}
+	return fileDescriptor_transaction_5c166e662974086c, []int{4}
 }
 func (m *ChaincodeActionPayload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeActionPayload.Unmarshal(m, b)
@@ -428,7 +432,7 @@ func (m *ChaincodeEndorsedAction) Reset()         { *m = ChaincodeEndorsedAction
 func (m *ChaincodeEndorsedAction) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeEndorsedAction) ProtoMessage()    {}
 func (*ChaincodeEndorsedAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4fbd1a0e1a50cfab, []int{5}
+	return fileDescriptor_transaction_5c166e662974086c, []int{5}
 }
 func (m *ChaincodeEndorsedAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeEndorsedAction.Unmarshal(m, b)
@@ -462,6 +466,374 @@ func (m *ChaincodeEndorsedAction) GetEndorsements() []*Endorsement {
 	return nil
 }
 
+// InvalidationReason describes why a transaction was marked invalid by the
+// committing peer. It is assembled from the validation artifacts the peer
+// retains for a configurable window after the transaction is committed.
+type InvalidationReason struct {
+	// The id of the transaction
+	TxId string `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	// The number of the block the transaction was committed in
+	BlockNumber uint64 `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	// The position of the transaction in the block
+	TxNumber uint64 `protobuf:"varint,3,opt,name=tx_number,json=txNumber,proto3" json:"tx_number,omitempty"`
+	// The validation code the transaction was marked with
+	ValidationCode TxValidationCode `protobuf:"varint,4,opt,name=validation_code,json=validationCode,proto3,enum=protos.TxValidationCode" json:"validation_code,omitempty"`
+	// A human readable description of the failure, if any
+	Details string `protobuf:"bytes,5,opt,name=details,proto3" json:"details,omitempty"`
+	// The reads that were invalidated by a committed write, for transactions
+	// marked MVCC_READ_CONFLICT or PHANTOM_READ_CONFLICT
+	ReadConflicts []*ReadConflict `protobuf:"bytes,6,rep,name=read_conflicts,json=readConflicts,proto3" json:"read_conflicts,omitempty"`
+	// The endorsement policy that was not satisfied, for transactions marked
+	// ENDORSEMENT_POLICY_FAILURE
+	EndorsementPolicyFailure *EndorsementPolicyFailure `protobuf:"bytes,7,opt,name=endorsement_policy_failure,json=endorsementPolicyFailure,proto3" json:"endorsement_policy_failure,omitempty"`
+	// The token inputs that had already been spent
+	SpentTokenInputs []*SpentTokenInput `protobuf:"bytes,8,rep,name=spent_token_inputs,json=spentTokenInputs,proto3" json:"spent_token_inputs,omitempty"`
+	// The time the peer recorded the validation artifacts
+	RecordedAt           *timestamp.Timestamp `protobuf:"bytes,9,opt,name=recorded_at,json=recordedAt,proto3" json:"recorded_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *InvalidationReason) Reset()         { *m = InvalidationReason{} }
+func (m *InvalidationReason) String() string { return proto.CompactTextString(m) }
+func (*InvalidationReason) ProtoMessage()    {}
+func (*InvalidationReason) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_5c166e662974086c, []int{6}
+}
+func (m *InvalidationReason) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InvalidationReason.Unmarshal(m, b)
+}
+func (m *InvalidationReason) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InvalidationReason.Marshal(b, m, deterministic)
+}
+func (dst *InvalidationReason) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InvalidationReason.Merge(dst, src)
+}
+func (m *InvalidationReason) XXX_Size() int {
+	return xxx_messageInfo_InvalidationReason.Size(m)
+}
+func (m *InvalidationReason) XXX_DiscardUnknown() {
+	xxx_messageInfo_InvalidationReason.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InvalidationReason proto.InternalMessageInfo
+
+func (m *InvalidationReason) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *InvalidationReason) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *InvalidationReason) GetTxNumber() uint64 {
+	if m != nil {
+		return m.TxNumber
+	}
+	return 0
+}
+
+func (m *InvalidationReason) GetValidationCode() TxValidationCode {
+	if m != nil {
+		return m.ValidationCode
+	}
+	return TxValidationCode_VALID
+}
+
+func (m *InvalidationReason) GetDetails() string {
+	if m != nil {
+		return m.Details
+	}
+	return ""
+}
+
+func (m *InvalidationReason) GetReadConflicts() []*ReadConflict {
+	if m != nil {
+		return m.ReadConflicts
+	}
+	return nil
+}
+
+func (m *InvalidationReason) GetEndorsementPolicyFailure() *EndorsementPolicyFailure {
+	if m != nil {
+		return m.EndorsementPolicyFailure
+	}
+	return nil
+}
+
+func (m *InvalidationReason) GetSpentTokenInputs() []*SpentTokenInput {
+	if m != nil {
+		return m.SpentTokenInputs
+	}
+	return nil
+}
+
+func (m *InvalidationReason) GetRecordedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.RecordedAt
+	}
+	return nil
+}
+
+// ReadConflict describes a read of a transaction whose version no longer
+// matched the committed state when the transaction was validated.
+type ReadConflict struct {
+	// The namespace (chaincode) of the key
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// The private data collection of the key, empty for public keys
+	Collection string `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+	// The key that was read, empty for private data keys
+	Key string `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	// The hash of the private data key that was read
+	KeyHash []byte `protobuf:"bytes,4,opt,name=key_hash,json=keyHash,proto3" json:"key_hash,omitempty"`
+	// The start key of the range query whose results changed, if the
+	// conflict is a phantom read
+	RangeStartKey string `protobuf:"bytes,5,opt,name=range_start_key,json=rangeStartKey,proto3" json:"range_start_key,omitempty"`
+	// The end key of the range query whose results changed, if the conflict
+	// is a phantom read
+	RangeEndKey string `protobuf:"bytes,6,opt,name=range_end_key,json=rangeEndKey,proto3" json:"range_end_key,omitempty"`
+	// The version of the key seen by the transaction when it was simulated
+	ReadVersion *kvrwset.Version `protobuf:"bytes,7,opt,name=read_version,json=readVersion,proto3" json:"read_version,omitempty"`
+	// The version of the key in the committed state, if any
+	CommittedVersion *kvrwset.Version `protobuf:"bytes,8,opt,name=committed_version,json=committedVersion,proto3" json:"committed_version,omitempty"`
+	// Whether the key was updated by a preceding valid transaction of the
+	// same block
+	UpdatedInBlock bool `protobuf:"varint,9,opt,name=updated_in_block,json=updatedInBlock,proto3" json:"updated_in_block,omitempty"`
+	// The id of the transaction that wrote the committed version, if known
+	WriterTxId           string   `protobuf:"bytes,10,opt,name=writer_tx_id,json=writerTxId,proto3" json:"writer_tx_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadConflict) Reset()         { *m = ReadConflict{} }
+func (m *ReadConflict) String() string { return proto.CompactTextString(m) }
+func (*ReadConflict) ProtoMessage()    {}
+func (*ReadConflict) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_5c166e662974086c, []int{7}
+}
+func (m *ReadConflict) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadConflict.Unmarshal(m, b)
+}
+func (m *ReadConflict) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadConflict.Marshal(b, m, deterministic)
+}
+func (dst *ReadConflict) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadConflict.Merge(dst, src)
+}
+func (m *ReadConflict) XXX_Size() int {
+	return xxx_messageInfo_ReadConflict.Size(m)
+}
+func (m *ReadConflict) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadConflict.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadConflict proto.InternalMessageInfo
+
+func (m *ReadConflict) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ReadConflict) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *ReadConflict) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *ReadConflict) GetKeyHash() []byte {
+	if m != nil {
+		return m.KeyHash
+	}
+	return nil
+}
+
+func (m *ReadConflict) GetRangeStartKey() string {
+	if m != nil {
+		return m.RangeStartKey
+	}
+	return ""
+}
+
+func (m *ReadConflict) GetRangeEndKey() string {
+	if m != nil {
+		return m.RangeEndKey
+	}
+	return ""
+}
+
+func (m *ReadConflict) GetReadVersion() *kvrwset.Version {
+	if m != nil {
+		return m.ReadVersion
+	}
+	return nil
+}
+
+func (m *ReadConflict) GetCommittedVersion() *kvrwset.Version {
+	if m != nil {
+		return m.CommittedVersion
+	}
+	return nil
+}
+
+func (m *ReadConflict) GetUpdatedInBlock() bool {
+	if m != nil {
+		return m.UpdatedInBlock
+	}
+	return false
+}
+
+func (m *ReadConflict) GetWriterTxId() string {
+	if m != nil {
+		return m.WriterTxId
+	}
+	return ""
+}
+
+// EndorsementPolicyFailure describes an endorsement policy that the
+// endorsements of a transaction did not satisfy.
+type EndorsementPolicyFailure struct {
+	// The namespace (chaincode) whose endorsement policy failed
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// A readable representation of the endorsement policy
+	Policy string `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
+	// The MSP ids of the endorsers of the transaction
+	Endorsers []string `protobuf:"bytes,3,rep,name=endorsers,proto3" json:"endorsers,omitempty"`
+	// The principals of the policy that none of the endorsers satisfy
+	UnsatisfiedPrincipals []string `protobuf:"bytes,4,rep,name=unsatisfied_principals,json=unsatisfiedPrincipals,proto3" json:"unsatisfied_principals,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
+}
+
+func (m *EndorsementPolicyFailure) Reset()         { *m = EndorsementPolicyFailure{} }
+func (m *EndorsementPolicyFailure) String() string { return proto.CompactTextString(m) }
+func (*EndorsementPolicyFailure) ProtoMessage()    {}
+func (*EndorsementPolicyFailure) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_5c166e662974086c, []int{8}
+}
+func (m *EndorsementPolicyFailure) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndorsementPolicyFailure.Unmarshal(m, b)
+}
+func (m *EndorsementPolicyFailure) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndorsementPolicyFailure.Marshal(b, m, deterministic)
+}
+func (dst *EndorsementPolicyFailure) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndorsementPolicyFailure.Merge(dst, src)
+}
+func (m *EndorsementPolicyFailure) XXX_Size() int {
+	return xxx_messageInfo_EndorsementPolicyFailure.Size(m)
+}
+func (m *EndorsementPolicyFailure) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndorsementPolicyFailure.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndorsementPolicyFailure proto.InternalMessageInfo
+
+func (m *EndorsementPolicyFailure) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *EndorsementPolicyFailure) GetPolicy() string {
+	if m != nil {
+		return m.Policy
+	}
+	return ""
+}
+
+func (m *EndorsementPolicyFailure) GetEndorsers() []string {
+	if m != nil {
+		return m.Endorsers
+	}
+	return nil
+}
+
+func (m *EndorsementPolicyFailure) GetUnsatisfiedPrincipals() []string {
+	if m != nil {
+		return m.UnsatisfiedPrincipals
+	}
+	return nil
+}
+
+// SpentTokenInput describes a token input of a transaction that had already
+// been spent.
+type SpentTokenInput struct {
+	// The id of the transaction that created the token
+	TxId string `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	// The index of the token in the outputs of that transaction
+	Index uint32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	// The id of the transaction that spent the token, if known
+	SpentByTxId          string   `protobuf:"bytes,3,opt,name=spent_by_tx_id,json=spentByTxId,proto3" json:"spent_by_tx_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SpentTokenInput) Reset()         { *m = SpentTokenInput{} }
+func (m *SpentTokenInput) String() string { return proto.CompactTextString(m) }
+func (*SpentTokenInput) ProtoMessage()    {}
+func (*SpentTokenInput) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_5c166e662974086c, []int{9}
+}
+func (m *SpentTokenInput) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SpentTokenInput.Unmarshal(m, b)
+}
+func (m *SpentTokenInput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SpentTokenInput.Marshal(b, m, deterministic)
+}
+func (dst *SpentTokenInput) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SpentTokenInput.Merge(dst, src)
+}
+func (m *SpentTokenInput) XXX_Size() int {
+	return xxx_messageInfo_SpentTokenInput.Size(m)
+}
+func (m *SpentTokenInput) XXX_DiscardUnknown() {
+	xxx_messageInfo_SpentTokenInput.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SpentTokenInput proto.InternalMessageInfo
+
+func (m *SpentTokenInput) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *SpentTokenInput) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *SpentTokenInput) GetSpentByTxId() string {
+	if m != nil {
+		return m.SpentByTxId
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*SignedTransaction)(nil), "protos.SignedTransaction")
 	proto.RegisterType((*ProcessedTransaction)(nil), "protos.ProcessedTransaction")
@@ -469,67 +841,107 @@ func init() {
 	proto.RegisterType((*TransactionAction)(nil), "protos.TransactionAction")
 	proto.RegisterType((*ChaincodeActionPayload)(nil), "protos.ChaincodeActionPayload")
 	proto.RegisterType((*ChaincodeEndorsedAction)(nil), "protos.ChaincodeEndorsedAction")
+	proto.RegisterType((*InvalidationReason)(nil), "protos.InvalidationReason")
+	proto.RegisterType((*ReadConflict)(nil), "protos.ReadConflict")
+	proto.RegisterType((*EndorsementPolicyFailure)(nil), "protos.EndorsementPolicyFailure")
+	proto.RegisterType((*SpentTokenInput)(nil), "protos.SpentTokenInput")
 	proto.RegisterEnum("protos.TxValidationCode", TxValidationCode_name, TxValidationCode_value)
 	proto.RegisterEnum("protos.MetaDataKeys", MetaDataKeys_name, MetaDataKeys_value)
 }
 
-func init() { proto.RegisterFile("peer/transaction.proto", fileDescriptor_transaction_4fbd1a0e1a50cfab) }
-
-var fileDescriptor_transaction_4fbd1a0e1a50cfab = []byte{
-	// 877 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x55, 0x41, 0x6f, 0xe2, 0x46,
-	0x14, 0x5e, 0xb2, 0x4d, 0xd2, 0x0c, 0x24, 0x19, 0x06, 0x42, 0x08, 0x8a, 0xba, 0x2b, 0x0e, 0x55,
-	0xba, 0x95, 0x40, 0xca, 0x1e, 0x2a, 0x55, 0xbd, 0x0c, 0xf6, 0x24, 0x58, 0x6b, 0x66, 0xac, 0xf1,
-	0x40, 0x48, 0x0f, 0x1d, 0x19, 0x98, 0x25, 0xa8, 0x60, 0x23, 0xdb, 0x59, 0x35, 0xd7, 0xfe, 0x80,
-	0xf6, 0xd2, 0xdf, 0xdb, 0x56, 0xe3, 0xb1, 0x81, 0x24, 0xed, 0x05, 0x33, 0xdf, 0xfb, 0xde, 0x7b,
-	0xdf, 0xf7, 0x9e, 0x35, 0x06, 0x8d, 0xb5, 0x52, 0x71, 0x37, 0x8d, 0x83, 0x30, 0x09, 0xa6, 0xe9,
-	0x22, 0x0a, 0x3b, 0xeb, 0x38, 0x4a, 0x23, 0x74, 0x90, 0x3d, 0x92, 0xd6, 0xbb, 0x79, 0x14, 0xcd,
-	0x97, 0xaa, 0x9b, 0x1d, 0x27, 0x8f, 0x9f, 0xbb, 0xe9, 0x62, 0xa5, 0x92, 0x34, 0x58, 0xad, 0x0d,
-	0xb1, 0x75, 0x99, 0x15, 0x58, 0xc7, 0xd1, 0x3a, 0x4a, 0x82, 0xa5, 0x8c, 0x55, 0xb2, 0x8e, 0xc2,
-	0x44, 0xe5, 0xd1, 0xda, 0x34, 0x5a, 0xad, 0xa2, 0xb0, 0x6b, 0x1e, 0x06, 0x6c, 0xff, 0x02, 0xaa,
-	0xfe, 0x62, 0x1e, 0xaa, 0x99, 0xd8, 0xb6, 0x45, 0xdf, 0x83, 0xea, 0x8e, 0x0a, 0x39, 0x79, 0x4a,
-	0x55, 0xd2, 0x2c, 0xbd, 0x2f, 0x5d, 0x55, 0x38, 0xdc, 0x09, 0xf4, 0x34, 0x8e, 0x2e, 0xc1, 0x51,
-	0xb2, 0x98, 0x87, 0x41, 0xfa, 0x18, 0xab, 0xe6, 0x5e, 0x46, 0xda, 0x02, 0xed, 0xdf, 0x4b, 0xa0,
-	0xee, 0xc5, 0xd1, 0x54, 0x25, 0xc9, 0xf3, 0x1e, 0x3d, 0x50, 0xdb, 0x29, 0x45, 0xc2, 0x2f, 0x6a,
-	0x19, 0xad, 0x55, 0xd6, 0xa5, 0x7c, 0x0d, 0x3b, 0xb9, 0xc8, 0x02, 0xe7, 0xff, 0x45, 0x46, 0xdf,
-	0x82, 0x93, 0x2f, 0xc1, 0x72, 0x31, 0x0b, 0x34, 0x6a, 0x45, 0x33, 0xd3, 0x7f, 0x9f, 0xbf, 0x40,
-	0xdb, 0x3d, 0x50, 0xde, 0x6d, 0xfd, 0x11, 0x1c, 0x9a, 0x7f, 0xda, 0xd4, 0xdb, 0xab, 0xf2, 0xf5,
-	0x85, 0x19, 0x46, 0xd2, 0xd9, 0x61, 0xe1, 0xec, 0x97, 0x17, 0xcc, 0x36, 0x01, 0xd5, 0x57, 0x51,
-	0xd4, 0x00, 0x07, 0x0f, 0x2a, 0x98, 0xa9, 0x38, 0x9f, 0x4e, 0x7e, 0x42, 0x4d, 0x70, 0xb8, 0x0e,
-	0x9e, 0x96, 0x51, 0x30, 0xcb, 0x27, 0x52, 0x1c, 0xdb, 0x7f, 0x96, 0x40, 0xc3, 0x7a, 0x08, 0x16,
-	0xe1, 0x34, 0x9a, 0x29, 0x53, 0xc5, 0x33, 0x21, 0xf4, 0x13, 0x68, 0x4d, 0x8b, 0x88, 0xdc, 0x2c,
-	0xb1, 0xa8, 0x63, 0x1a, 0x34, 0x37, 0x0c, 0x2f, 0x27, 0x14, 0xd9, 0x3f, 0x80, 0x03, 0x23, 0x2d,
-	0xeb, 0x58, 0xbe, 0x7e, 0x57, 0x78, 0xda, 0x74, 0x23, 0xe1, 0x2c, 0x8a, 0x13, 0x35, 0xcb, 0x9d,
-	0xe5, 0xf4, 0xf6, 0x1f, 0x25, 0x70, 0xfe, 0x3f, 0x1c, 0xf4, 0x23, 0xb8, 0x78, 0xf5, 0x36, 0xbd,
-	0x50, 0x74, 0x5e, 0x10, 0x78, 0x1e, 0xdf, 0x0a, 0xaa, 0x28, 0x53, 0x6d, 0xa5, 0xc2, 0x34, 0x69,
-	0xee, 0x65, 0xa3, 0xae, 0x15, 0xb2, 0xc8, 0x36, 0xc6, 0x9f, 0x11, 0x3f, 0xfc, 0xb5, 0x0f, 0xa0,
-	0xf8, 0x6d, 0xf4, 0x6c, 0x85, 0xe8, 0x08, 0xec, 0x8f, 0xb0, 0xeb, 0xd8, 0xf0, 0x0d, 0x82, 0xa0,
-	0x42, 0x1d, 0x57, 0x12, 0x3a, 0x22, 0x2e, 0xf3, 0x08, 0x2c, 0xa1, 0x53, 0x50, 0xee, 0x61, 0x5b,
-	0x7a, 0xf8, 0xde, 0x65, 0xd8, 0x86, 0x7b, 0xe8, 0x0c, 0x54, 0x35, 0x60, 0xb1, 0xc1, 0x80, 0x51,
-	0xd9, 0x27, 0xd8, 0x26, 0x1c, 0xbe, 0x45, 0x17, 0xe0, 0x2c, 0x83, 0x39, 0xc1, 0x82, 0x71, 0xe9,
-	0x3b, 0xb7, 0x14, 0x8b, 0x21, 0x27, 0xf0, 0x2b, 0xf4, 0x1e, 0x5c, 0x3a, 0x34, 0xeb, 0x20, 0x09,
-	0xb5, 0x19, 0xf7, 0x09, 0x97, 0x82, 0x63, 0xea, 0x63, 0x4b, 0x38, 0x8c, 0xc2, 0x7d, 0xf4, 0x0d,
-	0x68, 0x15, 0x0c, 0x8b, 0xd1, 0x1b, 0xe7, 0xf6, 0x59, 0xfc, 0x00, 0xb5, 0x40, 0x63, 0x48, 0xfd,
-	0xa1, 0xe7, 0x31, 0x2e, 0x88, 0x2d, 0xc5, 0x78, 0xa3, 0xe7, 0xb0, 0xd0, 0xe3, 0x71, 0xe6, 0x31,
-	0x1f, 0xbb, 0x52, 0x8c, 0x1d, 0x1b, 0x7e, 0x8d, 0x10, 0x38, 0xb1, 0x87, 0x9e, 0xeb, 0x58, 0x58,
-	0x10, 0x83, 0x1d, 0xe9, 0x36, 0xb9, 0x80, 0x01, 0xa1, 0x42, 0x7a, 0xcc, 0x75, 0xac, 0x7b, 0x79,
-	0x83, 0x1d, 0x57, 0x0b, 0x05, 0xa8, 0x01, 0xd0, 0x60, 0x64, 0x59, 0x92, 0x13, 0x6c, 0x84, 0xb8,
-	0x8e, 0x25, 0x60, 0x59, 0x7b, 0xf3, 0xfa, 0x98, 0x0a, 0x36, 0x78, 0x11, 0xaa, 0xa0, 0x1a, 0x38,
-	0x1d, 0xd2, 0x4f, 0x94, 0xdd, 0x51, 0xad, 0x4a, 0xdc, 0x7b, 0x04, 0x1e, 0x6b, 0xb9, 0x02, 0xf3,
-	0x5b, 0x22, 0xa4, 0xd5, 0xc7, 0x0e, 0x95, 0x94, 0x09, 0x79, 0xc3, 0x86, 0xd4, 0x86, 0x27, 0xa8,
-	0x0e, 0xe0, 0x00, 0x73, 0xbf, 0x9f, 0x29, 0x95, 0x84, 0x73, 0xc6, 0xe1, 0x69, 0x31, 0x77, 0x31,
-	0xce, 0x2d, 0x43, 0x6d, 0x8b, 0x8c, 0x3d, 0x87, 0x13, 0xdb, 0x14, 0xb1, 0x98, 0x4d, 0x60, 0x55,
-	0x5b, 0xd8, 0x1c, 0xe5, 0x88, 0x70, 0xdf, 0x61, 0x74, 0xab, 0x07, 0xa1, 0x26, 0xa8, 0xeb, 0x69,
-	0x98, 0xb5, 0x48, 0x32, 0x16, 0x84, 0x6a, 0x0a, 0xac, 0x69, 0x73, 0xd9, 0x82, 0xfa, 0x98, 0x52,
-	0xe2, 0x16, 0x8b, 0xab, 0x17, 0x19, 0x9c, 0xf8, 0x1e, 0xa3, 0x3e, 0xd9, 0x4c, 0xf6, 0x0c, 0x1d,
-	0x83, 0xa3, 0x2c, 0x72, 0xe7, 0x13, 0x01, 0x1b, 0x5a, 0xb9, 0xe3, 0xba, 0xe4, 0x16, 0xbb, 0xf2,
-	0x8e, 0x3b, 0x82, 0x68, 0xf4, 0x3c, 0x43, 0xf3, 0xd5, 0x6d, 0xd0, 0x26, 0x42, 0xe0, 0x58, 0x9b,
-	0xce, 0x70, 0x2c, 0x88, 0x0d, 0xff, 0x2e, 0xa1, 0x0b, 0x50, 0x2f, 0x98, 0x4c, 0xf4, 0x09, 0xd7,
-	0xb3, 0xf4, 0x19, 0x85, 0xff, 0x94, 0x3e, 0x5c, 0x81, 0xca, 0x40, 0xa5, 0x81, 0x1d, 0xa4, 0xc1,
-	0x27, 0xf5, 0x94, 0x68, 0x4d, 0x79, 0xaa, 0xb6, 0xe7, 0x61, 0x8e, 0x07, 0x44, 0x10, 0x0e, 0xdf,
-	0xf4, 0xa6, 0xa0, 0x1d, 0xc5, 0xf3, 0xce, 0xc3, 0xd3, 0x5a, 0xc5, 0x4b, 0x35, 0x9b, 0xab, 0xb8,
-	0xf3, 0x39, 0x98, 0xc4, 0x8b, 0x69, 0xf1, 0xee, 0xeb, 0x6b, 0xba, 0x87, 0x76, 0xae, 0x13, 0x2f,
-	0x98, 0xfe, 0x1a, 0xcc, 0xd5, 0xcf, 0xdf, 0xcd, 0x17, 0xe9, 0xc3, 0xe3, 0x44, 0xdf, 0x7e, 0xdd,
-	0x9d, 0xf4, 0xae, 0x49, 0x37, 0x17, 0x7f, 0xd2, 0xd5, 0xe9, 0x13, 0xf3, 0x51, 0xf8, 0xf8, 0x6f,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0xba, 0xd3, 0xb2, 0x32, 0x35, 0x06, 0x00, 0x00,
+func init() {
+	proto.RegisterFile("peer/transaction.proto", fileDescriptor_transaction_5c166e662974086c)
+}
+
+var fileDescriptor_transaction_5c166e662974086c = []byte{
+	// 1416 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x56, 0x5d, 0x4f, 0xe3, 0xcc,
+	0x15, 0x7e, 0xb3, 0xe1, 0x2b, 0x27, 0x01, 0xcc, 0x00, 0xc1, 0xd0, 0xd5, 0xfb, 0xd2, 0xac, 0xb4,
+	0xa2, 0x5b, 0x29, 0x91, 0x58, 0x55, 0x95, 0xfa, 0x71, 0xe1, 0x24, 0x03, 0x58, 0x9b, 0xd8, 0xd6,
+	0xc4, 0xb0, 0x6c, 0x2f, 0x76, 0x34, 0xb1, 0x87, 0x60, 0x11, 0x6c, 0xcb, 0x63, 0x58, 0x72, 0x5b,
+	0xa9, 0xb7, 0xed, 0xaf, 0xe8, 0x65, 0x7f, 0x57, 0xff, 0x45, 0x5b, 0xcd, 0x8c, 0x9d, 0x04, 0x76,
+	0x51, 0x6f, 0x92, 0xcc, 0x73, 0x9e, 0x73, 0xce, 0x73, 0x3e, 0x32, 0x1a, 0x68, 0xa6, 0x9c, 0x67,
+	0x9d, 0x3c, 0x63, 0xb1, 0x60, 0x41, 0x1e, 0x25, 0x71, 0x3b, 0xcd, 0x92, 0x3c, 0x41, 0x6b, 0xea,
+	0x4b, 0x1c, 0xfd, 0x32, 0x49, 0x92, 0xc9, 0x94, 0x77, 0xd4, 0x71, 0xfc, 0x70, 0xd3, 0xc9, 0xa3,
+	0x7b, 0x2e, 0x72, 0x76, 0x9f, 0x6a, 0xe2, 0xd1, 0x5b, 0x15, 0x20, 0xcd, 0x92, 0x34, 0x11, 0x6c,
+	0x4a, 0x33, 0x2e, 0xd2, 0x24, 0x16, 0xbc, 0xb0, 0xee, 0x06, 0xc9, 0xfd, 0x7d, 0x12, 0x77, 0xf4,
+	0x57, 0x01, 0xbe, 0x9b, 0xf2, 0x70, 0xc2, 0xb3, 0x4e, 0xf6, 0x4d, 0xf0, 0xbc, 0x73, 0xf7, 0x58,
+	0x7e, 0x53, 0xf5, 0x43, 0x93, 0x5a, 0x5f, 0x61, 0x67, 0x14, 0x4d, 0x62, 0x1e, 0xfa, 0x0b, 0x6d,
+	0xe8, 0xb7, 0xb0, 0xb3, 0x24, 0x95, 0x8e, 0x67, 0x39, 0x17, 0x66, 0xe5, 0xb8, 0x72, 0xd2, 0x20,
+	0xc6, 0x92, 0xa1, 0x2b, 0x71, 0xf4, 0x16, 0x6a, 0x22, 0x9a, 0xc4, 0x2c, 0x7f, 0xc8, 0xb8, 0xf9,
+	0x46, 0x91, 0x16, 0x40, 0xeb, 0xaf, 0x15, 0xd8, 0xf3, 0xb2, 0x24, 0xe0, 0x42, 0x3c, 0xcf, 0xd1,
+	0x85, 0xdd, 0xa5, 0x50, 0x38, 0x7e, 0xe4, 0xd3, 0x24, 0xe5, 0x2a, 0x4b, 0xfd, 0xd4, 0x68, 0x17,
+	0x95, 0x94, 0x38, 0xf9, 0x11, 0x19, 0xbd, 0x87, 0xad, 0x47, 0x36, 0x8d, 0x42, 0x26, 0xd1, 0x5e,
+	0x12, 0xea, 0xfc, 0xab, 0xe4, 0x05, 0xda, 0xea, 0x42, 0x7d, 0x39, 0xf5, 0x47, 0x58, 0xd7, 0xbf,
+	0x64, 0x51, 0xd5, 0x93, 0xfa, 0xe9, 0xa1, 0x6e, 0x86, 0x68, 0x2f, 0xb1, 0x2c, 0xf5, 0x49, 0x4a,
+	0x66, 0x0b, 0xc3, 0xce, 0x77, 0x56, 0xd4, 0x84, 0xb5, 0x5b, 0xce, 0x42, 0x9e, 0x15, 0xdd, 0x29,
+	0x4e, 0xc8, 0x84, 0xf5, 0x94, 0xcd, 0xa6, 0x09, 0x0b, 0x8b, 0x8e, 0x94, 0xc7, 0xd6, 0x3f, 0x2a,
+	0xd0, 0xec, 0xdd, 0xb2, 0x28, 0x0e, 0x92, 0x90, 0xeb, 0x28, 0x9e, 0x36, 0xa1, 0x3f, 0xc1, 0x51,
+	0x50, 0x5a, 0xe8, 0x7c, 0xd2, 0x65, 0x1c, 0x9d, 0xc0, 0x9c, 0x33, 0xbc, 0x82, 0x50, 0x7a, 0xff,
+	0x1e, 0xd6, 0xb4, 0x34, 0x95, 0xb1, 0x7e, 0xfa, 0x4b, 0x59, 0xd3, 0x3c, 0x1b, 0x8e, 0xc3, 0x24,
+	0x13, 0x3c, 0x2c, 0x2a, 0x2b, 0xe8, 0xad, 0xbf, 0x57, 0xe0, 0xe0, 0x15, 0x0e, 0xfa, 0x03, 0x1c,
+	0x7e, 0xb7, 0x72, 0x2f, 0x14, 0x1d, 0x94, 0x04, 0x52, 0xd8, 0x17, 0x82, 0x1a, 0x5c, 0x47, 0xbb,
+	0xe7, 0x71, 0x2e, 0xcc, 0x37, 0xaa, 0xd5, 0xbb, 0xa5, 0x2c, 0xbc, 0xb0, 0x91, 0x67, 0xc4, 0xd6,
+	0xbf, 0xab, 0x80, 0xec, 0x78, 0x31, 0x42, 0xc2, 0x99, 0x48, 0x62, 0xb4, 0x0b, 0xab, 0xf9, 0x13,
+	0x8d, 0x74, 0xde, 0x1a, 0x59, 0xc9, 0x9f, 0xec, 0x10, 0xfd, 0x1a, 0x1a, 0xe3, 0x69, 0x12, 0xdc,
+	0xd1, 0xf8, 0xe1, 0x7e, 0xcc, 0x33, 0x55, 0xfb, 0x0a, 0xa9, 0x2b, 0xcc, 0x51, 0x10, 0xfa, 0x15,
+	0xd4, 0xf2, 0xa7, 0xd2, 0x5e, 0x55, 0xf6, 0x8d, 0xfc, 0xa9, 0x30, 0x5a, 0xb0, 0xbd, 0x48, 0x44,
+	0x65, 0x07, 0xcc, 0x95, 0xe3, 0xca, 0xc9, 0xd6, 0xa9, 0x39, 0x5f, 0x89, 0xa7, 0xab, 0x67, 0xcb,
+	0xf4, 0x72, 0xb9, 0xe4, 0xac, 0x43, 0x9e, 0xb3, 0x68, 0x2a, 0xcc, 0x55, 0xa5, 0xac, 0x3c, 0xa2,
+	0x3f, 0xc2, 0x56, 0xc6, 0x59, 0x48, 0x83, 0x24, 0xbe, 0x99, 0x46, 0x41, 0x2e, 0xcc, 0x35, 0xd5,
+	0x83, 0xbd, 0x32, 0x36, 0xe1, 0x2c, 0xec, 0x15, 0x46, 0xb2, 0x99, 0x2d, 0x9d, 0x04, 0xfa, 0x0a,
+	0x47, 0x4b, 0x5d, 0xa1, 0x69, 0x32, 0x8d, 0x82, 0x19, 0xbd, 0x61, 0xd1, 0x54, 0xfe, 0xcf, 0xd6,
+	0xd5, 0x8c, 0x8f, 0x7f, 0xd0, 0x4c, 0x4f, 0x11, 0xcf, 0x34, 0x8f, 0x98, 0xfc, 0x15, 0x0b, 0xc2,
+	0x80, 0x44, 0x2a, 0x23, 0xe7, 0xc9, 0x1d, 0x8f, 0x69, 0x14, 0xa7, 0x0f, 0xb9, 0x30, 0x37, 0x94,
+	0xc0, 0x83, 0x32, 0xee, 0x48, 0x32, 0x7c, 0x49, 0xb0, 0xa5, 0x9d, 0x18, 0xe2, 0x39, 0x20, 0x6b,
+	0xac, 0x67, 0x3c, 0x48, 0xb2, 0x90, 0x87, 0x94, 0xe5, 0x66, 0x4d, 0xe9, 0x3a, 0x6a, 0xeb, 0xeb,
+	0xac, 0x5d, 0x5e, 0x67, 0x6d, 0xbf, 0xbc, 0xce, 0x08, 0x94, 0x74, 0x2b, 0x6f, 0xfd, 0xad, 0x0a,
+	0x8d, 0xe5, 0x1e, 0xc8, 0xbb, 0x24, 0x66, 0xf7, 0x5c, 0xa4, 0x2c, 0xe0, 0xc5, 0x9c, 0x17, 0x00,
+	0xfa, 0x19, 0x20, 0x48, 0xa6, 0x53, 0xbe, 0x58, 0xf3, 0x1a, 0x59, 0x42, 0x90, 0x01, 0xd5, 0x3b,
+	0x3e, 0x53, 0x33, 0xae, 0x11, 0xf9, 0x13, 0x1d, 0xc2, 0xc6, 0x1d, 0x9f, 0xd1, 0x5b, 0x26, 0x6e,
+	0xd5, 0x5c, 0x1b, 0x64, 0xfd, 0x8e, 0xcf, 0x2e, 0x98, 0xb8, 0x45, 0xef, 0x61, 0x3b, 0x63, 0xf1,
+	0x84, 0x53, 0x91, 0xb3, 0x2c, 0xa7, 0xd2, 0x51, 0x8f, 0x6f, 0x53, 0xc1, 0x23, 0x89, 0x7e, 0xe2,
+	0x33, 0xd4, 0x02, 0x0d, 0x50, 0x1e, 0x87, 0x8a, 0xb5, 0xa6, 0x58, 0x75, 0x05, 0xe2, 0x38, 0x94,
+	0x9c, 0x8f, 0xd0, 0x50, 0x83, 0x7e, 0xe4, 0x99, 0x90, 0xd2, 0xd6, 0x8b, 0x4b, 0xac, 0xb8, 0x73,
+	0xdb, 0x57, 0x1a, 0x27, 0x75, 0xc9, 0x2a, 0x0e, 0xe8, 0xcf, 0xb0, 0x23, 0x2f, 0xb9, 0x28, 0xcf,
+	0xf9, 0xc2, 0x73, 0xe3, 0x15, 0x4f, 0x63, 0x4e, 0x2d, 0xdd, 0x4f, 0xc0, 0x78, 0x48, 0x43, 0x26,
+	0x9d, 0xa3, 0x98, 0xaa, 0x85, 0x57, 0xdd, 0xdf, 0x20, 0x5b, 0x05, 0x6e, 0xc7, 0x5d, 0x89, 0xa2,
+	0x63, 0x68, 0x7c, 0xcb, 0xa2, 0x9c, 0x67, 0x54, 0xff, 0x7f, 0x40, 0x37, 0x4e, 0x63, 0xfe, 0x93,
+	0x1d, 0xb6, 0xfe, 0x59, 0x01, 0xf3, 0xb5, 0x15, 0xfa, 0x3f, 0x33, 0x69, 0xc2, 0x9a, 0x5e, 0xcd,
+	0x62, 0x1e, 0xc5, 0x49, 0x7a, 0x15, 0xab, 0x97, 0x09, 0xb3, 0x7a, 0x5c, 0x95, 0x5e, 0x73, 0x00,
+	0xfd, 0x0e, 0x9a, 0x0f, 0xb1, 0x60, 0x79, 0x24, 0x6e, 0x22, 0x1e, 0xd2, 0x34, 0x8b, 0xe2, 0x20,
+	0x4a, 0xd9, 0x54, 0x98, 0x2b, 0x8a, 0xba, 0xbf, 0x64, 0xf5, 0xe6, 0xc6, 0x16, 0x83, 0xed, 0x17,
+	0x1b, 0xf9, 0xe3, 0x5b, 0x61, 0x0f, 0x56, 0xa3, 0x38, 0xe4, 0x4f, 0x4a, 0xd3, 0x26, 0xd1, 0x07,
+	0xf4, 0x0e, 0xb6, 0xf4, 0xc6, 0x8f, 0x67, 0x45, 0x27, 0xf4, 0xa6, 0xd4, 0x15, 0xda, 0x9d, 0xc9,
+	0x56, 0x7c, 0xf8, 0xd7, 0x2a, 0x18, 0x2f, 0xff, 0xf2, 0xa8, 0x06, 0xab, 0x57, 0xd6, 0xc0, 0xee,
+	0x1b, 0x3f, 0x21, 0x03, 0x1a, 0x8e, 0x3d, 0xa0, 0xd8, 0xb9, 0xc2, 0x03, 0xd7, 0xc3, 0x46, 0x05,
+	0x6d, 0x43, 0xbd, 0x6b, 0xf5, 0xa9, 0x67, 0x7d, 0x19, 0xb8, 0x56, 0xdf, 0x78, 0x83, 0xf6, 0x61,
+	0x47, 0x02, 0x3d, 0x77, 0x38, 0x74, 0x1d, 0x7a, 0x81, 0xad, 0x3e, 0x26, 0x46, 0x15, 0x1d, 0xc2,
+	0xbe, 0x82, 0x09, 0xb6, 0x7c, 0x97, 0xd0, 0x91, 0x7d, 0xee, 0x58, 0xfe, 0x25, 0xc1, 0xc6, 0x0a,
+	0x3a, 0x86, 0xb7, 0xb6, 0xa3, 0x32, 0x50, 0xec, 0xf4, 0x5d, 0x32, 0xc2, 0x84, 0xfa, 0xc4, 0x72,
+	0x46, 0x56, 0xcf, 0xb7, 0x5d, 0xc7, 0x58, 0x45, 0x3f, 0xc3, 0x51, 0xc9, 0xe8, 0xb9, 0xce, 0x99,
+	0x7d, 0xfe, 0xcc, 0xbe, 0x86, 0x8e, 0xa0, 0x79, 0xe9, 0x8c, 0x2e, 0x3d, 0xcf, 0x25, 0x3e, 0xee,
+	0x53, 0xff, 0x7a, 0xae, 0x67, 0xbd, 0xd4, 0xe3, 0x11, 0xd7, 0x73, 0x47, 0xd6, 0x80, 0xfa, 0xd7,
+	0x76, 0xdf, 0xd8, 0x40, 0x08, 0xb6, 0xfa, 0x97, 0xde, 0xc0, 0xee, 0x59, 0x3e, 0xd6, 0x58, 0x4d,
+	0xa6, 0x29, 0x04, 0x0c, 0xb1, 0xe3, 0x53, 0xcf, 0x1d, 0xd8, 0xbd, 0x2f, 0xf4, 0xcc, 0xb2, 0x07,
+	0x52, 0x28, 0xa0, 0x26, 0xa0, 0xe1, 0x55, 0xaf, 0x47, 0x09, 0xb6, 0xb4, 0x90, 0x81, 0xdd, 0xf3,
+	0x8d, 0xba, 0xac, 0xcd, 0xbb, 0xb0, 0x1c, 0xdf, 0x1d, 0xbe, 0x30, 0x35, 0xd0, 0x2e, 0x6c, 0x5f,
+	0x3a, 0x9f, 0x1c, 0xf7, 0xb3, 0x23, 0x55, 0xf9, 0x5f, 0x3c, 0x6c, 0x6c, 0x4a, 0xb9, 0xbe, 0x45,
+	0xce, 0xb1, 0x4f, 0x7b, 0x17, 0x96, 0xed, 0x50, 0xc7, 0xf5, 0xe9, 0x99, 0x7b, 0xe9, 0xf4, 0x8d,
+	0x2d, 0xb4, 0x07, 0xc6, 0xd0, 0x22, 0xa3, 0x0b, 0xa5, 0x94, 0x62, 0x42, 0x5c, 0x62, 0x6c, 0x97,
+	0x7d, 0xf7, 0xaf, 0x8b, 0x92, 0x0d, 0x59, 0x16, 0xbe, 0xf6, 0x6c, 0x82, 0xfb, 0x3a, 0x48, 0xcf,
+	0xed, 0x63, 0x63, 0x47, 0x96, 0x30, 0x3f, 0xd2, 0x2b, 0x4c, 0x46, 0xb6, 0xeb, 0x2c, 0xf4, 0x20,
+	0x64, 0xc2, 0x9e, 0xec, 0x86, 0x1e, 0x0b, 0xc5, 0xd7, 0x3e, 0x76, 0x24, 0xc5, 0xd8, 0x95, 0xc5,
+	0xa9, 0x01, 0x5d, 0x58, 0x8e, 0x83, 0x07, 0xe5, 0xe0, 0xf6, 0x4a, 0x0f, 0x82, 0x47, 0x9e, 0xeb,
+	0x8c, 0xf0, 0xbc, 0xb3, 0xfb, 0x68, 0x13, 0x6a, 0xca, 0xf2, 0x79, 0x84, 0x7d, 0xa3, 0x29, 0x95,
+	0xdb, 0x83, 0x01, 0x3e, 0xb7, 0x06, 0xf4, 0x33, 0xb1, 0x7d, 0x2c, 0xd1, 0x03, 0x85, 0x16, 0xa3,
+	0x9b, 0xa3, 0x26, 0x3a, 0x80, 0xdd, 0x52, 0xfd, 0xf2, 0x24, 0x0f, 0x11, 0x82, 0x4d, 0xd9, 0x0d,
+	0xe5, 0x60, 0xf9, 0xb8, 0x6f, 0xfc, 0xa7, 0x82, 0x0e, 0x61, 0xaf, 0x0c, 0xe1, 0xfa, 0x17, 0x98,
+	0xc8, 0x26, 0x8f, 0x5c, 0xc7, 0xf8, 0x6f, 0xe5, 0xc3, 0x09, 0x34, 0x86, 0x3c, 0x67, 0x7d, 0x96,
+	0xb3, 0x4f, 0x7c, 0x26, 0xa4, 0xd8, 0xc2, 0x55, 0xd6, 0xed, 0x59, 0xc4, 0x1a, 0x62, 0x1f, 0x13,
+	0xe3, 0xa7, 0x6e, 0x00, 0xad, 0x24, 0x9b, 0xb4, 0x6f, 0x67, 0x29, 0xcf, 0xf4, 0xcb, 0xb0, 0x7d,
+	0xc3, 0xc6, 0x59, 0x14, 0x94, 0x97, 0xbd, 0x7c, 0x61, 0x76, 0xd1, 0xd2, 0x23, 0xc7, 0x63, 0xc1,
+	0x1d, 0x9b, 0xf0, 0xbf, 0xfc, 0x66, 0x12, 0xe5, 0xb7, 0x0f, 0x63, 0xf9, 0x26, 0xeb, 0x2c, 0xb9,
+	0x77, 0xb4, 0xbb, 0x7e, 0xb3, 0x8a, 0x8e, 0x74, 0x1f, 0xeb, 0xf7, 0xec, 0xc7, 0xff, 0x0d, 0x00,
+	0x94, 0x89, 0x87, 0x48, 0xf0, 0x0a, 0x00, 0x00,
 }
@@ -0,0 +1,260 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+// BytesEncoding is the encoding of the bytes fields of token messages in JSON.
+type BytesEncoding string
+
+const (
+	// Base64Bytes encodes bytes fields in base64, as the proto3 JSON mapping
+	// prescribes.
+	Base64Bytes BytesEncoding = "base64"
+	// HexBytes encodes bytes fields in hex, which is easier to match against
+	// transaction IDs and hashes when debugging.
+	HexBytes BytesEncoding = "hex"
+)
+
+// ParseBytesEncoding returns the BytesEncoding with the passed name. The empty
+// name stands for Base64Bytes.
+func ParseBytesEncoding(name string) (BytesEncoding, error) {
+	switch BytesEncoding(name) {
+	case "", Base64Bytes:
+		return Base64Bytes, nil
+	case HexBytes:
+		return HexBytes, nil
+	default:
+		return "", errors.Errorf("unknown bytes encoding '%s'", name)
+	}
+}
+
+// JSONCodec converts token messages to and from canonical JSON: the proto3
+// JSON mapping, with the fields of objects sorted by name so that equal
+// messages have equal encodings.
+type JSONCodec struct {
+	// Bytes is the encoding of bytes fields, base64 when empty
+	Bytes BytesEncoding
+	// Indent indents nested objects and arrays when not empty
+	Indent string
+}
+
+// MarshalJSON returns the canonical JSON of a token message, with base64 bytes.
+func MarshalJSON(msg proto.Message) ([]byte, error) {
+	return (&JSONCodec{}).Marshal(msg)
+}
+
+// UnmarshalJSON parses the canonical JSON of a token message, with base64
+// bytes, into msg.
+func UnmarshalJSON(data []byte, msg proto.Message) error {
+	return (&JSONCodec{}).Unmarshal(data, msg)
+}
+
+// Marshal returns the JSON of msg.
+func (c *JSONCodec) Marshal(msg proto.Message) ([]byte, error) {
+	encoding, err := ParseBytesEncoding(string(c.Bytes))
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := (&jsonpb.Marshaler{}).MarshalToString(msg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed marshaling %s to JSON", proto.MessageName(msg))
+	}
+	obj, err := decodeObject([]byte(raw))
+	if err != nil {
+		return nil, err
+	}
+	if encoding == HexBytes {
+		err = convertBytes(reflect.TypeOf(msg).Elem(), obj, base64ToHex)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("failed encoding bytes of %s", proto.MessageName(msg)))
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", c.Indent)
+	if err := encoder.Encode(obj); err != nil {
+		return nil, errors.Wrapf(err, "failed marshaling %s to JSON", proto.MessageName(msg))
+	}
+	// the encoder terminates values with a newline
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// Unmarshal parses the JSON of a message into msg. Unknown fields are
+// rejected.
+func (c *JSONCodec) Unmarshal(data []byte, msg proto.Message) error {
+	encoding, err := ParseBytesEncoding(string(c.Bytes))
+	if err != nil {
+		return err
+	}
+
+	if encoding == HexBytes {
+		obj, err := decodeObject(data)
+		if err != nil {
+			return err
+		}
+		err = convertBytes(reflect.TypeOf(msg).Elem(), obj, hexToBase64)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("failed decoding bytes of %s", proto.MessageName(msg)))
+		}
+		data, err = json.Marshal(obj)
+		if err != nil {
+			return errors.Wrap(err, "failed re-encoding JSON")
+		}
+	}
+
+	err = jsonpb.Unmarshal(bytes.NewReader(data), msg)
+	if err != nil {
+		return errors.Wrapf(err, "failed unmarshaling %s from JSON", proto.MessageName(msg))
+	}
+	return nil
+}
+
+// NewMessage returns an empty token message of the passed type, named either
+// fully, e.g. protos.Command, or without its package.
+func NewMessage(name string) (proto.Message, error) {
+	// the messages of transaction.proto have no package, the others share
+	// the protos package with the peer messages
+	t := proto.MessageType(name)
+	if t == nil && !strings.Contains(name, ".") {
+		t = proto.MessageType("protos." + name)
+	}
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().PkgPath() != reflect.TypeOf(TokenTransaction{}).PkgPath() {
+		return nil, errors.Errorf("unknown token message type '%s'", name)
+	}
+	return reflect.New(t.Elem()).Interface().(proto.Message), nil
+}
+
+func decodeObject(data []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// numbers are kept as they are, not converted to floats
+	decoder.UseNumber()
+	obj := map[string]interface{}{}
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, errors.Wrap(err, "failed decoding JSON")
+	}
+	return obj, nil
+}
+
+func base64ToHex(s string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hexToBase64(s string) (string, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// convertBytes converts the bytes fields of the JSON object of a message of
+// type t, a generated struct, and of its nested messages.
+func convertBytes(t reflect.Type, obj map[string]interface{}, convert func(string) (string, error)) error {
+	props := proto.GetProperties(t)
+	convertField := func(fieldType reflect.Type, prop *proto.Properties) error {
+		// jsonpb accepts both the JSON name and the original name of fields
+		for _, key := range []string{prop.JSONName, prop.OrigName} {
+			value, ok := obj[key]
+			if !ok {
+				continue
+			}
+			converted, err := convertValue(fieldType, value, convert)
+			if err != nil {
+				return errors.WithMessage(err, fmt.Sprintf("field %s", key))
+			}
+			obj[key] = converted
+		}
+		return nil
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if strings.HasPrefix(field.Name, "XXX_") || field.Tag.Get("protobuf_oneof") != "" {
+			continue
+		}
+		if err := convertField(field.Type, props.Prop[i]); err != nil {
+			return err
+		}
+	}
+	for _, oneof := range props.OneofTypes {
+		if err := convertField(oneof.Type.Elem().Field(0).Type, oneof.Prop); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func convertValue(t reflect.Type, value interface{}, convert func(string) (string, error)) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			s, ok := value.(string)
+			if !ok {
+				return nil, errors.Errorf("expected a string, got %T", value)
+			}
+			return convert(s)
+		}
+		array, ok := value.([]interface{})
+		if !ok {
+			return nil, errors.Errorf("expected an array, got %T", value)
+		}
+		for i := range array {
+			converted, err := convertValue(t.Elem(), array[i], convert)
+			if err != nil {
+				return nil, errors.WithMessage(err, fmt.Sprintf("element %d", i))
+			}
+			array[i] = converted
+		}
+		return array, nil
+	case reflect.Map:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("expected an object, got %T", value)
+		}
+		for key := range obj {
+			converted, err := convertValue(t.Elem(), obj[key], convert)
+			if err != nil {
+				return nil, errors.WithMessage(err, fmt.Sprintf("entry %s", key))
+			}
+			obj[key] = converted
+		}
+		return obj, nil
+	case reflect.Ptr:
+		// well known types, such as timestamps, are not JSON objects
+		obj, ok := value.(map[string]interface{})
+		if !ok || t.Elem().Kind() != reflect.Struct {
+			return value, nil
+		}
+		return obj, convertBytes(t.Elem(), obj, convert)
+	default:
+		return value, nil
+	}
+}
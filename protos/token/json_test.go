@@ -0,0 +1,118 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func transferTransaction() *TokenTransaction {
+	return &TokenTransaction{
+		Action: &TokenTransaction_PlainAction{
+			PlainAction: &PlainTokenAction{
+				Data: &PlainTokenAction_PlainTransfer{
+					PlainTransfer: &PlainTransfer{
+						Inputs:  []*InputId{{TxId: "txid", Index: 1}},
+						Outputs: []*PlainOutput{{Owner: []byte{0xca, 0xfe}, Type: "PDQ", Quantity: 100}},
+					},
+				},
+			},
+		},
+		ApplicationReference: []byte{0x01},
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	data, err := MarshalJSON(transferTransaction())
+	assert.NoError(t, err)
+	assert.Equal(t, `{"applicationReference":"AQ==","plainAction":{"plainTransfer":{"inputs":[{"index":1,"txId":"txid"}],"outputs":[{"owner":"yv4=","quantity":"100","type":"PDQ"}]}}}`, string(data))
+
+	ttx := &TokenTransaction{}
+	assert.NoError(t, UnmarshalJSON(data, ttx))
+	assert.True(t, proto.Equal(transferTransaction(), ttx))
+}
+
+func TestJSONCodecHexBytes(t *testing.T) {
+	codec := &JSONCodec{Bytes: HexBytes}
+	data, err := codec.Marshal(transferTransaction())
+	assert.NoError(t, err)
+	assert.Equal(t, `{"applicationReference":"01","plainAction":{"plainTransfer":{"inputs":[{"index":1,"txId":"txid"}],"outputs":[{"owner":"cafe","quantity":"100","type":"PDQ"}]}}}`, string(data))
+
+	ttx := &TokenTransaction{}
+	assert.NoError(t, codec.Unmarshal(data, ttx))
+	assert.True(t, proto.Equal(transferTransaction(), ttx))
+
+	// original field names are accepted as well
+	ttx = &TokenTransaction{}
+	assert.NoError(t, codec.Unmarshal([]byte(`{"application_reference":"01"}`), ttx))
+	assert.Equal(t, []byte{0x01}, ttx.ApplicationReference)
+
+	err = codec.Unmarshal([]byte(`{"plainAction":{"plainTransfer":{"outputs":[{"owner":"xyz"}]}}}`), &TokenTransaction{})
+	assert.EqualError(t, err, "failed decoding bytes of TokenTransaction: field plainAction: field plainTransfer: field outputs: element 0: field owner: encoding/hex: invalid byte: U+0078 'x'")
+}
+
+func TestJSONCodecMapBytes(t *testing.T) {
+	capabilities := &ChannelCapabilities{TokenEncryptionKeys: map[string][]byte{"Org1MSP": {0xab}}}
+
+	codec := &JSONCodec{Bytes: HexBytes, Indent: "  "}
+	data, err := codec.Marshal(capabilities)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n  \"tokenEncryptionKeys\": {\n    \"Org1MSP\": \"ab\"\n  }\n}", string(data))
+
+	decoded := &ChannelCapabilities{}
+	assert.NoError(t, codec.Unmarshal(data, decoded))
+	assert.True(t, proto.Equal(capabilities, decoded))
+}
+
+func TestJSONCodecErrors(t *testing.T) {
+	_, err := (&JSONCodec{Bytes: "base32"}).Marshal(&TokenTransaction{})
+	assert.EqualError(t, err, "unknown bytes encoding 'base32'")
+
+	err = UnmarshalJSON([]byte(`{"unknown":1}`), &TokenTransaction{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed unmarshaling TokenTransaction from JSON")
+
+	err = (&JSONCodec{Bytes: HexBytes}).Unmarshal([]byte(`[`), &TokenTransaction{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed decoding JSON")
+}
+
+func TestParseBytesEncoding(t *testing.T) {
+	encoding, err := ParseBytesEncoding("")
+	assert.NoError(t, err)
+	assert.Equal(t, Base64Bytes, encoding)
+
+	encoding, err = ParseBytesEncoding("hex")
+	assert.NoError(t, err)
+	assert.Equal(t, HexBytes, encoding)
+
+	_, err = ParseBytesEncoding("base32")
+	assert.EqualError(t, err, "unknown bytes encoding 'base32'")
+}
+
+func TestNewMessage(t *testing.T) {
+	msg, err := NewMessage("TokenTransaction")
+	assert.NoError(t, err)
+	assert.IsType(t, &TokenTransaction{}, msg)
+
+	msg, err = NewMessage("Command")
+	assert.NoError(t, err)
+	assert.IsType(t, &Command{}, msg)
+
+	msg, err = NewMessage("protos.Command")
+	assert.NoError(t, err)
+	assert.IsType(t, &Command{}, msg)
+
+	_, err = NewMessage("Proposal")
+	assert.EqualError(t, err, "unknown token message type 'Proposal'")
+
+	_, err = NewMessage("protos.Missing")
+	assert.EqualError(t, err, "unknown token message type 'protos.Missing'")
+}
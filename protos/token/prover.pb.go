@@ -24,6 +24,47 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// Phase is the progress of a submitted transaction
+type SubmitStatus_Phase int32
+
+const (
+	SubmitStatus_UNKNOWN SubmitStatus_Phase = 0
+	// ACCEPTED reports that the gateway took charge of the transaction
+	SubmitStatus_ACCEPTED SubmitStatus_Phase = 1
+	// ORDERED reports that an orderer accepted the transaction
+	SubmitStatus_ORDERED SubmitStatus_Phase = 2
+	// COMMITTED reports that the transaction was committed as valid
+	SubmitStatus_COMMITTED SubmitStatus_Phase = 3
+	// INVALID reports that the transaction was committed as invalid
+	SubmitStatus_INVALID SubmitStatus_Phase = 4
+	// FAILED reports that the gateway gave up on the transaction
+	SubmitStatus_FAILED SubmitStatus_Phase = 5
+)
+
+var SubmitStatus_Phase_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "ACCEPTED",
+	2: "ORDERED",
+	3: "COMMITTED",
+	4: "INVALID",
+	5: "FAILED",
+}
+var SubmitStatus_Phase_value = map[string]int32{
+	"UNKNOWN":   0,
+	"ACCEPTED":  1,
+	"ORDERED":   2,
+	"COMMITTED": 3,
+	"INVALID":   4,
+	"FAILED":    5,
+}
+
+func (x SubmitStatus_Phase) String() string {
+	return proto.EnumName(SubmitStatus_Phase_name, int32(x))
+}
+func (SubmitStatus_Phase) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{41, 0}
+}
+
 // TokenToIssue describes a token to be issued in the system
 type TokenToIssue struct {
 	// Recipient refers to the owner of the token to be issued
@@ -31,7 +72,10 @@ type TokenToIssue struct {
 	// Type refers to the token type
 	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
 	// Quantity refers to the number of token units to be issued
-	Quantity             uint64   `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Quantity uint64 `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// Metadata is the metadata of the token, a JSON object validated against
+	// the schema of its type
+	Metadata             []byte   `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -41,7 +85,7 @@ func (m *TokenToIssue) Reset()         { *m = TokenToIssue{} }
 func (m *TokenToIssue) String() string { return proto.CompactTextString(m) }
 func (*TokenToIssue) ProtoMessage()    {}
 func (*TokenToIssue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{0}
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{0}
 }
 func (m *TokenToIssue) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TokenToIssue.Unmarshal(m, b)
@@ -82,12 +126,23 @@ func (m *TokenToIssue) GetQuantity() uint64 {
 	return 0
 }
 
+func (m *TokenToIssue) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
 // RecipientTransferShare describes how much a recipient will receive in a token transfer
 type RecipientTransferShare struct {
 	// Recipient refers to the prospective owner of a transferred token
 	Recipient []byte `protobuf:"bytes,1,opt,name=recipient,proto3" json:"recipient,omitempty"`
 	// Quantity refers to the number of token units to be transferred to the recipient
-	Quantity             uint64   `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Quantity uint64 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// Metadata re-specifies the metadata of the transferred token, when the
+	// schema of its type allows it; when empty, the token keeps the metadata
+	// of the inputs
+	Metadata             []byte   `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -97,7 +152,7 @@ func (m *RecipientTransferShare) Reset()         { *m = RecipientTransferShare{}
 func (m *RecipientTransferShare) String() string { return proto.CompactTextString(m) }
 func (*RecipientTransferShare) ProtoMessage()    {}
 func (*RecipientTransferShare) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{1}
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{1}
 }
 func (m *RecipientTransferShare) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RecipientTransferShare.Unmarshal(m, b)
@@ -131,6 +186,13 @@ func (m *RecipientTransferShare) GetQuantity() uint64 {
 	return 0
 }
 
+func (m *RecipientTransferShare) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
 // TokenOutput is used to specify a token returned by ListRequest
 type TokenOutput struct {
 	// ID is used to uniquely identify the token
@@ -138,7 +200,9 @@ type TokenOutput struct {
 	// Type is the type of the token
 	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
 	// Quantity represents the number for this type of token
-	Quantity             uint64   `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Quantity uint64 `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// Metadata is the metadata of the token, if any
+	Metadata             []byte   `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -148,7 +212,7 @@ func (m *TokenOutput) Reset()         { *m = TokenOutput{} }
 func (m *TokenOutput) String() string { return proto.CompactTextString(m) }
 func (*TokenOutput) ProtoMessage()    {}
 func (*TokenOutput) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{2}
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{2}
 }
 func (m *TokenOutput) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TokenOutput.Unmarshal(m, b)
@@ -189,6 +253,13 @@ func (m *TokenOutput) GetQuantity() uint64 {
 	return 0
 }
 
+func (m *TokenOutput) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
 // UnspentTokens is used to hold the output of listRequest
 type UnspentTokens struct {
 	Tokens               []*TokenOutput `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
@@ -201,7 +272,7 @@ func (m *UnspentTokens) Reset()         { *m = UnspentTokens{} }
 func (m *UnspentTokens) String() string { return proto.CompactTextString(m) }
 func (*UnspentTokens) ProtoMessage()    {}
 func (*UnspentTokens) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{3}
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{3}
 }
 func (m *UnspentTokens) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UnspentTokens.Unmarshal(m, b)
@@ -230,17 +301,22 @@ func (m *UnspentTokens) GetTokens() []*TokenOutput {
 
 // ListRequest is used to request a list of unspent tokens
 type ListRequest struct {
-	Credential           []byte   `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	// PseudonymProofs, when present, request the tokens owned by the
+	// pseudonyms of the creator instead of the tokens owned by the creator,
+	// so that an anonymous creator can list its tokens without revealing
+	// which member it is
+	PseudonymProofs      []*PseudonymProof `protobuf:"bytes,2,rep,name=pseudonym_proofs,json=pseudonymProofs,proto3" json:"pseudonym_proofs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *ListRequest) Reset()         { *m = ListRequest{} }
 func (m *ListRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRequest) ProtoMessage()    {}
 func (*ListRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{4}
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{4}
 }
 func (m *ListRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListRequest.Unmarshal(m, b)
@@ -267,332 +343,1486 @@ func (m *ListRequest) GetCredential() []byte {
 	return nil
 }
 
-// ImportRequest is used to request creation of imports
-type ImportRequest struct {
-	// Credential contains information about the party who is requesting the operation
-	// the content of this field depends on the charateristic of the token manager system used.
-	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
-	// TokenToIssue contains the information about the tokens to be issued
-	TokensToIssue        []*TokenToIssue `protobuf:"bytes,2,rep,name=tokens_to_issue,json=tokensToIssue,proto3" json:"tokens_to_issue,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+func (m *ListRequest) GetPseudonymProofs() []*PseudonymProof {
+	if m != nil {
+		return m.PseudonymProofs
+	}
+	return nil
 }
 
-func (m *ImportRequest) Reset()         { *m = ImportRequest{} }
-func (m *ImportRequest) String() string { return proto.CompactTextString(m) }
-func (*ImportRequest) ProtoMessage()    {}
-func (*ImportRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{5}
+// PseudonymProof proves that the creator of a command holds a pseudonym,
+// such as an Idemix identity
+type PseudonymProof struct {
+	// Owner is the serialized pseudonym, as it appears in the outputs it owns
+	Owner []byte `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	// Signature is the signature of the pseudonym over the nonce of the
+	// command header followed by its creator
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *ImportRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ImportRequest.Unmarshal(m, b)
+
+func (m *PseudonymProof) Reset()         { *m = PseudonymProof{} }
+func (m *PseudonymProof) String() string { return proto.CompactTextString(m) }
+func (*PseudonymProof) ProtoMessage()    {}
+func (*PseudonymProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{5}
 }
-func (m *ImportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ImportRequest.Marshal(b, m, deterministic)
+func (m *PseudonymProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PseudonymProof.Unmarshal(m, b)
 }
-func (dst *ImportRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ImportRequest.Merge(dst, src)
+func (m *PseudonymProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PseudonymProof.Marshal(b, m, deterministic)
 }
-func (m *ImportRequest) XXX_Size() int {
-	return xxx_messageInfo_ImportRequest.Size(m)
+func (dst *PseudonymProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PseudonymProof.Merge(dst, src)
 }
-func (m *ImportRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ImportRequest.DiscardUnknown(m)
+func (m *PseudonymProof) XXX_Size() int {
+	return xxx_messageInfo_PseudonymProof.Size(m)
+}
+func (m *PseudonymProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_PseudonymProof.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ImportRequest proto.InternalMessageInfo
+var xxx_messageInfo_PseudonymProof proto.InternalMessageInfo
 
-func (m *ImportRequest) GetCredential() []byte {
+func (m *PseudonymProof) GetOwner() []byte {
 	if m != nil {
-		return m.Credential
+		return m.Owner
 	}
 	return nil
 }
 
-func (m *ImportRequest) GetTokensToIssue() []*TokenToIssue {
+func (m *PseudonymProof) GetSignature() []byte {
 	if m != nil {
-		return m.TokensToIssue
+		return m.Signature
 	}
 	return nil
 }
 
-// RequestTransfer is used to request creation of transfers
-type TransferRequest struct {
-	Credential           []byte                    `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
-	TokenIds             [][]byte                  `protobuf:"bytes,2,rep,name=token_ids,json=tokenIds,proto3" json:"token_ids,omitempty"`
-	Shares               []*RecipientTransferShare `protobuf:"bytes,3,rep,name=shares,proto3" json:"shares,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+// ReferenceRequest is used to request the token transactions carrying an
+// application reference
+type ReferenceRequest struct {
+	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	// ApplicationReference is the reference to look up
+	ApplicationReference []byte   `protobuf:"bytes,2,opt,name=application_reference,json=applicationReference,proto3" json:"application_reference,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *TransferRequest) Reset()         { *m = TransferRequest{} }
-func (m *TransferRequest) String() string { return proto.CompactTextString(m) }
-func (*TransferRequest) ProtoMessage()    {}
-func (*TransferRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{6}
+func (m *ReferenceRequest) Reset()         { *m = ReferenceRequest{} }
+func (m *ReferenceRequest) String() string { return proto.CompactTextString(m) }
+func (*ReferenceRequest) ProtoMessage()    {}
+func (*ReferenceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{6}
 }
-func (m *TransferRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TransferRequest.Unmarshal(m, b)
+func (m *ReferenceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReferenceRequest.Unmarshal(m, b)
 }
-func (m *TransferRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TransferRequest.Marshal(b, m, deterministic)
+func (m *ReferenceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReferenceRequest.Marshal(b, m, deterministic)
 }
-func (dst *TransferRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TransferRequest.Merge(dst, src)
+func (dst *ReferenceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReferenceRequest.Merge(dst, src)
 }
-func (m *TransferRequest) XXX_Size() int {
-	return xxx_messageInfo_TransferRequest.Size(m)
+func (m *ReferenceRequest) XXX_Size() int {
+	return xxx_messageInfo_ReferenceRequest.Size(m)
 }
-func (m *TransferRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_TransferRequest.DiscardUnknown(m)
+func (m *ReferenceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReferenceRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TransferRequest proto.InternalMessageInfo
+var xxx_messageInfo_ReferenceRequest proto.InternalMessageInfo
 
-func (m *TransferRequest) GetCredential() []byte {
+func (m *ReferenceRequest) GetCredential() []byte {
 	if m != nil {
 		return m.Credential
 	}
 	return nil
 }
 
-func (m *TransferRequest) GetTokenIds() [][]byte {
+func (m *ReferenceRequest) GetApplicationReference() []byte {
 	if m != nil {
-		return m.TokenIds
+		return m.ApplicationReference
 	}
 	return nil
 }
 
-func (m *TransferRequest) GetShares() []*RecipientTransferShare {
+// ReferencedTransaction is a committed token transaction returned by a ReferenceRequest
+type ReferencedTransaction struct {
+	// TxId is the ID of the transaction
+	TxId string `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	// TokenTransaction is the committed transaction
+	TokenTransaction     *TokenTransaction `protobuf:"bytes,2,opt,name=token_transaction,json=tokenTransaction,proto3" json:"token_transaction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ReferencedTransaction) Reset()         { *m = ReferencedTransaction{} }
+func (m *ReferencedTransaction) String() string { return proto.CompactTextString(m) }
+func (*ReferencedTransaction) ProtoMessage()    {}
+func (*ReferencedTransaction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{7}
+}
+func (m *ReferencedTransaction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReferencedTransaction.Unmarshal(m, b)
+}
+func (m *ReferencedTransaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReferencedTransaction.Marshal(b, m, deterministic)
+}
+func (dst *ReferencedTransaction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReferencedTransaction.Merge(dst, src)
+}
+func (m *ReferencedTransaction) XXX_Size() int {
+	return xxx_messageInfo_ReferencedTransaction.Size(m)
+}
+func (m *ReferencedTransaction) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReferencedTransaction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReferencedTransaction proto.InternalMessageInfo
+
+func (m *ReferencedTransaction) GetTxId() string {
 	if m != nil {
-		return m.Shares
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *ReferencedTransaction) GetTokenTransaction() *TokenTransaction {
+	if m != nil {
+		return m.TokenTransaction
 	}
 	return nil
 }
 
-// RedeemRequest is used to request token redemption
-type RedeemRequest struct {
-	// Credential contains information for the party who is requesting the operation
-	// The content of this field depends on the characteristic of token manager system
-	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
-	// token_ids specifies the ids for the tokens that will be redeemed
-	TokenIds [][]byte `protobuf:"bytes,2,rep,name=token_ids,json=tokenIds,proto3" json:"token_ids,omitempty"`
-	// quantity refers to the number of units of a given token needs to be redeemed.
-	QuantityToRedeem     uint64   `protobuf:"varint,3,opt,name=quantity_to_redeem,json=quantityToRedeem,proto3" json:"quantity_to_redeem,omitempty"`
+// ReferencedTransactions is used to hold the output of a ReferenceRequest
+type ReferencedTransactions struct {
+	Transactions         []*ReferencedTransaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *ReferencedTransactions) Reset()         { *m = ReferencedTransactions{} }
+func (m *ReferencedTransactions) String() string { return proto.CompactTextString(m) }
+func (*ReferencedTransactions) ProtoMessage()    {}
+func (*ReferencedTransactions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{8}
+}
+func (m *ReferencedTransactions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReferencedTransactions.Unmarshal(m, b)
+}
+func (m *ReferencedTransactions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReferencedTransactions.Marshal(b, m, deterministic)
+}
+func (dst *ReferencedTransactions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReferencedTransactions.Merge(dst, src)
+}
+func (m *ReferencedTransactions) XXX_Size() int {
+	return xxx_messageInfo_ReferencedTransactions.Size(m)
+}
+func (m *ReferencedTransactions) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReferencedTransactions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReferencedTransactions proto.InternalMessageInfo
+
+func (m *ReferencedTransactions) GetTransactions() []*ReferencedTransaction {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+// CapabilitiesRequest is used to request the token capabilities of a channel
+type CapabilitiesRequest struct {
+	Credential           []byte   `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RedeemRequest) Reset()         { *m = RedeemRequest{} }
-func (m *RedeemRequest) String() string { return proto.CompactTextString(m) }
-func (*RedeemRequest) ProtoMessage()    {}
-func (*RedeemRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{7}
+func (m *CapabilitiesRequest) Reset()         { *m = CapabilitiesRequest{} }
+func (m *CapabilitiesRequest) String() string { return proto.CompactTextString(m) }
+func (*CapabilitiesRequest) ProtoMessage()    {}
+func (*CapabilitiesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{9}
 }
-func (m *RedeemRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RedeemRequest.Unmarshal(m, b)
+func (m *CapabilitiesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CapabilitiesRequest.Unmarshal(m, b)
 }
-func (m *RedeemRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RedeemRequest.Marshal(b, m, deterministic)
+func (m *CapabilitiesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CapabilitiesRequest.Marshal(b, m, deterministic)
 }
-func (dst *RedeemRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RedeemRequest.Merge(dst, src)
+func (dst *CapabilitiesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CapabilitiesRequest.Merge(dst, src)
 }
-func (m *RedeemRequest) XXX_Size() int {
-	return xxx_messageInfo_RedeemRequest.Size(m)
+func (m *CapabilitiesRequest) XXX_Size() int {
+	return xxx_messageInfo_CapabilitiesRequest.Size(m)
 }
-func (m *RedeemRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_RedeemRequest.DiscardUnknown(m)
+func (m *CapabilitiesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CapabilitiesRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RedeemRequest proto.InternalMessageInfo
+var xxx_messageInfo_CapabilitiesRequest proto.InternalMessageInfo
 
-func (m *RedeemRequest) GetCredential() []byte {
+func (m *CapabilitiesRequest) GetCredential() []byte {
 	if m != nil {
 		return m.Credential
 	}
 	return nil
 }
 
-func (m *RedeemRequest) GetTokenIds() [][]byte {
+// ChannelCapabilities holds the output of a CapabilitiesRequest
+type ChannelCapabilities struct {
+	// FabToken is true when the FabToken capability is enabled on the channel
+	FabToken bool `protobuf:"varint,1,opt,name=fab_token,json=fabToken,proto3" json:"fab_token,omitempty"`
+	// HashingSuite is the hashing algorithm with which the channel computes
+	// transaction IDs, e.g. SHA256 or SHA3_256
+	HashingSuite string `protobuf:"bytes,2,opt,name=hashing_suite,json=hashingSuite,proto3" json:"hashing_suite,omitempty"`
+	// TokenEncryptionKeys are the DER encoded TokenEncryptionKeys of the
+	// application orgs of the channel, by MSP ID
+	TokenEncryptionKeys map[string][]byte `protobuf:"bytes,3,rep,name=token_encryption_keys,json=tokenEncryptionKeys,proto3" json:"token_encryption_keys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// TxExpiration is true when the committing peers of the channel invalidate
+	// the transactions that have expired
+	TxExpiration         bool     `protobuf:"varint,4,opt,name=tx_expiration,json=txExpiration,proto3" json:"tx_expiration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChannelCapabilities) Reset()         { *m = ChannelCapabilities{} }
+func (m *ChannelCapabilities) String() string { return proto.CompactTextString(m) }
+func (*ChannelCapabilities) ProtoMessage()    {}
+func (*ChannelCapabilities) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{10}
+}
+func (m *ChannelCapabilities) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelCapabilities.Unmarshal(m, b)
+}
+func (m *ChannelCapabilities) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelCapabilities.Marshal(b, m, deterministic)
+}
+func (dst *ChannelCapabilities) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelCapabilities.Merge(dst, src)
+}
+func (m *ChannelCapabilities) XXX_Size() int {
+	return xxx_messageInfo_ChannelCapabilities.Size(m)
+}
+func (m *ChannelCapabilities) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelCapabilities.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelCapabilities proto.InternalMessageInfo
+
+func (m *ChannelCapabilities) GetFabToken() bool {
 	if m != nil {
-		return m.TokenIds
+		return m.FabToken
+	}
+	return false
+}
+
+func (m *ChannelCapabilities) GetHashingSuite() string {
+	if m != nil {
+		return m.HashingSuite
+	}
+	return ""
+}
+
+func (m *ChannelCapabilities) GetTokenEncryptionKeys() map[string][]byte {
+	if m != nil {
+		return m.TokenEncryptionKeys
 	}
 	return nil
 }
 
-func (m *RedeemRequest) GetQuantityToRedeem() uint64 {
+func (m *ChannelCapabilities) GetTxExpiration() bool {
 	if m != nil {
-		return m.QuantityToRedeem
+		return m.TxExpiration
 	}
-	return 0
+	return false
 }
 
-// ALlowance defines how many and what tokens a recipient can transfer on behalf of their actual owner
-type AllowanceRecipientShare struct {
-	// Recipient refers to the entity allowed to spend the specified quantity from the tokens identified by token IDs
-	Recipient []byte `protobuf:"bytes,1,opt,name=recipient,proto3" json:"recipient,omitempty"`
-	// Quantity is how many tokens are delegated to the recipient
-	Quantity             uint64   `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+// SupplyAttestationRequest is used to request an attestation of the circulating
+// supply of the token types of a channel, signed by the prover peer
+type SupplyAttestationRequest struct {
+	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	// Types restricts the attestation to the passed token types; every token
+	// type in circulation is attested when empty
+	Types                []string `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AllowanceRecipientShare) Reset()         { *m = AllowanceRecipientShare{} }
-func (m *AllowanceRecipientShare) String() string { return proto.CompactTextString(m) }
-func (*AllowanceRecipientShare) ProtoMessage()    {}
-func (*AllowanceRecipientShare) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{8}
+func (m *SupplyAttestationRequest) Reset()         { *m = SupplyAttestationRequest{} }
+func (m *SupplyAttestationRequest) String() string { return proto.CompactTextString(m) }
+func (*SupplyAttestationRequest) ProtoMessage()    {}
+func (*SupplyAttestationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{11}
 }
-func (m *AllowanceRecipientShare) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AllowanceRecipientShare.Unmarshal(m, b)
+func (m *SupplyAttestationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SupplyAttestationRequest.Unmarshal(m, b)
 }
-func (m *AllowanceRecipientShare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AllowanceRecipientShare.Marshal(b, m, deterministic)
+func (m *SupplyAttestationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SupplyAttestationRequest.Marshal(b, m, deterministic)
 }
-func (dst *AllowanceRecipientShare) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AllowanceRecipientShare.Merge(dst, src)
+func (dst *SupplyAttestationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SupplyAttestationRequest.Merge(dst, src)
 }
-func (m *AllowanceRecipientShare) XXX_Size() int {
-	return xxx_messageInfo_AllowanceRecipientShare.Size(m)
+func (m *SupplyAttestationRequest) XXX_Size() int {
+	return xxx_messageInfo_SupplyAttestationRequest.Size(m)
 }
-func (m *AllowanceRecipientShare) XXX_DiscardUnknown() {
-	xxx_messageInfo_AllowanceRecipientShare.DiscardUnknown(m)
+func (m *SupplyAttestationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SupplyAttestationRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AllowanceRecipientShare proto.InternalMessageInfo
+var xxx_messageInfo_SupplyAttestationRequest proto.InternalMessageInfo
 
-func (m *AllowanceRecipientShare) GetRecipient() []byte {
+func (m *SupplyAttestationRequest) GetCredential() []byte {
 	if m != nil {
-		return m.Recipient
+		return m.Credential
 	}
 	return nil
 }
 
-func (m *AllowanceRecipientShare) GetQuantity() uint64 {
+func (m *SupplyAttestationRequest) GetTypes() []string {
 	if m != nil {
-		return m.Quantity
+		return m.Types
 	}
-	return 0
+	return nil
 }
 
-// ApproveRequest is used to request the creation of allowance from one owner to another
-type ApproveRequest struct {
-	// Credential refers to the public credential of the request creator
-	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
-	// Allowance describes the tokens the creator of the request is willing to delegate
-	AllowanceShares []*AllowanceRecipientShare `protobuf:"bytes,2,rep,name=allowance_shares,json=allowanceShares,proto3" json:"allowance_shares,omitempty"`
-	// TokenIds are the token identifiers used to create the allowance
-	TokenIds             [][]byte `protobuf:"bytes,3,rep,name=token_ids,json=tokenIds,proto3" json:"token_ids,omitempty"`
+// SupplyAttestation states the circulating supply of token types, i.e. the
+// total quantity of their unspent outputs, as computed by a peer from the token
+// namespace of a channel at a block height
+type SupplyAttestation struct {
+	// ChannelId is the channel of the token namespace
+	ChannelId string `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// BlockHeight is the number of blocks of the ledger of the channel the
+	// supply was computed at
+	BlockHeight uint64 `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	// Supplies are the supplies of the token types, sorted by type
+	Supplies []*TypeSupply `protobuf:"bytes,3,rep,name=supplies,proto3" json:"supplies,omitempty"`
+	// Timestamp is the local time of the peer when it computed the supply
+	Timestamp *timestamp.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Peer is the serialized identity of the peer signing the attestation
+	Peer                 []byte   `protobuf:"bytes,5,opt,name=peer,proto3" json:"peer,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ApproveRequest) Reset()         { *m = ApproveRequest{} }
-func (m *ApproveRequest) String() string { return proto.CompactTextString(m) }
-func (*ApproveRequest) ProtoMessage()    {}
-func (*ApproveRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{9}
+func (m *SupplyAttestation) Reset()         { *m = SupplyAttestation{} }
+func (m *SupplyAttestation) String() string { return proto.CompactTextString(m) }
+func (*SupplyAttestation) ProtoMessage()    {}
+func (*SupplyAttestation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{12}
+}
+func (m *SupplyAttestation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SupplyAttestation.Unmarshal(m, b)
+}
+func (m *SupplyAttestation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SupplyAttestation.Marshal(b, m, deterministic)
+}
+func (dst *SupplyAttestation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SupplyAttestation.Merge(dst, src)
+}
+func (m *SupplyAttestation) XXX_Size() int {
+	return xxx_messageInfo_SupplyAttestation.Size(m)
+}
+func (m *SupplyAttestation) XXX_DiscardUnknown() {
+	xxx_messageInfo_SupplyAttestation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SupplyAttestation proto.InternalMessageInfo
+
+func (m *SupplyAttestation) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *SupplyAttestation) GetBlockHeight() uint64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *SupplyAttestation) GetSupplies() []*TypeSupply {
+	if m != nil {
+		return m.Supplies
+	}
+	return nil
+}
+
+func (m *SupplyAttestation) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *SupplyAttestation) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
+	}
+	return nil
+}
+
+// TypeSupply is the circulating supply of a token type
+type TypeSupply struct {
+	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Quantity             uint64   `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TypeSupply) Reset()         { *m = TypeSupply{} }
+func (m *TypeSupply) String() string { return proto.CompactTextString(m) }
+func (*TypeSupply) ProtoMessage()    {}
+func (*TypeSupply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{13}
+}
+func (m *TypeSupply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TypeSupply.Unmarshal(m, b)
+}
+func (m *TypeSupply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TypeSupply.Marshal(b, m, deterministic)
+}
+func (dst *TypeSupply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TypeSupply.Merge(dst, src)
+}
+func (m *TypeSupply) XXX_Size() int {
+	return xxx_messageInfo_TypeSupply.Size(m)
+}
+func (m *TypeSupply) XXX_DiscardUnknown() {
+	xxx_messageInfo_TypeSupply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TypeSupply proto.InternalMessageInfo
+
+func (m *TypeSupply) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *TypeSupply) GetQuantity() uint64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+// SignedSupplyAttestation holds the output of a SupplyAttestationRequest
+type SignedSupplyAttestation struct {
+	// Attestation is a serialized SupplyAttestation
+	Attestation []byte `protobuf:"bytes,1,opt,name=attestation,proto3" json:"attestation,omitempty"`
+	// Signature is the signature of the peer over the attestation
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedSupplyAttestation) Reset()         { *m = SignedSupplyAttestation{} }
+func (m *SignedSupplyAttestation) String() string { return proto.CompactTextString(m) }
+func (*SignedSupplyAttestation) ProtoMessage()    {}
+func (*SignedSupplyAttestation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{14}
+}
+func (m *SignedSupplyAttestation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedSupplyAttestation.Unmarshal(m, b)
+}
+func (m *SignedSupplyAttestation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedSupplyAttestation.Marshal(b, m, deterministic)
+}
+func (dst *SignedSupplyAttestation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedSupplyAttestation.Merge(dst, src)
+}
+func (m *SignedSupplyAttestation) XXX_Size() int {
+	return xxx_messageInfo_SignedSupplyAttestation.Size(m)
+}
+func (m *SignedSupplyAttestation) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedSupplyAttestation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedSupplyAttestation proto.InternalMessageInfo
+
+func (m *SignedSupplyAttestation) GetAttestation() []byte {
+	if m != nil {
+		return m.Attestation
+	}
+	return nil
+}
+
+func (m *SignedSupplyAttestation) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// CommitAttestationRequest is used to request an attestation of the commit of
+// the block of a transaction, signed by the prover peer
+type CommitAttestationRequest struct {
+	// TxId is the ID of the transaction
+	TxId                 string   `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommitAttestationRequest) Reset()         { *m = CommitAttestationRequest{} }
+func (m *CommitAttestationRequest) String() string { return proto.CompactTextString(m) }
+func (*CommitAttestationRequest) ProtoMessage()    {}
+func (*CommitAttestationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{15}
+}
+func (m *CommitAttestationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitAttestationRequest.Unmarshal(m, b)
+}
+func (m *CommitAttestationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitAttestationRequest.Marshal(b, m, deterministic)
+}
+func (dst *CommitAttestationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitAttestationRequest.Merge(dst, src)
+}
+func (m *CommitAttestationRequest) XXX_Size() int {
+	return xxx_messageInfo_CommitAttestationRequest.Size(m)
+}
+func (m *CommitAttestationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitAttestationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitAttestationRequest proto.InternalMessageInfo
+
+func (m *CommitAttestationRequest) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+// CommitAttestation states the validation results of the transactions of a
+// block committed by a peer, so that clients can require the attestations of
+// several peers before they treat a transaction as final
+type CommitAttestation struct {
+	// ChannelId is the channel of the block
+	ChannelId string `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// BlockNumber is the number of the block
+	BlockNumber uint64 `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	// BlockHash is the hash of the header of the block, computed with the
+	// hashing suite of the channel
+	BlockHash []byte `protobuf:"bytes,3,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	// ValidationFlags is the transaction validation bitmap of the block, the
+	// validation code of each of its transactions
+	ValidationFlags []byte `protobuf:"bytes,4,opt,name=validation_flags,json=validationFlags,proto3" json:"validation_flags,omitempty"`
+	// TxId is the ID of the attested transaction
+	TxId string `protobuf:"bytes,5,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	// TxIndex is the index of the attested transaction in the block
+	TxIndex uint32 `protobuf:"varint,6,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
+	// Timestamp is the local time of the peer when it signed the attestation
+	Timestamp *timestamp.Timestamp `protobuf:"bytes,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Peer is the serialized identity of the peer signing the attestation
+	Peer                 []byte   `protobuf:"bytes,8,opt,name=peer,proto3" json:"peer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommitAttestation) Reset()         { *m = CommitAttestation{} }
+func (m *CommitAttestation) String() string { return proto.CompactTextString(m) }
+func (*CommitAttestation) ProtoMessage()    {}
+func (*CommitAttestation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{16}
+}
+func (m *CommitAttestation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitAttestation.Unmarshal(m, b)
+}
+func (m *CommitAttestation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitAttestation.Marshal(b, m, deterministic)
+}
+func (dst *CommitAttestation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitAttestation.Merge(dst, src)
+}
+func (m *CommitAttestation) XXX_Size() int {
+	return xxx_messageInfo_CommitAttestation.Size(m)
+}
+func (m *CommitAttestation) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitAttestation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitAttestation proto.InternalMessageInfo
+
+func (m *CommitAttestation) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *CommitAttestation) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *CommitAttestation) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *CommitAttestation) GetValidationFlags() []byte {
+	if m != nil {
+		return m.ValidationFlags
+	}
+	return nil
+}
+
+func (m *CommitAttestation) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *CommitAttestation) GetTxIndex() uint32 {
+	if m != nil {
+		return m.TxIndex
+	}
+	return 0
+}
+
+func (m *CommitAttestation) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *CommitAttestation) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
+	}
+	return nil
+}
+
+// SignedCommitAttestation holds the output of a CommitAttestationRequest
+type SignedCommitAttestation struct {
+	// Attestation is a serialized CommitAttestation
+	Attestation []byte `protobuf:"bytes,1,opt,name=attestation,proto3" json:"attestation,omitempty"`
+	// Signature is the signature of the peer over the attestation
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedCommitAttestation) Reset()         { *m = SignedCommitAttestation{} }
+func (m *SignedCommitAttestation) String() string { return proto.CompactTextString(m) }
+func (*SignedCommitAttestation) ProtoMessage()    {}
+func (*SignedCommitAttestation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{17}
+}
+func (m *SignedCommitAttestation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedCommitAttestation.Unmarshal(m, b)
+}
+func (m *SignedCommitAttestation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedCommitAttestation.Marshal(b, m, deterministic)
+}
+func (dst *SignedCommitAttestation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedCommitAttestation.Merge(dst, src)
+}
+func (m *SignedCommitAttestation) XXX_Size() int {
+	return xxx_messageInfo_SignedCommitAttestation.Size(m)
+}
+func (m *SignedCommitAttestation) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedCommitAttestation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedCommitAttestation proto.InternalMessageInfo
+
+func (m *SignedCommitAttestation) GetAttestation() []byte {
+	if m != nil {
+		return m.Attestation
+	}
+	return nil
+}
+
+func (m *SignedCommitAttestation) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// ImportRequest is used to request creation of imports
+type ImportRequest struct {
+	// Credential contains information about the party who is requesting the operation
+	// the content of this field depends on the charateristic of the token manager system used.
+	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	// TokenToIssue contains the information about the tokens to be issued
+	TokensToIssue        []*TokenToIssue `protobuf:"bytes,2,rep,name=tokens_to_issue,json=tokensToIssue,proto3" json:"tokens_to_issue,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ImportRequest) Reset()         { *m = ImportRequest{} }
+func (m *ImportRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportRequest) ProtoMessage()    {}
+func (*ImportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{18}
+}
+func (m *ImportRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportRequest.Unmarshal(m, b)
+}
+func (m *ImportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportRequest.Marshal(b, m, deterministic)
+}
+func (dst *ImportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportRequest.Merge(dst, src)
+}
+func (m *ImportRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportRequest.Size(m)
+}
+func (m *ImportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportRequest proto.InternalMessageInfo
+
+func (m *ImportRequest) GetCredential() []byte {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+func (m *ImportRequest) GetTokensToIssue() []*TokenToIssue {
+	if m != nil {
+		return m.TokensToIssue
+	}
+	return nil
+}
+
+// RequestTransfer is used to request creation of transfers
+type TransferRequest struct {
+	Credential []byte                    `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	TokenIds   [][]byte                  `protobuf:"bytes,2,rep,name=token_ids,json=tokenIds,proto3" json:"token_ids,omitempty"`
+	Shares     []*RecipientTransferShare `protobuf:"bytes,3,rep,name=shares,proto3" json:"shares,omitempty"`
+	// Delegations is the chain of signed delegations authorizing the creator
+	// to spend tokens owned by another party, if the tokens are not owned by the creator
+	Delegations          []*SignedDelegation `protobuf:"bytes,4,rep,name=delegations,proto3" json:"delegations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *TransferRequest) Reset()         { *m = TransferRequest{} }
+func (m *TransferRequest) String() string { return proto.CompactTextString(m) }
+func (*TransferRequest) ProtoMessage()    {}
+func (*TransferRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{19}
+}
+func (m *TransferRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransferRequest.Unmarshal(m, b)
+}
+func (m *TransferRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransferRequest.Marshal(b, m, deterministic)
+}
+func (dst *TransferRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferRequest.Merge(dst, src)
+}
+func (m *TransferRequest) XXX_Size() int {
+	return xxx_messageInfo_TransferRequest.Size(m)
+}
+func (m *TransferRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferRequest proto.InternalMessageInfo
+
+func (m *TransferRequest) GetCredential() []byte {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+func (m *TransferRequest) GetTokenIds() [][]byte {
+	if m != nil {
+		return m.TokenIds
+	}
+	return nil
+}
+
+func (m *TransferRequest) GetShares() []*RecipientTransferShare {
+	if m != nil {
+		return m.Shares
+	}
+	return nil
+}
+
+func (m *TransferRequest) GetDelegations() []*SignedDelegation {
+	if m != nil {
+		return m.Delegations
+	}
+	return nil
+}
+
+// RedeemRequest is used to request token redemption
+type RedeemRequest struct {
+	// Credential contains information for the party who is requesting the operation
+	// The content of this field depends on the characteristic of token manager system
+	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	// token_ids specifies the ids for the tokens that will be redeemed
+	TokenIds [][]byte `protobuf:"bytes,2,rep,name=token_ids,json=tokenIds,proto3" json:"token_ids,omitempty"`
+	// quantity refers to the number of units of a given token needs to be redeemed.
+	QuantityToRedeem     uint64   `protobuf:"varint,3,opt,name=quantity_to_redeem,json=quantityToRedeem,proto3" json:"quantity_to_redeem,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RedeemRequest) Reset()         { *m = RedeemRequest{} }
+func (m *RedeemRequest) String() string { return proto.CompactTextString(m) }
+func (*RedeemRequest) ProtoMessage()    {}
+func (*RedeemRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{20}
+}
+func (m *RedeemRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RedeemRequest.Unmarshal(m, b)
+}
+func (m *RedeemRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RedeemRequest.Marshal(b, m, deterministic)
+}
+func (dst *RedeemRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RedeemRequest.Merge(dst, src)
+}
+func (m *RedeemRequest) XXX_Size() int {
+	return xxx_messageInfo_RedeemRequest.Size(m)
+}
+func (m *RedeemRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RedeemRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RedeemRequest proto.InternalMessageInfo
+
+func (m *RedeemRequest) GetCredential() []byte {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+func (m *RedeemRequest) GetTokenIds() [][]byte {
+	if m != nil {
+		return m.TokenIds
+	}
+	return nil
+}
+
+func (m *RedeemRequest) GetQuantityToRedeem() uint64 {
+	if m != nil {
+		return m.QuantityToRedeem
+	}
+	return 0
+}
+
+// ALlowance defines how many and what tokens a recipient can transfer on behalf of their actual owner
+type AllowanceRecipientShare struct {
+	// Recipient refers to the entity allowed to spend the specified quantity from the tokens identified by token IDs
+	Recipient []byte `protobuf:"bytes,1,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	// Quantity is how many tokens are delegated to the recipient
+	Quantity             uint64   `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AllowanceRecipientShare) Reset()         { *m = AllowanceRecipientShare{} }
+func (m *AllowanceRecipientShare) String() string { return proto.CompactTextString(m) }
+func (*AllowanceRecipientShare) ProtoMessage()    {}
+func (*AllowanceRecipientShare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{21}
+}
+func (m *AllowanceRecipientShare) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AllowanceRecipientShare.Unmarshal(m, b)
+}
+func (m *AllowanceRecipientShare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AllowanceRecipientShare.Marshal(b, m, deterministic)
+}
+func (dst *AllowanceRecipientShare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AllowanceRecipientShare.Merge(dst, src)
+}
+func (m *AllowanceRecipientShare) XXX_Size() int {
+	return xxx_messageInfo_AllowanceRecipientShare.Size(m)
+}
+func (m *AllowanceRecipientShare) XXX_DiscardUnknown() {
+	xxx_messageInfo_AllowanceRecipientShare.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AllowanceRecipientShare proto.InternalMessageInfo
+
+func (m *AllowanceRecipientShare) GetRecipient() []byte {
+	if m != nil {
+		return m.Recipient
+	}
+	return nil
+}
+
+func (m *AllowanceRecipientShare) GetQuantity() uint64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+// ApproveRequest is used to request the creation of allowance from one owner to another
+type ApproveRequest struct {
+	// Credential refers to the public credential of the request creator
+	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	// Allowance describes the tokens the creator of the request is willing to delegate
+	AllowanceShares []*AllowanceRecipientShare `protobuf:"bytes,2,rep,name=allowance_shares,json=allowanceShares,proto3" json:"allowance_shares,omitempty"`
+	// TokenIds are the token identifiers used to create the allowance
+	TokenIds             [][]byte `protobuf:"bytes,3,rep,name=token_ids,json=tokenIds,proto3" json:"token_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApproveRequest) Reset()         { *m = ApproveRequest{} }
+func (m *ApproveRequest) String() string { return proto.CompactTextString(m) }
+func (*ApproveRequest) ProtoMessage()    {}
+func (*ApproveRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{22}
+}
+func (m *ApproveRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApproveRequest.Unmarshal(m, b)
+}
+func (m *ApproveRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApproveRequest.Marshal(b, m, deterministic)
+}
+func (dst *ApproveRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApproveRequest.Merge(dst, src)
+}
+func (m *ApproveRequest) XXX_Size() int {
+	return xxx_messageInfo_ApproveRequest.Size(m)
+}
+func (m *ApproveRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApproveRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApproveRequest proto.InternalMessageInfo
+
+func (m *ApproveRequest) GetCredential() []byte {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+func (m *ApproveRequest) GetAllowanceShares() []*AllowanceRecipientShare {
+	if m != nil {
+		return m.AllowanceShares
+	}
+	return nil
+}
+
+func (m *ApproveRequest) GetTokenIds() [][]byte {
+	if m != nil {
+		return m.TokenIds
+	}
+	return nil
+}
+
+// ExpectationRequest is used to request indirect token import or transfer based on the token expectation
+type ExpectationRequest struct {
+	// credential contains information for the party who is requesting the operation
+	// The content of this field depends on the characteristic of token manager system
+	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	// expectation contains the expected outputs for token import or transfer
+	Expectation *TokenExpectation `protobuf:"bytes,2,opt,name=expectation,proto3" json:"expectation,omitempty"`
+	// TokenIds are the token identifiers used to fulfill the expectation
+	TokenIds             [][]byte `protobuf:"bytes,3,rep,name=token_ids,json=tokenIds,proto3" json:"token_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExpectationRequest) Reset()         { *m = ExpectationRequest{} }
+func (m *ExpectationRequest) String() string { return proto.CompactTextString(m) }
+func (*ExpectationRequest) ProtoMessage()    {}
+func (*ExpectationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{23}
+}
+func (m *ExpectationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExpectationRequest.Unmarshal(m, b)
+}
+func (m *ExpectationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExpectationRequest.Marshal(b, m, deterministic)
+}
+func (dst *ExpectationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExpectationRequest.Merge(dst, src)
+}
+func (m *ExpectationRequest) XXX_Size() int {
+	return xxx_messageInfo_ExpectationRequest.Size(m)
+}
+func (m *ExpectationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExpectationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExpectationRequest proto.InternalMessageInfo
+
+func (m *ExpectationRequest) GetCredential() []byte {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+func (m *ExpectationRequest) GetExpectation() *TokenExpectation {
+	if m != nil {
+		return m.Expectation
+	}
+	return nil
+}
+
+func (m *ExpectationRequest) GetTokenIds() [][]byte {
+	if m != nil {
+		return m.TokenIds
+	}
+	return nil
+}
+
+// BalanceRequest is used to request the balances of the creator, aggregated by token type
+type BalanceRequest struct {
+	Credential           []byte   `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BalanceRequest) Reset()         { *m = BalanceRequest{} }
+func (m *BalanceRequest) String() string { return proto.CompactTextString(m) }
+func (*BalanceRequest) ProtoMessage()    {}
+func (*BalanceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{24}
+}
+func (m *BalanceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BalanceRequest.Unmarshal(m, b)
+}
+func (m *BalanceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BalanceRequest.Marshal(b, m, deterministic)
+}
+func (dst *BalanceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BalanceRequest.Merge(dst, src)
+}
+func (m *BalanceRequest) XXX_Size() int {
+	return xxx_messageInfo_BalanceRequest.Size(m)
+}
+func (m *BalanceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BalanceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BalanceRequest proto.InternalMessageInfo
+
+func (m *BalanceRequest) GetCredential() []byte {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+// Balance is the aggregated quantity of the unspent tokens of a given type owned by an owner
+type Balance struct {
+	// Type is the type of the tokens
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// Quantity is the sum of the quantities of the unspent tokens
+	Quantity uint64 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// Outputs is the number of unspent tokens making up the balance
+	Outputs              uint32   `protobuf:"varint,3,opt,name=outputs,proto3" json:"outputs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Balance) Reset()         { *m = Balance{} }
+func (m *Balance) String() string { return proto.CompactTextString(m) }
+func (*Balance) ProtoMessage()    {}
+func (*Balance) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{25}
+}
+func (m *Balance) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Balance.Unmarshal(m, b)
+}
+func (m *Balance) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Balance.Marshal(b, m, deterministic)
+}
+func (dst *Balance) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Balance.Merge(dst, src)
+}
+func (m *Balance) XXX_Size() int {
+	return xxx_messageInfo_Balance.Size(m)
+}
+func (m *Balance) XXX_DiscardUnknown() {
+	xxx_messageInfo_Balance.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Balance proto.InternalMessageInfo
+
+func (m *Balance) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Balance) GetQuantity() uint64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+func (m *Balance) GetOutputs() uint32 {
+	if m != nil {
+		return m.Outputs
+	}
+	return 0
+}
+
+// Balances is used to hold the output of balanceRequest
+type Balances struct {
+	Balances             []*Balance `protobuf:"bytes,1,rep,name=balances,proto3" json:"balances,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *Balances) Reset()         { *m = Balances{} }
+func (m *Balances) String() string { return proto.CompactTextString(m) }
+func (*Balances) ProtoMessage()    {}
+func (*Balances) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{26}
+}
+func (m *Balances) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Balances.Unmarshal(m, b)
+}
+func (m *Balances) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Balances.Marshal(b, m, deterministic)
+}
+func (dst *Balances) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Balances.Merge(dst, src)
+}
+func (m *Balances) XXX_Size() int {
+	return xxx_messageInfo_Balances.Size(m)
+}
+func (m *Balances) XXX_DiscardUnknown() {
+	xxx_messageInfo_Balances.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Balances proto.InternalMessageInfo
+
+func (m *Balances) GetBalances() []*Balance {
+	if m != nil {
+		return m.Balances
+	}
+	return nil
+}
+
+// CreditRequest is used to request a transfer of a quantity of tokens from the balance
+// of the creator to a recipient; the prover selects the tokens to spend and returns
+// the change to the creator
+type CreditRequest struct {
+	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	// Recipient is the owner of the transferred tokens
+	Recipient []byte `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	// Type is the type of the tokens to transfer
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// Quantity is the number of units to transfer
+	Quantity             uint64   `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreditRequest) Reset()         { *m = CreditRequest{} }
+func (m *CreditRequest) String() string { return proto.CompactTextString(m) }
+func (*CreditRequest) ProtoMessage()    {}
+func (*CreditRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{27}
+}
+func (m *CreditRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreditRequest.Unmarshal(m, b)
+}
+func (m *CreditRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreditRequest.Marshal(b, m, deterministic)
+}
+func (dst *CreditRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreditRequest.Merge(dst, src)
+}
+func (m *CreditRequest) XXX_Size() int {
+	return xxx_messageInfo_CreditRequest.Size(m)
+}
+func (m *CreditRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreditRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreditRequest proto.InternalMessageInfo
+
+func (m *CreditRequest) GetCredential() []byte {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+func (m *CreditRequest) GetRecipient() []byte {
+	if m != nil {
+		return m.Recipient
+	}
+	return nil
+}
+
+func (m *CreditRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *CreditRequest) GetQuantity() uint64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+// DebitRequest is used to request the redemption of a quantity of tokens from the balance
+// of the creator; the prover selects the tokens to redeem and returns the change to the creator
+type DebitRequest struct {
+	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	// Type is the type of the tokens to redeem
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Quantity is the number of units to redeem
+	Quantity             uint64   `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DebitRequest) Reset()         { *m = DebitRequest{} }
+func (m *DebitRequest) String() string { return proto.CompactTextString(m) }
+func (*DebitRequest) ProtoMessage()    {}
+func (*DebitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{28}
+}
+func (m *DebitRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DebitRequest.Unmarshal(m, b)
+}
+func (m *DebitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DebitRequest.Marshal(b, m, deterministic)
+}
+func (dst *DebitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DebitRequest.Merge(dst, src)
+}
+func (m *DebitRequest) XXX_Size() int {
+	return xxx_messageInfo_DebitRequest.Size(m)
+}
+func (m *DebitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DebitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DebitRequest proto.InternalMessageInfo
+
+func (m *DebitRequest) GetCredential() []byte {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+func (m *DebitRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *DebitRequest) GetQuantity() uint64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+// PauseRequest is used to request the pause of a token type; the transaction is
+// only valid if the creator is an administrator
+type PauseRequest struct {
+	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	// Type is the token type to pause
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PauseRequest) Reset()         { *m = PauseRequest{} }
+func (m *PauseRequest) String() string { return proto.CompactTextString(m) }
+func (*PauseRequest) ProtoMessage()    {}
+func (*PauseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{29}
+}
+func (m *PauseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PauseRequest.Unmarshal(m, b)
+}
+func (m *PauseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PauseRequest.Marshal(b, m, deterministic)
+}
+func (dst *PauseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PauseRequest.Merge(dst, src)
+}
+func (m *PauseRequest) XXX_Size() int {
+	return xxx_messageInfo_PauseRequest.Size(m)
+}
+func (m *PauseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PauseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PauseRequest proto.InternalMessageInfo
+
+func (m *PauseRequest) GetCredential() []byte {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+func (m *PauseRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+// ResumeRequest is used to request the resumption of a paused token type; the
+// transaction is only valid if the creator is an administrator
+type ResumeRequest struct {
+	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	// Type is the token type to resume
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *ApproveRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ApproveRequest.Unmarshal(m, b)
+
+func (m *ResumeRequest) Reset()         { *m = ResumeRequest{} }
+func (m *ResumeRequest) String() string { return proto.CompactTextString(m) }
+func (*ResumeRequest) ProtoMessage()    {}
+func (*ResumeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{30}
 }
-func (m *ApproveRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ApproveRequest.Marshal(b, m, deterministic)
+func (m *ResumeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResumeRequest.Unmarshal(m, b)
 }
-func (dst *ApproveRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ApproveRequest.Merge(dst, src)
+func (m *ResumeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResumeRequest.Marshal(b, m, deterministic)
 }
-func (m *ApproveRequest) XXX_Size() int {
-	return xxx_messageInfo_ApproveRequest.Size(m)
+func (dst *ResumeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResumeRequest.Merge(dst, src)
 }
-func (m *ApproveRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ApproveRequest.DiscardUnknown(m)
+func (m *ResumeRequest) XXX_Size() int {
+	return xxx_messageInfo_ResumeRequest.Size(m)
+}
+func (m *ResumeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResumeRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ApproveRequest proto.InternalMessageInfo
+var xxx_messageInfo_ResumeRequest proto.InternalMessageInfo
 
-func (m *ApproveRequest) GetCredential() []byte {
+func (m *ResumeRequest) GetCredential() []byte {
 	if m != nil {
 		return m.Credential
 	}
 	return nil
 }
 
-func (m *ApproveRequest) GetAllowanceShares() []*AllowanceRecipientShare {
-	if m != nil {
-		return m.AllowanceShares
-	}
-	return nil
-}
-
-func (m *ApproveRequest) GetTokenIds() [][]byte {
+func (m *ResumeRequest) GetType() string {
 	if m != nil {
-		return m.TokenIds
+		return m.Type
 	}
-	return nil
+	return ""
 }
 
-// ExpectationRequest is used to request indirect token import or transfer based on the token expectation
-type ExpectationRequest struct {
-	// credential contains information for the party who is requesting the operation
-	// The content of this field depends on the characteristic of token manager system
+// IssueManifestRequest is used to request a transaction tying together the
+// imports of a batch of tokens issued in chunks
+type IssueManifestRequest struct {
 	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
-	// expectation contains the expected outputs for token import or transfer
-	Expectation *TokenExpectation `protobuf:"bytes,2,opt,name=expectation,proto3" json:"expectation,omitempty"`
-	// TokenIds are the token identifiers used to fulfill the expectation
-	TokenIds             [][]byte `protobuf:"bytes,3,rep,name=token_ids,json=tokenIds,proto3" json:"token_ids,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// BatchId identifies the batch, unique per issuer
+	BatchId []byte `protobuf:"bytes,2,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	// Chunks are the imports of the batch, in order
+	Chunks               []*IssueChunk `protobuf:"bytes,3,rep,name=chunks,proto3" json:"chunks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
-func (m *ExpectationRequest) Reset()         { *m = ExpectationRequest{} }
-func (m *ExpectationRequest) String() string { return proto.CompactTextString(m) }
-func (*ExpectationRequest) ProtoMessage()    {}
-func (*ExpectationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{10}
+func (m *IssueManifestRequest) Reset()         { *m = IssueManifestRequest{} }
+func (m *IssueManifestRequest) String() string { return proto.CompactTextString(m) }
+func (*IssueManifestRequest) ProtoMessage()    {}
+func (*IssueManifestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{31}
 }
-func (m *ExpectationRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ExpectationRequest.Unmarshal(m, b)
+func (m *IssueManifestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IssueManifestRequest.Unmarshal(m, b)
 }
-func (m *ExpectationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ExpectationRequest.Marshal(b, m, deterministic)
+func (m *IssueManifestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IssueManifestRequest.Marshal(b, m, deterministic)
 }
-func (dst *ExpectationRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ExpectationRequest.Merge(dst, src)
+func (dst *IssueManifestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IssueManifestRequest.Merge(dst, src)
 }
-func (m *ExpectationRequest) XXX_Size() int {
-	return xxx_messageInfo_ExpectationRequest.Size(m)
+func (m *IssueManifestRequest) XXX_Size() int {
+	return xxx_messageInfo_IssueManifestRequest.Size(m)
 }
-func (m *ExpectationRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ExpectationRequest.DiscardUnknown(m)
+func (m *IssueManifestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_IssueManifestRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ExpectationRequest proto.InternalMessageInfo
+var xxx_messageInfo_IssueManifestRequest proto.InternalMessageInfo
 
-func (m *ExpectationRequest) GetCredential() []byte {
+func (m *IssueManifestRequest) GetCredential() []byte {
 	if m != nil {
 		return m.Credential
 	}
 	return nil
 }
 
-func (m *ExpectationRequest) GetExpectation() *TokenExpectation {
+func (m *IssueManifestRequest) GetBatchId() []byte {
 	if m != nil {
-		return m.Expectation
+		return m.BatchId
 	}
 	return nil
 }
 
-func (m *ExpectationRequest) GetTokenIds() [][]byte {
+func (m *IssueManifestRequest) GetChunks() []*IssueChunk {
 	if m != nil {
-		return m.TokenIds
+		return m.Chunks
 	}
 	return nil
 }
@@ -609,17 +1839,21 @@ type Header struct {
 	Nonce []byte `protobuf:"bytes,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
 	// Creator of the message.
 	// Typically, a marshaled msp.SerializedIdentity
-	Creator              []byte   `protobuf:"bytes,4,opt,name=creator,proto3" json:"creator,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Creator []byte `protobuf:"bytes,4,opt,name=creator,proto3" json:"creator,omitempty"`
+	// Extensions carry optional client metadata, such as the client version,
+	// application tags or a trace context. They are covered by the signature
+	// of the command.
+	Extensions           []*HeaderExtension `protobuf:"bytes,5,rep,name=extensions,proto3" json:"extensions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *Header) Reset()         { *m = Header{} }
 func (m *Header) String() string { return proto.CompactTextString(m) }
 func (*Header) ProtoMessage()    {}
 func (*Header) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{11}
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{32}
 }
 func (m *Header) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Header.Unmarshal(m, b)
@@ -667,6 +1901,61 @@ func (m *Header) GetCreator() []byte {
 	return nil
 }
 
+func (m *Header) GetExtensions() []*HeaderExtension {
+	if m != nil {
+		return m.Extensions
+	}
+	return nil
+}
+
+// HeaderExtension is a named piece of client metadata. The serialization of
+// the value is defined by the extension.
+type HeaderExtension struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HeaderExtension) Reset()         { *m = HeaderExtension{} }
+func (m *HeaderExtension) String() string { return proto.CompactTextString(m) }
+func (*HeaderExtension) ProtoMessage()    {}
+func (*HeaderExtension) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{33}
+}
+func (m *HeaderExtension) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HeaderExtension.Unmarshal(m, b)
+}
+func (m *HeaderExtension) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HeaderExtension.Marshal(b, m, deterministic)
+}
+func (dst *HeaderExtension) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeaderExtension.Merge(dst, src)
+}
+func (m *HeaderExtension) XXX_Size() int {
+	return xxx_messageInfo_HeaderExtension.Size(m)
+}
+func (m *HeaderExtension) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeaderExtension.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeaderExtension proto.InternalMessageInfo
+
+func (m *HeaderExtension) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *HeaderExtension) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
 // Command describes the type of operation that a client is requesting.
 type Command struct {
 	// Header is the header of this command
@@ -681,6 +1970,16 @@ type Command struct {
 	//	*Command_ApproveRequest
 	//	*Command_TransferFromRequest
 	//	*Command_ExpectationRequest
+	//	*Command_BalanceRequest
+	//	*Command_CreditRequest
+	//	*Command_DebitRequest
+	//	*Command_PauseRequest
+	//	*Command_ResumeRequest
+	//	*Command_ReferenceRequest
+	//	*Command_CapabilitiesRequest
+	//	*Command_IssueManifestRequest
+	//	*Command_SupplyAttestationRequest
+	//	*Command_CommitAttestationRequest
 	Payload              isCommand_Payload `protobuf_oneof:"payload"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
@@ -691,7 +1990,7 @@ func (m *Command) Reset()         { *m = Command{} }
 func (m *Command) String() string { return proto.CompactTextString(m) }
 func (*Command) ProtoMessage()    {}
 func (*Command) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{12}
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{34}
 }
 func (m *Command) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Command.Unmarshal(m, b)
@@ -750,6 +2049,46 @@ type Command_ExpectationRequest struct {
 	ExpectationRequest *ExpectationRequest `protobuf:"bytes,8,opt,name=expectation_request,json=expectationRequest,proto3,oneof"`
 }
 
+type Command_BalanceRequest struct {
+	BalanceRequest *BalanceRequest `protobuf:"bytes,9,opt,name=balance_request,json=balanceRequest,proto3,oneof"`
+}
+
+type Command_CreditRequest struct {
+	CreditRequest *CreditRequest `protobuf:"bytes,10,opt,name=credit_request,json=creditRequest,proto3,oneof"`
+}
+
+type Command_DebitRequest struct {
+	DebitRequest *DebitRequest `protobuf:"bytes,11,opt,name=debit_request,json=debitRequest,proto3,oneof"`
+}
+
+type Command_PauseRequest struct {
+	PauseRequest *PauseRequest `protobuf:"bytes,12,opt,name=pause_request,json=pauseRequest,proto3,oneof"`
+}
+
+type Command_ResumeRequest struct {
+	ResumeRequest *ResumeRequest `protobuf:"bytes,13,opt,name=resume_request,json=resumeRequest,proto3,oneof"`
+}
+
+type Command_ReferenceRequest struct {
+	ReferenceRequest *ReferenceRequest `protobuf:"bytes,14,opt,name=reference_request,json=referenceRequest,proto3,oneof"`
+}
+
+type Command_CapabilitiesRequest struct {
+	CapabilitiesRequest *CapabilitiesRequest `protobuf:"bytes,15,opt,name=capabilities_request,json=capabilitiesRequest,proto3,oneof"`
+}
+
+type Command_IssueManifestRequest struct {
+	IssueManifestRequest *IssueManifestRequest `protobuf:"bytes,16,opt,name=issue_manifest_request,json=issueManifestRequest,proto3,oneof"`
+}
+
+type Command_SupplyAttestationRequest struct {
+	SupplyAttestationRequest *SupplyAttestationRequest `protobuf:"bytes,17,opt,name=supply_attestation_request,json=supplyAttestationRequest,proto3,oneof"`
+}
+
+type Command_CommitAttestationRequest struct {
+	CommitAttestationRequest *CommitAttestationRequest `protobuf:"bytes,18,opt,name=commit_attestation_request,json=commitAttestationRequest,proto3,oneof"`
+}
+
 func (*Command_ImportRequest) isCommand_Payload() {}
 
 func (*Command_TransferRequest) isCommand_Payload() {}
@@ -764,6 +2103,26 @@ func (*Command_TransferFromRequest) isCommand_Payload() {}
 
 func (*Command_ExpectationRequest) isCommand_Payload() {}
 
+func (*Command_BalanceRequest) isCommand_Payload() {}
+
+func (*Command_CreditRequest) isCommand_Payload() {}
+
+func (*Command_DebitRequest) isCommand_Payload() {}
+
+func (*Command_PauseRequest) isCommand_Payload() {}
+
+func (*Command_ResumeRequest) isCommand_Payload() {}
+
+func (*Command_ReferenceRequest) isCommand_Payload() {}
+
+func (*Command_CapabilitiesRequest) isCommand_Payload() {}
+
+func (*Command_IssueManifestRequest) isCommand_Payload() {}
+
+func (*Command_SupplyAttestationRequest) isCommand_Payload() {}
+
+func (*Command_CommitAttestationRequest) isCommand_Payload() {}
+
 func (m *Command) GetPayload() isCommand_Payload {
 	if m != nil {
 		return m.Payload
@@ -820,6 +2179,76 @@ func (m *Command) GetExpectationRequest() *ExpectationRequest {
 	return nil
 }
 
+func (m *Command) GetBalanceRequest() *BalanceRequest {
+	if x, ok := m.GetPayload().(*Command_BalanceRequest); ok {
+		return x.BalanceRequest
+	}
+	return nil
+}
+
+func (m *Command) GetCreditRequest() *CreditRequest {
+	if x, ok := m.GetPayload().(*Command_CreditRequest); ok {
+		return x.CreditRequest
+	}
+	return nil
+}
+
+func (m *Command) GetDebitRequest() *DebitRequest {
+	if x, ok := m.GetPayload().(*Command_DebitRequest); ok {
+		return x.DebitRequest
+	}
+	return nil
+}
+
+func (m *Command) GetPauseRequest() *PauseRequest {
+	if x, ok := m.GetPayload().(*Command_PauseRequest); ok {
+		return x.PauseRequest
+	}
+	return nil
+}
+
+func (m *Command) GetResumeRequest() *ResumeRequest {
+	if x, ok := m.GetPayload().(*Command_ResumeRequest); ok {
+		return x.ResumeRequest
+	}
+	return nil
+}
+
+func (m *Command) GetReferenceRequest() *ReferenceRequest {
+	if x, ok := m.GetPayload().(*Command_ReferenceRequest); ok {
+		return x.ReferenceRequest
+	}
+	return nil
+}
+
+func (m *Command) GetCapabilitiesRequest() *CapabilitiesRequest {
+	if x, ok := m.GetPayload().(*Command_CapabilitiesRequest); ok {
+		return x.CapabilitiesRequest
+	}
+	return nil
+}
+
+func (m *Command) GetIssueManifestRequest() *IssueManifestRequest {
+	if x, ok := m.GetPayload().(*Command_IssueManifestRequest); ok {
+		return x.IssueManifestRequest
+	}
+	return nil
+}
+
+func (m *Command) GetSupplyAttestationRequest() *SupplyAttestationRequest {
+	if x, ok := m.GetPayload().(*Command_SupplyAttestationRequest); ok {
+		return x.SupplyAttestationRequest
+	}
+	return nil
+}
+
+func (m *Command) GetCommitAttestationRequest() *CommitAttestationRequest {
+	if x, ok := m.GetPayload().(*Command_CommitAttestationRequest); ok {
+		return x.CommitAttestationRequest
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Command) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Command_OneofMarshaler, _Command_OneofUnmarshaler, _Command_OneofSizer, []interface{}{
@@ -830,6 +2259,16 @@ func (*Command) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error
 		(*Command_ApproveRequest)(nil),
 		(*Command_TransferFromRequest)(nil),
 		(*Command_ExpectationRequest)(nil),
+		(*Command_BalanceRequest)(nil),
+		(*Command_CreditRequest)(nil),
+		(*Command_DebitRequest)(nil),
+		(*Command_PauseRequest)(nil),
+		(*Command_ResumeRequest)(nil),
+		(*Command_ReferenceRequest)(nil),
+		(*Command_CapabilitiesRequest)(nil),
+		(*Command_IssueManifestRequest)(nil),
+		(*Command_SupplyAttestationRequest)(nil),
+		(*Command_CommitAttestationRequest)(nil),
 	}
 }
 
@@ -872,6 +2311,56 @@ func _Command_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.ExpectationRequest); err != nil {
 			return err
 		}
+	case *Command_BalanceRequest:
+		b.EncodeVarint(9<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.BalanceRequest); err != nil {
+			return err
+		}
+	case *Command_CreditRequest:
+		b.EncodeVarint(10<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.CreditRequest); err != nil {
+			return err
+		}
+	case *Command_DebitRequest:
+		b.EncodeVarint(11<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.DebitRequest); err != nil {
+			return err
+		}
+	case *Command_PauseRequest:
+		b.EncodeVarint(12<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PauseRequest); err != nil {
+			return err
+		}
+	case *Command_ResumeRequest:
+		b.EncodeVarint(13<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ResumeRequest); err != nil {
+			return err
+		}
+	case *Command_ReferenceRequest:
+		b.EncodeVarint(14<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ReferenceRequest); err != nil {
+			return err
+		}
+	case *Command_CapabilitiesRequest:
+		b.EncodeVarint(15<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.CapabilitiesRequest); err != nil {
+			return err
+		}
+	case *Command_IssueManifestRequest:
+		b.EncodeVarint(16<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.IssueManifestRequest); err != nil {
+			return err
+		}
+	case *Command_SupplyAttestationRequest:
+		b.EncodeVarint(17<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SupplyAttestationRequest); err != nil {
+			return err
+		}
+	case *Command_CommitAttestationRequest:
+		b.EncodeVarint(18<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.CommitAttestationRequest); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Command.Payload has unexpected type %T", x)
@@ -938,6 +2427,86 @@ func _Command_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer
 		err := b.DecodeMessage(msg)
 		m.Payload = &Command_ExpectationRequest{msg}
 		return true, err
+	case 9: // payload.balance_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(BalanceRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_BalanceRequest{msg}
+		return true, err
+	case 10: // payload.credit_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(CreditRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_CreditRequest{msg}
+		return true, err
+	case 11: // payload.debit_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(DebitRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_DebitRequest{msg}
+		return true, err
+	case 12: // payload.pause_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PauseRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_PauseRequest{msg}
+		return true, err
+	case 13: // payload.resume_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResumeRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_ResumeRequest{msg}
+		return true, err
+	case 14: // payload.reference_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ReferenceRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_ReferenceRequest{msg}
+		return true, err
+	case 15: // payload.capabilities_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(CapabilitiesRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_CapabilitiesRequest{msg}
+		return true, err
+	case 16: // payload.issue_manifest_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(IssueManifestRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_IssueManifestRequest{msg}
+		return true, err
+	case 17: // payload.supply_attestation_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SupplyAttestationRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_SupplyAttestationRequest{msg}
+		return true, err
+	case 18: // payload.commit_attestation_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(CommitAttestationRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_CommitAttestationRequest{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -977,9 +2546,59 @@ func _Command_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
-	case *Command_ExpectationRequest:
-		s := proto.Size(x.ExpectationRequest)
-		n += 1 // tag and wire
+	case *Command_ExpectationRequest:
+		s := proto.Size(x.ExpectationRequest)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Command_BalanceRequest:
+		s := proto.Size(x.BalanceRequest)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Command_CreditRequest:
+		s := proto.Size(x.CreditRequest)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Command_DebitRequest:
+		s := proto.Size(x.DebitRequest)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Command_PauseRequest:
+		s := proto.Size(x.PauseRequest)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Command_ResumeRequest:
+		s := proto.Size(x.ResumeRequest)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Command_ReferenceRequest:
+		s := proto.Size(x.ReferenceRequest)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Command_CapabilitiesRequest:
+		s := proto.Size(x.CapabilitiesRequest)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Command_IssueManifestRequest:
+		s := proto.Size(x.IssueManifestRequest)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Command_SupplyAttestationRequest:
+		s := proto.Size(x.SupplyAttestationRequest)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Command_CommitAttestationRequest:
+		s := proto.Size(x.CommitAttestationRequest)
+		n += 2 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
 	case nil:
@@ -1004,7 +2623,7 @@ func (m *SignedCommand) Reset()         { *m = SignedCommand{} }
 func (m *SignedCommand) String() string { return proto.CompactTextString(m) }
 func (*SignedCommand) ProtoMessage()    {}
 func (*SignedCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{13}
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{35}
 }
 func (m *SignedCommand) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedCommand.Unmarshal(m, b)
@@ -1058,7 +2677,7 @@ func (m *CommandResponseHeader) Reset()         { *m = CommandResponseHeader{} }
 func (m *CommandResponseHeader) String() string { return proto.CompactTextString(m) }
 func (*CommandResponseHeader) ProtoMessage()    {}
 func (*CommandResponseHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{14}
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{36}
 }
 func (m *CommandResponseHeader) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandResponseHeader.Unmarshal(m, b)
@@ -1114,7 +2733,7 @@ func (m *Error) Reset()         { *m = Error{} }
 func (m *Error) String() string { return proto.CompactTextString(m) }
 func (*Error) ProtoMessage()    {}
 func (*Error) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{15}
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{37}
 }
 func (m *Error) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Error.Unmarshal(m, b)
@@ -1158,6 +2777,11 @@ type CommandResponse struct {
 	//	*CommandResponse_Err
 	//	*CommandResponse_TokenTransaction
 	//	*CommandResponse_UnspentTokens
+	//	*CommandResponse_Balances
+	//	*CommandResponse_ReferencedTransactions
+	//	*CommandResponse_ChannelCapabilities
+	//	*CommandResponse_SupplyAttestation
+	//	*CommandResponse_CommitAttestation
 	Payload              isCommandResponse_Payload `protobuf_oneof:"payload"`
 	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
 	XXX_unrecognized     []byte                    `json:"-"`
@@ -1168,7 +2792,7 @@ func (m *CommandResponse) Reset()         { *m = CommandResponse{} }
 func (m *CommandResponse) String() string { return proto.CompactTextString(m) }
 func (*CommandResponse) ProtoMessage()    {}
 func (*CommandResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{16}
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{38}
 }
 func (m *CommandResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandResponse.Unmarshal(m, b)
@@ -1211,12 +2835,42 @@ type CommandResponse_UnspentTokens struct {
 	UnspentTokens *UnspentTokens `protobuf:"bytes,4,opt,name=unspent_tokens,json=unspentTokens,proto3,oneof"`
 }
 
+type CommandResponse_Balances struct {
+	Balances *Balances `protobuf:"bytes,5,opt,name=balances,proto3,oneof"`
+}
+
+type CommandResponse_ReferencedTransactions struct {
+	ReferencedTransactions *ReferencedTransactions `protobuf:"bytes,6,opt,name=referenced_transactions,json=referencedTransactions,proto3,oneof"`
+}
+
+type CommandResponse_ChannelCapabilities struct {
+	ChannelCapabilities *ChannelCapabilities `protobuf:"bytes,7,opt,name=channel_capabilities,json=channelCapabilities,proto3,oneof"`
+}
+
+type CommandResponse_SupplyAttestation struct {
+	SupplyAttestation *SignedSupplyAttestation `protobuf:"bytes,8,opt,name=supply_attestation,json=supplyAttestation,proto3,oneof"`
+}
+
+type CommandResponse_CommitAttestation struct {
+	CommitAttestation *SignedCommitAttestation `protobuf:"bytes,9,opt,name=commit_attestation,json=commitAttestation,proto3,oneof"`
+}
+
 func (*CommandResponse_Err) isCommandResponse_Payload() {}
 
 func (*CommandResponse_TokenTransaction) isCommandResponse_Payload() {}
 
 func (*CommandResponse_UnspentTokens) isCommandResponse_Payload() {}
 
+func (*CommandResponse_Balances) isCommandResponse_Payload() {}
+
+func (*CommandResponse_ReferencedTransactions) isCommandResponse_Payload() {}
+
+func (*CommandResponse_ChannelCapabilities) isCommandResponse_Payload() {}
+
+func (*CommandResponse_SupplyAttestation) isCommandResponse_Payload() {}
+
+func (*CommandResponse_CommitAttestation) isCommandResponse_Payload() {}
+
 func (m *CommandResponse) GetPayload() isCommandResponse_Payload {
 	if m != nil {
 		return m.Payload
@@ -1245,12 +2899,52 @@ func (m *CommandResponse) GetUnspentTokens() *UnspentTokens {
 	return nil
 }
 
+func (m *CommandResponse) GetBalances() *Balances {
+	if x, ok := m.GetPayload().(*CommandResponse_Balances); ok {
+		return x.Balances
+	}
+	return nil
+}
+
+func (m *CommandResponse) GetReferencedTransactions() *ReferencedTransactions {
+	if x, ok := m.GetPayload().(*CommandResponse_ReferencedTransactions); ok {
+		return x.ReferencedTransactions
+	}
+	return nil
+}
+
+func (m *CommandResponse) GetChannelCapabilities() *ChannelCapabilities {
+	if x, ok := m.GetPayload().(*CommandResponse_ChannelCapabilities); ok {
+		return x.ChannelCapabilities
+	}
+	return nil
+}
+
+func (m *CommandResponse) GetSupplyAttestation() *SignedSupplyAttestation {
+	if x, ok := m.GetPayload().(*CommandResponse_SupplyAttestation); ok {
+		return x.SupplyAttestation
+	}
+	return nil
+}
+
+func (m *CommandResponse) GetCommitAttestation() *SignedCommitAttestation {
+	if x, ok := m.GetPayload().(*CommandResponse_CommitAttestation); ok {
+		return x.CommitAttestation
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*CommandResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _CommandResponse_OneofMarshaler, _CommandResponse_OneofUnmarshaler, _CommandResponse_OneofSizer, []interface{}{
 		(*CommandResponse_Err)(nil),
 		(*CommandResponse_TokenTransaction)(nil),
 		(*CommandResponse_UnspentTokens)(nil),
+		(*CommandResponse_Balances)(nil),
+		(*CommandResponse_ReferencedTransactions)(nil),
+		(*CommandResponse_ChannelCapabilities)(nil),
+		(*CommandResponse_SupplyAttestation)(nil),
+		(*CommandResponse_CommitAttestation)(nil),
 	}
 }
 
@@ -1273,6 +2967,31 @@ func _CommandResponse_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.UnspentTokens); err != nil {
 			return err
 		}
+	case *CommandResponse_Balances:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Balances); err != nil {
+			return err
+		}
+	case *CommandResponse_ReferencedTransactions:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ReferencedTransactions); err != nil {
+			return err
+		}
+	case *CommandResponse_ChannelCapabilities:
+		b.EncodeVarint(7<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ChannelCapabilities); err != nil {
+			return err
+		}
+	case *CommandResponse_SupplyAttestation:
+		b.EncodeVarint(8<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SupplyAttestation); err != nil {
+			return err
+		}
+	case *CommandResponse_CommitAttestation:
+		b.EncodeVarint(9<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.CommitAttestation); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("CommandResponse.Payload has unexpected type %T", x)
@@ -1307,6 +3026,46 @@ func _CommandResponse_OneofUnmarshaler(msg proto.Message, tag, wire int, b *prot
 		err := b.DecodeMessage(msg)
 		m.Payload = &CommandResponse_UnspentTokens{msg}
 		return true, err
+	case 5: // payload.balances
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Balances)
+		err := b.DecodeMessage(msg)
+		m.Payload = &CommandResponse_Balances{msg}
+		return true, err
+	case 6: // payload.referenced_transactions
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ReferencedTransactions)
+		err := b.DecodeMessage(msg)
+		m.Payload = &CommandResponse_ReferencedTransactions{msg}
+		return true, err
+	case 7: // payload.channel_capabilities
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ChannelCapabilities)
+		err := b.DecodeMessage(msg)
+		m.Payload = &CommandResponse_ChannelCapabilities{msg}
+		return true, err
+	case 8: // payload.supply_attestation
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SignedSupplyAttestation)
+		err := b.DecodeMessage(msg)
+		m.Payload = &CommandResponse_SupplyAttestation{msg}
+		return true, err
+	case 9: // payload.commit_attestation
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SignedCommitAttestation)
+		err := b.DecodeMessage(msg)
+		m.Payload = &CommandResponse_CommitAttestation{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -1331,6 +3090,31 @@ func _CommandResponse_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *CommandResponse_Balances:
+		s := proto.Size(x.Balances)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *CommandResponse_ReferencedTransactions:
+		s := proto.Size(x.ReferencedTransactions)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *CommandResponse_ChannelCapabilities:
+		s := proto.Size(x.ChannelCapabilities)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *CommandResponse_SupplyAttestation:
+		s := proto.Size(x.SupplyAttestation)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *CommandResponse_CommitAttestation:
+		s := proto.Size(x.CommitAttestation)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1353,7 +3137,7 @@ func (m *SignedCommandResponse) Reset()         { *m = SignedCommandResponse{} }
 func (m *SignedCommandResponse) String() string { return proto.CompactTextString(m) }
 func (*SignedCommandResponse) ProtoMessage()    {}
 func (*SignedCommandResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{17}
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{39}
 }
 func (m *SignedCommandResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedCommandResponse.Unmarshal(m, b)
@@ -1387,25 +3171,261 @@ func (m *SignedCommandResponse) GetSignature() []byte {
 	return nil
 }
 
+// SubmitRequest asks a gateway peer to submit a token transaction to the
+// ordering service on behalf of the client
+type SubmitRequest struct {
+	// Envelope is the serialised version of the common.Envelope carrying the
+	// token transaction, signed by the client
+	Envelope []byte `protobuf:"bytes,1,opt,name=envelope,proto3" json:"envelope,omitempty"`
+	// WaitForCommit asks the gateway to report the commit of the transaction
+	WaitForCommit        bool     `protobuf:"varint,2,opt,name=wait_for_commit,json=waitForCommit,proto3" json:"wait_for_commit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubmitRequest) Reset()         { *m = SubmitRequest{} }
+func (m *SubmitRequest) String() string { return proto.CompactTextString(m) }
+func (*SubmitRequest) ProtoMessage()    {}
+func (*SubmitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{40}
+}
+func (m *SubmitRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubmitRequest.Unmarshal(m, b)
+}
+func (m *SubmitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubmitRequest.Marshal(b, m, deterministic)
+}
+func (dst *SubmitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubmitRequest.Merge(dst, src)
+}
+func (m *SubmitRequest) XXX_Size() int {
+	return xxx_messageInfo_SubmitRequest.Size(m)
+}
+func (m *SubmitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubmitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubmitRequest proto.InternalMessageInfo
+
+func (m *SubmitRequest) GetEnvelope() []byte {
+	if m != nil {
+		return m.Envelope
+	}
+	return nil
+}
+
+func (m *SubmitRequest) GetWaitForCommit() bool {
+	if m != nil {
+		return m.WaitForCommit
+	}
+	return false
+}
+
+// SubmitStatus reports the progress of a submitted transaction
+type SubmitStatus struct {
+	// TxId is the ID of the transaction
+	TxId string `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	// Phase is the phase reached by the transaction
+	Phase SubmitStatus_Phase `protobuf:"varint,2,opt,name=phase,proto3,enum=protos.SubmitStatus_Phase" json:"phase,omitempty"`
+	// ValidationCode is the validation code of a committed transaction
+	ValidationCode string `protobuf:"bytes,3,opt,name=validation_code,json=validationCode,proto3" json:"validation_code,omitempty"`
+	// Message describes why the gateway gave up on the transaction
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	// InputHotspots are the inputs of an invalid transaction recently found
+	// in conflicts between transactions, if the gateway advises clients of
+	// them
+	InputHotspots        []*InputHotspot `protobuf:"bytes,5,rep,name=input_hotspots,json=inputHotspots,proto3" json:"input_hotspots,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *SubmitStatus) Reset()         { *m = SubmitStatus{} }
+func (m *SubmitStatus) String() string { return proto.CompactTextString(m) }
+func (*SubmitStatus) ProtoMessage()    {}
+func (*SubmitStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{41}
+}
+func (m *SubmitStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubmitStatus.Unmarshal(m, b)
+}
+func (m *SubmitStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubmitStatus.Marshal(b, m, deterministic)
+}
+func (dst *SubmitStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubmitStatus.Merge(dst, src)
+}
+func (m *SubmitStatus) XXX_Size() int {
+	return xxx_messageInfo_SubmitStatus.Size(m)
+}
+func (m *SubmitStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubmitStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubmitStatus proto.InternalMessageInfo
+
+func (m *SubmitStatus) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *SubmitStatus) GetPhase() SubmitStatus_Phase {
+	if m != nil {
+		return m.Phase
+	}
+	return SubmitStatus_UNKNOWN
+}
+
+func (m *SubmitStatus) GetValidationCode() string {
+	if m != nil {
+		return m.ValidationCode
+	}
+	return ""
+}
+
+func (m *SubmitStatus) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *SubmitStatus) GetInputHotspots() []*InputHotspot {
+	if m != nil {
+		return m.InputHotspots
+	}
+	return nil
+}
+
+// InputHotspot is an input of token transactions recently found in conflicts
+// between them, such as transactions spending it at the same time
+type InputHotspot struct {
+	// Namespace is the namespace of the ledger key of the input
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Key is the ledger key marking the input as spent
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// Conflicts is the number of transactions recently invalidated by
+	// conflicts on the key
+	Conflicts uint64 `protobuf:"varint,3,opt,name=conflicts,proto3" json:"conflicts,omitempty"`
+	// TxIds are the IDs of the transactions most recently invalidated by
+	// conflicts on the key, the most recent first
+	TxIds []string `protobuf:"bytes,4,rep,name=tx_ids,json=txIds,proto3" json:"tx_ids,omitempty"`
+	// TokenId is the ID of the token spent by the input, as listed by
+	// ListTokens
+	TokenId              []byte   `protobuf:"bytes,5,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InputHotspot) Reset()         { *m = InputHotspot{} }
+func (m *InputHotspot) String() string { return proto.CompactTextString(m) }
+func (*InputHotspot) ProtoMessage()    {}
+func (*InputHotspot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_51b61c964c3dc6d0, []int{42}
+}
+func (m *InputHotspot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InputHotspot.Unmarshal(m, b)
+}
+func (m *InputHotspot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InputHotspot.Marshal(b, m, deterministic)
+}
+func (dst *InputHotspot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InputHotspot.Merge(dst, src)
+}
+func (m *InputHotspot) XXX_Size() int {
+	return xxx_messageInfo_InputHotspot.Size(m)
+}
+func (m *InputHotspot) XXX_DiscardUnknown() {
+	xxx_messageInfo_InputHotspot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InputHotspot proto.InternalMessageInfo
+
+func (m *InputHotspot) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *InputHotspot) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *InputHotspot) GetConflicts() uint64 {
+	if m != nil {
+		return m.Conflicts
+	}
+	return 0
+}
+
+func (m *InputHotspot) GetTxIds() []string {
+	if m != nil {
+		return m.TxIds
+	}
+	return nil
+}
+
+func (m *InputHotspot) GetTokenId() []byte {
+	if m != nil {
+		return m.TokenId
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*TokenToIssue)(nil), "protos.TokenToIssue")
 	proto.RegisterType((*RecipientTransferShare)(nil), "protos.RecipientTransferShare")
 	proto.RegisterType((*TokenOutput)(nil), "protos.TokenOutput")
 	proto.RegisterType((*UnspentTokens)(nil), "protos.UnspentTokens")
 	proto.RegisterType((*ListRequest)(nil), "protos.ListRequest")
+	proto.RegisterType((*PseudonymProof)(nil), "protos.PseudonymProof")
+	proto.RegisterType((*ReferenceRequest)(nil), "protos.ReferenceRequest")
+	proto.RegisterType((*ReferencedTransaction)(nil), "protos.ReferencedTransaction")
+	proto.RegisterType((*ReferencedTransactions)(nil), "protos.ReferencedTransactions")
+	proto.RegisterType((*CapabilitiesRequest)(nil), "protos.CapabilitiesRequest")
+	proto.RegisterType((*ChannelCapabilities)(nil), "protos.ChannelCapabilities")
+	proto.RegisterMapType((map[string][]byte)(nil), "protos.ChannelCapabilities.TokenEncryptionKeysEntry")
+	proto.RegisterType((*SupplyAttestationRequest)(nil), "protos.SupplyAttestationRequest")
+	proto.RegisterType((*SupplyAttestation)(nil), "protos.SupplyAttestation")
+	proto.RegisterType((*TypeSupply)(nil), "protos.TypeSupply")
+	proto.RegisterType((*SignedSupplyAttestation)(nil), "protos.SignedSupplyAttestation")
+	proto.RegisterType((*CommitAttestationRequest)(nil), "protos.CommitAttestationRequest")
+	proto.RegisterType((*CommitAttestation)(nil), "protos.CommitAttestation")
+	proto.RegisterType((*SignedCommitAttestation)(nil), "protos.SignedCommitAttestation")
 	proto.RegisterType((*ImportRequest)(nil), "protos.ImportRequest")
 	proto.RegisterType((*TransferRequest)(nil), "protos.TransferRequest")
 	proto.RegisterType((*RedeemRequest)(nil), "protos.RedeemRequest")
 	proto.RegisterType((*AllowanceRecipientShare)(nil), "protos.AllowanceRecipientShare")
 	proto.RegisterType((*ApproveRequest)(nil), "protos.ApproveRequest")
 	proto.RegisterType((*ExpectationRequest)(nil), "protos.ExpectationRequest")
+	proto.RegisterType((*BalanceRequest)(nil), "protos.BalanceRequest")
+	proto.RegisterType((*Balance)(nil), "protos.Balance")
+	proto.RegisterType((*Balances)(nil), "protos.Balances")
+	proto.RegisterType((*CreditRequest)(nil), "protos.CreditRequest")
+	proto.RegisterType((*DebitRequest)(nil), "protos.DebitRequest")
+	proto.RegisterType((*PauseRequest)(nil), "protos.PauseRequest")
+	proto.RegisterType((*ResumeRequest)(nil), "protos.ResumeRequest")
+	proto.RegisterType((*IssueManifestRequest)(nil), "protos.IssueManifestRequest")
 	proto.RegisterType((*Header)(nil), "protos.Header")
+	proto.RegisterType((*HeaderExtension)(nil), "protos.HeaderExtension")
 	proto.RegisterType((*Command)(nil), "protos.Command")
 	proto.RegisterType((*SignedCommand)(nil), "protos.SignedCommand")
 	proto.RegisterType((*CommandResponseHeader)(nil), "protos.CommandResponseHeader")
 	proto.RegisterType((*Error)(nil), "protos.Error")
 	proto.RegisterType((*CommandResponse)(nil), "protos.CommandResponse")
 	proto.RegisterType((*SignedCommandResponse)(nil), "protos.SignedCommandResponse")
+	proto.RegisterType((*SubmitRequest)(nil), "protos.SubmitRequest")
+	proto.RegisterType((*SubmitStatus)(nil), "protos.SubmitStatus")
+	proto.RegisterType((*InputHotspot)(nil), "protos.InputHotspot")
+	proto.RegisterEnum("protos.SubmitStatus_Phase", SubmitStatus_Phase_name, SubmitStatus_Phase_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1488,72 +3508,294 @@ var _Prover_serviceDesc = grpc.ServiceDesc{
 	Metadata: "token/prover.proto",
 }
 
-func init() { proto.RegisterFile("token/prover.proto", fileDescriptor_prover_aacdf31643d38144) }
-
-var fileDescriptor_prover_aacdf31643d38144 = []byte{
-	// 1010 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xdd, 0x6f, 0x1b, 0x45,
-	0x10, 0xf7, 0xd9, 0xae, 0x13, 0x8f, 0xbf, 0xd2, 0x4d, 0xd3, 0x9c, 0x0c, 0x69, 0xd3, 0x43, 0x42,
-	0x11, 0x1f, 0xb6, 0x14, 0x04, 0xaa, 0xa0, 0xaa, 0x48, 0xa1, 0x70, 0x41, 0x54, 0xb4, 0x1b, 0xf3,
-	0x82, 0x90, 0xac, 0xcd, 0xdd, 0xc6, 0x5e, 0xe1, 0xbb, 0xbd, 0xee, 0xee, 0x01, 0xe1, 0x0f, 0xe0,
-	0x0d, 0x24, 0x1e, 0x79, 0xe0, 0xff, 0xe4, 0x11, 0xdd, 0x7e, 0x9c, 0xef, 0xdc, 0xd0, 0x06, 0x95,
-	0x27, 0x7b, 0x67, 0x66, 0x67, 0x7e, 0x3b, 0xfb, 0xfb, 0xcd, 0x1e, 0x20, 0xc5, 0x7f, 0xa0, 0xe9,
-	0x34, 0x13, 0xfc, 0x47, 0x2a, 0x26, 0x99, 0xe0, 0x8a, 0xa3, 0x8e, 0xfe, 0x91, 0xe3, 0xbb, 0x0b,
-	0xce, 0x17, 0x2b, 0x3a, 0xd5, 0xcb, 0xf3, 0xfc, 0x62, 0xaa, 0x58, 0x42, 0xa5, 0x22, 0x49, 0x66,
-	0x02, 0xc7, 0xbe, 0xd9, 0x4c, 0x7f, 0xce, 0x68, 0xa4, 0x88, 0x62, 0x3c, 0x95, 0xd6, 0xb3, 0x6f,
-	0x3c, 0x4a, 0x90, 0x54, 0x92, 0xa8, 0xf0, 0x18, 0x47, 0xf0, 0x3d, 0xf4, 0x67, 0x85, 0x6b, 0xc6,
-	0x4f, 0xa5, 0xcc, 0x29, 0x7a, 0x13, 0xba, 0x82, 0x46, 0x2c, 0x63, 0x34, 0x55, 0xbe, 0x77, 0xe8,
-	0x1d, 0xf5, 0xf1, 0xda, 0x80, 0x10, 0xb4, 0xd5, 0x65, 0x46, 0xfd, 0xe6, 0xa1, 0x77, 0xd4, 0xc5,
-	0xfa, 0x3f, 0x1a, 0xc3, 0xf6, 0xf3, 0x9c, 0xa4, 0x8a, 0xa9, 0x4b, 0xbf, 0x75, 0xe8, 0x1d, 0xb5,
-	0x71, 0xb9, 0x0e, 0x30, 0xdc, 0xc6, 0x6e, 0xf3, 0xac, 0xa8, 0x7d, 0x41, 0xc5, 0xd9, 0x92, 0x88,
-	0x57, 0xd5, 0xa9, 0xe6, 0x6c, 0x6e, 0xe4, 0x7c, 0x02, 0x3d, 0x8d, 0xf8, 0x9b, 0x5c, 0x65, 0xb9,
-	0x42, 0x43, 0x68, 0xb2, 0xd8, 0x66, 0x68, 0xb2, 0xf8, 0x3f, 0x43, 0x7c, 0x00, 0x83, 0x6f, 0x53,
-	0x99, 0x15, 0x00, 0x8b, 0xac, 0x12, 0xbd, 0x0b, 0x1d, 0xdd, 0x2c, 0xe9, 0x7b, 0x87, 0xad, 0xa3,
-	0xde, 0xf1, 0xae, 0xe9, 0x94, 0x9c, 0x54, 0xaa, 0x62, 0x1b, 0x12, 0xbc, 0x0f, 0xbd, 0xaf, 0x99,
-	0x54, 0x98, 0x3e, 0xcf, 0xa9, 0x54, 0xe8, 0x0e, 0x40, 0x24, 0x68, 0x4c, 0x53, 0xc5, 0xc8, 0xca,
-	0x82, 0xaa, 0x58, 0x82, 0x04, 0x06, 0xa7, 0x49, 0xc6, 0xc5, 0x75, 0x37, 0xa0, 0x07, 0x30, 0x32,
-	0x95, 0xe6, 0x8a, 0xcf, 0x59, 0x71, 0x43, 0x7e, 0x53, 0xa3, 0xba, 0x55, 0x43, 0x65, 0x6f, 0x0f,
-	0x0f, 0x4c, 0xb0, 0x5d, 0x06, 0xbf, 0x7a, 0x30, 0x72, 0x6d, 0xbf, 0x6e, 0xc5, 0x37, 0xa0, 0xab,
-	0x93, 0xcc, 0x59, 0x2c, 0x75, 0xad, 0x3e, 0xde, 0xd6, 0x86, 0xd3, 0x58, 0xa2, 0x8f, 0xa0, 0x23,
-	0x8b, 0xeb, 0x93, 0x7e, 0x4b, 0xa3, 0xb8, 0xe3, 0x50, 0x5c, 0x7d, 0xcb, 0xd8, 0x46, 0x07, 0xbf,
-	0xc0, 0x00, 0xd3, 0x98, 0xd2, 0xe4, 0x7f, 0x41, 0xf1, 0x1e, 0x20, 0x77, 0x7d, 0x45, 0x5b, 0x84,
-	0xce, 0x6c, 0x2f, 0x76, 0xc7, 0x79, 0x66, 0xdc, 0x54, 0x0c, 0xce, 0x60, 0xff, 0x64, 0xb5, 0xe2,
-	0x3f, 0x91, 0x34, 0xa2, 0x25, 0xcc, 0xd7, 0x25, 0xe1, 0x9f, 0x1e, 0x0c, 0x4f, 0x32, 0xad, 0xd2,
-	0xeb, 0x1e, 0xe9, 0x2b, 0xd8, 0x21, 0x0e, 0xc7, 0xdc, 0x76, 0xd1, 0xdc, 0xe5, 0x5d, 0xd7, 0xc5,
-	0x7f, 0xc1, 0x89, 0x47, 0xe5, 0x46, 0xbd, 0x96, 0xf5, 0xf6, 0xb4, 0xea, 0xed, 0x09, 0x7e, 0xf3,
-	0x00, 0x3d, 0x5e, 0x8f, 0x80, 0xeb, 0xe2, 0xfb, 0x18, 0x7a, 0x95, 0xc1, 0xa1, 0x4f, 0xdc, 0x3b,
-	0xf6, 0x6b, 0x34, 0xab, 0x66, 0xad, 0x06, 0xbf, 0x1c, 0xcf, 0x1f, 0x1e, 0x74, 0x42, 0x4a, 0x62,
-	0x2a, 0xd0, 0x7d, 0xe8, 0x96, 0x33, 0x4b, 0x43, 0xe8, 0x1d, 0x8f, 0x27, 0x66, 0xaa, 0x4d, 0xdc,
-	0x54, 0x9b, 0xcc, 0x5c, 0x04, 0x5e, 0x07, 0xa3, 0x03, 0x80, 0x68, 0x49, 0xd2, 0x94, 0xae, 0xe6,
-	0x2c, 0xb6, 0xe2, 0xee, 0x5a, 0xcb, 0x69, 0x8c, 0x6e, 0xc1, 0x8d, 0x94, 0xa7, 0x11, 0xd5, 0x2c,
-	0xe8, 0x63, 0xb3, 0x40, 0x3e, 0x6c, 0x45, 0x82, 0x12, 0xc5, 0x85, 0xdf, 0xd6, 0x76, 0xb7, 0x0c,
-	0xfe, 0x6a, 0xc3, 0xd6, 0x67, 0x3c, 0x49, 0x48, 0x1a, 0xa3, 0xb7, 0xa1, 0xb3, 0xd4, 0xf0, 0x2c,
-	0xa2, 0xa1, 0x3b, 0xb3, 0x01, 0x8d, 0xad, 0x17, 0x3d, 0x84, 0x21, 0xd3, 0xe2, 0x9d, 0x0b, 0xd3,
-	0x52, 0xdb, 0xa3, 0x3d, 0x17, 0x5f, 0x93, 0x76, 0xd8, 0xc0, 0x03, 0x56, 0xd3, 0xfa, 0xe7, 0xb0,
-	0xa3, 0xac, 0x3a, 0xca, 0x0c, 0x2d, 0x9d, 0x61, 0xbf, 0xec, 0x72, 0x5d, 0xac, 0x61, 0x03, 0x8f,
-	0xd4, 0x86, 0x7e, 0xef, 0x43, 0x7f, 0xc5, 0xe4, 0x1a, 0x43, 0x5b, 0x67, 0x28, 0x87, 0x54, 0x65,
-	0x1a, 0x85, 0x0d, 0xdc, 0x5b, 0x55, 0x86, 0xd3, 0x43, 0x18, 0x1a, 0xa9, 0x94, 0x7b, 0x6f, 0xd4,
-	0xf1, 0xd7, 0x24, 0x5a, 0xe0, 0x17, 0x35, 0xcd, 0x9e, 0xc0, 0x88, 0x18, 0xca, 0x97, 0x09, 0x3a,
-	0x3a, 0xc1, 0xed, 0x92, 0xbf, 0x35, 0x45, 0x84, 0x0d, 0x3c, 0x24, 0x75, 0x8d, 0x3c, 0x81, 0xbd,
-	0xb2, 0x05, 0x17, 0x82, 0xaf, 0x91, 0x6c, 0xbd, 0xaa, 0x0f, 0xbb, 0x6e, 0xdf, 0x17, 0x82, 0x27,
-	0xeb, 0x74, 0xbb, 0x15, 0x16, 0x96, 0xc9, 0xb6, 0x2d, 0xb1, 0x6c, 0xb2, 0x17, 0xb5, 0x10, 0x36,
-	0x30, 0xa2, 0x2f, 0x58, 0x1f, 0x75, 0x61, 0x2b, 0x23, 0x97, 0x2b, 0x4e, 0xe2, 0xe0, 0x4b, 0x18,
-	0x9c, 0xb1, 0x45, 0x4a, 0x63, 0x47, 0x92, 0x82, 0x4a, 0xe6, 0xaf, 0x95, 0x8e, 0x5b, 0x16, 0x43,
-	0x44, 0xb2, 0x45, 0x4a, 0x54, 0x2e, 0xcc, 0xab, 0xd3, 0xc7, 0x6b, 0x43, 0xf0, 0xbb, 0x07, 0x7b,
-	0x36, 0x07, 0xa6, 0x32, 0xe3, 0xa9, 0xa4, 0xaf, 0xad, 0x85, 0x7b, 0xd0, 0xb7, 0xc5, 0xe7, 0x4b,
-	0x22, 0x97, 0xb6, 0x68, 0xcf, 0xda, 0x42, 0x22, 0x97, 0x55, 0xe6, 0xb7, 0xea, 0xcc, 0xff, 0x04,
-	0x6e, 0x3c, 0x16, 0x82, 0x8b, 0x22, 0x24, 0xa1, 0x52, 0x92, 0x05, 0xd5, 0xd5, 0xbb, 0xd8, 0x2d,
-	0x0b, 0x8f, 0xed, 0x83, 0x4d, 0x5d, 0xb6, 0xe5, 0x6f, 0x0f, 0x46, 0x1b, 0xa7, 0x41, 0x1f, 0x6e,
-	0xc8, 0xe7, 0xc0, 0xf5, 0xfd, 0xca, 0x63, 0x97, 0x6a, 0xba, 0x07, 0x2d, 0x2a, 0x84, 0x95, 0xd0,
-	0xa0, 0xbc, 0xab, 0x02, 0x5a, 0xd8, 0xc0, 0x85, 0x0f, 0x7d, 0x0a, 0x37, 0xcd, 0x54, 0xa9, 0x7c,
-	0xb6, 0x58, 0xc5, 0xdc, 0xb4, 0xef, 0xde, 0xda, 0x11, 0x36, 0xf0, 0x8e, 0xda, 0xb0, 0x15, 0x94,
-	0xcf, 0xcd, 0xe3, 0x3e, 0xb7, 0x6f, 0x7a, 0xbb, 0x4e, 0xf9, 0xda, 0xd3, 0x5f, 0x50, 0x3e, 0xaf,
-	0x1a, 0xaa, 0x8c, 0x78, 0x06, 0x7b, 0x35, 0x46, 0x94, 0xe7, 0x1f, 0xc3, 0xb6, 0xb0, 0xff, 0x2d,
-	0x35, 0xca, 0xf5, 0xcb, 0xb9, 0x71, 0x8c, 0xa1, 0xf3, 0x54, 0x7f, 0xe7, 0xa1, 0x10, 0x86, 0x4f,
-	0x05, 0x8f, 0xa8, 0x94, 0x8e, 0x6f, 0x25, 0xc2, 0x5a, 0xd1, 0xf1, 0xc1, 0x95, 0x66, 0x87, 0x25,
-	0x68, 0x3c, 0x7a, 0x06, 0x6f, 0x71, 0xb1, 0x98, 0x2c, 0x2f, 0x33, 0x2a, 0x56, 0x34, 0x5e, 0x50,
-	0x31, 0xb9, 0x20, 0xe7, 0x82, 0x45, 0x6e, 0xa3, 0xee, 0xc3, 0x77, 0xef, 0x2c, 0x98, 0x5a, 0xe6,
-	0xe7, 0x93, 0x88, 0x27, 0xd3, 0x4a, 0xec, 0xd4, 0xc4, 0x9a, 0x2f, 0x4c, 0x39, 0xd5, 0xb1, 0xe7,
-	0xe6, 0xf3, 0xf3, 0x83, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x10, 0x78, 0x90, 0x5b, 0x9b, 0x0a,
-	0x00, 0x00,
+// GatewayClient is the client API for Gateway service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type GatewayClient interface {
+	// Submit submits the transaction and streams its progress back to the
+	// client. The gateway keeps submitting the transaction if the client
+	// goes away.
+	Submit(ctx context.Context, in *SubmitRequest, opts ...grpc.CallOption) (Gateway_SubmitClient, error)
+	// AttestCommit returns the attestation of the commit of the transaction
+	// of the commit attestation request of the command, signed by the peer,
+	// so that clients can collect the attestations of several peers without
+	// the prover service.
+	AttestCommit(ctx context.Context, in *SignedCommand, opts ...grpc.CallOption) (*SignedCommitAttestation, error)
+}
+
+type gatewayClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewGatewayClient(cc *grpc.ClientConn) GatewayClient {
+	return &gatewayClient{cc}
+}
+
+func (c *gatewayClient) Submit(ctx context.Context, in *SubmitRequest, opts ...grpc.CallOption) (Gateway_SubmitClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Gateway_serviceDesc.Streams[0], "/protos.Gateway/Submit", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gatewaySubmitClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Gateway_SubmitClient interface {
+	Recv() (*SubmitStatus, error)
+	grpc.ClientStream
+}
+
+type gatewaySubmitClient struct {
+	grpc.ClientStream
+}
+
+func (x *gatewaySubmitClient) Recv() (*SubmitStatus, error) {
+	m := new(SubmitStatus)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gatewayClient) AttestCommit(ctx context.Context, in *SignedCommand, opts ...grpc.CallOption) (*SignedCommitAttestation, error) {
+	out := new(SignedCommitAttestation)
+	err := c.cc.Invoke(ctx, "/protos.Gateway/AttestCommit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GatewayServer is the server API for Gateway service.
+type GatewayServer interface {
+	// Submit submits the transaction and streams its progress back to the
+	// client. The gateway keeps submitting the transaction if the client
+	// goes away.
+	Submit(*SubmitRequest, Gateway_SubmitServer) error
+	// AttestCommit returns the attestation of the commit of the transaction
+	// of the commit attestation request of the command, signed by the peer,
+	// so that clients can collect the attestations of several peers without
+	// the prover service.
+	AttestCommit(context.Context, *SignedCommand) (*SignedCommitAttestation, error)
+}
+
+func RegisterGatewayServer(s *grpc.Server, srv GatewayServer) {
+	s.RegisterService(&_Gateway_serviceDesc, srv)
+}
+
+func _Gateway_Submit_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubmitRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GatewayServer).Submit(m, &gatewaySubmitServer{stream})
+}
+
+type Gateway_SubmitServer interface {
+	Send(*SubmitStatus) error
+	grpc.ServerStream
+}
+
+type gatewaySubmitServer struct {
+	grpc.ServerStream
+}
+
+func (x *gatewaySubmitServer) Send(m *SubmitStatus) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Gateway_AttestCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedCommand)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServer).AttestCommit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Gateway/AttestCommit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServer).AttestCommit(ctx, req.(*SignedCommand))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Gateway_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "protos.Gateway",
+	HandlerType: (*GatewayServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AttestCommit",
+			Handler:    _Gateway_AttestCommit_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Submit",
+			Handler:       _Gateway_Submit_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "token/prover.proto",
+}
+
+func init() { proto.RegisterFile("token/prover.proto", fileDescriptor_prover_51b61c964c3dc6d0) }
+
+var fileDescriptor_prover_51b61c964c3dc6d0 = []byte{
+	// 2348 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x39, 0xe9, 0x72, 0x1b, 0xc7,
+	0xd1, 0x38, 0x88, 0xab, 0x71, 0x72, 0x48, 0x4a, 0x30, 0x2d, 0xc9, 0xd4, 0xaa, 0xca, 0x9f, 0xbe,
+	0x24, 0x05, 0xaa, 0xa4, 0x38, 0x52, 0x59, 0x2a, 0x57, 0x28, 0x92, 0x12, 0x10, 0xeb, 0xa0, 0x87,
+	0x74, 0x5c, 0x4e, 0xaa, 0x82, 0x0c, 0x76, 0x07, 0xc0, 0x96, 0x80, 0xdd, 0xf5, 0xce, 0x40, 0x22,
+	0x52, 0x95, 0x37, 0xc8, 0xf1, 0x3b, 0x3f, 0xf2, 0x0a, 0x79, 0x81, 0xbc, 0x41, 0xfe, 0xe6, 0x69,
+	0xf2, 0x2f, 0x35, 0xc7, 0xee, 0xce, 0xe2, 0x10, 0xa1, 0xc8, 0xbf, 0xb0, 0xd3, 0x3d, 0xd3, 0xdd,
+	0xd3, 0xf7, 0x34, 0x00, 0x71, 0xff, 0x0d, 0xf5, 0x0e, 0x83, 0xd0, 0x7f, 0x4b, 0xc3, 0x4e, 0x10,
+	0xfa, 0xdc, 0x47, 0x45, 0xf9, 0xc3, 0xf6, 0x3f, 0x1b, 0xf9, 0xfe, 0x68, 0x42, 0x0f, 0xe5, 0x72,
+	0x30, 0x1b, 0x1e, 0x72, 0x77, 0x4a, 0x19, 0x27, 0xd3, 0x40, 0x6d, 0xdc, 0x6f, 0xab, 0xc3, 0xf4,
+	0x32, 0xa0, 0x36, 0x27, 0xdc, 0xf5, 0x3d, 0xa6, 0x31, 0xd7, 0x15, 0x86, 0x87, 0xc4, 0x63, 0xc4,
+	0x16, 0x18, 0x85, 0xb0, 0x2e, 0xa1, 0x76, 0x21, 0x50, 0x17, 0x7e, 0x8f, 0xb1, 0x19, 0x45, 0x37,
+	0xa0, 0x12, 0x52, 0xdb, 0x0d, 0x5c, 0xea, 0xf1, 0x76, 0xf6, 0x20, 0x7b, 0xb7, 0x86, 0x13, 0x00,
+	0x42, 0xb0, 0xc5, 0xe7, 0x01, 0x6d, 0xe7, 0x0e, 0xb2, 0x77, 0x2b, 0x58, 0x7e, 0xa3, 0x7d, 0x28,
+	0xff, 0x30, 0x23, 0x1e, 0x77, 0xf9, 0xbc, 0x9d, 0x3f, 0xc8, 0xde, 0xdd, 0xc2, 0xf1, 0x5a, 0xe0,
+	0xa6, 0x94, 0x13, 0x87, 0x70, 0xd2, 0xde, 0x92, 0xc4, 0xe2, 0xb5, 0xe5, 0xc1, 0x35, 0x1c, 0x11,
+	0xbe, 0x10, 0x72, 0x0d, 0x69, 0x78, 0x3e, 0x26, 0xe1, 0x55, 0x32, 0x98, 0xfc, 0x72, 0xef, 0xe1,
+	0x97, 0x5f, 0xe0, 0xe7, 0x42, 0x55, 0xde, 0xf4, 0xf5, 0x8c, 0x07, 0x33, 0x8e, 0x1a, 0x90, 0x73,
+	0x1d, 0x4d, 0x3d, 0xe7, 0x3a, 0x3f, 0xea, 0xd5, 0x9e, 0x40, 0xfd, 0x5b, 0x8f, 0x05, 0xe2, 0x62,
+	0x82, 0x23, 0x43, 0x3f, 0x85, 0xa2, 0x34, 0x00, 0x6b, 0x67, 0x0f, 0xf2, 0x77, 0xab, 0xf7, 0x77,
+	0x94, 0xf6, 0x59, 0xc7, 0x90, 0x08, 0xeb, 0x2d, 0x56, 0x00, 0xd5, 0x17, 0x2e, 0xe3, 0x98, 0xfe,
+	0x30, 0xa3, 0x8c, 0xa3, 0x5b, 0x00, 0x76, 0x48, 0x1d, 0xea, 0x71, 0x97, 0x4c, 0xb4, 0xc0, 0x06,
+	0x04, 0x1d, 0x41, 0x2b, 0x60, 0x74, 0xe6, 0xf8, 0xde, 0x7c, 0xda, 0x0f, 0x42, 0xdf, 0x1f, 0xb2,
+	0x76, 0x4e, 0x72, 0xb9, 0x16, 0x71, 0x39, 0x8b, 0xf0, 0x67, 0x02, 0x8d, 0x9b, 0x41, 0x6a, 0xcd,
+	0xac, 0x13, 0x68, 0xa4, 0xb7, 0xa0, 0x5d, 0x28, 0xf8, 0xef, 0x3c, 0x1a, 0x6a, 0x7e, 0x6a, 0x21,
+	0x0c, 0xc3, 0xdc, 0x91, 0x47, 0xf8, 0x2c, 0x54, 0x8a, 0xaa, 0xe1, 0x04, 0x60, 0x8d, 0xa0, 0x85,
+	0xe9, 0x90, 0x86, 0xd4, 0xb3, 0xe9, 0xa6, 0xc2, 0x3f, 0x80, 0x3d, 0x12, 0x04, 0x13, 0xd7, 0x96,
+	0xde, 0xda, 0x0f, 0xa3, 0xf3, 0x9a, 0xfa, 0xae, 0x81, 0x8c, 0x69, 0x5b, 0x13, 0xd8, 0x8b, 0x17,
+	0xce, 0x45, 0xe2, 0xd2, 0x68, 0x07, 0x0a, 0xfc, 0xb2, 0xaf, 0xcd, 0x2a, 0x8c, 0x78, 0xd9, 0x73,
+	0xd0, 0x57, 0xb0, 0x2d, 0x15, 0xdb, 0x37, 0x9c, 0x5f, 0x92, 0xaf, 0xde, 0xdf, 0x56, 0xfa, 0x37,
+	0x48, 0xe0, 0x16, 0x5f, 0x80, 0x58, 0xbf, 0x85, 0x6b, 0x2b, 0xb9, 0x31, 0x74, 0x04, 0x35, 0x83,
+	0x66, 0x64, 0xdb, 0x9b, 0x91, 0xd6, 0x57, 0x9e, 0xc2, 0xa9, 0x23, 0xd6, 0x17, 0xb0, 0x73, 0x4c,
+	0x02, 0x32, 0x70, 0x27, 0x2e, 0x77, 0x29, 0xdb, 0x50, 0x6d, 0xd6, 0x3f, 0x72, 0xb0, 0x73, 0x3c,
+	0x26, 0x9e, 0x47, 0x27, 0xe6, 0x71, 0xf4, 0x29, 0x54, 0x86, 0x64, 0xd0, 0x97, 0x77, 0x90, 0xc7,
+	0xca, 0xb8, 0x3c, 0x24, 0x03, 0x79, 0x4b, 0x74, 0x07, 0xea, 0x63, 0xc2, 0xc6, 0xae, 0x37, 0xea,
+	0xb3, 0x99, 0xcb, 0x23, 0x57, 0xaf, 0x69, 0xe0, 0xb9, 0x80, 0xa1, 0x31, 0xec, 0x29, 0x6d, 0x51,
+	0xcf, 0x0e, 0xe7, 0x81, 0xb4, 0xca, 0x1b, 0x3a, 0x67, 0xed, 0xbc, 0xbc, 0xdc, 0xcf, 0xa3, 0xcb,
+	0xad, 0xe0, 0xae, 0x94, 0x79, 0x1a, 0x9f, 0xfb, 0x9a, 0xce, 0xd9, 0xa9, 0xc7, 0xc3, 0x39, 0xde,
+	0xe1, 0xcb, 0x18, 0x21, 0x0e, 0xbf, 0xec, 0xd3, 0xcb, 0xc0, 0x0d, 0xa5, 0x7d, 0x65, 0x14, 0x95,
+	0x71, 0x8d, 0x5f, 0x9e, 0xc6, 0xb0, 0xfd, 0x67, 0xd0, 0x5e, 0x47, 0x15, 0xb5, 0x20, 0xff, 0x86,
+	0xce, 0xb5, 0xad, 0xc5, 0xa7, 0xf0, 0xda, 0xb7, 0x64, 0x32, 0x8b, 0xbc, 0x47, 0x2d, 0xbe, 0xcc,
+	0x3d, 0xca, 0x5a, 0x67, 0xd0, 0x3e, 0x9f, 0x05, 0xc1, 0x64, 0x7e, 0xc4, 0x39, 0x65, 0x5c, 0x3b,
+	0xd4, 0x66, 0x3e, 0xba, 0x0b, 0x05, 0x91, 0x0d, 0x54, 0x54, 0x55, 0xb0, 0x5a, 0x58, 0xff, 0xce,
+	0xc2, 0xf6, 0x12, 0x49, 0x74, 0x13, 0xc0, 0x56, 0x9a, 0x49, 0xdc, 0xb0, 0xa2, 0x21, 0x3d, 0x07,
+	0xdd, 0x86, 0xda, 0x60, 0xe2, 0xdb, 0x6f, 0xfa, 0x63, 0xea, 0x8e, 0xc6, 0x5c, 0xe7, 0xaf, 0xaa,
+	0x84, 0x75, 0x25, 0x08, 0x75, 0xa0, 0xcc, 0x04, 0x59, 0x97, 0x46, 0x3a, 0x47, 0x71, 0xb2, 0x98,
+	0x07, 0x54, 0xb1, 0xc4, 0xf1, 0x1e, 0xf4, 0x08, 0x2a, 0x71, 0x19, 0x90, 0x2a, 0xac, 0xde, 0xdf,
+	0xef, 0xa8, 0x42, 0xd1, 0x89, 0x0a, 0x45, 0xe7, 0x22, 0xda, 0x81, 0x93, 0xcd, 0x22, 0xe3, 0x05,
+	0x94, 0x86, 0xed, 0x82, 0xbc, 0xb1, 0xfc, 0xb6, 0x9e, 0x00, 0x24, 0x5c, 0xe2, 0x9c, 0x98, 0x5d,
+	0x93, 0x13, 0x17, 0xd2, 0xaf, 0xf5, 0x3d, 0x5c, 0x3f, 0x77, 0x47, 0x1e, 0x75, 0x96, 0x15, 0x73,
+	0x00, 0x55, 0x92, 0x2c, 0xb5, 0x96, 0x4d, 0xd0, 0x15, 0xc9, 0xe5, 0x10, 0xda, 0xc7, 0xfe, 0x74,
+	0xea, 0xf2, 0x15, 0x06, 0x5c, 0x15, 0xf6, 0xd6, 0xdf, 0x73, 0xb0, 0xbd, 0x74, 0x62, 0x63, 0xfb,
+	0x78, 0xb3, 0xe9, 0x80, 0x86, 0x29, 0xfb, 0xbc, 0x92, 0x20, 0x41, 0x41, 0x9b, 0x90, 0xb0, 0xb1,
+	0x2e, 0x32, 0x15, 0x65, 0x40, 0xc2, 0xc6, 0xe8, 0xff, 0xa1, 0xf5, 0x96, 0x4c, 0x5c, 0x47, 0xe5,
+	0xb3, 0xe1, 0x84, 0x8c, 0x98, 0x2e, 0x0f, 0xcd, 0x04, 0xfe, 0x4c, 0x80, 0x13, 0xb1, 0x0b, 0x46,
+	0xb6, 0xfa, 0x04, 0xca, 0x02, 0xe8, 0x39, 0xf4, 0xb2, 0x5d, 0x3c, 0xc8, 0xde, 0xad, 0xe3, 0x12,
+	0xbf, 0xec, 0x89, 0x65, 0xda, 0xd2, 0xa5, 0xff, 0xc5, 0xd2, 0x65, 0xc3, 0xd2, 0xb1, 0xad, 0x96,
+	0x95, 0xf4, 0xb1, 0xb6, 0x9a, 0x42, 0xbd, 0x37, 0x0d, 0xfc, 0x70, 0xe3, 0x12, 0xf6, 0x04, 0x9a,
+	0xaa, 0xf6, 0xf5, 0xb9, 0xdf, 0x77, 0x19, 0x93, 0x11, 0x2c, 0x5c, 0x7f, 0x37, 0x55, 0x27, 0x75,
+	0x8f, 0x82, 0xeb, 0x6a, 0xb3, 0x5e, 0x5a, 0xff, 0xcc, 0x42, 0x33, 0x6a, 0x20, 0x36, 0xe5, 0xf8,
+	0x29, 0x54, 0x54, 0x9a, 0x73, 0x1d, 0x15, 0xd7, 0x35, 0x5c, 0x96, 0x80, 0x9e, 0xc3, 0xd0, 0x2f,
+	0xa0, 0xc8, 0x44, 0x23, 0x12, 0x05, 0xe0, 0xad, 0x24, 0xa3, 0xaf, 0xea, 0x57, 0xb0, 0xde, 0x8d,
+	0x1e, 0x40, 0xd5, 0xa1, 0x13, 0x3a, 0x52, 0x9d, 0x57, 0x7b, 0x4b, 0x1e, 0xde, 0xee, 0x28, 0x35,
+	0x9f, 0xc4, 0x18, 0x6c, 0xee, 0xb2, 0xfe, 0x00, 0x75, 0x4c, 0x1d, 0x4a, 0xa7, 0x3f, 0x8a, 0xe8,
+	0x3f, 0x03, 0x14, 0x45, 0xa3, 0xd0, 0x65, 0x28, 0x29, 0xeb, 0xde, 0xa5, 0x15, 0x61, 0x2e, 0x7c,
+	0xc5, 0xd1, 0x3a, 0x87, 0xeb, 0x47, 0x93, 0x89, 0xff, 0x8e, 0xc8, 0x8a, 0xad, 0xef, 0xf6, 0x91,
+	0x3d, 0x98, 0xf5, 0xb7, 0x2c, 0x34, 0x8e, 0x02, 0xd9, 0xc0, 0x6e, 0x7a, 0xa5, 0x5f, 0x41, 0x8b,
+	0x44, 0x72, 0xf4, 0xb5, 0xea, 0x95, 0x03, 0x7c, 0x16, 0xa9, 0x7e, 0x8d, 0x9c, 0xb8, 0x19, 0x1f,
+	0x3c, 0x57, 0x46, 0x48, 0xa9, 0x27, 0x9f, 0x56, 0x8f, 0xf5, 0xe7, 0x2c, 0xa0, 0xd3, 0xa4, 0x3b,
+	0xde, 0x54, 0xbe, 0x2f, 0xa1, 0x6a, 0xf4, 0xd4, 0xba, 0x79, 0x68, 0xa7, 0x7c, 0xd3, 0xa4, 0x6a,
+	0x6e, 0x7e, 0xbf, 0x3c, 0xf7, 0xa0, 0xf1, 0x94, 0x4c, 0xc8, 0xe6, 0x0d, 0x93, 0x75, 0x0e, 0x25,
+	0x7d, 0xe2, 0x43, 0xb3, 0x33, 0x6a, 0x43, 0xc9, 0x97, 0x9d, 0x26, 0x93, 0x0e, 0x51, 0xc7, 0xd1,
+	0xd2, 0x7a, 0x08, 0x65, 0x4d, 0x54, 0xb4, 0xaa, 0xe5, 0x81, 0xfe, 0xd6, 0x0d, 0x4d, 0x33, 0xba,
+	0x68, 0x24, 0x6a, 0xbc, 0xc1, 0xfa, 0x23, 0xd4, 0x8f, 0x43, 0xea, 0xb8, 0x1b, 0x47, 0x7a, 0xca,
+	0xad, 0x72, 0xeb, 0x9e, 0x17, 0xf9, 0x35, 0x37, 0xda, 0x5a, 0x70, 0xb5, 0xdf, 0x41, 0xed, 0x84,
+	0x0e, 0x36, 0xe7, 0xfe, 0x81, 0x3d, 0xbe, 0xf5, 0x14, 0x6a, 0x67, 0x64, 0xc6, 0xe8, 0x47, 0xd0,
+	0xb7, 0x8e, 0x45, 0x7c, 0xb3, 0xd9, 0xf4, 0xa3, 0x88, 0xbc, 0x85, 0x5d, 0x99, 0xeb, 0x5e, 0x12,
+	0xcf, 0x1d, 0xd2, 0xcd, 0xdf, 0x06, 0x9f, 0x08, 0x63, 0x72, 0x7b, 0x2c, 0xaa, 0x8c, 0xd2, 0x76,
+	0x49, 0xae, 0x7b, 0x0e, 0xba, 0x03, 0x45, 0x7b, 0x3c, 0xf3, 0xde, 0x44, 0x49, 0xae, 0xda, 0x91,
+	0x1c, 0x8e, 0x05, 0x0c, 0x6b, 0x94, 0xf5, 0xaf, 0x2c, 0x14, 0xbb, 0x94, 0x38, 0x34, 0x4c, 0x57,
+	0x9f, 0xec, 0x87, 0x54, 0x9f, 0x74, 0xcd, 0xcd, 0x2d, 0xd6, 0xdc, 0x5d, 0x28, 0x78, 0xbe, 0x68,
+	0xf9, 0x55, 0x2d, 0x55, 0x0b, 0xe1, 0xac, 0x76, 0x48, 0x09, 0xf7, 0x43, 0x5d, 0x3e, 0xa3, 0x25,
+	0x7a, 0x08, 0x40, 0x2f, 0x39, 0xf5, 0x98, 0x4c, 0xb2, 0x05, 0x29, 0xfc, 0xf5, 0xc8, 0x45, 0x95,
+	0xb0, 0xa7, 0x11, 0x1e, 0x1b, 0x5b, 0xad, 0xc7, 0xd0, 0x5c, 0x40, 0x0b, 0x5d, 0x7b, 0x64, 0x1a,
+	0x87, 0x90, 0xf8, 0x5e, 0xdd, 0x44, 0x5a, 0x7f, 0x05, 0x28, 0x89, 0x4a, 0x49, 0x3c, 0x07, 0x7d,
+	0x0e, 0xc5, 0xb1, 0x24, 0xa4, 0xf5, 0xd0, 0x48, 0x73, 0xc7, 0x1a, 0x8b, 0xbe, 0x82, 0x86, 0x2b,
+	0xeb, 0x60, 0x3f, 0x54, 0xf6, 0xd2, 0x99, 0x63, 0x2f, 0xda, 0x9f, 0xaa, 0x92, 0xdd, 0x0c, 0xae,
+	0xbb, 0x26, 0x00, 0x9d, 0x40, 0x8b, 0xeb, 0x42, 0x13, 0x53, 0xc8, 0x1f, 0x64, 0xcd, 0xfb, 0x2e,
+	0xd4, 0xbd, 0x6e, 0x06, 0x37, 0x79, 0x1a, 0x84, 0x1e, 0x41, 0x6d, 0xe2, 0xb2, 0x44, 0x06, 0xd5,
+	0x23, 0xc6, 0x2f, 0x50, 0xe3, 0xa9, 0xd9, 0xcd, 0xe0, 0xea, 0x24, 0x59, 0x0a, 0xf9, 0x55, 0x01,
+	0x89, 0xcf, 0x16, 0xd2, 0xf2, 0xa7, 0x0a, 0x97, 0x90, 0x3f, 0x34, 0x01, 0xe8, 0x08, 0x9a, 0x44,
+	0x15, 0x82, 0x98, 0x40, 0xf1, 0x20, 0x6b, 0x3e, 0x4c, 0xd3, 0x75, 0xa2, 0x9b, 0xc1, 0x0d, 0x92,
+	0x82, 0xa0, 0x97, 0xb0, 0x17, 0xab, 0x60, 0x18, 0xfa, 0x89, 0x24, 0xa5, 0xab, 0xf4, 0xb0, 0x13,
+	0x9d, 0x7b, 0x16, 0xfa, 0xd3, 0x84, 0xdc, 0x8e, 0x91, 0x9b, 0x63, 0x62, 0x65, 0xed, 0xce, 0x9a,
+	0xd8, 0x72, 0x85, 0xe8, 0x66, 0x30, 0xa2, 0x4b, 0x50, 0x71, 0x41, 0x9d, 0x0a, 0x63, 0x52, 0x95,
+	0xf4, 0x05, 0xd3, 0xd9, 0x5d, 0x5c, 0x70, 0x90, 0x82, 0x08, 0x1d, 0xdb, 0x32, 0x83, 0xc6, 0x14,
+	0x20, 0xad, 0xe3, 0x54, 0x7e, 0x15, 0x3a, 0xb6, 0x4d, 0x00, 0x7a, 0x0c, 0x75, 0x87, 0x0e, 0x8c,
+	0xe3, 0xd5, 0x83, 0xac, 0xd9, 0x38, 0x99, 0xf9, 0xb1, 0x9b, 0xc1, 0x35, 0x87, 0x0e, 0x52, 0x87,
+	0x03, 0x91, 0xdf, 0xe2, 0xc3, 0xb5, 0xf4, 0x61, 0x33, 0xf9, 0x89, 0xc3, 0x81, 0xb1, 0x56, 0xde,
+	0x21, 0x12, 0x5b, 0x7c, 0xba, 0xbe, 0xe8, 0x1d, 0x46, 0xda, 0x53, 0xde, 0x61, 0x00, 0xd0, 0x73,
+	0xd8, 0x8e, 0x9f, 0xfb, 0x31, 0x89, 0x46, 0xba, 0xb4, 0x2e, 0xce, 0x13, 0xba, 0x19, 0xdc, 0x0a,
+	0x17, 0x60, 0xe8, 0x0c, 0x76, 0x6d, 0xe3, 0x19, 0x1a, 0xd3, 0x6a, 0x4a, 0x5a, 0x9f, 0xc6, 0x8a,
+	0x5c, 0x7e, 0x67, 0x0b, 0x37, 0xb1, 0x97, 0xc1, 0xe8, 0x02, 0xae, 0xc9, 0x2e, 0xb4, 0x3f, 0xd5,
+	0xf9, 0x36, 0xa6, 0xd9, 0x92, 0x34, 0x6f, 0xc4, 0x01, 0xbc, 0x22, 0x29, 0x77, 0x33, 0x78, 0xd7,
+	0x5d, 0x01, 0x47, 0xbf, 0x87, 0x7d, 0xf9, 0x6a, 0x9b, 0xf7, 0x8d, 0x56, 0x3a, 0xa6, 0xbc, 0x2d,
+	0x29, 0x1f, 0x44, 0x94, 0xd7, 0xbd, 0x56, 0xbb, 0x19, 0xdc, 0x66, 0x6b, 0x70, 0x82, 0x83, 0x2d,
+	0xbb, 0xf9, 0x95, 0x1c, 0x50, 0x9a, 0xc3, 0xba, 0xe7, 0x94, 0xe0, 0x60, 0xaf, 0xc1, 0x3d, 0xad,
+	0x40, 0x29, 0x20, 0xf3, 0x89, 0x4f, 0x1c, 0xeb, 0x39, 0xd4, 0x93, 0x07, 0x84, 0x48, 0x8b, 0x22,
+	0x65, 0xab, 0x4f, 0x5d, 0x89, 0xa2, 0xe5, 0x15, 0xcf, 0x85, 0xbf, 0x64, 0x61, 0x4f, 0xd3, 0xc0,
+	0x94, 0x05, 0xbe, 0xc7, 0xe8, 0x47, 0xd7, 0x9c, 0xdb, 0x50, 0xd3, 0xcc, 0xd5, 0x3b, 0x4d, 0x31,
+	0xad, 0x6a, 0x98, 0x7c, 0xa9, 0x19, 0x15, 0x26, 0x9f, 0xaa, 0x30, 0xd6, 0x63, 0x28, 0x9c, 0x86,
+	0xa1, 0x1f, 0x8a, 0x2d, 0x53, 0xca, 0x18, 0x19, 0x45, 0x15, 0x22, 0x5a, 0xa2, 0x76, 0xac, 0x87,
+	0xa8, 0xae, 0x46, 0x6a, 0xf9, 0xcf, 0x16, 0x34, 0x17, 0x6e, 0x83, 0xbe, 0x58, 0x28, 0x18, 0x37,
+	0x4d, 0x1b, 0x2c, 0x5d, 0x3b, 0xae, 0x1f, 0xb7, 0x21, 0x4f, 0xc3, 0x50, 0x17, 0x8d, 0x7a, 0x9c,
+	0x9d, 0x84, 0x68, 0xdd, 0x0c, 0x16, 0x38, 0xf4, 0xcb, 0x55, 0xc3, 0xad, 0xfc, 0x9a, 0xe1, 0x96,
+	0x88, 0x9e, 0xc5, 0xf1, 0x96, 0x08, 0xe3, 0x99, 0x9a, 0x55, 0xf6, 0xf5, 0x88, 0x72, 0x2b, 0x1d,
+	0xc6, 0xa9, 0x49, 0xa6, 0x08, 0xe3, 0x99, 0x09, 0x10, 0xf3, 0x8a, 0xb8, 0x5f, 0x54, 0xe5, 0xa1,
+	0xb5, 0x90, 0xfc, 0xc4, 0xa1, 0x78, 0x0f, 0xfa, 0x1e, 0xae, 0xc7, 0x11, 0xec, 0xf4, 0x53, 0xf3,
+	0x33, 0x55, 0x1c, 0x6e, 0xbd, 0x77, 0x7e, 0x26, 0x88, 0x5d, 0x0b, 0x57, 0x62, 0x64, 0x22, 0xd0,
+	0x8d, 0x86, 0x19, 0xd5, 0xed, 0xd2, 0x42, 0x22, 0x58, 0x1e, 0x5d, 0xc9, 0x44, 0xb0, 0x0c, 0x46,
+	0x67, 0x80, 0x96, 0x43, 0x56, 0x97, 0x8b, 0xf8, 0x69, 0xb2, 0x66, 0xe4, 0xd1, 0xcd, 0xe0, 0xed,
+	0xa5, 0x48, 0x15, 0x14, 0x97, 0x43, 0xb4, 0x5d, 0x59, 0x45, 0x71, 0x29, 0x40, 0x05, 0xc5, 0xa5,
+	0xc8, 0x34, 0x43, 0xf2, 0x1b, 0xd8, 0x4b, 0x85, 0x64, 0xec, 0x80, 0xfb, 0x50, 0x0e, 0xf5, 0xb7,
+	0x8e, 0xcd, 0x78, 0x7d, 0x45, 0x70, 0x9e, 0x43, 0xfd, 0x7c, 0x36, 0x98, 0x26, 0x35, 0x63, 0x1f,
+	0xca, 0xd4, 0x7b, 0x4b, 0x27, 0x7e, 0x10, 0x93, 0x8a, 0xd6, 0xe8, 0x73, 0x68, 0xbe, 0x23, 0x2e,
+	0xef, 0x0f, 0xfd, 0xb0, 0xaf, 0x04, 0x95, 0x04, 0xcb, 0xb8, 0x2e, 0xc0, 0xcf, 0xfc, 0x50, 0x5d,
+	0x49, 0x8c, 0x2f, 0x6b, 0x8a, 0xea, 0x39, 0x27, 0x7c, 0xc6, 0x56, 0x0f, 0x6e, 0xef, 0x41, 0x21,
+	0x18, 0x13, 0xa6, 0x84, 0x6a, 0x24, 0xe5, 0xd9, 0x3c, 0xd9, 0x39, 0x13, 0x3b, 0xb0, 0xda, 0x88,
+	0xfe, 0x0f, 0x8c, 0x21, 0x4b, 0xdf, 0xf6, 0x9d, 0xe8, 0x29, 0xd1, 0x48, 0xc0, 0xc7, 0xbe, 0x43,
+	0xcd, 0xc0, 0xde, 0x4a, 0x07, 0xf6, 0x63, 0x68, 0xb8, 0x5e, 0x30, 0xe3, 0xfd, 0xb1, 0xcf, 0x59,
+	0xe0, 0xf3, 0xa8, 0xc3, 0x8c, 0x6b, 0x62, 0x4f, 0x60, 0xbb, 0x0a, 0x89, 0xeb, 0xae, 0xb1, 0x62,
+	0xd6, 0x77, 0x50, 0x90, 0xf2, 0xa0, 0x2a, 0x94, 0xbe, 0x7d, 0xf5, 0xf5, 0xab, 0xd7, 0xdf, 0xbd,
+	0x6a, 0x65, 0x50, 0x0d, 0xca, 0x47, 0xc7, 0xc7, 0xa7, 0x67, 0x17, 0xa7, 0x27, 0xad, 0xac, 0x40,
+	0xbd, 0xc6, 0x27, 0xa7, 0xf8, 0xf4, 0xa4, 0x95, 0x43, 0x75, 0xa8, 0x1c, 0xbf, 0x7e, 0xf9, 0xb2,
+	0x77, 0x21, 0x70, 0x79, 0x81, 0xeb, 0xbd, 0xfa, 0xf5, 0xd1, 0x8b, 0xde, 0x49, 0x6b, 0x0b, 0x01,
+	0x14, 0x9f, 0x1d, 0xf5, 0x5e, 0x9c, 0x9e, 0xb4, 0x0a, 0x22, 0x45, 0xd6, 0x4c, 0xc6, 0xc2, 0x68,
+	0xa2, 0x59, 0x65, 0x01, 0xb1, 0xa3, 0xdc, 0x94, 0x00, 0xa2, 0xc9, 0x68, 0x2e, 0x99, 0x8c, 0xde,
+	0x80, 0x8a, 0xed, 0x7b, 0xc3, 0x89, 0x6b, 0xeb, 0xd7, 0xdf, 0x16, 0x4e, 0x00, 0x68, 0x0f, 0x8a,
+	0x52, 0xfd, 0x6a, 0x66, 0x21, 0x46, 0x9c, 0x97, 0x62, 0x98, 0x20, 0x66, 0x51, 0xfa, 0xe9, 0xaa,
+	0x87, 0x84, 0x25, 0xfd, 0x72, 0xbd, 0x8f, 0xa1, 0x78, 0x26, 0xff, 0xa2, 0x42, 0x5d, 0x68, 0x9c,
+	0x85, 0xbe, 0x4d, 0x19, 0x8b, 0xea, 0xc0, 0xde, 0xb2, 0x1b, 0x13, 0xcf, 0xd9, 0xbf, 0xb9, 0x12,
+	0x1c, 0xb9, 0xa8, 0x95, 0xb9, 0xff, 0xa7, 0x2c, 0x94, 0x9e, 0x13, 0x4e, 0xdf, 0x91, 0x39, 0x7a,
+	0x08, 0x45, 0x65, 0x66, 0x83, 0x9a, 0xe9, 0x86, 0xfb, 0xbb, 0xab, 0xbc, 0xe1, 0x5e, 0x16, 0x75,
+	0xa1, 0xa6, 0x82, 0x43, 0xb9, 0xda, 0x3a, 0x61, 0xae, 0x0a, 0x35, 0x2b, 0xf3, 0xf4, 0x1b, 0xb8,
+	0xe3, 0x87, 0xa3, 0xce, 0x78, 0x1e, 0xd0, 0x70, 0x42, 0x9d, 0x11, 0x0d, 0x3b, 0x43, 0x32, 0x08,
+	0x5d, 0x3b, 0x3a, 0x2a, 0x75, 0xf1, 0x9b, 0x9f, 0x8c, 0x5c, 0x3e, 0x9e, 0x0d, 0x3a, 0xb6, 0x3f,
+	0x3d, 0x34, 0xf6, 0x1e, 0xaa, 0xbd, 0xea, 0xbf, 0x3a, 0x76, 0x28, 0xf7, 0x0e, 0xd4, 0x1f, 0x79,
+	0x0f, 0xfe, 0x3b, 0x00, 0xfa, 0x02, 0x0b, 0xfa, 0xe5, 0x1b, 0x00, 0x00,
 }
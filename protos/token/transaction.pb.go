@@ -26,17 +26,22 @@ type TokenTransaction struct {
 	//
 	// Types that are valid to be assigned to Action:
 	//	*TokenTransaction_PlainAction
-	Action               isTokenTransaction_Action `protobuf_oneof:"action"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+	//	*TokenTransaction_EncryptedAction
+	Action isTokenTransaction_Action `protobuf_oneof:"action"`
+	// ApplicationReference is an opaque reference to client application data,
+	// such as an invoice ID or an order hash. It is committed with the transaction
+	// and indexed, so that transactions can be looked up by reference.
+	ApplicationReference []byte   `protobuf:"bytes,2,opt,name=application_reference,json=applicationReference,proto3" json:"application_reference,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *TokenTransaction) Reset()         { *m = TokenTransaction{} }
 func (m *TokenTransaction) String() string { return proto.CompactTextString(m) }
 func (*TokenTransaction) ProtoMessage()    {}
 func (*TokenTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_a359b0c8d648a383, []int{0}
+	return fileDescriptor_transaction_a6492db343aa5d66, []int{0}
 }
 func (m *TokenTransaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TokenTransaction.Unmarshal(m, b)
@@ -64,8 +69,14 @@ type TokenTransaction_PlainAction struct {
 	PlainAction *PlainTokenAction `protobuf:"bytes,1,opt,name=plain_action,json=plainAction,proto3,oneof"`
 }
 
+type TokenTransaction_EncryptedAction struct {
+	EncryptedAction *EncryptedTokenAction `protobuf:"bytes,3,opt,name=encrypted_action,json=encryptedAction,proto3,oneof"`
+}
+
 func (*TokenTransaction_PlainAction) isTokenTransaction_Action() {}
 
+func (*TokenTransaction_EncryptedAction) isTokenTransaction_Action() {}
+
 func (m *TokenTransaction) GetAction() isTokenTransaction_Action {
 	if m != nil {
 		return m.Action
@@ -80,10 +91,25 @@ func (m *TokenTransaction) GetPlainAction() *PlainTokenAction {
 	return nil
 }
 
+func (m *TokenTransaction) GetEncryptedAction() *EncryptedTokenAction {
+	if x, ok := m.GetAction().(*TokenTransaction_EncryptedAction); ok {
+		return x.EncryptedAction
+	}
+	return nil
+}
+
+func (m *TokenTransaction) GetApplicationReference() []byte {
+	if m != nil {
+		return m.ApplicationReference
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*TokenTransaction) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _TokenTransaction_OneofMarshaler, _TokenTransaction_OneofUnmarshaler, _TokenTransaction_OneofSizer, []interface{}{
 		(*TokenTransaction_PlainAction)(nil),
+		(*TokenTransaction_EncryptedAction)(nil),
 	}
 }
 
@@ -96,6 +122,11 @@ func _TokenTransaction_OneofMarshaler(msg proto.Message, b *proto.Buffer) error
 		if err := b.EncodeMessage(x.PlainAction); err != nil {
 			return err
 		}
+	case *TokenTransaction_EncryptedAction:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.EncryptedAction); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("TokenTransaction.Action has unexpected type %T", x)
@@ -114,6 +145,14 @@ func _TokenTransaction_OneofUnmarshaler(msg proto.Message, tag, wire int, b *pro
 		err := b.DecodeMessage(msg)
 		m.Action = &TokenTransaction_PlainAction{msg}
 		return true, err
+	case 3: // action.encrypted_action
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(EncryptedTokenAction)
+		err := b.DecodeMessage(msg)
+		m.Action = &TokenTransaction_EncryptedAction{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -128,6 +167,11 @@ func _TokenTransaction_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *TokenTransaction_EncryptedAction:
+		s := proto.Size(x.EncryptedAction)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -135,6 +179,138 @@ func _TokenTransaction_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+// EncryptedTokenAction carries a serialized TokenTransaction encrypted with
+// AES-256-GCM under a content key, which is wrapped for each application org
+// of the channel under the TokenEncryptionKey of the org
+type EncryptedTokenAction struct {
+	// Ciphertext is the encrypted serialized TokenTransaction
+	Ciphertext []byte `protobuf:"bytes,1,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+	// Nonce is the AES-GCM nonce of the ciphertext
+	Nonce []byte `protobuf:"bytes,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// EphemeralPublicKey is the uncompressed P-256 point of the ephemeral key
+	// agreed with the TokenEncryptionKey of each recipient
+	EphemeralPublicKey []byte `protobuf:"bytes,3,opt,name=ephemeral_public_key,json=ephemeralPublicKey,proto3" json:"ephemeral_public_key,omitempty"`
+	// KeyCommitment is the SHA256 hash of the content key, the ephemeral public
+	// key and the wrapped keys of all the recipients, binding the ciphertext to a
+	// single content key for all the recipients
+	KeyCommitment []byte `protobuf:"bytes,4,opt,name=key_commitment,json=keyCommitment,proto3" json:"key_commitment,omitempty"`
+	// Recipients hold the content key wrapped for each application org
+	Recipients           []*TokenRecipient `protobuf:"bytes,5,rep,name=recipients,proto3" json:"recipients,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *EncryptedTokenAction) Reset()         { *m = EncryptedTokenAction{} }
+func (m *EncryptedTokenAction) String() string { return proto.CompactTextString(m) }
+func (*EncryptedTokenAction) ProtoMessage()    {}
+func (*EncryptedTokenAction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_a6492db343aa5d66, []int{1}
+}
+func (m *EncryptedTokenAction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EncryptedTokenAction.Unmarshal(m, b)
+}
+func (m *EncryptedTokenAction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EncryptedTokenAction.Marshal(b, m, deterministic)
+}
+func (dst *EncryptedTokenAction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EncryptedTokenAction.Merge(dst, src)
+}
+func (m *EncryptedTokenAction) XXX_Size() int {
+	return xxx_messageInfo_EncryptedTokenAction.Size(m)
+}
+func (m *EncryptedTokenAction) XXX_DiscardUnknown() {
+	xxx_messageInfo_EncryptedTokenAction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EncryptedTokenAction proto.InternalMessageInfo
+
+func (m *EncryptedTokenAction) GetCiphertext() []byte {
+	if m != nil {
+		return m.Ciphertext
+	}
+	return nil
+}
+
+func (m *EncryptedTokenAction) GetNonce() []byte {
+	if m != nil {
+		return m.Nonce
+	}
+	return nil
+}
+
+func (m *EncryptedTokenAction) GetEphemeralPublicKey() []byte {
+	if m != nil {
+		return m.EphemeralPublicKey
+	}
+	return nil
+}
+
+func (m *EncryptedTokenAction) GetKeyCommitment() []byte {
+	if m != nil {
+		return m.KeyCommitment
+	}
+	return nil
+}
+
+func (m *EncryptedTokenAction) GetRecipients() []*TokenRecipient {
+	if m != nil {
+		return m.Recipients
+	}
+	return nil
+}
+
+// TokenRecipient holds the content key of an EncryptedTokenAction wrapped
+// for the committing peers of an application org
+type TokenRecipient struct {
+	// MspId identifies the org
+	MspId string `protobuf:"bytes,1,opt,name=msp_id,json=mspId,proto3" json:"msp_id,omitempty"`
+	// EncryptedKey is the content key encrypted under the key agreed between
+	// the ephemeral key and the TokenEncryptionKey of the org
+	EncryptedKey         []byte   `protobuf:"bytes,2,opt,name=encrypted_key,json=encryptedKey,proto3" json:"encrypted_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenRecipient) Reset()         { *m = TokenRecipient{} }
+func (m *TokenRecipient) String() string { return proto.CompactTextString(m) }
+func (*TokenRecipient) ProtoMessage()    {}
+func (*TokenRecipient) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_a6492db343aa5d66, []int{2}
+}
+func (m *TokenRecipient) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenRecipient.Unmarshal(m, b)
+}
+func (m *TokenRecipient) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenRecipient.Marshal(b, m, deterministic)
+}
+func (dst *TokenRecipient) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenRecipient.Merge(dst, src)
+}
+func (m *TokenRecipient) XXX_Size() int {
+	return xxx_messageInfo_TokenRecipient.Size(m)
+}
+func (m *TokenRecipient) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenRecipient.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenRecipient proto.InternalMessageInfo
+
+func (m *TokenRecipient) GetMspId() string {
+	if m != nil {
+		return m.MspId
+	}
+	return ""
+}
+
+func (m *TokenRecipient) GetEncryptedKey() []byte {
+	if m != nil {
+		return m.EncryptedKey
+	}
+	return nil
+}
+
 // PlainTokenAction governs the structure of a token action that is
 // subjected to no privacy restrictions
 type PlainTokenAction struct {
@@ -144,6 +320,9 @@ type PlainTokenAction struct {
 	//	*PlainTokenAction_PlainRedeem
 	//	*PlainTokenAction_PlainApprove
 	//	*PlainTokenAction_PlainTransfer_From
+	//	*PlainTokenAction_PlainPause
+	//	*PlainTokenAction_PlainResume
+	//	*PlainTokenAction_PlainIssueManifest
 	Data                 isPlainTokenAction_Data `protobuf_oneof:"data"`
 	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
 	XXX_unrecognized     []byte                  `json:"-"`
@@ -154,7 +333,7 @@ func (m *PlainTokenAction) Reset()         { *m = PlainTokenAction{} }
 func (m *PlainTokenAction) String() string { return proto.CompactTextString(m) }
 func (*PlainTokenAction) ProtoMessage()    {}
 func (*PlainTokenAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_a359b0c8d648a383, []int{1}
+	return fileDescriptor_transaction_a6492db343aa5d66, []int{3}
 }
 func (m *PlainTokenAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlainTokenAction.Unmarshal(m, b)
@@ -198,6 +377,18 @@ type PlainTokenAction_PlainTransfer_From struct {
 	PlainTransfer_From *PlainTransferFrom `protobuf:"bytes,5,opt,name=plain_transfer_From,json=plainTransferFrom,proto3,oneof"`
 }
 
+type PlainTokenAction_PlainPause struct {
+	PlainPause *PlainGovernance `protobuf:"bytes,6,opt,name=plain_pause,json=plainPause,proto3,oneof"`
+}
+
+type PlainTokenAction_PlainResume struct {
+	PlainResume *PlainGovernance `protobuf:"bytes,7,opt,name=plain_resume,json=plainResume,proto3,oneof"`
+}
+
+type PlainTokenAction_PlainIssueManifest struct {
+	PlainIssueManifest *PlainIssueManifest `protobuf:"bytes,8,opt,name=plain_issue_manifest,json=plainIssueManifest,proto3,oneof"`
+}
+
 func (*PlainTokenAction_PlainImport) isPlainTokenAction_Data() {}
 
 func (*PlainTokenAction_PlainTransfer) isPlainTokenAction_Data() {}
@@ -208,6 +399,12 @@ func (*PlainTokenAction_PlainApprove) isPlainTokenAction_Data() {}
 
 func (*PlainTokenAction_PlainTransfer_From) isPlainTokenAction_Data() {}
 
+func (*PlainTokenAction_PlainPause) isPlainTokenAction_Data() {}
+
+func (*PlainTokenAction_PlainResume) isPlainTokenAction_Data() {}
+
+func (*PlainTokenAction_PlainIssueManifest) isPlainTokenAction_Data() {}
+
 func (m *PlainTokenAction) GetData() isPlainTokenAction_Data {
 	if m != nil {
 		return m.Data
@@ -250,6 +447,27 @@ func (m *PlainTokenAction) GetPlainTransfer_From() *PlainTransferFrom {
 	return nil
 }
 
+func (m *PlainTokenAction) GetPlainPause() *PlainGovernance {
+	if x, ok := m.GetData().(*PlainTokenAction_PlainPause); ok {
+		return x.PlainPause
+	}
+	return nil
+}
+
+func (m *PlainTokenAction) GetPlainResume() *PlainGovernance {
+	if x, ok := m.GetData().(*PlainTokenAction_PlainResume); ok {
+		return x.PlainResume
+	}
+	return nil
+}
+
+func (m *PlainTokenAction) GetPlainIssueManifest() *PlainIssueManifest {
+	if x, ok := m.GetData().(*PlainTokenAction_PlainIssueManifest); ok {
+		return x.PlainIssueManifest
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*PlainTokenAction) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _PlainTokenAction_OneofMarshaler, _PlainTokenAction_OneofUnmarshaler, _PlainTokenAction_OneofSizer, []interface{}{
@@ -258,6 +476,9 @@ func (*PlainTokenAction) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buff
 		(*PlainTokenAction_PlainRedeem)(nil),
 		(*PlainTokenAction_PlainApprove)(nil),
 		(*PlainTokenAction_PlainTransfer_From)(nil),
+		(*PlainTokenAction_PlainPause)(nil),
+		(*PlainTokenAction_PlainResume)(nil),
+		(*PlainTokenAction_PlainIssueManifest)(nil),
 	}
 }
 
@@ -290,6 +511,21 @@ func _PlainTokenAction_OneofMarshaler(msg proto.Message, b *proto.Buffer) error
 		if err := b.EncodeMessage(x.PlainTransfer_From); err != nil {
 			return err
 		}
+	case *PlainTokenAction_PlainPause:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PlainPause); err != nil {
+			return err
+		}
+	case *PlainTokenAction_PlainResume:
+		b.EncodeVarint(7<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PlainResume); err != nil {
+			return err
+		}
+	case *PlainTokenAction_PlainIssueManifest:
+		b.EncodeVarint(8<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PlainIssueManifest); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("PlainTokenAction.Data has unexpected type %T", x)
@@ -340,6 +576,30 @@ func _PlainTokenAction_OneofUnmarshaler(msg proto.Message, tag, wire int, b *pro
 		err := b.DecodeMessage(msg)
 		m.Data = &PlainTokenAction_PlainTransfer_From{msg}
 		return true, err
+	case 6: // data.plain_pause
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PlainGovernance)
+		err := b.DecodeMessage(msg)
+		m.Data = &PlainTokenAction_PlainPause{msg}
+		return true, err
+	case 7: // data.plain_resume
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PlainGovernance)
+		err := b.DecodeMessage(msg)
+		m.Data = &PlainTokenAction_PlainResume{msg}
+		return true, err
+	case 8: // data.plain_issue_manifest
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PlainIssueManifest)
+		err := b.DecodeMessage(msg)
+		m.Data = &PlainTokenAction_PlainIssueManifest{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -374,6 +634,21 @@ func _PlainTokenAction_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *PlainTokenAction_PlainPause:
+		s := proto.Size(x.PlainPause)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *PlainTokenAction_PlainResume:
+		s := proto.Size(x.PlainResume)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *PlainTokenAction_PlainIssueManifest:
+		s := proto.Size(x.PlainIssueManifest)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -384,17 +659,21 @@ func _PlainTokenAction_OneofSizer(msg proto.Message) (n int) {
 // PlainImport specifies an import of one or more tokens in plaintext format
 type PlainImport struct {
 	// An import transaction may contain one or more outputs
-	Outputs              []*PlainOutput `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	Outputs []*PlainOutput `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	// SeriesId is an optional identifier, unique per issuer, of the batch of
+	// tokens imported. It is recorded when the import is committed, so that
+	// an issuer retrying an import cannot mint the same batch twice.
+	SeriesId             []byte   `protobuf:"bytes,2,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *PlainImport) Reset()         { *m = PlainImport{} }
 func (m *PlainImport) String() string { return proto.CompactTextString(m) }
 func (*PlainImport) ProtoMessage()    {}
 func (*PlainImport) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_a359b0c8d648a383, []int{2}
+	return fileDescriptor_transaction_a6492db343aa5d66, []int{4}
 }
 func (m *PlainImport) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlainImport.Unmarshal(m, b)
@@ -421,22 +700,33 @@ func (m *PlainImport) GetOutputs() []*PlainOutput {
 	return nil
 }
 
+func (m *PlainImport) GetSeriesId() []byte {
+	if m != nil {
+		return m.SeriesId
+	}
+	return nil
+}
+
 // PlainTransfer specifies a transfer of one or more plaintext tokens to one or more outputs
 type PlainTransfer struct {
 	// The inputs to the transfer transaction are specified by their ID
 	Inputs []*InputId `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`
 	// A transfer transaction may contain one or more outputs
-	Outputs              []*PlainOutput `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	Outputs []*PlainOutput `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	// Delegations is the chain of signed delegations authorizing the creator
+	// to spend inputs owned by another party. The first delegation is signed
+	// by the owner of the inputs and the last one is granted to the creator.
+	Delegations          []*SignedDelegation `protobuf:"bytes,3,rep,name=delegations,proto3" json:"delegations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
 func (m *PlainTransfer) Reset()         { *m = PlainTransfer{} }
 func (m *PlainTransfer) String() string { return proto.CompactTextString(m) }
 func (*PlainTransfer) ProtoMessage()    {}
 func (*PlainTransfer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_a359b0c8d648a383, []int{3}
+	return fileDescriptor_transaction_a6492db343aa5d66, []int{5}
 }
 func (m *PlainTransfer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlainTransfer.Unmarshal(m, b)
@@ -470,6 +760,13 @@ func (m *PlainTransfer) GetOutputs() []*PlainOutput {
 	return nil
 }
 
+func (m *PlainTransfer) GetDelegations() []*SignedDelegation {
+	if m != nil {
+		return m.Delegations
+	}
+	return nil
+}
+
 // PlainApprove specifies an approve of one or more tokens in plaintext format
 type PlainApprove struct {
 	// The inputs to the transfer transaction are specified by their ID
@@ -487,7 +784,7 @@ func (m *PlainApprove) Reset()         { *m = PlainApprove{} }
 func (m *PlainApprove) String() string { return proto.CompactTextString(m) }
 func (*PlainApprove) ProtoMessage()    {}
 func (*PlainApprove) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_a359b0c8d648a383, []int{4}
+	return fileDescriptor_transaction_a6492db343aa5d66, []int{6}
 }
 func (m *PlainApprove) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlainApprove.Unmarshal(m, b)
@@ -546,7 +843,7 @@ func (m *PlainTransferFrom) Reset()         { *m = PlainTransferFrom{} }
 func (m *PlainTransferFrom) String() string { return proto.CompactTextString(m) }
 func (*PlainTransferFrom) ProtoMessage()    {}
 func (*PlainTransferFrom) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_a359b0c8d648a383, []int{5}
+	return fileDescriptor_transaction_a6492db343aa5d66, []int{7}
 }
 func (m *PlainTransferFrom) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlainTransferFrom.Unmarshal(m, b)
@@ -587,14 +884,160 @@ func (m *PlainTransferFrom) GetDelegatedOutput() *PlainDelegatedOutput {
 	return nil
 }
 
+// PlainGovernance specifies a governance action on a token type. While a token
+// type is paused, tokens of that type can be listed and queried but not issued,
+// transferred, redeemed or approved.
+type PlainGovernance struct {
+	// Type is the token type the action applies to
+	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlainGovernance) Reset()         { *m = PlainGovernance{} }
+func (m *PlainGovernance) String() string { return proto.CompactTextString(m) }
+func (*PlainGovernance) ProtoMessage()    {}
+func (*PlainGovernance) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_a6492db343aa5d66, []int{8}
+}
+func (m *PlainGovernance) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlainGovernance.Unmarshal(m, b)
+}
+func (m *PlainGovernance) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlainGovernance.Marshal(b, m, deterministic)
+}
+func (dst *PlainGovernance) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlainGovernance.Merge(dst, src)
+}
+func (m *PlainGovernance) XXX_Size() int {
+	return xxx_messageInfo_PlainGovernance.Size(m)
+}
+func (m *PlainGovernance) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlainGovernance.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlainGovernance proto.InternalMessageInfo
+
+func (m *PlainGovernance) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+// PlainIssueManifest ties together, for audit, the imports of a batch of
+// tokens issued in chunks. The committer only accepts it if every chunk was
+// imported by the creator of the manifest, and records it once per batch.
+type PlainIssueManifest struct {
+	// BatchId identifies the batch, unique per issuer
+	BatchId []byte `protobuf:"bytes,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	// Chunks are the imports of the batch, in order
+	Chunks               []*IssueChunk `protobuf:"bytes,2,rep,name=chunks,proto3" json:"chunks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *PlainIssueManifest) Reset()         { *m = PlainIssueManifest{} }
+func (m *PlainIssueManifest) String() string { return proto.CompactTextString(m) }
+func (*PlainIssueManifest) ProtoMessage()    {}
+func (*PlainIssueManifest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_a6492db343aa5d66, []int{9}
+}
+func (m *PlainIssueManifest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlainIssueManifest.Unmarshal(m, b)
+}
+func (m *PlainIssueManifest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlainIssueManifest.Marshal(b, m, deterministic)
+}
+func (dst *PlainIssueManifest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlainIssueManifest.Merge(dst, src)
+}
+func (m *PlainIssueManifest) XXX_Size() int {
+	return xxx_messageInfo_PlainIssueManifest.Size(m)
+}
+func (m *PlainIssueManifest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlainIssueManifest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlainIssueManifest proto.InternalMessageInfo
+
+func (m *PlainIssueManifest) GetBatchId() []byte {
+	if m != nil {
+		return m.BatchId
+	}
+	return nil
+}
+
+func (m *PlainIssueManifest) GetChunks() []*IssueChunk {
+	if m != nil {
+		return m.Chunks
+	}
+	return nil
+}
+
+// IssueChunk identifies the import of a chunk of a batch
+type IssueChunk struct {
+	// TxId is the ID of the import transaction
+	TxId string `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	// SeriesId is the series ID of the import
+	SeriesId             []byte   `protobuf:"bytes,2,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IssueChunk) Reset()         { *m = IssueChunk{} }
+func (m *IssueChunk) String() string { return proto.CompactTextString(m) }
+func (*IssueChunk) ProtoMessage()    {}
+func (*IssueChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_a6492db343aa5d66, []int{10}
+}
+func (m *IssueChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IssueChunk.Unmarshal(m, b)
+}
+func (m *IssueChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IssueChunk.Marshal(b, m, deterministic)
+}
+func (dst *IssueChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IssueChunk.Merge(dst, src)
+}
+func (m *IssueChunk) XXX_Size() int {
+	return xxx_messageInfo_IssueChunk.Size(m)
+}
+func (m *IssueChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_IssueChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IssueChunk proto.InternalMessageInfo
+
+func (m *IssueChunk) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *IssueChunk) GetSeriesId() []byte {
+	if m != nil {
+		return m.SeriesId
+	}
+	return nil
+}
+
 // A PlainOutput is the result of import and transfer transactions using plaintext tokens
 type PlainOutput struct {
 	// The owner is the serialization of a SerializedIdentity struct
+	// or of a HashedOwner struct
 	Owner []byte `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
 	// The token type
 	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
 	// The quantity of tokens
-	Quantity             uint64   `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Quantity uint64 `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// The metadata of the tokens, e.g. the maturity date of a bond, a JSON
+	// object validated against the schema of the token type
+	Metadata             []byte   `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -604,7 +1047,7 @@ func (m *PlainOutput) Reset()         { *m = PlainOutput{} }
 func (m *PlainOutput) String() string { return proto.CompactTextString(m) }
 func (*PlainOutput) ProtoMessage()    {}
 func (*PlainOutput) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_a359b0c8d648a383, []int{6}
+	return fileDescriptor_transaction_a6492db343aa5d66, []int{11}
 }
 func (m *PlainOutput) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlainOutput.Unmarshal(m, b)
@@ -645,6 +1088,63 @@ func (m *PlainOutput) GetQuantity() uint64 {
 	return 0
 }
 
+func (m *PlainOutput) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// A HashedOwner identifies the owner of a token without storing its identity on the ledger.
+// The owner reveals its identity, as the creator of the transaction, when it spends the token.
+type HashedOwner struct {
+	// The identifier of the MSP the owner belongs to
+	MspId string `protobuf:"bytes,1,opt,name=msp_id,json=mspId,proto3" json:"msp_id,omitempty"`
+	// The SHA-256 hash of the serialization of the SerializedIdentity struct of the owner
+	IdentityHash         []byte   `protobuf:"bytes,2,opt,name=identity_hash,json=identityHash,proto3" json:"identity_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HashedOwner) Reset()         { *m = HashedOwner{} }
+func (m *HashedOwner) String() string { return proto.CompactTextString(m) }
+func (*HashedOwner) ProtoMessage()    {}
+func (*HashedOwner) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_a6492db343aa5d66, []int{12}
+}
+func (m *HashedOwner) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HashedOwner.Unmarshal(m, b)
+}
+func (m *HashedOwner) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HashedOwner.Marshal(b, m, deterministic)
+}
+func (dst *HashedOwner) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HashedOwner.Merge(dst, src)
+}
+func (m *HashedOwner) XXX_Size() int {
+	return xxx_messageInfo_HashedOwner.Size(m)
+}
+func (m *HashedOwner) XXX_DiscardUnknown() {
+	xxx_messageInfo_HashedOwner.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HashedOwner proto.InternalMessageInfo
+
+func (m *HashedOwner) GetMspId() string {
+	if m != nil {
+		return m.MspId
+	}
+	return ""
+}
+
+func (m *HashedOwner) GetIdentityHash() []byte {
+	if m != nil {
+		return m.IdentityHash
+	}
+	return nil
+}
+
 // An InputId specifies an output using the transaction ID and the index of the output in the transaction
 type InputId struct {
 	// The transaction ID
@@ -660,7 +1160,7 @@ func (m *InputId) Reset()         { *m = InputId{} }
 func (m *InputId) String() string { return proto.CompactTextString(m) }
 func (*InputId) ProtoMessage()    {}
 func (*InputId) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_a359b0c8d648a383, []int{7}
+	return fileDescriptor_transaction_a6492db343aa5d66, []int{13}
 }
 func (m *InputId) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InputId.Unmarshal(m, b)
@@ -714,7 +1214,7 @@ func (m *PlainDelegatedOutput) Reset()         { *m = PlainDelegatedOutput{} }
 func (m *PlainDelegatedOutput) String() string { return proto.CompactTextString(m) }
 func (*PlainDelegatedOutput) ProtoMessage()    {}
 func (*PlainDelegatedOutput) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_a359b0c8d648a383, []int{8}
+	return fileDescriptor_transaction_a6492db343aa5d66, []int{14}
 }
 func (m *PlainDelegatedOutput) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlainDelegatedOutput.Unmarshal(m, b)
@@ -762,54 +1262,239 @@ func (m *PlainDelegatedOutput) GetQuantity() uint64 {
 	return 0
 }
 
+// A Delegation authorizes a delegatee to spend, on behalf of the delegator,
+// up to a quantity of tokens of a given type until an expiration block
+type Delegation struct {
+	// Delegator is the serialized identity of the party granting the delegation
+	Delegator []byte `protobuf:"bytes,1,opt,name=delegator,proto3" json:"delegator,omitempty"`
+	// Delegatee is the serialized identity of the party allowed to spend
+	Delegatee []byte `protobuf:"bytes,2,opt,name=delegatee,proto3" json:"delegatee,omitempty"`
+	// The token type that may be spent
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// The maximum quantity of tokens that may be spent
+	Quantity uint64 `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// ChannelId identifies the channel the delegation is valid on
+	ChannelId string `protobuf:"bytes,6,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// Nonce is a random value that makes each delegation unique
+	Nonce []byte `protobuf:"bytes,7,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// ExpirationBlock is the number of the last block a transaction spending
+	// tokens by way of the delegation can be committed in
+	ExpirationBlock      uint64   `protobuf:"varint,8,opt,name=expiration_block,json=expirationBlock,proto3" json:"expiration_block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Delegation) Reset()         { *m = Delegation{} }
+func (m *Delegation) String() string { return proto.CompactTextString(m) }
+func (*Delegation) ProtoMessage()    {}
+func (*Delegation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_a6492db343aa5d66, []int{15}
+}
+func (m *Delegation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Delegation.Unmarshal(m, b)
+}
+func (m *Delegation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Delegation.Marshal(b, m, deterministic)
+}
+func (dst *Delegation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Delegation.Merge(dst, src)
+}
+func (m *Delegation) XXX_Size() int {
+	return xxx_messageInfo_Delegation.Size(m)
+}
+func (m *Delegation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Delegation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Delegation proto.InternalMessageInfo
+
+func (m *Delegation) GetDelegator() []byte {
+	if m != nil {
+		return m.Delegator
+	}
+	return nil
+}
+
+func (m *Delegation) GetDelegatee() []byte {
+	if m != nil {
+		return m.Delegatee
+	}
+	return nil
+}
+
+func (m *Delegation) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Delegation) GetQuantity() uint64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+func (m *Delegation) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *Delegation) GetNonce() []byte {
+	if m != nil {
+		return m.Nonce
+	}
+	return nil
+}
+
+func (m *Delegation) GetExpirationBlock() uint64 {
+	if m != nil {
+		return m.ExpirationBlock
+	}
+	return 0
+}
+
+// SignedDelegation is a delegation that carries the signature of its delegator
+type SignedDelegation struct {
+	// Delegation is the serialised version of a Delegation message
+	Delegation []byte `protobuf:"bytes,1,opt,name=delegation,proto3" json:"delegation,omitempty"`
+	// Signature is the signature of the delegator over delegation
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedDelegation) Reset()         { *m = SignedDelegation{} }
+func (m *SignedDelegation) String() string { return proto.CompactTextString(m) }
+func (*SignedDelegation) ProtoMessage()    {}
+func (*SignedDelegation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_a6492db343aa5d66, []int{16}
+}
+func (m *SignedDelegation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedDelegation.Unmarshal(m, b)
+}
+func (m *SignedDelegation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedDelegation.Marshal(b, m, deterministic)
+}
+func (dst *SignedDelegation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedDelegation.Merge(dst, src)
+}
+func (m *SignedDelegation) XXX_Size() int {
+	return xxx_messageInfo_SignedDelegation.Size(m)
+}
+func (m *SignedDelegation) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedDelegation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedDelegation proto.InternalMessageInfo
+
+func (m *SignedDelegation) GetDelegation() []byte {
+	if m != nil {
+		return m.Delegation
+	}
+	return nil
+}
+
+func (m *SignedDelegation) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*TokenTransaction)(nil), "TokenTransaction")
+	proto.RegisterType((*EncryptedTokenAction)(nil), "EncryptedTokenAction")
+	proto.RegisterType((*TokenRecipient)(nil), "TokenRecipient")
 	proto.RegisterType((*PlainTokenAction)(nil), "PlainTokenAction")
 	proto.RegisterType((*PlainImport)(nil), "PlainImport")
 	proto.RegisterType((*PlainTransfer)(nil), "PlainTransfer")
 	proto.RegisterType((*PlainApprove)(nil), "PlainApprove")
 	proto.RegisterType((*PlainTransferFrom)(nil), "PlainTransferFrom")
+	proto.RegisterType((*PlainGovernance)(nil), "PlainGovernance")
+	proto.RegisterType((*PlainIssueManifest)(nil), "PlainIssueManifest")
+	proto.RegisterType((*IssueChunk)(nil), "IssueChunk")
 	proto.RegisterType((*PlainOutput)(nil), "PlainOutput")
+	proto.RegisterType((*HashedOwner)(nil), "HashedOwner")
 	proto.RegisterType((*InputId)(nil), "InputId")
 	proto.RegisterType((*PlainDelegatedOutput)(nil), "PlainDelegatedOutput")
+	proto.RegisterType((*Delegation)(nil), "Delegation")
+	proto.RegisterType((*SignedDelegation)(nil), "SignedDelegation")
 }
 
 func init() {
-	proto.RegisterFile("token/transaction.proto", fileDescriptor_transaction_a359b0c8d648a383)
-}
-
-var fileDescriptor_transaction_a359b0c8d648a383 = []byte{
-	// 505 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x4f, 0x8f, 0xd2, 0x40,
-	0x14, 0xa7, 0x50, 0xba, 0xf8, 0x68, 0x57, 0x98, 0x5d, 0x63, 0xe3, 0xc1, 0x90, 0xc6, 0x18, 0x63,
-	0x0c, 0x8d, 0xee, 0xaa, 0x57, 0x97, 0x6c, 0x0c, 0x9c, 0x34, 0x23, 0x17, 0xbd, 0x90, 0xc2, 0xcc,
-	0xb2, 0x8d, 0xd0, 0x19, 0x87, 0x41, 0x21, 0xf1, 0x93, 0x78, 0xf1, 0x63, 0xf8, 0xf5, 0x4c, 0xdf,
-	0x4c, 0xd9, 0x16, 0xc5, 0x78, 0xd8, 0x5b, 0x7f, 0xbf, 0x37, 0xbf, 0x3f, 0x6f, 0xda, 0x14, 0xee,
-	0x6b, 0xf1, 0x99, 0x67, 0xb1, 0x56, 0x49, 0xb6, 0x4a, 0x66, 0x3a, 0x15, 0x59, 0x5f, 0x2a, 0xa1,
-	0x45, 0x34, 0x86, 0xce, 0x38, 0x1f, 0x8d, 0x6f, 0x26, 0xe4, 0x15, 0xf8, 0x72, 0x91, 0xa4, 0xd9,
-	0xc4, 0xe0, 0xd0, 0xe9, 0x39, 0x4f, 0xda, 0x2f, 0xba, 0xfd, 0xf7, 0x39, 0x89, 0xa7, 0x2f, 0x70,
-	0x30, 0xac, 0xd1, 0x36, 0x1e, 0x34, 0x70, 0xd0, 0x02, 0xcf, 0x28, 0xa2, 0x5f, 0x75, 0xe8, 0xec,
-	0x9f, 0x26, 0xcf, 0x0b, 0xdb, 0x74, 0x29, 0x85, 0xd2, 0xd6, 0xd6, 0x37, 0xb6, 0x23, 0xe4, 0x76,
-	0x8e, 0x06, 0x92, 0xd7, 0x70, 0x6c, 0x24, 0x58, 0xfc, 0x8a, 0xab, 0xb0, 0x8e, 0xa2, 0x63, 0xdb,
-	0xc5, 0xb2, 0xc3, 0x1a, 0x0d, 0x64, 0x99, 0x20, 0x67, 0x45, 0x96, 0xe2, 0x8c, 0xf3, 0x65, 0xd8,
-	0x38, 0x20, 0x33, 0x69, 0x14, 0x0f, 0x91, 0x73, 0x08, 0xec, 0xde, 0x52, 0x2a, 0xf1, 0x95, 0x87,
-	0x2e, 0xaa, 0x02, 0xa3, 0xba, 0x30, 0xe4, 0xb0, 0x46, 0x8d, 0xb5, 0xc5, 0xe4, 0x12, 0x4e, 0xaa,
-	0x1d, 0x27, 0x6f, 0x95, 0x58, 0x86, 0x4d, 0xd4, 0x92, 0x6a, 0x62, 0x3e, 0x19, 0xd6, 0x68, 0x57,
-	0xee, 0x93, 0x03, 0x0f, 0x5c, 0x96, 0xe8, 0x24, 0x7a, 0x09, 0xed, 0xd2, 0x7d, 0x90, 0xc7, 0x70,
-	0x24, 0xd6, 0x5a, 0xae, 0xf5, 0x2a, 0x74, 0x7a, 0x8d, 0x9b, 0xeb, 0x7a, 0x87, 0x24, 0x2d, 0x86,
-	0xd1, 0x47, 0x08, 0x2a, 0x41, 0xa4, 0x07, 0x5e, 0x9a, 0x95, 0x74, 0xad, 0xfe, 0x28, 0x87, 0x23,
-	0x46, 0x2d, 0x5f, 0xb6, 0xae, 0xff, 0xcb, 0xfa, 0x87, 0x03, 0x7e, 0xf9, 0x02, 0xfe, 0xc3, 0x7a,
-	0x00, 0x5d, 0xc6, 0x17, 0x7c, 0x9e, 0x68, 0xce, 0x26, 0xd5, 0x90, 0x7b, 0x26, 0xe4, 0xb2, 0x18,
-	0xdb, 0xb4, 0x0e, 0xab, 0x12, 0x2b, 0xf2, 0x08, 0x3c, 0xa3, 0xb4, 0xef, 0xae, 0xda, 0xce, 0xce,
-	0xa2, 0x9f, 0x0e, 0x74, 0xff, 0xb8, 0xe1, 0xdb, 0x5b, 0x9e, 0xbc, 0x81, 0xce, 0xfe, 0x26, 0xb6,
-	0xcf, 0x81, 0x45, 0xee, 0xee, 0x2d, 0x12, 0x7d, 0xb0, 0x2f, 0xd4, 0x40, 0x72, 0x0a, 0x4d, 0xf1,
-	0x2d, 0xe3, 0x0a, 0xbf, 0x7e, 0x9f, 0x1a, 0x40, 0x08, 0xb8, 0x7a, 0x2b, 0x39, 0x7e, 0xdd, 0x77,
-	0x28, 0x3e, 0x93, 0x07, 0xd0, 0xfa, 0xb2, 0x4e, 0x32, 0x9d, 0xea, 0x2d, 0x46, 0xba, 0x74, 0x87,
-	0xa3, 0x73, 0x38, 0xb2, 0x1b, 0x91, 0x13, 0x68, 0xea, 0xcd, 0x24, 0x65, 0x68, 0x98, 0x6b, 0x37,
-	0x23, 0x96, 0xa7, 0xa4, 0x19, 0xe3, 0x1b, 0x34, 0x0c, 0xa8, 0x01, 0xd1, 0x77, 0x38, 0xfd, 0x5b,
-	0xe7, 0x03, 0x9d, 0x1e, 0x02, 0x14, 0xbb, 0x70, 0x73, 0x4b, 0x3e, 0x2d, 0x31, 0xbb, 0xce, 0x8d,
-	0x03, 0x9d, 0xdd, 0x6a, 0xe7, 0xc1, 0xb3, 0x4f, 0x4f, 0xe7, 0xa9, 0xbe, 0x5e, 0x4f, 0xfb, 0x33,
-	0xb1, 0x8c, 0xaf, 0xb7, 0x92, 0xab, 0x05, 0x67, 0x73, 0xae, 0xe2, 0xab, 0x64, 0xaa, 0xd2, 0x59,
-	0x8c, 0x3f, 0xa4, 0x55, 0x8c, 0x7f, 0xaa, 0xa9, 0x87, 0xe8, 0xec, 0x77, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0x10, 0xe3, 0xb3, 0x61, 0xb9, 0x04, 0x00, 0x00,
+	proto.RegisterFile("token/transaction.proto", fileDescriptor_transaction_a6492db343aa5d66)
+}
+
+var fileDescriptor_transaction_a6492db343aa5d66 = []byte{
+	// 1015 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xcd, 0x6e, 0x23, 0x45,
+	0x10, 0x8e, 0xe3, 0x9f, 0x38, 0x65, 0x3b, 0x71, 0x3a, 0x8e, 0x18, 0xfe, 0x56, 0xd1, 0xc0, 0xa2,
+	0x80, 0x90, 0x0d, 0xc9, 0x02, 0x37, 0xc4, 0x7a, 0x03, 0x6b, 0xb3, 0xa0, 0x8d, 0x9a, 0x9c, 0xb8,
+	0x8c, 0xc6, 0x33, 0x95, 0xb8, 0x65, 0x4f, 0x4f, 0xd3, 0xd3, 0xb3, 0xc4, 0x12, 0xcf, 0xc0, 0x03,
+	0x70, 0xe1, 0x89, 0xb8, 0xf2, 0x10, 0x88, 0x87, 0x40, 0xd3, 0xdd, 0xf3, 0x63, 0x27, 0x5e, 0x81,
+	0xc4, 0xcd, 0xf5, 0x7d, 0x55, 0x5d, 0xfd, 0x55, 0x95, 0x6b, 0x1a, 0xde, 0x50, 0xf1, 0x02, 0xf9,
+	0x48, 0x49, 0x9f, 0x27, 0x7e, 0xa0, 0x58, 0xcc, 0x87, 0x42, 0xc6, 0x2a, 0x76, 0xff, 0xa8, 0x41,
+	0xff, 0x3a, 0xe3, 0xae, 0x4b, 0x8a, 0x7c, 0x0e, 0x5d, 0xb1, 0xf4, 0x19, 0xf7, 0x8c, 0xed, 0xd4,
+	0x4e, 0x6b, 0x67, 0x9d, 0xf3, 0xa3, 0xe1, 0x55, 0x06, 0x6a, 0xef, 0xa7, 0x9a, 0x98, 0xec, 0xd0,
+	0x8e, 0x76, 0x34, 0x26, 0x19, 0x43, 0x1f, 0x79, 0x20, 0x57, 0x42, 0x61, 0x98, 0xc7, 0xd6, 0x75,
+	0xec, 0xc9, 0xf0, 0xeb, 0x9c, 0x58, 0x8f, 0x3f, 0x2c, 0x02, 0xec, 0x19, 0x17, 0x70, 0xe2, 0x0b,
+	0xb1, 0x64, 0x81, 0x9f, 0x99, 0x9e, 0xc4, 0x1b, 0x94, 0xc8, 0x03, 0x74, 0x76, 0x4f, 0x6b, 0x67,
+	0x5d, 0x3a, 0xa8, 0x90, 0x34, 0xe7, 0xc6, 0x6d, 0x68, 0x99, 0x74, 0xee, 0x9f, 0x35, 0x18, 0x3c,
+	0x94, 0x8a, 0x3c, 0x02, 0x08, 0x98, 0x98, 0xa3, 0x54, 0x78, 0xa7, 0xb4, 0xa2, 0x2e, 0xad, 0x20,
+	0x64, 0x00, 0x4d, 0x1e, 0x97, 0x79, 0x8c, 0x41, 0x3e, 0x81, 0x01, 0x8a, 0x39, 0x46, 0x28, 0xfd,
+	0xa5, 0x27, 0xd2, 0xd9, 0x92, 0x05, 0xde, 0x02, 0x57, 0x5a, 0x55, 0x97, 0x92, 0x82, 0xbb, 0xd2,
+	0xd4, 0x0b, 0x5c, 0x91, 0xc7, 0x70, 0xb0, 0xc0, 0x95, 0x17, 0xc4, 0x51, 0xc4, 0x54, 0x84, 0x5c,
+	0x39, 0x0d, 0xed, 0xdb, 0x5b, 0xe0, 0xea, 0x59, 0x01, 0x92, 0x11, 0x80, 0xc4, 0x80, 0x09, 0x86,
+	0x5c, 0x25, 0x4e, 0xf3, 0xb4, 0x7e, 0xd6, 0x39, 0x3f, 0x1c, 0xea, 0x0b, 0xd3, 0x1c, 0xa7, 0x15,
+	0x17, 0xf7, 0x3b, 0x38, 0x58, 0x67, 0xc9, 0x09, 0xb4, 0xa2, 0x44, 0x78, 0x2c, 0xd4, 0x6a, 0xf6,
+	0x69, 0x33, 0x4a, 0xc4, 0x34, 0x24, 0xef, 0x41, 0xaf, 0x6c, 0x42, 0x76, 0x57, 0x23, 0xa8, 0x5b,
+	0x80, 0x2f, 0x70, 0xe5, 0xfe, 0x5d, 0x87, 0xfe, 0x66, 0x37, 0xc9, 0xa7, 0x79, 0xdb, 0x59, 0x24,
+	0x62, 0xa9, 0x6c, 0xdb, 0xbb, 0xa6, 0xed, 0x53, 0x8d, 0x15, 0x1d, 0x37, 0x26, 0xf9, 0x02, 0x0e,
+	0x4c, 0x88, 0x9e, 0xac, 0x1b, 0x94, 0x3a, 0x5b, 0xe7, 0xfc, 0xc0, 0xce, 0x8a, 0x45, 0x27, 0x3b,
+	0xb4, 0x27, 0xaa, 0x00, 0xb9, 0xc8, 0x73, 0x49, 0x0c, 0x11, 0x23, 0xa7, 0xbe, 0x25, 0xcc, 0x64,
+	0xa3, 0xda, 0x89, 0x3c, 0x01, 0x73, 0x8a, 0xe7, 0x0b, 0x21, 0xe3, 0x57, 0xa8, 0x4b, 0xdb, 0x39,
+	0xef, 0x99, 0xa8, 0xa7, 0x06, 0x9c, 0xec, 0xd0, 0xae, 0xa8, 0xd8, 0xe4, 0x12, 0x8e, 0xd7, 0xef,
+	0xe8, 0x7d, 0x23, 0xe3, 0xc8, 0x69, 0xea, 0x58, 0xb2, 0x9e, 0x31, 0x63, 0x26, 0x3b, 0xf4, 0x48,
+	0x6c, 0x82, 0xe4, 0x02, 0xcc, 0x55, 0x3c, 0xe1, 0xa7, 0x09, 0x3a, 0x2d, 0x1d, 0xdd, 0x37, 0xd1,
+	0xcf, 0xe3, 0x57, 0x28, 0xb9, 0xcf, 0x83, 0x2c, 0x39, 0x68, 0xb7, 0xab, 0xcc, 0x8b, 0x7c, 0x56,
+	0xaa, 0x4c, 0xd2, 0x08, 0x9d, 0xbd, 0xad, 0x51, 0xb9, 0xce, 0xcc, 0x8d, 0x3c, 0x87, 0x81, 0x6d,
+	0x44, 0x92, 0xa4, 0xe8, 0x45, 0x3e, 0x67, 0x37, 0x98, 0x28, 0xa7, 0xad, 0xc3, 0x8f, 0x6d, 0x43,
+	0x32, 0xee, 0x7b, 0x4b, 0x4d, 0x76, 0x28, 0x11, 0xf7, 0xd0, 0x71, 0x0b, 0x1a, 0xa1, 0xaf, 0x7c,
+	0x97, 0x42, 0xa7, 0xd2, 0x44, 0xf2, 0x01, 0xec, 0xc5, 0xa9, 0x12, 0xa9, 0x4a, 0x9c, 0xda, 0x69,
+	0xbd, 0xec, 0xf1, 0x4b, 0x0d, 0xd2, 0x9c, 0x24, 0x6f, 0xc3, 0x7e, 0x82, 0x92, 0x61, 0x92, 0x0d,
+	0x99, 0x19, 0xa3, 0xb6, 0x01, 0xa6, 0xa1, 0xfb, 0x6b, 0x0d, 0x7a, 0x6b, 0xb5, 0x23, 0xa7, 0xd0,
+	0x62, 0xbc, 0x72, 0x6a, 0x7b, 0x38, 0xcd, 0xcc, 0x69, 0x48, 0x2d, 0x5e, 0x4d, 0xbc, 0xfb, 0xba,
+	0xc4, 0x17, 0xd0, 0x09, 0x71, 0x89, 0xb7, 0xfa, 0x6f, 0x9e, 0x38, 0x75, 0xed, 0x7b, 0x34, 0xfc,
+	0x81, 0xdd, 0x72, 0x0c, 0x2f, 0x0b, 0x86, 0x56, 0xbd, 0xdc, 0xdf, 0x6a, 0xd0, 0xad, 0x0e, 0xc2,
+	0xbf, 0xb8, 0xcf, 0x18, 0x8e, 0xec, 0x09, 0x18, 0x7a, 0xeb, 0x37, 0x3b, 0x31, 0x37, 0xbb, 0xcc,
+	0x69, 0x7b, 0xc5, 0x7e, 0xb8, 0x0e, 0x24, 0xe4, 0x7d, 0x68, 0x99, 0x48, 0x3b, 0xc3, 0xeb, 0x92,
+	0x2c, 0xe7, 0xfe, 0x5e, 0x83, 0xa3, 0x7b, 0x93, 0xf6, 0x3f, 0x56, 0xec, 0x2b, 0xe8, 0x6f, 0x2a,
+	0x29, 0x56, 0xef, 0x83, 0x42, 0x0e, 0x37, 0x84, 0xb8, 0x8f, 0xe1, 0x70, 0x63, 0x2c, 0x09, 0x81,
+	0x86, 0x5a, 0x09, 0xb4, 0xfb, 0x45, 0xff, 0x76, 0xaf, 0x81, 0xdc, 0x1f, 0x3f, 0xf2, 0x26, 0xb4,
+	0x67, 0xbe, 0x0a, 0xe6, 0xf9, 0x36, 0xea, 0xd2, 0x3d, 0x6d, 0xeb, 0x7d, 0xd4, 0x0a, 0xe6, 0x29,
+	0x5f, 0xe4, 0x02, 0x3a, 0x43, 0x1d, 0xfa, 0x2c, 0xc3, 0xa8, 0xa5, 0xdc, 0x2f, 0x01, 0x4a, 0x94,
+	0x1c, 0x43, 0x53, 0xdd, 0x95, 0x8b, 0xad, 0xa1, 0xee, 0xa6, 0xe1, 0xeb, 0x87, 0x31, 0xb6, 0x03,
+	0x6e, 0xb4, 0x64, 0xcb, 0x3c, 0xfe, 0x99, 0xa3, 0xb4, 0x77, 0x31, 0x46, 0x21, 0x67, 0xb7, 0x94,
+	0x43, 0xde, 0x82, 0xf6, 0x4f, 0xa9, 0xcf, 0x15, 0x53, 0x66, 0xa9, 0x37, 0x68, 0x61, 0x67, 0x5c,
+	0x84, 0xca, 0xcf, 0xfe, 0x41, 0x76, 0x89, 0x17, 0xb6, 0x3b, 0x85, 0xce, 0xc4, 0x4f, 0xe6, 0x18,
+	0xbe, 0xd4, 0x47, 0x6f, 0xdf, 0xc5, 0x2c, 0x44, 0x7d, 0x9a, 0x37, 0xf7, 0x93, 0x79, 0xbe, 0x8b,
+	0x73, 0x30, 0x3b, 0xc2, 0x7d, 0x02, 0x7b, 0xb6, 0xeb, 0x0f, 0x0b, 0x1f, 0x40, 0x93, 0xf1, 0x10,
+	0xef, 0x74, 0x70, 0x8f, 0x1a, 0xc3, 0xfd, 0x05, 0x06, 0x0f, 0xf5, 0x75, 0x8b, 0xf4, 0x47, 0x00,
+	0x79, 0xbf, 0xd1, 0x34, 0xa2, 0x4b, 0x2b, 0x48, 0x51, 0x9a, 0xfa, 0x96, 0xd2, 0x34, 0xd6, 0x4b,
+	0xe3, 0xfe, 0x55, 0x03, 0x28, 0xff, 0x87, 0xe4, 0x1d, 0xd8, 0xb7, 0x87, 0xc5, 0x79, 0xe2, 0x12,
+	0xa8, 0xb0, 0x98, 0x7f, 0x5e, 0x4b, 0xe0, 0xbf, 0xa6, 0x26, 0xef, 0x02, 0x04, 0x73, 0x9f, 0x73,
+	0x5c, 0x66, 0x85, 0x6a, 0xe9, 0xa8, 0x7d, 0x8b, 0x98, 0x6a, 0x99, 0xef, 0xf8, 0x5e, 0xf5, 0x3b,
+	0xfe, 0x21, 0xf4, 0xf1, 0x4e, 0x30, 0x69, 0x1e, 0x15, 0xb3, 0x65, 0x1c, 0x2c, 0xf4, 0x36, 0x6d,
+	0xd0, 0xc3, 0x12, 0x1f, 0x67, 0xf0, 0xb7, 0x8d, 0x76, 0xb3, 0xdf, 0xa2, 0x50, 0xc2, 0xee, 0x15,
+	0xf4, 0x37, 0x37, 0x4f, 0xa5, 0xa0, 0xf9, 0x03, 0xa9, 0x2c, 0xa8, 0xad, 0x48, 0xc2, 0x6e, 0xb9,
+	0xaf, 0x52, 0x59, 0x68, 0x2e, 0x80, 0xf1, 0xc7, 0x3f, 0x7e, 0x74, 0xcb, 0xd4, 0x3c, 0x9d, 0x0d,
+	0x83, 0x38, 0x1a, 0xcd, 0x57, 0x02, 0xe5, 0x12, 0xc3, 0x5b, 0x94, 0xa3, 0x1b, 0x7f, 0x26, 0x59,
+	0x30, 0xd2, 0x8f, 0xb3, 0x64, 0xa4, 0x5f, 0x6d, 0xb3, 0x96, 0xb6, 0x2e, 0xfe, 0x19, 0x00, 0x14,
+	0x82, 0x4e, 0x0e, 0xc5, 0x09, 0x00, 0x00,
 }
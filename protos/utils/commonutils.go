@@ -327,6 +327,23 @@ func ChannelID(env *cb.Envelope) (string, error) {
 	return chdr.ChannelId, nil
 }
 
+// IsExpired returns whether the transaction of the channel header cannot be
+// committed in the block with the passed number, either because the block is
+// past the last block of the transaction, or because the transaction claims
+// to have been created after its own expiration time.
+func IsExpired(chdr *cb.ChannelHeader, blockNum uint64) bool {
+	if chdr.NotValidAfterBlock != 0 && blockNum > chdr.NotValidAfterBlock {
+		return true
+	}
+	if chdr.NotValidAfter == nil || chdr.Timestamp == nil {
+		return false
+	}
+	if chdr.Timestamp.Seconds != chdr.NotValidAfter.Seconds {
+		return chdr.Timestamp.Seconds > chdr.NotValidAfter.Seconds
+	}
+	return chdr.Timestamp.Nanos > chdr.NotValidAfter.Nanos
+}
+
 // EnvelopeToConfigUpdate is used to extract a ConfigUpdateEnvelope from an envelope of
 // type CONFIG_UPDATE
 func EnvelopeToConfigUpdate(configtx *cb.Envelope) (*cb.ConfigUpdateEnvelope, error) {
@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/common/crypto"
 	cb "github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
@@ -349,6 +350,27 @@ func TestChannelHeader(t *testing.T) {
 	assert.Error(t, err, "Payload was missing")
 }
 
+func TestIsExpired(t *testing.T) {
+	assert.False(t, IsExpired(&cb.ChannelHeader{}, 100), "No expiration")
+
+	chdr := &cb.ChannelHeader{NotValidAfterBlock: 10}
+	assert.False(t, IsExpired(chdr, 9))
+	assert.False(t, IsExpired(chdr, 10), "The last block is not expired")
+	assert.True(t, IsExpired(chdr, 11))
+
+	chdr = &cb.ChannelHeader{
+		Timestamp:     &timestamp.Timestamp{Seconds: 100, Nanos: 5},
+		NotValidAfter: &timestamp.Timestamp{Seconds: 100, Nanos: 5},
+	}
+	assert.False(t, IsExpired(chdr, 0))
+	chdr.Timestamp.Nanos = 6
+	assert.True(t, IsExpired(chdr, 0), "Created after the expiration time")
+	chdr.Timestamp.Seconds = 99
+	assert.False(t, IsExpired(chdr, 0))
+	chdr.Timestamp = nil
+	assert.False(t, IsExpired(chdr, 0), "No timestamp to compare with")
+}
+
 func TestIsConfigBlock(t *testing.T) {
 	newBlock := func(env *cb.Envelope) *cb.Block {
 		return &cb.Block{
@@ -260,6 +260,14 @@ func CreateChaincodeProposalWithTransient(typ common.HeaderType, chainID string,
 // input. It returns the proposal and the transaction id associated with the
 // proposal
 func CreateChaincodeProposalWithTxIDAndTransient(typ common.HeaderType, chainID string, cis *peer.ChaincodeInvocationSpec, creator []byte, txid string, transientMap map[string][]byte) (*peer.Proposal, string, error) {
+	return CreateChaincodeProposalWithTxIDTransientAndHash(typ, chainID, cis, creator, txid, transientMap, nil)
+}
+
+// CreateChaincodeProposalWithTxIDTransientAndHash creates a proposal from given
+// input, whose transaction id is computed with the hashing suite of the
+// channel. A nil hash computes the transaction id with SHA256. It returns the
+// proposal and the transaction id associated with the proposal
+func CreateChaincodeProposalWithTxIDTransientAndHash(typ common.HeaderType, chainID string, cis *peer.ChaincodeInvocationSpec, creator []byte, txid string, transientMap map[string][]byte, hash func([]byte) []byte) (*peer.Proposal, string, error) {
 	// generate a random nonce
 	nonce, err := crypto.GetRandomNonce()
 	if err != nil {
@@ -268,7 +276,7 @@ func CreateChaincodeProposalWithTxIDAndTransient(typ common.HeaderType, chainID
 
 	// compute txid unless provided by tests
 	if txid == "" {
-		txid, err = ComputeTxID(nonce, creator)
+		txid, err = ComputeTxIDWithHash(nonce, creator, hash)
 		if err != nil {
 			return nil, "", err
 		}
@@ -470,6 +478,13 @@ func CreateProposalFromCIS(typ common.HeaderType, chainID string, cis *peer.Chai
 // CreateGetChaincodesProposal returns a GETCHAINCODES proposal given a
 // serialized identity
 func CreateGetChaincodesProposal(chainID string, creator []byte) (*peer.Proposal, string, error) {
+	return CreateGetChaincodesProposalWithHash(chainID, creator, nil)
+}
+
+// CreateGetChaincodesProposalWithHash returns a GETCHAINCODES proposal, whose
+// transaction id is computed with the hashing suite of the channel, given a
+// serialized identity
+func CreateGetChaincodesProposalWithHash(chainID string, creator []byte, hash func([]byte) []byte) (*peer.Proposal, string, error) {
 	ccinp := &peer.ChaincodeInput{Args: [][]byte{[]byte("getchaincodes")}}
 	lsccSpec := &peer.ChaincodeInvocationSpec{
 		ChaincodeSpec: &peer.ChaincodeSpec{
@@ -478,7 +493,7 @@ func CreateGetChaincodesProposal(chainID string, creator []byte) (*peer.Proposal
 			Input:       ccinp,
 		},
 	}
-	return CreateProposalFromCIS(common.HeaderType_ENDORSER_TRANSACTION, chainID, lsccSpec, creator)
+	return CreateChaincodeProposalWithTxIDTransientAndHash(common.HeaderType_ENDORSER_TRANSACTION, chainID, lsccSpec, creator, "", nil, hash)
 }
 
 // CreateGetInstalledChaincodesProposal returns a GETINSTALLEDCHAINCODES
@@ -498,7 +513,7 @@ func CreateGetInstalledChaincodesProposal(creator []byte) (*peer.Proposal, strin
 // CreateInstallProposalFromCDS returns a install proposal given a serialized
 // identity and a ChaincodeDeploymentSpec
 func CreateInstallProposalFromCDS(ccpack proto.Message, creator []byte) (*peer.Proposal, string, error) {
-	return createProposalFromCDS("", ccpack, creator, "install")
+	return createProposalFromCDS("", ccpack, creator, "install", nil)
 }
 
 // CreateDeployProposalFromCDS returns a deploy proposal given a serialized
@@ -511,10 +526,25 @@ func CreateDeployProposalFromCDS(
 	escc []byte,
 	vscc []byte,
 	collectionConfig []byte) (*peer.Proposal, string, error) {
+	return CreateDeployProposalFromCDSWithHash(chainID, cds, creator, policy, escc, vscc, collectionConfig, nil)
+}
+
+// CreateDeployProposalFromCDSWithHash returns a deploy proposal, whose transaction
+// id is computed with the hashing suite of the channel, given a serialized
+// identity and a ChaincodeDeploymentSpec
+func CreateDeployProposalFromCDSWithHash(
+	chainID string,
+	cds *peer.ChaincodeDeploymentSpec,
+	creator []byte,
+	policy []byte,
+	escc []byte,
+	vscc []byte,
+	collectionConfig []byte,
+	hash func([]byte) []byte) (*peer.Proposal, string, error) {
 	if collectionConfig == nil {
-		return createProposalFromCDS(chainID, cds, creator, "deploy", policy, escc, vscc)
+		return createProposalFromCDS(chainID, cds, creator, "deploy", hash, policy, escc, vscc)
 	}
-	return createProposalFromCDS(chainID, cds, creator, "deploy", policy, escc, vscc, collectionConfig)
+	return createProposalFromCDS(chainID, cds, creator, "deploy", hash, policy, escc, vscc, collectionConfig)
 }
 
 // CreateUpgradeProposalFromCDS returns a upgrade proposal given a serialized
@@ -527,15 +557,30 @@ func CreateUpgradeProposalFromCDS(
 	escc []byte,
 	vscc []byte,
 	collectionConfig []byte) (*peer.Proposal, string, error) {
+	return CreateUpgradeProposalFromCDSWithHash(chainID, cds, creator, policy, escc, vscc, collectionConfig, nil)
+}
+
+// CreateUpgradeProposalFromCDSWithHash returns an upgrade proposal, whose transaction
+// id is computed with the hashing suite of the channel, given a serialized
+// identity and a ChaincodeDeploymentSpec
+func CreateUpgradeProposalFromCDSWithHash(
+	chainID string,
+	cds *peer.ChaincodeDeploymentSpec,
+	creator []byte,
+	policy []byte,
+	escc []byte,
+	vscc []byte,
+	collectionConfig []byte,
+	hash func([]byte) []byte) (*peer.Proposal, string, error) {
 	if collectionConfig == nil {
-		return createProposalFromCDS(chainID, cds, creator, "upgrade", policy, escc, vscc)
+		return createProposalFromCDS(chainID, cds, creator, "upgrade", hash, policy, escc, vscc)
 	}
-	return createProposalFromCDS(chainID, cds, creator, "upgrade", policy, escc, vscc, collectionConfig)
+	return createProposalFromCDS(chainID, cds, creator, "upgrade", hash, policy, escc, vscc, collectionConfig)
 }
 
 // createProposalFromCDS returns a deploy or upgrade proposal given a
 // serialized identity and a ChaincodeDeploymentSpec
-func createProposalFromCDS(chainID string, msg proto.Message, creator []byte, propType string, args ...[]byte) (*peer.Proposal, string, error) {
+func createProposalFromCDS(chainID string, msg proto.Message, creator []byte, propType string, hash func([]byte) []byte, args ...[]byte) (*peer.Proposal, string, error) {
 	// in the new mode, cds will be nil, "deploy" and "upgrade" are instantiates.
 	var ccinp *peer.ChaincodeInput
 	var b []byte
@@ -572,7 +617,7 @@ func createProposalFromCDS(chainID string, msg proto.Message, creator []byte, pr
 	}
 
 	// ...and get the proposal for it
-	return CreateProposalFromCIS(common.HeaderType_ENDORSER_TRANSACTION, chainID, lsccSpec, creator)
+	return CreateChaincodeProposalWithTxIDTransientAndHash(common.HeaderType_ENDORSER_TRANSACTION, chainID, lsccSpec, creator, "", nil, hash)
 }
 
 // ComputeTxID computes TxID as the Hash computed
@@ -604,6 +649,34 @@ func CheckTxID(txid string, nonce, creator []byte) error {
 	return nil
 }
 
+// ComputeTxIDWithHash computes TxID as the hash, with the hashing suite
+// of a channel, of the concatenation of nonce and creator. A nil hash
+// computes the TxID with SHA256, like ComputeTxID.
+func ComputeTxIDWithHash(nonce, creator []byte, hash func([]byte) []byte) (string, error) {
+	if hash == nil {
+		return ComputeTxID(nonce, creator)
+	}
+	input := make([]byte, 0, len(nonce)+len(creator))
+	input = append(input, nonce...)
+	input = append(input, creator...)
+	return hex.EncodeToString(hash(input)), nil
+}
+
+// CheckTxIDWithHash checks that txid is equal to the hash, with the hashing
+// suite of a channel, of the concatenation of nonce and creator.
+func CheckTxIDWithHash(txid string, nonce, creator []byte, hash func([]byte) []byte) error {
+	computedTxID, err := ComputeTxIDWithHash(nonce, creator, hash)
+	if err != nil {
+		return errors.WithMessage(err, "error computing target txid")
+	}
+
+	if txid != computedTxID {
+		return errors.Errorf("invalid txid. got [%s], expected [%s]", txid, computedTxID)
+	}
+
+	return nil
+}
+
 // ComputeProposalBinding computes the binding of a proposal
 func ComputeProposalBinding(proposal *peer.Proposal) ([]byte, error) {
 	if proposal == nil {
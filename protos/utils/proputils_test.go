@@ -636,6 +636,56 @@ func TestComputeProposalTxID(t *testing.T) {
 	assert.Equal(t, txid, txid2)
 }
 
+func TestComputeTxIDWithHash(t *testing.T) {
+	txid, err := utils.ComputeTxIDWithHash([]byte{1}, []byte{2}, nil)
+	assert.NoError(t, err)
+	expected, err := utils.ComputeTxID([]byte{1}, []byte{2})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, txid)
+
+	txid, err = utils.ComputeTxIDWithHash([]byte{1}, []byte{2}, util.ComputeSHA3256)
+	assert.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(util.ComputeSHA3256([]byte{1, 2})), txid)
+	assert.NotEqual(t, expected, txid)
+
+	assert.NoError(t, utils.CheckTxIDWithHash(txid, []byte{1}, []byte{2}, util.ComputeSHA3256))
+	assert.EqualError(t, utils.CheckTxIDWithHash(expected, []byte{1}, []byte{2}, util.ComputeSHA3256),
+		fmt.Sprintf("invalid txid. got [%s], expected [%s]", expected, txid))
+	assert.NoError(t, utils.CheckTxIDWithHash(expected, []byte{1}, []byte{2}, nil))
+}
+
+func TestCreateProposalsWithHash(t *testing.T) {
+	checkTxID := func(prop *pb.Proposal, txid string) {
+		hdr, err := utils.GetHeader(prop.Header)
+		assert.NoError(t, err)
+		chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+		assert.NoError(t, err)
+		shdr, err := utils.GetSignatureHeader(hdr.SignatureHeader)
+		assert.NoError(t, err)
+		assert.Equal(t, txid, chdr.TxId)
+		assert.NoError(t, utils.CheckTxIDWithHash(chdr.TxId, shdr.Nonce, shdr.Creator, util.ComputeSHA3256))
+		assert.Error(t, utils.CheckTxID(chdr.TxId, shdr.Nonce, shdr.Creator))
+	}
+
+	cis := &pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "mycc"}}}
+	prop, txid, err := utils.CreateChaincodeProposalWithTxIDTransientAndHash(common.HeaderType_ENDORSER_TRANSACTION, "testchainid", cis, signerSerialized, "", nil, util.ComputeSHA3256)
+	assert.NoError(t, err)
+	checkTxID(prop, txid)
+
+	cds := &pb.ChaincodeDeploymentSpec{ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "mycc", Version: "1.0"}}}
+	prop, txid, err = utils.CreateDeployProposalFromCDSWithHash("testchainid", cds, signerSerialized, nil, nil, nil, nil, util.ComputeSHA3256)
+	assert.NoError(t, err)
+	checkTxID(prop, txid)
+
+	prop, txid, err = utils.CreateUpgradeProposalFromCDSWithHash("testchainid", cds, signerSerialized, nil, nil, nil, nil, util.ComputeSHA3256)
+	assert.NoError(t, err)
+	checkTxID(prop, txid)
+
+	prop, txid, err = utils.CreateGetChaincodesProposalWithHash("testchainid", signerSerialized, util.ComputeSHA3256)
+	assert.NoError(t, err)
+	checkTxID(prop, txid)
+}
+
 var signer msp.SigningIdentity
 var signerSerialized []byte
 
@@ -60,7 +60,7 @@ cat docs/wrappers/peer_logging_postscript.md >> $DOC
 DOC=docs/source/commands/peernode.md
 cat docs/wrappers/peer_node_preamble.md > $DOC
 
-for x in "peer node start" "peer node status"; do
+for x in "peer node start" "peer node status" "peer node membership"; do
   echo "" >> $DOC
   echo "##" $x >> $DOC
   echo "\`\`\`" >> $DOC
@@ -70,6 +70,19 @@ for x in "peer node start" "peer node status"; do
 done
 cat docs/wrappers/peer_node_postscript.md >> $DOC
 
+DOC=docs/source/commands/peertoken.md
+cat docs/wrappers/peer_token_preamble.md > $DOC
+
+for x in "peer token" "peer token inspect"; do
+  echo "" >> $DOC
+  echo "##" $x >> $DOC
+  echo "\`\`\`" >> $DOC
+  .build/bin/${x} --help 1>> $DOC 2>/dev/null
+  echo "\`\`\`" >> $DOC
+  echo "" >> $DOC
+done
+cat docs/wrappers/peer_token_postscript.md >> $DOC
+
 DOC=${PWD}/docs/source/commands/configtxgen.md
 cat docs/wrappers/configtxgen_preamble.md > $DOC
 
@@ -0,0 +1,196 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/pkg/errors"
+)
+
+// DefaultChunkSize is the number of tokens imported by each transaction of a
+// batch when the BatchIssuer does not set one.
+const DefaultChunkSize = 1000
+
+//go:generate counterfeiter -o mock/batch_prover.go -fake-name BatchProver . BatchProver
+
+// BatchProver assembles the imports of the chunks of a batch and the manifest
+// tying them together. It is implemented by ProverPeer.
+type BatchProver interface {
+	RequestImportContext(ctx context.Context, tokensToIssue []*token.TokenToIssue, signingIdentity tk.SigningIdentity) ([]byte, error)
+	RequestIssueManifestContext(ctx context.Context, batchID []byte, chunks []*token.IssueChunk, signingIdentity tk.SigningIdentity) ([]byte, error)
+}
+
+//go:generate counterfeiter -o mock/batch_submitter.go -fake-name BatchSubmitter . BatchSubmitter
+
+// BatchSubmitter submits the transactions of a batch and waits for them to be
+// committed. It is implemented by TxSubmitter and GatewaySubmitter.
+type BatchSubmitter interface {
+	CreateTxEnvelope(txBytes []byte) (string, *common.Envelope, error)
+	SubmitTransactionContext(ctx context.Context, txEnvelope *common.Envelope) (committed bool, txId string, err error)
+}
+
+//go:generate counterfeiter -o mock/issue_source.go -fake-name IssueSource . IssueSource
+
+// IssueSource streams the tokens of a batch, so that batches need not fit in
+// memory. Next returns io.EOF after the last token.
+type IssueSource interface {
+	Next() (*token.TokenToIssue, error)
+}
+
+// BatchReceipt records the transactions of a batch: the imports of its chunks
+// and, once they are all committed, the manifest tying them together.
+type BatchReceipt struct {
+	BatchID      []byte
+	Chunks       []*token.IssueChunk
+	ManifestTxID string
+}
+
+// BatchIssuer issues batches of tokens too large for a single transaction. It
+// imports the tokens in chunks, each in its own transaction, and then submits
+// a manifest transaction listing the chunks, so that auditors can account for
+// the whole batch. The committer only accepts the manifest if every chunk was
+// imported by the same issuer.
+type BatchIssuer struct {
+	Prover          BatchProver
+	Submitter       BatchSubmitter
+	SigningIdentity tk.SigningIdentity
+	// ChunkSize is the number of tokens imported by each transaction; 0
+	// means DefaultChunkSize.
+	ChunkSize int
+}
+
+// IssueBatch issues the tokens of source in chunks and ties them together with
+// a manifest. The import of each chunk is a series whose ID is derived from
+// the batch ID, and carries the batch ID as application reference, so that
+// the chunks of a batch can be listed by reference. The chunks are submitted
+// one at a time, each after the previous one is committed.
+//
+// When a chunk or the manifest fails, the returned receipt records the
+// committed chunks, and ResumeBatch continues the batch from there.
+func (b *BatchIssuer) IssueBatch(ctx context.Context, batchID []byte, source IssueSource) (*BatchReceipt, error) {
+	if len(batchID) == 0 {
+		return nil, errors.New("batch ID is required")
+	}
+	receipt := &BatchReceipt{BatchID: batchID}
+	return receipt, b.ResumeBatch(ctx, receipt, source)
+}
+
+// ResumeBatch continues the batch of the receipt with the tokens of source,
+// which must start after the tokens of the chunks already in the receipt, and
+// records the chunks and the manifest in the receipt.
+func (b *BatchIssuer) ResumeBatch(ctx context.Context, receipt *BatchReceipt, source IssueSource) error {
+	if receipt.ManifestTxID != "" {
+		return errors.Errorf("batch '%s' is already complete", receipt.BatchID)
+	}
+	chunkSize := b.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	for done := false; !done; {
+		var tokensToIssue []*token.TokenToIssue
+		for len(tokensToIssue) < chunkSize {
+			tokenToIssue, err := source.Next()
+			if err == io.EOF {
+				done = true
+				break
+			}
+			if err != nil {
+				return errors.Wrap(err, "failed reading the tokens of the batch")
+			}
+			tokensToIssue = append(tokensToIssue, tokenToIssue)
+		}
+		if len(tokensToIssue) == 0 {
+			break
+		}
+
+		chunk, err := b.importChunk(ctx, receipt.BatchID, len(receipt.Chunks), tokensToIssue)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("failed importing chunk %d of batch '%s'", len(receipt.Chunks), receipt.BatchID))
+		}
+		receipt.Chunks = append(receipt.Chunks, chunk)
+	}
+	if len(receipt.Chunks) == 0 {
+		return errors.Errorf("no tokens in batch '%s'", receipt.BatchID)
+	}
+
+	raw, err := b.Prover.RequestIssueManifestContext(ctx, receipt.BatchID, receipt.Chunks, b.SigningIdentity)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed requesting the manifest of batch '%s'", receipt.BatchID))
+	}
+	txID, err := b.submit(ctx, raw, nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed submitting the manifest of batch '%s'", receipt.BatchID))
+	}
+	receipt.ManifestTxID = txID
+	return nil
+}
+
+// ChunkSeriesID returns the series ID of the import of the chunk with the
+// passed index of a batch.
+func ChunkSeriesID(batchID []byte, index int) []byte {
+	return []byte(fmt.Sprintf("%s/%d", batchID, index))
+}
+
+func (b *BatchIssuer) importChunk(ctx context.Context, batchID []byte, index int, tokensToIssue []*token.TokenToIssue) (*token.IssueChunk, error) {
+	raw, err := b.Prover.RequestImportContext(ctx, tokensToIssue, b.SigningIdentity)
+	if err != nil {
+		return nil, err
+	}
+	seriesID := ChunkSeriesID(batchID, index)
+	txID, err := b.submit(ctx, raw, seriesID, batchID)
+	if err != nil {
+		return nil, err
+	}
+	return &token.IssueChunk{TxId: txID, SeriesId: seriesID}, nil
+}
+
+// submit submits the token transaction of the command response of the prover,
+// with the series ID and the application reference when set, and waits for it
+// to be committed. It returns the ID of the transaction.
+func (b *BatchIssuer) submit(ctx context.Context, commandResponse []byte, seriesID []byte, reference []byte) (string, error) {
+	response := &token.CommandResponse{}
+	err := proto.Unmarshal(commandResponse, response)
+	if err != nil {
+		return "", errors.Wrap(err, "failed unmarshaling command response")
+	}
+	if response.GetErr() != nil {
+		return "", errors.Errorf("prover responded error: %s", response.GetErr().GetMessage())
+	}
+	tx, err := proto.Marshal(response.GetTokenTransaction())
+	if err != nil {
+		return "", errors.Wrap(err, "failed marshaling token transaction")
+	}
+	tx, err = setSeriesID(tx, seriesID)
+	if err != nil {
+		return "", err
+	}
+	tx, err = setApplicationReference(tx, reference)
+	if err != nil {
+		return "", err
+	}
+
+	_, envelope, err := b.Submitter.CreateTxEnvelope(tx)
+	if err != nil {
+		return "", err
+	}
+	committed, txID, err := b.Submitter.SubmitTransactionContext(ctx, envelope)
+	if err != nil {
+		return "", err
+	}
+	if !committed {
+		return "", errors.Errorf("transaction %s not committed", txID)
+	}
+	return txID, nil
+}
@@ -0,0 +1,190 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/hyperledger/fabric/token/client/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("BatchIssuer", func() {
+	var (
+		fakeSigningIdentity *mock.SigningIdentity
+		fakeProver          *mock.BatchProver
+		fakeSubmitter       *mock.BatchSubmitter
+		fakeSource          *mock.IssueSource
+
+		submitted   []*token.TokenTransaction
+		batchIssuer *client.BatchIssuer
+	)
+
+	tokensToIssue := func(n int) []*token.TokenToIssue {
+		var tokens []*token.TokenToIssue
+		for i := 0; i < n; i++ {
+			tokens = append(tokens, &token.TokenToIssue{Recipient: []byte("Alice"), Type: "ABC", Quantity: uint64(i + 1)})
+		}
+		return tokens
+	}
+
+	streamTokens := func(tokens []*token.TokenToIssue) {
+		for i, t := range tokens {
+			fakeSource.NextReturnsOnCall(i, t, nil)
+		}
+		fakeSource.NextReturns(nil, io.EOF)
+	}
+
+	BeforeEach(func() {
+		fakeSigningIdentity = &mock.SigningIdentity{}
+
+		fakeProver = &mock.BatchProver{}
+		fakeProver.RequestImportContextStub = func(ctx context.Context, tokensToIssue []*token.TokenToIssue, _ tk.SigningIdentity) ([]byte, error) {
+			return ProtoMarshal(&token.CommandResponse{Payload: &token.CommandResponse_TokenTransaction{
+				TokenTransaction: &token.TokenTransaction{Action: &token.TokenTransaction_PlainAction{PlainAction: &token.PlainTokenAction{
+					Data: &token.PlainTokenAction_PlainImport{PlainImport: &token.PlainImport{}},
+				}}},
+			}}), nil
+		}
+		fakeProver.RequestIssueManifestContextReturns(ProtoMarshal(&token.CommandResponse{Payload: &token.CommandResponse_TokenTransaction{
+			TokenTransaction: &token.TokenTransaction{},
+		}}), nil)
+
+		submitted = nil
+		fakeSubmitter = &mock.BatchSubmitter{}
+		fakeSubmitter.CreateTxEnvelopeStub = func(tx []byte) (string, *common.Envelope, error) {
+			ttx := &token.TokenTransaction{}
+			Expect(proto.Unmarshal(tx, ttx)).To(Succeed())
+			submitted = append(submitted, ttx)
+			txID := fmt.Sprintf("tx-%d", len(submitted))
+			return txID, &common.Envelope{Payload: []byte(txID)}, nil
+		}
+		fakeSubmitter.SubmitTransactionContextStub = func(ctx context.Context, envelope *common.Envelope) (bool, string, error) {
+			return true, string(envelope.Payload), nil
+		}
+
+		fakeSource = &mock.IssueSource{}
+		streamTokens(tokensToIssue(5))
+
+		batchIssuer = &client.BatchIssuer{
+			Prover:          fakeProver,
+			Submitter:       fakeSubmitter,
+			SigningIdentity: fakeSigningIdentity,
+			ChunkSize:       2,
+		}
+	})
+
+	It("imports the tokens in chunks and ties them together with a manifest", func() {
+		receipt, err := batchIssuer.IssueBatch(context.Background(), []byte("batch-1"), fakeSource)
+		Expect(err).NotTo(HaveOccurred())
+
+		chunks := []*token.IssueChunk{
+			{TxId: "tx-1", SeriesId: []byte("batch-1/0")},
+			{TxId: "tx-2", SeriesId: []byte("batch-1/1")},
+			{TxId: "tx-3", SeriesId: []byte("batch-1/2")},
+		}
+		Expect(receipt).To(Equal(&client.BatchReceipt{BatchID: []byte("batch-1"), Chunks: chunks, ManifestTxID: "tx-4"}))
+
+		Expect(fakeProver.RequestImportContextCallCount()).To(Equal(3))
+		_, imported, signingIdentity := fakeProver.RequestImportContextArgsForCall(0)
+		Expect(imported).To(Equal(tokensToIssue(2)))
+		Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+		_, imported, _ = fakeProver.RequestImportContextArgsForCall(2)
+		Expect(imported).To(Equal(tokensToIssue(5)[4:]))
+
+		Expect(submitted).To(HaveLen(4))
+		for i := 0; i < 3; i++ {
+			Expect(submitted[i].GetPlainAction().GetPlainImport().GetSeriesId()).To(Equal(client.ChunkSeriesID([]byte("batch-1"), i)))
+			Expect(submitted[i].ApplicationReference).To(Equal([]byte("batch-1")))
+		}
+
+		Expect(fakeProver.RequestIssueManifestContextCallCount()).To(Equal(1))
+		_, batchID, manifestChunks, _ := fakeProver.RequestIssueManifestContextArgsForCall(0)
+		Expect(batchID).To(Equal([]byte("batch-1")))
+		Expect(manifestChunks).To(Equal(chunks))
+	})
+
+	It("uses the default chunk size when none is set", func() {
+		batchIssuer.ChunkSize = 0
+		receipt, err := batchIssuer.IssueBatch(context.Background(), []byte("batch-1"), fakeSource)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(receipt.Chunks).To(HaveLen(1))
+	})
+
+	It("rejects batches without ID or tokens", func() {
+		_, err := batchIssuer.IssueBatch(context.Background(), nil, fakeSource)
+		Expect(err).To(MatchError("batch ID is required"))
+
+		fakeSource = &mock.IssueSource{}
+		fakeSource.NextReturns(nil, io.EOF)
+		_, err = batchIssuer.IssueBatch(context.Background(), []byte("batch-1"), fakeSource)
+		Expect(err).To(MatchError("no tokens in batch 'batch-1'"))
+	})
+
+	Context("when a chunk is not committed", func() {
+		BeforeEach(func() {
+			fakeSubmitter.SubmitTransactionContextStub = func(ctx context.Context, envelope *common.Envelope) (bool, string, error) {
+				txID := string(envelope.Payload)
+				return txID != "tx-2", txID, nil
+			}
+		})
+
+		It("returns the committed chunks, from which the batch can be resumed", func() {
+			receipt, err := batchIssuer.IssueBatch(context.Background(), []byte("batch-1"), fakeSource)
+			Expect(err).To(MatchError("failed importing chunk 1 of batch 'batch-1': transaction tx-2 not committed"))
+			Expect(receipt.Chunks).To(Equal([]*token.IssueChunk{{TxId: "tx-1", SeriesId: []byte("batch-1/0")}}))
+			Expect(receipt.ManifestTxID).To(BeEmpty())
+
+			fakeSource = &mock.IssueSource{}
+			streamTokens(tokensToIssue(5)[2:])
+			Expect(batchIssuer.ResumeBatch(context.Background(), receipt, fakeSource)).To(Succeed())
+			Expect(receipt.Chunks).To(HaveLen(3))
+			Expect(receipt.Chunks[1].SeriesId).To(Equal([]byte("batch-1/1")))
+			Expect(receipt.Chunks[2].SeriesId).To(Equal([]byte("batch-1/2")))
+			Expect(receipt.ManifestTxID).To(Equal("tx-5"))
+
+			err = batchIssuer.ResumeBatch(context.Background(), receipt, fakeSource)
+			Expect(err).To(MatchError("batch 'batch-1' is already complete"))
+		})
+	})
+
+	Context("when the source fails", func() {
+		BeforeEach(func() {
+			fakeSource.NextReturnsOnCall(3, nil, errors.New("disk on fire"))
+		})
+
+		It("returns the error", func() {
+			receipt, err := batchIssuer.IssueBatch(context.Background(), []byte("batch-1"), fakeSource)
+			Expect(err).To(MatchError("failed reading the tokens of the batch: disk on fire"))
+			Expect(receipt.Chunks).To(HaveLen(1))
+		})
+	})
+
+	Context("when the prover responds an error", func() {
+		BeforeEach(func() {
+			fakeProver.RequestIssueManifestContextReturns(ProtoMarshal(&token.CommandResponse{Payload: &token.CommandResponse_Err{
+				Err: &token.Error{Message: "flipper"},
+			}}), nil)
+		})
+
+		It("returns the error", func() {
+			receipt, err := batchIssuer.IssueBatch(context.Background(), []byte("batch-1"), fakeSource)
+			Expect(err).To(MatchError("failed submitting the manifest of batch 'batch-1': prover responded error: flipper"))
+			Expect(receipt.Chunks).To(HaveLen(3))
+			Expect(receipt.ManifestTxID).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,238 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"io/ioutil"
+	"sort"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/protos/utils"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/hyperledger/fabric/token/lightclient"
+	"github.com/pkg/errors"
+)
+
+// ChannelConfigBlock is the configuration of a channel read from a config
+// block exported to a file, for instance with
+// 'peer channel fetch config'. It lets air-gapped clients learn the MSPs, the
+// orderer endpoints and the capabilities of the channel without querying a
+// peer. Unless the config blocks that follow it are passed to Update, it must
+// be exported again after every channel configuration update.
+//
+// The block is trusted on first use, unless the hash of its header is pinned,
+// for instance with the software of the client, in which case the block is
+// verified against the hash. The config blocks committed after it can then
+// be verified with Update, which checks that the orderers of the channel
+// signed them, so that the client follows the changes of the orderers and
+// of the MSPs of the channel without trusting the peer they are fetched
+// from.
+type ChannelConfigBlock struct {
+	mutex    sync.RWMutex
+	verifier *lightclient.Verifier
+}
+
+// LoadChannelConfigBlock reads the config block in the file at path.
+func LoadChannelConfigBlock(path string) (*ChannelConfigBlock, error) {
+	block, err := readConfigBlock(path)
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := lightclient.NewVerifier(block)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid channel config block "+path)
+	}
+	return &ChannelConfigBlock{verifier: verifier}, nil
+}
+
+// LoadPinnedChannelConfigBlock reads the config block in the file at path,
+// whose header must have the hex encoded hash, the previous hash of the next
+// block.
+func LoadPinnedChannelConfigBlock(path, hash string) (*ChannelConfigBlock, error) {
+	headerHash, err := hex.DecodeString(hash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid channel config block hash %s", hash)
+	}
+	block, err := readConfigBlock(path)
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := lightclient.NewPinnedVerifier(block, headerHash)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid channel config block "+path)
+	}
+	return &ChannelConfigBlock{verifier: verifier}, nil
+}
+
+func readConfigBlock(path string) (*common.Block, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading channel config block %s", path)
+	}
+	block := &common.Block{}
+	if err := proto.Unmarshal(raw, block); err != nil {
+		return nil, errors.Wrapf(err, "failed unmarshaling channel config block %s", path)
+	}
+	if !utils.IsConfigBlock(block) {
+		return nil, errors.Errorf("block %s is not a config block", path)
+	}
+	return block, nil
+}
+
+// Update verifies that a config block committed after the block was signed
+// by the orderers of the channel, and updates the configuration to the one
+// of the block. The connections already established are not affected.
+func (b *ChannelConfigBlock) Update(block *common.Block) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.verifier.AddCheckpoint(block)
+}
+
+// Number returns the number of the config block of the configuration.
+func (b *ChannelConfigBlock) Number() uint64 {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.verifier.Checkpoint()
+}
+
+func (b *ChannelConfigBlock) bundle() *channelconfig.Bundle {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.verifier.Config()
+}
+
+// TLSRootCerts returns the TLS root and intermediate certificates of the
+// MSPs of the channel, with which the client authenticates the orderers and
+// the peers of the channel rather than with the certificates of files.
+func (b *ChannelConfigBlock) TLSRootCerts() ([][]byte, error) {
+	msps, err := b.bundle().MSPManager().GetMSPs()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(msps))
+	for id := range msps {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	var certs [][]byte
+	for _, id := range ids {
+		certs = append(certs, msps[id].GetTLSRootCerts()...)
+		certs = append(certs, msps[id].GetTLSIntermediateCerts()...)
+	}
+	return certs, nil
+}
+
+// ChannelId returns the ID of the channel.
+func (b *ChannelConfigBlock) ChannelId() string {
+	return b.bundle().ConfigtxValidator().ChainID()
+}
+
+// OrdererAddresses returns the addresses of the orderers of the channel.
+func (b *ChannelConfigBlock) OrdererAddresses() []string {
+	return b.bundle().ChannelConfig().OrdererAddresses()
+}
+
+// MSPManager returns the MSPs of the channel, with which clients validate
+// the identities of the owners and the signers of tokens.
+func (b *ChannelConfigBlock) MSPManager() msp.MSPManager {
+	return b.bundle().MSPManager()
+}
+
+// MSPIDs returns the sorted MSP IDs of the application orgs of the channel.
+func (b *ChannelConfigBlock) MSPIDs() []string {
+	ac, ok := b.bundle().ApplicationConfig()
+	if !ok {
+		return nil
+	}
+	var ids []string
+	for _, org := range ac.Organizations() {
+		ids = append(ids, org.MSPID())
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Capabilities returns the token capabilities of the channel, as a prover
+// peer would report them.
+func (b *ChannelConfigBlock) Capabilities() *token.ChannelCapabilities {
+	capabilities := &token.ChannelCapabilities{HashingSuite: b.bundle().ChannelConfig().HashingSuiteName()}
+	ac, ok := b.bundle().ApplicationConfig()
+	if !ok {
+		return capabilities
+	}
+	capabilities.FabToken = ac.Capabilities().FabToken()
+	capabilities.TxExpiration = ac.Capabilities().TxExpiration()
+	if !ac.Capabilities().TokenEncryption() {
+		return capabilities
+	}
+	keys := map[string][]byte{}
+	for _, org := range ac.Organizations() {
+		key := org.TokenEncryptionKey()
+		if key == nil {
+			return capabilities
+		}
+		keys[org.MSPID()] = key
+	}
+	capabilities.TokenEncryptionKeys = keys
+	return capabilities
+}
+
+// GetChannelCapabilitiesContext implements CapabilitiesProver, so that a
+// ChannelCapabilities reads the capabilities from the block rather than from
+// a prover peer.
+func (b *ChannelConfigBlock) GetChannelCapabilitiesContext(ctx context.Context, signingIdentity tk.SigningIdentity) (*token.ChannelCapabilities, error) {
+	return b.Capabilities(), nil
+}
+
+// Bootstrap completes config with the channel ID, the hashing suite, the
+// first orderer address and, when TLS is enabled, the TLS root certificates
+// of the block, unless config sets them, and returns an error if config
+// targets another channel or hashing suite.
+func (b *ChannelConfigBlock) Bootstrap(config *ClientConfig) error {
+	switch config.ChannelId {
+	case "":
+		config.ChannelId = b.ChannelId()
+	case b.ChannelId():
+	default:
+		return errors.Errorf("channel config block is for channel %s, not %s", b.ChannelId(), config.ChannelId)
+	}
+
+	hashingSuite := b.bundle().ChannelConfig().HashingSuiteName()
+	if config.HashingSuite == "" {
+		config.HashingSuite = hashingSuite
+	} else if hashingSuiteName(config.HashingSuite) != hashingSuiteName(hashingSuite) {
+		return errors.Errorf("channel %s computes transaction IDs with %s, not %s", b.ChannelId(), hashingSuiteName(hashingSuite), config.HashingSuite)
+	}
+
+	if config.OrdererCfg.Address == "" && config.GatewayPeerCfg.Address == "" {
+		addresses := b.OrdererAddresses()
+		if len(addresses) == 0 {
+			return errors.Errorf("no orderer addresses found in channel config block of channel %s", b.ChannelId())
+		}
+		config.OrdererCfg.Address = addresses[0]
+	}
+
+	if config.TlsEnabled {
+		certs, err := b.TLSRootCerts()
+		if err != nil {
+			return errors.WithMessage(err, "failed reading TLS root certificates of channel "+b.ChannelId())
+		}
+		for _, cfg := range []*ConnectionConfig{&config.OrdererCfg, &config.CommitPeerCfg, &config.ProverPeerCfg, &config.GatewayPeerCfg} {
+			if cfg.Address != "" && !cfg.hasTlsRootCerts() {
+				cfg.TlsRootCerts = certs
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client_test
+
+import (
+	"context"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
+	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/msp/mgmt/testtools"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/hyperledger/fabric/token/client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ChannelConfigBlock", func() {
+	var (
+		dir     string
+		path    string
+		genesis *common.Block
+		block   *client.ChannelConfigBlock
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "tokenclient")
+		Expect(err).NotTo(HaveOccurred())
+		path = filepath.Join(dir, "config.block")
+
+		genesis, err = configtxtest.MakeGenesisBlock("testchannel")
+		Expect(err).NotTo(HaveOccurred())
+		err = ioutil.WriteFile(path, ProtoMarshal(genesis), 0644)
+		Expect(err).NotTo(HaveOccurred())
+
+		block, err = client.LoadChannelConfigBlock(path)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("returns the configuration of the channel", func() {
+		Expect(block.ChannelId()).To(Equal("testchannel"))
+		Expect(block.OrdererAddresses()).To(Equal([]string{"127.0.0.1:7050"}))
+		Expect(block.MSPIDs()).To(Equal([]string{"SampleOrg"}))
+
+		msps, err := block.MSPManager().GetMSPs()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(msps).To(HaveKey("SampleOrg"))
+	})
+
+	It("reports the token capabilities of the channel", func() {
+		capabilities := &client.ChannelCapabilities{ChannelId: "testchannel", Prover: block}
+		caps, err := capabilities.Get(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(caps.HashingSuite).To(Equal("SHA256"))
+		Expect(caps.TokenEncryptionKeys).To(BeNil())
+		Expect(caps.TxExpiration).To(BeFalse())
+
+		err = capabilities.CheckFabToken(context.Background())
+		Expect(err).To(MatchError("FabToken capability not enabled for channel testchannel"))
+	})
+
+	Describe("Bootstrap", func() {
+		It("completes the config", func() {
+			config := &client.ClientConfig{}
+			err := block.Bootstrap(config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config).To(Equal(&client.ClientConfig{
+				ChannelId:    "testchannel",
+				HashingSuite: "SHA256",
+				OrdererCfg:   client.ConnectionConfig{Address: "127.0.0.1:7050"},
+			}))
+		})
+
+		It("keeps the orderer address of the config", func() {
+			config := &client.ClientConfig{OrdererCfg: client.ConnectionConfig{Address: "orderer:7050"}}
+			err := block.Bootstrap(config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.OrdererCfg.Address).To(Equal("orderer:7050"))
+		})
+
+		It("completes the TLS root certificates of the connections when TLS is enabled", func() {
+			config := &client.ClientConfig{
+				TlsEnabled:    true,
+				ProverPeerCfg: client.ConnectionConfig{Address: "peer:7051"},
+				CommitPeerCfg: client.ConnectionConfig{Address: "peer:7051", TlsRootCertFile: "ca.crt"},
+			}
+			err := block.Bootstrap(config)
+			Expect(err).NotTo(HaveOccurred())
+
+			certs, err := block.TLSRootCerts()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(certs).To(HaveLen(2))
+			Expect(config.OrdererCfg.TlsRootCerts).To(Equal(certs))
+			Expect(config.ProverPeerCfg.TlsRootCerts).To(Equal(certs))
+			Expect(config.CommitPeerCfg.TlsRootCerts).To(BeNil())
+			Expect(config.GatewayPeerCfg.TlsRootCerts).To(BeNil())
+		})
+
+		Context("when the config uses another hashing suite", func() {
+			It("returns an error", func() {
+				err := block.Bootstrap(&client.ClientConfig{HashingSuite: "SHA3_256"})
+				Expect(err).To(MatchError("channel testchannel computes transaction IDs with SHA256, not SHA3_256"))
+			})
+		})
+	})
+
+	Describe("LoadPinnedChannelConfigBlock", func() {
+		It("loads the block with the pinned hash", func() {
+			pinned, err := client.LoadPinnedChannelConfigBlock(path, hex.EncodeToString(genesis.Header.Hash()))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pinned.ChannelId()).To(Equal("testchannel"))
+		})
+
+		It("rejects the blocks with another hash", func() {
+			_, err := client.LoadPinnedChannelConfigBlock(path, "0102")
+			Expect(err).To(MatchError("invalid channel config block " + path + ": config block 0 does not have the pinned hash 0102"))
+		})
+
+		It("rejects the hashes not hex encoded", func() {
+			_, err := client.LoadPinnedChannelConfigBlock(path, "hash")
+			Expect(err).To(MatchError(ContainSubstring("invalid channel config block hash hash")))
+		})
+	})
+
+	Describe("Update", func() {
+		var configBlock *common.Block
+
+		BeforeEach(func() {
+			err := msptesttools.LoadMSPSetupForTesting()
+			Expect(err).NotTo(HaveOccurred())
+			signer := localmsp.NewSigner()
+
+			configBlock = common.NewBlock(5, []byte("previous-hash"))
+			configBlock.Data = genesis.Data
+			configBlock.Header.DataHash = configBlock.Data.Hash()
+			shdr, err := signer.NewSignatureHeader()
+			Expect(err).NotTo(HaveOccurred())
+			signature := &common.MetadataSignature{SignatureHeader: ProtoMarshal(shdr)}
+			signature.Signature, err = signer.Sign(util.ConcatenateBytes(nil, signature.SignatureHeader, configBlock.Header.Bytes()))
+			Expect(err).NotTo(HaveOccurred())
+			configBlock.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(&common.Metadata{
+				Signatures: []*common.MetadataSignature{signature},
+			})
+		})
+
+		It("follows the config blocks signed by the orderers", func() {
+			Expect(block.Update(configBlock)).To(Succeed())
+			Expect(block.Number()).To(Equal(uint64(5)))
+			Expect(block.ChannelId()).To(Equal("testchannel"))
+		})
+
+		It("rejects the config blocks not signed by the orderers", func() {
+			configBlock.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = nil
+			err := block.Update(configBlock)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix("block 5 is not signed by the orderers of the channel"))
+			Expect(block.Number()).To(Equal(uint64(0)))
+		})
+	})
+
+	Context("when the block is not a config block", func() {
+		It("returns an error", func() {
+			err := ioutil.WriteFile(path, ProtoMarshal(common.NewBlock(1, nil)), 0644)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.LoadChannelConfigBlock(path)
+			Expect(err).To(MatchError("block " + path + " is not a config block"))
+		})
+	})
+})
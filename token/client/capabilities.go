@@ -0,0 +1,138 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"sync"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/hyperledger/fabric/token/encryption"
+	"github.com/pkg/errors"
+)
+
+//go:generate counterfeiter -o mock/capabilities_prover.go -fake-name CapabilitiesProver . CapabilitiesProver
+
+// CapabilitiesProver returns the token capabilities of a channel. It is implemented by ProverPeer.
+type CapabilitiesProver interface {
+	GetChannelCapabilitiesContext(ctx context.Context, signingIdentity tk.SigningIdentity) (*token.ChannelCapabilities, error)
+}
+
+// ChannelCapabilities fetches the token capabilities of a channel from the
+// prover peer and caches them, so that clients can reject operations the
+// channel does not support before submitting them.
+type ChannelCapabilities struct {
+	ChannelId       string
+	Prover          CapabilitiesProver
+	SigningIdentity tk.SigningIdentity
+
+	mutex        sync.Mutex
+	capabilities *token.ChannelCapabilities
+}
+
+// Get returns the capabilities of the channel, fetching them on first use.
+// Failures are not cached.
+//
+// The provers predating capability requests only process the commands of the
+// channels with the FabToken capability, and predate hashing suites and token
+// encryption: their capabilities are derived from their rejection of the
+// request.
+func (c *ChannelCapabilities) Get(ctx context.Context) (*token.ChannelCapabilities, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.capabilities != nil {
+		return c.capabilities, nil
+	}
+	capabilities, err := c.Prover.GetChannelCapabilitiesContext(ctx, c.SigningIdentity)
+	if _, ok := errors.Cause(err).(*IncompatibleProverError); ok {
+		logger.Warningf("prover peer does not report the capabilities of channel %s, assuming a channel with the FabToken capability only: %s", c.ChannelId, err)
+		capabilities, err = &token.ChannelCapabilities{FabToken: true}, nil
+	}
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed fetching channel capabilities")
+	}
+	c.capabilities = capabilities
+	return capabilities, nil
+}
+
+// Invalidate drops the cached capabilities, for instance after a channel
+// configuration update, so that the next call to Get fetches them again.
+func (c *ChannelCapabilities) Invalidate() {
+	c.mutex.Lock()
+	c.capabilities = nil
+	c.mutex.Unlock()
+}
+
+// CheckFabToken returns an error if the FabToken capability is not enabled on the channel.
+func (c *ChannelCapabilities) CheckFabToken(ctx context.Context) error {
+	capabilities, err := c.Get(ctx)
+	if err != nil {
+		return err
+	}
+	if !capabilities.FabToken {
+		return errors.Errorf("FabToken capability not enabled for channel %s", c.ChannelId)
+	}
+	return nil
+}
+
+// CheckHashingSuite returns an error if the channel does not compute the
+// transaction IDs with the hashing suite, so that clients do not submit
+// transactions whose IDs the committing peers reject. An empty hashing suite,
+// also reported by the provers predating hashing suites, stands for SHA256.
+func (c *ChannelCapabilities) CheckHashingSuite(ctx context.Context, hashingSuite string) error {
+	capabilities, err := c.Get(ctx)
+	if err != nil {
+		return err
+	}
+	if hashingSuiteName(capabilities.HashingSuite) != hashingSuiteName(hashingSuite) {
+		return errors.Errorf("channel %s computes transaction IDs with %s, not %s", c.ChannelId, hashingSuiteName(capabilities.HashingSuite), hashingSuiteName(hashingSuite))
+	}
+	return nil
+}
+
+// TxExpiration returns true if the committing peers of the channel invalidate
+// the transactions that have expired. The provers predating the expiration of
+// transactions report it as disabled.
+func (c *ChannelCapabilities) TxExpiration(ctx context.Context) (bool, error) {
+	capabilities, err := c.Get(ctx)
+	if err != nil {
+		return false, err
+	}
+	return capabilities.TxExpiration, nil
+}
+
+// TokenEncryptionKeys returns the keys, by MSP ID, to which the token
+// transactions of the channel are encrypted, or an error if they cannot be
+// encrypted to all the application orgs of the channel.
+func (c *ChannelCapabilities) TokenEncryptionKeys(ctx context.Context) (map[string]*ecdsa.PublicKey, error) {
+	capabilities, err := c.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(capabilities.TokenEncryptionKeys) == 0 {
+		return nil, errors.Errorf("token transactions cannot be encrypted on channel %s", c.ChannelId)
+	}
+	keys := make(map[string]*ecdsa.PublicKey, len(capabilities.TokenEncryptionKeys))
+	for mspID, der := range capabilities.TokenEncryptionKeys {
+		keys[mspID], err = encryption.ParsePublicKey(der)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid token encryption key of org "+mspID)
+		}
+	}
+	return keys, nil
+}
+
+func hashingSuiteName(hashingSuite string) string {
+	if hashingSuite == "" {
+		return bccsp.SHA256
+	}
+	return hashingSuite
+}
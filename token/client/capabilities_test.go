@@ -0,0 +1,145 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client_test
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/hyperledger/fabric/token/client/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("ChannelCapabilities", func() {
+	var (
+		fakeProver          *mock.CapabilitiesProver
+		fakeSigningIdentity *mock.SigningIdentity
+		capabilities        *client.ChannelCapabilities
+	)
+
+	BeforeEach(func() {
+		fakeProver = &mock.CapabilitiesProver{}
+		fakeProver.GetChannelCapabilitiesContextReturns(&token.ChannelCapabilities{FabToken: true}, nil)
+		fakeSigningIdentity = &mock.SigningIdentity{}
+		capabilities = &client.ChannelCapabilities{
+			ChannelId:       "mychannel",
+			Prover:          fakeProver,
+			SigningIdentity: fakeSigningIdentity,
+		}
+	})
+
+	It("fetches the capabilities once", func() {
+		for i := 0; i < 2; i++ {
+			c, err := capabilities.Get(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c).To(Equal(&token.ChannelCapabilities{FabToken: true}))
+		}
+		Expect(fakeProver.GetChannelCapabilitiesContextCallCount()).To(Equal(1))
+		_, signingIdentity := fakeProver.GetChannelCapabilitiesContextArgsForCall(0)
+		Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+	})
+
+	It("fetches the capabilities again once invalidated", func() {
+		_, err := capabilities.Get(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		capabilities.Invalidate()
+		_, err = capabilities.Get(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeProver.GetChannelCapabilitiesContextCallCount()).To(Equal(2))
+	})
+
+	Context("when the prover fails", func() {
+		BeforeEach(func() {
+			fakeProver.GetChannelCapabilitiesContextReturnsOnCall(0, nil, errors.New("durian"))
+		})
+
+		It("returns the error without caching it", func() {
+			_, err := capabilities.Get(context.Background())
+			Expect(err).To(MatchError("failed fetching channel capabilities: durian"))
+
+			_, err = capabilities.Get(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("when the prover predates capability requests", func() {
+		BeforeEach(func() {
+			fakeProver.GetChannelCapabilitiesContextReturns(nil, &client.IncompatibleProverError{Command: "CapabilitiesRequest"})
+		})
+
+		It("assumes a channel with the FabToken capability only", func() {
+			c, err := capabilities.Get(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c).To(Equal(&token.ChannelCapabilities{FabToken: true}))
+			Expect(capabilities.CheckHashingSuite(context.Background(), "")).To(Succeed())
+			_, err = capabilities.TokenEncryptionKeys(context.Background())
+			Expect(err).To(MatchError("token transactions cannot be encrypted on channel mychannel"))
+		})
+	})
+
+	Describe("CheckFabToken", func() {
+		It("succeeds when FabToken is enabled", func() {
+			err := capabilities.CheckFabToken(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when FabToken is not enabled", func() {
+			BeforeEach(func() {
+				fakeProver.GetChannelCapabilitiesContextReturns(&token.ChannelCapabilities{}, nil)
+			})
+
+			It("returns an error", func() {
+				err := capabilities.CheckFabToken(context.Background())
+				Expect(err).To(MatchError("FabToken capability not enabled for channel mychannel"))
+			})
+		})
+	})
+
+	Describe("CheckHashingSuite", func() {
+		It("defaults to SHA256", func() {
+			Expect(capabilities.CheckHashingSuite(context.Background(), "")).To(Succeed())
+			Expect(capabilities.CheckHashingSuite(context.Background(), "SHA256")).To(Succeed())
+			err := capabilities.CheckHashingSuite(context.Background(), "SHA3_256")
+			Expect(err).To(MatchError("channel mychannel computes transaction IDs with SHA256, not SHA3_256"))
+		})
+
+		Context("when the channel has a hashing suite", func() {
+			BeforeEach(func() {
+				fakeProver.GetChannelCapabilitiesContextReturns(&token.ChannelCapabilities{FabToken: true, HashingSuite: "SHA3_256"}, nil)
+			})
+
+			It("checks the hashing suite", func() {
+				Expect(capabilities.CheckHashingSuite(context.Background(), "SHA3_256")).To(Succeed())
+				err := capabilities.CheckHashingSuite(context.Background(), "")
+				Expect(err).To(MatchError("channel mychannel computes transaction IDs with SHA3_256, not SHA256"))
+			})
+		})
+	})
+
+	Describe("TxExpiration", func() {
+		It("is disabled by default", func() {
+			txExpiration, err := capabilities.TxExpiration(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(txExpiration).To(BeFalse())
+		})
+
+		Context("when the channel invalidates expired transactions", func() {
+			BeforeEach(func() {
+				fakeProver.GetChannelCapabilitiesContextReturns(&token.ChannelCapabilities{FabToken: true, TxExpiration: true}, nil)
+			})
+
+			It("is enabled", func() {
+				txExpiration, err := capabilities.TxExpiration(context.Background())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(txExpiration).To(BeTrue())
+			})
+		})
+	})
+})
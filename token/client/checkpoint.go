@@ -0,0 +1,103 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"strconv"
+	"sync"
+
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/token/client/wallet"
+	"github.com/pkg/errors"
+)
+
+const checkpointPrefix = "checkpoints/"
+
+//go:generate counterfeiter -o mock/checkpointer.go -fake-name Checkpointer . Checkpointer
+
+// A Checkpointer records the last block processed on each channel, so that a
+// client process restarting mid-stream resumes where it left off.
+// Each consumer of the block stream should use its own Checkpointer.
+type Checkpointer interface {
+	// LastBlock returns the number of the last block processed on channel;
+	// ok is false when no block has been processed yet.
+	LastBlock(channel string) (number uint64, ok bool, err error)
+
+	// Checkpoint records that the blocks up to number have been processed on channel.
+	Checkpoint(channel string, number uint64) error
+}
+
+// StoreCheckpointer keeps checkpoints in a wallet.Store. Checkpoints never move
+// backwards, so concurrent consumers cannot undo each other's progress.
+type StoreCheckpointer struct {
+	Store wallet.Store
+
+	mutex sync.Mutex
+}
+
+// NewFileCheckpointer returns a StoreCheckpointer keeping its checkpoints in files in dir.
+func NewFileCheckpointer(dir string) *StoreCheckpointer {
+	return &StoreCheckpointer{Store: &wallet.FileStore{Dir: dir}}
+}
+
+// LastBlock returns the number of the last block processed on channel.
+func (s *StoreCheckpointer) LastBlock(channel string) (uint64, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.lastBlock(channel)
+}
+
+// Checkpoint records that the blocks up to number have been processed on channel.
+func (s *StoreCheckpointer) Checkpoint(channel string, number uint64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	last, ok, err := s.lastBlock(channel)
+	if err != nil {
+		return err
+	}
+	if ok && last >= number {
+		return nil
+	}
+	return s.Store.Put(checkpointPrefix+channel, []byte(strconv.FormatUint(number, 10)))
+}
+
+func (s *StoreCheckpointer) lastBlock(channel string) (uint64, bool, error) {
+	raw, err := s.Store.Get(checkpointPrefix + channel)
+	if err != nil {
+		return 0, false, err
+	}
+	if raw == nil {
+		return 0, false, nil
+	}
+	number, err := strconv.ParseUint(string(raw), 10, 64)
+	if err != nil {
+		return 0, false, errors.Wrapf(err, "invalid checkpoint for channel %s", channel)
+	}
+	return number, true, nil
+}
+
+// resumePosition returns the position following the checkpoint of channel, or
+// fallback when there is no checkpoint.
+func resumePosition(checkpointer Checkpointer, channel string, fallback *ab.SeekPosition) (*ab.SeekPosition, error) {
+	if checkpointer == nil {
+		return fallback, nil
+	}
+	last, ok, err := checkpointer.LastBlock(channel)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed reading checkpoint")
+	}
+	if !ok {
+		return fallback, nil
+	}
+	return &ab.SeekPosition{
+		Type: &ab.SeekPosition_Specified{
+			Specified: &ab.SeekSpecified{Number: last + 1},
+		},
+	}, nil
+}
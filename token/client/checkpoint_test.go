@@ -0,0 +1,88 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client_test
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/hyperledger/fabric/token/client/wallet"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StoreCheckpointer", func() {
+	var (
+		dir          string
+		checkpointer *client.StoreCheckpointer
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "checkpoints")
+		Expect(err).NotTo(HaveOccurred())
+		checkpointer = client.NewFileCheckpointer(dir)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("returns no checkpoint for a new channel", func() {
+		_, ok, err := checkpointer.LastBlock("test-channel")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("records the last block processed", func() {
+		err := checkpointer.Checkpoint("test-channel", 7)
+		Expect(err).NotTo(HaveOccurred())
+
+		number, ok, err := checkpointer.LastBlock("test-channel")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(number).To(Equal(uint64(7)))
+
+		_, ok, err = checkpointer.LastBlock("another-channel")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("survives a restart", func() {
+		err := checkpointer.Checkpoint("test-channel", 3)
+		Expect(err).NotTo(HaveOccurred())
+
+		number, ok, err := client.NewFileCheckpointer(dir).LastBlock("test-channel")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(number).To(Equal(uint64(3)))
+	})
+
+	It("never moves backwards", func() {
+		err := checkpointer.Checkpoint("test-channel", 7)
+		Expect(err).NotTo(HaveOccurred())
+		err = checkpointer.Checkpoint("test-channel", 5)
+		Expect(err).NotTo(HaveOccurred())
+
+		number, _, err := checkpointer.LastBlock("test-channel")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(number).To(Equal(uint64(7)))
+	})
+
+	Context("when the stored checkpoint is invalid", func() {
+		BeforeEach(func() {
+			err := (&wallet.FileStore{Dir: dir}).Put("checkpoints/test-channel", []byte("garbage"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns an error", func() {
+			_, _, err := checkpointer.LastBlock("test-channel")
+			Expect(err).To(MatchError(ContainSubstring("invalid checkpoint for channel test-channel")))
+		})
+	})
+})
@@ -7,10 +7,15 @@ SPDX-License-Identifier: Apache-2.0
 package client
 
 import (
+	"context"
+	"crypto/rand"
+
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/token"
 	tk "github.com/hyperledger/fabric/token"
+	"github.com/hyperledger/fabric/token/encryption"
+	"github.com/pkg/errors"
 )
 
 //go:generate counterfeiter -o mock/prover.go -fake-name Prover . Prover
@@ -29,6 +34,15 @@ type Prover interface {
 	// among recipients; it returns a response in bytes and an error message in the case the
 	// request fails
 	RequestTransfer(tokenIDs [][]byte, shares []*token.RecipientTransferShare, signingIdentity tk.SigningIdentity) ([]byte, error)
+
+	// RequestRedeem allows the client to submit a redeem request to a prover peer service;
+	// the function takes as parameters the identifiers of the tokens to be redeemed and the
+	// quantity to redeem; it returns a serialized TokenTransaction and an error message in
+	// the case the request fails
+	RequestRedeem(tokenIDs [][]byte, quantity uint64, signingIdentity tk.SigningIdentity) ([]byte, error)
+
+	// ListTokens returns the unspent tokens owned by the signing identity
+	ListTokens(signingIdentity tk.SigningIdentity) ([]*token.TokenOutput, error)
 }
 
 //go:generate counterfeiter -o mock/fabric_tx_submitter.go -fake-name FabricTxSubmitter . FabricTxSubmitter
@@ -47,6 +61,32 @@ type Client struct {
 	SigningIdentity tk.SigningIdentity
 	Prover          Prover
 	TxSubmitter     FabricTxSubmitter
+	// FeePolicy, when set, is used to estimate transaction fees.
+	FeePolicy FeePolicy
+	// Capabilities, when set, is checked before requesting a transaction,
+	// so that operations on channels without FabToken fail fast.
+	Capabilities *ChannelCapabilities
+	// HashingSuite is the hashing suite with which TxSubmitter computes
+	// transaction IDs; when Capabilities is set, it is checked against the
+	// hashing suite of the channel.
+	HashingSuite string
+	// EncryptTransactions encrypts the token transactions to the committing
+	// peers of the application orgs of the channel, with the keys reported by
+	// Capabilities, so that the orderers cannot read them.
+	EncryptTransactions bool
+	// Interceptors intercept the invocations of Issue, Transfer, Redeem and
+	// ListTokens, and of their variants, the first one being the outermost.
+	Interceptors []Interceptor
+	// VerifyTransactions checks that the token transactions assembled by the
+	// prover carry out the requests before submitting them, so that a faulty
+	// or malicious prover cannot redirect tokens or change their quantities.
+	VerifyTransactions bool
+}
+
+// Close closes the prover and the transaction submitter of the client, when
+// they hold resources such as connections, e.g. a ProverPeer or a TxSubmitter.
+func (c *Client) Close() error {
+	return closeAll(c.Prover, c.TxSubmitter)
 }
 
 // Issue is the function that the client calls to introduce tokens into the system.
@@ -54,24 +94,136 @@ type Client struct {
 // are going to be introduced.
 
 func (c *Client) Issue(tokensToIssue []*token.TokenToIssue) ([]byte, error) {
-	serializedTokenTx, err := c.Prover.RequestImport(tokensToIssue, c.SigningIdentity)
+	return c.IssueWithReference(tokensToIssue, nil)
+}
+
+// IssueWithReference is like Issue for a transaction carrying the passed
+// application reference, such as an invoice ID or an order hash.
+func (c *Client) IssueWithReference(tokensToIssue []*token.TokenToIssue, reference []byte) ([]byte, error) {
+	return c.IssueSeries(tokensToIssue, nil, reference)
+}
+
+// IssueSeries is like IssueWithReference for an import of the series with
+// the passed ID. The committer invalidates any later import of the same
+// series by the same issuer, so that an issuer can safely retry an import
+// that timed out by resubmitting it with the same series ID.
+func (c *Client) IssueSeries(tokensToIssue []*token.TokenToIssue, seriesID []byte, reference []byte) ([]byte, error) {
+	return c.invokeTx(&Request{
+		Method:        MethodIssue,
+		TokensToIssue: tokensToIssue,
+		SeriesID:      seriesID,
+		Reference:     reference,
+	})
+}
+
+func (c *Client) issue(ctx context.Context, request *Request) (*Response, error) {
+	err := c.checkCapabilities()
 	if err != nil {
 		return nil, err
 	}
-
-	tx, err := c.createTx(serializedTokenTx)
+	serializedTokenTx, err := c.Prover.RequestImport(request.TokensToIssue, c.SigningIdentity)
 	if err != nil {
 		return nil, err
 	}
-
-	return tx, c.TxSubmitter.Submit(tx)
+	err = c.verify(request, serializedTokenTx)
+	if err != nil {
+		return nil, err
+	}
+	serializedTokenTx, err = setSeriesID(serializedTokenTx, request.SeriesID)
+	if err != nil {
+		return nil, err
+	}
+	serializedTokenTx, err = setApplicationReference(serializedTokenTx, request.Reference)
+	if err != nil {
+		return nil, err
+	}
+	return c.submit(serializedTokenTx)
 }
 
 // Transfer is the function that the client calls to transfer his tokens.
 // Transfer takes as parameter an array of token.RecipientTransferShare that
 // identifies who receives the tokens and describes how the tokens are distributed.
 func (c *Client) Transfer(tokenIDs [][]byte, shares []*token.RecipientTransferShare) ([]byte, error) {
-	serializedTokenTx, err := c.Prover.RequestTransfer(tokenIDs, shares, c.SigningIdentity)
+	return c.TransferWithReference(tokenIDs, shares, nil)
+}
+
+// TransferWithReference is like Transfer for a transaction carrying the passed
+// application reference, such as an invoice ID or an order hash.
+func (c *Client) TransferWithReference(tokenIDs [][]byte, shares []*token.RecipientTransferShare, reference []byte) ([]byte, error) {
+	return c.invokeTx(&Request{
+		Method:    MethodTransfer,
+		TokenIDs:  tokenIDs,
+		Shares:    shares,
+		Reference: reference,
+	})
+}
+
+func (c *Client) transfer(ctx context.Context, request *Request) (*Response, error) {
+	err := c.checkCapabilities()
+	if err != nil {
+		return nil, err
+	}
+	serializedTokenTx, err := c.Prover.RequestTransfer(request.TokenIDs, request.Shares, c.SigningIdentity)
+	if err != nil {
+		return nil, err
+	}
+	err = c.verify(request, serializedTokenTx)
+	if err != nil {
+		return nil, err
+	}
+	serializedTokenTx, err = setApplicationReference(serializedTokenTx, request.Reference)
+	if err != nil {
+		return nil, err
+	}
+	return c.submit(serializedTokenTx)
+}
+
+// Redeem is the function that the client calls to redeem quantity of the tokens
+// with the passed IDs; the remainder, if any, is returned to the client.
+func (c *Client) Redeem(tokenIDs [][]byte, quantity uint64) ([]byte, error) {
+	return c.invokeTx(&Request{
+		Method:   MethodRedeem,
+		TokenIDs: tokenIDs,
+		Quantity: quantity,
+	})
+}
+
+func (c *Client) redeem(ctx context.Context, request *Request) (*Response, error) {
+	err := c.checkCapabilities()
+	if err != nil {
+		return nil, err
+	}
+	serializedTokenTx, err := c.Prover.RequestRedeem(request.TokenIDs, request.Quantity, c.SigningIdentity)
+	if err != nil {
+		return nil, err
+	}
+	err = c.verify(request, serializedTokenTx)
+	if err != nil {
+		return nil, err
+	}
+	return c.submit(serializedTokenTx)
+}
+
+// ListTokens returns the unspent tokens owned by the client.
+func (c *Client) ListTokens() ([]*token.TokenOutput, error) {
+	response, err := c.invoke(&Request{Method: MethodListTokens})
+	if response == nil {
+		return nil, err
+	}
+	return response.Tokens, err
+}
+
+func (c *Client) listTokens(ctx context.Context, request *Request) (*Response, error) {
+	tokens, err := c.Prover.ListTokens(c.SigningIdentity)
+	if err != nil {
+		return nil, err
+	}
+	return &Response{Tokens: tokens}, nil
+}
+
+// submit encrypts, wraps and submits the serialized token transaction.
+func (c *Client) submit(serializedTokenTx []byte) (*Response, error) {
+	serializedTokenTx, err := c.encrypt(serializedTokenTx)
 	if err != nil {
 		return nil, err
 	}
@@ -79,8 +231,107 @@ func (c *Client) Transfer(tokenIDs [][]byte, shares []*token.RecipientTransferSh
 	if err != nil {
 		return nil, err
 	}
+	return &Response{Transaction: tx}, c.TxSubmitter.Submit(tx)
+}
+
+// invokeTx invokes the method of the request and returns the envelope of the
+// submitted transaction.
+func (c *Client) invokeTx(request *Request) ([]byte, error) {
+	response, err := c.invoke(request)
+	if response == nil {
+		return nil, err
+	}
+	return response.Transaction, err
+}
+
+// invoke invokes the method of the request through the interceptors.
+func (c *Client) invoke(request *Request) (*Response, error) {
+	return chain(c.Interceptors, c.invokeMethod)(context.Background(), request)
+}
+
+func (c *Client) invokeMethod(ctx context.Context, request *Request) (*Response, error) {
+	switch request.Method {
+	case MethodIssue:
+		return c.issue(ctx, request)
+	case MethodTransfer:
+		return c.transfer(ctx, request)
+	case MethodRedeem:
+		return c.redeem(ctx, request)
+	case MethodListTokens:
+		return c.listTokens(ctx, request)
+	default:
+		return nil, errors.Errorf("unknown method %s", request.Method)
+	}
+}
 
-	return tx, c.TxSubmitter.Submit(tx)
+// checkCapabilities verifies that the channel supports token transactions
+// with the transaction IDs of the client.
+func (c *Client) checkCapabilities() error {
+	if c.Capabilities == nil {
+		return nil
+	}
+	err := c.Capabilities.CheckFabToken(context.Background())
+	if err != nil {
+		return err
+	}
+	return c.Capabilities.CheckHashingSuite(context.Background(), c.HashingSuite)
+}
+
+// encrypt encrypts the serialized token transaction when EncryptTransactions is set.
+func (c *Client) encrypt(tokenTx []byte) ([]byte, error) {
+	if !c.EncryptTransactions {
+		return tokenTx, nil
+	}
+	if c.Capabilities == nil {
+		return nil, errors.New("the channel capabilities are required to encrypt token transactions")
+	}
+	keys, err := c.Capabilities.TokenEncryptionKeys(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	ttx := &token.TokenTransaction{}
+	err = proto.Unmarshal(tokenTx, ttx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling token transaction")
+	}
+	action, err := encryption.Encrypt(ttx, keys, rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(&token.TokenTransaction{Action: &token.TokenTransaction_EncryptedAction{EncryptedAction: action}})
+}
+
+// setApplicationReference sets the application reference of the serialized token transaction.
+// The reference is covered by the signature of the envelope created by createTx.
+func setApplicationReference(tokenTx []byte, reference []byte) ([]byte, error) {
+	if len(reference) == 0 {
+		return tokenTx, nil
+	}
+	ttx := &token.TokenTransaction{}
+	err := proto.Unmarshal(tokenTx, ttx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling token transaction")
+	}
+	ttx.ApplicationReference = reference
+	return proto.Marshal(ttx)
+}
+
+// setSeriesID sets the series ID of the import in the serialized token transaction.
+func setSeriesID(tokenTx []byte, seriesID []byte) ([]byte, error) {
+	if len(seriesID) == 0 {
+		return tokenTx, nil
+	}
+	ttx := &token.TokenTransaction{}
+	err := proto.Unmarshal(tokenTx, ttx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling token transaction")
+	}
+	importAction := ttx.GetPlainAction().GetPlainImport()
+	if importAction == nil {
+		return nil, errors.New("token transaction is not an import")
+	}
+	importAction.SeriesId = seriesID
+	return proto.Marshal(ttx)
 }
 
 // TODO to be updated later to have a proper fabric header
@@ -6,6 +6,8 @@ SPDX-License-Identifier: Apache-2.0
 package client_test
 
 import (
+	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
@@ -24,3 +26,23 @@ func ProtoMarshal(m proto.Message) []byte {
 
 	return bytes
 }
+
+// the specs must not leave goroutines of the client running
+var _ = AfterEach(func() {
+	Eventually(leakedGoroutines).Should(BeEmpty())
+})
+
+// leakedGoroutines returns the stacks of the goroutines, other than the
+// calling one, that run code of the client package.
+func leakedGoroutines() []string {
+	buf := make([]byte, 1<<20)
+	buf = buf[:runtime.Stack(buf, true)]
+	var leaked []string
+	// the first stack is the one of the calling goroutine
+	for _, stack := range strings.Split(string(buf), "\n\n")[1:] {
+		if strings.Contains(stack, "github.com/hyperledger/fabric/token/client.") {
+			leaked = append(leaked, stack)
+		}
+	}
+	return leaked
+}
@@ -7,10 +7,17 @@ SPDX-License-Identifier: Apache-2.0
 package client_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/token"
 	"github.com/hyperledger/fabric/token/client"
 	"github.com/hyperledger/fabric/token/client/mock"
+	"github.com/hyperledger/fabric/token/encryption"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
@@ -134,6 +141,253 @@ var _ = Describe("TokenClient", func() {
 		})
 	})
 
+	Context("when the channel does not support FabToken", func() {
+		BeforeEach(func() {
+			fakeCapabilitiesProver := &mock.CapabilitiesProver{}
+			fakeCapabilitiesProver.GetChannelCapabilitiesContextReturns(&token.ChannelCapabilities{FabToken: false}, nil)
+			tokenClient.Capabilities = &client.ChannelCapabilities{ChannelId: "mychannel", Prover: fakeCapabilitiesProver}
+		})
+
+		It("fails before requesting the transaction", func() {
+			_, err := tokenClient.Issue(nil)
+			Expect(err).To(MatchError("FabToken capability not enabled for channel mychannel"))
+			_, err = tokenClient.Transfer(nil, nil)
+			Expect(err).To(MatchError("FabToken capability not enabled for channel mychannel"))
+
+			Expect(fakeProver.RequestImportCallCount()).To(Equal(0))
+			Expect(fakeProver.RequestTransferCallCount()).To(Equal(0))
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("Redeem", func() {
+		BeforeEach(func() {
+			fakeProver.RequestRedeemReturns([]byte("tx-payload"), nil)
+		})
+
+		It("returns tx envelope without error", func() {
+			serializedTx, err := tokenClient.Redeem([][]byte{[]byte("id1")}, 50)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serializedTx).To(Equal(envelopeBytes))
+
+			Expect(fakeProver.RequestRedeemCallCount()).To(Equal(1))
+			tokenIDs, quantity, signingIdentity := fakeProver.RequestRedeemArgsForCall(0)
+			Expect(tokenIDs).To(Equal([][]byte{[]byte("id1")}))
+			Expect(quantity).To(Equal(uint64(50)))
+			Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(1))
+			Expect(fakeTxSubmitter.SubmitArgsForCall(0)).To(Equal(envelopeBytes))
+		})
+
+		Context("when prover.RequestRedeem fails", func() {
+			BeforeEach(func() {
+				fakeProver.RequestRedeemReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.Redeem([][]byte{[]byte("id1")}, 50)
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("ListTokens", func() {
+		It("returns the tokens of the signing identity", func() {
+			fakeProver.ListTokensReturns([]*token.TokenOutput{{Id: []byte("id1"), Type: "ABC", Quantity: 10}}, nil)
+
+			tokens, err := tokenClient.ListTokens()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokens).To(Equal([]*token.TokenOutput{{Id: []byte("id1"), Type: "ABC", Quantity: 10}}))
+			Expect(fakeProver.ListTokensArgsForCall(0)).To(Equal(fakeSigningIdentity))
+		})
+
+		Context("when prover.ListTokens fails", func() {
+			BeforeEach(func() {
+				fakeProver.ListTokensReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.ListTokens()
+				Expect(err).To(MatchError("wild-banana"))
+			})
+		})
+	})
+
+	Describe("IssueWithReference", func() {
+		var tokenTx *token.TokenTransaction
+
+		BeforeEach(func() {
+			tokenTx = &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainImport{PlainImport: &token.PlainImport{}},
+					},
+				},
+			}
+			fakeProver.RequestImportReturns(ProtoMarshal(tokenTx), nil)
+		})
+
+		It("sets the application reference of the transaction", func() {
+			_, err := tokenClient.IssueWithReference(nil, []byte("invoice-42"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeSigningIdentity.SignCallCount()).To(Equal(1))
+			signed := &common.Payload{}
+			err = proto.Unmarshal(fakeSigningIdentity.SignArgsForCall(0), signed)
+			Expect(err).NotTo(HaveOccurred())
+			tokenTx.ApplicationReference = []byte("invoice-42")
+			Expect(signed.Data).To(Equal(ProtoMarshal(tokenTx)))
+		})
+
+		Context("when the prover response is not a token transaction", func() {
+			BeforeEach(func() {
+				fakeProver.RequestImportReturns([]byte("garbage"), nil)
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.IssueWithReference(nil, []byte("invoice-42"))
+				Expect(err).To(MatchError(ContainSubstring("failed unmarshaling token transaction")))
+				Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("IssueSeries", func() {
+		var tokenTx *token.TokenTransaction
+
+		BeforeEach(func() {
+			tokenTx = &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainImport{PlainImport: &token.PlainImport{}},
+					},
+				},
+			}
+			fakeProver.RequestImportReturns(ProtoMarshal(tokenTx), nil)
+		})
+
+		It("sets the series ID of the import", func() {
+			_, err := tokenClient.IssueSeries(nil, []byte("batch-7"), []byte("invoice-42"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeSigningIdentity.SignCallCount()).To(Equal(1))
+			signed := &common.Payload{}
+			err = proto.Unmarshal(fakeSigningIdentity.SignArgsForCall(0), signed)
+			Expect(err).NotTo(HaveOccurred())
+			tokenTx.GetPlainAction().GetPlainImport().SeriesId = []byte("batch-7")
+			tokenTx.ApplicationReference = []byte("invoice-42")
+			Expect(signed.Data).To(Equal(ProtoMarshal(tokenTx)))
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(1))
+		})
+
+		Context("when the prover response is not an import", func() {
+			BeforeEach(func() {
+				tokenTx.GetPlainAction().Data = &token.PlainTokenAction_PlainTransfer{PlainTransfer: &token.PlainTransfer{}}
+				fakeProver.RequestImportReturns(ProtoMarshal(tokenTx), nil)
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.IssueSeries(nil, []byte("batch-7"), nil)
+				Expect(err).To(MatchError("token transaction is not an import"))
+				Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("EncryptTransactions", func() {
+		var (
+			tokenTx                *token.TokenTransaction
+			key                    *ecdsa.PrivateKey
+			fakeCapabilitiesProver *mock.CapabilitiesProver
+		)
+
+		BeforeEach(func() {
+			tokenTx = &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainTransfer{PlainTransfer: &token.PlainTransfer{}},
+					},
+				},
+			}
+			fakeProver.RequestTransferReturns(ProtoMarshal(tokenTx), nil)
+
+			var err error
+			key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+			der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+			Expect(err).NotTo(HaveOccurred())
+			fakeCapabilitiesProver = &mock.CapabilitiesProver{}
+			fakeCapabilitiesProver.GetChannelCapabilitiesContextReturns(&token.ChannelCapabilities{
+				FabToken:            true,
+				TokenEncryptionKeys: map[string][]byte{"Org1MSP": der},
+			}, nil)
+			tokenClient.Capabilities = &client.ChannelCapabilities{ChannelId: "mychannel", Prover: fakeCapabilitiesProver}
+			tokenClient.EncryptTransactions = true
+		})
+
+		It("submits the transaction encrypted to the orgs of the channel", func() {
+			_, err := tokenClient.TransferWithReference(nil, nil, []byte("invoice-42"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeSigningIdentity.SignCallCount()).To(Equal(1))
+			signed := &common.Payload{}
+			err = proto.Unmarshal(fakeSigningIdentity.SignArgsForCall(0), signed)
+			Expect(err).NotTo(HaveOccurred())
+			encrypted := &token.TokenTransaction{}
+			err = proto.Unmarshal(signed.Data, encrypted)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(encrypted.ApplicationReference).To(BeNil())
+			Expect(encrypted.GetEncryptedAction()).NotTo(BeNil())
+
+			decrypted, err := encryption.Decrypt(encrypted.GetEncryptedAction(), "Org1MSP", key, map[string]*ecdsa.PublicKey{"Org1MSP": &key.PublicKey})
+			Expect(err).NotTo(HaveOccurred())
+			tokenTx.ApplicationReference = []byte("invoice-42")
+			Expect(proto.Equal(decrypted, tokenTx)).To(BeTrue())
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(1))
+		})
+
+		Context("when the channel has no token encryption keys", func() {
+			BeforeEach(func() {
+				fakeCapabilitiesProver.GetChannelCapabilitiesContextReturns(&token.ChannelCapabilities{FabToken: true}, nil)
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.Transfer(nil, nil)
+				Expect(err).To(MatchError("token transactions cannot be encrypted on channel mychannel"))
+				Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when a token encryption key is invalid", func() {
+			BeforeEach(func() {
+				fakeCapabilitiesProver.GetChannelCapabilitiesContextReturns(&token.ChannelCapabilities{
+					FabToken:            true,
+					TokenEncryptionKeys: map[string][]byte{"Org1MSP": []byte("garbage")},
+				}, nil)
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.Transfer(nil, nil)
+				Expect(err).To(MatchError(ContainSubstring("invalid token encryption key of org Org1MSP")))
+				Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the client has no channel capabilities", func() {
+			BeforeEach(func() {
+				tokenClient.Capabilities = nil
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.Issue(nil)
+				Expect(err).To(MatchError("the channel capabilities are required to encrypt token transactions"))
+				Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+			})
+		})
+	})
+
 	Describe("Transfer", func() {
 		var (
 			tokenIDs       [][]byte
@@ -214,4 +468,54 @@ var _ = Describe("TokenClient", func() {
 			})
 		})
 	})
+
+	Describe("Close", func() {
+		It("closes the prover and the submitter that hold resources", func() {
+			prover := &closingProver{Prover: fakeProver}
+			txSubmitter := &closingTxSubmitter{FabricTxSubmitter: fakeTxSubmitter}
+			tokenClient.Prover = prover
+			tokenClient.TxSubmitter = txSubmitter
+
+			err := tokenClient.Close()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(prover.closed).To(BeTrue())
+			Expect(txSubmitter.closed).To(BeTrue())
+		})
+
+		It("ignores the ones that do not", func() {
+			Expect(tokenClient.Close()).To(Succeed())
+		})
+
+		Context("when closing fails", func() {
+			It("returns the errors", func() {
+				tokenClient.Prover = &closingProver{Prover: fakeProver, err: errors.New("wild-banana")}
+				tokenClient.TxSubmitter = &closingTxSubmitter{FabricTxSubmitter: fakeTxSubmitter, err: errors.New("wild-pineapple")}
+
+				err := tokenClient.Close()
+				Expect(err).To(MatchError("failed closing token client: wild-banana; wild-pineapple"))
+			})
+		})
+	})
 })
+
+type closingProver struct {
+	client.Prover
+	closed bool
+	err    error
+}
+
+func (c *closingProver) Close() error {
+	c.closed = true
+	return c.err
+}
+
+type closingTxSubmitter struct {
+	client.FabricTxSubmitter
+	closed bool
+	err    error
+}
+
+func (c *closingTxSubmitter) Close() error {
+	c.closed = true
+	return c.err
+}
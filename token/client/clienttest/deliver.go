@@ -0,0 +1,136 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clienttest
+
+import (
+	"context"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// deliverer delivers the filtered blocks of the network.
+type deliverer struct {
+	network *Network
+}
+
+func (d *deliverer) Deliver(stream pb.Deliver_DeliverServer) error {
+	return status.Error(codes.Unimplemented, "the peer of the network only delivers filtered blocks")
+}
+
+func (d *deliverer) DeliverWithPrivateData(stream pb.Deliver_DeliverWithPrivateDataServer) error {
+	return status.Error(codes.Unimplemented, "the peer of the network only delivers filtered blocks")
+}
+
+func (d *deliverer) DeliverFiltered(stream pb.Deliver_DeliverFilteredServer) error {
+	for {
+		envelope, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		send := func(block *pb.FilteredBlock) error {
+			return stream.Send(&pb.DeliverResponse{Type: &pb.DeliverResponse_FilteredBlock{FilteredBlock: block}})
+		}
+		deliverStatus, err := d.network.deliver(stream.Context(), envelope, send)
+		if err != nil {
+			return err
+		}
+		err = stream.Send(&pb.DeliverResponse{Type: &pb.DeliverResponse_Status{Status: deliverStatus}})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// deliver sends the filtered blocks requested by the seek envelope and
+// returns the status of the request.
+func (n *Network) deliver(ctx context.Context, envelope *common.Envelope, send func(*pb.FilteredBlock) error) (common.Status, error) {
+	payload, err := utils.UnmarshalPayload(envelope.Payload)
+	if err != nil || payload.Header == nil {
+		return common.Status_BAD_REQUEST, nil
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return common.Status_BAD_REQUEST, nil
+	}
+	if chdr.ChannelId != n.ChannelID {
+		return common.Status_NOT_FOUND, nil
+	}
+	seekInfo := &ab.SeekInfo{}
+	err = proto.Unmarshal(payload.Data, seekInfo)
+	if err != nil || seekInfo.Start == nil || seekInfo.Stop == nil {
+		return common.Status_BAD_REQUEST, nil
+	}
+
+	height := n.Height()
+	start := seekNumber(seekInfo.Start, height)
+	stop := seekNumber(seekInfo.Stop, height)
+	if stop < start {
+		return common.Status_BAD_REQUEST, nil
+	}
+	for number := start; ; number++ {
+		block, err := n.block(ctx, number, seekInfo.Behavior)
+		if err != nil {
+			return common.Status_UNKNOWN, err
+		}
+		if block == nil {
+			return common.Status_NOT_FOUND, nil
+		}
+		err = send(block)
+		if err != nil {
+			return common.Status_UNKNOWN, err
+		}
+		if number == stop {
+			return common.Status_SUCCESS, nil
+		}
+	}
+}
+
+// block returns the block with the passed number. Unless the behavior is to
+// fail if the block is not ready, it waits for the block until ctx is done.
+func (n *Network) block(ctx context.Context, number uint64, behavior ab.SeekInfo_SeekBehavior) (*pb.FilteredBlock, error) {
+	for {
+		n.mutex.Lock()
+		if number < uint64(len(n.blocks)) {
+			block := n.blocks[number]
+			n.mutex.Unlock()
+			return block, nil
+		}
+		newBlock := n.newBlock
+		n.mutex.Unlock()
+
+		if behavior == ab.SeekInfo_FAIL_IF_NOT_READY {
+			return nil, nil
+		}
+		select {
+		case <-newBlock:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func seekNumber(position *ab.SeekPosition, height uint64) uint64 {
+	switch t := position.Type.(type) {
+	case *ab.SeekPosition_Oldest:
+		return 0
+	case *ab.SeekPosition_Specified:
+		return t.Specified.Number
+	default:
+		return height - 1
+	}
+}
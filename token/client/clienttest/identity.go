@@ -0,0 +1,50 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clienttest
+
+import (
+	"crypto/sha256"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/msp"
+	tk "github.com/hyperledger/fabric/token"
+)
+
+// Identity is an identity of the in-process network. It serializes to a
+// SerializedIdentity, whose identity bytes are its name, and signs with
+// digests that the network does not verify.
+type Identity struct {
+	MSPID string
+	Name  string
+}
+
+// NewIdentity returns the identity of name in the MSP with the passed ID.
+func NewIdentity(mspID, name string) *Identity {
+	return &Identity{MSPID: mspID, Name: name}
+}
+
+func (i *Identity) Serialize() ([]byte, error) {
+	return proto.Marshal(&msp.SerializedIdentity{Mspid: i.MSPID, IdBytes: []byte(i.Name)})
+}
+
+// MustSerialize is like Serialize, e.g. to use the identity as the recipient of tokens.
+func (i *Identity) MustSerialize() []byte {
+	serialized, err := i.Serialize()
+	if err != nil {
+		panic(err)
+	}
+	return serialized
+}
+
+func (i *Identity) Sign(msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(append([]byte(i.MSPID+i.Name), msg...))
+	return digest[:], nil
+}
+
+func (i *Identity) GetPublicVersion() tk.Identity {
+	return i
+}
@@ -0,0 +1,164 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clienttest
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/token/identity"
+	"github.com/hyperledger/fabric/token/ledger"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	"github.com/hyperledger/fabric/token/transaction"
+)
+
+// WorldState is the in-memory world state of a channel, by namespace. It
+// implements the ledger.LedgerManager of a prover.
+type WorldState struct {
+	mutex   sync.RWMutex
+	entries map[string]map[string][]byte
+}
+
+// NewWorldState returns an empty world state.
+func NewWorldState() *WorldState {
+	return &WorldState{entries: map[string]map[string][]byte{}}
+}
+
+func (s *WorldState) GetLedgerReader(channel string) (ledger.LedgerReader, error) {
+	return &stateReader{state: s}, nil
+}
+
+func (s *WorldState) get(namespace, key string) []byte {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.entries[namespace][key]
+}
+
+// scan returns the entries of namespace between startKey, included, and
+// endKey, excluded, in key order. Empty keys leave the range open.
+func (s *WorldState) scan(namespace, startKey, endKey string) []*queryresult.KV {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	var kvs []*queryresult.KV
+	for key, value := range s.entries[namespace] {
+		if key < startKey || (endKey != "" && key >= endKey) {
+			continue
+		}
+		kvs = append(kvs, &queryresult.KV{Namespace: namespace, Key: key, Value: value})
+	}
+	sort.Slice(kvs, func(i, j int) bool { return kvs[i].Key < kvs[j].Key })
+	return kvs
+}
+
+// Commit validates the token transaction of the envelope against the world
+// state with the plain verifier of the channel and, if it is valid, applies
+// its writes. Anyone may issue tokens when issuingValidator is nil.
+func (s *WorldState) Commit(channelID string, issuingValidator identity.IssuingValidator, chdr *common.ChannelHeader, envelope *common.Envelope) pb.TxValidationCode {
+	_, ttx, creator, err := transaction.UnmarshalTokenTransaction(envelope.Payload)
+	if err != nil {
+		logger.Debugf("transaction %s has an invalid payload: %s", chdr.TxId, err)
+		return pb.TxValidationCode_BAD_PAYLOAD
+	}
+	var timestamp time.Time
+	if chdr.Timestamp != nil {
+		timestamp, err = ptypes.Timestamp(chdr.Timestamp)
+		if err != nil {
+			logger.Debugf("transaction %s has an invalid timestamp: %s", chdr.TxId, err)
+			return pb.TxValidationCode_INVALID_OTHER_REASON
+		}
+	}
+
+	if issuingValidator == nil {
+		issuingValidator = allowAllIssuers{}
+	}
+	verifier := &plain.Verifier{IssuingValidator: issuingValidator, Channel: channelID}
+	simulator := newSimulator(s)
+	// the verifier has no supply limits, so it does not need the block number
+	err = verifier.ProcessTxAt(chdr.TxId, creator, ttx, 0, timestamp, simulator)
+	if err != nil {
+		logger.Debugf("transaction %s is invalid: %s", chdr.TxId, err)
+		return pb.TxValidationCode_INVALID_OTHER_REASON
+	}
+	s.apply(simulator.writes)
+	return pb.TxValidationCode_VALID
+}
+
+func (s *WorldState) apply(writes map[string]map[string][]byte) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for namespace, kvs := range writes {
+		if s.entries[namespace] == nil {
+			s.entries[namespace] = map[string][]byte{}
+		}
+		for key, value := range kvs {
+			s.entries[namespace][key] = value
+		}
+	}
+}
+
+// stateReader reads the committed world state.
+type stateReader struct {
+	state *WorldState
+}
+
+func (r *stateReader) GetState(namespace string, key string) ([]byte, error) {
+	return r.state.get(namespace, key), nil
+}
+
+func (r *stateReader) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (commonledger.ResultsIterator, error) {
+	return &iterator{kvs: r.state.scan(namespace, startKey, endKey)}, nil
+}
+
+func (r *stateReader) Done() {}
+
+// simulator reads the committed world state and buffers the writes of a
+// transaction, which are applied to the world state once it is valid.
+type simulator struct {
+	stateReader
+	writes map[string]map[string][]byte
+}
+
+func newSimulator(state *WorldState) *simulator {
+	return &simulator{stateReader: stateReader{state: state}, writes: map[string]map[string][]byte{}}
+}
+
+func (s *simulator) GetState(namespace string, key string) ([]byte, error) {
+	if value, ok := s.writes[namespace][key]; ok {
+		return value, nil
+	}
+	return s.stateReader.GetState(namespace, key)
+}
+
+func (s *simulator) SetState(namespace string, key string, value []byte) error {
+	if s.writes[namespace] == nil {
+		s.writes[namespace] = map[string][]byte{}
+	}
+	s.writes[namespace][key] = value
+	return nil
+}
+
+// iterator iterates over a snapshot of a range of the world state.
+type iterator struct {
+	kvs []*queryresult.KV
+}
+
+func (i *iterator) Next() (commonledger.QueryResult, error) {
+	if len(i.kvs) == 0 {
+		return nil, nil
+	}
+	kv := i.kvs[0]
+	i.kvs = i.kvs[1:]
+	return kv, nil
+}
+
+func (i *iterator) Close() {}
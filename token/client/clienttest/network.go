@@ -0,0 +1,235 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package clienttest provides an in-process token network to unit test the
+// applications of the token client without deploying peers and orderers.
+//
+// A Network serves, on a single local address and in plaintext, the prover
+// service of a peer, the broadcast service of an orderer and the filtered
+// deliver service of a committing peer of a channel with FabToken enabled.
+// The prover assembles token transactions with the plain token management
+// system and the orderer commits every transaction it receives in a block of
+// its own, validated by the plain verifier against an in-memory ledger, so
+// that the token client sees the same responses and commit events as with a
+// real network.
+//
+// The network does not check signatures or access control: Identity is a
+// stand-in for the identities of an MSP and anyone may issue tokens, unless
+// an IssuingValidator is set.
+package clienttest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/comm"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/hyperledger/fabric/token/identity"
+	"github.com/hyperledger/fabric/token/server"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("token.client.clienttest")
+
+// Network is an in-process token network of a single channel.
+type Network struct {
+	ChannelID string
+	// IssuingValidator checks the creators of the transactions issuing
+	// tokens; when nil, anyone may issue tokens
+	IssuingValidator identity.IssuingValidator
+
+	grpcServer *comm.GRPCServer
+	state      *WorldState
+
+	mutex    sync.Mutex
+	blocks   []*pb.FilteredBlock
+	txs      map[string]pb.TxValidationCode
+	newBlock chan struct{}
+}
+
+// NewNetwork returns a network of the channel, whose ledger only holds an
+// empty genesis block.
+func NewNetwork(channelID string) *Network {
+	return &Network{
+		ChannelID: channelID,
+		state:     NewWorldState(),
+		blocks:    []*pb.FilteredBlock{{ChannelId: channelID, Number: 0}},
+		txs:       map[string]pb.TxValidationCode{},
+		newBlock:  make(chan struct{}),
+	}
+}
+
+// Start starts serving the network on a free local port.
+func (n *Network) Start() error {
+	grpcServer, err := comm.NewGRPCServer("127.0.0.1:0", comm.ServerConfig{})
+	if err != nil {
+		return errors.WithMessage(err, "failed creating the network server")
+	}
+	prover, err := NewProver(n.state)
+	if err != nil {
+		return err
+	}
+
+	token.RegisterProverServer(grpcServer.Server(), prover)
+	ab.RegisterAtomicBroadcastServer(grpcServer.Server(), &orderer{network: n})
+	pb.RegisterDeliverServer(grpcServer.Server(), &deliverer{network: n})
+	n.grpcServer = grpcServer
+	go grpcServer.Start()
+	return nil
+}
+
+// Stop stops serving the network; the streams of the clients are closed.
+func (n *Network) Stop() {
+	n.grpcServer.Stop()
+}
+
+// Address returns the address the network is served on.
+func (n *Network) Address() string {
+	return n.grpcServer.Address()
+}
+
+// ClientConfig returns the config of a token client of the network, which
+// the orderer, the commit peer and the prover peer are all served by.
+func (n *Network) ClientConfig() *client.ClientConfig {
+	return &client.ClientConfig{
+		ChannelId:     n.ChannelID,
+		OrdererCfg:    client.ConnectionConfig{Address: n.Address()},
+		CommitPeerCfg: client.ConnectionConfig{Address: n.Address()},
+		ProverPeerCfg: client.ConnectionConfig{Address: n.Address()},
+	}
+}
+
+// ProverPeer returns a client of the prover of the network.
+func (n *Network) ProverPeer() (*client.ProverPeer, error) {
+	return client.NewProverPeer(n.ClientConfig())
+}
+
+// TxSubmitter returns a client submitting the transactions of signer to the
+// network.
+func (n *Network) TxSubmitter(signer client.SignerIdentity) (*client.TxSubmitter, error) {
+	config := n.ClientConfig()
+	creator, err := signer.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	ordererClient, err := client.NewOrdererClient(config)
+	if err != nil {
+		return nil, err
+	}
+	deliverClient, err := client.NewDeliverClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return &client.TxSubmitter{
+		Config:        config,
+		Signer:        signer,
+		Creator:       creator,
+		OrdererClient: ordererClient,
+		DeliverClient: deliverClient,
+	}, nil
+}
+
+// Submit submits the token transaction of the command response of the prover
+// with the submitter and waits for it to be committed, until ctx is done. It
+// returns the ID of the transaction, and an error if the prover responded an
+// error or the transaction is invalid.
+func (n *Network) Submit(ctx context.Context, submitter *client.TxSubmitter, commandResponse []byte) (string, error) {
+	response := &token.CommandResponse{}
+	err := proto.Unmarshal(commandResponse, response)
+	if err != nil {
+		return "", errors.Wrap(err, "failed unmarshaling command response")
+	}
+	if response.GetErr() != nil {
+		return "", errors.Errorf("prover responded error: %s", response.GetErr().Message)
+	}
+	tx, err := proto.Marshal(response.GetTokenTransaction())
+	if err != nil {
+		return "", errors.Wrap(err, "failed marshaling token transaction")
+	}
+
+	_, envelope, err := submitter.CreateTxEnvelope(tx)
+	if err != nil {
+		return "", err
+	}
+	committed, txID, err := submitter.SubmitTransactionContext(ctx, envelope)
+	if err != nil {
+		return txID, err
+	}
+	if !committed {
+		return txID, errors.Errorf("transaction %s not committed", txID)
+	}
+	return txID, nil
+}
+
+// Height returns the number of blocks of the ledger.
+func (n *Network) Height() uint64 {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	return uint64(len(n.blocks))
+}
+
+// ValidationCode returns the validation code of the transaction, if it has
+// been committed.
+func (n *Network) ValidationCode(txID string) (pb.TxValidationCode, bool) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	code, ok := n.txs[txID]
+	return code, ok
+}
+
+// NewProver returns a prover assembling token transactions against the world
+// state with the plain token management system. It allows every command.
+func NewProver(state *WorldState) (*server.Prover, error) {
+	marshaler, err := server.NewResponseMarshaler(NewIdentity("PeerMSP", "prover"))
+	if err != nil {
+		return nil, err
+	}
+	return &server.Prover{
+		CapabilityChecker: capabilityChecker{},
+		Marshaler:         marshaler,
+		PolicyChecker:     allowAll{},
+		TMSManager:        &server.Manager{LedgerManager: state},
+	}, nil
+}
+
+// capabilityChecker enables FabToken, with SHA256 transaction IDs and
+// without encryption nor expiration.
+type capabilityChecker struct{}
+
+func (capabilityChecker) FabToken(channelId string) (bool, error) {
+	return true, nil
+}
+
+func (capabilityChecker) HashingSuite(channelId string) (string, error) {
+	return "SHA256", nil
+}
+
+func (capabilityChecker) TokenEncryptionKeys(channelId string) (map[string][]byte, error) {
+	return nil, nil
+}
+
+func (capabilityChecker) TxExpiration(channelId string) (bool, error) {
+	return false, nil
+}
+
+// allowAll allows every command.
+type allowAll struct{}
+
+func (allowAll) Check(sc *token.SignedCommand, c *token.Command) error {
+	return nil
+}
+
+// allowAllIssuers allows anyone to issue tokens.
+type allowAllIssuers struct{}
+
+func (allowAllIssuers) Validate(creator identity.PublicInfo, tokenType string) error {
+	return nil
+}
@@ -0,0 +1,154 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clienttest_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/hyperledger/fabric/token/client/clienttest"
+	"github.com/hyperledger/fabric/token/identity"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Network", func() {
+	var (
+		network *clienttest.Network
+		prover  *client.ProverPeer
+		alice   *clienttest.Identity
+		bob     *clienttest.Identity
+		ctx     context.Context
+		cancel  context.CancelFunc
+	)
+
+	BeforeEach(func() {
+		network = clienttest.NewNetwork("testchannel")
+		err := network.Start()
+		Expect(err).NotTo(HaveOccurred())
+
+		prover, err = network.ProverPeer()
+		Expect(err).NotTo(HaveOccurred())
+		alice = clienttest.NewIdentity("Org1MSP", "alice")
+		bob = clienttest.NewIdentity("Org1MSP", "bob")
+		ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	})
+
+	AfterEach(func() {
+		cancel()
+		network.Stop()
+	})
+
+	submit := func(signer *clienttest.Identity, response []byte) (string, error) {
+		submitter, err := network.TxSubmitter(signer)
+		Expect(err).NotTo(HaveOccurred())
+		return network.Submit(ctx, submitter, response)
+	}
+
+	issue := func(recipient *clienttest.Identity, quantity uint64) {
+		response, err := prover.RequestImport([]*token.TokenToIssue{{Recipient: recipient.MustSerialize(), Type: "USD", Quantity: quantity}}, alice)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = submit(alice, response)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	It("issues, lists, transfers and redeems tokens", func() {
+		issue(alice, 100)
+
+		tokens, err := prover.ListTokens(alice)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tokens).To(HaveLen(1))
+		Expect(tokens[0].Type).To(Equal("USD"))
+		Expect(tokens[0].Quantity).To(Equal(uint64(100)))
+
+		response, err := prover.RequestTransfer([][]byte{tokens[0].Id}, []*token.RecipientTransferShare{
+			{Recipient: bob.MustSerialize(), Quantity: 60},
+			{Recipient: alice.MustSerialize(), Quantity: 40},
+		}, alice)
+		Expect(err).NotTo(HaveOccurred())
+		txID, err := submit(alice, response)
+		Expect(err).NotTo(HaveOccurred())
+		code, ok := network.ValidationCode(txID)
+		Expect(ok).To(BeTrue())
+		Expect(code).To(Equal(pb.TxValidationCode_VALID))
+
+		bobTokens, err := prover.ListTokens(bob)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bobTokens).To(HaveLen(1))
+		Expect(bobTokens[0].Quantity).To(Equal(uint64(60)))
+
+		response, err = prover.RequestRedeem([][]byte{bobTokens[0].Id}, 10, bob)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = submit(bob, response)
+		Expect(err).NotTo(HaveOccurred())
+
+		bobTokens, err = prover.ListTokens(bob)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bobTokens).To(HaveLen(1))
+		Expect(bobTokens[0].Quantity).To(Equal(uint64(50)))
+		Expect(network.Height()).To(Equal(uint64(4)))
+	})
+
+	It("invalidates double spends", func() {
+		issue(alice, 100)
+		tokens, err := prover.ListTokens(alice)
+		Expect(err).NotTo(HaveOccurred())
+
+		shares := []*token.RecipientTransferShare{{Recipient: bob.MustSerialize(), Quantity: 100}}
+		first, err := prover.RequestTransfer([][]byte{tokens[0].Id}, shares, alice)
+		Expect(err).NotTo(HaveOccurred())
+		second, err := prover.RequestTransfer([][]byte{tokens[0].Id}, shares, alice)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = submit(alice, first)
+		Expect(err).NotTo(HaveOccurred())
+		txID, err := submit(alice, second)
+		Expect(err).To(MatchError(ContainSubstring("status is not valid: INVALID_OTHER_REASON")))
+		code, ok := network.ValidationCode(txID)
+		Expect(ok).To(BeTrue())
+		Expect(code).To(Equal(pb.TxValidationCode_INVALID_OTHER_REASON))
+	})
+
+	It("returns the errors of the prover", func() {
+		response, err := prover.RequestTransfer([][]byte{[]byte("missing")}, nil, alice)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = submit(alice, response)
+		Expect(err).To(MatchError(ContainSubstring("prover responded error")))
+	})
+
+	Context("when an issuing validator is set", func() {
+		BeforeEach(func() {
+			network.IssuingValidator = issuingValidator(func(creator identity.PublicInfo, tokenType string) error {
+				return errors.New("not an issuer")
+			})
+		})
+
+		It("invalidates the imports it rejects", func() {
+			response, err := prover.RequestImport([]*token.TokenToIssue{{Recipient: alice.MustSerialize(), Type: "USD", Quantity: 1}}, alice)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = submit(alice, response)
+			Expect(err).To(MatchError(ContainSubstring("INVALID_OTHER_REASON")))
+		})
+	})
+
+	It("serves the capabilities of the channel", func() {
+		capabilities, err := prover.GetChannelCapabilitiesContext(ctx, alice)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(proto.Equal(capabilities, &token.ChannelCapabilities{FabToken: true, HashingSuite: "SHA256"})).To(BeTrue())
+	})
+})
+
+type issuingValidator func(creator identity.PublicInfo, tokenType string) error
+
+func (v issuingValidator) Validate(creator identity.PublicInfo, tokenType string) error {
+	return v(creator, tokenType)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clienttest
+
+import (
+	"io"
+
+	"github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// orderer commits the transactions it receives, each in a block of its own.
+type orderer struct {
+	network *Network
+}
+
+func (o *orderer) Broadcast(stream ab.AtomicBroadcast_BroadcastServer) error {
+	for {
+		envelope, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		response := &ab.BroadcastResponse{Status: common.Status_SUCCESS}
+		chdr, err := o.network.checkTransaction(envelope)
+		if err != nil {
+			response = &ab.BroadcastResponse{Status: common.Status_BAD_REQUEST, Info: err.Error()}
+		} else {
+			o.network.commit(chdr, envelope)
+		}
+		err = stream.Send(response)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (o *orderer) Deliver(stream ab.AtomicBroadcast_DeliverServer) error {
+	return status.Error(codes.Unimplemented, "the orderer of the network does not deliver blocks")
+}
+
+// checkTransaction checks that the envelope carries a token transaction of
+// the channel and returns its channel header.
+func (n *Network) checkTransaction(envelope *common.Envelope) (*common.ChannelHeader, error) {
+	payload, err := utils.UnmarshalPayload(envelope.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Header == nil {
+		return nil, errors.New("missing header")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+	if chdr.ChannelId != n.ChannelID {
+		return nil, errors.Errorf("channel %s not found", chdr.ChannelId)
+	}
+	if common.HeaderType(chdr.Type) != common.HeaderType_TOKEN_TRANSACTION {
+		return nil, errors.Errorf("only token transactions are supported, provided type: %d", chdr.Type)
+	}
+	return chdr, nil
+}
+
+// commit validates the transaction and commits it in a new block.
+func (n *Network) commit(chdr *common.ChannelHeader, envelope *common.Envelope) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	code := n.validate(chdr, envelope)
+	if _, ok := n.txs[chdr.TxId]; !ok {
+		n.txs[chdr.TxId] = code
+	}
+	n.blocks = append(n.blocks, &pb.FilteredBlock{
+		ChannelId: n.ChannelID,
+		Number:    uint64(len(n.blocks)),
+		FilteredTransactions: []*pb.FilteredTransaction{{
+			Txid:             chdr.TxId,
+			Type:             common.HeaderType(chdr.Type),
+			TxValidationCode: code,
+		}},
+	})
+	close(n.newBlock)
+	n.newBlock = make(chan struct{})
+}
+
+// validate validates the transaction against the world state and, if it is
+// valid, applies its writes.
+func (n *Network) validate(chdr *common.ChannelHeader, envelope *common.Envelope) pb.TxValidationCode {
+	if _, ok := n.txs[chdr.TxId]; ok {
+		return pb.TxValidationCode_DUPLICATE_TXID
+	}
+	return n.state.Commit(n.ChannelID, n.IssuingValidator, chdr, envelope)
+}
@@ -0,0 +1,47 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+)
+
+// IncompatibleProverError is returned when the prover peer does not know the
+// type of a command, typically because it runs an older version than the
+// client.
+type IncompatibleProverError struct {
+	// Command is the type of the command, such as CapabilitiesRequest
+	Command string
+	// Message is the error of the prover
+	Message string
+}
+
+func (e *IncompatibleProverError) Error() string {
+	return fmt.Sprintf("prover peer does not support %s commands, it may run an older version than the client: %s", e.Command, e.Message)
+}
+
+// checkSupported returns an IncompatibleProverError if the response of the
+// prover reports that it does not know the type of the command. The other
+// errors, and malformed responses, are left to the callers.
+func checkSupported(sc *token.SignedCommand, rawResponse []byte) error {
+	response := &token.CommandResponse{}
+	if err := proto.Unmarshal(rawResponse, response); err != nil || !tk.IsUnsupportedCommand(response.GetErr().GetMessage()) {
+		return nil
+	}
+
+	commandType := "unknown"
+	command := &token.Command{}
+	if err := proto.Unmarshal(sc.Command, command); err == nil && command.GetPayload() != nil {
+		commandType = strings.TrimPrefix(fmt.Sprintf("%T", command.GetPayload()), "*token.Command_")
+	}
+	return &IncompatibleProverError{Command: commandType, Message: response.GetErr().GetMessage()}
+}
@@ -5,13 +5,40 @@ SPDX-License-Identifier: Apache-2.0
 */
 package client
 
-import "github.com/pkg/errors"
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/viperutil"
+	coreconfig "github.com/hyperledger/fabric/core/config"
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+)
 
 // ConnectionConfig contains data required to establish grpc connection to a peer or orderer
 type ConnectionConfig struct {
+	// Address is either host:port, dns://host:port to balance across every address
+	// of host, or srv://name to balance across the targets of the SRV records of name
 	Address            string
 	TlsRootCertFile    string
 	ServerNameOverride string
+	// Compression, when set, compresses requests and responses with "gzip" or "snappy"
+	Compression string
+	// Proxy is the URL of the HTTP CONNECT (http://) or SOCKS5 (socks5://) proxy used to
+	// reach Address. When empty, the HTTPS_PROXY, ALL_PROXY and NO_PROXY environment variables apply
+	Proxy string
+	// TlsRootCerts are the PEM encoded TLS root certificates used when TlsRootCertFile is empty,
+	// such as the ones of the MSPs of the channel config block
+	TlsRootCerts [][]byte `mapstructure:"-"`
+}
+
+// hasTlsRootCerts returns whether the connection has TLS root certificates.
+func (cfg *ConnectionConfig) hasTlsRootCerts() bool {
+	return cfg.TlsRootCertFile != "" || len(cfg.TlsRootCerts) != 0
 }
 
 // ClientConfig will be updated after the CR for token client config is merged, where the config data
@@ -24,6 +51,50 @@ type ClientConfig struct {
 	OrdererCfg    ConnectionConfig
 	CommitPeerCfg ConnectionConfig
 	ProverPeerCfg ConnectionConfig
+	// GatewayPeerCfg, when set, is the peer whose token gateway submits the
+	// transactions of a GatewaySubmitter to the orderers and waits for their
+	// commit, in which case OrdererCfg and CommitPeerCfg are not needed.
+	GatewayPeerCfg ConnectionConfig
+	// HashingSuite is the hashing algorithm with which the channel computes
+	// transaction IDs: SHA256 (the default when empty), SHA3_256 or SHA3_384
+	HashingSuite string
+	// ChannelConfigBlock, when set, is the path of a config block of the
+	// channel exported to a file, from which LoadConfig completes the
+	// channel ID, the hashing suite and the orderer address, so that
+	// air-gapped clients need not query a peer. See ChannelConfigBlock.
+	ChannelConfigBlock string
+	// ChannelConfigBlockHash, when set, is the hex encoded hash of the header
+	// of ChannelConfigBlock, the previous hash of the block following it,
+	// which pins the block instead of trusting it on first use.
+	ChannelConfigBlockHash string
+	// TxTTL is how long after their creation the token transactions of the
+	// client may still be committed, so that a transaction stuck at the
+	// client cannot commit unexpectedly when resubmitted much later: 0 means
+	// DefaultTxTTL, and a negative value disables the expiration. It only
+	// applies on the channels with the TxExpiration capability, as the peers
+	// of the other channels do not invalidate the expired transactions.
+	TxTTL time.Duration
+}
+
+// DefaultTxTTL is the TxTTL of the clients that do not configure one.
+const DefaultTxTTL = 10 * time.Minute
+
+// txTTL returns the time to live of the transactions on the channel with the
+// capabilities: the TxTTL of the config, or DefaultTxTTL when it is not set,
+// if the channel has the TxExpiration capability, and 0 otherwise, including
+// when the capabilities are unknown.
+func (config *ClientConfig) txTTL(capabilities *ChannelCapabilities) (time.Duration, error) {
+	if capabilities == nil {
+		return 0, nil
+	}
+	txExpiration, err := capabilities.TxExpiration(context.Background())
+	if err != nil || !txExpiration {
+		return 0, err
+	}
+	if config.TxTTL == 0 {
+		return DefaultTxTTL, nil
+	}
+	return config.TxTTL, nil
 }
 
 func ValidateClientConfig(config *ClientConfig) error {
@@ -34,22 +105,168 @@ func ValidateClientConfig(config *ClientConfig) error {
 		return errors.New("missing channelId")
 	}
 
-	if config.OrdererCfg.Address == "" {
-		return errors.New("missing orderer address")
+	if config.GatewayPeerCfg.Address != "" {
+		if config.TlsEnabled && !config.GatewayPeerCfg.hasTlsRootCerts() {
+			return errors.New("missing gateway peer TlsRootCertFile")
+		}
+	} else {
+		if config.OrdererCfg.Address == "" {
+			return errors.New("missing orderer address")
+		}
+
+		if config.TlsEnabled && !config.OrdererCfg.hasTlsRootCerts() {
+			return errors.New("missing orderer TlsRootCertFile")
+		}
+
+		if config.OrdererCfg.Address == "" {
+			return errors.New("missing commit peer address")
+		}
+
+		if config.TlsEnabled && !config.OrdererCfg.hasTlsRootCerts() {
+			return errors.New("missing commit peer TlsRootCertFile")
+		}
 	}
 
-	if config.TlsEnabled && config.OrdererCfg.TlsRootCertFile == "" {
-		return errors.New("missing orderer TlsRootCertFile")
+	if config.HashingSuite != "" {
+		if _, err := channelconfig.HashingSuiteByName(config.HashingSuite); err != nil {
+			return errors.Wrap(err, "invalid hashing suite")
+		}
 	}
 
-	if config.OrdererCfg.Address == "" {
-		return errors.New("missing commit peer address")
+	// TODO: add prover peer validation in a different CR
+	return nil
+}
+
+// ConfigEnvPrefix is the prefix of the environment variables overriding the
+// values of the config files loaded with LoadConfig; for instance
+// TOKENCLIENT_MSPDIR overrides mspDir and TOKENCLIENT_ORDERERCFG_ADDRESS
+// overrides the address of ordererCfg.
+const ConfigEnvPrefix = "TOKENCLIENT"
+
+// connectionKeys are the keys of the connection sections of the config files.
+var connectionKeys = []string{"address", "tlsRootCertFile", "serverNameOverride", "compression", "proxy"}
+
+// LoadConfig loads the ClientConfig of the YAML or JSON file at path, whose
+// keys are the names of the fields of ClientConfig, e.g. channelId and
+// ordererCfg.tlsRootCertFile, and validates it. The values are overridden by
+// the environment variables prefixed with ConfigEnvPrefix, which may also set
+// the keys missing from the file, such as the paths of secrets. Relative
+// paths are relative to the directory of the file. When only one of the
+// commit peer and the prover peer is configured, it is used as both. When
+// gatewayPeerCfg is configured, the orderer and the commit peer are optional.
+// When channelConfigBlock is configured, the channel ID, the hashing suite
+// and the orderer address default to the ones of the block, as do the TLS
+// root certificates of the connections without tlsRootCertFile; the block
+// must have the header hash channelConfigBlockHash when it is configured.
+func LoadConfig(path string) (*ClientConfig, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetEnvPrefix(ConfigEnvPrefix)
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	for _, key := range []string{"channelId", "mspDir", "mspId", "hashingSuite", "channelConfigBlock", "channelConfigBlockHash"} {
+		v.SetDefault(key, "")
+	}
+	v.SetDefault("tlsEnabled", false)
+	v.SetDefault("txTTL", time.Duration(0))
+	if err := v.ReadInConfig(); err != nil {
+		return nil, errors.Wrapf(err, "failed reading token client config %s", path)
 	}
 
-	if config.TlsEnabled && config.OrdererCfg.TlsRootCertFile == "" {
-		return errors.New("missing commit peer TlsRootCertFile")
+	// viper only looks up the environment for the keys it knows of, so the
+	// connection sections are completed with every key
+	for _, section := range []string{"ordererCfg", "commitPeerCfg", "proverPeerCfg", "gatewayPeerCfg"} {
+		cfg := map[string]interface{}{}
+		for _, key := range connectionKeys {
+			cfg[strings.ToLower(key)] = ""
+		}
+		for key, value := range cast.ToStringMap(v.Get(section)) {
+			cfg[strings.ToLower(key)] = value
+		}
+		v.Set(section, cfg)
 	}
 
-	// TODO: add prover peer validation in a different CR
+	config := &ClientConfig{}
+	if err := viperutil.EnhancedExactUnmarshal(v, config); err != nil {
+		return nil, errors.Wrapf(err, "failed unmarshaling token client config %s", path)
+	}
+
+	if config.CommitPeerCfg.Address == "" {
+		config.CommitPeerCfg = config.ProverPeerCfg
+	}
+	if config.ProverPeerCfg.Address == "" {
+		config.ProverPeerCfg = config.CommitPeerCfg
+	}
+	configDir := filepath.Dir(path)
+	for _, p := range []*string{&config.MspDir, &config.OrdererCfg.TlsRootCertFile, &config.CommitPeerCfg.TlsRootCertFile, &config.ProverPeerCfg.TlsRootCertFile, &config.GatewayPeerCfg.TlsRootCertFile, &config.ChannelConfigBlock} {
+		if *p != "" {
+			coreconfig.TranslatePathInPlace(configDir, p)
+		}
+	}
+
+	if config.ChannelConfigBlock != "" {
+		block, err := loadChannelConfigBlock(config)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid token client config "+path)
+		}
+		if err := block.Bootstrap(config); err != nil {
+			return nil, errors.WithMessage(err, "invalid token client config "+path)
+		}
+	}
+
+	if err := validateLoadedConfig(config); err != nil {
+		return nil, errors.WithMessage(err, "invalid token client config "+path)
+	}
+	return config, nil
+}
+
+// loadChannelConfigBlock loads the channel config block of the config, pinned
+// to its hash when the config sets one.
+func loadChannelConfigBlock(config *ClientConfig) (*ChannelConfigBlock, error) {
+	if config.ChannelConfigBlockHash != "" {
+		return LoadPinnedChannelConfigBlock(config.ChannelConfigBlock, config.ChannelConfigBlockHash)
+	}
+	return LoadChannelConfigBlock(config.ChannelConfigBlock)
+}
+
+// validateLoadedConfig validates a loaded config, which must also configure
+// the MSP of the client and every connection, except for the orderer and the
+// commit peer when the gateway peer is configured.
+func validateLoadedConfig(config *ClientConfig) error {
+	if err := ValidateClientConfig(config); err != nil {
+		return err
+	}
+	if config.MspDir == "" {
+		return errors.New("missing mspDir")
+	}
+	if config.MspId == "" {
+		return errors.New("missing mspId")
+	}
+	connections := []struct {
+		name     string
+		cfg      ConnectionConfig
+		optional bool
+	}{
+		{"orderer", config.OrdererCfg, config.GatewayPeerCfg.Address != ""},
+		{"commit peer", config.CommitPeerCfg, config.GatewayPeerCfg.Address != ""},
+		{"prover peer", config.ProverPeerCfg, false},
+		{"gateway peer", config.GatewayPeerCfg, true},
+	}
+	for _, conn := range connections {
+		if conn.optional && conn.cfg.Address == "" {
+			continue
+		}
+		if conn.cfg.Address == "" {
+			return errors.Errorf("missing %s address", conn.name)
+		}
+		if config.TlsEnabled && !conn.cfg.hasTlsRootCerts() {
+			return errors.Errorf("missing %s TlsRootCertFile", conn.name)
+		}
+		switch conn.cfg.Compression {
+		case "", "gzip", "snappy":
+		default:
+			return errors.Errorf("invalid %s compression: %s", conn.name, conn.cfg.Compression)
+		}
+	}
 	return nil
 }
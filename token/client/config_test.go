@@ -0,0 +1,309 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client_test
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/token/client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LoadConfig", func() {
+	var (
+		dir      string
+		path     string
+		contents string
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "tokenclient")
+		Expect(err).NotTo(HaveOccurred())
+		path = filepath.Join(dir, "config.yaml")
+		contents = `
+channelId: testchannel
+mspDir: msp
+mspId: Org1MSP
+tlsEnabled: true
+ordererCfg:
+  address: orderer.example.com:7050
+  tlsRootCertFile: /etc/orderer/ca.crt
+proverPeerCfg:
+  address: peer0.org1.example.com:7051
+  tlsRootCertFile: tls/ca.crt
+  compression: gzip
+`
+	})
+
+	JustBeforeEach(func() {
+		err := ioutil.WriteFile(path, []byte(contents), 0644)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("loads the config with relative paths and the prover peer as commit peer", func() {
+		config, err := client.LoadConfig(path)
+		Expect(err).NotTo(HaveOccurred())
+		peerCfg := client.ConnectionConfig{
+			Address:         "peer0.org1.example.com:7051",
+			TlsRootCertFile: filepath.Join(dir, "tls/ca.crt"),
+			Compression:     "gzip",
+		}
+		Expect(config).To(Equal(&client.ClientConfig{
+			ChannelId:  "testchannel",
+			MspDir:     filepath.Join(dir, "msp"),
+			MspId:      "Org1MSP",
+			TlsEnabled: true,
+			OrdererCfg: client.ConnectionConfig{
+				Address:         "orderer.example.com:7050",
+				TlsRootCertFile: "/etc/orderer/ca.crt",
+			},
+			CommitPeerCfg: peerCfg,
+			ProverPeerCfg: peerCfg,
+		}))
+	})
+
+	Context("when the config is JSON", func() {
+		BeforeEach(func() {
+			path = filepath.Join(dir, "config.json")
+			contents = `{
+  "channelId": "testchannel",
+  "mspDir": "/etc/msp",
+  "mspId": "Org1MSP",
+  "ordererCfg": {"address": "orderer.example.com:7050"},
+  "commitPeerCfg": {"address": "peer0.org1.example.com:7051"},
+  "proverPeerCfg": {"address": "peer1.org1.example.com:7051"},
+  "txTTL": "5m"
+}`
+		})
+
+		It("loads the config", func() {
+			config, err := client.LoadConfig(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.MspDir).To(Equal("/etc/msp"))
+			Expect(config.TlsEnabled).To(BeFalse())
+			Expect(config.CommitPeerCfg.Address).To(Equal("peer0.org1.example.com:7051"))
+			Expect(config.ProverPeerCfg.Address).To(Equal("peer1.org1.example.com:7051"))
+			Expect(config.TxTTL).To(Equal(5 * time.Minute))
+		})
+	})
+
+	Context("when environment variables are set", func() {
+		BeforeEach(func() {
+			os.Setenv("TOKENCLIENT_MSPDIR", "/run/secrets/msp")
+			os.Setenv("TOKENCLIENT_PROVERPEERCFG_SERVERNAMEOVERRIDE", "peer0")
+			os.Setenv("TOKENCLIENT_TXTTL", "-1s")
+		})
+		AfterEach(func() {
+			os.Unsetenv("TOKENCLIENT_MSPDIR")
+			os.Unsetenv("TOKENCLIENT_PROVERPEERCFG_SERVERNAMEOVERRIDE")
+			os.Unsetenv("TOKENCLIENT_TXTTL")
+		})
+
+		It("overrides the values of the config, including the missing ones", func() {
+			config, err := client.LoadConfig(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.MspDir).To(Equal("/run/secrets/msp"))
+			Expect(config.ProverPeerCfg.ServerNameOverride).To(Equal("peer0"))
+			Expect(config.TxTTL).To(Equal(-time.Second))
+		})
+	})
+
+	Context("when the config has an unknown key", func() {
+		BeforeEach(func() {
+			contents += "proverPeerCfg2:\n  address: peer1.org1.example.com:7051\n"
+		})
+
+		It("returns an error", func() {
+			_, err := client.LoadConfig(path)
+			Expect(err).To(MatchError(ContainSubstring("failed unmarshaling token client config")))
+			Expect(err).To(MatchError(ContainSubstring("proverpeercfg2")))
+		})
+	})
+
+	Context("when a connection has an unknown key", func() {
+		BeforeEach(func() {
+			contents += "commitPeerCfg:\n  address: peer1.org1.example.com:7051\n  tlsRootCert: tls/ca.crt\n"
+		})
+
+		It("returns an error", func() {
+			_, err := client.LoadConfig(path)
+			Expect(err).To(MatchError(ContainSubstring("'CommitPeerCfg' has invalid keys: tlsrootcert")))
+		})
+	})
+
+	Context("when the MSP ID is missing", func() {
+		BeforeEach(func() {
+			contents = "channelId: testchannel\nmspDir: msp\nordererCfg:\n  address: orderer:7050\nproverPeerCfg:\n  address: peer:7051\n"
+		})
+
+		It("returns an error", func() {
+			_, err := client.LoadConfig(path)
+			Expect(err).To(MatchError("invalid token client config " + path + ": missing mspId"))
+		})
+	})
+
+	Context("when a TLS root cert is missing", func() {
+		BeforeEach(func() {
+			contents = "channelId: testchannel\nmspDir: msp\nmspId: Org1MSP\ntlsEnabled: true\nordererCfg:\n  address: orderer:7050\n  tlsRootCertFile: ca.crt\nproverPeerCfg:\n  address: peer:7051\n"
+		})
+
+		It("returns an error", func() {
+			_, err := client.LoadConfig(path)
+			Expect(err).To(MatchError("invalid token client config " + path + ": missing commit peer TlsRootCertFile"))
+		})
+	})
+
+	Context("when a compression is invalid", func() {
+		BeforeEach(func() {
+			contents = "channelId: testchannel\nmspDir: msp\nmspId: Org1MSP\nordererCfg:\n  address: orderer:7050\n  compression: zip\nproverPeerCfg:\n  address: peer:7051\n"
+		})
+
+		It("returns an error", func() {
+			_, err := client.LoadConfig(path)
+			Expect(err).To(MatchError("invalid token client config " + path + ": invalid orderer compression: zip"))
+		})
+	})
+
+	Context("when the gateway peer is configured", func() {
+		BeforeEach(func() {
+			contents = `
+channelId: testchannel
+mspDir: msp
+mspId: Org1MSP
+tlsEnabled: true
+proverPeerCfg:
+  address: peer0.org1.example.com:7051
+  tlsRootCertFile: tls/ca.crt
+gatewayPeerCfg:
+  address: peer1.org1.example.com:7051
+  tlsRootCertFile: tls/ca.crt
+`
+		})
+
+		It("does not need the orderer", func() {
+			config, err := client.LoadConfig(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.OrdererCfg.Address).To(BeEmpty())
+			Expect(config.GatewayPeerCfg).To(Equal(client.ConnectionConfig{
+				Address:         "peer1.org1.example.com:7051",
+				TlsRootCertFile: filepath.Join(dir, "tls/ca.crt"),
+			}))
+		})
+
+		Context("when its TLS root cert is missing", func() {
+			BeforeEach(func() {
+				contents = strings.Replace(contents, "gatewayPeerCfg:\n  address: peer1.org1.example.com:7051\n  tlsRootCertFile: tls/ca.crt\n", "gatewayPeerCfg:\n  address: peer1.org1.example.com:7051\n", 1)
+			})
+
+			It("returns an error", func() {
+				_, err := client.LoadConfig(path)
+				Expect(err).To(MatchError("invalid token client config " + path + ": missing gateway peer TlsRootCertFile"))
+			})
+		})
+	})
+
+	Context("when the channel config block is configured", func() {
+		var block *common.Block
+
+		BeforeEach(func() {
+			var err error
+			block, err = configtxtest.MakeGenesisBlock("testchannel")
+			Expect(err).NotTo(HaveOccurred())
+			err = ioutil.WriteFile(filepath.Join(dir, "config.block"), ProtoMarshal(block), 0644)
+			Expect(err).NotTo(HaveOccurred())
+
+			contents = "channelConfigBlock: config.block\nmspDir: msp\nmspId: Org1MSP\nproverPeerCfg:\n  address: peer:7051\n"
+		})
+
+		It("completes the config with the block", func() {
+			config, err := client.LoadConfig(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.ChannelConfigBlock).To(Equal(filepath.Join(dir, "config.block")))
+			Expect(config.ChannelId).To(Equal("testchannel"))
+			Expect(config.HashingSuite).To(Equal("SHA256"))
+			Expect(config.OrdererCfg.Address).To(Equal("127.0.0.1:7050"))
+		})
+
+		Context("when TLS is enabled", func() {
+			BeforeEach(func() {
+				contents += "tlsEnabled: true\n"
+			})
+
+			It("authenticates the connections with the TLS root certificates of the block", func() {
+				config, err := client.LoadConfig(path)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.OrdererCfg.TlsRootCerts).NotTo(BeEmpty())
+				Expect(config.ProverPeerCfg.TlsRootCerts).To(Equal(config.OrdererCfg.TlsRootCerts))
+			})
+		})
+
+		Context("when the hash of the block is pinned", func() {
+			BeforeEach(func() {
+				contents += "channelConfigBlockHash: " + hex.EncodeToString(block.Header.Hash()) + "\n"
+			})
+
+			It("loads the block with the hash", func() {
+				config, err := client.LoadConfig(path)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.ChannelId).To(Equal("testchannel"))
+			})
+
+			Context("when the block has another hash", func() {
+				BeforeEach(func() {
+					contents = strings.Replace(contents, "channelConfigBlockHash: ", "channelConfigBlockHash: 00", 1)
+				})
+
+				It("returns an error", func() {
+					_, err := client.LoadConfig(path)
+					Expect(err).To(MatchError(ContainSubstring("does not have the pinned hash")))
+				})
+			})
+		})
+
+		Context("when the config targets another channel", func() {
+			BeforeEach(func() {
+				contents += "channelId: otherchannel\n"
+			})
+
+			It("returns an error", func() {
+				_, err := client.LoadConfig(path)
+				Expect(err).To(MatchError("invalid token client config " + path + ": channel config block is for channel testchannel, not otherchannel"))
+			})
+		})
+
+		Context("when the block does not exist", func() {
+			BeforeEach(func() {
+				contents = strings.Replace(contents, "config.block", "missing.block", 1)
+			})
+
+			It("returns an error", func() {
+				_, err := client.LoadConfig(path)
+				Expect(err).To(MatchError(ContainSubstring("failed reading channel config block " + filepath.Join(dir, "missing.block"))))
+			})
+		})
+	})
+
+	Context("when the file does not exist", func() {
+		It("returns an error", func() {
+			_, err := client.LoadConfig(filepath.Join(dir, "missing.yaml"))
+			Expect(err).To(MatchError(ContainSubstring("failed reading token client config")))
+		})
+	})
+})
@@ -0,0 +1,277 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultConflictMaxAttempts = 3
+	defaultConflictBaseDelay   = 100 * time.Millisecond
+	defaultConflictMaxDelay    = 5 * time.Second
+)
+
+// InvalidTxError is returned when a submitted transaction is committed as
+// invalid.
+type InvalidTxError struct {
+	TxID           string
+	ValidationCode string
+	// Hotspots are the inputs of the transaction that recently caused
+	// conflicts, when reported by the gateway peer
+	Hotspots []*token.InputHotspot
+}
+
+func (e *InvalidTxError) Error() string {
+	return fmt.Sprintf("transaction [%s] status is not valid: %s", e.TxID, e.ValidationCode)
+}
+
+// ConflictRetry is an interceptor that resubmits the token transactions
+// invalidated by conflicts with concurrent transactions, after a jittered
+// exponential delay. Before resubmitting a transfer or a redemption, it
+// replaces the inputs that were spent meanwhile, or that the gateway peer
+// reported as conflict hotspots, with other unspent tokens of the same type.
+//
+// Use its Intercept method as an interceptor of the Client.
+type ConflictRetry struct {
+	// MaxAttempts is the number of submissions of a transaction, the first
+	// one included. Zero means 3.
+	MaxAttempts int
+	// BaseDelay is the delay before the first resubmission; it doubles with
+	// each resubmission, and the actual delay is drawn at random below it.
+	// Zero means 100ms.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay before a resubmission. Zero means 5s.
+	MaxDelay time.Duration
+	// Owner is the recipient of the change of the transfers whose inputs
+	// are reselected, usually the serialized public version of the signing
+	// identity of the client. When empty, the inputs of a transfer are only
+	// reselected if they match the quantity transferred exactly.
+	Owner []byte
+}
+
+// Intercept invokes the method of the request, and invokes it again with
+// reselected inputs while the transaction is invalidated by a conflict.
+func (r *ConflictRetry) Intercept(ctx context.Context, request *Request, invoker Invoker) (*Response, error) {
+	if request.Method == MethodListTokens {
+		return invoker(ctx, request)
+	}
+
+	maxAttempts := r.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultConflictMaxAttempts
+	}
+	for attempt := 1; ; attempt++ {
+		response, err := invoker(ctx, request)
+		invalid, ok := errors.Cause(err).(*InvalidTxError)
+		if !ok || attempt >= maxAttempts {
+			return response, err
+		}
+
+		next := request
+		switch invalid.ValidationCode {
+		case pb.TxValidationCode_MVCC_READ_CONFLICT.String(), pb.TxValidationCode_PHANTOM_READ_CONFLICT.String():
+			if reselected, changed := r.reselect(ctx, request, response, invalid, invoker); changed {
+				next = reselected
+			}
+		case pb.TxValidationCode_INVALID_OTHER_REASON.String():
+			// the inputs of plain token transactions were spent meanwhile;
+			// resubmitting the same transaction is bound to fail again
+			reselected, changed := r.reselect(ctx, request, response, invalid, invoker)
+			if !changed {
+				return response, err
+			}
+			next = reselected
+		default:
+			return response, err
+		}
+
+		delay := r.delay(attempt)
+		logger.Debugf("transaction %s invalidated by a conflict (%s), resubmitting in %s", invalid.TxID, invalid.ValidationCode, delay)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return response, err
+		}
+		request = next
+	}
+}
+
+// delay returns the delay before the resubmission following the attempt,
+// drawn at random between zero and the exponential backoff
+func (r *ConflictRetry) delay(attempt int) time.Duration {
+	base, max := r.BaseDelay, r.MaxDelay
+	if base <= 0 {
+		base = defaultConflictBaseDelay
+	}
+	if max <= 0 {
+		max = defaultConflictMaxDelay
+	}
+	backoff := base
+	for i := 1; i < attempt && backoff < max; i++ {
+		backoff *= 2
+	}
+	if backoff > max {
+		backoff = max
+	}
+	return time.Duration(rand.Int63n(int64(backoff) + 1))
+}
+
+// reselect returns a copy of the transfer or redemption request whose inputs
+// that are spent or hot are replaced by other unspent tokens of the same
+// type, and whether the inputs changed. Hot tokens are only selected when the
+// other tokens do not cover the quantity needed.
+func (r *ConflictRetry) reselect(ctx context.Context, request *Request, response *Response, invalid *InvalidTxError, invoker Invoker) (*Request, bool) {
+	var needed uint64
+	switch request.Method {
+	case MethodTransfer:
+		for _, share := range request.Shares {
+			needed += share.Quantity
+		}
+	case MethodRedeem:
+		needed = request.Quantity
+	default:
+		return request, false
+	}
+
+	listed, err := invoker(ctx, &Request{Method: MethodListTokens})
+	if err != nil {
+		logger.Warningf("failed listing tokens to reselect the inputs of transaction %s: %s", invalid.TxID, err)
+		return request, false
+	}
+	unspent := map[string]*token.TokenOutput{}
+	for _, t := range listed.Tokens {
+		unspent[string(t.Id)] = t
+	}
+	hot := map[string]bool{}
+	for _, hotspot := range invalid.Hotspots {
+		hot[string(hotspot.TokenId)] = true
+	}
+
+	tokenType := ""
+	var inputs [][]byte
+	var quantity uint64
+	original := map[string]bool{}
+	for _, id := range request.TokenIDs {
+		original[string(id)] = true
+		t, ok := unspent[string(id)]
+		if !ok {
+			continue
+		}
+		tokenType = t.Type
+		if !hot[string(id)] {
+			inputs = append(inputs, id)
+			quantity += t.Quantity
+		}
+	}
+	if len(inputs) == len(request.TokenIDs) {
+		return request, false
+	}
+	if tokenType == "" {
+		tokenType = transactionTokenType(response)
+		if tokenType == "" {
+			return request, false
+		}
+	}
+
+	var candidates []*token.TokenOutput
+	for _, t := range listed.Tokens {
+		if t.Type == tokenType && (!original[string(t.Id)] || hot[string(t.Id)]) {
+			candidates = append(candidates, t)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if hot[string(candidates[i].Id)] != hot[string(candidates[j].Id)] {
+			return !hot[string(candidates[i].Id)]
+		}
+		return candidates[i].Quantity > candidates[j].Quantity
+	})
+	for _, t := range candidates {
+		if quantity >= needed {
+			break
+		}
+		inputs = append(inputs, t.Id)
+		quantity += t.Quantity
+	}
+	if quantity < needed {
+		logger.Debugf("not enough unspent tokens of type %s to reselect the inputs of transaction %s", tokenType, invalid.TxID)
+		return request, false
+	}
+
+	if sameTokens(inputs, request.TokenIDs) {
+		return request, false
+	}
+
+	reselected := *request
+	reselected.TokenIDs = inputs
+	if request.Method == MethodTransfer && quantity > needed {
+		if len(r.Owner) == 0 {
+			return request, false
+		}
+		reselected.Shares = append(append([]*token.RecipientTransferShare{}, request.Shares...), &token.RecipientTransferShare{
+			Recipient: r.Owner,
+			Quantity:  quantity - needed,
+		})
+	}
+	return &reselected, true
+}
+
+func sameTokens(a, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	ids := map[string]bool{}
+	for _, id := range b {
+		ids[string(id)] = true
+	}
+	for _, id := range a {
+		if !ids[string(id)] {
+			return false
+		}
+	}
+	return true
+}
+
+// transactionTokenType returns the type of the tokens of the plain transfer
+// or redemption in the envelope of the response, or the empty string if it
+// cannot be read.
+func transactionTokenType(response *Response) string {
+	if response == nil || len(response.Transaction) == 0 {
+		return ""
+	}
+	envelope := &common.Envelope{}
+	if err := proto.Unmarshal(response.Transaction, envelope); err != nil {
+		return ""
+	}
+	payload := &common.Payload{}
+	if err := proto.Unmarshal(envelope.Payload, payload); err != nil {
+		return ""
+	}
+	tokenTx := &token.TokenTransaction{}
+	if err := proto.Unmarshal(payload.Data, tokenTx); err != nil {
+		return ""
+	}
+	action := tokenTx.GetPlainAction()
+	for _, transfer := range []*token.PlainTransfer{action.GetPlainTransfer(), action.GetPlainRedeem()} {
+		for _, output := range transfer.GetOutputs() {
+			if output.Type != "" {
+				return output.Type
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,213 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("ConflictRetry", func() {
+	var (
+		retry    *client.ConflictRetry
+		requests []client.Request
+		errs     []error
+		tokens   []*token.TokenOutput
+		invoker  client.Invoker
+	)
+
+	BeforeEach(func() {
+		retry = &client.ConflictRetry{BaseDelay: time.Millisecond, MaxDelay: time.Millisecond, Owner: []byte("alice")}
+		requests = nil
+		errs = nil
+		tokens = []*token.TokenOutput{
+			{Id: []byte("id1"), Type: "USD", Quantity: 10},
+			{Id: []byte("id2"), Type: "USD", Quantity: 20},
+			{Id: []byte("id3"), Type: "USD", Quantity: 30},
+			{Id: []byte("id4"), Type: "EUR", Quantity: 100},
+		}
+		invoker = func(ctx context.Context, request *client.Request) (*client.Response, error) {
+			if request.Method == client.MethodListTokens {
+				return &client.Response{Tokens: tokens}, nil
+			}
+			requests = append(requests, *request)
+			var err error
+			if len(errs) > 0 {
+				err, errs = errs[0], errs[1:]
+			}
+			return &client.Response{Transaction: []byte("tx")}, err
+		}
+	})
+
+	It("does not retry the transactions that are not invalidated by conflicts", func() {
+		errs = []error{errors.New("unavailable")}
+
+		_, err := retry.Intercept(context.Background(), &client.Request{Method: client.MethodIssue}, invoker)
+		Expect(err).To(MatchError("unavailable"))
+		Expect(requests).To(HaveLen(1))
+
+		requests = nil
+		errs = []error{&client.InvalidTxError{TxID: "tx1", ValidationCode: "ENDORSEMENT_POLICY_FAILURE"}}
+		_, err = retry.Intercept(context.Background(), &client.Request{Method: client.MethodIssue}, invoker)
+		Expect(err).To(MatchError("transaction [tx1] status is not valid: ENDORSEMENT_POLICY_FAILURE"))
+		Expect(requests).To(HaveLen(1))
+	})
+
+	It("retries the transactions invalidated by read conflicts up to MaxAttempts", func() {
+		errs = []error{
+			&client.InvalidTxError{TxID: "tx1", ValidationCode: "MVCC_READ_CONFLICT"},
+			errors.Wrap(&client.InvalidTxError{TxID: "tx2", ValidationCode: "PHANTOM_READ_CONFLICT"}, "failed"),
+			&client.InvalidTxError{TxID: "tx3", ValidationCode: "MVCC_READ_CONFLICT"},
+		}
+
+		_, err := retry.Intercept(context.Background(), &client.Request{Method: client.MethodIssue}, invoker)
+		Expect(err).To(MatchError("transaction [tx3] status is not valid: MVCC_READ_CONFLICT"))
+		Expect(requests).To(HaveLen(3))
+
+		requests = nil
+		errs = []error{&client.InvalidTxError{TxID: "tx4", ValidationCode: "MVCC_READ_CONFLICT"}}
+		_, err = retry.Intercept(context.Background(), &client.Request{Method: client.MethodIssue}, invoker)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requests).To(HaveLen(2))
+	})
+
+	It("replaces the spent inputs of a transfer and returns the change", func() {
+		errs = []error{&client.InvalidTxError{TxID: "tx1", ValidationCode: "INVALID_OTHER_REASON"}}
+		shares := []*token.RecipientTransferShare{{Recipient: []byte("bob"), Quantity: 25}}
+
+		_, err := retry.Intercept(context.Background(), &client.Request{
+			Method:   client.MethodTransfer,
+			TokenIDs: [][]byte{[]byte("id1"), []byte("spent")},
+			Shares:   shares,
+		}, invoker)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requests).To(HaveLen(2))
+		Expect(requests[1].TokenIDs).To(Equal([][]byte{[]byte("id1"), []byte("id3")}))
+		Expect(requests[1].Shares).To(Equal([]*token.RecipientTransferShare{
+			{Recipient: []byte("bob"), Quantity: 25},
+			{Recipient: []byte("alice"), Quantity: 15},
+		}))
+		Expect(shares).To(HaveLen(1))
+	})
+
+	It("avoids the inputs reported as conflict hotspots", func() {
+		errs = []error{&client.InvalidTxError{
+			TxID:           "tx1",
+			ValidationCode: "MVCC_READ_CONFLICT",
+			Hotspots:       []*token.InputHotspot{{TokenId: []byte("id3"), Conflicts: 3}},
+		}}
+
+		_, err := retry.Intercept(context.Background(), &client.Request{
+			Method:   client.MethodRedeem,
+			TokenIDs: [][]byte{[]byte("id3")},
+			Quantity: 25,
+		}, invoker)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requests).To(HaveLen(2))
+		Expect(requests[1].TokenIDs).To(Equal([][]byte{[]byte("id2"), []byte("id1")}))
+		Expect(requests[1].Quantity).To(Equal(uint64(25)))
+	})
+
+	It("selects hot inputs when the other tokens do not suffice", func() {
+		errs = []error{&client.InvalidTxError{
+			TxID:           "tx1",
+			ValidationCode: "MVCC_READ_CONFLICT",
+			Hotspots:       []*token.InputHotspot{{TokenId: []byte("id3")}},
+		}}
+
+		_, err := retry.Intercept(context.Background(), &client.Request{
+			Method:   client.MethodRedeem,
+			TokenIDs: [][]byte{[]byte("id3")},
+			Quantity: 50,
+		}, invoker)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requests).To(HaveLen(2))
+		Expect(requests[1].TokenIDs).To(Equal([][]byte{[]byte("id2"), []byte("id1"), []byte("id3")}))
+	})
+
+	It("reads the type of the tokens from the transaction when all the inputs are spent", func() {
+		tokenTx := &token.TokenTransaction{Action: &token.TokenTransaction_PlainAction{PlainAction: &token.PlainTokenAction{
+			Data: &token.PlainTokenAction_PlainRedeem{PlainRedeem: &token.PlainTransfer{
+				Outputs: []*token.PlainOutput{{Type: "EUR", Quantity: 40}},
+			}},
+		}}}
+		tx := ProtoMarshal(&common.Envelope{Payload: ProtoMarshal(&common.Payload{Data: ProtoMarshal(tokenTx)})})
+		first := true
+		failing := func(ctx context.Context, request *client.Request) (*client.Response, error) {
+			if request.Method == client.MethodRedeem && first {
+				first = false
+				requests = append(requests, *request)
+				return &client.Response{Transaction: tx}, &client.InvalidTxError{TxID: "tx1", ValidationCode: "INVALID_OTHER_REASON"}
+			}
+			return invoker(ctx, request)
+		}
+
+		_, err := retry.Intercept(context.Background(), &client.Request{
+			Method:   client.MethodRedeem,
+			TokenIDs: [][]byte{[]byte("spent")},
+			Quantity: 40,
+		}, failing)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requests).To(HaveLen(2))
+		Expect(requests[1].TokenIDs).To(Equal([][]byte{[]byte("id4")}))
+	})
+
+	It("does not resubmit spent inputs that cannot be replaced", func() {
+		errs = []error{&client.InvalidTxError{TxID: "tx1", ValidationCode: "INVALID_OTHER_REASON"}}
+
+		_, err := retry.Intercept(context.Background(), &client.Request{
+			Method:   client.MethodRedeem,
+			TokenIDs: [][]byte{[]byte("id1"), []byte("spent")},
+			Quantity: 1000,
+		}, invoker)
+		Expect(err).To(MatchError("transaction [tx1] status is not valid: INVALID_OTHER_REASON"))
+		Expect(requests).To(HaveLen(1))
+	})
+
+	It("does not reselect the inputs of a transfer with change when the owner is not set", func() {
+		retry.Owner = nil
+		errs = []error{&client.InvalidTxError{TxID: "tx1", ValidationCode: "INVALID_OTHER_REASON"}}
+
+		_, err := retry.Intercept(context.Background(), &client.Request{
+			Method:   client.MethodTransfer,
+			TokenIDs: [][]byte{[]byte("spent")},
+			Shares:   []*token.RecipientTransferShare{{Recipient: []byte("bob"), Quantity: 25}},
+		}, invoker)
+		Expect(err).To(HaveOccurred())
+		Expect(requests).To(HaveLen(1))
+	})
+
+	It("stops retrying when the context is done", func() {
+		retry.BaseDelay = time.Hour
+		retry.MaxDelay = time.Hour
+		errs = []error{&client.InvalidTxError{TxID: "tx1", ValidationCode: "MVCC_READ_CONFLICT"}}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := retry.Intercept(ctx, &client.Request{Method: client.MethodIssue}, invoker)
+		Expect(err).To(MatchError("transaction [tx1] status is not valid: MVCC_READ_CONFLICT"))
+		Expect(requests).To(HaveLen(1))
+	})
+
+	It("does not retry listing tokens", func() {
+		listing := func(ctx context.Context, request *client.Request) (*client.Response, error) {
+			requests = append(requests, *request)
+			return nil, &client.InvalidTxError{ValidationCode: "MVCC_READ_CONFLICT"}
+		}
+
+		_, err := retry.Intercept(context.Background(), &client.Request{Method: client.MethodListTokens}, listing)
+		Expect(err).To(HaveOccurred())
+		Expect(requests).To(HaveLen(1))
+	})
+})
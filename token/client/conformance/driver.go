@@ -0,0 +1,114 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package conformance checks the compatibility of prover client
+// implementations, whatever their language, with a live prover.
+//
+// The client under test is driven through a Driver, typically an ExecDriver
+// running an executable for each scenario of the suite. The executable reads a
+// JSON Request from its standard input, sends the commands of the scenario in
+// plaintext to the prover address of the request, as the identity it is
+// configured with, and writes a JSON Response to its standard output. The
+// address is the one of a proxy of the suite, which records the commands and
+// forwards them to the live prover; the suite checks the commands against the
+// requirements of the prover protocol, and the response against the ones of
+// the prover. The commands of the scenarios only assemble transactions; none
+// is submitted to the orderer.
+//
+// The scenarios are:
+//
+//   - capabilities: request the capabilities of the channel and respond them
+//   - list: list the unspent tokens of the client and respond them
+//   - issue: request the import of quantity tokens of the type to the client
+//     and respond the token transaction
+//   - transfer: request the transfer of the tokens with the token IDs, in a
+//     single share of quantity to the client, and respond the token transaction
+//   - redeem: request the redemption of quantity of the tokens with the token
+//     IDs and respond the token transaction
+//
+// When the prover responds an error, the client responds it in the error of
+// the Response.
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+// Request is the request of a scenario to the client under test.
+type Request struct {
+	// Scenario is the name of the scenario
+	Scenario string `json:"scenario"`
+	// ProverAddress is the host:port of the prover the commands are sent to
+	ProverAddress string `json:"prover_address"`
+	// ChannelID is the channel the commands are bound for
+	ChannelID string `json:"channel_id"`
+	// Type is the type of the tokens to issue
+	Type string `json:"type,omitempty"`
+	// Quantity is the quantity to issue, transfer or redeem
+	Quantity uint64 `json:"quantity,omitempty"`
+	// TokenIDs are the IDs of the tokens to transfer or redeem
+	TokenIDs [][]byte `json:"token_ids,omitempty"`
+}
+
+// Response is the response of the client under test to a Request.
+type Response struct {
+	// Capabilities are the capabilities of the channel
+	Capabilities *token.ChannelCapabilities `json:"capabilities,omitempty"`
+	// Tokens are the unspent tokens of the client
+	Tokens []*token.TokenOutput `json:"tokens,omitempty"`
+	// Transaction is the serialized token transaction assembled by the prover
+	Transaction []byte `json:"transaction,omitempty"`
+	// Error is the error responded by the prover
+	Error string `json:"error,omitempty"`
+}
+
+// A Driver drives the client under test through the scenarios.
+type Driver interface {
+	// Run has the client under test process the request of a scenario.
+	Run(ctx context.Context, request *Request) (*Response, error)
+}
+
+// ExecDriver is a Driver running an executable for each request.
+type ExecDriver struct {
+	// Path is the path of the executable
+	Path string
+	// Args are the arguments passed to the executable
+	Args []string
+	// Env is added to the environment of the executable, e.g. to locate the
+	// identity of the client
+	Env []string
+}
+
+func (d *ExecDriver) Run(ctx context.Context, request *Request) (*Response, error) {
+	input, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling request")
+	}
+
+	cmd := exec.CommandContext(ctx, d.Path, d.Args...)
+	cmd.Env = append(os.Environ(), d.Env...)
+	cmd.Stdin = bytes.NewReader(input)
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "client failed scenario %s: %s", request.Scenario, stderr.String())
+	}
+
+	response := &Response{}
+	if err := json.Unmarshal(stdout.Bytes(), response); err != nil {
+		return nil, errors.Wrapf(err, "client responded invalid JSON to scenario %s", request.Scenario)
+	}
+	return response, nil
+}
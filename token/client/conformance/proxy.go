@@ -0,0 +1,49 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package conformance
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hyperledger/fabric/protos/token"
+)
+
+// exchange is a command sent by the client under test and the response of
+// the prover to it.
+type exchange struct {
+	command  *token.SignedCommand
+	response *token.SignedCommandResponse
+	err      error
+}
+
+// proxy is a prover server forwarding the commands to a prover and recording
+// the exchanges.
+type proxy struct {
+	prover    token.ProverClient
+	mutex     sync.Mutex
+	exchanges []*exchange
+}
+
+func (p *proxy) ProcessCommand(ctx context.Context, sc *token.SignedCommand) (*token.SignedCommandResponse, error) {
+	response, err := p.prover.ProcessCommand(ctx, sc)
+
+	p.mutex.Lock()
+	p.exchanges = append(p.exchanges, &exchange{command: sc, response: response, err: err})
+	p.mutex.Unlock()
+
+	return response, err
+}
+
+// take returns the exchanges recorded since the last call.
+func (p *proxy) take() []*exchange {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	exchanges := p.exchanges
+	p.exchanges = nil
+	return exchanges
+}
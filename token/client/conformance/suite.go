@@ -0,0 +1,321 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/pkg/errors"
+)
+
+// The scenarios of the suite, in the order they are run.
+const (
+	ScenarioCapabilities = "capabilities"
+	ScenarioList         = "list"
+	ScenarioIssue        = "issue"
+	ScenarioTransfer     = "transfer"
+	ScenarioRedeem       = "redeem"
+)
+
+// Scenarios are the scenarios of the suite, in the order they are run. The
+// transfer and redeem scenarios spend the first token listed by the list
+// scenario.
+var Scenarios = []string{ScenarioCapabilities, ScenarioList, ScenarioIssue, ScenarioTransfer, ScenarioRedeem}
+
+// MinNonceSize is the minimum size of the nonces of the command headers.
+const MinNonceSize = 24
+
+// ErrNoTokens is returned for the scenarios spending a token when the client
+// owns none.
+var ErrNoTokens = errors.New("the client owns no tokens to spend")
+
+// Suite is the conformance suite of prover clients. It runs the scenarios
+// against the client under test, in order, and checks the commands sent by
+// the client and the responses of the client.
+type Suite struct {
+	// ChannelID is the channel the commands are bound for
+	ChannelID string
+	// Prover is the client of the live prover
+	Prover token.ProverClient
+	// Driver drives the client under test
+	Driver Driver
+	// TokenType is the type of the tokens issued by the issue scenario;
+	// CONFORMANCE by default
+	TokenType string
+	// MaxClockSkew is the maximum difference between the timestamps of the
+	// command headers and the time they are received at; 5 minutes by default
+	MaxClockSkew time.Duration
+	// Timeout is the time the client has to process a scenario; 30 seconds
+	// by default
+	Timeout time.Duration
+
+	proxy   *proxy
+	server  *comm.GRPCServer
+	creator []byte
+	nonces  map[string]bool
+	tokens  []*token.TokenOutput
+}
+
+// Start starts the proxy the client under test sends its commands to.
+func (s *Suite) Start() error {
+	server, err := comm.NewGRPCServer("127.0.0.1:0", comm.ServerConfig{})
+	if err != nil {
+		return errors.WithMessage(err, "failed creating the prover proxy")
+	}
+	s.proxy = &proxy{prover: s.Prover}
+	s.server = server
+	s.nonces = map[string]bool{}
+	token.RegisterProverServer(server.Server(), s.proxy)
+	go server.Start()
+	return nil
+}
+
+// Stop stops the proxy.
+func (s *Suite) Stop() {
+	s.server.Stop()
+}
+
+// Run starts the suite and runs the scenarios as subtests of t. The scenarios
+// spending a token are skipped when the client owns none.
+func (s *Suite) Run(t *testing.T) {
+	if err := s.Start(); err != nil {
+		t.Fatalf("failed starting the conformance suite: %s", err)
+	}
+	defer s.Stop()
+
+	for _, scenario := range Scenarios {
+		t.Run(scenario, func(t *testing.T) {
+			err := s.RunScenario(scenario)
+			if err == ErrNoTokens {
+				t.Skip(err)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// RunScenario runs the scenario against the client under test and returns an
+// error if the client does not conform to it.
+func (s *Suite) RunScenario(scenario string) error {
+	request, err := s.request(scenario)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout())
+	defer cancel()
+	output, err := s.Driver.Run(ctx, request)
+	exchanges := s.proxy.take()
+	if err != nil {
+		return err
+	}
+	if len(exchanges) != 1 {
+		return errors.Errorf("client sent %d commands, expected 1", len(exchanges))
+	}
+	ex := exchanges[0]
+	if ex.err != nil {
+		return errors.WithMessage(ex.err, "prover failed processing the command")
+	}
+
+	command, err := s.checkCommand(ex.command)
+	if err != nil {
+		return errors.WithMessage(err, "invalid command")
+	}
+	if err := s.checkPayload(request, command); err != nil {
+		return errors.WithMessage(err, "invalid command payload")
+	}
+
+	response := &token.CommandResponse{}
+	if err := proto.Unmarshal(ex.response.Response, response); err != nil {
+		return errors.Wrap(err, "prover responded an invalid command response")
+	}
+	if response.GetErr() != nil {
+		if scenario == ScenarioCapabilities || scenario == ScenarioList {
+			return errors.Errorf("prover rejected the command: %s", response.GetErr().Message)
+		}
+		// the client may not be authorized; it conforms if it reports the error
+		if output.Error == "" || !strings.Contains(output.Error, response.GetErr().Message) {
+			return errors.Errorf("client responded error '%s', expected '%s'", output.Error, response.GetErr().Message)
+		}
+		return nil
+	}
+	if output.Error != "" {
+		return errors.Errorf("client responded error '%s', but the prover succeeded", output.Error)
+	}
+	return errors.WithMessage(s.checkOutput(scenario, response, output), "invalid client response")
+}
+
+func (s *Suite) request(scenario string) (*Request, error) {
+	request := &Request{
+		Scenario:      scenario,
+		ProverAddress: s.server.Address(),
+		ChannelID:     s.ChannelID,
+	}
+	switch scenario {
+	case ScenarioCapabilities, ScenarioList:
+	case ScenarioIssue:
+		request.Type = s.TokenType
+		if request.Type == "" {
+			request.Type = "CONFORMANCE"
+		}
+		request.Quantity = 100
+	case ScenarioTransfer, ScenarioRedeem:
+		if len(s.tokens) == 0 {
+			return nil, ErrNoTokens
+		}
+		request.TokenIDs = [][]byte{s.tokens[0].Id}
+		request.Quantity = s.tokens[0].Quantity
+		if scenario == ScenarioRedeem {
+			request.Quantity = 1
+		}
+	default:
+		return nil, errors.Errorf("unknown scenario %s", scenario)
+	}
+	return request, nil
+}
+
+// checkCommand checks the signed command against the requirements of the
+// prover protocol, and that the client uses the same identity and fresh
+// nonces throughout the suite.
+func (s *Suite) checkCommand(sc *token.SignedCommand) (*token.Command, error) {
+	if len(sc.Signature) == 0 {
+		return nil, errors.New("missing signature")
+	}
+	command := &token.Command{}
+	if err := proto.Unmarshal(sc.Command, command); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling command")
+	}
+
+	header := command.Header
+	if header == nil {
+		return nil, errors.New("missing header")
+	}
+	if header.ChannelId != s.ChannelID {
+		return nil, errors.Errorf("channel ID is %s, expected %s", header.ChannelId, s.ChannelID)
+	}
+	if len(header.Nonce) < MinNonceSize {
+		return nil, errors.Errorf("nonce is %d bytes long, expected at least %d", len(header.Nonce), MinNonceSize)
+	}
+	if s.nonces[string(header.Nonce)] {
+		return nil, errors.New("nonce is reused")
+	}
+	s.nonces[string(header.Nonce)] = true
+
+	if header.Timestamp == nil {
+		return nil, errors.New("missing timestamp")
+	}
+	timestamp, err := ptypes.Timestamp(header.Timestamp)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid timestamp")
+	}
+	if skew := time.Since(timestamp); skew > s.maxClockSkew() || skew < -s.maxClockSkew() {
+		return nil, errors.Errorf("timestamp %s is off by %s", timestamp, skew)
+	}
+
+	creator := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(header.Creator, creator); err != nil {
+		return nil, errors.Wrap(err, "creator is not a serialized identity")
+	}
+	if creator.Mspid == "" || len(creator.IdBytes) == 0 {
+		return nil, errors.New("creator is not a serialized identity")
+	}
+	if s.creator == nil {
+		s.creator = header.Creator
+	}
+	if !bytes.Equal(header.Creator, s.creator) {
+		return nil, errors.New("creator differs from the one of the previous commands")
+	}
+
+	if err := tk.ValidateHeaderExtensions(header.Extensions, tk.DefaultHeaderExtensionValidators); err != nil {
+		return nil, err
+	}
+	return command, nil
+}
+
+// checkPayload checks that the payload of the command is the one of the request.
+func (s *Suite) checkPayload(request *Request, command *token.Command) error {
+	var expected *token.Command
+	switch request.Scenario {
+	case ScenarioCapabilities:
+		expected = &token.Command{Payload: &token.Command_CapabilitiesRequest{CapabilitiesRequest: &token.CapabilitiesRequest{}}}
+	case ScenarioList:
+		expected = &token.Command{Payload: &token.Command_ListRequest{ListRequest: &token.ListRequest{}}}
+	case ScenarioIssue:
+		expected = &token.Command{Payload: &token.Command_ImportRequest{ImportRequest: &token.ImportRequest{
+			TokensToIssue: []*token.TokenToIssue{{Recipient: s.creator, Type: request.Type, Quantity: request.Quantity}},
+		}}}
+	case ScenarioTransfer:
+		expected = &token.Command{Payload: &token.Command_TransferRequest{TransferRequest: &token.TransferRequest{
+			TokenIds: request.TokenIDs,
+			Shares:   []*token.RecipientTransferShare{{Recipient: s.creator, Quantity: request.Quantity}},
+		}}}
+	case ScenarioRedeem:
+		expected = &token.Command{Payload: &token.Command_RedeemRequest{RedeemRequest: &token.RedeemRequest{
+			TokenIds:         request.TokenIDs,
+			QuantityToRedeem: request.Quantity,
+		}}}
+	}
+
+	// the header is checked on its own
+	actual := &token.Command{Payload: command.Payload}
+	if !proto.Equal(actual, expected) {
+		return errors.Errorf("payload is %s, expected %s", proto.CompactTextString(actual), proto.CompactTextString(expected))
+	}
+	return nil
+}
+
+// checkOutput checks that the output of the client is the successful response
+// of the prover.
+func (s *Suite) checkOutput(scenario string, response *token.CommandResponse, output *Response) error {
+	switch scenario {
+	case ScenarioCapabilities:
+		if !proto.Equal(output.Capabilities, response.GetChannelCapabilities()) {
+			return errors.Errorf("capabilities are %v, expected %v", output.Capabilities, response.GetChannelCapabilities())
+		}
+	case ScenarioList:
+		tokens := response.GetUnspentTokens().GetTokens()
+		if !proto.Equal(&token.UnspentTokens{Tokens: output.Tokens}, &token.UnspentTokens{Tokens: tokens}) {
+			return errors.Errorf("tokens are %v, expected %v", output.Tokens, tokens)
+		}
+		s.tokens = tokens
+	default:
+		tx := &token.TokenTransaction{}
+		if err := proto.Unmarshal(output.Transaction, tx); err != nil {
+			return errors.Wrap(err, "failed unmarshaling token transaction")
+		}
+		if !proto.Equal(tx, response.GetTokenTransaction()) {
+			return errors.New("token transaction differs from the one of the prover")
+		}
+	}
+	return nil
+}
+
+func (s *Suite) maxClockSkew() time.Duration {
+	if s.MaxClockSkew == 0 {
+		return 5 * time.Minute
+	}
+	return s.MaxClockSkew
+}
+
+func (s *Suite) timeout() time.Duration {
+	if s.Timeout == 0 {
+		return 30 * time.Second
+	}
+	return s.Timeout
+}
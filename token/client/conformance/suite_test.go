@@ -0,0 +1,356 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package conformance_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/hyperledger/fabric/token/client/conformance"
+	"github.com/hyperledger/fabric/token/client/mock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// goDriver drives the Go prover client in process, as a reference.
+type goDriver struct {
+	identity tk.SigningIdentity
+	// configure, if set, configures the prover peer of each request
+	configure func(*client.ProverPeer)
+	// tamper, if set, modifies the commands after they are signed
+	tamper func(*token.Command)
+	// dropErrors drops the errors responded by the prover
+	dropErrors bool
+}
+
+func (d *goDriver) Run(ctx context.Context, request *conformance.Request) (*conformance.Response, error) {
+	conn, err := grpc.DialContext(ctx, request.ProverAddress, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	prover := &client.ProverPeer{
+		ChannelID:        request.ChannelID,
+		ProverClient:     token.NewProverClient(conn),
+		RandomnessReader: rand.Reader,
+		Time:             time.Now,
+	}
+	if d.configure != nil {
+		d.configure(prover)
+	}
+
+	owner, err := d.identity.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	var payload interface{}
+	switch request.Scenario {
+	case conformance.ScenarioCapabilities:
+		payload = &token.Command_CapabilitiesRequest{CapabilitiesRequest: &token.CapabilitiesRequest{}}
+	case conformance.ScenarioList:
+		payload = &token.Command_ListRequest{ListRequest: &token.ListRequest{}}
+	case conformance.ScenarioIssue:
+		payload = &token.Command_ImportRequest{ImportRequest: &token.ImportRequest{
+			TokensToIssue: []*token.TokenToIssue{{Recipient: owner, Type: request.Type, Quantity: request.Quantity}},
+		}}
+	case conformance.ScenarioTransfer:
+		payload = &token.Command_TransferRequest{TransferRequest: &token.TransferRequest{
+			TokenIds: request.TokenIDs,
+			Shares:   []*token.RecipientTransferShare{{Recipient: owner, Quantity: request.Quantity}},
+		}}
+	case conformance.ScenarioRedeem:
+		payload = &token.Command_RedeemRequest{RedeemRequest: &token.RedeemRequest{
+			TokenIds:         request.TokenIDs,
+			QuantityToRedeem: request.Quantity,
+		}}
+	}
+	sc, err := prover.CreateSignedCommand(payload, d.identity)
+	if err != nil {
+		return nil, err
+	}
+	if d.tamper != nil {
+		command := &token.Command{}
+		if err := proto.Unmarshal(sc.Command, command); err != nil {
+			return nil, err
+		}
+		d.tamper(command)
+		if sc.Command, err = proto.Marshal(command); err != nil {
+			return nil, err
+		}
+	}
+
+	scr, err := prover.ProverClient.ProcessCommand(ctx, sc)
+	if err != nil {
+		return nil, err
+	}
+	response := &token.CommandResponse{}
+	if err := proto.Unmarshal(scr.Response, response); err != nil {
+		return nil, err
+	}
+	if response.GetErr() != nil {
+		if d.dropErrors {
+			return &conformance.Response{}, nil
+		}
+		return &conformance.Response{Error: "prover failed: " + response.GetErr().Message}, nil
+	}
+
+	output := &conformance.Response{
+		Capabilities: response.GetChannelCapabilities(),
+		Tokens:       response.GetUnspentTokens().GetTokens(),
+	}
+	if tx := response.GetTokenTransaction(); tx != nil {
+		if output.Transaction, err = proto.Marshal(tx); err != nil {
+			return nil, err
+		}
+	}
+	return output, nil
+}
+
+// fakeProver responds the commands like a prover would.
+type fakeProver struct {
+	// issueErr, if set, is the error responded to import requests
+	issueErr string
+}
+
+func (p *fakeProver) ProcessCommand(ctx context.Context, sc *token.SignedCommand, opts ...grpc.CallOption) (*token.SignedCommandResponse, error) {
+	command := &token.Command{}
+	if err := proto.Unmarshal(sc.Command, command); err != nil {
+		return nil, err
+	}
+	response := &token.CommandResponse{}
+	switch payload := command.Payload.(type) {
+	case *token.Command_CapabilitiesRequest:
+		response.Payload = &token.CommandResponse_ChannelCapabilities{ChannelCapabilities: &token.ChannelCapabilities{FabToken: true, HashingSuite: "SHA256"}}
+	case *token.Command_ListRequest:
+		response.Payload = &token.CommandResponse_UnspentTokens{UnspentTokens: &token.UnspentTokens{
+			Tokens: []*token.TokenOutput{{Id: []byte("token-1"), Type: "USD", Quantity: 10}},
+		}}
+	case *token.Command_ImportRequest:
+		if p.issueErr != "" {
+			response.Payload = &token.CommandResponse_Err{Err: &token.Error{Message: p.issueErr}}
+			break
+		}
+		response.Payload = importTransaction(payload.ImportRequest.TokensToIssue[0])
+	default:
+		response.Payload = importTransaction(&token.TokenToIssue{Recipient: command.Header.Creator, Type: "USD", Quantity: 10})
+	}
+	raw, err := proto.Marshal(response)
+	if err != nil {
+		return nil, err
+	}
+	return &token.SignedCommandResponse{Response: raw, Signature: []byte("signature")}, nil
+}
+
+func importTransaction(tti *token.TokenToIssue) *token.CommandResponse_TokenTransaction {
+	return &token.CommandResponse_TokenTransaction{TokenTransaction: &token.TokenTransaction{
+		Action: &token.TokenTransaction_PlainAction{PlainAction: &token.PlainTokenAction{
+			Data: &token.PlainTokenAction_PlainImport{PlainImport: &token.PlainImport{
+				Outputs: []*token.PlainOutput{{Owner: tti.Recipient, Type: tti.Type, Quantity: tti.Quantity}},
+			}},
+		}},
+	}}
+}
+
+func newIdentity(t *testing.T) *mock.SigningIdentity {
+	creator, err := proto.Marshal(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("alice")})
+	require.NoError(t, err)
+	identity := &mock.SigningIdentity{}
+	identity.SerializeReturns(creator, nil)
+	identity.GetPublicVersionReturns(identity)
+	identity.SignReturns([]byte("signature"), nil)
+	return identity
+}
+
+func TestSuite(t *testing.T) {
+	suite := &conformance.Suite{
+		ChannelID: "testchannel",
+		Prover:    &fakeProver{},
+		Driver:    &goDriver{identity: newIdentity(t)},
+	}
+	suite.Run(t)
+}
+
+func TestSuiteNonConforming(t *testing.T) {
+	tests := []struct {
+		name     string
+		driver   func(identity tk.SigningIdentity) *goDriver
+		prover   *fakeProver
+		scenario string
+		err      string
+	}{
+		{
+			name: "wrong channel",
+			driver: func(identity tk.SigningIdentity) *goDriver {
+				return &goDriver{identity: identity, configure: func(p *client.ProverPeer) { p.ChannelID = "otherchannel" }}
+			},
+			scenario: conformance.ScenarioList,
+			err:      "invalid command: channel ID is otherchannel, expected testchannel",
+		},
+		{
+			name: "short nonce",
+			driver: func(identity tk.SigningIdentity) *goDriver {
+				return &goDriver{identity: identity, tamper: func(c *token.Command) { c.Header.Nonce = c.Header.Nonce[:8] }}
+			},
+			scenario: conformance.ScenarioList,
+			err:      "invalid command: nonce is 8 bytes long, expected at least 24",
+		},
+		{
+			name: "stale timestamp",
+			driver: func(identity tk.SigningIdentity) *goDriver {
+				return &goDriver{identity: identity, configure: func(p *client.ProverPeer) {
+					p.Time = func() time.Time { return time.Now().Add(-time.Hour) }
+				}}
+			},
+			scenario: conformance.ScenarioList,
+			err:      "invalid command: timestamp",
+		},
+		{
+			name: "wrong payload",
+			driver: func(identity tk.SigningIdentity) *goDriver {
+				return &goDriver{identity: identity, tamper: func(c *token.Command) {
+					c.GetImportRequest().TokensToIssue[0].Quantity = 1
+				}}
+			},
+			scenario: conformance.ScenarioIssue,
+			err:      "invalid command payload: payload is",
+		},
+		{
+			name: "dropped error",
+			driver: func(identity tk.SigningIdentity) *goDriver {
+				return &goDriver{identity: identity, dropErrors: true}
+			},
+			prover:   &fakeProver{issueErr: "creator is not an issuer"},
+			scenario: conformance.ScenarioIssue,
+			err:      "client responded error '', expected 'creator is not an issuer'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prover := tt.prover
+			if prover == nil {
+				prover = &fakeProver{}
+			}
+			suite := &conformance.Suite{
+				ChannelID: "testchannel",
+				Prover:    prover,
+				Driver:    tt.driver(newIdentity(t)),
+			}
+			require.NoError(t, suite.Start())
+			defer suite.Stop()
+
+			err := suite.RunScenario(tt.scenario)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestSuiteReportedError(t *testing.T) {
+	suite := &conformance.Suite{
+		ChannelID: "testchannel",
+		Prover:    &fakeProver{issueErr: "creator is not an issuer"},
+		Driver:    &goDriver{identity: newIdentity(t)},
+	}
+	require.NoError(t, suite.Start())
+	defer suite.Stop()
+
+	assert.NoError(t, suite.RunScenario(conformance.ScenarioIssue))
+}
+
+func TestSuiteReusedNonce(t *testing.T) {
+	suite := &conformance.Suite{
+		ChannelID: "testchannel",
+		Prover:    &fakeProver{},
+		Driver: &goDriver{identity: newIdentity(t), configure: func(p *client.ProverPeer) {
+			p.RandomnessReader = bytes.NewReader(make([]byte, 32))
+		}},
+	}
+	require.NoError(t, suite.Start())
+	defer suite.Stop()
+
+	require.NoError(t, suite.RunScenario(conformance.ScenarioCapabilities))
+	assert.EqualError(t, suite.RunScenario(conformance.ScenarioList), "invalid command: nonce is reused")
+}
+
+func TestSuiteNoTokens(t *testing.T) {
+	suite := &conformance.Suite{
+		ChannelID: "testchannel",
+		Prover:    &fakeProver{},
+		Driver:    &goDriver{identity: newIdentity(t)},
+	}
+	require.NoError(t, suite.Start())
+	defer suite.Stop()
+
+	assert.Equal(t, conformance.ErrNoTokens, suite.RunScenario(conformance.ScenarioTransfer))
+}
+
+func TestExecDriver(t *testing.T) {
+	driver := &conformance.ExecDriver{
+		Path: "sh",
+		Args: []string{"-c", `grep -q '"scenario":"list"' && echo '{"tokens":[{"id":"dG9rZW4tMQ==","type":"USD","quantity":10}]}'`},
+	}
+	response, err := driver.Run(context.Background(), &conformance.Request{Scenario: conformance.ScenarioList})
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(&token.UnspentTokens{Tokens: response.Tokens}, &token.UnspentTokens{
+		Tokens: []*token.TokenOutput{{Id: []byte("token-1"), Type: "USD", Quantity: 10}},
+	}))
+
+	driver.Args = []string{"-c", "echo unsupported scenario >&2; exit 1"}
+	_, err = driver.Run(context.Background(), &conformance.Request{Scenario: conformance.ScenarioList})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "client failed scenario list: unsupported scenario")
+
+	driver.Args = []string{"-c", "echo not json"}
+	_, err = driver.Run(context.Background(), &conformance.Request{Scenario: conformance.ScenarioList})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "client responded invalid JSON to scenario list")
+}
+
+// TestLiveProver runs the suite against the client executable of
+// CONFORMANCE_CLIENT, a command line, and the live prover at
+// CONFORMANCE_PROVER_ADDRESS serving the channel of CONFORMANCE_CHANNEL. The
+// prover is reached with TLS when CONFORMANCE_PROVER_TLS_ROOT_CERT is the path
+// of the root certificate of its TLS CA.
+func TestLiveProver(t *testing.T) {
+	command := strings.Fields(os.Getenv("CONFORMANCE_CLIENT"))
+	address := os.Getenv("CONFORMANCE_PROVER_ADDRESS")
+	if len(command) == 0 || address == "" {
+		t.Skip("CONFORMANCE_CLIENT and CONFORMANCE_PROVER_ADDRESS are not set")
+	}
+
+	config := &client.ClientConfig{
+		ChannelId: os.Getenv("CONFORMANCE_CHANNEL"),
+		ProverPeerCfg: client.ConnectionConfig{
+			Address:         address,
+			TlsRootCertFile: os.Getenv("CONFORMANCE_PROVER_TLS_ROOT_CERT"),
+		},
+	}
+	config.TlsEnabled = config.ProverPeerCfg.TlsRootCertFile != ""
+	prover, err := client.NewProverPeer(config)
+	if err != nil {
+		t.Fatal(errors.WithMessage(err, "failed connecting to the prover"))
+	}
+
+	suite := &conformance.Suite{
+		ChannelID: config.ChannelId,
+		Prover:    prover.ProverClient,
+		Driver:    &conformance.ExecDriver{Path: command[0], Args: command[1:]},
+	}
+	suite.Run(t)
+}
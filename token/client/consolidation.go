@@ -0,0 +1,168 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"sort"
+	"time"
+
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/pkg/errors"
+)
+
+//go:generate counterfeiter -o mock/token_lister.go -fake-name TokenLister . TokenLister
+
+// TokenLister lists the unspent tokens owned by a signing identity.
+type TokenLister interface {
+	ListTokens(signingIdentity tk.SigningIdentity) ([]*token.TokenOutput, error)
+}
+
+// ConsolidationConfig holds the thresholds that decide when unspent tokens are
+// consolidated.
+type ConsolidationConfig struct {
+	// Interval is the time between two consolidation rounds.
+	Interval time.Duration
+	// DustQuantity is the largest quantity of a token that is considered dust;
+	// when 0 every token is a candidate for consolidation.
+	DustQuantity uint64
+	// MinOutputs is the number of dust tokens of a given type from which they
+	// are consolidated.
+	MinOutputs int
+	// MaxInputs is the largest number of tokens spent by a single
+	// consolidation transfer; when 0 there is no limit.
+	MaxInputs int
+}
+
+func (c ConsolidationConfig) validate() error {
+	if c.MinOutputs < 2 {
+		return errors.Errorf("MinOutputs must be at least 2, got %d", c.MinOutputs)
+	}
+	if c.MaxInputs != 0 && c.MaxInputs < 2 {
+		return errors.Errorf("MaxInputs must be 0 or at least 2, got %d", c.MaxInputs)
+	}
+	return nil
+}
+
+// Consolidator merges the small unspent tokens of the client's signing
+// identity into fewer tokens by transferring them to itself.
+type Consolidator struct {
+	Client *Client
+	Lister TokenLister
+	Config ConsolidationConfig
+}
+
+// Consolidate runs a single consolidation round. Tokens of the same type whose
+// quantity does not exceed DustQuantity are merged, smallest first, once there
+// are at least MinOutputs of them. It returns the number of transfers submitted.
+func (c *Consolidator) Consolidate() (int, error) {
+	if err := c.Config.validate(); err != nil {
+		return 0, err
+	}
+
+	owner, err := c.Client.SigningIdentity.GetPublicVersion().Serialize()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed serializing signing identity")
+	}
+
+	tokens, err := c.Lister.ListTokens(c.Client.SigningIdentity)
+	if err != nil {
+		return 0, errors.WithMessage(err, "failed listing tokens")
+	}
+
+	submitted := 0
+	for _, batch := range c.batches(tokens) {
+		var tokenIDs [][]byte
+		quantity := uint64(0)
+		for _, t := range batch {
+			tokenIDs = append(tokenIDs, t.Id)
+			quantity += t.Quantity
+		}
+
+		shares := []*token.RecipientTransferShare{{Recipient: owner, Quantity: quantity}}
+		_, err := c.Client.Transfer(tokenIDs, shares)
+		if err != nil {
+			return submitted, errors.WithMessage(err, "failed consolidating tokens of type "+batch[0].Type)
+		}
+		submitted++
+	}
+	return submitted, nil
+}
+
+// batches groups the dust tokens by type and splits each group into batches of
+// at most MaxInputs tokens. Batches of a single token are dropped since there is
+// nothing to merge, and so are tokens that would make a batch overflow.
+func (c *Consolidator) batches(tokens []*token.TokenOutput) [][]*token.TokenOutput {
+	byType := map[string][]*token.TokenOutput{}
+	for _, t := range tokens {
+		if c.Config.DustQuantity != 0 && t.Quantity > c.Config.DustQuantity {
+			continue
+		}
+		byType[t.Type] = append(byType[t.Type], t)
+	}
+
+	var types []string
+	for tokenType, dust := range byType {
+		if len(dust) >= c.Config.MinOutputs {
+			types = append(types, tokenType)
+		}
+	}
+	sort.Strings(types)
+
+	var batches [][]*token.TokenOutput
+	for _, tokenType := range types {
+		dust := byType[tokenType]
+		sort.SliceStable(dust, func(i, j int) bool { return dust[i].Quantity < dust[j].Quantity })
+
+		var batch []*token.TokenOutput
+		quantity := uint64(0)
+		for _, t := range dust {
+			if quantity+t.Quantity < quantity {
+				break
+			}
+			batch = append(batch, t)
+			quantity += t.Quantity
+			if len(batch) == c.Config.MaxInputs {
+				batches = append(batches, batch)
+				batch, quantity = nil, 0
+			}
+		}
+		if len(batch) > 1 {
+			batches = append(batches, batch)
+		}
+	}
+	return batches
+}
+
+// Run consolidates tokens every Interval until done is closed. Failed rounds
+// are logged and retried at the next interval.
+func (c *Consolidator) Run(done <-chan struct{}) error {
+	if err := c.Config.validate(); err != nil {
+		return err
+	}
+	if c.Config.Interval <= 0 {
+		return errors.Errorf("Interval must be positive, got %s", c.Config.Interval)
+	}
+
+	ticker := time.NewTicker(c.Config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return nil
+		case <-ticker.C:
+			submitted, err := c.Consolidate()
+			if err != nil {
+				logger.Warningf("token consolidation failed after %d transfers: %s", submitted, err)
+				continue
+			}
+			if submitted > 0 {
+				logger.Infof("submitted %d token consolidation transfers", submitted)
+			}
+		}
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client_test
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/hyperledger/fabric/token/client/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Consolidator", func() {
+	var (
+		fakeIdentity        *mock.Identity
+		fakeSigningIdentity *mock.SigningIdentity
+		fakeProver          *mock.Prover
+		fakeTxSubmitter     *mock.FabricTxSubmitter
+		fakeLister          *mock.TokenLister
+
+		consolidator *client.Consolidator
+	)
+
+	BeforeEach(func() {
+		fakeIdentity = &mock.Identity{}
+		fakeIdentity.SerializeReturns([]byte("Alice"), nil)
+		fakeSigningIdentity = &mock.SigningIdentity{}
+		fakeSigningIdentity.GetPublicVersionReturns(fakeIdentity)
+		fakeSigningIdentity.SignReturns([]byte("tx-signature"), nil)
+
+		fakeProver = &mock.Prover{}
+		fakeProver.RequestTransferReturns([]byte("tx-payload"), nil)
+		fakeTxSubmitter = &mock.FabricTxSubmitter{}
+
+		fakeLister = &mock.TokenLister{}
+		fakeLister.ListTokensReturns([]*token.TokenOutput{
+			{Id: []byte("id-1"), Type: "XYZ", Quantity: 5},
+			{Id: []byte("id-2"), Type: "XYZ", Quantity: 1},
+			{Id: []byte("id-3"), Type: "XYZ", Quantity: 500},
+			{Id: []byte("id-4"), Type: "XYZ", Quantity: 3},
+			{Id: []byte("id-5"), Type: "PDQ", Quantity: 2},
+			{Id: []byte("id-6"), Type: "XYZ", Quantity: 2},
+		}, nil)
+
+		consolidator = &client.Consolidator{
+			Client: &client.Client{
+				SigningIdentity: fakeSigningIdentity,
+				Prover:          fakeProver,
+				TxSubmitter:     fakeTxSubmitter,
+			},
+			Lister: fakeLister,
+			Config: client.ConsolidationConfig{
+				DustQuantity: 10,
+				MinOutputs:   3,
+			},
+		}
+	})
+
+	Describe("Consolidate", func() {
+		It("transfers the dust tokens of each type to the owner", func() {
+			submitted, err := consolidator.Consolidate()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(submitted).To(Equal(1))
+
+			Expect(fakeLister.ListTokensCallCount()).To(Equal(1))
+			Expect(fakeLister.ListTokensArgsForCall(0)).To(Equal(fakeSigningIdentity))
+
+			Expect(fakeProver.RequestTransferCallCount()).To(Equal(1))
+			tokenIDs, shares, _ := fakeProver.RequestTransferArgsForCall(0)
+			Expect(tokenIDs).To(Equal([][]byte{[]byte("id-2"), []byte("id-6"), []byte("id-4"), []byte("id-1")}))
+			Expect(shares).To(Equal([]*token.RecipientTransferShare{{Recipient: []byte("Alice"), Quantity: 11}}))
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(1))
+		})
+
+		Context("when the number of inputs is limited", func() {
+			BeforeEach(func() {
+				consolidator.Config.MaxInputs = 3
+			})
+
+			It("splits the tokens across transfers and skips a single leftover token", func() {
+				submitted, err := consolidator.Consolidate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(submitted).To(Equal(1))
+
+				tokenIDs, shares, _ := fakeProver.RequestTransferArgsForCall(0)
+				Expect(tokenIDs).To(Equal([][]byte{[]byte("id-2"), []byte("id-6"), []byte("id-4")}))
+				Expect(shares).To(Equal([]*token.RecipientTransferShare{{Recipient: []byte("Alice"), Quantity: 6}}))
+			})
+		})
+
+		Context("when every token is dust", func() {
+			BeforeEach(func() {
+				consolidator.Config.DustQuantity = 0
+				consolidator.Config.MinOutputs = 2
+				consolidator.Config.MaxInputs = 2
+			})
+
+			It("consolidates all types", func() {
+				submitted, err := consolidator.Consolidate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(submitted).To(Equal(2))
+
+				tokenIDs, _, _ := fakeProver.RequestTransferArgsForCall(0)
+				Expect(tokenIDs).To(Equal([][]byte{[]byte("id-2"), []byte("id-6")}))
+				tokenIDs, _, _ = fakeProver.RequestTransferArgsForCall(1)
+				Expect(tokenIDs).To(Equal([][]byte{[]byte("id-4"), []byte("id-1")}))
+			})
+		})
+
+		Context("when there are not enough dust tokens", func() {
+			BeforeEach(func() {
+				consolidator.Config.MinOutputs = 5
+			})
+
+			It("does not transfer", func() {
+				submitted, err := consolidator.Consolidate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(submitted).To(Equal(0))
+				Expect(fakeProver.RequestTransferCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the config is invalid", func() {
+			BeforeEach(func() {
+				consolidator.Config.MinOutputs = 1
+			})
+
+			It("returns an error", func() {
+				_, err := consolidator.Consolidate()
+				Expect(err).To(MatchError("MinOutputs must be at least 2, got 1"))
+			})
+		})
+
+		Context("when listing the tokens fails", func() {
+			BeforeEach(func() {
+				fakeLister.ListTokensReturns(nil, errors.New("banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := consolidator.Consolidate()
+				Expect(err).To(MatchError("failed listing tokens: banana"))
+			})
+		})
+
+		Context("when the transfer fails", func() {
+			BeforeEach(func() {
+				fakeTxSubmitter.SubmitReturns(errors.New("mango"))
+			})
+
+			It("returns an error", func() {
+				submitted, err := consolidator.Consolidate()
+				Expect(err).To(MatchError("failed consolidating tokens of type XYZ: mango"))
+				Expect(submitted).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		It("consolidates every interval until done is closed", func() {
+			consolidator.Config.Interval = time.Millisecond
+			done := make(chan struct{})
+			errCh := make(chan error, 1)
+			go func() { errCh <- consolidator.Run(done) }()
+
+			Eventually(fakeLister.ListTokensCallCount).Should(BeNumerically(">=", 2))
+			close(done)
+			Eventually(errCh).Should(Receive(BeNil()))
+		})
+
+		It("requires a positive interval", func() {
+			err := consolidator.Run(make(chan struct{}))
+			Expect(err).To(MatchError("Interval must be positive, got 0s"))
+		})
+	})
+})
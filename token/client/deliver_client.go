@@ -10,6 +10,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"math"
+	"sync"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/bccsp"
@@ -18,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/status"
@@ -49,6 +51,12 @@ type deliverClient struct {
 	serverNameOverride string
 	grpcClient         *comm.GRPCClient
 	conn               *grpc.ClientConn
+	endpoints          *Endpoints
+	// backend is the commit peer backend the client is connected to
+	backend string
+
+	mutex  sync.Mutex
+	closed bool
 }
 
 func NewDeliverClient(config *ClientConfig) (DeliverClient, error) {
@@ -58,31 +66,49 @@ func NewDeliverClient(config *ClientConfig) (DeliverClient, error) {
 		logger.Errorf("%s", err)
 		return nil, err
 	}
-	conn, err := grpcClient.NewConnection(config.CommitPeerCfg.Address, config.CommitPeerCfg.ServerNameOverride)
+	d := &deliverClient{
+		peerAddr:           config.CommitPeerCfg.Address,
+		serverNameOverride: serverName(config.CommitPeerCfg.Address, config.CommitPeerCfg.ServerNameOverride),
+		grpcClient:         grpcClient,
+		endpoints:          NewEndpoints(config.CommitPeerCfg.Address),
+	}
+	d.backend, err = d.endpoints.Next(context.Background())
 	if err != nil {
-		return nil, errors.WithMessage(err, fmt.Sprintf("failed to connect to commit peer %s", config.CommitPeerCfg.Address))
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to resolve commit peer %s", d.peerAddr))
+	}
+	d.conn, err = grpcClient.NewConnection(d.backend, d.serverNameOverride)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to connect to commit peer %s", d.backend))
 	}
 
-	return &deliverClient{
-		peerAddr:           config.CommitPeerCfg.Address,
-		serverNameOverride: config.CommitPeerCfg.ServerNameOverride,
-		grpcClient:         grpcClient,
-		conn:               conn,
-	}, nil
+	return d, nil
 }
 
-// NewDeliverFilterd creates a DeliverFiltered client
+// NewDeliverFilterd creates a DeliverFiltered client. Deliver streams are sticky:
+// they reconnect to the same backend of the commit peer address as long as it
+// is resolved, so that blocks are delivered in the order of a single peer.
 func (d *deliverClient) NewDeliverFiltered(ctx context.Context, opts ...grpc.CallOption) (DeliverFiltered, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.closed {
+		return nil, ErrClosed
+	}
+
 	if d.conn != nil {
 		// close the old connection because new connection will restart its timeout
 		d.conn.Close()
 	}
 
+	backend, err := d.endpoints.Sticky(ctx, d.backend)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to resolve commit peer %s", d.peerAddr))
+	}
+	d.backend = backend
+
 	// create a new connection to the peer
-	var err error
-	d.conn, err = d.grpcClient.NewConnection(d.peerAddr, d.serverNameOverride)
+	d.conn, err = d.grpcClient.NewConnection(d.backend, d.serverNameOverride)
 	if err != nil {
-		return nil, errors.WithMessage(err, fmt.Sprintf("failed to connect to commit peer %s", d.peerAddr))
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to connect to commit peer %s", d.backend))
 	}
 
 	// create a new DeliverFiltered
@@ -99,8 +125,66 @@ func (d *deliverClient) Certificate() *tls.Certificate {
 	return &cert
 }
 
+// Close closes the connection to the commit peer; the deliver filtered
+// clients created on it fail afterwards
+func (d *deliverClient) Close() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.closed {
+		return ErrClosed
+	}
+	d.closed = true
+	if d.conn == nil {
+		return nil
+	}
+	return errors.Wrapf(d.conn.Close(), "failed closing connection to commit peer %s", d.backend)
+}
+
 // create a signed envelope with SeekPosition_Newest for block
 func CreateDeliverEnvelope(channelId string, creator []byte, signer SignerIdentity, cert *tls.Certificate) (*common.Envelope, error) {
+	start := &ab.SeekPosition{
+		Type: &ab.SeekPosition_Newest{
+			Newest: &ab.SeekNewest{},
+		},
+	}
+	return createDeliverEnvelope(channelId, creator, signer, cert, seekInfoFrom(start), nil)
+}
+
+// CreateResumingDeliverEnvelope creates a signed envelope requesting the blocks
+// following the checkpoint of the channel, or the newest block when there is
+// no checkpoint yet.
+func CreateResumingDeliverEnvelope(channelId string, creator []byte, signer SignerIdentity, cert *tls.Certificate, checkpointer Checkpointer) (*common.Envelope, error) {
+	newest := &ab.SeekPosition{
+		Type: &ab.SeekPosition_Newest{
+			Newest: &ab.SeekNewest{},
+		},
+	}
+	start, err := resumePosition(checkpointer, channelId, newest)
+	if err != nil {
+		return nil, err
+	}
+	return createDeliverEnvelope(channelId, creator, signer, cert, seekInfoFrom(start), nil)
+}
+
+// seekInfoFrom returns a SeekInfo requesting all the blocks from start,
+// waiting for the blocks to be committed.
+func seekInfoFrom(start *ab.SeekPosition) *ab.SeekInfo {
+	return &ab.SeekInfo{
+		Start: start,
+		Stop: &ab.SeekPosition{
+			Type: &ab.SeekPosition_Specified{
+				Specified: &ab.SeekSpecified{
+					Number: math.MaxUint64,
+				},
+			},
+		},
+		Behavior: ab.SeekInfo_BLOCK_UNTIL_READY,
+	}
+}
+
+// createDeliverEnvelope creates a signed envelope requesting the blocks of
+// seekInfo; extension, when set, is the extension of the channel header.
+func createDeliverEnvelope(channelId string, creator []byte, signer SignerIdentity, cert *tls.Certificate, seekInfo *ab.SeekInfo, extension []byte) (*common.Envelope, error) {
 	var tlsCertHash []byte
 	var err error
 	// check for client certificate and compute SHA2-256 on certificate if present
@@ -117,25 +201,16 @@ func CreateDeliverEnvelope(channelId string, creator []byte, signer SignerIdenti
 	if err != nil {
 		return nil, err
 	}
-
-	start := &ab.SeekPosition{
-		Type: &ab.SeekPosition_Newest{
-			Newest: &ab.SeekNewest{},
-		},
-	}
-
-	stop := &ab.SeekPosition{
-		Type: &ab.SeekPosition_Specified{
-			Specified: &ab.SeekSpecified{
-				Number: math.MaxUint64,
-			},
-		},
-	}
-
-	seekInfo := &ab.SeekInfo{
-		Start:    start,
-		Stop:     stop,
-		Behavior: ab.SeekInfo_BLOCK_UNTIL_READY,
+	if extension != nil {
+		chdr, err := utils.UnmarshalChannelHeader(header.ChannelHeader)
+		if err != nil {
+			return nil, err
+		}
+		chdr.Extension = extension
+		header.ChannelHeader, err = proto.Marshal(chdr)
+		if err != nil {
+			return nil, errors.Wrap(err, "error marshaling ChannelHeader")
+		}
 	}
 
 	raw, err := proto.Marshal(seekInfo)
@@ -161,6 +236,16 @@ func DeliverSend(df DeliverFiltered, address string, envelope *common.Envelope)
 }
 
 func DeliverReceive(df DeliverFiltered, address string, txid string, eventCh chan TxEvent) error {
+	return deliverReceive(df, address, txid, eventCh, nil)
+}
+
+// DeliverReceiveCheckpointed is like DeliverReceive and records each block it
+// processes with the checkpointer.
+func DeliverReceiveCheckpointed(df DeliverFiltered, address string, txid string, eventCh chan TxEvent, checkpointer Checkpointer) error {
+	return deliverReceive(df, address, txid, eventCh, checkpointer)
+}
+
+func deliverReceive(df DeliverFiltered, address string, txid string, eventCh chan TxEvent, checkpointer Checkpointer) error {
 	event := TxEvent{
 		Txid:       txid,
 		Committed:  false,
@@ -183,11 +268,13 @@ read:
 					if tx.TxValidationCode == pb.TxValidationCode_VALID {
 						event.Committed = true
 					} else {
-						event.Err = errors.Errorf("transaction [%s] status is not valid: %s", tx.Txid, tx.TxValidationCode)
+						event.Err = &InvalidTxError{TxID: tx.Txid, ValidationCode: tx.TxValidationCode.String()}
 					}
+					checkpoint(checkpointer, r.FilteredBlock)
 					break read
 				}
 			}
+			checkpoint(checkpointer, r.FilteredBlock)
 		case *pb.DeliverResponse_Status:
 			event.Err = errors.Errorf("deliver completed with status (%s) before txid %s received from peer %s", r.Status, txid, address)
 			break read
@@ -210,9 +297,22 @@ read:
 	return event.Err
 }
 
+// checkpoint records the filtered block as processed. Failing to record a
+// checkpoint only means blocks are processed again after a restart.
+func checkpoint(checkpointer Checkpointer, block *pb.FilteredBlock) {
+	if checkpointer == nil {
+		return
+	}
+	err := checkpointer.Checkpoint(block.ChannelId, block.Number)
+	if err != nil {
+		logger.Warningf("failed recording checkpoint of block %d on channel %s: %s", block.Number, block.ChannelId, err)
+	}
+}
+
 // DeliverWaitForResponse waits for either eventChan has value (i.e., response has been received) or ctx is timed out
 // This function assumes that the eventCh is only for the specified txid
 // If an eventCh is shared by multiple transactions, a loop should be used to listen to events from multiple transactions
+// A commit event that is already received when ctx is done wins over the timeout.
 func DeliverWaitForResponse(ctx context.Context, eventCh chan TxEvent, txid string) (bool, error) {
 	select {
 	case event, _ := <-eventCh:
@@ -223,6 +323,13 @@ func DeliverWaitForResponse(ctx context.Context, eventCh chan TxEvent, txid stri
 			return false, errors.Errorf("no event received for txid %s", txid)
 		}
 	case <-ctx.Done():
+		select {
+		case event := <-eventCh:
+			if txid == event.Txid && event.Committed {
+				return true, nil
+			}
+		default:
+		}
 		err := errors.Errorf("timed out waiting for committing txid %s", txid)
 		logger.Errorf("%s", err)
 		return false, err
@@ -122,6 +122,50 @@ var _ = Describe("OrdererClient", func() {
 		})
 	})
 
+	Describe("CreateResumingDeliverEnvelope", func() {
+		var fakeCheckpointer *mock.Checkpointer
+
+		BeforeEach(func() {
+			fakeCheckpointer = &mock.Checkpointer{}
+		})
+
+		seekInfoOf := func(envelope *common.Envelope) *ab.SeekInfo {
+			payload := &common.Payload{}
+			err := proto.Unmarshal(envelope.Payload, payload)
+			Expect(err).NotTo(HaveOccurred())
+			seekInfo := &ab.SeekInfo{}
+			err = proto.Unmarshal(payload.Data, seekInfo)
+			Expect(err).NotTo(HaveOccurred())
+			return seekInfo
+		}
+
+		It("starts from the block following the checkpoint", func() {
+			fakeCheckpointer.LastBlockReturns(41, true, nil)
+
+			envelope, err := client.CreateResumingDeliverEnvelope(channelId, creator, fakeSigner, nil, fakeCheckpointer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(seekInfoOf(envelope).Start.GetSpecified().GetNumber()).To(Equal(uint64(42)))
+			Expect(fakeCheckpointer.LastBlockArgsForCall(0)).To(Equal(channelId))
+		})
+
+		Context("when there is no checkpoint", func() {
+			It("starts from the newest block", func() {
+				envelope, err := client.CreateResumingDeliverEnvelope(channelId, creator, fakeSigner, nil, fakeCheckpointer)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(envelope.Payload).NotTo(BeEmpty())
+				Expect(seekInfoOf(envelope).Start.GetNewest()).NotTo(BeNil())
+			})
+		})
+
+		Context("when the checkpointer fails", func() {
+			It("returns an error", func() {
+				fakeCheckpointer.LastBlockReturns(0, false, errors.New("wild-plum"))
+				_, err := client.CreateResumingDeliverEnvelope(channelId, creator, fakeSigner, nil, fakeCheckpointer)
+				Expect(err).To(MatchError("failed reading checkpoint: wild-plum"))
+			})
+		})
+	})
+
 	Describe("DeliverSend", func() {
 		var envelope *common.Envelope
 
@@ -168,6 +212,18 @@ var _ = Describe("OrdererClient", func() {
 			Expect(committed).To(Equal(true))
 		})
 
+		It("checkpoints the blocks it processes", func() {
+			fakeCheckpointer := &mock.Checkpointer{}
+			deliverResp.GetFilteredBlock().Number = 9
+
+			err := client.DeliverReceiveCheckpointed(fakeDeliverFiltered, "dummyAddress", fakeTxid, eventCh, fakeCheckpointer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeCheckpointer.CheckpointCallCount()).To(Equal(1))
+			channel, number := fakeCheckpointer.CheckpointArgsForCall(0)
+			Expect(channel).To(Equal(channelId))
+			Expect(number).To(Equal(uint64(9)))
+		})
+
 		Context("when Deliver.Recv returns error", func() {
 			BeforeEach(func() {
 				fakeDeliverFiltered.RecvReturns(nil, errors.New("flying-banana"))
@@ -190,11 +246,32 @@ var _ = Describe("OrdererClient", func() {
 			It("returns an error", func() {
 				ctx, cancelFunc := context.WithTimeout(context.Background(), time.Second)
 				defer cancelFunc()
-				// receive response for another txid
+				// receive response for another txid, until the stream ends with ctx
+				fakeDeliverFiltered.RecvStub = func() (*pb.DeliverResponse, error) {
+					if fakeDeliverFiltered.RecvCallCount() == 1 {
+						return deliverResp, nil
+					}
+					<-ctx.Done()
+					return nil, ctx.Err()
+				}
 				go client.DeliverReceive(fakeDeliverFiltered, "dummyAddress", fakeTxid, eventCh)
 				_, err := client.DeliverWaitForResponse(ctx, eventCh, fakeTxid)
 				Expect(err.Error()).To(ContainSubstring("timed out waiting for committing txid " + fakeTxid))
 			})
 		})
+
+		Context("when the commit event is received by the time ctx is done", func() {
+			It("reports the commit", func() {
+				ctx, cancelFunc := context.WithCancel(context.Background())
+				cancelFunc()
+				// both cases of the wait are ready: the commit must win every time
+				for i := 0; i < 100; i++ {
+					eventCh <- client.TxEvent{Txid: fakeTxid, Committed: true}
+					committed, err := client.DeliverWaitForResponse(ctx, eventCh, fakeTxid)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(committed).To(BeTrue())
+				}
+			})
+		})
 	})
 })
@@ -0,0 +1,128 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"math"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/pkg/errors"
+)
+
+//go:generate counterfeiter -o mock/fee_policy.go -fake-name FeePolicy . FeePolicy
+
+// FeePolicy computes the fee charged for a transaction.
+type FeePolicy interface {
+	// Fee returns the fee for a transaction of the given serialized size, in
+	// bytes, that spends the given number of inputs.
+	Fee(size int, inputs int) uint64
+}
+
+// LinearFeePolicy charges a base fee plus a fee per byte and per input.
+type LinearFeePolicy struct {
+	Base     uint64
+	PerByte  uint64
+	PerInput uint64
+}
+
+func (l *LinearFeePolicy) Fee(size int, inputs int) uint64 {
+	return l.Base + l.PerByte*uint64(size) + l.PerInput*uint64(inputs)
+}
+
+// Estimate describes a transaction before it is submitted.
+type Estimate struct {
+	// Size is the projected size, in bytes, of the serialized transaction envelope.
+	Size int
+	// Inputs is the number of tokens spent by the transaction.
+	Inputs int
+	// Fee is the fee computed by the client's FeePolicy; it is 0 when the
+	// client has no FeePolicy.
+	Fee uint64
+}
+
+// Estimate asks the prover to assemble the transfer of tokens of the type
+// described by request and returns its projected size, number of inputs and
+// fee. Unless the request names the tokens to spend, they are selected among
+// the unspent tokens of the client as the prover selects them for a credit,
+// with the change returned to the client. The transaction is signed to size
+// the envelope but it is not submitted to the orderer.
+func (c *Client) Estimate(tokenType string, request *token.TransferRequest) (*Estimate, error) {
+	if request == nil {
+		return nil, errors.New("transfer request is nil")
+	}
+	if len(request.Delegations) != 0 {
+		return nil, errors.New("delegated transfers cannot be estimated")
+	}
+
+	tokenIDs, shares := request.TokenIds, request.Shares
+	if len(tokenIDs) == 0 {
+		var err error
+		tokenIDs, shares, err = c.selectInputs(tokenType, shares)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	serializedTokenTx, err := c.Prover.RequestTransfer(tokenIDs, shares, c.SigningIdentity)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenTx := &token.TokenTransaction{}
+	err = proto.Unmarshal(serializedTokenTx, tokenTx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling token transaction")
+	}
+
+	tx, err := c.createTx(serializedTokenTx)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := &Estimate{
+		Size:   len(tx),
+		Inputs: len(tokenTx.GetPlainAction().GetPlainTransfer().GetInputs()),
+	}
+	if c.FeePolicy != nil {
+		estimate.Fee = c.FeePolicy.Fee(estimate.Size, estimate.Inputs)
+	}
+	return estimate, nil
+}
+
+// selectInputs selects the unspent tokens of the type of the client that
+// cover the shares, with tk.SelectTokens, and returns them with the shares
+// and a share of the change to the client, if any.
+func (c *Client) selectInputs(tokenType string, shares []*token.RecipientTransferShare) ([][]byte, []*token.RecipientTransferShare, error) {
+	quantity := uint64(0)
+	for _, share := range shares {
+		if quantity > math.MaxUint64-share.Quantity {
+			return nil, nil, errors.New("quantity of the transfer overflows")
+		}
+		quantity += share.Quantity
+	}
+
+	unspent, err := c.Prover.ListTokens(c.SigningIdentity)
+	if err != nil {
+		return nil, nil, err
+	}
+	tokenIDs, total, err := tk.SelectTokens(unspent, tokenType, quantity)
+	if err != nil {
+		return nil, nil, err
+	}
+	if total == quantity {
+		return tokenIDs, shares, nil
+	}
+
+	owner, err := c.SigningIdentity.Serialize()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed serializing the signing identity")
+	}
+	change := &token.RecipientTransferShare{Recipient: owner, Quantity: total - quantity}
+	return tokenIDs, append(append([]*token.RecipientTransferShare{}, shares...), change), nil
+}
@@ -0,0 +1,220 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client_test
+
+import (
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/hyperledger/fabric/token/client/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Estimate", func() {
+	var (
+		fakeSigningIdentity *mock.SigningIdentity
+		fakeProver          *mock.Prover
+		fakeTxSubmitter     *mock.FabricTxSubmitter
+
+		tokenTx     []byte
+		request     *token.TransferRequest
+		tokenClient *client.Client
+	)
+
+	BeforeEach(func() {
+		tokenTx = ProtoMarshal(&token.TokenTransaction{
+			Action: &token.TokenTransaction_PlainAction{
+				PlainAction: &token.PlainTokenAction{
+					Data: &token.PlainTokenAction_PlainTransfer{
+						PlainTransfer: &token.PlainTransfer{
+							Inputs: []*token.InputId{{TxId: "tx1", Index: 0}, {TxId: "tx2", Index: 1}},
+							Outputs: []*token.PlainOutput{
+								{Owner: []byte("bob"), Type: "XYZ", Quantity: 100},
+							},
+						},
+					},
+				},
+			},
+		})
+
+		fakeProver = &mock.Prover{}
+		fakeProver.RequestTransferReturns(tokenTx, nil)
+		fakeSigningIdentity = &mock.SigningIdentity{}
+		fakeSigningIdentity.SignReturns([]byte("tx-signature"), nil)
+		fakeTxSubmitter = &mock.FabricTxSubmitter{}
+
+		request = &token.TransferRequest{
+			TokenIds: [][]byte{[]byte("id1"), []byte("id2")},
+			Shares:   []*token.RecipientTransferShare{{Recipient: []byte("bob"), Quantity: 100}},
+		}
+		tokenClient = &client.Client{
+			SigningIdentity: fakeSigningIdentity,
+			Prover:          fakeProver,
+			TxSubmitter:     fakeTxSubmitter,
+		}
+	})
+
+	It("returns the size and inputs of the transaction without submitting it", func() {
+		envelope := ProtoMarshal(&common.Envelope{
+			Payload:   ProtoMarshal(&common.Payload{Data: tokenTx}),
+			Signature: []byte("tx-signature"),
+		})
+
+		estimate, err := tokenClient.Estimate("XYZ", request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(estimate).To(Equal(&client.Estimate{Size: len(envelope), Inputs: 2}))
+
+		Expect(fakeProver.RequestTransferCallCount()).To(Equal(1))
+		tokenIDs, shares, signingIdentity := fakeProver.RequestTransferArgsForCall(0)
+		Expect(tokenIDs).To(Equal(request.TokenIds))
+		Expect(shares).To(Equal(request.Shares))
+		Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+		Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+	})
+
+	Context("when the request names no tokens", func() {
+		BeforeEach(func() {
+			request.TokenIds = nil
+			request.Shares = []*token.RecipientTransferShare{
+				{Recipient: []byte("bob"), Quantity: 60},
+				{Recipient: []byte("carol"), Quantity: 40},
+			}
+			fakeProver.ListTokensReturns([]*token.TokenOutput{
+				{Id: []byte("id1"), Type: "XYZ", Quantity: 80},
+				{Id: []byte("id2"), Type: "ABC", Quantity: 10},
+				{Id: []byte("id3"), Type: "XYZ", Quantity: 30},
+				{Id: []byte("id4"), Type: "XYZ", Quantity: 500},
+			}, nil)
+			fakeSigningIdentity.SerializeReturns([]byte("alice"), nil)
+		})
+
+		It("selects the tokens to spend as the prover does", func() {
+			estimate, err := tokenClient.Estimate("XYZ", request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(estimate.Inputs).To(Equal(2))
+
+			Expect(fakeProver.ListTokensCallCount()).To(Equal(1))
+			Expect(fakeProver.ListTokensArgsForCall(0)).To(Equal(fakeSigningIdentity))
+			Expect(fakeProver.RequestTransferCallCount()).To(Equal(1))
+			tokenIDs, shares, _ := fakeProver.RequestTransferArgsForCall(0)
+			Expect(tokenIDs).To(Equal([][]byte{[]byte("id3"), []byte("id1")}))
+			Expect(shares).To(Equal([]*token.RecipientTransferShare{
+				{Recipient: []byte("bob"), Quantity: 60},
+				{Recipient: []byte("carol"), Quantity: 40},
+				{Recipient: []byte("alice"), Quantity: 10},
+			}))
+			Expect(request.Shares).To(HaveLen(2))
+		})
+
+		Context("when the selected tokens cover the shares exactly", func() {
+			BeforeEach(func() {
+				request.Shares[0].Quantity = 70
+			})
+
+			It("returns no change", func() {
+				_, err := tokenClient.Estimate("XYZ", request)
+				Expect(err).NotTo(HaveOccurred())
+				_, shares, _ := fakeProver.RequestTransferArgsForCall(0)
+				Expect(shares).To(Equal(request.Shares))
+				Expect(fakeSigningIdentity.SerializeCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the balance is insufficient", func() {
+			It("returns an error", func() {
+				_, err := tokenClient.Estimate("ABC", request)
+				Expect(err).To(MatchError("insufficient balance of token type 'ABC': 10 < 100"))
+				Expect(fakeProver.RequestTransferCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when listing the tokens fails", func() {
+			BeforeEach(func() {
+				fakeProver.ListTokensReturns(nil, errors.New("kiwi"))
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.Estimate("XYZ", request)
+				Expect(err).To(MatchError("kiwi"))
+			})
+		})
+
+		Context("when serializing the signing identity fails", func() {
+			BeforeEach(func() {
+				fakeSigningIdentity.SerializeReturns(nil, errors.New("papaya"))
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.Estimate("XYZ", request)
+				Expect(err).To(MatchError("failed serializing the signing identity: papaya"))
+			})
+		})
+	})
+
+	Context("when fees are enabled", func() {
+		var fakeFeePolicy *mock.FeePolicy
+
+		BeforeEach(func() {
+			fakeFeePolicy = &mock.FeePolicy{}
+			fakeFeePolicy.FeeReturns(42)
+			tokenClient.FeePolicy = fakeFeePolicy
+		})
+
+		It("includes the fee", func() {
+			estimate, err := tokenClient.Estimate("XYZ", request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(estimate.Fee).To(Equal(uint64(42)))
+
+			Expect(fakeFeePolicy.FeeCallCount()).To(Equal(1))
+			size, inputs := fakeFeePolicy.FeeArgsForCall(0)
+			Expect(size).To(Equal(estimate.Size))
+			Expect(inputs).To(Equal(2))
+		})
+	})
+
+	Context("when the request has delegations", func() {
+		BeforeEach(func() {
+			request.Delegations = []*token.SignedDelegation{{}}
+		})
+
+		It("returns an error", func() {
+			_, err := tokenClient.Estimate("XYZ", request)
+			Expect(err).To(MatchError("delegated transfers cannot be estimated"))
+		})
+	})
+
+	Context("when the prover fails", func() {
+		BeforeEach(func() {
+			fakeProver.RequestTransferReturns(nil, errors.New("banana"))
+		})
+
+		It("returns an error", func() {
+			_, err := tokenClient.Estimate("XYZ", request)
+			Expect(err).To(MatchError("banana"))
+		})
+	})
+
+	Context("when signing the transaction fails", func() {
+		BeforeEach(func() {
+			fakeSigningIdentity.SignReturns(nil, errors.New("mango"))
+		})
+
+		It("returns an error", func() {
+			_, err := tokenClient.Estimate("XYZ", request)
+			Expect(err).To(MatchError("mango"))
+		})
+	})
+})
+
+var _ = Describe("LinearFeePolicy", func() {
+	It("charges a base fee plus a fee per byte and per input", func() {
+		policy := &client.LinearFeePolicy{Base: 10, PerByte: 2, PerInput: 5}
+		Expect(policy.Fee(100, 3)).To(Equal(uint64(225)))
+	})
+})
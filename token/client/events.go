@@ -0,0 +1,173 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// A BlockHandler processes a filtered block delivered to an EventListener.
+type BlockHandler func(block *pb.FilteredBlock) error
+
+// EventListener delivers the filtered blocks of the commit peer to a
+// BlockHandler, in place of the buffering of blocks by the applications.
+// The blocks can be restricted on the peer to the chaincode events and the
+// token transactions of interest with a filter.
+type EventListener struct {
+	Config        *ClientConfig
+	Signer        SignerIdentity
+	Creator       []byte
+	DeliverClient DeliverClient
+	// Checkpointer records the blocks handled by Listen, which resumes
+	// from the last checkpoint; Listen requires it.
+	Checkpointer Checkpointer
+	// Filter, when set, restricts the transactions of the filtered blocks.
+	// Blocks are delivered even when none of their transactions matches.
+	Filter *pb.EventFilter
+}
+
+// Listen handles the blocks following the checkpoint, or the newest block
+// when there is no checkpoint yet, as they are committed. A block is
+// checkpointed once handled, and blocks at or below the checkpoint are never
+// handled again, so that every block is handled exactly once as long as the
+// checkpoints are recorded. Listen returns when handle or the checkpointer
+// fails, when the deliver stream fails, or when ctx is done; it can then be
+// called again to resume.
+func (l *EventListener) Listen(ctx context.Context, handle BlockHandler) error {
+	if l.Checkpointer == nil {
+		return errors.New("listening requires a checkpointer")
+	}
+	channel := l.Config.ChannelId
+	last, checkpointed, err := l.Checkpointer.LastBlock(channel)
+	if err != nil {
+		return errors.WithMessage(err, "failed reading checkpoint")
+	}
+	start := &ab.SeekPosition{
+		Type: &ab.SeekPosition_Newest{
+			Newest: &ab.SeekNewest{},
+		},
+	}
+	if checkpointed {
+		start = &ab.SeekPosition{
+			Type: &ab.SeekPosition_Specified{
+				Specified: &ab.SeekSpecified{Number: last + 1},
+			},
+		}
+	}
+
+	return l.deliver(ctx, seekInfoFrom(start), func(block *pb.FilteredBlock) (bool, error) {
+		if checkpointed && block.Number <= last {
+			logger.Debugf("skipping block %d already handled on channel %s", block.Number, channel)
+			return false, nil
+		}
+		if err := handle(block); err != nil {
+			return false, err
+		}
+		if err := l.Checkpointer.Checkpoint(channel, block.Number); err != nil {
+			return false, errors.WithMessage(err, fmt.Sprintf("failed recording checkpoint of block %d", block.Number))
+		}
+		last, checkpointed = block.Number, true
+		return false, nil
+	})
+}
+
+// Replay handles the blocks from block from to block to included, for
+// instance to rebuild the state of an application, without recording
+// checkpoints. Replay fails if the commit peer has not committed block to
+// yet, or does not retain block from anymore.
+func (l *EventListener) Replay(ctx context.Context, from, to uint64, handle BlockHandler) error {
+	if from > to {
+		return errors.Errorf("invalid block range %d to %d", from, to)
+	}
+	seekInfo := &ab.SeekInfo{
+		Start: &ab.SeekPosition{
+			Type: &ab.SeekPosition_Specified{
+				Specified: &ab.SeekSpecified{Number: from},
+			},
+		},
+		Stop: &ab.SeekPosition{
+			Type: &ab.SeekPosition_Specified{
+				Specified: &ab.SeekSpecified{Number: to},
+			},
+		},
+		Behavior: ab.SeekInfo_FAIL_IF_NOT_READY,
+	}
+
+	err := l.deliver(ctx, seekInfo, func(block *pb.FilteredBlock) (bool, error) {
+		if err := handle(block); err != nil {
+			return false, err
+		}
+		return block.Number >= to, nil
+	})
+	if errors.Cause(err) == errNotFound {
+		return errors.Errorf("blocks %d to %d are not available from peer %s", from, to, l.Config.CommitPeerCfg.Address)
+	}
+	return err
+}
+
+var errNotFound = errors.New("deliver completed with status NOT_FOUND")
+
+// deliver requests the blocks of seekInfo and passes them to process until
+// process reports it is done.
+func (l *EventListener) deliver(ctx context.Context, seekInfo *ab.SeekInfo, process func(*pb.FilteredBlock) (bool, error)) error {
+	var extension []byte
+	if l.Filter != nil {
+		var err error
+		extension, err = proto.Marshal(l.Filter)
+		if err != nil {
+			return errors.Wrap(err, "error marshaling EventFilter")
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	deliverFiltered, err := l.DeliverClient.NewDeliverFiltered(ctx)
+	if err != nil {
+		return err
+	}
+	envelope, err := createDeliverEnvelope(l.Config.ChannelId, l.Creator, l.Signer, l.DeliverClient.Certificate(), seekInfo, extension)
+	if err != nil {
+		return err
+	}
+	address := l.Config.CommitPeerCfg.Address
+	err = DeliverSend(deliverFiltered, address, envelope)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := deliverFiltered.Recv()
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error receiving deliver response from peer %s", address))
+		}
+
+		switch r := resp.Type.(type) {
+		case *pb.DeliverResponse_FilteredBlock:
+			done, err := process(r.FilteredBlock)
+			if err != nil || done {
+				return err
+			}
+		case *pb.DeliverResponse_Status:
+			if r.Status == common.Status_NOT_FOUND {
+				return errNotFound
+			}
+			return errors.Errorf("deliver completed with status (%s) from peer %s", r.Status, address)
+		default:
+			return errors.Errorf("received unexpected response type (%T) from peer %s", r, address)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client_test
+
+import (
+	"context"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/hyperledger/fabric/token/client/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("EventListener", func() {
+	var (
+		fakeDeliverFiltered *mock.DeliverFiltered
+		fakeDeliverClient   *mock.DeliverClient
+		fakeCheckpointer    *mock.Checkpointer
+		handled             []uint64
+		handle              client.BlockHandler
+
+		listener *client.EventListener
+	)
+
+	filteredBlock := func(number uint64) *pb.DeliverResponse {
+		return &pb.DeliverResponse{
+			Type: &pb.DeliverResponse_FilteredBlock{FilteredBlock: &pb.FilteredBlock{
+				ChannelId: "test-channel",
+				Number:    number,
+			}},
+		}
+	}
+
+	sentRequest := func() (*common.ChannelHeader, *ab.SeekInfo) {
+		Expect(fakeDeliverFiltered.SendCallCount()).To(Equal(1))
+		payload := &common.Payload{}
+		Expect(proto.Unmarshal(fakeDeliverFiltered.SendArgsForCall(0).Payload, payload)).To(Succeed())
+		chdr := &common.ChannelHeader{}
+		Expect(proto.Unmarshal(payload.Header.ChannelHeader, chdr)).To(Succeed())
+		seekInfo := &ab.SeekInfo{}
+		Expect(proto.Unmarshal(payload.Data, seekInfo)).To(Succeed())
+		return chdr, seekInfo
+	}
+
+	BeforeEach(func() {
+		fakeDeliverFiltered = &mock.DeliverFiltered{}
+		fakeDeliverFiltered.RecvReturnsOnCall(0, filteredBlock(4), nil)
+		fakeDeliverFiltered.RecvReturnsOnCall(1, filteredBlock(5), nil)
+		fakeDeliverFiltered.RecvReturnsOnCall(2, filteredBlock(6), nil)
+		fakeDeliverFiltered.RecvReturns(nil, io.EOF)
+		fakeDeliverClient = &mock.DeliverClient{}
+		fakeDeliverClient.NewDeliverFilteredReturns(fakeDeliverFiltered, nil)
+
+		fakeCheckpointer = &mock.Checkpointer{}
+		fakeCheckpointer.LastBlockReturns(4, true, nil)
+
+		handled = nil
+		handle = func(block *pb.FilteredBlock) error {
+			handled = append(handled, block.Number)
+			return nil
+		}
+
+		fakeSigner := &mock.SignerIdentity{}
+		fakeSigner.SignReturns([]byte("signature"), nil)
+		listener = &client.EventListener{
+			Config: &client.ClientConfig{
+				ChannelId:     "test-channel",
+				CommitPeerCfg: client.ConnectionConfig{Address: "peer-address"},
+			},
+			Signer:        fakeSigner,
+			Creator:       []byte("creator"),
+			DeliverClient: fakeDeliverClient,
+			Checkpointer:  fakeCheckpointer,
+			Filter:        &pb.EventFilter{EventNames: []string{"event"}, TokenTransactions: true},
+		}
+	})
+
+	Describe("Listen", func() {
+		It("handles and checkpoints the blocks following the checkpoint once", func() {
+			err := listener.Listen(context.Background(), handle)
+			Expect(err).To(MatchError("error receiving deliver response from peer peer-address: EOF"))
+
+			chdr, seekInfo := sentRequest()
+			Expect(seekInfo.Start.GetSpecified().GetNumber()).To(Equal(uint64(5)))
+			Expect(seekInfo.Behavior).To(Equal(ab.SeekInfo_BLOCK_UNTIL_READY))
+			filter := &pb.EventFilter{}
+			Expect(proto.Unmarshal(chdr.Extension, filter)).To(Succeed())
+			Expect(proto.Equal(filter, listener.Filter)).To(BeTrue())
+
+			Expect(handled).To(Equal([]uint64{5, 6}))
+			Expect(fakeCheckpointer.CheckpointCallCount()).To(Equal(2))
+			channel, number := fakeCheckpointer.CheckpointArgsForCall(1)
+			Expect(channel).To(Equal("test-channel"))
+			Expect(number).To(Equal(uint64(6)))
+		})
+
+		Context("when there is no checkpoint", func() {
+			BeforeEach(func() {
+				fakeCheckpointer.LastBlockReturns(0, false, nil)
+				listener.Filter = nil
+			})
+
+			It("starts from the newest block without filter", func() {
+				err := listener.Listen(context.Background(), handle)
+				Expect(err).To(HaveOccurred())
+
+				chdr, seekInfo := sentRequest()
+				Expect(seekInfo.Start.GetNewest()).NotTo(BeNil())
+				Expect(chdr.Extension).To(BeNil())
+				Expect(handled).To(Equal([]uint64{4, 5, 6}))
+			})
+		})
+
+		Context("when there is no checkpointer", func() {
+			BeforeEach(func() {
+				listener.Checkpointer = nil
+			})
+
+			It("returns an error", func() {
+				err := listener.Listen(context.Background(), handle)
+				Expect(err).To(MatchError("listening requires a checkpointer"))
+			})
+		})
+
+		Context("when handling a block fails", func() {
+			BeforeEach(func() {
+				handle = func(block *pb.FilteredBlock) error {
+					return errors.New("banana")
+				}
+			})
+
+			It("returns the error without checkpointing the block", func() {
+				err := listener.Listen(context.Background(), handle)
+				Expect(err).To(MatchError("banana"))
+				Expect(fakeCheckpointer.CheckpointCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when checkpointing a block fails", func() {
+			BeforeEach(func() {
+				fakeCheckpointer.CheckpointReturns(errors.New("wild-banana"))
+			})
+
+			It("returns the error", func() {
+				err := listener.Listen(context.Background(), handle)
+				Expect(err).To(MatchError("failed recording checkpoint of block 5: wild-banana"))
+				Expect(handled).To(Equal([]uint64{5}))
+			})
+		})
+
+		Context("when the context is done", func() {
+			It("returns the error of the context", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				err := listener.Listen(ctx, handle)
+				Expect(err).To(Equal(context.Canceled))
+			})
+		})
+	})
+
+	Describe("Replay", func() {
+		It("handles the blocks of the range without checkpointing them", func() {
+			err := listener.Replay(context.Background(), 4, 5, handle)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, seekInfo := sentRequest()
+			Expect(seekInfo.Start.GetSpecified().GetNumber()).To(Equal(uint64(4)))
+			Expect(seekInfo.Stop.GetSpecified().GetNumber()).To(Equal(uint64(5)))
+			Expect(seekInfo.Behavior).To(Equal(ab.SeekInfo_FAIL_IF_NOT_READY))
+			Expect(handled).To(Equal([]uint64{4, 5}))
+			Expect(fakeCheckpointer.CheckpointCallCount()).To(Equal(0))
+		})
+
+		Context("when the blocks are not available", func() {
+			BeforeEach(func() {
+				fakeDeliverFiltered.RecvReturnsOnCall(0, &pb.DeliverResponse{
+					Type: &pb.DeliverResponse_Status{Status: common.Status_NOT_FOUND},
+				}, nil)
+			})
+
+			It("returns an error", func() {
+				err := listener.Replay(context.Background(), 1, 2, handle)
+				Expect(err).To(MatchError("blocks 1 to 2 are not available from peer peer-address"))
+			})
+		})
+
+		Context("when the range is invalid", func() {
+			It("returns an error", func() {
+				err := listener.Replay(context.Background(), 2, 1, handle)
+				Expect(err).To(MatchError("invalid block range 2 to 1"))
+				Expect(fakeDeliverClient.NewDeliverFilteredCallCount()).To(Equal(0))
+			})
+		})
+	})
+})
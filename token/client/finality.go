@@ -0,0 +1,141 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/hyperledger/fabric/token/identity"
+	"github.com/pkg/errors"
+)
+
+// VerifyCommitAttestation checks that the attestation was signed by the peer
+// it names, whose identity must be valid for the deserializer and belong to
+// one of the passed MSPs, if any, and that it attests a transaction of the
+// block it holds the validation bitmap of. It returns the attestation.
+func VerifyCommitAttestation(signed *token.SignedCommitAttestation, deserializer identity.Deserializer, mspIDs ...string) (*token.CommitAttestation, error) {
+	if signed == nil {
+		return nil, errors.New("no commit attestation")
+	}
+	attestation := &token.CommitAttestation{}
+	err := proto.Unmarshal(signed.Attestation, attestation)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling commit attestation")
+	}
+	if int(attestation.TxIndex) >= len(attestation.ValidationFlags) {
+		return nil, errors.Errorf("commit attestation of block %d has no validation code for transaction %s", attestation.BlockNumber, attestation.TxId)
+	}
+
+	err = verifyPeerSignature(attestation.Peer, signed.Attestation, signed.Signature, deserializer, mspIDs, "commits", "commit attestation")
+	if err != nil {
+		return nil, err
+	}
+	return attestation, nil
+}
+
+// validationCode returns the validation code of the attested transaction.
+func validationCode(attestation *token.CommitAttestation) pb.TxValidationCode {
+	return util.TxValidationFlags(attestation.ValidationFlags).Flag(int(attestation.TxIndex))
+}
+
+//go:generate counterfeiter -o mock/commit_attestation_prover.go -fake-name CommitAttestationProver . CommitAttestationProver
+
+// A CommitAttestationProver returns the commit attestations of a peer, such
+// as a ProverPeer, or a GatewaySubmitter for the peers serving them through
+// their token gateway.
+type CommitAttestationProver interface {
+	RequestCommitAttestationContext(ctx context.Context, txID string, signingIdentity tk.SigningIdentity) (*token.SignedCommitAttestation, error)
+}
+
+// A FinalityChecker treats a token transaction as final once several peers
+// attest that they committed it as valid in the same block. The orderers
+// only sign the blocks, not the validation codes each peer computes, so a
+// client trusting the commit event of a single peer trusts that peer with
+// the outcome of the transaction; high-value transfers can require the
+// attestations of the peers of several organizations instead.
+type FinalityChecker struct {
+	// Provers are the peers whose attestations are requested, in order,
+	// until enough of them attest the transaction.
+	Provers      []CommitAttestationProver
+	Deserializer identity.Deserializer
+	// MSPIDs, when set, are the MSPs of the peers trusted to attest commits.
+	MSPIDs []string
+	// Attestations is the number of distinct peers that must attest the
+	// transaction; every prover must when it is 0.
+	Attestations int
+}
+
+// CheckFinality returns the attestations of the peers that committed the
+// transaction of the ID as valid, once enough peers attest it in the same
+// block. It returns an error when fewer peers attest it, for instance as
+// they have not committed the block yet, or when a peer attests another
+// block or another validation code, in which case the transaction must not
+// be treated as final whatever the other peers attest.
+func (f *FinalityChecker) CheckFinality(ctx context.Context, channelID, txID string, signingIdentity tk.SigningIdentity) ([]*token.CommitAttestation, error) {
+	required := f.Attestations
+	if required <= 0 {
+		required = len(f.Provers)
+	}
+	if required == 0 {
+		return nil, errors.New("no provers to request commit attestations from")
+	}
+
+	var attestations []*token.CommitAttestation
+	var failures []string
+	for _, prover := range f.Provers {
+		if len(attestations) == required {
+			break
+		}
+		signed, err := prover.RequestCommitAttestationContext(ctx, txID, signingIdentity)
+		if err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+		attestation, err := VerifyCommitAttestation(signed, f.Deserializer, f.MSPIDs...)
+		if err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+		if attestation.ChannelId != channelID || attestation.TxId != txID {
+			failures = append(failures, fmt.Sprintf("commit attestation is for transaction %s of channel %s", attestation.TxId, attestation.ChannelId))
+			continue
+		}
+		if code := validationCode(attestation); code != pb.TxValidationCode_VALID {
+			return nil, errors.Errorf("a peer attests that transaction %s is invalid: %s", txID, code)
+		}
+		if len(attestations) > 0 {
+			first := attestations[0]
+			if attestation.BlockNumber != first.BlockNumber || !bytes.Equal(attestation.BlockHash, first.BlockHash) {
+				return nil, errors.Errorf("peers attest that transaction %s committed in distinct blocks %d and %d", txID, first.BlockNumber, attestation.BlockNumber)
+			}
+		}
+		if !containsPeer(attestations, attestation.Peer) {
+			attestations = append(attestations, attestation)
+		}
+	}
+
+	if len(attestations) < required {
+		return nil, errors.Errorf("%d of %d peers attest that transaction %s committed: %v", len(attestations), required, txID, failures)
+	}
+	return attestations, nil
+}
+
+func containsPeer(attestations []*token.CommitAttestation, peer []byte) bool {
+	for _, a := range attestations {
+		if bytes.Equal(a.Peer, peer) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client_test
+
+import (
+	"context"
+
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/hyperledger/fabric/token/client/mock"
+	idmock "github.com/hyperledger/fabric/token/identity/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("FinalityChecker", func() {
+	var (
+		fakeDeserializer *idmock.Deserializer
+		fakePeer         *idmock.Identity
+		fakeProvers      []*mock.CommitAttestationProver
+		checker          *client.FinalityChecker
+	)
+
+	signedAttestation := func(peer string, blockHash string, code pb.TxValidationCode) *token.SignedCommitAttestation {
+		attestation := &token.CommitAttestation{
+			ChannelId:       "channel-id",
+			BlockNumber:     7,
+			BlockHash:       []byte(blockHash),
+			ValidationFlags: []byte{uint8(pb.TxValidationCode_VALID), uint8(code)},
+			TxId:            "tx1",
+			TxIndex:         1,
+			Peer:            []byte(peer),
+		}
+		return &token.SignedCommitAttestation{Attestation: ProtoMarshal(attestation), Signature: []byte("signature")}
+	}
+
+	BeforeEach(func() {
+		fakePeer = &idmock.Identity{}
+		fakePeer.GetMSPIdentifierReturns("Org1MSP")
+		fakeDeserializer = &idmock.Deserializer{}
+		fakeDeserializer.DeserializeIdentityReturns(fakePeer, nil)
+
+		fakeProvers = nil
+		checker = &client.FinalityChecker{Deserializer: fakeDeserializer, Attestations: 2}
+		for _, peer := range []string{"peer0", "peer1", "peer2"} {
+			fakeProver := &mock.CommitAttestationProver{}
+			fakeProver.RequestCommitAttestationContextReturns(signedAttestation(peer, "hash", pb.TxValidationCode_VALID), nil)
+			fakeProvers = append(fakeProvers, fakeProver)
+			checker.Provers = append(checker.Provers, fakeProver)
+		}
+	})
+
+	It("returns the attestations of the peers once enough of them attest the transaction", func() {
+		attestations, err := checker.CheckFinality(context.Background(), "channel-id", "tx1", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(attestations).To(HaveLen(2))
+		Expect(attestations[0].Peer).To(Equal([]byte("peer0")))
+		Expect(attestations[1].Peer).To(Equal([]byte("peer1")))
+
+		_, txID, _ := fakeProvers[0].RequestCommitAttestationContextArgsForCall(0)
+		Expect(txID).To(Equal("tx1"))
+		Expect(fakeProvers[2].RequestCommitAttestationContextCallCount()).To(Equal(0))
+	})
+
+	It("requires the attestations of every prover by default", func() {
+		checker.Attestations = 0
+		attestations, err := checker.CheckFinality(context.Background(), "channel-id", "tx1", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(attestations).To(HaveLen(3))
+	})
+
+	It("skips the peers that fail to attest the transaction", func() {
+		fakeProvers[0].RequestCommitAttestationContextReturns(nil, errors.New("not committed yet"))
+		attestations, err := checker.CheckFinality(context.Background(), "channel-id", "tx1", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(attestations[0].Peer).To(Equal([]byte("peer1")))
+		Expect(attestations[1].Peer).To(Equal([]byte("peer2")))
+	})
+
+	It("counts the attestations of a peer once", func() {
+		fakeProvers[1].RequestCommitAttestationContextReturns(signedAttestation("peer0", "hash", pb.TxValidationCode_VALID), nil)
+		fakeProvers[2].RequestCommitAttestationContextReturns(nil, errors.New("unavailable"))
+		_, err := checker.CheckFinality(context.Background(), "channel-id", "tx1", nil)
+		Expect(err).To(MatchError("1 of 2 peers attest that transaction tx1 committed: [unavailable]"))
+	})
+
+	It("rejects the attestations of peers of untrusted MSPs", func() {
+		checker.MSPIDs = []string{"Org2MSP"}
+		_, err := checker.CheckFinality(context.Background(), "channel-id", "tx1", nil)
+		Expect(err).To(MatchError(ContainSubstring("0 of 2 peers attest that transaction tx1 committed")))
+		Expect(err.Error()).To(ContainSubstring("peer of MSP 'Org1MSP' is not trusted to attest commits"))
+	})
+
+	It("rejects the attestations of other transactions", func() {
+		_, err := checker.CheckFinality(context.Background(), "channel-id", "tx2", nil)
+		Expect(err).To(MatchError(ContainSubstring("commit attestation is for transaction tx1 of channel channel-id")))
+	})
+
+	Context("when a peer attests that the transaction is invalid", func() {
+		BeforeEach(func() {
+			fakeProvers[1].RequestCommitAttestationContextReturns(signedAttestation("peer1", "hash", pb.TxValidationCode_MVCC_READ_CONFLICT), nil)
+		})
+
+		It("returns an error", func() {
+			_, err := checker.CheckFinality(context.Background(), "channel-id", "tx1", nil)
+			Expect(err).To(MatchError("a peer attests that transaction tx1 is invalid: MVCC_READ_CONFLICT"))
+		})
+	})
+
+	Context("when peers attest distinct blocks", func() {
+		BeforeEach(func() {
+			fakeProvers[1].RequestCommitAttestationContextReturns(signedAttestation("peer1", "fork", pb.TxValidationCode_VALID), nil)
+		})
+
+		It("returns an error", func() {
+			_, err := checker.CheckFinality(context.Background(), "channel-id", "tx1", nil)
+			Expect(err).To(MatchError("peers attest that transaction tx1 committed in distinct blocks 7 and 7"))
+		})
+	})
+})
+
+var _ = Describe("VerifyCommitAttestation", func() {
+	var (
+		fakeDeserializer *idmock.Deserializer
+		fakePeer         *idmock.Identity
+		attestation      *token.CommitAttestation
+	)
+
+	BeforeEach(func() {
+		fakePeer = &idmock.Identity{}
+		fakeDeserializer = &idmock.Deserializer{}
+		fakeDeserializer.DeserializeIdentityReturns(fakePeer, nil)
+		attestation = &token.CommitAttestation{BlockNumber: 7, ValidationFlags: []byte{0}, TxId: "tx1", Peer: []byte("peer0")}
+	})
+
+	It("returns the attestation signed by the peer", func() {
+		signed := &token.SignedCommitAttestation{Attestation: ProtoMarshal(attestation), Signature: []byte("signature")}
+		verified, err := client.VerifyCommitAttestation(signed, fakeDeserializer)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(verified.TxId).To(Equal("tx1"))
+		msg, signature := fakePeer.VerifyArgsForCall(0)
+		Expect(msg).To(Equal(signed.Attestation))
+		Expect(signature).To(Equal([]byte("signature")))
+
+		fakePeer.VerifyReturns(errors.New("bad signature"))
+		_, err = client.VerifyCommitAttestation(signed, fakeDeserializer)
+		Expect(err).To(MatchError("invalid commit attestation signature: bad signature"))
+	})
+
+	It("rejects the attestations without the validation code of the transaction", func() {
+		attestation.TxIndex = 1
+		signed := &token.SignedCommitAttestation{Attestation: ProtoMarshal(attestation)}
+		_, err := client.VerifyCommitAttestation(signed, fakeDeserializer)
+		Expect(err).To(MatchError("commit attestation of block 7 has no validation code for transaction tx1"))
+	})
+})
@@ -0,0 +1,255 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/crypto"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	peercommon "github.com/hyperledger/fabric/peer/common"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/pkg/errors"
+)
+
+// GatewaySubmitter submits token transactions through the token gateway of a
+// peer, which sends them to the orderers, retrying on failure, and waits for
+// their commit on behalf of the client. As it only connects to the gateway
+// peer, it suits constrained clients such as mobile or IoT devices.
+type GatewaySubmitter struct {
+	Config        *ClientConfig
+	Signer        SignerIdentity
+	Creator       []byte
+	GatewayClient token.GatewayClient
+	// Capabilities, when set, tells whether the channel invalidates expired
+	// transactions; the transactions expire Config.TxTTL after their
+	// creation only when it does.
+	Capabilities *ChannelCapabilities
+
+	closed int32
+}
+
+// NewGatewaySubmitter creates a GatewaySubmitter connected to the gateway
+// peer of the client config. When the address resolves to several backends,
+// transactions are balanced across them.
+func NewGatewaySubmitter(config *ClientConfig) (*GatewaySubmitter, error) {
+	err := ValidateClientConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	if config.GatewayPeerCfg.Address == "" {
+		return nil, errors.New("missing gateway peer address")
+	}
+
+	// TODO: make mspType configurable
+	mspType := "bccsp"
+	peercommon.InitCrypto(config.MspDir, config.MspId, mspType)
+
+	signer, err := mspmgmt.GetLocalMSP().GetDefaultSigningIdentity()
+	if err != nil {
+		return nil, err
+	}
+	creator, err := signer.Serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	grpcClient, err := createGrpcClient(&config.GatewayPeerCfg, config.TlsEnabled)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to create a GRPCClient to gateway peer %s", config.GatewayPeerCfg.Address))
+	}
+	address := config.GatewayPeerCfg.Address
+	gatewayClient := &balancedGatewayClient{
+		endpoints: NewEndpoints(address),
+		conns:     &connectionCache{grpcClient: grpcClient, serverName: serverName(address, config.GatewayPeerCfg.ServerNameOverride)},
+	}
+	// connect to a first backend to report misconfigurations early
+	backend, err := gatewayClient.endpoints.Next(context.Background())
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to resolve gateway peer %s", address))
+	}
+	_, err = gatewayClient.conns.get(backend)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to connect to gateway peer %s", address))
+	}
+
+	s := &GatewaySubmitter{
+		Config:        config,
+		Signer:        signer,
+		Creator:       creator,
+		GatewayClient: gatewayClient,
+	}
+	runtime.SetFinalizer(s, func(s *GatewaySubmitter) {
+		if atomic.LoadInt32(&s.closed) == 0 {
+			logger.Warningf("GatewaySubmitter of channel %s was not closed; its connections to gateway peer %s leaked", config.ChannelId, address)
+		}
+	})
+	return s, nil
+}
+
+// Close closes the connections to the gateway peer, and returns ErrClosed if
+// they were already closed. The submitter cannot be used afterwards. The
+// transactions already accepted by the gateway are still submitted.
+func (s *GatewaySubmitter) Close() error {
+	if !atomic.CompareAndSwapInt32(&s.closed, 0, 1) {
+		return ErrClosed
+	}
+	return closeAll(s.GatewayClient)
+}
+
+// CreateTxEnvelope wraps the serialized token transaction in an envelope
+// signed by the client. Unlike with a TxSubmitter, the header is not bound
+// to a TLS certificate, as the gateway, not the client, connects to the
+// orderers.
+func (s *GatewaySubmitter) CreateTxEnvelope(txBytes []byte) (string, *common.Envelope, error) {
+	var hashingSuite func([]byte) []byte
+	if s.Config.HashingSuite != "" {
+		var err error
+		hashingSuite, err = channelconfig.HashingSuiteByName(s.Config.HashingSuite)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	ttl, err := s.Config.txTTL(s.Capabilities)
+	if err != nil {
+		return "", nil, err
+	}
+
+	txid, header, err := CreateHeaderWithTTL(common.HeaderType_TOKEN_TRANSACTION, s.Config.ChannelId, s.Creator, nil, hashingSuite, ttl)
+	if err != nil {
+		return txid, nil, err
+	}
+
+	txEnvelope, err := CreateEnvelope(txBytes, header, s.Signer)
+	return txid, txEnvelope, err
+}
+
+// RequestCommitAttestationContext returns an attestation of the validation
+// results of the block holding the transaction of the ID, signed by the
+// gateway peer, which must serve commit attestations. VerifyCommitAttestation
+// checks it.
+func (s *GatewaySubmitter) RequestCommitAttestationContext(ctx context.Context, txID string, signingIdentity tk.SigningIdentity) (*token.SignedCommitAttestation, error) {
+	extensions := tk.HeaderExtensions(ctx)
+	err := tk.ValidateHeaderExtensions(extensions, tk.DefaultHeaderExtensionValidators)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := crypto.GetRandomNonce()
+	if err != nil {
+		return nil, err
+	}
+	creator, err := signingIdentity.GetPublicVersion().Serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	command := &token.Command{
+		Header: &token.Header{
+			Timestamp:  ptypes.TimestampNow(),
+			Nonce:      nonce,
+			Creator:    creator,
+			ChannelId:  s.Config.ChannelId,
+			Extensions: extensions,
+		},
+		Payload: &token.Command_CommitAttestationRequest{CommitAttestationRequest: &token.CommitAttestationRequest{TxId: txID}},
+	}
+	sc, err := signCommand(command, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	attestation, err := s.GatewayClient.AttestCommit(ctx, sc)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("gateway failed attesting the commit of transaction %s", txID))
+	}
+	return attestation, nil
+}
+
+// SubmitTransaction submits a token transaction through the gateway.
+// The 'waitTimeInSeconds' indicates how long to wait for the transaction to be committed.
+// If it is 0, the function returns once the transaction is ordered.
+// If it is greater than 0, the function waits until timeout or the transaction is committed, whichever is earlier.
+func (s *GatewaySubmitter) SubmitTransaction(txEnvelope *common.Envelope, waitTimeInSeconds int) (committed bool, txId string, err error) {
+	if waitTimeInSeconds > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*time.Duration(waitTimeInSeconds))
+		defer cancel()
+		return s.SubmitTransactionContext(ctx, txEnvelope)
+	}
+	txId, err = s.submit(context.Background(), txEnvelope, false, token.SubmitStatus_ORDERED)
+	return false, txId, err
+}
+
+// SubmitTransactionContext submits a token transaction through the gateway
+// and waits for it to be committed until ctx is done. The gateway keeps
+// submitting the transaction if ctx is done first.
+func (s *GatewaySubmitter) SubmitTransactionContext(ctx context.Context, txEnvelope *common.Envelope) (committed bool, txId string, err error) {
+	txId, err = s.submit(ctx, txEnvelope, true, token.SubmitStatus_COMMITTED)
+	return err == nil, txId, err
+}
+
+// SubmitAndForget hands a token transaction over to the gateway and returns
+// as soon as the gateway accepted it, leaving the submission to the orderers
+// and the wait for the commit to the gateway. The client finds out whether
+// the transaction committed by querying the ledger, e.g. through the prover.
+func (s *GatewaySubmitter) SubmitAndForget(txEnvelope *common.Envelope) (txId string, err error) {
+	return s.submit(context.Background(), txEnvelope, false, token.SubmitStatus_ACCEPTED)
+}
+
+// submit submits the transaction and reads its progress until the gateway
+// reports the phase until, the transaction fails or ctx is done.
+func (s *GatewaySubmitter) submit(ctx context.Context, txEnvelope *common.Envelope, waitForCommit bool, until token.SubmitStatus_Phase) (string, error) {
+	if atomic.LoadInt32(&s.closed) != 0 {
+		return "", ErrClosed
+	}
+	txid, err := getTransactionId(txEnvelope)
+	if err != nil {
+		return "", err
+	}
+	raw, err := proto.Marshal(txEnvelope)
+	if err != nil {
+		return txid, errors.Wrap(err, "failed to marshal transaction envelope")
+	}
+
+	// canceling the stream does not stop the submission in the gateway
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := s.GatewayClient.Submit(ctx, &token.SubmitRequest{Envelope: raw, WaitForCommit: waitForCommit})
+	if err != nil {
+		return txid, errors.Wrapf(err, "failed to submit transaction to gateway peer %s", s.Config.GatewayPeerCfg.Address)
+	}
+
+	for {
+		submitStatus, err := stream.Recv()
+		if err == io.EOF {
+			return txid, errors.Errorf("gateway peer %s stopped reporting transaction %s before %s", s.Config.GatewayPeerCfg.Address, txid, until)
+		}
+		if err != nil {
+			return txid, errors.Wrapf(err, "failed to receive status of transaction %s from gateway peer %s", txid, s.Config.GatewayPeerCfg.Address)
+		}
+		logger.Debugf("gateway peer reported transaction %s as %s", txid, submitStatus.Phase)
+
+		switch submitStatus.Phase {
+		case token.SubmitStatus_FAILED:
+			return txid, errors.Errorf("gateway peer %s failed submitting transaction %s: %s", s.Config.GatewayPeerCfg.Address, txid, submitStatus.Message)
+		case token.SubmitStatus_INVALID:
+			return txid, &InvalidTxError{TxID: txid, ValidationCode: submitStatus.ValidationCode, Hotspots: submitStatus.InputHotspots}
+		}
+		if submitStatus.Phase >= until {
+			return txid, nil
+		}
+	}
+}
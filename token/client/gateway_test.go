@@ -0,0 +1,374 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client_test
+
+import (
+	"context"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/hyperledger/fabric/token/client/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("GatewaySubmitter", func() {
+	var (
+		server      *comm.GRPCServer
+		gateway     *gatewayServer
+		conn        *grpc.ClientConn
+		fakeSigner  *mock.SignerIdentity
+		txEnvelope  *common.Envelope
+		txid        string
+		txSubmitter *client.GatewaySubmitter
+	)
+
+	BeforeEach(func() {
+		var err error
+		server, err = comm.NewGRPCServer("127.0.0.1:0", comm.ServerConfig{})
+		Expect(err).NotTo(HaveOccurred())
+		gateway = &gatewayServer{}
+		token.RegisterGatewayServer(server.Server(), gateway)
+		go server.Start()
+
+		conn, err = grpc.Dial(server.Address(), grpc.WithInsecure())
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeSigner = &mock.SignerIdentity{}
+		fakeSigner.SignReturns([]byte("envelope-signature"), nil)
+
+		txSubmitter = &client.GatewaySubmitter{
+			Config: &client.ClientConfig{
+				ChannelId:      "test-channel",
+				GatewayPeerCfg: client.ConnectionConfig{Address: server.Address()},
+			},
+			Signer:        fakeSigner,
+			Creator:       []byte("creator"),
+			GatewayClient: token.NewGatewayClient(conn),
+		}
+		txid, txEnvelope, err = txSubmitter.CreateTxEnvelope([]byte("tx-bytes"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		conn.Close()
+		server.Stop()
+	})
+
+	Describe("CreateTxEnvelope", func() {
+		It("returns a token transaction envelope without TLS binding", func() {
+			payload, err := utils.UnmarshalPayload(txEnvelope.Payload)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(payload.Data).To(Equal([]byte("tx-bytes")))
+
+			chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(chdr.Type).To(Equal(int32(common.HeaderType_TOKEN_TRANSACTION)))
+			Expect(chdr.ChannelId).To(Equal("test-channel"))
+			Expect(chdr.TxId).To(Equal(txid))
+			Expect(chdr.TlsCertHash).To(BeNil())
+
+			Expect(txEnvelope.Signature).To(Equal([]byte("envelope-signature")))
+		})
+	})
+
+	Describe("SubmitTransaction", func() {
+		BeforeEach(func() {
+			gateway.statuses = []token.SubmitStatus_Phase{token.SubmitStatus_ACCEPTED, token.SubmitStatus_ORDERED, token.SubmitStatus_COMMITTED}
+		})
+
+		It("waits for the transaction to be committed", func() {
+			committed, id, err := txSubmitter.SubmitTransaction(txEnvelope, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(committed).To(BeTrue())
+			Expect(id).To(Equal(txid))
+
+			req := gateway.request()
+			Expect(req.WaitForCommit).To(BeTrue())
+			Expect(req.Envelope).To(Equal(ProtoMarshal(txEnvelope)))
+		})
+
+		Context("when the wait time is 0", func() {
+			BeforeEach(func() {
+				gateway.statuses = []token.SubmitStatus_Phase{token.SubmitStatus_ACCEPTED, token.SubmitStatus_ORDERED}
+			})
+
+			It("returns once the transaction is ordered", func() {
+				committed, id, err := txSubmitter.SubmitTransaction(txEnvelope, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(committed).To(BeFalse())
+				Expect(id).To(Equal(txid))
+				Expect(gateway.request().WaitForCommit).To(BeFalse())
+			})
+		})
+
+		Context("when the transaction is invalid", func() {
+			BeforeEach(func() {
+				gateway.statuses = []token.SubmitStatus_Phase{token.SubmitStatus_ACCEPTED, token.SubmitStatus_ORDERED, token.SubmitStatus_INVALID}
+				gateway.validationCode = "MVCC_READ_CONFLICT"
+				gateway.hotspots = []*token.InputHotspot{{Namespace: "tms", Key: "key1", Conflicts: 2, TxIds: []string{"tx1", "tx2"}, TokenId: []byte("id1")}}
+			})
+
+			It("returns an error", func() {
+				committed, _, err := txSubmitter.SubmitTransaction(txEnvelope, 10)
+				Expect(err).To(MatchError("transaction [" + txid + "] status is not valid: MVCC_READ_CONFLICT"))
+				Expect(committed).To(BeFalse())
+			})
+
+			It("returns the conflict hotspots reported by the gateway", func() {
+				_, _, err := txSubmitter.SubmitTransaction(txEnvelope, 10)
+				Expect(err).To(BeAssignableToTypeOf(&client.InvalidTxError{}))
+				invalid := err.(*client.InvalidTxError)
+				Expect(invalid.TxID).To(Equal(txid))
+				Expect(invalid.ValidationCode).To(Equal("MVCC_READ_CONFLICT"))
+				Expect(invalid.Hotspots).To(HaveLen(1))
+				Expect(invalid.Hotspots[0].TokenId).To(Equal([]byte("id1")))
+			})
+		})
+
+		Context("when the gateway fails submitting the transaction", func() {
+			BeforeEach(func() {
+				gateway.statuses = []token.SubmitStatus_Phase{token.SubmitStatus_ACCEPTED, token.SubmitStatus_FAILED}
+				gateway.message = "failed broadcasting transaction: mango"
+			})
+
+			It("returns an error", func() {
+				_, _, err := txSubmitter.SubmitTransaction(txEnvelope, 10)
+				Expect(err).To(MatchError("gateway peer " + server.Address() + " failed submitting transaction " + txid + ": failed broadcasting transaction: mango"))
+			})
+		})
+
+		Context("when the gateway stops reporting early", func() {
+			BeforeEach(func() {
+				gateway.statuses = []token.SubmitStatus_Phase{token.SubmitStatus_ACCEPTED}
+			})
+
+			It("returns an error", func() {
+				_, _, err := txSubmitter.SubmitTransaction(txEnvelope, 10)
+				Expect(err).To(MatchError("gateway peer " + server.Address() + " stopped reporting transaction " + txid + " before COMMITTED"))
+			})
+		})
+
+		Context("when the gateway rejects the transaction", func() {
+			BeforeEach(func() {
+				gateway.err = status.Error(codes.PermissionDenied, "access denied")
+			})
+
+			It("returns an error", func() {
+				_, _, err := txSubmitter.SubmitTransaction(txEnvelope, 10)
+				Expect(status.Code(errors.Cause(err))).To(Equal(codes.PermissionDenied))
+			})
+		})
+	})
+
+	Describe("SubmitTransactionContext", func() {
+		Context("when ctx is done before the commit", func() {
+			BeforeEach(func() {
+				gateway.statuses = []token.SubmitStatus_Phase{token.SubmitStatus_ACCEPTED, token.SubmitStatus_ORDERED}
+				gateway.block = make(chan struct{})
+			})
+
+			AfterEach(func() {
+				close(gateway.block)
+			})
+
+			It("returns an error", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				go func() {
+					Eventually(gateway.sent).Should(Equal(2))
+					cancel()
+				}()
+
+				committed, id, err := txSubmitter.SubmitTransactionContext(ctx, txEnvelope)
+				Expect(status.Code(errors.Cause(err))).To(Equal(codes.Canceled))
+				Expect(committed).To(BeFalse())
+				Expect(id).To(Equal(txid))
+			})
+		})
+	})
+
+	Describe("SubmitAndForget", func() {
+		BeforeEach(func() {
+			gateway.statuses = []token.SubmitStatus_Phase{token.SubmitStatus_ACCEPTED}
+			gateway.block = make(chan struct{})
+		})
+
+		AfterEach(func() {
+			close(gateway.block)
+		})
+
+		It("returns once the gateway accepted the transaction", func() {
+			id, err := txSubmitter.SubmitAndForget(txEnvelope)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal(txid))
+			Expect(gateway.request().WaitForCommit).To(BeFalse())
+		})
+	})
+
+	Describe("RequestCommitAttestationContext", func() {
+		var (
+			fakeSigningIdentity *mock.SigningIdentity
+			attestation         *token.SignedCommitAttestation
+		)
+
+		BeforeEach(func() {
+			fakeIdentity := &mock.Identity{}
+			fakeIdentity.SerializeReturns([]byte("Alice"), nil)
+			fakeSigningIdentity = &mock.SigningIdentity{}
+			fakeSigningIdentity.GetPublicVersionReturns(fakeIdentity)
+			fakeSigningIdentity.SignReturns([]byte("command-signature"), nil)
+
+			attestation = &token.SignedCommitAttestation{Attestation: []byte("attestation"), Signature: []byte("signature")}
+			gateway.attestation = attestation
+		})
+
+		It("requests the attestation with a signed command", func() {
+			resp, err := txSubmitter.RequestCommitAttestationContext(context.Background(), "tx-id", fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(resp, attestation)).To(BeTrue())
+
+			sc := gateway.attestationRequest()
+			Expect(sc.Signature).To(Equal([]byte("command-signature")))
+			command := &token.Command{}
+			Expect(proto.Unmarshal(sc.Command, command)).To(Succeed())
+			Expect(command.Header.ChannelId).To(Equal("test-channel"))
+			Expect(command.Header.Creator).To(Equal([]byte("Alice")))
+			Expect(command.Header.Nonce).NotTo(BeEmpty())
+			Expect(command.GetCommitAttestationRequest().TxId).To(Equal("tx-id"))
+		})
+
+		Context("when the gateway fails", func() {
+			BeforeEach(func() {
+				gateway.err = status.Error(codes.Unimplemented, "commit attestations are not enabled on this peer")
+			})
+
+			It("returns the error", func() {
+				_, err := txSubmitter.RequestCommitAttestationContext(context.Background(), "tx-id", fakeSigningIdentity)
+				Expect(err).To(MatchError(ContainSubstring("gateway failed attesting the commit of transaction tx-id")))
+				Expect(err).To(MatchError(ContainSubstring("commit attestations are not enabled on this peer")))
+			})
+		})
+	})
+
+	Describe("Close", func() {
+		It("returns ErrClosed when already closed", func() {
+			Expect(txSubmitter.Close()).To(Succeed())
+			Expect(txSubmitter.Close()).To(Equal(client.ErrClosed))
+
+			_, err := txSubmitter.SubmitAndForget(txEnvelope)
+			Expect(err).To(Equal(client.ErrClosed))
+		})
+	})
+
+	Describe("NewGatewaySubmitter", func() {
+		Context("when the gateway peer address is missing", func() {
+			It("returns an error", func() {
+				_, err := client.NewGatewaySubmitter(&client.ClientConfig{
+					ChannelId:  "test-channel",
+					OrdererCfg: client.ConnectionConfig{Address: "orderer:7050"},
+				})
+				Expect(err).To(MatchError("missing gateway peer address"))
+			})
+		})
+	})
+})
+
+// gatewayServer is a token gateway reporting the statuses, or failing with
+// err. When block is set, it keeps the stream open after the statuses until
+// block is closed. It returns attestation to the commit attestation requests.
+type gatewayServer struct {
+	statuses       []token.SubmitStatus_Phase
+	validationCode string
+	hotspots       []*token.InputHotspot
+	message        string
+	err            error
+	block          chan struct{}
+	attestation    *token.SignedCommitAttestation
+
+	mutex sync.Mutex
+	req   *token.SubmitRequest
+	sc    *token.SignedCommand
+	count int
+}
+
+func (g *gatewayServer) AttestCommit(ctx context.Context, sc *token.SignedCommand) (*token.SignedCommitAttestation, error) {
+	g.mutex.Lock()
+	g.sc = sc
+	g.mutex.Unlock()
+	if g.err != nil {
+		return nil, g.err
+	}
+	return g.attestation, nil
+}
+
+func (g *gatewayServer) Submit(req *token.SubmitRequest, stream token.Gateway_SubmitServer) error {
+	g.mutex.Lock()
+	g.req = req
+	g.mutex.Unlock()
+	if g.err != nil {
+		return g.err
+	}
+
+	envelope, err := utils.UnmarshalEnvelope(req.Envelope)
+	if err != nil {
+		return err
+	}
+	chdr, err := utils.ChannelHeader(envelope)
+	if err != nil {
+		return err
+	}
+	for _, phase := range g.statuses {
+		s := &token.SubmitStatus{TxId: chdr.TxId, Phase: phase}
+		switch phase {
+		case token.SubmitStatus_INVALID:
+			s.ValidationCode = g.validationCode
+			s.InputHotspots = g.hotspots
+		case token.SubmitStatus_FAILED:
+			s.Message = g.message
+		}
+		if err := stream.Send(s); err != nil {
+			return err
+		}
+		g.mutex.Lock()
+		g.count++
+		g.mutex.Unlock()
+	}
+	if g.block != nil {
+		select {
+		case <-g.block:
+		case <-stream.Context().Done():
+		}
+	}
+	return nil
+}
+
+func (g *gatewayServer) request() *token.SubmitRequest {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return proto.Clone(g.req).(*token.SubmitRequest)
+}
+
+func (g *gatewayServer) attestationRequest() *token.SignedCommand {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return proto.Clone(g.sc).(*token.SignedCommand)
+}
+
+func (g *gatewayServer) sent() int {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.count
+}
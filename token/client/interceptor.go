@@ -0,0 +1,77 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric/protos/token"
+)
+
+// Method is a method of the Client passing through its interceptors.
+type Method string
+
+const (
+	MethodIssue      Method = "Issue"
+	MethodTransfer   Method = "Transfer"
+	MethodRedeem     Method = "Redeem"
+	MethodListTokens Method = "ListTokens"
+)
+
+// Request is the request to a method of the Client. Only the fields of the
+// method are set; interceptors may change them before invoking the method.
+type Request struct {
+	Method Method
+	// TokensToIssue are the tokens issued by Issue
+	TokensToIssue []*token.TokenToIssue
+	// SeriesID is the series of the import of Issue
+	SeriesID []byte
+	// TokenIDs are the tokens spent by Transfer and Redeem
+	TokenIDs [][]byte
+	// Shares are the shares of the recipients of Transfer
+	Shares []*token.RecipientTransferShare
+	// Quantity is the quantity redeemed by Redeem
+	Quantity uint64
+	// Reference is the application reference of the transaction of Issue
+	// and Transfer
+	Reference []byte
+}
+
+// Response is the response of a method of the Client.
+type Response struct {
+	// Transaction is the envelope of the transaction submitted by Issue,
+	// Transfer and Redeem; it is set even when the submission fails
+	Transaction []byte
+	// Tokens are the tokens listed by ListTokens
+	Tokens []*token.TokenOutput
+}
+
+// Invoker invokes the method of the request, or the next interceptor.
+type Invoker func(ctx context.Context, request *Request) (*Response, error)
+
+// Interceptor intercepts the invocations of the methods of the Client, like
+// a gRPC unary interceptor. It may log or measure the invocation, change the
+// request, and invoke the method several times or not at all.
+type Interceptor func(ctx context.Context, request *Request, invoker Invoker) (*Response, error)
+
+// ChainInterceptors returns an interceptor running the passed interceptors
+// in order, the first one being the outermost.
+func ChainInterceptors(interceptors ...Interceptor) Interceptor {
+	return func(ctx context.Context, request *Request, invoker Invoker) (*Response, error) {
+		return chain(interceptors, invoker)(ctx, request)
+	}
+}
+
+func chain(interceptors []Interceptor, invoker Invoker) Invoker {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, request *Request) (*Response, error) {
+			return interceptor(ctx, request, next)
+		}
+	}
+	return invoker
+}
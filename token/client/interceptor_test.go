@@ -0,0 +1,175 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client_test
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/hyperledger/fabric/token/client/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Interceptors", func() {
+	var (
+		fakeSigningIdentity *mock.SigningIdentity
+		fakeProver          *mock.Prover
+		fakeTxSubmitter     *mock.FabricTxSubmitter
+		calls               []string
+
+		tokenClient *client.Client
+	)
+
+	recorder := func(name string) client.Interceptor {
+		return func(ctx context.Context, request *client.Request, invoker client.Invoker) (*client.Response, error) {
+			calls = append(calls, name+" "+string(request.Method))
+			response, err := invoker(ctx, request)
+			calls = append(calls, name+" done")
+			return response, err
+		}
+	}
+
+	BeforeEach(func() {
+		calls = nil
+
+		fakeProver = &mock.Prover{}
+		fakeProver.RequestImportReturns([]byte("tx-payload"), nil)
+		fakeProver.RequestTransferReturns([]byte("tx-payload"), nil)
+		fakeProver.RequestRedeemReturns([]byte("tx-payload"), nil)
+		fakeProver.ListTokensReturns([]*token.TokenOutput{{Id: []byte("id1")}}, nil)
+
+		fakeSigningIdentity = &mock.SigningIdentity{}
+		fakeSigningIdentity.SignReturns([]byte("tx-signature"), nil)
+
+		fakeTxSubmitter = &mock.FabricTxSubmitter{}
+
+		tokenClient = &client.Client{
+			SigningIdentity: fakeSigningIdentity,
+			Prover:          fakeProver,
+			TxSubmitter:     fakeTxSubmitter,
+			Interceptors:    []client.Interceptor{recorder("outer"), recorder("inner")},
+		}
+	})
+
+	It("intercepts every method in order", func() {
+		_, err := tokenClient.Issue(nil)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = tokenClient.Transfer(nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = tokenClient.Redeem(nil, 1)
+		Expect(err).NotTo(HaveOccurred())
+		tokens, err := tokenClient.ListTokens()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tokens).To(HaveLen(1))
+
+		Expect(calls).To(Equal([]string{
+			"outer Issue", "inner Issue", "inner done", "outer done",
+			"outer Transfer", "inner Transfer", "inner done", "outer done",
+			"outer Redeem", "inner Redeem", "inner done", "outer done",
+			"outer ListTokens", "inner ListTokens", "inner done", "outer done",
+		}))
+	})
+
+	It("passes the requests of the methods", func() {
+		var requests []client.Request
+		tokenClient.Interceptors = []client.Interceptor{
+			func(ctx context.Context, request *client.Request, invoker client.Invoker) (*client.Response, error) {
+				requests = append(requests, *request)
+				return invoker(ctx, request)
+			},
+		}
+
+		tokensToIssue := []*token.TokenToIssue{{Type: "ABC", Quantity: 1}}
+		_, err := tokenClient.IssueSeries(tokensToIssue, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		shares := []*token.RecipientTransferShare{{Recipient: []byte("bob"), Quantity: 1}}
+		_, err = tokenClient.Transfer([][]byte{[]byte("id1")}, shares)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = tokenClient.Redeem([][]byte{[]byte("id2")}, 5)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(requests).To(Equal([]client.Request{
+			{Method: client.MethodIssue, TokensToIssue: tokensToIssue},
+			{Method: client.MethodTransfer, TokenIDs: [][]byte{[]byte("id1")}, Shares: shares},
+			{Method: client.MethodRedeem, TokenIDs: [][]byte{[]byte("id2")}, Quantity: 5},
+		}))
+	})
+
+	It("lets interceptors change the request", func() {
+		tokenClient.Interceptors = []client.Interceptor{
+			func(ctx context.Context, request *client.Request, invoker client.Invoker) (*client.Response, error) {
+				request.Quantity = 7
+				return invoker(ctx, request)
+			},
+		}
+
+		_, err := tokenClient.Redeem([][]byte{[]byte("id1")}, 5)
+		Expect(err).NotTo(HaveOccurred())
+		_, quantity, _ := fakeProver.RequestRedeemArgsForCall(0)
+		Expect(quantity).To(Equal(uint64(7)))
+	})
+
+	It("lets interceptors retry", func() {
+		fakeProver.RequestImportReturnsOnCall(0, nil, errors.New("unavailable"))
+		tokenClient.Interceptors = []client.Interceptor{
+			func(ctx context.Context, request *client.Request, invoker client.Invoker) (*client.Response, error) {
+				response, err := invoker(ctx, request)
+				if err != nil {
+					response, err = invoker(ctx, request)
+				}
+				return response, err
+			},
+		}
+
+		tx, err := tokenClient.Issue(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tx).NotTo(BeEmpty())
+		Expect(fakeProver.RequestImportCallCount()).To(Equal(2))
+		Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(1))
+	})
+
+	It("lets interceptors short-circuit the method", func() {
+		tokenClient.Interceptors = []client.Interceptor{
+			func(ctx context.Context, request *client.Request, invoker client.Invoker) (*client.Response, error) {
+				return nil, errors.New("denied")
+			},
+		}
+
+		_, err := tokenClient.Transfer(nil, nil)
+		Expect(err).To(MatchError("denied"))
+		_, err = tokenClient.ListTokens()
+		Expect(err).To(MatchError("denied"))
+		Expect(fakeProver.RequestTransferCallCount()).To(Equal(0))
+		Expect(fakeProver.ListTokensCallCount()).To(Equal(0))
+	})
+
+	It("returns the transaction when its submission fails", func() {
+		fakeTxSubmitter.SubmitReturns(errors.New("wild-banana"))
+
+		tx, err := tokenClient.Issue(nil)
+		Expect(err).To(MatchError("wild-banana"))
+		Expect(tx).NotTo(BeEmpty())
+		Expect(calls).To(Equal([]string{"outer Issue", "inner Issue", "inner done", "outer done"}))
+	})
+
+	Describe("ChainInterceptors", func() {
+		It("runs the interceptors in order", func() {
+			chained := client.ChainInterceptors(recorder("first"), recorder("second"))
+			invoker := func(ctx context.Context, request *client.Request) (*client.Response, error) {
+				calls = append(calls, "invoked")
+				return &client.Response{}, nil
+			}
+
+			_, err := chained(context.Background(), &client.Request{Method: client.MethodListTokens}, invoker)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(calls).To(Equal([]string{"first ListTokens", "second ListTokens", "invoked", "second done", "first done"}))
+		})
+	})
+})
@@ -0,0 +1,259 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// The outcomes of the commands recorded in a Journal.
+const (
+	OutcomeSubmitted = "submitted"
+	OutcomeFailed    = "failed"
+)
+
+// A JournalEntry records a command of the client in a Journal. Each entry is
+// chained to the previous one by its hash, so that removing, reordering or
+// changing entries breaks the chain.
+type JournalEntry struct {
+	Sequence uint64    `json:"sequence"`
+	Time     time.Time `json:"time"`
+	Method   Method    `json:"method"`
+	Request  *Request  `json:"request"`
+	// TxID and EnvelopeHash identify the transaction of the command, when
+	// the prover assembled one
+	TxID         string `json:"txId,omitempty"`
+	EnvelopeHash []byte `json:"envelopeHash,omitempty"`
+	Outcome      string `json:"outcome"`
+	Error        string `json:"error,omitempty"`
+	// PrevHash is the hash of the previous entry, and is empty for the first one
+	PrevHash []byte `json:"prevHash,omitempty"`
+	// Hash is the SHA256 hash of the entry without Hash and Signature
+	Hash []byte `json:"hash"`
+	// Signature is the signature of Hash by the client, when the journal has a signer
+	Signature []byte `json:"signature,omitempty"`
+}
+
+// A Verifier verifies the signatures of the entries of a journal; msp.Identity
+// implements it.
+type Verifier interface {
+	Verify(msg []byte, sig []byte) error
+}
+
+// A Journal is an append-only, hash-chained log of the commands submitted by
+// a client, kept in a local file for compliance reviews. Its Intercept method
+// is an Interceptor recording every Issue, Transfer and Redeem of the client
+// with its outcome. The file holds one JSON entry per line and can be
+// exported with Export and checked for tampering with VerifyJournal.
+type Journal struct {
+	path   string
+	signer Signer
+
+	mutex    sync.Mutex
+	file     *os.File
+	sequence uint64
+	hash     []byte
+}
+
+// OpenJournal opens the journal in the file at path, creating it if needed,
+// after verifying the entries it already holds. When signer is not nil, it
+// signs the entries appended to the journal.
+func OpenJournal(path string, signer Signer) (*Journal, error) {
+	j := &Journal{path: path, signer: signer}
+
+	f, err := os.Open(path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return nil, errors.Wrapf(err, "failed opening journal %s", path)
+	default:
+		entries, err := VerifyJournal(f, nil)
+		f.Close()
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid journal "+path)
+		}
+		if len(entries) != 0 {
+			last := entries[len(entries)-1]
+			j.sequence, j.hash = last.Sequence, last.Hash
+		}
+	}
+
+	j.file, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed opening journal %s", path)
+	}
+	return j, nil
+}
+
+// Intercept implements Interceptor by recording the Issue, Transfer and
+// Redeem commands of the client. As a command missing from the journal
+// defeats its purpose, a failure to record a command is returned even if
+// the command succeeded.
+func (j *Journal) Intercept(ctx context.Context, request *Request, invoker Invoker) (*Response, error) {
+	if request.Method == MethodListTokens {
+		return invoker(ctx, request)
+	}
+
+	response, err := invoker(ctx, request)
+
+	entry := &JournalEntry{
+		Method:  request.Method,
+		Request: request,
+		Outcome: OutcomeSubmitted,
+	}
+	if response != nil && response.Transaction != nil {
+		hash := sha256.Sum256(response.Transaction)
+		entry.EnvelopeHash = hash[:]
+		if envelope, err := utils.UnmarshalEnvelope(response.Transaction); err == nil {
+			entry.TxID, _ = getTransactionId(envelope)
+		}
+	}
+	if err != nil {
+		entry.Outcome, entry.Error = OutcomeFailed, err.Error()
+	}
+
+	if journalErr := j.Append(entry); journalErr != nil {
+		if err == nil {
+			err = errors.WithMessage(journalErr, "transaction submitted but not journaled")
+		} else {
+			logger.Errorf("Failed journaling failed %s: %s", request.Method, journalErr)
+		}
+	}
+	return response, err
+}
+
+// Append completes the sequence, the time, the hashes and the signature of
+// the entry and appends it to the journal.
+func (j *Journal) Append(entry *JournalEntry) error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if j.file == nil {
+		return ErrClosed
+	}
+
+	entry.Sequence = j.sequence + 1
+	entry.Time = time.Now().UTC()
+	entry.PrevHash = j.hash
+	hash, err := entryHash(entry)
+	if err != nil {
+		return err
+	}
+	entry.Hash = hash
+	if j.signer != nil {
+		entry.Signature, err = j.signer.Sign(hash)
+		if err != nil {
+			return errors.WithMessage(err, "failed signing journal entry")
+		}
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling journal entry")
+	}
+	if _, err := j.file.Write(append(line, '\n')); err != nil {
+		return errors.Wrapf(err, "failed writing journal %s", j.path)
+	}
+	if err := j.file.Sync(); err != nil {
+		return errors.Wrapf(err, "failed syncing journal %s", j.path)
+	}
+	j.sequence, j.hash = entry.Sequence, entry.Hash
+	return nil
+}
+
+// Export writes the entries of the journal to w, for instance for a
+// compliance review.
+func (j *Journal) Export(w io.Writer) error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	f, err := os.Open(j.path)
+	if err != nil {
+		return errors.Wrapf(err, "failed opening journal %s", j.path)
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return errors.Wrapf(err, "failed exporting journal %s", j.path)
+}
+
+// Close closes the file of the journal, and returns ErrClosed if it was
+// already closed.
+func (j *Journal) Close() error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if j.file == nil {
+		return ErrClosed
+	}
+	err := j.file.Close()
+	j.file = nil
+	return err
+}
+
+// VerifyJournal reads the entries of the journal exported to r and returns
+// them, or an error if the hash chain is broken. When verifier is not nil,
+// every entry must also carry a valid signature.
+func VerifyJournal(r io.Reader, verifier Verifier) ([]*JournalEntry, error) {
+	var entries []*JournalEntry
+	var prevHash []byte
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 16<<20)
+	for scanner.Scan() {
+		entry := &JournalEntry{}
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			return nil, errors.Wrapf(err, "invalid journal entry %d", len(entries)+1)
+		}
+		if entry.Sequence != uint64(len(entries)+1) {
+			return nil, errors.Errorf("journal entry %d has sequence %d", len(entries)+1, entry.Sequence)
+		}
+		if !bytes.Equal(entry.PrevHash, prevHash) {
+			return nil, errors.Errorf("journal entry %d is not chained to the previous entry", entry.Sequence)
+		}
+		hash, err := entryHash(entry)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(entry.Hash, hash) {
+			return nil, errors.Errorf("journal entry %d does not match its hash", entry.Sequence)
+		}
+		if verifier != nil {
+			if err := verifier.Verify(entry.Hash, entry.Signature); err != nil {
+				return nil, errors.WithMessage(err, fmt.Sprintf("invalid signature of journal entry %d", entry.Sequence))
+			}
+		}
+		entries = append(entries, entry)
+		prevHash = entry.Hash
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed reading journal")
+	}
+	return entries, nil
+}
+
+// entryHash returns the hash of the entry without its hash and signature.
+func entryHash(entry *JournalEntry) ([]byte, error) {
+	e := *entry
+	e.Hash, e.Signature = nil, nil
+	raw, err := json.Marshal(&e)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling journal entry")
+	}
+	hash := sha256.Sum256(raw)
+	return hash[:], nil
+}
@@ -0,0 +1,197 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/hyperledger/fabric/token/client/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Journal", func() {
+	var (
+		dir        string
+		path       string
+		fakeSigner *mock.SignerIdentity
+		journal    *client.Journal
+		envelope   []byte
+		invoker    client.Invoker
+		issue      *client.Request
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "journal")
+		Expect(err).NotTo(HaveOccurred())
+		path = filepath.Join(dir, "journal.log")
+
+		fakeSigner = &mock.SignerIdentity{}
+		fakeSigner.SignStub = func(msg []byte) ([]byte, error) {
+			return append([]byte("signed:"), msg...), nil
+		}
+		journal, err = client.OpenJournal(path, fakeSigner)
+		Expect(err).NotTo(HaveOccurred())
+
+		envelope = ProtoMarshal(&common.Envelope{
+			Payload: ProtoMarshal(&common.Payload{
+				Header: &common.Header{
+					ChannelHeader: ProtoMarshal(&common.ChannelHeader{ChannelId: "test-channel", TxId: "tx-id"}),
+				},
+			}),
+		})
+		invoker = func(ctx context.Context, request *client.Request) (*client.Response, error) {
+			return &client.Response{Transaction: envelope}, nil
+		}
+		issue = &client.Request{
+			Method:        client.MethodIssue,
+			TokensToIssue: []*token.TokenToIssue{{Type: "USD", Quantity: 10, Recipient: []byte("alice")}},
+		}
+	})
+
+	AfterEach(func() {
+		journal.Close()
+		os.RemoveAll(dir)
+	})
+
+	export := func() []byte {
+		buf := &bytes.Buffer{}
+		Expect(journal.Export(buf)).To(Succeed())
+		return buf.Bytes()
+	}
+
+	It("records the submitted commands with their transaction", func() {
+		_, err := journal.Intercept(context.Background(), issue, invoker)
+		Expect(err).NotTo(HaveOccurred())
+
+		entries, err := client.VerifyJournal(bytes.NewReader(export()), &prefixVerifier{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+		hash := sha256.Sum256(envelope)
+		Expect(entries[0].Sequence).To(Equal(uint64(1)))
+		Expect(entries[0].Method).To(Equal(client.MethodIssue))
+		Expect(entries[0].Request.TokensToIssue[0].Recipient).To(Equal([]byte("alice")))
+		Expect(entries[0].TxID).To(Equal("tx-id"))
+		Expect(entries[0].EnvelopeHash).To(Equal(hash[:]))
+		Expect(entries[0].Outcome).To(Equal(client.OutcomeSubmitted))
+		Expect(entries[0].PrevHash).To(BeNil())
+		Expect(entries[0].Signature).To(Equal(append([]byte("signed:"), entries[0].Hash...)))
+	})
+
+	It("records the failed commands", func() {
+		_, err := journal.Intercept(context.Background(), issue, func(context.Context, *client.Request) (*client.Response, error) {
+			return nil, errors.New("mango")
+		})
+		Expect(err).To(MatchError("mango"))
+
+		entries, err := client.VerifyJournal(bytes.NewReader(export()), nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Outcome).To(Equal(client.OutcomeFailed))
+		Expect(entries[0].Error).To(Equal("mango"))
+		Expect(entries[0].EnvelopeHash).To(BeNil())
+	})
+
+	It("does not record the listing of tokens", func() {
+		_, err := journal.Intercept(context.Background(), &client.Request{Method: client.MethodListTokens}, invoker)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(export()).To(BeEmpty())
+	})
+
+	It("continues the chain of a reopened journal", func() {
+		_, err := journal.Intercept(context.Background(), issue, invoker)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(journal.Close()).To(Succeed())
+
+		journal, err = client.OpenJournal(path, fakeSigner)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = journal.Intercept(context.Background(), issue, invoker)
+		Expect(err).NotTo(HaveOccurred())
+
+		entries, err := client.VerifyJournal(bytes.NewReader(export()), &prefixVerifier{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(2))
+		Expect(entries[1].Sequence).To(Equal(uint64(2)))
+		Expect(entries[1].PrevHash).To(Equal(entries[0].Hash))
+	})
+
+	Context("when the journal is tampered with", func() {
+		var lines []string
+
+		BeforeEach(func() {
+			for i := 0; i < 3; i++ {
+				_, err := journal.Intercept(context.Background(), issue, invoker)
+				Expect(err).NotTo(HaveOccurred())
+			}
+			lines = strings.SplitAfter(string(export()), "\n")
+		})
+
+		It("detects changed entries", func() {
+			lines[1] = strings.Replace(lines[1], `"outcome":"submitted"`, `"outcome":"failed"`, 1)
+			_, err := client.VerifyJournal(strings.NewReader(strings.Join(lines, "")), nil)
+			Expect(err).To(MatchError("journal entry 2 does not match its hash"))
+		})
+
+		It("detects removed entries", func() {
+			_, err := client.VerifyJournal(strings.NewReader(lines[0]+lines[2]), nil)
+			Expect(err).To(MatchError("journal entry 2 has sequence 3"))
+		})
+
+		It("detects forged signatures", func() {
+			_, err := client.VerifyJournal(strings.NewReader(strings.Join(lines, "")), &prefixVerifier{prefix: "forged:"})
+			Expect(err).To(MatchError("invalid signature of journal entry 1: bad signature"))
+		})
+
+		It("refuses to reopen the journal", func() {
+			Expect(journal.Close()).To(Succeed())
+			lines[0] = strings.Replace(lines[0], "tx-id", "tx-id2", 1)
+			Expect(ioutil.WriteFile(path, []byte(strings.Join(lines, "")), 0600)).To(Succeed())
+
+			_, err := client.OpenJournal(path, fakeSigner)
+			Expect(err).To(MatchError("invalid journal " + path + ": journal entry 1 does not match its hash"))
+		})
+	})
+
+	Context("when the journal is closed", func() {
+		It("fails the commands it cannot record", func() {
+			Expect(journal.Close()).To(Succeed())
+			Expect(journal.Close()).To(Equal(client.ErrClosed))
+
+			response, err := journal.Intercept(context.Background(), issue, invoker)
+			Expect(err).To(MatchError("transaction submitted but not journaled: token client closed"))
+			Expect(response.Transaction).To(Equal(envelope))
+		})
+	})
+})
+
+// prefixVerifier accepts the signatures made of prefix, "signed:" by default,
+// followed by the message.
+type prefixVerifier struct {
+	prefix string
+}
+
+func (v *prefixVerifier) Verify(msg, sig []byte) error {
+	prefix := v.prefix
+	if prefix == "" {
+		prefix = "signed:"
+	}
+	if !bytes.Equal(sig, append([]byte(prefix), msg...)) {
+		return errors.New("bad signature")
+	}
+	return nil
+}
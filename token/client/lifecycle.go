@@ -0,0 +1,147 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrClosed is returned when a client is used, or closed again, after it was
+// closed.
+var ErrClosed = errors.New("token client closed")
+
+// closeTimeout is how long Close waits for the goroutines of a client to stop.
+const closeTimeout = 5 * time.Second
+
+// tracker tracks the goroutines started by a client, so that closing the
+// client stops them and those that do not stop are reported. The zero value
+// is ready to use.
+type tracker struct {
+	mutex   sync.Mutex
+	closed  bool
+	done    chan struct{}
+	running int
+	stopped sync.WaitGroup
+}
+
+// context returns a context derived from parent that is also canceled when
+// the tracker is closed, or ErrClosed if it already is.
+func (t *tracker) context(parent context.Context) (context.Context, context.CancelFunc, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.closed {
+		return nil, nil, ErrClosed
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+	done := t.doneCh()
+	go func() {
+		select {
+		case <-done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel, nil
+}
+
+// goroutine runs f in a tracked goroutine, or returns ErrClosed if the
+// tracker is closed. f must return once the contexts of the tracker are
+// canceled.
+func (t *tracker) goroutine(f func()) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.closed {
+		return ErrClosed
+	}
+
+	t.running++
+	t.stopped.Add(1)
+	go func() {
+		defer t.stopped.Done()
+		defer t.exited()
+		f()
+	}()
+	return nil
+}
+
+func (t *tracker) exited() {
+	t.mutex.Lock()
+	t.running--
+	t.mutex.Unlock()
+}
+
+// goroutines returns the number of tracked goroutines that are running.
+func (t *tracker) goroutines() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.running
+}
+
+func (t *tracker) isClosed() bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.closed
+}
+
+// close cancels the contexts of the tracker and waits up to timeout for its
+// goroutines to stop. It returns ErrClosed if the tracker was already closed.
+func (t *tracker) close(timeout time.Duration) error {
+	t.mutex.Lock()
+	if t.closed {
+		t.mutex.Unlock()
+		return ErrClosed
+	}
+	t.closed = true
+	close(t.doneCh())
+	t.mutex.Unlock()
+
+	stopped := make(chan struct{})
+	go func() {
+		t.stopped.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-time.After(timeout):
+		return errors.Errorf("%d goroutines did not stop within %s", t.goroutines(), timeout)
+	}
+}
+
+// doneCh must be called with the mutex held.
+func (t *tracker) doneCh() chan struct{} {
+	if t.done == nil {
+		t.done = make(chan struct{})
+	}
+	return t.done
+}
+
+// closeAll closes the closers among components and returns their errors
+// as one.
+func closeAll(components ...interface{}) error {
+	var messages []string
+	for _, c := range components {
+		closer, ok := c.(io.Closer)
+		if !ok {
+			continue
+		}
+		if err := closer.Close(); err != nil {
+			messages = append(messages, err.Error())
+		}
+	}
+	if len(messages) != 0 {
+		return errors.Errorf("failed closing token client: %s", strings.Join(messages, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+var (
+	submissions = metrics.CounterOpts{
+		Namespace:    "token_client",
+		Name:         "submissions",
+		Help:         "The number of token transactions submitted to the orderer.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	assembleDuration = metrics.HistogramOpts{
+		Namespace:    "token_client",
+		Name:         "assemble_duration",
+		Help:         "The time for the prover peer to assemble a token transaction in seconds.",
+		LabelNames:   []string{"channel", "success"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{success}",
+	}
+	orderDuration = metrics.HistogramOpts{
+		Namespace:    "token_client",
+		Name:         "order_duration",
+		Help:         "The time for the orderer to accept a token transaction in seconds.",
+		LabelNames:   []string{"channel", "success"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{success}",
+	}
+	commitDuration = metrics.HistogramOpts{
+		Namespace:    "token_client",
+		Name:         "commit_duration",
+		Help:         "The time waited for a token transaction to be committed in seconds.",
+		LabelNames:   []string{"channel", "success"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{success}",
+	}
+	failures = metrics.CounterOpts{
+		Namespace:    "token_client",
+		Name:         "failures",
+		Help:         "The number of token transactions that failed, by phase.",
+		LabelNames:   []string{"channel", "phase"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{phase}",
+	}
+)
+
+// Metrics are the metrics of an application embedding the token client.
+// Applications create them from the provider of their choice; for instance
+// NewMetrics(&prometheus.Provider{}) registers them with the default
+// Prometheus registry. ProverPeer and TxSubmitter record no metrics when
+// their Metrics are nil.
+type Metrics struct {
+	Submissions      metrics.Counter
+	AssembleDuration metrics.Histogram
+	OrderDuration    metrics.Histogram
+	CommitDuration   metrics.Histogram
+	Failures         metrics.Counter
+}
+
+func NewMetrics(p metrics.Provider) *Metrics {
+	return &Metrics{
+		Submissions:      p.NewCounter(submissions),
+		AssembleDuration: p.NewHistogram(assembleDuration),
+		OrderDuration:    p.NewHistogram(orderDuration),
+		CommitDuration:   p.NewHistogram(commitDuration),
+		Failures:         p.NewCounter(failures),
+	}
+}
+
+// submitted counts a transaction submitted to the orderer.
+func (m *Metrics) submitted(channel string) {
+	if m == nil {
+		return
+	}
+	m.Submissions.With("channel", channel).Add(1)
+}
+
+// observe records the duration of phase since start and, if err is not nil, the failure.
+func (m *Metrics) observe(channel string, phase Phase, start time.Time, err error) {
+	if m == nil {
+		return
+	}
+	var histogram metrics.Histogram
+	switch phase {
+	case PhaseAssemble:
+		histogram = m.AssembleDuration
+	case PhaseOrder:
+		histogram = m.OrderDuration
+	case PhaseCommit:
+		histogram = m.CommitDuration
+	default:
+		return
+	}
+	histogram.With("channel", channel, "success", strconv.FormatBool(err == nil)).Observe(time.Since(start).Seconds())
+	if err != nil {
+		m.Failures.With("channel", channel, "phase", phase.String()).Add(1)
+	}
+}
@@ -0,0 +1,37 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client_test
+
+import (
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/token/client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Metrics", func() {
+	var fakeProvider *metricsfakes.Provider
+
+	BeforeEach(func() {
+		fakeProvider = &metricsfakes.Provider{}
+		fakeProvider.NewHistogramReturns(&metricsfakes.Histogram{})
+		fakeProvider.NewCounterReturns(&metricsfakes.Counter{})
+	})
+
+	It("uses the provider to initialize all fields", func() {
+		metrics := client.NewMetrics(fakeProvider)
+		Expect(metrics).NotTo(BeNil())
+		Expect(metrics.Submissions).To(Equal(&metricsfakes.Counter{}))
+		Expect(metrics.AssembleDuration).To(Equal(&metricsfakes.Histogram{}))
+		Expect(metrics.OrderDuration).To(Equal(&metricsfakes.Histogram{}))
+		Expect(metrics.CommitDuration).To(Equal(&metricsfakes.Histogram{}))
+		Expect(metrics.Failures).To(Equal(&metricsfakes.Counter{}))
+
+		Expect(fakeProvider.NewHistogramCallCount()).To(Equal(3))
+		Expect(fakeProvider.NewCounterCallCount()).To(Equal(2))
+	})
+})
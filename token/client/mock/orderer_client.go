@@ -2,20 +2,47 @@
 package mock
 
 import (
-	"context"
-	"crypto/tls"
-	"sync"
+	context "context"
+	tls "crypto/tls"
+	sync "sync"
 
-	"github.com/hyperledger/fabric/token/client"
-	"google.golang.org/grpc"
+	common "github.com/hyperledger/fabric/protos/common"
+	orderer "github.com/hyperledger/fabric/protos/orderer"
+	client "github.com/hyperledger/fabric/token/client"
+	grpc "google.golang.org/grpc"
 )
 
 type OrdererClient struct {
-	NewBroadcastStub        func(ctx context.Context, opts ...grpc.CallOption) (client.Broadcast, error)
+	CertificateStub        func() *tls.Certificate
+	certificateMutex       sync.RWMutex
+	certificateArgsForCall []struct {
+	}
+	certificateReturns struct {
+		result1 *tls.Certificate
+	}
+	certificateReturnsOnCall map[int]struct {
+		result1 *tls.Certificate
+	}
+	InclusionStub        func(context.Context, *common.Envelope, ...grpc.CallOption) (*orderer.InclusionResponse, error)
+	inclusionMutex       sync.RWMutex
+	inclusionArgsForCall []struct {
+		arg1 context.Context
+		arg2 *common.Envelope
+		arg3 []grpc.CallOption
+	}
+	inclusionReturns struct {
+		result1 *orderer.InclusionResponse
+		result2 error
+	}
+	inclusionReturnsOnCall map[int]struct {
+		result1 *orderer.InclusionResponse
+		result2 error
+	}
+	NewBroadcastStub        func(context.Context, ...grpc.CallOption) (client.Broadcast, error)
 	newBroadcastMutex       sync.RWMutex
 	newBroadcastArgsForCall []struct {
-		ctx  context.Context
-		opts []grpc.CallOption
+		arg1 context.Context
+		arg2 []grpc.CallOption
 	}
 	newBroadcastReturns struct {
 		result1 client.Broadcast
@@ -25,35 +52,144 @@ type OrdererClient struct {
 		result1 client.Broadcast
 		result2 error
 	}
-	CertificateStub        func() *tls.Certificate
-	certificateMutex       sync.RWMutex
-	certificateArgsForCall []struct{}
-	certificateReturns     struct {
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *OrdererClient) Certificate() *tls.Certificate {
+	fake.certificateMutex.Lock()
+	ret, specificReturn := fake.certificateReturnsOnCall[len(fake.certificateArgsForCall)]
+	fake.certificateArgsForCall = append(fake.certificateArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Certificate", []interface{}{})
+	fake.certificateMutex.Unlock()
+	if fake.CertificateStub != nil {
+		return fake.CertificateStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.certificateReturns
+	return fakeReturns.result1
+}
+
+func (fake *OrdererClient) CertificateCallCount() int {
+	fake.certificateMutex.RLock()
+	defer fake.certificateMutex.RUnlock()
+	return len(fake.certificateArgsForCall)
+}
+
+func (fake *OrdererClient) CertificateCalls(stub func() *tls.Certificate) {
+	fake.certificateMutex.Lock()
+	defer fake.certificateMutex.Unlock()
+	fake.CertificateStub = stub
+}
+
+func (fake *OrdererClient) CertificateReturns(result1 *tls.Certificate) {
+	fake.certificateMutex.Lock()
+	defer fake.certificateMutex.Unlock()
+	fake.CertificateStub = nil
+	fake.certificateReturns = struct {
 		result1 *tls.Certificate
+	}{result1}
+}
+
+func (fake *OrdererClient) CertificateReturnsOnCall(i int, result1 *tls.Certificate) {
+	fake.certificateMutex.Lock()
+	defer fake.certificateMutex.Unlock()
+	fake.CertificateStub = nil
+	if fake.certificateReturnsOnCall == nil {
+		fake.certificateReturnsOnCall = make(map[int]struct {
+			result1 *tls.Certificate
+		})
 	}
-	certificateReturnsOnCall map[int]struct {
+	fake.certificateReturnsOnCall[i] = struct {
 		result1 *tls.Certificate
+	}{result1}
+}
+
+func (fake *OrdererClient) Inclusion(arg1 context.Context, arg2 *common.Envelope, arg3 ...grpc.CallOption) (*orderer.InclusionResponse, error) {
+	fake.inclusionMutex.Lock()
+	ret, specificReturn := fake.inclusionReturnsOnCall[len(fake.inclusionArgsForCall)]
+	fake.inclusionArgsForCall = append(fake.inclusionArgsForCall, struct {
+		arg1 context.Context
+		arg2 *common.Envelope
+		arg3 []grpc.CallOption
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Inclusion", []interface{}{arg1, arg2, arg3})
+	fake.inclusionMutex.Unlock()
+	if fake.InclusionStub != nil {
+		return fake.InclusionStub(arg1, arg2, arg3...)
 	}
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.inclusionReturns
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *OrdererClient) NewBroadcast(ctx context.Context, opts ...grpc.CallOption) (client.Broadcast, error) {
+func (fake *OrdererClient) InclusionCallCount() int {
+	fake.inclusionMutex.RLock()
+	defer fake.inclusionMutex.RUnlock()
+	return len(fake.inclusionArgsForCall)
+}
+
+func (fake *OrdererClient) InclusionCalls(stub func(context.Context, *common.Envelope, ...grpc.CallOption) (*orderer.InclusionResponse, error)) {
+	fake.inclusionMutex.Lock()
+	defer fake.inclusionMutex.Unlock()
+	fake.InclusionStub = stub
+}
+
+func (fake *OrdererClient) InclusionArgsForCall(i int) (context.Context, *common.Envelope, []grpc.CallOption) {
+	fake.inclusionMutex.RLock()
+	defer fake.inclusionMutex.RUnlock()
+	argsForCall := fake.inclusionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *OrdererClient) InclusionReturns(result1 *orderer.InclusionResponse, result2 error) {
+	fake.inclusionMutex.Lock()
+	defer fake.inclusionMutex.Unlock()
+	fake.InclusionStub = nil
+	fake.inclusionReturns = struct {
+		result1 *orderer.InclusionResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *OrdererClient) InclusionReturnsOnCall(i int, result1 *orderer.InclusionResponse, result2 error) {
+	fake.inclusionMutex.Lock()
+	defer fake.inclusionMutex.Unlock()
+	fake.InclusionStub = nil
+	if fake.inclusionReturnsOnCall == nil {
+		fake.inclusionReturnsOnCall = make(map[int]struct {
+			result1 *orderer.InclusionResponse
+			result2 error
+		})
+	}
+	fake.inclusionReturnsOnCall[i] = struct {
+		result1 *orderer.InclusionResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *OrdererClient) NewBroadcast(arg1 context.Context, arg2 ...grpc.CallOption) (client.Broadcast, error) {
 	fake.newBroadcastMutex.Lock()
 	ret, specificReturn := fake.newBroadcastReturnsOnCall[len(fake.newBroadcastArgsForCall)]
 	fake.newBroadcastArgsForCall = append(fake.newBroadcastArgsForCall, struct {
-		ctx  context.Context
-		opts []grpc.CallOption
-	}{ctx, opts})
-	fake.recordInvocation("NewBroadcast", []interface{}{ctx, opts})
+		arg1 context.Context
+		arg2 []grpc.CallOption
+	}{arg1, arg2})
+	fake.recordInvocation("NewBroadcast", []interface{}{arg1, arg2})
 	fake.newBroadcastMutex.Unlock()
 	if fake.NewBroadcastStub != nil {
-		return fake.NewBroadcastStub(ctx, opts...)
+		return fake.NewBroadcastStub(arg1, arg2...)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.newBroadcastReturns.result1, fake.newBroadcastReturns.result2
+	fakeReturns := fake.newBroadcastReturns
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *OrdererClient) NewBroadcastCallCount() int {
@@ -62,13 +198,22 @@ func (fake *OrdererClient) NewBroadcastCallCount() int {
 	return len(fake.newBroadcastArgsForCall)
 }
 
+func (fake *OrdererClient) NewBroadcastCalls(stub func(context.Context, ...grpc.CallOption) (client.Broadcast, error)) {
+	fake.newBroadcastMutex.Lock()
+	defer fake.newBroadcastMutex.Unlock()
+	fake.NewBroadcastStub = stub
+}
+
 func (fake *OrdererClient) NewBroadcastArgsForCall(i int) (context.Context, []grpc.CallOption) {
 	fake.newBroadcastMutex.RLock()
 	defer fake.newBroadcastMutex.RUnlock()
-	return fake.newBroadcastArgsForCall[i].ctx, fake.newBroadcastArgsForCall[i].opts
+	argsForCall := fake.newBroadcastArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *OrdererClient) NewBroadcastReturns(result1 client.Broadcast, result2 error) {
+	fake.newBroadcastMutex.Lock()
+	defer fake.newBroadcastMutex.Unlock()
 	fake.NewBroadcastStub = nil
 	fake.newBroadcastReturns = struct {
 		result1 client.Broadcast
@@ -77,6 +222,8 @@ func (fake *OrdererClient) NewBroadcastReturns(result1 client.Broadcast, result2
 }
 
 func (fake *OrdererClient) NewBroadcastReturnsOnCall(i int, result1 client.Broadcast, result2 error) {
+	fake.newBroadcastMutex.Lock()
+	defer fake.newBroadcastMutex.Unlock()
 	fake.NewBroadcastStub = nil
 	if fake.newBroadcastReturnsOnCall == nil {
 		fake.newBroadcastReturnsOnCall = make(map[int]struct {
@@ -90,53 +237,15 @@ func (fake *OrdererClient) NewBroadcastReturnsOnCall(i int, result1 client.Broad
 	}{result1, result2}
 }
 
-func (fake *OrdererClient) Certificate() *tls.Certificate {
-	fake.certificateMutex.Lock()
-	ret, specificReturn := fake.certificateReturnsOnCall[len(fake.certificateArgsForCall)]
-	fake.certificateArgsForCall = append(fake.certificateArgsForCall, struct{}{})
-	fake.recordInvocation("Certificate", []interface{}{})
-	fake.certificateMutex.Unlock()
-	if fake.CertificateStub != nil {
-		return fake.CertificateStub()
-	}
-	if specificReturn {
-		return ret.result1
-	}
-	return fake.certificateReturns.result1
-}
-
-func (fake *OrdererClient) CertificateCallCount() int {
-	fake.certificateMutex.RLock()
-	defer fake.certificateMutex.RUnlock()
-	return len(fake.certificateArgsForCall)
-}
-
-func (fake *OrdererClient) CertificateReturns(result1 *tls.Certificate) {
-	fake.CertificateStub = nil
-	fake.certificateReturns = struct {
-		result1 *tls.Certificate
-	}{result1}
-}
-
-func (fake *OrdererClient) CertificateReturnsOnCall(i int, result1 *tls.Certificate) {
-	fake.CertificateStub = nil
-	if fake.certificateReturnsOnCall == nil {
-		fake.certificateReturnsOnCall = make(map[int]struct {
-			result1 *tls.Certificate
-		})
-	}
-	fake.certificateReturnsOnCall[i] = struct {
-		result1 *tls.Certificate
-	}{result1}
-}
-
 func (fake *OrdererClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.newBroadcastMutex.RLock()
-	defer fake.newBroadcastMutex.RUnlock()
 	fake.certificateMutex.RLock()
 	defer fake.certificateMutex.RUnlock()
+	fake.inclusionMutex.RLock()
+	defer fake.inclusionMutex.RUnlock()
+	fake.newBroadcastMutex.RLock()
+	defer fake.newBroadcastMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
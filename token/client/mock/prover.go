@@ -10,6 +10,19 @@ import (
 )
 
 type Prover struct {
+	ListTokensStub        func(tokena.SigningIdentity) ([]*token.TokenOutput, error)
+	listTokensMutex       sync.RWMutex
+	listTokensArgsForCall []struct {
+		arg1 tokena.SigningIdentity
+	}
+	listTokensReturns struct {
+		result1 []*token.TokenOutput
+		result2 error
+	}
+	listTokensReturnsOnCall map[int]struct {
+		result1 []*token.TokenOutput
+		result2 error
+	}
 	RequestImportStub        func([]*token.TokenToIssue, tokena.SigningIdentity) ([]byte, error)
 	requestImportMutex       sync.RWMutex
 	requestImportArgsForCall []struct {
@@ -24,6 +37,21 @@ type Prover struct {
 		result1 []byte
 		result2 error
 	}
+	RequestRedeemStub        func([][]byte, uint64, tokena.SigningIdentity) ([]byte, error)
+	requestRedeemMutex       sync.RWMutex
+	requestRedeemArgsForCall []struct {
+		arg1 [][]byte
+		arg2 uint64
+		arg3 tokena.SigningIdentity
+	}
+	requestRedeemReturns struct {
+		result1 []byte
+		result2 error
+	}
+	requestRedeemReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	RequestTransferStub        func([][]byte, []*token.RecipientTransferShare, tokena.SigningIdentity) ([]byte, error)
 	requestTransferMutex       sync.RWMutex
 	requestTransferArgsForCall []struct {
@@ -43,6 +71,69 @@ type Prover struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *Prover) ListTokens(arg1 tokena.SigningIdentity) ([]*token.TokenOutput, error) {
+	fake.listTokensMutex.Lock()
+	ret, specificReturn := fake.listTokensReturnsOnCall[len(fake.listTokensArgsForCall)]
+	fake.listTokensArgsForCall = append(fake.listTokensArgsForCall, struct {
+		arg1 tokena.SigningIdentity
+	}{arg1})
+	fake.recordInvocation("ListTokens", []interface{}{arg1})
+	fake.listTokensMutex.Unlock()
+	if fake.ListTokensStub != nil {
+		return fake.ListTokensStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listTokensReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) ListTokensCallCount() int {
+	fake.listTokensMutex.RLock()
+	defer fake.listTokensMutex.RUnlock()
+	return len(fake.listTokensArgsForCall)
+}
+
+func (fake *Prover) ListTokensCalls(stub func(tokena.SigningIdentity) ([]*token.TokenOutput, error)) {
+	fake.listTokensMutex.Lock()
+	defer fake.listTokensMutex.Unlock()
+	fake.ListTokensStub = stub
+}
+
+func (fake *Prover) ListTokensArgsForCall(i int) tokena.SigningIdentity {
+	fake.listTokensMutex.RLock()
+	defer fake.listTokensMutex.RUnlock()
+	argsForCall := fake.listTokensArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Prover) ListTokensReturns(result1 []*token.TokenOutput, result2 error) {
+	fake.listTokensMutex.Lock()
+	defer fake.listTokensMutex.Unlock()
+	fake.ListTokensStub = nil
+	fake.listTokensReturns = struct {
+		result1 []*token.TokenOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) ListTokensReturnsOnCall(i int, result1 []*token.TokenOutput, result2 error) {
+	fake.listTokensMutex.Lock()
+	defer fake.listTokensMutex.Unlock()
+	fake.ListTokensStub = nil
+	if fake.listTokensReturnsOnCall == nil {
+		fake.listTokensReturnsOnCall = make(map[int]struct {
+			result1 []*token.TokenOutput
+			result2 error
+		})
+	}
+	fake.listTokensReturnsOnCall[i] = struct {
+		result1 []*token.TokenOutput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Prover) RequestImport(arg1 []*token.TokenToIssue, arg2 tokena.SigningIdentity) ([]byte, error) {
 	var arg1Copy []*token.TokenToIssue
 	if arg1 != nil {
@@ -112,6 +203,76 @@ func (fake *Prover) RequestImportReturnsOnCall(i int, result1 []byte, result2 er
 	}{result1, result2}
 }
 
+func (fake *Prover) RequestRedeem(arg1 [][]byte, arg2 uint64, arg3 tokena.SigningIdentity) ([]byte, error) {
+	var arg1Copy [][]byte
+	if arg1 != nil {
+		arg1Copy = make([][]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.requestRedeemMutex.Lock()
+	ret, specificReturn := fake.requestRedeemReturnsOnCall[len(fake.requestRedeemArgsForCall)]
+	fake.requestRedeemArgsForCall = append(fake.requestRedeemArgsForCall, struct {
+		arg1 [][]byte
+		arg2 uint64
+		arg3 tokena.SigningIdentity
+	}{arg1Copy, arg2, arg3})
+	fake.recordInvocation("RequestRedeem", []interface{}{arg1Copy, arg2, arg3})
+	fake.requestRedeemMutex.Unlock()
+	if fake.RequestRedeemStub != nil {
+		return fake.RequestRedeemStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestRedeemReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) RequestRedeemCallCount() int {
+	fake.requestRedeemMutex.RLock()
+	defer fake.requestRedeemMutex.RUnlock()
+	return len(fake.requestRedeemArgsForCall)
+}
+
+func (fake *Prover) RequestRedeemCalls(stub func([][]byte, uint64, tokena.SigningIdentity) ([]byte, error)) {
+	fake.requestRedeemMutex.Lock()
+	defer fake.requestRedeemMutex.Unlock()
+	fake.RequestRedeemStub = stub
+}
+
+func (fake *Prover) RequestRedeemArgsForCall(i int) ([][]byte, uint64, tokena.SigningIdentity) {
+	fake.requestRedeemMutex.RLock()
+	defer fake.requestRedeemMutex.RUnlock()
+	argsForCall := fake.requestRedeemArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Prover) RequestRedeemReturns(result1 []byte, result2 error) {
+	fake.requestRedeemMutex.Lock()
+	defer fake.requestRedeemMutex.Unlock()
+	fake.RequestRedeemStub = nil
+	fake.requestRedeemReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestRedeemReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.requestRedeemMutex.Lock()
+	defer fake.requestRedeemMutex.Unlock()
+	fake.RequestRedeemStub = nil
+	if fake.requestRedeemReturnsOnCall == nil {
+		fake.requestRedeemReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.requestRedeemReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Prover) RequestTransfer(arg1 [][]byte, arg2 []*token.RecipientTransferShare, arg3 tokena.SigningIdentity) ([]byte, error) {
 	var arg1Copy [][]byte
 	if arg1 != nil {
@@ -190,8 +351,12 @@ func (fake *Prover) RequestTransferReturnsOnCall(i int, result1 []byte, result2
 func (fake *Prover) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.listTokensMutex.RLock()
+	defer fake.listTokensMutex.RUnlock()
 	fake.requestImportMutex.RLock()
 	defer fake.requestImportMutex.RUnlock()
+	fake.requestRedeemMutex.RLock()
+	defer fake.requestRedeemMutex.RUnlock()
 	fake.requestTransferMutex.RLock()
 	defer fake.requestTransferMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
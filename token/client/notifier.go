@@ -0,0 +1,61 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+)
+
+//go:generate counterfeiter -o mock/commit_notifier.go -fake-name CommitNotifier . CommitNotifier
+
+// CommitNotifier notifies the commit of token transactions.
+type CommitNotifier interface {
+	// Notify starts listening for the commit of the transaction and returns
+	// once it does. The TxEvent of the transaction is sent on eventCh when the
+	// transaction is committed or invalidated, or when listening fails; it is
+	// discarded if eventCh is full. Listening stops when ctx is done.
+	Notify(ctx context.Context, txid string, eventCh chan TxEvent) error
+}
+
+// deliverNotifier notifies the commit of transactions from the filtered
+// blocks delivered by the commit peer of the submitter.
+type deliverNotifier struct {
+	submitter *TxSubmitter
+}
+
+func (d *deliverNotifier) Notify(ctx context.Context, txid string, eventCh chan TxEvent) error {
+	s := d.submitter
+	// the deliver stream outlives the call, until the event is received or
+	// the submitter is closed
+	ctx, cancel, err := s.tracker.context(ctx)
+	if err != nil {
+		return err
+	}
+	deliverFiltered, err := s.DeliverClient.NewDeliverFiltered(ctx)
+	if err != nil {
+		cancel()
+		return err
+	}
+	blockEnvelope, err := CreateResumingDeliverEnvelope(s.Config.ChannelId, s.Creator, s.Signer, s.DeliverClient.Certificate(), s.Checkpointer)
+	if err != nil {
+		cancel()
+		return err
+	}
+	err = DeliverSend(deliverFiltered, s.Config.CommitPeerCfg.Address, blockEnvelope)
+	if err != nil {
+		cancel()
+		return err
+	}
+	err = s.tracker.goroutine(func() {
+		defer cancel()
+		DeliverReceiveCheckpointed(deliverFiltered, s.Config.CommitPeerCfg.Address, txid, eventCh, s.Checkpointer)
+	})
+	if err != nil {
+		cancel()
+	}
+	return err
+}
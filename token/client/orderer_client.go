@@ -38,14 +38,18 @@ type OrdererClient interface {
 
 	// Certificate returns tls certificate for the orderer client
 	Certificate() *tls.Certificate
+
+	// Inclusion queries the orderer for the block including a transaction,
+	// with an envelope whose payload data is an InclusionQuery
+	Inclusion(ctx context.Context, envelope *common.Envelope, opts ...grpc.CallOption) (*ab.InclusionResponse, error)
 }
 
 // ordererClient implements OrdererClient interface
 type ordererClient struct {
-	ordererAddr        string
-	serverNameOverride string
-	grpcClient         *comm.GRPCClient
-	conn               *grpc.ClientConn
+	ordererAddr string
+	grpcClient  *comm.GRPCClient
+	endpoints   *Endpoints
+	conns       *connectionCache
 }
 
 func NewOrdererClient(config *ClientConfig) (OrdererClient, error) {
@@ -55,35 +59,50 @@ func NewOrdererClient(config *ClientConfig) (OrdererClient, error) {
 		logger.Errorf("%s", err)
 		return nil, err
 	}
-	conn, err := grpcClient.NewConnection(config.OrdererCfg.Address, config.OrdererCfg.ServerNameOverride)
+	oc := &ordererClient{
+		ordererAddr: config.OrdererCfg.Address,
+		grpcClient:  grpcClient,
+		endpoints:   NewEndpoints(config.OrdererCfg.Address),
+		conns:       &connectionCache{grpcClient: grpcClient, serverName: serverName(config.OrdererCfg.Address, config.OrdererCfg.ServerNameOverride)},
+	}
+	backend, err := oc.endpoints.Next(context.Background())
 	if err != nil {
-		return nil, errors.WithMessage(err, fmt.Sprintf("failed to connect to orderer %s", config.OrdererCfg.Address))
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to resolve orderer %s", oc.ordererAddr))
+	}
+	_, err = oc.conns.get(backend)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to connect to orderer %s", backend))
 	}
 
-	return &ordererClient{
-		ordererAddr:        config.OrdererCfg.Address,
-		serverNameOverride: config.OrdererCfg.ServerNameOverride,
-		grpcClient:         grpcClient,
-		conn:               conn,
-	}, nil
+	return oc, nil
 }
 
-// NewBroadcast creates a Broadcast
+// NewBroadcast creates a Broadcast to the next backend of the orderer address
 func (oc *ordererClient) NewBroadcast(ctx context.Context, opts ...grpc.CallOption) (Broadcast, error) {
+	backend, err := oc.endpoints.Next(ctx)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to resolve orderer %s", oc.ordererAddr))
+	}
+	oc.conns.retain(oc.endpoints.Backends())
+
 	// reuse the existing connection to create Broadcast client
-	broadcast, err := ab.NewAtomicBroadcastClient(oc.conn).Broadcast(ctx)
+	conn, err := oc.conns.get(backend)
 	if err == nil {
-		return broadcast, nil
+		broadcast, err := ab.NewAtomicBroadcastClient(conn).Broadcast(ctx)
+		if err == nil {
+			return broadcast, nil
+		}
 	}
 
 	// error occurred with the existing connection, so create a new connection to orderer
-	oc.conn, err = oc.grpcClient.NewConnection(oc.ordererAddr, oc.serverNameOverride)
+	oc.conns.drop(backend)
+	conn, err = oc.conns.get(backend)
 	if err != nil {
-		return nil, errors.WithMessage(err, fmt.Sprintf("failed to connect to orderer %s", oc.ordererAddr))
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to connect to orderer %s", backend))
 	}
 
 	// create a new Broadcast
-	broadcast, err = ab.NewAtomicBroadcastClient(oc.conn).Broadcast(ctx)
+	broadcast, err := ab.NewAtomicBroadcastClient(conn).Broadcast(ctx)
 	if err != nil {
 		rpcStatus, _ := status.FromError(err)
 		return nil, errors.Wrapf(err, "failed to new a broadcast, rpcStatus=%+v", rpcStatus)
@@ -91,11 +110,39 @@ func (oc *ordererClient) NewBroadcast(ctx context.Context, opts ...grpc.CallOpti
 	return broadcast, nil
 }
 
+// Inclusion queries the next backend of the orderer address for the block
+// including the transaction of the query envelope
+func (oc *ordererClient) Inclusion(ctx context.Context, envelope *common.Envelope, opts ...grpc.CallOption) (*ab.InclusionResponse, error) {
+	backend, err := oc.endpoints.Next(ctx)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to resolve orderer %s", oc.ordererAddr))
+	}
+	oc.conns.retain(oc.endpoints.Backends())
+
+	conn, err := oc.conns.get(backend)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to connect to orderer %s", backend))
+	}
+	resp, err := ab.NewTxInclusionClient(conn).Inclusion(ctx, envelope, opts...)
+	if err != nil {
+		// drop the connection, so that the next query uses a new one
+		oc.conns.drop(backend)
+		rpcStatus, _ := status.FromError(err)
+		return nil, errors.Wrapf(err, "failed to query inclusion from orderer %s, rpcStatus=%+v", backend, rpcStatus)
+	}
+	return resp, nil
+}
+
 func (oc *ordererClient) Certificate() *tls.Certificate {
 	cert := oc.grpcClient.Certificate()
 	return &cert
 }
 
+// Close closes the connections to the backends of the orderer
+func (oc *ordererClient) Close() error {
+	return oc.conns.close()
+}
+
 // broadcastSend sends transaction envelope to orderer service
 func BroadcastSend(broadcast Broadcast, addr string, envelope *common.Envelope) error {
 	err := broadcast.Send(envelope)
@@ -8,11 +8,16 @@ package client
 
 import (
 	"context"
+	"crypto/rand"
+	"fmt"
 	"io"
+	"runtime"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/protos/token"
 	tk "github.com/hyperledger/fabric/token"
 	"github.com/pkg/errors"
@@ -25,57 +30,518 @@ type ProverPeer struct {
 	ProverClient     token.ProverClient
 	RandomnessReader io.Reader
 	Time             TimeFunc
+	// Breaker, when set, stops requests to the prover while it is failing.
+	Breaker *CircuitBreaker
+	// Metrics, when set, record the latency and failures of the commands.
+	Metrics *Metrics
+	// HeaderExtensions are added to the header of every command, for instance
+	// the client version or application tags. Extensions specific to a command,
+	// such as a trace context, are passed with tk.WithHeaderExtensions.
+	HeaderExtensions []*token.HeaderExtension
+
+	closed int32
+}
+
+// NewProverPeer creates a ProverPeer connected to the prover peer of the client config.
+// When the address resolves to several backends, commands are balanced across them.
+func NewProverPeer(config *ClientConfig) (*ProverPeer, error) {
+	grpcClient, err := createGrpcClient(&config.ProverPeerCfg, config.TlsEnabled)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to create a GRPCClient to prover peer %s", config.ProverPeerCfg.Address))
+	}
+	address := config.ProverPeerCfg.Address
+	proverClient := &balancedProverClient{
+		endpoints: NewEndpoints(address),
+		conns:     &connectionCache{grpcClient: grpcClient, serverName: serverName(address, config.ProverPeerCfg.ServerNameOverride)},
+	}
+	// connect to a first backend to report misconfigurations early
+	backend, err := proverClient.endpoints.Next(context.Background())
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to resolve prover peer %s", address))
+	}
+	_, err = proverClient.conns.get(backend)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to connect to prover peer %s", address))
+	}
+
+	prover := &ProverPeer{
+		ChannelID:        config.ChannelId,
+		ProverClient:     proverClient,
+		RandomnessReader: rand.Reader,
+		Time:             time.Now,
+	}
+	runtime.SetFinalizer(prover, func(prover *ProverPeer) {
+		if atomic.LoadInt32(&prover.closed) == 0 {
+			logger.Warningf("ProverPeer of channel %s was not closed; its connections to prover peer %s leaked", config.ChannelId, address)
+		}
+	})
+	return prover, nil
+}
+
+// Close closes the connections to the prover peer, and returns ErrClosed if
+// they were already closed. The prover peer cannot be used afterwards.
+func (prover *ProverPeer) Close() error {
+	if !atomic.CompareAndSwapInt32(&prover.closed, 0, 1) {
+		return ErrClosed
+	}
+	return closeAll(prover.ProverClient)
 }
 
 func (prover *ProverPeer) RequestImport(tokensToIssue []*token.TokenToIssue, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	return prover.RequestImportContext(context.Background(), tokensToIssue, signingIdentity)
+}
+
+// RequestImportContext is like RequestImport but the request is bound by ctx
+// and, when ctx carries a DeadlineBudget, by the share of the assemble phase.
+func (prover *ProverPeer) RequestImportContext(ctx context.Context, tokensToIssue []*token.TokenToIssue, signingIdentity tk.SigningIdentity) ([]byte, error) {
 	ir := &token.ImportRequest{
 		TokensToIssue: tokensToIssue,
 	}
 	payload := &token.Command_ImportRequest{ImportRequest: ir}
 
-	sc, err := prover.CreateSignedCommand(payload, signingIdentity)
+	sc, err := prover.CreateSignedCommandContext(ctx, payload, signingIdentity)
 
 	if err != nil {
 		return nil, err
 	}
 
-	scr, err := prover.ProverClient.ProcessCommand(context.Background(), sc)
-	if err != nil {
-		return nil, err
-	}
-	return scr.Response, nil
+	return prover.processCommand(ctx, sc)
 }
 
 func (prover *ProverPeer) RequestTransfer(
 	tokenIDs [][]byte,
 	shares []*token.RecipientTransferShare,
 	signingIdentity tk.SigningIdentity) ([]byte, error) {
+	return prover.RequestTransferContext(context.Background(), tokenIDs, shares, signingIdentity)
+}
+
+// RequestTransferContext is like RequestTransfer but the request is bound by ctx
+// and, when ctx carries a DeadlineBudget, by the share of the assemble phase.
+func (prover *ProverPeer) RequestTransferContext(
+	ctx context.Context,
+	tokenIDs [][]byte,
+	shares []*token.RecipientTransferShare,
+	signingIdentity tk.SigningIdentity) ([]byte, error) {
+
+	return prover.RequestDelegatedTransferContext(ctx, tokenIDs, shares, nil, signingIdentity)
+}
+
+// RequestDelegatedTransferContext is like RequestTransferContext but spends
+// tokens owned by another party under the authority of the delegation chain.
+// The first delegation must be signed by the owner of the tokens and the last
+// one must be granted to the signing identity.
+func (prover *ProverPeer) RequestDelegatedTransferContext(
+	ctx context.Context,
+	tokenIDs [][]byte,
+	shares []*token.RecipientTransferShare,
+	delegations []*token.SignedDelegation,
+	signingIdentity tk.SigningIdentity) ([]byte, error) {
 
 	tr := &token.TransferRequest{
-		Shares:   shares,
-		TokenIds: tokenIDs,
+		Shares:      shares,
+		TokenIds:    tokenIDs,
+		Delegations: delegations,
 	}
 	payload := &token.Command_TransferRequest{TransferRequest: tr}
 
-	sc, err := prover.CreateSignedCommand(payload, signingIdentity)
+	sc, err := prover.CreateSignedCommandContext(ctx, payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	return prover.processCommand(ctx, sc)
+}
+
+func (prover *ProverPeer) RequestRedeem(tokenIDs [][]byte, quantity uint64, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	return prover.RequestRedeemContext(context.Background(), tokenIDs, quantity, signingIdentity)
+}
+
+// RequestRedeemContext is like RequestRedeem but the request is bound by ctx
+// and, when ctx carries a DeadlineBudget, by the share of the assemble phase.
+func (prover *ProverPeer) RequestRedeemContext(ctx context.Context, tokenIDs [][]byte, quantity uint64, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	rr := &token.RedeemRequest{
+		TokenIds:         tokenIDs,
+		QuantityToRedeem: quantity,
+	}
+	payload := &token.Command_RedeemRequest{RedeemRequest: rr}
+
+	sc, err := prover.CreateSignedCommandContext(ctx, payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	return prover.processCommand(ctx, sc)
+}
+
+func (prover *ProverPeer) ListTokens(signingIdentity tk.SigningIdentity) ([]*token.TokenOutput, error) {
+	return prover.ListTokensContext(context.Background(), signingIdentity)
+}
+
+// ListTokensContext returns the unspent tokens owned by the signing identity.
+func (prover *ProverPeer) ListTokensContext(ctx context.Context, signingIdentity tk.SigningIdentity) ([]*token.TokenOutput, error) {
+	payload := &token.Command_ListRequest{ListRequest: &token.ListRequest{}}
+
+	sc, err := prover.CreateSignedCommandContext(ctx, payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := prover.processCommand(ctx, sc)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &token.CommandResponse{}
+	err = proto.Unmarshal(raw, response)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling command response")
+	}
+	if response.GetErr() != nil {
+		return nil, errors.Errorf("prover failed listing tokens: %s", response.GetErr().GetMessage())
+	}
+	return response.GetUnspentTokens().GetTokens(), nil
+}
+
+// ListPseudonymTokensContext returns the unspent tokens owned by the passed
+// pseudonyms, such as the Idemix identities the tokens were transferred to.
+// The signing identity, which may itself be anonymous, proves that it holds
+// the pseudonyms with their signatures over the header of the command. The
+// prover must be enabled to list the tokens of pseudonyms.
+func (prover *ProverPeer) ListPseudonymTokensContext(ctx context.Context, pseudonyms []tk.SigningIdentity, signingIdentity tk.SigningIdentity) ([]*token.TokenOutput, error) {
+	if len(pseudonyms) == 0 {
+		return nil, errors.New("no pseudonyms to list the tokens of")
+	}
+
+	header, err := prover.createHeader(ctx, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	message := tk.PseudonymProofMessage(header)
+	proofs := make([]*token.PseudonymProof, len(pseudonyms))
+	for i, pseudonym := range pseudonyms {
+		owner, err := pseudonym.GetPublicVersion().Serialize()
+		if err != nil {
+			return nil, err
+		}
+		signature, err := pseudonym.Sign(message)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed signing pseudonym proof")
+		}
+		proofs[i] = &token.PseudonymProof{Owner: owner, Signature: signature}
+	}
+
+	command := &token.Command{
+		Header:  header,
+		Payload: &token.Command_ListRequest{ListRequest: &token.ListRequest{PseudonymProofs: proofs}},
+	}
+	sc, err := signCommand(command, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := prover.processCommand(ctx, sc)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &token.CommandResponse{}
+	err = proto.Unmarshal(raw, response)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling command response")
+	}
+	if response.GetErr() != nil {
+		return nil, errors.Errorf("prover failed listing tokens: %s", response.GetErr().GetMessage())
+	}
+	return response.GetUnspentTokens().GetTokens(), nil
+}
+
+// ListTransactionsByReferenceContext returns the committed token transactions
+// carrying the passed application reference.
+func (prover *ProverPeer) ListTransactionsByReferenceContext(ctx context.Context, reference []byte, signingIdentity tk.SigningIdentity) ([]*token.ReferencedTransaction, error) {
+	payload := &token.Command_ReferenceRequest{ReferenceRequest: &token.ReferenceRequest{ApplicationReference: reference}}
+
+	sc, err := prover.CreateSignedCommandContext(ctx, payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := prover.processCommand(ctx, sc)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &token.CommandResponse{}
+	err = proto.Unmarshal(raw, response)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling command response")
+	}
+	if response.GetErr() != nil {
+		return nil, errors.Errorf("prover failed listing referenced transactions: %s", response.GetErr().GetMessage())
+	}
+	return response.GetReferencedTransactions().GetTransactions(), nil
+}
+
+// RequestSupplyAttestationContext returns an attestation of the circulating
+// supply of the passed token types, or of every token type in circulation when
+// none is passed, signed by the prover peer. VerifySupplyAttestation checks it.
+func (prover *ProverPeer) RequestSupplyAttestationContext(ctx context.Context, types []string, signingIdentity tk.SigningIdentity) (*token.SignedSupplyAttestation, error) {
+	payload := &token.Command_SupplyAttestationRequest{SupplyAttestationRequest: &token.SupplyAttestationRequest{Types: types}}
+
+	sc, err := prover.CreateSignedCommandContext(ctx, payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := prover.processCommand(ctx, sc)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &token.CommandResponse{}
+	err = proto.Unmarshal(raw, response)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling command response")
+	}
+	if response.GetErr() != nil {
+		return nil, errors.Errorf("prover failed attesting the token supply: %s", response.GetErr().GetMessage())
+	}
+	return response.GetSupplyAttestation(), nil
+}
+
+// RequestCommitAttestationContext returns an attestation of the validation
+// results of the block holding the transaction of the ID, signed by the prover
+// peer. VerifyCommitAttestation checks it.
+func (prover *ProverPeer) RequestCommitAttestationContext(ctx context.Context, txID string, signingIdentity tk.SigningIdentity) (*token.SignedCommitAttestation, error) {
+	payload := &token.Command_CommitAttestationRequest{CommitAttestationRequest: &token.CommitAttestationRequest{TxId: txID}}
+
+	sc, err := prover.CreateSignedCommandContext(ctx, payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := prover.processCommand(ctx, sc)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &token.CommandResponse{}
+	err = proto.Unmarshal(raw, response)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling command response")
+	}
+	if response.GetErr() != nil {
+		return nil, errors.Errorf("prover failed attesting the commit of transaction %s: %s", txID, response.GetErr().GetMessage())
+	}
+	return response.GetCommitAttestation(), nil
+}
+
+// GetChannelCapabilitiesContext returns the token capabilities of the channel, as seen by the prover peer.
+func (prover *ProverPeer) GetChannelCapabilitiesContext(ctx context.Context, signingIdentity tk.SigningIdentity) (*token.ChannelCapabilities, error) {
+	payload := &token.Command_CapabilitiesRequest{CapabilitiesRequest: &token.CapabilitiesRequest{}}
+
+	sc, err := prover.CreateSignedCommandContext(ctx, payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := prover.processCommand(ctx, sc)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &token.CommandResponse{}
+	err = proto.Unmarshal(raw, response)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling command response")
+	}
+	if response.GetErr() != nil {
+		return nil, errors.Errorf("prover failed getting channel capabilities: %s", response.GetErr().GetMessage())
+	}
+	if response.GetChannelCapabilities() == nil {
+		return nil, errors.New("prover returned no channel capabilities")
+	}
+	return response.GetChannelCapabilities(), nil
+}
+
+// RequestBalancesContext requests the balances of the signing identity,
+// aggregated by token type.
+func (prover *ProverPeer) RequestBalancesContext(ctx context.Context, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	payload := &token.Command_BalanceRequest{BalanceRequest: &token.BalanceRequest{}}
+
+	sc, err := prover.CreateSignedCommandContext(ctx, payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	return prover.processCommand(ctx, sc)
+}
+
+// RequestCreditContext requests a transfer of quantity tokens of tokenType from
+// the balance of the signing identity to recipient. The prover selects the tokens
+// to spend and returns the change to the signing identity.
+func (prover *ProverPeer) RequestCreditContext(ctx context.Context, recipient []byte, tokenType string, quantity uint64, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	cr := &token.CreditRequest{
+		Recipient: recipient,
+		Type:      tokenType,
+		Quantity:  quantity,
+	}
+	payload := &token.Command_CreditRequest{CreditRequest: cr}
+
+	sc, err := prover.CreateSignedCommandContext(ctx, payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	return prover.processCommand(ctx, sc)
+}
+
+// RequestDebitContext requests the redemption of quantity tokens of tokenType
+// from the balance of the signing identity.
+func (prover *ProverPeer) RequestDebitContext(ctx context.Context, tokenType string, quantity uint64, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	dr := &token.DebitRequest{
+		Type:     tokenType,
+		Quantity: quantity,
+	}
+	payload := &token.Command_DebitRequest{DebitRequest: dr}
+
+	sc, err := prover.CreateSignedCommandContext(ctx, payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	return prover.processCommand(ctx, sc)
+}
+
+// RequestPauseContext requests a governance transaction pausing tokenType.
+// The transaction is only valid if the signing identity is an administrator.
+func (prover *ProverPeer) RequestPauseContext(ctx context.Context, tokenType string, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	payload := &token.Command_PauseRequest{PauseRequest: &token.PauseRequest{Type: tokenType}}
+
+	sc, err := prover.CreateSignedCommandContext(ctx, payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	return prover.processCommand(ctx, sc)
+}
+
+// RequestResumeContext requests a governance transaction resuming the paused
+// tokenType. The transaction is only valid if the signing identity is an administrator.
+func (prover *ProverPeer) RequestResumeContext(ctx context.Context, tokenType string, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	payload := &token.Command_ResumeRequest{ResumeRequest: &token.ResumeRequest{Type: tokenType}}
+
+	sc, err := prover.CreateSignedCommandContext(ctx, payload, signingIdentity)
 	if err != nil {
 		return nil, err
 	}
-	scr, err := prover.ProverClient.ProcessCommand(context.Background(), sc)
+	return prover.processCommand(ctx, sc)
+}
+
+// RequestIssueManifestContext requests a transaction tying together the
+// imports of the chunks of the batch with the passed ID. The transaction is only
+// valid if the signing identity imported every chunk.
+func (prover *ProverPeer) RequestIssueManifestContext(ctx context.Context, batchID []byte, chunks []*token.IssueChunk, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	payload := &token.Command_IssueManifestRequest{IssueManifestRequest: &token.IssueManifestRequest{BatchId: batchID, Chunks: chunks}}
+
+	sc, err := prover.CreateSignedCommandContext(ctx, payload, signingIdentity)
 	if err != nil {
 		return nil, err
 	}
+	return prover.processCommand(ctx, sc)
+}
 
+// CreateSignedDelegation creates a delegation, signed by signingIdentity, which
+// permits delegatee to spend up to quantity tokens of tokenType owned by the
+// signing identity on the prover's channel in transactions committed up to block
+// expirationBlock.
+func (prover *ProverPeer) CreateSignedDelegation(delegatee []byte, tokenType string, quantity uint64, expirationBlock uint64, signingIdentity tk.SigningIdentity) (*token.SignedDelegation, error) {
+	if expirationBlock == 0 {
+		return nil, errors.New("expiration block must be greater than 0")
+	}
+
+	nonce := make([]byte, 32)
+	_, err := io.ReadFull(prover.RandomnessReader, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	delegator, err := signingIdentity.GetPublicVersion().Serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := proto.Marshal(&token.Delegation{
+		Delegator:       delegator,
+		Delegatee:       delegatee,
+		Type:            tokenType,
+		Quantity:        quantity,
+		ExpirationBlock: expirationBlock,
+		ChannelId:       prover.ChannelID,
+		Nonce:           nonce,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := signingIdentity.Sign(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.SignedDelegation{Delegation: raw, Signature: signature}, nil
+}
+
+func (prover *ProverPeer) processCommand(ctx context.Context, sc *token.SignedCommand) ([]byte, error) {
+	ctx, cancel := phaseContext(ctx, PhaseAssemble)
+	defer cancel()
+
+	// correlate the records of the prover with the ones of the client; callers
+	// set the correlation ID to also correlate them with the transaction
+	correlationID := tk.CorrelationID(ctx)
+	if correlationID == "" {
+		correlationID = tk.NewCorrelationID()
+		ctx = tk.WithCorrelationID(ctx, correlationID)
+	}
+	logger.With(flogging.CorrelationIDKey, correlationID).Debugf("sending command to prover peer")
+
+	var scr *token.SignedCommandResponse
+	start := time.Now()
+	err := prover.Breaker.Do(func() error {
+		var err error
+		scr, err = prover.ProverClient.ProcessCommand(ctx, sc)
+		return err
+	})
+	prover.Metrics.observe(prover.ChannelID, PhaseAssemble, start, err)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSupported(sc, scr.Response); err != nil {
+		return nil, err
+	}
 	return scr.Response, nil
 }
 
 func (prover *ProverPeer) CreateSignedCommand(payload interface{}, signingIdentity tk.SigningIdentity) (*token.SignedCommand, error) {
+	return prover.CreateSignedCommandContext(context.Background(), payload, signingIdentity)
+}
 
+// CreateSignedCommandContext is like CreateSignedCommand but the header also
+// carries the extensions of ctx.
+func (prover *ProverPeer) CreateSignedCommandContext(ctx context.Context, payload interface{}, signingIdentity tk.SigningIdentity) (*token.SignedCommand, error) {
 	command, err := commandFromPayload(payload)
 	if err != nil {
 		return nil, err
 	}
 
+	command.Header, err = prover.createHeader(ctx, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+
+	return signCommand(command, signingIdentity)
+}
+
+// createHeader returns the header of a command of the signing identity,
+// carrying the extensions of the prover and of ctx.
+func (prover *ProverPeer) createHeader(ctx context.Context, signingIdentity tk.SigningIdentity) (*token.Header, error) {
+	var extensions []*token.HeaderExtension
+	extensions = append(extensions, prover.HeaderExtensions...)
+	extensions = append(extensions, tk.HeaderExtensions(ctx)...)
+	err := tk.ValidateHeaderExtensions(extensions, tk.DefaultHeaderExtensionValidators)
+	if err != nil {
+		return nil, err
+	}
+
 	nonce := make([]byte, 32)
 	_, err = io.ReadFull(prover.RandomnessReader, nonce)
 	if err != nil {
@@ -92,13 +558,16 @@ func (prover *ProverPeer) CreateSignedCommand(payload interface{}, signingIdenti
 		return nil, err
 	}
 
-	header := &token.Header{Timestamp: ts,
-		Nonce:     nonce,
-		Creator:   creator,
-		ChannelId: prover.ChannelID,
-	}
-	command.Header = header
+	return &token.Header{Timestamp: ts,
+		Nonce:      nonce,
+		Creator:    creator,
+		ChannelId:  prover.ChannelID,
+		Extensions: extensions,
+	}, nil
+}
 
+// signCommand marshals the command and signs it with the signing identity.
+func signCommand(command *token.Command, signingIdentity tk.SigningIdentity) (*token.SignedCommand, error) {
 	raw, err := proto.Marshal(command)
 	if err != nil {
 		return nil, err
@@ -122,6 +591,30 @@ func commandFromPayload(payload interface{}) (*token.Command, error) {
 		return &token.Command{Payload: t}, nil
 	case *token.Command_TransferRequest:
 		return &token.Command{Payload: t}, nil
+	case *token.Command_RedeemRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_ListRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_ReferenceRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_BalanceRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_CreditRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_DebitRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_PauseRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_ResumeRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_IssueManifestRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_SupplyAttestationRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_CommitAttestationRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_CapabilitiesRequest:
+		return &token.Command{Payload: t}, nil
 	default:
 		return nil, errors.Errorf("command type not recognized: %T", t)
 	}
@@ -7,17 +7,23 @@ SPDX-License-Identifier: Apache-2.0
 package client_test
 
 import (
+	"context"
 	"io"
 	"strings"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
 	"github.com/hyperledger/fabric/token/client"
 	"github.com/hyperledger/fabric/token/client/mock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
 )
 
 //go:generate counterfeiter -o mock/prover_client.go -fake-name ProverClient . proverClient
@@ -68,6 +74,162 @@ var _ = Describe("TokenClient", func() {
 		prover = &client.ProverPeer{RandomnessReader: fakeRandomnessReader, ProverClient: fakeProverClient, ChannelID: channelId, Time: clock}
 	})
 
+	Describe("correlation IDs", func() {
+		It("sends a new correlation ID with the command", func() {
+			_, err := prover.RequestImport(nil, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			ctx, _, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			md, ok := metadata.FromOutgoingContext(ctx)
+			Expect(ok).To(BeTrue())
+			Expect(md.Get(tk.CorrelationIDMetadataKey)).To(HaveLen(1))
+			Expect(md.Get(tk.CorrelationIDMetadataKey)[0]).To(HaveLen(32))
+		})
+
+		It("sends the correlation ID of the context", func() {
+			ctx := tk.WithCorrelationID(context.Background(), "invoice-42")
+			_, err := prover.(*client.ProverPeer).RequestImportContext(ctx, nil, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+
+			ctx, _, _ = fakeProverClient.ProcessCommandArgsForCall(0)
+			md, _ := metadata.FromOutgoingContext(ctx)
+			Expect(md.Get(tk.CorrelationIDMetadataKey)).To(Equal([]string{"invoice-42"}))
+		})
+	})
+
+	Describe("header extensions", func() {
+		It("adds the extensions of the prover and of the context to the header", func() {
+			prover.(*client.ProverPeer).HeaderExtensions = []*token.HeaderExtension{tk.NewClientVersionExtension("1.4.0")}
+			traceContext := tk.NewTraceContextExtension("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+			ctx := tk.WithHeaderExtensions(context.Background(), traceContext)
+			_, err := prover.(*client.ProverPeer).RequestImportContext(ctx, nil, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			command := &token.Command{}
+			err = proto.Unmarshal(sc.Command, command)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(command.Header.Extensions).To(HaveLen(2))
+			Expect(proto.Equal(command.Header.Extensions[0], tk.NewClientVersionExtension("1.4.0"))).To(BeTrue())
+			Expect(proto.Equal(command.Header.Extensions[1], traceContext)).To(BeTrue())
+		})
+
+		Context("when the extensions are invalid", func() {
+			It("returns an error without sending the command", func() {
+				ctx := tk.WithHeaderExtensions(context.Background(), tk.NewClientVersionExtension(""))
+				_, err := prover.(*client.ProverPeer).RequestImportContext(ctx, nil, fakeSigningIdentity)
+				Expect(err).To(MatchError("invalid header extension 'client-version': empty client version"))
+				Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("metrics", func() {
+		var (
+			fakeHistogram *metricsfakes.Histogram
+			fakeCounter   *metricsfakes.Counter
+		)
+
+		BeforeEach(func() {
+			fakeHistogram = &metricsfakes.Histogram{}
+			fakeHistogram.WithReturns(fakeHistogram)
+			fakeCounter = &metricsfakes.Counter{}
+			fakeCounter.WithReturns(fakeCounter)
+			prover.(*client.ProverPeer).Metrics = &client.Metrics{AssembleDuration: fakeHistogram, Failures: fakeCounter}
+		})
+
+		It("records the duration of the commands", func() {
+			_, err := prover.RequestImport(nil, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeHistogram.WithCallCount()).To(Equal(1))
+			Expect(fakeHistogram.WithArgsForCall(0)).To(Equal([]string{"channel", "mychannel", "success", "true"}))
+			Expect(fakeHistogram.ObserveCallCount()).To(Equal(1))
+			Expect(fakeCounter.AddCallCount()).To(Equal(0))
+		})
+
+		Context("when the command fails", func() {
+			BeforeEach(func() {
+				fakeProverClient.ProcessCommandReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("records the failure", func() {
+				_, err := prover.RequestImport(nil, fakeSigningIdentity)
+				Expect(err).To(MatchError("wild-banana"))
+
+				Expect(fakeHistogram.WithArgsForCall(0)).To(Equal([]string{"channel", "mychannel", "success", "false"}))
+				Expect(fakeCounter.WithCallCount()).To(Equal(1))
+				Expect(fakeCounter.WithArgsForCall(0)).To(Equal([]string{"channel", "mychannel", "phase", "assemble"}))
+				Expect(fakeCounter.AddCallCount()).To(Equal(1))
+				Expect(fakeCounter.AddArgsForCall(0)).To(Equal(float64(1)))
+			})
+		})
+	})
+
+	Describe("NewProverPeer", func() {
+		Context("when the compression is not supported", func() {
+			It("returns an error", func() {
+				config := &client.ClientConfig{
+					ChannelId:     channelId,
+					ProverPeerCfg: client.ConnectionConfig{Address: "127.0.0.1:0", Compression: "lzma"},
+				}
+				_, err := client.NewProverPeer(config)
+				Expect(err).To(MatchError("failed to create a GRPCClient to prover peer 127.0.0.1:0: unsupported compression 'lzma'"))
+			})
+		})
+
+		Context("when the proxy is not supported", func() {
+			It("returns an error", func() {
+				config := &client.ClientConfig{
+					ChannelId:     channelId,
+					ProverPeerCfg: client.ConnectionConfig{Address: "127.0.0.1:0", Proxy: "ftp://proxy:21"},
+				}
+				_, err := client.NewProverPeer(config)
+				Expect(err).To(MatchError("failed to create a GRPCClient to prover peer 127.0.0.1:0: unsupported proxy scheme 'ftp'"))
+			})
+		})
+	})
+
+	Describe("Close", func() {
+		var (
+			server     *comm.GRPCServer
+			proverPeer *client.ProverPeer
+		)
+
+		BeforeEach(func() {
+			var err error
+			server, err = comm.NewGRPCServer("127.0.0.1:0", comm.ServerConfig{})
+			Expect(err).NotTo(HaveOccurred())
+			go server.Start()
+
+			proverPeer, err = client.NewProverPeer(&client.ClientConfig{
+				ChannelId:     channelId,
+				ProverPeerCfg: client.ConnectionConfig{Address: server.Address()},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			server.Stop()
+		})
+
+		It("closes the connections to the prover peer", func() {
+			err := proverPeer.Close()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = proverPeer.RequestImport(nil, fakeSigningIdentity)
+			Expect(errors.Cause(err)).To(Equal(client.ErrClosed))
+		})
+
+		Context("when the prover peer is already closed", func() {
+			It("returns ErrClosed", func() {
+				Expect(proverPeer.Close()).To(Succeed())
+				Expect(proverPeer.Close()).To(Equal(client.ErrClosed))
+			})
+		})
+	})
+
 	Describe("RequestImport", func() {
 		var (
 			tokensToIssue     []*token.TokenToIssue
@@ -247,6 +409,457 @@ var _ = Describe("TokenClient", func() {
 			})
 		})
 	})
+
+	Describe("RequestDelegatedTransferContext", func() {
+		It("includes the delegations in the transfer request", func() {
+			tokenIDs := [][]byte{[]byte("id1")}
+			shares := []*token.RecipientTransferShare{{Recipient: []byte("Bob"), Quantity: 50}}
+			delegations := []*token.SignedDelegation{{Delegation: []byte("delegation"), Signature: []byte("signature")}}
+
+			response, err := prover.(*client.ProverPeer).RequestDelegatedTransferContext(context.Background(), tokenIDs, shares, delegations, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			raw := fakeSigningIdentity.SignArgsForCall(0)
+			Expect(raw).To(Equal(ProtoMarshal(&token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_TransferRequest{
+					TransferRequest: &token.TransferRequest{
+						TokenIds:    tokenIDs,
+						Shares:      shares,
+						Delegations: delegations,
+					},
+				},
+			})))
+		})
+	})
+
+	Describe("ListTokens", func() {
+		var tokens []*token.TokenOutput
+
+		BeforeEach(func() {
+			tokens = []*token.TokenOutput{{Id: []byte("id1"), Type: "XYZ", Quantity: 50}}
+			signedCommandResp.Response = ProtoMarshal(&token.CommandResponse{
+				Payload: &token.CommandResponse_UnspentTokens{UnspentTokens: &token.UnspentTokens{Tokens: tokens}},
+			})
+		})
+
+		It("returns the unspent tokens", func() {
+			result, err := prover.(*client.ProverPeer).ListTokens(fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(HaveLen(1))
+			Expect(proto.Equal(result[0], tokens[0])).To(BeTrue())
+
+			raw := fakeSigningIdentity.SignArgsForCall(0)
+			Expect(raw).To(Equal(ProtoMarshal(&token.Command{
+				Header:  commandHeader,
+				Payload: &token.Command_ListRequest{ListRequest: &token.ListRequest{}},
+			})))
+		})
+
+		Context("when the prover returns an error", func() {
+			BeforeEach(func() {
+				signedCommandResp.Response = ProtoMarshal(&token.CommandResponse{
+					Payload: &token.CommandResponse_Err{Err: &token.Error{Message: "banana"}},
+				})
+			})
+
+			It("returns an error", func() {
+				_, err := prover.(*client.ProverPeer).ListTokens(fakeSigningIdentity)
+				Expect(err).To(MatchError("prover failed listing tokens: banana"))
+			})
+		})
+	})
+
+	Describe("ListPseudonymTokensContext", func() {
+		var (
+			tokens             []*token.TokenOutput
+			fakePseudonymID    *mock.Identity
+			fakePseudonym      *mock.SigningIdentity
+			expectedProofBytes []byte
+		)
+
+		BeforeEach(func() {
+			tokens = []*token.TokenOutput{{Id: []byte("id1"), Type: "XYZ", Quantity: 50}}
+			signedCommandResp.Response = ProtoMarshal(&token.CommandResponse{
+				Payload: &token.CommandResponse_UnspentTokens{UnspentTokens: &token.UnspentTokens{Tokens: tokens}},
+			})
+
+			fakePseudonymID = &mock.Identity{}
+			fakePseudonymID.SerializeReturns([]byte("pseudonym"), nil)
+			fakePseudonym = &mock.SigningIdentity{}
+			fakePseudonym.GetPublicVersionReturns(fakePseudonymID)
+			fakePseudonym.SignReturns([]byte("pseudonym-signature"), nil)
+			expectedProofBytes = append(append([]byte{}, commandHeader.Nonce...), commandHeader.Creator...)
+		})
+
+		It("returns the unspent tokens of the pseudonyms", func() {
+			result, err := prover.(*client.ProverPeer).ListPseudonymTokensContext(context.Background(), []tk.SigningIdentity{fakePseudonym}, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(HaveLen(1))
+			Expect(proto.Equal(result[0], tokens[0])).To(BeTrue())
+
+			Expect(fakePseudonym.SignCallCount()).To(Equal(1))
+			Expect(fakePseudonym.SignArgsForCall(0)).To(Equal(expectedProofBytes))
+			raw := fakeSigningIdentity.SignArgsForCall(0)
+			Expect(raw).To(Equal(ProtoMarshal(&token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_ListRequest{ListRequest: &token.ListRequest{
+					PseudonymProofs: []*token.PseudonymProof{{Owner: []byte("pseudonym"), Signature: []byte("pseudonym-signature")}},
+				}},
+			})))
+		})
+
+		Context("when no pseudonym is passed", func() {
+			It("returns an error", func() {
+				_, err := prover.(*client.ProverPeer).ListPseudonymTokensContext(context.Background(), nil, fakeSigningIdentity)
+				Expect(err).To(MatchError("no pseudonyms to list the tokens of"))
+				Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when a pseudonym fails to sign", func() {
+			BeforeEach(func() {
+				fakePseudonym.SignReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := prover.(*client.ProverPeer).ListPseudonymTokensContext(context.Background(), []tk.SigningIdentity{fakePseudonym}, fakeSigningIdentity)
+				Expect(err).To(MatchError("failed signing pseudonym proof: wild-banana"))
+				Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the prover returns an error", func() {
+			BeforeEach(func() {
+				signedCommandResp.Response = ProtoMarshal(&token.CommandResponse{
+					Payload: &token.CommandResponse_Err{Err: &token.Error{Message: "banana"}},
+				})
+			})
+
+			It("returns an error", func() {
+				_, err := prover.(*client.ProverPeer).ListPseudonymTokensContext(context.Background(), []tk.SigningIdentity{fakePseudonym}, fakeSigningIdentity)
+				Expect(err).To(MatchError("prover failed listing tokens: banana"))
+			})
+		})
+	})
+
+	Describe("ListTransactionsByReferenceContext", func() {
+		var transactions []*token.ReferencedTransaction
+
+		BeforeEach(func() {
+			transactions = []*token.ReferencedTransaction{
+				{TxId: "tx-1", TokenTransaction: &token.TokenTransaction{ApplicationReference: []byte("invoice-42")}},
+			}
+			signedCommandResp.Response = ProtoMarshal(&token.CommandResponse{
+				Payload: &token.CommandResponse_ReferencedTransactions{
+					ReferencedTransactions: &token.ReferencedTransactions{Transactions: transactions},
+				},
+			})
+		})
+
+		It("returns the referenced transactions", func() {
+			response, err := prover.(*client.ProverPeer).ListTransactionsByReferenceContext(context.Background(), []byte("invoice-42"), fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(HaveLen(1))
+			Expect(proto.Equal(response[0], transactions[0])).To(BeTrue())
+
+			raw := fakeSigningIdentity.SignArgsForCall(0)
+			Expect(raw).To(Equal(ProtoMarshal(&token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_ReferenceRequest{
+					ReferenceRequest: &token.ReferenceRequest{ApplicationReference: []byte("invoice-42")},
+				},
+			})))
+		})
+
+		Context("when the prover returns an error", func() {
+			BeforeEach(func() {
+				signedCommandResp.Response = ProtoMarshal(&token.CommandResponse{
+					Payload: &token.CommandResponse_Err{Err: &token.Error{Message: "banana"}},
+				})
+			})
+
+			It("returns an error", func() {
+				_, err := prover.(*client.ProverPeer).ListTransactionsByReferenceContext(context.Background(), []byte("invoice-42"), fakeSigningIdentity)
+				Expect(err).To(MatchError("prover failed listing referenced transactions: banana"))
+			})
+		})
+	})
+
+	Describe("RequestSupplyAttestationContext", func() {
+		var attestation *token.SignedSupplyAttestation
+
+		BeforeEach(func() {
+			attestation = &token.SignedSupplyAttestation{Attestation: []byte("attestation"), Signature: []byte("signature")}
+			signedCommandResp.Response = ProtoMarshal(&token.CommandResponse{
+				Payload: &token.CommandResponse_SupplyAttestation{SupplyAttestation: attestation},
+			})
+		})
+
+		It("returns the attestation of the supply", func() {
+			response, err := prover.(*client.ProverPeer).RequestSupplyAttestationContext(context.Background(), []string{"USD"}, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(response, attestation)).To(BeTrue())
+
+			raw := fakeSigningIdentity.SignArgsForCall(0)
+			Expect(raw).To(Equal(ProtoMarshal(&token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_SupplyAttestationRequest{
+					SupplyAttestationRequest: &token.SupplyAttestationRequest{Types: []string{"USD"}},
+				},
+			})))
+		})
+
+		Context("when the prover returns an error", func() {
+			BeforeEach(func() {
+				signedCommandResp.Response = ProtoMarshal(&token.CommandResponse{
+					Payload: &token.CommandResponse_Err{Err: &token.Error{Message: "banana"}},
+				})
+			})
+
+			It("returns an error", func() {
+				_, err := prover.(*client.ProverPeer).RequestSupplyAttestationContext(context.Background(), nil, fakeSigningIdentity)
+				Expect(err).To(MatchError("prover failed attesting the token supply: banana"))
+			})
+		})
+	})
+
+	Describe("RequestCommitAttestationContext", func() {
+		var attestation *token.SignedCommitAttestation
+
+		BeforeEach(func() {
+			attestation = &token.SignedCommitAttestation{Attestation: []byte("attestation"), Signature: []byte("signature")}
+			signedCommandResp.Response = ProtoMarshal(&token.CommandResponse{
+				Payload: &token.CommandResponse_CommitAttestation{CommitAttestation: attestation},
+			})
+		})
+
+		It("returns the attestation of the commit", func() {
+			response, err := prover.(*client.ProverPeer).RequestCommitAttestationContext(context.Background(), "tx1", fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(response, attestation)).To(BeTrue())
+
+			raw := fakeSigningIdentity.SignArgsForCall(0)
+			Expect(raw).To(Equal(ProtoMarshal(&token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_CommitAttestationRequest{
+					CommitAttestationRequest: &token.CommitAttestationRequest{TxId: "tx1"},
+				},
+			})))
+		})
+
+		Context("when the prover returns an error", func() {
+			BeforeEach(func() {
+				signedCommandResp.Response = ProtoMarshal(&token.CommandResponse{
+					Payload: &token.CommandResponse_Err{Err: &token.Error{Message: "banana"}},
+				})
+			})
+
+			It("returns an error", func() {
+				_, err := prover.(*client.ProverPeer).RequestCommitAttestationContext(context.Background(), "tx1", fakeSigningIdentity)
+				Expect(err).To(MatchError("prover failed attesting the commit of transaction tx1: banana"))
+			})
+		})
+	})
+
+	Describe("GetChannelCapabilitiesContext", func() {
+		BeforeEach(func() {
+			signedCommandResp.Response = ProtoMarshal(&token.CommandResponse{
+				Payload: &token.CommandResponse_ChannelCapabilities{
+					ChannelCapabilities: &token.ChannelCapabilities{FabToken: true},
+				},
+			})
+		})
+
+		It("returns the channel capabilities", func() {
+			capabilities, err := prover.(*client.ProverPeer).GetChannelCapabilitiesContext(context.Background(), fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(capabilities, &token.ChannelCapabilities{FabToken: true})).To(BeTrue())
+
+			raw := fakeSigningIdentity.SignArgsForCall(0)
+			Expect(raw).To(Equal(ProtoMarshal(&token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_CapabilitiesRequest{
+					CapabilitiesRequest: &token.CapabilitiesRequest{},
+				},
+			})))
+		})
+
+		Context("when the prover returns an error", func() {
+			BeforeEach(func() {
+				signedCommandResp.Response = ProtoMarshal(&token.CommandResponse{
+					Payload: &token.CommandResponse_Err{Err: &token.Error{Message: "banana"}},
+				})
+			})
+
+			It("returns an error", func() {
+				_, err := prover.(*client.ProverPeer).GetChannelCapabilitiesContext(context.Background(), fakeSigningIdentity)
+				Expect(err).To(MatchError("prover failed getting channel capabilities: banana"))
+			})
+		})
+
+		Context("when the prover predates capability requests", func() {
+			BeforeEach(func() {
+				signedCommandResp.Response = ProtoMarshal(&token.CommandResponse{
+					Payload: &token.CommandResponse_Err{Err: &token.Error{Message: "command type not recognized: <nil>"}},
+				})
+			})
+
+			It("returns an IncompatibleProverError", func() {
+				_, err := prover.(*client.ProverPeer).GetChannelCapabilitiesContext(context.Background(), fakeSigningIdentity)
+				Expect(err).To(Equal(&client.IncompatibleProverError{
+					Command: "CapabilitiesRequest",
+					Message: "command type not recognized: <nil>",
+				}))
+				Expect(err).To(MatchError("prover peer does not support CapabilitiesRequest commands, it may run an older version than the client: command type not recognized: <nil>"))
+			})
+		})
+	})
+
+	Describe("RequestRedeem", func() {
+		It("sends a redeem request", func() {
+			response, err := prover.RequestRedeem([][]byte{[]byte("id1")}, 50, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			raw := fakeSigningIdentity.SignArgsForCall(0)
+			Expect(raw).To(Equal(ProtoMarshal(&token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_RedeemRequest{
+					RedeemRequest: &token.RedeemRequest{
+						TokenIds:         [][]byte{[]byte("id1")},
+						QuantityToRedeem: 50,
+					},
+				},
+			})))
+		})
+	})
+
+	Describe("RequestCreditContext", func() {
+		It("sends a credit request", func() {
+			response, err := prover.(*client.ProverPeer).RequestCreditContext(context.Background(), []byte("Bob"), "XYZ", 50, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			raw := fakeSigningIdentity.SignArgsForCall(0)
+			Expect(raw).To(Equal(ProtoMarshal(&token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_CreditRequest{
+					CreditRequest: &token.CreditRequest{
+						Recipient: []byte("Bob"),
+						Type:      "XYZ",
+						Quantity:  50,
+					},
+				},
+			})))
+		})
+	})
+
+	Describe("RequestDebitContext", func() {
+		It("sends a debit request", func() {
+			response, err := prover.(*client.ProverPeer).RequestDebitContext(context.Background(), "XYZ", 50, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			raw := fakeSigningIdentity.SignArgsForCall(0)
+			Expect(raw).To(Equal(ProtoMarshal(&token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_DebitRequest{
+					DebitRequest: &token.DebitRequest{
+						Type:     "XYZ",
+						Quantity: 50,
+					},
+				},
+			})))
+		})
+	})
+
+	Describe("RequestPauseContext", func() {
+		It("sends a pause request", func() {
+			response, err := prover.(*client.ProverPeer).RequestPauseContext(context.Background(), "XYZ", fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			raw := fakeSigningIdentity.SignArgsForCall(0)
+			Expect(raw).To(Equal(ProtoMarshal(&token.Command{
+				Header:  commandHeader,
+				Payload: &token.Command_PauseRequest{PauseRequest: &token.PauseRequest{Type: "XYZ"}},
+			})))
+		})
+	})
+
+	Describe("RequestResumeContext", func() {
+		It("sends a resume request", func() {
+			response, err := prover.(*client.ProverPeer).RequestResumeContext(context.Background(), "XYZ", fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			raw := fakeSigningIdentity.SignArgsForCall(0)
+			Expect(raw).To(Equal(ProtoMarshal(&token.Command{
+				Header:  commandHeader,
+				Payload: &token.Command_ResumeRequest{ResumeRequest: &token.ResumeRequest{Type: "XYZ"}},
+			})))
+		})
+	})
+
+	Describe("RequestIssueManifestContext", func() {
+		It("sends an issue manifest request", func() {
+			chunks := []*token.IssueChunk{{TxId: "tx-1", SeriesId: []byte("batch-1/0")}}
+			response, err := prover.(*client.ProverPeer).RequestIssueManifestContext(context.Background(), []byte("batch-1"), chunks, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			raw := fakeSigningIdentity.SignArgsForCall(0)
+			Expect(raw).To(Equal(ProtoMarshal(&token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_IssueManifestRequest{
+					IssueManifestRequest: &token.IssueManifestRequest{BatchId: []byte("batch-1"), Chunks: chunks},
+				},
+			})))
+		})
+	})
+
+	Describe("CreateSignedDelegation", func() {
+		It("returns a delegation signed by the signing identity", func() {
+			sd, err := prover.(*client.ProverPeer).CreateSignedDelegation([]byte("service"), "PDQ", 50, 1000, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sd.Signature).To(Equal([]byte("pineapple")))
+			Expect(fakeSigningIdentity.SignArgsForCall(0)).To(Equal(sd.Delegation))
+
+			delegation := &token.Delegation{}
+			err = proto.Unmarshal(sd.Delegation, delegation)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(delegation, &token.Delegation{
+				Delegator:       []byte("Alice"),
+				Delegatee:       []byte("service"),
+				Type:            "PDQ",
+				Quantity:        50,
+				ExpirationBlock: 1000,
+				ChannelId:       "mychannel",
+				Nonce:           make([]byte, 32),
+			})).To(BeTrue())
+		})
+
+		Context("when the expiration block is 0", func() {
+			It("returns an error", func() {
+				_, err := prover.(*client.ProverPeer).CreateSignedDelegation([]byte("service"), "PDQ", 50, 0, fakeSigningIdentity)
+				Expect(err).To(MatchError("expiration block must be greater than 0"))
+				Expect(fakeSigningIdentity.SignCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when SigningIdentity sign fails", func() {
+			BeforeEach(func() {
+				fakeSigningIdentity.SignReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := prover.(*client.ProverPeer).CreateSignedDelegation([]byte("service"), "PDQ", 50, 1000, fakeSigningIdentity)
+				Expect(err).To(MatchError("wild-banana"))
+			})
+		})
+	})
 })
 
 func clock() time.Time {
@@ -0,0 +1,299 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrCircuitOpen is returned, without calling the remote service, while a
+// circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// BreakerStats summarizes the outcome of the calls observed by a breaker
+// since its window started.
+type BreakerStats struct {
+	Requests            int
+	Failures            int
+	ConsecutiveFailures int
+}
+
+//go:generate counterfeiter -o mock/breaker_policy.go -fake-name BreakerPolicy . BreakerPolicy
+
+// BreakerPolicy decides when a circuit breaker opens.
+type BreakerPolicy interface {
+	// ShouldTrip returns true when the observed calls warrant opening the circuit.
+	ShouldTrip(stats BreakerStats) bool
+}
+
+// ErrorRatePolicy opens the circuit once at least MinRequests calls have been
+// observed and the share of failed calls reaches Ratio.
+type ErrorRatePolicy struct {
+	MinRequests int
+	Ratio       float64
+}
+
+func (e *ErrorRatePolicy) ShouldTrip(stats BreakerStats) bool {
+	if stats.Requests == 0 || stats.Requests < e.MinRequests {
+		return false
+	}
+	return float64(stats.Failures)/float64(stats.Requests) >= e.Ratio
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// CircuitBreaker stops calls to a failing service for a cool-down period so
+// that a slow or unavailable prover or orderer does not consume the deadline
+// of every request. After OpenDuration a single trial call is let through; its
+// outcome closes the circuit again or re-opens it.
+type CircuitBreaker struct {
+	Name   string
+	Policy BreakerPolicy
+	// OpenDuration is how long the circuit stays open before a trial call.
+	OpenDuration time.Duration
+	// Window is how often the statistics of a closed circuit are reset.
+	Window time.Duration
+	Time   TimeFunc
+
+	mutex       sync.Mutex
+	state       breakerState
+	stats       BreakerStats
+	windowStart time.Time
+	openedAt    time.Time
+	trial       bool
+}
+
+// Allow returns ErrCircuitOpen when the call must not be attempted. Every call
+// that is allowed must be followed by a call to Record. A nil breaker allows
+// every call.
+func (c *CircuitBreaker) Allow() error {
+	if c == nil {
+		return nil
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := c.now()
+	switch c.state {
+	case breakerOpen:
+		if now.Sub(c.openedAt) < c.OpenDuration {
+			return errors.WithMessage(ErrCircuitOpen, c.Name)
+		}
+		c.state = breakerHalfOpen
+		c.trial = true
+		return nil
+	case breakerHalfOpen:
+		if c.trial {
+			return errors.WithMessage(ErrCircuitOpen, c.Name)
+		}
+		c.trial = true
+		return nil
+	default:
+		if c.Window > 0 && now.Sub(c.windowStart) >= c.Window {
+			c.stats = BreakerStats{}
+			c.windowStart = now
+		}
+		return nil
+	}
+}
+
+// Record reports the outcome of a call let through by Allow.
+func (c *CircuitBreaker) Record(err error) {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.state == breakerHalfOpen {
+		c.trial = false
+		if err != nil {
+			c.open()
+			return
+		}
+		c.state = breakerClosed
+		c.stats = BreakerStats{}
+		c.windowStart = c.now()
+		logger.Infof("circuit breaker %s closed", c.Name)
+		return
+	}
+
+	c.stats.Requests++
+	if err != nil {
+		c.stats.Failures++
+		c.stats.ConsecutiveFailures++
+	} else {
+		c.stats.ConsecutiveFailures = 0
+	}
+	if c.state == breakerClosed && c.Policy != nil && c.Policy.ShouldTrip(c.stats) {
+		c.open()
+	}
+}
+
+// Do calls fn if the circuit allows it and records its outcome.
+func (c *CircuitBreaker) Do(fn func() error) error {
+	if c == nil {
+		return fn()
+	}
+	err := c.Allow()
+	if err != nil {
+		return err
+	}
+	err = fn()
+	c.Record(err)
+	return err
+}
+
+func (c *CircuitBreaker) open() {
+	c.state = breakerOpen
+	c.openedAt = c.now()
+	c.stats = BreakerStats{}
+	logger.Warningf("circuit breaker %s opened", c.Name)
+}
+
+func (c *CircuitBreaker) now() time.Time {
+	if c.Time != nil {
+		return c.Time()
+	}
+	return time.Now()
+}
+
+// Phase is a step in the life of a token transaction.
+type Phase int
+
+const (
+	// PhaseAssemble is the prover assembling the token transaction.
+	PhaseAssemble Phase = iota
+	// PhaseOrder is the orderer accepting the transaction.
+	PhaseOrder
+	// PhaseCommit is waiting for the transaction to be committed.
+	PhaseCommit
+)
+
+func (p Phase) String() string {
+	switch p {
+	case PhaseAssemble:
+		return "assemble"
+	case PhaseOrder:
+		return "order"
+	case PhaseCommit:
+		return "commit"
+	default:
+		return "unknown"
+	}
+}
+
+//go:generate counterfeiter -o mock/budget_policy.go -fake-name BudgetPolicy . BudgetPolicy
+
+// BudgetPolicy decides how much of the remaining time a phase may use.
+type BudgetPolicy interface {
+	Allocate(phase Phase, remaining time.Duration) time.Duration
+}
+
+// WeightedBudgetPolicy splits the remaining time between the current phase and
+// the phases that follow it in proportion to their weights. Time a phase does
+// not use is carried over to the later phases.
+type WeightedBudgetPolicy struct {
+	Assemble float64
+	Order    float64
+	Commit   float64
+}
+
+// DefaultBudgetPolicy reserves the largest share of the budget for the commit wait.
+var DefaultBudgetPolicy = &WeightedBudgetPolicy{Assemble: 0.3, Order: 0.2, Commit: 0.5}
+
+func (w *WeightedBudgetPolicy) Allocate(phase Phase, remaining time.Duration) time.Duration {
+	weights := []float64{w.Assemble, w.Order, w.Commit}
+	if int(phase) >= len(weights) {
+		return remaining
+	}
+	var total float64
+	for _, weight := range weights[phase:] {
+		total += weight
+	}
+	if total <= 0 {
+		return remaining
+	}
+	return time.Duration(float64(remaining) * weights[phase] / total)
+}
+
+// DeadlineBudget distributes a single deadline across the phases of a token
+// transaction, so that a slow prover cannot consume the time reserved for the
+// orderer and the commit wait.
+type DeadlineBudget struct {
+	deadline time.Time
+	policy   BudgetPolicy
+	time     TimeFunc
+}
+
+// NewDeadlineBudget creates a budget of total, split according to policy.
+// DefaultBudgetPolicy is used when policy is nil.
+func NewDeadlineBudget(total time.Duration, policy BudgetPolicy, timeFunc TimeFunc) *DeadlineBudget {
+	if policy == nil {
+		policy = DefaultBudgetPolicy
+	}
+	if timeFunc == nil {
+		timeFunc = time.Now
+	}
+	return &DeadlineBudget{
+		deadline: timeFunc().Add(total),
+		policy:   policy,
+		time:     timeFunc,
+	}
+}
+
+// Remaining returns the time left in the budget.
+func (d *DeadlineBudget) Remaining() time.Duration {
+	remaining := d.deadline.Sub(d.time())
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// Context returns a context that expires when the time allocated to phase runs out.
+func (d *DeadlineBudget) Context(parent context.Context, phase Phase) (context.Context, context.CancelFunc) {
+	remaining := d.Remaining()
+	if remaining == 0 {
+		ctx, cancel := context.WithCancel(parent)
+		cancel()
+		return ctx, cancel
+	}
+	allocated := d.policy.Allocate(phase, remaining)
+	if allocated > remaining || allocated <= 0 {
+		allocated = remaining
+	}
+	return context.WithTimeout(parent, allocated)
+}
+
+type budgetKey struct{}
+
+// WithBudget returns a copy of ctx that carries budget. Calls made with the
+// returned context bound each phase by its share of the budget.
+func WithBudget(ctx context.Context, budget *DeadlineBudget) context.Context {
+	return context.WithValue(ctx, budgetKey{}, budget)
+}
+
+// phaseContext bounds ctx by the share of phase in the budget carried by
+// ctx, if any.
+func phaseContext(ctx context.Context, phase Phase) (context.Context, context.CancelFunc) {
+	budget, ok := ctx.Value(budgetKey{}).(*DeadlineBudget)
+	if !ok || budget == nil {
+		return context.WithCancel(ctx)
+	}
+	return budget.Context(ctx, phase)
+}
@@ -0,0 +1,236 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client_test
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/hyperledger/fabric/token/client/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("CircuitBreaker", func() {
+	var (
+		now        time.Time
+		fakePolicy *mock.BreakerPolicy
+		breaker    *client.CircuitBreaker
+	)
+
+	BeforeEach(func() {
+		now = time.Unix(1000, 0)
+		fakePolicy = &mock.BreakerPolicy{}
+		breaker = &client.CircuitBreaker{
+			Name:         "prover",
+			Policy:       fakePolicy,
+			OpenDuration: time.Minute,
+			Time:         func() time.Time { return now },
+		}
+	})
+
+	It("passes the observed calls to the policy", func() {
+		breaker.Do(func() error { return nil })
+		breaker.Do(func() error { return errors.New("boom") })
+
+		Expect(fakePolicy.ShouldTripCallCount()).To(Equal(2))
+		Expect(fakePolicy.ShouldTripArgsForCall(1)).To(Equal(client.BreakerStats{
+			Requests:            2,
+			Failures:            1,
+			ConsecutiveFailures: 1,
+		}))
+	})
+
+	Context("when the policy trips", func() {
+		BeforeEach(func() {
+			fakePolicy.ShouldTripReturns(true)
+			err := breaker.Do(func() error { return errors.New("boom") })
+			Expect(err).To(MatchError("boom"))
+		})
+
+		It("rejects calls while open", func() {
+			called := false
+			err := breaker.Do(func() error { called = true; return nil })
+			Expect(errors.Cause(err)).To(Equal(client.ErrCircuitOpen))
+			Expect(err).To(MatchError("prover: circuit breaker is open"))
+			Expect(called).To(BeFalse())
+		})
+
+		It("lets a single trial call through after the open duration", func() {
+			now = now.Add(time.Minute)
+			Expect(breaker.Allow()).To(Succeed())
+			Expect(errors.Cause(breaker.Allow())).To(Equal(client.ErrCircuitOpen))
+
+			breaker.Record(nil)
+			Expect(breaker.Allow()).To(Succeed())
+		})
+
+		It("re-opens when the trial call fails", func() {
+			now = now.Add(time.Minute)
+			err := breaker.Do(func() error { return errors.New("still down") })
+			Expect(err).To(MatchError("still down"))
+
+			now = now.Add(time.Second)
+			Expect(errors.Cause(breaker.Allow())).To(Equal(client.ErrCircuitOpen))
+		})
+	})
+
+	Context("when the window elapses", func() {
+		BeforeEach(func() {
+			breaker.Window = time.Minute
+		})
+
+		It("resets the statistics", func() {
+			breaker.Do(func() error { return errors.New("boom") })
+			now = now.Add(time.Minute)
+			breaker.Do(func() error { return nil })
+
+			Expect(fakePolicy.ShouldTripArgsForCall(1)).To(Equal(client.BreakerStats{Requests: 1}))
+		})
+	})
+
+	Context("when the breaker is nil", func() {
+		It("calls the function", func() {
+			var nilBreaker *client.CircuitBreaker
+			err := nilBreaker.Do(func() error { return errors.New("boom") })
+			Expect(err).To(MatchError("boom"))
+		})
+	})
+})
+
+var _ = Describe("ErrorRatePolicy", func() {
+	var policy *client.ErrorRatePolicy
+
+	BeforeEach(func() {
+		policy = &client.ErrorRatePolicy{MinRequests: 4, Ratio: 0.5}
+	})
+
+	It("does not trip below the minimum number of requests", func() {
+		Expect(policy.ShouldTrip(client.BreakerStats{Requests: 3, Failures: 3})).To(BeFalse())
+	})
+
+	It("trips when the error rate reaches the ratio", func() {
+		Expect(policy.ShouldTrip(client.BreakerStats{Requests: 4, Failures: 1})).To(BeFalse())
+		Expect(policy.ShouldTrip(client.BreakerStats{Requests: 4, Failures: 2})).To(BeTrue())
+	})
+})
+
+var _ = Describe("DeadlineBudget", func() {
+	var (
+		now       time.Time
+		timeFunc  client.TimeFunc
+		budget    *client.DeadlineBudget
+		fakeSplit *mock.BudgetPolicy
+	)
+
+	BeforeEach(func() {
+		now = time.Unix(1000, 0)
+		timeFunc = func() time.Time { return now }
+		budget = client.NewDeadlineBudget(10*time.Second, nil, timeFunc)
+	})
+
+	It("splits the remaining time according to the weights", func() {
+		policy := client.DefaultBudgetPolicy
+		Expect(policy.Allocate(client.PhaseAssemble, 10*time.Second)).To(Equal(3 * time.Second))
+		Expect(policy.Allocate(client.PhaseOrder, 7*time.Second)).To(Equal(2 * time.Second))
+		Expect(policy.Allocate(client.PhaseCommit, 5*time.Second)).To(Equal(5 * time.Second))
+	})
+
+	It("carries unused time over to later phases", func() {
+		now = now.Add(time.Second)
+		Expect(budget.Remaining()).To(Equal(9 * time.Second))
+		Expect(client.DefaultBudgetPolicy.Allocate(client.PhaseOrder, budget.Remaining())).To(Equal(9 * time.Second * 2 / 7))
+	})
+
+	It("returns a context bounded by the phase allocation", func() {
+		fakeSplit = &mock.BudgetPolicy{}
+		fakeSplit.AllocateReturns(time.Hour)
+		budget = client.NewDeadlineBudget(time.Minute, fakeSplit, nil)
+
+		ctx, cancel := budget.Context(context.Background(), client.PhaseCommit)
+		defer cancel()
+		deadline, ok := ctx.Deadline()
+		Expect(ok).To(BeTrue())
+		Expect(deadline).To(BeTemporally("~", time.Now().Add(time.Minute), time.Second))
+
+		phase, remaining := fakeSplit.AllocateArgsForCall(0)
+		Expect(phase).To(Equal(client.PhaseCommit))
+		Expect(remaining).To(BeNumerically("~", time.Minute, time.Second))
+	})
+
+	Context("when the budget is exhausted", func() {
+		It("returns a context that is already done", func() {
+			now = now.Add(time.Minute)
+			ctx, cancel := budget.Context(context.Background(), client.PhaseAssemble)
+			defer cancel()
+			Expect(ctx.Err()).To(Equal(context.Canceled))
+		})
+	})
+})
+
+var _ = Describe("ProverPeer with a deadline budget", func() {
+	var (
+		fakeIdentity        *mock.Identity
+		fakeSigningIdentity *mock.SigningIdentity
+		fakeProverClient    *mock.ProverClient
+		fakePolicy          *mock.BreakerPolicy
+		prover              *client.ProverPeer
+	)
+
+	BeforeEach(func() {
+		fakeIdentity = &mock.Identity{}
+		fakeIdentity.SerializeReturns([]byte("Alice"), nil)
+		fakeSigningIdentity = &mock.SigningIdentity{}
+		fakeSigningIdentity.GetPublicVersionReturns(fakeIdentity)
+		fakeSigningIdentity.SignReturns([]byte("pineapple"), nil)
+		fakeProverClient = &mock.ProverClient{}
+		fakeProverClient.ProcessCommandReturns(&token.SignedCommandResponse{Response: []byte("command-response")}, nil)
+		fakePolicy = &mock.BreakerPolicy{}
+
+		prover = &client.ProverPeer{
+			ChannelID:        "mychannel",
+			ProverClient:     fakeProverClient,
+			RandomnessReader: strings.NewReader(strings.Repeat("x", 64)),
+			Time:             clock,
+			Breaker:          &client.CircuitBreaker{Name: "prover", Policy: fakePolicy, OpenDuration: time.Minute},
+		}
+	})
+
+	It("bounds the prover call by the assemble share of the budget", func() {
+		budget := client.NewDeadlineBudget(10*time.Second, nil, nil)
+		ctx := client.WithBudget(context.Background(), budget)
+
+		resp, err := prover.RequestImportContext(ctx, []*token.TokenToIssue{{Type: "PDQ", Quantity: 1}}, fakeSigningIdentity)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp).To(Equal([]byte("command-response")))
+
+		callCtx, _, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+		deadline, ok := callCtx.Deadline()
+		Expect(ok).To(BeTrue())
+		Expect(deadline).To(BeTemporally("~", time.Now().Add(3*time.Second), time.Second))
+		Expect(fakePolicy.ShouldTripCallCount()).To(Equal(1))
+	})
+
+	Context("when the prover circuit is open", func() {
+		BeforeEach(func() {
+			fakePolicy.ShouldTripReturns(true)
+			fakeProverClient.ProcessCommandReturnsOnCall(0, nil, errors.New("slow-banana"))
+			_, err := prover.RequestTransfer([][]byte{[]byte("id")}, nil, fakeSigningIdentity)
+			Expect(err).To(MatchError("slow-banana"))
+		})
+
+		It("fails fast without calling the prover", func() {
+			_, err := prover.RequestTransfer([][]byte{[]byte("id")}, nil, fakeSigningIdentity)
+			Expect(errors.Cause(err)).To(Equal(client.ErrCircuitOpen))
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+		})
+	})
+})
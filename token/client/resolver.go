@@ -0,0 +1,354 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	dnsScheme = "dns://"
+	srvScheme = "srv://"
+)
+
+//go:generate counterfeiter -o mock/resolver.go -fake-name Resolver . Resolver
+
+// Resolver resolves a logical address to the addresses of its backends
+type Resolver interface {
+	Resolve(ctx context.Context, address string) ([]string, error)
+}
+
+// DNSResolver resolves addresses of the form dns://host:port to every address
+// host resolves to, for instance the pods behind a headless Kubernetes service,
+// and addresses of the form srv://name to the targets of the SRV records of name
+// with the lowest priority, ordered by decreasing weight. Other addresses
+// resolve to themselves.
+type DNSResolver struct {
+	// LookupHost and LookupSRV default to the functions of net.DefaultResolver
+	LookupHost func(ctx context.Context, host string) ([]string, error)
+	LookupSRV  func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+// Resolve returns the backends of address.
+func (r *DNSResolver) Resolve(ctx context.Context, address string) ([]string, error) {
+	switch {
+	case strings.HasPrefix(address, dnsScheme):
+		return r.resolveHost(ctx, strings.TrimPrefix(address, dnsScheme))
+	case strings.HasPrefix(address, srvScheme):
+		return r.resolveSRV(ctx, strings.TrimPrefix(address, srvScheme))
+	default:
+		return []string{address}, nil
+	}
+}
+
+func (r *DNSResolver) resolveHost(ctx context.Context, address string) ([]string, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid address %s", address)
+	}
+	lookupHost := r.LookupHost
+	if lookupHost == nil {
+		lookupHost = net.DefaultResolver.LookupHost
+	}
+	hosts, err := lookupHost(ctx, host)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed resolving %s", host)
+	}
+	sort.Strings(hosts)
+
+	var backends []string
+	for _, h := range hosts {
+		backends = append(backends, net.JoinHostPort(h, port))
+	}
+	return backends, nil
+}
+
+func (r *DNSResolver) resolveSRV(ctx context.Context, name string) ([]string, error) {
+	lookupSRV := r.LookupSRV
+	if lookupSRV == nil {
+		lookupSRV = net.DefaultResolver.LookupSRV
+	}
+	_, records, err := lookupSRV(ctx, "", "", name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed resolving SRV records of %s", name)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].Priority != records[j].Priority {
+			return records[i].Priority < records[j].Priority
+		}
+		return records[i].Weight > records[j].Weight
+	})
+	var backends []string
+	for _, record := range records {
+		if record.Priority != records[0].Priority {
+			break
+		}
+		target := strings.TrimSuffix(record.Target, ".")
+		backends = append(backends, net.JoinHostPort(target, strconv.Itoa(int(record.Port))))
+	}
+	return backends, nil
+}
+
+// serverName returns the name used to verify the TLS certificates of the
+// backends of address: the override if set, the host name of dns:// addresses,
+// or the empty string so that each backend is verified against its own address.
+func serverName(address, serverNameOverride string) string {
+	if serverNameOverride != "" || !strings.HasPrefix(address, dnsScheme) {
+		return serverNameOverride
+	}
+	host, _, err := net.SplitHostPort(strings.TrimPrefix(address, dnsScheme))
+	if err != nil {
+		return ""
+	}
+	return host
+}
+
+// Endpoints balances the RPCs to a logical address across its backends. The
+// address is resolved again on each pick, so that backends joining or leaving
+// are taken into account without reconnecting the client.
+type Endpoints struct {
+	Address  string
+	Resolver Resolver
+
+	mutex    sync.Mutex
+	next     int
+	backends []string
+}
+
+// NewEndpoints returns the Endpoints of address resolved with a DNSResolver.
+func NewEndpoints(address string) *Endpoints {
+	return &Endpoints{Address: address, Resolver: &DNSResolver{}}
+}
+
+// Next returns the backends in turn.
+func (e *Endpoints) Next(ctx context.Context) (string, error) {
+	backends, err := e.resolve(ctx)
+	if err != nil {
+		return "", err
+	}
+	return e.roundRobin(backends), nil
+}
+
+// Sticky returns current as long as it is a backend of the address, so that
+// streams reconnect to the backend they were using, and the next backend otherwise.
+func (e *Endpoints) Sticky(ctx context.Context, current string) (string, error) {
+	backends, err := e.resolve(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, backend := range backends {
+		if backend == current {
+			return current, nil
+		}
+	}
+	return e.roundRobin(backends), nil
+}
+
+// Backends returns the backends found by the last resolution.
+func (e *Endpoints) Backends() []string {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.backends
+}
+
+func (e *Endpoints) roundRobin(backends []string) string {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	backend := backends[e.next%len(backends)]
+	e.next++
+	return backend
+}
+
+func (e *Endpoints) resolve(ctx context.Context) ([]string, error) {
+	backends, err := e.Resolver.Resolve(ctx, e.Address)
+	if err != nil {
+		return nil, err
+	}
+	if len(backends) == 0 {
+		return nil, errors.Errorf("no backends found for %s", e.Address)
+	}
+
+	e.mutex.Lock()
+	e.backends = backends
+	e.mutex.Unlock()
+	return backends, nil
+}
+
+// connectionCache keeps a connection to each backend of a logical address,
+// closing the connections to backends that are no longer resolved.
+type connectionCache struct {
+	grpcClient *comm.GRPCClient
+	serverName string
+
+	mutex  sync.Mutex
+	conns  map[string]*grpc.ClientConn
+	closed bool
+}
+
+func (c *connectionCache) get(backend string) (*grpc.ClientConn, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.closed {
+		return nil, ErrClosed
+	}
+	if conn, ok := c.conns[backend]; ok {
+		return conn, nil
+	}
+	conn, err := c.grpcClient.NewConnection(backend, c.serverName)
+	if err != nil {
+		return nil, err
+	}
+	if c.conns == nil {
+		c.conns = map[string]*grpc.ClientConn{}
+	}
+	c.conns[backend] = conn
+	return conn, nil
+}
+
+// drop closes the connection to backend, so that the next get reconnects
+func (c *connectionCache) drop(backend string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if conn, ok := c.conns[backend]; ok {
+		conn.Close()
+		delete(c.conns, backend)
+	}
+}
+
+// retain closes the connections to the backends not in backends
+func (c *connectionCache) retain(backends []string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	keep := map[string]bool{}
+	for _, backend := range backends {
+		keep[backend] = true
+	}
+	for backend, conn := range c.conns {
+		if !keep[backend] {
+			conn.Close()
+			delete(c.conns, backend)
+		}
+	}
+}
+
+// close closes the connections to all the backends; get fails afterwards
+func (c *connectionCache) close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.closed {
+		return ErrClosed
+	}
+	c.closed = true
+	var err error
+	for backend, conn := range c.conns {
+		if closeErr := conn.Close(); closeErr != nil && err == nil {
+			err = errors.Wrapf(closeErr, "failed closing connection to %s", backend)
+		}
+		delete(c.conns, backend)
+	}
+	return err
+}
+
+// balancedProverClient is a token.ProverClient sending each command to the
+// next backend of the prover peer address
+type balancedProverClient struct {
+	endpoints *Endpoints
+	conns     *connectionCache
+}
+
+func (b *balancedProverClient) ProcessCommand(ctx context.Context, in *token.SignedCommand, opts ...grpc.CallOption) (*token.SignedCommandResponse, error) {
+	backend, err := b.endpoints.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+	b.conns.retain(b.endpoints.Backends())
+	conn, err := b.conns.get(backend)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to connect to prover peer %s", backend))
+	}
+
+	resp, err := token.NewProverClient(conn).ProcessCommand(ctx, in, opts...)
+	if status.Code(err) == codes.Unavailable {
+		b.conns.drop(backend)
+	}
+	return resp, err
+}
+
+// Close closes the connections to the backends of the prover peer
+func (b *balancedProverClient) Close() error {
+	return b.conns.close()
+}
+
+// balancedGatewayClient is a token.GatewayClient submitting each transaction
+// to the next backend of the gateway peer address
+type balancedGatewayClient struct {
+	endpoints *Endpoints
+	conns     *connectionCache
+}
+
+func (b *balancedGatewayClient) Submit(ctx context.Context, in *token.SubmitRequest, opts ...grpc.CallOption) (token.Gateway_SubmitClient, error) {
+	backend, err := b.endpoints.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+	b.conns.retain(b.endpoints.Backends())
+	conn, err := b.conns.get(backend)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to connect to gateway peer %s", backend))
+	}
+
+	stream, err := token.NewGatewayClient(conn).Submit(ctx, in, opts...)
+	if status.Code(err) == codes.Unavailable {
+		b.conns.drop(backend)
+	}
+	return stream, err
+}
+
+func (b *balancedGatewayClient) AttestCommit(ctx context.Context, in *token.SignedCommand, opts ...grpc.CallOption) (*token.SignedCommitAttestation, error) {
+	backend, err := b.endpoints.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+	b.conns.retain(b.endpoints.Backends())
+	conn, err := b.conns.get(backend)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to connect to gateway peer %s", backend))
+	}
+
+	attestation, err := token.NewGatewayClient(conn).AttestCommit(ctx, in, opts...)
+	if status.Code(err) == codes.Unavailable {
+		b.conns.drop(backend)
+	}
+	return attestation, err
+}
+
+// Close closes the connections to the backends of the gateway peer
+func (b *balancedGatewayClient) Close() error {
+	return b.conns.close()
+}
@@ -0,0 +1,137 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client_test
+
+import (
+	"context"
+	"net"
+
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/hyperledger/fabric/token/client/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("DNSResolver", func() {
+	var resolver *client.DNSResolver
+
+	BeforeEach(func() {
+		resolver = &client.DNSResolver{
+			LookupHost: func(ctx context.Context, host string) ([]string, error) {
+				if host != "orderer.example.com" {
+					return nil, errors.New("no such host")
+				}
+				return []string{"10.0.0.2", "10.0.0.1"}, nil
+			},
+			LookupSRV: func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+				if name != "_grpc._tcp.peer.example.com" {
+					return "", nil, errors.New("no such host")
+				}
+				return name, []*net.SRV{
+					{Target: "peer2.example.com.", Port: 7051, Priority: 10, Weight: 10},
+					{Target: "backup.example.com.", Port: 7051, Priority: 20, Weight: 100},
+					{Target: "peer1.example.com.", Port: 7051, Priority: 10, Weight: 50},
+				}, nil
+			},
+		}
+	})
+
+	It("resolves plain addresses to themselves", func() {
+		backends, err := resolver.Resolve(context.Background(), "orderer.example.com:7050")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backends).To(Equal([]string{"orderer.example.com:7050"}))
+	})
+
+	It("resolves dns addresses to every address of the host", func() {
+		backends, err := resolver.Resolve(context.Background(), "dns://orderer.example.com:7050")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backends).To(Equal([]string{"10.0.0.1:7050", "10.0.0.2:7050"}))
+	})
+
+	It("resolves srv addresses to the targets with the lowest priority", func() {
+		backends, err := resolver.Resolve(context.Background(), "srv://_grpc._tcp.peer.example.com")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backends).To(Equal([]string{"peer1.example.com:7051", "peer2.example.com:7051"}))
+	})
+
+	Context("when the dns address has no port", func() {
+		It("returns an error", func() {
+			_, err := resolver.Resolve(context.Background(), "dns://orderer.example.com")
+			Expect(err).To(MatchError("invalid address orderer.example.com: address orderer.example.com: missing port in address"))
+		})
+	})
+
+	Context("when the lookup fails", func() {
+		It("returns an error", func() {
+			_, err := resolver.Resolve(context.Background(), "dns://unknown.example.com:7050")
+			Expect(err).To(MatchError("failed resolving unknown.example.com: no such host"))
+			_, err = resolver.Resolve(context.Background(), "srv://unknown.example.com")
+			Expect(err).To(MatchError("failed resolving SRV records of unknown.example.com: no such host"))
+		})
+	})
+})
+
+var _ = Describe("Endpoints", func() {
+	var (
+		fakeResolver *mock.Resolver
+		endpoints    *client.Endpoints
+	)
+
+	BeforeEach(func() {
+		fakeResolver = &mock.Resolver{}
+		fakeResolver.ResolveReturns([]string{"peer1:7051", "peer2:7051"}, nil)
+		endpoints = &client.Endpoints{Address: "dns://peer:7051", Resolver: fakeResolver}
+	})
+
+	It("balances across the backends, resolving the address every time", func() {
+		var picked []string
+		for i := 0; i < 3; i++ {
+			backend, err := endpoints.Next(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			picked = append(picked, backend)
+		}
+		Expect(picked).To(Equal([]string{"peer1:7051", "peer2:7051", "peer1:7051"}))
+		Expect(fakeResolver.ResolveCallCount()).To(Equal(3))
+		_, address := fakeResolver.ResolveArgsForCall(0)
+		Expect(address).To(Equal("dns://peer:7051"))
+		Expect(endpoints.Backends()).To(Equal([]string{"peer1:7051", "peer2:7051"}))
+	})
+
+	It("keeps sticky backends while they are resolved", func() {
+		backend, err := endpoints.Sticky(context.Background(), "peer2:7051")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backend).To(Equal("peer2:7051"))
+
+		fakeResolver.ResolveReturns([]string{"peer1:7051", "peer3:7051"}, nil)
+		backend, err = endpoints.Sticky(context.Background(), "peer2:7051")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backend).To(Equal("peer1:7051"))
+	})
+
+	Context("when no backend is resolved", func() {
+		BeforeEach(func() {
+			fakeResolver.ResolveReturns(nil, nil)
+		})
+
+		It("returns an error", func() {
+			_, err := endpoints.Next(context.Background())
+			Expect(err).To(MatchError("no backends found for dns://peer:7051"))
+		})
+	})
+
+	Context("when the resolution fails", func() {
+		BeforeEach(func() {
+			fakeResolver.ResolveReturns(nil, errors.New("rambutan"))
+		})
+
+		It("returns an error", func() {
+			_, err := endpoints.Sticky(context.Background(), "peer1:7051")
+			Expect(err).To(MatchError("rambutan"))
+		})
+	})
+})
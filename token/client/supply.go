@@ -0,0 +1,68 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/identity"
+	"github.com/pkg/errors"
+)
+
+// VerifySupplyAttestation checks that the attestation was signed by the peer
+// it names, whose identity must be valid for the deserializer, e.g. an MSP
+// set up with the configuration of the organizations of the channel, and
+// belong to one of the passed MSPs, if any. It returns the attestation, so
+// that parties that do not run a peer of the channel, such as regulators, can
+// trust the supply figures of the peers of the organizations they choose.
+func VerifySupplyAttestation(signed *token.SignedSupplyAttestation, deserializer identity.Deserializer, mspIDs ...string) (*token.SupplyAttestation, error) {
+	if signed == nil {
+		return nil, errors.New("no supply attestation")
+	}
+	attestation := &token.SupplyAttestation{}
+	err := proto.Unmarshal(signed.Attestation, attestation)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling supply attestation")
+	}
+
+	err = verifyPeerSignature(attestation.Peer, signed.Attestation, signed.Signature, deserializer, mspIDs, "the supply", "supply attestation")
+	if err != nil {
+		return nil, err
+	}
+	return attestation, nil
+}
+
+// verifyPeerSignature checks that the signature of an attestation was made by
+// the serialized peer identity, which must be valid for the deserializer and
+// belong to one of the passed MSPs, if any, to attest the subject.
+func verifyPeerSignature(peerIdentity, attestation, signature []byte, deserializer identity.Deserializer, mspIDs []string, subject, kind string) error {
+	peer, err := deserializer.DeserializeIdentity(peerIdentity)
+	if err != nil {
+		return errors.Wrap(err, "failed deserializing the identity of the peer")
+	}
+	err = peer.Validate()
+	if err != nil {
+		return errors.Wrap(err, "invalid peer identity")
+	}
+	if len(mspIDs) != 0 && !containsString(mspIDs, peer.GetMSPIdentifier()) {
+		return errors.Errorf("peer of MSP '%s' is not trusted to attest %s", peer.GetMSPIdentifier(), subject)
+	}
+	err = peer.Verify(attestation, signature)
+	if err != nil {
+		return errors.Wrapf(err, "invalid %s signature", kind)
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
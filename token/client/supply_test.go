@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client_test
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/hyperledger/fabric/token/identity/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("VerifySupplyAttestation", func() {
+	var (
+		fakeDeserializer *mock.Deserializer
+		fakePeer         *mock.Identity
+		attestation      *token.SupplyAttestation
+		signed           *token.SignedSupplyAttestation
+	)
+
+	BeforeEach(func() {
+		fakePeer = &mock.Identity{}
+		fakePeer.GetMSPIdentifierReturns("Org1MSP")
+		fakeDeserializer = &mock.Deserializer{}
+		fakeDeserializer.DeserializeIdentityReturns(fakePeer, nil)
+
+		attestation = &token.SupplyAttestation{
+			ChannelId:   "channel-id",
+			BlockHeight: 42,
+			Supplies:    []*token.TypeSupply{{Type: "USD", Quantity: 150}},
+			Peer:        []byte("peer0"),
+		}
+		signed = &token.SignedSupplyAttestation{Attestation: ProtoMarshal(attestation), Signature: []byte("signature")}
+	})
+
+	It("returns the attestation signed by a peer of a trusted MSP", func() {
+		verified, err := client.VerifySupplyAttestation(signed, fakeDeserializer, "Org1MSP", "Org2MSP")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(proto.Equal(verified, attestation)).To(BeTrue())
+
+		Expect(fakeDeserializer.DeserializeIdentityArgsForCall(0)).To(Equal([]byte("peer0")))
+		Expect(fakePeer.ValidateCallCount()).To(Equal(1))
+		msg, signature := fakePeer.VerifyArgsForCall(0)
+		Expect(msg).To(Equal(signed.Attestation))
+		Expect(signature).To(Equal([]byte("signature")))
+	})
+
+	It("accepts the peers of any MSP when none is passed", func() {
+		_, err := client.VerifySupplyAttestation(signed, fakeDeserializer)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("rejects the attestations of peers of other MSPs", func() {
+		_, err := client.VerifySupplyAttestation(signed, fakeDeserializer, "Org2MSP")
+		Expect(err).To(MatchError("peer of MSP 'Org1MSP' is not trusted to attest the supply"))
+		Expect(fakePeer.VerifyCallCount()).To(Equal(0))
+	})
+
+	It("rejects invalid signatures", func() {
+		fakePeer.VerifyReturns(errors.New("bad signature"))
+		_, err := client.VerifySupplyAttestation(signed, fakeDeserializer)
+		Expect(err).To(MatchError("invalid supply attestation signature: bad signature"))
+	})
+
+	It("rejects invalid peer identities", func() {
+		fakePeer.ValidateReturns(errors.New("expired"))
+		_, err := client.VerifySupplyAttestation(signed, fakeDeserializer)
+		Expect(err).To(MatchError("invalid peer identity: expired"))
+
+		fakeDeserializer.DeserializeIdentityReturns(nil, errors.New("unknown MSP"))
+		_, err = client.VerifySupplyAttestation(signed, fakeDeserializer)
+		Expect(err).To(MatchError("failed deserializing the identity of the peer: unknown MSP"))
+	})
+
+	It("rejects malformed attestations", func() {
+		_, err := client.VerifySupplyAttestation(nil, fakeDeserializer)
+		Expect(err).To(MatchError("no supply attestation"))
+
+		signed.Attestation = []byte("garbage")
+		_, err = client.VerifySupplyAttestation(signed, fakeDeserializer)
+		Expect(err).To(MatchError(ContainSubstring("failed unmarshaling supply attestation")))
+	})
+})
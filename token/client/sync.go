@@ -0,0 +1,119 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+//go:generate counterfeiter -o mock/pending_tx_store.go -fake-name PendingTxStore . PendingTxStore
+
+// PendingTxStore keeps the transactions that have been submitted but not yet
+// committed. It is implemented by wallet.Wallet.
+type PendingTxStore interface {
+	// PendingTxs returns the pending transactions, keyed by transaction ID.
+	PendingTxs() (map[string][]byte, error)
+	// DeletePendingTx removes a pending transaction.
+	DeletePendingTx(txID string) error
+}
+
+// PendingSync resolves the pending transactions of a wallet from the blocks
+// delivered by the commit peer. Blocks are recorded with the Checkpointer as
+// they are processed, so a client restarting mid-stream does not process
+// them again.
+type PendingSync struct {
+	Config        *ClientConfig
+	Signer        SignerIdentity
+	Creator       []byte
+	DeliverClient DeliverClient
+	Pending       PendingTxStore
+	Checkpointer  Checkpointer
+}
+
+// Sync processes the blocks following the checkpoint, or all blocks when there
+// is no checkpoint yet. For each pending transaction found in a block, Sync
+// sends an event to eventCh and then removes the transaction from the store.
+// Sync returns once no transaction is pending or when ctx is done.
+func (s *PendingSync) Sync(ctx context.Context, eventCh chan<- TxEvent) error {
+	pending, err := s.Pending.PendingTxs()
+	if err != nil {
+		return errors.WithMessage(err, "failed reading pending transactions")
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	oldest := &ab.SeekPosition{
+		Type: &ab.SeekPosition_Oldest{
+			Oldest: &ab.SeekOldest{},
+		},
+	}
+	start, err := resumePosition(s.Checkpointer, s.Config.ChannelId, oldest)
+	if err != nil {
+		return err
+	}
+
+	deliverFiltered, err := s.DeliverClient.NewDeliverFiltered(ctx)
+	if err != nil {
+		return err
+	}
+	envelope, err := createDeliverEnvelope(s.Config.ChannelId, s.Creator, s.Signer, s.DeliverClient.Certificate(), seekInfoFrom(start), nil)
+	if err != nil {
+		return err
+	}
+	address := s.Config.CommitPeerCfg.Address
+	err = DeliverSend(deliverFiltered, address, envelope)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := deliverFiltered.Recv()
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error receiving deliver response from peer %s", address))
+		}
+
+		switch r := resp.Type.(type) {
+		case *pb.DeliverResponse_FilteredBlock:
+			for _, tx := range r.FilteredBlock.FilteredTransactions {
+				if _, ok := pending[tx.Txid]; !ok {
+					continue
+				}
+				event := TxEvent{Txid: tx.Txid, Committed: tx.TxValidationCode == pb.TxValidationCode_VALID, CommitPeer: address}
+				if !event.Committed {
+					event.Err = &InvalidTxError{TxID: tx.Txid, ValidationCode: tx.TxValidationCode.String()}
+				}
+				select {
+				case eventCh <- event:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+				err = s.Pending.DeletePendingTx(tx.Txid)
+				if err != nil {
+					return errors.WithMessage(err, fmt.Sprintf("failed deleting pending transaction %s", tx.Txid))
+				}
+				delete(pending, tx.Txid)
+			}
+			checkpoint(s.Checkpointer, r.FilteredBlock)
+			if len(pending) == 0 {
+				return nil
+			}
+		case *pb.DeliverResponse_Status:
+			return errors.Errorf("deliver completed with status (%s) with %d transactions pending from peer %s", r.Status, len(pending), address)
+		default:
+			return errors.Errorf("received unexpected response type (%T) from peer %s", r, address)
+		}
+	}
+}
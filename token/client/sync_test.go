@@ -0,0 +1,172 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client_test
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/hyperledger/fabric/token/client/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("PendingSync", func() {
+	var (
+		fakeDeliverFiltered *mock.DeliverFiltered
+		fakeDeliverClient   *mock.DeliverClient
+		fakePending         *mock.PendingTxStore
+		fakeCheckpointer    *mock.Checkpointer
+		eventCh             chan client.TxEvent
+
+		pendingSync *client.PendingSync
+	)
+
+	BeforeEach(func() {
+		fakeDeliverFiltered = &mock.DeliverFiltered{}
+		fakeDeliverFiltered.RecvReturnsOnCall(0, &pb.DeliverResponse{
+			Type: &pb.DeliverResponse_FilteredBlock{FilteredBlock: &pb.FilteredBlock{
+				ChannelId: "test-channel",
+				Number:    5,
+				FilteredTransactions: []*pb.FilteredTransaction{
+					{Txid: "other-tx", TxValidationCode: pb.TxValidationCode_VALID},
+					{Txid: "tx-1", TxValidationCode: pb.TxValidationCode_VALID},
+				},
+			}},
+		}, nil)
+		fakeDeliverFiltered.RecvReturnsOnCall(1, &pb.DeliverResponse{
+			Type: &pb.DeliverResponse_FilteredBlock{FilteredBlock: &pb.FilteredBlock{
+				ChannelId: "test-channel",
+				Number:    6,
+				FilteredTransactions: []*pb.FilteredTransaction{
+					{Txid: "tx-2", TxValidationCode: pb.TxValidationCode_MVCC_READ_CONFLICT},
+				},
+			}},
+		}, nil)
+		fakeDeliverClient = &mock.DeliverClient{}
+		fakeDeliverClient.NewDeliverFilteredReturns(fakeDeliverFiltered, nil)
+
+		fakePending = &mock.PendingTxStore{}
+		fakePending.PendingTxsReturns(map[string][]byte{"tx-1": []byte("tx-1"), "tx-2": []byte("tx-2")}, nil)
+		fakeCheckpointer = &mock.Checkpointer{}
+		fakeCheckpointer.LastBlockReturns(4, true, nil)
+		eventCh = make(chan client.TxEvent, 2)
+
+		fakeSigner := &mock.SignerIdentity{}
+		fakeSigner.SignReturns([]byte("signature"), nil)
+		pendingSync = &client.PendingSync{
+			Config: &client.ClientConfig{
+				ChannelId:     "test-channel",
+				CommitPeerCfg: client.ConnectionConfig{Address: "peer-address"},
+			},
+			Signer:        fakeSigner,
+			Creator:       []byte("creator"),
+			DeliverClient: fakeDeliverClient,
+			Pending:       fakePending,
+			Checkpointer:  fakeCheckpointer,
+		}
+	})
+
+	It("resolves the pending transactions from the blocks following the checkpoint", func() {
+		err := pendingSync.Sync(context.Background(), eventCh)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeDeliverFiltered.SendCallCount()).To(Equal(1))
+		payload := &common.Payload{}
+		err = proto.Unmarshal(fakeDeliverFiltered.SendArgsForCall(0).Payload, payload)
+		Expect(err).NotTo(HaveOccurred())
+		seekInfo := &ab.SeekInfo{}
+		err = proto.Unmarshal(payload.Data, seekInfo)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(seekInfo.Start.GetSpecified().GetNumber()).To(Equal(uint64(5)))
+
+		Expect(<-eventCh).To(Equal(client.TxEvent{Txid: "tx-1", Committed: true, CommitPeer: "peer-address"}))
+		event := <-eventCh
+		Expect(event.Txid).To(Equal("tx-2"))
+		Expect(event.Committed).To(BeFalse())
+		Expect(event.Err).To(MatchError("transaction [tx-2] status is not valid: MVCC_READ_CONFLICT"))
+
+		Expect(fakePending.DeletePendingTxCallCount()).To(Equal(2))
+		Expect(fakePending.DeletePendingTxArgsForCall(0)).To(Equal("tx-1"))
+		Expect(fakePending.DeletePendingTxArgsForCall(1)).To(Equal("tx-2"))
+
+		Expect(fakeCheckpointer.CheckpointCallCount()).To(Equal(2))
+		channel, number := fakeCheckpointer.CheckpointArgsForCall(1)
+		Expect(channel).To(Equal("test-channel"))
+		Expect(number).To(Equal(uint64(6)))
+	})
+
+	Context("when no transaction is pending", func() {
+		BeforeEach(func() {
+			fakePending.PendingTxsReturns(map[string][]byte{}, nil)
+		})
+
+		It("returns immediately", func() {
+			err := pendingSync.Sync(context.Background(), eventCh)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeDeliverClient.NewDeliverFilteredCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when there is no checkpoint", func() {
+		BeforeEach(func() {
+			fakeCheckpointer.LastBlockReturns(0, false, nil)
+		})
+
+		It("starts from the oldest block", func() {
+			err := pendingSync.Sync(context.Background(), eventCh)
+			Expect(err).NotTo(HaveOccurred())
+
+			payload := &common.Payload{}
+			err = proto.Unmarshal(fakeDeliverFiltered.SendArgsForCall(0).Payload, payload)
+			Expect(err).NotTo(HaveOccurred())
+			seekInfo := &ab.SeekInfo{}
+			err = proto.Unmarshal(payload.Data, seekInfo)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(seekInfo.Start.GetOldest()).NotTo(BeNil())
+		})
+	})
+
+	Context("when the deliver stream ends before all transactions are resolved", func() {
+		BeforeEach(func() {
+			fakeDeliverFiltered.RecvReturnsOnCall(1, &pb.DeliverResponse{
+				Type: &pb.DeliverResponse_Status{Status: common.Status_NOT_FOUND},
+			}, nil)
+		})
+
+		It("returns an error", func() {
+			err := pendingSync.Sync(context.Background(), eventCh)
+			Expect(err).To(MatchError("deliver completed with status (NOT_FOUND) with 1 transactions pending from peer peer-address"))
+			Expect(fakePending.DeletePendingTxCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when reading the pending transactions fails", func() {
+		BeforeEach(func() {
+			fakePending.PendingTxsReturns(nil, errors.New("sour-cherry"))
+		})
+
+		It("returns an error", func() {
+			err := pendingSync.Sync(context.Background(), eventCh)
+			Expect(err).To(MatchError("failed reading pending transactions: sour-cherry"))
+		})
+	})
+
+	Context("when the context is done", func() {
+		It("returns the context error", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			err := pendingSync.Sync(ctx, eventCh)
+			Expect(err).To(Equal(context.Canceled))
+		})
+	})
+})
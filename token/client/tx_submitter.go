@@ -9,19 +9,26 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"runtime"
+	"strings"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/comm"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	peercommon "github.com/hyperledger/fabric/peer/common"
 	"github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/utils"
+	tk "github.com/hyperledger/fabric/token"
 	"github.com/pkg/errors"
 )
 
@@ -33,6 +40,26 @@ type TxSubmitter struct {
 	Creator       []byte
 	OrdererClient OrdererClient
 	DeliverClient DeliverClient
+	// OrdererBreaker, when set, stops broadcasts to the orderer while it is failing.
+	OrdererBreaker *CircuitBreaker
+	// Checkpointer, when set, records the blocks processed while waiting for
+	// commit events; waits resume from the last checkpoint.
+	Checkpointer Checkpointer
+	// Metrics, when set, record the submissions and the latency and failures
+	// of the orderer broadcasts and commit waits.
+	Metrics *Metrics
+	// Clock, when set, measures the time SubmitTransaction waits for commit
+	// events; tests inject a fake clock.
+	Clock clock.Clock
+	// Notifier, when set, notifies the commit of the submitted transactions
+	// in place of the deliver service of the commit peer.
+	Notifier CommitNotifier
+	// Capabilities, when set, tells whether the channel invalidates expired
+	// transactions; the transactions expire Config.TxTTL after their
+	// creation only when it does.
+	Capabilities *ChannelCapabilities
+
+	tracker tracker
 }
 
 // TxEvent contains information for token transaction commit
@@ -78,13 +105,44 @@ func NewTxSubmitter(config *ClientConfig) (*TxSubmitter, error) {
 		return nil, err
 	}
 
-	return &TxSubmitter{
+	s := &TxSubmitter{
 		Config:        config,
 		Signer:        Signer,
 		Creator:       creator,
 		OrdererClient: ordererClient,
 		DeliverClient: deliverClient,
-	}, nil
+	}
+	runtime.SetFinalizer(s, func(s *TxSubmitter) {
+		if !s.tracker.isClosed() {
+			logger.Warningf("TxSubmitter of channel %s was not closed; its connections to orderer %s and commit peer %s leaked", config.ChannelId, config.OrdererCfg.Address, config.CommitPeerCfg.Address)
+		}
+	})
+	return s, nil
+}
+
+// Close stops listening for the commit events of the submitted transactions
+// and closes the connections to the orderer and to the commit peer. It
+// returns an error if the goroutines of the submitter do not stop in time or
+// the connections fail to close, and ErrClosed if the submitter was already
+// closed. The submitter cannot be used afterwards.
+func (s *TxSubmitter) Close() error {
+	err := s.tracker.close(closeTimeout)
+	if err == ErrClosed {
+		return err
+	}
+	closeErr := closeAll(s.OrdererClient, s.DeliverClient)
+	if err != nil {
+		logger.Warningf("failed stopping TxSubmitter of channel %s: %s", s.Config.ChannelId, err)
+		return err
+	}
+	return closeErr
+}
+
+// Goroutines returns the number of goroutines the submitter runs, for
+// instance to listen for commit events. Long-running services can watch it
+// to detect leaks: it should not grow with the number of transactions.
+func (s *TxSubmitter) Goroutines() int {
+	return s.tracker.goroutines()
 }
 
 // SubmitTransaction submits a token transaction to fabric.
@@ -95,11 +153,11 @@ func NewTxSubmitter(config *ClientConfig) (*TxSubmitter, error) {
 func (s *TxSubmitter) SubmitTransaction(txEnvelope *common.Envelope, waitTimeInSeconds int) (committed bool, txId string, err error) {
 	if waitTimeInSeconds > 0 {
 		waitTime := time.Second * time.Duration(waitTimeInSeconds)
-		ctx, cancelFunc := context.WithTimeout(context.Background(), waitTime)
+		ctx, cancelFunc := withClockTimeout(context.Background(), s.clock(), waitTime)
 		defer cancelFunc()
+		// localCh is not closed: the commit event may be sent after the wait timed out
 		localCh := make(chan TxEvent, 1)
 		committed, txId, err = s.sendTransactionInternal(txEnvelope, ctx, localCh, true)
-		close(localCh)
 		return
 	} else {
 		committed, txId, err = s.sendTransactionInternal(txEnvelope, context.Background(), nil, false)
@@ -107,6 +165,16 @@ func (s *TxSubmitter) SubmitTransaction(txEnvelope *common.Envelope, waitTimeInS
 	}
 }
 
+// SubmitTransactionContext submits a token transaction to fabric and waits for
+// it to be committed until ctx is done. When ctx carries a DeadlineBudget, the
+// orderer broadcast and the commit wait are each bound by their share of it,
+// so time not used by the orderer is left for the commit wait.
+func (s *TxSubmitter) SubmitTransactionContext(ctx context.Context, txEnvelope *common.Envelope) (committed bool, txId string, err error) {
+	localCh := make(chan TxEvent, 1)
+	committed, txId, err = s.sendTransactionInternal(txEnvelope, ctx, localCh, true)
+	return
+}
+
 // SubmitTransactionWithChan submits a token transaction to fabric with an event channel.
 // This function does not wait for transaction commit and returns as soon as the orderer client receives the response.
 // The application will be notified on transaction completion by reading events from the eventCh.
@@ -129,65 +197,144 @@ func (s *TxSubmitter) sendTransactionInternal(txEnvelope *common.Envelope, ctx c
 	if err != nil {
 		return false, "", err
 	}
+	lg := logger.ForTransaction(s.Config.ChannelId, txid, s.Creator)
+	if correlationID := tk.CorrelationID(ctx); correlationID != "" {
+		lg = lg.With(flogging.CorrelationIDKey, correlationID)
+	}
 
-	broadcast, err := s.OrdererClient.NewBroadcast(context.Background())
+	submitCtx, cancelSubmit, err := s.tracker.context(ctx)
 	if err != nil {
 		return false, "", err
 	}
+	defer cancelSubmit()
 
-	committed := false
+	// register for the commit event before the broadcast, so that it cannot
+	// be missed, and outside of the orderer breaker, as only the commit peer
+	// is involved
 	if eventCh != nil {
-		deliverFiltered, err := s.DeliverClient.NewDeliverFiltered(ctx)
-		if err != nil {
-			return false, "", err
-		}
-		blockEnvelope, err := CreateDeliverEnvelope(s.Config.ChannelId, s.Creator, s.Signer, s.DeliverClient.Certificate())
+		err = s.notifier().Notify(ctx, txid, eventCh)
 		if err != nil {
 			return false, "", err
 		}
-		err = DeliverSend(deliverFiltered, s.Config.CommitPeerCfg.Address, blockEnvelope)
-		if err != nil {
-			return false, "", err
+	}
+
+	orderCtx, cancelOrder := phaseContext(submitCtx, PhaseOrder)
+	defer cancelOrder()
+	orderStart := time.Now()
+	sent := false
+	err = s.OrdererBreaker.Do(func() error {
+		var err error
+		sent, err = s.broadcast(orderCtx, txEnvelope)
+		return err
+	})
+	s.Metrics.observe(s.Config.ChannelId, PhaseOrder, orderStart, err)
+	cancelOrder()
+	if !sent {
+		return false, txid, err
+	}
+	if err != nil {
+		lg.Warningf("orderer %s rejected transaction: %s", s.Config.OrdererCfg.Address, err)
+	} else {
+		lg.Debugf("transaction submitted to orderer %s", s.Config.OrdererCfg.Address)
+	}
+
+	committed := false
+	// wait for commit event from deliver service in this case
+	if eventCh != nil && waitForCommit {
+		commitCtx, cancelCommit := phaseContext(ctx, PhaseCommit)
+		defer cancelCommit()
+		commitStart := time.Now()
+		committed, err = DeliverWaitForResponse(commitCtx, eventCh, txid)
+		lg.Debugf("transaction committed: %t", committed)
+		commitErr := err
+		if commitErr == nil && !committed {
+			commitErr = errors.Errorf("transaction %s not committed", txid)
 		}
-		go DeliverReceive(deliverFiltered, s.Config.CommitPeerCfg.Address, txid, eventCh)
+		s.Metrics.observe(s.Config.ChannelId, PhaseCommit, commitStart, commitErr)
+	}
+
+	return committed, txid, err
+}
+
+// broadcast sends the transaction to the orderer and waits for its response.
+// It returns whether the transaction reached the orderer, in which case an
+// error is the rejection of the transaction by the orderer.
+func (s *TxSubmitter) broadcast(ctx context.Context, txEnvelope *common.Envelope) (bool, error) {
+	broadcast, err := s.OrdererClient.NewBroadcast(ctx)
+	if err != nil {
+		return false, err
 	}
 
+	s.Metrics.submitted(s.Config.ChannelId)
 	err = BroadcastSend(broadcast, s.Config.OrdererCfg.Address, txEnvelope)
 	if err != nil {
-		return false, txid, err
+		return false, err
 	}
 
 	// wait for response from orderer broadcast - it does not wait for commit peer response
 	responses := make(chan common.Status)
 	errs := make(chan error, 1)
-	go BroadcastReceive(broadcast, s.Config.OrdererCfg.Address, responses, errs)
+	err = s.tracker.goroutine(func() {
+		BroadcastReceive(broadcast, s.Config.OrdererCfg.Address, responses, errs)
+	})
+	if err != nil {
+		return false, err
+	}
 	_, err = BroadcastWaitForResponse(responses, errs)
+	return true, err
+}
 
-	// wait for commit event from deliver service in this case
-	if eventCh != nil && waitForCommit {
-		committed, err = DeliverWaitForResponse(ctx, eventCh, txid)
+func (s *TxSubmitter) clock() clock.Clock {
+	if s.Clock != nil {
+		return s.Clock
 	}
+	return clock.NewClock()
+}
 
-	return committed, txid, err
+func (s *TxSubmitter) notifier() CommitNotifier {
+	if s.Notifier != nil {
+		return s.Notifier
+	}
+	return &deliverNotifier{submitter: s}
+}
+
+// withClockTimeout is like context.WithTimeout, with the timeout measured by clk.
+func withClockTimeout(parent context.Context, clk clock.Clock, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	timer := clk.NewTimer(timeout)
+	go func() {
+		defer timer.Stop()
+		select {
+		case <-timer.C():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
 }
 
 func (s *TxSubmitter) CreateTxEnvelope(txBytes []byte) (string, *common.Envelope, error) {
 	// channelId string, creator []byte, signer SignerIdentity, cert *tls.Certificate
 	// , s.Config.ChannelId, s.Creator, s.Signer, s.OrdererClient.Certificate()
-	var tlsCertHash []byte
-	var err error
-	// check for client certificate and compute SHA2-256 on certificate if present
-	cert := s.OrdererClient.Certificate()
-	if cert != nil && len(cert.Certificate) > 0 {
-		tlsCertHash, err = factory.GetDefault().Hash(cert.Certificate[0], &bccsp.SHA256Opts{})
+	tlsCertHash, err := s.tlsCertHash()
+	if err != nil {
+		return "", nil, err
+	}
+
+	var hashingSuite func([]byte) []byte
+	if s.Config.HashingSuite != "" {
+		hashingSuite, err = channelconfig.HashingSuiteByName(s.Config.HashingSuite)
 		if err != nil {
-			err = errors.New("failed to compute SHA256 on client certificate")
-			logger.Errorf("%s", err)
 			return "", nil, err
 		}
 	}
 
-	txid, header, err := CreateHeader(common.HeaderType_TOKEN_TRANSACTION, s.Config.ChannelId, s.Creator, tlsCertHash)
+	ttl, err := s.Config.txTTL(s.Capabilities)
+	if err != nil {
+		return "", nil, err
+	}
+
+	txid, header, err := CreateHeaderWithTTL(common.HeaderType_TOKEN_TRANSACTION, s.Config.ChannelId, s.Creator, tlsCertHash, hashingSuite, ttl)
 	if err != nil {
 		return txid, nil, err
 	}
@@ -196,31 +343,103 @@ func (s *TxSubmitter) CreateTxEnvelope(txBytes []byte) (string, *common.Envelope
 	return txid, txEnvelope, err
 }
 
+// TxInclusion asks the orderer whether the transaction has been included in a
+// block of the channel. The response status is SUCCESS along with the block
+// number and the index of the transaction if it has, and NOT_FOUND if not yet.
+// The orderer must index the transaction identifiers of its ledgers.
+func (s *TxSubmitter) TxInclusion(ctx context.Context, txID string) (*ab.InclusionResponse, error) {
+	tlsCertHash, err := s.tlsCertHash()
+	if err != nil {
+		return nil, err
+	}
+	_, header, err := CreateHeader(common.HeaderType_MESSAGE, s.Config.ChannelId, s.Creator, tlsCertHash)
+	if err != nil {
+		return nil, err
+	}
+	query, err := proto.Marshal(&ab.InclusionQuery{TxId: txID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal inclusion query")
+	}
+	envelope, err := CreateEnvelope(query, header, s.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.OrdererClient.Inclusion(ctx, envelope)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.Status {
+	case common.Status_SUCCESS, common.Status_NOT_FOUND:
+		return resp, nil
+	default:
+		return nil, errors.Errorf("inclusion query for transaction %s failed with status %s: %s", txID, resp.Status, resp.Info)
+	}
+}
+
+// tlsCertHash computes SHA2-256 on the client certificate, if present
+func (s *TxSubmitter) tlsCertHash() ([]byte, error) {
+	cert := s.OrdererClient.Certificate()
+	if cert == nil || len(cert.Certificate) == 0 {
+		return nil, nil
+	}
+	tlsCertHash, err := factory.GetDefault().Hash(cert.Certificate[0], &bccsp.SHA256Opts{})
+	if err != nil {
+		err = errors.New("failed to compute SHA256 on client certificate")
+		logger.Errorf("%s", err)
+		return nil, err
+	}
+	return tlsCertHash, nil
+}
+
 // CreateHeader creates common.Header for a token transaction
 // tlsCertHash is for client TLS cert, only applicable when ClientAuthRequired is true
 func CreateHeader(txType common.HeaderType, channelId string, creator []byte, tlsCertHash []byte) (string, *common.Header, error) {
-	ts, err := ptypes.TimestampProto(time.Now())
+	return CreateHeaderWithHashingSuite(txType, channelId, creator, tlsCertHash, nil)
+}
+
+// CreateHeaderWithHashingSuite creates common.Header for a token transaction
+// whose transaction ID is computed with the hashing suite of the channel;
+// a nil hashing suite stands for SHA256
+func CreateHeaderWithHashingSuite(txType common.HeaderType, channelId string, creator []byte, tlsCertHash []byte, hashingSuite func([]byte) []byte) (string, *common.Header, error) {
+	return CreateHeaderWithTTL(txType, channelId, creator, tlsCertHash, hashingSuite, 0)
+}
+
+// CreateHeaderWithTTL creates common.Header for a token transaction which the
+// orderers no longer accept ttl after its creation; a ttl of 0 or less creates
+// a header without expiration
+func CreateHeaderWithTTL(txType common.HeaderType, channelId string, creator []byte, tlsCertHash []byte, hashingSuite func([]byte) []byte, ttl time.Duration) (string, *common.Header, error) {
+	now := time.Now()
+	ts, err := ptypes.TimestampProto(now)
 	if err != nil {
 		return "", nil, err
 	}
+	var notValidAfter *timestamp.Timestamp
+	if ttl > 0 {
+		notValidAfter, err = ptypes.TimestampProto(now.Add(ttl))
+		if err != nil {
+			return "", nil, err
+		}
+	}
 
 	nonce, err := crypto.GetRandomNonce()
 	if err != nil {
 		return "", nil, err
 	}
 
-	txId, err := utils.ComputeTxID(nonce, creator)
+	txId, err := utils.ComputeTxIDWithHash(nonce, creator, hashingSuite)
 	if err != nil {
 		return "", nil, err
 	}
 
 	chdr := &common.ChannelHeader{
-		Type:        int32(txType),
-		ChannelId:   channelId,
-		TxId:        txId,
-		Epoch:       uint64(0),
-		Timestamp:   ts,
-		TlsCertHash: tlsCertHash,
+		Type:          int32(txType),
+		ChannelId:     channelId,
+		TxId:          txId,
+		Epoch:         uint64(0),
+		Timestamp:     ts,
+		TlsCertHash:   tlsCertHash,
+		NotValidAfter: notValidAfter,
 	}
 	chdrBytes, err := proto.Marshal(chdr)
 	if err != nil {
@@ -287,19 +506,28 @@ func getTransactionId(txEnvelope *common.Envelope) (string, error) {
 
 // createGrpcClient returns a comm.GRPCClient based on toke client config
 func createGrpcClient(cfg *ConnectionConfig, tlsEnabled bool) (*comm.GRPCClient, error) {
-	clientConfig := comm.ClientConfig{Timeout: time.Second}
+	clientConfig := comm.ClientConfig{Timeout: time.Second, Compression: cfg.Compression}
+	clientConfig.Proxy = cfg.Proxy
+	if clientConfig.Proxy == "" {
+		address := strings.TrimPrefix(strings.TrimPrefix(cfg.Address, dnsScheme), srvScheme)
+		clientConfig.Proxy = comm.ProxyFromEnvironment(address)
+	}
 
 	if tlsEnabled {
-		if cfg.TlsRootCertFile == "" {
-			return nil, errors.New("missing TlsRootCertFile in client config")
+		rootCAs := cfg.TlsRootCerts
+		if cfg.TlsRootCertFile != "" {
+			caPEM, err := ioutil.ReadFile(cfg.TlsRootCertFile)
+			if err != nil {
+				return nil, errors.WithMessage(err, fmt.Sprintf("unable to load TLS cert from %s", cfg.TlsRootCertFile))
+			}
+			rootCAs = [][]byte{caPEM}
 		}
-		caPEM, err := ioutil.ReadFile(cfg.TlsRootCertFile)
-		if err != nil {
-			return nil, errors.WithMessage(err, fmt.Sprintf("unable to load TLS cert from %s", cfg.TlsRootCertFile))
+		if len(rootCAs) == 0 {
+			return nil, errors.New("missing TlsRootCertFile in client config")
 		}
 		secOpts := &comm.SecureOptions{
 			UseTLS:            true,
-			ServerRootCAs:     [][]byte{caPEM},
+			ServerRootCAs:     rootCAs,
 			RequireClientCert: false,
 		}
 		clientConfig.SecOpts = secOpts
@@ -6,9 +6,15 @@ SPDX-License-Identifier: Apache-2.0
 package client_test
 
 import (
+	"context"
 	"io"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	pb "github.com/hyperledger/fabric/protos/peer"
@@ -19,6 +25,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc"
 )
 
 var _ = Describe("TxSubmitter", func() {
@@ -251,6 +258,427 @@ var _ = Describe("TxSubmitter", func() {
 		})
 	})
 
+	Describe("SubmitTransactionContext", func() {
+		It("waits for the commit event", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+			committed, txid, err := txSubmitter.SubmitTransactionContext(ctx, txEnvelope)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(committed).To(BeTrue())
+			Expect(txid).To(Equal(expectedTxid))
+		})
+
+		It("bounds the broadcast by the order share of the budget", func() {
+			budget := client.NewDeadlineBudget(10*time.Second, nil, nil)
+			ctx := client.WithBudget(context.Background(), budget)
+			_, _, err := txSubmitter.SubmitTransactionContext(ctx, txEnvelope)
+			Expect(err).NotTo(HaveOccurred())
+
+			broadcastCtx, _ := fakeOrdererClient.NewBroadcastArgsForCall(0)
+			deadline, ok := broadcastCtx.Deadline()
+			Expect(ok).To(BeTrue())
+			Expect(deadline).To(BeTemporally("~", time.Now().Add(10*time.Second*2/7), time.Second))
+		})
+
+		Context("when the orderer circuit is open", func() {
+			BeforeEach(func() {
+				fakePolicy := &mock.BreakerPolicy{}
+				fakePolicy.ShouldTripReturns(true)
+				txSubmitter.OrdererBreaker = &client.CircuitBreaker{Name: "orderer", Policy: fakePolicy, OpenDuration: time.Minute}
+				fakeOrdererClient.NewBroadcastReturnsOnCall(0, nil, errors.New("wild-banana"))
+				_, _, err := txSubmitter.SubmitTransaction(txEnvelope, 0)
+				Expect(err).To(MatchError("wild-banana"))
+			})
+
+			It("fails fast without broadcasting", func() {
+				_, _, err := txSubmitter.SubmitTransaction(txEnvelope, 0)
+				Expect(errors.Cause(err)).To(Equal(client.ErrCircuitOpen))
+				Expect(fakeOrdererClient.NewBroadcastCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the orderer circuit is half-open", func() {
+			var now time.Time
+
+			BeforeEach(func() {
+				now = time.Now()
+				fakePolicy := &mock.BreakerPolicy{}
+				fakePolicy.ShouldTripStub = func(stats client.BreakerStats) bool { return stats.Failures > 0 }
+				txSubmitter.OrdererBreaker = &client.CircuitBreaker{
+					Name:         "orderer",
+					Policy:       fakePolicy,
+					OpenDuration: time.Minute,
+					Time:         func() time.Time { return now },
+				}
+				fakeOrdererClient.NewBroadcastReturnsOnCall(0, nil, errors.New("wild-banana"))
+				_, _, err := txSubmitter.SubmitTransaction(txEnvelope, 0)
+				Expect(err).To(MatchError("wild-banana"))
+
+				now = now.Add(time.Minute)
+				fakeOrdererClient.NewBroadcastStub = func(ctx context.Context, opts ...grpc.CallOption) (client.Broadcast, error) {
+					broadcast := &mock.Broadcast{}
+					broadcast.RecvReturnsOnCall(0, broadcastResp, nil)
+					broadcast.RecvReturnsOnCall(1, nil, io.EOF)
+					return broadcast, nil
+				}
+			})
+
+			It("closes the circuit once the trial broadcast succeeds", func() {
+				_, _, err := txSubmitter.SubmitTransaction(txEnvelope, 0)
+				Expect(err).NotTo(HaveOccurred())
+				_, _, err = txSubmitter.SubmitTransaction(txEnvelope, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeOrdererClient.NewBroadcastCallCount()).To(Equal(3))
+			})
+
+			Context("when the commit peer fails", func() {
+				BeforeEach(func() {
+					fakeDeliverClient.NewDeliverFilteredReturnsOnCall(0, nil, errors.New("wild-pineapple"))
+				})
+
+				It("does not use up the trial", func() {
+					_, _, err := txSubmitter.SubmitTransaction(txEnvelope, 1)
+					Expect(err).To(MatchError("wild-pineapple"))
+					Expect(fakeOrdererClient.NewBroadcastCallCount()).To(Equal(1))
+
+					_, _, err = txSubmitter.SubmitTransaction(txEnvelope, 0)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeOrdererClient.NewBroadcastCallCount()).To(Equal(2))
+				})
+			})
+		})
+	})
+
+	Describe("waiting for the commit", func() {
+		var (
+			fakeClock    *fakeclock.FakeClock
+			fakeNotifier *mock.CommitNotifier
+			notified     chan chan client.TxEvent
+		)
+
+		type result struct {
+			committed bool
+			err       error
+		}
+
+		submit := func(waitTimeInSeconds int) chan result {
+			results := make(chan result, 1)
+			go func() {
+				committed, _, err := txSubmitter.SubmitTransaction(txEnvelope, waitTimeInSeconds)
+				results <- result{committed: committed, err: err}
+			}()
+			return results
+		}
+
+		BeforeEach(func() {
+			fakeClock = fakeclock.NewFakeClock(time.Now())
+			notified = make(chan chan client.TxEvent, 1)
+			fakeNotifier = &mock.CommitNotifier{}
+			fakeNotifier.NotifyStub = func(ctx context.Context, txid string, eventCh chan client.TxEvent) error {
+				notified <- eventCh
+				return nil
+			}
+			txSubmitter.Clock = fakeClock
+			txSubmitter.Notifier = fakeNotifier
+		})
+
+		It("listens for the commit of the transaction with the notifier", func() {
+			results := submit(5)
+			eventCh := <-notified
+			eventCh <- client.TxEvent{Txid: expectedTxid, Committed: true}
+
+			var r result
+			Eventually(results).Should(Receive(&r))
+			Expect(r.err).NotTo(HaveOccurred())
+			Expect(r.committed).To(BeTrue())
+			_, txid, _ := fakeNotifier.NotifyArgsForCall(0)
+			Expect(txid).To(Equal(expectedTxid))
+			Expect(fakeDeliverClient.Invocations()).To(BeEmpty())
+		})
+
+		It("waits until the wait time has passed on the clock", func() {
+			results := submit(5)
+			eventCh := <-notified
+
+			fakeClock.WaitForWatcherAndIncrement(4 * time.Second)
+			Consistently(results).ShouldNot(Receive())
+
+			eventCh <- client.TxEvent{Txid: expectedTxid, Committed: true}
+			var r result
+			Eventually(results).Should(Receive(&r))
+			Expect(r.err).NotTo(HaveOccurred())
+			Expect(r.committed).To(BeTrue())
+		})
+
+		It("times out once the wait time has passed on the clock", func() {
+			results := submit(5)
+			<-notified
+
+			fakeClock.WaitForWatcherAndIncrement(5 * time.Second)
+			var r result
+			Eventually(results).Should(Receive(&r))
+			Expect(r.committed).To(BeFalse())
+			Expect(r.err).To(MatchError("timed out waiting for committing txid " + expectedTxid))
+		})
+
+		It("cancels the notification when the wait times out", func() {
+			results := submit(5)
+			<-notified
+
+			fakeClock.WaitForWatcherAndIncrement(5 * time.Second)
+			Eventually(results).Should(Receive())
+			ctx, _, _ := fakeNotifier.NotifyArgsForCall(0)
+			Eventually(ctx.Done()).Should(BeClosed())
+		})
+
+		Context("when the commit event is sent after the wait timed out", func() {
+			It("is discarded", func() {
+				results := submit(5)
+				eventCh := <-notified
+
+				fakeClock.WaitForWatcherAndIncrement(5 * time.Second)
+				Eventually(results).Should(Receive())
+
+				Expect(func() { eventCh <- client.TxEvent{Txid: expectedTxid, Committed: true} }).NotTo(Panic())
+			})
+		})
+
+		Context("when the notifier fails", func() {
+			BeforeEach(func() {
+				fakeNotifier.NotifyStub = nil
+				fakeNotifier.NotifyReturns(errors.New("deaf-banana"))
+			})
+
+			It("returns the error without broadcasting", func() {
+				committed, _, err := txSubmitter.SubmitTransaction(txEnvelope, 5)
+				Expect(err).To(MatchError("deaf-banana"))
+				Expect(committed).To(BeFalse())
+				Expect(fakeBroadcast.Invocations()).To(BeEmpty())
+			})
+		})
+
+		Context("when not waiting for the commit", func() {
+			It("does not listen for it", func() {
+				_, _, err := txSubmitter.SubmitTransaction(txEnvelope, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeNotifier.NotifyCallCount()).To(Equal(0))
+				Expect(fakeClock.WatcherCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("Close", func() {
+		var eventCh chan client.TxEvent
+
+		BeforeEach(func() {
+			eventCh = make(chan client.TxEvent, 1)
+			// the deliver stream ends when its context is canceled
+			fakeDeliverClient.NewDeliverFilteredStub = func(ctx context.Context, opts ...grpc.CallOption) (client.DeliverFiltered, error) {
+				fakeDeliverFiltered.RecvStub = func() (*pb.DeliverResponse, error) {
+					<-ctx.Done()
+					return nil, ctx.Err()
+				}
+				return fakeDeliverFiltered, nil
+			}
+		})
+
+		It("stops listening for commit events", func() {
+			_, _, err := txSubmitter.SubmitTransactionWithChan(txEnvelope, eventCh)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(txSubmitter.Goroutines).Should(Equal(1))
+
+			err = txSubmitter.Close()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(txSubmitter.Goroutines()).To(Equal(0))
+			var event client.TxEvent
+			Expect(eventCh).To(Receive(&event))
+			Expect(event.Committed).To(BeFalse())
+			Expect(event.Err).To(MatchError(ContainSubstring("context canceled")))
+		})
+
+		It("closes the orderer and deliver clients", func() {
+			ordererClient := &closingOrdererClient{OrdererClient: fakeOrdererClient}
+			deliverClient := &closingDeliverClient{DeliverClient: fakeDeliverClient}
+			txSubmitter.OrdererClient = ordererClient
+			txSubmitter.DeliverClient = deliverClient
+
+			err := txSubmitter.Close()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ordererClient.closed).To(BeTrue())
+			Expect(deliverClient.closed).To(BeTrue())
+		})
+
+		It("rejects submissions afterwards", func() {
+			err := txSubmitter.Close()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, err = txSubmitter.SubmitTransactionWithChan(txEnvelope, eventCh)
+			Expect(err).To(Equal(client.ErrClosed))
+			Expect(fakeBroadcast.SendCallCount()).To(Equal(0))
+		})
+
+		Context("when the submitter is already closed", func() {
+			It("returns ErrClosed", func() {
+				Expect(txSubmitter.Close()).To(Succeed())
+				Expect(txSubmitter.Close()).To(Equal(client.ErrClosed))
+			})
+		})
+
+		Context("when a client fails to close", func() {
+			It("returns the error", func() {
+				txSubmitter.DeliverClient = &closingDeliverClient{DeliverClient: fakeDeliverClient, err: errors.New("stuck-lid")}
+				err := txSubmitter.Close()
+				Expect(err).To(MatchError("failed closing token client: stuck-lid"))
+			})
+		})
+	})
+
+	Describe("TxInclusion", func() {
+		BeforeEach(func() {
+			fakeOrdererClient.InclusionReturns(&ab.InclusionResponse{
+				Status:      common.Status_SUCCESS,
+				BlockNumber: 7,
+				TxIndex:     2,
+				Height:      9,
+			}, nil)
+		})
+
+		It("queries the orderer with a signed inclusion query", func() {
+			resp, err := txSubmitter.TxInclusion(context.Background(), "tx1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.BlockNumber).To(Equal(uint64(7)))
+			Expect(resp.TxIndex).To(Equal(uint32(2)))
+			Expect(resp.Height).To(Equal(uint64(9)))
+
+			Expect(fakeOrdererClient.InclusionCallCount()).To(Equal(1))
+			_, env, _ := fakeOrdererClient.InclusionArgsForCall(0)
+			Expect(env.Signature).To(Equal([]byte("envelop-signature")))
+			payload, err := utils.UnmarshalPayload(env.Payload)
+			Expect(err).NotTo(HaveOccurred())
+			chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(chdr.Type).To(Equal(int32(common.HeaderType_MESSAGE)))
+			Expect(chdr.ChannelId).To(Equal(channelId))
+			query := &ab.InclusionQuery{}
+			Expect(proto.Unmarshal(payload.Data, query)).To(Succeed())
+			Expect(query.TxId).To(Equal("tx1"))
+		})
+
+		Context("when the transaction is not included yet", func() {
+			BeforeEach(func() {
+				fakeOrdererClient.InclusionReturns(&ab.InclusionResponse{Status: common.Status_NOT_FOUND, Height: 9}, nil)
+			})
+
+			It("returns the not found response", func() {
+				resp, err := txSubmitter.TxInclusion(context.Background(), "tx1")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Status).To(Equal(common.Status_NOT_FOUND))
+				Expect(resp.Height).To(Equal(uint64(9)))
+			})
+		})
+
+		Context("when the orderer rejects the query", func() {
+			BeforeEach(func() {
+				fakeOrdererClient.InclusionReturns(&ab.InclusionResponse{Status: common.Status_FORBIDDEN, Info: "not a reader"}, nil)
+			})
+
+			It("returns an error", func() {
+				_, err := txSubmitter.TxInclusion(context.Background(), "tx1")
+				Expect(err).To(MatchError("inclusion query for transaction tx1 failed with status FORBIDDEN: not a reader"))
+			})
+		})
+
+		Context("when the orderer cannot be reached", func() {
+			BeforeEach(func() {
+				fakeOrdererClient.InclusionReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := txSubmitter.TxInclusion(context.Background(), "tx1")
+				Expect(err).To(MatchError("wild-banana"))
+			})
+		})
+	})
+
+	Describe("metrics", func() {
+		var (
+			fakeSubmissions    *metricsfakes.Counter
+			fakeFailures       *metricsfakes.Counter
+			fakeOrderDuration  *metricsfakes.Histogram
+			fakeCommitDuration *metricsfakes.Histogram
+		)
+
+		BeforeEach(func() {
+			fakeSubmissions = &metricsfakes.Counter{}
+			fakeSubmissions.WithReturns(fakeSubmissions)
+			fakeFailures = &metricsfakes.Counter{}
+			fakeFailures.WithReturns(fakeFailures)
+			fakeOrderDuration = &metricsfakes.Histogram{}
+			fakeOrderDuration.WithReturns(fakeOrderDuration)
+			fakeCommitDuration = &metricsfakes.Histogram{}
+			fakeCommitDuration.WithReturns(fakeCommitDuration)
+			txSubmitter.Metrics = &client.Metrics{
+				Submissions:    fakeSubmissions,
+				Failures:       fakeFailures,
+				OrderDuration:  fakeOrderDuration,
+				CommitDuration: fakeCommitDuration,
+			}
+		})
+
+		It("records the submission and the duration of each phase", func() {
+			_, _, err := txSubmitter.SubmitTransaction(txEnvelope, 1)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeSubmissions.WithCallCount()).To(Equal(1))
+			Expect(fakeSubmissions.WithArgsForCall(0)).To(Equal([]string{"channel", "test-channel"}))
+			Expect(fakeSubmissions.AddCallCount()).To(Equal(1))
+			Expect(fakeOrderDuration.WithArgsForCall(0)).To(Equal([]string{"channel", "test-channel", "success", "true"}))
+			Expect(fakeOrderDuration.ObserveCallCount()).To(Equal(1))
+			Expect(fakeCommitDuration.WithArgsForCall(0)).To(Equal([]string{"channel", "test-channel", "success", "true"}))
+			Expect(fakeCommitDuration.ObserveCallCount()).To(Equal(1))
+			Expect(fakeFailures.AddCallCount()).To(Equal(0))
+		})
+
+		It("does not record the commit when not waiting for it", func() {
+			_, _, err := txSubmitter.SubmitTransaction(txEnvelope, 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeOrderDuration.ObserveCallCount()).To(Equal(1))
+			Expect(fakeCommitDuration.ObserveCallCount()).To(Equal(0))
+		})
+
+		Context("when the orderer fails", func() {
+			BeforeEach(func() {
+				fakeOrdererClient.NewBroadcastReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("records the failure", func() {
+				_, _, err := txSubmitter.SubmitTransaction(txEnvelope, 0)
+				Expect(err).To(MatchError("wild-banana"))
+
+				Expect(fakeSubmissions.AddCallCount()).To(Equal(0))
+				Expect(fakeOrderDuration.WithArgsForCall(0)).To(Equal([]string{"channel", "test-channel", "success", "false"}))
+				Expect(fakeFailures.WithCallCount()).To(Equal(1))
+				Expect(fakeFailures.WithArgsForCall(0)).To(Equal([]string{"channel", "test-channel", "phase", "order"}))
+			})
+		})
+
+		Context("when the commit fails", func() {
+			BeforeEach(func() {
+				fakeDeliverFiltered.RecvReturns(nil, errors.New("flying-pineapple"))
+			})
+
+			It("records the failure", func() {
+				_, _, err := txSubmitter.SubmitTransaction(txEnvelope, 1)
+				Expect(err).To(HaveOccurred())
+
+				Expect(fakeCommitDuration.WithArgsForCall(0)).To(Equal([]string{"channel", "test-channel", "success", "false"}))
+				Expect(fakeFailures.WithCallCount()).To(Equal(1))
+				Expect(fakeFailures.WithArgsForCall(0)).To(Equal([]string{"channel", "test-channel", "phase", "commit"}))
+			})
+		})
+	})
+
 	Describe("CreateTxEnvelope", func() {
 		It("returns expected envelope", func() {
 			txid, envelope, err := txSubmitter.CreateTxEnvelope(txBytes)
@@ -272,6 +700,9 @@ var _ = Describe("TxSubmitter", func() {
 			Expect(channelHeader.Epoch).To(Equal(expectedChannelHeader.Epoch))
 			Expect(channelHeader.TxId).To(Equal(txid))
 
+			// the transaction does not expire, as the capabilities of the channel are unknown
+			Expect(channelHeader.NotValidAfter).To(BeNil())
+
 			// verify signature header
 			signatureHeader := common.SignatureHeader{}
 			err = proto.Unmarshal(payload.Header.SignatureHeader, &signatureHeader)
@@ -299,6 +730,116 @@ var _ = Describe("TxSubmitter", func() {
 				Expect(err).To(MatchError("flying-pineapple"))
 			})
 		})
+
+		Context("when the channel has a hashing suite", func() {
+			BeforeEach(func() {
+				config.HashingSuite = "SHA3_256"
+			})
+
+			It("computes the txid with the hashing suite", func() {
+				txid, envelope, err := txSubmitter.CreateTxEnvelope(txBytes)
+				Expect(err).NotTo(HaveOccurred())
+
+				payload := common.Payload{}
+				err = proto.Unmarshal(envelope.Payload, &payload)
+				Expect(err).NotTo(HaveOccurred())
+				signatureHeader := common.SignatureHeader{}
+				err = proto.Unmarshal(payload.Header.SignatureHeader, &signatureHeader)
+				Expect(err).NotTo(HaveOccurred())
+
+				expectedTxid, err := utils.ComputeTxIDWithHash(signatureHeader.Nonce, txSubmitter.Creator, util.ComputeSHA3256)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(txid).To(Equal(expectedTxid))
+			})
+		})
+
+		Context("when the channel invalidates expired transactions", func() {
+			var fakeCapabilitiesProver *mock.CapabilitiesProver
+
+			BeforeEach(func() {
+				fakeCapabilitiesProver = &mock.CapabilitiesProver{}
+				fakeCapabilitiesProver.GetChannelCapabilitiesContextReturns(&token.ChannelCapabilities{FabToken: true, TxExpiration: true}, nil)
+				txSubmitter.Capabilities = &client.ChannelCapabilities{ChannelId: "test-channel", Prover: fakeCapabilitiesProver}
+			})
+
+			channelHeader := func(envelope *common.Envelope) *common.ChannelHeader {
+				payload := common.Payload{}
+				err := proto.Unmarshal(envelope.Payload, &payload)
+				Expect(err).NotTo(HaveOccurred())
+				channelHeader := &common.ChannelHeader{}
+				err = proto.Unmarshal(payload.Header.ChannelHeader, channelHeader)
+				Expect(err).NotTo(HaveOccurred())
+				return channelHeader
+			}
+
+			It("returns an envelope which expires after the default time to live", func() {
+				_, envelope, err := txSubmitter.CreateTxEnvelope(txBytes)
+				Expect(err).NotTo(HaveOccurred())
+
+				channelHeader := channelHeader(envelope)
+				timestamp, err := ptypes.Timestamp(channelHeader.Timestamp)
+				Expect(err).NotTo(HaveOccurred())
+				notValidAfter, err := ptypes.Timestamp(channelHeader.NotValidAfter)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(notValidAfter.Sub(timestamp)).To(Equal(client.DefaultTxTTL))
+			})
+
+			Context("when the time to live is disabled", func() {
+				BeforeEach(func() {
+					config.TxTTL = -1
+				})
+
+				It("returns an envelope without expiration", func() {
+					_, envelope, err := txSubmitter.CreateTxEnvelope(txBytes)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(channelHeader(envelope).NotValidAfter).To(BeNil())
+				})
+			})
+
+			Context("when the capabilities cannot be fetched", func() {
+				BeforeEach(func() {
+					fakeCapabilitiesProver.GetChannelCapabilitiesContextReturns(nil, errors.New("unreachable-prover"))
+				})
+
+				It("returns an error", func() {
+					_, _, err := txSubmitter.CreateTxEnvelope(txBytes)
+					Expect(err).To(MatchError("failed fetching channel capabilities: unreachable-prover"))
+				})
+			})
+		})
+
+		Context("when the channel does not invalidate expired transactions", func() {
+			BeforeEach(func() {
+				fakeCapabilitiesProver := &mock.CapabilitiesProver{}
+				fakeCapabilitiesProver.GetChannelCapabilitiesContextReturns(&token.ChannelCapabilities{FabToken: true}, nil)
+				txSubmitter.Capabilities = &client.ChannelCapabilities{ChannelId: "test-channel", Prover: fakeCapabilitiesProver}
+				config.TxTTL = time.Minute
+			})
+
+			It("returns an envelope without expiration", func() {
+				_, envelope, err := txSubmitter.CreateTxEnvelope(txBytes)
+				Expect(err).NotTo(HaveOccurred())
+
+				payload := common.Payload{}
+				err = proto.Unmarshal(envelope.Payload, &payload)
+				Expect(err).NotTo(HaveOccurred())
+				channelHeader := common.ChannelHeader{}
+				err = proto.Unmarshal(payload.Header.ChannelHeader, &channelHeader)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(channelHeader.NotValidAfter).To(BeNil())
+			})
+		})
+
+		Context("when the hashing suite is unknown", func() {
+			BeforeEach(func() {
+				config.HashingSuite = "MD5"
+			})
+
+			It("returns an error", func() {
+				_, _, err := txSubmitter.CreateTxEnvelope(txBytes)
+				Expect(err).To(MatchError("Unknown hashing algorithm type: MD5"))
+			})
+		})
 	})
 })
 
@@ -397,6 +938,23 @@ var _ = Describe("Create a header", func() {
 			Expect(channelHeader.Epoch).To(Equal(expectedChannelHeader.Epoch))
 			Expect(channelHeader.TxId).To(Equal(txid))
 			Expect(signatureHeader.Creator).To(Equal(creator))
+			Expect(channelHeader.NotValidAfter).To(BeNil())
+		})
+	})
+
+	Describe("CreateHeaderWithTTL", func() {
+		It("returns a header which expires after the time to live", func() {
+			_, header, err := client.CreateHeaderWithTTL(txType, channelId, creator, nil, nil, time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+
+			channelHeader := common.ChannelHeader{}
+			err = proto.Unmarshal(header.ChannelHeader, &channelHeader)
+			Expect(err).NotTo(HaveOccurred())
+			timestamp, err := ptypes.Timestamp(channelHeader.Timestamp)
+			Expect(err).NotTo(HaveOccurred())
+			notValidAfter, err := ptypes.Timestamp(channelHeader.NotValidAfter)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(notValidAfter.Sub(timestamp)).To(Equal(time.Minute))
 		})
 	})
 })
@@ -421,3 +979,24 @@ func createFilteredBlock(channelId string, txIDs ...string) *pb.FilteredBlock {
 func getTxid() {
 
 }
+
+type closingOrdererClient struct {
+	client.OrdererClient
+	closed bool
+}
+
+func (c *closingOrdererClient) Close() error {
+	c.closed = true
+	return nil
+}
+
+type closingDeliverClient struct {
+	client.DeliverClient
+	closed bool
+	err    error
+}
+
+func (c *closingDeliverClient) Close() error {
+	c.closed = true
+	return c.err
+}
@@ -0,0 +1,131 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+// ErrUnexpectedTransaction is returned when the token transaction assembled
+// by a prover does not carry out the request of the client.
+var ErrUnexpectedTransaction = errors.New("token transaction does not match the request")
+
+// VerifyImport checks that the token transaction issues exactly the tokens
+// to issue, in order.
+func VerifyImport(tx *token.TokenTransaction, tokensToIssue []*token.TokenToIssue) error {
+	importAction := tx.GetPlainAction().GetPlainImport()
+	if importAction == nil {
+		return mismatch("not a plain import")
+	}
+	if len(importAction.Outputs) != len(tokensToIssue) {
+		return mismatch("%d outputs for %d tokens to issue", len(importAction.Outputs), len(tokensToIssue))
+	}
+	for i, output := range importAction.Outputs {
+		tti := tokensToIssue[i]
+		if !bytes.Equal(output.Owner, tti.Recipient) || output.Type != tti.Type || output.Quantity != tti.Quantity {
+			return mismatch("output %d issues %d %s to another owner or quantity than requested", i, output.Quantity, output.Type)
+		}
+		if !bytes.Equal(output.Metadata, tti.Metadata) {
+			return mismatch("output %d carries other metadata than requested", i)
+		}
+	}
+	return nil
+}
+
+// VerifyTransfer checks that the token transaction spends as many tokens as
+// there are token IDs and distributes them exactly as the shares, in order.
+// The metadata of the outputs is only checked when the shares re-specify it.
+func VerifyTransfer(tx *token.TokenTransaction, tokenIDs [][]byte, shares []*token.RecipientTransferShare) error {
+	transfer := tx.GetPlainAction().GetPlainTransfer()
+	if transfer == nil {
+		return mismatch("not a plain transfer")
+	}
+	if len(transfer.Delegations) != 0 {
+		return mismatch("unrequested delegations")
+	}
+	if len(transfer.Inputs) != len(tokenIDs) {
+		return mismatch("%d inputs for %d token IDs", len(transfer.Inputs), len(tokenIDs))
+	}
+	if len(transfer.Outputs) != len(shares) {
+		return mismatch("%d outputs for %d shares", len(transfer.Outputs), len(shares))
+	}
+	for i, output := range transfer.Outputs {
+		if !bytes.Equal(output.Owner, shares[i].Recipient) {
+			return mismatch("output %d is owned by another recipient than requested", i)
+		}
+		if output.Quantity != shares[i].Quantity {
+			return mismatch("output %d transfers %d tokens instead of %d", i, output.Quantity, shares[i].Quantity)
+		}
+		if output.Type != transfer.Outputs[0].Type {
+			return mismatch("outputs of types %s and %s", transfer.Outputs[0].Type, output.Type)
+		}
+		if len(shares[i].Metadata) != 0 && !bytes.Equal(output.Metadata, shares[i].Metadata) {
+			return mismatch("output %d carries other metadata than requested", i)
+		}
+	}
+	return nil
+}
+
+// VerifyRedeem checks that the token transaction spends as many tokens as
+// there are token IDs and redeems exactly quantity of them. The owner of the
+// remainder, if any, is not checked as it depends on the owner encoding of
+// the prover; the committer checks that it does not exceed the inputs.
+func VerifyRedeem(tx *token.TokenTransaction, tokenIDs [][]byte, quantity uint64) error {
+	redeem := tx.GetPlainAction().GetPlainRedeem()
+	if redeem == nil {
+		return mismatch("not a plain redeem")
+	}
+	if len(redeem.Inputs) != len(tokenIDs) {
+		return mismatch("%d inputs for %d token IDs", len(redeem.Inputs), len(tokenIDs))
+	}
+	if len(redeem.Outputs) == 0 || len(redeem.Outputs) > 2 {
+		return mismatch("%d outputs for a redemption", len(redeem.Outputs))
+	}
+	redeemed := redeem.Outputs[0]
+	if len(redeemed.Owner) != 0 {
+		return mismatch("the redeemed output has an owner")
+	}
+	if redeemed.Quantity != quantity {
+		return mismatch("redeems %d tokens instead of %d", redeemed.Quantity, quantity)
+	}
+	if len(redeem.Outputs) == 2 && redeem.Outputs[1].Type != redeemed.Type {
+		return mismatch("outputs of types %s and %s", redeemed.Type, redeem.Outputs[1].Type)
+	}
+	return nil
+}
+
+// verify checks the serialized token transaction assembled by the prover
+// against the request, when VerifyTransactions is set.
+func (c *Client) verify(request *Request, serializedTokenTx []byte) error {
+	if !c.VerifyTransactions {
+		return nil
+	}
+	tx := &token.TokenTransaction{}
+	err := proto.Unmarshal(serializedTokenTx, tx)
+	if err != nil {
+		return errors.Wrap(err, "failed unmarshaling token transaction")
+	}
+	switch request.Method {
+	case MethodIssue:
+		return VerifyImport(tx, request.TokensToIssue)
+	case MethodTransfer:
+		return VerifyTransfer(tx, request.TokenIDs, request.Shares)
+	case MethodRedeem:
+		return VerifyRedeem(tx, request.TokenIDs, request.Quantity)
+	default:
+		return errors.Errorf("cannot verify the transactions of method %s", request.Method)
+	}
+}
+
+func mismatch(format string, args ...interface{}) error {
+	return errors.WithMessage(ErrUnexpectedTransaction, fmt.Sprintf(format, args...))
+}
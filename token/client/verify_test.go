@@ -0,0 +1,294 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client_test
+
+import (
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/hyperledger/fabric/token/client/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Verify", func() {
+	var (
+		tokenIDs [][]byte
+		inputs   []*token.InputId
+	)
+
+	plainTx := func(action *token.PlainTokenAction) *token.TokenTransaction {
+		return &token.TokenTransaction{Action: &token.TokenTransaction_PlainAction{PlainAction: action}}
+	}
+
+	BeforeEach(func() {
+		tokenIDs = [][]byte{[]byte("token-1"), []byte("token-2")}
+		inputs = []*token.InputId{{TxId: "tx", Index: 0}, {TxId: "tx", Index: 1}}
+	})
+
+	Describe("VerifyImport", func() {
+		var (
+			tokensToIssue []*token.TokenToIssue
+			outputs       []*token.PlainOutput
+			tx            *token.TokenTransaction
+		)
+
+		BeforeEach(func() {
+			tokensToIssue = []*token.TokenToIssue{
+				{Recipient: []byte("alice"), Type: "PDQ", Quantity: 100},
+				{Recipient: []byte("bob"), Type: "XYZ", Quantity: 10},
+			}
+			outputs = []*token.PlainOutput{
+				{Owner: []byte("alice"), Type: "PDQ", Quantity: 100},
+				{Owner: []byte("bob"), Type: "XYZ", Quantity: 10},
+			}
+			tx = plainTx(&token.PlainTokenAction{Data: &token.PlainTokenAction_PlainImport{
+				PlainImport: &token.PlainImport{Outputs: outputs},
+			}})
+		})
+
+		It("accepts the import of the tokens to issue", func() {
+			Expect(client.VerifyImport(tx, tokensToIssue)).To(Succeed())
+		})
+
+		It("rejects an output to another recipient", func() {
+			outputs[1].Owner = []byte("mallory")
+			err := client.VerifyImport(tx, tokensToIssue)
+			Expect(errors.Cause(err)).To(Equal(client.ErrUnexpectedTransaction))
+			Expect(err).To(MatchError("output 1 issues 10 XYZ to another owner or quantity than requested: token transaction does not match the request"))
+		})
+
+		It("rejects an inflated quantity", func() {
+			outputs[0].Quantity = 1000
+			err := client.VerifyImport(tx, tokensToIssue)
+			Expect(errors.Cause(err)).To(Equal(client.ErrUnexpectedTransaction))
+		})
+
+		It("rejects another type", func() {
+			outputs[0].Type = "ABC"
+			err := client.VerifyImport(tx, tokensToIssue)
+			Expect(errors.Cause(err)).To(Equal(client.ErrUnexpectedTransaction))
+		})
+
+		It("rejects other metadata", func() {
+			tokensToIssue[0].Metadata = []byte(`{"maturity":"2030-01-01"}`)
+			err := client.VerifyImport(tx, tokensToIssue)
+			Expect(err).To(MatchError("output 0 carries other metadata than requested: token transaction does not match the request"))
+		})
+
+		It("rejects an additional output", func() {
+			tx.GetPlainAction().GetPlainImport().Outputs = append(outputs, &token.PlainOutput{Owner: []byte("mallory"), Type: "PDQ", Quantity: 1})
+			err := client.VerifyImport(tx, tokensToIssue)
+			Expect(err).To(MatchError("3 outputs for 2 tokens to issue: token transaction does not match the request"))
+		})
+
+		It("rejects another action", func() {
+			err := client.VerifyImport(plainTx(&token.PlainTokenAction{Data: &token.PlainTokenAction_PlainTransfer{PlainTransfer: &token.PlainTransfer{}}}), tokensToIssue)
+			Expect(err).To(MatchError("not a plain import: token transaction does not match the request"))
+		})
+	})
+
+	Describe("VerifyTransfer", func() {
+		var (
+			shares   []*token.RecipientTransferShare
+			transfer *token.PlainTransfer
+			tx       *token.TokenTransaction
+		)
+
+		BeforeEach(func() {
+			shares = []*token.RecipientTransferShare{
+				{Recipient: []byte("bob"), Quantity: 60},
+				{Recipient: []byte("alice"), Quantity: 40},
+			}
+			transfer = &token.PlainTransfer{
+				Inputs: inputs,
+				Outputs: []*token.PlainOutput{
+					{Owner: []byte("bob"), Type: "PDQ", Quantity: 60},
+					{Owner: []byte("alice"), Type: "PDQ", Quantity: 40},
+				},
+			}
+			tx = plainTx(&token.PlainTokenAction{Data: &token.PlainTokenAction_PlainTransfer{PlainTransfer: transfer}})
+		})
+
+		It("accepts the transfer of the shares", func() {
+			Expect(client.VerifyTransfer(tx, tokenIDs, shares)).To(Succeed())
+		})
+
+		It("rejects an output to another recipient", func() {
+			transfer.Outputs[0].Owner = []byte("mallory")
+			err := client.VerifyTransfer(tx, tokenIDs, shares)
+			Expect(err).To(MatchError("output 0 is owned by another recipient than requested: token transaction does not match the request"))
+		})
+
+		It("rejects an inflated quantity", func() {
+			transfer.Outputs[1].Quantity = 400
+			err := client.VerifyTransfer(tx, tokenIDs, shares)
+			Expect(err).To(MatchError("output 1 transfers 400 tokens instead of 40: token transaction does not match the request"))
+		})
+
+		It("rejects outputs of different types", func() {
+			transfer.Outputs[1].Type = "XYZ"
+			err := client.VerifyTransfer(tx, tokenIDs, shares)
+			Expect(err).To(MatchError("outputs of types PDQ and XYZ: token transaction does not match the request"))
+		})
+
+		It("rejects other metadata than re-specified by the shares", func() {
+			transfer.Outputs[0].Metadata = []byte(`{"maturity":"2030-01-01"}`)
+			Expect(client.VerifyTransfer(tx, tokenIDs, shares)).To(Succeed())
+
+			shares[0].Metadata = []byte(`{"maturity":"2031-01-01"}`)
+			err := client.VerifyTransfer(tx, tokenIDs, shares)
+			Expect(err).To(MatchError("output 0 carries other metadata than requested: token transaction does not match the request"))
+		})
+
+		It("rejects additional inputs", func() {
+			transfer.Inputs = append(inputs, &token.InputId{TxId: "tx", Index: 2})
+			err := client.VerifyTransfer(tx, tokenIDs, shares)
+			Expect(err).To(MatchError("3 inputs for 2 token IDs: token transaction does not match the request"))
+		})
+
+		It("rejects additional outputs", func() {
+			transfer.Outputs = append(transfer.Outputs, &token.PlainOutput{Owner: []byte("mallory"), Type: "PDQ", Quantity: 1})
+			err := client.VerifyTransfer(tx, tokenIDs, shares)
+			Expect(err).To(MatchError("3 outputs for 2 shares: token transaction does not match the request"))
+		})
+
+		It("rejects delegations", func() {
+			transfer.Delegations = []*token.SignedDelegation{{}}
+			err := client.VerifyTransfer(tx, tokenIDs, shares)
+			Expect(err).To(MatchError("unrequested delegations: token transaction does not match the request"))
+		})
+
+		It("rejects another action", func() {
+			err := client.VerifyTransfer(plainTx(&token.PlainTokenAction{Data: &token.PlainTokenAction_PlainRedeem{PlainRedeem: transfer}}), tokenIDs, shares)
+			Expect(err).To(MatchError("not a plain transfer: token transaction does not match the request"))
+		})
+	})
+
+	Describe("VerifyRedeem", func() {
+		var (
+			redeem *token.PlainTransfer
+			tx     *token.TokenTransaction
+		)
+
+		BeforeEach(func() {
+			redeem = &token.PlainTransfer{
+				Inputs: inputs,
+				Outputs: []*token.PlainOutput{
+					{Type: "PDQ", Quantity: 10},
+					{Owner: []byte("alice"), Type: "PDQ", Quantity: 90},
+				},
+			}
+			tx = plainTx(&token.PlainTokenAction{Data: &token.PlainTokenAction_PlainRedeem{PlainRedeem: redeem}})
+		})
+
+		It("accepts the redemption of the quantity", func() {
+			Expect(client.VerifyRedeem(tx, tokenIDs, 10)).To(Succeed())
+		})
+
+		It("accepts a redemption without remainder", func() {
+			redeem.Outputs = redeem.Outputs[:1]
+			Expect(client.VerifyRedeem(tx, tokenIDs, 10)).To(Succeed())
+		})
+
+		It("rejects another quantity", func() {
+			err := client.VerifyRedeem(tx, tokenIDs, 20)
+			Expect(err).To(MatchError("redeems 10 tokens instead of 20: token transaction does not match the request"))
+		})
+
+		It("rejects a redeemed output with an owner", func() {
+			redeem.Outputs[0].Owner = []byte("mallory")
+			err := client.VerifyRedeem(tx, tokenIDs, 10)
+			Expect(err).To(MatchError("the redeemed output has an owner: token transaction does not match the request"))
+		})
+
+		It("rejects additional outputs", func() {
+			redeem.Outputs = append(redeem.Outputs, &token.PlainOutput{Owner: []byte("mallory"), Type: "PDQ", Quantity: 1})
+			err := client.VerifyRedeem(tx, tokenIDs, 10)
+			Expect(err).To(MatchError("3 outputs for a redemption: token transaction does not match the request"))
+		})
+
+		It("rejects additional inputs", func() {
+			redeem.Inputs = inputs[:1]
+			err := client.VerifyRedeem(tx, tokenIDs, 10)
+			Expect(err).To(MatchError("1 inputs for 2 token IDs: token transaction does not match the request"))
+		})
+
+		It("rejects a remainder of another type", func() {
+			redeem.Outputs[1].Type = "XYZ"
+			err := client.VerifyRedeem(tx, tokenIDs, 10)
+			Expect(err).To(MatchError("outputs of types PDQ and XYZ: token transaction does not match the request"))
+		})
+	})
+
+	Describe("Client", func() {
+		var (
+			fakeProver      *mock.Prover
+			fakeTxSubmitter *mock.FabricTxSubmitter
+			tokenClient     *client.Client
+			shares          []*token.RecipientTransferShare
+		)
+
+		BeforeEach(func() {
+			shares = []*token.RecipientTransferShare{{Recipient: []byte("bob"), Quantity: 60}}
+			fakeProver = &mock.Prover{}
+			fakeProver.RequestTransferReturns(ProtoMarshal(plainTx(&token.PlainTokenAction{Data: &token.PlainTokenAction_PlainTransfer{
+				PlainTransfer: &token.PlainTransfer{
+					Inputs:  inputs,
+					Outputs: []*token.PlainOutput{{Owner: []byte("mallory"), Type: "PDQ", Quantity: 60}},
+				},
+			}})), nil)
+			fakeSigningIdentity := &mock.SigningIdentity{}
+			fakeSigningIdentity.SignReturns([]byte("tx-signature"), nil)
+			fakeTxSubmitter = &mock.FabricTxSubmitter{}
+			tokenClient = &client.Client{
+				SigningIdentity:    fakeSigningIdentity,
+				Prover:             fakeProver,
+				TxSubmitter:        fakeTxSubmitter,
+				VerifyTransactions: true,
+			}
+		})
+
+		It("does not submit the transactions that do not match the request", func() {
+			_, err := tokenClient.Transfer(tokenIDs, shares)
+			Expect(errors.Cause(err)).To(Equal(client.ErrUnexpectedTransaction))
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+		})
+
+		It("submits the transactions that match the request", func() {
+			shares[0].Recipient = []byte("mallory")
+			_, err := tokenClient.Transfer(tokenIDs, shares)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(1))
+		})
+
+		Context("when the prover responds something else than a token transaction", func() {
+			BeforeEach(func() {
+				fakeProver.RequestTransferReturns([]byte("garbage"), nil)
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.Transfer(tokenIDs, shares)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("failed unmarshaling token transaction"))
+				Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when verification is not enabled", func() {
+			BeforeEach(func() {
+				tokenClient.VerifyTransactions = false
+			})
+
+			It("submits the transactions as assembled by the prover", func() {
+				_, err := tokenClient.Transfer(tokenIDs, shares)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(1))
+			})
+		})
+	})
+})
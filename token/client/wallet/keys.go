@@ -0,0 +1,137 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"crypto/rand"
+	"sync"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/scrypt"
+)
+
+// KeySize is the size, in bytes, of the AES-256 keys that encrypt records.
+const KeySize = 32
+
+// SaltSize is the size, in bytes, of the salts used to derive passphrase keys.
+const SaltSize = 16
+
+//go:generate counterfeiter -o mock/key_provider.go -fake-name KeyProvider . KeyProvider
+
+// KeyProvider holds the keys that encrypt the records of a wallet. Records are
+// encrypted with the current key; older keys remain available to decrypt the
+// records written before a rotation.
+type KeyProvider interface {
+	// CurrentKeyID returns the ID of the key that encrypts new records.
+	CurrentKeyID() string
+	// Key returns the key with the passed ID.
+	Key(id string) ([]byte, error)
+}
+
+// Keyring is a KeyProvider backed by an in-memory set of keys.
+type Keyring struct {
+	mutex   sync.RWMutex
+	current string
+	keys    map[string][]byte
+}
+
+// NewKeyring creates an empty Keyring.
+func NewKeyring() *Keyring {
+	return &Keyring{keys: map[string][]byte{}}
+}
+
+// Add adds a key to the keyring; when current is true the key becomes the one
+// used to encrypt new records.
+func (k *Keyring) Add(id string, key []byte, current bool) error {
+	if id == "" {
+		return errors.New("key ID is required")
+	}
+	if len(key) != KeySize {
+		return errors.Errorf("key must be %d bytes, got %d", KeySize, len(key))
+	}
+
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	k.keys[id] = key
+	if current {
+		k.current = id
+	}
+	return nil
+}
+
+// CurrentKeyID returns the ID of the key that encrypts new records.
+func (k *Keyring) CurrentKeyID() string {
+	k.mutex.RLock()
+	defer k.mutex.RUnlock()
+	return k.current
+}
+
+// Key returns the key with the passed ID.
+func (k *Keyring) Key(id string) ([]byte, error) {
+	k.mutex.RLock()
+	defer k.mutex.RUnlock()
+	key, ok := k.keys[id]
+	if !ok {
+		return nil, errors.Errorf("key %s not found", id)
+	}
+	return key, nil
+}
+
+// DeriveKey derives a key from a passphrase with scrypt. The salt must be
+// random, at least SaltSize bytes long, and stored alongside the wallet.
+func DeriveKey(passphrase, salt []byte) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("passphrase is required")
+	}
+	if len(salt) < SaltSize {
+		return nil, errors.Errorf("salt must be at least %d bytes", SaltSize)
+	}
+	key, err := scrypt.Key(passphrase, salt, 1<<15, 8, 1, KeySize)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed deriving key")
+	}
+	return key, nil
+}
+
+// NewSalt returns a random salt for DeriveKey.
+func NewSalt() ([]byte, error) {
+	salt := make([]byte, SaltSize)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed generating salt")
+	}
+	return salt, nil
+}
+
+// WrapKey generates a new random key and returns it together with its copy
+// encrypted under kek, a BCCSP AES key. Only the wrapped copy must be stored;
+// the BCCSP keeps the key encryption key.
+func WrapKey(csp bccsp.BCCSP, kek bccsp.Key) (key, wrapped []byte, err error) {
+	key = make([]byte, KeySize)
+	_, err = rand.Read(key)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed generating key")
+	}
+	wrapped, err = csp.Encrypt(kek, key, &bccsp.AESCBCPKCS7ModeOpts{})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed wrapping key")
+	}
+	return key, wrapped, nil
+}
+
+// UnwrapKey decrypts a key returned by WrapKey.
+func UnwrapKey(csp bccsp.BCCSP, kek bccsp.Key, wrapped []byte) ([]byte, error) {
+	key, err := csp.Decrypt(kek, wrapped, &bccsp.AESCBCPKCS7ModeOpts{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unwrapping key")
+	}
+	if len(key) != KeySize {
+		return nil, errors.Errorf("unwrapped key must be %d bytes, got %d", KeySize, len(key))
+	}
+	return key, nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet_test
+
+import (
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/token/client/wallet"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Keys", func() {
+	Describe("Keyring", func() {
+		It("rejects keys of the wrong size", func() {
+			err := wallet.NewKeyring().Add("key1", []byte("short"), true)
+			Expect(err).To(MatchError("key must be 32 bytes, got 5"))
+		})
+
+		It("rejects keys without an ID", func() {
+			err := wallet.NewKeyring().Add("", make([]byte, wallet.KeySize), true)
+			Expect(err).To(MatchError("key ID is required"))
+		})
+
+		It("returns an error for unknown keys", func() {
+			_, err := wallet.NewKeyring().Key("key1")
+			Expect(err).To(MatchError("key key1 not found"))
+		})
+	})
+
+	Describe("DeriveKey", func() {
+		It("derives the same key from the same passphrase and salt", func() {
+			salt, err := wallet.NewSalt()
+			Expect(err).NotTo(HaveOccurred())
+
+			key1, err := wallet.DeriveKey([]byte("passphrase"), salt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key1).To(HaveLen(wallet.KeySize))
+			key2, err := wallet.DeriveKey([]byte("passphrase"), salt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key2).To(Equal(key1))
+
+			key3, err := wallet.DeriveKey([]byte("other"), salt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key3).NotTo(Equal(key1))
+		})
+
+		It("requires a passphrase", func() {
+			_, err := wallet.DeriveKey(nil, make([]byte, wallet.SaltSize))
+			Expect(err).To(MatchError("passphrase is required"))
+		})
+
+		It("requires a long enough salt", func() {
+			_, err := wallet.DeriveKey([]byte("passphrase"), []byte("salt"))
+			Expect(err).To(MatchError("salt must be at least 16 bytes"))
+		})
+	})
+
+	Describe("WrapKey", func() {
+		var (
+			csp bccsp.BCCSP
+			kek bccsp.Key
+		)
+
+		BeforeEach(func() {
+			var err error
+			csp = factory.GetDefault()
+			kek, err = csp.KeyGen(&bccsp.AESKeyGenOpts{Temporary: true})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("protects a random key with a BCCSP key", func() {
+			key, wrapped, err := wallet.WrapKey(csp, kek)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(HaveLen(wallet.KeySize))
+			Expect(wrapped).NotTo(ContainSubstring(string(key)))
+
+			unwrapped, err := wallet.UnwrapKey(csp, kek, wrapped)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(unwrapped).To(Equal(key))
+		})
+
+		It("fails to unwrap with another key", func() {
+			_, wrapped, err := wallet.WrapKey(csp, kek)
+			Expect(err).NotTo(HaveOccurred())
+			other, err := csp.KeyGen(&bccsp.AESKeyGenOpts{Temporary: true})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = wallet.UnwrapKey(csp, other, wrapped)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,121 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+//go:generate counterfeiter -o mock/store.go -fake-name Store . Store
+
+// Store persists the encrypted records of a wallet.
+type Store interface {
+	// Put stores value under name, replacing any previous value.
+	Put(name string, value []byte) error
+	// Get returns the value stored under name, or nil when there is none.
+	Get(name string) ([]byte, error)
+	// Delete removes the value stored under name.
+	Delete(name string) error
+	// List returns the names of the stored values that start with prefix.
+	List(prefix string) ([]string, error)
+}
+
+// FileStore keeps each record of a wallet in its own file in a directory.
+// Files are replaced atomically so a crash never leaves a partial record.
+type FileStore struct {
+	Dir string
+
+	mutex sync.Mutex
+}
+
+// Put stores value under name.
+func (f *FileStore) Put(name string, value []byte) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	err := os.MkdirAll(f.Dir, 0700)
+	if err != nil {
+		return errors.Wrapf(err, "failed creating wallet directory %s", f.Dir)
+	}
+
+	tmp := f.path(name) + ".tmp"
+	err = ioutil.WriteFile(tmp, value, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed writing record %s", name)
+	}
+	return os.Rename(tmp, f.path(name))
+}
+
+// Get returns the value stored under name.
+func (f *FileStore) Get(name string) ([]byte, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	value, err := ioutil.ReadFile(f.path(name))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading record %s", name)
+	}
+	return value, nil
+}
+
+// Delete removes the value stored under name.
+func (f *FileStore) Delete(name string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	err := os.Remove(f.path(name))
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed deleting record %s", name)
+	}
+	return nil
+}
+
+// List returns the names of the stored values that start with prefix.
+func (f *FileStore) List(prefix string) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	files, err := ioutil.ReadDir(f.Dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading wallet directory %s", f.Dir)
+	}
+
+	var names []string
+	for _, file := range files {
+		if file.IsDir() || strings.HasSuffix(file.Name(), ".tmp") {
+			continue
+		}
+		raw, err := hex.DecodeString(file.Name())
+		if err != nil {
+			continue
+		}
+		if strings.HasPrefix(string(raw), prefix) {
+			names = append(names, string(raw))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// path hex encodes the name so that any record name is a valid file name.
+func (f *FileStore) path(name string) string {
+	return filepath.Join(f.Dir, hex.EncodeToString([]byte(name)))
+}
@@ -0,0 +1,68 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/token/client/wallet"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FileStore", func() {
+	var (
+		tempDir string
+		store   *wallet.FileStore
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "wallet-store")
+		Expect(err).NotTo(HaveOccurred())
+		store = &wallet.FileStore{Dir: filepath.Join(tempDir, "wallet")}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	It("stores, lists and deletes records", func() {
+		Expect(store.Put("outputs/a", []byte("1"))).To(Succeed())
+		Expect(store.Put("outputs/b", []byte("2"))).To(Succeed())
+		Expect(store.Put("pending/c", []byte("3"))).To(Succeed())
+
+		value, err := store.Get("outputs/b")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal([]byte("2")))
+
+		names, err := store.List("outputs/")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(Equal([]string{"outputs/a", "outputs/b"}))
+
+		Expect(store.Delete("outputs/a")).To(Succeed())
+		value, err = store.Get("outputs/a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(BeNil())
+	})
+
+	It("restricts access to the records", func() {
+		Expect(store.Put("outputs/a", []byte("1"))).To(Succeed())
+		info, err := os.Stat(store.Dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0700)))
+	})
+
+	It("returns no records when the directory does not exist", func() {
+		names, err := store.List("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(BeEmpty())
+		Expect(store.Delete("missing")).To(Succeed())
+	})
+})
@@ -0,0 +1,219 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+const (
+	outputPrefix  = "outputs/"
+	pendingPrefix = "pending/"
+)
+
+// sealedRecord is the stored form of a record.
+type sealedRecord struct {
+	KeyID      string `json:"key_id"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Wallet persists the unspent outputs and pending transactions of a token
+// client. Records are encrypted with AES-256-GCM under the current key of the
+// KeyProvider. The record name and key ID are authenticated as additional
+// data, so a record cannot be altered or moved to a different name without
+// detection.
+type Wallet struct {
+	Store Store
+	Keys  KeyProvider
+}
+
+// PutOutput stores an unspent output owned by the client.
+func (w *Wallet) PutOutput(output *token.TokenOutput) error {
+	raw, err := proto.Marshal(output)
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling output")
+	}
+	return w.put(outputPrefix+hex.EncodeToString(output.Id), raw)
+}
+
+// DeleteOutput removes a spent output.
+func (w *Wallet) DeleteOutput(id []byte) error {
+	return w.Store.Delete(outputPrefix + hex.EncodeToString(id))
+}
+
+// Outputs returns the stored outputs.
+func (w *Wallet) Outputs() ([]*token.TokenOutput, error) {
+	names, err := w.Store.List(outputPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var outputs []*token.TokenOutput
+	for _, name := range names {
+		raw, err := w.get(name)
+		if err != nil {
+			return nil, err
+		}
+		output := &token.TokenOutput{}
+		err = proto.Unmarshal(raw, output)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed unmarshaling output %s", name)
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+// PutPendingTx stores a transaction that has been submitted but not yet committed.
+func (w *Wallet) PutPendingTx(txID string, tx []byte) error {
+	return w.put(pendingPrefix+txID, tx)
+}
+
+// DeletePendingTx removes a pending transaction once it is committed or discarded.
+func (w *Wallet) DeletePendingTx(txID string) error {
+	return w.Store.Delete(pendingPrefix + txID)
+}
+
+// PendingTxs returns the stored pending transactions, keyed by transaction ID.
+func (w *Wallet) PendingTxs() (map[string][]byte, error) {
+	names, err := w.Store.List(pendingPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := map[string][]byte{}
+	for _, name := range names {
+		raw, err := w.get(name)
+		if err != nil {
+			return nil, err
+		}
+		txs[strings.TrimPrefix(name, pendingPrefix)] = raw
+	}
+	return txs, nil
+}
+
+// Rotate re-encrypts every record that is not encrypted under the current key.
+// Once Rotate returns, the previous keys are no longer needed.
+func (w *Wallet) Rotate() error {
+	current := w.Keys.CurrentKeyID()
+	names, err := w.Store.List("")
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		record, err := w.load(name)
+		if err != nil {
+			return err
+		}
+		if record == nil || record.KeyID == current {
+			continue
+		}
+		plaintext, err := w.open(name, record)
+		if err != nil {
+			return err
+		}
+		err = w.put(name, plaintext)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *Wallet) put(name string, plaintext []byte) error {
+	keyID := w.Keys.CurrentKeyID()
+	aead, err := w.aead(keyID)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return errors.Wrap(err, "failed generating nonce")
+	}
+
+	record := &sealedRecord{
+		KeyID:      keyID,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, plaintext, additionalData(name, keyID)),
+	}
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling record")
+	}
+	return w.Store.Put(name, raw)
+}
+
+func (w *Wallet) get(name string) ([]byte, error) {
+	record, err := w.load(name)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, errors.Errorf("record %s not found", name)
+	}
+	return w.open(name, record)
+}
+
+func (w *Wallet) load(name string) (*sealedRecord, error) {
+	raw, err := w.Store.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, nil
+	}
+	record := &sealedRecord{}
+	err = json.Unmarshal(raw, record)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed unmarshaling record %s", name)
+	}
+	return record, nil
+}
+
+func (w *Wallet) open(name string, record *sealedRecord) ([]byte, error) {
+	aead, err := w.aead(record.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	if len(record.Nonce) != aead.NonceSize() {
+		return nil, errors.Errorf("invalid nonce in record %s", name)
+	}
+	plaintext, err := aead.Open(nil, record.Nonce, record.Ciphertext, additionalData(name, record.KeyID))
+	if err != nil {
+		return nil, errors.Errorf("record %s failed integrity check", name)
+	}
+	return plaintext, nil
+}
+
+func (w *Wallet) aead(keyID string) (cipher.AEAD, error) {
+	key, err := w.Keys.Key(keyID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed getting wallet key")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating cipher")
+	}
+	return cipher.NewGCM(block)
+}
+
+func additionalData(name, keyID string) []byte {
+	return []byte(name + "\x00" + keyID)
+}
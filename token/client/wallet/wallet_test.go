@@ -0,0 +1,183 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/client/wallet"
+	"github.com/hyperledger/fabric/token/client/wallet/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Wallet", func() {
+	var (
+		tempDir string
+		store   *wallet.FileStore
+		keyring *wallet.Keyring
+		w       *wallet.Wallet
+		output  *token.TokenOutput
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "wallet")
+		Expect(err).NotTo(HaveOccurred())
+
+		store = &wallet.FileStore{Dir: tempDir}
+		keyring = wallet.NewKeyring()
+		err = keyring.Add("key1", bytes.Repeat([]byte{1}, wallet.KeySize), true)
+		Expect(err).NotTo(HaveOccurred())
+
+		w = &wallet.Wallet{Store: store, Keys: keyring}
+		output = &token.TokenOutput{Id: []byte("tx0.0"), Type: "PDQ", Quantity: 100}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	It("stores outputs encrypted", func() {
+		err := w.PutOutput(output)
+		Expect(err).NotTo(HaveOccurred())
+
+		outputs, err := w.Outputs()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(outputs).To(HaveLen(1))
+		Expect(proto.Equal(outputs[0], output)).To(BeTrue())
+
+		names, err := store.List("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(HaveLen(1))
+		raw, err := store.Get(names[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(raw)).NotTo(ContainSubstring("PDQ"))
+	})
+
+	It("deletes outputs", func() {
+		Expect(w.PutOutput(output)).To(Succeed())
+		Expect(w.DeleteOutput(output.Id)).To(Succeed())
+
+		outputs, err := w.Outputs()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(outputs).To(BeEmpty())
+	})
+
+	It("stores pending transactions", func() {
+		Expect(w.PutPendingTx("tx1", []byte("envelope"))).To(Succeed())
+		Expect(w.PutOutput(output)).To(Succeed())
+
+		txs, err := w.PendingTxs()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(txs).To(Equal(map[string][]byte{"tx1": []byte("envelope")}))
+
+		Expect(w.DeletePendingTx("tx1")).To(Succeed())
+		txs, err = w.PendingTxs()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(txs).To(BeEmpty())
+	})
+
+	Context("when a record is tampered with", func() {
+		BeforeEach(func() {
+			Expect(w.PutPendingTx("tx1", []byte("envelope"))).To(Succeed())
+
+			raw, err := store.Get("pending/tx1")
+			Expect(err).NotTo(HaveOccurred())
+			record := map[string]interface{}{}
+			Expect(json.Unmarshal(raw, &record)).To(Succeed())
+			record["ciphertext"] = "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=="
+			raw, err = json.Marshal(record)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(store.Put("pending/tx1", raw)).To(Succeed())
+		})
+
+		It("fails the integrity check", func() {
+			_, err := w.PendingTxs()
+			Expect(err).To(MatchError("record pending/tx1 failed integrity check"))
+		})
+	})
+
+	Context("when a record is moved to another name", func() {
+		BeforeEach(func() {
+			Expect(w.PutPendingTx("tx1", []byte("envelope"))).To(Succeed())
+			raw, err := store.Get("pending/tx1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(store.Put("pending/tx2", raw)).To(Succeed())
+		})
+
+		It("fails the integrity check", func() {
+			_, err := w.PendingTxs()
+			Expect(err).To(MatchError("record pending/tx2 failed integrity check"))
+		})
+	})
+
+	Describe("Rotate", func() {
+		BeforeEach(func() {
+			Expect(w.PutOutput(output)).To(Succeed())
+			Expect(w.PutPendingTx("tx1", []byte("envelope"))).To(Succeed())
+			Expect(keyring.Add("key2", bytes.Repeat([]byte{2}, wallet.KeySize), true)).To(Succeed())
+		})
+
+		It("re-encrypts the records under the current key", func() {
+			err := w.Rotate()
+			Expect(err).NotTo(HaveOccurred())
+
+			rotated := wallet.NewKeyring()
+			Expect(rotated.Add("key2", bytes.Repeat([]byte{2}, wallet.KeySize), true)).To(Succeed())
+			w.Keys = rotated
+
+			outputs, err := w.Outputs()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(outputs).To(HaveLen(1))
+			txs, err := w.PendingTxs()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(txs).To(HaveKey("tx1"))
+		})
+
+		It("keeps records readable with the previous key until rotated", func() {
+			outputs, err := w.Outputs()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(outputs).To(HaveLen(1))
+		})
+	})
+
+	Context("when the key is not available", func() {
+		var fakeKeys *mock.KeyProvider
+
+		BeforeEach(func() {
+			fakeKeys = &mock.KeyProvider{}
+			fakeKeys.CurrentKeyIDReturns("missing")
+			fakeKeys.KeyReturns(nil, errors.New("key missing not found"))
+			w.Keys = fakeKeys
+		})
+
+		It("returns an error", func() {
+			err := w.PutOutput(output)
+			Expect(err).To(MatchError("failed getting wallet key: key missing not found"))
+		})
+	})
+
+	Context("when the store fails", func() {
+		BeforeEach(func() {
+			fakeStore := &mock.Store{}
+			fakeStore.ListReturns(nil, errors.New("disk failure"))
+			w.Store = fakeStore
+		})
+
+		It("returns an error", func() {
+			_, err := w.Outputs()
+			Expect(err).To(MatchError("disk failure"))
+		})
+	})
+})
@@ -0,0 +1,25 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import "strings"
+
+const (
+	// UnsupportedCommandMessage prefixes the errors of the provers receiving
+	// a command of a type they do not know, typically from a newer client.
+	UnsupportedCommandMessage = "command type not supported by this prover"
+
+	// legacyUnsupportedCommandMessage is the error of the provers predating
+	// UnsupportedCommandMessage for the commands of unknown types.
+	legacyUnsupportedCommandMessage = "command type not recognized: <nil>"
+)
+
+// IsUnsupportedCommand returns true if the message of a prover error reports
+// a command of a type the prover does not know.
+func IsUnsupportedCommand(message string) bool {
+	return strings.HasPrefix(message, UnsupportedCommandMessage) || message == legacyUnsupportedCommandMessage
+}
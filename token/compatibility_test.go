@@ -0,0 +1,21 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token_test
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsUnsupportedCommand(t *testing.T) {
+	assert.True(t, token.IsUnsupportedCommand(token.UnsupportedCommandMessage+" (peer version 2.0.0)"))
+	assert.True(t, token.IsUnsupportedCommand("command type not recognized: <nil>"))
+	assert.False(t, token.IsUnsupportedCommand("command type not recognized: *token.Command_ImportRequest"))
+	assert.False(t, token.IsUnsupportedCommand("FabToken capability not enabled for channel testchannel"))
+}
@@ -0,0 +1,52 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// CorrelationIDMetadataKey is the gRPC metadata key carrying the correlation ID
+// of the requests sent to the prover, before a transaction ID exists.
+const CorrelationIDMetadataKey = "correlation-id"
+
+type correlationIDKey struct{}
+
+// NewCorrelationID returns a random correlation ID.
+func NewCorrelationID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// WithCorrelationID returns a context carrying the correlation ID, which is
+// also sent along with the gRPC requests made with the context.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	ctx = context.WithValue(ctx, correlationIDKey{}, id)
+	return metadata.AppendToOutgoingContext(ctx, CorrelationIDMetadataKey, id)
+}
+
+// CorrelationID returns the correlation ID of the context, or the correlation
+// ID received with the gRPC request the context belongs to. The empty string
+// is returned if there is none.
+func CorrelationID(ctx context.Context) string {
+	if id, ok := ctx.Value(correlationIDKey{}).(string); ok {
+		return id
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if ids := md.Get(CorrelationIDMetadataKey); len(ids) > 0 {
+		return ids[0]
+	}
+	return ""
+}
@@ -0,0 +1,33 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/fabric/token"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestCorrelationID(t *testing.T) {
+	assert.Equal(t, "", token.CorrelationID(context.Background()))
+
+	id := token.NewCorrelationID()
+	assert.Len(t, id, 32)
+	assert.NotEqual(t, id, token.NewCorrelationID())
+
+	ctx := token.WithCorrelationID(context.Background(), id)
+	assert.Equal(t, id, token.CorrelationID(ctx))
+	md, ok := metadata.FromOutgoingContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, []string{id}, md.Get(token.CorrelationIDMetadataKey))
+
+	incoming := metadata.NewIncomingContext(context.Background(), metadata.Pairs(token.CorrelationIDMetadataKey, "remote-id"))
+	assert.Equal(t, "remote-id", token.CorrelationID(incoming))
+}
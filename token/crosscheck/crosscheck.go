@@ -0,0 +1,212 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package crosscheck compares the token ledgers of two peers of a channel,
+// to track down the nondeterminism that makes peers diverge.
+package crosscheck
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("token.crosscheck")
+
+// BlockIteratorProvider provides the blocks committed by a peer, like
+// peerblocks.Provider.
+type BlockIteratorProvider interface {
+	GetBlocksIterator(startBlockNumber uint64) (ledger.ResultsIterator, error)
+}
+
+// A Peer is a peer whose token ledger is cross-checked.
+type Peer struct {
+	// Name identifies the peer in the reports, e.g. its address
+	Name   string
+	Blocks BlockIteratorProvider
+}
+
+// A TxDivergence is a transaction the two peers validated differently.
+type TxDivergence struct {
+	Index int
+	TxID  string
+	CodeA pb.TxValidationCode
+	CodeB pb.TxValidationCode
+}
+
+// A BucketDivergence is a bucket whose unspent outputs add up to different
+// quantities on the two peers.
+type BucketDivergence struct {
+	Bucket
+	QuantityA uint64
+	QuantityB uint64
+}
+
+// A Divergence describes the first block on which the ledgers of two peers
+// differ.
+type Divergence struct {
+	Block uint64
+	// HeaderDiffers is true when the peers committed different blocks, in
+	// which case the transactions are not compared
+	HeaderDiffers bool
+	Transactions  []TxDivergence
+	Buckets       []BucketDivergence
+}
+
+// String returns a report of the divergence naming the peers a and b.
+func (d *Divergence) String() string {
+	return d.report("A", "B")
+}
+
+func (d *Divergence) report(a, b string) string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "ledgers of %s and %s diverge at block %d\n", a, b, d.Block)
+	if d.HeaderDiffers {
+		fmt.Fprintf(buf, "  block headers differ\n")
+	}
+	for _, tx := range d.Transactions {
+		fmt.Fprintf(buf, "  transaction %d [%s]: %s on %s, %s on %s\n", tx.Index, tx.TxID, tx.CodeA, a, tx.CodeB, b)
+	}
+	for _, bucket := range d.Buckets {
+		fmt.Fprintf(buf, "  owner %s type %s: %d on %s, %d on %s\n", bucket.Owner, bucket.Type, bucket.QuantityA, a, bucket.QuantityB, b)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// Checker replays the token transactions committed by two peers of a channel
+// block by block, from the genesis block, and compares the blocks, their
+// validation codes and the resulting balances of every owner and token type.
+type Checker struct {
+	A Peer
+	B Peer
+}
+
+// Check compares the ledgers of the peers up to block last included, and
+// returns the first divergence, or nil if the ledgers match. Both peers must
+// have committed block last.
+func (c *Checker) Check(last uint64) (*Divergence, error) {
+	itA, err := c.A.Blocks.GetBlocksIterator(0)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed reading blocks of "+c.A.Name)
+	}
+	defer itA.Close()
+	itB, err := c.B.Blocks.GetBlocksIterator(0)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed reading blocks of "+c.B.Name)
+	}
+	defer itB.Close()
+
+	ledgerA, ledgerB := &Ledger{}, &Ledger{}
+	for number := uint64(0); number <= last; number++ {
+		blockA, err := nextBlock(itA, c.A.Name, number)
+		if err != nil {
+			return nil, err
+		}
+		blockB, err := nextBlock(itB, c.B.Name, number)
+		if err != nil {
+			return nil, err
+		}
+
+		if !proto.Equal(blockA.Header, blockB.Header) {
+			return &Divergence{Block: number, HeaderDiffers: true}, nil
+		}
+		if err := ledgerA.Apply(blockA); err != nil {
+			return nil, errors.WithMessage(err, "failed replaying block of "+c.A.Name)
+		}
+		if err := ledgerB.Apply(blockB); err != nil {
+			return nil, errors.WithMessage(err, "failed replaying block of "+c.B.Name)
+		}
+
+		txs := compareValidation(blockA, blockB)
+		if len(txs) != 0 || !bytes.Equal(ledgerA.Hash(), ledgerB.Hash()) {
+			return &Divergence{
+				Block:        number,
+				Transactions: txs,
+				Buckets:      compareBuckets(ledgerA.Buckets(), ledgerB.Buckets()),
+			}, nil
+		}
+		logger.Debugf("block %d matches", number)
+	}
+	return nil, nil
+}
+
+// Report returns a report of the divergence naming the peers of the checker.
+func (c *Checker) Report(d *Divergence) string {
+	return d.report(c.A.Name, c.B.Name)
+}
+
+func nextBlock(it ledger.ResultsIterator, name string, number uint64) (*cb.Block, error) {
+	result, err := it.Next()
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed reading block %d of %s", number, name))
+	}
+	block, ok := result.(*cb.Block)
+	if !ok || block == nil {
+		return nil, errors.Errorf("blocks of %s ended before block %d", name, number)
+	}
+	if block.GetHeader().GetNumber() != number {
+		return nil, errors.Errorf("%s returned block %d instead of %d", name, block.GetHeader().GetNumber(), number)
+	}
+	return block, nil
+}
+
+// compareValidation returns the transactions of the blocks, which have the
+// same header, with different validation codes.
+func compareValidation(a, b *cb.Block) []TxDivergence {
+	flagsA, flagsB := validationFlags(a), validationFlags(b)
+	var txs []TxDivergence
+	for i, data := range a.GetData().GetData() {
+		codeA, codeB := pb.TxValidationCode_VALID, pb.TxValidationCode_VALID
+		if len(flagsA) > i {
+			codeA = flagsA.Flag(i)
+		}
+		if len(flagsB) > i {
+			codeB = flagsB.Flag(i)
+		}
+		if codeA == codeB {
+			continue
+		}
+		tx := TxDivergence{Index: i, CodeA: codeA, CodeB: codeB}
+		if env, err := utils.GetEnvelopeFromBlock(data); err == nil {
+			if chdr, err := utils.ChannelHeader(env); err == nil {
+				tx.TxID = chdr.TxId
+			}
+		}
+		txs = append(txs, tx)
+	}
+	return txs
+}
+
+// compareBuckets returns the buckets with different quantities, sorted by
+// owner and type.
+func compareBuckets(a, b map[Bucket]uint64) []BucketDivergence {
+	var keys []Bucket
+	for bucket, quantity := range a {
+		if b[bucket] != quantity {
+			keys = append(keys, bucket)
+		}
+	}
+	for bucket := range b {
+		if _, ok := a[bucket]; !ok {
+			keys = append(keys, bucket)
+		}
+	}
+	sortBuckets(keys)
+
+	var buckets []BucketDivergence
+	for _, bucket := range keys {
+		buckets = append(buckets, BucketDivergence{Bucket: bucket, QuantityA: a[bucket], QuantityB: b[bucket]})
+	}
+	return buckets
+}
@@ -0,0 +1,107 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package crosscheck_test
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestCrosscheck(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Crosscheck Suite")
+}
+
+func ProtoMarshal(m proto.Message) []byte {
+	bytes, err := proto.Marshal(m)
+	Expect(err).NotTo(HaveOccurred())
+
+	return bytes
+}
+
+func newEnvelope(txID string, action *token.PlainTokenAction) *cb.Envelope {
+	chdr := &cb.ChannelHeader{
+		Type:      int32(cb.HeaderType_TOKEN_TRANSACTION),
+		ChannelId: "testchannel",
+		TxId:      txID,
+	}
+	payload := &cb.Payload{
+		Header: &cb.Header{ChannelHeader: ProtoMarshal(chdr)},
+		Data:   ProtoMarshal(&token.TokenTransaction{Action: &token.TokenTransaction_PlainAction{PlainAction: action}}),
+	}
+	return &cb.Envelope{Payload: ProtoMarshal(payload)}
+}
+
+func newBlock(number uint64, envelopes ...*cb.Envelope) *cb.Block {
+	block := &cb.Block{
+		Header:   &cb.BlockHeader{Number: number},
+		Data:     &cb.BlockData{},
+		Metadata: &cb.BlockMetadata{Metadata: make([][]byte, len(cb.BlockMetadataIndex_name))},
+	}
+	for _, env := range envelopes {
+		block.Data.Data = append(block.Data.Data, ProtoMarshal(env))
+	}
+	block.Header.DataHash = block.Data.Hash()
+	block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER] = util.NewTxValidationFlagsSetValue(len(envelopes), pb.TxValidationCode_VALID)
+	return block
+}
+
+func importAction(owner, tokenType string, quantity uint64) *token.PlainTokenAction {
+	return &token.PlainTokenAction{
+		Data: &token.PlainTokenAction_PlainImport{
+			PlainImport: &token.PlainImport{
+				Outputs: []*token.PlainOutput{{Owner: []byte(owner), Type: tokenType, Quantity: quantity}},
+			},
+		},
+	}
+}
+
+func transferAction(txID string, index uint32, outputs ...*token.PlainOutput) *token.PlainTokenAction {
+	return &token.PlainTokenAction{
+		Data: &token.PlainTokenAction_PlainTransfer{
+			PlainTransfer: &token.PlainTransfer{
+				Inputs:  []*token.InputId{{TxId: txID, Index: index}},
+				Outputs: outputs,
+			},
+		},
+	}
+}
+
+// blocks provides a copy of the blocks, so that tests can change the blocks
+// of a peer without changing the ones of the other.
+type blocks []*cb.Block
+
+func (b blocks) GetBlocksIterator(start uint64) (ledger.ResultsIterator, error) {
+	it := &blockIterator{}
+	for _, block := range b[start:] {
+		it.blocks = append(it.blocks, proto.Clone(block).(*cb.Block))
+	}
+	return it, nil
+}
+
+type blockIterator struct {
+	blocks []*cb.Block
+}
+
+func (b *blockIterator) Next() (ledger.QueryResult, error) {
+	if len(b.blocks) == 0 {
+		return nil, nil
+	}
+	block := b.blocks[0]
+	b.blocks = b.blocks[1:]
+	return block, nil
+}
+
+func (b *blockIterator) Close() {}
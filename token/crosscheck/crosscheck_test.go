@@ -0,0 +1,110 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package crosscheck_test
+
+import (
+	"encoding/hex"
+
+	"github.com/hyperledger/fabric/core/ledger/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/crosscheck"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Checker", func() {
+	var (
+		blocksA blocks
+		blocksB blocks
+		checker *crosscheck.Checker
+		alice   string
+		bob     string
+	)
+
+	BeforeEach(func() {
+		alice, bob = hex.EncodeToString([]byte("alice")), hex.EncodeToString([]byte("bob"))
+		blocksA = blocks{
+			newBlock(0),
+			newBlock(1, newEnvelope("tx1", importAction("alice", "USD", 100))),
+			newBlock(2, newEnvelope("tx2", transferAction("tx1", 0,
+				&token.PlainOutput{Owner: []byte("bob"), Type: "USD", Quantity: 30},
+				&token.PlainOutput{Owner: []byte("alice"), Type: "USD", Quantity: 70},
+			))),
+			newBlock(3, newEnvelope("tx3", importAction("bob", "EUR", 5))),
+		}
+		blocksB = append(blocks{}, blocksA...)
+		checker = &crosscheck.Checker{
+			A: crosscheck.Peer{Name: "peer0", Blocks: blocksA},
+			B: crosscheck.Peer{Name: "peer1", Blocks: blocksB},
+		}
+	})
+
+	It("reports no divergence when the ledgers match", func() {
+		d, err := checker.Check(3)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(d).To(BeNil())
+	})
+
+	Context("when a peer validated a transaction differently", func() {
+		BeforeEach(func() {
+			invalid := newBlock(2, newEnvelope("tx2", transferAction("tx1", 0,
+				&token.PlainOutput{Owner: []byte("bob"), Type: "USD", Quantity: 30},
+				&token.PlainOutput{Owner: []byte("alice"), Type: "USD", Quantity: 70},
+			)))
+			invalid.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER] = util.NewTxValidationFlagsSetValue(1, pb.TxValidationCode_MVCC_READ_CONFLICT)
+			blocksB[2] = invalid
+			checker.B.Blocks = blocksB
+		})
+
+		It("reports the first differing block", func() {
+			d, err := checker.Check(3)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(d).To(Equal(&crosscheck.Divergence{
+				Block: 2,
+				Transactions: []crosscheck.TxDivergence{
+					{Index: 0, TxID: "tx2", CodeA: pb.TxValidationCode_VALID, CodeB: pb.TxValidationCode_MVCC_READ_CONFLICT},
+				},
+				Buckets: []crosscheck.BucketDivergence{
+					{Bucket: crosscheck.Bucket{Owner: alice, Type: "USD"}, QuantityA: 70, QuantityB: 100},
+					{Bucket: crosscheck.Bucket{Owner: bob, Type: "USD"}, QuantityA: 30, QuantityB: 0},
+				},
+			}))
+			Expect(checker.Report(d)).To(Equal(
+				"ledgers of peer0 and peer1 diverge at block 2\n" +
+					"  transaction 0 [tx2]: VALID on peer0, MVCC_READ_CONFLICT on peer1\n" +
+					"  owner " + alice + " type USD: 70 on peer0, 100 on peer1\n" +
+					"  owner " + bob + " type USD: 30 on peer0, 0 on peer1",
+			))
+		})
+	})
+
+	Context("when the peers committed different blocks", func() {
+		BeforeEach(func() {
+			blocksB[1] = newBlock(1, newEnvelope("tx1", importAction("alice", "USD", 101)))
+			checker.B.Blocks = blocksB
+		})
+
+		It("reports the block", func() {
+			d, err := checker.Check(3)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(d).To(Equal(&crosscheck.Divergence{Block: 1, HeaderDiffers: true}))
+		})
+	})
+
+	Context("when a peer has not committed the last block", func() {
+		BeforeEach(func() {
+			checker.B.Blocks = blocksB[:3]
+		})
+
+		It("returns an error", func() {
+			_, err := checker.Check(3)
+			Expect(err).To(MatchError("blocks of peer1 ended before block 3"))
+		})
+	})
+})
@@ -0,0 +1,180 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package crosscheck
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"sort"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// A Bucket groups the unspent outputs of a token type owned by an owner. The
+// outputs redeemed have no owner.
+type Bucket struct {
+	// Owner is the hex encoded owner
+	Owner string
+	Type  string
+}
+
+type output struct {
+	bucket   Bucket
+	quantity uint64
+}
+
+// Ledger replays the valid plain token transactions of the blocks committed
+// by a peer into the unspent outputs of the token namespace of the channel,
+// like the plain token verifier does on commit. Delegated outputs are not
+// tracked because they do not contribute to the balance of their owner.
+type Ledger struct {
+	outputs map[string]*output
+}
+
+// Apply applies the valid token transactions of the block.
+func (l *Ledger) Apply(block *cb.Block) error {
+	if l.outputs == nil {
+		l.outputs = map[string]*output{}
+	}
+
+	flags := validationFlags(block)
+	for i, data := range block.GetData().GetData() {
+		if len(flags) > i && flags.Flag(i) != pb.TxValidationCode_VALID {
+			continue
+		}
+		txID, action, err := plainAction(data)
+		if err != nil {
+			return errors.WithMessage(err, "invalid transaction "+strconv.Itoa(i)+" of block "+strconv.FormatUint(block.GetHeader().GetNumber(), 10))
+		}
+		if action != nil {
+			l.apply(txID, action)
+		}
+	}
+	return nil
+}
+
+func (l *Ledger) apply(txID string, action *token.PlainTokenAction) {
+	var (
+		inputs  []*token.InputId
+		outputs []*token.PlainOutput
+	)
+	switch a := action.Data.(type) {
+	case *token.PlainTokenAction_PlainImport:
+		outputs = a.PlainImport.GetOutputs()
+	case *token.PlainTokenAction_PlainTransfer:
+		inputs, outputs = a.PlainTransfer.GetInputs(), a.PlainTransfer.GetOutputs()
+	case *token.PlainTokenAction_PlainRedeem:
+		inputs, outputs = a.PlainRedeem.GetInputs(), a.PlainRedeem.GetOutputs()
+	case *token.PlainTokenAction_PlainApprove:
+		inputs = a.PlainApprove.GetInputs()
+		if a.PlainApprove.GetOutput() != nil {
+			outputs = []*token.PlainOutput{a.PlainApprove.GetOutput()}
+		}
+	case *token.PlainTokenAction_PlainTransfer_From:
+		inputs, outputs = a.PlainTransfer_From.GetInputs(), a.PlainTransfer_From.GetOutputs()
+	}
+
+	for _, input := range inputs {
+		delete(l.outputs, outputKey(input.TxId, int(input.Index)))
+	}
+	for i, o := range outputs {
+		l.outputs[outputKey(txID, i)] = &output{
+			bucket:   Bucket{Owner: hex.EncodeToString(o.Owner), Type: o.Type},
+			quantity: o.Quantity,
+		}
+	}
+}
+
+// Buckets returns the total quantity of the unspent outputs of every bucket.
+func (l *Ledger) Buckets() map[Bucket]uint64 {
+	buckets := map[Bucket]uint64{}
+	for _, o := range l.outputs {
+		buckets[o.bucket] += o.quantity
+	}
+	return buckets
+}
+
+// Hash returns the hash of the buckets, which is the same for two ledgers
+// with the same buckets.
+func (l *Ledger) Hash() []byte {
+	buckets := l.Buckets()
+	keys := make([]Bucket, 0, len(buckets))
+	for b := range buckets {
+		keys = append(keys, b)
+	}
+	sortBuckets(keys)
+
+	h := sha256.New()
+	var quantity [8]byte
+	for _, b := range keys {
+		h.Write([]byte(b.Owner))
+		h.Write([]byte{0})
+		h.Write([]byte(b.Type))
+		h.Write([]byte{0})
+		binary.BigEndian.PutUint64(quantity[:], buckets[b])
+		h.Write(quantity[:])
+	}
+	return h.Sum(nil)
+}
+
+func sortBuckets(buckets []Bucket) {
+	sort.Slice(buckets, func(i, j int) bool {
+		if buckets[i].Owner != buckets[j].Owner {
+			return buckets[i].Owner < buckets[j].Owner
+		}
+		return buckets[i].Type < buckets[j].Type
+	})
+}
+
+func outputKey(txID string, index int) string {
+	return txID + "\x00" + strconv.Itoa(index)
+}
+
+func validationFlags(block *cb.Block) util.TxValidationFlags {
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(cb.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		return util.TxValidationFlags(block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+	return nil
+}
+
+// plainAction returns the ID and the plain token action of a transaction, or
+// a nil action if it is not a plain token transaction.
+func plainAction(data []byte) (string, *token.PlainTokenAction, error) {
+	env, err := utils.GetEnvelopeFromBlock(data)
+	if err != nil {
+		return "", nil, errors.WithMessage(err, "failed getting envelope")
+	}
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return "", nil, err
+	}
+	if payload.Header == nil {
+		return "", nil, errors.New("missing header")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return "", nil, err
+	}
+	if cb.HeaderType(chdr.Type) != cb.HeaderType_TOKEN_TRANSACTION {
+		return chdr.TxId, nil, nil
+	}
+
+	ttx := &token.TokenTransaction{}
+	err = proto.Unmarshal(payload.Data, ttx)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed unmarshaling transaction [%s]", chdr.TxId)
+	}
+	return chdr.TxId, ttx.GetPlainAction(), nil
+}
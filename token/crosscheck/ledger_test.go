@@ -0,0 +1,61 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package crosscheck_test
+
+import (
+	"encoding/hex"
+
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/crosscheck"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Ledger", func() {
+	var l *crosscheck.Ledger
+
+	BeforeEach(func() {
+		l = &crosscheck.Ledger{}
+		err := l.Apply(newBlock(1,
+			newEnvelope("tx1", importAction("alice", "USD", 100)),
+			newEnvelope("tx2", importAction("alice", "USD", 20)),
+		))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("adds up the unspent outputs of every owner and type", func() {
+		err := l.Apply(newBlock(2, newEnvelope("tx3", transferAction("tx1", 0,
+			&token.PlainOutput{Owner: []byte("bob"), Type: "USD", Quantity: 100},
+		))))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(l.Buckets()).To(Equal(map[crosscheck.Bucket]uint64{
+			{Owner: hex.EncodeToString([]byte("alice")), Type: "USD"}: 20,
+			{Owner: hex.EncodeToString([]byte("bob")), Type: "USD"}:   100,
+		}))
+	})
+
+	It("hashes the buckets", func() {
+		other := &crosscheck.Ledger{}
+		err := other.Apply(newBlock(1, newEnvelope("tx4", importAction("alice", "USD", 120))))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(other.Hash()).To(Equal(l.Hash()))
+
+		err = other.Apply(newBlock(2, newEnvelope("tx5", importAction("alice", "EUR", 1))))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(other.Hash()).NotTo(Equal(l.Hash()))
+	})
+
+	Context("when a transaction is malformed", func() {
+		It("returns an error", func() {
+			block := newBlock(3)
+			block.Data.Data = [][]byte{[]byte("garbage")}
+			err := l.Apply(block)
+			Expect(err).To(MatchError(ContainSubstring("invalid transaction 0 of block 3")))
+		})
+	})
+})
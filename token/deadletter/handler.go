@@ -0,0 +1,112 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deadletter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+)
+
+// Handler serves the invalid token transactions retained for the channels the
+// peer has joined. GET requests return the entries of every channel, or of
+// the channel passed in the channel query parameter, indexed by channel name.
+// When the txid query parameter is passed too, the entry of the transaction
+// is returned alone.
+type Handler struct {
+	// Provider returns the provider of the stores, or nil if the invalid
+	// token transactions are not retained
+	Provider func() *Provider
+	// Channels returns the names of the channels the peer has joined
+	Channels func() []string
+	Logger   *flogging.FabricLogger
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (h *Handler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		h.sendResponse(resp, http.StatusBadRequest, fmt.Errorf("invalid request method: %s", req.Method))
+		return
+	}
+	provider := h.Provider()
+	if provider == nil {
+		h.sendResponse(resp, http.StatusNotFound, errors.New("invalid token transactions are not retained"))
+		return
+	}
+
+	channels := h.Channels()
+	channel := req.URL.Query().Get("channel")
+	if channel != "" {
+		if !contains(channels, channel) {
+			h.sendResponse(resp, http.StatusNotFound, errors.Errorf("channel %s not found", channel))
+			return
+		}
+		channels = []string{channel}
+	}
+
+	if txID := req.URL.Query().Get("txid"); txID != "" {
+		if channel == "" {
+			h.sendResponse(resp, http.StatusBadRequest, errors.New("the channel of the transaction is required"))
+			return
+		}
+		h.sendEntry(resp, provider.OpenStore(channel), channel, txID)
+		return
+	}
+
+	entries := map[string][]*Entry{}
+	for _, channel := range channels {
+		e, err := provider.OpenStore(channel).List()
+		if err != nil {
+			h.Logger.Errorf("failed listing invalid token transactions of channel %s: %s", channel, err)
+			h.sendResponse(resp, http.StatusInternalServerError, errors.WithMessage(err, fmt.Sprintf("failed listing invalid token transactions of channel %s", channel)))
+			return
+		}
+		entries[channel] = e
+	}
+	h.sendResponse(resp, http.StatusOK, entries)
+}
+
+func (h *Handler) sendEntry(resp http.ResponseWriter, store *Store, channel, txID string) {
+	entry, err := store.Get(txID)
+	if err != nil {
+		h.Logger.Errorf("failed reading invalid token transaction %s of channel %s: %s", txID, channel, err)
+		h.sendResponse(resp, http.StatusInternalServerError, errors.WithMessage(err, fmt.Sprintf("failed reading invalid token transaction %s of channel %s", txID, channel)))
+		return
+	}
+	if entry == nil {
+		h.sendResponse(resp, http.StatusNotFound, errors.Errorf("no invalid token transaction %s retained for channel %s", txID, channel))
+		return
+	}
+	h.sendResponse(resp, http.StatusOK, entry)
+}
+
+func (h *Handler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	if err, ok := payload.(error); ok {
+		payload = &errorResponse{Error: err.Error()}
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+	if err := json.NewEncoder(resp).Encode(payload); err != nil {
+		h.Logger.Errorw("failed to encode payload", "error", err)
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deadletter
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	provider, cleanup := newTestProvider(t, 10)
+	defer cleanup()
+	require.NoError(t, provider.OpenStore("channel-1").Put(&Entry{TxID: "tx1", Reason: "verification", Details: "output already exists"}))
+
+	handler := &Handler{
+		Provider: func() *Provider { return provider },
+		Channels: func() []string { return []string{"channel-1", "channel-2"} },
+		Logger:   flogging.MustGetLogger("test"),
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		code     int
+		response string
+	}{
+		{"all channels", http.MethodGet, "/token/deadletters", http.StatusOK,
+			`{"channel-1":[{"tx_id":"tx1","reason":"verification","details":"output already exists","timestamp":"0001-01-01T00:00:00Z","recorded_at":"0001-01-01T00:00:00Z"}],"channel-2":[]}`},
+		{"one channel", http.MethodGet, "/token/deadletters?channel=channel-2", http.StatusOK, `{"channel-2":[]}`},
+		{"one transaction", http.MethodGet, "/token/deadletters?channel=channel-1&txid=tx1", http.StatusOK,
+			`{"tx_id":"tx1","reason":"verification","details":"output already exists","timestamp":"0001-01-01T00:00:00Z","recorded_at":"0001-01-01T00:00:00Z"}`},
+		{"unknown transaction", http.MethodGet, "/token/deadletters?channel=channel-1&txid=tx2", http.StatusNotFound,
+			`{"error":"no invalid token transaction tx2 retained for channel channel-1"}`},
+		{"transaction without channel", http.MethodGet, "/token/deadletters?txid=tx1", http.StatusBadRequest,
+			`{"error":"the channel of the transaction is required"}`},
+		{"unknown channel", http.MethodGet, "/token/deadletters?channel=channel-3", http.StatusNotFound,
+			`{"error":"channel channel-3 not found"}`},
+		{"invalid method", http.MethodPost, "/token/deadletters", http.StatusBadRequest,
+			`{"error":"invalid request method: POST"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.target, nil))
+			assert.Equal(t, tt.code, recorder.Code)
+			assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			assert.JSONEq(t, tt.response, recorder.Body.String())
+		})
+	}
+
+	t.Run("not retained", func(t *testing.T) {
+		handler.Provider = func() *Provider { return nil }
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/token/deadletters", nil))
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		resp := map[string]string{}
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+		assert.Equal(t, "invalid token transactions are not retained", resp["error"])
+	})
+}
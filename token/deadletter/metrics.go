@@ -0,0 +1,37 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deadletter
+
+import (
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+var invalidTransactions = metrics.CounterOpts{
+	Namespace:    "token",
+	Name:         "invalid_transactions",
+	Help:         "The number of committed token transactions found invalid, by reason.",
+	LabelNames:   []string{"channel", "reason"},
+	StatsdFormat: "%{#fqname}.%{channel}.%{reason}",
+}
+
+// Metrics are the metrics of the invalid token transactions.
+type Metrics struct {
+	InvalidTransactions metrics.Counter
+}
+
+func NewMetrics(p metrics.Provider) *Metrics {
+	return &Metrics{
+		InvalidTransactions: p.NewCounter(invalidTransactions),
+	}
+}
+
+func (m *Metrics) invalidated(channel, reason string) {
+	if m == nil {
+		return
+	}
+	m.InvalidTransactions.With("channel", channel, "reason", reason).Add(1)
+}
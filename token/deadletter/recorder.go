@@ -0,0 +1,52 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deadletter
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/protos/common"
+)
+
+// Recorder counts the invalid token transactions of the channels in the
+// Metrics and, if it has a Provider, records them in its stores. Failing to
+// record a transaction is logged; it does not fail the commit of its block.
+type Recorder struct {
+	// Provider is the provider of the stores; without it, the invalid
+	// transactions are only counted
+	Provider *Provider
+	Metrics  *Metrics
+	Logger   *flogging.FabricLogger
+}
+
+func (r *Recorder) RecordInvalidTx(ch *common.ChannelHeader, reason string, txEnv *common.Envelope, err error) {
+	r.Metrics.invalidated(ch.ChannelId, reason)
+	if r.Provider == nil {
+		return
+	}
+
+	entry := &Entry{
+		TxID:       ch.TxId,
+		Reason:     reason,
+		Details:    err.Error(),
+		RecordedAt: time.Now(),
+	}
+	if ch.Timestamp != nil {
+		// a malformed timestamp is left out; the envelope retains it
+		entry.Timestamp, _ = ptypes.Timestamp(ch.Timestamp)
+	}
+	entry.Envelope, err = proto.Marshal(txEnv)
+	if err != nil {
+		r.Logger.Warningf("failed marshaling invalid token transaction %s of channel %s: %s", ch.TxId, ch.ChannelId, err)
+	}
+	if err := r.Provider.OpenStore(ch.ChannelId).Put(entry); err != nil {
+		r.Logger.Errorf("failed recording invalid token transaction %s of channel %s: %s", ch.TxId, ch.ChannelId, err)
+	}
+}
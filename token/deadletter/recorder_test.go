@@ -0,0 +1,59 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deadletter
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	provider, cleanup := newTestProvider(t, 10)
+	defer cleanup()
+
+	fakeCounter := &metricsfakes.Counter{}
+	fakeCounter.WithReturns(fakeCounter)
+	fakeProvider := &metricsfakes.Provider{}
+	fakeProvider.NewCounterReturns(fakeCounter)
+	recorder := &Recorder{
+		Provider: provider,
+		Metrics:  NewMetrics(fakeProvider),
+		Logger:   flogging.MustGetLogger("test"),
+	}
+
+	timestamp := time.Date(2018, 11, 1, 10, 30, 0, 0, time.UTC)
+	ts, err := ptypes.TimestampProto(timestamp)
+	require.NoError(t, err)
+	txEnv := &common.Envelope{Payload: []byte("payload"), Signature: []byte("signature")}
+	recorder.RecordInvalidTx(&common.ChannelHeader{ChannelId: "channel-1", TxId: "tx1", Timestamp: ts}, "verification", txEnv, errors.New("output already exists"))
+
+	assert.Equal(t, 1, fakeCounter.WithCallCount())
+	assert.Equal(t, []string{"channel", "channel-1", "reason", "verification"}, fakeCounter.WithArgsForCall(0))
+	assert.Equal(t, 1, fakeCounter.AddCallCount())
+	assert.Equal(t, float64(1), fakeCounter.AddArgsForCall(0))
+
+	entry, err := provider.OpenStore("channel-1").Get("tx1")
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, "tx1", entry.TxID)
+	assert.Equal(t, "verification", entry.Reason)
+	assert.Equal(t, "output already exists", entry.Details)
+	assert.True(t, entry.Timestamp.Equal(timestamp))
+	assert.False(t, entry.RecordedAt.IsZero())
+	env := &common.Envelope{}
+	require.NoError(t, proto.Unmarshal(entry.Envelope, env))
+	assert.True(t, proto.Equal(txEnv, env))
+}
@@ -0,0 +1,197 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deadletter
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/pkg/errors"
+)
+
+var (
+	nextSeqKey   = []byte{'n'}
+	entryPrefix  = []byte{'e'}
+	txIDPrefix   = []byte{'t'}
+	entryRangeTo = []byte{'e' + 1}
+)
+
+// Entry is a token transaction committed to a channel and found invalid.
+type Entry struct {
+	// TxID is the ID of the transaction
+	TxID string `json:"tx_id"`
+	// Reason is the stage of the processing which invalidated the transaction
+	Reason string `json:"reason"`
+	// Details is the error which invalidated the transaction
+	Details string `json:"details"`
+	// Timestamp is the timestamp of the channel header of the transaction
+	Timestamp time.Time `json:"timestamp,omitempty"`
+	// RecordedAt is the time the transaction was found invalid
+	RecordedAt time.Time `json:"recorded_at"`
+	// Envelope is the serialized envelope of the transaction
+	Envelope []byte `json:"envelope,omitempty"`
+}
+
+// Provider provides the deadletter stores of the channels, kept in a single
+// leveldb.
+type Provider struct {
+	// MaxEntries is the number of entries retained by the store of each
+	// channel; the oldest entries are dropped beyond it
+	MaxEntries int
+
+	dbProvider *leveldbhelper.Provider
+	mutex      sync.Mutex
+	stores     map[string]*Store
+}
+
+// NewProvider returns a provider of deadletter stores kept in the leveldb at
+// the path.
+func NewProvider(path string, maxEntries int) *Provider {
+	return &Provider{
+		MaxEntries: maxEntries,
+		dbProvider: leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: path}),
+		stores:     map[string]*Store{},
+	}
+}
+
+// OpenStore returns the deadletter store of the channel.
+func (p *Provider) OpenStore(channel string) *Store {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	s, ok := p.stores[channel]
+	if !ok {
+		s = &Store{db: p.dbProvider.GetDBHandle(channel), maxEntries: p.MaxEntries}
+		p.stores[channel] = s
+	}
+	return s
+}
+
+// Close closes the leveldb of the provider.
+func (p *Provider) Close() {
+	p.dbProvider.Close()
+}
+
+// Store keeps the most recent invalid token transactions of a channel. The
+// entries are kept in the order they are recorded, under a sequence number,
+// and indexed by transaction ID.
+type Store struct {
+	db         *leveldbhelper.DBHandle
+	maxEntries int
+	mutex      sync.Mutex
+}
+
+// Put records the entry, unless an entry for the transaction is already
+// recorded, as happens when a block is committed again after a crash.
+func (s *Store) Put(entry *Entry) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	existing, err := s.db.Get(txIDKey(entry.TxID))
+	if err != nil {
+		return errors.Wrap(err, "failed reading the deadletter index")
+	}
+	if existing != nil {
+		return nil
+	}
+
+	next, err := s.nextSeq()
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling deadletter entry")
+	}
+
+	batch := leveldbhelper.NewUpdateBatch()
+	batch.Put(entryKey(next), raw)
+	batch.Put(txIDKey(entry.TxID), encodeSeq(next))
+	batch.Put(nextSeqKey, encodeSeq(next+1))
+	if s.maxEntries > 0 && next >= uint64(s.maxEntries) {
+		evicted, err := s.get(next - uint64(s.maxEntries))
+		if err != nil {
+			return err
+		}
+		if evicted != nil {
+			batch.Delete(entryKey(next - uint64(s.maxEntries)))
+			batch.Delete(txIDKey(evicted.TxID))
+		}
+	}
+	return errors.Wrap(s.db.WriteBatch(batch, true), "failed writing deadletter entry")
+}
+
+// Get returns the entry of the transaction, or nil if none is retained.
+func (s *Store) Get(txID string) (*Entry, error) {
+	seq, err := s.db.Get(txIDKey(txID))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed reading the deadletter index")
+	}
+	if seq == nil {
+		return nil, nil
+	}
+	return s.get(binary.BigEndian.Uint64(seq))
+}
+
+// List returns the retained entries, oldest first.
+func (s *Store) List() ([]*Entry, error) {
+	itr := s.db.GetIterator(entryPrefix, entryRangeTo)
+	defer itr.Release()
+
+	entries := []*Entry{}
+	for itr.Next() {
+		entry := &Entry{}
+		if err := json.Unmarshal(itr.Value(), entry); err != nil {
+			return nil, errors.Wrap(err, "failed unmarshaling deadletter entry")
+		}
+		entries = append(entries, entry)
+	}
+	return entries, errors.Wrap(itr.Error(), "failed iterating deadletter entries")
+}
+
+func (s *Store) get(seq uint64) (*Entry, error) {
+	raw, err := s.db.Get(entryKey(seq))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed reading deadletter entry")
+	}
+	if raw == nil {
+		return nil, nil
+	}
+	entry := &Entry{}
+	if err := json.Unmarshal(raw, entry); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling deadletter entry")
+	}
+	return entry, nil
+}
+
+func (s *Store) nextSeq() (uint64, error) {
+	raw, err := s.db.Get(nextSeqKey)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed reading the next deadletter sequence number")
+	}
+	if raw == nil {
+		return 0, nil
+	}
+	return binary.BigEndian.Uint64(raw), nil
+}
+
+func encodeSeq(seq uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, seq)
+	return b
+}
+
+func entryKey(seq uint64) []byte {
+	return append(append([]byte{}, entryPrefix...), encodeSeq(seq)...)
+}
+
+func txIDKey(txID string) []byte {
+	return append(append([]byte{}, txIDPrefix...), txID...)
+}
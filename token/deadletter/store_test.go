@@ -0,0 +1,77 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deadletter
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProvider(t *testing.T, maxEntries int) (*Provider, func()) {
+	path, err := ioutil.TempDir("", "deadletter")
+	require.NoError(t, err)
+	provider := NewProvider(path, maxEntries)
+	return provider, func() {
+		provider.Close()
+		os.RemoveAll(path)
+	}
+}
+
+func TestStore(t *testing.T) {
+	provider, cleanup := newTestProvider(t, 3)
+	defer cleanup()
+
+	store := provider.OpenStore("channel-1")
+	assert.True(t, store == provider.OpenStore("channel-1"))
+	for i := 0; i < 5; i++ {
+		err := store.Put(&Entry{TxID: fmt.Sprintf("tx%d", i), Reason: "verification"})
+		require.NoError(t, err)
+	}
+	// a transaction committed again is recorded once
+	err := store.Put(&Entry{TxID: "tx4", Reason: "budget"})
+	require.NoError(t, err)
+
+	entries, err := store.List()
+	require.NoError(t, err)
+	assert.Equal(t, []*Entry{
+		{TxID: "tx2", Reason: "verification"},
+		{TxID: "tx3", Reason: "verification"},
+		{TxID: "tx4", Reason: "verification"},
+	}, entries)
+
+	entry, err := store.Get("tx3")
+	require.NoError(t, err)
+	assert.Equal(t, &Entry{TxID: "tx3", Reason: "verification"}, entry)
+	entry, err = store.Get("tx1")
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+
+	entries, err = provider.OpenStore("channel-2").List()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestStoreUnbounded(t *testing.T) {
+	provider, cleanup := newTestProvider(t, 0)
+	defer cleanup()
+
+	store := provider.OpenStore("channel-1")
+	for i := 0; i < 300; i++ {
+		err := store.Put(&Entry{TxID: fmt.Sprintf("tx%d", i)})
+		require.NoError(t, err)
+	}
+	entries, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, entries, 300)
+	assert.Equal(t, "tx0", entries[0].TxID)
+	assert.Equal(t, "tx299", entries[299].TxID)
+}
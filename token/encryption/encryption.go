@@ -0,0 +1,309 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package encryption encrypts token transactions to the committing peers of
+// the application orgs of a channel, so that the orderers order ciphertext
+// they cannot read.
+//
+// A token transaction is encrypted with AES-256-GCM under a random content
+// key. For each org, the content key is wrapped with AES-256-GCM under the
+// SHA256 hash of the ECDH secret agreed between an ephemeral P-256 key and the
+// TokenEncryptionKey of the org. The encrypted action commits to the content
+// key, the ephemeral key and every wrapped key.
+//
+// The ephemeral key is derived from the content key and the wrapping uses a
+// fixed nonce, so the wrapped keys are a function of the content key and of
+// the TokenEncryptionKeys. An org that recovers the content key wraps it again
+// for every org and checks the result against the action. Either every wrapped
+// key is the one of the committed content key, and all the orgs decrypt the
+// same transaction, or no org accepts the action: an org that cannot unwrap
+// its key rejects it, and so does every org that can, as the wrapped key of
+// that org does not match.
+package encryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"hash"
+	"io"
+	"math/big"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+const (
+	contentKeySize = 32
+	// the size of a content key wrapped with AES-GCM
+	wrappedKeySize = contentKeySize + 16
+	nonceSize      = 12
+)
+
+// ParsePublicKey parses a DER encoded PKIX ECDSA P-256 TokenEncryptionKey
+func ParsePublicKey(der []byte) (*ecdsa.PublicKey, error) {
+	pk, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed parsing token encryption key")
+	}
+	ecdsaPk, ok := pk.(*ecdsa.PublicKey)
+	if !ok || ecdsaPk.Curve != elliptic.P256() {
+		return nil, errors.New("token encryption key is not an ECDSA P-256 key")
+	}
+	return ecdsaPk, nil
+}
+
+// Encrypt encrypts a token transaction to the public keys of the orgs, by MSP ID
+func Encrypt(ttx *token.TokenTransaction, keys map[string]*ecdsa.PublicKey, rand io.Reader) (*token.EncryptedTokenAction, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no recipients to encrypt the token transaction to")
+	}
+	if ttx.GetEncryptedAction() != nil {
+		return nil, errors.New("token transaction is already encrypted")
+	}
+	plaintext, err := proto.Marshal(ttx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshalling token transaction")
+	}
+
+	contentKey := make([]byte, contentKeySize)
+	if _, err := io.ReadFull(rand, contentKey); err != nil {
+		return nil, errors.Wrap(err, "failed generating content key")
+	}
+	aead, err := newAEAD(contentKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand, nonce); err != nil {
+		return nil, errors.Wrap(err, "failed generating nonce")
+	}
+
+	ephemeral := ephemeralKey(contentKey)
+	ephemeralPk := elliptic.Marshal(elliptic.P256(), ephemeral.X, ephemeral.Y)
+
+	var mspIDs []string
+	for mspID := range keys {
+		mspIDs = append(mspIDs, mspID)
+	}
+	sort.Strings(mspIDs)
+
+	action := &token.EncryptedTokenAction{
+		Ciphertext:         aead.Seal(nil, nonce, plaintext, nil),
+		Nonce:              nonce,
+		EphemeralPublicKey: ephemeralPk,
+	}
+	for _, mspID := range mspIDs {
+		pk := keys[mspID]
+		if pk == nil || pk.Curve != elliptic.P256() {
+			return nil, errors.Errorf("invalid token encryption key for org %s", mspID)
+		}
+		encryptedKey, err := wrapContentKey(contentKey, ephemeral.D, ephemeralPk, pk, mspID)
+		if err != nil {
+			return nil, err
+		}
+		action.Recipients = append(action.Recipients, &token.TokenRecipient{
+			MspId:        mspID,
+			EncryptedKey: encryptedKey,
+		})
+	}
+	action.KeyCommitment = keyCommitment(contentKey, action)
+	return action, nil
+}
+
+// Check returns an error unless an encrypted action is well formed and wraps
+// its content key exactly once for each of the orgs. It needs no private key,
+// so that every peer runs it, whether or not it can decrypt the action.
+func Check(action *token.EncryptedTokenAction, mspIDs []string) error {
+	if x, _ := elliptic.Unmarshal(elliptic.P256(), action.GetEphemeralPublicKey()); x == nil {
+		return errors.New("invalid ephemeral public key")
+	}
+	if len(action.GetNonce()) != nonceSize {
+		return errors.Errorf("invalid nonce size %d", len(action.GetNonce()))
+	}
+	if len(action.GetKeyCommitment()) != sha256.Size {
+		return errors.Errorf("invalid key commitment size %d", len(action.GetKeyCommitment()))
+	}
+
+	expected := map[string]bool{}
+	for _, mspID := range mspIDs {
+		expected[mspID] = true
+	}
+	wrapped := map[string]bool{}
+	for _, r := range action.GetRecipients() {
+		if wrapped[r.MspId] {
+			return errors.Errorf("content key wrapped more than once for org %s", r.MspId)
+		}
+		if !expected[r.MspId] {
+			return errors.Errorf("content key wrapped for org %s, which is not an application org", r.MspId)
+		}
+		if len(r.EncryptedKey) != wrappedKeySize {
+			return errors.Errorf("invalid wrapped key size %d for org %s", len(r.EncryptedKey), r.MspId)
+		}
+		wrapped[r.MspId] = true
+	}
+	for _, mspID := range mspIDs {
+		if !wrapped[mspID] {
+			return errors.Errorf("token transaction is not encrypted to org %s", mspID)
+		}
+	}
+	return nil
+}
+
+// Decrypt decrypts the token transaction of an encrypted action with the
+// private key of the TokenEncryptionKey of an org. It checks the wrapped keys
+// of all the recipients against the public keys of the orgs, by MSP ID.
+func Decrypt(action *token.EncryptedTokenAction, mspID string, key *ecdsa.PrivateKey, keys map[string]*ecdsa.PublicKey) (*token.TokenTransaction, error) {
+	var recipient *token.TokenRecipient
+	for _, r := range action.GetRecipients() {
+		if r.MspId == mspID {
+			recipient = r
+			break
+		}
+	}
+	if recipient == nil {
+		return nil, errors.Errorf("token transaction is not encrypted to org %s", mspID)
+	}
+
+	x, y := elliptic.Unmarshal(elliptic.P256(), action.EphemeralPublicKey)
+	if x == nil {
+		return nil, errors.New("invalid ephemeral public key")
+	}
+	sx, _ := elliptic.P256().ScalarMult(x, y, key.D.Bytes())
+	kek, err := keyEncryptionKey(sx.Bytes(), action.EphemeralPublicKey, mspID)
+	if err != nil {
+		return nil, err
+	}
+	contentKey, err := kek.Open(nil, make([]byte, kek.NonceSize()), recipient.EncryptedKey, nil)
+	if err != nil {
+		return nil, errors.Errorf("failed decrypting content key for org %s", mspID)
+	}
+
+	ephemeral := ephemeralKey(contentKey)
+	if !bytes.Equal(elliptic.Marshal(elliptic.P256(), ephemeral.X, ephemeral.Y), action.EphemeralPublicKey) {
+		return nil, errors.New("ephemeral public key does not match the content key")
+	}
+	if !bytes.Equal(keyCommitment(contentKey, action), action.KeyCommitment) {
+		return nil, errors.New("content key does not match its commitment")
+	}
+	for _, r := range action.GetRecipients() {
+		pk := keys[r.MspId]
+		if pk == nil || pk.Curve != elliptic.P256() {
+			return nil, errors.Errorf("no token encryption key for org %s", r.MspId)
+		}
+		encryptedKey, err := wrapContentKey(contentKey, ephemeral.D, action.EphemeralPublicKey, pk, r.MspId)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(encryptedKey, r.EncryptedKey) {
+			return nil, errors.Errorf("content key is not wrapped for org %s", r.MspId)
+		}
+	}
+
+	aead, err := newAEAD(contentKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(action.Nonce) != aead.NonceSize() {
+		return nil, errors.Errorf("invalid nonce size %d", len(action.Nonce))
+	}
+	plaintext, err := aead.Open(nil, action.Nonce, action.Ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("failed decrypting token transaction")
+	}
+	ttx := &token.TokenTransaction{}
+	if err := proto.Unmarshal(plaintext, ttx); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshalling decrypted token transaction")
+	}
+	if ttx.GetEncryptedAction() != nil {
+		return nil, errors.New("decrypted token transaction is encrypted")
+	}
+	return ttx, nil
+}
+
+// ephemeralKey derives the ephemeral key of an encrypted action from its
+// content key, so that the orgs recovering the content key can wrap it again
+func ephemeralKey(contentKey []byte) *ecdsa.PrivateKey {
+	curve := elliptic.P256()
+	h := sha256.New()
+	h.Write([]byte("fabtoken ephemeral key"))
+	h.Write(contentKey)
+	// d is in [1, N-1]
+	one := big.NewInt(1)
+	d := new(big.Int).SetBytes(h.Sum(nil))
+	d.Mod(d, new(big.Int).Sub(curve.Params().N, one))
+	d.Add(d, one)
+
+	key := &ecdsa.PrivateKey{D: d}
+	key.Curve = curve
+	key.X, key.Y = curve.ScalarBaseMult(d.Bytes())
+	return key
+}
+
+// wrapContentKey wraps the content key for an org. The wrapping is a function
+// of its inputs, as the nonce is fixed: each key encryption key wraps a
+// single content key.
+func wrapContentKey(contentKey []byte, ephemeralD *big.Int, ephemeralPk []byte, pk *ecdsa.PublicKey, mspID string) ([]byte, error) {
+	x, _ := pk.Curve.ScalarMult(pk.X, pk.Y, ephemeralD.Bytes())
+	kek, err := keyEncryptionKey(x.Bytes(), ephemeralPk, mspID)
+	if err != nil {
+		return nil, err
+	}
+	return kek.Seal(nil, make([]byte, kek.NonceSize()), contentKey, nil), nil
+}
+
+// keyCommitment returns the commitment to the content key, the ephemeral
+// public key and the wrapped keys of all the recipients of an action
+func keyCommitment(contentKey []byte, action *token.EncryptedTokenAction) []byte {
+	h := sha256.New()
+	h.Write([]byte("fabtoken key commitment"))
+	writeField(h, contentKey)
+	writeField(h, action.EphemeralPublicKey)
+	for _, r := range action.Recipients {
+		writeField(h, []byte(r.MspId))
+		writeField(h, r.EncryptedKey)
+	}
+	return h.Sum(nil)
+}
+
+// writeField writes a length prefixed field to a hash
+func writeField(h hash.Hash, field []byte) {
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(field)))
+	h.Write(length[:])
+	h.Write(field)
+}
+
+// keyEncryptionKey derives the key wrapping the content key for an org from
+// the x coordinate of the shared ECDH point
+func keyEncryptionKey(sharedX, ephemeralPk []byte, mspID string) (cipher.AEAD, error) {
+	secret := make([]byte, 32)
+	copy(secret[len(secret)-len(sharedX):], sharedX)
+	h := sha256.New()
+	h.Write(secret)
+	h.Write(ephemeralPk)
+	h.Write([]byte(mspID))
+	return newAEAD(h.Sum(nil))
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating AES cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating GCM")
+	}
+	return aead, nil
+}
@@ -0,0 +1,173 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package encryption_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/encryption"
+	"github.com/stretchr/testify/assert"
+)
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	return key
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	org1, org2 := newKey(t), newKey(t)
+	ttx := &token.TokenTransaction{
+		Action: &token.TokenTransaction_PlainAction{
+			PlainAction: &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainImport{
+					PlainImport: &token.PlainImport{
+						Outputs: []*token.PlainOutput{{Owner: []byte("alice"), Type: "USD", Quantity: 100}},
+					},
+				},
+			},
+		},
+		ApplicationReference: []byte("invoice-1"),
+	}
+
+	keys := map[string]*ecdsa.PublicKey{"Org1MSP": &org1.PublicKey, "Org2MSP": &org2.PublicKey}
+	action, err := encryption.Encrypt(ttx, keys, rand.Reader)
+	assert.NoError(t, err)
+	assert.Len(t, action.Recipients, 2)
+	plaintext, err := proto.Marshal(ttx)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(action.Ciphertext), "alice")
+	assert.NotEqual(t, plaintext, action.Ciphertext)
+	assert.NoError(t, encryption.Check(action, []string{"Org1MSP", "Org2MSP"}))
+
+	for mspID, key := range map[string]*ecdsa.PrivateKey{"Org1MSP": org1, "Org2MSP": org2} {
+		decrypted, err := encryption.Decrypt(action, mspID, key, keys)
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(ttx, decrypted))
+	}
+
+	_, err = encryption.Decrypt(action, "Org3MSP", org1, keys)
+	assert.EqualError(t, err, "token transaction is not encrypted to org Org3MSP")
+	_, err = encryption.Decrypt(action, "Org1MSP", org2, keys)
+	assert.EqualError(t, err, "failed decrypting content key for org Org1MSP")
+
+	tampered := proto.Clone(action).(*token.EncryptedTokenAction)
+	tampered.Ciphertext[0] ^= 1
+	_, err = encryption.Decrypt(tampered, "Org1MSP", org1, keys)
+	assert.EqualError(t, err, "failed decrypting token transaction")
+
+	tampered = proto.Clone(action).(*token.EncryptedTokenAction)
+	tampered.KeyCommitment[0] ^= 1
+	_, err = encryption.Decrypt(tampered, "Org1MSP", org1, keys)
+	assert.EqualError(t, err, "content key does not match its commitment")
+
+	// the commitment binds the wrapped keys of the other orgs
+	tampered = proto.Clone(action).(*token.EncryptedTokenAction)
+	tampered.Recipients[0], tampered.Recipients[1] = tampered.Recipients[1], tampered.Recipients[0]
+	_, err = encryption.Decrypt(tampered, "Org1MSP", org1, keys)
+	assert.EqualError(t, err, "content key does not match its commitment")
+
+	tampered = proto.Clone(action).(*token.EncryptedTokenAction)
+	tampered.Nonce = tampered.Nonce[1:]
+	_, err = encryption.Decrypt(tampered, "Org1MSP", org1, keys)
+	assert.EqualError(t, err, "invalid nonce size 11")
+
+	tampered = proto.Clone(action).(*token.EncryptedTokenAction)
+	tampered.EphemeralPublicKey = []byte("point")
+	_, err = encryption.Decrypt(tampered, "Org1MSP", org1, keys)
+	assert.EqualError(t, err, "invalid ephemeral public key")
+
+	_, err = encryption.Decrypt(action, "Org1MSP", org1, map[string]*ecdsa.PublicKey{"Org1MSP": &org1.PublicKey})
+	assert.EqualError(t, err, "no token encryption key for org Org2MSP")
+}
+
+func TestDecryptRejectsKeyNotWrappedForAnOrg(t *testing.T) {
+	org1, org2, other := newKey(t), newKey(t), newKey(t)
+	ttx := &token.TokenTransaction{ApplicationReference: []byte("invoice-1")}
+	keys := map[string]*ecdsa.PublicKey{"Org1MSP": &org1.PublicKey, "Org2MSP": &org2.PublicKey}
+
+	// the content key is wrapped for Org2MSP under a key that is not the one of the org
+	action, err := encryption.Encrypt(ttx, map[string]*ecdsa.PublicKey{"Org1MSP": &org1.PublicKey, "Org2MSP": &other.PublicKey}, rand.Reader)
+	assert.NoError(t, err)
+	assert.NoError(t, encryption.Check(action, []string{"Org1MSP", "Org2MSP"}))
+
+	// both orgs reject the action, not only the one which cannot unwrap its key
+	_, err = encryption.Decrypt(action, "Org2MSP", org2, keys)
+	assert.EqualError(t, err, "failed decrypting content key for org Org2MSP")
+	_, err = encryption.Decrypt(action, "Org1MSP", org1, keys)
+	assert.EqualError(t, err, "content key is not wrapped for org Org2MSP")
+}
+
+func TestCheck(t *testing.T) {
+	org1, org2 := newKey(t), newKey(t)
+	action, err := encryption.Encrypt(&token.TokenTransaction{}, map[string]*ecdsa.PublicKey{"Org1MSP": &org1.PublicKey, "Org2MSP": &org2.PublicKey}, rand.Reader)
+	assert.NoError(t, err)
+	assert.NoError(t, encryption.Check(action, []string{"Org1MSP", "Org2MSP"}))
+
+	assert.EqualError(t, encryption.Check(action, []string{"Org1MSP", "Org2MSP", "Org3MSP"}), "token transaction is not encrypted to org Org3MSP")
+	assert.EqualError(t, encryption.Check(action, []string{"Org1MSP"}), "content key wrapped for org Org2MSP, which is not an application org")
+
+	tampered := proto.Clone(action).(*token.EncryptedTokenAction)
+	tampered.Recipients = append(tampered.Recipients, tampered.Recipients[0])
+	assert.EqualError(t, encryption.Check(tampered, []string{"Org1MSP", "Org2MSP"}), "content key wrapped more than once for org Org1MSP")
+
+	tampered = proto.Clone(action).(*token.EncryptedTokenAction)
+	tampered.Recipients[1].EncryptedKey = tampered.Recipients[1].EncryptedKey[1:]
+	assert.EqualError(t, encryption.Check(tampered, []string{"Org1MSP", "Org2MSP"}), "invalid wrapped key size 47 for org Org2MSP")
+
+	tampered = proto.Clone(action).(*token.EncryptedTokenAction)
+	tampered.Nonce = tampered.Nonce[1:]
+	assert.EqualError(t, encryption.Check(tampered, []string{"Org1MSP", "Org2MSP"}), "invalid nonce size 11")
+
+	tampered = proto.Clone(action).(*token.EncryptedTokenAction)
+	tampered.KeyCommitment = tampered.KeyCommitment[1:]
+	assert.EqualError(t, encryption.Check(tampered, []string{"Org1MSP", "Org2MSP"}), "invalid key commitment size 31")
+
+	tampered = proto.Clone(action).(*token.EncryptedTokenAction)
+	tampered.EphemeralPublicKey = []byte("point")
+	assert.EqualError(t, encryption.Check(tampered, []string{"Org1MSP", "Org2MSP"}), "invalid ephemeral public key")
+}
+
+func TestEncryptErrors(t *testing.T) {
+	ttx := &token.TokenTransaction{}
+	_, err := encryption.Encrypt(ttx, nil, rand.Reader)
+	assert.EqualError(t, err, "no recipients to encrypt the token transaction to")
+
+	_, err = encryption.Encrypt(ttx, map[string]*ecdsa.PublicKey{"Org1MSP": nil}, rand.Reader)
+	assert.EqualError(t, err, "invalid token encryption key for org Org1MSP")
+
+	org1 := newKey(t)
+	action, err := encryption.Encrypt(ttx, map[string]*ecdsa.PublicKey{"Org1MSP": &org1.PublicKey}, rand.Reader)
+	assert.NoError(t, err)
+	_, err = encryption.Encrypt(&token.TokenTransaction{Action: &token.TokenTransaction_EncryptedAction{EncryptedAction: action}}, map[string]*ecdsa.PublicKey{"Org1MSP": &org1.PublicKey}, rand.Reader)
+	assert.EqualError(t, err, "token transaction is already encrypted")
+}
+
+func TestParsePublicKey(t *testing.T) {
+	key := newKey(t)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err)
+	pk, err := encryption.ParsePublicKey(der)
+	assert.NoError(t, err)
+	assert.Equal(t, key.PublicKey.X, pk.X)
+
+	_, err = encryption.ParsePublicKey([]byte("garbage"))
+	assert.Error(t, err)
+
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	assert.NoError(t, err)
+	der, err = x509.MarshalPKIXPublicKey(&p384.PublicKey)
+	assert.NoError(t, err)
+	_, err = encryption.ParsePublicKey(der)
+	assert.EqualError(t, err, "token encryption key is not an ECDSA P-256 key")
+}
@@ -0,0 +1,146 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package exporter
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// AvroSchema is the Avro schema of the records. The transaction is the
+	// JSON document of the decoded transaction.
+	AvroSchema = `{"type":"record","name":"Record","namespace":"org.hyperledger.fabric.token.exporter","fields":[` +
+		`{"name":"channel","type":"string"},` +
+		`{"name":"block_number","type":"long"},` +
+		`{"name":"tx_index","type":"int"},` +
+		`{"name":"tx_id","type":"string"},` +
+		`{"name":"type","type":"string"},` +
+		`{"name":"timestamp","type":{"type":"long","logicalType":"timestamp-micros"}},` +
+		`{"name":"validation_code","type":"string"},` +
+		`{"name":"transaction","type":"string"}]}`
+
+	// avroCanonicalSchema is the Parsing Canonical Form of AvroSchema, which
+	// the schema fingerprint is computed from.
+	avroCanonicalSchema = `{"name":"org.hyperledger.fabric.token.exporter.Record","type":"record","fields":[` +
+		`{"name":"channel","type":"string"},` +
+		`{"name":"block_number","type":"long"},` +
+		`{"name":"tx_index","type":"int"},` +
+		`{"name":"tx_id","type":"string"},` +
+		`{"name":"type","type":"string"},` +
+		`{"name":"timestamp","type":"long"},` +
+		`{"name":"validation_code","type":"string"},` +
+		`{"name":"transaction","type":"string"}]}`
+
+	avroEmptyFingerprint = 0xc15d213aa4d7a795
+)
+
+var (
+	avroSingleObjectMarker = []byte{0xc3, 0x01}
+	avroContainerMagic     = []byte{'O', 'b', 'j', 0x01}
+
+	avroFingerprintTable = func() [256]uint64 {
+		var table [256]uint64
+		for i := range table {
+			fp := uint64(i)
+			for j := 0; j < 8; j++ {
+				fp = (fp >> 1) ^ (avroEmptyFingerprint & -(fp & 1))
+			}
+			table[i] = fp
+		}
+		return table
+	}()
+)
+
+// AvroFingerprint returns the CRC-64-AVRO fingerprint of a schema in Parsing
+// Canonical Form.
+func AvroFingerprint(canonicalSchema []byte) uint64 {
+	fp := uint64(avroEmptyFingerprint)
+	for _, b := range canonicalSchema {
+		fp = (fp >> 8) ^ avroFingerprintTable[byte(fp)^b]
+	}
+	return fp
+}
+
+// AvroEncoding encodes the records with the Avro binary encoding and
+// AvroSchema. A single record is encoded as an Avro single object, which
+// carries the fingerprint of the schema, and the records of a block as an
+// Avro object container file, which carries the schema.
+type AvroEncoding struct{}
+
+// EncodeRecord returns the Avro single object encoding of the record.
+func (*AvroEncoding) EncodeRecord(r *Record) ([]byte, error) {
+	buf := bytes.NewBuffer(append([]byte{}, avroSingleObjectMarker...))
+	var fingerprint [8]byte
+	binary.LittleEndian.PutUint64(fingerprint[:], AvroFingerprint([]byte(avroCanonicalSchema)))
+	buf.Write(fingerprint[:])
+	writeAvroRecord(buf, r)
+	return buf.Bytes(), nil
+}
+
+// EncodeRecords returns an Avro object container file holding the records in
+// a single block.
+func (*AvroEncoding) EncodeRecords(records []*Record) ([]byte, error) {
+	var sync [16]byte
+	if _, err := rand.Read(sync[:]); err != nil {
+		return nil, errors.Wrap(err, "failed generating sync marker")
+	}
+
+	buf := bytes.NewBuffer(append([]byte{}, avroContainerMagic...))
+	// file metadata, as a map with a single block of two entries
+	writeAvroLong(buf, 2)
+	writeAvroString(buf, "avro.schema")
+	writeAvroBytes(buf, []byte(AvroSchema))
+	writeAvroString(buf, "avro.codec")
+	writeAvroBytes(buf, []byte("null"))
+	writeAvroLong(buf, 0)
+	buf.Write(sync[:])
+
+	block := &bytes.Buffer{}
+	for _, r := range records {
+		writeAvroRecord(block, r)
+	}
+	writeAvroLong(buf, int64(len(records)))
+	writeAvroBytes(buf, block.Bytes())
+	buf.Write(sync[:])
+	return buf.Bytes(), nil
+}
+
+// ContentType returns avro/binary.
+func (*AvroEncoding) ContentType() string {
+	return "avro/binary"
+}
+
+func writeAvroRecord(buf *bytes.Buffer, r *Record) {
+	writeAvroString(buf, r.Channel)
+	writeAvroLong(buf, int64(r.BlockNumber))
+	writeAvroLong(buf, int64(r.TxIndex))
+	writeAvroString(buf, r.TxID)
+	writeAvroString(buf, r.Type)
+	writeAvroLong(buf, r.Timestamp.Unix()*1e6+int64(r.Timestamp.Nanosecond()/1e3))
+	writeAvroString(buf, r.ValidationCode)
+	writeAvroBytes(buf, r.Transaction)
+}
+
+// writeAvroLong writes the zig-zag variable length encoding of an int or a long
+func writeAvroLong(buf *bytes.Buffer, v int64) {
+	var b [binary.MaxVarintLen64]byte
+	buf.Write(b[:binary.PutVarint(b[:], v)])
+}
+
+func writeAvroBytes(buf *bytes.Buffer, v []byte) {
+	writeAvroLong(buf, int64(len(v)))
+	buf.Write(v)
+}
+
+func writeAvroString(buf *bytes.Buffer, v string) {
+	writeAvroLong(buf, int64(len(v)))
+	buf.WriteString(v)
+}
@@ -0,0 +1,156 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package exporter_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"time"
+
+	"github.com/hyperledger/fabric/token/exporter"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// avroReader decodes the Avro binary encoding
+type avroReader struct {
+	*bytes.Reader
+}
+
+func (r *avroReader) long() int64 {
+	v, err := binary.ReadVarint(r)
+	Expect(err).NotTo(HaveOccurred())
+	return v
+}
+
+func (r *avroReader) bytes() []byte {
+	b := make([]byte, r.long())
+	_, err := r.Read(b)
+	Expect(err).NotTo(HaveOccurred())
+	return b
+}
+
+func (r *avroReader) record() *exporter.Record {
+	record := &exporter.Record{
+		Channel:     string(r.bytes()),
+		BlockNumber: uint64(r.long()),
+		TxIndex:     int(r.long()),
+		TxID:        string(r.bytes()),
+		Type:        string(r.bytes()),
+	}
+	micros := r.long()
+	record.Timestamp = time.Unix(micros/1e6, micros%1e6*1e3).UTC()
+	record.ValidationCode = string(r.bytes())
+	record.Transaction = r.bytes()
+	return record
+}
+
+var _ = Describe("AvroEncoding", func() {
+	var (
+		encoding *exporter.AvroEncoding
+		records  []*exporter.Record
+	)
+
+	BeforeEach(func() {
+		encoding = &exporter.AvroEncoding{}
+		records = []*exporter.Record{
+			{
+				Channel:        "testchannel",
+				BlockNumber:    300,
+				TxIndex:        2,
+				TxID:           "tx0",
+				Type:           "TOKEN_TRANSACTION",
+				Timestamp:      time.Unix(1000, 5000).UTC(),
+				ValidationCode: "VALID",
+				Transaction:    []byte(`{"token_action":{}}`),
+			},
+			{
+				Channel:        "testchannel",
+				BlockNumber:    300,
+				TxIndex:        3,
+				TxID:           "tx1",
+				Type:           "ENDORSER_TRANSACTION",
+				Timestamp:      time.Unix(1001, 0).UTC(),
+				ValidationCode: "MVCC_READ_CONFLICT",
+				Transaction:    []byte(`{}`),
+			},
+		}
+	})
+
+	It("computes the CRC-64-AVRO fingerprint of schemas", func() {
+		Expect(exporter.AvroFingerprint(nil)).To(Equal(uint64(0xc15d213aa4d7a795)))
+		Expect(exporter.AvroFingerprint([]byte(`"int"`))).To(Equal(uint64(0x7275d51a3f395c8f)))
+		Expect(exporter.AvroFingerprint([]byte(`"string"`))).To(Equal(uint64(0x8f014872634503c7)))
+	})
+
+	It("encodes a record as a single object", func() {
+		value, err := encoding.EncodeRecord(records[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value[:2]).To(Equal([]byte{0xc3, 0x01}))
+		Expect(binary.LittleEndian.Uint64(value[2:10])).To(Equal(uint64(0xc1dd92f0f337ac3d)))
+
+		r := &avroReader{bytes.NewReader(value[10:])}
+		Expect(r.record()).To(Equal(records[0]))
+		Expect(r.Len()).To(Equal(0))
+	})
+
+	It("encodes the records of a block as an object container file", func() {
+		value, err := encoding.EncodeRecords(records)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value[:4]).To(Equal([]byte("Obj\x01")))
+
+		r := &avroReader{bytes.NewReader(value[4:])}
+		Expect(r.long()).To(Equal(int64(2)))
+		metadata := map[string]string{}
+		for i := 0; i < 2; i++ {
+			key := string(r.bytes())
+			metadata[key] = string(r.bytes())
+		}
+		Expect(r.long()).To(Equal(int64(0)))
+		Expect(metadata).To(Equal(map[string]string{
+			"avro.schema": exporter.AvroSchema,
+			"avro.codec":  "null",
+		}))
+		sync := make([]byte, 16)
+		_, err = r.Read(sync)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(r.long()).To(Equal(int64(2)))
+		block := &avroReader{bytes.NewReader(r.bytes())}
+		Expect(block.record()).To(Equal(records[0]))
+		Expect(block.record()).To(Equal(records[1]))
+		Expect(block.Len()).To(Equal(0))
+		trailer := make([]byte, 16)
+		_, err = r.Read(trailer)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(trailer).To(Equal(sync))
+		Expect(r.Len()).To(Equal(0))
+	})
+
+	It("has the avro/binary content type", func() {
+		Expect(encoding.ContentType()).To(Equal("avro/binary"))
+	})
+})
+
+var _ = Describe("EncodingByName", func() {
+	It("returns the encoding of a format", func() {
+		encoding, err := exporter.EncodingByName("json")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(encoding).To(Equal(&exporter.JSONEncoding{}))
+
+		encoding, err = exporter.EncodingByName("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(encoding).To(Equal(&exporter.JSONEncoding{}))
+
+		encoding, err = exporter.EncodingByName("avro")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(encoding).To(Equal(&exporter.AvroEncoding{}))
+
+		_, err = exporter.EncodingByName("xml")
+		Expect(err).To(MatchError("unknown token exporter format: xml"))
+	})
+})
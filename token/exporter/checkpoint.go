@@ -0,0 +1,77 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package exporter
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+type checkpoint struct {
+	Next uint64 `json:"next"`
+}
+
+// FileCheckpointStore keeps one checkpoint file per channel in a directory.
+// Files are replaced atomically so a crash never leaves a partial checkpoint.
+type FileCheckpointStore struct {
+	Dir string
+
+	mutex sync.Mutex
+}
+
+// Load returns the number of the next block to export for the channel.
+func (f *FileCheckpointStore) Load(channel string) (uint64, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	raw, err := ioutil.ReadFile(f.path(channel))
+	if os.IsNotExist(err) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, errors.Wrapf(err, "failed reading checkpoint for channel %s", channel)
+	}
+
+	cp := &checkpoint{}
+	err = json.Unmarshal(raw, cp)
+	if err != nil {
+		return 0, false, errors.Wrapf(err, "failed unmarshaling checkpoint for channel %s", channel)
+	}
+	return cp.Next, true, nil
+}
+
+// Store records the number of the next block to export for the channel.
+func (f *FileCheckpointStore) Store(channel string, next uint64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	err := os.MkdirAll(f.Dir, 0755)
+	if err != nil {
+		return errors.Wrapf(err, "failed creating checkpoint directory %s", f.Dir)
+	}
+
+	raw, err := json.Marshal(&checkpoint{Next: next})
+	if err != nil {
+		return err
+	}
+
+	tmp := f.path(channel) + ".tmp"
+	err = ioutil.WriteFile(tmp, raw, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "failed writing checkpoint for channel %s", channel)
+	}
+	return os.Rename(tmp, f.path(channel))
+}
+
+func (f *FileCheckpointStore) path(channel string) string {
+	return filepath.Join(f.Dir, channel+".json")
+}
@@ -0,0 +1,73 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package exporter_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/token/exporter"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FileCheckpointStore", func() {
+	var (
+		tempDir string
+		store   *exporter.FileCheckpointStore
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "checkpoints")
+		Expect(err).NotTo(HaveOccurred())
+		store = &exporter.FileCheckpointStore{Dir: filepath.Join(tempDir, "exporter")}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	It("returns false when no checkpoint exists", func() {
+		next, found, err := store.Load("testchannel")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+		Expect(next).To(Equal(uint64(0)))
+	})
+
+	It("loads the stored checkpoint", func() {
+		err := store.Store("testchannel", 42)
+		Expect(err).NotTo(HaveOccurred())
+		err = store.Store("otherchannel", 7)
+		Expect(err).NotTo(HaveOccurred())
+
+		next, found, err := store.Load("testchannel")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(next).To(Equal(uint64(42)))
+
+		next, found, err = store.Load("otherchannel")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(next).To(Equal(uint64(7)))
+	})
+
+	Context("when the checkpoint file is corrupt", func() {
+		BeforeEach(func() {
+			err := os.MkdirAll(store.Dir, 0755)
+			Expect(err).NotTo(HaveOccurred())
+			err = ioutil.WriteFile(filepath.Join(store.Dir, "testchannel.json"), []byte("{"), 0644)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns an error", func() {
+			_, _, err := store.Load("testchannel")
+			Expect(err).To(MatchError(ContainSubstring("failed unmarshaling checkpoint for channel testchannel")))
+		})
+	})
+})
@@ -0,0 +1,105 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package exporter
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/common/tools/protolator"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// Decoder turns the transactions of a block into Records.
+type Decoder struct {
+	// IncludeEndorserTransactions controls whether endorser transactions are
+	// exported along with token transactions.
+	IncludeEndorserTransactions bool
+}
+
+// Decode returns a Record for every exported transaction in the block.
+func (d *Decoder) Decode(channel string, block *cb.Block) ([]*Record, error) {
+	var flags util.TxValidationFlags
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(cb.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		flags = util.TxValidationFlags(block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+
+	var records []*Record
+	for i, data := range block.GetData().GetData() {
+		env, err := utils.GetEnvelopeFromBlock(data)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed getting envelope")
+		}
+		payload, err := utils.UnmarshalPayload(env.Payload)
+		if err != nil {
+			return nil, err
+		}
+		if payload.Header == nil {
+			return nil, errors.Errorf("missing header in transaction %d of block %d", i, block.Header.Number)
+		}
+		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil {
+			return nil, err
+		}
+
+		var tx proto.Message
+		switch cb.HeaderType(chdr.Type) {
+		case cb.HeaderType_TOKEN_TRANSACTION:
+			tx = &token.TokenTransaction{}
+		case cb.HeaderType_ENDORSER_TRANSACTION:
+			if !d.IncludeEndorserTransactions {
+				continue
+			}
+			tx = &pb.Transaction{}
+		default:
+			continue
+		}
+		err = proto.Unmarshal(payload.Data, tx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed unmarshaling transaction [%s]", chdr.TxId)
+		}
+
+		buf := &bytes.Buffer{}
+		err = protolator.DeepMarshalJSON(buf, tx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed encoding transaction [%s]", chdr.TxId)
+		}
+
+		validationCode := pb.TxValidationCode_VALID
+		if len(flags) > i {
+			validationCode = flags.Flag(i)
+		}
+
+		var ts time.Time
+		if chdr.Timestamp != nil {
+			ts, err = ptypes.Timestamp(chdr.Timestamp)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		records = append(records, &Record{
+			Channel:        channel,
+			BlockNumber:    block.Header.Number,
+			TxIndex:        i,
+			TxID:           chdr.TxId,
+			Type:           cb.HeaderType(chdr.Type).String(),
+			Timestamp:      ts,
+			ValidationCode: validationCode.String(),
+			Transaction:    buf.Bytes(),
+		})
+	}
+
+	return records, nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package exporter_test
+
+import (
+	"encoding/json"
+	"time"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/exporter"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Decoder", func() {
+	var (
+		decoder  *exporter.Decoder
+		tokenTx  *token.TokenTransaction
+		block    *cb.Block
+		endorser *pb.Transaction
+	)
+
+	BeforeEach(func() {
+		decoder = &exporter.Decoder{}
+		tokenTx = &token.TokenTransaction{
+			Action: &token.TokenTransaction_PlainAction{
+				PlainAction: &token.PlainTokenAction{
+					Data: &token.PlainTokenAction_PlainImport{
+						PlainImport: &token.PlainImport{
+							Outputs: []*token.PlainOutput{{Owner: []byte("owner"), Type: "PDQ", Quantity: 100}},
+						},
+					},
+				},
+			},
+		}
+		endorser = &pb.Transaction{}
+		block = newBlock(7,
+			newEnvelope(cb.HeaderType_TOKEN_TRANSACTION, "tx0", tokenTx),
+			newEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx1", endorser),
+			newEnvelope(cb.HeaderType_TOKEN_TRANSACTION, "tx2", tokenTx),
+		)
+		block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER][2] = uint8(pb.TxValidationCode_MVCC_READ_CONFLICT)
+	})
+
+	It("returns a record for each token transaction", func() {
+		records, err := decoder.Decode("testchannel", block)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(HaveLen(2))
+
+		Expect(records[0].Channel).To(Equal("testchannel"))
+		Expect(records[0].BlockNumber).To(Equal(uint64(7)))
+		Expect(records[0].TxIndex).To(Equal(0))
+		Expect(records[0].TxID).To(Equal("tx0"))
+		Expect(records[0].Type).To(Equal("TOKEN_TRANSACTION"))
+		Expect(records[0].Timestamp).To(Equal(time.Unix(1000, 0).UTC()))
+		Expect(records[0].ValidationCode).To(Equal("VALID"))
+
+		Expect(records[1].TxIndex).To(Equal(2))
+		Expect(records[1].TxID).To(Equal("tx2"))
+		Expect(records[1].ValidationCode).To(Equal("MVCC_READ_CONFLICT"))
+	})
+
+	It("encodes the token transaction as JSON", func() {
+		records, err := decoder.Decode("testchannel", block)
+		Expect(err).NotTo(HaveOccurred())
+
+		decoded := map[string]interface{}{}
+		err = json.Unmarshal(records[0].Transaction, &decoded)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decoded).To(HaveKey("plain_action"))
+	})
+
+	Context("when endorser transactions are included", func() {
+		BeforeEach(func() {
+			decoder.IncludeEndorserTransactions = true
+		})
+
+		It("returns a record for the endorser transaction too", func() {
+			records, err := decoder.Decode("testchannel", block)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(3))
+			Expect(records[1].TxID).To(Equal("tx1"))
+			Expect(records[1].Type).To(Equal("ENDORSER_TRANSACTION"))
+		})
+	})
+
+	Context("when the block contains an invalid envelope", func() {
+		BeforeEach(func() {
+			block.Data.Data[1] = []byte("garbage")
+		})
+
+		It("returns an error", func() {
+			_, err := decoder.Decode("testchannel", block)
+			Expect(err).To(MatchError(ContainSubstring("failed getting envelope")))
+		})
+	})
+})
@@ -0,0 +1,65 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package exporter
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// An Encoding serializes the records published by the sinks.
+type Encoding interface {
+	// EncodeRecord encodes a single record, as published to Kafka.
+	EncodeRecord(r *Record) ([]byte, error)
+
+	// EncodeRecords encodes the records of a block, as posted to a webhook.
+	EncodeRecords(records []*Record) ([]byte, error)
+
+	// ContentType returns the media type of the output of EncodeRecords.
+	ContentType() string
+}
+
+// EncodingByName returns the encoding with the passed name: json, the
+// default, or avro.
+func EncodingByName(name string) (Encoding, error) {
+	switch name {
+	case "", "json":
+		return &JSONEncoding{}, nil
+	case "avro":
+		return &AvroEncoding{}, nil
+	default:
+		return nil, errors.Errorf("unknown token exporter format: %s", name)
+	}
+}
+
+// JSONEncoding encodes a record as a JSON object, and the records of a block
+// as a JSON array.
+type JSONEncoding struct{}
+
+// EncodeRecord returns the JSON object of the record.
+func (*JSONEncoding) EncodeRecord(r *Record) ([]byte, error) {
+	value, err := json.Marshal(r)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed marshaling record for transaction [%s]", r.TxID)
+	}
+	return value, nil
+}
+
+// EncodeRecords returns the JSON array of the records.
+func (*JSONEncoding) EncodeRecords(records []*Record) ([]byte, error) {
+	body, err := json.Marshal(records)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling records")
+	}
+	return body, nil
+}
+
+// ContentType returns application/json.
+func (*JSONEncoding) ContentType() string {
+	return "application/json"
+}
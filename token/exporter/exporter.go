@@ -0,0 +1,157 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package exporter
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("token.exporter")
+
+// Record is a committed transaction published to a Sink. Transaction is the
+// JSON document of the decoded transaction.
+type Record struct {
+	Channel        string          `json:"channel"`
+	BlockNumber    uint64          `json:"block_number"`
+	TxIndex        int             `json:"tx_index"`
+	TxID           string          `json:"tx_id"`
+	Type           string          `json:"type"`
+	Timestamp      time.Time       `json:"timestamp"`
+	ValidationCode string          `json:"validation_code"`
+	Transaction    json.RawMessage `json:"transaction"`
+}
+
+//go:generate counterfeiter -o mock/sink.go -fake-name Sink . Sink
+
+// A Sink publishes records to a downstream system.
+type Sink interface {
+	// Publish delivers the records extracted from a single block. Publish must
+	// only return nil once every record has been acknowledged by the downstream
+	// system.
+	Publish(channel string, records []*Record) error
+}
+
+//go:generate counterfeiter -o mock/checkpoint_store.go -fake-name CheckpointStore . CheckpointStore
+
+// A CheckpointStore tracks, per channel, the number of the next block to be exported.
+type CheckpointStore interface {
+	// Load returns the number of the next block to export for the channel.
+	// It returns false when no checkpoint has been recorded yet.
+	Load(channel string) (uint64, bool, error)
+
+	// Store records the number of the next block to export for the channel.
+	Store(channel string, next uint64) error
+}
+
+// Exporter extracts token transactions from committed blocks and publishes them
+// to a Sink. A block is checkpointed only after the Sink acknowledges it, so a
+// block may be published more than once after a failure (at-least-once delivery).
+type Exporter struct {
+	Sink        Sink
+	Checkpoints CheckpointStore
+	Decoder     *Decoder
+}
+
+// ProcessBlock publishes the transactions of the passed block and advances the
+// channel checkpoint. Blocks below the current checkpoint are skipped.
+func (e *Exporter) ProcessBlock(channel string, block *cb.Block) error {
+	if block == nil || block.Header == nil {
+		return errors.New("block header is required")
+	}
+
+	next, _, err := e.Checkpoints.Load(channel)
+	if err != nil {
+		return errors.WithMessage(err, "failed loading checkpoint")
+	}
+	if block.Header.Number < next {
+		logger.Debugf("[%s] skipping block [%d] already exported", channel, block.Header.Number)
+		return nil
+	}
+
+	records, err := e.Decoder.Decode(channel, block)
+	if err != nil {
+		return errors.WithMessage(err, "failed decoding block")
+	}
+
+	if len(records) != 0 {
+		err = e.Sink.Publish(channel, records)
+		if err != nil {
+			return errors.WithMessage(err, "failed publishing records")
+		}
+	}
+
+	err = e.Checkpoints.Store(channel, block.Header.Number+1)
+	if err != nil {
+		return errors.WithMessage(err, "failed storing checkpoint")
+	}
+	logger.Debugf("[%s] exported %d records from block [%d]", channel, len(records), block.Header.Number)
+
+	return nil
+}
+
+// BlockIteratorProvider returns an iterator over the committed blocks of a
+// channel, starting from startBlockNumber.
+type BlockIteratorProvider interface {
+	GetBlocksIterator(startBlockNumber uint64) (ledger.ResultsIterator, error)
+}
+
+// Follow exports the blocks of channel, as they are committed, until the
+// iterator is closed or done is closed. A block that cannot be exported is
+// retried after retryInterval.
+func (e *Exporter) Follow(channel string, provider BlockIteratorProvider, retryInterval time.Duration, done <-chan struct{}) error {
+	next, _, err := e.Checkpoints.Load(channel)
+	if err != nil {
+		return errors.WithMessage(err, "failed loading checkpoint")
+	}
+
+	iterator, err := provider.GetBlocksIterator(next)
+	if err != nil {
+		return errors.WithMessage(err, "failed creating block iterator")
+	}
+	defer iterator.Close()
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-done:
+			iterator.Close()
+		case <-stop:
+		}
+	}()
+
+	for {
+		result, err := iterator.Next()
+		if err != nil {
+			return errors.WithMessage(err, "failed reading block")
+		}
+		block, ok := result.(*cb.Block)
+		if !ok || block == nil {
+			logger.Infof("[%s] block iterator closed, stopping export", channel)
+			return nil
+		}
+
+		for {
+			err = e.ProcessBlock(channel, block)
+			if err == nil {
+				break
+			}
+			logger.Warningf("[%s] failed exporting block [%d], retrying in %s: %s", channel, block.Header.Number, retryInterval, err)
+			select {
+			case <-done:
+				return nil
+			case <-time.After(retryInterval):
+			}
+		}
+	}
+}
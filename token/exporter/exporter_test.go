@@ -0,0 +1,177 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package exporter_test
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/exporter"
+	"github.com/hyperledger/fabric/token/exporter/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+type blockIterator struct {
+	blocks chan *cb.Block
+}
+
+func (b *blockIterator) Next() (ledger.QueryResult, error) {
+	block, ok := <-b.blocks
+	if !ok {
+		return nil, nil
+	}
+	return block, nil
+}
+
+func (b *blockIterator) Close() {}
+
+type iteratorProvider struct {
+	iterator *blockIterator
+	start    uint64
+}
+
+func (i *iteratorProvider) GetBlocksIterator(start uint64) (ledger.ResultsIterator, error) {
+	i.start = start
+	return i.iterator, nil
+}
+
+var _ = Describe("Exporter", func() {
+	var (
+		fakeSink        *mock.Sink
+		fakeCheckpoints *mock.CheckpointStore
+		exp             *exporter.Exporter
+		block           *cb.Block
+	)
+
+	BeforeEach(func() {
+		fakeSink = &mock.Sink{}
+		fakeCheckpoints = &mock.CheckpointStore{}
+		fakeCheckpoints.LoadReturns(3, true, nil)
+		exp = &exporter.Exporter{
+			Sink:        fakeSink,
+			Checkpoints: fakeCheckpoints,
+			Decoder:     &exporter.Decoder{},
+		}
+		block = newBlock(3, newEnvelope(cb.HeaderType_TOKEN_TRANSACTION, "tx0", &token.TokenTransaction{}))
+	})
+
+	Describe("ProcessBlock", func() {
+		It("publishes the records and advances the checkpoint", func() {
+			err := exp.ProcessBlock("testchannel", block)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeSink.PublishCallCount()).To(Equal(1))
+			channel, records := fakeSink.PublishArgsForCall(0)
+			Expect(channel).To(Equal("testchannel"))
+			Expect(records).To(HaveLen(1))
+			Expect(records[0].TxID).To(Equal("tx0"))
+
+			Expect(fakeCheckpoints.StoreCallCount()).To(Equal(1))
+			channel, next := fakeCheckpoints.StoreArgsForCall(0)
+			Expect(channel).To(Equal("testchannel"))
+			Expect(next).To(Equal(uint64(4)))
+		})
+
+		Context("when the block has already been exported", func() {
+			BeforeEach(func() {
+				fakeCheckpoints.LoadReturns(4, true, nil)
+			})
+
+			It("skips the block", func() {
+				err := exp.ProcessBlock("testchannel", block)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeSink.PublishCallCount()).To(Equal(0))
+				Expect(fakeCheckpoints.StoreCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the block has no token transactions", func() {
+			BeforeEach(func() {
+				block = newBlock(3)
+			})
+
+			It("advances the checkpoint without publishing", func() {
+				err := exp.ProcessBlock("testchannel", block)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeSink.PublishCallCount()).To(Equal(0))
+				Expect(fakeCheckpoints.StoreCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the sink fails", func() {
+			BeforeEach(func() {
+				fakeSink.PublishReturns(errors.New("boom"))
+			})
+
+			It("returns an error and does not advance the checkpoint", func() {
+				err := exp.ProcessBlock("testchannel", block)
+				Expect(err).To(MatchError("failed publishing records: boom"))
+				Expect(fakeCheckpoints.StoreCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when loading the checkpoint fails", func() {
+			BeforeEach(func() {
+				fakeCheckpoints.LoadReturns(0, false, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				err := exp.ProcessBlock("testchannel", block)
+				Expect(err).To(MatchError("failed loading checkpoint: boom"))
+			})
+		})
+
+		Context("when storing the checkpoint fails", func() {
+			BeforeEach(func() {
+				fakeCheckpoints.StoreReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				err := exp.ProcessBlock("testchannel", block)
+				Expect(err).To(MatchError("failed storing checkpoint: boom"))
+			})
+		})
+	})
+
+	Describe("Follow", func() {
+		var (
+			provider *iteratorProvider
+			done     chan struct{}
+		)
+
+		BeforeEach(func() {
+			provider = &iteratorProvider{iterator: &blockIterator{blocks: make(chan *cb.Block, 2)}}
+			done = make(chan struct{})
+		})
+
+		It("starts from the checkpoint and retries failed blocks", func() {
+			fakeSink.PublishReturnsOnCall(0, errors.New("unavailable"))
+			provider.iterator.blocks <- block
+			close(provider.iterator.blocks)
+
+			err := exp.Follow("testchannel", provider, time.Millisecond, done)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(provider.start).To(Equal(uint64(3)))
+			Expect(fakeSink.PublishCallCount()).To(Equal(2))
+			Expect(fakeCheckpoints.StoreCallCount()).To(Equal(1))
+		})
+
+		It("stops when done is closed while retrying", func() {
+			fakeSink.PublishReturns(errors.New("unavailable"))
+			provider.iterator.blocks <- block
+			close(done)
+
+			err := exp.Follow("testchannel", provider, time.Hour, done)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeCheckpoints.StoreCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,69 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package exporter
+
+import (
+	"github.com/Shopify/sarama"
+	"github.com/pkg/errors"
+)
+
+// KafkaSink publishes records to a Kafka topic. Records are keyed by channel
+// so that the records of a channel are kept in order within a partition.
+// Records are encoded as JSON unless an Encoding is set.
+type KafkaSink struct {
+	Producer sarama.SyncProducer
+	Topic    string
+	Encoding Encoding
+}
+
+// NewKafkaSink creates a KafkaSink connected to the passed brokers. The
+// producer waits for all in-sync replicas to acknowledge each message.
+func NewKafkaSink(brokers []string, topic string) (*KafkaSink, error) {
+	if topic == "" {
+		return nil, errors.New("kafka topic is required")
+	}
+
+	config := sarama.NewConfig()
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Return.Successes = true
+	config.Producer.Return.Errors = true
+
+	producer, err := sarama.NewSyncProducer(brokers, config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating kafka producer")
+	}
+
+	return &KafkaSink{Producer: producer, Topic: topic}, nil
+}
+
+// Publish sends the records to the topic and waits for their acknowledgment.
+func (k *KafkaSink) Publish(channel string, records []*Record) error {
+	encoding := k.Encoding
+	if encoding == nil {
+		encoding = &JSONEncoding{}
+	}
+
+	messages := make([]*sarama.ProducerMessage, len(records))
+	for i, r := range records {
+		value, err := encoding.EncodeRecord(r)
+		if err != nil {
+			return err
+		}
+		messages[i] = &sarama.ProducerMessage{
+			Topic: k.Topic,
+			Key:   sarama.StringEncoder(channel),
+			Value: sarama.ByteEncoder(value),
+		}
+	}
+
+	return k.Producer.SendMessages(messages)
+}
+
+// Close shuts down the underlying producer.
+func (k *KafkaSink) Close() error {
+	return k.Producer.Close()
+}
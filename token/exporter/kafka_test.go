@@ -0,0 +1,92 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package exporter_test
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/hyperledger/fabric/token/exporter"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("KafkaSink", func() {
+	var (
+		producer *mocks.SyncProducer
+		sink     *exporter.KafkaSink
+	)
+
+	BeforeEach(func() {
+		producer = mocks.NewSyncProducer(GinkgoT(), nil)
+		sink = &exporter.KafkaSink{Producer: producer, Topic: "token-events"}
+	})
+
+	AfterEach(func() {
+		producer.Close()
+	})
+
+	It("sends one message per record keyed by channel", func() {
+		producer.ExpectSendMessageWithCheckerFunctionAndSucceed(func(value []byte) error {
+			record := &exporter.Record{}
+			err := json.Unmarshal(value, record)
+			if err != nil {
+				return err
+			}
+			if record.TxID != "tx0" {
+				return errors.Errorf("unexpected txid %s", record.TxID)
+			}
+			return nil
+		})
+		producer.ExpectSendMessageAndSucceed()
+
+		err := sink.Publish("testchannel", []*exporter.Record{
+			{TxID: "tx0", Transaction: []byte(`{}`)},
+			{TxID: "tx1", Transaction: []byte(`{}`)},
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("when the records are encoded with Avro", func() {
+		BeforeEach(func() {
+			sink.Encoding = &exporter.AvroEncoding{}
+		})
+
+		It("sends the records as Avro single objects", func() {
+			producer.ExpectSendMessageWithCheckerFunctionAndSucceed(func(value []byte) error {
+				if !bytes.HasPrefix(value, []byte{0xc3, 0x01}) {
+					return errors.Errorf("unexpected message %x", value)
+				}
+				return nil
+			})
+
+			err := sink.Publish("testchannel", []*exporter.Record{{TxID: "tx0", Transaction: []byte(`{}`)}})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("when the producer fails", func() {
+		BeforeEach(func() {
+			producer.ExpectSendMessageAndFail(sarama.ErrOutOfBrokers)
+		})
+
+		It("returns an error", func() {
+			err := sink.Publish("testchannel", []*exporter.Record{{TxID: "tx0", Transaction: []byte(`{}`)}})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("NewKafkaSink", func() {
+		It("requires a topic", func() {
+			_, err := exporter.NewKafkaSink([]string{"localhost:9092"}, "")
+			Expect(err).To(MatchError("kafka topic is required"))
+		})
+	})
+})
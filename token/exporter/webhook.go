@@ -0,0 +1,60 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package exporter
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// WebhookSink publishes the records of a block in a POST to a URL, as a JSON
+// array unless an Encoding is set. Any 2xx response is treated as an
+// acknowledgment.
+type WebhookSink struct {
+	URL      string
+	Client   *http.Client
+	Encoding Encoding
+}
+
+// Publish POSTs the records to the webhook URL.
+func (w *WebhookSink) Publish(channel string, records []*Record) error {
+	encoding := w.Encoding
+	if encoding == nil {
+		encoding = &JSONEncoding{}
+	}
+	body, err := encoding.EncodeRecords(records)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed creating webhook request")
+	}
+	req.Header.Set("Content-Type", encoding.ContentType())
+	req.Header.Set("X-Fabric-Channel", channel)
+
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed posting to webhook %s", w.URL)
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("webhook %s returned status %d", w.URL, resp.StatusCode)
+	}
+	return nil
+}
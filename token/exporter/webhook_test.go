@@ -0,0 +1,88 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package exporter_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/hyperledger/fabric/token/exporter"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WebhookSink", func() {
+	var (
+		status      int
+		received    []*exporter.Record
+		body        []byte
+		contentType string
+		channel     string
+		server      *httptest.Server
+		sink        *exporter.WebhookSink
+	)
+
+	BeforeEach(func() {
+		status = http.StatusOK
+		received = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.Method).To(Equal(http.MethodPost))
+			contentType = r.Header.Get("Content-Type")
+			channel = r.Header.Get("X-Fabric-Channel")
+			var err error
+			body, err = ioutil.ReadAll(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+			if contentType == "application/json" {
+				err = json.Unmarshal(body, &received)
+				Expect(err).NotTo(HaveOccurred())
+			}
+			w.WriteHeader(status)
+		}))
+		sink = &exporter.WebhookSink{URL: server.URL}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("posts the records as JSON", func() {
+		err := sink.Publish("testchannel", []*exporter.Record{{TxID: "tx0", Transaction: []byte(`{}`)}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(contentType).To(Equal("application/json"))
+		Expect(channel).To(Equal("testchannel"))
+		Expect(received).To(HaveLen(1))
+		Expect(received[0].TxID).To(Equal("tx0"))
+	})
+
+	Context("when the records are encoded with Avro", func() {
+		BeforeEach(func() {
+			sink.Encoding = &exporter.AvroEncoding{}
+		})
+
+		It("posts the records as an Avro object container file", func() {
+			err := sink.Publish("testchannel", []*exporter.Record{{TxID: "tx0", Transaction: []byte(`{}`)}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(contentType).To(Equal("avro/binary"))
+			Expect(channel).To(Equal("testchannel"))
+			Expect(body[:4]).To(Equal([]byte("Obj\x01")))
+		})
+	})
+
+	Context("when the webhook returns a non 2xx status", func() {
+		BeforeEach(func() {
+			status = http.StatusServiceUnavailable
+		})
+
+		It("returns an error", func() {
+			err := sink.Publish("testchannel", []*exporter.Record{{TxID: "tx0", Transaction: []byte(`{}`)}})
+			Expect(err).To(MatchError(ContainSubstring("returned status 503")))
+		})
+	})
+})
@@ -0,0 +1,181 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"unicode"
+
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ClientVersionExtension carries the version of the client as a string.
+	ClientVersionExtension = "client-version"
+	// TagsExtension carries application tags as a JSON object of strings.
+	TagsExtension = "tags"
+	// TraceContextExtension carries a W3C trace context traceparent, so that
+	// the processing of the command can be attached to the trace of the client.
+	TraceContextExtension = "traceparent"
+
+	// MaxHeaderExtensions is the maximum number of extensions of a header.
+	MaxHeaderExtensions = 16
+	// MaxHeaderExtensionNameSize is the maximum size in bytes of an extension name.
+	MaxHeaderExtensionNameSize = 64
+	// MaxHeaderExtensionsSize is the maximum total size in bytes of the names
+	// and values of the extensions of a header.
+	MaxHeaderExtensionsSize = 4096
+)
+
+var (
+	extensionNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9._/-]*$`)
+	traceParentRegexp   = regexp.MustCompile(`^[0-9a-f]{2}-[0-9a-f]{32}-[0-9a-f]{16}-[0-9a-f]{2}$`)
+)
+
+// A HeaderExtensionValidator validates the value of an extension.
+type HeaderExtensionValidator func(value []byte) error
+
+// DefaultHeaderExtensionValidators validate the values of the extensions
+// defined in this package. The values of other extensions are opaque.
+var DefaultHeaderExtensionValidators = map[string]HeaderExtensionValidator{
+	ClientVersionExtension: validateClientVersion,
+	TagsExtension:          validateTags,
+	TraceContextExtension:  validateTraceContext,
+}
+
+// NewClientVersionExtension returns an extension carrying the client version.
+func NewClientVersionExtension(version string) *token.HeaderExtension {
+	return &token.HeaderExtension{Name: ClientVersionExtension, Value: []byte(version)}
+}
+
+// NewTagsExtension returns an extension carrying the application tags.
+func NewTagsExtension(tags map[string]string) (*token.HeaderExtension, error) {
+	value, err := json.Marshal(tags)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling tags")
+	}
+	return &token.HeaderExtension{Name: TagsExtension, Value: value}, nil
+}
+
+// NewTraceContextExtension returns an extension carrying the traceparent of a W3C trace context.
+func NewTraceContextExtension(traceParent string) *token.HeaderExtension {
+	return &token.HeaderExtension{Name: TraceContextExtension, Value: []byte(traceParent)}
+}
+
+// GetHeaderExtension returns the value of the extension of the header with the
+// passed name, and whether the header has the extension.
+func GetHeaderExtension(header *token.Header, name string) ([]byte, bool) {
+	for _, extension := range header.GetExtensions() {
+		if extension.Name == name {
+			return extension.Value, true
+		}
+	}
+	return nil, false
+}
+
+// GetTags returns the application tags of the header, if any.
+func GetTags(header *token.Header) (map[string]string, error) {
+	value, ok := GetHeaderExtension(header, TagsExtension)
+	if !ok {
+		return nil, nil
+	}
+	tags := map[string]string{}
+	err := json.Unmarshal(value, &tags)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid tags")
+	}
+	return tags, nil
+}
+
+// ValidateHeaderExtensions checks that the extensions are within the size
+// limits, that their names are well formed and unique, and that their values
+// are accepted by the validator registered for their name, if any.
+func ValidateHeaderExtensions(extensions []*token.HeaderExtension, validators map[string]HeaderExtensionValidator) error {
+	if len(extensions) > MaxHeaderExtensions {
+		return errors.Errorf("too many header extensions: %d exceeds %d", len(extensions), MaxHeaderExtensions)
+	}
+
+	size := 0
+	names := map[string]bool{}
+	for _, extension := range extensions {
+		if extension == nil {
+			return errors.New("nil header extension")
+		}
+		name := extension.Name
+		if len(name) > MaxHeaderExtensionNameSize {
+			return errors.Errorf("header extension name exceeds %d bytes", MaxHeaderExtensionNameSize)
+		}
+		if !extensionNameRegexp.MatchString(name) {
+			return errors.Errorf("invalid header extension name '%s'", name)
+		}
+		if names[name] {
+			return errors.Errorf("duplicate header extension '%s'", name)
+		}
+		names[name] = true
+
+		size += len(name) + len(extension.Value)
+		if size > MaxHeaderExtensionsSize {
+			return errors.Errorf("header extensions exceed %d bytes", MaxHeaderExtensionsSize)
+		}
+
+		if validate, ok := validators[name]; ok && validate != nil {
+			if err := validate(extension.Value); err != nil {
+				return errors.WithMessage(err, "invalid header extension '"+name+"'")
+			}
+		}
+	}
+	return nil
+}
+
+type headerExtensionsKey struct{}
+
+// WithHeaderExtensions returns a context carrying the extensions, in addition
+// to the ones ctx already carries, to add to the headers of the commands sent
+// with the context.
+func WithHeaderExtensions(ctx context.Context, extensions ...*token.HeaderExtension) context.Context {
+	existing := HeaderExtensions(ctx)
+	all := make([]*token.HeaderExtension, 0, len(existing)+len(extensions))
+	all = append(append(all, existing...), extensions...)
+	return context.WithValue(ctx, headerExtensionsKey{}, all)
+}
+
+// HeaderExtensions returns the extensions carried by the context.
+func HeaderExtensions(ctx context.Context) []*token.HeaderExtension {
+	extensions, _ := ctx.Value(headerExtensionsKey{}).([]*token.HeaderExtension)
+	return extensions
+}
+
+func validateClientVersion(value []byte) error {
+	if len(value) == 0 {
+		return errors.New("empty client version")
+	}
+	for _, r := range string(value) {
+		if !unicode.IsPrint(r) {
+			return errors.New("client version must be printable")
+		}
+	}
+	return nil
+}
+
+func validateTags(value []byte) error {
+	tags := map[string]string{}
+	err := json.Unmarshal(value, &tags)
+	if err != nil {
+		return errors.New("tags must be a JSON object of strings")
+	}
+	return nil
+}
+
+func validateTraceContext(value []byte) error {
+	if !traceParentRegexp.Match(value) {
+		return errors.New("malformed traceparent")
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	pb "github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaderExtensions(t *testing.T) {
+	tags, err := token.NewTagsExtension(map[string]string{"tenant": "acme", "env": "prod"})
+	require.NoError(t, err)
+	header := &pb.Header{Extensions: []*pb.HeaderExtension{
+		token.NewClientVersionExtension("1.4.0"),
+		tags,
+	}}
+
+	value, ok := token.GetHeaderExtension(header, token.ClientVersionExtension)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1.4.0"), value)
+	_, ok = token.GetHeaderExtension(header, token.TraceContextExtension)
+	assert.False(t, ok)
+
+	decoded, err := token.GetTags(header)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"tenant": "acme", "env": "prod"}, decoded)
+	decoded, err = token.GetTags(&pb.Header{})
+	require.NoError(t, err)
+	assert.Nil(t, decoded)
+
+	err = token.ValidateHeaderExtensions(header.Extensions, token.DefaultHeaderExtensionValidators)
+	assert.NoError(t, err)
+}
+
+func TestValidateHeaderExtensions(t *testing.T) {
+	t.Parallel()
+
+	tooMany := make([]*pb.HeaderExtension, token.MaxHeaderExtensions+1)
+	for i := range tooMany {
+		tooMany[i] = &pb.HeaderExtension{Name: "ext" + strings.Repeat("x", i)}
+	}
+	tests := []struct {
+		name       string
+		extensions []*pb.HeaderExtension
+		err        string
+	}{
+		{name: "none"},
+		{name: "opaque", extensions: []*pb.HeaderExtension{{Name: "app/route", Value: []byte{0, 1, 2}}}},
+		{name: "trace context", extensions: []*pb.HeaderExtension{token.NewTraceContextExtension("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")}},
+		{name: "too many", extensions: tooMany, err: "too many header extensions: 17 exceeds 16"},
+		{name: "nil", extensions: []*pb.HeaderExtension{nil}, err: "nil header extension"},
+		{name: "empty name", extensions: []*pb.HeaderExtension{{Value: []byte("v")}}, err: "invalid header extension name ''"},
+		{name: "uppercase name", extensions: []*pb.HeaderExtension{{Name: "Route"}}, err: "invalid header extension name 'Route'"},
+		{name: "long name", extensions: []*pb.HeaderExtension{{Name: strings.Repeat("a", 65)}}, err: "header extension name exceeds 64 bytes"},
+		{name: "duplicate", extensions: []*pb.HeaderExtension{{Name: "a"}, {Name: "a"}}, err: "duplicate header extension 'a'"},
+		{name: "oversized", extensions: []*pb.HeaderExtension{{Name: "a", Value: bytes.Repeat([]byte{1}, 4096)}}, err: "header extensions exceed 4096 bytes"},
+		{name: "bad client version", extensions: []*pb.HeaderExtension{token.NewClientVersionExtension("1.4\n")}, err: "invalid header extension 'client-version': client version must be printable"},
+		{name: "bad tags", extensions: []*pb.HeaderExtension{{Name: token.TagsExtension, Value: []byte(`{"a": 1}`)}}, err: "invalid header extension 'tags': tags must be a JSON object of strings"},
+		{name: "bad trace context", extensions: []*pb.HeaderExtension{token.NewTraceContextExtension("00-abc")}, err: "invalid header extension 'traceparent': malformed traceparent"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			err := token.ValidateHeaderExtensions(test.extensions, token.DefaultHeaderExtensionValidators)
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.err)
+			}
+		})
+	}
+
+	validators := map[string]token.HeaderExtensionValidator{
+		"app/route": func(value []byte) error { return errors.New("unknown route") },
+	}
+	err := token.ValidateHeaderExtensions([]*pb.HeaderExtension{{Name: "app/route"}}, validators)
+	assert.EqualError(t, err, "invalid header extension 'app/route': unknown route")
+}
+
+func TestWithHeaderExtensions(t *testing.T) {
+	assert.Nil(t, token.HeaderExtensions(context.Background()))
+
+	version := token.NewClientVersionExtension("1.4.0")
+	trace := token.NewTraceContextExtension("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	parent := token.WithHeaderExtensions(context.Background(), version)
+	child := token.WithHeaderExtensions(parent, trace)
+	assert.Equal(t, []*pb.HeaderExtension{version}, token.HeaderExtensions(parent))
+	assert.Equal(t, []*pb.HeaderExtension{version, trace}, token.HeaderExtensions(child))
+}
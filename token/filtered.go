@@ -0,0 +1,50 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/hyperledger/fabric/protos/peer"
+)
+
+// OwnerKey returns the key of an owner in the filtered blocks of a deliver
+// stream whose owner hashes are derived from the secret. Without its key, an
+// owner hash cannot be linked to its owner, even by guessing the owner.
+func OwnerKey(secret []byte, owner []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(owner)
+	return mac.Sum(nil)
+}
+
+// OwnerHash returns the hash, keyed with the key of its owner, of the output
+// with the index in the outputs of the filtered token transaction.
+func OwnerHash(ownerKey []byte, txID string, index int) []byte {
+	mac := hmac.New(sha256.New, ownerKey)
+	mac.Write([]byte(txID))
+	binary.Write(mac, binary.BigEndian, uint64(index))
+	return mac.Sum(nil)
+}
+
+// OwnedOutputs returns, by transaction ID, the indexes of the token outputs
+// of the filtered block owned by the subscriber the block was sent to.
+func OwnedOutputs(block *peer.FilteredBlock) map[string][]int {
+	owned := map[string][]int{}
+	if len(block.OwnerKey) == 0 {
+		return owned
+	}
+	for _, tx := range block.FilteredTransactions {
+		for i, output := range tx.GetTokenTransaction().GetOutputs() {
+			if hmac.Equal(output.OwnerHash, OwnerHash(block.OwnerKey, tx.Txid, i)) {
+				owned[tx.Txid] = append(owned[tx.Txid], i)
+			}
+		}
+	}
+	return owned
+}
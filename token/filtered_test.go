@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token_test
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOwnedOutputs(t *testing.T) {
+	secret := []byte("secret")
+	aliceKey := token.OwnerKey(secret, []byte("alice"))
+	bobKey := token.OwnerKey(secret, []byte("bob"))
+	assert.NotEqual(t, aliceKey, bobKey)
+	assert.NotEqual(t, aliceKey, token.OwnerKey([]byte("another secret"), []byte("alice")))
+	assert.NotEqual(t, token.OwnerHash(aliceKey, "tx1", 0), token.OwnerHash(aliceKey, "tx1", 1))
+	assert.NotEqual(t, token.OwnerHash(aliceKey, "tx1", 0), token.OwnerHash(aliceKey, "tx2", 0))
+
+	block := &peer.FilteredBlock{
+		OwnerKey: aliceKey,
+		FilteredTransactions: []*peer.FilteredTransaction{
+			{
+				Txid: "tx1",
+				Data: &peer.FilteredTransaction_TokenTransaction{TokenTransaction: &peer.FilteredTokenTransaction{
+					Outputs: []*peer.FilteredTokenOutput{
+						{OwnerHash: token.OwnerHash(bobKey, "tx1", 0), Type: "USD", Quantity: 10},
+						{OwnerHash: token.OwnerHash(aliceKey, "tx1", 1), Type: "USD", Quantity: 90},
+					},
+				}},
+			},
+			{
+				Txid: "tx2",
+				Data: &peer.FilteredTransaction_TransactionActions{TransactionActions: &peer.FilteredTransactionActions{}},
+			},
+			{
+				Txid: "tx3",
+				Data: &peer.FilteredTransaction_TokenTransaction{TokenTransaction: &peer.FilteredTokenTransaction{
+					Outputs: []*peer.FilteredTokenOutput{
+						{OwnerHash: token.OwnerHash(aliceKey, "tx1", 1), Type: "USD", Quantity: 90},
+						{OwnerHash: token.OwnerHash(aliceKey, "tx3", 1), Type: "EUR", Quantity: 5},
+					},
+				}},
+			},
+		},
+	}
+	assert.Equal(t, map[string][]int{"tx1": {1}, "tx3": {1}}, token.OwnedOutputs(block))
+
+	block.OwnerKey = nil
+	assert.Empty(t, token.OwnedOutputs(block))
+}
@@ -0,0 +1,78 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"net/http"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+// Caller is the authenticated identity a query is executed for.
+type Caller struct {
+	// Identity is the serialized identity of the caller, as it appears in the
+	// owner field of the tokens it holds.
+	Identity []byte
+	// Auditor callers may query the tokens of any owner.
+	Auditor bool
+}
+
+type callerKey struct{}
+
+// WithCaller returns a copy of ctx carrying caller.
+func WithCaller(ctx context.Context, caller *Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// CallerFromContext returns the caller carried by ctx, or nil.
+func CallerFromContext(ctx context.Context) *Caller {
+	caller, _ := ctx.Value(callerKey{}).(*Caller)
+	return caller
+}
+
+//go:generate counterfeiter -o mock/authenticator.go -fake-name Authenticator . Authenticator
+
+// Authenticator determines the caller of an HTTP request.
+type Authenticator interface {
+	Authenticate(req *http.Request) (*Caller, error)
+}
+
+// CertificateAuthenticator authenticates callers by the client certificate of
+// a mutual TLS connection. The certificate must already have been verified by
+// the TLS configuration of the server; it is serialized as an identity of MSPID.
+type CertificateAuthenticator struct {
+	MSPID string
+	// Auditors are the serialized identities allowed to query any owner.
+	Auditors [][]byte
+}
+
+// Authenticate returns the caller identified by the TLS client certificate.
+func (c *CertificateAuthenticator) Authenticate(req *http.Request) (*Caller, error) {
+	if req.TLS == nil || len(req.TLS.PeerCertificates) == 0 {
+		return nil, errors.New("client certificate required")
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: req.TLS.PeerCertificates[0].Raw})
+	identity, err := proto.Marshal(&msp.SerializedIdentity{Mspid: c.MSPID, IdBytes: certPEM})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed serializing identity")
+	}
+
+	caller := &Caller{Identity: identity}
+	for _, auditor := range c.Auditors {
+		if bytes.Equal(auditor, identity) {
+			caller.Auditor = true
+			break
+		}
+	}
+	return caller, nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package graphql_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http/httptest"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/token/graphql"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CertificateAuthenticator", func() {
+	var (
+		authenticator *graphql.CertificateAuthenticator
+		certPEM       []byte
+		identity      []byte
+	)
+
+	BeforeEach(func() {
+		ca, err := tlsgen.NewCA()
+		Expect(err).NotTo(HaveOccurred())
+		keyPair, err := ca.NewClientCertKeyPair()
+		Expect(err).NotTo(HaveOccurred())
+		certPEM = keyPair.Cert
+
+		identity, err = proto.Marshal(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: certPEM})
+		Expect(err).NotTo(HaveOccurred())
+
+		authenticator = &graphql.CertificateAuthenticator{MSPID: "Org1MSP"}
+	})
+
+	connectionState := func() *tls.ConnectionState {
+		block, _ := pem.Decode(certPEM)
+		cert, err := x509.ParseCertificate(block.Bytes)
+		Expect(err).NotTo(HaveOccurred())
+		return &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	}
+
+	It("serializes the client certificate as an identity", func() {
+		req := httptest.NewRequest("POST", "/graphql", nil)
+		req.TLS = connectionState()
+
+		caller, err := authenticator.Authenticate(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(caller.Identity).To(Equal(identity))
+		Expect(caller.Auditor).To(BeFalse())
+	})
+
+	It("recognizes auditors", func() {
+		authenticator.Auditors = [][]byte{identity}
+		req := httptest.NewRequest("POST", "/graphql", nil)
+		req.TLS = connectionState()
+
+		caller, err := authenticator.Authenticate(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(caller.Auditor).To(BeTrue())
+	})
+
+	It("requires a client certificate", func() {
+		req := httptest.NewRequest("POST", "/graphql", nil)
+		_, err := authenticator.Authenticate(req)
+		Expect(err).To(MatchError("client certificate required"))
+	})
+})
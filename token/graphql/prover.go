@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package graphql
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/hyperledger/fabric/token/sqlview"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	"github.com/pkg/errors"
+)
+
+//go:generate counterfeiter -o mock/prover.go -fake-name Prover . Prover
+
+// A Prover lists the unspent tokens of a signing identity and attests the
+// supply of token types. It is implemented by client.ProverPeer.
+type Prover interface {
+	ListTokensContext(ctx context.Context, signingIdentity tk.SigningIdentity) ([]*token.TokenOutput, error)
+	RequestSupplyAttestationContext(ctx context.Context, types []string, signingIdentity tk.SigningIdentity) (*token.SignedSupplyAttestation, error)
+}
+
+// ProverBackend serves the queries of a channel from a prover peer, for
+// deployments without a SQL view. The prover only lists the tokens of the
+// creator of a command, so the backend serves the unspent tokens of its own
+// signing identity alone, such as the wallet of an application, and the
+// prover keeps no transfer history, which ViewBackend must serve.
+type ProverBackend struct {
+	Prover          Prover
+	SigningIdentity tk.SigningIdentity
+}
+
+// UnspentOutputs returns the unspent tokens of the owner, which must be the
+// signing identity of the backend.
+func (p *ProverBackend) UnspentOutputs(owner []byte) ([]*sqlview.Output, error) {
+	identity, err := p.SigningIdentity.Serialize()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed serializing the signing identity")
+	}
+	if !bytes.Equal(owner, identity) {
+		return nil, errors.New("the prover backend only serves the tokens of its signing identity")
+	}
+
+	tokens, err := p.Prover.ListTokensContext(context.Background(), p.SigningIdentity)
+	if err != nil {
+		return nil, err
+	}
+	outputs := make([]*sqlview.Output, len(tokens))
+	for i, t := range tokens {
+		input := plain.ParseTokenID(t.Id)
+		if input == nil {
+			return nil, errors.Errorf("invalid token ID %x", t.Id)
+		}
+		outputs[i] = &sqlview.Output{TxID: input.TxId, Index: int(input.Index), Type: t.Type, Quantity: t.Quantity}
+	}
+	return outputs, nil
+}
+
+// Supply returns the circulating supply of the token type attested by the
+// prover peer.
+func (p *ProverBackend) Supply(tokenType string) (uint64, error) {
+	signed, err := p.Prover.RequestSupplyAttestationContext(context.Background(), []string{tokenType}, p.SigningIdentity)
+	if err != nil {
+		return 0, err
+	}
+	attestation := &token.SupplyAttestation{}
+	err = proto.Unmarshal(signed.GetAttestation(), attestation)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed unmarshaling supply attestation")
+	}
+	for _, supply := range attestation.Supplies {
+		if supply.Type == tokenType {
+			return supply.Quantity, nil
+		}
+	}
+	return 0, nil
+}
+
+// History returns an error: the prover keeps no transfer history.
+func (p *ProverBackend) History(owner []byte, limit int) ([]*sqlview.Transfer, error) {
+	return nil, errors.New("the prover backend does not serve the transfer history")
+}
@@ -0,0 +1,104 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package graphql_test
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/token"
+	clientmock "github.com/hyperledger/fabric/token/client/mock"
+	"github.com/hyperledger/fabric/token/graphql"
+	"github.com/hyperledger/fabric/token/graphql/mock"
+	"github.com/hyperledger/fabric/token/sqlview"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("ProverBackend", func() {
+	var (
+		prover          *mock.Prover
+		signingIdentity *clientmock.SigningIdentity
+		backend         *graphql.ProverBackend
+	)
+
+	BeforeEach(func() {
+		prover = &mock.Prover{}
+		signingIdentity = &clientmock.SigningIdentity{}
+		signingIdentity.SerializeReturns([]byte("alice"), nil)
+		backend = &graphql.ProverBackend{Prover: prover, SigningIdentity: signingIdentity}
+	})
+
+	Describe("UnspentOutputs", func() {
+		BeforeEach(func() {
+			id, err := plain.TokenID(&token.InputId{TxId: "tx1", Index: 2})
+			Expect(err).NotTo(HaveOccurred())
+			prover.ListTokensContextReturns([]*token.TokenOutput{{Id: id, Type: "USD", Quantity: 10}}, nil)
+		})
+
+		It("lists the tokens of the signing identity", func() {
+			outputs, err := backend.UnspentOutputs([]byte("alice"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(outputs).To(Equal([]*sqlview.Output{{TxID: "tx1", Index: 2, Type: "USD", Quantity: 10}}))
+			_, si := prover.ListTokensContextArgsForCall(0)
+			Expect(si).To(Equal(signingIdentity))
+		})
+
+		It("does not serve the tokens of other owners", func() {
+			_, err := backend.UnspentOutputs([]byte("bob"))
+			Expect(err).To(MatchError("the prover backend only serves the tokens of its signing identity"))
+			Expect(prover.ListTokensContextCallCount()).To(Equal(0))
+		})
+
+		It("rejects invalid token IDs", func() {
+			prover.ListTokensContextReturns([]*token.TokenOutput{{Id: []byte("id"), Type: "USD", Quantity: 10}}, nil)
+			_, err := backend.UnspentOutputs([]byte("alice"))
+			Expect(err).To(MatchError("invalid token ID 6964"))
+		})
+
+		It("returns the errors of the prover", func() {
+			prover.ListTokensContextReturns(nil, errors.New("wild-banana"))
+			_, err := backend.UnspentOutputs([]byte("alice"))
+			Expect(err).To(MatchError("wild-banana"))
+		})
+	})
+
+	Describe("Supply", func() {
+		BeforeEach(func() {
+			attestation, err := proto.Marshal(&token.SupplyAttestation{
+				Supplies: []*token.TypeSupply{{Type: "USD", Quantity: 300}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			prover.RequestSupplyAttestationContextReturns(&token.SignedSupplyAttestation{Attestation: attestation}, nil)
+		})
+
+		It("returns the attested supply of the type", func() {
+			supply, err := backend.Supply("USD")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(supply).To(Equal(uint64(300)))
+			_, types, _ := prover.RequestSupplyAttestationContextArgsForCall(0)
+			Expect(types).To(Equal([]string{"USD"}))
+		})
+
+		It("returns 0 for the types not in circulation", func() {
+			supply, err := backend.Supply("EUR")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(supply).To(Equal(uint64(0)))
+		})
+
+		It("returns the errors of the prover", func() {
+			prover.RequestSupplyAttestationContextReturns(nil, errors.New("wild-banana"))
+			_, err := backend.Supply("USD")
+			Expect(err).To(MatchError("wild-banana"))
+		})
+	})
+
+	It("does not serve the history", func() {
+		_, err := backend.History([]byte("alice"), 10)
+		Expect(err).To(MatchError("the prover backend does not serve the transfer history"))
+	})
+})
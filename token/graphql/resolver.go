@@ -0,0 +1,151 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package graphql
+
+import (
+	"context"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/hyperledger/fabric/token/sqlview"
+	"github.com/pkg/errors"
+)
+
+//go:generate counterfeiter -o mock/backend.go -fake-name Backend . Backend
+
+// Backend answers the queries of the facade for a single channel.
+// *sqlview.View, bound to a channel with ViewBackend, is the default backend;
+// ProverBackend serves the tokens of a single identity from a prover peer.
+type Backend interface {
+	UnspentOutputs(owner []byte) ([]*sqlview.Output, error)
+	Supply(tokenType string) (uint64, error)
+	History(owner []byte, limit int) ([]*sqlview.Transfer, error)
+}
+
+// ViewBackend serves the queries of a channel from a SQL view.
+type ViewBackend struct {
+	View    *sqlview.View
+	Channel string
+}
+
+func (v *ViewBackend) UnspentOutputs(owner []byte) ([]*sqlview.Output, error) {
+	return v.View.UnspentOutputs(v.Channel, owner)
+}
+
+func (v *ViewBackend) Supply(tokenType string) (uint64, error) {
+	return v.View.Supply(v.Channel, tokenType)
+}
+
+func (v *ViewBackend) History(owner []byte, limit int) ([]*sqlview.Transfer, error) {
+	return v.View.History(v.Channel, owner, limit)
+}
+
+// maxHistoryLimit bounds the number of transfers returned by a history query.
+const maxHistoryLimit = 1000
+
+type resolver struct {
+	backend Backend
+}
+
+// owner returns the owner a query is scoped to. Callers may only query their
+// own tokens unless they are auditors.
+func owner(ctx context.Context, requested *string) ([]byte, error) {
+	caller := CallerFromContext(ctx)
+	if caller == nil {
+		return nil, errors.New("caller is not authenticated")
+	}
+	if requested == nil {
+		return caller.Identity, nil
+	}
+
+	o, err := hex.DecodeString(*requested)
+	if err != nil {
+		return nil, errors.New("owner must be a hex encoded identity")
+	}
+	if !caller.Auditor && string(o) != string(caller.Identity) {
+		return nil, errors.New("caller is not authorized to query tokens of other owners")
+	}
+	return o, nil
+}
+
+func (r *resolver) UnspentTokens(ctx context.Context, args struct{ Owner *string }) ([]*tokenResolver, error) {
+	o, err := owner(ctx, args.Owner)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := r.backend.UnspentOutputs(o)
+	if err != nil {
+		return nil, err
+	}
+	tokens := make([]*tokenResolver, len(outputs))
+	for i, output := range outputs {
+		tokens[i] = &tokenResolver{output: output}
+	}
+	return tokens, nil
+}
+
+func (r *resolver) Supply(ctx context.Context, args struct{ Type string }) (string, error) {
+	if CallerFromContext(ctx) == nil {
+		return "", errors.New("caller is not authenticated")
+	}
+	supply, err := r.backend.Supply(args.Type)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatUint(supply, 10), nil
+}
+
+func (r *resolver) History(ctx context.Context, args struct {
+	Owner *string
+	Limit int32
+}) ([]*transferResolver, error) {
+	o, err := owner(ctx, args.Owner)
+	if err != nil {
+		return nil, err
+	}
+	if args.Limit <= 0 || args.Limit > maxHistoryLimit {
+		return nil, errors.Errorf("limit must be between 1 and %d", maxHistoryLimit)
+	}
+	history, err := r.backend.History(o, int(args.Limit))
+	if err != nil {
+		return nil, err
+	}
+	transfers := make([]*transferResolver, len(history))
+	for i, transfer := range history {
+		transfers[i] = &transferResolver{transfer: transfer}
+	}
+	return transfers, nil
+}
+
+type tokenResolver struct {
+	output *sqlview.Output
+}
+
+func (t *tokenResolver) TxId() string     { return t.output.TxID }
+func (t *tokenResolver) Index() int32     { return int32(t.output.Index) }
+func (t *tokenResolver) Type() string     { return t.output.Type }
+func (t *tokenResolver) Quantity() string { return strconv.FormatUint(t.output.Quantity, 10) }
+
+type transferResolver struct {
+	transfer *sqlview.Transfer
+}
+
+func (t *transferResolver) TxId() string { return t.transfer.TxID }
+func (t *transferResolver) BlockNumber() string {
+	return strconv.FormatUint(t.transfer.BlockNumber, 10)
+}
+func (t *transferResolver) TxIndex() int32 { return int32(t.transfer.TxIndex) }
+func (t *transferResolver) Action() string { return t.transfer.Action }
+
+func (t *transferResolver) Timestamp() *string {
+	if t.transfer.Timestamp == nil {
+		return nil
+	}
+	ts := t.transfer.Timestamp.UTC().Format(time.RFC3339)
+	return &ts
+}
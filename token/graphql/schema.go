@@ -0,0 +1,40 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package graphql
+
+// schema is the read-only GraphQL schema of the token facade. Owners are hex
+// encoded serialized identities. Quantities and block numbers are returned as
+// decimal strings because GraphQL integers are limited to 32 bits.
+const schema = `
+schema {
+	query: Query
+}
+
+type Query {
+	# The unspent tokens of owner, or of the caller when owner is omitted.
+	unspentTokens(owner: String): [Token!]!
+	# The quantity of a token type in circulation.
+	supply(type: String!): String!
+	# The most recent transfers involving owner, or the caller when owner is omitted.
+	history(owner: String, limit: Int = 100): [Transfer!]!
+}
+
+type Token {
+	txId: String!
+	index: Int!
+	type: String!
+	quantity: String!
+}
+
+type Transfer {
+	txId: String!
+	blockNumber: String!
+	txIndex: Int!
+	action: String!
+	timestamp: String
+}
+`
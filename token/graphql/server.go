@@ -0,0 +1,73 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package graphql
+
+import (
+	"encoding/json"
+	"net/http"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+	"github.com/hyperledger/fabric/common/flogging"
+)
+
+var logger = flogging.MustGetLogger("token.graphql")
+
+// maxQueryDepth bounds the nesting of the queries accepted by the facade.
+const maxQueryDepth = 5
+
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Handler serves read-only GraphQL queries over token state. Every request is
+// authenticated and queries are scoped to the identity of the caller.
+type Handler struct {
+	Authenticator Authenticator
+
+	schema *graphqlgo.Schema
+}
+
+// NewHandler creates a Handler that answers queries from backend.
+func NewHandler(backend Backend, authenticator Authenticator) *Handler {
+	return &Handler{
+		Authenticator: authenticator,
+		schema: graphqlgo.MustParseSchema(schema, &resolver{backend: backend},
+			graphqlgo.MaxDepth(maxQueryDepth),
+		),
+	}
+}
+
+func (h *Handler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(resp, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	caller, err := h.Authenticator.Authenticate(req)
+	if err != nil {
+		logger.Warningf("rejecting unauthenticated request from %s: %s", req.RemoteAddr, err)
+		http.Error(resp, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var r request
+	err = json.NewDecoder(req.Body).Decode(&r)
+	if err != nil {
+		http.Error(resp, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response := h.schema.Exec(WithCaller(req.Context(), caller), r.Query, r.OperationName, r.Variables)
+
+	resp.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(resp).Encode(response)
+	if err != nil {
+		logger.Warningf("failed writing response: %s", err)
+	}
+}
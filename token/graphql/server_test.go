@@ -0,0 +1,173 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package graphql_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/token/graphql"
+	"github.com/hyperledger/fabric/token/graphql/mock"
+	"github.com/hyperledger/fabric/token/sqlview"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Handler", func() {
+	var (
+		fakeBackend       *mock.Backend
+		fakeAuthenticator *mock.Authenticator
+		handler           *graphql.Handler
+	)
+
+	BeforeEach(func() {
+		fakeBackend = &mock.Backend{}
+		fakeAuthenticator = &mock.Authenticator{}
+		fakeAuthenticator.AuthenticateReturns(&graphql.Caller{Identity: []byte("alice")}, nil)
+		handler = graphql.NewHandler(fakeBackend, fakeAuthenticator)
+	})
+
+	query := func(q string, variables map[string]interface{}) *httptest.ResponseRecorder {
+		body, err := json.Marshal(map[string]interface{}{"query": q, "variables": variables})
+		Expect(err).NotTo(HaveOccurred())
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body))))
+		return resp
+	}
+
+	Describe("unspentTokens", func() {
+		BeforeEach(func() {
+			fakeBackend.UnspentOutputsReturns([]*sqlview.Output{{TxID: "tx0", Index: 1, Type: "PDQ", Quantity: 18446744073709551615}}, nil)
+		})
+
+		It("returns the tokens of the caller", func() {
+			resp := query(`{ unspentTokens { txId index type quantity } }`, nil)
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Body).To(MatchJSON(`{"data":{"unspentTokens":[{"txId":"tx0","index":1,"type":"PDQ","quantity":"18446744073709551615"}]}}`))
+
+			Expect(fakeBackend.UnspentOutputsCallCount()).To(Equal(1))
+			Expect(fakeBackend.UnspentOutputsArgsForCall(0)).To(Equal([]byte("alice")))
+		})
+
+		It("allows the caller to name itself as owner", func() {
+			resp := query(`query($owner: String) { unspentTokens(owner: $owner) { txId } }`, map[string]interface{}{"owner": hex.EncodeToString([]byte("alice"))})
+			Expect(resp.Body).To(MatchJSON(`{"data":{"unspentTokens":[{"txId":"tx0"}]}}`))
+		})
+
+		It("rejects queries for other owners", func() {
+			resp := query(`{ unspentTokens(owner: "626f62") { txId } }`, nil)
+			Expect(resp.Body.String()).To(ContainSubstring("caller is not authorized to query tokens of other owners"))
+			Expect(fakeBackend.UnspentOutputsCallCount()).To(Equal(0))
+		})
+
+		Context("when the caller is an auditor", func() {
+			BeforeEach(func() {
+				fakeAuthenticator.AuthenticateReturns(&graphql.Caller{Identity: []byte("auditor"), Auditor: true}, nil)
+			})
+
+			It("allows queries for other owners", func() {
+				resp := query(`{ unspentTokens(owner: "626f62") { txId } }`, nil)
+				Expect(resp.Body).To(MatchJSON(`{"data":{"unspentTokens":[{"txId":"tx0"}]}}`))
+				Expect(fakeBackend.UnspentOutputsArgsForCall(0)).To(Equal([]byte("bob")))
+			})
+		})
+
+		It("rejects owners that are not hex encoded", func() {
+			resp := query(`{ unspentTokens(owner: "bob") { txId } }`, nil)
+			Expect(resp.Body.String()).To(ContainSubstring("owner must be a hex encoded identity"))
+		})
+
+		Context("when the backend fails", func() {
+			BeforeEach(func() {
+				fakeBackend.UnspentOutputsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns the error", func() {
+				resp := query(`{ unspentTokens { txId } }`, nil)
+				Expect(resp.Body.String()).To(ContainSubstring("boom"))
+			})
+		})
+	})
+
+	Describe("supply", func() {
+		It("returns the supply of the type", func() {
+			fakeBackend.SupplyReturns(140, nil)
+			resp := query(`{ supply(type: "PDQ") }`, nil)
+			Expect(resp.Body).To(MatchJSON(`{"data":{"supply":"140"}}`))
+			Expect(fakeBackend.SupplyArgsForCall(0)).To(Equal("PDQ"))
+		})
+	})
+
+	Describe("history", func() {
+		BeforeEach(func() {
+			ts := time.Unix(1000, 0)
+			fakeBackend.HistoryReturns([]*sqlview.Transfer{
+				{TxID: "tx1", BlockNumber: 5, TxIndex: 1, Action: "transfer", Timestamp: &ts},
+				{TxID: "tx0", BlockNumber: 5, TxIndex: 0, Action: "import"},
+			}, nil)
+		})
+
+		It("returns the transfers of the caller", func() {
+			resp := query(`{ history(limit: 10) { txId blockNumber txIndex action timestamp } }`, nil)
+			Expect(resp.Body).To(MatchJSON(`{"data":{"history":[
+				{"txId":"tx1","blockNumber":"5","txIndex":1,"action":"transfer","timestamp":"1970-01-01T00:16:40Z"},
+				{"txId":"tx0","blockNumber":"5","txIndex":0,"action":"import","timestamp":null}
+			]}}`))
+
+			owner, limit := fakeBackend.HistoryArgsForCall(0)
+			Expect(owner).To(Equal([]byte("alice")))
+			Expect(limit).To(Equal(10))
+		})
+
+		It("defaults the limit", func() {
+			query(`{ history { txId } }`, nil)
+			_, limit := fakeBackend.HistoryArgsForCall(0)
+			Expect(limit).To(Equal(100))
+		})
+
+		It("rejects limits out of range", func() {
+			resp := query(`{ history(limit: 5000) { txId } }`, nil)
+			Expect(resp.Body.String()).To(ContainSubstring("limit must be between 1 and 1000"))
+			Expect(fakeBackend.HistoryCallCount()).To(Equal(0))
+		})
+	})
+
+	It("rejects mutations", func() {
+		resp := query(`mutation { supply(type: "PDQ") }`, nil)
+		Expect(resp.Body.String()).To(ContainSubstring("errors"))
+		Expect(fakeBackend.SupplyCallCount()).To(Equal(0))
+	})
+
+	It("rejects methods other than POST", func() {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/graphql", nil))
+		Expect(resp.Code).To(Equal(http.StatusMethodNotAllowed))
+	})
+
+	It("rejects invalid request bodies", func() {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader("{")))
+		Expect(resp.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	Context("when authentication fails", func() {
+		BeforeEach(func() {
+			fakeAuthenticator.AuthenticateReturns(nil, errors.New("no certificate"))
+		})
+
+		It("returns unauthorized", func() {
+			resp := query(`{ supply(type: "PDQ") }`, nil)
+			Expect(resp.Code).To(Equal(http.StatusUnauthorized))
+			Expect(fakeBackend.SupplyCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -16,6 +16,12 @@ type IssuingValidator interface {
 	Validate(creator PublicInfo, tokenType string) error
 }
 
+// GovernanceValidator is used to establish if the creator can pause and resume tokens of the passed type.
+type GovernanceValidator interface {
+	// Validate returns no error if the passed creator can pause and resume tokens of the passed type, an error otherwise.
+	Validate(creator PublicInfo, tokenType string) error
+}
+
 // PublicInfo is used to identify token owners.
 type PublicInfo interface {
 	Public() []byte
@@ -0,0 +1,84 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package identity
+
+import (
+	"bytes"
+	"crypto/sha256"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+// OwnerEncoding is the representation of token owners on the ledger.
+type OwnerEncoding string
+
+const (
+	// SerializedOwners are owners stored as serialized identities.
+	SerializedOwners OwnerEncoding = "serialized"
+	// HashedOwners are owners stored as the MSP ID and hash of their serialized
+	// identity; the identity is only revealed when the owner spends the token.
+	HashedOwners OwnerEncoding = "hashed"
+)
+
+// ParseOwnerEncoding returns the OwnerEncoding with the passed name. The empty
+// name stands for SerializedOwners.
+func ParseOwnerEncoding(name string) (OwnerEncoding, error) {
+	switch OwnerEncoding(name) {
+	case "", SerializedOwners:
+		return SerializedOwners, nil
+	case HashedOwners:
+		return HashedOwners, nil
+	default:
+		return "", errors.Errorf("unknown owner encoding '%s'", name)
+	}
+}
+
+// EncodeOwner returns the owner of a token held by the passed serialized identity.
+func (e OwnerEncoding) EncodeOwner(serializedIdentity []byte) ([]byte, error) {
+	switch e {
+	case "", SerializedOwners:
+		return serializedIdentity, nil
+	case HashedOwners:
+		return HashOwner(serializedIdentity)
+	default:
+		return nil, errors.Errorf("unknown owner encoding '%s'", e)
+	}
+}
+
+// HashOwner returns the serialized HashedOwner of the passed serialized identity.
+func HashOwner(serializedIdentity []byte) ([]byte, error) {
+	sID := &msp.SerializedIdentity{}
+	err := proto.Unmarshal(serializedIdentity, sID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling serialized identity")
+	}
+	if sID.Mspid == "" {
+		return nil, errors.New("serialized identity has no MSP ID")
+	}
+
+	hash := sha256.Sum256(serializedIdentity)
+	return proto.Marshal(&token.HashedOwner{MspId: sID.Mspid, IdentityHash: hash[:]})
+}
+
+// IsOwner returns true if owner designates the passed serialized identity,
+// either directly or by way of its HashedOwner.
+func IsOwner(owner, serializedIdentity []byte) bool {
+	if bytes.Equal(owner, serializedIdentity) {
+		return true
+	}
+	if len(owner) == 0 {
+		return false
+	}
+	hashed, err := HashOwner(serializedIdentity)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(owner, hashed)
+}
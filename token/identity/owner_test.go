@@ -0,0 +1,87 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package identity_test
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/identity"
+	. "github.com/onsi/gomega"
+)
+
+func serializedIdentity(t *testing.T, mspID, id string) []byte {
+	raw, err := proto.Marshal(&msp.SerializedIdentity{Mspid: mspID, IdBytes: []byte(id)})
+	if err != nil {
+		t.Fatalf("failed marshaling identity: %s", err)
+	}
+	return raw
+}
+
+func TestHashOwner(t *testing.T) {
+	gt := NewGomegaWithT(t)
+
+	alice := serializedIdentity(t, "Org1MSP", "alice")
+	owner, err := identity.HashOwner(alice)
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	hashed := &token.HashedOwner{}
+	err = proto.Unmarshal(owner, hashed)
+	gt.Expect(err).NotTo(HaveOccurred())
+	hash := sha256.Sum256(alice)
+	gt.Expect(hashed.MspId).To(Equal("Org1MSP"))
+	gt.Expect(hashed.IdentityHash).To(Equal(hash[:]))
+
+	_, err = identity.HashOwner([]byte("garbage"))
+	gt.Expect(err).To(MatchError(ContainSubstring("failed unmarshaling serialized identity")))
+
+	_, err = identity.HashOwner(serializedIdentity(t, "", "alice"))
+	gt.Expect(err).To(MatchError("serialized identity has no MSP ID"))
+}
+
+func TestIsOwner(t *testing.T) {
+	gt := NewGomegaWithT(t)
+
+	alice := serializedIdentity(t, "Org1MSP", "alice")
+	bob := serializedIdentity(t, "Org1MSP", "bob")
+	hashedAlice, err := identity.HashOwner(alice)
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	gt.Expect(identity.IsOwner(alice, alice)).To(BeTrue())
+	gt.Expect(identity.IsOwner(hashedAlice, alice)).To(BeTrue())
+	gt.Expect(identity.IsOwner(hashedAlice, bob)).To(BeFalse())
+	gt.Expect(identity.IsOwner(bob, alice)).To(BeFalse())
+	gt.Expect(identity.IsOwner(nil, alice)).To(BeFalse())
+}
+
+func TestOwnerEncoding(t *testing.T) {
+	gt := NewGomegaWithT(t)
+
+	alice := serializedIdentity(t, "Org1MSP", "alice")
+	hashedAlice, err := identity.HashOwner(alice)
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	encoding, err := identity.ParseOwnerEncoding("")
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(encoding).To(Equal(identity.SerializedOwners))
+	owner, err := encoding.EncodeOwner(alice)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(owner).To(Equal(alice))
+
+	encoding, err = identity.ParseOwnerEncoding("hashed")
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(encoding).To(Equal(identity.HashedOwners))
+	owner, err = encoding.EncodeOwner(alice)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(owner).To(Equal(hashedAlice))
+
+	_, err = identity.ParseOwnerEncoding("base64")
+	gt.Expect(err).To(MatchError("unknown owner encoding 'base64'"))
+}
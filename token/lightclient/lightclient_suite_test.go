@@ -0,0 +1,78 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lightclient_test
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/util"
+	ledgerutil "github.com/hyperledger/fabric/core/ledger/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/protos/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestLightclient(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Lightclient Suite")
+}
+
+func ProtoMarshal(m proto.Message) []byte {
+	bytes, err := proto.Marshal(m)
+	Expect(err).NotTo(HaveOccurred())
+
+	return bytes
+}
+
+func tokenTransactionEnvelope(channelID, txID string) *cb.Envelope {
+	chdr := &cb.ChannelHeader{
+		Type:      int32(cb.HeaderType_TOKEN_TRANSACTION),
+		ChannelId: channelID,
+		TxId:      txID,
+	}
+	ttx := &token.TokenTransaction{Action: &token.TokenTransaction_PlainAction{PlainAction: &token.PlainTokenAction{
+		Data: &token.PlainTokenAction_PlainImport{PlainImport: &token.PlainImport{
+			Outputs: []*token.PlainOutput{{Owner: []byte("owner"), Type: "PDQ", Quantity: 10}},
+		}},
+	}}}
+	payload := &cb.Payload{
+		Header: &cb.Header{ChannelHeader: ProtoMarshal(chdr), SignatureHeader: ProtoMarshal(&cb.SignatureHeader{Creator: []byte("creator")})},
+		Data:   ProtoMarshal(ttx),
+	}
+	return &cb.Envelope{Payload: ProtoMarshal(payload)}
+}
+
+// nextBlock returns the block following previous with the envelopes, all
+// valid, signed by the signer like an orderer does.
+func nextBlock(previous *cb.Block, signer crypto.LocalSigner, envelopes ...*cb.Envelope) *cb.Block {
+	block := cb.NewBlock(previous.Header.Number+1, previous.Header.Hash())
+	for _, envelope := range envelopes {
+		block.Data.Data = append(block.Data.Data, ProtoMarshal(envelope))
+	}
+	block.Header.DataHash = block.Data.Hash()
+	block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER] = ledgerutil.NewTxValidationFlagsSetValue(len(envelopes), pb.TxValidationCode_VALID)
+	sign(block, signer, nil)
+	return block
+}
+
+// sign sets the signature of the orderer of the block.
+func sign(block *cb.Block, signer crypto.LocalSigner, value []byte) {
+	shdr, err := signer.NewSignatureHeader()
+	Expect(err).NotTo(HaveOccurred())
+	signature := &cb.MetadataSignature{SignatureHeader: ProtoMarshal(shdr)}
+	signature.Signature, err = signer.Sign(util.ConcatenateBytes(value, signature.SignatureHeader, block.Header.Bytes()))
+	Expect(err).NotTo(HaveOccurred())
+	block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(&cb.Metadata{
+		Value:      value,
+		Signatures: []*cb.MetadataSignature{signature},
+	})
+}
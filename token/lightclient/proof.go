@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lightclient
+
+import (
+	"github.com/hyperledger/fabric/core/ledger/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/hyperledger/fabric/token/transaction"
+	"github.com/pkg/errors"
+)
+
+// A Proof proves that a token transaction was committed in a block of a
+// channel.
+type Proof struct {
+	// Block is the block of the transaction, with its data and its metadata.
+	Block *cb.Block
+	// Headers are the headers of the blocks following Block, up to a header
+	// signed by the consenter set, when Block itself is not signed.
+	Headers []*cb.BlockHeader
+	// Signature is the threshold signature of the consenter set of the last
+	// header of the proof, of Block or the last of Headers. Without it, the
+	// signatures of the orderers in the metadata of Block are verified.
+	Signature []byte
+}
+
+// VerifyTransaction verifies that the proof includes the valid token
+// transaction of the ID, and returns the transaction.
+func (v *Verifier) VerifyTransaction(proof *Proof, txID string) (*token.TokenTransaction, error) {
+	block := proof.Block
+	if err := v.verifyData(block); err != nil {
+		return nil, err
+	}
+	if err := v.verifyHeaders(block.Header, proof.Headers); err != nil {
+		return nil, err
+	}
+	switch {
+	case len(proof.Signature) != 0:
+		last := block.Header
+		if len(proof.Headers) > 0 {
+			last = proof.Headers[len(proof.Headers)-1]
+		}
+		if _, err := v.config(last.Number); err != nil {
+			return nil, err
+		}
+		if err := v.verifyThresholdSignature(last, proof.Signature); err != nil {
+			return nil, err
+		}
+	case len(proof.Headers) > 0:
+		return nil, errors.New("the last header of the proof is not signed")
+	default:
+		if err := v.verifySignatures(block); err != nil {
+			return nil, err
+		}
+	}
+
+	flags := validationFlags(block)
+	for i, data := range block.Data.Data {
+		envelope, err := utils.GetEnvelopeFromBlock(data)
+		if err != nil {
+			continue
+		}
+		chdr, err := utils.ChannelHeader(envelope)
+		if err != nil || chdr.TxId != txID {
+			continue
+		}
+		if chdr.ChannelId != v.channelID {
+			return nil, errors.Errorf("transaction %s is for channel %s, not %s", txID, chdr.ChannelId, v.channelID)
+		}
+		if cb.HeaderType(chdr.Type) != cb.HeaderType_TOKEN_TRANSACTION {
+			return nil, errors.Errorf("transaction %s is not a token transaction", txID)
+		}
+		if i >= len(flags) {
+			return nil, errors.Errorf("block %d has no validation code for transaction %s", block.Header.Number, txID)
+		}
+		if code := flags.Flag(i); code != pb.TxValidationCode_VALID {
+			return nil, errors.Errorf("transaction %s is invalid: %s", txID, code)
+		}
+		_, ttx, _, err := transaction.UnmarshalTokenTransaction(envelope.Payload)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid token transaction "+txID)
+		}
+		return ttx, nil
+	}
+	return nil, errors.Errorf("transaction %s not found in block %d", txID, block.Header.Number)
+}
+
+func validationFlags(block *cb.Block) util.TxValidationFlags {
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(cb.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		return util.TxValidationFlags(block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+	return nil
+}
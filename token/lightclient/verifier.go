@@ -0,0 +1,246 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package lightclient verifies that token transactions were committed on a
+// channel without a full peer, for instance in mobile wallets or in bridges
+// to other networks. A Verifier trusts a config block of the channel, such as
+// its genesis block shipped with the client, and follows the configuration
+// updates through the config blocks it verifies, its checkpoints. It then
+// verifies proofs of transactions made of their block and of block headers,
+// signed by the orderers of the channel or by its consenter set.
+//
+// The orderers only sign the headers of the blocks, which cover the data of
+// the blocks but not their metadata. The validation codes of the
+// transactions, which the peers set in the metadata of the blocks they
+// commit, are therefore only as trustworthy as the peer the block of a proof
+// was fetched from. Clients that do not trust a single peer should compare
+// the proofs of several peers.
+package lightclient
+
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/crypto/tbls"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// A Verifier verifies the blocks and the token transactions of a channel
+// against the configuration of its checkpoints. It is not safe for
+// concurrent use while checkpoints are added.
+type Verifier struct {
+	// ConsenterSetKey is the public key of the consenter set of the channel,
+	// when its orderers sign the block headers with threshold key shares. It
+	// verifies the threshold signatures of the proofs.
+	ConsenterSetKey *tbls.PublicKey
+
+	channelID string
+	// checkpoints are sorted by block number
+	checkpoints []*checkpoint
+}
+
+type checkpoint struct {
+	number uint64
+	bundle *channelconfig.Bundle
+}
+
+// NewVerifier returns a Verifier trusting the config block of a channel.
+func NewVerifier(configBlock *cb.Block) (*Verifier, error) {
+	channelID, c, err := parseCheckpoint(configBlock)
+	if err != nil {
+		return nil, err
+	}
+	return &Verifier{channelID: channelID, checkpoints: []*checkpoint{c}}, nil
+}
+
+// NewPinnedVerifier returns a Verifier trusting the config block of a channel
+// whose header hashes to headerHash, the hash held by the header of the next
+// block, pinned out of band, for instance with the software of the client.
+func NewPinnedVerifier(configBlock *cb.Block, headerHash []byte) (*Verifier, error) {
+	v, err := NewVerifier(configBlock)
+	if err != nil {
+		return nil, err
+	}
+	if err := v.verifyData(configBlock); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(configBlock.Header.HashWith(v.hashingSuite()), headerHash) {
+		return nil, errors.Errorf("config block %d does not have the pinned hash %x", configBlock.Header.Number, headerHash)
+	}
+	return v, nil
+}
+
+func parseCheckpoint(configBlock *cb.Block) (string, *checkpoint, error) {
+	if configBlock.GetHeader() == nil {
+		return "", nil, errors.New("block has no header")
+	}
+	if !utils.IsConfigBlock(configBlock) {
+		return "", nil, errors.Errorf("block %d is not a config block", configBlock.Header.Number)
+	}
+	envelope, err := utils.ExtractEnvelope(configBlock, 0)
+	if err != nil {
+		return "", nil, errors.WithMessage(err, "invalid config block")
+	}
+	bundle, err := channelconfig.NewBundleFromEnvelope(envelope)
+	if err != nil {
+		return "", nil, errors.WithMessage(err, "invalid config block")
+	}
+	return bundle.ConfigtxValidator().ChainID(), &checkpoint{number: configBlock.Header.Number, bundle: bundle}, nil
+}
+
+// ChannelID returns the ID of the channel of the verifier.
+func (v *Verifier) ChannelID() string {
+	return v.channelID
+}
+
+// Checkpoint returns the number of the last config block of the verifier.
+func (v *Verifier) Checkpoint() uint64 {
+	return v.checkpoints[len(v.checkpoints)-1].number
+}
+
+// Config returns the configuration of the channel at the last checkpoint.
+func (v *Verifier) Config() *channelconfig.Bundle {
+	return v.checkpoints[len(v.checkpoints)-1].bundle
+}
+
+// AddCheckpoint verifies a config block following the last checkpoint, and
+// verifies the blocks following it against its configuration.
+func (v *Verifier) AddCheckpoint(configBlock *cb.Block) error {
+	if err := v.VerifyBlock(configBlock); err != nil {
+		return err
+	}
+	channelID, c, err := parseCheckpoint(configBlock)
+	if err != nil {
+		return err
+	}
+	if channelID != v.channelID {
+		return errors.Errorf("config block is for channel %s, not %s", channelID, v.channelID)
+	}
+	if c.number <= v.Checkpoint() {
+		return errors.Errorf("config block %d does not follow checkpoint %d", c.number, v.Checkpoint())
+	}
+	v.checkpoints = append(v.checkpoints, c)
+	return nil
+}
+
+// config returns the configuration the block of the number was signed with,
+// the one of the last checkpoint preceding it.
+func (v *Verifier) config(number uint64) (*channelconfig.Bundle, error) {
+	first := v.checkpoints[0]
+	if number < first.number {
+		return nil, errors.Errorf("block %d precedes checkpoint %d", number, first.number)
+	}
+	bundle := first.bundle
+	for _, c := range v.checkpoints[1:] {
+		if c.number >= number {
+			break
+		}
+		bundle = c.bundle
+	}
+	return bundle, nil
+}
+
+// hashingSuite returns the hashing suite of the channel, with which the
+// headers and, but for the genesis block, the data of the blocks are hashed.
+func (v *Verifier) hashingSuite() func([]byte) []byte {
+	if hashingSuite := v.checkpoints[0].bundle.ChannelConfig().HashingSuite(); hashingSuite != nil {
+		return hashingSuite
+	}
+	return util.ComputeSHA256
+}
+
+// VerifyBlock verifies that the block was signed by the orderers of the
+// channel and that its data matches its header.
+func (v *Verifier) VerifyBlock(block *cb.Block) error {
+	if err := v.verifyData(block); err != nil {
+		return err
+	}
+	return v.verifySignatures(block)
+}
+
+func (v *Verifier) verifyData(block *cb.Block) error {
+	if block.GetHeader() == nil {
+		return errors.New("block has no header")
+	}
+	dataHash := block.GetData().Hash()
+	if block.Header.Number > 0 {
+		dataHash = block.GetData().HashWith(v.hashingSuite())
+	}
+	if !bytes.Equal(dataHash, block.Header.DataHash) {
+		return errors.Errorf("data of block %d does not match its header", block.Header.Number)
+	}
+	return nil
+}
+
+// verifySignatures evaluates the block validation policy of the channel over
+// the signatures of the orderers of the block.
+func (v *Verifier) verifySignatures(block *cb.Block) error {
+	bundle, err := v.config(block.Header.Number)
+	if err != nil {
+		return err
+	}
+	metadata, err := utils.GetMetadataFromBlock(block, cb.BlockMetadataIndex_SIGNATURES)
+	if err != nil {
+		return errors.WithMessage(err, "invalid signatures of block")
+	}
+	var signatureSet []*cb.SignedData
+	for _, signature := range metadata.Signatures {
+		shdr := &cb.SignatureHeader{}
+		if err := proto.Unmarshal(signature.SignatureHeader, shdr); err != nil {
+			return errors.Wrapf(err, "invalid signature header of block %d", block.Header.Number)
+		}
+		signatureSet = append(signatureSet, &cb.SignedData{
+			Identity:  shdr.Creator,
+			Data:      util.ConcatenateBytes(metadata.Value, signature.SignatureHeader, block.Header.Bytes()),
+			Signature: signature.Signature,
+		})
+	}
+	policy, ok := bundle.PolicyManager().GetPolicy(policies.BlockValidation)
+	if !ok {
+		return errors.Errorf("no block validation policy for channel %s", v.channelID)
+	}
+	if err := policy.Evaluate(signatureSet); err != nil {
+		return errors.Wrapf(err, "block %d is not signed by the orderers of the channel", block.Header.Number)
+	}
+	return nil
+}
+
+// verifyHeaders verifies that the headers follow the header, each holding
+// the hash of the previous one.
+func (v *Verifier) verifyHeaders(header *cb.BlockHeader, headers []*cb.BlockHeader) error {
+	previous := header
+	for _, h := range headers {
+		if h == nil || h.Number != previous.Number+1 {
+			return errors.Errorf("headers do not follow block %d", previous.Number)
+		}
+		if !bytes.Equal(h.PreviousHash, previous.HashWith(v.hashingSuite())) {
+			return errors.Errorf("header of block %d does not hold the hash of block %d", h.Number, previous.Number)
+		}
+		previous = h
+	}
+	return nil
+}
+
+// verifyThresholdSignature verifies the signature of the header by the
+// consenter set of the channel.
+func (v *Verifier) verifyThresholdSignature(header *cb.BlockHeader, signature []byte) error {
+	if v.ConsenterSetKey == nil {
+		return errors.New("no consenter set key to verify threshold signatures with")
+	}
+	s, err := tbls.SignatureFromBytes(signature)
+	if err != nil {
+		return err
+	}
+	if !tbls.VerifyBlockHeader(v.ConsenterSetKey, header, s) {
+		return errors.Errorf("block %d is not signed by the consenter set of the channel", header.Number)
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lightclient_test
+
+import (
+	"github.com/golang/protobuf/proto"
+	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/crypto/tbls"
+	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/msp/mgmt/testtools"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/hyperledger/fabric/token/lightclient"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Verifier", func() {
+	var (
+		signer   crypto.LocalSigner
+		genesis  *cb.Block
+		blocks   []*cb.Block
+		verifier *lightclient.Verifier
+	)
+
+	BeforeEach(func() {
+		err := msptesttools.LoadMSPSetupForTesting()
+		Expect(err).NotTo(HaveOccurred())
+		signer = localmsp.NewSigner()
+
+		genesis, err = configtxtest.MakeGenesisBlock("testchannel")
+		Expect(err).NotTo(HaveOccurred())
+		blocks = []*cb.Block{genesis}
+		blocks = append(blocks, nextBlock(blocks[0], signer, tokenTransactionEnvelope("testchannel", "tx1"), tokenTransactionEnvelope("testchannel", "tx2")))
+		blocks = append(blocks, nextBlock(blocks[1], signer))
+		blocks = append(blocks, nextBlock(blocks[2], signer))
+
+		verifier, err = lightclient.NewVerifier(genesis)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("trusts the config block of a channel", func() {
+		Expect(verifier.ChannelID()).To(Equal("testchannel"))
+		Expect(verifier.Checkpoint()).To(Equal(uint64(0)))
+
+		_, err := lightclient.NewVerifier(blocks[1])
+		Expect(err).To(MatchError("block 1 is not a config block"))
+	})
+
+	Describe("NewPinnedVerifier", func() {
+		It("trusts the config block with the pinned hash", func() {
+			v, err := lightclient.NewPinnedVerifier(genesis, blocks[1].Header.PreviousHash)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v.Config().ConfigtxValidator().ChainID()).To(Equal("testchannel"))
+		})
+
+		It("rejects the config blocks with another hash", func() {
+			_, err := lightclient.NewPinnedVerifier(genesis, []byte{0x01, 0x02})
+			Expect(err).To(MatchError("config block 0 does not have the pinned hash 0102"))
+		})
+
+		It("rejects the config blocks whose data does not match the pinned header", func() {
+			forged := proto.Clone(genesis).(*cb.Block)
+			forged.Data.Data = append(forged.Data.Data, []byte("forged"))
+			_, err := lightclient.NewPinnedVerifier(forged, blocks[1].Header.PreviousHash)
+			Expect(err).To(MatchError("data of block 0 does not match its header"))
+		})
+	})
+
+	Describe("VerifyTransaction", func() {
+		It("returns the token transaction of a block signed by the orderers", func() {
+			ttx, err := verifier.VerifyTransaction(&lightclient.Proof{Block: blocks[1]}, "tx2")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ttx.GetPlainAction().GetPlainImport().GetOutputs()[0].Quantity).To(Equal(uint64(10)))
+		})
+
+		It("rejects the transactions not in the block", func() {
+			_, err := verifier.VerifyTransaction(&lightclient.Proof{Block: blocks[1]}, "tx3")
+			Expect(err).To(MatchError("transaction tx3 not found in block 1"))
+		})
+
+		It("rejects the invalid transactions", func() {
+			blocks[1].Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER][1] = uint8(pb.TxValidationCode_MVCC_READ_CONFLICT)
+			_, err := verifier.VerifyTransaction(&lightclient.Proof{Block: blocks[1]}, "tx2")
+			Expect(err).To(MatchError("transaction tx2 is invalid: MVCC_READ_CONFLICT"))
+		})
+
+		It("rejects the blocks whose data does not match their header", func() {
+			blocks[1].Data.Data = blocks[1].Data.Data[1:]
+			_, err := verifier.VerifyTransaction(&lightclient.Proof{Block: blocks[1]}, "tx2")
+			Expect(err).To(MatchError("data of block 1 does not match its header"))
+		})
+
+		It("rejects the blocks not signed by the orderers", func() {
+			metadata := utils.GetMetadataFromBlockOrPanic(blocks[1], cb.BlockMetadataIndex_SIGNATURES)
+			metadata.Signatures[0].Signature = []byte("forged")
+			blocks[1].Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = ProtoMarshal(metadata)
+
+			_, err := verifier.VerifyTransaction(&lightclient.Proof{Block: blocks[1]}, "tx2")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix("block 1 is not signed by the orderers of the channel"))
+		})
+
+		It("rejects the transactions of another channel", func() {
+			block := nextBlock(blocks[3], signer, tokenTransactionEnvelope("otherchannel", "tx4"))
+			_, err := verifier.VerifyTransaction(&lightclient.Proof{Block: block}, "tx4")
+			Expect(err).To(MatchError("transaction tx4 is for channel otherchannel, not testchannel"))
+		})
+
+		Context("when the consenter set signs the block headers", func() {
+			var (
+				keyShares    []*tbls.KeyShare
+				publicShares []*tbls.PublicKey
+				proof        *lightclient.Proof
+			)
+
+			BeforeEach(func() {
+				var publicKey *tbls.PublicKey
+				var err error
+				publicKey, keyShares, publicShares, err = tbls.Deal(2, 3)
+				Expect(err).NotTo(HaveOccurred())
+				verifier.ConsenterSetKey = publicKey
+
+				proof = &lightclient.Proof{
+					Block:     blocks[1],
+					Headers:   []*cb.BlockHeader{blocks[2].Header, blocks[3].Header},
+					Signature: thresholdSignature(blocks[3], keyShares[0], keyShares[2], publicShares),
+				}
+			})
+
+			It("verifies the headers up to a header signed by the consenter set", func() {
+				ttx, err := verifier.VerifyTransaction(proof, "tx1")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ttx).NotTo(BeNil())
+			})
+
+			It("rejects the headers not chained", func() {
+				proof.Headers = []*cb.BlockHeader{blocks[3].Header}
+				_, err := verifier.VerifyTransaction(proof, "tx1")
+				Expect(err).To(MatchError("headers do not follow block 1"))
+
+				header := proto.Clone(blocks[2].Header).(*cb.BlockHeader)
+				header.PreviousHash = []byte("forged")
+				proof.Headers = []*cb.BlockHeader{header, blocks[3].Header}
+				_, err = verifier.VerifyTransaction(proof, "tx1")
+				Expect(err).To(MatchError("header of block 2 does not hold the hash of block 1"))
+			})
+
+			It("rejects the signatures of other headers", func() {
+				proof.Headers = proof.Headers[:1]
+				_, err := verifier.VerifyTransaction(proof, "tx1")
+				Expect(err).To(MatchError("block 2 is not signed by the consenter set of the channel"))
+			})
+
+			It("requires the last header to be signed", func() {
+				proof.Signature = nil
+				_, err := verifier.VerifyTransaction(proof, "tx1")
+				Expect(err).To(MatchError("the last header of the proof is not signed"))
+			})
+
+			It("requires the key of the consenter set", func() {
+				verifier.ConsenterSetKey = nil
+				_, err := verifier.VerifyTransaction(proof, "tx1")
+				Expect(err).To(MatchError("no consenter set key to verify threshold signatures with"))
+			})
+		})
+	})
+
+	Describe("AddCheckpoint", func() {
+		var configBlock *cb.Block
+
+		BeforeEach(func() {
+			configBlock = cb.NewBlock(4, blocks[3].Header.Hash())
+			configBlock.Data = genesis.Data
+			configBlock.Header.DataHash = configBlock.Data.Hash()
+			sign(configBlock, signer, nil)
+		})
+
+		It("follows the config blocks signed by the orderers", func() {
+			Expect(verifier.AddCheckpoint(configBlock)).To(Succeed())
+			Expect(verifier.Checkpoint()).To(Equal(uint64(4)))
+
+			block := nextBlock(configBlock, signer, tokenTransactionEnvelope("testchannel", "tx5"))
+			_, err := verifier.VerifyTransaction(&lightclient.Proof{Block: block}, "tx5")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(verifier.AddCheckpoint(configBlock)).To(MatchError("config block 4 does not follow checkpoint 4"))
+		})
+
+		It("rejects the blocks other than config blocks", func() {
+			Expect(verifier.AddCheckpoint(blocks[2])).To(MatchError("block 2 is not a config block"))
+		})
+
+		It("rejects the config blocks not signed by the orderers", func() {
+			configBlock.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = nil
+			err := verifier.AddCheckpoint(configBlock)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix("block 4 is not signed by the orderers of the channel"))
+			Expect(verifier.Checkpoint()).To(Equal(uint64(0)))
+		})
+	})
+})
+
+// thresholdSignature returns the signature of the header of the block by the
+// consenter set, combined from the shares of two consenters.
+func thresholdSignature(block *cb.Block, first, second *tbls.KeyShare, publicShares []*tbls.PublicKey) []byte {
+	var copies []*cb.Block
+	for _, keyShare := range []*tbls.KeyShare{first, second} {
+		c := proto.Clone(block).(*cb.Block)
+		c.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = ProtoMarshal(&cb.Metadata{Value: tbls.SignBlockHeader(keyShare, block.Header)})
+		copies = append(copies, c)
+	}
+	signature, err := tbls.CombineBlockSignatures(2, publicShares, copies)
+	Expect(err).NotTo(HaveOccurred())
+	return signature.Bytes()
+}
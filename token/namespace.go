@@ -0,0 +1,11 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+// Namespace is the namespace under which the token transaction processor
+// stores the tokens in the state DB.
+const Namespace = "tms"
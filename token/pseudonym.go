@@ -0,0 +1,21 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"github.com/hyperledger/fabric/protos/token"
+)
+
+// PseudonymProofMessage returns the message signed by the pseudonyms of a
+// list request with the passed header. It binds the proofs to the nonce and
+// the creator of the command, so that they cannot be replayed by another
+// creator.
+func PseudonymProofMessage(header *token.Header) []byte {
+	message := make([]byte, 0, len(header.Nonce)+len(header.Creator))
+	message = append(message, header.Nonce...)
+	return append(message, header.Creator...)
+}
@@ -0,0 +1,55 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"math"
+	"sort"
+
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+// SelectTokens selects unspent tokens of the given type, smallest first, until
+// their total quantity covers quantity. It returns the IDs of the selected tokens
+// and their total quantity.
+func SelectTokens(tokens []*token.TokenOutput, tokenType string, quantity uint64) ([][]byte, uint64, error) {
+	if tokenType == "" {
+		return nil, 0, errors.New("token type is required")
+	}
+	if quantity == 0 {
+		return nil, 0, errors.New("quantity must be greater than 0")
+	}
+
+	var candidates []*token.TokenOutput
+	for _, t := range tokens {
+		if t.Type == tokenType && t.Quantity > 0 {
+			candidates = append(candidates, t)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Quantity < candidates[j].Quantity
+	})
+
+	var tokenIDs [][]byte
+	total := uint64(0)
+	for _, t := range candidates {
+		if total >= quantity {
+			break
+		}
+		if total > math.MaxUint64-t.Quantity {
+			break
+		}
+		tokenIDs = append(tokenIDs, t.Id)
+		total += t.Quantity
+	}
+
+	if total < quantity {
+		return nil, 0, errors.Errorf("insufficient balance of token type '%s': %d < %d", tokenType, total, quantity)
+	}
+	return tokenIDs, total, nil
+}
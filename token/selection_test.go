@@ -0,0 +1,51 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectTokens(t *testing.T) {
+	tokens := []*token.TokenOutput{
+		{Id: []byte("id1"), Type: "XYZ", Quantity: 80},
+		{Id: []byte("id2"), Type: "ABC", Quantity: 10},
+		{Id: []byte("id3"), Type: "XYZ", Quantity: 30},
+		{Id: []byte("id4"), Type: "XYZ", Quantity: 0},
+		{Id: []byte("id5"), Type: "XYZ", Quantity: 500},
+	}
+
+	tokenIDs, total, err := SelectTokens(tokens, "XYZ", 100)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("id3"), []byte("id1")}, tokenIDs)
+	assert.Equal(t, uint64(110), total)
+
+	tokenIDs, total, err = SelectTokens(tokens, "XYZ", 30)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("id3")}, tokenIDs)
+	assert.Equal(t, uint64(30), total)
+
+	_, _, err = SelectTokens(tokens, "XYZ", 611)
+	assert.EqualError(t, err, "insufficient balance of token type 'XYZ': 610 < 611")
+
+	_, _, err = SelectTokens(tokens, "", 1)
+	assert.EqualError(t, err, "token type is required")
+
+	_, _, err = SelectTokens(tokens, "XYZ", 0)
+	assert.EqualError(t, err, "quantity must be greater than 0")
+
+	huge := []*token.TokenOutput{
+		{Id: []byte("id1"), Type: "XYZ", Quantity: math.MaxUint64 - 1},
+		{Id: []byte("id2"), Type: "XYZ", Quantity: math.MaxUint64},
+	}
+	_, _, err = SelectTokens(huge, "XYZ", math.MaxUint64)
+	assert.Error(t, err)
+}
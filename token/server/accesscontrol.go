@@ -21,10 +21,21 @@ type ACLProvider interface {
 	CheckACL(resName string, channelID string, idinfo interface{}) error
 }
 
+// ACLResources holds the names of the channel resources whose policies
+// govern each kind of token command.
 type ACLResources struct {
 	IssueTokens    string
 	TransferTokens string
 	ListTokens     string
+	// RedeemTokens is the resource for redeem commands.
+	// When empty, redeem commands are checked against TransferTokens.
+	RedeemTokens string
+	// GovernTokens is the resource for pause and resume commands.
+	// When empty, these commands are checked against IssueTokens.
+	GovernTokens string
+	// SubmitTokens is the resource for the transactions submitted through
+	// the gateway. When empty, submissions are checked against TransferTokens.
+	SubmitTokens string
 }
 
 // PolicyBasedAccessControl implements token command access control functions.
@@ -42,7 +53,8 @@ func (ac *PolicyBasedAccessControl) Check(sc *token.SignedCommand, c *token.Comm
 
 	switch t := c.GetPayload().(type) {
 
-	case *token.Command_ImportRequest:
+	case *token.Command_ImportRequest, *token.Command_IssueManifestRequest:
+		// Manifests tie imports together and have the same policy as issue
 		return ac.ACLProvider.CheckACL(
 			ac.ACLResources.IssueTokens,
 			c.Header.ChannelId,
@@ -61,9 +73,8 @@ func (ac *PolicyBasedAccessControl) Check(sc *token.SignedCommand, c *token.Comm
 			signedData,
 		)
 	case *token.Command_RedeemRequest:
-		// Redeem has same policy as transfer
 		return ac.ACLProvider.CheckACL(
-			ac.ACLResources.TransferTokens,
+			ac.redeemResource(),
 			c.Header.ChannelId,
 			signedData,
 		)
@@ -84,6 +95,69 @@ func (ac *PolicyBasedAccessControl) Check(sc *token.SignedCommand, c *token.Comm
 			signedData,
 		)
 
+	case *token.Command_BalanceRequest:
+		// Balance has the same policy as list
+		return ac.ACLProvider.CheckACL(
+			ac.ACLResources.ListTokens,
+			c.Header.ChannelId,
+			signedData,
+		)
+
+	case *token.Command_CreditRequest:
+		// Credit has the same policy as transfer
+		return ac.ACLProvider.CheckACL(
+			ac.ACLResources.TransferTokens,
+			c.Header.ChannelId,
+			signedData,
+		)
+
+	case *token.Command_DebitRequest:
+		// Debit has the same policy as redeem
+		return ac.ACLProvider.CheckACL(
+			ac.redeemResource(),
+			c.Header.ChannelId,
+			signedData,
+		)
+
+	case *token.Command_ReferenceRequest:
+		// Reference lookups have the same policy as list
+		return ac.ACLProvider.CheckACL(
+			ac.ACLResources.ListTokens,
+			c.Header.ChannelId,
+			signedData,
+		)
+
+	case *token.Command_SupplyAttestationRequest:
+		// Supply attestations have the same policy as list
+		return ac.ACLProvider.CheckACL(
+			ac.ACLResources.ListTokens,
+			c.Header.ChannelId,
+			signedData,
+		)
+
+	case *token.Command_CommitAttestationRequest:
+		// Commit attestations have the same policy as list
+		return ac.ACLProvider.CheckACL(
+			ac.ACLResources.ListTokens,
+			c.Header.ChannelId,
+			signedData,
+		)
+
+	case *token.Command_CapabilitiesRequest:
+		// Capability lookups have the same policy as list
+		return ac.ACLProvider.CheckACL(
+			ac.ACLResources.ListTokens,
+			c.Header.ChannelId,
+			signedData,
+		)
+
+	case *token.Command_PauseRequest, *token.Command_ResumeRequest:
+		return ac.ACLProvider.CheckACL(
+			ac.governResource(),
+			c.Header.ChannelId,
+			signedData,
+		)
+
 	case *token.Command_ExpectationRequest:
 		if c.GetExpectationRequest().GetExpectation() == nil {
 			return errors.New("ExpectationRequest has nil Expectation")
@@ -117,3 +191,30 @@ func (ac *PolicyBasedAccessControl) checkExpectation(plainExpectation *token.Pla
 		return errors.Errorf("expectation payload type not recognized: %T", t)
 	}
 }
+
+// CheckEnvelope checks the creator of a transaction submitted through the
+// gateway against the policy of the submission resource.
+func (ac *PolicyBasedAccessControl) CheckEnvelope(channelID string, envelope *common.Envelope) error {
+	return ac.ACLProvider.CheckACL(ac.submitResource(), channelID, envelope)
+}
+
+func (ac *PolicyBasedAccessControl) redeemResource() string {
+	if ac.ACLResources.RedeemTokens != "" {
+		return ac.ACLResources.RedeemTokens
+	}
+	return ac.ACLResources.TransferTokens
+}
+
+func (ac *PolicyBasedAccessControl) governResource() string {
+	if ac.ACLResources.GovernTokens != "" {
+		return ac.ACLResources.GovernTokens
+	}
+	return ac.ACLResources.IssueTokens
+}
+
+func (ac *PolicyBasedAccessControl) submitResource() string {
+	if ac.ACLResources.SubmitTokens != "" {
+		return ac.ACLResources.SubmitTokens
+	}
+	return ac.ACLResources.TransferTokens
+}
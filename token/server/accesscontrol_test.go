@@ -12,6 +12,7 @@ import (
 	"github.com/hyperledger/fabric/token/server"
 	"github.com/hyperledger/fabric/token/server/mock"
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 )
@@ -117,6 +118,69 @@ var _ = Describe("AccessControl", func() {
 		}))
 	})
 
+	Context("when each command has its own resource", func() {
+		BeforeEach(func() {
+			aclResources.TransferTokens = "banana"
+			aclResources.RedeemTokens = "mango"
+			aclResources.ListTokens = "kiwi"
+			aclResources.GovernTokens = "papaya"
+		})
+
+		DescribeTable("checks the resource for the command",
+			func(c *token.Command, expectedResource string) {
+				command.Payload = c.Payload
+				err := pbac.Check(signedCommand, command)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeACLProvider.CheckACLCallCount()).To(Equal(1))
+				resourceName, _, _ := fakeACLProvider.CheckACLArgsForCall(0)
+				Expect(resourceName).To(Equal(expectedResource))
+			},
+			Entry("issue", &token.Command{Payload: &token.Command_ImportRequest{ImportRequest: &token.ImportRequest{}}}, "pineapple"),
+			Entry("transfer", &token.Command{Payload: &token.Command_TransferRequest{TransferRequest: &token.TransferRequest{}}}, "banana"),
+			Entry("redeem", &token.Command{Payload: &token.Command_RedeemRequest{RedeemRequest: &token.RedeemRequest{}}}, "mango"),
+			Entry("list", &token.Command{Payload: &token.Command_ListRequest{ListRequest: &token.ListRequest{}}}, "kiwi"),
+			Entry("balance", &token.Command{Payload: &token.Command_BalanceRequest{BalanceRequest: &token.BalanceRequest{}}}, "kiwi"),
+			Entry("credit", &token.Command{Payload: &token.Command_CreditRequest{CreditRequest: &token.CreditRequest{}}}, "banana"),
+			Entry("debit", &token.Command{Payload: &token.Command_DebitRequest{DebitRequest: &token.DebitRequest{}}}, "mango"),
+			Entry("reference", &token.Command{Payload: &token.Command_ReferenceRequest{ReferenceRequest: &token.ReferenceRequest{}}}, "kiwi"),
+			Entry("capabilities", &token.Command{Payload: &token.Command_CapabilitiesRequest{CapabilitiesRequest: &token.CapabilitiesRequest{}}}, "kiwi"),
+			Entry("pause", &token.Command{Payload: &token.Command_PauseRequest{PauseRequest: &token.PauseRequest{}}}, "papaya"),
+			Entry("resume", &token.Command{Payload: &token.Command_ResumeRequest{ResumeRequest: &token.ResumeRequest{}}}, "papaya"),
+			Entry("supply attestation", &token.Command{Payload: &token.Command_SupplyAttestationRequest{SupplyAttestationRequest: &token.SupplyAttestationRequest{}}}, "kiwi"),
+			Entry("commit attestation", &token.Command{Payload: &token.Command_CommitAttestationRequest{CommitAttestationRequest: &token.CommitAttestationRequest{}}}, "kiwi"),
+			Entry("issue manifest", &token.Command{Payload: &token.Command_IssueManifestRequest{IssueManifestRequest: &token.IssueManifestRequest{}}}, "pineapple"),
+		)
+
+		Context("when no redeem resource is configured", func() {
+			BeforeEach(func() {
+				aclResources.RedeemTokens = ""
+				command.Payload = &token.Command_RedeemRequest{RedeemRequest: &token.RedeemRequest{}}
+			})
+
+			It("checks redeem against the transfer resource", func() {
+				err := pbac.Check(signedCommand, command)
+				Expect(err).NotTo(HaveOccurred())
+				resourceName, _, _ := fakeACLProvider.CheckACLArgsForCall(0)
+				Expect(resourceName).To(Equal("banana"))
+			})
+		})
+
+		Context("when no govern resource is configured", func() {
+			BeforeEach(func() {
+				aclResources.GovernTokens = ""
+				command.Payload = &token.Command_PauseRequest{PauseRequest: &token.PauseRequest{}}
+			})
+
+			It("checks governance commands against the issue resource", func() {
+				err := pbac.Check(signedCommand, command)
+				Expect(err).NotTo(HaveOccurred())
+				resourceName, _, _ := fakeACLProvider.CheckACLArgsForCall(0)
+				Expect(resourceName).To(Equal("pineapple"))
+			})
+		})
+	})
+
 	Context("when the policy checker returns an error", func() {
 		BeforeEach(func() {
 			fakeACLProvider.CheckACLReturns(errors.New("wild-banana"))
@@ -262,4 +326,49 @@ var _ = Describe("AccessControl", func() {
 			Expect(err).To(MatchError("ExpectationRequest has nil PlainExpectation"))
 		})
 	})
+
+	Describe("CheckEnvelope", func() {
+		var envelope *common.Envelope
+
+		BeforeEach(func() {
+			aclResources.TransferTokens = "banana"
+			aclResources.SubmitTokens = "cherry"
+			envelope = &common.Envelope{Payload: []byte("payload"), Signature: []byte("signature")}
+		})
+
+		It("checks the envelope against the submit resource", func() {
+			err := pbac.CheckEnvelope("channel-id", envelope)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeACLProvider.CheckACLCallCount()).To(Equal(1))
+			resourceName, channelID, idinfo := fakeACLProvider.CheckACLArgsForCall(0)
+			Expect(resourceName).To(Equal("cherry"))
+			Expect(channelID).To(Equal("channel-id"))
+			Expect(idinfo).To(Equal(envelope))
+		})
+
+		Context("when no submit resource is configured", func() {
+			BeforeEach(func() {
+				aclResources.SubmitTokens = ""
+			})
+
+			It("checks the envelope against the transfer resource", func() {
+				err := pbac.CheckEnvelope("channel-id", envelope)
+				Expect(err).NotTo(HaveOccurred())
+				resourceName, _, _ := fakeACLProvider.CheckACLArgsForCall(0)
+				Expect(resourceName).To(Equal("banana"))
+			})
+		})
+
+		Context("when the policy check fails", func() {
+			BeforeEach(func() {
+				fakeACLProvider.CheckACLReturns(errors.New("no-can-do"))
+			})
+
+			It("returns the error", func() {
+				err := pbac.CheckEnvelope("channel-id", envelope)
+				Expect(err).To(MatchError("no-can-do"))
+			})
+		})
+	})
 })
@@ -0,0 +1,142 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/pkg/errors"
+)
+
+// The account model is an overlay on top of the unspent tokens of an owner:
+// a balance is the aggregate of the owner's unspent tokens of a given type,
+// credits and debits spend as many of those tokens as needed and return any
+// change to the owner in a single output. Tokens are selected smallest first,
+// by tk.SelectTokens, so that, over time, small outputs are consolidated into
+// larger ones.
+
+// ListBalances returns the balances of the creator, one for each token type.
+func (s *Prover) ListBalances(ctx context.Context, header *token.Header, request *token.BalanceRequest) (*token.CommandResponse_Balances, error) {
+	transactor, err := s.TMSManager.GetTransactor(header.ChannelId, request.Credential, header.Creator)
+	if err != nil {
+		return nil, err
+	}
+	defer transactor.Done()
+
+	unspent, err := transactor.ListTokens()
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := aggregateBalances(unspent.GetTokens())
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.CommandResponse_Balances{Balances: balances}, nil
+}
+
+// RequestCredit creates a transfer of the requested quantity from the balance
+// of the creator to the recipient.
+func (s *Prover) RequestCredit(ctx context.Context, header *token.Header, request *token.CreditRequest) (*token.CommandResponse_TokenTransaction, error) {
+	if len(request.Recipient) == 0 {
+		return nil, errors.New("recipient is required")
+	}
+
+	transactor, err := s.TMSManager.GetTransactor(header.ChannelId, request.Credential, header.Creator)
+	if err != nil {
+		return nil, err
+	}
+	defer transactor.Done()
+
+	unspent, err := transactor.ListTokens()
+	if err != nil {
+		return nil, err
+	}
+
+	tokenIDs, total, err := tk.SelectTokens(unspent.GetTokens(), request.Type, request.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	shares := []*token.RecipientTransferShare{{Recipient: request.Recipient, Quantity: request.Quantity}}
+	if total > request.Quantity {
+		shares = append(shares, &token.RecipientTransferShare{Recipient: header.Creator, Quantity: total - request.Quantity})
+	}
+
+	tokenTransaction, err := transactor.RequestTransfer(&token.TransferRequest{
+		Credential: request.Credential,
+		TokenIds:   tokenIDs,
+		Shares:     shares,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.CommandResponse_TokenTransaction{TokenTransaction: tokenTransaction}, nil
+}
+
+// RequestDebit creates a redemption of the requested quantity from the balance
+// of the creator.
+func (s *Prover) RequestDebit(ctx context.Context, header *token.Header, request *token.DebitRequest) (*token.CommandResponse_TokenTransaction, error) {
+	transactor, err := s.TMSManager.GetTransactor(header.ChannelId, request.Credential, header.Creator)
+	if err != nil {
+		return nil, err
+	}
+	defer transactor.Done()
+
+	unspent, err := transactor.ListTokens()
+	if err != nil {
+		return nil, err
+	}
+
+	tokenIDs, _, err := tk.SelectTokens(unspent.GetTokens(), request.Type, request.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenTransaction, err := transactor.RequestRedeem(&token.RedeemRequest{
+		Credential:       request.Credential,
+		TokenIds:         tokenIDs,
+		QuantityToRedeem: request.Quantity,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.CommandResponse_TokenTransaction{TokenTransaction: tokenTransaction}, nil
+}
+
+// aggregateBalances sums the unspent tokens by type. The balances are sorted by type.
+func aggregateBalances(tokens []*token.TokenOutput) (*token.Balances, error) {
+	byType := map[string]*token.Balance{}
+	for _, t := range tokens {
+		balance, ok := byType[t.Type]
+		if !ok {
+			balance = &token.Balance{Type: t.Type}
+			byType[t.Type] = balance
+		}
+		if balance.Quantity > math.MaxUint64-t.Quantity {
+			return nil, errors.Errorf("balance of token type '%s' overflows", t.Type)
+		}
+		balance.Quantity += t.Quantity
+		balance.Outputs++
+	}
+
+	balances := &token.Balances{}
+	for _, balance := range byType {
+		balances.Balances = append(balances.Balances, balance)
+	}
+	sort.Slice(balances.Balances, func(i, j int) bool {
+		return balances.Balances[i].Type < balances.Balances[j].Type
+	})
+	return balances, nil
+}
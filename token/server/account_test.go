@@ -0,0 +1,225 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server_test
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/server"
+	"github.com/hyperledger/fabric/token/server/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Account", func() {
+	var (
+		fakeTransactor *mock.Transactor
+		fakeTMSManager *mock.TMSManager
+		prover         *server.Prover
+
+		header           *token.Header
+		tokenTransaction *token.TokenTransaction
+	)
+
+	BeforeEach(func() {
+		tokenTransaction = &token.TokenTransaction{}
+
+		fakeTransactor = &mock.Transactor{}
+		fakeTransactor.ListTokensReturns(&token.UnspentTokens{
+			Tokens: []*token.TokenOutput{
+				{Id: []byte("id-1"), Type: "XYZ", Quantity: 50},
+				{Id: []byte("id-2"), Type: "PDQ", Quantity: 30},
+				{Id: []byte("id-3"), Type: "XYZ", Quantity: 10},
+				{Id: []byte("id-4"), Type: "XYZ", Quantity: 20},
+			},
+		}, nil)
+		fakeTransactor.RequestTransferReturns(tokenTransaction, nil)
+		fakeTransactor.RequestRedeemReturns(tokenTransaction, nil)
+
+		fakeTMSManager = &mock.TMSManager{}
+		fakeTMSManager.GetTransactorReturns(fakeTransactor, nil)
+
+		prover = &server.Prover{TMSManager: fakeTMSManager}
+
+		header = &token.Header{
+			ChannelId: "channel-id",
+			Creator:   []byte("creator"),
+		}
+	})
+
+	Describe("ListBalances", func() {
+		It("aggregates the unspent tokens by type", func() {
+			resp, err := prover.ListBalances(context.Background(), header, &token.BalanceRequest{Credential: []byte("credential")})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&token.CommandResponse_Balances{
+				Balances: &token.Balances{
+					Balances: []*token.Balance{
+						{Type: "PDQ", Quantity: 30, Outputs: 1},
+						{Type: "XYZ", Quantity: 80, Outputs: 3},
+					},
+				},
+			}))
+
+			Expect(fakeTMSManager.GetTransactorCallCount()).To(Equal(1))
+			channel, cred, creator := fakeTMSManager.GetTransactorArgsForCall(0)
+			Expect(channel).To(Equal("channel-id"))
+			Expect(cred).To(Equal([]byte("credential")))
+			Expect(creator).To(Equal([]byte("creator")))
+			Expect(fakeTransactor.DoneCallCount()).To(Equal(1))
+		})
+
+		Context("when listing the tokens fails", func() {
+			BeforeEach(func() {
+				fakeTransactor.ListTokensReturns(nil, errors.New("banana"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.ListBalances(context.Background(), header, &token.BalanceRequest{})
+				Expect(err).To(MatchError("banana"))
+			})
+		})
+	})
+
+	Describe("RequestCredit", func() {
+		var request *token.CreditRequest
+
+		BeforeEach(func() {
+			request = &token.CreditRequest{
+				Credential: []byte("credential"),
+				Recipient:  []byte("recipient"),
+				Type:       "XYZ",
+				Quantity:   25,
+			}
+		})
+
+		It("spends the smallest tokens first and returns the change to the creator", func() {
+			resp, err := prover.RequestCredit(context.Background(), header, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&token.CommandResponse_TokenTransaction{TokenTransaction: tokenTransaction}))
+
+			Expect(fakeTransactor.RequestTransferCallCount()).To(Equal(1))
+			Expect(fakeTransactor.RequestTransferArgsForCall(0)).To(Equal(&token.TransferRequest{
+				Credential: []byte("credential"),
+				TokenIds:   [][]byte{[]byte("id-3"), []byte("id-4")},
+				Shares: []*token.RecipientTransferShare{
+					{Recipient: []byte("recipient"), Quantity: 25},
+					{Recipient: []byte("creator"), Quantity: 5},
+				},
+			}))
+		})
+
+		Context("when the selected tokens match the quantity", func() {
+			BeforeEach(func() {
+				request.Quantity = 30
+			})
+
+			It("does not create a change output", func() {
+				_, err := prover.RequestCredit(context.Background(), header, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				tr := fakeTransactor.RequestTransferArgsForCall(0)
+				Expect(tr.Shares).To(Equal([]*token.RecipientTransferShare{
+					{Recipient: []byte("recipient"), Quantity: 30},
+				}))
+			})
+		})
+
+		Context("when the balance is insufficient", func() {
+			BeforeEach(func() {
+				request.Quantity = 81
+			})
+
+			It("returns an error", func() {
+				_, err := prover.RequestCredit(context.Background(), header, request)
+				Expect(err).To(MatchError("insufficient balance of token type 'XYZ': 80 < 81"))
+				Expect(fakeTransactor.RequestTransferCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the recipient is missing", func() {
+			BeforeEach(func() {
+				request.Recipient = nil
+			})
+
+			It("returns an error", func() {
+				_, err := prover.RequestCredit(context.Background(), header, request)
+				Expect(err).To(MatchError("recipient is required"))
+			})
+		})
+
+		Context("when the quantity is 0", func() {
+			BeforeEach(func() {
+				request.Quantity = 0
+			})
+
+			It("returns an error", func() {
+				_, err := prover.RequestCredit(context.Background(), header, request)
+				Expect(err).To(MatchError("quantity must be greater than 0"))
+			})
+		})
+
+		Context("when the transactor fails to transfer", func() {
+			BeforeEach(func() {
+				fakeTransactor.RequestTransferReturns(nil, errors.New("watermelon"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.RequestCredit(context.Background(), header, request)
+				Expect(err).To(MatchError("watermelon"))
+			})
+		})
+	})
+
+	Describe("RequestDebit", func() {
+		var request *token.DebitRequest
+
+		BeforeEach(func() {
+			request = &token.DebitRequest{
+				Credential: []byte("credential"),
+				Type:       "XYZ",
+				Quantity:   35,
+			}
+		})
+
+		It("redeems the smallest tokens first", func() {
+			resp, err := prover.RequestDebit(context.Background(), header, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&token.CommandResponse_TokenTransaction{TokenTransaction: tokenTransaction}))
+
+			Expect(fakeTransactor.RequestRedeemCallCount()).To(Equal(1))
+			Expect(fakeTransactor.RequestRedeemArgsForCall(0)).To(Equal(&token.RedeemRequest{
+				Credential:       []byte("credential"),
+				TokenIds:         [][]byte{[]byte("id-3"), []byte("id-4"), []byte("id-1")},
+				QuantityToRedeem: 35,
+			}))
+		})
+
+		Context("when the token type is missing", func() {
+			BeforeEach(func() {
+				request.Type = ""
+			})
+
+			It("returns an error", func() {
+				_, err := prover.RequestDebit(context.Background(), header, request)
+				Expect(err).To(MatchError("token type is required"))
+			})
+		})
+
+		Context("when the transactor fails to redeem", func() {
+			BeforeEach(func() {
+				fakeTransactor.RequestRedeemReturns(nil, errors.New("pineapple"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.RequestDebit(context.Background(), header, request)
+				Expect(err).To(MatchError("pineapple"))
+			})
+		})
+	})
+})
@@ -15,6 +15,15 @@ import (
 // CapabilityChecker is used to check whether or not a channel supports token functions.
 type CapabilityChecker interface {
 	FabToken(channelId string) (bool, error)
+	// HashingSuite returns the hashing algorithm of the transaction IDs of the channel
+	HashingSuite(channelId string) (string, error)
+	// TokenEncryptionKeys returns the keys, by MSP ID, to which the token
+	// transactions of the channel are encrypted, or nil if they cannot be
+	// encrypted to all the application orgs of the channel
+	TokenEncryptionKeys(channelId string) (map[string][]byte, error)
+	// TxExpiration returns true if the committing peers of the channel
+	// invalidate the transactions that have expired
+	TxExpiration(channelId string) (bool, error)
 }
 
 // TokenCapabilityChecker implements CapabilityChecker interface
@@ -29,3 +38,38 @@ func (c *TokenCapabilityChecker) FabToken(channelId string) (bool, error) {
 	}
 	return ac.Capabilities().FabToken(), nil
 }
+
+func (c *TokenCapabilityChecker) HashingSuite(channelId string) (string, error) {
+	cc := c.PeerOps.GetChannelConfig(channelId)
+	if cc == nil {
+		return "", errors.Errorf("no channel config found for channel %s", channelId)
+	}
+	return cc.ChannelConfig().HashingSuiteName(), nil
+}
+
+func (c *TokenCapabilityChecker) TokenEncryptionKeys(channelId string) (map[string][]byte, error) {
+	ac, ok := c.PeerOps.GetChannelConfig(channelId).ApplicationConfig()
+	if !ok {
+		return nil, errors.Errorf("no application config found for channel %s", channelId)
+	}
+	if !ac.Capabilities().TokenEncryption() {
+		return nil, nil
+	}
+	keys := map[string][]byte{}
+	for _, org := range ac.Organizations() {
+		key := org.TokenEncryptionKey()
+		if key == nil {
+			return nil, nil
+		}
+		keys[org.MSPID()] = key
+	}
+	return keys, nil
+}
+
+func (c *TokenCapabilityChecker) TxExpiration(channelId string) (bool, error) {
+	ac, ok := c.PeerOps.GetChannelConfig(channelId).ApplicationConfig()
+	if !ok {
+		return false, errors.Errorf("no application config found for channel %s", channelId)
+	}
+	return ac.Capabilities().TxExpiration(), nil
+}
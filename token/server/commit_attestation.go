@@ -0,0 +1,195 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//go:generate counterfeiter -o mock/commit_attestor.go -fake-name CommitAttestor . CommitAttestor
+
+// A CommitAttestor produces signed attestations of the commit of the blocks
+// of a channel.
+type CommitAttestor interface {
+	// AttestCommit attests the validation results of the block holding the
+	// transaction of the ID.
+	AttestCommit(channel, txID string) (*token.SignedCommitAttestation, error)
+}
+
+//go:generate counterfeiter -o mock/block_ledger.go -fake-name BlockLedger . BlockLedger
+
+// A BlockLedger is the ledger of a channel whose blocks are attested.
+type BlockLedger interface {
+	GetBlockByTxID(txID string) (*common.Block, error)
+}
+
+// LedgerCommitAttestor implements the CommitAttestor interface by reading the
+// blocks committed to the ledger of the peer, with the validation codes the
+// peer set, and signing the attestations with the identity of the peer. As
+// the validation codes are not signed by the orderers, clients that do not
+// trust a single peer collect the attestations of several peers, possibly
+// of several organizations, before they treat a transaction as final.
+type LedgerCommitAttestor struct {
+	// GetLedger returns the ledger of a channel, or nil if the channel is
+	// not found.
+	GetLedger func(channelID string) BlockLedger
+	// CapabilityChecker provides the hashing suite of the channel, with which
+	// the headers of the blocks are hashed.
+	CapabilityChecker CapabilityChecker
+	Signer            SignerIdentity
+	Time              TimeFunc
+}
+
+func (a *LedgerCommitAttestor) AttestCommit(channel, txID string) (*token.SignedCommitAttestation, error) {
+	l := a.GetLedger(channel)
+	if l == nil {
+		return nil, errors.Errorf("ledger not found for channel %s", channel)
+	}
+	block, err := l.GetBlockByTxID(txID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed reading the block of transaction "+txID)
+	}
+	index, err := txIndex(block, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := a.CapabilityChecker.HashingSuite(channel)
+	if err != nil {
+		return nil, err
+	}
+	hashingSuite, err := channelconfig.HashingSuiteByName(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid hashing suite of channel %s", channel)
+	}
+
+	ts, err := ptypes.TimestampProto(a.Time())
+	if err != nil {
+		return nil, err
+	}
+	identity, err := a.Signer.Serialize()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed serializing peer identity")
+	}
+	raw, err := proto.Marshal(&token.CommitAttestation{
+		ChannelId:       channel,
+		BlockNumber:     block.Header.Number,
+		BlockHash:       block.Header.HashWith(hashingSuite),
+		ValidationFlags: validationFlags(block),
+		TxId:            txID,
+		TxIndex:         uint32(index),
+		Timestamp:       ts,
+		Peer:            identity,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling commit attestation")
+	}
+	signature, err := a.Signer.Sign(raw)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed signing commit attestation")
+	}
+	return &token.SignedCommitAttestation{Attestation: raw, Signature: signature}, nil
+}
+
+// txIndex returns the index of the transaction of the ID in the block.
+func txIndex(block *common.Block, txID string) (int, error) {
+	for i, data := range block.GetData().GetData() {
+		envelope, err := utils.GetEnvelopeFromBlock(data)
+		if err != nil {
+			continue
+		}
+		chdr, err := utils.ChannelHeader(envelope)
+		if err != nil {
+			continue
+		}
+		if chdr.TxId == txID {
+			return i, nil
+		}
+	}
+	return 0, errors.Errorf("transaction %s not found in block %d", txID, block.GetHeader().GetNumber())
+}
+
+// RequestCommitAttestation returns an attestation of the validation results
+// of the block of a transaction of the channel, signed by the peer.
+func (s *Prover) RequestCommitAttestation(ctx context.Context, header *token.Header, request *token.CommitAttestationRequest) (*token.CommandResponse_CommitAttestation, error) {
+	if s.CommitAttestor == nil {
+		return nil, errors.New("commit attestations are not enabled on this peer")
+	}
+	attestation, err := s.CommitAttestor.AttestCommit(header.ChannelId, request.TxId)
+	if err != nil {
+		return nil, err
+	}
+	return &token.CommandResponse_CommitAttestation{CommitAttestation: attestation}, nil
+}
+
+// AttestCommit returns an attestation of the validation results of the block
+// of the transaction of the commit attestation request of the command, signed
+// by the peer. The command is subject to the access control of the commit
+// attestation requests of the prover.
+func (g *Gateway) AttestCommit(ctx context.Context, sc *token.SignedCommand) (*token.SignedCommitAttestation, error) {
+	if g.CommitAttestor == nil {
+		return nil, status.Error(codes.Unimplemented, "commit attestations are not enabled on this peer")
+	}
+	if !g.drainer.begin() {
+		return nil, status.Error(codes.Unavailable, "gateway is shutting down")
+	}
+	defer g.drainer.end()
+
+	command, err := UnmarshalCommand(sc.Command)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	request := command.GetCommitAttestationRequest()
+	if request == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "command %T is not a commit attestation request", command.GetPayload())
+	}
+	channelID := command.GetHeader().GetChannelId()
+	if channelID == "" || len(command.GetHeader().GetCreator()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing channel ID or creator")
+	}
+
+	enabled, err := g.CapabilityChecker.FabToken(channelID)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if !enabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "FabToken capability not enabled for channel %s", channelID)
+	}
+
+	err = g.PolicyChecker.Check(sc, command)
+	if err != nil {
+		logger.Warningf("access denied for the commit attestation of transaction %s on channel %s: %s", request.TxId, channelID, err)
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	attestation, err := g.CommitAttestor.AttestCommit(channelID, request.TxId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return attestation, nil
+}
+
+// PeerBlockLedger returns the ledger of the channel of the peer, or nil if
+// the peer has not joined the channel.
+func PeerBlockLedger(channelID string) BlockLedger {
+	l := peer.Default.GetLedger(channelID)
+	if l == nil {
+		return nil
+	}
+	return l
+}
@@ -0,0 +1,156 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/server"
+	"github.com/hyperledger/fabric/token/server/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("LedgerCommitAttestor", func() {
+	var (
+		fakeBlockLedger       *mock.BlockLedger
+		fakeCapabilityChecker *mock.CapabilityChecker
+		fakeSigner            *mock.SignerIdentity
+		committed             *common.Block
+		now                   time.Time
+		attestor              *server.LedgerCommitAttestor
+	)
+
+	BeforeEach(func() {
+		committed = block(7, pb.TxValidationCode_MVCC_READ_CONFLICT, "tx1", "tx2")
+		fakeBlockLedger = &mock.BlockLedger{}
+		fakeBlockLedger.GetBlockByTxIDReturns(committed, nil)
+		fakeCapabilityChecker = &mock.CapabilityChecker{}
+		fakeCapabilityChecker.HashingSuiteReturns("SHA3_256", nil)
+
+		fakeSigner = &mock.SignerIdentity{}
+		fakeSigner.SerializeReturns([]byte("peer0"), nil)
+		fakeSigner.SignReturns([]byte("signature"), nil)
+
+		now = time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)
+		attestor = &server.LedgerCommitAttestor{
+			GetLedger: func(channelID string) server.BlockLedger {
+				if channelID != "channel-id" {
+					return nil
+				}
+				return fakeBlockLedger
+			},
+			CapabilityChecker: fakeCapabilityChecker,
+			Signer:            fakeSigner,
+			Time:              func() time.Time { return now },
+		}
+	})
+
+	It("attests the validation results of the block of the transaction", func() {
+		signed, err := attestor.AttestCommit("channel-id", "tx2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(signed.Signature).To(Equal([]byte("signature")))
+		Expect(fakeSigner.SignArgsForCall(0)).To(Equal(signed.Attestation))
+		Expect(fakeBlockLedger.GetBlockByTxIDArgsForCall(0)).To(Equal("tx2"))
+
+		attestation := &token.CommitAttestation{}
+		Expect(proto.Unmarshal(signed.Attestation, attestation)).To(Succeed())
+		ts, err := ptypes.TimestampProto(now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(proto.Equal(attestation, &token.CommitAttestation{
+			ChannelId:       "channel-id",
+			BlockNumber:     7,
+			BlockHash:       committed.Header.HashWith(util.ComputeSHA3256),
+			ValidationFlags: []byte{uint8(pb.TxValidationCode_VALID), uint8(pb.TxValidationCode_MVCC_READ_CONFLICT)},
+			TxId:            "tx2",
+			TxIndex:         1,
+			Timestamp:       ts,
+			Peer:            []byte("peer0"),
+		})).To(BeTrue())
+	})
+
+	Context("when the channel is not found", func() {
+		It("returns an error", func() {
+			_, err := attestor.AttestCommit("missing", "tx1")
+			Expect(err).To(MatchError("ledger not found for channel missing"))
+		})
+	})
+
+	Context("when the transaction is not committed", func() {
+		BeforeEach(func() {
+			fakeBlockLedger.GetBlockByTxIDReturns(nil, errors.New("no such transaction ID"))
+		})
+
+		It("returns an error", func() {
+			_, err := attestor.AttestCommit("channel-id", "tx3")
+			Expect(err).To(MatchError("failed reading the block of transaction tx3: no such transaction ID"))
+		})
+	})
+
+	Context("when the block does not hold the transaction", func() {
+		It("returns an error", func() {
+			_, err := attestor.AttestCommit("channel-id", "tx3")
+			Expect(err).To(MatchError("transaction tx3 not found in block 7"))
+		})
+	})
+
+	Context("when signing fails", func() {
+		BeforeEach(func() {
+			fakeSigner.SignReturns(nil, errors.New("no-key"))
+		})
+
+		It("returns an error", func() {
+			_, err := attestor.AttestCommit("channel-id", "tx1")
+			Expect(err).To(MatchError("failed signing commit attestation: no-key"))
+		})
+	})
+})
+
+var _ = Describe("RequestCommitAttestation", func() {
+	var (
+		fakeAttestor *mock.CommitAttestor
+		prover       *server.Prover
+		header       *token.Header
+	)
+
+	BeforeEach(func() {
+		fakeAttestor = &mock.CommitAttestor{}
+		fakeAttestor.AttestCommitReturns(&token.SignedCommitAttestation{Attestation: []byte("attestation")}, nil)
+		prover = &server.Prover{CommitAttestor: fakeAttestor}
+		header = &token.Header{ChannelId: "channel-id"}
+	})
+
+	It("returns the attestation of the commit", func() {
+		resp, err := prover.RequestCommitAttestation(context.Background(), header, &token.CommitAttestationRequest{TxId: "tx1"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp).To(Equal(&token.CommandResponse_CommitAttestation{
+			CommitAttestation: &token.SignedCommitAttestation{Attestation: []byte("attestation")},
+		}))
+		channel, txID := fakeAttestor.AttestCommitArgsForCall(0)
+		Expect(channel).To(Equal("channel-id"))
+		Expect(txID).To(Equal("tx1"))
+	})
+
+	Context("when commit attestations are not enabled", func() {
+		BeforeEach(func() {
+			prover.CommitAttestor = nil
+		})
+
+		It("returns an error", func() {
+			_, err := prover.RequestCommitAttestation(context.Background(), header, &token.CommitAttestationRequest{})
+			Expect(err).To(MatchError("commit attestations are not enabled on this peer"))
+		})
+	})
+})
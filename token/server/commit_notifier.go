@@ -0,0 +1,124 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// CommitNotifier implements the CommitWaiter interface with the notifications
+// of the commit pipeline of the peer, which passes it every block it commits
+// through BlockCommitted. Unlike the LedgerCommitWaiter, waiters do not read
+// the committed blocks back from the ledger.
+type CommitNotifier struct {
+	// GetLedger returns the ledger of a channel, or nil if the channel is
+	// not found. The transactions committed before the wait started are
+	// looked up in it.
+	GetLedger func(channelID string) CommitLedger
+
+	mutex   sync.Mutex
+	waiters map[string]map[string][]chan pb.TxValidationCode
+}
+
+func (n *CommitNotifier) WaitForCommit(ctx context.Context, channelID, txID string) (pb.TxValidationCode, error) {
+	l := n.GetLedger(channelID)
+	if l == nil {
+		return pb.TxValidationCode_NOT_VALIDATED, errors.Errorf("ledger not found for channel %s", channelID)
+	}
+
+	// the waiter is registered before looking the transaction up, so that
+	// a transaction committed in between is notified
+	committed := make(chan pb.TxValidationCode, 1)
+	n.register(channelID, txID, committed)
+	defer n.unregister(channelID, txID, committed)
+
+	tx, err := l.GetTransactionByID(txID)
+	if err == nil {
+		return pb.TxValidationCode(tx.ValidationCode), nil
+	}
+
+	select {
+	case code := <-committed:
+		return code, nil
+	case <-ctx.Done():
+		return pb.TxValidationCode_NOT_VALIDATED, errors.Errorf("transaction %s not committed: %s", txID, ctx.Err())
+	}
+}
+
+// BlockCommitted notifies the waiters of the transactions of a block
+// committed on a channel of their validation code.
+func (n *CommitNotifier) BlockCommitted(channelID string, block *common.Block) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	waiters := n.waiters[channelID]
+	if len(waiters) == 0 {
+		return
+	}
+
+	flags := validationFlags(block)
+	for i, data := range block.GetData().GetData() {
+		envelope, err := utils.GetEnvelopeFromBlock(data)
+		if err != nil {
+			continue
+		}
+		chdr, err := utils.ChannelHeader(envelope)
+		if err != nil {
+			continue
+		}
+		chans, ok := waiters[chdr.TxId]
+		if !ok {
+			continue
+		}
+		code := pb.TxValidationCode_NOT_VALIDATED
+		if i < len(flags) {
+			code = flags.Flag(i)
+		}
+		// the channels are buffered and notified once
+		for _, c := range chans {
+			c <- code
+		}
+		delete(waiters, chdr.TxId)
+	}
+}
+
+func (n *CommitNotifier) register(channelID, txID string, c chan pb.TxValidationCode) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if n.waiters == nil {
+		n.waiters = map[string]map[string][]chan pb.TxValidationCode{}
+	}
+	if n.waiters[channelID] == nil {
+		n.waiters[channelID] = map[string][]chan pb.TxValidationCode{}
+	}
+	n.waiters[channelID][txID] = append(n.waiters[channelID][txID], c)
+}
+
+func (n *CommitNotifier) unregister(channelID, txID string, c chan pb.TxValidationCode) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	chans := n.waiters[channelID][txID]
+	for i := range chans {
+		if chans[i] == c {
+			chans = append(chans[:i], chans[i+1:]...)
+			break
+		}
+	}
+	if len(chans) == 0 {
+		delete(n.waiters[channelID], txID)
+	} else {
+		n.waiters[channelID][txID] = chans
+	}
+	if len(n.waiters[channelID]) == 0 {
+		delete(n.waiters, channelID)
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server_test
+
+import (
+	"context"
+
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/token/server"
+	"github.com/hyperledger/fabric/token/server/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("CommitNotifier", func() {
+	var (
+		fakeLedger *mock.CommitLedger
+		notifier   *server.CommitNotifier
+	)
+
+	BeforeEach(func() {
+		fakeLedger = &mock.CommitLedger{}
+		fakeLedger.GetTransactionByIDReturns(nil, errors.New("not found"))
+
+		notifier = &server.CommitNotifier{
+			GetLedger: func(channelID string) server.CommitLedger {
+				if channelID != "channel-id" {
+					return nil
+				}
+				return fakeLedger
+			},
+		}
+	})
+
+	// wait waits for the commit of tx-id in the background, once the waiter
+	// is registered
+	wait := func(ctx context.Context) (<-chan pb.TxValidationCode, <-chan error) {
+		codes := make(chan pb.TxValidationCode, 1)
+		errs := make(chan error, 1)
+		registered := make(chan struct{})
+		fakeLedger.GetTransactionByIDStub = func(string) (*pb.ProcessedTransaction, error) {
+			close(registered)
+			return nil, errors.New("not found")
+		}
+		go func() {
+			code, err := notifier.WaitForCommit(ctx, "channel-id", "tx-id")
+			codes <- code
+			errs <- err
+		}()
+		Eventually(registered).Should(BeClosed())
+		return codes, errs
+	}
+
+	It("returns the validation code of a committed transaction", func() {
+		fakeLedger.GetTransactionByIDReturns(&pb.ProcessedTransaction{ValidationCode: int32(pb.TxValidationCode_MVCC_READ_CONFLICT)}, nil)
+
+		code, err := notifier.WaitForCommit(context.Background(), "channel-id", "tx-id")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(code).To(Equal(pb.TxValidationCode_MVCC_READ_CONFLICT))
+		Expect(fakeLedger.GetTransactionByIDArgsForCall(0)).To(Equal("tx-id"))
+	})
+
+	It("is notified of the block including the transaction", func() {
+		codes, errs := wait(context.Background())
+
+		notifier.BlockCommitted("other-channel-id", block(7, pb.TxValidationCode_VALID, "tx-id"))
+		notifier.BlockCommitted("channel-id", block(7, pb.TxValidationCode_VALID, "other-tx-id"))
+		Consistently(codes).ShouldNot(Receive())
+
+		notifier.BlockCommitted("channel-id", block(8, pb.TxValidationCode_ENDORSEMENT_POLICY_FAILURE, "another-tx-id", "tx-id"))
+		Eventually(codes).Should(Receive(Equal(pb.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)))
+		Expect(<-errs).NotTo(HaveOccurred())
+	})
+
+	It("notifies every waiter of the transaction", func() {
+		codes1, _ := wait(context.Background())
+		codes2, _ := wait(context.Background())
+
+		notifier.BlockCommitted("channel-id", block(7, pb.TxValidationCode_VALID, "tx-id"))
+		Eventually(codes1).Should(Receive(Equal(pb.TxValidationCode_VALID)))
+		Eventually(codes2).Should(Receive(Equal(pb.TxValidationCode_VALID)))
+	})
+
+	Context("when ctx is done", func() {
+		It("returns an error", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			_, errs := wait(ctx)
+			cancel()
+			Eventually(errs).Should(Receive(MatchError("transaction tx-id not committed: context canceled")))
+		})
+	})
+
+	Context("when the channel is not found", func() {
+		It("returns an error", func() {
+			_, err := notifier.WaitForCommit(context.Background(), "missing-channel", "tx-id")
+			Expect(err).To(MatchError("ledger not found for channel missing-channel"))
+		})
+	})
+})
@@ -0,0 +1,131 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+//go:generate counterfeiter -o mock/flusher.go -fake-name Flusher . Flusher
+
+// A Flusher holds buffered data, such as an audit log, that must be written
+// out before the prover stops.
+type Flusher interface {
+	Flush() error
+}
+
+// drainer tracks the commands in flight so that the prover can stop accepting
+// new commands and wait for the in-flight ones to finish before shutting down.
+// The zero value is ready to use.
+type drainer struct {
+	mutex    sync.Mutex
+	draining bool
+	inflight int
+	idle     chan struct{}
+}
+
+// begin registers a new command. It returns false once draining has started.
+func (d *drainer) begin() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.draining {
+		return false
+	}
+	d.inflight++
+	return true
+}
+
+// end marks a command registered with begin as complete.
+func (d *drainer) end() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.inflight--
+	if d.draining && d.inflight == 0 && d.idle != nil {
+		close(d.idle)
+		d.idle = nil
+	}
+}
+
+// drain stops new commands from being accepted and waits until the in-flight
+// commands complete or ctx is done.
+func (d *drainer) drain(ctx context.Context) error {
+	d.mutex.Lock()
+	d.draining = true
+	if d.inflight == 0 {
+		d.mutex.Unlock()
+		return nil
+	}
+	if d.idle == nil {
+		d.idle = make(chan struct{})
+	}
+	idle := d.idle
+	d.mutex.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		d.mutex.Lock()
+		inflight := d.inflight
+		d.mutex.Unlock()
+		return errors.Errorf("timed out waiting for %d in-flight commands", inflight)
+	}
+}
+
+func (d *drainer) isDraining() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.draining
+}
+
+// HealthCheck reports the prover as not ready once it has started draining,
+// so that load balancers stop routing new commands to it.
+func (s *Prover) HealthCheck(ctx context.Context) error {
+	if s.drainer.isDraining() {
+		return errors.New("prover is shutting down")
+	}
+	return nil
+}
+
+// Shutdown stops the prover from accepting new commands, waits for the
+// in-flight commands to finish assembling their transactions and flushes the
+// Flushers. Commands received after Shutdown is called are rejected with an
+// Unavailable status so clients can retry against another prover.
+func (s *Prover) Shutdown(ctx context.Context) error {
+	drainErr := s.Drain(ctx)
+	err := s.Flush()
+	if err != nil {
+		return err
+	}
+	return drainErr
+}
+
+// Drain stops the prover from accepting new commands and waits for the
+// in-flight commands to finish, without flushing the Flushers, so that a
+// peer can flush them once it stopped committing blocks.
+func (s *Prover) Drain(ctx context.Context) error {
+	logger.Info("Draining prover")
+	err := s.drainer.drain(ctx)
+	if err != nil {
+		logger.Warningf("Prover did not drain cleanly: %s", err)
+	}
+	return err
+}
+
+// Flush flushes the Flushers.
+func (s *Prover) Flush() error {
+	for _, f := range s.Flushers {
+		err := f.Flush()
+		if err != nil {
+			return errors.WithMessage(err, "failed flushing prover state")
+		}
+	}
+	return nil
+}
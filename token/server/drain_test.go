@@ -0,0 +1,136 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/server"
+	"github.com/hyperledger/fabric/token/server/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("Prover drain", func() {
+	var (
+		fakeCapabilityChecker *mock.CapabilityChecker
+		fakeMarshaler         *mock.Marshaler
+		fakeFlusher           *mock.Flusher
+		prover                *server.Prover
+		signedCommand         *token.SignedCommand
+		release               chan struct{}
+		started               chan struct{}
+	)
+
+	BeforeEach(func() {
+		release = make(chan struct{})
+		started = make(chan struct{}, 1)
+
+		fakeCapabilityChecker = &mock.CapabilityChecker{}
+		fakeCapabilityChecker.FabTokenStub = func(string) (bool, error) {
+			started <- struct{}{}
+			<-release
+			return true, nil
+		}
+		fakeMarshaler = &mock.Marshaler{}
+		fakeMarshaler.MarshalCommandResponseReturns(&token.SignedCommandResponse{Response: []byte("response")}, nil)
+		fakeFlusher = &mock.Flusher{}
+		fakeTMSManager := &mock.TMSManager{}
+		fakeTMSManager.GetTransactorReturns(nil, errors.New("no transactor"))
+
+		prover = &server.Prover{
+			CapabilityChecker: fakeCapabilityChecker,
+			Marshaler:         fakeMarshaler,
+			PolicyChecker:     &mock.PolicyChecker{},
+			TMSManager:        fakeTMSManager,
+			Flushers:          []server.Flusher{fakeFlusher},
+		}
+
+		signedCommand = &token.SignedCommand{
+			Command: ProtoMarshal(&token.Command{
+				Header: &token.Header{
+					ChannelId: "channel-id",
+					Creator:   []byte("creator"),
+					Nonce:     []byte("nonce"),
+				},
+				Payload: &token.Command_ListRequest{ListRequest: &token.ListRequest{}},
+			}),
+		}
+	})
+
+	It("reports ready until shutdown starts", func() {
+		Expect(prover.HealthCheck(context.Background())).To(Succeed())
+		Expect(prover.Shutdown(context.Background())).To(Succeed())
+		Expect(prover.HealthCheck(context.Background())).To(MatchError("prover is shutting down"))
+	})
+
+	It("waits for in-flight commands before flushing", func() {
+		done := make(chan *token.SignedCommandResponse)
+		go func() {
+			resp, _ := prover.ProcessCommand(context.Background(), signedCommand)
+			done <- resp
+		}()
+		Eventually(started).Should(Receive())
+
+		shutdown := make(chan error)
+		go func() { shutdown <- prover.Shutdown(context.Background()) }()
+
+		Eventually(func() error { return prover.HealthCheck(context.Background()) }).Should(HaveOccurred())
+		Consistently(shutdown).ShouldNot(Receive())
+		Expect(fakeFlusher.FlushCallCount()).To(Equal(0))
+
+		close(release)
+		Eventually(done).Should(Receive(Equal(&token.SignedCommandResponse{Response: []byte("response")})))
+		Eventually(shutdown).Should(Receive(BeNil()))
+		Expect(fakeFlusher.FlushCallCount()).To(Equal(1))
+	})
+
+	It("rejects new commands once draining", func() {
+		Expect(prover.Shutdown(context.Background())).To(Succeed())
+
+		_, err := prover.ProcessCommand(context.Background(), signedCommand)
+		Expect(status.Code(err)).To(Equal(codes.Unavailable))
+		Expect(fakeCapabilityChecker.FabTokenCallCount()).To(Equal(0))
+	})
+
+	It("gives up waiting when the context expires", func() {
+		go prover.ProcessCommand(context.Background(), signedCommand)
+		Eventually(started).Should(Receive())
+		defer close(release)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		err := prover.Shutdown(ctx)
+		Expect(err).To(MatchError("timed out waiting for 1 in-flight commands"))
+		Expect(fakeFlusher.FlushCallCount()).To(Equal(1))
+	})
+
+	It("does not flush when draining", func() {
+		Expect(prover.Drain(context.Background())).To(Succeed())
+		Expect(prover.HealthCheck(context.Background())).To(MatchError("prover is shutting down"))
+		Expect(fakeFlusher.FlushCallCount()).To(Equal(0))
+
+		Expect(prover.Flush()).To(Succeed())
+		Expect(fakeFlusher.FlushCallCount()).To(Equal(1))
+	})
+
+	Context("when flushing fails", func() {
+		BeforeEach(func() {
+			fakeFlusher.FlushReturns(errors.New("disk full"))
+		})
+
+		It("returns an error", func() {
+			err := prover.Shutdown(context.Background())
+			Expect(err).To(MatchError("failed flushing prover state: disk full"))
+		})
+	})
+})
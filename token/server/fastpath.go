@@ -0,0 +1,72 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/token/transaction"
+)
+
+// A FastPath serves the intra-org transfers submitted through the gateway on
+// the channels whose peers are all operated by the org of the peer, for
+// private consortium deployments. The gateway checks these transfers against
+// the submission policy as any other transaction, but waits for their commit
+// with the notifications of the commit pipeline of the peer rather than by
+// reading the ledger. They are validated at commit time as any other
+// transaction.
+type FastPath struct {
+	// Channels are the channels on which the fast path is taken
+	Channels map[string]bool
+	// MSPID is the MSP ID of the org of the peer
+	MSPID string
+	// CommitWaiter waits for the commit of the transactions taking the fast
+	// path, typically a CommitNotifier
+	CommitWaiter CommitWaiter
+}
+
+// Takes returns true if a transaction submitted on a channel is an intra-org
+// transfer taking the fast path: a plain transfer whose creator and owners
+// are all members of the org of the peer. The creator is the one claimed by
+// the envelope, so Takes must only be called on envelopes whose signature
+// was checked.
+func (f *FastPath) Takes(channelID string, envelope *common.Envelope) bool {
+	if f == nil || !f.Channels[channelID] {
+		return false
+	}
+
+	_, ttx, creator, err := transaction.UnmarshalTokenTransaction(envelope.Payload)
+	if err != nil {
+		return false
+	}
+	transfer := ttx.GetPlainAction().GetPlainTransfer()
+	if transfer == nil || len(transfer.Delegations) != 0 {
+		return false
+	}
+	if !f.isMember(creator.Public()) {
+		return false
+	}
+	for _, output := range transfer.Outputs {
+		if !f.isMember(output.Owner) {
+			return false
+		}
+	}
+	return true
+}
+
+// isMember returns true if the serialized identity or hashed owner belongs
+// to the org of the peer.
+func (f *FastPath) isMember(owner []byte) bool {
+	// HashedOwner shares the wire format of SerializedIdentity, so both
+	// decode as one
+	sID := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(owner, sID); err != nil {
+		return false
+	}
+	return sID.Mspid != "" && sID.Mspid == f.MSPID
+}
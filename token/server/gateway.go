@@ -0,0 +1,320 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/committer/invalidation"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	"github.com/hyperledger/fabric/token/transaction"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultGatewayMaxAttempts   = 3
+	defaultGatewayRetryInterval = time.Second
+	defaultGatewayCommitTimeout = time.Minute
+)
+
+//go:generate counterfeiter -o mock/broadcaster.go -fake-name Broadcaster . Broadcaster
+
+// A Broadcaster sends transactions to the ordering service of a channel.
+type Broadcaster interface {
+	// Broadcast sends the envelope to an orderer of the channel and returns
+	// an error unless the orderer accepted it. Each call may select another
+	// orderer, so that retries reach the orderers that are still available.
+	Broadcast(ctx context.Context, channelID string, envelope *common.Envelope) error
+}
+
+//go:generate counterfeiter -o mock/commit_waiter.go -fake-name CommitWaiter . CommitWaiter
+
+// A CommitWaiter waits for transactions to be committed to the ledger of the peer.
+type CommitWaiter interface {
+	// WaitForCommit blocks until the transaction is committed or ctx is done,
+	// and returns the validation code of the transaction.
+	WaitForCommit(ctx context.Context, channelID, txID string) (pb.TxValidationCode, error)
+}
+
+//go:generate counterfeiter -o mock/envelope_checker.go -fake-name EnvelopeChecker . EnvelopeChecker
+
+// An EnvelopeChecker performs access control checks on the transactions
+// submitted through the gateway.
+type EnvelopeChecker interface {
+	CheckEnvelope(channelID string, envelope *common.Envelope) error
+}
+
+//go:generate counterfeiter -o mock/conflict_advisor.go -fake-name ConflictAdvisor . ConflictAdvisor
+
+// A ConflictAdvisor tracks the ledger keys recently found in conflicts between
+// transactions.
+type ConflictAdvisor interface {
+	// Advise returns the hotspots among the keys, or nil if none of them is
+	// a hotspot.
+	Advise(channelID string, keys []invalidation.Key) *pb.ConflictAdvisory
+}
+
+// A Gateway submits token transactions, assembled and signed by clients, to
+// the ordering service and reports their progress back to the clients. It
+// takes care of selecting the orderers, retrying and waiting for the commit,
+// so that constrained clients only need a connection to the gateway peer.
+type Gateway struct {
+	Broadcaster       Broadcaster
+	CommitWaiter      CommitWaiter
+	EnvelopeChecker   EnvelopeChecker
+	CapabilityChecker CapabilityChecker
+	// ConflictAdvisor, when set, reports the inputs of the invalid
+	// transactions that are conflict hotspots, so that clients can select
+	// other tokens when retrying.
+	ConflictAdvisor ConflictAdvisor
+	// MaxAttempts is the number of times a transaction is broadcast before
+	// the gateway gives up. Zero means 3.
+	MaxAttempts int
+	// RetryInterval is the time between two broadcast attempts. Zero means a
+	// second.
+	RetryInterval time.Duration
+	// CommitTimeout bounds the wait for the commit of a transaction. Zero
+	// means a minute.
+	CommitTimeout time.Duration
+	// FastPath, when set, serves the intra-org transfers of its channels
+	// without the submission policy check, and waits for their commit with
+	// its CommitWaiter.
+	FastPath *FastPath
+	// CommitAttestor, when set, serves the commit attestations of the peer
+	// through the gateway, for the clients collecting the attestations of
+	// several peers. The requests are checked by the PolicyChecker.
+	CommitAttestor CommitAttestor
+	PolicyChecker  PolicyChecker
+
+	drainer drainer
+	once    sync.Once
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+// Submit validates the submitted transaction and, once accepted, submits it
+// in the background: the submission completes even if the client goes away,
+// which it may do as soon as it received the ACCEPTED status.
+func (g *Gateway) Submit(req *token.SubmitRequest, stream token.Gateway_SubmitServer) error {
+	if !g.drainer.begin() {
+		return status.Error(codes.Unavailable, "gateway is shutting down")
+	}
+
+	envelope, chdr, fastPath, err := g.accept(req)
+	if err != nil {
+		g.drainer.end()
+		return err
+	}
+
+	lg := logger.ForTransaction(chdr.ChannelId, chdr.TxId, nil)
+	lg.Debugf("accepted transaction, waiting for commit: %t, fast path: %t", req.WaitForCommit, fastPath)
+
+	// buffered for every status of a submission, so that the submission
+	// never blocks on a client that stopped reading
+	statuses := make(chan *token.SubmitStatus, 3)
+	go func() {
+		defer g.drainer.end()
+		defer close(statuses)
+		g.submit(lg, envelope, chdr, req.WaitForCommit, fastPath, statuses)
+	}()
+
+	for s := range statuses {
+		err := stream.Send(s)
+		if err != nil {
+			lg.Debugf("client went away, submitting in the background: %s", err)
+			return nil
+		}
+	}
+	return nil
+}
+
+// accept checks that the request carries a token transaction the creator
+// may submit to a channel with FabToken enabled, and returns whether it takes
+// the fast path.
+func (g *Gateway) accept(req *token.SubmitRequest) (*common.Envelope, *common.ChannelHeader, bool, error) {
+	envelope, err := utils.UnmarshalEnvelope(req.Envelope)
+	if err != nil {
+		return nil, nil, false, status.Error(codes.InvalidArgument, err.Error())
+	}
+	chdr, err := utils.ChannelHeader(envelope)
+	if err != nil {
+		return nil, nil, false, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if chdr.Type != int32(common.HeaderType_TOKEN_TRANSACTION) {
+		return nil, nil, false, status.Errorf(codes.InvalidArgument, "invalid transaction type %s, expected %s", common.HeaderType(chdr.Type), common.HeaderType_TOKEN_TRANSACTION)
+	}
+	if chdr.ChannelId == "" || chdr.TxId == "" {
+		return nil, nil, false, status.Error(codes.InvalidArgument, "missing channel ID or transaction ID")
+	}
+
+	enabled, err := g.CapabilityChecker.FabToken(chdr.ChannelId)
+	if err != nil {
+		return nil, nil, false, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if !enabled {
+		return nil, nil, false, status.Errorf(codes.FailedPrecondition, "FabToken capability not enabled for channel %s", chdr.ChannelId)
+	}
+
+	err = g.EnvelopeChecker.CheckEnvelope(chdr.ChannelId, envelope)
+	if err != nil {
+		logger.Warningf("access denied for transaction %s on channel %s: %s", chdr.TxId, chdr.ChannelId, err)
+		return nil, nil, false, status.Error(codes.PermissionDenied, err.Error())
+	}
+	return envelope, chdr, g.FastPath.Takes(chdr.ChannelId, envelope), nil
+}
+
+func (g *Gateway) submit(lg *flogging.FabricLogger, envelope *common.Envelope, chdr *common.ChannelHeader, waitForCommit, fastPath bool, statuses chan<- *token.SubmitStatus) {
+	ctx := g.context()
+	report := func(phase token.SubmitStatus_Phase, validationCode, message string) {
+		statuses <- &token.SubmitStatus{TxId: chdr.TxId, Phase: phase, ValidationCode: validationCode, Message: message}
+	}
+	report(token.SubmitStatus_ACCEPTED, "", "")
+
+	err := g.broadcast(ctx, chdr.ChannelId, envelope)
+	if err != nil {
+		lg.Warningf("failed submitting transaction: %s", err)
+		report(token.SubmitStatus_FAILED, "", err.Error())
+		return
+	}
+	report(token.SubmitStatus_ORDERED, "", "")
+	if !waitForCommit {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, g.commitTimeout())
+	defer cancel()
+	commitWaiter := g.CommitWaiter
+	if fastPath {
+		commitWaiter = g.FastPath.CommitWaiter
+	}
+	code, err := commitWaiter.WaitForCommit(ctx, chdr.ChannelId, chdr.TxId)
+	switch {
+	case err != nil:
+		report(token.SubmitStatus_FAILED, "", errors.WithMessage(err, "failed waiting for commit").Error())
+	case code == pb.TxValidationCode_VALID:
+		report(token.SubmitStatus_COMMITTED, code.String(), "")
+	default:
+		statuses <- &token.SubmitStatus{
+			TxId:           chdr.TxId,
+			Phase:          token.SubmitStatus_INVALID,
+			ValidationCode: code.String(),
+			InputHotspots:  g.inputHotspots(chdr.ChannelId, envelope),
+		}
+	}
+}
+
+// inputHotspots returns the inputs of the transaction that are conflict
+// hotspots. The inputs of encrypted transactions are not known.
+func (g *Gateway) inputHotspots(channelID string, envelope *common.Envelope) []*token.InputHotspot {
+	if g.ConflictAdvisor == nil {
+		return nil
+	}
+	_, ttx, _, err := transaction.UnmarshalTokenTransaction(envelope.Payload)
+	if err != nil {
+		return nil
+	}
+	var keys []invalidation.Key
+	for _, input := range plain.SpentInputs(ttx) {
+		spentKey, err := plain.SpentKey(input)
+		if err != nil {
+			continue
+		}
+		keys = append(keys, invalidation.Key{Namespace: plain.Namespace, Key: spentKey})
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	var hotspots []*token.InputHotspot
+	for _, h := range g.ConflictAdvisor.Advise(channelID, keys).GetHotspots() {
+		hotspot := &token.InputHotspot{
+			Namespace: h.Namespace,
+			Key:       h.Key,
+			Conflicts: h.Conflicts,
+			TxIds:     h.TxIds,
+		}
+		if input := plain.ParseSpentKey(h.Key); input != nil {
+			hotspot.TokenId, _ = plain.TokenID(input)
+		}
+		hotspots = append(hotspots, hotspot)
+	}
+	return hotspots
+}
+
+// broadcast sends the envelope to the ordering service, retrying up to
+// MaxAttempts times.
+func (g *Gateway) broadcast(ctx context.Context, channelID string, envelope *common.Envelope) error {
+	for attempt := 1; ; attempt++ {
+		err := g.Broadcaster.Broadcast(ctx, channelID, envelope)
+		if err == nil {
+			return nil
+		}
+		if attempt >= g.maxAttempts() {
+			return errors.WithMessage(err, "failed broadcasting transaction")
+		}
+		logger.Debugf("broadcast attempt %d on channel %s failed, retrying: %s", attempt, channelID, err)
+
+		select {
+		case <-time.After(g.retryInterval()):
+		case <-ctx.Done():
+			return errors.WithMessage(err, "gateway shut down before broadcasting transaction")
+		}
+	}
+}
+
+// context returns the context of the background submissions, which is
+// canceled when the gateway shuts down.
+func (g *Gateway) context() context.Context {
+	g.once.Do(func() {
+		g.ctx, g.cancel = context.WithCancel(context.Background())
+	})
+	return g.ctx
+}
+
+// Shutdown stops the gateway from accepting new transactions and waits for
+// the submissions in progress to complete. The submissions still in progress
+// when ctx is done are abandoned.
+func (g *Gateway) Shutdown(ctx context.Context) error {
+	logger.Info("Draining token gateway")
+	err := g.drainer.drain(ctx)
+	g.context()
+	g.cancel()
+	if err != nil {
+		logger.Warningf("Token gateway did not drain cleanly: %s", err)
+	}
+	return err
+}
+
+func (g *Gateway) maxAttempts() int {
+	if g.MaxAttempts > 0 {
+		return g.MaxAttempts
+	}
+	return defaultGatewayMaxAttempts
+}
+
+func (g *Gateway) retryInterval() time.Duration {
+	if g.RetryInterval > 0 {
+		return g.RetryInterval
+	}
+	return defaultGatewayRetryInterval
+}
+
+func (g *Gateway) commitTimeout() time.Duration {
+	if g.CommitTimeout > 0 {
+		return g.CommitTimeout
+	}
+	return defaultGatewayCommitTimeout
+}
@@ -0,0 +1,178 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	ledgerutil "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+// PeerBroadcaster implements the Broadcaster interface by sending the
+// transactions to the orderers of the channel configuration in turn.
+type PeerBroadcaster struct {
+	// OrdererAddresses returns the addresses of the orderers of a channel.
+	OrdererAddresses func(channelID string) []string
+	// Connect connects to an orderer of a channel.
+	Connect func(channelID, address string) (*grpc.ClientConn, error)
+
+	next uint32
+}
+
+func (b *PeerBroadcaster) Broadcast(ctx context.Context, channelID string, envelope *common.Envelope) error {
+	addresses := b.OrdererAddresses(channelID)
+	if len(addresses) == 0 {
+		return errors.Errorf("no orderer addresses found for channel %s", channelID)
+	}
+	address := addresses[int((atomic.AddUint32(&b.next, 1)-1)%uint32(len(addresses)))]
+
+	conn, err := b.Connect(channelID, address)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed connecting to orderer %s", address))
+	}
+	defer conn.Close()
+
+	broadcast, err := ab.NewAtomicBroadcastClient(conn).Broadcast(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed creating broadcast to orderer %s", address)
+	}
+	defer broadcast.CloseSend()
+	err = broadcast.Send(envelope)
+	if err != nil {
+		return errors.Wrapf(err, "failed sending transaction to orderer %s", address)
+	}
+	resp, err := broadcast.Recv()
+	if err != nil {
+		return errors.Wrapf(err, "failed receiving broadcast response from orderer %s", address)
+	}
+	if resp.Status != common.Status_SUCCESS {
+		return errors.Errorf("orderer %s rejected transaction with status %s: %s", address, resp.Status, resp.Info)
+	}
+	return nil
+}
+
+// PeerOrdererAddresses returns the addresses of the orderers in the
+// configuration of a channel the peer has joined.
+func PeerOrdererAddresses(channelID string) []string {
+	cc := peer.GetStableChannelConfig(channelID)
+	if cc == nil {
+		return nil
+	}
+	return cc.ChannelConfig().OrdererAddresses()
+}
+
+//go:generate counterfeiter -o mock/commit_ledger.go -fake-name CommitLedger . CommitLedger
+
+// A CommitLedger is the part of the ledger of a channel read to find out
+// when transactions are committed.
+type CommitLedger interface {
+	GetBlockchainInfo() (*common.BlockchainInfo, error)
+	GetTransactionByID(txID string) (*pb.ProcessedTransaction, error)
+	GetBlocksIterator(startBlockNumber uint64) (commonledger.ResultsIterator, error)
+}
+
+// LedgerCommitWaiter implements the CommitWaiter interface by reading the
+// blocks committed to the ledger of the peer.
+type LedgerCommitWaiter struct {
+	// GetLedger returns the ledger of a channel, or nil if the channel is
+	// not found.
+	GetLedger func(channelID string) CommitLedger
+}
+
+func (w *LedgerCommitWaiter) WaitForCommit(ctx context.Context, channelID, txID string) (pb.TxValidationCode, error) {
+	l := w.GetLedger(channelID)
+	if l == nil {
+		return pb.TxValidationCode_NOT_VALIDATED, errors.Errorf("ledger not found for channel %s", channelID)
+	}
+
+	// the height is read before looking the transaction up, so that a
+	// transaction committed in between is found in the blocks that follow
+	info, err := l.GetBlockchainInfo()
+	if err != nil {
+		return pb.TxValidationCode_NOT_VALIDATED, errors.WithMessage(err, "failed reading blockchain info")
+	}
+	tx, err := l.GetTransactionByID(txID)
+	if err == nil {
+		return pb.TxValidationCode(tx.ValidationCode), nil
+	}
+
+	itr, err := l.GetBlocksIterator(info.Height)
+	if err != nil {
+		return pb.TxValidationCode_NOT_VALIDATED, errors.WithMessage(err, "failed creating blocks iterator")
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		itr.Close()
+	}()
+
+	for {
+		result, err := itr.Next()
+		if err != nil {
+			return pb.TxValidationCode_NOT_VALIDATED, errors.WithMessage(err, "failed reading block")
+		}
+		if result == nil {
+			return pb.TxValidationCode_NOT_VALIDATED, errors.Errorf("transaction %s not committed: %s", txID, ctx.Err())
+		}
+		if code, ok := validationCode(result.(*common.Block), txID); ok {
+			return code, nil
+		}
+	}
+}
+
+// validationCode returns the validation code of the transaction if the block
+// includes it.
+func validationCode(block *common.Block, txID string) (pb.TxValidationCode, bool) {
+	flags := validationFlags(block)
+	for i, data := range block.GetData().GetData() {
+		envelope, err := utils.GetEnvelopeFromBlock(data)
+		if err != nil {
+			continue
+		}
+		chdr, err := utils.ChannelHeader(envelope)
+		if err != nil || chdr.TxId != txID {
+			continue
+		}
+		if i >= len(flags) {
+			return pb.TxValidationCode_NOT_VALIDATED, true
+		}
+		return flags.Flag(i), true
+	}
+	return pb.TxValidationCode_NOT_VALIDATED, false
+}
+
+// validationFlags returns the validation flags of the transactions of a
+// block, if any.
+func validationFlags(block *common.Block) ledgerutil.TxValidationFlags {
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		return ledgerutil.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+	return nil
+}
+
+// PeerCommitLedger returns the ledger of a channel the peer has joined.
+func PeerCommitLedger(channelID string) CommitLedger {
+	l := peer.Default.GetLedger(channelID)
+	if l == nil {
+		return nil
+	}
+	return l
+}
@@ -0,0 +1,239 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server_test
+
+import (
+	"context"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/core/comm"
+	ledgerutil "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	mock2 "github.com/hyperledger/fabric/token/ledger/mock"
+	"github.com/hyperledger/fabric/token/server"
+	"github.com/hyperledger/fabric/token/server/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+var _ = Describe("PeerBroadcaster", func() {
+	var (
+		orderers    []*broadcastServer
+		grpcServers []*comm.GRPCServer
+		addresses   []string
+		broadcaster *server.PeerBroadcaster
+		envelope    *common.Envelope
+	)
+
+	BeforeEach(func() {
+		orderers, grpcServers, addresses = nil, nil, nil
+		for i := 0; i < 2; i++ {
+			grpcServer, err := comm.NewGRPCServer("127.0.0.1:0", comm.ServerConfig{})
+			Expect(err).NotTo(HaveOccurred())
+			orderer := &broadcastServer{status: common.Status_SUCCESS}
+			ab.RegisterAtomicBroadcastServer(grpcServer.Server(), orderer)
+			go grpcServer.Start()
+
+			orderers = append(orderers, orderer)
+			grpcServers = append(grpcServers, grpcServer)
+			addresses = append(addresses, grpcServer.Address())
+		}
+
+		broadcaster = &server.PeerBroadcaster{
+			OrdererAddresses: func(string) []string { return addresses },
+			Connect: func(channelID, address string) (*grpc.ClientConn, error) {
+				return grpc.Dial(address, grpc.WithInsecure())
+			},
+		}
+		envelope = tokenTransactionEnvelope(common.HeaderType_TOKEN_TRANSACTION, "channel-id", "tx-id")
+	})
+
+	AfterEach(func() {
+		for _, s := range grpcServers {
+			s.Stop()
+		}
+	})
+
+	It("sends the transactions to the orderers in turn", func() {
+		for i := 0; i < 3; i++ {
+			err := broadcaster.Broadcast(context.Background(), "channel-id", envelope)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(orderers[0].received()).To(HaveLen(2))
+		Expect(orderers[1].received()).To(HaveLen(1))
+		Expect(proto.Equal(orderers[0].received()[0], envelope)).To(BeTrue())
+	})
+
+	Context("when the orderer rejects the transaction", func() {
+		BeforeEach(func() {
+			orderers[0].status = common.Status_BAD_REQUEST
+		})
+
+		It("returns an error", func() {
+			err := broadcaster.Broadcast(context.Background(), "channel-id", envelope)
+			Expect(err).To(MatchError(ContainSubstring("orderer " + addresses[0] + " rejected transaction with status BAD_REQUEST")))
+		})
+	})
+
+	Context("when the channel has no orderers", func() {
+		BeforeEach(func() {
+			addresses = nil
+		})
+
+		It("returns an error", func() {
+			err := broadcaster.Broadcast(context.Background(), "channel-id", envelope)
+			Expect(err).To(MatchError("no orderer addresses found for channel channel-id"))
+		})
+	})
+
+	Context("when connecting fails", func() {
+		BeforeEach(func() {
+			broadcaster.Connect = func(string, string) (*grpc.ClientConn, error) {
+				return nil, errors.New("papaya")
+			}
+		})
+
+		It("returns an error", func() {
+			err := broadcaster.Broadcast(context.Background(), "channel-id", envelope)
+			Expect(err).To(MatchError("failed connecting to orderer " + addresses[0] + ": papaya"))
+		})
+	})
+})
+
+var _ = Describe("LedgerCommitWaiter", func() {
+	var (
+		fakeLedger   *mock.CommitLedger
+		fakeIterator *mock2.ResultsIterator
+		waiter       *server.LedgerCommitWaiter
+	)
+
+	BeforeEach(func() {
+		fakeLedger = &mock.CommitLedger{}
+		fakeLedger.GetBlockchainInfoReturns(&common.BlockchainInfo{Height: 7}, nil)
+		fakeLedger.GetTransactionByIDReturns(nil, errors.New("not found"))
+		fakeIterator = &mock2.ResultsIterator{}
+		fakeLedger.GetBlocksIteratorReturns(fakeIterator, nil)
+
+		waiter = &server.LedgerCommitWaiter{
+			GetLedger: func(channelID string) server.CommitLedger {
+				if channelID != "channel-id" {
+					return nil
+				}
+				return fakeLedger
+			},
+		}
+	})
+
+	It("returns the validation code of a committed transaction", func() {
+		fakeLedger.GetTransactionByIDReturns(&pb.ProcessedTransaction{ValidationCode: int32(pb.TxValidationCode_MVCC_READ_CONFLICT)}, nil)
+
+		code, err := waiter.WaitForCommit(context.Background(), "channel-id", "tx-id")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(code).To(Equal(pb.TxValidationCode_MVCC_READ_CONFLICT))
+		Expect(fakeLedger.GetBlocksIteratorCallCount()).To(Equal(0))
+	})
+
+	It("waits for the block including the transaction", func() {
+		fakeIterator.NextReturnsOnCall(0, block(7, pb.TxValidationCode_VALID, "other-tx-id"), nil)
+		fakeIterator.NextReturnsOnCall(1, block(8, pb.TxValidationCode_VALID, "another-tx-id", "tx-id"), nil)
+
+		code, err := waiter.WaitForCommit(context.Background(), "channel-id", "tx-id")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(code).To(Equal(pb.TxValidationCode_VALID))
+		Expect(fakeLedger.GetBlocksIteratorArgsForCall(0)).To(Equal(uint64(7)))
+		Eventually(fakeIterator.CloseCallCount).Should(Equal(1))
+	})
+
+	It("returns the validation code of an invalid transaction", func() {
+		fakeIterator.NextReturns(block(7, pb.TxValidationCode_ENDORSEMENT_POLICY_FAILURE, "tx-id"), nil)
+
+		code, err := waiter.WaitForCommit(context.Background(), "channel-id", "tx-id")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(code).To(Equal(pb.TxValidationCode_ENDORSEMENT_POLICY_FAILURE))
+	})
+
+	Context("when ctx is done", func() {
+		It("closes the iterator and returns an error", func() {
+			closed := make(chan struct{})
+			fakeIterator.CloseStub = func() { close(closed) }
+			fakeIterator.NextStub = func() (ledger.QueryResult, error) {
+				<-closed
+				return nil, nil
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_, err := waiter.WaitForCommit(ctx, "channel-id", "tx-id")
+			Expect(err).To(MatchError("transaction tx-id not committed: context canceled"))
+		})
+	})
+
+	Context("when the channel is not found", func() {
+		It("returns an error", func() {
+			_, err := waiter.WaitForCommit(context.Background(), "missing-channel", "tx-id")
+			Expect(err).To(MatchError("ledger not found for channel missing-channel"))
+		})
+	})
+
+	Context("when reading a block fails", func() {
+		It("returns an error", func() {
+			fakeIterator.NextReturns(nil, errors.New("durian"))
+			_, err := waiter.WaitForCommit(context.Background(), "channel-id", "tx-id")
+			Expect(err).To(MatchError("failed reading block: durian"))
+		})
+	})
+})
+
+// broadcastServer is an orderer accepting or rejecting every transaction
+// with status.
+type broadcastServer struct {
+	status    common.Status
+	mutex     sync.Mutex
+	envelopes []*common.Envelope
+}
+
+func (s *broadcastServer) Broadcast(srv ab.AtomicBroadcast_BroadcastServer) error {
+	envelope, err := srv.Recv()
+	if err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	s.envelopes = append(s.envelopes, envelope)
+	s.mutex.Unlock()
+	return srv.Send(&ab.BroadcastResponse{Status: s.status})
+}
+
+func (s *broadcastServer) Deliver(ab.AtomicBroadcast_DeliverServer) error {
+	return errors.New("not implemented")
+}
+
+func (s *broadcastServer) received() []*common.Envelope {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.envelopes
+}
+
+// block returns a block of transactions with the given IDs, the last one
+// with the validation code and the others valid.
+func block(number uint64, code pb.TxValidationCode, txIDs ...string) *common.Block {
+	b := common.NewBlock(number, nil)
+	flags := ledgerutil.NewTxValidationFlagsSetValue(len(txIDs), pb.TxValidationCode_VALID)
+	for i, txID := range txIDs {
+		b.Data.Data = append(b.Data.Data, ProtoMarshal(tokenTransactionEnvelope(common.HeaderType_TOKEN_TRANSACTION, "channel-id", txID)))
+		if i == len(txIDs)-1 {
+			flags.SetFlag(i, code)
+		}
+	}
+	b.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = flags
+	return b
+}
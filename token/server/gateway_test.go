@@ -0,0 +1,621 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server_test
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/committer/invalidation"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/server"
+	"github.com/hyperledger/fabric/token/server/mock"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("Gateway", func() {
+	var (
+		fakeBroadcaster       *mock.Broadcaster
+		fakeCommitWaiter      *mock.CommitWaiter
+		fakeEnvelopeChecker   *mock.EnvelopeChecker
+		fakeCapabilityChecker *mock.CapabilityChecker
+		gateway               *server.Gateway
+
+		grpcServer    *comm.GRPCServer
+		conn          *grpc.ClientConn
+		gatewayClient token.GatewayClient
+
+		envelope *common.Envelope
+	)
+
+	BeforeEach(func() {
+		fakeBroadcaster = &mock.Broadcaster{}
+		fakeCommitWaiter = &mock.CommitWaiter{}
+		fakeCommitWaiter.WaitForCommitReturns(pb.TxValidationCode_VALID, nil)
+		fakeEnvelopeChecker = &mock.EnvelopeChecker{}
+		fakeCapabilityChecker = &mock.CapabilityChecker{}
+		fakeCapabilityChecker.FabTokenReturns(true, nil)
+
+		gateway = &server.Gateway{
+			Broadcaster:       fakeBroadcaster,
+			CommitWaiter:      fakeCommitWaiter,
+			EnvelopeChecker:   fakeEnvelopeChecker,
+			CapabilityChecker: fakeCapabilityChecker,
+			RetryInterval:     time.Millisecond,
+		}
+
+		var err error
+		grpcServer, err = comm.NewGRPCServer("127.0.0.1:0", comm.ServerConfig{})
+		Expect(err).NotTo(HaveOccurred())
+		token.RegisterGatewayServer(grpcServer.Server(), gateway)
+		go grpcServer.Start()
+
+		conn, err = grpc.Dial(grpcServer.Address(), grpc.WithInsecure())
+		Expect(err).NotTo(HaveOccurred())
+		gatewayClient = token.NewGatewayClient(conn)
+
+		envelope = tokenTransactionEnvelope(common.HeaderType_TOKEN_TRANSACTION, "channel-id", "tx-id")
+	})
+
+	AfterEach(func() {
+		conn.Close()
+		grpcServer.Stop()
+	})
+
+	submit := func(waitForCommit bool) ([]*token.SubmitStatus, error) {
+		stream, err := gatewayClient.Submit(context.Background(), &token.SubmitRequest{
+			Envelope:      ProtoMarshal(envelope),
+			WaitForCommit: waitForCommit,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		var statuses []*token.SubmitStatus
+		for {
+			s, err := stream.Recv()
+			if err == io.EOF {
+				return statuses, nil
+			}
+			if err != nil {
+				return statuses, err
+			}
+			statuses = append(statuses, s)
+		}
+	}
+
+	phases := func(statuses []*token.SubmitStatus) []token.SubmitStatus_Phase {
+		var phases []token.SubmitStatus_Phase
+		for _, s := range statuses {
+			Expect(s.TxId).To(Equal("tx-id"))
+			phases = append(phases, s.Phase)
+		}
+		return phases
+	}
+
+	It("submits the transaction and reports its commit", func() {
+		statuses, err := submit(true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phases(statuses)).To(Equal([]token.SubmitStatus_Phase{
+			token.SubmitStatus_ACCEPTED,
+			token.SubmitStatus_ORDERED,
+			token.SubmitStatus_COMMITTED,
+		}))
+		Expect(statuses[2].ValidationCode).To(Equal("VALID"))
+
+		Expect(fakeEnvelopeChecker.CheckEnvelopeCallCount()).To(Equal(1))
+		channelID, checked := fakeEnvelopeChecker.CheckEnvelopeArgsForCall(0)
+		Expect(channelID).To(Equal("channel-id"))
+		Expect(proto.Equal(checked, envelope)).To(BeTrue())
+
+		Expect(fakeBroadcaster.BroadcastCallCount()).To(Equal(1))
+		_, channelID, broadcast := fakeBroadcaster.BroadcastArgsForCall(0)
+		Expect(channelID).To(Equal("channel-id"))
+		Expect(proto.Equal(broadcast, envelope)).To(BeTrue())
+
+		Expect(fakeCommitWaiter.WaitForCommitCallCount()).To(Equal(1))
+		_, channelID, txID := fakeCommitWaiter.WaitForCommitArgsForCall(0)
+		Expect(channelID).To(Equal("channel-id"))
+		Expect(txID).To(Equal("tx-id"))
+	})
+
+	Context("when the client does not wait for the commit", func() {
+		It("reports the transaction once ordered", func() {
+			statuses, err := submit(false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(phases(statuses)).To(Equal([]token.SubmitStatus_Phase{
+				token.SubmitStatus_ACCEPTED,
+				token.SubmitStatus_ORDERED,
+			}))
+			Expect(fakeCommitWaiter.WaitForCommitCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the transaction is committed as invalid", func() {
+		BeforeEach(func() {
+			fakeCommitWaiter.WaitForCommitReturns(pb.TxValidationCode_MVCC_READ_CONFLICT, nil)
+		})
+
+		It("reports the validation code", func() {
+			statuses, err := submit(true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statuses).To(HaveLen(3))
+			Expect(statuses[2].Phase).To(Equal(token.SubmitStatus_INVALID))
+			Expect(statuses[2].ValidationCode).To(Equal("MVCC_READ_CONFLICT"))
+			Expect(statuses[2].InputHotspots).To(BeEmpty())
+		})
+
+		Context("and the gateway advises of conflicts", func() {
+			var (
+				fakeConflictAdvisor *mock.ConflictAdvisor
+				spentKeys           []string
+			)
+
+			BeforeEach(func() {
+				inputs := []*token.InputId{{TxId: "tx0", Index: 0}, {TxId: "tx1", Index: 1}}
+				spentKeys = nil
+				for _, input := range inputs {
+					spentKey, err := plain.SpentKey(input)
+					Expect(err).NotTo(HaveOccurred())
+					spentKeys = append(spentKeys, spentKey)
+				}
+				payload := &common.Payload{}
+				Expect(proto.Unmarshal(envelope.Payload, payload)).To(Succeed())
+				payload.Data = ProtoMarshal(&token.TokenTransaction{Action: &token.TokenTransaction_PlainAction{PlainAction: &token.PlainTokenAction{
+					Data: &token.PlainTokenAction_PlainTransfer{PlainTransfer: &token.PlainTransfer{Inputs: inputs}},
+				}}})
+				envelope.Payload = ProtoMarshal(payload)
+
+				fakeConflictAdvisor = &mock.ConflictAdvisor{}
+				fakeConflictAdvisor.AdviseReturns(&pb.ConflictAdvisory{Hotspots: []*pb.ConflictHotspot{
+					{Namespace: plain.Namespace, Key: spentKeys[1], Conflicts: 3, TxIds: []string{"tx4", "tx3"}},
+				}})
+				gateway.ConflictAdvisor = fakeConflictAdvisor
+			})
+
+			It("reports the inputs that are conflict hotspots", func() {
+				statuses, err := submit(true)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(statuses).To(HaveLen(3))
+				Expect(statuses[2].Phase).To(Equal(token.SubmitStatus_INVALID))
+
+				tokenID, err := plain.TokenID(&token.InputId{TxId: "tx1", Index: 1})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(statuses[2].InputHotspots).To(HaveLen(1))
+				Expect(proto.Equal(statuses[2].InputHotspots[0], &token.InputHotspot{
+					Namespace: plain.Namespace,
+					Key:       spentKeys[1],
+					Conflicts: 3,
+					TxIds:     []string{"tx4", "tx3"},
+					TokenId:   tokenID,
+				})).To(BeTrue())
+
+				Expect(fakeConflictAdvisor.AdviseCallCount()).To(Equal(1))
+				channelID, keys := fakeConflictAdvisor.AdviseArgsForCall(0)
+				Expect(channelID).To(Equal("channel-id"))
+				Expect(keys).To(Equal([]invalidation.Key{
+					{Namespace: plain.Namespace, Key: spentKeys[0]},
+					{Namespace: plain.Namespace, Key: spentKeys[1]},
+				}))
+			})
+
+			It("does not advise of the committed transactions", func() {
+				fakeCommitWaiter.WaitForCommitReturns(pb.TxValidationCode_VALID, nil)
+				_, err := submit(true)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeConflictAdvisor.AdviseCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("when waiting for the commit fails", func() {
+		BeforeEach(func() {
+			fakeCommitWaiter.WaitForCommitReturns(pb.TxValidationCode_NOT_VALIDATED, errors.New("mango"))
+		})
+
+		It("reports the failure", func() {
+			statuses, err := submit(true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statuses).To(HaveLen(3))
+			Expect(statuses[2].Phase).To(Equal(token.SubmitStatus_FAILED))
+			Expect(statuses[2].Message).To(Equal("failed waiting for commit: mango"))
+		})
+	})
+
+	Context("when a broadcast fails", func() {
+		BeforeEach(func() {
+			fakeBroadcaster.BroadcastReturnsOnCall(0, errors.New("kiwi"))
+		})
+
+		It("retries", func() {
+			statuses, err := submit(true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(phases(statuses)).To(Equal([]token.SubmitStatus_Phase{
+				token.SubmitStatus_ACCEPTED,
+				token.SubmitStatus_ORDERED,
+				token.SubmitStatus_COMMITTED,
+			}))
+			Expect(fakeBroadcaster.BroadcastCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when every broadcast attempt fails", func() {
+		BeforeEach(func() {
+			fakeBroadcaster.BroadcastReturns(errors.New("kiwi"))
+		})
+
+		It("gives up after MaxAttempts", func() {
+			statuses, err := submit(true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(phases(statuses)).To(Equal([]token.SubmitStatus_Phase{
+				token.SubmitStatus_ACCEPTED,
+				token.SubmitStatus_FAILED,
+			}))
+			Expect(statuses[1].Message).To(Equal("failed broadcasting transaction: kiwi"))
+			Expect(fakeBroadcaster.BroadcastCallCount()).To(Equal(3))
+			Expect(fakeCommitWaiter.WaitForCommitCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the envelope is not a token transaction", func() {
+		BeforeEach(func() {
+			envelope = tokenTransactionEnvelope(common.HeaderType_ENDORSER_TRANSACTION, "channel-id", "tx-id")
+		})
+
+		It("rejects the submission", func() {
+			_, err := submit(true)
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			Expect(err.Error()).To(ContainSubstring("invalid transaction type ENDORSER_TRANSACTION, expected TOKEN_TRANSACTION"))
+			Expect(fakeBroadcaster.BroadcastCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the envelope is malformed", func() {
+		BeforeEach(func() {
+			envelope = &common.Envelope{Payload: []byte("garbage")}
+		})
+
+		It("rejects the submission", func() {
+			_, err := submit(true)
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			Expect(fakeBroadcaster.BroadcastCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when FabToken is not enabled", func() {
+		BeforeEach(func() {
+			fakeCapabilityChecker.FabTokenReturns(false, nil)
+		})
+
+		It("rejects the submission", func() {
+			_, err := submit(true)
+			Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+			Expect(err.Error()).To(ContainSubstring("FabToken capability not enabled for channel channel-id"))
+			Expect(fakeBroadcaster.BroadcastCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the creator may not submit", func() {
+		BeforeEach(func() {
+			fakeEnvelopeChecker.CheckEnvelopeReturns(errors.New("no-can-do"))
+		})
+
+		It("rejects the submission", func() {
+			_, err := submit(true)
+			Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+			Expect(err.Error()).To(ContainSubstring("no-can-do"))
+			Expect(fakeBroadcaster.BroadcastCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the transaction is an intra-org transfer on a fast path channel", func() {
+		var fakeFastPathCommitWaiter *mock.CommitWaiter
+
+		BeforeEach(func() {
+			envelope = intraOrgTransferEnvelope("channel-id", "tx-id", "Org1MSP", "Org1MSP")
+			fakeFastPathCommitWaiter = &mock.CommitWaiter{}
+			fakeFastPathCommitWaiter.WaitForCommitReturns(pb.TxValidationCode_VALID, nil)
+			gateway.FastPath = &server.FastPath{
+				Channels:     map[string]bool{"channel-id": true},
+				MSPID:        "Org1MSP",
+				CommitWaiter: fakeFastPathCommitWaiter,
+			}
+		})
+
+		It("checks the submission policy and waits with the fast path", func() {
+			statuses, err := submit(true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(phases(statuses)).To(Equal([]token.SubmitStatus_Phase{
+				token.SubmitStatus_ACCEPTED,
+				token.SubmitStatus_ORDERED,
+				token.SubmitStatus_COMMITTED,
+			}))
+
+			Expect(fakeEnvelopeChecker.CheckEnvelopeCallCount()).To(Equal(1))
+			Expect(fakeBroadcaster.BroadcastCallCount()).To(Equal(1))
+			Expect(fakeCommitWaiter.WaitForCommitCallCount()).To(Equal(0))
+			Expect(fakeFastPathCommitWaiter.WaitForCommitCallCount()).To(Equal(1))
+			_, channelID, txID := fakeFastPathCommitWaiter.WaitForCommitArgsForCall(0)
+			Expect(channelID).To(Equal("channel-id"))
+			Expect(txID).To(Equal("tx-id"))
+		})
+
+		Context("and the envelope does not satisfy the submission policy", func() {
+			BeforeEach(func() {
+				fakeEnvelopeChecker.CheckEnvelopeReturns(errors.New("bad signature"))
+			})
+
+			It("rejects the transaction", func() {
+				_, err := submit(true)
+				Expect(err).To(HaveOccurred())
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+				Expect(fakeBroadcaster.BroadcastCallCount()).To(Equal(0))
+				Expect(fakeFastPathCommitWaiter.WaitForCommitCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("and a recipient is a member of another org", func() {
+			BeforeEach(func() {
+				envelope = intraOrgTransferEnvelope("channel-id", "tx-id", "Org1MSP", "Org2MSP")
+			})
+
+			It("takes the regular path", func() {
+				_, err := submit(true)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeEnvelopeChecker.CheckEnvelopeCallCount()).To(Equal(1))
+				Expect(fakeCommitWaiter.WaitForCommitCallCount()).To(Equal(1))
+				Expect(fakeFastPathCommitWaiter.WaitForCommitCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("when the client goes away", func() {
+		var release chan struct{}
+
+		BeforeEach(func() {
+			release = make(chan struct{})
+			fakeBroadcaster.BroadcastStub = func(context.Context, string, *common.Envelope) error {
+				<-release
+				return nil
+			}
+		})
+
+		It("completes the submission", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			stream, err := gatewayClient.Submit(ctx, &token.SubmitRequest{Envelope: ProtoMarshal(envelope), WaitForCommit: true})
+			Expect(err).NotTo(HaveOccurred())
+			s, err := stream.Recv()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s.Phase).To(Equal(token.SubmitStatus_ACCEPTED))
+			cancel()
+
+			close(release)
+			Eventually(fakeCommitWaiter.WaitForCommitCallCount).Should(Equal(1))
+		})
+	})
+
+	Describe("AttestCommit", func() {
+		var (
+			fakeCommitAttestor *mock.CommitAttestor
+			fakePolicyChecker  *mock.PolicyChecker
+			signedCommand      *token.SignedCommand
+			attestation        *token.SignedCommitAttestation
+		)
+
+		BeforeEach(func() {
+			fakeCommitAttestor = &mock.CommitAttestor{}
+			attestation = &token.SignedCommitAttestation{Attestation: []byte("attestation"), Signature: []byte("signature")}
+			fakeCommitAttestor.AttestCommitReturns(attestation, nil)
+			fakePolicyChecker = &mock.PolicyChecker{}
+			gateway.CommitAttestor = fakeCommitAttestor
+			gateway.PolicyChecker = fakePolicyChecker
+
+			signedCommand = &token.SignedCommand{
+				Command: ProtoMarshal(&token.Command{
+					Header: &token.Header{ChannelId: "channel-id", Creator: []byte("creator"), Nonce: []byte("nonce")},
+					Payload: &token.Command_CommitAttestationRequest{
+						CommitAttestationRequest: &token.CommitAttestationRequest{TxId: "tx-id"},
+					},
+				}),
+				Signature: []byte("command-signature"),
+			}
+		})
+
+		It("returns the attestation of the commit of the transaction", func() {
+			resp, err := gatewayClient.AttestCommit(context.Background(), signedCommand)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(resp, attestation)).To(BeTrue())
+
+			Expect(fakePolicyChecker.CheckCallCount()).To(Equal(1))
+			sc, command := fakePolicyChecker.CheckArgsForCall(0)
+			Expect(proto.Equal(sc, signedCommand)).To(BeTrue())
+			Expect(command.GetCommitAttestationRequest().TxId).To(Equal("tx-id"))
+			channelID, txID := fakeCommitAttestor.AttestCommitArgsForCall(0)
+			Expect(channelID).To(Equal("channel-id"))
+			Expect(txID).To(Equal("tx-id"))
+		})
+
+		Context("when commit attestations are not enabled", func() {
+			BeforeEach(func() {
+				gateway.CommitAttestor = nil
+			})
+
+			It("rejects the request", func() {
+				_, err := gatewayClient.AttestCommit(context.Background(), signedCommand)
+				Expect(status.Code(err)).To(Equal(codes.Unimplemented))
+			})
+		})
+
+		Context("when the command is not a commit attestation request", func() {
+			BeforeEach(func() {
+				signedCommand.Command = ProtoMarshal(&token.Command{
+					Header:  &token.Header{ChannelId: "channel-id", Creator: []byte("creator")},
+					Payload: &token.Command_ListRequest{ListRequest: &token.ListRequest{}},
+				})
+			})
+
+			It("rejects the request", func() {
+				_, err := gatewayClient.AttestCommit(context.Background(), signedCommand)
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+				Expect(err).To(MatchError(ContainSubstring("command *token.Command_ListRequest is not a commit attestation request")))
+			})
+		})
+
+		Context("when the header has no channel", func() {
+			BeforeEach(func() {
+				signedCommand.Command = ProtoMarshal(&token.Command{
+					Header: &token.Header{Creator: []byte("creator")},
+					Payload: &token.Command_CommitAttestationRequest{
+						CommitAttestationRequest: &token.CommitAttestationRequest{TxId: "tx-id"},
+					},
+				})
+			})
+
+			It("rejects the request", func() {
+				_, err := gatewayClient.AttestCommit(context.Background(), signedCommand)
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+		})
+
+		Context("when FabToken is not enabled", func() {
+			BeforeEach(func() {
+				fakeCapabilityChecker.FabTokenReturns(false, nil)
+			})
+
+			It("rejects the request", func() {
+				_, err := gatewayClient.AttestCommit(context.Background(), signedCommand)
+				Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+				Expect(fakeCommitAttestor.AttestCommitCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the creator may not request attestations", func() {
+			BeforeEach(func() {
+				fakePolicyChecker.CheckReturns(errors.New("no-way-man"))
+			})
+
+			It("rejects the request", func() {
+				_, err := gatewayClient.AttestCommit(context.Background(), signedCommand)
+				Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+				Expect(fakeCommitAttestor.AttestCommitCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the transaction is not found", func() {
+			BeforeEach(func() {
+				fakeCommitAttestor.AttestCommitReturns(nil, errors.New("transaction tx-id not found"))
+			})
+
+			It("returns the error", func() {
+				_, err := gatewayClient.AttestCommit(context.Background(), signedCommand)
+				Expect(status.Code(err)).To(Equal(codes.NotFound))
+				Expect(err).To(MatchError(ContainSubstring("transaction tx-id not found")))
+			})
+		})
+	})
+
+	Describe("Shutdown", func() {
+		It("rejects new submissions", func() {
+			Expect(gateway.Shutdown(context.Background())).To(Succeed())
+			_, err := submit(true)
+			Expect(status.Code(err)).To(Equal(codes.Unavailable))
+		})
+
+		It("waits for the submissions in progress", func() {
+			release := make(chan struct{})
+			fakeCommitWaiter.WaitForCommitStub = func(context.Context, string, string) (pb.TxValidationCode, error) {
+				<-release
+				return pb.TxValidationCode_VALID, nil
+			}
+			go submit(true)
+			Eventually(fakeCommitWaiter.WaitForCommitCallCount).Should(Equal(1))
+
+			done := make(chan error)
+			go func() { done <- gateway.Shutdown(context.Background()) }()
+			Consistently(done).ShouldNot(Receive())
+			close(release)
+			Eventually(done).Should(Receive(BeNil()))
+		})
+
+		It("abandons the submissions still in progress once ctx is done", func() {
+			fakeCommitWaiter.WaitForCommitStub = func(ctx context.Context, _, _ string) (pb.TxValidationCode, error) {
+				<-ctx.Done()
+				return pb.TxValidationCode_NOT_VALIDATED, ctx.Err()
+			}
+			result := make(chan []*token.SubmitStatus)
+			go func() {
+				statuses, _ := submit(true)
+				result <- statuses
+			}()
+			Eventually(fakeCommitWaiter.WaitForCommitCallCount).Should(Equal(1))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			Expect(gateway.Shutdown(ctx)).To(MatchError("timed out waiting for 1 in-flight commands"))
+
+			var statuses []*token.SubmitStatus
+			Eventually(result).Should(Receive(&statuses))
+			Expect(statuses).To(HaveLen(3))
+			Expect(statuses[2].Phase).To(Equal(token.SubmitStatus_FAILED))
+		})
+	})
+})
+
+func tokenTransactionEnvelope(headerType common.HeaderType, channelID, txID string) *common.Envelope {
+	return &common.Envelope{
+		Payload: ProtoMarshal(&common.Payload{
+			Header: &common.Header{
+				ChannelHeader: ProtoMarshal(&common.ChannelHeader{
+					Type:      int32(headerType),
+					ChannelId: channelID,
+					TxId:      txID,
+				}),
+				SignatureHeader: ProtoMarshal(&common.SignatureHeader{Creator: []byte("creator")}),
+			},
+			Data: []byte("token-transaction"),
+		}),
+		Signature: []byte("signature"),
+	}
+}
+
+// intraOrgTransferEnvelope returns the envelope of a plain transfer created by
+// a member of creatorMSP to a member of recipientMSP.
+func intraOrgTransferEnvelope(channelID, txID, creatorMSP, recipientMSP string) *common.Envelope {
+	return &common.Envelope{
+		Payload: ProtoMarshal(&common.Payload{
+			Header: &common.Header{
+				ChannelHeader: ProtoMarshal(&common.ChannelHeader{
+					Type:      int32(common.HeaderType_TOKEN_TRANSACTION),
+					ChannelId: channelID,
+					TxId:      txID,
+				}),
+				SignatureHeader: ProtoMarshal(&common.SignatureHeader{
+					Creator: ProtoMarshal(&msp.SerializedIdentity{Mspid: creatorMSP, IdBytes: []byte("creator")}),
+				}),
+			},
+			Data: ProtoMarshal(&token.TokenTransaction{Action: &token.TokenTransaction_PlainAction{PlainAction: &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainTransfer{PlainTransfer: &token.PlainTransfer{
+					Inputs: []*token.InputId{{TxId: "tx0", Index: 0}},
+					Outputs: []*token.PlainOutput{
+						{Owner: ProtoMarshal(&msp.SerializedIdentity{Mspid: recipientMSP, IdBytes: []byte("recipient")}), Type: "PDQ", Quantity: 10},
+					},
+				}},
+			}}}),
+		}),
+		Signature: []byte("signature"),
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+// RequestPause creates a governance transaction pausing a token type. The
+// committer only accepts it from an administrator.
+func (s *Prover) RequestPause(ctx context.Context, header *token.Header, request *token.PauseRequest) (*token.CommandResponse_TokenTransaction, error) {
+	if request.Type == "" {
+		return nil, errors.New("token type is required")
+	}
+	issuer, err := s.TMSManager.GetIssuer(header.ChannelId, request.Credential, header.Creator)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenTransaction, err := issuer.RequestPause(request.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.CommandResponse_TokenTransaction{TokenTransaction: tokenTransaction}, nil
+}
+
+// RequestResume creates a governance transaction resuming a paused token type.
+func (s *Prover) RequestResume(ctx context.Context, header *token.Header, request *token.ResumeRequest) (*token.CommandResponse_TokenTransaction, error) {
+	if request.Type == "" {
+		return nil, errors.New("token type is required")
+	}
+	issuer, err := s.TMSManager.GetIssuer(header.ChannelId, request.Credential, header.Creator)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenTransaction, err := issuer.RequestResume(request.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.CommandResponse_TokenTransaction{TokenTransaction: tokenTransaction}, nil
+}
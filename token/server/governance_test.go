@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server_test
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/server"
+	"github.com/hyperledger/fabric/token/server/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Governance", func() {
+	var (
+		fakeIssuer     *mock.Issuer
+		fakeTMSManager *mock.TMSManager
+		prover         *server.Prover
+
+		header           *token.Header
+		tokenTransaction *token.TokenTransaction
+	)
+
+	BeforeEach(func() {
+		tokenTransaction = &token.TokenTransaction{}
+
+		fakeIssuer = &mock.Issuer{}
+		fakeIssuer.RequestPauseReturns(tokenTransaction, nil)
+		fakeIssuer.RequestResumeReturns(tokenTransaction, nil)
+
+		fakeTMSManager = &mock.TMSManager{}
+		fakeTMSManager.GetIssuerReturns(fakeIssuer, nil)
+
+		prover = &server.Prover{TMSManager: fakeTMSManager}
+
+		header = &token.Header{
+			ChannelId: "channel-id",
+			Creator:   []byte("creator"),
+		}
+	})
+
+	Describe("RequestPause", func() {
+		It("creates a pause transaction", func() {
+			resp, err := prover.RequestPause(context.Background(), header, &token.PauseRequest{Credential: []byte("credential"), Type: "XYZ"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&token.CommandResponse_TokenTransaction{TokenTransaction: tokenTransaction}))
+
+			Expect(fakeTMSManager.GetIssuerCallCount()).To(Equal(1))
+			channel, credential, creator := fakeTMSManager.GetIssuerArgsForCall(0)
+			Expect(channel).To(Equal("channel-id"))
+			Expect(credential).To(Equal([]byte("credential")))
+			Expect(creator).To(Equal([]byte("creator")))
+			Expect(fakeIssuer.RequestPauseCallCount()).To(Equal(1))
+			Expect(fakeIssuer.RequestPauseArgsForCall(0)).To(Equal("XYZ"))
+		})
+
+		Context("when the token type is missing", func() {
+			It("returns an error", func() {
+				_, err := prover.RequestPause(context.Background(), header, &token.PauseRequest{})
+				Expect(err).To(MatchError("token type is required"))
+				Expect(fakeTMSManager.GetIssuerCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the issuer fails", func() {
+			BeforeEach(func() {
+				fakeIssuer.RequestPauseReturns(nil, errors.New("boom"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.RequestPause(context.Background(), header, &token.PauseRequest{Type: "XYZ"})
+				Expect(err).To(MatchError("boom"))
+			})
+		})
+	})
+
+	Describe("RequestResume", func() {
+		It("creates a resume transaction", func() {
+			resp, err := prover.RequestResume(context.Background(), header, &token.ResumeRequest{Type: "XYZ"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&token.CommandResponse_TokenTransaction{TokenTransaction: tokenTransaction}))
+			Expect(fakeIssuer.RequestResumeCallCount()).To(Equal(1))
+			Expect(fakeIssuer.RequestResumeArgsForCall(0)).To(Equal("XYZ"))
+		})
+
+		Context("when the token type is missing", func() {
+			It("returns an error", func() {
+				_, err := prover.RequestResume(context.Background(), header, &token.ResumeRequest{})
+				Expect(err).To(MatchError("token type is required"))
+			})
+		})
+	})
+})
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package server
 
 import (
+	"github.com/hyperledger/fabric/token/identity"
 	"github.com/hyperledger/fabric/token/ledger"
 	"github.com/hyperledger/fabric/token/tms/plain"
 	"github.com/pkg/errors"
@@ -16,6 +17,14 @@ import (
 // TODO: it will be updated after lscc-baased tms configuration is available
 type Manager struct {
 	LedgerManager ledger.LedgerManager
+	// IdentityDeserializerManager provides the deserializers used to verify
+	// delegations; when nil, delegated transfers are rejected.
+	IdentityDeserializerManager identity.DeserializerManager
+	// OwnerEncoding is the representation of the creator in change outputs.
+	OwnerEncoding identity.OwnerEncoding
+	// SpentFilters, when set, provide the transactors with the spent filter
+	// of their channel.
+	SpentFilters *SpentFilters
 }
 
 // For now it returns a plain issuer.
@@ -32,5 +41,15 @@ func (manager *Manager) GetTransactor(channel string, privateCredential, publicC
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed getting ledger for channel: %s", channel)
 	}
-	return &plain.Transactor{Ledger: ledger, PublicCredential: publicCredential}, nil
+	transactor := &plain.Transactor{Ledger: ledger, PublicCredential: publicCredential, Channel: channel, OwnerEncoding: manager.OwnerEncoding}
+	transactor.SpentFilter = manager.SpentFilters.Get(channel)
+	if manager.IdentityDeserializerManager != nil {
+		deserializer, err := manager.IdentityDeserializerManager.Deserializer(channel)
+		if err != nil {
+			ledger.Done()
+			return nil, errors.Wrapf(err, "failed getting identity deserializer for channel: %s", channel)
+		}
+		transactor.Deserializer = deserializer
+	}
+	return transactor, nil
 }
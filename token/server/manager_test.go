@@ -9,6 +9,7 @@ package server_test
 import (
 	"errors"
 
+	idmock "github.com/hyperledger/fabric/token/identity/mock"
 	"github.com/hyperledger/fabric/token/ledger/mock"
 	"github.com/hyperledger/fabric/token/server"
 	"github.com/hyperledger/fabric/token/tms/plain"
@@ -42,7 +43,42 @@ var _ = Describe("Manager", func() {
 			fakeLedgerManager.GetLedgerReaderReturns(fakeLedgerReader, nil)
 			transactor, err := manager.GetTransactor("test-channel", []byte("private-credential"), []byte("public-credential"))
 			Expect(err).NotTo(HaveOccurred())
-			Expect(transactor).To(Equal(&plain.Transactor{Ledger: fakeLedgerReader, PublicCredential: []byte("public-credential")}))
+			Expect(transactor).To(Equal(&plain.Transactor{Ledger: fakeLedgerReader, PublicCredential: []byte("public-credential"), Channel: "test-channel"}))
+		})
+
+		Context("when an identity deserializer manager is configured", func() {
+			var (
+				fakeDeserializerManager *idmock.DeserializerManager
+				fakeDeserializer        *idmock.Deserializer
+			)
+
+			BeforeEach(func() {
+				fakeDeserializer = &idmock.Deserializer{}
+				fakeDeserializerManager = &idmock.DeserializerManager{}
+				fakeDeserializerManager.DeserializerReturns(fakeDeserializer, nil)
+				fakeLedgerManager.GetLedgerReaderReturns(fakeLedgerReader, nil)
+			})
+
+			It("returns a plain transactor that can verify delegations", func() {
+				manager := &server.Manager{LedgerManager: fakeLedgerManager, IdentityDeserializerManager: fakeDeserializerManager}
+				transactor, err := manager.GetTransactor("test-channel", []byte("private-credential"), []byte("public-credential"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(transactor).To(Equal(&plain.Transactor{
+					Ledger:           fakeLedgerReader,
+					PublicCredential: []byte("public-credential"),
+					Channel:          "test-channel",
+					Deserializer:     fakeDeserializer,
+				}))
+				Expect(fakeDeserializerManager.DeserializerArgsForCall(0)).To(Equal("test-channel"))
+			})
+
+			It("returns an error when the deserializer is not available", func() {
+				fakeDeserializerManager.DeserializerReturns(nil, errors.New("no channel"))
+				manager := &server.Manager{LedgerManager: fakeLedgerManager, IdentityDeserializerManager: fakeDeserializerManager}
+				_, err := manager.GetTransactor("test-channel", []byte("private-credential"), []byte("public-credential"))
+				Expect(err).To(MatchError("failed getting identity deserializer for channel: test-channel: no channel"))
+				Expect(fakeLedgerReader.DoneCallCount()).To(Equal(1))
+			})
 		})
 		It("returns an error", func() {
 			manager := &server.Manager{LedgerManager: fakeLedgerManager}
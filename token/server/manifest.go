@@ -0,0 +1,36 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+// RequestIssueManifest creates a transaction tying together the imports of the
+// chunks of a batch. The committer only accepts it from the issuer of the chunks.
+func (s *Prover) RequestIssueManifest(ctx context.Context, header *token.Header, request *token.IssueManifestRequest) (*token.CommandResponse_TokenTransaction, error) {
+	if len(request.BatchId) == 0 {
+		return nil, errors.New("batch ID is required")
+	}
+	if len(request.Chunks) == 0 {
+		return nil, errors.New("no chunks in manifest")
+	}
+	issuer, err := s.TMSManager.GetIssuer(header.ChannelId, request.Credential, header.Creator)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenTransaction, err := issuer.RequestIssueManifest(request.BatchId, request.Chunks)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.CommandResponse_TokenTransaction{TokenTransaction: tokenTransaction}, nil
+}
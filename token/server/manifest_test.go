@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server_test
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/server"
+	"github.com/hyperledger/fabric/token/server/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("RequestIssueManifest", func() {
+	var (
+		fakeIssuer     *mock.Issuer
+		fakeTMSManager *mock.TMSManager
+		prover         *server.Prover
+
+		header           *token.Header
+		request          *token.IssueManifestRequest
+		tokenTransaction *token.TokenTransaction
+	)
+
+	BeforeEach(func() {
+		tokenTransaction = &token.TokenTransaction{}
+
+		fakeIssuer = &mock.Issuer{}
+		fakeIssuer.RequestIssueManifestReturns(tokenTransaction, nil)
+
+		fakeTMSManager = &mock.TMSManager{}
+		fakeTMSManager.GetIssuerReturns(fakeIssuer, nil)
+
+		prover = &server.Prover{TMSManager: fakeTMSManager}
+
+		header = &token.Header{
+			ChannelId: "channel-id",
+			Creator:   []byte("creator"),
+		}
+		request = &token.IssueManifestRequest{
+			Credential: []byte("credential"),
+			BatchId:    []byte("batch-1"),
+			Chunks: []*token.IssueChunk{
+				{TxId: "tx-1", SeriesId: []byte("batch-1/0")},
+				{TxId: "tx-2", SeriesId: []byte("batch-1/1")},
+			},
+		}
+	})
+
+	It("creates a manifest transaction", func() {
+		resp, err := prover.RequestIssueManifest(context.Background(), header, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp).To(Equal(&token.CommandResponse_TokenTransaction{TokenTransaction: tokenTransaction}))
+
+		Expect(fakeTMSManager.GetIssuerCallCount()).To(Equal(1))
+		channel, credential, creator := fakeTMSManager.GetIssuerArgsForCall(0)
+		Expect(channel).To(Equal("channel-id"))
+		Expect(credential).To(Equal([]byte("credential")))
+		Expect(creator).To(Equal([]byte("creator")))
+		Expect(fakeIssuer.RequestIssueManifestCallCount()).To(Equal(1))
+		batchID, chunks := fakeIssuer.RequestIssueManifestArgsForCall(0)
+		Expect(batchID).To(Equal([]byte("batch-1")))
+		Expect(chunks).To(Equal(request.Chunks))
+	})
+
+	Context("when the batch ID is missing", func() {
+		It("returns an error", func() {
+			request.BatchId = nil
+			_, err := prover.RequestIssueManifest(context.Background(), header, request)
+			Expect(err).To(MatchError("batch ID is required"))
+			Expect(fakeTMSManager.GetIssuerCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when there are no chunks", func() {
+		It("returns an error", func() {
+			request.Chunks = nil
+			_, err := prover.RequestIssueManifest(context.Background(), header, request)
+			Expect(err).To(MatchError("no chunks in manifest"))
+		})
+	})
+
+	Context("when the issuer fails", func() {
+		BeforeEach(func() {
+			fakeIssuer.RequestIssueManifestReturns(nil, errors.New("boom"))
+		})
+
+		It("returns the error", func() {
+			_, err := prover.RequestIssueManifest(context.Background(), header, request)
+			Expect(err).To(MatchError("boom"))
+		})
+	})
+})
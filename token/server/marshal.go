@@ -94,6 +94,16 @@ func commandResponseFromPayload(payload interface{}) (*token.CommandResponse, er
 		return &token.CommandResponse{Payload: t}, nil
 	case *token.CommandResponse_UnspentTokens:
 		return &token.CommandResponse{Payload: t}, nil
+	case *token.CommandResponse_Balances:
+		return &token.CommandResponse{Payload: t}, nil
+	case *token.CommandResponse_ReferencedTransactions:
+		return &token.CommandResponse{Payload: t}, nil
+	case *token.CommandResponse_ChannelCapabilities:
+		return &token.CommandResponse{Payload: t}, nil
+	case *token.CommandResponse_SupplyAttestation:
+		return &token.CommandResponse{Payload: t}, nil
+	case *token.CommandResponse_CommitAttestation:
+		return &token.CommandResponse{Payload: t}, nil
 	default:
 		return nil, errors.Errorf("command type not recognized: %T", t)
 	}
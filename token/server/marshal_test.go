@@ -185,6 +185,25 @@ var _ = Describe("Marshal", func() {
 			}))
 		})
 
+		It("marshals and signs ChannelCapabilities responses", func() {
+			capabilitiesResponse := &token.CommandResponse_ChannelCapabilities{
+				ChannelCapabilities: &token.ChannelCapabilities{FabToken: true, HashingSuite: "SHA256"},
+			}
+
+			marshaledCommandResponse, err := proto.Marshal(&token.CommandResponse{
+				Header:  expectedResponseHeader,
+				Payload: capabilitiesResponse,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			scr, err := rm.MarshalCommandResponse([]byte("command"), capabilitiesResponse)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(scr).To(Equal(&token.SignedCommandResponse{
+				Response:  marshaledCommandResponse,
+				Signature: []byte("signature"),
+			}))
+		})
+
 		Context("when marshal is called with an unexpected response payload type", func() {
 			It("returns an error", func() {
 				_, err := rm.MarshalCommandResponse([]byte("command"), nil)
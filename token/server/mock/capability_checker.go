@@ -21,6 +21,45 @@ type CapabilityChecker struct {
 		result1 bool
 		result2 error
 	}
+	HashingSuiteStub        func(channelId string) (string, error)
+	hashingSuiteMutex       sync.RWMutex
+	hashingSuiteArgsForCall []struct {
+		channelId string
+	}
+	hashingSuiteReturns struct {
+		result1 string
+		result2 error
+	}
+	hashingSuiteReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	TokenEncryptionKeysStub        func(channelId string) (map[string][]byte, error)
+	tokenEncryptionKeysMutex       sync.RWMutex
+	tokenEncryptionKeysArgsForCall []struct {
+		channelId string
+	}
+	tokenEncryptionKeysReturns struct {
+		result1 map[string][]byte
+		result2 error
+	}
+	tokenEncryptionKeysReturnsOnCall map[int]struct {
+		result1 map[string][]byte
+		result2 error
+	}
+	TxExpirationStub        func(channelId string) (bool, error)
+	txExpirationMutex       sync.RWMutex
+	txExpirationArgsForCall []struct {
+		channelId string
+	}
+	txExpirationReturns struct {
+		result1 bool
+		result2 error
+	}
+	txExpirationReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -76,11 +115,170 @@ func (fake *CapabilityChecker) FabTokenReturnsOnCall(i int, result1 bool, result
 	}{result1, result2}
 }
 
+func (fake *CapabilityChecker) HashingSuite(channelId string) (string, error) {
+	fake.hashingSuiteMutex.Lock()
+	ret, specificReturn := fake.hashingSuiteReturnsOnCall[len(fake.hashingSuiteArgsForCall)]
+	fake.hashingSuiteArgsForCall = append(fake.hashingSuiteArgsForCall, struct {
+		channelId string
+	}{channelId})
+	fake.recordInvocation("HashingSuite", []interface{}{channelId})
+	fake.hashingSuiteMutex.Unlock()
+	if fake.HashingSuiteStub != nil {
+		return fake.HashingSuiteStub(channelId)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.hashingSuiteReturns.result1, fake.hashingSuiteReturns.result2
+}
+
+func (fake *CapabilityChecker) HashingSuiteCallCount() int {
+	fake.hashingSuiteMutex.RLock()
+	defer fake.hashingSuiteMutex.RUnlock()
+	return len(fake.hashingSuiteArgsForCall)
+}
+
+func (fake *CapabilityChecker) HashingSuiteArgsForCall(i int) string {
+	fake.hashingSuiteMutex.RLock()
+	defer fake.hashingSuiteMutex.RUnlock()
+	return fake.hashingSuiteArgsForCall[i].channelId
+}
+
+func (fake *CapabilityChecker) HashingSuiteReturns(result1 string, result2 error) {
+	fake.HashingSuiteStub = nil
+	fake.hashingSuiteReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CapabilityChecker) HashingSuiteReturnsOnCall(i int, result1 string, result2 error) {
+	fake.HashingSuiteStub = nil
+	if fake.hashingSuiteReturnsOnCall == nil {
+		fake.hashingSuiteReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.hashingSuiteReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CapabilityChecker) TokenEncryptionKeys(channelId string) (map[string][]byte, error) {
+	fake.tokenEncryptionKeysMutex.Lock()
+	ret, specificReturn := fake.tokenEncryptionKeysReturnsOnCall[len(fake.tokenEncryptionKeysArgsForCall)]
+	fake.tokenEncryptionKeysArgsForCall = append(fake.tokenEncryptionKeysArgsForCall, struct {
+		channelId string
+	}{channelId})
+	fake.recordInvocation("TokenEncryptionKeys", []interface{}{channelId})
+	fake.tokenEncryptionKeysMutex.Unlock()
+	if fake.TokenEncryptionKeysStub != nil {
+		return fake.TokenEncryptionKeysStub(channelId)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.tokenEncryptionKeysReturns.result1, fake.tokenEncryptionKeysReturns.result2
+}
+
+func (fake *CapabilityChecker) TokenEncryptionKeysCallCount() int {
+	fake.tokenEncryptionKeysMutex.RLock()
+	defer fake.tokenEncryptionKeysMutex.RUnlock()
+	fake.txExpirationMutex.RLock()
+	defer fake.txExpirationMutex.RUnlock()
+	return len(fake.tokenEncryptionKeysArgsForCall)
+}
+
+func (fake *CapabilityChecker) TokenEncryptionKeysArgsForCall(i int) string {
+	fake.tokenEncryptionKeysMutex.RLock()
+	defer fake.tokenEncryptionKeysMutex.RUnlock()
+	return fake.tokenEncryptionKeysArgsForCall[i].channelId
+}
+
+func (fake *CapabilityChecker) TokenEncryptionKeysReturns(result1 map[string][]byte, result2 error) {
+	fake.TokenEncryptionKeysStub = nil
+	fake.tokenEncryptionKeysReturns = struct {
+		result1 map[string][]byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CapabilityChecker) TokenEncryptionKeysReturnsOnCall(i int, result1 map[string][]byte, result2 error) {
+	fake.TokenEncryptionKeysStub = nil
+	if fake.tokenEncryptionKeysReturnsOnCall == nil {
+		fake.tokenEncryptionKeysReturnsOnCall = make(map[int]struct {
+			result1 map[string][]byte
+			result2 error
+		})
+	}
+	fake.tokenEncryptionKeysReturnsOnCall[i] = struct {
+		result1 map[string][]byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CapabilityChecker) TxExpiration(channelId string) (bool, error) {
+	fake.txExpirationMutex.Lock()
+	ret, specificReturn := fake.txExpirationReturnsOnCall[len(fake.txExpirationArgsForCall)]
+	fake.txExpirationArgsForCall = append(fake.txExpirationArgsForCall, struct {
+		channelId string
+	}{channelId})
+	fake.recordInvocation("TxExpiration", []interface{}{channelId})
+	fake.txExpirationMutex.Unlock()
+	if fake.TxExpirationStub != nil {
+		return fake.TxExpirationStub(channelId)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.txExpirationReturns.result1, fake.txExpirationReturns.result2
+}
+
+func (fake *CapabilityChecker) TxExpirationCallCount() int {
+	fake.txExpirationMutex.RLock()
+	defer fake.txExpirationMutex.RUnlock()
+	return len(fake.txExpirationArgsForCall)
+}
+
+func (fake *CapabilityChecker) TxExpirationArgsForCall(i int) string {
+	fake.txExpirationMutex.RLock()
+	defer fake.txExpirationMutex.RUnlock()
+	return fake.txExpirationArgsForCall[i].channelId
+}
+
+func (fake *CapabilityChecker) TxExpirationReturns(result1 bool, result2 error) {
+	fake.TxExpirationStub = nil
+	fake.txExpirationReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CapabilityChecker) TxExpirationReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.TxExpirationStub = nil
+	if fake.txExpirationReturnsOnCall == nil {
+		fake.txExpirationReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.txExpirationReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CapabilityChecker) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.fabTokenMutex.RLock()
 	defer fake.fabTokenMutex.RUnlock()
+	fake.hashingSuiteMutex.RLock()
+	defer fake.hashingSuiteMutex.RUnlock()
+	fake.tokenEncryptionKeysMutex.RLock()
+	defer fake.tokenEncryptionKeysMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -35,6 +35,46 @@ type Issuer struct {
 		result1 *token.TokenTransaction
 		result2 error
 	}
+	RequestIssueManifestStub        func([]byte, []*token.IssueChunk) (*token.TokenTransaction, error)
+	requestIssueManifestMutex       sync.RWMutex
+	requestIssueManifestArgsForCall []struct {
+		arg1 []byte
+		arg2 []*token.IssueChunk
+	}
+	requestIssueManifestReturns struct {
+		result1 *token.TokenTransaction
+		result2 error
+	}
+	requestIssueManifestReturnsOnCall map[int]struct {
+		result1 *token.TokenTransaction
+		result2 error
+	}
+	RequestPauseStub        func(string) (*token.TokenTransaction, error)
+	requestPauseMutex       sync.RWMutex
+	requestPauseArgsForCall []struct {
+		arg1 string
+	}
+	requestPauseReturns struct {
+		result1 *token.TokenTransaction
+		result2 error
+	}
+	requestPauseReturnsOnCall map[int]struct {
+		result1 *token.TokenTransaction
+		result2 error
+	}
+	RequestResumeStub        func(string) (*token.TokenTransaction, error)
+	requestResumeMutex       sync.RWMutex
+	requestResumeArgsForCall []struct {
+		arg1 string
+	}
+	requestResumeReturns struct {
+		result1 *token.TokenTransaction
+		result2 error
+	}
+	requestResumeReturnsOnCall map[int]struct {
+		result1 *token.TokenTransaction
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -170,6 +210,206 @@ func (fake *Issuer) RequestImportReturnsOnCall(i int, result1 *token.TokenTransa
 	}{result1, result2}
 }
 
+func (fake *Issuer) RequestIssueManifest(arg1 []byte, arg2 []*token.IssueChunk) (*token.TokenTransaction, error) {
+	var arg1Copy []byte
+	if arg1 != nil {
+		arg1Copy = make([]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	var arg2Copy []*token.IssueChunk
+	if arg2 != nil {
+		arg2Copy = make([]*token.IssueChunk, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.requestIssueManifestMutex.Lock()
+	ret, specificReturn := fake.requestIssueManifestReturnsOnCall[len(fake.requestIssueManifestArgsForCall)]
+	fake.requestIssueManifestArgsForCall = append(fake.requestIssueManifestArgsForCall, struct {
+		arg1 []byte
+		arg2 []*token.IssueChunk
+	}{arg1Copy, arg2Copy})
+	fake.recordInvocation("RequestIssueManifest", []interface{}{arg1Copy, arg2Copy})
+	fake.requestIssueManifestMutex.Unlock()
+	if fake.RequestIssueManifestStub != nil {
+		return fake.RequestIssueManifestStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestIssueManifestReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Issuer) RequestIssueManifestCallCount() int {
+	fake.requestIssueManifestMutex.RLock()
+	defer fake.requestIssueManifestMutex.RUnlock()
+	return len(fake.requestIssueManifestArgsForCall)
+}
+
+func (fake *Issuer) RequestIssueManifestCalls(stub func([]byte, []*token.IssueChunk) (*token.TokenTransaction, error)) {
+	fake.requestIssueManifestMutex.Lock()
+	defer fake.requestIssueManifestMutex.Unlock()
+	fake.RequestIssueManifestStub = stub
+}
+
+func (fake *Issuer) RequestIssueManifestArgsForCall(i int) ([]byte, []*token.IssueChunk) {
+	fake.requestIssueManifestMutex.RLock()
+	defer fake.requestIssueManifestMutex.RUnlock()
+	argsForCall := fake.requestIssueManifestArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Issuer) RequestIssueManifestReturns(result1 *token.TokenTransaction, result2 error) {
+	fake.requestIssueManifestMutex.Lock()
+	defer fake.requestIssueManifestMutex.Unlock()
+	fake.RequestIssueManifestStub = nil
+	fake.requestIssueManifestReturns = struct {
+		result1 *token.TokenTransaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Issuer) RequestIssueManifestReturnsOnCall(i int, result1 *token.TokenTransaction, result2 error) {
+	fake.requestIssueManifestMutex.Lock()
+	defer fake.requestIssueManifestMutex.Unlock()
+	fake.RequestIssueManifestStub = nil
+	if fake.requestIssueManifestReturnsOnCall == nil {
+		fake.requestIssueManifestReturnsOnCall = make(map[int]struct {
+			result1 *token.TokenTransaction
+			result2 error
+		})
+	}
+	fake.requestIssueManifestReturnsOnCall[i] = struct {
+		result1 *token.TokenTransaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Issuer) RequestPause(arg1 string) (*token.TokenTransaction, error) {
+	fake.requestPauseMutex.Lock()
+	ret, specificReturn := fake.requestPauseReturnsOnCall[len(fake.requestPauseArgsForCall)]
+	fake.requestPauseArgsForCall = append(fake.requestPauseArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("RequestPause", []interface{}{arg1})
+	fake.requestPauseMutex.Unlock()
+	if fake.RequestPauseStub != nil {
+		return fake.RequestPauseStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestPauseReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Issuer) RequestPauseCallCount() int {
+	fake.requestPauseMutex.RLock()
+	defer fake.requestPauseMutex.RUnlock()
+	return len(fake.requestPauseArgsForCall)
+}
+
+func (fake *Issuer) RequestPauseCalls(stub func(string) (*token.TokenTransaction, error)) {
+	fake.requestPauseMutex.Lock()
+	defer fake.requestPauseMutex.Unlock()
+	fake.RequestPauseStub = stub
+}
+
+func (fake *Issuer) RequestPauseArgsForCall(i int) string {
+	fake.requestPauseMutex.RLock()
+	defer fake.requestPauseMutex.RUnlock()
+	argsForCall := fake.requestPauseArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Issuer) RequestPauseReturns(result1 *token.TokenTransaction, result2 error) {
+	fake.requestPauseMutex.Lock()
+	defer fake.requestPauseMutex.Unlock()
+	fake.RequestPauseStub = nil
+	fake.requestPauseReturns = struct {
+		result1 *token.TokenTransaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Issuer) RequestPauseReturnsOnCall(i int, result1 *token.TokenTransaction, result2 error) {
+	fake.requestPauseMutex.Lock()
+	defer fake.requestPauseMutex.Unlock()
+	fake.RequestPauseStub = nil
+	if fake.requestPauseReturnsOnCall == nil {
+		fake.requestPauseReturnsOnCall = make(map[int]struct {
+			result1 *token.TokenTransaction
+			result2 error
+		})
+	}
+	fake.requestPauseReturnsOnCall[i] = struct {
+		result1 *token.TokenTransaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Issuer) RequestResume(arg1 string) (*token.TokenTransaction, error) {
+	fake.requestResumeMutex.Lock()
+	ret, specificReturn := fake.requestResumeReturnsOnCall[len(fake.requestResumeArgsForCall)]
+	fake.requestResumeArgsForCall = append(fake.requestResumeArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("RequestResume", []interface{}{arg1})
+	fake.requestResumeMutex.Unlock()
+	if fake.RequestResumeStub != nil {
+		return fake.RequestResumeStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestResumeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Issuer) RequestResumeCallCount() int {
+	fake.requestResumeMutex.RLock()
+	defer fake.requestResumeMutex.RUnlock()
+	return len(fake.requestResumeArgsForCall)
+}
+
+func (fake *Issuer) RequestResumeCalls(stub func(string) (*token.TokenTransaction, error)) {
+	fake.requestResumeMutex.Lock()
+	defer fake.requestResumeMutex.Unlock()
+	fake.RequestResumeStub = stub
+}
+
+func (fake *Issuer) RequestResumeArgsForCall(i int) string {
+	fake.requestResumeMutex.RLock()
+	defer fake.requestResumeMutex.RUnlock()
+	argsForCall := fake.requestResumeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Issuer) RequestResumeReturns(result1 *token.TokenTransaction, result2 error) {
+	fake.requestResumeMutex.Lock()
+	defer fake.requestResumeMutex.Unlock()
+	fake.RequestResumeStub = nil
+	fake.requestResumeReturns = struct {
+		result1 *token.TokenTransaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Issuer) RequestResumeReturnsOnCall(i int, result1 *token.TokenTransaction, result2 error) {
+	fake.requestResumeMutex.Lock()
+	defer fake.requestResumeMutex.Unlock()
+	fake.RequestResumeStub = nil
+	if fake.requestResumeReturnsOnCall == nil {
+		fake.requestResumeReturnsOnCall = make(map[int]struct {
+			result1 *token.TokenTransaction
+			result2 error
+		})
+	}
+	fake.requestResumeReturnsOnCall[i] = struct {
+		result1 *token.TokenTransaction
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Issuer) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -177,6 +417,12 @@ func (fake *Issuer) Invocations() map[string][][]interface{} {
 	defer fake.requestExpectationMutex.RUnlock()
 	fake.requestImportMutex.RLock()
 	defer fake.requestImportMutex.RUnlock()
+	fake.requestIssueManifestMutex.RLock()
+	defer fake.requestIssueManifestMutex.RUnlock()
+	fake.requestPauseMutex.RLock()
+	defer fake.requestPauseMutex.RUnlock()
+	fake.requestResumeMutex.RLock()
+	defer fake.requestResumeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -25,6 +25,19 @@ type Transactor struct {
 		result1 *token.UnspentTokens
 		result2 error
 	}
+	ListTransactionsByReferenceStub        func([]byte) (*token.ReferencedTransactions, error)
+	listTransactionsByReferenceMutex       sync.RWMutex
+	listTransactionsByReferenceArgsForCall []struct {
+		arg1 []byte
+	}
+	listTransactionsByReferenceReturns struct {
+		result1 *token.ReferencedTransactions
+		result2 error
+	}
+	listTransactionsByReferenceReturnsOnCall map[int]struct {
+		result1 *token.ReferencedTransactions
+		result2 error
+	}
 	RequestApproveStub        func(*token.ApproveRequest) (*token.TokenTransaction, error)
 	requestApproveMutex       sync.RWMutex
 	requestApproveArgsForCall []struct {
@@ -172,6 +185,74 @@ func (fake *Transactor) ListTokensReturnsOnCall(i int, result1 *token.UnspentTok
 	}{result1, result2}
 }
 
+func (fake *Transactor) ListTransactionsByReference(arg1 []byte) (*token.ReferencedTransactions, error) {
+	var arg1Copy []byte
+	if arg1 != nil {
+		arg1Copy = make([]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.listTransactionsByReferenceMutex.Lock()
+	ret, specificReturn := fake.listTransactionsByReferenceReturnsOnCall[len(fake.listTransactionsByReferenceArgsForCall)]
+	fake.listTransactionsByReferenceArgsForCall = append(fake.listTransactionsByReferenceArgsForCall, struct {
+		arg1 []byte
+	}{arg1Copy})
+	fake.recordInvocation("ListTransactionsByReference", []interface{}{arg1Copy})
+	fake.listTransactionsByReferenceMutex.Unlock()
+	if fake.ListTransactionsByReferenceStub != nil {
+		return fake.ListTransactionsByReferenceStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listTransactionsByReferenceReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Transactor) ListTransactionsByReferenceCallCount() int {
+	fake.listTransactionsByReferenceMutex.RLock()
+	defer fake.listTransactionsByReferenceMutex.RUnlock()
+	return len(fake.listTransactionsByReferenceArgsForCall)
+}
+
+func (fake *Transactor) ListTransactionsByReferenceCalls(stub func([]byte) (*token.ReferencedTransactions, error)) {
+	fake.listTransactionsByReferenceMutex.Lock()
+	defer fake.listTransactionsByReferenceMutex.Unlock()
+	fake.ListTransactionsByReferenceStub = stub
+}
+
+func (fake *Transactor) ListTransactionsByReferenceArgsForCall(i int) []byte {
+	fake.listTransactionsByReferenceMutex.RLock()
+	defer fake.listTransactionsByReferenceMutex.RUnlock()
+	argsForCall := fake.listTransactionsByReferenceArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Transactor) ListTransactionsByReferenceReturns(result1 *token.ReferencedTransactions, result2 error) {
+	fake.listTransactionsByReferenceMutex.Lock()
+	defer fake.listTransactionsByReferenceMutex.Unlock()
+	fake.ListTransactionsByReferenceStub = nil
+	fake.listTransactionsByReferenceReturns = struct {
+		result1 *token.ReferencedTransactions
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Transactor) ListTransactionsByReferenceReturnsOnCall(i int, result1 *token.ReferencedTransactions, result2 error) {
+	fake.listTransactionsByReferenceMutex.Lock()
+	defer fake.listTransactionsByReferenceMutex.Unlock()
+	fake.ListTransactionsByReferenceStub = nil
+	if fake.listTransactionsByReferenceReturnsOnCall == nil {
+		fake.listTransactionsByReferenceReturnsOnCall = make(map[int]struct {
+			result1 *token.ReferencedTransactions
+			result2 error
+		})
+	}
+	fake.listTransactionsByReferenceReturnsOnCall[i] = struct {
+		result1 *token.ReferencedTransactions
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Transactor) RequestApprove(arg1 *token.ApproveRequest) (*token.TokenTransaction, error) {
 	fake.requestApproveMutex.Lock()
 	ret, specificReturn := fake.requestApproveReturnsOnCall[len(fake.requestApproveArgsForCall)]
@@ -494,6 +575,8 @@ func (fake *Transactor) Invocations() map[string][][]interface{} {
 	defer fake.doneMutex.RUnlock()
 	fake.listTokensMutex.RLock()
 	defer fake.listTokensMutex.RUnlock()
+	fake.listTransactionsByReferenceMutex.RLock()
+	defer fake.listTransactionsByReferenceMutex.RUnlock()
 	fake.requestApproveMutex.RLock()
 	defer fake.requestApproveMutex.RUnlock()
 	fake.requestExpectationMutex.RLock()
@@ -8,11 +8,20 @@ package server
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metadata"
 	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/hyperledger/fabric/token/tms/manager"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+var logger = flogging.MustGetLogger("token.server")
+
 //go:generate counterfeiter -o mock/access_control.go -fake-name PolicyChecker . PolicyChecker
 
 // A PolicyChecker is responsible for performing policy based access control
@@ -34,6 +43,35 @@ type Prover struct {
 	Marshaler         Marshaler
 	PolicyChecker     PolicyChecker
 	TMSManager        TMSManager
+	// HeaderExtensionValidators validate the values of the header extensions
+	// with their name, in addition to tk.DefaultHeaderExtensionValidators.
+	// The values of other extensions are opaque; all of them are available to
+	// the PolicyChecker in the command header.
+	HeaderExtensionValidators map[string]tk.HeaderExtensionValidator
+	// Flushers are flushed on Shutdown once in-flight commands complete.
+	Flushers []Flusher
+	// QueryOnly restricts the prover to the commands that query the ledger,
+	// such as listing tokens; the assembly of token transactions is refused.
+	QueryOnly bool
+	// PseudonymVerifier, when set, enables list requests carrying pseudonym
+	// proofs, which list the tokens owned by the pseudonyms of the creator.
+	// The creator is authenticated by the policy checker, e.g. with an Idemix
+	// proof that it is a member of an organization, without revealing which
+	// member; the outputs owned by the creator itself are not listed.
+	PseudonymVerifier PseudonymVerifier
+	// SupplyAttestor, when set, enables supply attestation requests, which
+	// return the circulating supply of the token types of the channel
+	// signed by the peer.
+	SupplyAttestor SupplyAttestor
+	// CommitAttestor, when set, enables commit attestation requests, which
+	// return the validation results of the block of a transaction signed by
+	// the peer.
+	CommitAttestor CommitAttestor
+	// ReplayGuard, when set, rejects the replays of the commands assembling
+	// token transactions; queries may be replayed.
+	ReplayGuard *ReplayGuard
+
+	drainer drainer
 }
 
 // NewProver creates a Prover
@@ -47,12 +85,18 @@ func NewProver(policyChecker PolicyChecker, signingIdentity SignerIdentity) (*Pr
 		Marshaler:     responseMarshaler,
 		PolicyChecker: policyChecker,
 		TMSManager: &Manager{
-			LedgerManager: &PeerLedgerManager{},
+			LedgerManager:               &PeerLedgerManager{},
+			IdentityDeserializerManager: &manager.FabricIdentityDeserializerManager{},
 		},
 	}, nil
 }
 
 func (s *Prover) ProcessCommand(ctx context.Context, sc *token.SignedCommand) (*token.SignedCommandResponse, error) {
+	if !s.drainer.begin() {
+		return nil, status.Error(codes.Unavailable, "prover is shutting down")
+	}
+	defer s.drainer.end()
+
 	command, err := UnmarshalCommand(sc.Command)
 	if err != nil {
 		return s.MarshalErrorResponse(sc.Command, err)
@@ -63,21 +107,52 @@ func (s *Prover) ProcessCommand(ctx context.Context, sc *token.SignedCommand) (*
 		return s.MarshalErrorResponse(sc.Command, err)
 	}
 
-	// check if FabToken capability is enabled
+	// check if FabToken capability is enabled; capability requests are
+	// answered regardless, so that clients can find out before submitting
 	channelId := command.Header.ChannelId
 	enabled, err := s.CapabilityChecker.FabToken(channelId)
 	if err != nil {
 		return s.MarshalErrorResponse(sc.Command, err)
 	}
-	if !enabled {
+	_, capabilitiesRequest := command.GetPayload().(*token.Command_CapabilitiesRequest)
+	if !enabled && !capabilitiesRequest {
 		return s.MarshalErrorResponse(sc.Command, errors.Errorf("FabToken capability not enabled for channel %s", channelId))
 	}
 
+	// the commands of the types added after the version of the peer
+	// unmarshal with no payload
+	if command.GetPayload() == nil {
+		return s.MarshalErrorResponse(sc.Command, unsupportedCommandError(command.Header))
+	}
+
+	if s.QueryOnly && !isQueryCommand(command) {
+		return s.MarshalErrorResponse(sc.Command, errors.Errorf("prover is query-only and does not process command %T", command.GetPayload()))
+	}
+
+	lg := logger.ForTransaction(channelId, "", command.Header.Creator)
+	if correlationID := tk.CorrelationID(ctx); correlationID != "" {
+		lg = lg.With(flogging.CorrelationIDKey, correlationID)
+	}
+	if traceParent, ok := tk.GetHeaderExtension(command.Header, tk.TraceContextExtension); ok {
+		lg = lg.With(tk.TraceContextExtension, string(traceParent))
+	}
+	lg.Debugf("processing command %T", command.GetPayload())
 	err = s.PolicyChecker.Check(sc, command)
 	if err != nil {
+		lg.Warningf("access denied for command %T: %s", command.GetPayload(), err)
 		return s.MarshalErrorResponse(sc.Command, err)
 	}
 
+	// replays are checked once the creator is authenticated, so that others
+	// cannot consume its nonces
+	if s.ReplayGuard != nil && !isQueryCommand(command) {
+		err = s.ReplayGuard.Check(command.Header)
+		if err != nil {
+			lg.Warningf("replay of command %T rejected: %s", command.GetPayload(), err)
+			return s.MarshalErrorResponse(sc.Command, err)
+		}
+	}
+
 	var payload interface{}
 	switch t := command.GetPayload().(type) {
 	case *token.Command_ImportRequest:
@@ -94,11 +169,44 @@ func (s *Prover) ProcessCommand(ctx context.Context, sc *token.SignedCommand) (*
 		payload, err = s.RequestTransferFrom(ctx, command.Header, t.TransferFromRequest)
 	case *token.Command_ExpectationRequest:
 		payload, err = s.RequestExpectation(ctx, command.Header, t.ExpectationRequest)
+	case *token.Command_BalanceRequest:
+		payload, err = s.ListBalances(ctx, command.Header, t.BalanceRequest)
+	case *token.Command_CreditRequest:
+		payload, err = s.RequestCredit(ctx, command.Header, t.CreditRequest)
+	case *token.Command_DebitRequest:
+		payload, err = s.RequestDebit(ctx, command.Header, t.DebitRequest)
+	case *token.Command_PauseRequest:
+		payload, err = s.RequestPause(ctx, command.Header, t.PauseRequest)
+	case *token.Command_ResumeRequest:
+		payload, err = s.RequestResume(ctx, command.Header, t.ResumeRequest)
+	case *token.Command_IssueManifestRequest:
+		payload, err = s.RequestIssueManifest(ctx, command.Header, t.IssueManifestRequest)
+	case *token.Command_SupplyAttestationRequest:
+		payload, err = s.RequestSupplyAttestation(ctx, command.Header, t.SupplyAttestationRequest)
+	case *token.Command_CommitAttestationRequest:
+		payload, err = s.RequestCommitAttestation(ctx, command.Header, t.CommitAttestationRequest)
+	case *token.Command_ReferenceRequest:
+		payload, err = s.ListReferencedTransactions(ctx, command.Header, t.ReferenceRequest)
+	case *token.Command_CapabilitiesRequest:
+		var hashingSuite string
+		var keys map[string][]byte
+		var txExpiration bool
+		hashingSuite, err = s.CapabilityChecker.HashingSuite(channelId)
+		if err == nil {
+			keys, err = s.CapabilityChecker.TokenEncryptionKeys(channelId)
+		}
+		if err == nil {
+			txExpiration, err = s.CapabilityChecker.TxExpiration(channelId)
+		}
+		payload = &token.CommandResponse_ChannelCapabilities{
+			ChannelCapabilities: &token.ChannelCapabilities{FabToken: enabled, HashingSuite: hashingSuite, TokenEncryptionKeys: keys, TxExpiration: txExpiration},
+		}
 	default:
 		err = errors.Errorf("command type not recognized: %T", t)
 	}
 
 	if err != nil {
+		lg.Debugf("command %T failed: %s", command.GetPayload(), err)
 		payload = &token.CommandResponse_Err{
 			Err: &token.Error{Message: err.Error()},
 		}
@@ -107,6 +215,28 @@ func (s *Prover) ProcessCommand(ctx context.Context, sc *token.SignedCommand) (*
 	return s.Marshaler.MarshalCommandResponse(sc.Command, payload)
 }
 
+// unsupportedCommandError reports a command of a type the prover does not
+// know, with the versions of the peer and of the client, when the client
+// reports it, so that clients can tell the incompatibility.
+func unsupportedCommandError(header *token.Header) error {
+	clientVersion := "unknown"
+	if version, ok := tk.GetHeaderExtension(header, tk.ClientVersionExtension); ok {
+		clientVersion = string(version)
+	}
+	return errors.Errorf("%s (peer version %s, client version %s): the client may be newer than the peer", tk.UnsupportedCommandMessage, metadata.Version, clientVersion)
+}
+
+// isQueryCommand returns true if the command only queries the ledger.
+func isQueryCommand(command *token.Command) bool {
+	switch command.GetPayload().(type) {
+	case *token.Command_ListRequest, *token.Command_BalanceRequest, *token.Command_ReferenceRequest, *token.Command_CapabilitiesRequest,
+		*token.Command_SupplyAttestationRequest, *token.Command_CommitAttestationRequest:
+		return true
+	default:
+		return false
+	}
+}
+
 func (s *Prover) RequestImport(ctx context.Context, header *token.Header, requestImport *token.ImportRequest) (*token.CommandResponse_TokenTransaction, error) {
 	issuer, err := s.TMSManager.GetIssuer(header.ChannelId, requestImport.Credential, header.Creator)
 	if err != nil {
@@ -152,6 +282,10 @@ func (s *Prover) RequestRedeem(ctx context.Context, header *token.Header, reques
 }
 
 func (s *Prover) ListUnspentTokens(ctxt context.Context, header *token.Header, listRequest *token.ListRequest) (*token.CommandResponse_UnspentTokens, error) {
+	if len(listRequest.PseudonymProofs) != 0 {
+		return s.listPseudonymTokens(header, listRequest)
+	}
+
 	transactor, err := s.TMSManager.GetTransactor(header.ChannelId, listRequest.Credential, header.Creator)
 	if err != nil {
 		return nil, err
@@ -166,6 +300,63 @@ func (s *Prover) ListUnspentTokens(ctxt context.Context, header *token.Header, l
 	return &token.CommandResponse_UnspentTokens{UnspentTokens: tokens}, nil
 }
 
+// listPseudonymTokens lists the unspent tokens owned by the pseudonyms of
+// the list request, once the proofs of the creator holding them are verified.
+func (s *Prover) listPseudonymTokens(header *token.Header, listRequest *token.ListRequest) (*token.CommandResponse_UnspentTokens, error) {
+	if s.PseudonymVerifier == nil {
+		return nil, errors.New("listing the tokens of pseudonyms is not enabled on this prover")
+	}
+
+	message := tk.PseudonymProofMessage(header)
+	owners := make([][]byte, 0, len(listRequest.PseudonymProofs))
+	seen := map[string]bool{}
+	for i, proof := range listRequest.PseudonymProofs {
+		if len(proof.Owner) == 0 {
+			return nil, errors.Errorf("pseudonym proof %d has no owner", i)
+		}
+		if seen[string(proof.Owner)] {
+			continue
+		}
+		err := s.PseudonymVerifier.VerifyPseudonym(header.ChannelId, proof.Owner, message, proof.Signature)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("failed verifying pseudonym proof %d", i))
+		}
+		seen[string(proof.Owner)] = true
+		owners = append(owners, proof.Owner)
+	}
+
+	unspentTokens := &token.UnspentTokens{Tokens: []*token.TokenOutput{}}
+	for _, owner := range owners {
+		transactor, err := s.TMSManager.GetTransactor(header.ChannelId, listRequest.Credential, owner)
+		if err != nil {
+			return nil, err
+		}
+		tokens, err := transactor.ListTokens()
+		transactor.Done()
+		if err != nil {
+			return nil, err
+		}
+		unspentTokens.Tokens = append(unspentTokens.Tokens, tokens.Tokens...)
+	}
+
+	return &token.CommandResponse_UnspentTokens{UnspentTokens: unspentTokens}, nil
+}
+
+func (s *Prover) ListReferencedTransactions(ctx context.Context, header *token.Header, request *token.ReferenceRequest) (*token.CommandResponse_ReferencedTransactions, error) {
+	transactor, err := s.TMSManager.GetTransactor(header.ChannelId, request.Credential, header.Creator)
+	if err != nil {
+		return nil, err
+	}
+	defer transactor.Done()
+
+	transactions, err := transactor.ListTransactionsByReference(request.ApplicationReference)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.CommandResponse_ReferencedTransactions{ReferencedTransactions: transactions}, nil
+}
+
 func (s *Prover) RequestApprove(ctx context.Context, header *token.Header, request *token.ApproveRequest) (*token.CommandResponse_TokenTransaction, error) {
 	transactor, err := s.TMSManager.GetTransactor(header.ChannelId, request.Credential, header.Creator)
 	if err != nil {
@@ -254,7 +445,21 @@ func (s *Prover) ValidateHeader(header *token.Header) error {
 		return errors.New("creator is required in header")
 	}
 
-	return nil
+	return tk.ValidateHeaderExtensions(header.Extensions, s.headerExtensionValidators())
+}
+
+func (s *Prover) headerExtensionValidators() map[string]tk.HeaderExtensionValidator {
+	if len(s.HeaderExtensionValidators) == 0 {
+		return tk.DefaultHeaderExtensionValidators
+	}
+	validators := map[string]tk.HeaderExtensionValidator{}
+	for name, validator := range tk.DefaultHeaderExtensionValidators {
+		validators[name] = validator
+	}
+	for name, validator := range s.HeaderExtensionValidators {
+		validators[name] = validator
+	}
+	return validators
 }
 
 func (s *Prover) MarshalErrorResponse(command []byte, e error) (*token.SignedCommandResponse, error) {
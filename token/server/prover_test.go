@@ -8,14 +8,17 @@ package server_test
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"strconv"
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/metadata"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
 	mock2 "github.com/hyperledger/fabric/token/ledger/mock"
 	"github.com/hyperledger/fabric/token/server"
 	"github.com/hyperledger/fabric/token/server/mock"
@@ -68,6 +71,7 @@ var _ = Describe("Prover", func() {
 	BeforeEach(func() {
 		fakeCapabilityChecker = &mock.CapabilityChecker{}
 		fakeCapabilityChecker.FabTokenReturns(true, nil)
+		fakeCapabilityChecker.HashingSuiteReturns("SHA256", nil)
 		fakePolicyChecker = &mock.PolicyChecker{}
 
 		tokenTransaction = &token.TokenTransaction{
@@ -377,6 +381,64 @@ var _ = Describe("Prover", func() {
 			})
 		})
 
+		Context("when the command header carries extensions", func() {
+			BeforeEach(func() {
+				command.Header.Extensions = []*token.HeaderExtension{
+					tk.NewClientVersionExtension("1.4.0"),
+					{Name: "app/route", Value: []byte("checkout")},
+				}
+				signedCommand.Command = ProtoMarshal(command)
+			})
+
+			It("passes them to the access control checks", func() {
+				_, err := prover.ProcessCommand(context.Background(), signedCommand)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakePolicyChecker.CheckCallCount()).To(Equal(1))
+				_, c := fakePolicyChecker.CheckArgsForCall(0)
+				value, ok := tk.GetHeaderExtension(c.Header, "app/route")
+				Expect(ok).To(BeTrue())
+				Expect(value).To(Equal([]byte("checkout")))
+			})
+
+			Context("when an extension is rejected by a registered validator", func() {
+				BeforeEach(func() {
+					prover.HeaderExtensionValidators = map[string]tk.HeaderExtensionValidator{
+						"app/route": func(value []byte) error { return errors.New("unknown route") },
+					}
+				})
+
+				It("returns an error response", func() {
+					resp, err := prover.ProcessCommand(context.Background(), signedCommand)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(resp).To(Equal(marshaledResponse))
+
+					Expect(fakePolicyChecker.CheckCallCount()).To(Equal(0))
+					_, payload := fakeMarshaler.MarshalCommandResponseArgsForCall(0)
+					Expect(payload).To(Equal(&token.CommandResponse_Err{
+						Err: &token.Error{Message: "invalid header extension 'app/route': unknown route"},
+					}))
+				})
+			})
+
+			Context("when a well-known extension is malformed", func() {
+				BeforeEach(func() {
+					command.Header.Extensions = []*token.HeaderExtension{tk.NewTraceContextExtension("not-a-trace")}
+					signedCommand.Command = ProtoMarshal(command)
+				})
+
+				It("returns an error response", func() {
+					_, err := prover.ProcessCommand(context.Background(), signedCommand)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, payload := fakeMarshaler.MarshalCommandResponseArgsForCall(0)
+					Expect(payload).To(Equal(&token.CommandResponse_Err{
+						Err: &token.Error{Message: "invalid header extension 'traceparent': malformed traceparent"},
+					}))
+				})
+			})
+		})
+
 		Context("when an unknown command is received", func() {
 			BeforeEach(func() {
 				command.Payload = nil
@@ -392,8 +454,26 @@ var _ = Describe("Prover", func() {
 				cmd, payload := fakeMarshaler.MarshalCommandResponseArgsForCall(0)
 				Expect(cmd).To(Equal(ProtoMarshal(command)))
 				Expect(payload).To(Equal(&token.CommandResponse_Err{
-					Err: &token.Error{Message: "command type not recognized: <nil>"},
+					Err: &token.Error{Message: fmt.Sprintf("command type not supported by this prover (peer version %s, client version unknown): the client may be newer than the peer", metadata.Version)},
 				}))
+				Expect(fakePolicyChecker.CheckCallCount()).To(Equal(0))
+			})
+
+			Context("when the client reports its version", func() {
+				BeforeEach(func() {
+					command.Header.Extensions = []*token.HeaderExtension{tk.NewClientVersionExtension("2.1.0")}
+					signedCommand.Command = ProtoMarshal(command)
+				})
+
+				It("reports the version of the client", func() {
+					_, err := prover.ProcessCommand(context.Background(), signedCommand)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, payload := fakeMarshaler.MarshalCommandResponseArgsForCall(0)
+					Expect(payload).To(Equal(&token.CommandResponse_Err{
+						Err: &token.Error{Message: fmt.Sprintf("command type not supported by this prover (peer version %s, client version 2.1.0): the client may be newer than the peer", metadata.Version)},
+					}))
+				})
 			})
 		})
 
@@ -417,6 +497,218 @@ var _ = Describe("Prover", func() {
 		})
 	})
 
+	Describe("ProcessCommand with a replay guard", func() {
+		BeforeEach(func() {
+			prover.ReplayGuard = server.NewReplayGuard(time.Minute, time.Now)
+		})
+
+		It("rejects the replays of the command", func() {
+			_, err := prover.ProcessCommand(context.Background(), signedCommand)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = prover.ProcessCommand(context.Background(), signedCommand)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeIssuer.RequestImportCallCount()).To(Equal(1))
+			Expect(fakeMarshaler.MarshalCommandResponseCallCount()).To(Equal(2))
+			_, payload := fakeMarshaler.MarshalCommandResponseArgsForCall(1)
+			Expect(payload).To(Equal(&token.CommandResponse_Err{
+				Err: &token.Error{Message: "command with nonce 6e6f6e6365 was already processed"},
+			}))
+		})
+
+		Context("when the command lists tokens", func() {
+			BeforeEach(func() {
+				command.Payload = &token.Command_ListRequest{ListRequest: listRequest}
+				signedCommand.Command = ProtoMarshal(command)
+			})
+
+			It("processes the replays of the command", func() {
+				_, err := prover.ProcessCommand(context.Background(), signedCommand)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = prover.ProcessCommand(context.Background(), signedCommand)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, payload := fakeMarshaler.MarshalCommandResponseArgsForCall(1)
+				Expect(payload).To(Equal(&token.CommandResponse_UnspentTokens{
+					UnspentTokens: unspentTokens,
+				}))
+			})
+		})
+	})
+
+	Describe("ProcessCommand on a query-only prover", func() {
+		BeforeEach(func() {
+			prover.QueryOnly = true
+		})
+
+		It("refuses to assemble token transactions", func() {
+			resp, err := prover.ProcessCommand(context.Background(), signedCommand)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(marshaledResponse))
+
+			Expect(fakeIssuer.RequestImportCallCount()).To(Equal(0))
+			Expect(fakeMarshaler.MarshalCommandResponseCallCount()).To(Equal(1))
+			_, payload := fakeMarshaler.MarshalCommandResponseArgsForCall(0)
+			Expect(payload).To(Equal(&token.CommandResponse_Err{
+				Err: &token.Error{Message: "prover is query-only and does not process command *token.Command_ImportRequest"},
+			}))
+		})
+
+		Context("when the command lists tokens", func() {
+			BeforeEach(func() {
+				command.Payload = &token.Command_ListRequest{ListRequest: listRequest}
+				signedCommand.Command = ProtoMarshal(command)
+			})
+
+			It("processes the command", func() {
+				_, err := prover.ProcessCommand(context.Background(), signedCommand)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeMarshaler.MarshalCommandResponseCallCount()).To(Equal(1))
+				_, payload := fakeMarshaler.MarshalCommandResponseArgsForCall(0)
+				Expect(payload).To(Equal(&token.CommandResponse_UnspentTokens{
+					UnspentTokens: unspentTokens,
+				}))
+			})
+		})
+	})
+
+	Describe("ProcessCommand_CapabilitiesRequest", func() {
+		BeforeEach(func() {
+			command = &token.Command{
+				Header: &token.Header{
+					ChannelId: "channel-id",
+					Creator:   []byte("creator"),
+					Nonce:     []byte("nonce"),
+				},
+				Payload: &token.Command_CapabilitiesRequest{
+					CapabilitiesRequest: &token.CapabilitiesRequest{},
+				},
+			}
+			marshaledCommand = ProtoMarshal(command)
+			signedCommand = &token.SignedCommand{
+				Command:   marshaledCommand,
+				Signature: []byte("command-signature"),
+			}
+			fakeMarshaler.MarshalCommandResponseReturns(marshaledResponse, nil)
+		})
+
+		It("returns the channel capabilities", func() {
+			resp, err := prover.ProcessCommand(context.Background(), signedCommand)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(marshaledResponse))
+
+			Expect(fakeMarshaler.MarshalCommandResponseCallCount()).To(Equal(1))
+			_, payload := fakeMarshaler.MarshalCommandResponseArgsForCall(0)
+			Expect(payload).To(Equal(&token.CommandResponse_ChannelCapabilities{
+				ChannelCapabilities: &token.ChannelCapabilities{FabToken: true, HashingSuite: "SHA256"},
+			}))
+			Expect(fakeCapabilityChecker.HashingSuiteArgsForCall(0)).To(Equal("channel-id"))
+		})
+
+		Context("when the hashing suite cannot be determined", func() {
+			BeforeEach(func() {
+				fakeCapabilityChecker.HashingSuiteReturns("", errors.New("no channel config"))
+			})
+
+			It("returns an error response", func() {
+				_, err := prover.ProcessCommand(context.Background(), signedCommand)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, payload := fakeMarshaler.MarshalCommandResponseArgsForCall(0)
+				Expect(payload).To(Equal(&token.CommandResponse_Err{
+					Err: &token.Error{Message: "no channel config"},
+				}))
+			})
+		})
+
+		Context("when the token transactions are encrypted", func() {
+			BeforeEach(func() {
+				fakeCapabilityChecker.TokenEncryptionKeysReturns(map[string][]byte{"Org1MSP": []byte("public-key")}, nil)
+			})
+
+			It("returns the token encryption keys", func() {
+				_, err := prover.ProcessCommand(context.Background(), signedCommand)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, payload := fakeMarshaler.MarshalCommandResponseArgsForCall(0)
+				Expect(payload).To(Equal(&token.CommandResponse_ChannelCapabilities{
+					ChannelCapabilities: &token.ChannelCapabilities{
+						FabToken:            true,
+						HashingSuite:        "SHA256",
+						TokenEncryptionKeys: map[string][]byte{"Org1MSP": []byte("public-key")},
+					},
+				}))
+				Expect(fakeCapabilityChecker.TokenEncryptionKeysArgsForCall(0)).To(Equal("channel-id"))
+			})
+		})
+
+		Context("when the token encryption keys cannot be determined", func() {
+			BeforeEach(func() {
+				fakeCapabilityChecker.TokenEncryptionKeysReturns(nil, errors.New("no application config"))
+			})
+
+			It("returns an error response", func() {
+				_, err := prover.ProcessCommand(context.Background(), signedCommand)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, payload := fakeMarshaler.MarshalCommandResponseArgsForCall(0)
+				Expect(payload).To(Equal(&token.CommandResponse_Err{
+					Err: &token.Error{Message: "no application config"},
+				}))
+			})
+		})
+
+		Context("when the transactions expire", func() {
+			BeforeEach(func() {
+				fakeCapabilityChecker.TxExpirationReturns(true, nil)
+			})
+
+			It("reports the capability as enabled", func() {
+				_, err := prover.ProcessCommand(context.Background(), signedCommand)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, payload := fakeMarshaler.MarshalCommandResponseArgsForCall(0)
+				Expect(payload).To(Equal(&token.CommandResponse_ChannelCapabilities{
+					ChannelCapabilities: &token.ChannelCapabilities{FabToken: true, HashingSuite: "SHA256", TxExpiration: true},
+				}))
+				Expect(fakeCapabilityChecker.TxExpirationArgsForCall(0)).To(Equal("channel-id"))
+			})
+		})
+
+		Context("when the expiration of the transactions cannot be determined", func() {
+			BeforeEach(func() {
+				fakeCapabilityChecker.TxExpirationReturns(false, errors.New("no application config"))
+			})
+
+			It("returns an error response", func() {
+				_, err := prover.ProcessCommand(context.Background(), signedCommand)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, payload := fakeMarshaler.MarshalCommandResponseArgsForCall(0)
+				Expect(payload).To(Equal(&token.CommandResponse_Err{
+					Err: &token.Error{Message: "no application config"},
+				}))
+			})
+		})
+
+		Context("when fabtoken capability is not enabled", func() {
+			BeforeEach(func() {
+				fakeCapabilityChecker.FabTokenReturns(false, nil)
+			})
+
+			It("reports the capability as disabled", func() {
+				_, err := prover.ProcessCommand(context.Background(), signedCommand)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, payload := fakeMarshaler.MarshalCommandResponseArgsForCall(0)
+				Expect(payload).To(Equal(&token.CommandResponse_ChannelCapabilities{
+					ChannelCapabilities: &token.ChannelCapabilities{FabToken: false, HashingSuite: "SHA256"},
+				}))
+			})
+		})
+	})
+
 	Describe("ProcessCommand_RequestImport", func() {
 		It("returns a signed command response", func() {
 			resp, err := prover.ProcessCommand(context.Background(), signedCommand)
@@ -548,6 +840,93 @@ var _ = Describe("Prover", func() {
 		})
 	})
 
+	Describe("ListUnspentTokens with pseudonym proofs", func() {
+		var fakePseudonymVerifier *mock.PseudonymVerifier
+
+		BeforeEach(func() {
+			fakePseudonymVerifier = &mock.PseudonymVerifier{}
+			prover.PseudonymVerifier = fakePseudonymVerifier
+			listRequest.PseudonymProofs = []*token.PseudonymProof{
+				{Owner: []byte("pseudonym-1"), Signature: []byte("signature-1")},
+				{Owner: []byte("pseudonym-2"), Signature: []byte("signature-2")},
+				{Owner: []byte("pseudonym-1"), Signature: []byte("signature-1")},
+			}
+		})
+
+		It("lists the tokens of each verified pseudonym", func() {
+			resp, err := prover.ListUnspentTokens(context.Background(), command.Header, listRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&token.CommandResponse_UnspentTokens{
+				UnspentTokens: &token.UnspentTokens{Tokens: append(transactorTokens, transactorTokens...)},
+			}))
+
+			Expect(fakePseudonymVerifier.VerifyPseudonymCallCount()).To(Equal(2))
+			channel, pseudonym, message, signature := fakePseudonymVerifier.VerifyPseudonymArgsForCall(0)
+			Expect(channel).To(Equal("channel-id"))
+			Expect(pseudonym).To(Equal([]byte("pseudonym-1")))
+			Expect(message).To(Equal([]byte("noncecreator")))
+			Expect(signature).To(Equal([]byte("signature-1")))
+			_, pseudonym, _, signature = fakePseudonymVerifier.VerifyPseudonymArgsForCall(1)
+			Expect(pseudonym).To(Equal([]byte("pseudonym-2")))
+			Expect(signature).To(Equal([]byte("signature-2")))
+
+			Expect(fakeTMSManager.GetTransactorCallCount()).To(Equal(2))
+			_, privateCredential, publicCredential := fakeTMSManager.GetTransactorArgsForCall(0)
+			Expect(privateCredential).To(Equal([]byte("credential")))
+			Expect(publicCredential).To(Equal([]byte("pseudonym-1")))
+			_, _, publicCredential = fakeTMSManager.GetTransactorArgsForCall(1)
+			Expect(publicCredential).To(Equal([]byte("pseudonym-2")))
+			Expect(fakeTransactor.DoneCallCount()).To(Equal(2))
+		})
+
+		Context("when a pseudonym proof is invalid", func() {
+			BeforeEach(func() {
+				fakePseudonymVerifier.VerifyPseudonymReturnsOnCall(1, errors.New("invalid pseudonym signature"))
+			})
+
+			It("returns an error without listing tokens", func() {
+				_, err := prover.ListUnspentTokens(context.Background(), command.Header, listRequest)
+				Expect(err).To(MatchError("failed verifying pseudonym proof 1: invalid pseudonym signature"))
+				Expect(fakeTMSManager.GetTransactorCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when a pseudonym proof has no owner", func() {
+			BeforeEach(func() {
+				listRequest.PseudonymProofs[1].Owner = nil
+			})
+
+			It("returns an error", func() {
+				_, err := prover.ListUnspentTokens(context.Background(), command.Header, listRequest)
+				Expect(err).To(MatchError("pseudonym proof 1 has no owner"))
+			})
+		})
+
+		Context("when the transactor fails to list tokens", func() {
+			BeforeEach(func() {
+				fakeTransactor.ListTokensReturns(nil, errors.New("boing boing"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.ListUnspentTokens(context.Background(), command.Header, listRequest)
+				Expect(err).To(MatchError("boing boing"))
+				Expect(fakeTransactor.DoneCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the prover does not list the tokens of pseudonyms", func() {
+			BeforeEach(func() {
+				prover.PseudonymVerifier = nil
+			})
+
+			It("returns an error", func() {
+				_, err := prover.ListUnspentTokens(context.Background(), command.Header, listRequest)
+				Expect(err).To(MatchError("listing the tokens of pseudonyms is not enabled on this prover"))
+				Expect(fakeTMSManager.GetTransactorCallCount()).To(Equal(0))
+			})
+		})
+	})
+
 	Describe("RequestImport", func() {
 		It("gets an issuer", func() {
 			_, err := prover.RequestImport(context.Background(), command.Header, importRequest)
@@ -1023,6 +1402,42 @@ var _ = Describe("Prover", func() {
 		})
 	})
 
+	Describe("ListReferencedTransactions", func() {
+		var (
+			referenceRequest *token.ReferenceRequest
+			transactions     *token.ReferencedTransactions
+		)
+
+		BeforeEach(func() {
+			referenceRequest = &token.ReferenceRequest{Credential: []byte("credential"), ApplicationReference: []byte("invoice-42")}
+			transactions = &token.ReferencedTransactions{
+				Transactions: []*token.ReferencedTransaction{{TxId: "tx-1", TokenTransaction: &token.TokenTransaction{}}},
+			}
+			fakeTransactor.ListTransactionsByReferenceReturns(transactions, nil)
+		})
+
+		It("uses the transactor to list the referenced transactions", func() {
+			resp, err := prover.ListReferencedTransactions(context.Background(), command.Header, referenceRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&token.CommandResponse_ReferencedTransactions{ReferencedTransactions: transactions}))
+
+			Expect(fakeTransactor.ListTransactionsByReferenceCallCount()).To(Equal(1))
+			Expect(fakeTransactor.ListTransactionsByReferenceArgsForCall(0)).To(Equal([]byte("invoice-42")))
+			Expect(fakeTransactor.DoneCallCount()).To(Equal(1))
+		})
+
+		Context("when the transactor fails to list transactions", func() {
+			BeforeEach(func() {
+				fakeTransactor.ListTransactionsByReferenceReturns(nil, errors.New("pineapple"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.ListReferencedTransactions(context.Background(), command.Header, referenceRequest)
+				Expect(err).To(MatchError("pineapple"))
+			})
+		})
+	})
+
 	Describe("ProcessCommand_RequestExpection for import", func() {
 		BeforeEach(func() {
 			command = &token.Command{
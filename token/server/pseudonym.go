@@ -0,0 +1,45 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"github.com/hyperledger/fabric/token/identity"
+	"github.com/pkg/errors"
+)
+
+//go:generate counterfeiter -o mock/pseudonym_verifier.go -fake-name PseudonymVerifier . PseudonymVerifier
+
+// A PseudonymVerifier verifies that the creator of a list request holds the
+// pseudonyms whose tokens are listed.
+type PseudonymVerifier interface {
+	// VerifyPseudonym returns no error if the signature over the message
+	// was produced by the holder of the serialized pseudonym.
+	VerifyPseudonym(channel string, pseudonym, message, signature []byte) error
+}
+
+// IdemixPseudonymVerifier verifies pseudonyms that are Idemix identities of
+// the MSPs of the channel. The signature of an Idemix identity proves the
+// knowledge of the secrets of its pseudonym, without disclosing the
+// credential it was derived from.
+type IdemixPseudonymVerifier struct {
+	IdentityDeserializerManager identity.DeserializerManager
+}
+
+func (v *IdemixPseudonymVerifier) VerifyPseudonym(channel string, pseudonym, message, signature []byte) error {
+	deserializer, err := v.IdentityDeserializerManager.Deserializer(channel)
+	if err != nil {
+		return errors.Wrapf(err, "failed getting identity deserializer for channel: %s", channel)
+	}
+	id, err := deserializer.DeserializeIdentity(pseudonym)
+	if err != nil {
+		return errors.Wrap(err, "failed deserializing pseudonym")
+	}
+	if !id.Anonymous() {
+		return errors.New("pseudonym is not an anonymous identity")
+	}
+	return errors.Wrap(id.Verify(message, signature), "invalid pseudonym signature")
+}
@@ -0,0 +1,92 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server_test
+
+import (
+	"errors"
+
+	idmock "github.com/hyperledger/fabric/token/identity/mock"
+	"github.com/hyperledger/fabric/token/server"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IdemixPseudonymVerifier", func() {
+	var (
+		fakeDeserializerManager *idmock.DeserializerManager
+		fakeDeserializer        *idmock.Deserializer
+		fakeIdentity            *idmock.Identity
+		verifier                *server.IdemixPseudonymVerifier
+	)
+
+	BeforeEach(func() {
+		fakeIdentity = &idmock.Identity{}
+		fakeIdentity.AnonymousReturns(true)
+		fakeDeserializer = &idmock.Deserializer{}
+		fakeDeserializer.DeserializeIdentityReturns(fakeIdentity, nil)
+		fakeDeserializerManager = &idmock.DeserializerManager{}
+		fakeDeserializerManager.DeserializerReturns(fakeDeserializer, nil)
+		verifier = &server.IdemixPseudonymVerifier{IdentityDeserializerManager: fakeDeserializerManager}
+	})
+
+	It("verifies the signature of the pseudonym", func() {
+		err := verifier.VerifyPseudonym("test-channel", []byte("pseudonym"), []byte("message"), []byte("signature"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeDeserializerManager.DeserializerArgsForCall(0)).To(Equal("test-channel"))
+		Expect(fakeDeserializer.DeserializeIdentityArgsForCall(0)).To(Equal([]byte("pseudonym")))
+		Expect(fakeIdentity.VerifyCallCount()).To(Equal(1))
+		message, signature := fakeIdentity.VerifyArgsForCall(0)
+		Expect(message).To(Equal([]byte("message")))
+		Expect(signature).To(Equal([]byte("signature")))
+	})
+
+	Context("when the signature is invalid", func() {
+		BeforeEach(func() {
+			fakeIdentity.VerifyReturns(errors.New("wrong signature"))
+		})
+
+		It("returns an error", func() {
+			err := verifier.VerifyPseudonym("test-channel", []byte("pseudonym"), []byte("message"), []byte("signature"))
+			Expect(err).To(MatchError("invalid pseudonym signature: wrong signature"))
+		})
+	})
+
+	Context("when the pseudonym is not anonymous", func() {
+		BeforeEach(func() {
+			fakeIdentity.AnonymousReturns(false)
+		})
+
+		It("returns an error", func() {
+			err := verifier.VerifyPseudonym("test-channel", []byte("pseudonym"), []byte("message"), []byte("signature"))
+			Expect(err).To(MatchError("pseudonym is not an anonymous identity"))
+			Expect(fakeIdentity.VerifyCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the pseudonym cannot be deserialized", func() {
+		BeforeEach(func() {
+			fakeDeserializer.DeserializeIdentityReturns(nil, errors.New("bad identity"))
+		})
+
+		It("returns an error", func() {
+			err := verifier.VerifyPseudonym("test-channel", []byte("pseudonym"), []byte("message"), []byte("signature"))
+			Expect(err).To(MatchError("failed deserializing pseudonym: bad identity"))
+		})
+	})
+
+	Context("when the channel has no deserializer", func() {
+		BeforeEach(func() {
+			fakeDeserializerManager.DeserializerReturns(nil, errors.New("channel not found"))
+		})
+
+		It("returns an error", func() {
+			err := verifier.VerifyPseudonym("test-channel", []byte("pseudonym"), []byte("message"), []byte("signature"))
+			Expect(err).To(MatchError("failed getting identity deserializer for channel: test-channel: channel not found"))
+		})
+	})
+})
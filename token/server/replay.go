@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+// A ReplayGuard rejects the commands whose creator and nonce were seen within
+// a window, so that a command submitted more than once, e.g. by the retry
+// storms of a client, does not assemble the same token transaction twice
+// before anything reaches the orderer. Clients sign each command with a fresh
+// nonce, hence only the replays of the exact same command are rejected.
+type ReplayGuard struct {
+	window time.Duration
+	now    TimeFunc
+
+	mutex sync.Mutex
+	seen  map[string]bool
+	// entries are the seen commands in the order they were seen, which is
+	// also the order they expire in
+	entries []replayEntry
+}
+
+type replayEntry struct {
+	key    string
+	expiry time.Time
+}
+
+// NewReplayGuard creates a ReplayGuard remembering the commands for window.
+func NewReplayGuard(window time.Duration, now TimeFunc) *ReplayGuard {
+	return &ReplayGuard{
+		window: window,
+		now:    now,
+		seen:   map[string]bool{},
+	}
+}
+
+// Check records the creator and the nonce of the header of a command, and
+// returns an error if they were already recorded within the window.
+func (g *ReplayGuard) Check(header *token.Header) error {
+	key := replayKey(header.Creator, header.Nonce)
+	now := g.now()
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.expire(now)
+	if g.seen[key] {
+		return errors.Errorf("command with nonce %x was already processed", header.Nonce)
+	}
+	g.seen[key] = true
+	g.entries = append(g.entries, replayEntry{key: key, expiry: now.Add(g.window)})
+	return nil
+}
+
+// expire forgets the commands whose window has passed.
+func (g *ReplayGuard) expire(now time.Time) {
+	i := 0
+	for ; i < len(g.entries) && !now.Before(g.entries[i].expiry); i++ {
+		delete(g.seen, g.entries[i].key)
+	}
+	g.entries = g.entries[i:]
+}
+
+// replayKey hashes the creator and the nonce, whose sizes are chosen by the
+// clients, to bound the memory held by each entry.
+func replayKey(creator, nonce []byte) string {
+	creatorHash := sha256.Sum256(creator)
+	h := sha256.New()
+	h.Write(creatorHash[:])
+	h.Write(nonce)
+	return string(h.Sum(nil))
+}
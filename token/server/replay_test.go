@@ -0,0 +1,53 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server_test
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/server"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReplayGuard", func() {
+	var (
+		now    time.Time
+		guard  *server.ReplayGuard
+		header *token.Header
+	)
+
+	BeforeEach(func() {
+		now = time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)
+		guard = server.NewReplayGuard(time.Minute, func() time.Time { return now })
+		header = &token.Header{Creator: []byte("alice"), Nonce: []byte{1, 2, 3}}
+	})
+
+	It("rejects the replays of a command within the window", func() {
+		Expect(guard.Check(header)).To(Succeed())
+		now = now.Add(59 * time.Second)
+		Expect(guard.Check(header)).To(MatchError("command with nonce 010203 was already processed"))
+	})
+
+	It("accepts the commands with another creator or nonce", func() {
+		Expect(guard.Check(header)).To(Succeed())
+		Expect(guard.Check(&token.Header{Creator: []byte("bob"), Nonce: []byte{1, 2, 3}})).To(Succeed())
+		Expect(guard.Check(&token.Header{Creator: []byte("alice"), Nonce: []byte{4, 5, 6}})).To(Succeed())
+	})
+
+	It("forgets the commands after the window", func() {
+		Expect(guard.Check(header)).To(Succeed())
+		now = now.Add(30 * time.Second)
+		other := &token.Header{Creator: []byte("alice"), Nonce: []byte{4, 5, 6}}
+		Expect(guard.Check(other)).To(Succeed())
+
+		now = now.Add(30 * time.Second)
+		Expect(guard.Check(header)).To(Succeed())
+		Expect(guard.Check(other)).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,288 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/hyperledger/fabric/token/ledger"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	"github.com/hyperledger/fabric/token/transaction"
+	"github.com/pkg/errors"
+)
+
+// SpentFilters maintains the spent filters of the channels of the peer, which
+// the transactors of the prover check the inputs against before reading
+// their spent keys from the state DB. The commit pipeline of the peer passes
+// every block it commits to BlockCommitted, which adds the inputs spent by
+// its valid token transactions to the filter of the channel.
+//
+// The filter of a channel is loaded from Dir on its first use if it was
+// saved at the current height of the ledger, or otherwise rebuilt from the
+// spent keys of the state DB, such as after a crash. Filters are saved every
+// SaveInterval blocks and when flushed, on the shutdown of the prover.
+type SpentFilters struct {
+	// Dir is the directory the filters are saved in, a file per channel
+	Dir string
+	// Capacity and FalsePositiveRate size the filter of each channel
+	Capacity          int
+	FalsePositiveRate float64
+	// SaveInterval is the number of blocks committed on a channel between
+	// two saves of its filter; 0 only saves the filters when flushed
+	SaveInterval  uint64
+	LedgerManager ledger.LedgerManager
+	// GetLedger returns the ledger of a channel, or nil if the channel is
+	// not found.
+	GetLedger func(channelID string) CommitLedger
+
+	mutex   sync.Mutex
+	filters map[string]*channelSpentFilter
+}
+
+type channelSpentFilter struct {
+	filter *plain.SpentFilter
+	// height is the number of blocks whose spent inputs are in the filter
+	height  uint64
+	unsaved uint64
+	// saturated filters miss the inputs of transactions that could not be
+	// read, such as encrypted ones; they are rebuilt rather than saved
+	saturated bool
+}
+
+// Get returns the spent filter of the channel, or nil if it is not available,
+// in which case the spent keys are read from the state DB.
+func (f *SpentFilters) Get(channelID string) *plain.SpentFilter {
+	if f == nil {
+		return nil
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if c, ok := f.filters[channelID]; ok {
+		return c.filter
+	}
+
+	c, err := f.open(channelID)
+	if err != nil {
+		logger.Warningf("Spent filter of channel %s not available: %s", channelID, err)
+		return nil
+	}
+	if f.filters == nil {
+		f.filters = map[string]*channelSpentFilter{}
+	}
+	f.filters[channelID] = c
+	return c.filter
+}
+
+// open loads the filter of the channel, or rebuilds it if it was not saved
+// at the height of the ledger.
+func (f *SpentFilters) open(channelID string) (*channelSpentFilter, error) {
+	l := f.GetLedger(channelID)
+	if l == nil {
+		return nil, errors.Errorf("ledger not found for channel %s", channelID)
+	}
+	info, err := l.GetBlockchainInfo()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed getting ledger height")
+	}
+	filter, err := plain.NewSpentFilter(f.Capacity, f.FalsePositiveRate)
+	if err != nil {
+		return nil, err
+	}
+
+	height, err := f.load(channelID, filter)
+	if err == nil && height == info.Height {
+		logger.Infof("Loaded spent filter of channel %s at height %d", channelID, height)
+		return &channelSpentFilter{filter: filter, height: height}, nil
+	}
+	if err != nil && !os.IsNotExist(errors.Cause(err)) {
+		logger.Warningf("Failed loading spent filter of channel %s, rebuilding it: %s", channelID, err)
+	}
+
+	// the blocks committed after the height was read are added by
+	// BlockCommitted once the filter is open; adding inputs twice is harmless
+	filter, err = plain.NewSpentFilter(f.Capacity, f.FalsePositiveRate)
+	if err != nil {
+		return nil, err
+	}
+	count, err := f.rebuild(channelID, filter)
+	if err != nil {
+		return nil, err
+	}
+	logger.Infof("Rebuilt spent filter of channel %s at height %d from %d spent inputs", channelID, info.Height, count)
+	c := &channelSpentFilter{filter: filter, height: info.Height}
+	if err := f.save(channelID, c); err != nil {
+		logger.Warningf("Failed saving spent filter of channel %s: %s", channelID, err)
+	}
+	return c, nil
+}
+
+// rebuild adds the spent keys of the state DB of the channel to the filter.
+func (f *SpentFilters) rebuild(channelID string, filter *plain.SpentFilter) (int, error) {
+	reader, err := f.LedgerManager.GetLedgerReader(channelID)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Done()
+	startKey, endKey := plain.SpentKeyRange()
+	iterator, err := reader.GetStateRangeScanIterator(plain.Namespace, startKey, endKey)
+	if err != nil {
+		return 0, err
+	}
+	defer iterator.Close()
+
+	count := 0
+	for {
+		result, err := iterator.Next()
+		if err != nil {
+			return 0, err
+		}
+		if result == nil {
+			return count, nil
+		}
+		kv, ok := result.(*queryresult.KV)
+		if !ok {
+			return 0, errors.Errorf("unexpected query result %T", result)
+		}
+		filter.Add(kv.Key)
+		count++
+	}
+}
+
+func (f *SpentFilters) path(channelID string) string {
+	return filepath.Join(f.Dir, channelID+".spent")
+}
+
+// load loads the filter of the channel and returns the height it was saved
+// at. The saved filter must have the size of filter.
+func (f *SpentFilters) load(channelID string, filter *plain.SpentFilter) (uint64, error) {
+	data, err := ioutil.ReadFile(f.path(channelID))
+	if err != nil {
+		return 0, err
+	}
+	if len(data) < 8 {
+		return 0, errors.New("invalid spent filter file")
+	}
+	saved := &plain.SpentFilter{}
+	if err := saved.UnmarshalBinary(data[8:]); err != nil {
+		return 0, err
+	}
+	if !saved.SameSize(filter) {
+		return 0, errors.New("the size of the saved spent filter does not match its configuration")
+	}
+	if err := filter.UnmarshalBinary(data[8:]); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(data), nil
+}
+
+// save saves the filter of the channel, with its height, unless it is
+// saturated, in which case any saved filter is removed.
+func (f *SpentFilters) save(channelID string, c *channelSpentFilter) error {
+	path := f.path(channelID)
+	if c.saturated {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "failed removing %s", path)
+		}
+		return nil
+	}
+
+	encoded, err := c.filter.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	data := make([]byte, 8, 8+len(encoded))
+	binary.BigEndian.PutUint64(data, c.height)
+	data = append(data, encoded...)
+
+	if err := os.MkdirAll(f.Dir, 0750); err != nil {
+		return errors.Wrapf(err, "failed creating directory %s", f.Dir)
+	}
+	// the filter is replaced atomically, so that a crash leaves either the
+	// previous filter or the new one
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0640); err != nil {
+		return errors.Wrapf(err, "failed writing %s", tmp)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return errors.Wrapf(err, "failed renaming %s", tmp)
+	}
+	c.unsaved = 0
+	return nil
+}
+
+// BlockCommitted adds the inputs spent by the valid token transactions of a
+// block committed on a channel to its filter, if it is open.
+func (f *SpentFilters) BlockCommitted(channelID string, block *common.Block) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	c, ok := f.filters[channelID]
+	if !ok {
+		// the filter is built from the state DB when opened
+		return
+	}
+
+	flags := validationFlags(block)
+	for i, data := range block.GetData().GetData() {
+		if i < len(flags) && flags.Flag(i) != pb.TxValidationCode_VALID {
+			continue
+		}
+		envelope, err := utils.GetEnvelopeFromBlock(data)
+		if err != nil {
+			continue
+		}
+		chdr, err := utils.ChannelHeader(envelope)
+		if err != nil || common.HeaderType(chdr.Type) != common.HeaderType_TOKEN_TRANSACTION {
+			continue
+		}
+		_, ttx, _, err := transaction.UnmarshalTokenTransaction(envelope.Payload)
+		if err != nil || ttx.GetPlainAction() == nil {
+			if !c.saturated {
+				logger.Warningf("Spent filter of channel %s disabled until the peer restarts: cannot read the inputs of transaction %s", channelID, chdr.TxId)
+				c.filter.Saturate()
+				c.saturated = true
+			}
+			continue
+		}
+		for _, input := range plain.SpentInputs(ttx) {
+			key, err := plain.SpentKey(input)
+			if err != nil {
+				continue
+			}
+			c.filter.Add(key)
+		}
+	}
+
+	if height := block.GetHeader().GetNumber() + 1; height > c.height {
+		c.height = height
+	}
+	c.unsaved++
+	if f.SaveInterval > 0 && c.unsaved >= f.SaveInterval {
+		if err := f.save(channelID, c); err != nil {
+			logger.Warningf("Failed saving spent filter of channel %s: %s", channelID, err)
+		}
+	}
+}
+
+// Flush saves the filters of the channels.
+func (f *SpentFilters) Flush() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for channelID, c := range f.filters {
+		if err := f.save(channelID, c); err != nil {
+			return errors.WithMessage(err, "failed saving spent filter of channel "+channelID)
+		}
+	}
+	return nil
+}
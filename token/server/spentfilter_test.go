@@ -0,0 +1,213 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	mock2 "github.com/hyperledger/fabric/token/ledger/mock"
+	"github.com/hyperledger/fabric/token/server"
+	"github.com/hyperledger/fabric/token/server/mock"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("SpentFilters", func() {
+	var (
+		dir               string
+		fakeCommitLedger  *mock.CommitLedger
+		fakeLedgerManager *mock2.LedgerManager
+		fakeLedgerReader  *mock2.LedgerReader
+		filters           *server.SpentFilters
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "spentfilters")
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeCommitLedger = &mock.CommitLedger{}
+		fakeCommitLedger.GetBlockchainInfoReturns(&common.BlockchainInfo{Height: 5}, nil)
+		fakeLedgerReader = &mock2.LedgerReader{}
+		fakeLedgerReader.GetStateRangeScanIteratorStub = func(string, string, string) (ledger.ResultsIterator, error) {
+			return iterate(&queryresult.KV{Namespace: plain.Namespace, Key: spentKey("tx0", 0)}), nil
+		}
+		fakeLedgerManager = &mock2.LedgerManager{}
+		fakeLedgerManager.GetLedgerReaderReturns(fakeLedgerReader, nil)
+
+		filters = newSpentFilters(dir, fakeLedgerManager, fakeCommitLedger)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("rebuilds the filter of a channel from the spent keys of the state DB", func() {
+		filter := filters.Get("channel-id")
+		Expect(filter).NotTo(BeNil())
+		Expect(filter.MayContain(spentKey("tx0", 0))).To(BeTrue())
+		Expect(filter.MayContain(spentKey("tx0", 1))).To(BeFalse())
+
+		Expect(fakeLedgerManager.GetLedgerReaderArgsForCall(0)).To(Equal("channel-id"))
+		namespace, startKey, endKey := fakeLedgerReader.GetStateRangeScanIteratorArgsForCall(0)
+		Expect(namespace).To(Equal(plain.Namespace))
+		expectedStartKey, expectedEndKey := plain.SpentKeyRange()
+		Expect(startKey).To(Equal(expectedStartKey))
+		Expect(endKey).To(Equal(expectedEndKey))
+		Expect(fakeLedgerReader.DoneCallCount()).To(Equal(1))
+
+		Expect(filters.Get("channel-id")).To(BeIdenticalTo(filter))
+		Expect(fakeLedgerManager.GetLedgerReaderCallCount()).To(Equal(1))
+	})
+
+	It("adds the inputs spent by the valid token transactions of the committed blocks", func() {
+		filter := filters.Get("channel-id")
+		filters.BlockCommitted("channel-id", transferBlock(5, pb.TxValidationCode_VALID, &token.InputId{TxId: "tx1", Index: 2}))
+		filters.BlockCommitted("channel-id", transferBlock(6, pb.TxValidationCode_MVCC_READ_CONFLICT, &token.InputId{TxId: "tx2", Index: 0}))
+		filters.BlockCommitted("other-channel-id", transferBlock(6, pb.TxValidationCode_VALID, &token.InputId{TxId: "tx3", Index: 0}))
+
+		Expect(filter.MayContain(spentKey("tx1", 2))).To(BeTrue())
+		Expect(filter.MayContain(spentKey("tx2", 0))).To(BeFalse())
+		Expect(filter.MayContain(spentKey("tx3", 0))).To(BeFalse())
+	})
+
+	It("loads the filter saved at the height of the ledger", func() {
+		filters.Get("channel-id")
+		filters.BlockCommitted("channel-id", transferBlock(5, pb.TxValidationCode_VALID, &token.InputId{TxId: "tx1", Index: 2}))
+		Expect(filters.Flush()).To(Succeed())
+
+		fakeCommitLedger.GetBlockchainInfoReturns(&common.BlockchainInfo{Height: 6}, nil)
+		filters = newSpentFilters(dir, fakeLedgerManager, fakeCommitLedger)
+		filter := filters.Get("channel-id")
+		Expect(filter.MayContain(spentKey("tx0", 0))).To(BeTrue())
+		Expect(filter.MayContain(spentKey("tx1", 2))).To(BeTrue())
+		Expect(fakeLedgerManager.GetLedgerReaderCallCount()).To(Equal(1))
+	})
+
+	It("saves the filters every save interval blocks", func() {
+		filters.SaveInterval = 2
+		filters.Get("channel-id")
+		filters.BlockCommitted("channel-id", transferBlock(5, pb.TxValidationCode_VALID, &token.InputId{TxId: "tx1", Index: 2}))
+		filters.BlockCommitted("channel-id", transferBlock(6, pb.TxValidationCode_VALID, &token.InputId{TxId: "tx2", Index: 0}))
+
+		fakeCommitLedger.GetBlockchainInfoReturns(&common.BlockchainInfo{Height: 7}, nil)
+		filter := newSpentFilters(dir, fakeLedgerManager, fakeCommitLedger).Get("channel-id")
+		Expect(filter.MayContain(spentKey("tx2", 0))).To(BeTrue())
+		Expect(fakeLedgerManager.GetLedgerReaderCallCount()).To(Equal(1))
+	})
+
+	Context("when the saved filter is behind the ledger", func() {
+		It("rebuilds the filter", func() {
+			filters.Get("channel-id")
+			Expect(filters.Flush()).To(Succeed())
+
+			fakeCommitLedger.GetBlockchainInfoReturns(&common.BlockchainInfo{Height: 6}, nil)
+			filters = newSpentFilters(dir, fakeLedgerManager, fakeCommitLedger)
+			Expect(filters.Get("channel-id")).NotTo(BeNil())
+			Expect(fakeLedgerManager.GetLedgerReaderCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when the saved filter has another size", func() {
+		It("rebuilds the filter", func() {
+			filters.Get("channel-id")
+			Expect(filters.Flush()).To(Succeed())
+
+			filters = newSpentFilters(dir, fakeLedgerManager, fakeCommitLedger)
+			filters.Capacity = 10
+			Expect(filters.Get("channel-id")).NotTo(BeNil())
+			Expect(fakeLedgerManager.GetLedgerReaderCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when the saved filter is corrupted", func() {
+		It("rebuilds the filter", func() {
+			Expect(ioutil.WriteFile(filepath.Join(dir, "channel-id.spent"), []byte("garbage"), 0640)).To(Succeed())
+			filter := filters.Get("channel-id")
+			Expect(filter.MayContain(spentKey("tx0", 0))).To(BeTrue())
+			Expect(fakeLedgerManager.GetLedgerReaderCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the inputs of a valid token transaction cannot be read", func() {
+		It("saturates the filter and does not save it", func() {
+			filter := filters.Get("channel-id")
+			Expect(filters.Flush()).To(Succeed())
+			filters.BlockCommitted("channel-id", block(5, pb.TxValidationCode_VALID, "tx-id"))
+
+			Expect(filter.MayContain(spentKey("tx1", 0))).To(BeTrue())
+			Expect(filters.Flush()).To(Succeed())
+			_, err := os.Stat(filepath.Join(dir, "channel-id.spent"))
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+	})
+
+	Context("when the ledger of the channel is not found", func() {
+		It("returns no filter", func() {
+			filters.GetLedger = func(string) server.CommitLedger { return nil }
+			Expect(filters.Get("channel-id")).To(BeNil())
+		})
+	})
+
+	Context("when the spent keys cannot be read", func() {
+		It("returns no filter", func() {
+			fakeLedgerReader.GetStateRangeScanIteratorStub = nil
+			fakeLedgerReader.GetStateRangeScanIteratorReturns(nil, errors.New("boom"))
+			Expect(filters.Get("channel-id")).To(BeNil())
+		})
+	})
+
+	Context("when the filters are not configured", func() {
+		It("returns no filter", func() {
+			var filters *server.SpentFilters
+			Expect(filters.Get("channel-id")).To(BeNil())
+		})
+	})
+})
+
+func newSpentFilters(dir string, ledgerManager *mock2.LedgerManager, commitLedger *mock.CommitLedger) *server.SpentFilters {
+	return &server.SpentFilters{
+		Dir:               dir,
+		Capacity:          1000,
+		FalsePositiveRate: 0.001,
+		LedgerManager:     ledgerManager,
+		GetLedger: func(channelID string) server.CommitLedger {
+			return commitLedger
+		},
+	}
+}
+
+func spentKey(txID string, index uint32) string {
+	key, err := plain.SpentKey(&token.InputId{TxId: txID, Index: index})
+	Expect(err).NotTo(HaveOccurred())
+	return key
+}
+
+// transferBlock returns a block with a plain transfer of the inputs, with the
+// validation code.
+func transferBlock(number uint64, code pb.TxValidationCode, inputs ...*token.InputId) *common.Block {
+	b := block(number, code, "tx-id")
+	envelope := tokenTransactionEnvelope(common.HeaderType_TOKEN_TRANSACTION, "channel-id", "tx-id")
+	payload := &common.Payload{}
+	Expect(proto.Unmarshal(envelope.Payload, payload)).To(Succeed())
+	payload.Data = ProtoMarshal(&token.TokenTransaction{Action: &token.TokenTransaction_PlainAction{PlainAction: &token.PlainTokenAction{
+		Data: &token.PlainTokenAction_PlainTransfer{PlainTransfer: &token.PlainTransfer{Inputs: inputs}},
+	}}})
+	envelope.Payload = ProtoMarshal(payload)
+	b.Data.Data = [][]byte{ProtoMarshal(envelope)}
+	return b
+}
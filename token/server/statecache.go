@@ -0,0 +1,371 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"container/list"
+	"sync"
+
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/hyperledger/fabric/token/ledger"
+)
+
+var (
+	stateCacheHits = metrics.CounterOpts{
+		Namespace:    "token",
+		Subsystem:    "state_cache",
+		Name:         "hits",
+		Help:         "The number of reads of the token namespace served by the state cache, by kind of read.",
+		LabelNames:   []string{"channel", "read"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{read}",
+	}
+	stateCacheMisses = metrics.CounterOpts{
+		Namespace:    "token",
+		Subsystem:    "state_cache",
+		Name:         "misses",
+		Help:         "The number of reads of the token namespace that read the state DB, by kind of read.",
+		LabelNames:   []string{"channel", "read"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{read}",
+	}
+	stateCacheEntries = metrics.GaugeOpts{
+		Namespace:    "token",
+		Subsystem:    "state_cache",
+		Name:         "entries",
+		Help:         "The number of keys of the token namespace held by the state cache.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+)
+
+// StateCacheMetrics are the metrics of a StateCache.
+type StateCacheMetrics struct {
+	Hits    metrics.Counter
+	Misses  metrics.Counter
+	Entries metrics.Gauge
+}
+
+func NewStateCacheMetrics(p metrics.Provider) *StateCacheMetrics {
+	return &StateCacheMetrics{
+		Hits:    p.NewCounter(stateCacheHits),
+		Misses:  p.NewCounter(stateCacheMisses),
+		Entries: p.NewGauge(stateCacheEntries),
+	}
+}
+
+func (m *StateCacheMetrics) read(channel, read string, hit bool) {
+	if m == nil {
+		return
+	}
+	if hit {
+		m.Hits.With("channel", channel, "read", read).Add(1)
+	} else {
+		m.Misses.With("channel", channel, "read", read).Add(1)
+	}
+}
+
+func (m *StateCacheMetrics) entries(channel string, entries int) {
+	if m == nil {
+		return
+	}
+	m.Entries.With("channel", channel).Set(float64(entries))
+}
+
+// StateCache is a LedgerManager caching in memory the reads of the token
+// namespace from the state DB of the wrapped LedgerManager, so that the hot
+// keys, such as the supply of the token types, and the range scans listing
+// the tokens of the owners, are read from the state DB once per block rather
+// than once per command under heavy read load. The other namespaces are read
+// from the state DB.
+//
+// The cache of a channel is invalidated when a block with valid token
+// transactions is committed on it, which the commit pipeline of the peer
+// notifies through BlockCommitted. Like the reads of a query executor opened
+// right before a commit, the reads of the cache may therefore lag behind the
+// state DB by the block being committed; the validation of the transactions
+// reads the state DB and is not affected.
+type StateCache struct {
+	LedgerManager ledger.LedgerManager
+	// MaxEntries is the maximum number of keys cached for a channel, counting
+	// every key returned by a cached range scan. The least recently used
+	// reads are evicted beyond it.
+	MaxEntries int
+	Metrics    *StateCacheMetrics
+
+	mutex    sync.Mutex
+	channels map[string]*channelCache
+}
+
+// channelCache holds the cached reads of a channel since the last commit,
+// least recently used last. The generation is incremented by each commit, so
+// that the reads started before it are not cached.
+type channelCache struct {
+	generation uint64
+	reads      map[cacheKey]*list.Element
+	lru        *list.List
+	entries    int
+}
+
+type cacheKey struct {
+	// scan is true for range scans, false for single keys
+	scan     bool
+	key      string
+	startKey string
+	endKey   string
+}
+
+type cachedRead struct {
+	key   cacheKey
+	value []byte
+	kvs   []*queryresult.KV
+}
+
+func (r *cachedRead) entries() int {
+	if r.key.scan {
+		return len(r.kvs)
+	}
+	return 1
+}
+
+// GetLedgerReader returns a reader of the channel which reads the token
+// namespace from the cache. The reader of the wrapped LedgerManager is only
+// requested on the first read missing the cache.
+func (c *StateCache) GetLedgerReader(channel string) (ledger.LedgerReader, error) {
+	return &cachingReader{cache: c, channel: channel}, nil
+}
+
+// BlockCommitted invalidates the cache of the channel, if the block holds
+// valid token transactions.
+func (c *StateCache) BlockCommitted(channelID string, block *common.Block) {
+	if !hasValidTokenTransactions(block) {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	cache := c.channel(channelID)
+	cache.generation++
+	cache.reads = map[cacheKey]*list.Element{}
+	cache.lru.Init()
+	cache.entries = 0
+	c.Metrics.entries(channelID, 0)
+}
+
+func hasValidTokenTransactions(block *common.Block) bool {
+	flags := validationFlags(block)
+	for i, data := range block.GetData().GetData() {
+		if i < len(flags) && flags.Flag(i) != pb.TxValidationCode_VALID {
+			continue
+		}
+		envelope, err := utils.GetEnvelopeFromBlock(data)
+		if err != nil {
+			continue
+		}
+		chdr, err := utils.ChannelHeader(envelope)
+		if err != nil {
+			continue
+		}
+		if common.HeaderType(chdr.Type) == common.HeaderType_TOKEN_TRANSACTION {
+			return true
+		}
+	}
+	return false
+}
+
+// channel returns the cache of the channel; the mutex must be held.
+func (c *StateCache) channel(channelID string) *channelCache {
+	if c.channels == nil {
+		c.channels = map[string]*channelCache{}
+	}
+	cache, ok := c.channels[channelID]
+	if !ok {
+		cache = &channelCache{reads: map[cacheKey]*list.Element{}, lru: list.New()}
+		c.channels[channelID] = cache
+	}
+	return cache
+}
+
+// get returns the cached read of the key, if any, and the generation of the
+// cache of the channel.
+func (c *StateCache) get(channelID string, key cacheKey) (*cachedRead, uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	cache := c.channel(channelID)
+	element, ok := cache.reads[key]
+	if !ok {
+		return nil, cache.generation
+	}
+	cache.lru.MoveToFront(element)
+	return element.Value.(*cachedRead), cache.generation
+}
+
+// put caches a read, unless a block was committed since the generation it
+// was read at or it alone exceeds MaxEntries.
+func (c *StateCache) put(channelID string, generation uint64, read *cachedRead) {
+	if read.entries() > c.MaxEntries {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	cache := c.channel(channelID)
+	if cache.generation != generation {
+		return
+	}
+	if _, ok := cache.reads[read.key]; ok {
+		return
+	}
+	for cache.entries+read.entries() > c.MaxEntries {
+		oldest := cache.lru.Remove(cache.lru.Back()).(*cachedRead)
+		delete(cache.reads, oldest.key)
+		cache.entries -= oldest.entries()
+	}
+	cache.reads[read.key] = cache.lru.PushFront(read)
+	cache.entries += read.entries()
+	c.Metrics.entries(channelID, cache.entries)
+}
+
+// cachingReader reads the token namespace from the cache, and from the
+// reader of the wrapped LedgerManager on misses.
+type cachingReader struct {
+	cache   *StateCache
+	channel string
+	reader  ledger.LedgerReader
+}
+
+func (r *cachingReader) ledgerReader() (ledger.LedgerReader, error) {
+	if r.reader == nil {
+		reader, err := r.cache.LedgerManager.GetLedgerReader(r.channel)
+		if err != nil {
+			return nil, err
+		}
+		r.reader = reader
+	}
+	return r.reader, nil
+}
+
+func (r *cachingReader) GetState(namespace string, key string) ([]byte, error) {
+	if namespace != tk.Namespace {
+		reader, err := r.ledgerReader()
+		if err != nil {
+			return nil, err
+		}
+		return reader.GetState(namespace, key)
+	}
+
+	k := cacheKey{key: key}
+	cached, generation := r.cache.get(r.channel, k)
+	r.cache.Metrics.read(r.channel, "state", cached != nil)
+	if cached != nil {
+		return cached.value, nil
+	}
+
+	reader, err := r.ledgerReader()
+	if err != nil {
+		return nil, err
+	}
+	value, err := reader.GetState(namespace, key)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.put(r.channel, generation, &cachedRead{key: k, value: value})
+	return value, nil
+}
+
+func (r *cachingReader) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (commonledger.ResultsIterator, error) {
+	if namespace != tk.Namespace {
+		reader, err := r.ledgerReader()
+		if err != nil {
+			return nil, err
+		}
+		return reader.GetStateRangeScanIterator(namespace, startKey, endKey)
+	}
+
+	k := cacheKey{scan: true, startKey: startKey, endKey: endKey}
+	cached, generation := r.cache.get(r.channel, k)
+	r.cache.Metrics.read(r.channel, "range", cached != nil)
+	if cached != nil {
+		return &cachedIterator{kvs: cached.kvs}, nil
+	}
+
+	reader, err := r.ledgerReader()
+	if err != nil {
+		return nil, err
+	}
+	iterator, err := reader.GetStateRangeScanIterator(namespace, startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingIterator{
+		ResultsIterator: iterator,
+		done: func(kvs []*queryresult.KV) {
+			r.cache.put(r.channel, generation, &cachedRead{key: k, kvs: kvs})
+		},
+		limit: r.cache.MaxEntries,
+	}, nil
+}
+
+func (r *cachingReader) Done() {
+	if r.reader != nil {
+		r.reader.Done()
+	}
+}
+
+// cachedIterator iterates over the results of a cached range scan.
+type cachedIterator struct {
+	kvs []*queryresult.KV
+}
+
+func (it *cachedIterator) Next() (commonledger.QueryResult, error) {
+	if len(it.kvs) == 0 {
+		return nil, nil
+	}
+	kv := it.kvs[0]
+	it.kvs = it.kvs[1:]
+	return kv, nil
+}
+
+func (it *cachedIterator) Close() {}
+
+// recordingIterator records the results of a range scan as they are
+// iterated, and passes them to done once the scan is complete. Scans closed
+// before their end, or returning more than limit results, are not recorded.
+type recordingIterator struct {
+	commonledger.ResultsIterator
+	done  func([]*queryresult.KV)
+	limit int
+
+	kvs       []*queryresult.KV
+	discarded bool
+}
+
+func (it *recordingIterator) Next() (commonledger.QueryResult, error) {
+	result, err := it.ResultsIterator.Next()
+	if err != nil {
+		it.discarded = true
+		return nil, err
+	}
+	if result == nil {
+		if !it.discarded {
+			it.done(it.kvs)
+			it.discarded = true
+		}
+		return nil, nil
+	}
+	kv, ok := result.(*queryresult.KV)
+	if !ok || len(it.kvs) >= it.limit {
+		it.discarded = true
+		it.kvs = nil
+	}
+	if !it.discarded {
+		it.kvs = append(it.kvs, kv)
+	}
+	return result, nil
+}
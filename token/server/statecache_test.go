@@ -0,0 +1,237 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server_test
+
+import (
+	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	mock2 "github.com/hyperledger/fabric/token/ledger/mock"
+	"github.com/hyperledger/fabric/token/server"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("StateCache", func() {
+	var (
+		fakeLedgerManager *mock2.LedgerManager
+		fakeLedgerReader  *mock2.LedgerReader
+		fakeHits          *metricsfakes.Counter
+		fakeMisses        *metricsfakes.Counter
+		fakeEntries       *metricsfakes.Gauge
+		cache             *server.StateCache
+		kvs               []*queryresult.KV
+	)
+
+	BeforeEach(func() {
+		fakeLedgerReader = &mock2.LedgerReader{}
+		fakeLedgerReader.GetStateStub = func(namespace, key string) ([]byte, error) {
+			return []byte(namespace + "/" + key), nil
+		}
+		kvs = []*queryresult.KV{
+			{Namespace: "tms", Key: "a", Value: []byte("1")},
+			{Namespace: "tms", Key: "b", Value: []byte("2")},
+		}
+		fakeLedgerReader.GetStateRangeScanIteratorStub = func(string, string, string) (ledger.ResultsIterator, error) {
+			return iterate(kvs...), nil
+		}
+		fakeLedgerManager = &mock2.LedgerManager{}
+		fakeLedgerManager.GetLedgerReaderReturns(fakeLedgerReader, nil)
+
+		fakeHits = &metricsfakes.Counter{}
+		fakeHits.WithReturns(fakeHits)
+		fakeMisses = &metricsfakes.Counter{}
+		fakeMisses.WithReturns(fakeMisses)
+		fakeEntries = &metricsfakes.Gauge{}
+		fakeEntries.WithReturns(fakeEntries)
+
+		cache = &server.StateCache{
+			LedgerManager: fakeLedgerManager,
+			MaxEntries:    4,
+			Metrics: &server.StateCacheMetrics{
+				Hits:    fakeHits,
+				Misses:  fakeMisses,
+				Entries: fakeEntries,
+			},
+		}
+	})
+
+	getState := func(key string) []byte {
+		reader, err := cache.GetLedgerReader("channel-id")
+		Expect(err).NotTo(HaveOccurred())
+		defer reader.Done()
+		value, err := reader.GetState("tms", key)
+		Expect(err).NotTo(HaveOccurred())
+		return value
+	}
+
+	scan := func() []*queryresult.KV {
+		reader, err := cache.GetLedgerReader("channel-id")
+		Expect(err).NotTo(HaveOccurred())
+		defer reader.Done()
+		iterator, err := reader.GetStateRangeScanIterator("tms", "", "")
+		Expect(err).NotTo(HaveOccurred())
+		defer iterator.Close()
+		return drain(iterator)
+	}
+
+	It("reads the keys of the token namespace once", func() {
+		Expect(getState("key")).To(Equal([]byte("tms/key")))
+		Expect(getState("key")).To(Equal([]byte("tms/key")))
+
+		Expect(fakeLedgerReader.GetStateCallCount()).To(Equal(1))
+		Expect(fakeLedgerManager.GetLedgerReaderCallCount()).To(Equal(1))
+		Expect(fakeLedgerManager.GetLedgerReaderArgsForCall(0)).To(Equal("channel-id"))
+		Expect(fakeLedgerReader.DoneCallCount()).To(Equal(1))
+
+		Expect(fakeMisses.AddCallCount()).To(Equal(1))
+		Expect(fakeMisses.WithArgsForCall(0)).To(Equal([]string{"channel", "channel-id", "read", "state"}))
+		Expect(fakeHits.AddCallCount()).To(Equal(1))
+		Expect(fakeEntries.SetArgsForCall(0)).To(Equal(float64(1)))
+	})
+
+	It("caches the missing keys", func() {
+		fakeLedgerReader.GetStateReturns(nil, nil)
+		fakeLedgerReader.GetStateStub = nil
+
+		Expect(getState("missing")).To(BeNil())
+		Expect(getState("missing")).To(BeNil())
+		Expect(fakeLedgerReader.GetStateCallCount()).To(Equal(1))
+	})
+
+	It("reads the other namespaces from the state DB", func() {
+		reader, err := cache.GetLedgerReader("channel-id")
+		Expect(err).NotTo(HaveOccurred())
+		for i := 0; i < 2; i++ {
+			value, err := reader.GetState("mycc", "key")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(Equal([]byte("mycc/key")))
+		}
+		Expect(fakeLedgerReader.GetStateCallCount()).To(Equal(2))
+		Expect(fakeMisses.AddCallCount()).To(Equal(0))
+	})
+
+	It("caches the complete range scans", func() {
+		Expect(scan()).To(Equal(kvs))
+		Expect(scan()).To(Equal(kvs))
+		Expect(fakeLedgerReader.GetStateRangeScanIteratorCallCount()).To(Equal(1))
+		Expect(fakeHits.WithArgsForCall(0)).To(Equal([]string{"channel", "channel-id", "read", "range"}))
+	})
+
+	It("does not cache the range scans closed before their end", func() {
+		reader, err := cache.GetLedgerReader("channel-id")
+		Expect(err).NotTo(HaveOccurred())
+		iterator, err := reader.GetStateRangeScanIterator("tms", "", "")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = iterator.Next()
+		Expect(err).NotTo(HaveOccurred())
+		iterator.Close()
+
+		Expect(scan()).To(Equal(kvs))
+		Expect(fakeLedgerReader.GetStateRangeScanIteratorCallCount()).To(Equal(2))
+	})
+
+	It("does not cache the range scans returning more than the maximum entries", func() {
+		cache.MaxEntries = 1
+
+		Expect(scan()).To(Equal(kvs))
+		Expect(scan()).To(Equal(kvs))
+		Expect(fakeLedgerReader.GetStateRangeScanIteratorCallCount()).To(Equal(2))
+	})
+
+	It("evicts the least recently used reads beyond the maximum entries", func() {
+		scan()
+		getState("key1")
+		getState("key2")
+		// the scan is now the least recently used read
+		getState("key1")
+		getState("key3")
+
+		scan()
+		Expect(fakeLedgerReader.GetStateRangeScanIteratorCallCount()).To(Equal(2))
+		getState("key1")
+		Expect(fakeLedgerReader.GetStateCallCount()).To(Equal(3))
+	})
+
+	Context("when a block with valid token transactions is committed", func() {
+		It("invalidates the cache of the channel", func() {
+			getState("key")
+			cache.BlockCommitted("other-channel-id", block(7, pb.TxValidationCode_VALID, "tx-id"))
+			getState("key")
+			Expect(fakeLedgerReader.GetStateCallCount()).To(Equal(1))
+
+			cache.BlockCommitted("channel-id", block(7, pb.TxValidationCode_VALID, "tx-id"))
+			getState("key")
+			Expect(fakeLedgerReader.GetStateCallCount()).To(Equal(2))
+			Expect(fakeEntries.SetArgsForCall(1)).To(Equal(float64(0)))
+		})
+
+		It("does not cache the reads started before the commit", func() {
+			fakeLedgerReader.GetStateStub = func(namespace, key string) ([]byte, error) {
+				cache.BlockCommitted("channel-id", block(7, pb.TxValidationCode_VALID, "tx-id"))
+				return []byte("stale"), nil
+			}
+			Expect(getState("key")).To(Equal([]byte("stale")))
+
+			fakeLedgerReader.GetStateStub = nil
+			fakeLedgerReader.GetStateReturns([]byte("fresh"), nil)
+			Expect(getState("key")).To(Equal([]byte("fresh")))
+		})
+	})
+
+	Context("when a block without valid token transactions is committed", func() {
+		It("keeps the cache of the channel", func() {
+			getState("key")
+			cache.BlockCommitted("channel-id", block(7, pb.TxValidationCode_MVCC_READ_CONFLICT, "tx-id"))
+
+			b := common.NewBlock(8, nil)
+			b.Data.Data = [][]byte{ProtoMarshal(tokenTransactionEnvelope(common.HeaderType_ENDORSER_TRANSACTION, "channel-id", "tx-id"))}
+			cache.BlockCommitted("channel-id", b)
+
+			getState("key")
+			Expect(fakeLedgerReader.GetStateCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the ledger of the channel is not found", func() {
+		BeforeEach(func() {
+			fakeLedgerManager.GetLedgerReaderReturns(nil, errors.New("ledger not found for channel channel-id"))
+		})
+
+		It("returns the error on the first read", func() {
+			reader, err := cache.GetLedgerReader("channel-id")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = reader.GetState("tms", "key")
+			Expect(err).To(MatchError("ledger not found for channel channel-id"))
+			reader.Done()
+		})
+	})
+})
+
+// iterate returns an iterator over kvs.
+func iterate(kvs ...*queryresult.KV) *mock2.ResultsIterator {
+	iterator := &mock2.ResultsIterator{}
+	for i, kv := range kvs {
+		iterator.NextReturnsOnCall(i, kv, nil)
+	}
+	return iterator
+}
+
+func drain(iterator ledger.ResultsIterator) []*queryresult.KV {
+	var kvs []*queryresult.KV
+	for {
+		result, err := iterator.Next()
+		Expect(err).NotTo(HaveOccurred())
+		if result == nil {
+			return kvs
+		}
+		kvs = append(kvs, result.(*queryresult.KV))
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/token/ledger"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	"github.com/pkg/errors"
+)
+
+// StatsHandler serves the statistics of the token namespace of the channels
+// the peer has joined, for capacity planning. GET requests return the
+// statistics of every channel, or of the channel passed in the channel query
+// parameter, indexed by channel name.
+type StatsHandler struct {
+	LedgerManager ledger.LedgerManager
+	// Channels returns the names of the channels the peer has joined
+	Channels func() []string
+	Logger   *flogging.FabricLogger
+}
+
+type statsErrorResponse struct {
+	Error string `json:"error"`
+}
+
+func (h *StatsHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		h.sendResponse(resp, http.StatusBadRequest, fmt.Errorf("invalid request method: %s", req.Method))
+		return
+	}
+
+	channels := h.Channels()
+	if channel := req.URL.Query().Get("channel"); channel != "" {
+		if !contains(channels, channel) {
+			h.sendResponse(resp, http.StatusNotFound, errors.Errorf("channel %s not found", channel))
+			return
+		}
+		channels = []string{channel}
+	}
+
+	stats := map[string]*plain.NamespaceStats{}
+	for _, channel := range channels {
+		s, err := h.channelStats(channel)
+		if err != nil {
+			h.Logger.Errorf("failed collecting token statistics of channel %s: %s", channel, err)
+			h.sendResponse(resp, http.StatusInternalServerError, errors.WithMessage(err, fmt.Sprintf("failed collecting token statistics of channel %s", channel)))
+			return
+		}
+		stats[channel] = s
+	}
+	h.sendResponse(resp, http.StatusOK, stats)
+}
+
+func (h *StatsHandler) channelStats(channel string) (*plain.NamespaceStats, error) {
+	reader, err := h.LedgerManager.GetLedgerReader(channel)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Done()
+
+	return plain.CollectStats(reader)
+}
+
+func (h *StatsHandler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	if err, ok := payload.(error); ok {
+		payload = &statsErrorResponse{Error: err.Error()}
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+	if err := json.NewEncoder(resp).Encode(payload); err != nil {
+		h.Logger.Errorw("failed to encode payload", "error", err)
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/ledger/mock"
+	"github.com/hyperledger/fabric/token/server"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("StatsHandler", func() {
+	var (
+		fakeLedgerManager *mock.LedgerManager
+		fakeLedgerReader  *mock.LedgerReader
+		fakeIterator      *mock.ResultsIterator
+		handler           *server.StatsHandler
+		recorder          *httptest.ResponseRecorder
+		outputSize        int
+	)
+
+	BeforeEach(func() {
+		output, err := proto.Marshal(&token.PlainOutput{Owner: []byte("alice"), Type: "USD", Quantity: 100})
+		Expect(err).NotTo(HaveOccurred())
+		outputSize = len("\x00tokenOutput\x000\x000\x00") + len(output)
+
+		fakeIterator = &mock.ResultsIterator{}
+		fakeIterator.NextReturnsOnCall(0, &queryresult.KV{Key: "\x00tokenOutput\x000\x000\x00", Value: output}, nil)
+		fakeIterator.NextReturnsOnCall(2, &queryresult.KV{Key: "\x00tokenOutput\x000\x000\x00", Value: output}, nil)
+		fakeLedgerReader = &mock.LedgerReader{}
+		fakeLedgerReader.GetStateRangeScanIteratorReturns(fakeIterator, nil)
+		fakeLedgerManager = &mock.LedgerManager{}
+		fakeLedgerManager.GetLedgerReaderReturns(fakeLedgerReader, nil)
+
+		handler = &server.StatsHandler{
+			LedgerManager: fakeLedgerManager,
+			Channels:      func() []string { return []string{"channel-1", "channel-2"} },
+			Logger:        flogging.MustGetLogger("test"),
+		}
+		recorder = httptest.NewRecorder()
+	})
+
+	It("returns the statistics of every channel", func() {
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/token/stats", nil))
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header().Get("Content-Type")).To(Equal("application/json"))
+		var stats map[string]*plain.NamespaceStats
+		err := json.Unmarshal(recorder.Body.Bytes(), &stats)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stats).To(HaveLen(2))
+		Expect(stats["channel-1"].UnspentOutputs).To(Equal(map[string]*plain.OutputStats{"USD": {Count: 1, Quantity: 100}}))
+		Expect(stats["channel-2"].Keys).To(Equal(uint64(1)))
+
+		Expect(fakeLedgerManager.GetLedgerReaderCallCount()).To(Equal(2))
+		Expect(fakeLedgerManager.GetLedgerReaderArgsForCall(0)).To(Equal("channel-1"))
+		Expect(fakeLedgerManager.GetLedgerReaderArgsForCall(1)).To(Equal("channel-2"))
+		Expect(fakeLedgerReader.DoneCallCount()).To(Equal(2))
+	})
+
+	It("returns the statistics of the requested channel", func() {
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/token/stats?channel=channel-2", nil))
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Body.String()).To(MatchJSON(fmt.Sprintf(`{"channel-2": {"unspent_outputs": {"USD": {"count": 1, "quantity": 100}}, "keys": 1, "bytes": %d, "references": {"entries": 0, "dangling": 0}}}`, outputSize)))
+		Expect(fakeLedgerManager.GetLedgerReaderCallCount()).To(Equal(1))
+		Expect(fakeLedgerManager.GetLedgerReaderArgsForCall(0)).To(Equal("channel-2"))
+	})
+
+	Context("when the channel does not exist", func() {
+		It("returns not found", func() {
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/token/stats?channel=missing", nil))
+
+			Expect(recorder.Code).To(Equal(http.StatusNotFound))
+			Expect(recorder.Body.String()).To(MatchJSON(`{"error": "channel missing not found"}`))
+		})
+	})
+
+	Context("when the ledger reader cannot be obtained", func() {
+		BeforeEach(func() {
+			fakeLedgerManager.GetLedgerReaderReturns(nil, errors.New("boom"))
+		})
+
+		It("returns an internal server error", func() {
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/token/stats", nil))
+
+			Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+			Expect(recorder.Body.String()).To(MatchJSON(`{"error": "failed collecting token statistics of channel channel-1: boom"}`))
+		})
+	})
+
+	Context("when the method is not GET", func() {
+		It("returns bad request", func() {
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, "/token/stats", nil))
+
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+			Expect(recorder.Body.String()).To(MatchJSON(`{"error": "invalid request method: PUT"}`))
+			Expect(fakeLedgerManager.GetLedgerReaderCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,151 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"context"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/ledger"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	"github.com/pkg/errors"
+)
+
+// maxSupplyAttempts is the number of times the supply is computed before
+// giving up when blocks keep being committed in the meantime.
+const maxSupplyAttempts = 3
+
+//go:generate counterfeiter -o mock/supply_attestor.go -fake-name SupplyAttestor . SupplyAttestor
+
+// A SupplyAttestor produces signed attestations of the circulating supply of
+// the token types of a channel.
+type SupplyAttestor interface {
+	// AttestSupply attests the supply of the passed token types, or of every
+	// token type in circulation when none is passed.
+	AttestSupply(channel string, types []string) (*token.SignedSupplyAttestation, error)
+}
+
+// LedgerSupplyAttestor implements the SupplyAttestor interface by computing
+// the supply from the token namespace of the ledger of the peer, and signing
+// the attestations with the identity of the peer, so that they can be
+// verified against the MSP of its organization by parties that do not run
+// a peer of the channel, such as regulators.
+type LedgerSupplyAttestor struct {
+	LedgerManager ledger.LedgerManager
+	// GetLedger returns the ledger of a channel, or nil if the channel is
+	// not found.
+	GetLedger func(channelID string) CommitLedger
+	Signer    SignerIdentity
+	Time      TimeFunc
+}
+
+func (a *LedgerSupplyAttestor) AttestSupply(channel string, types []string) (*token.SignedSupplyAttestation, error) {
+	l := a.GetLedger(channel)
+	if l == nil {
+		return nil, errors.Errorf("ledger not found for channel %s", channel)
+	}
+
+	// the state read by the supply scan cannot be tied to a block height, so
+	// the height is read before and after the scan, and the scan is retried
+	// when a block was committed in between
+	for attempt := 0; attempt < maxSupplyAttempts; attempt++ {
+		before, err := l.GetBlockchainInfo()
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed reading blockchain info")
+		}
+		supply, err := a.circulatingSupply(channel)
+		if err != nil {
+			return nil, err
+		}
+		after, err := l.GetBlockchainInfo()
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed reading blockchain info")
+		}
+		if before.Height == after.Height {
+			return a.sign(channel, before.Height, typeSupplies(supply, types))
+		}
+	}
+	return nil, errors.Errorf("blocks kept being committed to channel %s while computing the token supply", channel)
+}
+
+func (a *LedgerSupplyAttestor) circulatingSupply(channel string) (map[string]uint64, error) {
+	reader, err := a.LedgerManager.GetLedgerReader(channel)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Done()
+
+	supply, err := plain.CirculatingSupply(reader)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed computing the token supply")
+	}
+	return supply, nil
+}
+
+func (a *LedgerSupplyAttestor) sign(channel string, height uint64, supplies []*token.TypeSupply) (*token.SignedSupplyAttestation, error) {
+	ts, err := ptypes.TimestampProto(a.Time())
+	if err != nil {
+		return nil, err
+	}
+	peer, err := a.Signer.Serialize()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed serializing peer identity")
+	}
+	raw, err := proto.Marshal(&token.SupplyAttestation{
+		ChannelId:   channel,
+		BlockHeight: height,
+		Supplies:    supplies,
+		Timestamp:   ts,
+		Peer:        peer,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling supply attestation")
+	}
+	signature, err := a.Signer.Sign(raw)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed signing supply attestation")
+	}
+	return &token.SignedSupplyAttestation{Attestation: raw, Signature: signature}, nil
+}
+
+// typeSupplies returns the supply of the passed token types, which is 0 for
+// the types not in circulation, or of every type in circulation when none is
+// passed, sorted by type.
+func typeSupplies(supply map[string]uint64, types []string) []*token.TypeSupply {
+	if len(types) == 0 {
+		for tokenType := range supply {
+			types = append(types, tokenType)
+		}
+	}
+	seen := map[string]bool{}
+	var supplies []*token.TypeSupply
+	for _, tokenType := range types {
+		if seen[tokenType] {
+			continue
+		}
+		seen[tokenType] = true
+		supplies = append(supplies, &token.TypeSupply{Type: tokenType, Quantity: supply[tokenType]})
+	}
+	sort.Slice(supplies, func(i, j int) bool { return supplies[i].Type < supplies[j].Type })
+	return supplies
+}
+
+// RequestSupplyAttestation returns an attestation of the circulating supply of
+// the token types of the channel, signed by the peer.
+func (s *Prover) RequestSupplyAttestation(ctx context.Context, header *token.Header, request *token.SupplyAttestationRequest) (*token.CommandResponse_SupplyAttestation, error) {
+	if s.SupplyAttestor == nil {
+		return nil, errors.New("supply attestations are not enabled on this peer")
+	}
+	attestation, err := s.SupplyAttestor.AttestSupply(header.ChannelId, request.Types)
+	if err != nil {
+		return nil, err
+	}
+	return &token.CommandResponse_SupplyAttestation{SupplyAttestation: attestation}, nil
+}
@@ -0,0 +1,201 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/token"
+	ledgermock "github.com/hyperledger/fabric/token/ledger/mock"
+	"github.com/hyperledger/fabric/token/server"
+	"github.com/hyperledger/fabric/token/server/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("LedgerSupplyAttestor", func() {
+	var (
+		fakeLedgerManager *ledgermock.LedgerManager
+		fakeLedgerReader  *ledgermock.LedgerReader
+		fakeIterator      *ledgermock.ResultsIterator
+		fakeCommitLedger  *mock.CommitLedger
+		fakeSigner        *mock.SignerIdentity
+		now               time.Time
+		attestor          *server.LedgerSupplyAttestor
+	)
+
+	output := func(tokenType string, quantity uint64) []byte {
+		raw, err := proto.Marshal(&token.PlainOutput{Owner: []byte("alice"), Type: tokenType, Quantity: quantity})
+		Expect(err).NotTo(HaveOccurred())
+		return raw
+	}
+
+	BeforeEach(func() {
+		fakeIterator = &ledgermock.ResultsIterator{}
+		fakeIterator.NextReturnsOnCall(0, &queryresult.KV{Key: "\x00tokenOutput\x000\x000\x00", Value: output("USD", 100)}, nil)
+		fakeIterator.NextReturnsOnCall(1, &queryresult.KV{Key: "\x00tokenOutput\x000\x001\x00", Value: output("EUR", 20)}, nil)
+		fakeIterator.NextReturnsOnCall(2, &queryresult.KV{Key: "\x00tokenOutput\x001\x000\x00", Value: output("USD", 50)}, nil)
+		fakeLedgerReader = &ledgermock.LedgerReader{}
+		fakeLedgerReader.GetStateRangeScanIteratorReturns(fakeIterator, nil)
+		fakeLedgerManager = &ledgermock.LedgerManager{}
+		fakeLedgerManager.GetLedgerReaderReturns(fakeLedgerReader, nil)
+
+		fakeCommitLedger = &mock.CommitLedger{}
+		fakeCommitLedger.GetBlockchainInfoReturns(&common.BlockchainInfo{Height: 42}, nil)
+
+		fakeSigner = &mock.SignerIdentity{}
+		fakeSigner.SerializeReturns([]byte("peer0"), nil)
+		fakeSigner.SignReturns([]byte("signature"), nil)
+
+		now = time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)
+		attestor = &server.LedgerSupplyAttestor{
+			LedgerManager: fakeLedgerManager,
+			GetLedger: func(channelID string) server.CommitLedger {
+				if channelID != "channel-id" {
+					return nil
+				}
+				return fakeCommitLedger
+			},
+			Signer: fakeSigner,
+			Time:   func() time.Time { return now },
+		}
+	})
+
+	unmarshal := func(signed *token.SignedSupplyAttestation) *token.SupplyAttestation {
+		attestation := &token.SupplyAttestation{}
+		Expect(proto.Unmarshal(signed.Attestation, attestation)).To(Succeed())
+		return attestation
+	}
+
+	It("attests the circulating supply of every token type at the height of the ledger", func() {
+		signed, err := attestor.AttestSupply("channel-id", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(signed.Signature).To(Equal([]byte("signature")))
+		Expect(fakeSigner.SignArgsForCall(0)).To(Equal(signed.Attestation))
+
+		ts, err := ptypes.TimestampProto(now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(proto.Equal(unmarshal(signed), &token.SupplyAttestation{
+			ChannelId:   "channel-id",
+			BlockHeight: 42,
+			Supplies:    []*token.TypeSupply{{Type: "EUR", Quantity: 20}, {Type: "USD", Quantity: 150}},
+			Timestamp:   ts,
+			Peer:        []byte("peer0"),
+		})).To(BeTrue())
+		Expect(fakeLedgerManager.GetLedgerReaderArgsForCall(0)).To(Equal("channel-id"))
+		Expect(fakeLedgerReader.DoneCallCount()).To(Equal(1))
+	})
+
+	It("attests the supply of the requested token types", func() {
+		signed, err := attestor.AttestSupply("channel-id", []string{"USD", "JPY", "USD"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(unmarshal(signed).Supplies).To(Equal([]*token.TypeSupply{{Type: "JPY"}, {Type: "USD", Quantity: 150}}))
+	})
+
+	Context("when a block is committed while computing the supply", func() {
+		BeforeEach(func() {
+			fakeCommitLedger.GetBlockchainInfoReturnsOnCall(0, &common.BlockchainInfo{Height: 41}, nil)
+			fakeCommitLedger.GetBlockchainInfoReturnsOnCall(1, &common.BlockchainInfo{Height: 42}, nil)
+		})
+
+		It("computes the supply again", func() {
+			signed, err := attestor.AttestSupply("channel-id", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(unmarshal(signed).BlockHeight).To(Equal(uint64(42)))
+			Expect(fakeLedgerManager.GetLedgerReaderCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when blocks keep being committed", func() {
+		BeforeEach(func() {
+			height := uint64(0)
+			fakeCommitLedger.GetBlockchainInfoStub = func() (*common.BlockchainInfo, error) {
+				height++
+				return &common.BlockchainInfo{Height: height}, nil
+			}
+		})
+
+		It("returns an error", func() {
+			_, err := attestor.AttestSupply("channel-id", nil)
+			Expect(err).To(MatchError("blocks kept being committed to channel channel-id while computing the token supply"))
+			Expect(fakeLedgerManager.GetLedgerReaderCallCount()).To(Equal(3))
+		})
+	})
+
+	Context("when the channel is not found", func() {
+		It("returns an error", func() {
+			_, err := attestor.AttestSupply("missing", nil)
+			Expect(err).To(MatchError("ledger not found for channel missing"))
+		})
+	})
+
+	Context("when the supply cannot be computed", func() {
+		BeforeEach(func() {
+			fakeLedgerReader.GetStateRangeScanIteratorReturns(nil, errors.New("boom"))
+		})
+
+		It("returns an error", func() {
+			_, err := attestor.AttestSupply("channel-id", nil)
+			Expect(err).To(MatchError("failed computing the token supply: boom"))
+			Expect(fakeLedgerReader.DoneCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when signing fails", func() {
+		BeforeEach(func() {
+			fakeSigner.SignReturns(nil, errors.New("no-key"))
+		})
+
+		It("returns an error", func() {
+			_, err := attestor.AttestSupply("channel-id", nil)
+			Expect(err).To(MatchError("failed signing supply attestation: no-key"))
+		})
+	})
+})
+
+var _ = Describe("RequestSupplyAttestation", func() {
+	var (
+		fakeAttestor *mock.SupplyAttestor
+		prover       *server.Prover
+		header       *token.Header
+	)
+
+	BeforeEach(func() {
+		fakeAttestor = &mock.SupplyAttestor{}
+		fakeAttestor.AttestSupplyReturns(&token.SignedSupplyAttestation{Attestation: []byte("attestation")}, nil)
+		prover = &server.Prover{SupplyAttestor: fakeAttestor}
+		header = &token.Header{ChannelId: "channel-id"}
+	})
+
+	It("returns the attestation of the supply", func() {
+		resp, err := prover.RequestSupplyAttestation(context.Background(), header, &token.SupplyAttestationRequest{Types: []string{"USD"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp).To(Equal(&token.CommandResponse_SupplyAttestation{
+			SupplyAttestation: &token.SignedSupplyAttestation{Attestation: []byte("attestation")},
+		}))
+		channel, types := fakeAttestor.AttestSupplyArgsForCall(0)
+		Expect(channel).To(Equal("channel-id"))
+		Expect(types).To(Equal([]string{"USD"}))
+	})
+
+	Context("when supply attestations are not enabled", func() {
+		BeforeEach(func() {
+			prover.SupplyAttestor = nil
+		})
+
+		It("returns an error", func() {
+			_, err := prover.RequestSupplyAttestation(context.Background(), header, &token.SupplyAttestationRequest{})
+			Expect(err).To(MatchError("supply attestations are not enabled on this peer"))
+		})
+	})
+})
@@ -19,6 +19,16 @@ type Issuer interface {
 	// RequestExpectation allows indirect import based on the expectation.
 	// It creates a token transaction with the outputs as specified in the expectation.
 	RequestExpectation(request *token.ExpectationRequest) (*token.TokenTransaction, error)
+
+	// RequestPause creates a governance transaction pausing the passed token type.
+	RequestPause(tokenType string) (*token.TokenTransaction, error)
+
+	// RequestResume creates a governance transaction resuming the passed token type.
+	RequestResume(tokenType string) (*token.TokenTransaction, error)
+
+	// RequestIssueManifest creates a transaction tying together the imports of
+	// the chunks of a batch.
+	RequestIssueManifest(batchID []byte, chunks []*token.IssueChunk) (*token.TokenTransaction, error)
 }
 
 //go:generate counterfeiter -o mock/transactor.go -fake-name Transactor . Transactor
@@ -39,6 +49,10 @@ type Transactor interface {
 	// ListTokens returns a slice of unspent tokens owned by this transactor
 	ListTokens() (*token.UnspentTokens, error)
 
+	// ListTransactionsByReference returns the committed token transactions
+	// carrying the passed application reference
+	ListTransactionsByReference(reference []byte) (*token.ReferencedTransactions, error)
+
 	// RequestApprove creates a token transaction that includes the data necessary
 	// for approve
 	RequestApprove(request *token.ApproveRequest) (*token.TokenTransaction, error)
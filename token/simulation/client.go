@@ -0,0 +1,186 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package simulation
+
+import (
+	"context"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/hyperledger/fabric/token/client/clienttest"
+	"github.com/hyperledger/fabric/token/server"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+// Result is the outcome of an operation of a client. TxID is empty and Err
+// is set if the prover or the orderer rejected the operation; otherwise Code
+// is the validation code of the committed transaction.
+type Result struct {
+	TxID string
+	Code pb.TxValidationCode
+	Err  error
+}
+
+// Client is a token client of a simulated network. Its operations are
+// asynchronous: each one is a sequence of messages, from the client to the
+// prover, back to the client, to the orderer, and from the committer back to
+// the client, which run as the scheduler of the network advances.
+type Client struct {
+	Identity *clienttest.Identity
+
+	network *Network
+	prover  *client.ProverPeer
+}
+
+// NewClient returns a client of the network acting as identity.
+func (n *Network) NewClient(identity *clienttest.Identity) *Client {
+	return &Client{
+		Identity: identity,
+		network:  n,
+		prover: &client.ProverPeer{
+			ChannelID:        n.ChannelID,
+			ProverClient:     &proverClient{prover: n.prover},
+			RandomnessReader: n.Scheduler,
+			Time:             n.Scheduler.Now,
+		},
+	}
+}
+
+// Issue issues tokens and calls done once the transaction is committed.
+func (c *Client) Issue(tokensToIssue []*token.TokenToIssue, done func(Result)) {
+	c.invoke("issue", func() ([]byte, error) {
+		return c.prover.RequestImport(tokensToIssue, c.Identity)
+	}, done)
+}
+
+// Transfer transfers tokens and calls done once the transaction is
+// committed. The prover checks the owner of the tokens against the world
+// state at the time the command reaches it, while whether they are spent is
+// only checked by the committer.
+func (c *Client) Transfer(tokenIDs [][]byte, shares []*token.RecipientTransferShare, done func(Result)) {
+	c.invoke("transfer", func() ([]byte, error) {
+		return c.prover.RequestTransfer(tokenIDs, shares, c.Identity)
+	}, done)
+}
+
+// Redeem redeems tokens and calls done once the transaction is committed.
+func (c *Client) Redeem(tokenIDs [][]byte, quantity uint64, done func(Result)) {
+	c.invoke("redeem", func() ([]byte, error) {
+		return c.prover.RequestRedeem(tokenIDs, quantity, c.Identity)
+	}, done)
+}
+
+// ListTokens returns the unspent tokens of the client in the committed world
+// state. Unlike the other operations, it completes right away.
+func (c *Client) ListTokens() ([]*token.TokenOutput, error) {
+	return c.prover.ListTokens(c.Identity)
+}
+
+// invoke sends the command of request to the prover and submits the token
+// transaction of its response to the orderer.
+func (c *Client) invoke(operation string, request func() ([]byte, error), done func(Result)) {
+	name := c.Identity.MSPID + "." + c.Identity.Name
+	c.network.send(operation+" command of "+name, func() {
+		response, err := request()
+		c.network.send(operation+" response to "+name, func() {
+			if err != nil {
+				done(Result{Err: err})
+				return
+			}
+			c.submit(response, done)
+		})
+	})
+}
+
+// submit parses the command response of the prover and broadcasts its token
+// transaction in an envelope signed by the client.
+func (c *Client) submit(commandResponse []byte, done func(Result)) {
+	response := &token.CommandResponse{}
+	err := proto.Unmarshal(commandResponse, response)
+	if err != nil {
+		done(Result{Err: errors.Wrap(err, "failed unmarshaling command response")})
+		return
+	}
+	if response.GetErr() != nil {
+		done(Result{Err: errors.Errorf("prover responded error: %s", response.GetErr().Message)})
+		return
+	}
+	tx, err := proto.Marshal(response.GetTokenTransaction())
+	if err != nil {
+		done(Result{Err: errors.Wrap(err, "failed marshaling token transaction")})
+		return
+	}
+
+	txID, envelope, err := c.createTxEnvelope(tx)
+	if err != nil {
+		done(Result{Err: err})
+		return
+	}
+	c.network.Broadcast(envelope, func(code pb.TxValidationCode, err error) {
+		done(Result{TxID: txID, Code: code, Err: err})
+	})
+}
+
+// createTxEnvelope is like client.TxSubmitter.CreateTxEnvelope, but the
+// header is timestamped by the fake clock and its nonce drawn from the seed
+// of the simulation, so that the transaction ID is reproducible.
+func (c *Client) createTxEnvelope(tx []byte) (string, *common.Envelope, error) {
+	creator, err := c.Identity.Serialize()
+	if err != nil {
+		return "", nil, err
+	}
+	ts, err := ptypes.TimestampProto(c.network.Scheduler.Now())
+	if err != nil {
+		return "", nil, err
+	}
+	nonce := make([]byte, crypto.NonceSize)
+	if _, err := io.ReadFull(c.network.Scheduler, nonce); err != nil {
+		return "", nil, err
+	}
+	txID, err := utils.ComputeTxIDWithHash(nonce, creator, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	chdr, err := proto.Marshal(&common.ChannelHeader{
+		Type:      int32(common.HeaderType_TOKEN_TRANSACTION),
+		ChannelId: c.network.ChannelID,
+		TxId:      txID,
+		Timestamp: ts,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	shdr, err := proto.Marshal(&common.SignatureHeader{Creator: creator, Nonce: nonce})
+	if err != nil {
+		return "", nil, err
+	}
+	header := &common.Header{ChannelHeader: chdr, SignatureHeader: shdr}
+
+	envelope, err := client.CreateEnvelope(tx, header, c.Identity)
+	if err != nil {
+		return "", nil, err
+	}
+	return txID, envelope, nil
+}
+
+// proverClient calls the prover of the network in-process.
+type proverClient struct {
+	prover *server.Prover
+}
+
+func (c *proverClient) ProcessCommand(ctx context.Context, sc *token.SignedCommand, opts ...grpc.CallOption) (*token.SignedCommandResponse, error) {
+	return c.prover.ProcessCommand(ctx, sc)
+}
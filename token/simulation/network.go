@@ -0,0 +1,278 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package simulation runs a token network of a single channel in a single
+// process and on a fake clock, to reproduce deterministically the behaviors
+// that depend on the interleaving of its components, such as two transfers of
+// the same tokens racing to be committed, without deploying peers and
+// orderers.
+//
+// The prover, the orderer and the committer of the network, and the token
+// clients, exchange their messages through the events of a Scheduler, which
+// delays each message by a random latency drawn from the seed of the
+// simulation. The prover is the one of the token server, assembling token
+// transactions with the plain token management system, and the committer
+// validates the blocks cut by the orderer with the plain verifier, against
+// the in-memory world state of clienttest. A simulation run with the same
+// seed therefore produces the same transactions, blocks and trace, and runs
+// with different seeds explore different interleavings.
+//
+// As with clienttest, signatures and access control are not checked.
+package simulation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/hyperledger/fabric/token/client/clienttest"
+	"github.com/hyperledger/fabric/token/identity"
+	"github.com/hyperledger/fabric/token/server"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("token.simulation")
+
+// Config is the configuration of a simulated network.
+type Config struct {
+	ChannelID string
+	// Seed seeds the latencies of the messages and the nonces of the
+	// commands and transactions
+	Seed int64
+	// Start is the time of the fake clock when the simulation starts
+	Start time.Time
+	// MinLatency and MaxLatency bound the latency of every message between
+	// the clients and the components of the network
+	MinLatency time.Duration
+	MaxLatency time.Duration
+	// BatchSize is the maximum number of transactions of a block
+	BatchSize int
+	// BatchTimeout is the time the orderer waits after the first transaction
+	// of a block before cutting it
+	BatchTimeout time.Duration
+	// IssuingValidator checks the creators of the transactions issuing
+	// tokens; when nil, anyone may issue tokens
+	IssuingValidator identity.IssuingValidator
+}
+
+// DefaultConfig returns the configuration of a network of channel, whose
+// messages take between 1 and 50 milliseconds and whose orderer cuts blocks
+// of up to 10 transactions within 200 milliseconds.
+func DefaultConfig(channelID string, seed int64) Config {
+	return Config{
+		ChannelID:    channelID,
+		Seed:         seed,
+		Start:        time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC),
+		MinLatency:   time.Millisecond,
+		MaxLatency:   50 * time.Millisecond,
+		BatchSize:    10,
+		BatchTimeout: 200 * time.Millisecond,
+	}
+}
+
+// Block is a block committed by the network.
+type Block struct {
+	Number uint64
+	TxIDs  []string
+	Codes  []pb.TxValidationCode
+}
+
+// Network is a simulated token network of a single channel. It is not safe
+// for concurrent use: all its methods, and those of its clients, must be
+// called from the events of its scheduler or between runs of the scheduler.
+type Network struct {
+	Config
+	Scheduler *Scheduler
+
+	state  *clienttest.WorldState
+	prover *server.Prover
+
+	// batch holds the transactions received by the orderer since it cut the
+	// last block, and batchNumber identifies it, so that the timeout of a
+	// batch that was cut because it was full is ignored
+	batch       []*common.Envelope
+	batchNumber uint64
+
+	blocks   []*Block
+	txs      map[string]pb.TxValidationCode
+	received map[string]bool
+	waiters  map[string][]func(pb.TxValidationCode)
+}
+
+// NewNetwork returns a network configured by config, whose ledger only holds
+// an empty genesis block.
+func NewNetwork(config Config) (*Network, error) {
+	if config.BatchSize <= 0 {
+		return nil, errors.Errorf("invalid batch size %d", config.BatchSize)
+	}
+	if config.MaxLatency < config.MinLatency {
+		return nil, errors.Errorf("max latency %s is lower than min latency %s", config.MaxLatency, config.MinLatency)
+	}
+
+	state := clienttest.NewWorldState()
+	prover, err := clienttest.NewProver(state)
+	if err != nil {
+		return nil, err
+	}
+	return &Network{
+		Config:    config,
+		Scheduler: NewScheduler(config.Start, config.Seed),
+		state:     state,
+		prover:    prover,
+		blocks:    []*Block{{Number: 0}},
+		txs:       map[string]pb.TxValidationCode{},
+		received:  map[string]bool{},
+		waiters:   map[string][]func(pb.TxValidationCode){},
+	}, nil
+}
+
+// latency returns the latency of the next message.
+func (n *Network) latency() time.Duration {
+	return n.Scheduler.Delay(n.MinLatency, n.MaxLatency)
+}
+
+// send delivers a message to a component of the network, by running fn after
+// the latency of the message.
+func (n *Network) send(name string, fn func()) {
+	n.Scheduler.After(n.latency(), name, fn)
+}
+
+// Broadcast sends the envelope of a token transaction to the orderer, and
+// calls committed with its validation code once the committer notified it.
+// The transaction is rejected, and committed is called with an error, if the
+// orderer does not accept it.
+func (n *Network) Broadcast(envelope *common.Envelope, committed func(pb.TxValidationCode, error)) {
+	chdr, err := n.checkTransaction(envelope)
+	if err != nil {
+		committed(pb.TxValidationCode_INVALID_OTHER_REASON, err)
+		return
+	}
+	n.send("broadcast "+chdr.TxId, func() {
+		n.order(envelope)
+	})
+	n.waitForCommit(chdr.TxId, func(code pb.TxValidationCode) {
+		n.send("commit event "+chdr.TxId, func() {
+			committed(code, nil)
+		})
+	})
+}
+
+func (n *Network) checkTransaction(envelope *common.Envelope) (*common.ChannelHeader, error) {
+	payload, err := utils.UnmarshalPayload(envelope.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Header == nil {
+		return nil, errors.New("missing header")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+	if chdr.ChannelId != n.ChannelID {
+		return nil, errors.Errorf("channel %s not found", chdr.ChannelId)
+	}
+	if common.HeaderType(chdr.Type) != common.HeaderType_TOKEN_TRANSACTION {
+		return nil, errors.Errorf("only token transactions are supported, provided type: %d", chdr.Type)
+	}
+	return chdr, nil
+}
+
+// order adds the envelope to the batch of the orderer, and cuts the batch
+// once it is full or once its timeout expires.
+func (n *Network) order(envelope *common.Envelope) {
+	n.batch = append(n.batch, envelope)
+	if len(n.batch) >= n.BatchSize {
+		n.cut()
+		return
+	}
+	if len(n.batch) == 1 {
+		number := n.batchNumber
+		n.Scheduler.After(n.BatchTimeout, fmt.Sprintf("batch timeout %d", number), func() {
+			if n.batchNumber == number {
+				n.cut()
+			}
+		})
+	}
+}
+
+// cut cuts a block of the batch and delivers it to the committer.
+func (n *Network) cut() {
+	batch := n.batch
+	n.batch = nil
+	n.batchNumber++
+	n.send(fmt.Sprintf("deliver block of batch %d", n.batchNumber-1), func() {
+		n.commit(batch)
+	})
+}
+
+// commit validates the transactions of a block in order, applying the writes
+// of the valid ones to the world state, and notifies their waiters.
+func (n *Network) commit(envelopes []*common.Envelope) {
+	block := &Block{Number: uint64(len(n.blocks))}
+	for _, envelope := range envelopes {
+		chdr, err := n.checkTransaction(envelope)
+		if err != nil {
+			// the orderer only accepts token transactions of the channel
+			panic(fmt.Sprintf("invalid transaction ordered: %s", err))
+		}
+		code := n.validate(chdr, envelope)
+		block.TxIDs = append(block.TxIDs, chdr.TxId)
+		block.Codes = append(block.Codes, code)
+		logger.Debugf("transaction %s committed in block %d with code %s", chdr.TxId, block.Number, code)
+	}
+	n.blocks = append(n.blocks, block)
+
+	for i, txID := range block.TxIDs {
+		if block.Codes[i] == pb.TxValidationCode_DUPLICATE_TXID {
+			continue
+		}
+		n.txs[txID] = block.Codes[i]
+		for _, waiter := range n.waiters[txID] {
+			waiter(block.Codes[i])
+		}
+		delete(n.waiters, txID)
+	}
+}
+
+func (n *Network) validate(chdr *common.ChannelHeader, envelope *common.Envelope) pb.TxValidationCode {
+	if n.received[chdr.TxId] {
+		return pb.TxValidationCode_DUPLICATE_TXID
+	}
+	n.received[chdr.TxId] = true
+	return n.state.Commit(n.ChannelID, n.IssuingValidator, chdr, envelope)
+}
+
+// waitForCommit calls fn with the validation code of the transaction once it
+// is committed, or right away if it already is.
+func (n *Network) waitForCommit(txID string, fn func(pb.TxValidationCode)) {
+	if code, ok := n.txs[txID]; ok {
+		fn(code)
+		return
+	}
+	n.waiters[txID] = append(n.waiters[txID], fn)
+}
+
+// Run runs the simulation until no event is scheduled, that is until every
+// command and transaction of the clients has completed.
+func (n *Network) Run() {
+	n.Scheduler.Run()
+}
+
+// Blocks returns the blocks of the ledger, starting with the genesis block.
+func (n *Network) Blocks() []*Block {
+	return append([]*Block{}, n.blocks...)
+}
+
+// ValidationCode returns the validation code of the transaction, if it has
+// been committed.
+func (n *Network) ValidationCode(txID string) (pb.TxValidationCode, bool) {
+	code, ok := n.txs[txID]
+	return code, ok
+}
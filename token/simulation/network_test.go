@@ -0,0 +1,198 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package simulation_test
+
+import (
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/client/clienttest"
+	"github.com/hyperledger/fabric/token/simulation"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Network", func() {
+	var (
+		alice *clienttest.Identity
+		bob   *clienttest.Identity
+		carol *clienttest.Identity
+	)
+
+	BeforeEach(func() {
+		alice = clienttest.NewIdentity("Org1MSP", "alice")
+		bob = clienttest.NewIdentity("Org1MSP", "bob")
+		carol = clienttest.NewIdentity("Org2MSP", "carol")
+	})
+
+	newNetwork := func(config simulation.Config) *simulation.Network {
+		network, err := simulation.NewNetwork(config)
+		Expect(err).NotTo(HaveOccurred())
+		return network
+	}
+
+	// issue issues quantity USD to alice and returns her tokens once the
+	// transaction is committed
+	issue := func(network *simulation.Network, quantity uint64) []*token.TokenOutput {
+		var result simulation.Result
+		network.NewClient(alice).Issue([]*token.TokenToIssue{{Recipient: alice.MustSerialize(), Type: "USD", Quantity: quantity}}, func(r simulation.Result) {
+			result = r
+		})
+		network.Run()
+		Expect(result.Err).NotTo(HaveOccurred())
+		Expect(result.Code).To(Equal(pb.TxValidationCode_VALID))
+
+		tokens, err := network.NewClient(alice).ListTokens()
+		Expect(err).NotTo(HaveOccurred())
+		return tokens
+	}
+
+	shares := func(recipient *clienttest.Identity, quantity uint64) []*token.RecipientTransferShare {
+		return []*token.RecipientTransferShare{{Recipient: recipient.MustSerialize(), Quantity: quantity}}
+	}
+
+	// doubleSpend has alice transfer the same token to bob and to carol at
+	// the same time, and returns the results of both transfers and the trace
+	// of the simulation
+	doubleSpend := func(config simulation.Config) (toBob, toCarol simulation.Result, trace []string) {
+		network := newNetwork(config)
+		tokens := issue(network, 100)
+		Expect(tokens).To(HaveLen(1))
+
+		client := network.NewClient(alice)
+		client.Transfer([][]byte{tokens[0].Id}, shares(bob, 100), func(r simulation.Result) { toBob = r })
+		client.Transfer([][]byte{tokens[0].Id}, shares(carol, 100), func(r simulation.Result) { toCarol = r })
+		network.Run()
+		return toBob, toCarol, network.Scheduler.Trace()
+	}
+
+	It("issues, transfers and redeems tokens", func() {
+		network := newNetwork(simulation.DefaultConfig("testchannel", 1))
+		tokens := issue(network, 100)
+		Expect(tokens).To(HaveLen(1))
+		Expect(tokens[0].Quantity).To(Equal(uint64(100)))
+
+		var result simulation.Result
+		network.NewClient(alice).Transfer([][]byte{tokens[0].Id}, shares(bob, 100), func(r simulation.Result) { result = r })
+		network.Run()
+		Expect(result.Err).NotTo(HaveOccurred())
+		Expect(result.Code).To(Equal(pb.TxValidationCode_VALID))
+		code, ok := network.ValidationCode(result.TxID)
+		Expect(ok).To(BeTrue())
+		Expect(code).To(Equal(pb.TxValidationCode_VALID))
+
+		bobClient := network.NewClient(bob)
+		tokens, err := bobClient.ListTokens()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tokens).To(HaveLen(1))
+
+		bobClient.Redeem([][]byte{tokens[0].Id}, 30, func(r simulation.Result) { result = r })
+		network.Run()
+		Expect(result.Err).NotTo(HaveOccurred())
+		Expect(result.Code).To(Equal(pb.TxValidationCode_VALID))
+
+		tokens, err = bobClient.ListTokens()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tokens).To(HaveLen(1))
+		Expect(tokens[0].Quantity).To(Equal(uint64(70)))
+		Expect(network.Blocks()).To(HaveLen(4))
+	})
+
+	It("commits exactly one of two transfers racing to spend the same token", func() {
+		winners := map[string]int{}
+		for seed := int64(0); seed < 50; seed++ {
+			config := simulation.DefaultConfig("testchannel", seed)
+			config.BatchSize = 1
+			toBob, toCarol, _ := doubleSpend(config)
+			Expect(toBob.Err).NotTo(HaveOccurred())
+			Expect(toCarol.Err).NotTo(HaveOccurred())
+
+			codes := []pb.TxValidationCode{toBob.Code, toCarol.Code}
+			Expect(codes).To(ConsistOf(pb.TxValidationCode_VALID, pb.TxValidationCode_INVALID_OTHER_REASON), "seed %d", seed)
+			if toBob.Code == pb.TxValidationCode_VALID {
+				winners["bob"]++
+			} else {
+				winners["carol"]++
+			}
+		}
+		// the seeds explore both orders of the transfers
+		Expect(winners).To(HaveKey("bob"))
+		Expect(winners).To(HaveKey("carol"))
+	})
+
+	It("reproduces a simulation with the same seed", func() {
+		config := simulation.DefaultConfig("testchannel", 7)
+		toBob1, toCarol1, trace1 := doubleSpend(config)
+		toBob2, toCarol2, trace2 := doubleSpend(config)
+
+		Expect(trace1).To(Equal(trace2))
+		Expect(toBob1).To(Equal(toBob2))
+		Expect(toCarol1).To(Equal(toCarol2))
+
+		config.Seed = 8
+		_, _, trace3 := doubleSpend(config)
+		Expect(trace3).NotTo(Equal(trace1))
+	})
+
+	It("cuts a block of the transactions received within the batch timeout", func() {
+		network := newNetwork(simulation.DefaultConfig("testchannel", 3))
+		issue(network, 100)
+		tokens := issue(network, 50)
+		Expect(tokens).To(HaveLen(2))
+
+		var results []simulation.Result
+		client := network.NewClient(alice)
+		client.Transfer([][]byte{tokens[0].Id}, shares(bob, tokens[0].Quantity), func(r simulation.Result) { results = append(results, r) })
+		client.Transfer([][]byte{tokens[1].Id}, shares(carol, tokens[1].Quantity), func(r simulation.Result) { results = append(results, r) })
+		network.Run()
+
+		Expect(results).To(HaveLen(2))
+		blocks := network.Blocks()
+		Expect(blocks).To(HaveLen(4))
+		Expect(blocks[3].TxIDs).To(ConsistOf(results[0].TxID, results[1].TxID))
+		Expect(blocks[3].Codes).To(Equal([]pb.TxValidationCode{pb.TxValidationCode_VALID, pb.TxValidationCode_VALID}))
+	})
+
+	Context("when a transfer is proved after a conflicting one is committed", func() {
+		It("invalidates it", func() {
+			network := newNetwork(simulation.DefaultConfig("testchannel", 5))
+			tokens := issue(network, 100)
+			client := network.NewClient(alice)
+
+			var first, second simulation.Result
+			client.Transfer([][]byte{tokens[0].Id}, shares(bob, 100), func(r simulation.Result) { first = r })
+			network.Run()
+			Expect(first.Code).To(Equal(pb.TxValidationCode_VALID))
+
+			client.Transfer([][]byte{tokens[0].Id}, shares(carol, 100), func(r simulation.Result) { second = r })
+			network.Run()
+			Expect(second.Err).NotTo(HaveOccurred())
+			Expect(second.Code).To(Equal(pb.TxValidationCode_INVALID_OTHER_REASON))
+		})
+	})
+
+	Context("when the prover rejects a command", func() {
+		It("returns the error of the prover", func() {
+			network := newNetwork(simulation.DefaultConfig("testchannel", 5))
+
+			var result simulation.Result
+			network.NewClient(alice).Transfer([][]byte{[]byte("missing")}, shares(bob, 100), func(r simulation.Result) { result = r })
+			network.Run()
+			Expect(result.Err).To(MatchError(ContainSubstring("prover responded error")))
+			Expect(result.TxID).To(BeEmpty())
+			Expect(network.Blocks()).To(HaveLen(1))
+		})
+	})
+
+	Context("when the configuration is invalid", func() {
+		It("returns an error", func() {
+			config := simulation.DefaultConfig("testchannel", 1)
+			config.BatchSize = 0
+			_, err := simulation.NewNetwork(config)
+			Expect(err).To(MatchError("invalid batch size 0"))
+		})
+	})
+})
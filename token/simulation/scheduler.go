@@ -0,0 +1,148 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package simulation
+
+import (
+	"container/heap"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Scheduler runs the events of a simulation one at a time on a single
+// goroutine, in the order of their time on a fake clock, and in the order
+// they were scheduled when their times are equal. The random delays and bytes
+// it provides are drawn from a seeded source, so that a simulation run with
+// the same seed executes the same events in the same order.
+type Scheduler struct {
+	now    time.Time
+	start  time.Time
+	seq    uint64
+	events eventQueue
+	rand   *rand.Rand
+	trace  []string
+}
+
+type event struct {
+	at   time.Time
+	seq  uint64
+	name string
+	fn   func()
+}
+
+// NewScheduler returns a scheduler whose clock is at start, drawing random
+// delays and bytes from seed.
+func NewScheduler(start time.Time, seed int64) *Scheduler {
+	return &Scheduler{
+		now:   start,
+		start: start,
+		rand:  rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Now returns the time of the fake clock, which is the time of the event
+// being run.
+func (s *Scheduler) Now() time.Time {
+	return s.now
+}
+
+// At schedules fn to run at t, or now if t is in the past. The name of the
+// event is recorded in the trace once it runs.
+func (s *Scheduler) At(t time.Time, name string, fn func()) {
+	if t.Before(s.now) {
+		t = s.now
+	}
+	s.seq++
+	heap.Push(&s.events, &event{at: t, seq: s.seq, name: name, fn: fn})
+}
+
+// After schedules fn to run d after now.
+func (s *Scheduler) After(d time.Duration, name string, fn func()) {
+	s.At(s.now.Add(d), name, fn)
+}
+
+// Delay returns a random delay between min and max, included.
+func (s *Scheduler) Delay(min, max time.Duration) time.Duration {
+	if max <= min {
+		return min
+	}
+	return min + time.Duration(s.rand.Int63n(int64(max-min)+1))
+}
+
+// Read fills p with random bytes, e.g. the nonces of the commands and
+// transactions of the simulation. It never fails.
+func (s *Scheduler) Read(p []byte) (int, error) {
+	return s.rand.Read(p)
+}
+
+// Step runs the next event and returns true, or returns false if no event is
+// scheduled.
+func (s *Scheduler) Step() bool {
+	if len(s.events) == 0 {
+		return false
+	}
+	e := heap.Pop(&s.events).(*event)
+	s.now = e.at
+	s.trace = append(s.trace, fmt.Sprintf("%s %s", e.at.Sub(s.start), e.name))
+	e.fn()
+	return true
+}
+
+// Run runs the events until none is scheduled and returns the number of
+// events run.
+func (s *Scheduler) Run() int {
+	n := 0
+	for s.Step() {
+		n++
+	}
+	return n
+}
+
+// RunUntil runs the events scheduled until t, included, and sets the clock
+// to t.
+func (s *Scheduler) RunUntil(t time.Time) {
+	for len(s.events) != 0 && !s.events[0].at.After(t) {
+		s.Step()
+	}
+	if t.After(s.now) {
+		s.now = t
+	}
+}
+
+// RunFor runs the events scheduled in the next d.
+func (s *Scheduler) RunFor(d time.Duration) {
+	s.RunUntil(s.now.Add(d))
+}
+
+// Trace returns the events run so far, with their time relative to the
+// start of the simulation, e.g. to check that two runs are identical.
+func (s *Scheduler) Trace() []string {
+	return append([]string{}, s.trace...)
+}
+
+// eventQueue is a heap of events ordered by time, then by sequence.
+type eventQueue []*event
+
+func (q eventQueue) Len() int { return len(q) }
+
+func (q eventQueue) Less(i, j int) bool {
+	if !q[i].at.Equal(q[j].at) {
+		return q[i].at.Before(q[j].at)
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q eventQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *eventQueue) Push(x interface{}) { *q = append(*q, x.(*event)) }
+
+func (q *eventQueue) Pop() interface{} {
+	old := *q
+	e := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return e
+}
@@ -0,0 +1,84 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package simulation_test
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric/token/simulation"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scheduler", func() {
+	var (
+		start     time.Time
+		scheduler *simulation.Scheduler
+		ran       []string
+	)
+
+	BeforeEach(func() {
+		start = time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC)
+		scheduler = simulation.NewScheduler(start, 42)
+		ran = nil
+	})
+
+	record := func(name string) func() {
+		return func() { ran = append(ran, name) }
+	}
+
+	It("runs the events in the order of their time, then of their scheduling", func() {
+		scheduler.After(2*time.Second, "c", record("c"))
+		scheduler.After(time.Second, "a", record("a"))
+		scheduler.After(time.Second, "b", record("b"))
+
+		Expect(scheduler.Run()).To(Equal(3))
+		Expect(ran).To(Equal([]string{"a", "b", "c"}))
+		Expect(scheduler.Now()).To(Equal(start.Add(2 * time.Second)))
+		Expect(scheduler.Trace()).To(Equal([]string{"1s a", "1s b", "2s c"}))
+	})
+
+	It("runs the events scheduled by events", func() {
+		scheduler.After(time.Second, "a", func() {
+			ran = append(ran, "a")
+			scheduler.After(time.Second, "b", record("b"))
+			scheduler.At(start, "c", record("c"))
+		})
+
+		scheduler.Run()
+		Expect(ran).To(Equal([]string{"a", "c", "b"}))
+		Expect(scheduler.Trace()).To(Equal([]string{"1s a", "1s c", "2s b"}))
+	})
+
+	It("runs the events until a time", func() {
+		scheduler.After(time.Second, "a", record("a"))
+		scheduler.After(3*time.Second, "b", record("b"))
+
+		scheduler.RunFor(2 * time.Second)
+		Expect(ran).To(Equal([]string{"a"}))
+		Expect(scheduler.Now()).To(Equal(start.Add(2 * time.Second)))
+
+		Expect(scheduler.Step()).To(BeTrue())
+		Expect(ran).To(Equal([]string{"a", "b"}))
+		Expect(scheduler.Step()).To(BeFalse())
+	})
+
+	It("draws the same delays and bytes from the same seed", func() {
+		other := simulation.NewScheduler(start, 42)
+		for i := 0; i < 10; i++ {
+			delay := scheduler.Delay(time.Millisecond, 50*time.Millisecond)
+			Expect(delay).To(BeNumerically(">=", time.Millisecond))
+			Expect(delay).To(BeNumerically("<=", 50*time.Millisecond))
+			Expect(other.Delay(time.Millisecond, 50*time.Millisecond)).To(Equal(delay))
+		}
+
+		b1, b2 := make([]byte, 32), make([]byte, 32)
+		scheduler.Read(b1)
+		other.Read(b2)
+		Expect(b1).To(Equal(b2))
+	})
+})
@@ -0,0 +1,175 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sqlview_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+)
+
+// fakeDB is an in-memory database/sql driver that records the statements it
+// executes and answers queries with canned rows, keyed by a query substring.
+type fakeDB struct {
+	mutex sync.Mutex
+	execs []statement
+	rows  map[string]*fakeRows
+	errs  map[string]error
+	// commitErrs are returned, in order, by the next commits
+	commitErrs []error
+	commits    int
+	rollbacks  int
+}
+
+type statement struct {
+	query string
+	args  []driver.Value
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+var (
+	fakeDBsMutex sync.Mutex
+	fakeDBs      = map[string]*fakeDB{}
+)
+
+func init() {
+	sql.Register("sqlviewtest", &fakeDriver{})
+}
+
+func newFakeDB(name string) (*fakeDB, *sql.DB) {
+	f := &fakeDB{rows: map[string]*fakeRows{}, errs: map[string]error{}}
+	fakeDBsMutex.Lock()
+	fakeDBs[name] = f
+	fakeDBsMutex.Unlock()
+
+	db, err := sql.Open("sqlviewtest", name)
+	if err != nil {
+		panic(err)
+	}
+	return f, db
+}
+
+func (f *fakeDB) Execs(substring string) []statement {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	var matched []statement
+	for _, s := range f.execs {
+		if strings.Contains(s.query, substring) {
+			matched = append(matched, s)
+		}
+	}
+	return matched
+}
+
+func (f *fakeDB) err(query string) error {
+	for substring, err := range f.errs {
+		if strings.Contains(query, substring) {
+			return err
+		}
+	}
+	return nil
+}
+
+type fakeDriver struct{}
+
+func (*fakeDriver) Open(name string) (driver.Conn, error) {
+	fakeDBsMutex.Lock()
+	defer fakeDBsMutex.Unlock()
+	return &fakeConn{db: fakeDBs[name]}, nil
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{db: c.db, query: query}, nil
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return &fakeTx{db: c.db}, nil
+}
+
+type fakeTx struct {
+	db *fakeDB
+}
+
+func (t *fakeTx) Commit() error {
+	t.db.mutex.Lock()
+	defer t.db.mutex.Unlock()
+	t.db.commits++
+	if len(t.db.commitErrs) != 0 {
+		err := t.db.commitErrs[0]
+		t.db.commitErrs = t.db.commitErrs[1:]
+		return err
+	}
+	return nil
+}
+
+func (t *fakeTx) Rollback() error {
+	t.db.mutex.Lock()
+	defer t.db.mutex.Unlock()
+	t.db.rollbacks++
+	return nil
+}
+
+type fakeStmt struct {
+	db    *fakeDB
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.mutex.Lock()
+	defer s.db.mutex.Unlock()
+	if err := s.db.err(s.query); err != nil {
+		return nil, err
+	}
+	s.db.execs = append(s.db.execs, statement{query: s.query, args: args})
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.db.mutex.Lock()
+	defer s.db.mutex.Unlock()
+	if err := s.db.err(s.query); err != nil {
+		return nil, err
+	}
+	for substring, rows := range s.db.rows {
+		if strings.Contains(s.query, substring) {
+			return &fakeRowsIterator{rows: rows}, nil
+		}
+	}
+	return &fakeRowsIterator{rows: &fakeRows{}}, nil
+}
+
+type fakeRowsIterator struct {
+	rows *fakeRows
+	next int
+}
+
+func (r *fakeRowsIterator) Columns() []string { return r.rows.columns }
+func (r *fakeRowsIterator) Close() error      { return nil }
+
+func (r *fakeRowsIterator) Next(dest []driver.Value) error {
+	if r.next >= len(r.rows.values) {
+		return io.EOF
+	}
+	copy(dest, r.rows.values[r.next])
+	r.next++
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sqlview
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+const defaultHistoryLimit = 100
+
+// Handler serves balance and history queries from the view over HTTP.
+//
+//	GET /balances?channel=<channel>&owner=<hex owner>
+//	GET /history?channel=<channel>&owner=<hex owner>&limit=<n>
+type Handler struct {
+	View *View
+	mux  *http.ServeMux
+}
+
+// NewHandler creates a Handler backed by view.
+func NewHandler(view *View) *Handler {
+	h := &Handler{View: view, mux: http.NewServeMux()}
+	h.mux.HandleFunc("/balances", h.balances)
+	h.mux.HandleFunc("/history", h.history)
+	return h
+}
+
+func (h *Handler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	h.mux.ServeHTTP(resp, req)
+}
+
+func (h *Handler) balances(resp http.ResponseWriter, req *http.Request) {
+	channel, owner, ok := parseOwnerQuery(resp, req)
+	if !ok {
+		return
+	}
+	balances, err := h.View.Balances(channel, owner)
+	if err != nil {
+		logger.Errorf("failed querying balances: %s", err)
+		http.Error(resp, "failed querying balances", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(resp, balances)
+}
+
+func (h *Handler) history(resp http.ResponseWriter, req *http.Request) {
+	channel, owner, ok := parseOwnerQuery(resp, req)
+	if !ok {
+		return
+	}
+	limit := defaultHistoryLimit
+	if l := req.URL.Query().Get("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n <= 0 {
+			http.Error(resp, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+	transfers, err := h.View.History(channel, owner, limit)
+	if err != nil {
+		logger.Errorf("failed querying history: %s", err)
+		http.Error(resp, "failed querying history", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(resp, transfers)
+}
+
+func parseOwnerQuery(resp http.ResponseWriter, req *http.Request) (string, []byte, bool) {
+	if req.Method != http.MethodGet {
+		http.Error(resp, "method not allowed", http.StatusMethodNotAllowed)
+		return "", nil, false
+	}
+	query := req.URL.Query()
+	channel := query.Get("channel")
+	if channel == "" {
+		http.Error(resp, "channel is required", http.StatusBadRequest)
+		return "", nil, false
+	}
+	owner, err := hex.DecodeString(query.Get("owner"))
+	if err != nil || len(owner) == 0 {
+		http.Error(resp, "owner must be a hex encoded identity", http.StatusBadRequest)
+		return "", nil, false
+	}
+	return channel, owner, true
+}
+
+func writeJSON(resp http.ResponseWriter, v interface{}) {
+	resp.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(resp).Encode(v)
+	if err != nil {
+		logger.Warningf("failed writing response: %s", err)
+	}
+}
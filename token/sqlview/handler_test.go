@@ -0,0 +1,93 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sqlview_test
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/hyperledger/fabric/token/sqlview"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Handler", func() {
+	var (
+		fakeDB  *fakeDB
+		handler *sqlview.Handler
+		owner   string
+	)
+
+	BeforeEach(func() {
+		f, sqlDB := newFakeDB(CurrentGinkgoTestDescription().FullTestText)
+		fakeDB = f
+		handler = sqlview.NewHandler(&sqlview.View{DB: sqlDB})
+		owner = hex.EncodeToString([]byte("alice"))
+
+		fakeDB.rows["SUM(quantity)"] = &fakeRows{
+			columns: []string{"token_type", "sum"},
+			values:  [][]driver.Value{{"PDQ", []byte("40")}},
+		}
+		fakeDB.rows["FROM token_transfers"] = &fakeRows{
+			columns: []string{"tx_id", "block_number", "tx_index", "action", "created_at"},
+			values:  [][]driver.Value{{"tx0", int64(5), int64(0), "import", nil}},
+		}
+	})
+
+	serve := func(method, url string) *httptest.ResponseRecorder {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(method, url, nil))
+		return resp
+	}
+
+	It("serves balances", func() {
+		resp := serve(http.MethodGet, "/balances?channel=testchannel&owner="+owner)
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Header().Get("Content-Type")).To(Equal("application/json"))
+		Expect(resp.Body).To(MatchJSON(`[{"type":"PDQ","quantity":40}]`))
+	})
+
+	It("serves history", func() {
+		resp := serve(http.MethodGet, "/history?channel=testchannel&limit=5&owner="+owner)
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Body).To(MatchJSON(`[{"tx_id":"tx0","block_number":5,"tx_index":0,"action":"import"}]`))
+	})
+
+	It("rejects requests without a channel", func() {
+		resp := serve(http.MethodGet, "/balances?owner="+owner)
+		Expect(resp.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("rejects owners that are not hex encoded", func() {
+		resp := serve(http.MethodGet, "/balances?channel=testchannel&owner=alice")
+		Expect(resp.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("rejects an invalid limit", func() {
+		resp := serve(http.MethodGet, "/history?channel=testchannel&limit=0&owner="+owner)
+		Expect(resp.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("rejects methods other than GET", func() {
+		resp := serve(http.MethodPost, "/balances?channel=testchannel&owner="+owner)
+		Expect(resp.Code).To(Equal(http.StatusMethodNotAllowed))
+	})
+
+	Context("when the query fails", func() {
+		BeforeEach(func() {
+			fakeDB.errs["SUM(quantity)"] = errors.New("boom")
+		})
+
+		It("returns an internal server error", func() {
+			resp := serve(http.MethodGet, "/balances?channel=testchannel&owner="+owner)
+			Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
@@ -0,0 +1,127 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sqlview
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Balance is the unspent quantity of a token type held by an owner.
+type Balance struct {
+	Type     string `json:"type"`
+	Quantity uint64 `json:"quantity"`
+}
+
+// Output is an unspent token output.
+type Output struct {
+	TxID     string `json:"tx_id"`
+	Index    int    `json:"index"`
+	Type     string `json:"type"`
+	Quantity uint64 `json:"quantity"`
+}
+
+// Transfer is a token transaction that created or spent an output of an owner.
+type Transfer struct {
+	TxID        string     `json:"tx_id"`
+	BlockNumber uint64     `json:"block_number"`
+	TxIndex     int        `json:"tx_index"`
+	Action      string     `json:"action"`
+	Timestamp   *time.Time `json:"timestamp,omitempty"`
+}
+
+// Balances returns the unspent balance of owner for each token type it holds.
+func (v *View) Balances(channel string, owner []byte) ([]*Balance, error) {
+	rows, err := v.DB.Query(selectBalances, channel, owner)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed querying balances")
+	}
+	defer rows.Close()
+
+	balances := []*Balance{}
+	for rows.Next() {
+		var tokenType, sum string
+		err = rows.Scan(&tokenType, &sum)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed reading balance")
+		}
+		quantity, err := strconv.ParseUint(sum, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid balance for type %s", tokenType)
+		}
+		balances = append(balances, &Balance{Type: tokenType, Quantity: quantity})
+	}
+	return balances, errors.Wrap(rows.Err(), "failed reading balances")
+}
+
+// UnspentOutputs returns the outputs owned by owner that have not been spent.
+func (v *View) UnspentOutputs(channel string, owner []byte) ([]*Output, error) {
+	rows, err := v.DB.Query(selectUnspent, channel, owner)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed querying unspent outputs")
+	}
+	defer rows.Close()
+
+	outputs := []*Output{}
+	for rows.Next() {
+		var (
+			o        Output
+			quantity string
+		)
+		err = rows.Scan(&o.TxID, &o.Index, &o.Type, &quantity)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed reading output")
+		}
+		o.Quantity, err = strconv.ParseUint(quantity, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid quantity for output %s.%d", o.TxID, o.Index)
+		}
+		outputs = append(outputs, &o)
+	}
+	return outputs, errors.Wrap(rows.Err(), "failed reading unspent outputs")
+}
+
+// Supply returns the quantity of tokenType in circulation: imported tokens
+// that have not been redeemed.
+func (v *View) Supply(channel string, tokenType string) (uint64, error) {
+	var sum string
+	err := v.DB.QueryRow(selectSupply, channel, tokenType).Scan(&sum)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed querying supply")
+	}
+	supply, err := strconv.ParseUint(sum, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid supply for type %s", tokenType)
+	}
+	return supply, nil
+}
+
+// History returns the most recent transfers, up to limit, that involve owner.
+func (v *View) History(channel string, owner []byte, limit int) ([]*Transfer, error) {
+	rows, err := v.DB.Query(selectHistory, channel, owner, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed querying history")
+	}
+	defer rows.Close()
+
+	transfers := []*Transfer{}
+	for rows.Next() {
+		var (
+			t           Transfer
+			blockNumber int64
+		)
+		err = rows.Scan(&t.TxID, &blockNumber, &t.TxIndex, &t.Action, &t.Timestamp)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed reading transfer")
+		}
+		t.BlockNumber = uint64(blockNumber)
+		transfers = append(transfers, &t)
+	}
+	return transfers, errors.Wrap(rows.Err(), "failed reading history")
+}
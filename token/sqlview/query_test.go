@@ -0,0 +1,154 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sqlview_test
+
+import (
+	"database/sql/driver"
+	"time"
+
+	"github.com/hyperledger/fabric/token/sqlview"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Queries", func() {
+	var (
+		fakeDB *fakeDB
+		view   *sqlview.View
+	)
+
+	BeforeEach(func() {
+		f, sqlDB := newFakeDB(CurrentGinkgoTestDescription().FullTestText)
+		fakeDB = f
+		view = &sqlview.View{DB: sqlDB}
+	})
+
+	Describe("Balances", func() {
+		BeforeEach(func() {
+			fakeDB.rows["SUM(quantity)"] = &fakeRows{
+				columns: []string{"token_type", "sum"},
+				values: [][]driver.Value{
+					{"ABC", []byte("18446744073709551615")},
+					{"PDQ", []byte("40")},
+				},
+			}
+		})
+
+		It("returns the unspent balance per token type", func() {
+			balances, err := view.Balances("testchannel", []byte("alice"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(balances).To(Equal([]*sqlview.Balance{
+				{Type: "ABC", Quantity: 18446744073709551615},
+				{Type: "PDQ", Quantity: 40},
+			}))
+		})
+
+		Context("when the sum is not a valid quantity", func() {
+			BeforeEach(func() {
+				fakeDB.rows["SUM(quantity)"].values = [][]driver.Value{{"PDQ", []byte("-1")}}
+			})
+
+			It("returns an error", func() {
+				_, err := view.Balances("testchannel", []byte("alice"))
+				Expect(err).To(MatchError(ContainSubstring("invalid balance for type PDQ")))
+			})
+		})
+
+		Context("when the query fails", func() {
+			BeforeEach(func() {
+				fakeDB.errs["SUM(quantity)"] = errors.New("boom")
+			})
+
+			It("returns an error", func() {
+				_, err := view.Balances("testchannel", []byte("alice"))
+				Expect(err).To(MatchError("failed querying balances: boom"))
+			})
+		})
+	})
+
+	Describe("UnspentOutputs", func() {
+		BeforeEach(func() {
+			fakeDB.rows["SELECT tx_id, output_index"] = &fakeRows{
+				columns: []string{"tx_id", "output_index", "token_type", "quantity"},
+				values:  [][]driver.Value{{"tx0", int64(1), "PDQ", []byte("40")}},
+			}
+		})
+
+		It("returns the unspent outputs of the owner", func() {
+			outputs, err := view.UnspentOutputs("testchannel", []byte("alice"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(outputs).To(Equal([]*sqlview.Output{{TxID: "tx0", Index: 1, Type: "PDQ", Quantity: 40}}))
+		})
+
+		Context("when the query fails", func() {
+			BeforeEach(func() {
+				fakeDB.errs["SELECT tx_id, output_index"] = errors.New("boom")
+			})
+
+			It("returns an error", func() {
+				_, err := view.UnspentOutputs("testchannel", []byte("alice"))
+				Expect(err).To(MatchError("failed querying unspent outputs: boom"))
+			})
+		})
+	})
+
+	Describe("Supply", func() {
+		It("returns the quantity in circulation", func() {
+			fakeDB.rows["COALESCE(SUM(quantity), 0)"] = &fakeRows{columns: []string{"sum"}, values: [][]driver.Value{{[]byte("140")}}}
+			supply, err := view.Supply("testchannel", "PDQ")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(supply).To(Equal(uint64(140)))
+		})
+
+		Context("when the query fails", func() {
+			BeforeEach(func() {
+				fakeDB.errs["COALESCE(SUM(quantity), 0)"] = errors.New("boom")
+			})
+
+			It("returns an error", func() {
+				_, err := view.Supply("testchannel", "PDQ")
+				Expect(err).To(MatchError("failed querying supply: boom"))
+			})
+		})
+	})
+
+	Describe("History", func() {
+		var ts time.Time
+
+		BeforeEach(func() {
+			ts = time.Unix(1000, 0).UTC()
+			fakeDB.rows["FROM token_transfers"] = &fakeRows{
+				columns: []string{"tx_id", "block_number", "tx_index", "action", "created_at"},
+				values: [][]driver.Value{
+					{"tx1", int64(5), int64(1), "transfer", ts},
+					{"tx0", int64(5), int64(0), "import", nil},
+				},
+			}
+		})
+
+		It("returns the transfers involving the owner", func() {
+			transfers, err := view.History("testchannel", []byte("alice"), 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transfers).To(Equal([]*sqlview.Transfer{
+				{TxID: "tx1", BlockNumber: 5, TxIndex: 1, Action: "transfer", Timestamp: &ts},
+				{TxID: "tx0", BlockNumber: 5, TxIndex: 0, Action: "import"},
+			}))
+		})
+
+		Context("when the query fails", func() {
+			BeforeEach(func() {
+				fakeDB.errs["FROM token_transfers"] = errors.New("boom")
+			})
+
+			It("returns an error", func() {
+				_, err := view.History("testchannel", []byte("alice"), 10)
+				Expect(err).To(MatchError("failed querying history: boom"))
+			})
+		})
+	})
+})
@@ -0,0 +1,80 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sqlview
+
+// schema contains the statements that create the relational view. Quantities
+// are stored as NUMERIC(20,0) because Postgres has no unsigned 64-bit integer.
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS token_sync_checkpoints (
+		channel TEXT PRIMARY KEY,
+		next_block BIGINT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS token_owners (
+		channel TEXT NOT NULL,
+		owner BYTEA NOT NULL,
+		first_block BIGINT NOT NULL,
+		PRIMARY KEY (channel, owner)
+	)`,
+	`CREATE TABLE IF NOT EXISTS token_transfers (
+		channel TEXT NOT NULL,
+		tx_id TEXT NOT NULL,
+		block_number BIGINT NOT NULL,
+		tx_index INTEGER NOT NULL,
+		action TEXT NOT NULL,
+		created_at TIMESTAMPTZ,
+		PRIMARY KEY (channel, tx_id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS token_outputs (
+		channel TEXT NOT NULL,
+		tx_id TEXT NOT NULL,
+		output_index INTEGER NOT NULL,
+		owner BYTEA,
+		token_type TEXT NOT NULL,
+		quantity NUMERIC(20,0) NOT NULL,
+		spent_by TEXT,
+		PRIMARY KEY (channel, tx_id, output_index)
+	)`,
+	`CREATE INDEX IF NOT EXISTS token_outputs_unspent_idx ON token_outputs (channel, owner) WHERE spent_by IS NULL`,
+	`CREATE INDEX IF NOT EXISTS token_outputs_spent_by_idx ON token_outputs (channel, spent_by)`,
+}
+
+const (
+	selectCheckpoint = `SELECT next_block FROM token_sync_checkpoints WHERE channel = $1`
+
+	lockCheckpoint = `SELECT next_block FROM token_sync_checkpoints WHERE channel = $1 FOR UPDATE`
+
+	upsertCheckpoint = `INSERT INTO token_sync_checkpoints (channel, next_block) VALUES ($1, $2)
+		ON CONFLICT (channel) DO UPDATE SET next_block = EXCLUDED.next_block`
+
+	insertTransfer = `INSERT INTO token_transfers (channel, tx_id, block_number, tx_index, action, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT DO NOTHING`
+
+	insertOutput = `INSERT INTO token_outputs (channel, tx_id, output_index, owner, token_type, quantity)
+		VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT DO NOTHING`
+
+	insertOwner = `INSERT INTO token_owners (channel, owner, first_block) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`
+
+	spendOutput = `UPDATE token_outputs SET spent_by = $1 WHERE channel = $2 AND tx_id = $3 AND output_index = $4`
+
+	selectBalances = `SELECT token_type, SUM(quantity) FROM token_outputs
+		WHERE channel = $1 AND owner = $2 AND spent_by IS NULL
+		GROUP BY token_type ORDER BY token_type`
+
+	selectUnspent = `SELECT tx_id, output_index, token_type, quantity FROM token_outputs
+		WHERE channel = $1 AND owner = $2 AND spent_by IS NULL
+		ORDER BY tx_id, output_index`
+
+	selectSupply = `SELECT COALESCE(SUM(quantity), 0) FROM token_outputs
+		WHERE channel = $1 AND token_type = $2 AND owner IS NOT NULL AND spent_by IS NULL`
+
+	selectHistory = `SELECT t.tx_id, t.block_number, t.tx_index, t.action, t.created_at FROM token_transfers t
+		WHERE t.channel = $1 AND EXISTS (
+			SELECT 1 FROM token_outputs o
+			WHERE o.channel = t.channel AND o.owner = $2 AND (o.tx_id = t.tx_id OR o.spent_by = t.tx_id)
+		)
+		ORDER BY t.block_number DESC, t.tx_index DESC LIMIT $3`
+)
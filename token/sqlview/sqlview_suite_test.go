@@ -0,0 +1,58 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sqlview_test
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestSQLView(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SQL View Suite")
+}
+
+func ProtoMarshal(m proto.Message) []byte {
+	bytes, err := proto.Marshal(m)
+	Expect(err).NotTo(HaveOccurred())
+
+	return bytes
+}
+
+func newEnvelope(txType cb.HeaderType, txID string, data proto.Message) *cb.Envelope {
+	chdr := &cb.ChannelHeader{
+		Type:      int32(txType),
+		ChannelId: "testchannel",
+		TxId:      txID,
+		Timestamp: &timestamp.Timestamp{Seconds: 1000},
+	}
+	payload := &cb.Payload{
+		Header: &cb.Header{ChannelHeader: ProtoMarshal(chdr)},
+		Data:   ProtoMarshal(data),
+	}
+	return &cb.Envelope{Payload: ProtoMarshal(payload)}
+}
+
+func newBlock(number uint64, envelopes ...*cb.Envelope) *cb.Block {
+	block := &cb.Block{
+		Header:   &cb.BlockHeader{Number: number},
+		Data:     &cb.BlockData{},
+		Metadata: &cb.BlockMetadata{Metadata: make([][]byte, len(cb.BlockMetadataIndex_name))},
+	}
+	for _, env := range envelopes {
+		block.Data.Data = append(block.Data.Data, ProtoMarshal(env))
+	}
+	block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER] = util.NewTxValidationFlagsSetValue(len(envelopes), pb.TxValidationCode_VALID)
+	return block
+}
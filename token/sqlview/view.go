@@ -0,0 +1,276 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sqlview
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/hyperledger/fabric/token/exporter"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("token.sqlview")
+
+// View maintains a relational copy of the plain token outputs of one or more
+// channels. Every block is applied in a single database transaction together
+// with the channel checkpoint, so a block is reflected in the view exactly once.
+type View struct {
+	DB *sql.DB
+}
+
+// CreateSchema creates the tables and indexes of the view if they do not exist.
+func (v *View) CreateSchema() error {
+	for _, stmt := range schema {
+		_, err := v.DB.Exec(stmt)
+		if err != nil {
+			return errors.Wrap(err, "failed creating schema")
+		}
+	}
+	return nil
+}
+
+// Next returns the number of the next block to apply for the channel.
+func (v *View) Next(channel string) (uint64, error) {
+	var next int64
+	err := v.DB.QueryRow(selectCheckpoint, channel).Scan(&next)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed loading checkpoint for channel %s", channel)
+	}
+	return uint64(next), nil
+}
+
+// ProcessBlock applies the valid token transactions of the block to the view
+// and advances the channel checkpoint. Blocks below the checkpoint are skipped.
+func (v *View) ProcessBlock(channel string, block *cb.Block) error {
+	if block == nil || block.Header == nil {
+		return errors.New("block header is required")
+	}
+
+	txs, err := tokenTransactions(block)
+	if err != nil {
+		return err
+	}
+
+	dbtx, err := v.DB.Begin()
+	if err != nil {
+		return errors.Wrap(err, "failed starting database transaction")
+	}
+	defer dbtx.Rollback()
+
+	var next int64
+	err = dbtx.QueryRow(lockCheckpoint, channel).Scan(&next)
+	if err != nil && err != sql.ErrNoRows {
+		return errors.Wrapf(err, "failed loading checkpoint for channel %s", channel)
+	}
+	if block.Header.Number < uint64(next) {
+		logger.Debugf("[%s] skipping block [%d] already applied", channel, block.Header.Number)
+		return nil
+	}
+
+	for _, tx := range txs {
+		err = applyTransaction(dbtx, channel, block.Header.Number, tx)
+		if err != nil {
+			return errors.WithMessage(err, "failed applying transaction "+tx.txID)
+		}
+	}
+
+	_, err = dbtx.Exec(upsertCheckpoint, channel, int64(block.Header.Number+1))
+	if err != nil {
+		return errors.Wrapf(err, "failed storing checkpoint for channel %s", channel)
+	}
+
+	err = dbtx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "failed committing database transaction")
+	}
+	logger.Debugf("[%s] applied %d token transactions from block [%d]", channel, len(txs), block.Header.Number)
+
+	return nil
+}
+
+// Follow applies the blocks of channel, as they are committed, until the
+// iterator is closed or done is closed. A block that cannot be applied is
+// retried after retryInterval.
+func (v *View) Follow(channel string, provider exporter.BlockIteratorProvider, retryInterval time.Duration, done <-chan struct{}) error {
+	next, err := v.Next(channel)
+	if err != nil {
+		return err
+	}
+
+	iterator, err := provider.GetBlocksIterator(next)
+	if err != nil {
+		return errors.WithMessage(err, "failed creating block iterator")
+	}
+	defer iterator.Close()
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-done:
+			iterator.Close()
+		case <-stop:
+		}
+	}()
+
+	for {
+		result, err := iterator.Next()
+		if err != nil {
+			return errors.WithMessage(err, "failed reading block")
+		}
+		block, ok := result.(*cb.Block)
+		if !ok || block == nil {
+			logger.Infof("[%s] block iterator closed, stopping sync", channel)
+			return nil
+		}
+
+		for {
+			err = v.ProcessBlock(channel, block)
+			if err == nil {
+				break
+			}
+			logger.Warningf("[%s] failed applying block [%d], retrying in %s: %s", channel, block.Header.Number, retryInterval, err)
+			select {
+			case <-done:
+				return nil
+			case <-time.After(retryInterval):
+			}
+		}
+	}
+}
+
+type tokenTransaction struct {
+	txID      string
+	index     int
+	timestamp *time.Time
+	action    *token.PlainTokenAction
+}
+
+// tokenTransactions returns the valid plain token transactions of the block.
+func tokenTransactions(block *cb.Block) ([]*tokenTransaction, error) {
+	var flags util.TxValidationFlags
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(cb.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		flags = util.TxValidationFlags(block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+
+	var txs []*tokenTransaction
+	for i, data := range block.GetData().GetData() {
+		if len(flags) > i && flags.Flag(i) != pb.TxValidationCode_VALID {
+			continue
+		}
+
+		env, err := utils.GetEnvelopeFromBlock(data)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed getting envelope")
+		}
+		payload, err := utils.UnmarshalPayload(env.Payload)
+		if err != nil {
+			return nil, err
+		}
+		if payload.Header == nil {
+			return nil, errors.Errorf("missing header in transaction %d of block %d", i, block.Header.Number)
+		}
+		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil {
+			return nil, err
+		}
+		if cb.HeaderType(chdr.Type) != cb.HeaderType_TOKEN_TRANSACTION {
+			continue
+		}
+
+		ttx := &token.TokenTransaction{}
+		err = proto.Unmarshal(payload.Data, ttx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed unmarshaling transaction [%s]", chdr.TxId)
+		}
+		if ttx.GetPlainAction() == nil {
+			continue
+		}
+
+		tx := &tokenTransaction{txID: chdr.TxId, index: i, action: ttx.GetPlainAction()}
+		if chdr.Timestamp != nil {
+			ts, err := ptypes.Timestamp(chdr.Timestamp)
+			if err != nil {
+				return nil, err
+			}
+			tx.timestamp = &ts
+		}
+		txs = append(txs, tx)
+	}
+
+	return txs, nil
+}
+
+// applyTransaction mirrors the commit logic of the plain token verifier:
+// outputs are indexed by their position in the transaction and inputs are
+// marked as spent by the transaction. Delegated outputs are not tracked
+// because they do not contribute to the balance of their owner.
+func applyTransaction(dbtx *sql.Tx, channel string, blockNumber uint64, tx *tokenTransaction) error {
+	var (
+		action  string
+		inputs  []*token.InputId
+		outputs []*token.PlainOutput
+	)
+	switch a := tx.action.Data.(type) {
+	case *token.PlainTokenAction_PlainImport:
+		action, outputs = "import", a.PlainImport.GetOutputs()
+	case *token.PlainTokenAction_PlainTransfer:
+		action, inputs, outputs = "transfer", a.PlainTransfer.GetInputs(), a.PlainTransfer.GetOutputs()
+	case *token.PlainTokenAction_PlainRedeem:
+		action, inputs, outputs = "redeem", a.PlainRedeem.GetInputs(), a.PlainRedeem.GetOutputs()
+	case *token.PlainTokenAction_PlainApprove:
+		action, inputs = "approve", a.PlainApprove.GetInputs()
+		if a.PlainApprove.GetOutput() != nil {
+			outputs = []*token.PlainOutput{a.PlainApprove.GetOutput()}
+		}
+	default:
+		return nil
+	}
+
+	_, err := dbtx.Exec(insertTransfer, channel, tx.txID, int64(blockNumber), tx.index, action, tx.timestamp)
+	if err != nil {
+		return errors.Wrap(err, "failed inserting transfer")
+	}
+
+	for i, output := range outputs {
+		// redeemed outputs have no owner and are stored with a NULL owner
+		var owner interface{}
+		if len(output.Owner) != 0 {
+			owner = output.Owner
+			_, err = dbtx.Exec(insertOwner, channel, output.Owner, int64(blockNumber))
+			if err != nil {
+				return errors.Wrap(err, "failed inserting owner")
+			}
+		}
+		_, err = dbtx.Exec(insertOutput, channel, tx.txID, i, owner, output.Type, strconv.FormatUint(output.Quantity, 10))
+		if err != nil {
+			return errors.Wrap(err, "failed inserting output")
+		}
+	}
+
+	for _, input := range inputs {
+		_, err = dbtx.Exec(spendOutput, tx.txID, channel, input.TxId, int(input.Index))
+		if err != nil {
+			return errors.Wrap(err, "failed marking input spent")
+		}
+	}
+
+	return nil
+}
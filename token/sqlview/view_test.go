@@ -0,0 +1,241 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sqlview_test
+
+import (
+	"database/sql/driver"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/sqlview"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+type blockIterator struct {
+	blocks chan *cb.Block
+}
+
+func (b *blockIterator) Next() (ledger.QueryResult, error) {
+	block, ok := <-b.blocks
+	if !ok {
+		return nil, nil
+	}
+	return block, nil
+}
+
+func (b *blockIterator) Close() {}
+
+type iteratorProvider struct {
+	iterator *blockIterator
+	start    uint64
+}
+
+func (i *iteratorProvider) GetBlocksIterator(start uint64) (ledger.ResultsIterator, error) {
+	i.start = start
+	return i.iterator, nil
+}
+
+func plainTx(action *token.PlainTokenAction) *token.TokenTransaction {
+	return &token.TokenTransaction{Action: &token.TokenTransaction_PlainAction{PlainAction: action}}
+}
+
+var _ = Describe("View", func() {
+	var (
+		fakeDB *fakeDB
+		view   *sqlview.View
+		block  *cb.Block
+	)
+
+	BeforeEach(func() {
+		f, sqlDB := newFakeDB(CurrentGinkgoTestDescription().FullTestText)
+		fakeDB = f
+		view = &sqlview.View{DB: sqlDB}
+
+		importTx := plainTx(&token.PlainTokenAction{
+			Data: &token.PlainTokenAction_PlainImport{
+				PlainImport: &token.PlainImport{
+					Outputs: []*token.PlainOutput{{Owner: []byte("alice"), Type: "PDQ", Quantity: 100}},
+				},
+			},
+		})
+		transferTx := plainTx(&token.PlainTokenAction{
+			Data: &token.PlainTokenAction_PlainTransfer{
+				PlainTransfer: &token.PlainTransfer{
+					Inputs: []*token.InputId{{TxId: "tx0", Index: 0}},
+					Outputs: []*token.PlainOutput{
+						{Owner: []byte("bob"), Type: "PDQ", Quantity: 60},
+						{Owner: []byte("alice"), Type: "PDQ", Quantity: 40},
+					},
+				},
+			},
+		})
+		redeemTx := plainTx(&token.PlainTokenAction{
+			Data: &token.PlainTokenAction_PlainRedeem{
+				PlainRedeem: &token.PlainTransfer{
+					Inputs:  []*token.InputId{{TxId: "tx1", Index: 0}},
+					Outputs: []*token.PlainOutput{{Type: "PDQ", Quantity: 60}},
+				},
+			},
+		})
+		block = newBlock(5,
+			newEnvelope(cb.HeaderType_TOKEN_TRANSACTION, "tx0", importTx),
+			newEnvelope(cb.HeaderType_TOKEN_TRANSACTION, "tx1", transferTx),
+			newEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx2", &pb.Transaction{}),
+			newEnvelope(cb.HeaderType_TOKEN_TRANSACTION, "tx3", redeemTx),
+			newEnvelope(cb.HeaderType_TOKEN_TRANSACTION, "tx4", importTx),
+		)
+		block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER][4] = uint8(pb.TxValidationCode_MVCC_READ_CONFLICT)
+	})
+
+	Describe("CreateSchema", func() {
+		It("creates the tables", func() {
+			err := view.CreateSchema()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeDB.Execs("CREATE TABLE IF NOT EXISTS")).To(HaveLen(4))
+		})
+
+		Context("when the database fails", func() {
+			BeforeEach(func() {
+				fakeDB.errs["CREATE"] = errors.New("boom")
+			})
+
+			It("returns an error", func() {
+				err := view.CreateSchema()
+				Expect(err).To(MatchError("failed creating schema: boom"))
+			})
+		})
+	})
+
+	Describe("Next", func() {
+		It("returns zero when there is no checkpoint", func() {
+			next, err := view.Next("testchannel")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(next).To(Equal(uint64(0)))
+		})
+
+		It("returns the stored checkpoint", func() {
+			fakeDB.rows["FROM token_sync_checkpoints"] = &fakeRows{columns: []string{"next_block"}, values: [][]driver.Value{{int64(9)}}}
+			next, err := view.Next("testchannel")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(next).To(Equal(uint64(9)))
+		})
+	})
+
+	Describe("ProcessBlock", func() {
+		It("applies the valid token transactions in a single database transaction", func() {
+			err := view.ProcessBlock("testchannel", block)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeDB.commits).To(Equal(1))
+
+			transfers := fakeDB.Execs("INSERT INTO token_transfers")
+			Expect(transfers).To(HaveLen(3))
+			Expect(transfers[0].args[:5]).To(Equal([]driver.Value{"testchannel", "tx0", int64(5), int64(0), "import"}))
+			Expect(transfers[0].args[5]).To(Equal(time.Unix(1000, 0).UTC()))
+			Expect(transfers[1].args[1:5]).To(Equal([]driver.Value{"tx1", int64(5), int64(1), "transfer"}))
+			Expect(transfers[2].args[1:5]).To(Equal([]driver.Value{"tx3", int64(5), int64(3), "redeem"}))
+
+			outputs := fakeDB.Execs("INSERT INTO token_outputs")
+			Expect(outputs).To(HaveLen(4))
+			Expect(outputs[0].args).To(Equal([]driver.Value{"testchannel", "tx0", int64(0), []byte("alice"), "PDQ", "100"}))
+			Expect(outputs[1].args).To(Equal([]driver.Value{"testchannel", "tx1", int64(0), []byte("bob"), "PDQ", "60"}))
+			Expect(outputs[2].args).To(Equal([]driver.Value{"testchannel", "tx1", int64(1), []byte("alice"), "PDQ", "40"}))
+			Expect(outputs[3].args).To(Equal([]driver.Value{"testchannel", "tx3", int64(0), nil, "PDQ", "60"}))
+
+			Expect(fakeDB.Execs("INSERT INTO token_owners")).To(HaveLen(3))
+
+			spent := fakeDB.Execs("UPDATE token_outputs SET spent_by")
+			Expect(spent).To(HaveLen(2))
+			Expect(spent[0].args).To(Equal([]driver.Value{"tx1", "testchannel", "tx0", int64(0)}))
+			Expect(spent[1].args).To(Equal([]driver.Value{"tx3", "testchannel", "tx1", int64(0)}))
+
+			checkpoints := fakeDB.Execs("INSERT INTO token_sync_checkpoints")
+			Expect(checkpoints).To(HaveLen(1))
+			Expect(checkpoints[0].args).To(Equal([]driver.Value{"testchannel", int64(6)}))
+		})
+
+		Context("when the block has already been applied", func() {
+			BeforeEach(func() {
+				fakeDB.rows["FROM token_sync_checkpoints"] = &fakeRows{columns: []string{"next_block"}, values: [][]driver.Value{{int64(6)}}}
+			})
+
+			It("skips the block", func() {
+				err := view.ProcessBlock("testchannel", block)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeDB.Execs("INSERT")).To(BeEmpty())
+				Expect(fakeDB.commits).To(Equal(0))
+				Expect(fakeDB.rollbacks).To(Equal(1))
+			})
+		})
+
+		Context("when a statement fails", func() {
+			BeforeEach(func() {
+				fakeDB.errs["UPDATE token_outputs"] = errors.New("boom")
+			})
+
+			It("rolls back and does not advance the checkpoint", func() {
+				err := view.ProcessBlock("testchannel", block)
+				Expect(err).To(MatchError("failed applying transaction tx1: failed marking input spent: boom"))
+				Expect(fakeDB.Execs("INSERT INTO token_sync_checkpoints")).To(BeEmpty())
+				Expect(fakeDB.commits).To(Equal(0))
+				Expect(fakeDB.rollbacks).To(Equal(1))
+			})
+		})
+
+		Context("when the block contains an invalid envelope", func() {
+			BeforeEach(func() {
+				block.Data.Data[1] = []byte("garbage")
+			})
+
+			It("returns an error", func() {
+				err := view.ProcessBlock("testchannel", block)
+				Expect(err).To(MatchError(ContainSubstring("failed getting envelope")))
+			})
+		})
+
+		Context("when the block has no header", func() {
+			It("returns an error", func() {
+				err := view.ProcessBlock("testchannel", &cb.Block{})
+				Expect(err).To(MatchError("block header is required"))
+			})
+		})
+	})
+
+	Describe("Follow", func() {
+		var provider *iteratorProvider
+
+		BeforeEach(func() {
+			provider = &iteratorProvider{iterator: &blockIterator{blocks: make(chan *cb.Block, 1)}}
+			fakeDB.rows["FROM token_sync_checkpoints"] = &fakeRows{columns: []string{"next_block"}, values: [][]driver.Value{{int64(5)}}}
+		})
+
+		It("starts from the checkpoint and retries failed blocks", func() {
+			fakeDB.commitErrs = []error{errors.New("unavailable")}
+			provider.iterator.blocks <- block
+			close(provider.iterator.blocks)
+
+			err := view.Follow("testchannel", provider, time.Millisecond, make(chan struct{}))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(provider.start).To(Equal(uint64(5)))
+			Expect(fakeDB.commits).To(Equal(2))
+		})
+
+		It("stops when done is closed while retrying", func() {
+			fakeDB.errs["UPDATE token_outputs"] = errors.New("unavailable")
+			provider.iterator.blocks <- block
+			done := make(chan struct{})
+			close(done)
+
+			err := view.Follow("testchannel", provider, time.Hour, done)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
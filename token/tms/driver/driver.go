@@ -0,0 +1,117 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package driver
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/token/identity"
+	"github.com/hyperledger/fabric/token/transaction"
+	"github.com/pkg/errors"
+)
+
+// DefaultDriver is the name of the driver of the channels whose config
+// selects none.
+const DefaultDriver = "plain"
+
+// Dependencies are the services of the peer available to token drivers.
+type Dependencies struct {
+	// IdentityDeserializerManager provides the deserializers of the
+	// identities of the members of channels
+	IdentityDeserializerManager identity.DeserializerManager
+	// ApplicationConfig returns the application config of a channel and
+	// whether it exists
+	ApplicationConfig func(channel string) (channelconfig.Application, bool)
+}
+
+// A Factory creates the TMSManager of a token driver.
+type Factory func(deps *Dependencies) (transaction.TMSManager, error)
+
+// Registry holds the factories of token drivers by name, and the TMSManagers
+// they created.
+type Registry struct {
+	mutex     sync.Mutex
+	factories map[string]Factory
+	managers  map[string]transaction.TMSManager
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		factories: map[string]Factory{},
+		managers:  map[string]transaction.TMSManager{},
+	}
+}
+
+// Register registers the factory of a driver under name.
+func (r *Registry) Register(name string, factory Factory) error {
+	if name == "" {
+		return errors.New("token driver name is empty")
+	}
+	if factory == nil {
+		return errors.Errorf("token driver '%s' has no factory", name)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.factories[name]; ok {
+		return errors.Errorf("token driver '%s' is already registered", name)
+	}
+	r.factories[name] = factory
+	return nil
+}
+
+// Drivers returns the sorted names of the registered drivers.
+func (r *Registry) Drivers() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TMSManager returns the TMSManager of a driver, created by its factory the
+// first time it is requested.
+func (r *Registry) TMSManager(name string, deps *Dependencies) (transaction.TMSManager, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if manager, ok := r.managers[name]; ok {
+		return manager, nil
+	}
+	factory, ok := r.factories[name]
+	if !ok {
+		return nil, errors.Errorf("token driver '%s' is not available on this peer", name)
+	}
+	manager, err := factory(deps)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed creating token driver '%s'", name))
+	}
+	r.managers[name] = manager
+	return manager, nil
+}
+
+var defaultRegistry = NewRegistry()
+
+// Default returns the registry of the drivers of the peer.
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// Register registers a driver compiled in the peer, and panics if a driver
+// is already registered under name. Drivers call it from the init function
+// of their package, which the peer imports by default or with build tags.
+func Register(name string, factory Factory) {
+	if err := defaultRegistry.Register(name, factory); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package driver_test
+
+import (
+	"github.com/hyperledger/fabric/token/tms/driver"
+	"github.com/hyperledger/fabric/token/transaction"
+	"github.com/hyperledger/fabric/token/transaction/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Registry", func() {
+	var (
+		registry       *driver.Registry
+		fakeTMSManager *mock.TMSManager
+		deps           *driver.Dependencies
+		created        []*driver.Dependencies
+	)
+
+	BeforeEach(func() {
+		registry = driver.NewRegistry()
+		fakeTMSManager = &mock.TMSManager{}
+		deps = &driver.Dependencies{}
+		created = nil
+		err := registry.Register("zk", func(deps *driver.Dependencies) (transaction.TMSManager, error) {
+			created = append(created, deps)
+			return fakeTMSManager, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("creates the TMSManager of a driver once", func() {
+		manager, err := registry.TMSManager("zk", deps)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(manager).To(BeIdenticalTo(fakeTMSManager))
+
+		manager, err = registry.TMSManager("zk", deps)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(manager).To(BeIdenticalTo(fakeTMSManager))
+		Expect(created).To(Equal([]*driver.Dependencies{deps}))
+	})
+
+	It("lists the registered drivers", func() {
+		Expect(registry.Register("audited", func(*driver.Dependencies) (transaction.TMSManager, error) { return nil, nil })).To(Succeed())
+		Expect(registry.Drivers()).To(Equal([]string{"audited", "zk"}))
+	})
+
+	Context("when a driver is already registered under the name", func() {
+		It("returns an error", func() {
+			err := registry.Register("zk", func(*driver.Dependencies) (transaction.TMSManager, error) { return nil, nil })
+			Expect(err).To(MatchError("token driver 'zk' is already registered"))
+		})
+	})
+
+	Context("when the name is empty", func() {
+		It("returns an error", func() {
+			err := registry.Register("", func(*driver.Dependencies) (transaction.TMSManager, error) { return nil, nil })
+			Expect(err).To(MatchError("token driver name is empty"))
+		})
+	})
+
+	Context("when the factory is nil", func() {
+		It("returns an error", func() {
+			Expect(registry.Register("audited", nil)).To(MatchError("token driver 'audited' has no factory"))
+		})
+	})
+
+	Context("when the driver is not registered", func() {
+		It("returns an error", func() {
+			_, err := registry.TMSManager("audited", deps)
+			Expect(err).To(MatchError("token driver 'audited' is not available on this peer"))
+		})
+	})
+
+	Context("when the factory fails", func() {
+		BeforeEach(func() {
+			err := registry.Register("audited", func(*driver.Dependencies) (transaction.TMSManager, error) {
+				return nil, errors.New("no auditor")
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns an error", func() {
+			_, err := registry.TMSManager("audited", deps)
+			Expect(err).To(MatchError("failed creating token driver 'audited': no auditor"))
+		})
+	})
+
+	Context("when a plugin cannot be opened", func() {
+		It("returns an error", func() {
+			err := registry.LoadPlugin("audited", "/nonexistent/audited.so")
+			Expect(err).To(MatchError(ContainSubstring("failed opening token driver plugin at /nonexistent/audited.so")))
+			Expect(registry.Drivers()).To(Equal([]string{"zk"}))
+		})
+	})
+})
@@ -0,0 +1,55 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package frozen implements the frozen token driver, a reference driver for
+// the channels whose tokens may no longer move, e.g. while they migrate to
+// another driver: every token transaction is invalid, and the tokens on the
+// ledger remain as they are.
+//
+// The peer compiles the driver in with the tokendriver_frozen build tag.
+package frozen
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/identity"
+	"github.com/hyperledger/fabric/token/ledger"
+	"github.com/hyperledger/fabric/token/tms/driver"
+	"github.com/hyperledger/fabric/token/transaction"
+)
+
+// Name is the name of the frozen driver in channel configs.
+const Name = "frozen"
+
+func init() {
+	driver.Register(Name, NewTokenDriver)
+}
+
+// NewTokenDriver creates the Manager of the frozen driver.
+func NewTokenDriver(deps *driver.Dependencies) (transaction.TMSManager, error) {
+	return &Manager{}, nil
+}
+
+// Manager is the TMSManager of the frozen driver.
+type Manager struct{}
+
+// GetTxProcessor returns a TxProcessor rejecting the token transactions of
+// the channel.
+func (m *Manager) GetTxProcessor(channel string) (transaction.TMSTxProcessor, error) {
+	return &TxProcessor{Channel: channel}, nil
+}
+
+// TxProcessor is a TMSTxProcessor rejecting every token transaction.
+type TxProcessor struct {
+	Channel string
+}
+
+// ProcessTx returns an InvalidTxError.
+func (p *TxProcessor) ProcessTx(txID string, creator identity.PublicInfo, ttx *token.TokenTransaction, simulator ledger.LedgerWriter) error {
+	return &customtx.InvalidTxError{Msg: fmt.Sprintf("transaction %s rejected: the tokens of channel %s are frozen", txID, p.Channel)}
+}
@@ -0,0 +1,35 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package frozen_test
+
+import (
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/ledger/mock"
+	"github.com/hyperledger/fabric/token/tms/driver"
+	"github.com/hyperledger/fabric/token/tms/driver/frozen"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Frozen driver", func() {
+	It("registers itself", func() {
+		Expect(driver.Default().Drivers()).To(ContainElement(frozen.Name))
+	})
+
+	It("rejects every token transaction", func() {
+		manager, err := driver.Default().TMSManager(frozen.Name, &driver.Dependencies{})
+		Expect(err).NotTo(HaveOccurred())
+		processor, err := manager.GetTxProcessor("ch0")
+		Expect(err).NotTo(HaveOccurred())
+
+		writer := &mock.LedgerWriter{}
+		err = processor.ProcessTx("tx0", nil, &token.TokenTransaction{}, writer)
+		Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "transaction tx0 rejected: the tokens of channel ch0 are frozen"}))
+		Expect(writer.Invocations()).To(BeEmpty())
+	})
+})
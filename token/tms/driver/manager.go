@@ -0,0 +1,36 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package driver
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/token/transaction"
+	"github.com/pkg/errors"
+)
+
+// Manager is a TMSManager that processes the token transactions of each
+// channel with the driver selected by the TokenDriver value of the channel
+// config, or the DefaultDriver.
+type Manager struct {
+	Registry     *Registry
+	Dependencies *Dependencies
+}
+
+// GetTxProcessor returns the TMSTxProcessor of the driver of the channel.
+func (m *Manager) GetTxProcessor(channel string) (transaction.TMSTxProcessor, error) {
+	name := DefaultDriver
+	if ac, ok := m.Dependencies.ApplicationConfig(channel); ok && ac.TokenDriver() != "" {
+		name = ac.TokenDriver()
+	}
+
+	manager, err := m.Registry.TMSManager(name, m.Dependencies)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed getting token driver for channel '%s'", channel))
+	}
+	return manager.GetTxProcessor(channel)
+}
@@ -0,0 +1,95 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package driver_test
+
+import (
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/token/tms/driver"
+	"github.com/hyperledger/fabric/token/transaction"
+	"github.com/hyperledger/fabric/token/transaction/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manager", func() {
+	var (
+		application    *config.MockApplication
+		exists         bool
+		plainProcessor *mock.TMSTxProcessor
+		zkProcessor    *mock.TMSTxProcessor
+		zkManager      *mock.TMSManager
+		manager        *driver.Manager
+	)
+
+	BeforeEach(func() {
+		application = &config.MockApplication{}
+		exists = true
+
+		plainProcessor = &mock.TMSTxProcessor{}
+		plainManager := &mock.TMSManager{}
+		plainManager.GetTxProcessorReturns(plainProcessor, nil)
+		zkProcessor = &mock.TMSTxProcessor{}
+		zkManager = &mock.TMSManager{}
+		zkManager.GetTxProcessorReturns(zkProcessor, nil)
+
+		registry := driver.NewRegistry()
+		Expect(registry.Register(driver.DefaultDriver, func(*driver.Dependencies) (transaction.TMSManager, error) { return plainManager, nil })).To(Succeed())
+		Expect(registry.Register("zk", func(*driver.Dependencies) (transaction.TMSManager, error) { return zkManager, nil })).To(Succeed())
+
+		manager = &driver.Manager{
+			Registry: registry,
+			Dependencies: &driver.Dependencies{
+				ApplicationConfig: func(channel string) (channelconfig.Application, bool) {
+					return application, exists
+				},
+			},
+		}
+	})
+
+	It("uses the default driver", func() {
+		processor, err := manager.GetTxProcessor("ch0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(processor).To(BeIdenticalTo(plainProcessor))
+	})
+
+	Context("when the channel config selects a driver", func() {
+		BeforeEach(func() {
+			application.TokenDriverRv = "zk"
+		})
+
+		It("uses the driver of the channel", func() {
+			processor, err := manager.GetTxProcessor("ch0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(processor).To(BeIdenticalTo(zkProcessor))
+			Expect(zkManager.GetTxProcessorArgsForCall(0)).To(Equal("ch0"))
+		})
+	})
+
+	Context("when the channel does not exist", func() {
+		BeforeEach(func() {
+			exists = false
+		})
+
+		It("uses the default driver", func() {
+			processor, err := manager.GetTxProcessor("ch0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(processor).To(BeIdenticalTo(plainProcessor))
+		})
+	})
+
+	Context("when the driver of the channel is not available", func() {
+		BeforeEach(func() {
+			application.TokenDriverRv = "audited"
+		})
+
+		It("returns an error", func() {
+			_, err := manager.GetTxProcessor("ch0")
+			Expect(err).To(MatchError("failed getting token driver for channel 'ch0': token driver 'audited' is not available on this peer"))
+		})
+	})
+})
@@ -0,0 +1,37 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package driver
+
+import (
+	"plugin"
+
+	"github.com/hyperledger/fabric/token/transaction"
+	"github.com/pkg/errors"
+)
+
+// pluginFactory is the name of the factory function exported by the plugins
+// of token drivers.
+const pluginFactory = "NewTokenDriver"
+
+// LoadPlugin registers under name the driver of the Go plugin at path. The
+// plugin exports a NewTokenDriver function with the signature of a Factory,
+// and must be built against the same fabric sources as the peer.
+func (r *Registry) LoadPlugin(name, path string) error {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed opening token driver plugin at %s", path)
+	}
+	symbol, err := p.Lookup(pluginFactory)
+	if err != nil {
+		return errors.Wrapf(err, "token driver plugin at %s does not export %s", path, pluginFactory)
+	}
+	factory, ok := symbol.(func(*Dependencies) (transaction.TMSManager, error))
+	if !ok {
+		return errors.Errorf("%s of token driver plugin at %s has type %T, not a driver factory", pluginFactory, path, symbol)
+	}
+	return r.Register(name, factory)
+}
@@ -0,0 +1,33 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package manager
+
+import (
+	"github.com/hyperledger/fabric/token/tms/driver"
+	"github.com/hyperledger/fabric/token/transaction"
+)
+
+func init() {
+	driver.Register(driver.DefaultDriver, NewPlainDriver)
+}
+
+// NewPlainDriver creates the Manager of the plain token driver, configured by
+// the application config of the channels.
+func NewPlainDriver(deps *driver.Dependencies) (transaction.TMSManager, error) {
+	return &Manager{
+		IdentityDeserializerManager: deps.IdentityDeserializerManager,
+		SupplyLimitsProvider: &ChannelConfigSupplyLimitsProvider{
+			ApplicationConfig: deps.ApplicationConfig,
+		},
+		TypeNamespacesProvider: &ChannelConfigTypeNamespacesProvider{
+			ApplicationConfig: deps.ApplicationConfig,
+		},
+		MetadataSchemasProvider: &ChannelConfigMetadataSchemasProvider{
+			ApplicationConfig: deps.ApplicationConfig,
+		},
+	}, nil
+}
@@ -0,0 +1,43 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package manager_test
+
+import (
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/token/identity/mock"
+	"github.com/hyperledger/fabric/token/tms/driver"
+	"github.com/hyperledger/fabric/token/tms/manager"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Plain driver", func() {
+	It("is registered as the default driver", func() {
+		Expect(driver.Default().Drivers()).To(ContainElement(driver.DefaultDriver))
+	})
+
+	It("creates a Manager configured by the channel config", func() {
+		deserializerManager := &mock.DeserializerManager{}
+		applicationConfig := func(channel string) (channelconfig.Application, bool) {
+			return &config.MockApplication{}, true
+		}
+
+		tms, err := manager.NewPlainDriver(&driver.Dependencies{
+			IdentityDeserializerManager: deserializerManager,
+			ApplicationConfig:           applicationConfig,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tms).To(BeAssignableToTypeOf(&manager.Manager{}))
+
+		m := tms.(*manager.Manager)
+		Expect(m.IdentityDeserializerManager).To(BeIdenticalTo(deserializerManager))
+		Expect(m.SupplyLimitsProvider).To(BeAssignableToTypeOf(&manager.ChannelConfigSupplyLimitsProvider{}))
+		Expect(m.TypeNamespacesProvider).To(BeAssignableToTypeOf(&manager.ChannelConfigTypeNamespacesProvider{}))
+		Expect(m.MetadataSchemasProvider).To(BeAssignableToTypeOf(&manager.ChannelConfigMetadataSchemasProvider{}))
+	})
+})
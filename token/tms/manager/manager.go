@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package manager
 
 import (
+	"fmt"
+
 	"github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/token/identity"
 	"github.com/hyperledger/fabric/token/tms/plain"
@@ -32,6 +34,15 @@ func (*FabricIdentityDeserializerManager) Deserializer(channel string) (identity
 // Manager is used to access TMS components.
 type Manager struct {
 	IdentityDeserializerManager identity.DeserializerManager
+	// SupplyLimitsProvider provides the limits on the issuance of tokens;
+	// when nil, issuance is not limited.
+	SupplyLimitsProvider SupplyLimitsProvider
+	// TypeNamespacesProvider provides the issuance policies of the namespaces
+	// of token types; when nil, all members of a channel can issue every type.
+	TypeNamespacesProvider TypeNamespacesProvider
+	// MetadataSchemasProvider provides the schemas of the metadata of token
+	// outputs; when nil, outputs carry no metadata.
+	MetadataSchemasProvider MetadataSchemasProvider
 }
 
 // GetTxProcessor returns a TMSTxProcessor that is used to process token transactions.
@@ -41,5 +52,38 @@ func (m *Manager) GetTxProcessor(channel string) (transaction.TMSTxProcessor, er
 		return nil, errors.Wrapf(err, "failed getting identity deserialiser manager for channel '%s'", channel)
 	}
 
-	return &plain.Verifier{IssuingValidator: &AllIssuingValidator{Deserializer: identityDeserializerManager}}, nil
+	var issuingValidator identity.IssuingValidator = &AllIssuingValidator{Deserializer: identityDeserializerManager}
+	if m.TypeNamespacesProvider != nil {
+		namespaces, err := m.TypeNamespacesProvider.TypeNamespaces(channel)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("failed getting token type namespaces for channel '%s'", channel))
+		}
+		if len(namespaces) > 0 {
+			issuingValidator = &NamespaceIssuingValidator{
+				Namespaces:   namespaces,
+				Deserializer: identityDeserializerManager,
+				Default:      issuingValidator,
+			}
+		}
+	}
+
+	verifier := &plain.Verifier{
+		IssuingValidator:    issuingValidator,
+		GovernanceValidator: &AdminGovernanceValidator{Deserializer: identityDeserializerManager},
+		Deserializer:        identityDeserializerManager,
+		Channel:             channel,
+	}
+	if m.SupplyLimitsProvider != nil {
+		verifier.SupplyLimits, err = m.SupplyLimitsProvider.SupplyLimits(channel)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("failed getting token supply limits for channel '%s'", channel))
+		}
+	}
+	if m.MetadataSchemasProvider != nil {
+		verifier.MetadataSchemas, err = m.MetadataSchemasProvider.MetadataSchemas(channel)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("failed getting token metadata schemas for channel '%s'", channel))
+		}
+	}
+	return verifier, nil
 }
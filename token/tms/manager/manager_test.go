@@ -7,6 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package manager_test
 
 import (
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/mocks/config"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/token/identity/mock"
 	"github.com/hyperledger/fabric/token/tms/manager"
 	"github.com/hyperledger/fabric/token/tms/plain"
@@ -52,7 +55,12 @@ var _ = Describe("Manager", func() {
 				txProcessor, err := mgm.GetTxProcessor(channel)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(txProcessor).NotTo(BeNil())
-				Expect(txProcessor).To(Equal(&plain.Verifier{IssuingValidator: &manager.AllIssuingValidator{Deserializer: fakeIdentityDeserializer}}))
+				Expect(txProcessor).To(Equal(&plain.Verifier{
+					IssuingValidator:    &manager.AllIssuingValidator{Deserializer: fakeIdentityDeserializer},
+					GovernanceValidator: &manager.AdminGovernanceValidator{Deserializer: fakeIdentityDeserializer},
+					Deserializer:        fakeIdentityDeserializer,
+					Channel:             channel,
+				}))
 			})
 		})
 	})
@@ -72,3 +80,73 @@ var _ = Describe("FabricIdentityDeserializerManager", func() {
 		})
 	})
 })
+
+var _ = Describe("ChannelConfigSupplyLimitsProvider", func() {
+	var (
+		application *config.MockApplication
+		exists      bool
+		provider    *manager.ChannelConfigSupplyLimitsProvider
+	)
+
+	BeforeEach(func() {
+		application = &config.MockApplication{
+			TokenSupplyRv: map[string]*pb.TokenSupplyLimit{
+				"XYZ": {MaxTotalSupply: 100},
+				"PDQ": {MaxPerPeriod: 10, PeriodBlocks: 100},
+			},
+		}
+		exists = true
+		provider = &manager.ChannelConfigSupplyLimitsProvider{
+			ApplicationConfig: func(channel string) (channelconfig.Application, bool) {
+				return application, exists
+			},
+		}
+	})
+
+	It("returns the limits from the channel config", func() {
+		limits, err := provider.SupplyLimits("ch0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(limits).To(Equal(map[string]*plain.SupplyLimit{
+			"XYZ": {MaxTotalSupply: 100},
+			"PDQ": {MaxPerPeriod: 10, PeriodBlocks: 100},
+		}))
+	})
+
+	Context("when the channel has no application config", func() {
+		BeforeEach(func() {
+			exists = false
+		})
+
+		It("returns no limits", func() {
+			limits, err := provider.SupplyLimits("ch0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(limits).To(BeNil())
+		})
+	})
+
+	Context("when a period is invalid", func() {
+		BeforeEach(func() {
+			application.TokenSupplyRv["PDQ"].PeriodBlocks = 0
+		})
+
+		It("returns an error", func() {
+			_, err := provider.SupplyLimits("ch0")
+			Expect(err).To(MatchError(ContainSubstring("invalid issuance period for token type 'PDQ'")))
+		})
+	})
+
+	Context("when the manager has a provider", func() {
+		It("configures the verifier with the limits", func() {
+			fakeIdentityDeserializerManager := &mock.DeserializerManager{}
+			fakeIdentityDeserializerManager.DeserializerReturns(&mock.Deserializer{}, nil)
+			mgm := &manager.Manager{
+				IdentityDeserializerManager: fakeIdentityDeserializerManager,
+				SupplyLimitsProvider:        provider,
+			}
+
+			txProcessor, err := mgm.GetTxProcessor("ch0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(txProcessor.(*plain.Verifier).SupplyLimits).To(HaveKey("XYZ"))
+		})
+	})
+})
@@ -0,0 +1,56 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package manager
+
+import (
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/token/tms/plain"
+)
+
+// MetadataSchemasProvider returns the schemas of the metadata of the token
+// outputs of a channel, by token type.
+type MetadataSchemasProvider interface {
+	MetadataSchemas(channel string) (map[string]*plain.MetadataSchema, error)
+}
+
+// ChannelConfigMetadataSchemasProvider implements a MetadataSchemasProvider on
+// top of the TokenMetadataSchemas value of the application config of channels.
+type ChannelConfigMetadataSchemasProvider struct {
+	// ApplicationConfig returns the application config of a channel and
+	// whether it exists.
+	ApplicationConfig func(channel string) (channelconfig.Application, bool)
+}
+
+func (c *ChannelConfigMetadataSchemasProvider) MetadataSchemas(channel string) (map[string]*plain.MetadataSchema, error) {
+	ac, ok := c.ApplicationConfig(channel)
+	if !ok {
+		return nil, nil
+	}
+
+	var schemas map[string]*plain.MetadataSchema
+	for tokenType, s := range ac.TokenMetadataSchemas() {
+		if s == nil {
+			continue
+		}
+		schema := &plain.MetadataSchema{
+			Fields:        map[string]*plain.MetadataField{},
+			Respecifiable: s.Respecifiable,
+			MaxSize:       int(s.MaxSize),
+		}
+		for name, f := range s.Fields {
+			if f == nil {
+				continue
+			}
+			schema.Fields[name] = &plain.MetadataField{Type: f.Type, Required: f.Required}
+		}
+		if schemas == nil {
+			schemas = map[string]*plain.MetadataSchema{}
+		}
+		schemas[tokenType] = schema
+	}
+	return schemas, nil
+}
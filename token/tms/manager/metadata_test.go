@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package manager_test
+
+import (
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/mocks/config"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/token/identity/mock"
+	"github.com/hyperledger/fabric/token/tms/manager"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ChannelConfigMetadataSchemasProvider", func() {
+	var (
+		application *config.MockApplication
+		exists      bool
+		provider    *manager.ChannelConfigMetadataSchemasProvider
+	)
+
+	BeforeEach(func() {
+		application = &config.MockApplication{
+			TokenMetadataSchemasRv: map[string]*pb.TokenMetadataSchema{
+				"BOND": {
+					Fields:        map[string]*pb.TokenMetadataField{"maturity": {Type: "date", Required: true}},
+					Respecifiable: true,
+					MaxSize:       256,
+				},
+			},
+		}
+		exists = true
+		provider = &manager.ChannelConfigMetadataSchemasProvider{
+			ApplicationConfig: func(channel string) (channelconfig.Application, bool) {
+				return application, exists
+			},
+		}
+	})
+
+	It("returns the schemas from the channel config", func() {
+		schemas, err := provider.MetadataSchemas("ch0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(schemas).To(Equal(map[string]*plain.MetadataSchema{
+			"BOND": {
+				Fields:        map[string]*plain.MetadataField{"maturity": {Type: plain.MetadataDate, Required: true}},
+				Respecifiable: true,
+				MaxSize:       256,
+			},
+		}))
+	})
+
+	Context("when the channel has no application config", func() {
+		BeforeEach(func() {
+			exists = false
+		})
+
+		It("returns no schemas", func() {
+			schemas, err := provider.MetadataSchemas("ch0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schemas).To(BeNil())
+		})
+	})
+
+	Context("when the manager has a provider", func() {
+		It("configures the verifier with the schemas", func() {
+			fakeIdentityDeserializerManager := &mock.DeserializerManager{}
+			fakeIdentityDeserializerManager.DeserializerReturns(&mock.Deserializer{}, nil)
+			mgm := &manager.Manager{
+				IdentityDeserializerManager: fakeIdentityDeserializerManager,
+				MetadataSchemasProvider:     provider,
+			}
+
+			txProcessor, err := mgm.GetTxProcessor("ch0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(txProcessor.(*plain.Verifier).MetadataSchemas).To(HaveKey("BOND"))
+		})
+	})
+})
@@ -0,0 +1,100 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package manager
+
+import (
+	"strings"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/token/identity"
+	"github.com/pkg/errors"
+)
+
+// TypeNamespacesProvider returns the issuance policies of the hierarchical
+// token types of a channel, by namespace prefix.
+type TypeNamespacesProvider interface {
+	TypeNamespaces(channel string) (map[string]*pb.TokenTypeNamespace, error)
+}
+
+// ChannelConfigTypeNamespacesProvider implements a TypeNamespacesProvider on
+// top of the TokenTypeNamespaces value of the application config of channels.
+type ChannelConfigTypeNamespacesProvider struct {
+	// ApplicationConfig returns the application config of a channel and
+	// whether it exists.
+	ApplicationConfig func(channel string) (channelconfig.Application, bool)
+}
+
+func (c *ChannelConfigTypeNamespacesProvider) TypeNamespaces(channel string) (map[string]*pb.TokenTypeNamespace, error) {
+	ac, ok := c.ApplicationConfig(channel)
+	if !ok {
+		return nil, nil
+	}
+	return ac.TokenTypeNamespaces(), nil
+}
+
+// NamespaceIssuingValidator allows only the members of the issuer orgs of a
+// namespace, or their admins, to issue the token types in the namespace, e.g.
+// "bank1/USD" and "bank1/EUR" in the namespace "bank1". The token types out of
+// every namespace are validated by Default.
+type NamespaceIssuingValidator struct {
+	// Namespaces are the issuance policies of the namespaces, by prefix
+	Namespaces   map[string]*pb.TokenTypeNamespace
+	Deserializer identity.Deserializer
+	Default      identity.IssuingValidator
+}
+
+// Validate returns no error if the passed creator can issue tokens of the passed type, an error otherwise.
+func (v *NamespaceIssuingValidator) Validate(creator identity.PublicInfo, tokenType string) error {
+	prefix, namespace := TypeNamespace(v.Namespaces, tokenType)
+	if namespace == nil {
+		return v.Default.Validate(creator, tokenType)
+	}
+
+	identity, err := v.Deserializer.DeserializeIdentity(creator.Public())
+	if err != nil {
+		return errors.Wrapf(err, "identity [0x%x] cannot be deserialised", creator.Public())
+	}
+
+	if err := identity.Validate(); err != nil {
+		return errors.Wrapf(err, "identity [0x%x] cannot be validated", creator.Public())
+	}
+
+	if !contains(namespace.Issuers, identity.GetMSPIdentifier()) {
+		return errors.Errorf("identity [0x%x] of org '%s' cannot issue token type '%s' of namespace '%s'", creator.Public(), identity.GetMSPIdentifier(), tokenType, prefix)
+	}
+	if namespace.AdminsOnly {
+		return checkAdmin(identity, creator)
+	}
+	return nil
+}
+
+// TypeNamespace returns the longest namespace of the token type and its
+// issuance policy, or nil if the type is in no namespace. A namespace contains
+// the token type named after it and the types prefixed by it and a '/'.
+func TypeNamespace(namespaces map[string]*pb.TokenTypeNamespace, tokenType string) (string, *pb.TokenTypeNamespace) {
+	prefix := tokenType
+	for {
+		if namespace, ok := namespaces[prefix]; ok && namespace != nil {
+			return prefix, namespace
+		}
+		i := strings.LastIndex(prefix, "/")
+		if i < 0 {
+			return "", nil
+		}
+		prefix = prefix[:i]
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,206 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package manager_test
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/token/identity/mock"
+	"github.com/hyperledger/fabric/token/tms/manager"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("NamespaceIssuingValidator", func() {
+	var (
+		fakeCreatorInfo          *mock.PublicInfo
+		fakeIdentityDeserializer *mock.Deserializer
+		fakeIdentity             *mock.Identity
+		fakeDefault              *mock.IssuingValidator
+		issuingValidator         *manager.NamespaceIssuingValidator
+	)
+
+	BeforeEach(func() {
+		fakeCreatorInfo = &mock.PublicInfo{}
+		fakeCreatorInfo.PublicReturns([]byte{1, 2, 3})
+		fakeIdentity = &mock.Identity{}
+		fakeIdentity.GetMSPIdentifierReturns("Org1MSP")
+		fakeIdentityDeserializer = &mock.Deserializer{}
+		fakeIdentityDeserializer.DeserializeIdentityReturns(fakeIdentity, nil)
+		fakeDefault = &mock.IssuingValidator{}
+		fakeDefault.ValidateReturns(errors.New("default policy"))
+
+		issuingValidator = &manager.NamespaceIssuingValidator{
+			Namespaces: map[string]*pb.TokenTypeNamespace{
+				"bank1":        {Issuers: []string{"Org1MSP"}},
+				"bank1/retail": {Issuers: []string{"Org2MSP"}},
+				"bank2":        {Issuers: []string{"Org1MSP", "Org2MSP"}, AdminsOnly: true},
+			},
+			Deserializer: fakeIdentityDeserializer,
+			Default:      fakeDefault,
+		}
+	})
+
+	It("lets the members of the issuer orgs issue the types of the namespace", func() {
+		Expect(issuingValidator.Validate(fakeCreatorInfo, "bank1/USD")).To(Succeed())
+		Expect(issuingValidator.Validate(fakeCreatorInfo, "bank1/wholesale/EUR")).To(Succeed())
+		Expect(issuingValidator.Validate(fakeCreatorInfo, "bank1")).To(Succeed())
+		Expect(fakeIdentity.SatisfiesPrincipalCallCount()).To(Equal(0))
+		Expect(fakeDefault.ValidateCallCount()).To(Equal(0))
+	})
+
+	It("applies the policy of the longest namespace", func() {
+		err := issuingValidator.Validate(fakeCreatorInfo, "bank1/retail/USD")
+		Expect(err).To(MatchError("identity [0x010203] of org 'Org1MSP' cannot issue token type 'bank1/retail/USD' of namespace 'bank1/retail'"))
+
+		fakeIdentity.GetMSPIdentifierReturns("Org2MSP")
+		Expect(issuingValidator.Validate(fakeCreatorInfo, "bank1/retail/USD")).To(Succeed())
+		err = issuingValidator.Validate(fakeCreatorInfo, "bank1/USD")
+		Expect(err).To(MatchError("identity [0x010203] of org 'Org2MSP' cannot issue token type 'bank1/USD' of namespace 'bank1'"))
+	})
+
+	It("does not treat a type sharing the name of a namespace as part of it", func() {
+		err := issuingValidator.Validate(fakeCreatorInfo, "bank10/USD")
+		Expect(err).To(MatchError("default policy"))
+		Expect(fakeDefault.ValidateCallCount()).To(Equal(1))
+		creator, tokenType := fakeDefault.ValidateArgsForCall(0)
+		Expect(creator).To(Equal(fakeCreatorInfo))
+		Expect(tokenType).To(Equal("bank10/USD"))
+	})
+
+	It("requires the creator to be an admin when the namespace is restricted to admins", func() {
+		Expect(issuingValidator.Validate(fakeCreatorInfo, "bank2/USD")).To(Succeed())
+		Expect(fakeIdentity.SatisfiesPrincipalCallCount()).To(Equal(1))
+		principal := fakeIdentity.SatisfiesPrincipalArgsForCall(0)
+		role := &msp.MSPRole{}
+		Expect(proto.Unmarshal(principal.Principal, role)).To(Succeed())
+		Expect(role).To(Equal(&msp.MSPRole{MspIdentifier: "Org1MSP", Role: msp.MSPRole_ADMIN}))
+
+		fakeIdentity.SatisfiesPrincipalReturns(errors.New("not an admin"))
+		err := issuingValidator.Validate(fakeCreatorInfo, "bank2/USD")
+		Expect(err).To(MatchError("identity [0x010203] is not an admin: not an admin"))
+	})
+
+	Context("when the creator cannot be deserialized", func() {
+		BeforeEach(func() {
+			fakeIdentityDeserializer.DeserializeIdentityReturns(nil, errors.New("no-way-man"))
+		})
+
+		It("returns an error", func() {
+			err := issuingValidator.Validate(fakeCreatorInfo, "bank1/USD")
+			Expect(err).To(MatchError("identity [0x010203] cannot be deserialised: no-way-man"))
+		})
+	})
+
+	Context("when identity validation fails", func() {
+		BeforeEach(func() {
+			fakeIdentity.ValidateReturns(errors.New("no-way-man"))
+		})
+
+		It("returns an error", func() {
+			err := issuingValidator.Validate(fakeCreatorInfo, "bank1/USD")
+			Expect(err).To(MatchError("identity [0x010203] cannot be validated: no-way-man"))
+		})
+	})
+})
+
+var _ = Describe("TypeNamespace", func() {
+	It("returns the longest namespace of a token type", func() {
+		namespaces := map[string]*pb.TokenTypeNamespace{
+			"bank1":        {Issuers: []string{"Org1MSP"}},
+			"bank1/retail": {Issuers: []string{"Org2MSP"}},
+		}
+
+		prefix, namespace := manager.TypeNamespace(namespaces, "bank1/retail/USD")
+		Expect(prefix).To(Equal("bank1/retail"))
+		Expect(namespace).To(Equal(namespaces["bank1/retail"]))
+
+		prefix, namespace = manager.TypeNamespace(namespaces, "bank1/USD")
+		Expect(prefix).To(Equal("bank1"))
+		Expect(namespace).To(Equal(namespaces["bank1"]))
+
+		prefix, namespace = manager.TypeNamespace(namespaces, "USD")
+		Expect(prefix).To(BeEmpty())
+		Expect(namespace).To(BeNil())
+	})
+})
+
+var _ = Describe("ChannelConfigTypeNamespacesProvider", func() {
+	var (
+		application *config.MockApplication
+		exists      bool
+		provider    *manager.ChannelConfigTypeNamespacesProvider
+	)
+
+	BeforeEach(func() {
+		application = &config.MockApplication{
+			TokenTypeNamespacesRv: map[string]*pb.TokenTypeNamespace{
+				"bank1": {Issuers: []string{"Org1MSP"}},
+			},
+		}
+		exists = true
+		provider = &manager.ChannelConfigTypeNamespacesProvider{
+			ApplicationConfig: func(channel string) (channelconfig.Application, bool) {
+				return application, exists
+			},
+		}
+	})
+
+	It("returns the namespaces from the channel config", func() {
+		namespaces, err := provider.TypeNamespaces("ch0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(namespaces).To(Equal(application.TokenTypeNamespacesRv))
+	})
+
+	Context("when the channel has no application config", func() {
+		BeforeEach(func() {
+			exists = false
+		})
+
+		It("returns no namespaces", func() {
+			namespaces, err := provider.TypeNamespaces("ch0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(namespaces).To(BeNil())
+		})
+	})
+
+	Context("when the manager has a provider", func() {
+		It("configures the verifier with the namespaces", func() {
+			fakeIdentityDeserializerManager := &mock.DeserializerManager{}
+			fakeIdentityDeserializerManager.DeserializerReturns(&mock.Deserializer{}, nil)
+			mgm := &manager.Manager{
+				IdentityDeserializerManager: fakeIdentityDeserializerManager,
+				TypeNamespacesProvider:      provider,
+			}
+
+			txProcessor, err := mgm.GetTxProcessor("ch0")
+			Expect(err).NotTo(HaveOccurred())
+			issuingValidator := txProcessor.(*plain.Verifier).IssuingValidator.(*manager.NamespaceIssuingValidator)
+			Expect(issuingValidator.Namespaces).To(HaveKey("bank1"))
+			Expect(issuingValidator.Default).To(BeAssignableToTypeOf(&manager.AllIssuingValidator{}))
+		})
+
+		It("keeps the default policy when the channel has no namespaces", func() {
+			application.TokenTypeNamespacesRv = nil
+			fakeIdentityDeserializerManager := &mock.DeserializerManager{}
+			fakeIdentityDeserializerManager.DeserializerReturns(&mock.Deserializer{}, nil)
+			mgm := &manager.Manager{
+				IdentityDeserializerManager: fakeIdentityDeserializerManager,
+				TypeNamespacesProvider:      provider,
+			}
+
+			txProcessor, err := mgm.GetTxProcessor("ch0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(txProcessor.(*plain.Verifier).IssuingValidator).To(BeAssignableToTypeOf(&manager.AllIssuingValidator{}))
+		})
+	})
+})
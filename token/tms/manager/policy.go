@@ -7,6 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package manager
 
 import (
+	"github.com/golang/protobuf/proto"
+	mspi "github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/msp"
 	"github.com/hyperledger/fabric/token/identity"
 	"github.com/pkg/errors"
 )
@@ -31,3 +34,37 @@ func (p *AllIssuingValidator) Validate(creator identity.PublicInfo, tokenType st
 
 	return nil
 }
+
+// AdminGovernanceValidator allows the administrators of the channel members to
+// pause and resume token types.
+type AdminGovernanceValidator struct {
+	Deserializer identity.Deserializer
+}
+
+// Validate returns no error if the passed creator can pause and resume tokens of the passed type, an error otherwise.
+func (p *AdminGovernanceValidator) Validate(creator identity.PublicInfo, tokenType string) error {
+	identity, err := p.Deserializer.DeserializeIdentity(creator.Public())
+	if err != nil {
+		return errors.Wrapf(err, "identity [0x%x] cannot be deserialised", creator.Public())
+	}
+
+	if err := identity.Validate(); err != nil {
+		return errors.Wrapf(err, "identity [0x%x] cannot be validated", creator.Public())
+	}
+
+	return checkAdmin(identity, creator)
+}
+
+// checkAdmin returns an error if the identity of the creator is not an admin
+// of its MSP.
+func checkAdmin(id mspi.Identity, creator identity.PublicInfo) error {
+	role, err := proto.Marshal(&msp.MSPRole{MspIdentifier: id.GetMSPIdentifier(), Role: msp.MSPRole_ADMIN})
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling admin role")
+	}
+	err = id.SatisfiesPrincipal(&msp.MSPPrincipal{PrincipalClassification: msp.MSPPrincipal_ROLE, Principal: role})
+	if err != nil {
+		return errors.Wrapf(err, "identity [0x%x] is not an admin", creator.Public())
+	}
+	return nil
+}
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package manager_test
 
 import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/msp"
 	mockid "github.com/hyperledger/fabric/token/identity/mock"
 	"github.com/hyperledger/fabric/token/tms/manager"
 	. "github.com/onsi/ginkgo"
@@ -77,3 +79,60 @@ var _ = Describe("AllIssuingValidator", func() {
 
 	})
 })
+
+var _ = Describe("AdminGovernanceValidator", func() {
+	var (
+		fakeCreatorInfo          *mockid.PublicInfo
+		fakeIdentityDeserializer *mockid.Deserializer
+		fakeIdentity             *mockid.Identity
+		governanceValidator      *manager.AdminGovernanceValidator
+	)
+
+	BeforeEach(func() {
+		fakeCreatorInfo = &mockid.PublicInfo{}
+		fakeCreatorInfo.PublicReturns([]byte{1, 2, 3})
+		fakeIdentity = &mockid.Identity{}
+		fakeIdentity.GetMSPIdentifierReturns("Org1MSP")
+		fakeIdentityDeserializer = &mockid.Deserializer{}
+		fakeIdentityDeserializer.DeserializeIdentityReturns(fakeIdentity, nil)
+
+		governanceValidator = &manager.AdminGovernanceValidator{
+			Deserializer: fakeIdentityDeserializer,
+		}
+	})
+
+	It("requires the creator to be an admin of its MSP", func() {
+		err := governanceValidator.Validate(fakeCreatorInfo, "XYZ")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeIdentity.SatisfiesPrincipalCallCount()).To(Equal(1))
+		principal := fakeIdentity.SatisfiesPrincipalArgsForCall(0)
+		Expect(principal.PrincipalClassification).To(Equal(msp.MSPPrincipal_ROLE))
+		role := &msp.MSPRole{}
+		Expect(proto.Unmarshal(principal.Principal, role)).To(Succeed())
+		Expect(role).To(Equal(&msp.MSPRole{MspIdentifier: "Org1MSP", Role: msp.MSPRole_ADMIN}))
+	})
+
+	Context("when the creator is not an admin", func() {
+		BeforeEach(func() {
+			fakeIdentity.SatisfiesPrincipalReturns(errors.New("not an admin"))
+		})
+
+		It("returns an error", func() {
+			err := governanceValidator.Validate(fakeCreatorInfo, "XYZ")
+			Expect(err).To(MatchError("identity [0x010203] is not an admin: not an admin"))
+		})
+	})
+
+	Context("when identity validation fails", func() {
+		BeforeEach(func() {
+			fakeIdentity.ValidateReturns(errors.New("Validate, no-way-man"))
+		})
+
+		It("returns an error", func() {
+			err := governanceValidator.Validate(fakeCreatorInfo, "XYZ")
+			Expect(err).To(MatchError("identity [0x010203] cannot be validated: Validate, no-way-man"))
+			Expect(fakeIdentity.SatisfiesPrincipalCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,54 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package manager
+
+import (
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	"github.com/pkg/errors"
+)
+
+// SupplyLimitsProvider returns the limits on the issuance of tokens on a channel,
+// by token type.
+type SupplyLimitsProvider interface {
+	SupplyLimits(channel string) (map[string]*plain.SupplyLimit, error)
+}
+
+// ChannelConfigSupplyLimitsProvider implements a SupplyLimitsProvider on top
+// of the TokenSupply value of the application config of channels.
+type ChannelConfigSupplyLimitsProvider struct {
+	// ApplicationConfig returns the application config of a channel and
+	// whether it exists.
+	ApplicationConfig func(channel string) (channelconfig.Application, bool)
+}
+
+func (c *ChannelConfigSupplyLimitsProvider) SupplyLimits(channel string) (map[string]*plain.SupplyLimit, error) {
+	ac, ok := c.ApplicationConfig(channel)
+	if !ok {
+		return nil, nil
+	}
+
+	var limits map[string]*plain.SupplyLimit
+	for tokenType, l := range ac.TokenSupply() {
+		if l == nil {
+			continue
+		}
+		limit := &plain.SupplyLimit{
+			MaxTotalSupply: l.MaxTotalSupply,
+			MaxPerPeriod:   l.MaxPerPeriod,
+			PeriodBlocks:   l.PeriodBlocks,
+		}
+		if l.MaxPerPeriod != 0 && l.PeriodBlocks == 0 {
+			return nil, errors.Errorf("invalid issuance period for token type '%s': period_blocks is required", tokenType)
+		}
+		if limits == nil {
+			limits = map[string]*plain.SupplyLimit{}
+		}
+		limits[tokenType] = limit
+	}
+	return limits, nil
+}
@@ -0,0 +1,181 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/hyperledger/fabric/token/identity"
+	"github.com/hyperledger/fabric/token/ledger"
+	"github.com/pkg/errors"
+)
+
+const tokenDelegationSpent = "tokenDelegationSpent"
+
+// publicInfo is the PublicInfo of a party other than the creator of a transaction,
+// such as the owner of inputs spent by way of a delegation
+type publicInfo []byte
+
+func (p publicInfo) Public() []byte {
+	return p
+}
+
+func unmarshalDelegation(sd *token.SignedDelegation) (*token.Delegation, error) {
+	delegation := &token.Delegation{}
+	err := proto.Unmarshal(sd.GetDelegation(), delegation)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling delegation")
+	}
+	return delegation, nil
+}
+
+// delegationOwner returns the delegator of the first delegation of the chain,
+// i.e. the owner of the tokens being spent.
+func delegationOwner(chain []*token.SignedDelegation) ([]byte, error) {
+	if len(chain) == 0 {
+		return nil, errors.New("no delegations")
+	}
+	first, err := unmarshalDelegation(chain[0])
+	if err != nil {
+		return nil, err
+	}
+	return first.Delegator, nil
+}
+
+// checkDelegationChain verifies that the signed delegations form a chain starting
+// at owner and ending at spender, and that each delegation is signed by its
+// delegator and is valid for tokens of the given type on the given channel.
+// It returns the decoded delegations.
+func checkDelegationChain(deserializer identity.Deserializer, chain []*token.SignedDelegation, owner, spender []byte, tokenType, channel string) ([]*token.Delegation, error) {
+	if deserializer == nil {
+		return nil, errors.New("delegated spending is not supported")
+	}
+	if len(chain) == 0 {
+		return nil, errors.New("no delegations")
+	}
+
+	var delegations []*token.Delegation
+	delegator := owner
+	for i, sd := range chain {
+		d, err := unmarshalDelegation(sd)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("delegation %d is invalid", i))
+		}
+		if !bytes.Equal(d.Delegator, delegator) {
+			return nil, errors.Errorf("delegation %d is not granted by the expected delegator", i)
+		}
+		if len(d.Delegatee) == 0 {
+			return nil, errors.Errorf("delegation %d has no delegatee", i)
+		}
+		if d.Type != tokenType {
+			return nil, errors.Errorf("delegation %d is for token type '%s', not '%s'", i, d.Type, tokenType)
+		}
+		if d.ChannelId != channel {
+			return nil, errors.Errorf("delegation %d is for channel '%s', not '%s'", i, d.ChannelId, channel)
+		}
+		if d.Quantity == 0 {
+			return nil, errors.Errorf("delegation %d has quantity 0", i)
+		}
+		if d.ExpirationBlock == 0 {
+			return nil, errors.Errorf("delegation %d has no expiration", i)
+		}
+
+		id, err := deserializer.DeserializeIdentity(d.Delegator)
+		if err != nil {
+			return nil, errors.Wrapf(err, "delegator of delegation %d cannot be deserialized", i)
+		}
+		err = id.Verify(sd.Delegation, sd.Signature)
+		if err != nil {
+			return nil, errors.Wrapf(err, "signature of delegation %d is invalid", i)
+		}
+
+		delegations = append(delegations, d)
+		delegator = d.Delegatee
+	}
+
+	if !bytes.Equal(delegator, spender) {
+		return nil, errors.New("delegation chain is not granted to the spender")
+	}
+	return delegations, nil
+}
+
+// checkDelegationsNotExpired returns an error if any of the delegations expired before
+// block blockNum.
+func checkDelegationsNotExpired(delegations []*token.Delegation, blockNum uint64) error {
+	for i, d := range delegations {
+		if blockNum > d.ExpirationBlock {
+			return errors.Errorf("delegation %d expired at block %d", i, d.ExpirationBlock)
+		}
+	}
+	return nil
+}
+
+// delegatedQuantity returns the quantity of tokens that the outputs move away from the owner.
+func delegatedQuantity(outputs []*token.PlainOutput, owner []byte) uint64 {
+	quantity := uint64(0)
+	for _, output := range outputs {
+		if !identity.IsOwner(output.Owner, owner) {
+			quantity += output.Quantity
+		}
+	}
+	return quantity
+}
+
+// Create a ledger key recording the quantity spent so far under a delegation, as a function
+// of the hash of the delegation. The signature is not part of the hash so that a different
+// signature over the same delegation does not reset the quantity spent.
+func createDelegationSpentKey(sd *token.SignedDelegation) (string, error) {
+	hash := sha256.Sum256(sd.Delegation)
+	return createCompositeKey(tokenDelegationSpent, []string{hex.EncodeToString(hash[:])})
+}
+
+func getDelegationSpent(key string, reader ledger.LedgerReader) (uint64, error) {
+	raw, err := reader.GetState(tk.Namespace, key)
+	if err != nil {
+		return 0, err
+	}
+	if raw == nil {
+		return 0, nil
+	}
+	spent, err := strconv.ParseUint(string(raw), 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid quantity spent for delegation key '%s'", key)
+	}
+	return spent, nil
+}
+
+// checkDelegationAllowance checks that spending quantity more tokens stays within the
+// remaining allowance of every delegation in the chain.
+func checkDelegationAllowance(chain []*token.SignedDelegation, delegations []*token.Delegation, quantity uint64, reader ledger.LedgerReader) error {
+	for i, sd := range chain {
+		key, err := createDelegationSpentKey(sd)
+		if err != nil {
+			return err
+		}
+		spent, err := getDelegationSpent(key, reader)
+		if err != nil {
+			return err
+		}
+		allowed := delegations[i].Quantity
+		if spent > allowed || quantity > allowed-spent {
+			remaining := uint64(0)
+			if spent < allowed {
+				remaining = allowed - spent
+			}
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("delegation %d allows spending %d more tokens, %d requested", i, remaining, quantity)}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,397 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/token"
+	mockid "github.com/hyperledger/fabric/token/identity/mock"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func signedDelegation(delegator, delegatee string, quantity uint64, expirationBlock uint64, channel string) *token.SignedDelegation {
+	raw, err := proto.Marshal(&token.Delegation{
+		Delegator:       []byte(delegator),
+		Delegatee:       []byte(delegatee),
+		Type:            "TOK1",
+		Quantity:        quantity,
+		ExpirationBlock: expirationBlock,
+		ChannelId:       channel,
+		Nonce:           []byte(delegator + "-" + delegatee),
+	})
+	Expect(err).NotTo(HaveOccurred())
+	return &token.SignedDelegation{Delegation: raw, Signature: []byte("signature-of-" + delegator)}
+}
+
+func delegatedTransfer(delegations []*token.SignedDelegation, outputs ...*token.PlainOutput) *token.TokenTransaction {
+	return &token.TokenTransaction{
+		Action: &token.TokenTransaction_PlainAction{
+			PlainAction: &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainTransfer{
+					PlainTransfer: &token.PlainTransfer{
+						Inputs:      []*token.InputId{{TxId: "0", Index: 0}},
+						Outputs:     outputs,
+						Delegations: delegations,
+					},
+				},
+			},
+		},
+	}
+}
+
+var _ = Describe("Delegated transfers", func() {
+	var (
+		fakeCreator      *mockid.PublicInfo
+		fakeDeserializer *mockid.Deserializer
+		fakeIdentity     *mockid.Identity
+		memoryLedger     *plain.MemoryLedger
+		delegation       *token.SignedDelegation
+		expiration       uint64
+	)
+
+	BeforeEach(func() {
+		fakeCreator = &mockid.PublicInfo{}
+		fakeCreator.PublicReturns([]byte("service"))
+		fakeIdentity = &mockid.Identity{}
+		fakeDeserializer = &mockid.Deserializer{}
+		fakeDeserializer.DeserializeIdentityReturns(fakeIdentity, nil)
+
+		expiration = 10
+		delegation = signedDelegation("owner-1", "service", 50, expiration, "testchannel")
+
+		memoryLedger = plain.NewMemoryLedger()
+		importer := &mockid.PublicInfo{}
+		importVerifier := &plain.Verifier{IssuingValidator: &mockid.IssuingValidator{}}
+		err := importVerifier.ProcessTx("0", importer, &token.TokenTransaction{
+			Action: &token.TokenTransaction_PlainAction{
+				PlainAction: &token.PlainTokenAction{
+					Data: &token.PlainTokenAction_PlainImport{
+						PlainImport: &token.PlainImport{
+							Outputs: []*token.PlainOutput{{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 111}},
+						},
+					},
+				},
+			},
+		}, memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("Verifier", func() {
+		var (
+			verifier *plain.Verifier
+			blockNum uint64
+		)
+
+		BeforeEach(func() {
+			blockNum = 5
+			verifier = &plain.Verifier{
+				IssuingValidator: &mockid.IssuingValidator{},
+				Deserializer:     fakeDeserializer,
+				Channel:          "testchannel",
+			}
+		})
+
+		It("processes a transfer authorized by the owner", func() {
+			ttx := delegatedTransfer([]*token.SignedDelegation{delegation},
+				&token.PlainOutput{Owner: []byte("recipient"), Type: "TOK1", Quantity: 40},
+				&token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 71},
+			)
+			err := verifier.ProcessTxAt("1", fakeCreator, ttx, blockNum, time.Time{}, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeDeserializer.DeserializeIdentityCallCount()).To(Equal(1))
+			Expect(fakeDeserializer.DeserializeIdentityArgsForCall(0)).To(Equal([]byte("owner-1")))
+			msg, sig := fakeIdentity.VerifyArgsForCall(0)
+			Expect(msg).To(Equal(delegation.Delegation))
+			Expect(sig).To(Equal([]byte("signature-of-owner-1")))
+
+			spent, err := memoryLedger.GetState("tms", "\x00tokenInput\x000\x000\x00")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spent).To(Equal(plain.TokenInputSpentMarker))
+		})
+
+		It("enforces the quantity across transactions", func() {
+			ttx := delegatedTransfer([]*token.SignedDelegation{delegation},
+				&token.PlainOutput{Owner: []byte("recipient"), Type: "TOK1", Quantity: 40},
+				&token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 71},
+			)
+			err := verifier.ProcessTxAt("1", fakeCreator, ttx, blockNum, time.Time{}, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			ttx = &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainTransfer{
+							PlainTransfer: &token.PlainTransfer{
+								Inputs: []*token.InputId{{TxId: "1", Index: 1}},
+								Outputs: []*token.PlainOutput{
+									{Owner: []byte("recipient"), Type: "TOK1", Quantity: 20},
+									{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 51},
+								},
+								Delegations: []*token.SignedDelegation{delegation},
+							},
+						},
+					},
+				},
+			}
+			err = verifier.ProcessTxAt("2", fakeCreator, ttx, blockNum+1, time.Time{}, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "delegation 0 allows spending 10 more tokens, 20 requested"}))
+		})
+
+		It("accepts a chain of delegations", func() {
+			chain := []*token.SignedDelegation{
+				signedDelegation("owner-1", "broker", 50, expiration, "testchannel"),
+				signedDelegation("broker", "service", 30, expiration, "testchannel"),
+			}
+			ttx := delegatedTransfer(chain,
+				&token.PlainOutput{Owner: []byte("recipient"), Type: "TOK1", Quantity: 30},
+				&token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 81},
+			)
+			err := verifier.ProcessTxAt("1", fakeCreator, ttx, blockNum, time.Time{}, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeDeserializer.DeserializeIdentityArgsForCall(1)).To(Equal([]byte("broker")))
+		})
+
+		It("rejects a chain exceeding the quantity of an intermediate delegation", func() {
+			chain := []*token.SignedDelegation{
+				signedDelegation("owner-1", "broker", 50, expiration, "testchannel"),
+				signedDelegation("broker", "service", 30, expiration, "testchannel"),
+			}
+			ttx := delegatedTransfer(chain,
+				&token.PlainOutput{Owner: []byte("recipient"), Type: "TOK1", Quantity: 40},
+				&token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 71},
+			)
+			err := verifier.ProcessTxAt("1", fakeCreator, ttx, blockNum, time.Time{}, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "delegation 1 allows spending 30 more tokens, 40 requested"}))
+		})
+
+		Context("when the creator is not the delegatee", func() {
+			BeforeEach(func() {
+				fakeCreator.PublicReturns([]byte("intruder"))
+			})
+
+			It("returns an InvalidTxError", func() {
+				ttx := delegatedTransfer([]*token.SignedDelegation{delegation},
+					&token.PlainOutput{Owner: []byte("recipient"), Type: "TOK1", Quantity: 111},
+				)
+				err := verifier.ProcessTxAt("1", fakeCreator, ttx, blockNum, time.Time{}, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "invalid delegation chain in transfer with ID 1: delegation chain is not granted to the spender"}))
+			})
+		})
+
+		Context("when the delegation is not granted by the owner of the inputs", func() {
+			BeforeEach(func() {
+				delegation = signedDelegation("owner-2", "service", 50, expiration, "testchannel")
+			})
+
+			It("returns an InvalidTxError", func() {
+				ttx := delegatedTransfer([]*token.SignedDelegation{delegation},
+					&token.PlainOutput{Owner: []byte("recipient"), Type: "TOK1", Quantity: 111},
+				)
+				err := verifier.ProcessTxAt("1", fakeCreator, ttx, blockNum, time.Time{}, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "transfer input with ID \x00tokenOutput\x000\x000\x00 not owned by creator"}))
+			})
+		})
+
+		Context("when the signature of the delegation is invalid", func() {
+			BeforeEach(func() {
+				fakeIdentity.VerifyReturns(errors.New("bad signature"))
+			})
+
+			It("returns an InvalidTxError", func() {
+				ttx := delegatedTransfer([]*token.SignedDelegation{delegation},
+					&token.PlainOutput{Owner: []byte("recipient"), Type: "TOK1", Quantity: 40},
+					&token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 71},
+				)
+				err := verifier.ProcessTxAt("1", fakeCreator, ttx, blockNum, time.Time{}, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "invalid delegation chain in transfer with ID 1: signature of delegation 0 is invalid: bad signature"}))
+			})
+		})
+
+		Context("when the delegation is bound to another channel", func() {
+			BeforeEach(func() {
+				delegation = signedDelegation("owner-1", "service", 50, expiration, "otherchannel")
+			})
+
+			It("returns an InvalidTxError", func() {
+				ttx := delegatedTransfer([]*token.SignedDelegation{delegation},
+					&token.PlainOutput{Owner: []byte("recipient"), Type: "TOK1", Quantity: 40},
+					&token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 71},
+				)
+				err := verifier.ProcessTxAt("1", fakeCreator, ttx, blockNum, time.Time{}, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "invalid delegation chain in transfer with ID 1: delegation 0 is for channel 'otherchannel', not 'testchannel'"}))
+			})
+		})
+
+		Context("when the transaction is committed in the expiration block", func() {
+			BeforeEach(func() {
+				blockNum = expiration
+			})
+
+			It("processes the transfer", func() {
+				ttx := delegatedTransfer([]*token.SignedDelegation{delegation},
+					&token.PlainOutput{Owner: []byte("recipient"), Type: "TOK1", Quantity: 40},
+					&token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 71},
+				)
+				err := verifier.ProcessTxAt("1", fakeCreator, ttx, blockNum, time.Time{}, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when the transaction is committed after the expiration block", func() {
+			BeforeEach(func() {
+				blockNum = expiration + 1
+			})
+
+			It("returns an InvalidTxError, whatever the timestamp of the transaction", func() {
+				ttx := delegatedTransfer([]*token.SignedDelegation{delegation},
+					&token.PlainOutput{Owner: []byte("recipient"), Type: "TOK1", Quantity: 40},
+					&token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 71},
+				)
+				err := verifier.ProcessTxAt("1", fakeCreator, ttx, blockNum, time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC), memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "invalid delegation chain in transfer with ID 1: delegation 0 expired at block 10"}))
+			})
+		})
+
+		Context("when an intermediate delegation of the chain expired", func() {
+			It("returns an InvalidTxError", func() {
+				chain := []*token.SignedDelegation{
+					signedDelegation("owner-1", "broker", 50, expiration, "testchannel"),
+					signedDelegation("broker", "service", 30, blockNum-1, "testchannel"),
+				}
+				ttx := delegatedTransfer(chain,
+					&token.PlainOutput{Owner: []byte("recipient"), Type: "TOK1", Quantity: 30},
+					&token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 81},
+				)
+				err := verifier.ProcessTxAt("1", fakeCreator, ttx, blockNum, time.Time{}, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "invalid delegation chain in transfer with ID 1: delegation 1 expired at block 4"}))
+			})
+		})
+
+		Context("when the delegation has no expiration", func() {
+			BeforeEach(func() {
+				delegation = signedDelegation("owner-1", "service", 50, 0, "testchannel")
+			})
+
+			It("returns an InvalidTxError", func() {
+				ttx := delegatedTransfer([]*token.SignedDelegation{delegation},
+					&token.PlainOutput{Owner: []byte("recipient"), Type: "TOK1", Quantity: 40},
+					&token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 71},
+				)
+				err := verifier.ProcessTxAt("1", fakeCreator, ttx, blockNum, time.Time{}, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "invalid delegation chain in transfer with ID 1: delegation 0 has no expiration"}))
+			})
+		})
+
+		Context("when the block number is unknown", func() {
+			It("returns an InvalidTxError", func() {
+				ttx := delegatedTransfer([]*token.SignedDelegation{delegation},
+					&token.PlainOutput{Owner: []byte("recipient"), Type: "TOK1", Quantity: 40},
+					&token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 71},
+				)
+				err := verifier.ProcessTx("1", fakeCreator, ttx, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "the block number is required to spend tokens by way of a delegation in transfer with ID 1"}))
+			})
+		})
+
+		Context("when the verifier cannot verify delegations", func() {
+			BeforeEach(func() {
+				verifier.Deserializer = nil
+			})
+
+			It("returns an InvalidTxError", func() {
+				ttx := delegatedTransfer([]*token.SignedDelegation{delegation},
+					&token.PlainOutput{Owner: []byte("recipient"), Type: "TOK1", Quantity: 40},
+					&token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 71},
+				)
+				err := verifier.ProcessTxAt("1", fakeCreator, ttx, blockNum, time.Time{}, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "invalid delegation chain in transfer with ID 1: delegated spending is not supported"}))
+			})
+		})
+
+		Context("when a redeem carries delegations", func() {
+			It("returns an InvalidTxError", func() {
+				ttx := &token.TokenTransaction{
+					Action: &token.TokenTransaction_PlainAction{
+						PlainAction: &token.PlainTokenAction{
+							Data: &token.PlainTokenAction_PlainRedeem{
+								PlainRedeem: &token.PlainTransfer{
+									Inputs:      []*token.InputId{{TxId: "0", Index: 0}},
+									Outputs:     []*token.PlainOutput{{Type: "TOK1", Quantity: 111}},
+									Delegations: []*token.SignedDelegation{delegation},
+								},
+							},
+						},
+					},
+				}
+				err := verifier.ProcessTxAt("1", fakeCreator, ttx, blockNum, time.Time{}, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "delegations are not supported in redeem transaction with ID 1"}))
+			})
+		})
+	})
+
+	Describe("Transactor", func() {
+		var (
+			transactor *plain.Transactor
+			request    *token.TransferRequest
+		)
+
+		BeforeEach(func() {
+			transactor = &plain.Transactor{
+				PublicCredential: []byte("service"),
+				Ledger:           memoryLedger,
+				Channel:          "testchannel",
+				Deserializer:     fakeDeserializer,
+			}
+			request = &token.TransferRequest{
+				TokenIds: [][]byte{[]byte("\x00tokenOutput\x000\x000\x00")},
+				Shares: []*token.RecipientTransferShare{
+					{Recipient: []byte("recipient"), Quantity: 40},
+					{Recipient: []byte("owner-1"), Quantity: 71},
+				},
+				Delegations: []*token.SignedDelegation{delegation},
+			}
+		})
+
+		It("creates a transfer that carries the delegations", func() {
+			ttx, err := transactor.RequestTransfer(request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(ttx, delegatedTransfer([]*token.SignedDelegation{delegation},
+				&token.PlainOutput{Owner: []byte("recipient"), Type: "TOK1", Quantity: 40},
+				&token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 71},
+			))).To(BeTrue())
+		})
+
+		Context("when the transfer exceeds the delegated quantity", func() {
+			BeforeEach(func() {
+				request.Shares = []*token.RecipientTransferShare{{Recipient: []byte("recipient"), Quantity: 111}}
+			})
+
+			It("returns an error", func() {
+				_, err := transactor.RequestTransfer(request)
+				Expect(err).To(MatchError("delegation 0 allows spending 50 more tokens, 111 requested"))
+			})
+		})
+
+		Context("when the delegation is not granted to the transactor", func() {
+			BeforeEach(func() {
+				transactor.PublicCredential = []byte("intruder")
+			})
+
+			It("returns an error", func() {
+				_, err := transactor.RequestTransfer(request)
+				Expect(err).To(MatchError("invalid delegation chain: delegation chain is not granted to the spender"))
+			})
+		})
+	})
+})
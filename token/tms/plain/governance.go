@@ -0,0 +1,115 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/hyperledger/fabric/token/identity"
+	"github.com/hyperledger/fabric/token/ledger"
+)
+
+const tokenPaused = "tokenPaused"
+
+func (v *Verifier) checkGovernanceAction(creator identity.PublicInfo, governanceAction *token.PlainGovernance, pause bool, txID string, simulator ledger.LedgerReader) error {
+	tokenType := governanceAction.GetType()
+	if tokenType == "" {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("no token type in governance transaction: %s", txID)}
+	}
+	if v.GovernanceValidator == nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("governance transactions are not supported: %s", txID)}
+	}
+	err := v.GovernanceValidator.Validate(creator, tokenType)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("governance policy check failed: %s", err)}
+	}
+
+	paused, err := isPaused(tokenType, simulator)
+	if err != nil {
+		return err
+	}
+	if pause && paused {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("token type '%s' is already paused", tokenType)}
+	}
+	if !pause && !paused {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("token type '%s' is not paused", tokenType)}
+	}
+	return nil
+}
+
+// checkNotPaused checks that none of the token types the action operates on is paused.
+func (v *Verifier) checkNotPaused(plainAction *token.PlainTokenAction, txID string, simulator ledger.LedgerReader) error {
+	checked := map[string]bool{}
+	for _, tokenType := range actionTypes(plainAction) {
+		if checked[tokenType] {
+			continue
+		}
+		checked[tokenType] = true
+
+		paused, err := isPaused(tokenType, simulator)
+		if err != nil {
+			return err
+		}
+		if paused {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("token type '%s' is paused, transaction %s rejected", tokenType, txID)}
+		}
+	}
+	return nil
+}
+
+// actionTypes returns the token types of the outputs of the action. The verifier
+// checks that the types of the inputs match the types of the outputs.
+func actionTypes(plainAction *token.PlainTokenAction) []string {
+	var outputs []*token.PlainOutput
+	var types []string
+	switch action := plainAction.Data.(type) {
+	case *token.PlainTokenAction_PlainImport:
+		outputs = action.PlainImport.GetOutputs()
+	case *token.PlainTokenAction_PlainTransfer:
+		outputs = action.PlainTransfer.GetOutputs()
+	case *token.PlainTokenAction_PlainRedeem:
+		outputs = action.PlainRedeem.GetOutputs()
+	case *token.PlainTokenAction_PlainApprove:
+		if action.PlainApprove.GetOutput() != nil {
+			outputs = append(outputs, action.PlainApprove.GetOutput())
+		}
+		for _, delegatedOutput := range action.PlainApprove.GetDelegatedOutputs() {
+			types = append(types, delegatedOutput.GetType())
+		}
+	}
+	for _, output := range outputs {
+		types = append(types, output.GetType())
+	}
+	return types
+}
+
+func isPaused(tokenType string, simulator ledger.LedgerReader) (bool, error) {
+	pausedKey, err := createCompositeKey(tokenPaused, []string{tokenType})
+	if err != nil {
+		return false, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating paused key: %s", err)}
+	}
+	paused, err := simulator.GetState(tk.Namespace, pausedKey)
+	if err != nil {
+		return false, err
+	}
+	return len(paused) != 0, nil
+}
+
+func (v *Verifier) commitGovernanceAction(governanceAction *token.PlainGovernance, pause bool, simulator ledger.LedgerWriter) error {
+	pausedKey, err := createCompositeKey(tokenPaused, []string{governanceAction.GetType()})
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating paused key: %s", err)}
+	}
+	if !pause {
+		// a nil value deletes the key
+		return simulator.SetState(tk.Namespace, pausedKey, nil)
+	}
+	return simulator.SetState(tk.Namespace, pausedKey, []byte{1})
+}
@@ -0,0 +1,147 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain_test
+
+import (
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/token"
+	mockid "github.com/hyperledger/fabric/token/identity/mock"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Governance", func() {
+	var (
+		fakePublicInfo          *mockid.PublicInfo
+		fakeGovernanceValidator *mockid.GovernanceValidator
+		memoryLedger            *plain.MemoryLedger
+		verifier                *plain.Verifier
+		issuer                  *plain.Issuer
+		transferTx              *token.TokenTransaction
+	)
+
+	BeforeEach(func() {
+		fakePublicInfo = &mockid.PublicInfo{}
+		fakePublicInfo.PublicReturns([]byte("owner-1"))
+		fakeGovernanceValidator = &mockid.GovernanceValidator{}
+		memoryLedger = plain.NewMemoryLedger()
+		verifier = &plain.Verifier{
+			IssuingValidator:    &mockid.IssuingValidator{},
+			GovernanceValidator: fakeGovernanceValidator,
+		}
+		issuer = &plain.Issuer{}
+
+		importTx, err := issuer.RequestImport([]*token.TokenToIssue{{Recipient: []byte("owner-1"), Type: "XYZ", Quantity: 100}})
+		Expect(err).NotTo(HaveOccurred())
+		err = verifier.ProcessTx("0", fakePublicInfo, importTx, memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+
+		transferTx = &token.TokenTransaction{
+			Action: &token.TokenTransaction_PlainAction{
+				PlainAction: &token.PlainTokenAction{
+					Data: &token.PlainTokenAction_PlainTransfer{
+						PlainTransfer: &token.PlainTransfer{
+							Inputs:  []*token.InputId{{TxId: "0", Index: 0}},
+							Outputs: []*token.PlainOutput{{Owner: []byte("owner-2"), Type: "XYZ", Quantity: 100}},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	It("suspends and resumes transfers of a paused token type", func() {
+		pauseTx, err := issuer.RequestPause("XYZ")
+		Expect(err).NotTo(HaveOccurred())
+		err = verifier.ProcessTx("1", fakePublicInfo, pauseTx, memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeGovernanceValidator.ValidateCallCount()).To(Equal(1))
+		creator, tokenType := fakeGovernanceValidator.ValidateArgsForCall(0)
+		Expect(creator).To(Equal(fakePublicInfo))
+		Expect(tokenType).To(Equal("XYZ"))
+
+		err = verifier.ProcessTx("2", fakePublicInfo, transferTx, memoryLedger)
+		Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token type 'XYZ' is paused, transaction 2 rejected"}))
+
+		importTx, err := issuer.RequestImport([]*token.TokenToIssue{{Recipient: []byte("owner-1"), Type: "XYZ", Quantity: 100}})
+		Expect(err).NotTo(HaveOccurred())
+		err = verifier.ProcessTx("2", fakePublicInfo, importTx, memoryLedger)
+		Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token type 'XYZ' is paused, transaction 2 rejected"}))
+
+		resumeTx, err := issuer.RequestResume("XYZ")
+		Expect(err).NotTo(HaveOccurred())
+		err = verifier.ProcessTx("3", fakePublicInfo, resumeTx, memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = verifier.ProcessTx("4", fakePublicInfo, transferTx, memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("does not affect other token types", func() {
+		pauseTx, err := issuer.RequestPause("PDQ")
+		Expect(err).NotTo(HaveOccurred())
+		err = verifier.ProcessTx("1", fakePublicInfo, pauseTx, memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = verifier.ProcessTx("2", fakePublicInfo, transferTx, memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("rejects pausing a paused token type and resuming an active one", func() {
+		resumeTx, err := issuer.RequestResume("XYZ")
+		Expect(err).NotTo(HaveOccurred())
+		err = verifier.ProcessTx("1", fakePublicInfo, resumeTx, memoryLedger)
+		Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token type 'XYZ' is not paused"}))
+
+		pauseTx, err := issuer.RequestPause("XYZ")
+		Expect(err).NotTo(HaveOccurred())
+		err = verifier.ProcessTx("2", fakePublicInfo, pauseTx, memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+		err = verifier.ProcessTx("3", fakePublicInfo, pauseTx, memoryLedger)
+		Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token type 'XYZ' is already paused"}))
+	})
+
+	Context("when the creator fails the governance policy", func() {
+		BeforeEach(func() {
+			fakeGovernanceValidator.ValidateReturns(errors.New("not an admin"))
+		})
+
+		It("rejects the transaction", func() {
+			pauseTx, err := issuer.RequestPause("XYZ")
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.ProcessTx("1", fakePublicInfo, pauseTx, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "governance policy check failed: not an admin"}))
+
+			err = verifier.ProcessTx("2", fakePublicInfo, transferTx, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("when the verifier has no governance validator", func() {
+		BeforeEach(func() {
+			verifier.GovernanceValidator = nil
+		})
+
+		It("rejects governance transactions", func() {
+			pauseTx, err := issuer.RequestPause("XYZ")
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.ProcessTx("1", fakePublicInfo, pauseTx, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "governance transactions are not supported: 1"}))
+		})
+	})
+
+	Context("when the token type is missing", func() {
+		It("rejects the transaction", func() {
+			pauseTx, err := issuer.RequestPause("")
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.ProcessTx("1", fakePublicInfo, pauseTx, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "no token type in governance transaction: 1"}))
+		})
+	})
+})
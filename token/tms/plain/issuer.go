@@ -21,6 +21,7 @@ func (i *Issuer) RequestImport(tokensToIssue []*token.TokenToIssue) (*token.Toke
 			Owner:    tti.Recipient,
 			Type:     tti.Type,
 			Quantity: tti.Quantity,
+			Metadata: tti.Metadata,
 		})
 	}
 
@@ -37,6 +38,46 @@ func (i *Issuer) RequestImport(tokensToIssue []*token.TokenToIssue) (*token.Toke
 	}, nil
 }
 
+// RequestPause creates a governance transaction pausing the passed token type.
+func (i *Issuer) RequestPause(tokenType string) (*token.TokenTransaction, error) {
+	return &token.TokenTransaction{
+		Action: &token.TokenTransaction_PlainAction{
+			PlainAction: &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainPause{
+					PlainPause: &token.PlainGovernance{Type: tokenType},
+				},
+			},
+		},
+	}, nil
+}
+
+// RequestResume creates a governance transaction resuming the passed token type.
+func (i *Issuer) RequestResume(tokenType string) (*token.TokenTransaction, error) {
+	return &token.TokenTransaction{
+		Action: &token.TokenTransaction_PlainAction{
+			PlainAction: &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainResume{
+					PlainResume: &token.PlainGovernance{Type: tokenType},
+				},
+			},
+		},
+	}, nil
+}
+
+// RequestIssueManifest creates a transaction tying together the imports of the
+// chunks of the batch with the passed ID.
+func (i *Issuer) RequestIssueManifest(batchID []byte, chunks []*token.IssueChunk) (*token.TokenTransaction, error) {
+	return &token.TokenTransaction{
+		Action: &token.TokenTransaction_PlainAction{
+			PlainAction: &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainIssueManifest{
+					PlainIssueManifest: &token.PlainIssueManifest{BatchId: batchID, Chunks: chunks},
+				},
+			},
+		},
+	}, nil
+}
+
 // RequestExpectation allows indirect import based on the expectation.
 // It creates a token transaction with the outputs as specified in the expectation.
 func (i *Issuer) RequestExpectation(request *token.ExpectationRequest) (*token.TokenTransaction, error) {
@@ -77,4 +77,21 @@ var _ = Describe("Issuer", func() {
 			}))
 		})
 	})
+
+	It("creates governance transactions pausing and resuming a token type", func() {
+		tt, err := issuer.RequestPause("TOK1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tt.GetPlainAction().GetPlainPause()).To(Equal(&token.PlainGovernance{Type: "TOK1"}))
+
+		tt, err = issuer.RequestResume("TOK1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tt.GetPlainAction().GetPlainResume()).To(Equal(&token.PlainGovernance{Type: "TOK1"}))
+	})
+
+	It("creates manifest transactions tying together the chunks of a batch", func() {
+		chunks := []*token.IssueChunk{{TxId: "tx-1", SeriesId: []byte("batch-1/0")}}
+		tt, err := issuer.RequestIssueManifest([]byte("batch-1"), chunks)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tt.GetPlainAction().GetPlainIssueManifest()).To(Equal(&token.PlainIssueManifest{BatchId: []byte("batch-1"), Chunks: chunks}))
+	})
 })
@@ -0,0 +1,94 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/hyperledger/fabric/token/identity"
+	"github.com/hyperledger/fabric/token/ledger"
+)
+
+const tokenManifest = "tokenManifest"
+
+// checkIssueManifest checks that every chunk of the manifest was imported by
+// the issuer in the transaction it names, and that the issuer has not already
+// recorded a manifest for the batch.
+func (v *Verifier) checkIssueManifest(creator identity.PublicInfo, manifest *token.PlainIssueManifest, txID string, simulator ledger.LedgerReader) error {
+	batchID := manifest.GetBatchId()
+	if len(batchID) == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("no batch ID in manifest transaction: %s", txID)}
+	}
+	if len(batchID) > MaxSeriesIDSize {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("batch ID of transaction '%s' exceeds %d bytes", txID, MaxSeriesIDSize)}
+	}
+	if len(manifest.GetChunks()) == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("no chunks in manifest transaction: %s", txID)}
+	}
+
+	manifestKey, err := createManifestKey(creator.Public(), batchID)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating manifest key: %s", err)}
+	}
+	manifestTxID, err := simulator.GetState(tk.Namespace, manifestKey)
+	if err != nil {
+		return err
+	}
+	if manifestTxID != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("batch '%x' of transaction '%s' already has a manifest in transaction '%s'", batchID, txID, manifestTxID)}
+	}
+
+	seen := map[string]bool{}
+	for i, chunk := range manifest.GetChunks() {
+		if len(chunk.GetSeriesId()) == 0 || chunk.GetTxId() == "" {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("chunk %d of manifest transaction '%s' has no series ID or transaction ID", i, txID)}
+		}
+		if seen[string(chunk.GetSeriesId())] {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("series '%x' listed more than once in manifest transaction '%s'", chunk.GetSeriesId(), txID)}
+		}
+		seen[string(chunk.GetSeriesId())] = true
+
+		seriesKey, err := createSeriesKey(creator.Public(), chunk.GetSeriesId())
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating series key: %s", err)}
+		}
+		importTxID, err := simulator.GetState(tk.Namespace, seriesKey)
+		if err != nil {
+			return err
+		}
+		if string(importTxID) != chunk.GetTxId() {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("chunk %d of manifest transaction '%s' was not imported by the issuer in transaction '%s'", i, txID, chunk.GetTxId())}
+		}
+	}
+	return nil
+}
+
+// commitIssueManifest records the manifest of the batch, if the transaction
+// is a manifest, as recorded by the creator of the transaction.
+func (v *Verifier) commitIssueManifest(txID string, creator identity.PublicInfo, ttx *token.TokenTransaction, simulator ledger.LedgerWriter) error {
+	manifest := ttx.GetPlainAction().GetPlainIssueManifest()
+	if manifest == nil {
+		return nil
+	}
+	manifestKey, err := createManifestKey(creator.Public(), manifest.GetBatchId())
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating manifest key: %s", err)}
+	}
+	return simulator.SetState(tk.Namespace, manifestKey, []byte(txID))
+}
+
+// Create a ledger key recording the manifest of a batch of an issuer, encoded
+// like the series keys.
+func createManifestKey(issuer []byte, batchID []byte) (string, error) {
+	issuerHash := sha256.Sum256(issuer)
+	return createCompositeKey(tokenManifest, []string{hex.EncodeToString(issuerHash[:]), hex.EncodeToString(batchID)})
+}
@@ -0,0 +1,110 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/token"
+	mockid "github.com/hyperledger/fabric/token/identity/mock"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Issue manifest", func() {
+	var (
+		fakePublicInfo *mockid.PublicInfo
+		memoryLedger   *plain.MemoryLedger
+		verifier       *plain.Verifier
+		chunks         []*token.IssueChunk
+		manifestKey    string
+	)
+
+	newImport := func(seriesID string) *token.TokenTransaction {
+		ttx, err := (&plain.Issuer{}).RequestImport([]*token.TokenToIssue{{Recipient: []byte("owner-1"), Type: "XYZ", Quantity: 100}})
+		Expect(err).NotTo(HaveOccurred())
+		ttx.GetPlainAction().GetPlainImport().SeriesId = []byte(seriesID)
+		return ttx
+	}
+
+	newManifest := func(batchID string, chunks []*token.IssueChunk) *token.TokenTransaction {
+		ttx, err := (&plain.Issuer{}).RequestIssueManifest([]byte(batchID), chunks)
+		Expect(err).NotTo(HaveOccurred())
+		return ttx
+	}
+
+	BeforeEach(func() {
+		fakePublicInfo = &mockid.PublicInfo{}
+		fakePublicInfo.PublicReturns([]byte("issuer-1"))
+		memoryLedger = plain.NewMemoryLedger()
+		verifier = &plain.Verifier{IssuingValidator: &mockid.IssuingValidator{}}
+
+		Expect(verifier.ProcessTx("0", fakePublicInfo, newImport("batch-1/0"), memoryLedger)).To(Succeed())
+		Expect(verifier.ProcessTx("1", fakePublicInfo, newImport("batch-1/1"), memoryLedger)).To(Succeed())
+		chunks = []*token.IssueChunk{
+			{TxId: "0", SeriesId: []byte("batch-1/0")},
+			{TxId: "1", SeriesId: []byte("batch-1/1")},
+		}
+
+		issuerHash := sha256.Sum256([]byte("issuer-1"))
+		manifestKey = "\x00tokenManifest\x00" + hex.EncodeToString(issuerHash[:]) + "\x00" + hex.EncodeToString([]byte("batch-1")) + "\x00"
+	})
+
+	It("records the manifest of the batch", func() {
+		err := verifier.ProcessTx("2", fakePublicInfo, newManifest("batch-1", chunks), memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+
+		txID, err := memoryLedger.GetState("tms", manifestKey)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(txID).To(Equal([]byte("2")))
+	})
+
+	It("rejects a second manifest of the batch", func() {
+		Expect(verifier.ProcessTx("2", fakePublicInfo, newManifest("batch-1", chunks), memoryLedger)).To(Succeed())
+
+		err := verifier.ProcessTx("3", fakePublicInfo, newManifest("batch-1", chunks[:1]), memoryLedger)
+		Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "batch '62617463682d31' of transaction '3' already has a manifest in transaction '2'"}))
+	})
+
+	It("rejects chunks not imported by the issuer in the named transaction", func() {
+		otherIssuer := &mockid.PublicInfo{}
+		otherIssuer.PublicReturns([]byte("issuer-2"))
+		err := verifier.ProcessTx("2", otherIssuer, newManifest("batch-1", chunks), memoryLedger)
+		Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "chunk 0 of manifest transaction '2' was not imported by the issuer in transaction '0'"}))
+
+		chunks[1].TxId = "0"
+		err = verifier.ProcessTx("2", fakePublicInfo, newManifest("batch-1", chunks), memoryLedger)
+		Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "chunk 1 of manifest transaction '2' was not imported by the issuer in transaction '0'"}))
+
+		output, err := memoryLedger.GetState("tms", manifestKey)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).To(BeNil())
+	})
+
+	It("rejects chunks listed more than once", func() {
+		err := verifier.ProcessTx("2", fakePublicInfo, newManifest("batch-1", append(chunks, chunks[0])), memoryLedger)
+		Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "series '62617463682d312f30' listed more than once in manifest transaction '2'"}))
+	})
+
+	It("rejects malformed manifests", func() {
+		err := verifier.ProcessTx("2", fakePublicInfo, newManifest("", chunks), memoryLedger)
+		Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "no batch ID in manifest transaction: 2"}))
+
+		err = verifier.ProcessTx("2", fakePublicInfo, newManifest(string(bytes.Repeat([]byte{1}, plain.MaxSeriesIDSize+1)), chunks), memoryLedger)
+		Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "batch ID of transaction '2' exceeds 128 bytes"}))
+
+		err = verifier.ProcessTx("2", fakePublicInfo, newManifest("batch-1", nil), memoryLedger)
+		Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "no chunks in manifest transaction: 2"}))
+
+		err = verifier.ProcessTx("2", fakePublicInfo, newManifest("batch-1", []*token.IssueChunk{{TxId: "0"}}), memoryLedger)
+		Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "chunk 0 of manifest transaction '2' has no series ID or transaction ID"}))
+	})
+})
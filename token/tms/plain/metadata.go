@@ -0,0 +1,161 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+// The types of the fields of token metadata.
+const (
+	MetadataString  = "string"
+	MetadataNumber  = "number"
+	MetadataBoolean = "boolean"
+	// MetadataDate is a string holding a date formatted as YYYY-MM-DD.
+	MetadataDate = "date"
+)
+
+// DefaultMetadataMaxSize is the maximum size in bytes of the metadata of an
+// output when its schema does not set one.
+const DefaultMetadataMaxSize = 1024
+
+// A MetadataSchema describes the metadata attached to the outputs of a token
+// type. Metadata is a JSON object whose fields are described by the schema.
+type MetadataSchema struct {
+	// Fields are the fields the metadata may contain, by name.
+	Fields map[string]*MetadataField
+	// Respecifiable lets transfers and redemptions re-specify the metadata of
+	// their outputs; otherwise the outputs carry the metadata of the inputs.
+	Respecifiable bool
+	// MaxSize is the maximum size in bytes of the metadata; 0 means
+	// DefaultMetadataMaxSize.
+	MaxSize int
+}
+
+// A MetadataField describes a field of token metadata.
+type MetadataField struct {
+	// Type is one of MetadataString, MetadataNumber, MetadataBoolean and
+	// MetadataDate.
+	Type string
+	// Required fields must be present in the metadata of every output.
+	Required bool
+}
+
+// Validate returns an error if the metadata does not conform to the schema.
+// Empty metadata conforms to the schemas with no required field.
+func (s *MetadataSchema) Validate(metadata []byte) error {
+	maxSize := s.MaxSize
+	if maxSize <= 0 {
+		maxSize = DefaultMetadataMaxSize
+	}
+	if len(metadata) > maxSize {
+		return errors.Errorf("metadata size %d exceeds the maximum of %d bytes", len(metadata), maxSize)
+	}
+
+	values := map[string]interface{}{}
+	if len(metadata) != 0 {
+		if err := json.Unmarshal(metadata, &values); err != nil {
+			return errors.Wrap(err, "metadata is not a JSON object")
+		}
+	}
+
+	var names []string
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		field, ok := s.Fields[name]
+		if !ok || field == nil {
+			return errors.Errorf("unknown metadata field '%s'", name)
+		}
+		if err := checkMetadataValue(field.Type, values[name]); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("invalid metadata field '%s'", name))
+		}
+	}
+
+	names = nil
+	for name := range s.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := values[name]; !ok && s.Fields[name] != nil && s.Fields[name].Required {
+			return errors.Errorf("missing required metadata field '%s'", name)
+		}
+	}
+	return nil
+}
+
+func checkMetadataValue(fieldType string, value interface{}) error {
+	ok := false
+	switch fieldType {
+	case MetadataString:
+		_, ok = value.(string)
+	case MetadataNumber:
+		_, ok = value.(float64)
+	case MetadataBoolean:
+		_, ok = value.(bool)
+	case MetadataDate:
+		var s string
+		if s, ok = value.(string); ok {
+			if _, err := time.Parse("2006-01-02", s); err != nil {
+				return errors.Errorf("'%s' is not a date formatted as YYYY-MM-DD", s)
+			}
+		}
+	default:
+		return errors.Errorf("unknown field type '%s'", fieldType)
+	}
+	if !ok {
+		return errors.Errorf("value %v is not a %s", value, fieldType)
+	}
+	return nil
+}
+
+// checkTransferMetadata checks the metadata of the outputs of a transfer or a
+// redemption: the outputs carry the metadata of the inputs, unless the schema
+// of their token type lets them re-specify it.
+func (v *Verifier) checkTransferMetadata(outputs []*token.PlainOutput, inputMetadata []byte, txID string) error {
+	if len(outputs) == 0 {
+		return nil
+	}
+	schema := v.MetadataSchemas[outputs[0].GetType()]
+	for i, output := range outputs {
+		if schema != nil && schema.Respecifiable {
+			if err := v.checkOutputMetadata(output, i, txID); err != nil {
+				return err
+			}
+		} else if !bytes.Equal(output.GetMetadata(), inputMetadata) {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("output %d does not carry the metadata of the inputs in transaction: %s", i, txID)}
+		}
+	}
+	return nil
+}
+
+// checkOutputMetadata checks the metadata of an output against the schema of
+// its token type; token types without a schema carry no metadata.
+func (v *Verifier) checkOutputMetadata(output *token.PlainOutput, index int, txID string) error {
+	schema := v.MetadataSchemas[output.GetType()]
+	if schema == nil {
+		if len(output.GetMetadata()) != 0 {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("output %d has metadata but token type '%s' has no metadata schema in transaction: %s", index, output.GetType(), txID)}
+		}
+		return nil
+	}
+	if err := schema.Validate(output.GetMetadata()); err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("output %d metadata is invalid in transaction %s: %s", index, txID, err)}
+	}
+	return nil
+}
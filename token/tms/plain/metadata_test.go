@@ -0,0 +1,180 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain_test
+
+import (
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/token"
+	mockid "github.com/hyperledger/fabric/token/identity/mock"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MetadataSchema", func() {
+	var schema *plain.MetadataSchema
+
+	BeforeEach(func() {
+		schema = &plain.MetadataSchema{
+			Fields: map[string]*plain.MetadataField{
+				"maturity": {Type: plain.MetadataDate, Required: true},
+				"coupon":   {Type: plain.MetadataNumber},
+				"issuer":   {Type: plain.MetadataString},
+				"callable": {Type: plain.MetadataBoolean},
+			},
+		}
+	})
+
+	It("accepts the metadata conforming to the schema", func() {
+		Expect(schema.Validate([]byte(`{"maturity":"2030-06-30","coupon":2.5,"issuer":"bank1","callable":false}`))).To(Succeed())
+		Expect(schema.Validate([]byte(`{"maturity":"2030-06-30"}`))).To(Succeed())
+	})
+
+	It("rejects the metadata that does not conform to the schema", func() {
+		Expect(schema.Validate(nil)).To(MatchError("missing required metadata field 'maturity'"))
+		Expect(schema.Validate([]byte(`[1]`))).To(MatchError(ContainSubstring("metadata is not a JSON object")))
+		Expect(schema.Validate([]byte(`{"maturity":"2030-06-30","rating":"AAA"}`))).To(MatchError("unknown metadata field 'rating'"))
+		Expect(schema.Validate([]byte(`{"maturity":"30/06/2030"}`))).To(MatchError("invalid metadata field 'maturity': '30/06/2030' is not a date formatted as YYYY-MM-DD"))
+		Expect(schema.Validate([]byte(`{"maturity":"2030-06-30","coupon":"2.5"}`))).To(MatchError("invalid metadata field 'coupon': value 2.5 is not a number"))
+		Expect(schema.Validate([]byte(`{"maturity":"2030-06-30","callable":1}`))).To(MatchError("invalid metadata field 'callable': value 1 is not a boolean"))
+	})
+
+	It("limits the size of the metadata", func() {
+		schema.MaxSize = 16
+		Expect(schema.Validate([]byte(`{"maturity":"2030-06-30"}`))).To(MatchError("metadata size 25 exceeds the maximum of 16 bytes"))
+	})
+
+	It("accepts empty metadata when no field is required", func() {
+		schema.Fields["maturity"].Required = false
+		Expect(schema.Validate(nil)).To(Succeed())
+	})
+})
+
+var _ = Describe("Output metadata", func() {
+	var (
+		fakePublicInfo *mockid.PublicInfo
+		memoryLedger   *plain.MemoryLedger
+		verifier       *plain.Verifier
+		transactor     *plain.Transactor
+		metadata       []byte
+	)
+
+	plainTx := func(action *token.PlainTokenAction) *token.TokenTransaction {
+		return &token.TokenTransaction{Action: &token.TokenTransaction_PlainAction{PlainAction: action}}
+	}
+
+	importTx := func(outputs ...*token.PlainOutput) *token.TokenTransaction {
+		return plainTx(&token.PlainTokenAction{Data: &token.PlainTokenAction_PlainImport{PlainImport: &token.PlainImport{Outputs: outputs}}})
+	}
+
+	transferTx := func(inputs []*token.InputId, outputs ...*token.PlainOutput) *token.TokenTransaction {
+		return plainTx(&token.PlainTokenAction{Data: &token.PlainTokenAction_PlainTransfer{PlainTransfer: &token.PlainTransfer{Inputs: inputs, Outputs: outputs}}})
+	}
+
+	tokenID := func(txID string, index int) []byte {
+		key, err := plain.GenerateKeyForTest(txID, index)
+		Expect(err).NotTo(HaveOccurred())
+		return []byte(key)
+	}
+
+	BeforeEach(func() {
+		fakePublicInfo = &mockid.PublicInfo{}
+		fakePublicInfo.PublicReturns([]byte("owner-1"))
+		memoryLedger = plain.NewMemoryLedger()
+		verifier = &plain.Verifier{
+			IssuingValidator: &mockid.IssuingValidator{},
+			MetadataSchemas: map[string]*plain.MetadataSchema{
+				"BOND": {Fields: map[string]*plain.MetadataField{"maturity": {Type: plain.MetadataDate, Required: true}}},
+			},
+		}
+		transactor = &plain.Transactor{PublicCredential: []byte("owner-1"), Ledger: memoryLedger}
+		metadata = []byte(`{"maturity":"2030-06-30"}`)
+
+		err := verifier.ProcessTx("0", fakePublicInfo, importTx(
+			&token.PlainOutput{Owner: []byte("owner-1"), Type: "BOND", Quantity: 100, Metadata: metadata},
+			&token.PlainOutput{Owner: []byte("owner-1"), Type: "BOND", Quantity: 50, Metadata: metadata},
+		), memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("validates the metadata of issued outputs against the schema of their type", func() {
+		err := verifier.ProcessTx("1", fakePublicInfo, importTx(&token.PlainOutput{Owner: []byte("owner-1"), Type: "BOND", Quantity: 100}), memoryLedger)
+		Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "output 0 metadata is invalid in transaction 1: missing required metadata field 'maturity'"}))
+
+		err = verifier.ProcessTx("1", fakePublicInfo, importTx(&token.PlainOutput{Owner: []byte("owner-1"), Type: "USD", Quantity: 100, Metadata: metadata}), memoryLedger)
+		Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "output 0 has metadata but token type 'USD' has no metadata schema in transaction: 1"}))
+	})
+
+	It("preserves the metadata of the inputs across transfers", func() {
+		tx, err := transactor.RequestTransfer(&token.TransferRequest{
+			TokenIds: [][]byte{tokenID("0", 0), tokenID("0", 1)},
+			Shares:   []*token.RecipientTransferShare{{Recipient: []byte("owner-2"), Quantity: 150}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tx.GetPlainAction().GetPlainTransfer().Outputs[0].Metadata).To(Equal(metadata))
+		Expect(verifier.ProcessTx("1", fakePublicInfo, tx, memoryLedger)).To(Succeed())
+	})
+
+	It("rejects transfers altering the metadata when the schema is not respecifiable", func() {
+		tx := transferTx([]*token.InputId{{TxId: "0", Index: 0}},
+			&token.PlainOutput{Owner: []byte("owner-2"), Type: "BOND", Quantity: 100, Metadata: []byte(`{"maturity":"2031-06-30"}`)},
+		)
+		err := verifier.ProcessTx("1", fakePublicInfo, tx, memoryLedger)
+		Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "output 0 does not carry the metadata of the inputs in transaction: 1"}))
+	})
+
+	It("lets transfers re-specify the metadata when the schema is respecifiable", func() {
+		verifier.MetadataSchemas["BOND"].Respecifiable = true
+		tx, err := transactor.RequestTransfer(&token.TransferRequest{
+			TokenIds: [][]byte{tokenID("0", 0)},
+			Shares:   []*token.RecipientTransferShare{{Recipient: []byte("owner-2"), Quantity: 100, Metadata: []byte(`{"maturity":"2031-06-30"}`)}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(verifier.ProcessTx("1", fakePublicInfo, tx, memoryLedger)).To(Succeed())
+
+		tx = transferTx([]*token.InputId{{TxId: "1", Index: 0}},
+			&token.PlainOutput{Owner: []byte("owner-2"), Type: "BOND", Quantity: 100, Metadata: []byte(`{"maturity":"soon"}`)},
+		)
+		fakePublicInfo.PublicReturns([]byte("owner-2"))
+		err = verifier.ProcessTx("2", fakePublicInfo, tx, memoryLedger)
+		Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "output 0 metadata is invalid in transaction 2: invalid metadata field 'maturity': 'soon' is not a date formatted as YYYY-MM-DD"}))
+	})
+
+	It("carries the metadata of the inputs to the outputs of redemptions", func() {
+		tx, err := transactor.RequestRedeem(&token.RedeemRequest{
+			TokenIds:         [][]byte{tokenID("0", 0)},
+			QuantityToRedeem: 100,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tx.GetPlainAction().GetPlainRedeem().Outputs[0].Metadata).To(Equal(metadata))
+		Expect(verifier.ProcessTx("1", fakePublicInfo, tx, memoryLedger)).To(Succeed())
+	})
+
+	It("rejects inputs with different metadata", func() {
+		err := verifier.ProcessTx("1", fakePublicInfo, importTx(
+			&token.PlainOutput{Owner: []byte("owner-1"), Type: "BOND", Quantity: 10, Metadata: []byte(`{"maturity":"2031-06-30"}`)},
+		), memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+
+		tx := transferTx([]*token.InputId{{TxId: "0", Index: 0}, {TxId: "1", Index: 0}},
+			&token.PlainOutput{Owner: []byte("owner-2"), Type: "BOND", Quantity: 110, Metadata: metadata},
+		)
+		err = verifier.ProcessTx("2", fakePublicInfo, tx, memoryLedger)
+		Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "inputs with different metadata in transaction: 2"}))
+	})
+
+	It("rejects the approval of tokens with metadata", func() {
+		tx := plainTx(&token.PlainTokenAction{Data: &token.PlainTokenAction_PlainApprove{PlainApprove: &token.PlainApprove{
+			Inputs: []*token.InputId{{TxId: "0", Index: 0}},
+			DelegatedOutputs: []*token.PlainDelegatedOutput{
+				{Owner: []byte("owner-1"), Delegatees: [][]byte{[]byte("owner-2")}, Type: "BOND", Quantity: 100},
+			},
+		}}})
+		err := verifier.ProcessTx("1", fakePublicInfo, tx, memoryLedger)
+		Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "tokens with metadata cannot be approved in transaction: 1"}))
+	})
+})
@@ -0,0 +1,117 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/hyperledger/fabric/token/ledger"
+	"github.com/pkg/errors"
+)
+
+const (
+	tokenReference = "tokenReference"
+
+	// MaxApplicationReferenceSize is the maximum size in bytes of the
+	// application reference of a token transaction.
+	MaxApplicationReferenceSize = 256
+)
+
+func (v *Verifier) checkApplicationReference(ttx *token.TokenTransaction, txID string) error {
+	if len(ttx.GetApplicationReference()) > MaxApplicationReferenceSize {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("application reference of transaction '%s' exceeds %d bytes", txID, MaxApplicationReferenceSize)}
+	}
+	return nil
+}
+
+// commitApplicationReference indexes the transaction by its application reference, if any.
+func (v *Verifier) commitApplicationReference(txID string, ttx *token.TokenTransaction, simulator ledger.LedgerWriter) error {
+	reference := ttx.GetApplicationReference()
+	if len(reference) == 0 {
+		return nil
+	}
+	referenceKey, err := createReferenceKey(reference, txID)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating reference key: %s", err)}
+	}
+	return simulator.SetState(tk.Namespace, referenceKey, []byte(txID))
+}
+
+// ListTransactionsByReference returns the committed token transactions carrying the passed application reference.
+func (t *Transactor) ListTransactionsByReference(reference []byte) (*token.ReferencedTransactions, error) {
+	if len(reference) == 0 {
+		return nil, errors.New("application reference is required")
+	}
+
+	startKey, err := createCompositeKey(tokenReference, []string{hex.EncodeToString(reference)})
+	if err != nil {
+		return nil, err
+	}
+	iterator, err := t.Ledger.GetStateRangeScanIterator(tk.Namespace, startKey, startKey+string(maxUnicodeRuneValue))
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	transactions := make([]*token.ReferencedTransaction, 0)
+	for {
+		next, err := iterator.Next()
+
+		switch {
+		case err != nil:
+			return nil, err
+
+		case next == nil:
+			// nil response from iterator indicates end of query results
+			return &token.ReferencedTransactions{Transactions: transactions}, nil
+
+		default:
+			result, ok := next.(*queryresult.KV)
+			if !ok {
+				return nil, errors.New("failed to retrieve referenced transactions: casting error")
+			}
+			txID := string(result.Value)
+			ttx, err := t.getTransaction(txID)
+			if err != nil {
+				return nil, err
+			}
+			transactions = append(transactions, &token.ReferencedTransaction{TxId: txID, TokenTransaction: ttx})
+		}
+	}
+}
+
+func (t *Transactor) getTransaction(txID string) (*token.TokenTransaction, error) {
+	txKey, err := createTxKey(txID)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := t.Ledger.GetState(tk.Namespace, txKey)
+	if err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, errors.Errorf("transaction '%s' does not exist", txID)
+	}
+	ttx := &token.TokenTransaction{}
+	err = proto.Unmarshal(raw, ttx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed unmarshaling transaction '%s'", txID)
+	}
+	return ttx, nil
+}
+
+// Create a ledger key indexing a token transaction by its application reference.
+// The reference is hex encoded since the attributes of composite keys must be utf8 strings.
+func createReferenceKey(reference []byte, txID string) (string, error) {
+	return createCompositeKey(tokenReference, []string{hex.EncodeToString(reference), txID})
+}
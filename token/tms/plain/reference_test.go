@@ -0,0 +1,131 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain_test
+
+import (
+	"bytes"
+	"encoding/hex"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/token"
+	mockid "github.com/hyperledger/fabric/token/identity/mock"
+	"github.com/hyperledger/fabric/token/ledger/mock"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Application references", func() {
+	var (
+		fakePublicInfo *mockid.PublicInfo
+		memoryLedger   *plain.MemoryLedger
+		verifier       *plain.Verifier
+		importTx       *token.TokenTransaction
+		referenceKey   string
+	)
+
+	BeforeEach(func() {
+		fakePublicInfo = &mockid.PublicInfo{}
+		fakePublicInfo.PublicReturns([]byte("owner-1"))
+		memoryLedger = plain.NewMemoryLedger()
+		verifier = &plain.Verifier{IssuingValidator: &mockid.IssuingValidator{}}
+
+		var err error
+		importTx, err = (&plain.Issuer{}).RequestImport([]*token.TokenToIssue{{Recipient: []byte("owner-1"), Type: "XYZ", Quantity: 100}})
+		Expect(err).NotTo(HaveOccurred())
+		importTx.ApplicationReference = []byte("invoice-42")
+
+		referenceKey = "\x00tokenReference\x00" + hex.EncodeToString([]byte("invoice-42")) + "\x000\x00"
+	})
+
+	It("indexes transactions by application reference", func() {
+		err := verifier.ProcessTx("0", fakePublicInfo, importTx, memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+
+		txID, err := memoryLedger.GetState("tms", referenceKey)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(txID).To(Equal([]byte("0")))
+	})
+
+	Context("when the application reference is too large", func() {
+		BeforeEach(func() {
+			importTx.ApplicationReference = bytes.Repeat([]byte{1}, plain.MaxApplicationReferenceSize+1)
+		})
+
+		It("rejects the transaction", func() {
+			err := verifier.ProcessTx("0", fakePublicInfo, importTx, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "application reference of transaction '0' exceeds 256 bytes"}))
+		})
+	})
+
+	Describe("ListTransactionsByReference", func() {
+		var (
+			fakeLedger   *mock.LedgerReader
+			fakeIterator *mock.ResultsIterator
+			transactor   *plain.Transactor
+		)
+
+		BeforeEach(func() {
+			err := verifier.ProcessTx("0", fakePublicInfo, importTx, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeIterator = &mock.ResultsIterator{}
+			fakeIterator.NextReturnsOnCall(0, &queryresult.KV{Key: referenceKey, Value: []byte("0")}, nil)
+			fakeLedger = &mock.LedgerReader{}
+			fakeLedger.GetStateRangeScanIteratorReturns(fakeIterator, nil)
+			fakeLedger.GetStateStub = memoryLedger.GetState
+			transactor = &plain.Transactor{PublicCredential: []byte("owner-2"), Ledger: fakeLedger}
+		})
+
+		It("returns the transactions carrying the reference", func() {
+			transactions, err := transactor.ListTransactionsByReference([]byte("invoice-42"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transactions.Transactions).To(HaveLen(1))
+			Expect(transactions.Transactions[0].TxId).To(Equal("0"))
+			Expect(proto.Equal(transactions.Transactions[0].TokenTransaction, importTx)).To(BeTrue())
+
+			Expect(fakeLedger.GetStateRangeScanIteratorCallCount()).To(Equal(1))
+			namespace, startKey, endKey := fakeLedger.GetStateRangeScanIteratorArgsForCall(0)
+			Expect(namespace).To(Equal("tms"))
+			Expect(startKey).To(Equal("\x00tokenReference\x00" + hex.EncodeToString([]byte("invoice-42")) + "\x00"))
+			Expect(endKey).To(Equal(startKey + "\U0010FFFF"))
+			Expect(fakeIterator.CloseCallCount()).To(Equal(1))
+		})
+
+		Context("when the reference is empty", func() {
+			It("returns an error", func() {
+				_, err := transactor.ListTransactionsByReference(nil)
+				Expect(err).To(MatchError("application reference is required"))
+			})
+		})
+
+		Context("when the iterator fails", func() {
+			BeforeEach(func() {
+				fakeIterator.NextReturnsOnCall(0, nil, errors.New("boom"))
+			})
+
+			It("returns the error", func() {
+				_, err := transactor.ListTransactionsByReference([]byte("invoice-42"))
+				Expect(err).To(MatchError("boom"))
+			})
+		})
+
+		Context("when the indexed transaction does not exist", func() {
+			BeforeEach(func() {
+				fakeIterator.NextReturnsOnCall(0, &queryresult.KV{Key: referenceKey, Value: []byte("1")}, nil)
+			})
+
+			It("returns an error", func() {
+				_, err := transactor.ListTransactionsByReference([]byte("invoice-42"))
+				Expect(err).To(MatchError("transaction '1' does not exist"))
+			})
+		})
+	})
+})
@@ -0,0 +1,73 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/hyperledger/fabric/token/identity"
+	"github.com/hyperledger/fabric/token/ledger"
+)
+
+const (
+	tokenSeries = "tokenSeries"
+
+	// MaxSeriesIDSize is the maximum size in bytes of the series ID of an import.
+	MaxSeriesIDSize = 128
+)
+
+// checkImportSeries checks that the issuer has not already imported the
+// series of the import, if any, so that retried imports cannot mint twice.
+func (v *Verifier) checkImportSeries(creator identity.PublicInfo, importAction *token.PlainImport, txID string, simulator ledger.LedgerReader) error {
+	seriesID := importAction.GetSeriesId()
+	if len(seriesID) == 0 {
+		return nil
+	}
+	if len(seriesID) > MaxSeriesIDSize {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("series ID of transaction '%s' exceeds %d bytes", txID, MaxSeriesIDSize)}
+	}
+
+	seriesKey, err := createSeriesKey(creator.Public(), seriesID)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating series key: %s", err)}
+	}
+	importTxID, err := simulator.GetState(tk.Namespace, seriesKey)
+	if err != nil {
+		return err
+	}
+	if importTxID != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("series '%x' of transaction '%s' already imported in transaction '%s'", seriesID, txID, importTxID)}
+	}
+	return nil
+}
+
+// commitImportSeries records the series of the import, if any, as imported by
+// the creator of the transaction.
+func (v *Verifier) commitImportSeries(txID string, creator identity.PublicInfo, ttx *token.TokenTransaction, simulator ledger.LedgerWriter) error {
+	seriesID := ttx.GetPlainAction().GetPlainImport().GetSeriesId()
+	if len(seriesID) == 0 {
+		return nil
+	}
+	seriesKey, err := createSeriesKey(creator.Public(), seriesID)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating series key: %s", err)}
+	}
+	return simulator.SetState(tk.Namespace, seriesKey, []byte(txID))
+}
+
+// Create a ledger key recording the import of a series by an issuer.
+// The issuer is identified by the hash of its public identity, and the series ID
+// is hex encoded since the attributes of composite keys must be utf8 strings.
+func createSeriesKey(issuer []byte, seriesID []byte) (string, error) {
+	issuerHash := sha256.Sum256(issuer)
+	return createCompositeKey(tokenSeries, []string{hex.EncodeToString(issuerHash[:]), hex.EncodeToString(seriesID)})
+}
@@ -0,0 +1,111 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/token"
+	mockid "github.com/hyperledger/fabric/token/identity/mock"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Import series", func() {
+	var (
+		fakePublicInfo *mockid.PublicInfo
+		memoryLedger   *plain.MemoryLedger
+		verifier       *plain.Verifier
+		importTx       *token.TokenTransaction
+		seriesKey      string
+	)
+
+	newImport := func(seriesID []byte) *token.TokenTransaction {
+		ttx, err := (&plain.Issuer{}).RequestImport([]*token.TokenToIssue{{Recipient: []byte("owner-1"), Type: "XYZ", Quantity: 100}})
+		Expect(err).NotTo(HaveOccurred())
+		ttx.GetPlainAction().GetPlainImport().SeriesId = seriesID
+		return ttx
+	}
+
+	BeforeEach(func() {
+		fakePublicInfo = &mockid.PublicInfo{}
+		fakePublicInfo.PublicReturns([]byte("issuer-1"))
+		memoryLedger = plain.NewMemoryLedger()
+		verifier = &plain.Verifier{IssuingValidator: &mockid.IssuingValidator{}}
+		importTx = newImport([]byte("batch-7"))
+
+		issuerHash := sha256.Sum256([]byte("issuer-1"))
+		seriesKey = "\x00tokenSeries\x00" + hex.EncodeToString(issuerHash[:]) + "\x00" + hex.EncodeToString([]byte("batch-7")) + "\x00"
+	})
+
+	It("records the series of the import", func() {
+		err := verifier.ProcessTx("0", fakePublicInfo, importTx, memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+
+		txID, err := memoryLedger.GetState("tms", seriesKey)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(txID).To(Equal([]byte("0")))
+	})
+
+	Context("when the issuer already imported the series", func() {
+		BeforeEach(func() {
+			err := verifier.ProcessTx("0", fakePublicInfo, importTx, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects the import", func() {
+			err := verifier.ProcessTx("1", fakePublicInfo, newImport([]byte("batch-7")), memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "series '62617463682d37' of transaction '1' already imported in transaction '0'"}))
+
+			output, err := memoryLedger.GetState("tms", "\x00tokenOutput\x001\x000\x00")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(BeNil())
+		})
+
+		It("accepts an import of another series", func() {
+			err := verifier.ProcessTx("1", fakePublicInfo, newImport([]byte("batch-8")), memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("accepts an import of the series by another issuer", func() {
+			otherIssuer := &mockid.PublicInfo{}
+			otherIssuer.PublicReturns([]byte("issuer-2"))
+
+			err := verifier.ProcessTx("1", otherIssuer, newImport([]byte("batch-7")), memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("when the import has no series ID", func() {
+		BeforeEach(func() {
+			importTx = newImport(nil)
+		})
+
+		It("does not record a series", func() {
+			err := verifier.ProcessTx("0", fakePublicInfo, importTx, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = verifier.ProcessTx("1", fakePublicInfo, newImport(nil), memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("when the series ID is too large", func() {
+		BeforeEach(func() {
+			importTx = newImport(bytes.Repeat([]byte{1}, plain.MaxSeriesIDSize+1))
+		})
+
+		It("rejects the import", func() {
+			err := verifier.ProcessTx("0", fakePublicInfo, importTx, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "series ID of transaction '0' exceeds 128 bytes"}))
+		})
+	})
+})
@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"strconv"
+
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+)
+
+// Namespace is the namespace of the ledger state of the plain token transactions
+const Namespace = tk.Namespace
+
+// SpentKey returns the ledger key marking the input as spent.
+func SpentKey(input *token.InputId) (string, error) {
+	return createSpentKey(input.TxId, int(input.Index))
+}
+
+// SpentKeyRange returns the range of the ledger keys marking inputs as
+// spent, startKey included and endKey excluded.
+func SpentKeyRange() (startKey, endKey string) {
+	prefix, _ := createPrefix(tokenInput)
+	return prefix, prefix + string(maxUnicodeRuneValue)
+}
+
+// TokenID returns the ID of the token spent by the input, as listed by the
+// prover.
+func TokenID(input *token.InputId) ([]byte, error) {
+	outputKey, err := createOutputKey(input.TxId, int(input.Index))
+	if err != nil {
+		return nil, err
+	}
+	return getCompositeKeyBytes(outputKey), nil
+}
+
+// ParseTokenID returns the output of the ID of a token, as listed by the
+// prover, or nil if the ID is not the ID of an output.
+func ParseTokenID(id []byte) *token.InputId {
+	objectType, attributes, err := splitCompositeKey(parseCompositeKeyBytes(id))
+	if err != nil || objectType != tokenOutput || len(attributes) != 2 {
+		return nil
+	}
+	index, err := strconv.ParseUint(attributes[1], 10, 32)
+	if err != nil {
+		return nil
+	}
+	return &token.InputId{TxId: attributes[0], Index: uint32(index)}
+}
+
+// ParseSpentKey returns the input that the ledger key marks as spent, or nil
+// if the key does not mark an input as spent.
+func ParseSpentKey(key string) *token.InputId {
+	objectType, attributes, err := splitCompositeKey(key)
+	if err != nil || objectType != tokenInput || len(attributes) != 2 {
+		return nil
+	}
+	index, err := strconv.ParseUint(attributes[1], 10, 32)
+	if err != nil {
+		return nil
+	}
+	return &token.InputId{TxId: attributes[0], Index: uint32(index)}
+}
+
+// SpentInputs returns the inputs that the plain token transaction marks as
+// spent when it is committed.
+func SpentInputs(ttx *token.TokenTransaction) []*token.InputId {
+	action := ttx.GetPlainAction()
+	switch {
+	case action.GetPlainTransfer() != nil:
+		return action.GetPlainTransfer().GetInputs()
+	case action.GetPlainRedeem() != nil:
+		return action.GetPlainRedeem().GetInputs()
+	case action.GetPlainApprove() != nil:
+		return action.GetPlainApprove().GetInputs()
+	default:
+		return nil
+	}
+}
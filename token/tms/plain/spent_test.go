@@ -0,0 +1,112 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain_test
+
+import (
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Spent inputs", func() {
+	var inputs []*token.InputId
+
+	BeforeEach(func() {
+		inputs = []*token.InputId{{TxId: "tx0", Index: 0}, {TxId: "tx1", Index: 2}}
+	})
+
+	Describe("SpentKey and ParseSpentKey", func() {
+		It("round-trips the inputs", func() {
+			for _, input := range inputs {
+				key, err := plain.SpentKey(input)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(plain.ParseSpentKey(key)).To(Equal(input))
+			}
+		})
+
+		It("rejects invalid inputs", func() {
+			_, err := plain.SpentKey(&token.InputId{TxId: string(rune(0))})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("does not parse the keys that do not mark inputs as spent", func() {
+			Expect(plain.ParseSpentKey("tx0")).To(BeNil())
+			Expect(plain.ParseSpentKey("\x00tokenOutput\x00tx0\x000\x00")).To(BeNil())
+			Expect(plain.ParseSpentKey("\x00tokenInput\x00tx0\x00\x00")).To(BeNil())
+			Expect(plain.ParseSpentKey("\x00tokenInput\x00tx0\x00zero\x00")).To(BeNil())
+		})
+	})
+
+	Describe("SpentKeyRange", func() {
+		It("includes the keys marking inputs as spent", func() {
+			startKey, endKey := plain.SpentKeyRange()
+			for _, input := range inputs {
+				key, err := plain.SpentKey(input)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(key >= startKey && key < endKey).To(BeTrue())
+			}
+			Expect("\x00tokenOutput\x00tx0\x000\x00" >= startKey && "\x00tokenOutput\x00tx0\x000\x00" < endKey).To(BeFalse())
+		})
+	})
+
+	Describe("TokenID", func() {
+		It("returns the key of the output spent by the input", func() {
+			id, err := plain.TokenID(inputs[1])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal([]byte("\x00tokenOutput\x00tx1\x002\x00")))
+		})
+
+		It("rejects invalid inputs", func() {
+			_, err := plain.TokenID(&token.InputId{TxId: string(rune(0))})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("ParseTokenID", func() {
+		It("round-trips the inputs", func() {
+			for _, input := range inputs {
+				id, err := plain.TokenID(input)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(plain.ParseTokenID(id)).To(Equal(input))
+			}
+		})
+
+		It("does not parse the IDs of other keys", func() {
+			Expect(plain.ParseTokenID([]byte("tx0"))).To(BeNil())
+			Expect(plain.ParseTokenID([]byte("\x00tokenInput\x00tx0\x000\x00"))).To(BeNil())
+			Expect(plain.ParseTokenID([]byte("\x00tokenOutput\x00tx0\x00zero\x00"))).To(BeNil())
+		})
+	})
+
+	Describe("SpentInputs", func() {
+		It("returns the inputs of transfers, redemptions and approvals", func() {
+			transfer := &token.TokenTransaction{Action: &token.TokenTransaction_PlainAction{PlainAction: &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainTransfer{PlainTransfer: &token.PlainTransfer{Inputs: inputs}},
+			}}}
+			Expect(plain.SpentInputs(transfer)).To(Equal(inputs))
+
+			redeem := &token.TokenTransaction{Action: &token.TokenTransaction_PlainAction{PlainAction: &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainRedeem{PlainRedeem: &token.PlainTransfer{Inputs: inputs}},
+			}}}
+			Expect(plain.SpentInputs(redeem)).To(Equal(inputs))
+
+			approve := &token.TokenTransaction{Action: &token.TokenTransaction_PlainAction{PlainAction: &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainApprove{PlainApprove: &token.PlainApprove{Inputs: inputs}},
+			}}}
+			Expect(plain.SpentInputs(approve)).To(Equal(inputs))
+		})
+
+		It("returns no inputs for imports", func() {
+			importTx := &token.TokenTransaction{Action: &token.TokenTransaction_PlainAction{PlainAction: &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainImport{PlainImport: &token.PlainImport{}},
+			}}}
+			Expect(plain.SpentInputs(importTx)).To(BeNil())
+			Expect(plain.SpentInputs(&token.TokenTransaction{})).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,144 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// spentFilterVersion is the version of the encoding of a SpentFilter
+const spentFilterVersion = 1
+
+// SpentFilter is a bloom filter over the spent keys of the inputs spent on a
+// channel. It never misses a spent key that was added to it, but may report
+// a key that was not added with the false positive rate it was sized for, so
+// that a key it does not contain is known to be unspent without reading the
+// state DB, and a key it contains must be read to know whether it is spent.
+//
+// The committer still reads the spent keys from the state DB: the reads are
+// recorded in the read set of the transaction, which is what invalidates a
+// second transaction of the same block spending the same input.
+type SpentFilter struct {
+	mutex  sync.RWMutex
+	bits   []uint64
+	hashes uint32
+}
+
+// NewSpentFilter returns an empty filter sized to hold capacity keys with the
+// false positive rate.
+func NewSpentFilter(capacity int, falsePositiveRate float64) (*SpentFilter, error) {
+	if capacity <= 0 {
+		return nil, errors.Errorf("invalid spent filter capacity %d", capacity)
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		return nil, errors.Errorf("invalid spent filter false positive rate %g", falsePositiveRate)
+	}
+	// the optimal number of bits and of hash functions of a bloom filter
+	bits := math.Ceil(-float64(capacity) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	hashes := math.Max(1, math.Round(bits/float64(capacity)*math.Ln2))
+	return &SpentFilter{
+		bits:   make([]uint64, (uint64(bits)+63)/64),
+		hashes: uint32(hashes),
+	}, nil
+}
+
+// Add adds the spent key of an input to the filter.
+func (f *SpentFilter) Add(spentKey string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.locations(spentKey, func(word int, bit uint64) bool {
+		f.bits[word] |= bit
+		return true
+	})
+}
+
+// MayContain returns false if the spent key was never added to the filter,
+// and true if it may have been.
+func (f *SpentFilter) MayContain(spentKey string) bool {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	contained := true
+	f.locations(spentKey, func(word int, bit uint64) bool {
+		contained = f.bits[word]&bit != 0
+		return contained
+	})
+	return contained
+}
+
+// Saturate makes the filter contain every key, e.g. when the spent keys of
+// a transaction could not be added, so that they are read from the state DB.
+func (f *SpentFilter) Saturate() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for i := range f.bits {
+		f.bits[i] = math.MaxUint64
+	}
+}
+
+// locations calls fn with the word and the bit of each hash of the key, until
+// fn returns false. The hashes are derived from two 32 bit hashes of the key.
+func (f *SpentFilter) locations(key string, fn func(word int, bit uint64) bool) {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	sum := h.Sum64()
+	h1, h2 := uint32(sum), uint32(sum>>32)|1
+
+	size := uint64(len(f.bits)) * 64
+	for i := uint32(0); i < f.hashes; i++ {
+		location := uint64(h1+i*h2) % size
+		if !fn(int(location/64), 1<<(location%64)) {
+			return
+		}
+	}
+}
+
+// SameSize returns true if the filter has the size of other, that is they
+// were created with the same capacity and false positive rate.
+func (f *SpentFilter) SameSize(other *SpentFilter) bool {
+	return len(f.bits) == len(other.bits) && f.hashes == other.hashes
+}
+
+// MarshalBinary encodes the filter, e.g. to persist it.
+func (f *SpentFilter) MarshalBinary() ([]byte, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	data := make([]byte, 9+8*len(f.bits))
+	data[0] = spentFilterVersion
+	binary.BigEndian.PutUint32(data[1:], f.hashes)
+	binary.BigEndian.PutUint32(data[5:], uint32(len(f.bits)))
+	for i, word := range f.bits {
+		binary.BigEndian.PutUint64(data[9+8*i:], word)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes a filter encoded by MarshalBinary.
+func (f *SpentFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < 9 || data[0] != spentFilterVersion {
+		return errors.New("invalid spent filter encoding")
+	}
+	hashes := binary.BigEndian.Uint32(data[1:])
+	words := binary.BigEndian.Uint32(data[5:])
+	if hashes == 0 || words == 0 || uint64(len(data)) != 9+8*uint64(words) {
+		return errors.New("invalid spent filter encoding")
+	}
+	bits := make([]uint64, words)
+	for i := range bits {
+		bits[i] = binary.BigEndian.Uint64(data[9+8*i:])
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.bits = bits
+	f.hashes = hashes
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain_test
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/token/tms/plain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SpentFilter", func() {
+	var filter *plain.SpentFilter
+
+	BeforeEach(func() {
+		var err error
+		filter, err = plain.NewSpentFilter(1000, 0.01)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("contains the keys added to it", func() {
+		for i := 0; i < 1000; i++ {
+			filter.Add(fmt.Sprintf("spent-%d", i))
+		}
+		for i := 0; i < 1000; i++ {
+			Expect(filter.MayContain(fmt.Sprintf("spent-%d", i))).To(BeTrue())
+		}
+	})
+
+	It("does not contain most keys not added to it", func() {
+		for i := 0; i < 1000; i++ {
+			filter.Add(fmt.Sprintf("spent-%d", i))
+		}
+		falsePositives := 0
+		for i := 0; i < 10000; i++ {
+			if filter.MayContain(fmt.Sprintf("unspent-%d", i)) {
+				falsePositives++
+			}
+		}
+		// the expected rate is 1%, with some margin
+		Expect(falsePositives).To(BeNumerically("<", 300))
+	})
+
+	It("contains every key once saturated", func() {
+		Expect(filter.MayContain("key")).To(BeFalse())
+		filter.Saturate()
+		Expect(filter.MayContain("key")).To(BeTrue())
+	})
+
+	It("round-trips through its binary encoding", func() {
+		filter.Add("spent")
+		data, err := filter.MarshalBinary()
+		Expect(err).NotTo(HaveOccurred())
+
+		decoded := &plain.SpentFilter{}
+		err = decoded.UnmarshalBinary(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decoded.MayContain("spent")).To(BeTrue())
+		Expect(decoded.MayContain("unspent")).To(BeFalse())
+		Expect(decoded.SameSize(filter)).To(BeTrue())
+
+		other, err := plain.NewSpentFilter(2000, 0.01)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decoded.SameSize(other)).To(BeFalse())
+	})
+
+	It("rejects invalid encodings", func() {
+		data, err := filter.MarshalBinary()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = (&plain.SpentFilter{}).UnmarshalBinary(data[:len(data)-1])
+		Expect(err).To(MatchError("invalid spent filter encoding"))
+		err = (&plain.SpentFilter{}).UnmarshalBinary([]byte{2})
+		Expect(err).To(MatchError("invalid spent filter encoding"))
+	})
+
+	It("rejects invalid sizes", func() {
+		_, err := plain.NewSpentFilter(0, 0.01)
+		Expect(err).To(MatchError("invalid spent filter capacity 0"))
+		_, err = plain.NewSpentFilter(10, 1)
+		Expect(err).To(MatchError("invalid spent filter false positive rate 1"))
+	})
+})
@@ -0,0 +1,206 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"math"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/hyperledger/fabric/token/ledger"
+	"github.com/pkg/errors"
+)
+
+// NamespaceStats describes the content of the token namespace of a channel.
+type NamespaceStats struct {
+	// UnspentOutputs are the unspent outputs by token type
+	UnspentOutputs map[string]*OutputStats `json:"unspent_outputs"`
+	// Keys is the number of keys in the namespace
+	Keys uint64 `json:"keys"`
+	// Bytes is the total size of the keys and values in the namespace. It does
+	// not account for the overhead of the state database.
+	Bytes uint64 `json:"bytes"`
+	// References describes the application reference index
+	References IndexStats `json:"references"`
+}
+
+// OutputStats counts the outputs of a token type.
+type OutputStats struct {
+	Count    uint64 `json:"count"`
+	Quantity uint64 `json:"quantity"`
+}
+
+// IndexStats describes the health of an index.
+type IndexStats struct {
+	// Entries is the number of entries in the index
+	Entries uint64 `json:"entries"`
+	// Dangling is the number of entries pointing to a transaction that does not exist
+	Dangling uint64 `json:"dangling"`
+}
+
+// CollectStats scans the token namespace and returns its statistics.
+// The scan reads every key of the namespace and should be used sparingly.
+func CollectStats(reader ledger.LedgerReader) (*NamespaceStats, error) {
+	iterator, err := reader.GetStateRangeScanIterator(tk.Namespace, "", "")
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	stats := &NamespaceStats{UnspentOutputs: map[string]*OutputStats{}}
+	for {
+		next, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		if next == nil {
+			// nil response from iterator indicates end of query results
+			return stats, nil
+		}
+		result, ok := next.(*queryresult.KV)
+		if !ok {
+			return nil, errors.New("failed to collect token statistics: casting error")
+		}
+		stats.Keys++
+		stats.Bytes += uint64(len(result.Key) + len(result.Value))
+
+		objectType, attributes, err := splitCompositeKey(result.Key)
+		if err != nil {
+			continue
+		}
+		switch objectType {
+		case tokenOutput:
+			err = countUnspentOutput(result, attributes, reader, stats)
+		case tokenReference:
+			err = checkReference(result, reader, stats)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// countUnspentOutput adds the output to the statistics unless it is spent. The
+// attributes of the output key are the transaction ID and the output index.
+func countUnspentOutput(result *queryresult.KV, attributes []string, reader ledger.LedgerReader, stats *NamespaceStats) error {
+	spentKey, err := createCompositeKey(tokenInput, attributes)
+	if err != nil {
+		return err
+	}
+	spent, err := reader.GetState(tk.Namespace, spentKey)
+	if err != nil {
+		return err
+	}
+	if spent != nil {
+		return nil
+	}
+
+	output := &token.PlainOutput{}
+	err = proto.Unmarshal(result.Value, output)
+	if err != nil {
+		return errors.Wrapf(err, "failed unmarshaling output of transaction '%s'", attributes[0])
+	}
+	typeStats, ok := stats.UnspentOutputs[output.Type]
+	if !ok {
+		typeStats = &OutputStats{}
+		stats.UnspentOutputs[output.Type] = typeStats
+	}
+	typeStats.Count++
+	typeStats.Quantity += output.Quantity
+	return nil
+}
+
+// checkReference counts the reference index entry, and whether the transaction it points to is missing.
+func checkReference(result *queryresult.KV, reader ledger.LedgerReader, stats *NamespaceStats) error {
+	stats.References.Entries++
+
+	txKey, err := createTxKey(string(result.Value))
+	if err != nil {
+		stats.References.Dangling++
+		return nil
+	}
+	tx, err := reader.GetState(tk.Namespace, txKey)
+	if err != nil {
+		return err
+	}
+	if tx == nil {
+		stats.References.Dangling++
+	}
+	return nil
+}
+
+// circulatingOutput is implemented by PlainOutput and PlainDelegatedOutput.
+type circulatingOutput interface {
+	proto.Message
+	GetType() string
+	GetQuantity() uint64
+}
+
+// CirculatingSupply scans the token namespace and returns the circulating
+// supply of each token type, i.e. the total quantity of its unspent outputs,
+// delegated or not. Redeemed tokens are not in circulation. Like CollectStats,
+// the scan reads every key of the namespace and should be used sparingly.
+func CirculatingSupply(reader ledger.LedgerReader) (map[string]uint64, error) {
+	iterator, err := reader.GetStateRangeScanIterator(tk.Namespace, "", "")
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	supply := map[string]uint64{}
+	for {
+		next, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		if next == nil {
+			// nil response from iterator indicates end of query results
+			return supply, nil
+		}
+		result, ok := next.(*queryresult.KV)
+		if !ok {
+			return nil, errors.New("failed to compute the token supply: casting error")
+		}
+
+		objectType, attributes, err := splitCompositeKey(result.Key)
+		if err != nil {
+			continue
+		}
+		var spentType string
+		var output circulatingOutput
+		switch objectType {
+		case tokenOutput:
+			spentType, output = tokenInput, &token.PlainOutput{}
+		case tokenDelegatedOutput:
+			spentType, output = tokenDelegatedInput, &token.PlainDelegatedOutput{}
+		default:
+			continue
+		}
+
+		spentKey, err := createCompositeKey(spentType, attributes)
+		if err != nil {
+			return nil, err
+		}
+		spent, err := reader.GetState(tk.Namespace, spentKey)
+		if err != nil {
+			return nil, err
+		}
+		if spent != nil {
+			continue
+		}
+		err = proto.Unmarshal(result.Value, output)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed unmarshaling output of transaction '%s'", attributes[0])
+		}
+		if supply[output.GetType()] > math.MaxUint64-output.GetQuantity() {
+			return nil, errors.Errorf("supply of token type '%s' overflows", output.GetType())
+		}
+		supply[output.GetType()] += output.GetQuantity()
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain_test
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/ledger/mock"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("CollectStats", func() {
+	var (
+		memoryLedger *plain.MemoryLedger
+		fakeLedger   *mock.LedgerReader
+		fakeIterator *mock.ResultsIterator
+		keys         []string
+	)
+
+	setState := func(key string, value []byte) {
+		err := memoryLedger.SetState("tms", key, value)
+		Expect(err).NotTo(HaveOccurred())
+		keys = append(keys, key)
+	}
+
+	marshal := func(output *token.PlainOutput) []byte {
+		raw, err := proto.Marshal(output)
+		Expect(err).NotTo(HaveOccurred())
+		return raw
+	}
+
+	BeforeEach(func() {
+		memoryLedger = plain.NewMemoryLedger()
+		keys = nil
+
+		setState("\x00tokenTx\x000\x00", []byte("tx-0"))
+		setState("\x00tokenOutput\x000\x000\x00", marshal(&token.PlainOutput{Owner: []byte("alice"), Type: "USD", Quantity: 100}))
+		setState("\x00tokenOutput\x000\x001\x00", marshal(&token.PlainOutput{Owner: []byte("bob"), Type: "USD", Quantity: 50}))
+		setState("\x00tokenOutput\x000\x002\x00", marshal(&token.PlainOutput{Owner: []byte("bob"), Type: "EUR", Quantity: 20}))
+		setState("\x00tokenInput\x000\x000\x00", plain.TokenInputSpentMarker)
+		setState("\x00tokenReference\x00696e766f696365\x000\x00", []byte("0"))
+		setState("\x00tokenReference\x00696e766f696365\x001\x00", []byte("1"))
+
+		fakeIterator = &mock.ResultsIterator{}
+		for i, key := range keys {
+			value, _ := memoryLedger.GetState("tms", key)
+			fakeIterator.NextReturnsOnCall(i, &queryresult.KV{Key: key, Value: value}, nil)
+		}
+		fakeLedger = &mock.LedgerReader{}
+		fakeLedger.GetStateRangeScanIteratorReturns(fakeIterator, nil)
+		fakeLedger.GetStateStub = memoryLedger.GetState
+	})
+
+	It("returns the statistics of the token namespace", func() {
+		stats, err := plain.CollectStats(fakeLedger)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(stats.UnspentOutputs).To(Equal(map[string]*plain.OutputStats{
+			"USD": {Count: 1, Quantity: 50},
+			"EUR": {Count: 1, Quantity: 20},
+		}))
+		Expect(stats.Keys).To(Equal(uint64(7)))
+		var size uint64
+		for _, key := range keys {
+			value, _ := memoryLedger.GetState("tms", key)
+			size += uint64(len(key) + len(value))
+		}
+		Expect(stats.Bytes).To(Equal(size))
+		Expect(stats.References).To(Equal(plain.IndexStats{Entries: 2, Dangling: 1}))
+
+		Expect(fakeLedger.GetStateRangeScanIteratorCallCount()).To(Equal(1))
+		namespace, startKey, endKey := fakeLedger.GetStateRangeScanIteratorArgsForCall(0)
+		Expect(namespace).To(Equal("tms"))
+		Expect(startKey).To(Equal(""))
+		Expect(endKey).To(Equal(""))
+		Expect(fakeIterator.CloseCallCount()).To(Equal(1))
+	})
+
+	Context("when the iterator fails", func() {
+		BeforeEach(func() {
+			fakeIterator.NextReturnsOnCall(1, nil, errors.New("boom"))
+		})
+
+		It("returns the error", func() {
+			_, err := plain.CollectStats(fakeLedger)
+			Expect(err).To(MatchError("boom"))
+		})
+	})
+
+	Context("when an output cannot be unmarshaled", func() {
+		BeforeEach(func() {
+			fakeIterator.NextReturnsOnCall(1, &queryresult.KV{Key: "\x00tokenOutput\x002\x000\x00", Value: []byte("garbage")}, nil)
+		})
+
+		It("returns an error", func() {
+			_, err := plain.CollectStats(fakeLedger)
+			Expect(err).To(MatchError(ContainSubstring("failed unmarshaling output of transaction '2'")))
+		})
+	})
+
+	Context("when the range scan fails", func() {
+		BeforeEach(func() {
+			fakeLedger.GetStateRangeScanIteratorReturns(nil, errors.New("boom"))
+		})
+
+		It("returns the error", func() {
+			_, err := plain.CollectStats(fakeLedger)
+			Expect(err).To(MatchError("boom"))
+		})
+	})
+})
+
+var _ = Describe("CirculatingSupply", func() {
+	var (
+		memoryLedger *plain.MemoryLedger
+		fakeLedger   *mock.LedgerReader
+		fakeIterator *mock.ResultsIterator
+		keys         []string
+	)
+
+	setState := func(key string, value proto.Message) {
+		raw := plain.TokenInputSpentMarker
+		if value != nil {
+			var err error
+			raw, err = proto.Marshal(value)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		err := memoryLedger.SetState("tms", key, raw)
+		Expect(err).NotTo(HaveOccurred())
+		keys = append(keys, key)
+	}
+
+	BeforeEach(func() {
+		memoryLedger = plain.NewMemoryLedger()
+		keys = nil
+
+		setState("\x00tokenOutput\x000\x000\x00", &token.PlainOutput{Owner: []byte("alice"), Type: "USD", Quantity: 100})
+		setState("\x00tokenOutput\x000\x001\x00", &token.PlainOutput{Owner: []byte("bob"), Type: "USD", Quantity: 50})
+		setState("\x00tokenOutput\x000\x002\x00", &token.PlainOutput{Owner: []byte("bob"), Type: "EUR", Quantity: 20})
+		setState("\x00tokenInput\x000\x000\x00", nil)
+		setState("\x00tokenRedeem\x001\x000\x00", &token.PlainOutput{Type: "USD", Quantity: 30})
+		setState("\x00tokenDelegatedOutput\x002\x000\x00", &token.PlainDelegatedOutput{Owner: []byte("bob"), Type: "USD", Quantity: 7})
+		setState("\x00tokenDelegatedOutput\x002\x001\x00", &token.PlainDelegatedOutput{Owner: []byte("bob"), Type: "EUR", Quantity: 5})
+		setState("\x00tokenDelegateInput\x002\x001\x00", nil)
+
+		fakeIterator = &mock.ResultsIterator{}
+		for i, key := range keys {
+			value, _ := memoryLedger.GetState("tms", key)
+			fakeIterator.NextReturnsOnCall(i, &queryresult.KV{Key: key, Value: value}, nil)
+		}
+		fakeLedger = &mock.LedgerReader{}
+		fakeLedger.GetStateRangeScanIteratorReturns(fakeIterator, nil)
+		fakeLedger.GetStateStub = memoryLedger.GetState
+	})
+
+	It("sums the quantities of the unspent outputs by token type", func() {
+		supply, err := plain.CirculatingSupply(fakeLedger)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(supply).To(Equal(map[string]uint64{"USD": 57, "EUR": 20}))
+		Expect(fakeIterator.CloseCallCount()).To(Equal(1))
+	})
+
+	Context("when the supply overflows", func() {
+		BeforeEach(func() {
+			raw, err := proto.Marshal(&token.PlainOutput{Owner: []byte("carol"), Type: "USD", Quantity: ^uint64(0)})
+			Expect(err).NotTo(HaveOccurred())
+			fakeIterator.NextReturnsOnCall(3, &queryresult.KV{Key: "\x00tokenOutput\x003\x000\x00", Value: raw}, nil)
+		})
+
+		It("returns an error", func() {
+			_, err := plain.CirculatingSupply(fakeLedger)
+			Expect(err).To(MatchError("supply of token type 'USD' overflows"))
+		})
+	})
+
+	Context("when an output cannot be unmarshaled", func() {
+		BeforeEach(func() {
+			fakeIterator.NextReturnsOnCall(1, &queryresult.KV{Key: "\x00tokenOutput\x002\x000\x00", Value: []byte("garbage")}, nil)
+		})
+
+		It("returns an error", func() {
+			_, err := plain.CirculatingSupply(fakeLedger)
+			Expect(err).To(MatchError(ContainSubstring("failed unmarshaling output of transaction '2'")))
+		})
+	})
+
+	Context("when the range scan fails", func() {
+		BeforeEach(func() {
+			fakeLedger.GetStateRangeScanIteratorReturns(nil, errors.New("boom"))
+		})
+
+		It("returns the error", func() {
+			_, err := plain.CirculatingSupply(fakeLedger)
+			Expect(err).To(MatchError("boom"))
+		})
+	})
+})
@@ -0,0 +1,206 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/hyperledger/fabric/token/ledger"
+	"github.com/pkg/errors"
+)
+
+const (
+	tokenSupply       = "tokenSupply"
+	tokenPeriodIssued = "tokenPeriodIssued"
+)
+
+// A SupplyLimit limits the issuance of tokens of a given type.
+type SupplyLimit struct {
+	// MaxTotalSupply is the maximum quantity of tokens in circulation,
+	// i.e. issued and not yet redeemed; 0 means no limit.
+	MaxTotalSupply uint64
+	// MaxPerPeriod is the maximum quantity of tokens issued in each period;
+	// 0 means no limit.
+	MaxPerPeriod uint64
+	// PeriodBlocks is the length in blocks of the periods MaxPerPeriod applies
+	// to. Periods are counted from the genesis block of the channel and
+	// transactions are assigned to a period based on the block they are
+	// committed in, which, unlike the timestamp in their channel header, is
+	// not chosen by their creator.
+	PeriodBlocks uint64
+}
+
+type supplyUpdate struct {
+	key   string
+	value []byte
+}
+
+// checkSupply checks that the action keeps the supply of each limited token type
+// within its limits, and returns the updates to the supply counters. Counters are
+// only maintained for token types that have a limit.
+func (v *Verifier) checkSupply(action *token.PlainTokenAction, blockNum uint64, reader ledger.LedgerReader) ([]supplyUpdate, error) {
+	if len(v.SupplyLimits) == 0 {
+		return nil, nil
+	}
+
+	switch a := action.GetData().(type) {
+	case *token.PlainTokenAction_PlainImport:
+		issued := map[string]uint64{}
+		for _, output := range a.PlainImport.GetOutputs() {
+			if issued[output.Type] > math.MaxUint64-output.Quantity {
+				return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("quantity of tokens of type '%s' issued overflows", output.Type)}
+			}
+			issued[output.Type] += output.Quantity
+		}
+
+		var types []string
+		for tokenType := range issued {
+			types = append(types, tokenType)
+		}
+		sort.Strings(types)
+
+		var updates []supplyUpdate
+		for _, tokenType := range types {
+			limit, ok := v.SupplyLimits[tokenType]
+			if !ok || limit == nil {
+				continue
+			}
+			u, err := checkIssuance(tokenType, issued[tokenType], limit, blockNum, reader)
+			if err != nil {
+				return nil, err
+			}
+			updates = append(updates, u...)
+		}
+		return updates, nil
+
+	case *token.PlainTokenAction_PlainRedeem:
+		outputs := a.PlainRedeem.GetOutputs()
+		if len(outputs) == 0 {
+			return nil, nil
+		}
+		redeemed := outputs[0]
+		if _, ok := v.SupplyLimits[redeemed.Type]; !ok {
+			return nil, nil
+		}
+		key, supply, err := getSupply(redeemed.Type, reader)
+		if err != nil {
+			return nil, err
+		}
+		if supply > redeemed.Quantity {
+			supply -= redeemed.Quantity
+		} else {
+			supply = 0
+		}
+		return []supplyUpdate{{key: key, value: []byte(strconv.FormatUint(supply, 10))}}, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+func checkIssuance(tokenType string, quantity uint64, limit *SupplyLimit, blockNum uint64, reader ledger.LedgerReader) ([]supplyUpdate, error) {
+	key, supply, err := getSupply(tokenType, reader)
+	if err != nil {
+		return nil, err
+	}
+	if supply > math.MaxUint64-quantity || (limit.MaxTotalSupply != 0 && supply+quantity > limit.MaxTotalSupply) {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("issuing %d tokens of type '%s' exceeds the maximum total supply of %d, current supply is %d", quantity, tokenType, limit.MaxTotalSupply, supply)}
+	}
+	updates := []supplyUpdate{{key: key, value: []byte(strconv.FormatUint(supply+quantity, 10))}}
+
+	if limit.MaxPerPeriod == 0 {
+		return updates, nil
+	}
+	if limit.PeriodBlocks == 0 {
+		return nil, errors.Errorf("invalid issuance period of 0 blocks for token type '%s'", tokenType)
+	}
+	if blockNum == 0 {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("the block of the transaction is required to issue tokens of type '%s'", tokenType)}
+	}
+
+	period := blockNum / limit.PeriodBlocks
+	periodKey, lastPeriod, issued, err := getPeriodIssued(tokenType, reader)
+	if err != nil {
+		return nil, err
+	}
+	// the blocks are committed in order, so the period of the last issuance
+	// is either the current period or a previous one
+	if period != lastPeriod {
+		issued = 0
+	}
+	if issued > math.MaxUint64-quantity || issued+quantity > limit.MaxPerPeriod {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("issuing %d tokens of type '%s' exceeds the maximum of %d per period, %d already issued", quantity, tokenType, limit.MaxPerPeriod, issued)}
+	}
+	updates = append(updates, supplyUpdate{key: periodKey, value: []byte(fmt.Sprintf("%d %d", period, issued+quantity))})
+	return updates, nil
+}
+
+// getSupply returns the key and the value of the counter of the tokens of the given type in circulation.
+func getSupply(tokenType string, reader ledger.LedgerReader) (string, uint64, error) {
+	key, err := createCompositeKey(tokenSupply, []string{tokenType})
+	if err != nil {
+		return "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating supply key: %s", err)}
+	}
+	raw, err := reader.GetState(tk.Namespace, key)
+	if err != nil {
+		return "", 0, err
+	}
+	if raw == nil {
+		return key, 0, nil
+	}
+	supply, err := strconv.ParseUint(string(raw), 10, 64)
+	if err != nil {
+		return "", 0, errors.Wrapf(err, "invalid supply for token type '%s'", tokenType)
+	}
+	return key, supply, nil
+}
+
+// getPeriodIssued returns the key of the counter of the tokens of the given type issued
+// in the last period tokens were issued in, that period and the quantity issued in it.
+func getPeriodIssued(tokenType string, reader ledger.LedgerReader) (string, uint64, uint64, error) {
+	key, err := createCompositeKey(tokenPeriodIssued, []string{tokenType})
+	if err != nil {
+		return "", 0, 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating issuance period key: %s", err)}
+	}
+	raw, err := reader.GetState(tk.Namespace, key)
+	if err != nil {
+		return "", 0, 0, err
+	}
+	if raw == nil {
+		return key, 0, 0, nil
+	}
+	fields := strings.Fields(string(raw))
+	if len(fields) != 2 {
+		return "", 0, 0, errors.Errorf("invalid issuance period counter for token type '%s'", tokenType)
+	}
+	period, err := strconv.ParseUint(fields[0], 10, 64)
+	if err != nil {
+		return "", 0, 0, errors.Wrapf(err, "invalid issuance period for token type '%s'", tokenType)
+	}
+	issued, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return "", 0, 0, errors.Wrapf(err, "invalid quantity issued in period for token type '%s'", tokenType)
+	}
+	return key, period, issued, nil
+}
+
+func commitSupply(updates []supplyUpdate, writer ledger.LedgerWriter) error {
+	for _, u := range updates {
+		err := writer.SetState(tk.Namespace, u.key, u.value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain_test
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/token"
+	mockid "github.com/hyperledger/fabric/token/identity/mock"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Supply limits", func() {
+	var (
+		fakePublicInfo *mockid.PublicInfo
+		memoryLedger   *plain.MemoryLedger
+		verifier       *plain.Verifier
+		now            time.Time
+	)
+
+	importTx := func(outputs ...*token.PlainOutput) *token.TokenTransaction {
+		return &token.TokenTransaction{
+			Action: &token.TokenTransaction_PlainAction{
+				PlainAction: &token.PlainTokenAction{
+					Data: &token.PlainTokenAction_PlainImport{
+						PlainImport: &token.PlainImport{Outputs: outputs},
+					},
+				},
+			},
+		}
+	}
+
+	supplyKey := "\x00tokenSupply\x00XYZ\x00"
+
+	BeforeEach(func() {
+		fakePublicInfo = &mockid.PublicInfo{}
+		fakePublicInfo.PublicReturns([]byte("owner-1"))
+		memoryLedger = plain.NewMemoryLedger()
+		verifier = &plain.Verifier{
+			IssuingValidator: &mockid.IssuingValidator{},
+			SupplyLimits: map[string]*plain.SupplyLimit{
+				"XYZ": {MaxTotalSupply: 100, MaxPerPeriod: 60, PeriodBlocks: 10},
+			},
+		}
+		now = time.Date(2018, 11, 1, 10, 30, 0, 0, time.UTC)
+	})
+
+	It("tracks the supply of limited token types only", func() {
+		tx := importTx(
+			&token.PlainOutput{Owner: []byte("owner-1"), Type: "XYZ", Quantity: 30},
+			&token.PlainOutput{Owner: []byte("owner-1"), Type: "XYZ", Quantity: 20},
+			&token.PlainOutput{Owner: []byte("owner-1"), Type: "PDQ", Quantity: 1000},
+		)
+		err := verifier.ProcessTxAt("0", fakePublicInfo, tx, 12, now, memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+
+		supply, err := memoryLedger.GetState("tms", supplyKey)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(supply)).To(Equal("50"))
+		supply, err = memoryLedger.GetState("tms", "\x00tokenSupply\x00PDQ\x00")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(supply).To(BeNil())
+	})
+
+	It("rejects issuance beyond the maximum per period", func() {
+		err := verifier.ProcessTxAt("0", fakePublicInfo, importTx(&token.PlainOutput{Owner: []byte("owner-1"), Type: "XYZ", Quantity: 50}), 12, now, memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = verifier.ProcessTxAt("1", fakePublicInfo, importTx(&token.PlainOutput{Owner: []byte("owner-1"), Type: "XYZ", Quantity: 20}), 19, now, memoryLedger)
+		Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "issuing 20 tokens of type 'XYZ' exceeds the maximum of 60 per period, 50 already issued"}))
+
+		err = verifier.ProcessTxAt("1", fakePublicInfo, importTx(&token.PlainOutput{Owner: []byte("owner-1"), Type: "XYZ", Quantity: 20}), 20, now, memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("ignores the timestamp of the transactions", func() {
+		err := verifier.ProcessTxAt("0", fakePublicInfo, importTx(&token.PlainOutput{Owner: []byte("owner-1"), Type: "XYZ", Quantity: 50}), 12, now, memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+
+		// a timestamp chosen in a later period does not start a new period
+		err = verifier.ProcessTxAt("1", fakePublicInfo, importTx(&token.PlainOutput{Owner: []byte("owner-1"), Type: "XYZ", Quantity: 20}), 13, now.Add(24*365*time.Hour), memoryLedger)
+		Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "issuing 20 tokens of type 'XYZ' exceeds the maximum of 60 per period, 50 already issued"}))
+	})
+
+	It("starts a new period after periods without issuance", func() {
+		err := verifier.ProcessTxAt("0", fakePublicInfo, importTx(&token.PlainOutput{Owner: []byte("owner-1"), Type: "XYZ", Quantity: 50}), 12, now, memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = verifier.ProcessTxAt("1", fakePublicInfo, importTx(&token.PlainOutput{Owner: []byte("owner-1"), Type: "XYZ", Quantity: 40}), 45, now, memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("rejects issuance beyond the maximum total supply and frees supply on redemption", func() {
+		verifier.SupplyLimits["XYZ"].MaxPerPeriod = 0
+
+		err := verifier.ProcessTxAt("0", fakePublicInfo, importTx(&token.PlainOutput{Owner: []byte("owner-1"), Type: "XYZ", Quantity: 90}), 12, now, memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = verifier.ProcessTxAt("1", fakePublicInfo, importTx(&token.PlainOutput{Owner: []byte("owner-1"), Type: "XYZ", Quantity: 20}), 12, now, memoryLedger)
+		Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "issuing 20 tokens of type 'XYZ' exceeds the maximum total supply of 100, current supply is 90"}))
+
+		redeemTx := &token.TokenTransaction{
+			Action: &token.TokenTransaction_PlainAction{
+				PlainAction: &token.PlainTokenAction{
+					Data: &token.PlainTokenAction_PlainRedeem{
+						PlainRedeem: &token.PlainTransfer{
+							Inputs: []*token.InputId{{TxId: "0", Index: 0}},
+							Outputs: []*token.PlainOutput{
+								{Type: "XYZ", Quantity: 40},
+								{Owner: []byte("owner-1"), Type: "XYZ", Quantity: 50},
+							},
+						},
+					},
+				},
+			},
+		}
+		err = verifier.ProcessTxAt("2", fakePublicInfo, redeemTx, 12, now, memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+		supply, err := memoryLedger.GetState("tms", supplyKey)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(supply)).To(Equal("50"))
+
+		err = verifier.ProcessTxAt("3", fakePublicInfo, importTx(&token.PlainOutput{Owner: []byte("owner-1"), Type: "XYZ", Quantity: 20}), 12, now, memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("when the block of the transaction is unknown", func() {
+		It("rejects issuance of tokens limited per period", func() {
+			err := verifier.ProcessTx("0", fakePublicInfo, importTx(&token.PlainOutput{Owner: []byte("owner-1"), Type: "XYZ", Quantity: 10}), memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "the block of the transaction is required to issue tokens of type 'XYZ'"}))
+		})
+	})
+})
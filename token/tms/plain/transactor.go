@@ -15,6 +15,8 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/hyperledger/fabric/token/identity"
 	"github.com/hyperledger/fabric/token/ledger"
 	"github.com/pkg/errors"
 )
@@ -23,6 +25,19 @@ import (
 type Transactor struct {
 	PublicCredential []byte
 	Ledger           ledger.LedgerReader
+	// Channel is the channel delegations must be bound to.
+	Channel string
+	// Deserializer is used to verify the signatures of delegations
+	// in delegated transfers; when nil, delegated transfers are rejected.
+	Deserializer identity.Deserializer
+	// OwnerEncoding is the representation of the creator in the outputs
+	// returning change to the creator.
+	OwnerEncoding identity.OwnerEncoding
+	// SpentFilter, when set, holds the spent keys of the inputs spent on the
+	// channel, so that the spent keys it does not contain are not read from
+	// the ledger. The inputs of transfers and redeems are then checked not
+	// to be spent.
+	SpentFilter *SpentFilter
 }
 
 // RequestTransfer creates a TokenTransaction of type transfer request
@@ -30,27 +45,56 @@ type Transactor struct {
 func (t *Transactor) RequestTransfer(request *token.TransferRequest) (*token.TokenTransaction, error) {
 	var outputs []*token.PlainOutput
 
-	inputs, tokenType, _, err := t.getInputsFromTokenIds(request.GetTokenIds())
+	// tokens spent by way of a delegation are owned by the first delegator
+	owner := t.PublicCredential
+	if len(request.GetDelegations()) != 0 {
+		delegator, err := delegationOwner(request.GetDelegations())
+		if err != nil {
+			return nil, err
+		}
+		owner = delegator
+	}
+
+	inputs, tokenType, _, err := t.getInputsOwnedBy(request.GetTokenIds(), owner)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := t.getInputsMetadata(request.GetTokenIds())
 	if err != nil {
 		return nil, err
 	}
 
 	for _, ttt := range request.GetShares() {
+		// outputs carry the metadata of the inputs unless the share re-specifies it
+		outputMetadata := metadata
+		if len(ttt.Metadata) != 0 {
+			outputMetadata = ttt.Metadata
+		}
 		outputs = append(outputs, &token.PlainOutput{
 			Owner:    ttt.Recipient,
 			Type:     tokenType,
 			Quantity: ttt.Quantity,
+			Metadata: outputMetadata,
 		})
 	}
 
+	if len(request.GetDelegations()) != 0 {
+		err = t.checkDelegations(request.GetDelegations(), owner, tokenType, outputs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// prepare transfer request
 	transaction := &token.TokenTransaction{
 		Action: &token.TokenTransaction_PlainAction{
 			PlainAction: &token.PlainTokenAction{
 				Data: &token.PlainTokenAction_PlainTransfer{
 					PlainTransfer: &token.PlainTransfer{
-						Inputs:  inputs,
-						Outputs: outputs,
+						Inputs:      inputs,
+						Outputs:     outputs,
+						Delegations: request.GetDelegations(),
 					},
 				},
 			},
@@ -78,19 +122,30 @@ func (t *Transactor) RequestRedeem(request *token.RedeemRequest) (*token.TokenTr
 		return nil, errors.Errorf("total quantity [%d] from TokenIds is less than quantity [%d] to be redeemed", quantitySum, request.QuantityToRedeem)
 	}
 
+	metadata, err := t.getInputsMetadata(request.GetTokenIds())
+	if err != nil {
+		return nil, err
+	}
+
 	// add the output for redeem itself
 	var outputs []*token.PlainOutput
 	outputs = append(outputs, &token.PlainOutput{
 		Type:     tokenType,
 		Quantity: request.QuantityToRedeem,
+		Metadata: metadata,
 	})
 
 	// add another output if there is remaining quantity after redemption
 	if quantitySum > request.QuantityToRedeem {
+		owner, err := t.OwnerEncoding.EncodeOwner(t.PublicCredential) // PublicCredential is serialized identity for the creator
+		if err != nil {
+			return nil, err
+		}
 		outputs = append(outputs, &token.PlainOutput{
-			Owner:    t.PublicCredential, // PublicCredential is serialized identity for the creator
+			Owner:    owner,
 			Type:     tokenType,
 			Quantity: quantitySum - request.QuantityToRedeem,
+			Metadata: metadata,
 		})
 	}
 
@@ -111,9 +166,25 @@ func (t *Transactor) RequestRedeem(request *token.RedeemRequest) (*token.TokenTr
 	return transaction, nil
 }
 
+// checkDelegations verifies that the delegation chain authorizes this transactor to
+// move the outputs away from the owner. The expiration of the delegations depends on
+// the block the transaction is committed in, so it is left to the verifier.
+func (t *Transactor) checkDelegations(chain []*token.SignedDelegation, owner []byte, tokenType string, outputs []*token.PlainOutput) error {
+	delegations, err := checkDelegationChain(t.Deserializer, chain, owner, t.PublicCredential, tokenType, t.Channel)
+	if err != nil {
+		return errors.WithMessage(err, "invalid delegation chain")
+	}
+	return checkDelegationAllowance(chain, delegations, delegatedQuantity(outputs, owner), t.Ledger)
+}
+
 // read token data from ledger for each token ids and calculate the sum of quantities for all token ids
 // Returns InputIds, token type, sum of token quantities, and error in the case of failure
 func (t *Transactor) getInputsFromTokenIds(tokenIds [][]byte) ([]*token.InputId, string, uint64, error) {
+	return t.getInputsOwnedBy(tokenIds, t.PublicCredential)
+}
+
+// getInputsOwnedBy is like getInputsFromTokenIds but checks that the tokens are owned by owner
+func (t *Transactor) getInputsOwnedBy(tokenIds [][]byte, owner []byte) ([]*token.InputId, string, uint64, error) {
 	var inputs []*token.InputId
 	var tokenType string = ""
 	var quantitySum uint64 = 0
@@ -139,7 +210,7 @@ func (t *Transactor) getInputsFromTokenIds(tokenIds [][]byte) ([]*token.InputId,
 		}
 
 		// make sure the output exists in the ledger
-		inBytes, err := t.Ledger.GetState(tokenNameSpace, inKey)
+		inBytes, err := t.Ledger.GetState(tk.Namespace, inKey)
 		if err != nil {
 			return nil, "", 0, err
 		}
@@ -153,10 +224,20 @@ func (t *Transactor) getInputsFromTokenIds(tokenIds [][]byte) ([]*token.InputId,
 		}
 
 		// check the owner of the token
-		if !bytes.Equal(t.PublicCredential, input.Owner) {
+		if !identity.IsOwner(input.Owner, owner) {
 			return nil, "", 0, errors.New(fmt.Sprintf("the requestor does not own inputs"))
 		}
 
+		if t.SpentFilter != nil {
+			spent, err := t.isSpent(inKey)
+			if err != nil {
+				return nil, "", 0, err
+			}
+			if spent {
+				return nil, "", 0, errors.New(fmt.Sprintf("input '%s' has been spent", inKey))
+			}
+		}
+
 		// check the token type - only one type allowed per transfer
 		if tokenType == "" {
 			tokenType = input.Type
@@ -173,13 +254,41 @@ func (t *Transactor) getInputsFromTokenIds(tokenIds [][]byte) ([]*token.InputId,
 	return inputs, tokenType, quantitySum, nil
 }
 
+// getInputsMetadata returns the metadata shared by the tokens, or an error if
+// the tokens carry different metadata
+func (t *Transactor) getInputsMetadata(tokenIds [][]byte) ([]byte, error) {
+	var metadata []byte
+	for i, inKeyBytes := range tokenIds {
+		inKey := parseCompositeKeyBytes(inKeyBytes)
+		inBytes, err := t.Ledger.GetState(tk.Namespace, inKey)
+		if err != nil {
+			return nil, err
+		}
+		input := &token.PlainOutput{}
+		err = proto.Unmarshal(inBytes, input)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("error unmarshaling input bytes: '%s'", err))
+		}
+		if i == 0 {
+			metadata = input.Metadata
+		} else if !bytes.Equal(metadata, input.Metadata) {
+			return nil, errors.New("tokens with different metadata specified in input")
+		}
+	}
+	return metadata, nil
+}
+
 // ListTokens creates a TokenTransaction that lists the unspent tokens owned by owner.
 func (t *Transactor) ListTokens() (*token.UnspentTokens, error) {
-	iterator, err := t.Ledger.GetStateRangeScanIterator(tokenNameSpace, "", "")
+	iterator, err := t.Ledger.GetStateRangeScanIterator(tk.Namespace, "", "")
 	if err != nil {
 		return nil, err
 	}
 
+	// tokens may be owned by the creator directly or by way of its hash; the
+	// credential may not be a serialized identity, in which case it has no hash
+	hashedOwner, _ := identity.HashOwner(t.PublicCredential)
+
 	tokens := make([]*token.TokenOutput, 0)
 	prefix, err := createPrefix(tokenOutput)
 	if err != nil {
@@ -207,7 +316,7 @@ func (t *Transactor) ListTokens() (*token.UnspentTokens, error) {
 				if err != nil {
 					return nil, errors.New("failed to retrieve unspent tokens: casting error")
 				}
-				if string(output.Owner) == string(t.PublicCredential) {
+				if string(output.Owner) == string(t.PublicCredential) || (hashedOwner != nil && string(output.Owner) == string(hashedOwner)) {
 					spent, err := t.isSpent(result.Key)
 					if err != nil {
 						return nil, err
@@ -218,6 +327,7 @@ func (t *Transactor) ListTokens() (*token.UnspentTokens, error) {
 								Type:     output.Type,
 								Quantity: output.Quantity,
 								Id:       getCompositeKeyBytes(result.Key),
+								Metadata: output.Metadata,
 							})
 					}
 				}
@@ -314,7 +424,10 @@ func (t *Transactor) isSpent(outputID string) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	result, err := t.Ledger.GetState(tokenNameSpace, key)
+	if t.SpentFilter != nil && !t.SpentFilter.MayContain(key) {
+		return false, nil
+	}
+	result, err := t.Ledger.GetState(tk.Namespace, key)
 	if err != nil {
 		return false, err
 	}
@@ -327,8 +440,11 @@ func (t *Transactor) isSpent(outputID string) (bool, error) {
 // Create a ledger key for an individual input in a token transaction, as a function of
 // the outputID
 func createInputKey(outputID string) (string, error) {
-	att := strings.Split(outputID, string(minUnicodeRuneValue))
-	return createCompositeKey(tokenInput, att[1:])
+	_, att, err := splitCompositeKey(outputID)
+	if err != nil {
+		return "", err
+	}
+	return createCompositeKey(tokenInput, att)
 }
 
 // Create a prefix as a function of the string passed as argument
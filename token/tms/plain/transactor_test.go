@@ -15,7 +15,9 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/msp"
 	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/identity"
 	"github.com/hyperledger/fabric/token/ledger/mock"
 	"github.com/hyperledger/fabric/token/tms/plain"
 	. "github.com/onsi/ginkgo"
@@ -174,6 +176,46 @@ func TestTransactor_ListTokens(t *testing.T) {
 	}
 }
 
+func TestTransactor_ListTokensSpentKey(t *testing.T) {
+	ledgerReader := &mock.LedgerReader{}
+	iterator := &mock.ResultsIterator{}
+	transactor := &plain.Transactor{PublicCredential: []byte("Alice"), Ledger: ledgerReader}
+
+	key, err := plain.GenerateKeyForTest("1", 0)
+	assert.NoError(t, err)
+	output, err := proto.Marshal(&token.PlainOutput{Owner: []byte("Alice"), Type: "TOK1", Quantity: 100})
+	assert.NoError(t, err)
+	ledgerReader.GetStateRangeScanIteratorReturns(iterator, nil)
+	iterator.NextReturnsOnCall(0, &queryresult.KV{Key: key, Value: output}, nil)
+	ledgerReader.GetStateReturns([]byte{1}, nil)
+
+	tokens, err := transactor.ListTokens()
+	assert.NoError(t, err)
+	assert.Empty(t, tokens.Tokens)
+	namespace, spentKey := ledgerReader.GetStateArgsForCall(0)
+	assert.Equal(t, "tms", namespace)
+	assert.Equal(t, "\x00tokenInput\x001\x000\x00", spentKey)
+}
+
+func TestTransactor_ListTokensMetadata(t *testing.T) {
+	ledgerReader := &mock.LedgerReader{}
+	iterator := &mock.ResultsIterator{}
+	transactor := &plain.Transactor{PublicCredential: []byte("Alice"), Ledger: ledgerReader}
+
+	key, err := plain.GenerateKeyForTest("1", 0)
+	assert.NoError(t, err)
+	output, err := proto.Marshal(&token.PlainOutput{Owner: []byte("Alice"), Type: "BOND", Quantity: 100, Metadata: []byte(`{"maturity":"2030-06-30"}`)})
+	assert.NoError(t, err)
+	ledgerReader.GetStateRangeScanIteratorReturns(iterator, nil)
+	iterator.NextReturnsOnCall(0, &queryresult.KV{Key: key, Value: output}, nil)
+
+	tokens, err := transactor.ListTokens()
+	assert.NoError(t, err)
+	assert.Equal(t, []*token.TokenOutput{
+		{Id: []byte(key), Type: "BOND", Quantity: 100, Metadata: []byte(`{"maturity":"2030-06-30"}`)},
+	}, tokens.Tokens)
+}
+
 var _ = Describe("Transactor Transfer", func() {
 	var (
 		transactor              *plain.Transactor
@@ -286,6 +328,50 @@ var _ = Describe("Transactor Transfer", func() {
 				},
 			}))
 		})
+
+		Context("when a spent filter is set", func() {
+			var spentKey string
+
+			BeforeEach(func() {
+				var err error
+				transactor.SpentFilter, err = plain.NewSpentFilter(100, 0.01)
+				Expect(err).NotTo(HaveOccurred())
+				spentKey, err = plain.SpentKey(&token.InputId{TxId: "george", Index: 0})
+				Expect(err).NotTo(HaveOccurred())
+				transferRequest = &token.TransferRequest{
+					Credential: []byte("credential"),
+					TokenIds:   [][]byte{[]byte("\x00tokenOutput\x00george\x000\x00")},
+					Shares:     recipientTransferShares,
+				}
+			})
+
+			It("does not read the spent keys the filter does not contain", func() {
+				_, err := transactor.RequestTransfer(transferRequest)
+				Expect(err).NotTo(HaveOccurred())
+				for i := 0; i < fakeLedger.GetStateCallCount(); i++ {
+					_, key := fakeLedger.GetStateArgsForCall(i)
+					Expect(key).NotTo(Equal(spentKey))
+				}
+			})
+
+			It("rejects the spent inputs", func() {
+				transactor.SpentFilter.Add(spentKey)
+				fakeLedger.GetStateReturnsOnCall(1, plain.TokenInputSpentMarker, nil)
+
+				_, err := transactor.RequestTransfer(transferRequest)
+				Expect(err).To(MatchError("input '\x00tokenOutput\x00george\x000\x00' has been spent"))
+				_, key := fakeLedger.GetStateArgsForCall(1)
+				Expect(key).To(Equal(spentKey))
+			})
+
+			It("accepts the unspent inputs the filter may contain", func() {
+				transactor.SpentFilter.Add(spentKey)
+				fakeLedger.GetStateReturnsOnCall(1, nil, nil)
+
+				_, err := transactor.RequestTransfer(transferRequest)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
 	})
 
 	Describe("when a transfer request with a non-existing input is provided", func() {
@@ -552,6 +638,38 @@ var _ = Describe("Transactor Transfer", func() {
 				Expect(err).To(MatchError(fmt.Sprintf("total quantity [%d] from TokenIds is less than quantity [%d] to be redeemed", inputQuantity, redeemQuantity)))
 			})
 		})
+
+		Context("when owners are hashed", func() {
+			var hashedAlice []byte
+
+			BeforeEach(func() {
+				alice, err := proto.Marshal(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("alice")})
+				Expect(err).NotTo(HaveOccurred())
+				hashedAlice, err = identity.HashOwner(alice)
+				Expect(err).NotTo(HaveOccurred())
+
+				inputBytes, err = proto.Marshal(&token.PlainOutput{Owner: hashedAlice, Type: "TOK1", Quantity: inputQuantity})
+				Expect(err).NotTo(HaveOccurred())
+				fakeLedger.GetStateReturns(inputBytes, nil)
+
+				transactor.PublicCredential = alice
+				transactor.OwnerEncoding = identity.HashedOwners
+			})
+
+			It("spends the tokens of the creator and returns the change to its hash", func() {
+				redeemRequest = &token.RedeemRequest{
+					Credential:       []byte("credential"),
+					TokenIds:         [][]byte{[]byte(string("\x00") + "tokenOutput" + string("\x00") + "robert" + string("\x00") + "0" + string("\x00"))},
+					QuantityToRedeem: 50,
+				}
+				tt, err := transactor.RequestRedeem(redeemRequest)
+				Expect(err).NotTo(HaveOccurred())
+
+				outputs := tt.GetPlainAction().GetPlainRedeem().GetOutputs()
+				Expect(outputs).To(HaveLen(2))
+				Expect(outputs[1]).To(Equal(&token.PlainOutput{Owner: hashedAlice, Type: "TOK1", Quantity: inputQuantity - 50}))
+			})
+		})
 	})
 })
 
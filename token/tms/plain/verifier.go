@@ -11,6 +11,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strconv"
+	"time"
 	"unicode/utf8"
 
 	"github.com/golang/protobuf/proto"
@@ -18,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/customtx"
 	"github.com/hyperledger/fabric/protos/token"
 	"github.com/hyperledger/fabric/protos/utils"
+	tk "github.com/hyperledger/fabric/token"
 	"github.com/hyperledger/fabric/token/identity"
 	"github.com/hyperledger/fabric/token/ledger"
 	"github.com/pkg/errors"
@@ -33,7 +35,6 @@ const (
 	tokenDelegatedOutput  = "tokenDelegatedOutput"
 	tokenInput            = "tokenInput"
 	tokenDelegatedInput   = "tokenDelegateInput"
-	tokenNameSpace        = "tms"
 )
 
 var verifierLogger = flogging.MustGetLogger("token.tms.plain.verifier")
@@ -41,13 +42,38 @@ var verifierLogger = flogging.MustGetLogger("token.tms.plain.verifier")
 // A Verifier validates and commits token transactions.
 type Verifier struct {
 	IssuingValidator identity.IssuingValidator
+	// Deserializer is used to verify the signatures of delegations
+	// in delegated transfers; when nil, delegated transfers are rejected.
+	Deserializer identity.Deserializer
+	// Channel is the channel delegations must be bound to.
+	Channel string
+	// SupplyLimits limits the issuance of tokens, by token type.
+	SupplyLimits map[string]*SupplyLimit
+	// MetadataSchemas validates the metadata of outputs, by token type.
+	MetadataSchemas map[string]*MetadataSchema
+	// GovernanceValidator is used to check the creators of transactions pausing
+	// and resuming token types; when nil, these transactions are rejected.
+	GovernanceValidator identity.GovernanceValidator
 }
 
 // ProcessTx checks that transactions are correct wrt. the most recent ledger state.
 // ProcessTx checks are ones that shall be done sequentially, since transactions within a block may introduce dependencies.
 func (v *Verifier) ProcessTx(txID string, creator identity.PublicInfo, ttx *token.TokenTransaction, simulator ledger.LedgerWriter) error {
+	return v.ProcessTxAt(txID, creator, ttx, 0, time.Time{}, simulator)
+}
+
+// ProcessTxAt is like ProcessTx for a transaction committed in block blockNum and
+// created at timestamp. The block number is used to enforce the limits on the
+// quantity of tokens issued per period and the expiration of delegations; the
+// timestamp, set by the creator of the transaction, is not used.
+func (v *Verifier) ProcessTxAt(txID string, creator identity.PublicInfo, ttx *token.TokenTransaction, blockNum uint64, timestamp time.Time, simulator ledger.LedgerWriter) error {
 	verifierLogger.Debugf("checking transaction with txID '%s'", txID)
-	err := v.checkProcess(txID, creator, ttx, simulator)
+	err := v.checkProcess(txID, creator, ttx, blockNum, simulator)
+	if err != nil {
+		return err
+	}
+
+	supplyUpdates, err := v.checkSupply(ttx.GetPlainAction(), blockNum, simulator)
 	if err != nil {
 		return err
 	}
@@ -58,17 +84,28 @@ func (v *Verifier) ProcessTx(txID string, creator identity.PublicInfo, ttx *toke
 		verifierLogger.Errorf("error committing transaction with txID '%s': %s", txID, err)
 		return err
 	}
+
+	err = commitSupply(supplyUpdates, simulator)
+	if err != nil {
+		verifierLogger.Errorf("error committing token supply for transaction with txID '%s': %s", txID, err)
+		return err
+	}
 	verifierLogger.Debugf("successfully processed transaction with txID '%s'", txID)
 	return nil
 }
 
-func (v *Verifier) checkProcess(txID string, creator identity.PublicInfo, ttx *token.TokenTransaction, simulator ledger.LedgerReader) error {
+func (v *Verifier) checkProcess(txID string, creator identity.PublicInfo, ttx *token.TokenTransaction, blockNum uint64, simulator ledger.LedgerReader) error {
 	action := ttx.GetPlainAction()
 	if action == nil {
 		return &customtx.InvalidTxError{Msg: fmt.Sprintf("check process failed for transaction '%s': missing token action", txID)}
 	}
 
-	err := v.checkAction(creator, action, txID, simulator)
+	err := v.checkApplicationReference(ttx, txID)
+	if err != nil {
+		return err
+	}
+
+	err = v.checkAction(creator, action, txID, blockNum, simulator)
 	if err != nil {
 		return err
 	}
@@ -77,19 +114,26 @@ func (v *Verifier) checkProcess(txID string, creator identity.PublicInfo, ttx *t
 	if err != nil {
 		return err
 	}
-	return nil
+
+	return v.checkNotPaused(action, txID, simulator)
 }
 
-func (v *Verifier) checkAction(creator identity.PublicInfo, plainAction *token.PlainTokenAction, txID string, simulator ledger.LedgerReader) error {
+func (v *Verifier) checkAction(creator identity.PublicInfo, plainAction *token.PlainTokenAction, txID string, blockNum uint64, simulator ledger.LedgerReader) error {
 	switch action := plainAction.Data.(type) {
 	case *token.PlainTokenAction_PlainImport:
 		return v.checkImportAction(creator, action.PlainImport, txID, simulator)
 	case *token.PlainTokenAction_PlainTransfer:
-		return v.checkTransferAction(creator, action.PlainTransfer, txID, simulator)
+		return v.checkTransferAction(creator, action.PlainTransfer, txID, blockNum, simulator)
 	case *token.PlainTokenAction_PlainRedeem:
 		return v.checkRedeemAction(creator, action.PlainRedeem, txID, simulator)
 	case *token.PlainTokenAction_PlainApprove:
 		return v.checkApproveAction(creator, action.PlainApprove, txID, simulator)
+	case *token.PlainTokenAction_PlainPause:
+		return v.checkGovernanceAction(creator, action.PlainPause, true, txID, simulator)
+	case *token.PlainTokenAction_PlainResume:
+		return v.checkGovernanceAction(creator, action.PlainResume, false, txID, simulator)
+	case *token.PlainTokenAction_PlainIssueManifest:
+		return v.checkIssueManifest(creator, action.PlainIssueManifest, txID, simulator)
 	default:
 		return &customtx.InvalidTxError{Msg: fmt.Sprintf("unknown plain token action: %T", action)}
 	}
@@ -100,7 +144,11 @@ func (v *Verifier) checkImportAction(creator identity.PublicInfo, importAction *
 	if err != nil {
 		return err
 	}
-	return v.checkImportPolicy(creator, txID, importAction)
+	err = v.checkImportPolicy(creator, txID, importAction)
+	if err != nil {
+		return err
+	}
+	return v.checkImportSeries(creator, importAction, txID, simulator)
 }
 
 func (v *Verifier) checkImportOutputs(outputs []*token.PlainOutput, txID string, simulator ledger.LedgerReader) error {
@@ -116,16 +164,31 @@ func (v *Verifier) checkImportOutputs(outputs []*token.PlainOutput, txID string,
 		if output.Quantity == 0 {
 			return &customtx.InvalidTxError{Msg: fmt.Sprintf("output %d quantity is 0 in transaction: %s", i, txID)}
 		}
+
+		err = v.checkOutputMetadata(output, i, txID)
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-func (v *Verifier) checkTransferAction(creator identity.PublicInfo, transferAction *token.PlainTransfer, txID string, simulator ledger.LedgerReader) error {
+func (v *Verifier) checkTransferAction(creator identity.PublicInfo, transferAction *token.PlainTransfer, txID string, blockNum uint64, simulator ledger.LedgerReader) error {
+	// inputs spent by way of a delegation are owned by the first delegator
+	owner := creator
+	if len(transferAction.GetDelegations()) != 0 {
+		delegator, err := delegationOwner(transferAction.GetDelegations())
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid delegation in transfer with ID %s: %s", txID, err)}
+		}
+		owner = publicInfo(delegator)
+	}
+
 	outputType, outputSum, err := v.checkTransferOutputs(transferAction.GetOutputs(), txID, simulator)
 	if err != nil {
 		return err
 	}
-	inputType, inputSum, err := v.checkTransferInputs(creator, transferAction.GetInputs(), txID, simulator)
+	inputType, inputSum, inputMetadata, err := v.checkTransferInputs(owner, transferAction.GetInputs(), txID, simulator)
 	if err != nil {
 		return err
 	}
@@ -135,12 +198,43 @@ func (v *Verifier) checkTransferAction(creator identity.PublicInfo, transferActi
 	if outputSum != inputSum {
 		return &customtx.InvalidTxError{Msg: fmt.Sprintf("token sum mismatch in inputs and outputs for transfer with ID %s (%d vs %d)", txID, outputSum, inputSum)}
 	}
+	err = v.checkTransferMetadata(transferAction.GetOutputs(), inputMetadata, txID)
+	if err != nil {
+		return err
+	}
+	if len(transferAction.GetDelegations()) != 0 {
+		return v.checkDelegatedTransfer(creator, owner, transferAction, inputType, txID, blockNum, simulator)
+	}
 	return nil
 }
 
+// checkDelegatedTransfer checks the delegations of a transfer against the number
+// of the block the transaction is committed in, which, unlike the local clock or
+// the timestamp set by the delegatee, is the same on every peer and is not under
+// the control of the delegatee.
+func (v *Verifier) checkDelegatedTransfer(creator, owner identity.PublicInfo, transferAction *token.PlainTransfer, tokenType string, txID string, blockNum uint64, simulator ledger.LedgerReader) error {
+	delegations, err := checkDelegationChain(v.Deserializer, transferAction.GetDelegations(), owner.Public(), creator.Public(), tokenType, v.Channel)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid delegation chain in transfer with ID %s: %s", txID, err)}
+	}
+	if blockNum == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("the block number is required to spend tokens by way of a delegation in transfer with ID %s", txID)}
+	}
+	err = checkDelegationsNotExpired(delegations, blockNum)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid delegation chain in transfer with ID %s: %s", txID, err)}
+	}
+	quantity := delegatedQuantity(transferAction.GetOutputs(), owner.Public())
+	return checkDelegationAllowance(transferAction.GetDelegations(), delegations, quantity, simulator)
+}
+
 func (v *Verifier) checkRedeemAction(creator identity.PublicInfo, redeemAction *token.PlainTransfer, txID string, simulator ledger.LedgerReader) error {
-	// first perform the same checking as transfer
-	err := v.checkTransferAction(creator, redeemAction, txID, simulator)
+	if len(redeemAction.GetDelegations()) != 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("delegations are not supported in redeem transaction with ID %s", txID)}
+	}
+
+	// first perform the same checking as transfer; without delegations, the block number is not used
+	err := v.checkTransferAction(creator, redeemAction, txID, 0, simulator)
 	if err != nil {
 		return err
 	}
@@ -158,7 +252,7 @@ func (v *Verifier) checkRedeemAction(creator identity.PublicInfo, redeemAction *
 	}
 
 	// if output[1] presents, its owner must be same as the creator
-	if len(outputs) == 2 && !bytes.Equal(creator.Public(), outputs[1].Owner) {
+	if len(outputs) == 2 && !identity.IsOwner(outputs[1].Owner, creator.Public()) {
 		println(hex.EncodeToString(creator.Public()))
 		println(hex.EncodeToString(outputs[1].Owner))
 		return &customtx.InvalidTxError{Msg: fmt.Sprintf("wrong owner for remaining tokens, should be original owner %s, but got %s", creator.Public(), outputs[1].Owner)}
@@ -173,7 +267,7 @@ func (v *Verifier) checkOutputDoesNotExist(index int, txID string, simulator led
 		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID: %s", err)}
 	}
 
-	existingOutputBytes, err := simulator.GetState(tokenNameSpace, outputID)
+	existingOutputBytes, err := simulator.GetState(tk.Namespace, outputID)
 	if err != nil {
 		return err
 	}
@@ -202,50 +296,56 @@ func (v *Verifier) checkTransferOutputs(outputs []*token.PlainOutput, txID strin
 	return tokenType, tokenSum, nil
 }
 
-func (v *Verifier) checkTransferInputs(creator identity.PublicInfo, inputIDs []*token.InputId, txID string, simulator ledger.LedgerReader) (string, uint64, error) {
+func (v *Verifier) checkTransferInputs(creator identity.PublicInfo, inputIDs []*token.InputId, txID string, simulator ledger.LedgerReader) (string, uint64, []byte, error) {
 	tokenType := ""
 	inputSum := uint64(0)
+	var metadata []byte
 	processedIDs := make(map[string]bool)
 	for _, id := range inputIDs {
 		inputKey, err := createOutputKey(id.TxId, int(id.Index))
 		if err != nil {
-			return "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID for transfer input: %s", err)}
+			return "", 0, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID for transfer input: %s", err)}
 		}
 		input, err := v.getOutput(inputKey, simulator)
 		if err != nil {
-			return "", 0, err
+			return "", 0, nil, err
 		}
 		err = v.checkInputOwner(creator, input, inputKey)
 		if err != nil {
-			return "", 0, err
+			return "", 0, nil, err
 		}
 		if tokenType == "" {
 			tokenType = input.GetType()
 		} else if tokenType != input.GetType() {
-			return "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("multiple token types in transfer input for txID: %s (%s, %s)", txID, tokenType, input.GetType())}
+			return "", 0, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("multiple token types in transfer input for txID: %s (%s, %s)", txID, tokenType, input.GetType())}
+		}
+		if len(processedIDs) == 0 {
+			metadata = input.GetMetadata()
+		} else if !bytes.Equal(metadata, input.GetMetadata()) {
+			return "", 0, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("inputs with different metadata in transaction: %s", txID)}
 		}
 		if processedIDs[inputKey] {
-			return "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("token input '%s' spent more than once in single transfer with txID '%s'", inputKey, txID)}
+			return "", 0, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("token input '%s' spent more than once in single transfer with txID '%s'", inputKey, txID)}
 		}
 		processedIDs[inputKey] = true
 		inputSum += input.GetQuantity()
 		spentKey, err := createSpentKey(id.TxId, int(id.Index))
 		if err != nil {
-			return "", 0, err
+			return "", 0, nil, err
 		}
 		spent, err := v.isSpent(spentKey, simulator)
 		if err != nil {
-			return "", 0, err
+			return "", 0, nil, err
 		}
 		if spent {
-			return "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("input with ID %s for transfer has already been spent", inputKey)}
+			return "", 0, nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("input with ID %s for transfer has already been spent", inputKey)}
 		}
 	}
-	return tokenType, inputSum, nil
+	return tokenType, inputSum, metadata, nil
 }
 
 func (v *Verifier) checkInputOwner(creator identity.PublicInfo, input *token.PlainOutput, inputID string) error {
-	if !bytes.Equal(creator.Public(), input.Owner) {
+	if !identity.IsOwner(input.Owner, creator.Public()) {
 		return &customtx.InvalidTxError{Msg: fmt.Sprintf("transfer input with ID %s not owned by creator", inputID)}
 	}
 	return nil
@@ -257,7 +357,7 @@ func (v *Verifier) checkTxDoesNotExist(txID string, simulator ledger.LedgerReade
 		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating txID: %s", err)}
 	}
 
-	existingTx, err := simulator.GetState(tokenNameSpace, txKey)
+	existingTx, err := simulator.GetState(tk.Namespace, txKey)
 	if err != nil {
 		return err
 	}
@@ -293,6 +393,24 @@ func (v *Verifier) commitProcess(txID string, creator identity.PublicInfo, ttx *
 		return err
 	}
 
+	err = v.commitApplicationReference(txID, ttx, simulator)
+	if err != nil {
+		verifierLogger.Errorf("error indexing transaction with txID '%s' by application reference: %s", txID, err)
+		return err
+	}
+
+	err = v.commitImportSeries(txID, creator, ttx, simulator)
+	if err != nil {
+		verifierLogger.Errorf("error recording import series of transaction with txID '%s': %s", txID, err)
+		return err
+	}
+
+	err = v.commitIssueManifest(txID, creator, ttx, simulator)
+	if err != nil {
+		verifierLogger.Errorf("error recording issue manifest of transaction with txID '%s': %s", txID, err)
+		return err
+	}
+
 	verifierLogger.Debugf("action with txID '%s' committed successfully", txID)
 	return nil
 }
@@ -308,6 +426,10 @@ func (v *Verifier) commitAction(plainAction *token.PlainTokenAction, txID string
 		err = v.commitTransferAction(action.PlainRedeem, txID, simulator)
 	case *token.PlainTokenAction_PlainApprove:
 		err = v.commitApproveAction(action.PlainApprove, txID, simulator)
+	case *token.PlainTokenAction_PlainPause:
+		err = v.commitGovernanceAction(action.PlainPause, true, simulator)
+	case *token.PlainTokenAction_PlainResume:
+		err = v.commitGovernanceAction(action.PlainResume, false, simulator)
 	}
 	return
 }
@@ -347,18 +469,54 @@ func (v *Verifier) commitTransferAction(transferAction *token.PlainTransfer, txI
 			return err
 		}
 	}
+	err = v.commitDelegatedSpend(transferAction, simulator)
+	if err != nil {
+		return err
+	}
 	return v.markInputsSpent(txID, transferAction.GetInputs(), simulator)
 }
 
+// commitDelegatedSpend records the quantity spent under each delegation of a delegated transfer
+func (v *Verifier) commitDelegatedSpend(transferAction *token.PlainTransfer, simulator ledger.LedgerWriter) error {
+	chain := transferAction.GetDelegations()
+	if len(chain) == 0 {
+		return nil
+	}
+	owner, err := delegationOwner(chain)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid delegation: %s", err)}
+	}
+	quantity := delegatedQuantity(transferAction.GetOutputs(), owner)
+	for _, sd := range chain {
+		key, err := createDelegationSpentKey(sd)
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating delegation key: %s", err)}
+		}
+		spent, err := getDelegationSpent(key, simulator)
+		if err != nil {
+			return err
+		}
+		err = simulator.SetState(tk.Namespace, key, []byte(strconv.FormatUint(spent+quantity, 10)))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (v *Verifier) checkApproveAction(creator identity.PublicInfo, approveAction *token.PlainApprove, txID string, simulator ledger.LedgerReader) error {
 	outputType, outputSum, err := v.checkApproveOutputs(creator, approveAction.GetOutput(), approveAction.GetDelegatedOutputs(), txID, simulator)
 	if err != nil {
 		return err
 	}
-	inputType, inputSum, err := v.checkTransferInputs(creator, approveAction.GetInputs(), txID, simulator)
+	inputType, inputSum, inputMetadata, err := v.checkTransferInputs(creator, approveAction.GetInputs(), txID, simulator)
 	if err != nil {
 		return err
 	}
+	// delegated outputs have no metadata to carry the metadata of the inputs
+	if len(inputMetadata) != 0 || len(approveAction.GetOutput().GetMetadata()) != 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("tokens with metadata cannot be approved in transaction: %s", txID)}
+	}
 	if outputType != inputType {
 		return &customtx.InvalidTxError{Msg: fmt.Sprintf("token type mismatch in inputs and outputs for approve with ID %s (%s vs %s)", txID, outputType, inputType)}
 	}
@@ -398,7 +556,7 @@ func (v *Verifier) checkApproveOutputs(creator identity.PublicInfo, output *toke
 	// check whether this tx contains an output
 	if output != nil {
 		// check that the owner is not an empty slice
-		if !bytes.Equal(output.Owner, creator.Public()) {
+		if !identity.IsOwner(output.Owner, creator.Public()) {
 			return "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("the owner of the output is not valid")}
 		}
 		tokenType = output.GetType()
@@ -424,7 +582,7 @@ func (v *Verifier) checkApproveOutputs(creator identity.PublicInfo, output *toke
 	// check consistency of delegated outputs
 	for i, delegatedOutput := range delegatedOutputs {
 		// check that delegated outputs have the creator as one of the owners
-		if !bytes.Equal(delegatedOutput.Owner, creator.Public()) {
+		if !identity.IsOwner(delegatedOutput.Owner, creator.Public()) {
 			return "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("the owner of the delegated output is invalid")}
 		}
 		// check consistency of type
@@ -467,13 +625,13 @@ func (v *Verifier) checkApproveOutputs(creator identity.PublicInfo, output *toke
 func (v *Verifier) addOutput(outputID string, output *token.PlainOutput, simulator ledger.LedgerWriter) error {
 	outputBytes := utils.MarshalOrPanic(output)
 
-	return simulator.SetState(tokenNameSpace, outputID, outputBytes)
+	return simulator.SetState(tk.Namespace, outputID, outputBytes)
 }
 
 func (v *Verifier) addDelegatedOutput(outputID string, delegatedOutput *token.PlainDelegatedOutput, simulator ledger.LedgerWriter) error {
 	outputBytes := utils.MarshalOrPanic(delegatedOutput)
 
-	return simulator.SetState(tokenNameSpace, outputID, outputBytes)
+	return simulator.SetState(tk.Namespace, outputID, outputBytes)
 }
 
 func (v *Verifier) addTransaction(txID string, ttx *token.TokenTransaction, simulator ledger.LedgerWriter) error {
@@ -484,7 +642,7 @@ func (v *Verifier) addTransaction(txID string, ttx *token.TokenTransaction, simu
 		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating txID: %s", err)}
 	}
 
-	return simulator.SetState(tokenNameSpace, ttxID, ttxBytes)
+	return simulator.SetState(tk.Namespace, ttxID, ttxBytes)
 }
 
 var TokenInputSpentMarker = []byte{1}
@@ -496,7 +654,7 @@ func (v *Verifier) markInputsSpent(txID string, inputs []*token.InputId, simulat
 			return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating spent key: %s", err)}
 		}
 		verifierLogger.Debugf("marking input '%s' as spent", inputID)
-		err = simulator.SetState(tokenNameSpace, inputID, TokenInputSpentMarker)
+		err = simulator.SetState(tk.Namespace, inputID, TokenInputSpentMarker)
 		if err != nil {
 			return err
 		}
@@ -505,7 +663,7 @@ func (v *Verifier) markInputsSpent(txID string, inputs []*token.InputId, simulat
 }
 
 func (v *Verifier) getOutput(outputID string, simulator ledger.LedgerReader) (*token.PlainOutput, error) {
-	outputBytes, err := simulator.GetState(tokenNameSpace, outputID)
+	outputBytes, err := simulator.GetState(tk.Namespace, outputID)
 	if err != nil {
 		return nil, err
 	}
@@ -526,7 +684,7 @@ func (v *Verifier) getOutput(outputID string, simulator ledger.LedgerReader) (*t
 // isSpent checks whether an output token with identifier outputID has been spent.
 func (v *Verifier) isSpent(spentKey string, simulator ledger.LedgerReader) (bool, error) {
 	verifierLogger.Debugf("checking if input with ID '%s' has been spent", spentKey)
-	result, err := simulator.GetState(tokenNameSpace, spentKey)
+	result, err := simulator.GetState(tk.Namespace, spentKey)
 	return result != nil, err
 }
 
@@ -607,7 +765,7 @@ func (v *Verifier) checkDelegatedOutputDoesNotExist(index int, txID string, simu
 		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID: %s", err)}
 	}
 
-	existingOutputBytes, err := simulator.GetState(tokenNameSpace, outputID)
+	existingOutputBytes, err := simulator.GetState(tk.Namespace, outputID)
 	if err != nil {
 		return err
 	}
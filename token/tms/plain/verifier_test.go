@@ -14,7 +14,9 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/msp"
 	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/identity"
 	mockid "github.com/hyperledger/fabric/token/identity/mock"
 	mockledger "github.com/hyperledger/fabric/token/ledger/mock"
 	"github.com/hyperledger/fabric/token/tms/plain"
@@ -658,6 +660,53 @@ var _ = Describe("Verifier", func() {
 		})
 	})
 
+	Describe("ProcessTx with hashed owners", func() {
+		var (
+			alice, bob          []byte
+			transferTransaction *token.TokenTransaction
+		)
+
+		BeforeEach(func() {
+			var err error
+			alice, err = proto.Marshal(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("alice")})
+			Expect(err).NotTo(HaveOccurred())
+			bob, err = proto.Marshal(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("bob")})
+			Expect(err).NotTo(HaveOccurred())
+			hashedAlice, err := identity.HashOwner(alice)
+			Expect(err).NotTo(HaveOccurred())
+
+			importTransaction.GetPlainAction().GetPlainImport().Outputs[0].Owner = hashedAlice
+			memoryLedger = plain.NewMemoryLedger()
+			err = verifier.ProcessTx(importTxID, fakePublicInfo, importTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			transferTransaction = &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainTransfer{
+							PlainTransfer: &token.PlainTransfer{
+								Inputs:  []*token.InputId{{TxId: "0", Index: 0}},
+								Outputs: []*token.PlainOutput{{Owner: bob, Type: "TOK1", Quantity: 111}},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("lets the owner revealing its identity spend the token", func() {
+			fakePublicInfo.PublicReturns(alice)
+			err := verifier.ProcessTx("1", fakePublicInfo, transferTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects other creators", func() {
+			fakePublicInfo.PublicReturns(bob)
+			err := verifier.ProcessTx("1", fakePublicInfo, transferTransaction, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "transfer input with ID \x00tokenOutput\x000\x000\x00 not owned by creator"}))
+		})
+	})
+
 	Describe("Test ProcessTx PlainRedeem with memory ledger", func() {
 		var (
 			inputIds          []*token.InputId
@@ -0,0 +1,145 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transaction
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/token/ledger"
+	"github.com/pkg/errors"
+)
+
+// processWithinBudget runs process against the simulator and waits for it at most
+// ValidationBudget. When the budget is exceeded and InvalidateOverBudget is set,
+// the simulator is fenced and the transaction is abandoned and reported invalid;
+// otherwise the processing is awaited.
+func (p *Processor) processWithinBudget(lg *flogging.FabricLogger, process func(ledger.LedgerWriter) error, simulator ledger.LedgerWriter) (abandoned bool, err error) {
+	start := time.Now()
+	fenced := &fencedWriter{writer: simulator}
+	// the result is buffered so that an abandoned processing does not block forever
+	result := make(chan error, 1)
+	go func() {
+		result <- process(fenced)
+	}()
+
+	timer := time.NewTimer(p.ValidationBudget)
+	defer timer.Stop()
+	select {
+	case err := <-result:
+		return false, err
+	case <-timer.C:
+	}
+
+	if !p.InvalidateOverBudget {
+		lg.Warningf("processing of token transaction exceeds its budget of %s", p.ValidationBudget)
+		err := <-result
+		lg.Warningf("processing of token transaction completed in %s", time.Since(start))
+		return false, err
+	}
+
+	// the simulator is released as soon as we return, so the abandoned
+	// processing must not use it any more
+	fenced.fence()
+	lg.Errorf("invalidating token transaction: processing exceeds its budget of %s", p.ValidationBudget)
+	return true, &customtx.InvalidTxError{Msg: fmt.Sprintf("processing of transaction exceeds the validation budget of %s", p.ValidationBudget)}
+}
+
+var errFenced = errors.New("ledger access of abandoned transaction")
+
+// fencedWriter forwards calls to a LedgerWriter until it is fenced;
+// calls made afterwards fail with errFenced.
+type fencedWriter struct {
+	mutex     sync.Mutex
+	fenced    bool
+	writer    ledger.LedgerWriter
+	iterators []*fencedIterator
+}
+
+// fence waits for the ongoing call, if any, fences the writer and
+// closes the iterators left open.
+func (f *fencedWriter) fence() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.fenced = true
+	for _, iterator := range f.iterators {
+		if !iterator.closed {
+			iterator.closed = true
+			iterator.iterator.Close()
+		}
+	}
+}
+
+func (f *fencedWriter) GetState(namespace string, key string) ([]byte, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.fenced {
+		return nil, errFenced
+	}
+	return f.writer.GetState(namespace, key)
+}
+
+func (f *fencedWriter) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (commonledger.ResultsIterator, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.fenced {
+		return nil, errFenced
+	}
+	iterator, err := f.writer.GetStateRangeScanIterator(namespace, startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	fencedIterator := &fencedIterator{writer: f, iterator: iterator}
+	f.iterators = append(f.iterators, fencedIterator)
+	return fencedIterator, nil
+}
+
+func (f *fencedWriter) SetState(namespace string, key string, value []byte) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.fenced {
+		return errFenced
+	}
+	return f.writer.SetState(namespace, key, value)
+}
+
+func (f *fencedWriter) Done() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if !f.fenced {
+		f.writer.Done()
+	}
+}
+
+// fencedIterator is an iterator obtained from a fencedWriter, and fenced with it.
+type fencedIterator struct {
+	writer   *fencedWriter
+	iterator commonledger.ResultsIterator
+	closed   bool
+}
+
+func (f *fencedIterator) Next() (commonledger.QueryResult, error) {
+	f.writer.mutex.Lock()
+	defer f.writer.mutex.Unlock()
+	if f.writer.fenced {
+		return nil, errFenced
+	}
+	return f.iterator.Next()
+}
+
+func (f *fencedIterator) Close() {
+	f.writer.mutex.Lock()
+	defer f.writer.mutex.Unlock()
+	if !f.closed {
+		f.closed = true
+		f.iterator.Close()
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/encryption"
+	"github.com/pkg/errors"
+)
+
+//go:generate counterfeiter -o mock/decrypter.go -fake-name Decrypter . Decrypter
+
+// Decrypter decrypts the token transactions encrypted to the committing peers
+type Decrypter interface {
+	// Decrypt returns the token transaction of an encrypted action committed
+	// on a channel. It returns an InvalidTxError if the action is invalid, and
+	// a NotDecryptableError if the action is valid as far as the peer can
+	// tell but the peer cannot decrypt it.
+	Decrypt(channel string, action *token.EncryptedTokenAction) (*token.TokenTransaction, error)
+}
+
+// NotDecryptableError is returned by a Decrypter for an encrypted action that
+// the peer cannot decrypt for reasons of its own, such as not having the
+// private key of the TokenEncryptionKey of its org. The other peers may decrypt
+// the action, so the transaction must not be invalidated because of it.
+type NotDecryptableError struct {
+	Msg string
+}
+
+func (e *NotDecryptableError) Error() string {
+	return e.Msg
+}
+
+// ChannelConfigDecrypter implements a Decrypter with the private key of the
+// TokenEncryptionKey of the org of the peer.
+//
+// So that all the peers agree on their validity, the encrypted transactions
+// are invalidated only on grounds that do not depend on the peer: the checks
+// of encryption.Check, which every peer runs, and the decryption with the key
+// of the org of the peer, which checks the wrapped keys of all the orgs, so
+// that a wrapped key that an org cannot unwrap fails the decryption of the
+// peers of every org.
+type ChannelConfigDecrypter struct {
+	// MSPID is the MSP ID of the org of the peer
+	MSPID string
+	// Key is the private key of the TokenEncryptionKey of the org; without
+	// it, the peer checks the encrypted transactions but cannot decrypt them
+	Key *ecdsa.PrivateKey
+	// ApplicationConfig returns the application config of a channel and
+	// whether it exists.
+	ApplicationConfig func(channel string) (channelconfig.Application, bool)
+}
+
+func (d *ChannelConfigDecrypter) Decrypt(channel string, action *token.EncryptedTokenAction) (*token.TokenTransaction, error) {
+	ac, ok := d.ApplicationConfig(channel)
+	if !ok {
+		return nil, errors.Errorf("no application config found for channel %s", channel)
+	}
+	if !ac.Capabilities().TokenEncryption() {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("token encryption is not enabled on channel %s", channel)}
+	}
+
+	var mspIDs []string
+	keys := map[string]*ecdsa.PublicKey{}
+	for _, org := range ac.Organizations() {
+		if org.TokenEncryptionKey() == nil {
+			return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("org %s has no token encryption key on channel %s", org.MSPID(), channel)}
+		}
+		pk, err := encryption.ParsePublicKey(org.TokenEncryptionKey())
+		if err != nil {
+			return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid token encryption key for org %s on channel %s: %s", org.MSPID(), channel, err)}
+		}
+		mspIDs = append(mspIDs, org.MSPID())
+		keys[org.MSPID()] = pk
+	}
+	if err := encryption.Check(action, mspIDs); err != nil {
+		return nil, &customtx.InvalidTxError{Msg: err.Error()}
+	}
+
+	if d.Key == nil {
+		return nil, &NotDecryptableError{Msg: fmt.Sprintf("the peer has no token encryption key for channel %s", channel)}
+	}
+	pk, ok := keys[d.MSPID]
+	if !ok {
+		return nil, &NotDecryptableError{Msg: fmt.Sprintf("org %s is not an application org of channel %s", d.MSPID, channel)}
+	}
+	if pk.X.Cmp(d.Key.X) != 0 || pk.Y.Cmp(d.Key.Y) != 0 {
+		return nil, &NotDecryptableError{Msg: fmt.Sprintf("the token encryption key of the peer does not match the one of org %s on channel %s", d.MSPID, channel)}
+	}
+
+	ttx, err := encryption.Decrypt(action, d.MSPID, d.Key, keys)
+	if err != nil {
+		return nil, &customtx.InvalidTxError{Msg: err.Error()}
+	}
+	return ttx, nil
+}
@@ -0,0 +1,184 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transaction_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/encryption"
+	"github.com/hyperledger/fabric/token/transaction"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ChannelConfigDecrypter", func() {
+	var (
+		org1Key, org2Key *ecdsa.PrivateKey
+		application      *config.MockApplication
+		capabilities     *config.MockApplicationCapabilities
+		decrypter        *transaction.ChannelConfigDecrypter
+		ttx              *token.TokenTransaction
+		action           *token.EncryptedTokenAction
+	)
+
+	newOrg := func(mspID string, key *ecdsa.PrivateKey) *config.MockApplicationOrg {
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		Expect(err).NotTo(HaveOccurred())
+		return &config.MockApplicationOrg{NameRv: mspID, MSPIDRv: mspID, TokenEncryptionKeyRv: der}
+	}
+
+	BeforeEach(func() {
+		var err error
+		org1Key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		org2Key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+
+		capabilities = &config.MockApplicationCapabilities{TokenEncryptionRv: true}
+		application = &config.MockApplication{
+			CapabilitiesRv: capabilities,
+			OrganizationsRv: map[string]channelconfig.ApplicationOrg{
+				"Org1": newOrg("Org1MSP", org1Key),
+				"Org2": newOrg("Org2MSP", org2Key),
+			},
+		}
+		decrypter = &transaction.ChannelConfigDecrypter{
+			MSPID: "Org1MSP",
+			Key:   org1Key,
+			ApplicationConfig: func(channel string) (channelconfig.Application, bool) {
+				return application, channel == "wild_channel"
+			},
+		}
+
+		ttx = &token.TokenTransaction{
+			Action: &token.TokenTransaction_PlainAction{
+				PlainAction: &token.PlainTokenAction{},
+			},
+			ApplicationReference: []byte("reference"),
+		}
+		action, err = encryption.Encrypt(ttx, map[string]*ecdsa.PublicKey{"Org1MSP": &org1Key.PublicKey, "Org2MSP": &org2Key.PublicKey}, rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("decrypts the transactions encrypted to all the orgs", func() {
+		decrypted, err := decrypter.Decrypt("wild_channel", action)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(proto.Equal(decrypted, ttx)).To(BeTrue())
+	})
+
+	Context("when the channel does not exist", func() {
+		It("returns an error", func() {
+			_, err := decrypter.Decrypt("another_channel", action)
+			Expect(err).To(MatchError("no application config found for channel another_channel"))
+			Expect(err).NotTo(BeAssignableToTypeOf(&customtx.InvalidTxError{}))
+		})
+	})
+
+	Context("when the capability is not enabled", func() {
+		BeforeEach(func() {
+			capabilities.TokenEncryptionRv = false
+		})
+		It("invalidates the transaction", func() {
+			_, err := decrypter.Decrypt("wild_channel", action)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token encryption is not enabled on channel wild_channel"}))
+		})
+	})
+
+	Context("when an org has no token encryption key", func() {
+		BeforeEach(func() {
+			application.OrganizationsRv["Org3"] = &config.MockApplicationOrg{NameRv: "Org3", MSPIDRv: "Org3MSP"}
+		})
+		It("invalidates the transaction", func() {
+			_, err := decrypter.Decrypt("wild_channel", action)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "org Org3MSP has no token encryption key on channel wild_channel"}))
+		})
+	})
+
+	Context("when the transaction is not encrypted to an org", func() {
+		BeforeEach(func() {
+			var err error
+			action, err = encryption.Encrypt(ttx, map[string]*ecdsa.PublicKey{"Org1MSP": &org1Key.PublicKey}, rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("invalidates the transaction", func() {
+			_, err := decrypter.Decrypt("wild_channel", action)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token transaction is not encrypted to org Org2MSP"}))
+		})
+	})
+
+	Context("when the content key is not wrapped for another org", func() {
+		BeforeEach(func() {
+			otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+			action, err = encryption.Encrypt(ttx, map[string]*ecdsa.PublicKey{"Org1MSP": &org1Key.PublicKey, "Org2MSP": &otherKey.PublicKey}, rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("invalidates the transaction on the peers of every org", func() {
+			_, err := decrypter.Decrypt("wild_channel", action)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "content key is not wrapped for org Org2MSP"}))
+
+			decrypter.MSPID = "Org2MSP"
+			decrypter.Key = org2Key
+			_, err = decrypter.Decrypt("wild_channel", action)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "failed decrypting content key for org Org2MSP"}))
+		})
+	})
+
+	Context("when the transaction cannot be decrypted", func() {
+		BeforeEach(func() {
+			action.Ciphertext[0] ^= 1
+		})
+		It("invalidates the transaction", func() {
+			_, err := decrypter.Decrypt("wild_channel", action)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "failed decrypting token transaction"}))
+		})
+	})
+
+	Context("when the peer has no key", func() {
+		BeforeEach(func() {
+			decrypter.Key = nil
+		})
+		It("returns a NotDecryptableError", func() {
+			_, err := decrypter.Decrypt("wild_channel", action)
+			Expect(err).To(Equal(&transaction.NotDecryptableError{Msg: "the peer has no token encryption key for channel wild_channel"}))
+		})
+
+		It("still invalidates the transactions that fail the checks of every peer", func() {
+			action.Recipients = action.Recipients[:1]
+			_, err := decrypter.Decrypt("wild_channel", action)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token transaction is not encrypted to org Org2MSP"}))
+		})
+	})
+
+	Context("when the org of the peer is not an application org", func() {
+		BeforeEach(func() {
+			decrypter.MSPID = "Org3MSP"
+		})
+		It("returns a NotDecryptableError", func() {
+			_, err := decrypter.Decrypt("wild_channel", action)
+			Expect(err).To(Equal(&transaction.NotDecryptableError{Msg: "org Org3MSP is not an application org of channel wild_channel"}))
+		})
+	})
+
+	Context("when the key of the peer does not match the one of its org", func() {
+		BeforeEach(func() {
+			decrypter.Key = org2Key
+		})
+		It("returns a NotDecryptableError", func() {
+			_, err := decrypter.Decrypt("wild_channel", action)
+			Expect(err).To(Equal(&transaction.NotDecryptableError{Msg: "the token encryption key of the peer does not match the one of org Org1MSP on channel wild_channel"}))
+		})
+	})
+})
@@ -8,10 +8,14 @@ package transaction
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/customtx"
 	"github.com/hyperledger/fabric/protos/common"
+	tokenledger "github.com/hyperledger/fabric/token/ledger"
 	"github.com/pkg/errors"
 )
 
@@ -21,15 +25,56 @@ var logger = flogging.MustGetLogger("fabtoken-processor")
 // for FabToken transactions
 type Processor struct {
 	TMSManager TMSManager
+	// ValidationBudget is the maximum time to process a transaction; zero means no limit
+	ValidationBudget time.Duration
+	// InvalidateOverBudget marks the transactions exceeding ValidationBudget as
+	// invalid, without waiting for their processing to complete; otherwise they
+	// are only reported
+	InvalidateOverBudget bool
+	// Decrypter decrypts the token transactions encrypted to the committing
+	// peers. The encrypted transactions that the peer cannot decrypt, e.g.
+	// without a Decrypter, are committed without updating the token state of
+	// the peer, which then cannot serve the tokens of the channel.
+	Decrypter Decrypter
+	// InvalidTxRecorder, if set, records the token transactions found invalid
+	InvalidTxRecorder InvalidTxRecorder
 }
 
 func (p *Processor) GenerateSimulationResults(txEnv *common.Envelope, simulator ledger.TxSimulator, initializingLedger bool) error {
+	return p.GenerateSimulationResultsInBlock(txEnv, 0, simulator, initializingLedger)
+}
+
+// GenerateSimulationResultsInBlock implements the interface 'github.com/hyperledger/fabric/core/ledger/customtx/BlockProcessor'.
+// The block number is passed to the TMSTxProcessors that implement TimedTxProcessor; 0 means that
+// the block is unknown, as the genesis block of a channel holds no token transactions.
+func (p *Processor) GenerateSimulationResultsInBlock(txEnv *common.Envelope, blockNum uint64, simulator ledger.TxSimulator, initializingLedger bool) error {
 	// Extract channel header and token transaction
 	ch, ttx, ci, err := UnmarshalTokenTransaction(txEnv.Payload)
 	if err != nil {
 		return errors.WithMessage(err, "failed unmarshalling token transaction")
 	}
 
+	lg := logger.ForTransaction(ch.ChannelId, ch.TxId, nil)
+	lg.Debugf("processing token transaction")
+
+	if action := ttx.GetEncryptedAction(); action != nil {
+		if p.Decrypter == nil {
+			lg.Errorf("skipping encrypted token transaction: no decrypter configured, the token state of channel %s on this peer is incomplete", ch.ChannelId)
+			return nil
+		}
+		// invalid transaction errors are returned as is, for the ledger to mark the transaction
+		ttx, err = p.Decrypter.Decrypt(ch.ChannelId, action)
+		if _, ok := errors.Cause(err).(*NotDecryptableError); ok {
+			lg.Errorf("skipping encrypted token transaction: %s, the token state of channel %s on this peer is incomplete", err, ch.ChannelId)
+			return nil
+		}
+		if err != nil {
+			lg.Debugf("failed decrypting token transaction: %s", err)
+			p.recordInvalidTx(ch, ReasonDecryption, txEnv, err)
+			return err
+		}
+	}
+
 	// Get a TMSTxProcessor that corresponds to the channel
 	txProcessor, err := p.TMSManager.GetTxProcessor(ch.ChannelId)
 	if err != nil {
@@ -37,10 +82,53 @@ func (p *Processor) GenerateSimulationResults(txEnv *common.Envelope, simulator
 	}
 
 	// Extract the read dependencies and ledger updates associated to the transaction using simulator
-	err = txProcessor.ProcessTx(ch.TxId, ci, ttx, simulator)
+	var process func(simulator tokenledger.LedgerWriter) error
+	if timedTxProcessor, ok := txProcessor.(TimedTxProcessor); ok {
+		var timestamp time.Time
+		if ch.Timestamp != nil {
+			timestamp, err = ptypes.Timestamp(ch.Timestamp)
+			if err != nil {
+				err = &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid transaction timestamp: %s", err)}
+				p.recordInvalidTx(ch, ReasonTimestamp, txEnv, err)
+				return err
+			}
+		}
+		process = func(simulator tokenledger.LedgerWriter) error {
+			return timedTxProcessor.ProcessTxAt(ch.TxId, ci, ttx, blockNum, timestamp, simulator)
+		}
+	} else {
+		process = func(simulator tokenledger.LedgerWriter) error {
+			return txProcessor.ProcessTx(ch.TxId, ci, ttx, simulator)
+		}
+	}
+	if p.ValidationBudget > 0 {
+		var abandoned bool
+		abandoned, err = p.processWithinBudget(lg, process, simulator)
+		if abandoned {
+			// the ledger records the transaction as invalid only if err is an InvalidTxError
+			p.recordInvalidTx(ch, ReasonBudget, txEnv, err)
+			return err
+		}
+	} else {
+		err = process(simulator)
+	}
 	if err != nil {
+		lg.Debugf("token transaction is invalid: %s", err)
+		p.recordInvalidTx(ch, ReasonVerification, txEnv, err)
 		return errors.WithMessage(err, fmt.Sprintf("failed committing transaction for channel %s", ch.ChannelId))
 	}
 
 	return err
 }
+
+// recordInvalidTx records the transaction with the InvalidTxRecorder, if any,
+// when err invalidates it.
+func (p *Processor) recordInvalidTx(ch *common.ChannelHeader, reason string, txEnv *common.Envelope, err error) {
+	if p.InvalidTxRecorder == nil {
+		return
+	}
+	if _, ok := errors.Cause(err).(*customtx.InvalidTxError); !ok {
+		return
+	}
+	p.InvalidTxRecorder.RecordInvalidTx(ch, reason, txEnv, err)
+}
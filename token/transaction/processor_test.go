@@ -6,9 +6,16 @@ SPDX-License-Identifier: Apache-2.0
 package transaction_test
 
 import (
+	"time"
+
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	ledgermock "github.com/hyperledger/fabric/core/chaincode/mock"
+	"github.com/hyperledger/fabric/core/ledger/customtx"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/identity"
+	tokenledger "github.com/hyperledger/fabric/token/ledger"
 	"github.com/hyperledger/fabric/token/transaction"
 	"github.com/hyperledger/fabric/token/transaction/mock"
 	. "github.com/onsi/ginkgo"
@@ -105,6 +112,42 @@ var _ = Describe("Processor", func() {
 			})
 		})
 
+		Context("when the channel TxProcessor finds the transaction invalid", func() {
+			var (
+				verifier     *mock.TMSTxProcessor
+				fakeRecorder *mock.InvalidTxRecorder
+			)
+			BeforeEach(func() {
+				verifier = &mock.TMSTxProcessor{}
+				verifier.ProcessTxReturns(&customtx.InvalidTxError{Msg: "output already exists"})
+				fakeManager.GetTxProcessorReturns(verifier, nil)
+				fakeRecorder = &mock.InvalidTxRecorder{}
+				txProcessor.InvalidTxRecorder = fakeRecorder
+			})
+			It("records the invalid transaction", func() {
+				err := txProcessor.GenerateSimulationResults(validEnvelope, nil, false)
+				Expect(err).To(MatchError("failed committing transaction for channel wild_channel: output already exists"))
+				Expect(fakeRecorder.RecordInvalidTxCallCount()).To(Equal(1))
+				ch, reason, txEnv, err := fakeRecorder.RecordInvalidTxArgsForCall(0)
+				Expect(ch.ChannelId).To(Equal("wild_channel"))
+				Expect(ch.TxId).To(Equal("tx0"))
+				Expect(reason).To(Equal(transaction.ReasonVerification))
+				Expect(txEnv).To(Equal(validEnvelope))
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "output already exists"}))
+			})
+
+			Context("when the processing fails for another reason", func() {
+				BeforeEach(func() {
+					verifier.ProcessTxReturns(errors.New("mock TMSTxProcessor error"))
+				})
+				It("records nothing", func() {
+					err := txProcessor.GenerateSimulationResults(validEnvelope, nil, false)
+					Expect(err).To(HaveOccurred())
+					Expect(fakeRecorder.RecordInvalidTxCallCount()).To(Equal(0))
+				})
+			})
+		})
+
 		Context("when valid input is passed to an existing channel TxProcessor", func() {
 			var (
 				verifier *mock.TMSTxProcessor
@@ -127,6 +170,197 @@ var _ = Describe("Processor", func() {
 				Expect(simulator).To(BeNil())
 			})
 		})
+
+		Context("when the channel TxProcessor takes the transaction timestamp into account", func() {
+			var (
+				verifier  *mock.TimedTxProcessor
+				timestamp time.Time
+			)
+			BeforeEach(func() {
+				verifier = &mock.TimedTxProcessor{}
+				fakeManager.GetTxProcessorReturns(verifier, nil)
+
+				timestamp = time.Date(2018, 11, 1, 10, 30, 0, 0, time.UTC)
+				ts, err := ptypes.TimestampProto(timestamp)
+				Expect(err).NotTo(HaveOccurred())
+				payload := &common.Payload{}
+				err = proto.Unmarshal(validEnvelope.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+				payload.Header.ChannelHeader, err = proto.Marshal(&common.ChannelHeader{
+					Type: int32(common.HeaderType_TOKEN_TRANSACTION), ChannelId: "wild_channel",
+					TxId: "tx0", Timestamp: ts,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				validEnvelope.Payload, err = proto.Marshal(payload)
+				Expect(err).NotTo(HaveOccurred())
+			})
+			It("passes the timestamp", func() {
+				err := txProcessor.GenerateSimulationResults(validEnvelope, nil, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(verifier.ProcessTxCallCount()).To(Equal(0))
+				Expect(verifier.ProcessTxAtCallCount()).To(Equal(1))
+				txID, _, ttx, blockNum, ts, _ := verifier.ProcessTxAtArgsForCall(0)
+				Expect(txID).To(Equal("tx0"))
+				Expect(proto.Equal(ttx, validTtx)).To(BeTrue())
+				Expect(blockNum).To(Equal(uint64(0)))
+				Expect(ts.Equal(timestamp)).To(BeTrue())
+			})
+
+			It("passes the block number", func() {
+				err := txProcessor.GenerateSimulationResultsInBlock(validEnvelope, 42, nil, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(verifier.ProcessTxAtCallCount()).To(Equal(1))
+				_, _, _, blockNum, ts, _ := verifier.ProcessTxAtArgsForCall(0)
+				Expect(blockNum).To(Equal(uint64(42)))
+				Expect(ts.Equal(timestamp)).To(BeTrue())
+			})
+		})
+
+		Context("when the token transaction is encrypted", func() {
+			var (
+				verifier      *mock.TMSTxProcessor
+				fakeDecrypter *mock.Decrypter
+				encryptedTtx  *token.TokenTransaction
+			)
+			BeforeEach(func() {
+				verifier = &mock.TMSTxProcessor{}
+				fakeManager.GetTxProcessorReturns(verifier, nil)
+				fakeDecrypter = &mock.Decrypter{}
+				fakeDecrypter.DecryptReturns(validTtx, nil)
+				txProcessor.Decrypter = fakeDecrypter
+
+				encryptedTtx = &token.TokenTransaction{
+					Action: &token.TokenTransaction_EncryptedAction{
+						EncryptedAction: &token.EncryptedTokenAction{Ciphertext: []byte("ciphertext")},
+					},
+				}
+				payload := &common.Payload{}
+				err := proto.Unmarshal(validEnvelope.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+				payload.Data, err = proto.Marshal(encryptedTtx)
+				Expect(err).NotTo(HaveOccurred())
+				validEnvelope.Payload, err = proto.Marshal(payload)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("processes the decrypted transaction", func() {
+				err := txProcessor.GenerateSimulationResults(validEnvelope, nil, false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeDecrypter.DecryptCallCount()).To(Equal(1))
+				channel, action := fakeDecrypter.DecryptArgsForCall(0)
+				Expect(channel).To(Equal("wild_channel"))
+				Expect(proto.Equal(action, encryptedTtx.GetEncryptedAction())).To(BeTrue())
+				Expect(verifier.ProcessTxCallCount()).To(Equal(1))
+				_, _, ttx, _ := verifier.ProcessTxArgsForCall(0)
+				Expect(proto.Equal(ttx, validTtx)).To(BeTrue())
+			})
+
+			Context("when the transaction cannot be decrypted", func() {
+				BeforeEach(func() {
+					fakeDecrypter.DecryptReturns(nil, &customtx.InvalidTxError{Msg: "failed decrypting token transaction"})
+				})
+				It("returns the invalid transaction error as is", func() {
+					err := txProcessor.GenerateSimulationResults(validEnvelope, nil, false)
+					Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "failed decrypting token transaction"}))
+					Expect(verifier.ProcessTxCallCount()).To(Equal(0))
+				})
+
+				It("records the invalid transaction", func() {
+					fakeRecorder := &mock.InvalidTxRecorder{}
+					txProcessor.InvalidTxRecorder = fakeRecorder
+					txProcessor.GenerateSimulationResults(validEnvelope, nil, false)
+					Expect(fakeRecorder.RecordInvalidTxCallCount()).To(Equal(1))
+					_, reason, _, _ := fakeRecorder.RecordInvalidTxArgsForCall(0)
+					Expect(reason).To(Equal(transaction.ReasonDecryption))
+				})
+			})
+
+			Context("when the peer cannot decrypt the transaction", func() {
+				BeforeEach(func() {
+					fakeDecrypter.DecryptReturns(nil, &transaction.NotDecryptableError{Msg: "the peer has no token encryption key for channel wild_channel"})
+				})
+				It("commits the transaction without processing it", func() {
+					fakeRecorder := &mock.InvalidTxRecorder{}
+					txProcessor.InvalidTxRecorder = fakeRecorder
+					err := txProcessor.GenerateSimulationResults(validEnvelope, nil, false)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(verifier.ProcessTxCallCount()).To(Equal(0))
+					Expect(fakeRecorder.RecordInvalidTxCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when no decrypter is configured", func() {
+				BeforeEach(func() {
+					txProcessor.Decrypter = nil
+				})
+				It("commits the transaction without processing it", func() {
+					err := txProcessor.GenerateSimulationResults(validEnvelope, nil, false)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(verifier.ProcessTxCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when the processing of the transaction has a budget", func() {
+			var (
+				verifier      *mock.TMSTxProcessor
+				fakeSimulator *ledgermock.TxSimulator
+				release       chan struct{}
+				setStateErr   chan error
+			)
+			BeforeEach(func() {
+				release = make(chan struct{})
+				setStateErr = make(chan error, 1)
+				fakeSimulator = &ledgermock.TxSimulator{}
+				verifier = &mock.TMSTxProcessor{}
+				verifier.ProcessTxStub = func(txID string, creator identity.PublicInfo, ttx *token.TokenTransaction, simulator tokenledger.LedgerWriter) error {
+					<-release
+					setStateErr <- simulator.SetState("tms", "key", []byte("value"))
+					return nil
+				}
+				fakeManager.GetTxProcessorReturns(verifier, nil)
+				txProcessor.ValidationBudget = 10 * time.Millisecond
+			})
+			AfterEach(func() {
+				close(release)
+			})
+
+			It("waits for the processing exceeding the budget", func() {
+				time.AfterFunc(50*time.Millisecond, func() { release <- struct{}{} })
+				err := txProcessor.GenerateSimulationResults(validEnvelope, fakeSimulator, false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(<-setStateErr).NotTo(HaveOccurred())
+				Expect(fakeSimulator.SetStateCallCount()).To(Equal(1))
+			})
+
+			Context("and transactions exceeding it are invalidated", func() {
+				BeforeEach(func() {
+					txProcessor.InvalidateOverBudget = true
+				})
+
+				It("invalidates the transaction and fences the simulator", func() {
+					fakeRecorder := &mock.InvalidTxRecorder{}
+					txProcessor.InvalidTxRecorder = fakeRecorder
+					err := txProcessor.GenerateSimulationResults(validEnvelope, fakeSimulator, false)
+					Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "processing of transaction exceeds the validation budget of 10ms"}))
+					Expect(fakeRecorder.RecordInvalidTxCallCount()).To(Equal(1))
+					_, reason, _, _ := fakeRecorder.RecordInvalidTxArgsForCall(0)
+					Expect(reason).To(Equal(transaction.ReasonBudget))
+
+					release <- struct{}{}
+					Eventually(setStateErr).Should(Receive(MatchError("ledger access of abandoned transaction")))
+					Expect(fakeSimulator.SetStateCallCount()).To(Equal(0))
+				})
+
+				It("succeeds when the processing is within the budget", func() {
+					txProcessor.ValidationBudget = time.Minute
+					go func() { release <- struct{}{} }()
+					err := txProcessor.GenerateSimulationResults(validEnvelope, fakeSimulator, false)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeSimulator.SetStateCallCount()).To(Equal(1))
+				})
+			})
+		})
 	})
 
 })
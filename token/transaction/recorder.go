@@ -0,0 +1,44 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transaction
+
+import (
+	"github.com/hyperledger/fabric/protos/common"
+)
+
+//go:generate counterfeiter -o mock/invalid_tx_recorder.go -fake-name InvalidTxRecorder . InvalidTxRecorder
+
+// The reasons token transactions are found invalid, by stage of their processing
+const (
+	// ReasonDecryption is the reason of the encrypted transactions that cannot be decrypted
+	ReasonDecryption = "decryption"
+	// ReasonTimestamp is the reason of the transactions with a malformed timestamp
+	ReasonTimestamp = "timestamp"
+	// ReasonBudget is the reason of the transactions exceeding the validation budget
+	ReasonBudget = "budget"
+	// ReasonVerification is the reason of the transactions rejected by the TMS
+	ReasonVerification = "verification"
+)
+
+// InvalidTxRecorder records the token transactions found invalid at commit,
+// so that they can be inspected afterwards.
+type InvalidTxRecorder interface {
+	// RecordInvalidTx records the transaction of the envelope, with the channel
+	// header, found invalid for the reason with the error.
+	RecordInvalidTx(ch *common.ChannelHeader, reason string, txEnv *common.Envelope, err error)
+}
+
+// InvalidTxRecorders records the invalid token transactions with each of the
+// recorders, in order.
+type InvalidTxRecorders []InvalidTxRecorder
+
+// RecordInvalidTx records the transaction with each of the recorders.
+func (r InvalidTxRecorders) RecordInvalidTx(ch *common.ChannelHeader, reason string, txEnv *common.Envelope, err error) {
+	for _, recorder := range r {
+		recorder.RecordInvalidTx(ch, reason, txEnv, err)
+	}
+}
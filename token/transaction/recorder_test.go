@@ -0,0 +1,37 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transaction_test
+
+import (
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/token/transaction"
+	"github.com/hyperledger/fabric/token/transaction/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("InvalidTxRecorders", func() {
+	It("records the transaction with each of the recorders", func() {
+		first := &mock.InvalidTxRecorder{}
+		second := &mock.InvalidTxRecorder{}
+		recorders := transaction.InvalidTxRecorders{first, second}
+
+		ch := &common.ChannelHeader{ChannelId: "channel-1", TxId: "tx0"}
+		txEnv := &common.Envelope{Payload: []byte("payload")}
+		recorders.RecordInvalidTx(ch, transaction.ReasonVerification, txEnv, errors.New("boom"))
+
+		for _, recorder := range []*mock.InvalidTxRecorder{first, second} {
+			Expect(recorder.RecordInvalidTxCallCount()).To(Equal(1))
+			recordedCh, reason, recordedEnv, err := recorder.RecordInvalidTxArgsForCall(0)
+			Expect(recordedCh).To(Equal(ch))
+			Expect(reason).To(Equal(transaction.ReasonVerification))
+			Expect(recordedEnv).To(Equal(txEnv))
+			Expect(err).To(MatchError("boom"))
+		}
+	})
+})
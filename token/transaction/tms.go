@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package transaction
 
 import (
+	"time"
+
 	"github.com/hyperledger/fabric/protos/token"
 	"github.com/hyperledger/fabric/token/identity"
 	"github.com/hyperledger/fabric/token/ledger"
@@ -14,6 +16,7 @@ import (
 
 //go:generate counterfeiter -o mock/tms_tx_processor.go -fake-name TMSTxProcessor . TMSTxProcessor
 //go:generate counterfeiter -o mock/tms_manager.go -fake-name TMSManager . TMSManager
+//go:generate counterfeiter -o mock/timed_tx_processor.go -fake-name TimedTxProcessor . TimedTxProcessor
 
 // TMSTxProcessor is used to generate the read-dependencies of a token transaction
 // (read-set) along with the ledger updates triggered by that transaction
@@ -24,6 +27,16 @@ type TMSTxProcessor interface {
 	ProcessTx(txID string, creator identity.PublicInfo, ttx *token.TokenTransaction, simulator ledger.LedgerWriter) error
 }
 
+// A TimedTxProcessor is a TMSTxProcessor that also takes into account the block
+// a transaction is committed in and the time it was created at, as recorded in
+// its channel header.
+type TimedTxProcessor interface {
+	TMSTxProcessor
+	// ProcessTxAt parses ttx, committed in block blockNum and created at
+	// timestamp, to generate a RW set; blockNum is 0 when the block is unknown
+	ProcessTxAt(txID string, creator identity.PublicInfo, ttx *token.TokenTransaction, blockNum uint64, timestamp time.Time, simulator ledger.LedgerWriter) error
+}
+
 type TMSManager interface {
 	// GetTxProcessor returns a TxProcessor for TMS transactions for the provided channel
 	GetTxProcessor(channel string) (TMSTxProcessor, error)